@@ -11,6 +11,8 @@ import (
 
 	"github.com/RHEnVision/provisioning-backend/internal/background"
 	"github.com/RHEnVision/provisioning-backend/internal/cache"
+	"github.com/RHEnVision/provisioning-backend/internal/chaos"
+	"github.com/RHEnVision/provisioning-backend/internal/clients/fake"
 	"github.com/RHEnVision/provisioning-backend/internal/config"
 	"github.com/RHEnVision/provisioning-backend/internal/db"
 	"github.com/RHEnVision/provisioning-backend/internal/kafka"
@@ -38,6 +40,9 @@ func api() {
 	defer closeFunc()
 	logging.DumpConfigForDevelopment()
 
+	// in-memory cloud clients for development without credentials
+	fake.Initialize(logger.WithContext(ctx))
+
 	// initialize feature flags
 	err := config.InitializeFeatureFlags(ctx)
 	if err != nil {
@@ -62,14 +67,19 @@ func api() {
 	}
 	defer db.Close()
 
+	// listen for reservation status changes before long-polling requests arrive
+	if !config.Database.PgBouncer {
+		db.StartListener(db.ReservationStatusChannel)
+	}
+
 	// initialize cache
 	cache.Initialize()
 
-	// initialize platform kafka and notifications
-	if config.Kafka.Enabled {
-		err = kafka.InitializeKafkaBroker(ctx)
+	// initialize message bus and notifications
+	if config.BusEnabled() {
+		err = kafka.Initialize(ctx)
 		if err != nil {
-			logger.Fatal().Err(err).Msg("Unable to initialize the platform kafka")
+			logger.Fatal().Err(err).Msg("Unable to initialize the message bus")
 		}
 
 		if config.Application.Notifications.Enabled {
@@ -90,8 +100,12 @@ func api() {
 	jq.RegisterJobs(&logger)
 	if config.Worker.Queue == "memory" {
 		jq.StartDequeueLoop(ctx)
+		background.InitializeWorker(bgCtx)
 	}
 
+	// prefetch hot cache entries before accepting requests
+	warmUp(logger.WithContext(ctx))
+
 	// Setup routes
 	rootRouter := chi.NewRouter()
 	apiRouter := chi.NewRouter()
@@ -102,6 +116,13 @@ func api() {
 	apiRouter.Use(m.CorrelationID)
 	apiRouter.Use(m.TraceID)
 	apiRouter.Use(m.LoggerMiddleware(&log.Logger))
+	apiRouter.Use(m.RequestTimeout)
+	apiRouter.Use(m.CORS)
+	apiRouter.Use(m.HeadAndOptions(apiRouter))
+	if chaos.Enabled() {
+		log.Warn().Msg("Chaos fault injection is enabled")
+		apiRouter.Use(m.ChaosMiddleware(chaos.ServerRules(ctx)))
+	}
 
 	// Mount paths
 	routes.MountRoot(rootRouter)
@@ -112,6 +133,9 @@ func api() {
 	metricsRouter := chi.NewRouter()
 	metricsRouter.Get("/", s.WelcomeService)
 	metricsRouter.Handle(config.Prometheus.Path, promhttp.Handler())
+	if config.Admin.Token != "" {
+		routes.MountAdmin(metricsRouter, config.Admin.Token)
+	}
 
 	log.Info().Msgf("Starting new instance on port %d with prometheus on %d", config.Application.Port, config.Prometheus.Port)
 	apiServer := http.Server{
@@ -157,6 +181,9 @@ func api() {
 
 	<-waitForSignal
 
+	bgCancel()
+	background.StopApi(logger.WithContext(ctx))
+
 	if config.Worker.Queue == "memory" {
 		jq.StopDequeueLoop(ctx)
 	}
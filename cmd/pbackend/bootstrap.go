@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+
+	"github.com/RHEnVision/provisioning-backend/internal/cache"
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/db"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/RHEnVision/provisioning-backend/internal/logging"
+	"github.com/RHEnVision/provisioning-backend/internal/migrations"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// bootstrapEphemeral prepares a fresh ephemeral (or development) environment: it migrates the
+// database, seeds an account, creates provisioning sources, warms the cache and verifies all
+// backend services are reachable. It can be executed repeatedly, existing data is kept.
+func bootstrapEphemeral() {
+	ctx := context.Background()
+	config.Initialize("config/api.env", "config/bootstrap.env")
+
+	logging.InitializeStdout()
+	logging.DumpConfigForDevelopment()
+	logger := log.Logger
+	ctx = logger.WithContext(ctx)
+
+	flags := flag.NewFlagSet("bootstrap-ephemeral", flag.ExitOnError)
+	orgID := flags.String("org-id", "000013", "organization ID of the seeded account")
+	accountNumber := flags.String("account", "13", "EBS account number of the seeded account")
+	arn := flags.String("aws-arn", os.Getenv("ARN_ROLE"), "AWS role ARN, AWS source is not created when blank")
+	subscription := flags.String("azure-subscription", os.Getenv("SUBSCRIPTION_ID"), "Azure subscription ID, Azure source is not created when blank")
+	project := flags.String("gcp-project", os.Getenv("PROJECT_ID"), "GCP project ID, GCP source is not created when blank")
+	_ = flags.Parse(os.Args[2:])
+
+	if config.InClowder() && !config.InEphemeralClowder() {
+		logger.Fatal().Msgf("Bootstrap is only allowed in ephemeral environment, current: %s", config.Environment())
+	}
+
+	err := db.Initialize(ctx, "public")
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Error initializing database")
+	}
+	defer db.Close()
+	cache.Initialize()
+
+	err = migrations.Migrate(ctx, "public")
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Error running migration")
+	}
+
+	// seed the account and store it in the cache the same way the account middleware does
+	ctx = identity.WithIdentity(ctx, newPrincipal(*orgID, *accountNumber))
+	account, err := dao.GetAccountDao(ctx).GetOrCreateByIdentity(ctx, *orgID, *accountNumber)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Unable to seed account")
+	}
+	ctx = identity.WithAccountId(ctx, account.ID)
+	err = cache.Set(ctx, *orgID+*accountNumber, account)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Unable to store account to cache")
+	}
+	logger.Info().Msgf("Account %d (org_id %s, account %s) is ready", account.ID, *orgID, *accountNumber)
+
+	if !verifyConnectivity(ctx) {
+		logger.Fatal().Msg("Backend services are not reachable, see errors above")
+	}
+
+	sourcesClient, err := clients.GetSourcesClient(ctx)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Unable to initialize sources client")
+	}
+
+	// the provisioning application type must be registered in sources to create applications
+	appTypeId, err := sourcesClient.GetProvisioningTypeId(ctx)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Provisioning application type not found in sources")
+	}
+	logger.Info().Msgf("Provisioning application type ID is %s", appTypeId)
+
+	ensureSource(ctx, sourcesClient, models.ProviderTypeAWS, *arn)
+	ensureSource(ctx, sourcesClient, models.ProviderTypeAzure, *subscription)
+	ensureSource(ctx, sourcesClient, models.ProviderTypeGCP, *project)
+
+	logger.Info().Msg("Bootstrap finished")
+}
+
+func newPrincipal(orgID, accountNumber string) identity.Principal {
+	id := identity.Principal{}
+	id.Identity.OrgID = orgID
+	id.Identity.AccountNumber = accountNumber
+	id.Identity.Internal.OrgID = orgID
+	id.Identity.Type = "User"
+	return id
+}
+
+// ensureSource creates a provisioning source for the provider unless the authentication is blank
+// or a source with the same name already exists.
+func ensureSource(ctx context.Context, sourcesClient clients.Sources, provider models.ProviderType, authentication string) {
+	logger := zerolog.Ctx(ctx)
+	if authentication == "" {
+		logger.Info().Msgf("No authentication provided for %s, skipping source", provider.String())
+		return
+	}
+
+	name := "Provisioning " + provider.String() + " source"
+	existing, err := sourcesClient.ListProvisioningSourcesByProvider(ctx, provider)
+	if err != nil {
+		logger.Fatal().Err(err).Msgf("Unable to list %s sources", provider.String())
+	}
+	for _, source := range existing {
+		if source.Name == name {
+			logger.Info().Msgf("Source '%s' already exists with ID %s", name, source.ID)
+			return
+		}
+	}
+
+	err = sourcesClient.CreateProvisioningSource(ctx, provider, name, authentication)
+	if err != nil {
+		logger.Fatal().Err(err).Msgf("Unable to create source '%s'", name)
+	}
+	logger.Info().Msgf("Created source '%s'", name)
+}
+
+// verifyConnectivity checks all backend services and logs every failure, it returns false when
+// at least one of them is not reachable.
+func verifyConnectivity(ctx context.Context) bool {
+	logger := zerolog.Ctx(ctx)
+	ok := true
+
+	if err := db.Pool.Ping(ctx); err != nil {
+		logger.Error().Err(err).Msg("Database is not reachable")
+		ok = false
+	}
+
+	if err := clients.GetRbacClient(ctx).Ready(ctx); err != nil {
+		logger.Error().Err(err).Msg("RBAC is not ready")
+		ok = false
+	}
+
+	if sourcesClient, err := clients.GetSourcesClient(ctx); err != nil {
+		logger.Error().Err(err).Msg("Unable to initialize sources client")
+		ok = false
+	} else if err = sourcesClient.Ready(ctx); err != nil {
+		logger.Error().Err(err).Msg("Sources is not ready")
+		ok = false
+	}
+
+	if ibClient, err := clients.GetImageBuilderClient(ctx); err != nil {
+		logger.Error().Err(err).Msg("Unable to initialize image builder client")
+		ok = false
+	} else if err = ibClient.Ready(ctx); err != nil {
+		logger.Error().Err(err).Msg("Image builder is not ready")
+		ok = false
+	}
+
+	if ok {
+		logger.Info().Msg("All backend services are reachable")
+	}
+	return ok
+}
@@ -13,6 +13,7 @@ import (
 	_ "github.com/RHEnVision/provisioning-backend/internal/clients/http/ec2"
 	_ "github.com/RHEnVision/provisioning-backend/internal/clients/http/gcp"
 	_ "github.com/RHEnVision/provisioning-backend/internal/clients/http/image_builder"
+	_ "github.com/RHEnVision/provisioning-backend/internal/clients/http/s3"
 	_ "github.com/RHEnVision/provisioning-backend/internal/clients/http/sources"
 
 	// RBAC
@@ -42,6 +43,12 @@ func main() {
 		statuser()
 	case "stats":
 		stats()
+	case "replay":
+		replay()
+	case "reencrypt":
+		reencrypt()
+	case "bootstrap-ephemeral":
+		bootstrapEphemeral()
 	case "version":
 		ver()
 	default:
@@ -50,7 +57,7 @@ func main() {
 }
 
 func usage() {
-	fmt.Println("Usage: pbackend [migrate|api|worker|statuser|stats|version]")
+	fmt.Println("Usage: pbackend [migrate|api|worker|statuser|stats|replay|reencrypt|bootstrap-ephemeral|version]")
 	os.Exit(1)
 }
 
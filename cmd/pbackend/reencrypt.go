@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/db"
+	"github.com/RHEnVision/provisioning-backend/internal/encryption"
+	"github.com/RHEnVision/provisioning-backend/internal/logging"
+	"github.com/rs/zerolog/log"
+)
+
+// reencrypt encrypts all user submitted material stored in plain text or with a previous data key
+// after column encryption was enabled or the active key was rotated. It is safe to run while the
+// application is running and can be repeated, the previous key can be removed once it finishes.
+func reencrypt() {
+	ctx := context.Background()
+	config.Initialize("config/api.env", "config/migrate.env")
+
+	logging.InitializeStdout()
+	logger := log.Logger
+	ctx = logger.WithContext(ctx)
+
+	err := db.Initialize(ctx, "public")
+	if err != nil {
+		log.Fatal().Err(err).Msg("Error initializing database")
+	}
+	defer db.Close()
+
+	if !encryption.Enabled() {
+		logger.Warn().Msg("Column encryption is not enabled, configure DATABASE_ENCRYPTION_KEYS first")
+		return
+	}
+
+	serviceDao := dao.GetServiceDao(ctx)
+	count, err := serviceDao.ReencryptPubkeys(ctx)
+	if err != nil {
+		logger.Fatal().Err(err).Msgf("Error re-encrypting pubkeys, %d records were updated", count)
+	}
+	logger.Info().Msgf("Total number of re-encrypted pubkey records: %d", count)
+
+	count, err = serviceDao.ReencryptUserData(ctx)
+	if err != nil {
+		logger.Fatal().Err(err).Msgf("Error re-encrypting reservation and job user data, %d records were updated", count)
+	}
+	logger.Info().Msgf("Total number of re-encrypted reservation and job user data records: %d", count)
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/RHEnVision/provisioning-backend/internal/cache"
+	"github.com/RHEnVision/provisioning-backend/internal/clients/fake"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/db"
+	"github.com/RHEnVision/provisioning-backend/internal/jobs"
+	"github.com/RHEnVision/provisioning-backend/internal/kafka"
+	"github.com/RHEnVision/provisioning-backend/internal/logging"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/notifications"
+	"github.com/RHEnVision/provisioning-backend/internal/queue/jq"
+	"github.com/rs/zerolog/log"
+)
+
+// replay re-executes the stored job of a reservation in this process with the current code. It is
+// meant for debugging provider issues, the reservation is reset and its status is updated as usual.
+func replay() {
+	flags := flag.NewFlagSet("replay", flag.ExitOnError)
+	dryRun := flags.Bool("dry-run", false, "decode and print the stored job without executing it")
+	force := flags.Bool("force", false, "replay also successful reservations (launches instances again)")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: pbackend replay [-dry-run] [-force] RESERVATION_ID")
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(os.Args[2:])
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(1)
+	}
+	id, err := strconv.ParseInt(flags.Arg(0), 10, 64)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid reservation ID '%s'\n", flags.Arg(0))
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	config.Initialize("config/api.env", "config/worker.env")
+
+	logging.InitializeStdout()
+	logger, closeFunc := logging.InitializeLogger()
+	defer closeFunc()
+	ctx = logger.WithContext(ctx)
+
+	// in-memory cloud clients for development without credentials
+	fake.Initialize(ctx)
+
+	cache.Initialize()
+
+	err = db.Initialize(ctx, "public")
+	if err != nil {
+		log.Fatal().Err(err).Msg("Error initializing database")
+	}
+	defer db.Close()
+
+	rDao := dao.GetReservationDao(ctx)
+	reservation, err := rDao.UnscopedGetById(ctx, id)
+	if err != nil {
+		log.Fatal().Err(err).Msgf("Unable to find reservation %d", id)
+	}
+	stored, err := rDao.UnscopedGetJob(ctx, id)
+	if err != nil {
+		log.Fatal().Err(err).Msgf("Unable to find stored job of reservation %d", id)
+	}
+	job, err := jobs.UnmarshalJob(stored.Job)
+	if err != nil {
+		log.Fatal().Err(err).Msgf("Unable to decode stored job of reservation %d", id)
+	}
+
+	printReservation("Reservation", reservation)
+	args, _ := json.MarshalIndent(job.Args, "", "  ")
+	fmt.Printf("Job %s enqueued %s for account %d (org %s):\n%s\n", job.Type, stored.CreatedAt.Format("2006-01-02 15:04:05"),
+		job.AccountID, job.Identity.Identity.OrgID, args)
+
+	if *dryRun {
+		return
+	}
+	if !reservation.FinishedAt.Valid {
+		fmt.Fprintln(os.Stderr, "Reservation is still processing, wait until it finishes")
+		os.Exit(1)
+	}
+	if reservation.Success.Valid && reservation.Success.Bool && !*force {
+		fmt.Fprintln(os.Stderr, "Reservation finished successfully, use -force to launch instances again")
+		os.Exit(1)
+	}
+
+	// initialize message bus and notifications
+	if config.BusEnabled() {
+		err = kafka.Initialize(ctx)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Unable to initialize the message bus")
+		}
+
+		if config.Application.Notifications.Enabled {
+			notifications.Initialize(ctx)
+		}
+	}
+
+	err = rDao.UnscopedRequeue(ctx, id)
+	if err != nil {
+		log.Fatal().Err(err).Msgf("Unable to reset reservation %d", id)
+	}
+
+	logger.Warn().Int64("reservation_id", id).Msgf("Replaying reservation job %s", job.Type)
+	err = jq.Replay(ctx, job)
+	if err != nil {
+		log.Fatal().Err(err).Msgf("Unable to replay reservation %d", id)
+	}
+
+	reservation, err = rDao.UnscopedGetById(ctx, id)
+	if err != nil {
+		log.Fatal().Err(err).Msgf("Unable to find reservation %d", id)
+	}
+	printReservation("Result", reservation)
+	if !reservation.Success.Valid || !reservation.Success.Bool {
+		os.Exit(1)
+	}
+}
+
+func printReservation(title string, r *models.Reservation) {
+	result := "pending"
+	if r.Success.Valid && r.Success.Bool {
+		result = "success"
+	} else if r.Success.Valid {
+		result = "failed: " + r.Error
+	}
+	fmt.Printf("%s %d (account %d, provider %d) step %d/%d: %s [%s]\n", title, r.ID, r.AccountID, r.Provider,
+		r.Step, r.Steps, r.Status, result)
+}
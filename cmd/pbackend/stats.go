@@ -10,15 +10,27 @@ import (
 	"syscall"
 
 	"github.com/RHEnVision/provisioning-backend/internal/background"
+	"github.com/RHEnVision/provisioning-backend/internal/billing"
+	"github.com/RHEnVision/provisioning-backend/internal/cache"
+	"github.com/RHEnVision/provisioning-backend/internal/clients/fake"
 	"github.com/RHEnVision/provisioning-backend/internal/config"
 	"github.com/RHEnVision/provisioning-backend/internal/db"
+	"github.com/RHEnVision/provisioning-backend/internal/kafka"
 	"github.com/RHEnVision/provisioning-backend/internal/logging"
 	"github.com/RHEnVision/provisioning-backend/internal/metrics"
+	"github.com/RHEnVision/provisioning-backend/internal/notifications"
 	"github.com/RHEnVision/provisioning-backend/internal/queue/jq"
 	"github.com/RHEnVision/provisioning-backend/internal/telemetry"
 	"github.com/go-chi/chi/v5"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/zerolog/log"
+
+	// Clients for the orphan detection and reservation archival
+	_ "github.com/RHEnVision/provisioning-backend/internal/clients/http/azure"
+	_ "github.com/RHEnVision/provisioning-backend/internal/clients/http/ec2"
+	_ "github.com/RHEnVision/provisioning-backend/internal/clients/http/gcp"
+	_ "github.com/RHEnVision/provisioning-backend/internal/clients/http/s3"
+	_ "github.com/RHEnVision/provisioning-backend/internal/clients/http/sources"
 )
 
 func stats() {
@@ -30,10 +42,32 @@ func stats() {
 	defer closeFunc()
 	logging.DumpConfigForDevelopment()
 
+	// in-memory cloud clients for development without credentials
+	fake.Initialize(logger.WithContext(ctx))
+
 	// initialize telemetry
 	tel := telemetry.Initialize(&log.Logger)
 	defer tel.Close(ctx)
 
+	// initialize message bus for notifications of orphaned instances, org purges and usage records
+	if config.BusEnabled() && (config.Application.Notifications.Enabled || config.Application.TenantPurge || config.Application.Usage.Enabled) {
+		err := kafka.Initialize(ctx)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Unable to initialize the message bus")
+		}
+
+		if config.Application.Notifications.Enabled {
+			notifications.Initialize(ctx)
+		}
+
+		if config.Application.Usage.Enabled {
+			billing.Initialize(ctx)
+		}
+	}
+
+	// initialize cache, purged orgs are removed from it
+	cache.Initialize()
+
 	// initialize the job queue but don't register any workers
 	err := jq.Initialize(ctx, &logger)
 	if err != nil {
@@ -11,6 +11,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/RHEnVision/provisioning-backend/internal/cache"
 	"github.com/RHEnVision/provisioning-backend/internal/clients"
 	"github.com/RHEnVision/provisioning-backend/internal/db"
 	"github.com/RHEnVision/provisioning-backend/internal/models"
@@ -25,6 +26,7 @@ import (
 	_ "github.com/RHEnVision/provisioning-backend/internal/clients/http/image_builder"
 	_ "github.com/RHEnVision/provisioning-backend/internal/clients/http/sources"
 
+	"github.com/RHEnVision/provisioning-backend/internal/clients/fake"
 	"github.com/RHEnVision/provisioning-backend/internal/config"
 	"github.com/RHEnVision/provisioning-backend/internal/kafka"
 	"github.com/RHEnVision/provisioning-backend/internal/metrics"
@@ -42,9 +44,37 @@ const ChannelBuffer = 32
 type SourceInfo struct {
 	MessageContext      context.Context // Carries logger and identity
 	Authentication      clients.Authentication
+	SourceID            string
 	SourceApplicationID string
 }
 
+// inFlightSources are sources with a check in progress, more requests for them are skipped until
+// the check finishes. Messages are keyed by source ID, so all requests of a source are consumed by
+// the same statuser replica.
+type inFlightSources struct {
+	ids map[string]struct{}
+	m   sync.Mutex
+}
+
+// acquire returns false when a check of the source is already in progress.
+func (f *inFlightSources) acquire(sourceID string) bool {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	if _, ok := f.ids[sourceID]; ok {
+		return false
+	}
+	f.ids[sourceID] = struct{}{}
+	return true
+}
+
+func (f *inFlightSources) release(sourceID string) {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	delete(f.ids, sourceID)
+}
+
 var (
 	chAws        = make(chan SourceInfo, ChannelBuffer)
 	chAzure      = make(chan SourceInfo, ChannelBuffer)
@@ -53,6 +83,7 @@ var (
 	receiverWG   = sync.WaitGroup{}
 	processingWG = sync.WaitGroup{}
 	senderWG     = sync.WaitGroup{}
+	inFlight     = inFlightSources{ids: make(map[string]struct{})}
 )
 
 func init() {
@@ -75,6 +106,27 @@ func processMessage(msgCtx context.Context, message *kafka.GenericMessage) {
 	ctx := logger.WithContext(msgCtx)
 	logger.Trace().Msgf("Sources availability check for %s", sourceId)
 
+	if !inFlight.acquire(sourceId) {
+		logger.Trace().Msgf("Skipping availability check of %s already in progress", sourceId)
+		metrics.IncTotalSkippedAvailabilityCheckReqs("in_progress")
+		return
+	}
+	queued := false
+	defer func() {
+		if !queued {
+			inFlight.release(sourceId)
+		}
+	}()
+
+	allowed, err := cache.AllowAvailabilityCheck(ctx, sourceId)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Could not check availability check interval, checking anyway")
+	} else if !allowed {
+		logger.Trace().Msgf("Skipping availability check of %s checked recently", sourceId)
+		metrics.IncTotalSkippedAvailabilityCheckReqs("interval")
+		return
+	}
+
 	// Get sources client
 	sourcesClient, err := clients.GetSourcesClient(ctx)
 	if err != nil {
@@ -97,15 +149,20 @@ func processMessage(msgCtx context.Context, message *kafka.GenericMessage) {
 	s := SourceInfo{
 		MessageContext:      ctx,
 		Authentication:      *authentication,
+		SourceID:            sourceId,
 		SourceApplicationID: authentication.SourceApplictionID,
 	}
 
+	// processors release the source when its check finishes
 	switch authentication.ProviderType {
 	case models.ProviderTypeAWS:
+		queued = true
 		chAws <- s
 	case models.ProviderTypeAzure:
+		queued = true
 		chAzure <- s
 	case models.ProviderTypeGCP:
+		queued = true
 		chGcp <- s
 	case models.ProviderTypeNoop:
 	case models.ProviderTypeUnknown:
@@ -123,6 +180,7 @@ func checkSourceAvailabilityAzure(cancelCtx context.Context) {
 		if random.Float32() > config.Azure.AvailabilityRate {
 			logger.Trace().Msgf("Skipping Azure source availability status %s", s.SourceApplicationID)
 			metrics.IncTotalSentAvailabilityCheckReqs(models.ProviderTypeAzure.String(), "skipped", nil)
+			inFlight.release(s.SourceID)
 			continue
 		}
 
@@ -140,6 +198,7 @@ func checkSourceAvailabilityAzure(cancelCtx context.Context) {
 
 			return fmt.Errorf("error during check: %w", err)
 		})
+		inFlight.release(s.SourceID)
 
 		if cancelCtx.Err() != nil {
 			break
@@ -159,6 +218,7 @@ func checkSourceAvailabilityAWS(cancelCtx context.Context) {
 		if random.Float32() > config.AWS.AvailabilityRate {
 			logger.Trace().Msgf("Skipping AWS source availability status %s", s.SourceApplicationID)
 			metrics.IncTotalSentAvailabilityCheckReqs(models.ProviderTypeAWS.String(), "skipped", nil)
+			inFlight.release(s.SourceID)
 			continue
 		}
 
@@ -198,6 +258,7 @@ func checkSourceAvailabilityAWS(cancelCtx context.Context) {
 			metrics.IncTotalSentAvailabilityCheckReqs(models.ProviderTypeAWS.String(), sr.Status.String(), err)
 			return fmt.Errorf("error during check: %w", err)
 		})
+		inFlight.release(s.SourceID)
 
 		if cancelCtx.Err() != nil {
 			break
@@ -217,6 +278,7 @@ func checkSourceAvailabilityGCP(cancelCtx context.Context) {
 		if random.Float32() > config.GCP.AvailabilityRate {
 			logger.Trace().Msgf("Skipping GCP source availability status %s", s.SourceApplicationID)
 			metrics.IncTotalSentAvailabilityCheckReqs(models.ProviderTypeGCP.String(), "skipped", nil)
+			inFlight.release(s.SourceID)
 			continue
 		}
 
@@ -249,6 +311,7 @@ func checkSourceAvailabilityGCP(cancelCtx context.Context) {
 
 			return fmt.Errorf("error during check: %w", err)
 		})
+		inFlight.release(s.SourceID)
 
 		if cancelCtx.Err() != nil {
 			break
@@ -323,15 +386,18 @@ func statuser() {
 	defer closeFunc()
 	logging.DumpConfigForDevelopment()
 
+	// in-memory cloud clients for development without credentials
+	fake.Initialize(logger.WithContext(ctx))
+
 	// initialize telemetry
 	tel := telemetry.Initialize(&log.Logger)
 	defer tel.Close(ctx)
 
-	// initialize platform kafka and notifications
-	if config.Kafka.Enabled {
-		err := kafka.InitializeKafkaBroker(ctx)
+	// initialize message bus and notifications
+	if config.BusEnabled() {
+		err := kafka.Initialize(ctx)
 		if err != nil {
-			logger.Fatal().Err(err).Msg("Unable to initialize the platform kafka")
+			logger.Fatal().Err(err).Msg("Unable to initialize the message bus")
 		}
 
 		if config.Application.Notifications.Enabled {
@@ -339,6 +405,9 @@ func statuser() {
 		}
 	}
 
+	// initialize cache, shares the check interval of sources among replicas
+	cache.Initialize()
+
 	// metrics
 	logger.Info().Msgf("Starting new instance on port %d with prometheus on %d", config.Application.Port, config.Prometheus.Port)
 	metricsRouter := chi.NewRouter()
@@ -376,7 +445,11 @@ func statuser() {
 	consumerNotify := make(chan struct{})
 	go func() {
 		defer receiverWG.Done()
-		kafka.Consume(cancelCtx, kafka.AvailabilityStatusRequestTopic, time.Now(), processMessage)
+		if config.Statuser.ConsumerGroup != "" {
+			kafka.ConsumeGroup(cancelCtx, kafka.AvailabilityStatusRequestTopic, config.Statuser.ConsumerGroup, processMessage)
+		} else {
+			kafka.Consume(cancelCtx, kafka.AvailabilityStatusRequestTopic, time.Now(), processMessage)
+		}
 		close(consumerNotify)
 	}()
 
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/cache"
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/rs/zerolog"
+)
+
+// warmUp prefetches the provisioning application type id and translations of the most active
+// accounts, so the first requests after a deploy do not absorb the latency. Errors are only
+// logged, the API starts regardless.
+func warmUp(ctx context.Context) {
+	if !config.Application.Cache.WarmUp.Enabled {
+		return
+	}
+
+	logger := zerolog.Ctx(ctx)
+	ctx, cancel := context.WithTimeout(ctx, config.Application.Cache.WarmUp.Timeout)
+	defer cancel()
+	start := time.Now()
+
+	since := time.Now().Add(-config.Application.Cache.WarmUp.Since)
+	accounts, err := dao.GetAccountDao(ctx).UnscopedListMostActive(ctx, since, config.Application.Cache.WarmUp.Accounts)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Unable to list active accounts for cache warm-up")
+		return
+	}
+
+	for _, account := range accounts {
+		err = cache.Set(ctx, account.OrgID+account.AccountNumber.String, account)
+		if err != nil {
+			logger.Warn().Err(err).Msg("Unable to store account to cache during warm-up")
+			return
+		}
+	}
+
+	// sources requires an identity even for application types
+	if len(accounts) == 0 {
+		logger.Info().Msg("No active accounts, application type id is loaded on first request")
+		return
+	}
+	ctx = identity.WithIdentity(ctx, newPrincipal(accounts[0].OrgID, accounts[0].AccountNumber.String))
+	ctx = identity.WithAccountId(ctx, accounts[0].ID)
+
+	sourcesClient, err := clients.GetSourcesClient(ctx)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Unable to initialize sources client for cache warm-up")
+		return
+	}
+	appTypeId, err := sourcesClient.GetProvisioningTypeId(ctx)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Unable to prefetch application type id")
+		return
+	}
+
+	logger.Info().Bool("cache", true).Msgf("Cache warm-up with application type %s and %d accounts took %dms",
+		appTypeId, len(accounts), time.Since(start).Milliseconds())
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/RHEnVision/provisioning-backend/internal/background"
 	"github.com/RHEnVision/provisioning-backend/internal/cache"
+	"github.com/RHEnVision/provisioning-backend/internal/clients/fake"
 	"github.com/RHEnVision/provisioning-backend/internal/config"
 	"github.com/RHEnVision/provisioning-backend/internal/db"
 	"github.com/RHEnVision/provisioning-backend/internal/kafka"
@@ -36,6 +37,9 @@ func worker() {
 	defer closeFunc()
 	logging.DumpConfigForDevelopment()
 
+	// in-memory cloud clients for development without credentials
+	fake.Initialize(logger.WithContext(ctx))
+
 	logger.Info().Msg("Worker starting")
 
 	// initialize telemetry
@@ -54,11 +58,11 @@ func worker() {
 	}
 	defer db.Close()
 
-	// initialize platform kafka and notifications
-	if config.Kafka.Enabled {
-		err = kafka.InitializeKafkaBroker(ctx)
+	// initialize message bus and notifications
+	if config.BusEnabled() {
+		err = kafka.Initialize(ctx)
 		if err != nil {
-			logger.Fatal().Err(err).Msg("Unable to initialize the platform kafka")
+			logger.Fatal().Err(err).Msg("Unable to initialize the message bus")
 		}
 
 		if config.Application.Notifications.Enabled {
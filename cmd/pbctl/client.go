@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var ErrResponse = errors.New("admin API error")
+
+type client struct {
+	url     string
+	token   string
+	timeout time.Duration
+	http    *http.Client
+}
+
+func newClient(url, token string, timeout time.Duration) *client {
+	return &client{
+		url:     strings.TrimSuffix(url, "/"),
+		token:   token,
+		timeout: timeout,
+		http:    &http.Client{},
+	}
+}
+
+// do performs a request and decodes JSON response into the result.
+func (c *client) do(ctx context.Context, method, path string, result any) error {
+	return c.request(ctx, c.timeout, method, path, result)
+}
+
+// doWait performs a long-polling request, the timeout is extended by the maximum wait duration.
+func (c *client) doWait(ctx context.Context, method, path string, result any) error {
+	return c.request(ctx, c.timeout+time.Minute, method, path, result)
+}
+
+func (c *client) request(ctx context.Context, timeout time.Duration, method, path string, result any) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, c.url+path, nil)
+	if err != nil {
+		return fmt.Errorf("unable to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%w: %s %s returned %d: %s", ErrResponse, method, path, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("unable to decode response: %w", err)
+	}
+	return nil
+}
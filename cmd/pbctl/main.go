@@ -0,0 +1,274 @@
+// pbctl is an administrative client for the admin API of the provisioning backend. The admin API
+// is served on the metrics port of the API process when ADMIN_TOKEN is configured.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+)
+
+var ErrUsage = errors.New("invalid usage")
+
+const usage = `Usage: pbctl [flags] <command> [arguments]
+
+Commands:
+  reservation list [-pending] [-limit N] [-offset N]   list reservations of all accounts
+  reservation cancel ID                                 finish a pending reservation with an error
+  reservation requeue ID                                reset a reservation and enqueue its job again
+  reservation tail ID                                   print reservation progress until it finishes
+  reservation support [-o FILE] [-link] ID              download support bundle (JSON) of a reservation
+  cache flush                                           delete all application cache entries
+  queue stats                                           print job queue statistics
+  usage top [-days N] [-limit N]                        list accounts with the most API calls
+
+Environment variables PBCTL_URL and PBCTL_TOKEN can be used instead of flags.
+
+Flags:
+`
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	url := flag.String("url", envOrDefault("PBCTL_URL", "http://localhost:9000"), "admin API URL (metrics port)")
+	token := flag.String("token", os.Getenv("PBCTL_TOKEN"), "admin API token (ADMIN_TOKEN)")
+	timeout := flag.Duration("timeout", 10*time.Second, "timeout of a single request")
+	flag.Parse()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	c := newClient(*url, *token, *timeout)
+	err := run(ctx, c, flag.Args())
+	if errors.Is(err, ErrUsage) {
+		fmt.Fprintln(os.Stderr, err)
+		flag.Usage()
+		os.Exit(2)
+	} else if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+func envOrDefault(name, def string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return def
+}
+
+func run(ctx context.Context, c *client, args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("%w: command is missing", ErrUsage)
+	}
+
+	switch args[0] + " " + args[1] {
+	case "reservation list":
+		return listReservations(ctx, c, args[2:])
+	case "reservation cancel":
+		return reservationAction(ctx, c, "cancel", args[2:])
+	case "reservation requeue":
+		return reservationAction(ctx, c, "requeue", args[2:])
+	case "reservation tail":
+		return tailReservation(ctx, c, args[2:])
+	case "reservation support":
+		return supportBundle(ctx, c, args[2:])
+	case "cache flush":
+		resp := payloads.AdminCacheFlushResponse{}
+		if err := c.do(ctx, "POST", "/admin/cache/flush", &resp); err != nil {
+			return err
+		}
+		fmt.Printf("Deleted %d cache entries\n", resp.Deleted)
+		return nil
+	case "queue stats":
+		resp := payloads.AdminQueueStatsResponse{}
+		if err := c.do(ctx, "GET", "/admin/queue", &resp); err != nil {
+			return err
+		}
+		fmt.Printf("Enqueued jobs: %d\nIn-flight jobs: %d\n", resp.EnqueuedJobs, resp.InFlight)
+		return nil
+	case "usage top":
+		return topUsage(ctx, c, args[2:])
+	default:
+		return fmt.Errorf("%w: unknown command '%s'", ErrUsage, strings.Join(args, " "))
+	}
+}
+
+func listReservations(ctx context.Context, c *client, args []string) error {
+	flags := flag.NewFlagSet("reservation list", flag.ContinueOnError)
+	pending := flags.Bool("pending", false, "only reservations which are still processing")
+	limit := flags.Uint("limit", 100, "maximum number of reservations")
+	offset := flags.Uint("offset", 0, "number of reservations to skip")
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w: %s", ErrUsage, err.Error())
+	}
+
+	path := fmt.Sprintf("/admin/reservations?pending=%t&limit=%d&offset=%d", *pending, *limit, *offset)
+	resp := payloads.AdminReservationListResponse{}
+	if err := c.do(ctx, "GET", path, &resp); err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tACCOUNT\tPROVIDER\tCREATED\tSTEP\tSTATUS\tRESULT")
+	for _, r := range resp.Data {
+		fmt.Fprintf(tw, "%d\t%d\t%d\t%s\t%d/%d\t%s\t%s\n", r.ID, r.AccountID, r.Provider,
+			r.CreatedAt.Format(time.RFC3339), r.Step, r.Steps, r.Status, result(r))
+	}
+	return tw.Flush()
+}
+
+func topUsage(ctx context.Context, c *client, args []string) error {
+	flags := flag.NewFlagSet("usage top", flag.ContinueOnError)
+	days := flags.Uint("days", 7, "number of days including today")
+	limit := flags.Uint("limit", 20, "maximum number of accounts")
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w: %s", ErrUsage, err.Error())
+	}
+
+	path := fmt.Sprintf("/admin/usage?days=%d&limit=%d", *days, *limit)
+	resp := payloads.AdminUsageListResponse{}
+	if err := c.do(ctx, "GET", path, &resp); err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "ACCOUNT\tORG\tAPI CALLS\tLAUNCHES\tINSTANCES")
+	for _, u := range resp.Data {
+		fmt.Fprintf(tw, "%d\t%s\t%d\t%d\t%d\n", u.AccountID, u.OrgID, u.APICalls, u.Launches, u.Instances)
+	}
+	return tw.Flush()
+}
+
+func reservationAction(ctx context.Context, c *client, action string, args []string) error {
+	id, err := parseID(args)
+	if err != nil {
+		return err
+	}
+
+	resp := payloads.AdminReservationResponse{}
+	if err := c.do(ctx, "POST", fmt.Sprintf("/admin/reservations/%d/%s", id, action), &resp); err != nil {
+		return err
+	}
+	printReservation(&resp)
+	return nil
+}
+
+// tailReservation prints a line every time the reservation status changes, the server holds each
+// request until there is a change.
+func tailReservation(ctx context.Context, c *client, args []string) error {
+	id, err := parseID(args)
+	if err != nil {
+		return err
+	}
+
+	var last string
+	for {
+		resp := payloads.AdminReservationResponse{}
+		err := c.doWait(ctx, "GET", fmt.Sprintf("/admin/reservations/%d?wait=30s", id), &resp)
+		if err != nil {
+			return err
+		}
+
+		line := fmt.Sprintf("%d/%d %s %s", resp.Step, resp.Steps, resp.Status, result(&resp))
+		if line != last {
+			printReservation(&resp)
+			last = line
+		}
+		if resp.FinishedAt != nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// supportBundle writes the support bundle of a reservation into a file or standard output.
+func supportBundle(ctx context.Context, c *client, args []string) error {
+	flags := flag.NewFlagSet("reservation support", flag.ContinueOnError)
+	output := flags.String("o", "", "output file (default standard output)")
+	link := flags.Bool("link", false, "store the bundle in object storage and print a signed download URL")
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w: %s", ErrUsage, err.Error())
+	}
+	id, err := parseID(flags.Args())
+	if err != nil {
+		return err
+	}
+
+	if *link {
+		var resp struct {
+			URL       string    `json:"url"`
+			ExpiresAt time.Time `json:"expires_at"`
+		}
+		if err := c.do(ctx, "GET", fmt.Sprintf("/admin/reservations/%d/support?link=true", id), &resp); err != nil {
+			return err
+		}
+		fmt.Printf("%s\nExpires at %s\n", resp.URL, resp.ExpiresAt.Local().Format(time.RFC1123))
+		return nil
+	}
+
+	var resp json.RawMessage
+	if err := c.do(ctx, "GET", fmt.Sprintf("/admin/reservations/%d/support", id), &resp); err != nil {
+		return err
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, resp, "", "  "); err != nil {
+		return fmt.Errorf("unable to format bundle: %w", err)
+	}
+	indented.WriteByte('\n')
+
+	if *output == "" {
+		_, err = indented.WriteTo(os.Stdout)
+		return err
+	}
+	if err := os.WriteFile(*output, indented.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("unable to write bundle: %w", err)
+	}
+	fmt.Printf("Support bundle of reservation %d written to %s\n", id, *output)
+	return nil
+}
+
+func parseID(args []string) (int64, error) {
+	if len(args) != 1 {
+		return 0, fmt.Errorf("%w: reservation ID is missing", ErrUsage)
+	}
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid reservation ID '%s'", ErrUsage, args[0])
+	}
+	return id, nil
+}
+
+func result(r *payloads.AdminReservationResponse) string {
+	switch {
+	case r.Success == nil:
+		return "pending"
+	case *r.Success:
+		return "success"
+	default:
+		return "failed: " + r.Error
+	}
+}
+
+func printReservation(r *payloads.AdminReservationResponse) {
+	fmt.Printf("%s reservation %d (account %d) step %d/%d: %s [%s]\n", time.Now().Format("15:04:05"),
+		r.ID, r.AccountID, r.Step, r.Steps, r.Status, result(r))
+}
@@ -30,3 +30,10 @@ var ResponseErrorUserFriendlyExample = payloads.ResponseError{
 	Version:   "df8a489",
 	BuildTime: "2023-04-14_17:15:02",
 }
+
+var ResponseConflictErrorExample = payloads.ResponseError{
+	TraceId:   "b57f7b78c",
+	Error:     "error: conflict: details can be long",
+	Version:   "df8a489",
+	BuildTime: "2023-04-14_17:15:02",
+}
@@ -5,3 +5,45 @@ import "github.com/RHEnVision/provisioning-backend/internal/payloads"
 var AvailabilityStatusRequest = payloads.AvailabilityStatusRequest{
 	SourceID: "463243",
 }
+
+var LimitsResponse = payloads.LimitsResponse{
+	Enabled:   true,
+	Limit:     600,
+	Remaining: 588,
+	Reset:     42,
+}
+
+var AccountUsageListResponse = payloads.AccountUsageListResponse{
+	Data: []*payloads.AccountUsageResponse{
+		{
+			Day:       "2023-05-02",
+			APICalls:  1250,
+			Launches:  3,
+			Instances: 12,
+		},
+		{
+			Day:       "2023-05-01",
+			APICalls:  842,
+			Launches:  1,
+			Instances: 1,
+		},
+	},
+}
+
+var SettingsRequestExample = payloads.SettingsRequest{
+	ApprovalThreshold:    10,
+	AllowedInstanceTypes: []string{},
+	DeniedInstanceTypes:  []string{"*.metal", "*.metal-*"},
+	MaxVCPUs:             64,
+	ComposedImagesOnly:   true,
+	AllowedImages:        []string{"ami-0c830793775595d4b"},
+}
+
+var SettingsResponseExample = payloads.SettingsResponse{
+	ApprovalThreshold:    10,
+	AllowedInstanceTypes: []string{},
+	DeniedInstanceTypes:  []string{"*.metal", "*.metal-*"},
+	MaxVCPUs:             64,
+	ComposedImagesOnly:   true,
+	AllowedImages:        []string{"ami-0c830793775595d4b"},
+}
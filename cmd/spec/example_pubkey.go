@@ -29,4 +29,9 @@ var PubkeyListResponse = payloads.PubkeyListResponse{
 			FingerprintLegacy: "ee:f1:d4:62:99:ab:17:d9:3b:00:66:62:32:b2:55:9e",
 		},
 	},
+	Meta: &payloads.ListMeta{
+		Count: 1,
+		Total: 1,
+	},
+	Links: &payloads.ListLinks{},
 }
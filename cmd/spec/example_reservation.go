@@ -22,6 +22,7 @@ var GenericReservationResponsePayloadPendingExample = payloads.GenericReservatio
 	ID:         1310,
 	Provider:   1,
 	CreatedAt:  ReservationTime.Add(-10 * time.Second),
+	CreatedBy:  "jdoe",
 	Steps:      3,
 	StepTitles: []string{"Ensure public key", "Launch instance(s)", "Fetch instance(s) description"},
 	Step:       1,
@@ -29,12 +30,14 @@ var GenericReservationResponsePayloadPendingExample = payloads.GenericReservatio
 	Error:      "",
 	FinishedAt: nil,
 	Success:    nil,
+	Labels:     []string{},
 }
 
 var GenericReservationResponsePayloadSuccessExample = payloads.GenericReservationResponse{
 	ID:         1305,
 	Provider:   1,
 	CreatedAt:  ReservationTime.Add(-10 * time.Second),
+	CreatedBy:  "jdoe",
 	Steps:      3,
 	StepTitles: []string{"Ensure public key", "Launch instance(s)", "Fetch instance(s) description"},
 	Step:       3,
@@ -42,12 +45,14 @@ var GenericReservationResponsePayloadSuccessExample = payloads.GenericReservatio
 	Error:      "",
 	FinishedAt: ptr.To(ReservationTime),
 	Success:    ptr.To(true),
+	Labels:     []string{"hackathon"},
 }
 
 var GenericReservationResponsePayloadFailureExample = payloads.GenericReservationResponse{
 	ID:         1313,
 	Provider:   1,
 	CreatedAt:  ReservationTime.Add(-10 * time.Second),
+	CreatedBy:  "jdoe",
 	Steps:      3,
 	StepTitles: []string{"Ensure public key", "Launch instance(s)", "Fetch instance(s) description"},
 	Step:       2,
@@ -55,6 +60,23 @@ var GenericReservationResponsePayloadFailureExample = payloads.GenericReservatio
 	Error:      "cannot launch ec2 instance: VPCIdNotSpecified: No default VPC for this user. GroupName is only supported for EC2-Classic and default VPC",
 	FinishedAt: ptr.To(ReservationTime),
 	Success:    ptr.To(false),
+	Labels:     []string{"hackathon"},
+}
+
+var GenericReservationResponsePayloadApprovedExample = payloads.GenericReservationResponse{
+	ID:         1318,
+	Provider:   1,
+	CreatedAt:  ReservationTime.Add(-10 * time.Minute),
+	CreatedBy:  "jdoe",
+	Steps:      3,
+	StepTitles: []string{"Ensure public key", "Launch instance(s)", "Fetch instance(s) description"},
+	Step:       0,
+	Status:     "Created",
+	Error:      "",
+	FinishedAt: nil,
+	Success:    nil,
+	Approval:   "approved",
+	Labels:     []string{},
 }
 
 var GenericReservationResponsePayloadListExample = payloads.GenericReservationListResponse{
@@ -63,6 +85,17 @@ var GenericReservationResponsePayloadListExample = payloads.GenericReservationLi
 		&GenericReservationResponsePayloadSuccessExample,
 		&GenericReservationResponsePayloadFailureExample,
 	},
+	Meta: &payloads.ListMeta{
+		Count: 3,
+		Total: 5,
+	},
+	Links: &payloads.ListLinks{
+		Next: "/api/provisioning/v1/reservations?limit=3&offset=3",
+	},
+}
+
+var ReservationStatusRequestExample = payloads.ReservationStatusRequest{
+	IDs: []int64{1310, 1305, 1313},
 }
 
 var AwsReservationRequestPayloadExample = payloads.AWSReservationRequest{
@@ -75,6 +108,9 @@ var AwsReservationRequestPayloadExample = payloads.AWSReservationRequest{
 	LaunchTemplateID: "",
 	Name:             "my-instance",
 	PowerOff:         false,
+	HealthProbe:      &payloads.HealthProbeRequest{Timeout: 300},
+	Tags:             map[string]string{"team": "platform"},
+	UserData:         "#cloud-config\npackages:\n- vim\n",
 }
 
 var AwsReservationResponsePayloadPendingExample = payloads.AWSReservationResponse{
@@ -87,6 +123,9 @@ var AwsReservationResponsePayloadPendingExample = payloads.AWSReservationRespons
 	LaunchTemplateID: "",
 	Name:             "my-instance",
 	PowerOff:         false,
+	HealthProbe:      &payloads.HealthProbeRequest{Timeout: 300},
+	Addressing:       payloads.AddressingResponse{Mode: payloads.AddressingIPv4, PublicIPv4: true},
+	Tags:             map[string]string{"team": "platform"},
 }
 
 var AwsReservationResponsePayloadDoneExample = payloads.AWSReservationResponse{
@@ -101,11 +140,15 @@ var AwsReservationResponsePayloadDoneExample = payloads.AWSReservationResponse{
 	AWSReservationID: "r-3743243324231",
 	Name:             "my-instance",
 	PowerOff:         false,
+	Addressing:       payloads.AddressingResponse{Mode: payloads.AddressingIPv4, PublicIPv4: true},
+	Tags:             map[string]string{"team": "platform"},
 	Instances: []payloads.InstanceResponse{
 		{InstanceID: "i-2324343212", Detail: models.ReservationInstanceDetail{
-			PublicDNS:  "",
-			PublicIPv4: "10.0.0.88",
-		}},
+			PublicDNS:   "",
+			PublicIPv4:  "10.0.0.88",
+			PrivateDNS:  "ip-172-31-5-17.ec2.internal",
+			PrivateIPv4: "172.31.5.17",
+		}, PowerState: models.PowerStateRunning},
 	},
 }
 
@@ -118,6 +161,7 @@ var AzureReservationRequestPayloadExample = payloads.AzureReservationRequest{
 	ImageID:      "composer-api-081fc867-838f-44a5-af03-8b8def808431",
 	Name:         "my-instance",
 	PowerOff:     false,
+	UserData:     "#cloud-config\npackages:\n- vim\n",
 }
 
 var AzureReservationResponsePayloadPendingExample = payloads.AzureReservationResponse{
@@ -149,6 +193,7 @@ var AzureReservationResponsePayloadDoneExample = payloads.AzureReservationRespon
 			PublicDNS:  "",
 			PublicIPv4: "10.0.0.88",
 		},
+		PowerState: models.PowerStateRunning,
 	}},
 }
 
@@ -175,6 +220,7 @@ var GCPReservationResponsePayloadPendingExample = payloads.GCPReservationRespons
 	LaunchTemplateID: "4883371230199373111",
 	GCPOperationName: "operation-1686646674436-5fdff07e43209-66146b7e-f3f65ec5",
 	PowerOff:         false,
+	Addressing:       payloads.AddressingResponse{Mode: payloads.AddressingIPv4, PublicIPv4: true},
 }
 
 var GCPReservationResponsePayloadDoneExample = payloads.GCPReservationResponse{
@@ -189,14 +235,236 @@ var GCPReservationResponsePayloadDoneExample = payloads.GCPReservationResponse{
 	NamePattern:      "my-instance",
 	GCPOperationName: "operation-1686646674436-5fdff07e43209-66146b7e-f3f65ec5",
 	PowerOff:         false,
+	Addressing:       payloads.AddressingResponse{Mode: payloads.AddressingIPv4, PublicIPv4: true},
 	Instances: []payloads.InstanceResponse{
 		{InstanceID: "3003942005876582747", Detail: models.ReservationInstanceDetail{
 			PublicDNS:  "",
 			PublicIPv4: "10.0.0.88",
-		}},
+		}, PowerState: models.PowerStateRunning},
 	},
 }
 
 var NoopReservationResponsePayloadExample = payloads.NoopReservationResponse{
-	ID: 1310,
+	ID:  1310,
+	IDs: []int64{1310},
+}
+
+var InstanceResponseStopExample = payloads.InstanceResponse{
+	InstanceID: "i-2324343212",
+	Detail: models.ReservationInstanceDetail{
+		PublicDNS:  "",
+		PublicIPv4: "10.0.0.88",
+	},
+	PowerState: models.PowerStateStopping,
+}
+
+var InstanceResponseStartExample = payloads.InstanceResponse{
+	InstanceID: "i-2324343212",
+	Detail: models.ReservationInstanceDetail{
+		PublicDNS:  "",
+		PublicIPv4: "10.0.0.88",
+	},
+	PowerState: models.PowerStateStarting,
+}
+
+var ResizeInstanceRequestExample = payloads.ResizeInstanceRequest{
+	InstanceType: "t3.large",
+}
+
+var InstanceResponseResizeExample = payloads.InstanceResponse{
+	InstanceID: "i-2324343212",
+	Detail: models.ReservationInstanceDetail{
+		PublicDNS:  "",
+		PublicIPv4: "10.0.0.88",
+	},
+	PowerState: models.PowerStateStopped,
+}
+
+var InstanceConsoleResponseExample = payloads.InstanceConsoleResponse{
+	InstanceID: "i-2324343212",
+	Output:     "[    0.000000] Linux version 5.14.0-284.11.1.el9_2.x86_64\n...\nip-10-0-0-88 login: ",
+}
+
+var InstanceListResponseExample = payloads.InstanceListResponse{
+	Data: []*payloads.ListedInstanceResponse{
+		{
+			InstanceResponse: payloads.InstanceResponse{
+				InstanceID: "i-2324343212",
+				Detail: models.ReservationInstanceDetail{
+					PublicDNS:  "ec2-184-73-141-211.compute-1.amazonaws.com",
+					PublicIPv4: "184.73.141.211",
+				},
+				PowerState: models.PowerStateRunning,
+			},
+			ReservationID: 1310,
+			Provider:      "aws",
+			SourceID:      "654321",
+			Location:      "us-east-1",
+			InstanceType:  "t3.small",
+			CreatedAt:     ReservationTime,
+		},
+		{
+			InstanceResponse: payloads.InstanceResponse{
+				InstanceID: "3003942005876582747",
+				Detail: models.ReservationInstanceDetail{
+					PublicIPv4: "34.67.42.10",
+				},
+				PowerState: models.PowerStateTerminated,
+			},
+			ReservationID: 1305,
+			Provider:      "gcp",
+			SourceID:      "654322",
+			Location:      "us-central1-a",
+			InstanceType:  "n1-standard-1",
+			CreatedAt:     ReservationTime,
+		},
+	},
+	Meta: &payloads.ListMeta{
+		Count: 2,
+		Total: 2,
+	},
+	Links: &payloads.ListLinks{},
+}
+
+var OrphanedInstanceListResponseExample = payloads.OrphanedInstanceListResponse{
+	Data: []*payloads.OrphanedInstanceResponse{
+		{
+			InstanceID:    "i-0b5ae5c1d2f3a4b6c",
+			Kind:          models.OrphanKindUntracked,
+			ReservationID: 1302,
+			Provider:      "aws",
+			SourceID:      "654321",
+			Location:      "us-east-1",
+			DetectedAt:    ReservationTime,
+		},
+		{
+			InstanceID:    "i-2324343212",
+			Kind:          models.OrphanKindMissing,
+			ReservationID: 1310,
+			Provider:      "aws",
+			SourceID:      "654321",
+			Location:      "us-east-1",
+			DetectedAt:    ReservationTime,
+		},
+	},
+	Meta: &payloads.ListMeta{
+		Count: 2,
+		Total: 2,
+	},
+	Links: &payloads.ListLinks{},
+}
+
+var ReservationArchiveResponseExample = payloads.ReservationArchiveResponse{
+	ArchivedAt: ReservationTime.Add(8760 * time.Hour),
+	Reservation: &payloads.GenericReservationResponse{
+		ID:         1305,
+		Provider:   int(models.ProviderTypeAWS),
+		CreatedAt:  ReservationTime.Add(-10 * time.Second),
+		CreatedBy:  "jdoe",
+		Steps:      3,
+		StepTitles: []string{"Ensure public key", "Launch instance(s)", "Fetch instance(s) description"},
+		Step:       3,
+		Status:     "Finished Fetch instance(s) description",
+		FinishedAt: ptr.To(ReservationTime),
+		Success:    ptr.To(true),
+	},
+	CreatedByUserID: "a1b2c3d4",
+	WorkspaceID:     "",
+	Detail: map[string]interface{}{
+		"id":            1305,
+		"source_id":     "654321",
+		"region":        "us-east-1",
+		"instance_type": "t3.small",
+		"amount":        1,
+		"image_id":      "ami-7846387643232",
+		"instances": []map[string]interface{}{
+			{"instance_id": "i-2324343212", "power_state": "terminated"},
+		},
+	},
+	AuditTrail: []payloads.AuditRecordResponse{
+		{
+			ID:         7,
+			InstanceID: "i-2324343212",
+			Action:     string(models.AuditActionInstanceResize),
+			Actor:      "jdoe",
+			Details:    map[string]interface{}{"instance_type": "t3.medium"},
+			CreatedAt:  ReservationTime.Add(time.Hour),
+		},
+	},
+}
+
+var ArtifactLinkResponseExample = payloads.ArtifactLinkResponse{
+	URL:       "https://s3.us-east-1.amazonaws.com/provisioning-archive/reservations/000013/1305.json.gz?X-Amz-Algorithm=AWS4-HMAC-SHA256&X-Amz-Credential=AKIAEXAMPLE%2F20150513%2Fus-east-1%2Fs3%2Faws4_request&X-Amz-Date=20150513T192025Z&X-Amz-Expires=900&X-Amz-SignedHeaders=host&X-Amz-Signature=0123456789abcdef",
+	ExpiresAt: ReservationTime.Add(8760*time.Hour + 15*time.Minute),
+}
+
+var LabelsRequestExample = payloads.LabelsRequest{
+	Labels: []string{"hackathon", "team-platform"},
+}
+
+var LabelStatsListResponseExample = payloads.LabelStatsListResponse{
+	Data: []*payloads.LabelStatsResponse{
+		{Label: "hackathon", Reservations: 12, Succeeded: 10, Failed: 1, Instances: 37},
+		{Label: "team-platform", Reservations: 3, Succeeded: 3, Failed: 0, Instances: 3},
+	},
+}
+
+var ReservationDiffResponseExample = payloads.ReservationDiffResponse{
+	ReservationID:      1305,
+	OtherReservationID: 1310,
+	Changes: []payloads.ReservationChangeResponse{
+		{
+			Field:      "detail.launched_instance_type",
+			Kind:       payloads.DiffOutcome,
+			Value:      "t3.small",
+			OtherValue: "t3a.small",
+		},
+		{
+			Field:      "detail.fallback_instance_types",
+			Kind:       payloads.DiffParameter,
+			Value:      nil,
+			OtherValue: []string{"t3a.small"},
+		},
+		{
+			Field:      "detail.tags.env",
+			Kind:       payloads.DiffParameter,
+			Value:      "staging",
+			OtherValue: "production",
+		},
+	},
+}
+
+var ReservationEventListResponseExample = payloads.ReservationEventListResponse{
+	Data: []*payloads.ReservationEventResponse{
+		{
+			ID:        1,
+			Kind:      "enqueued",
+			Message:   "Job enqueued",
+			Details:   map[string]interface{}{"job_id": "a8e3f1d2-5b6c-4e7f-9a0b-1c2d3e4f5a6b", "job_type": "launch_instances_aws"},
+			CreatedAt: ReservationTime.Add(-2 * time.Minute),
+		},
+		{
+			ID:        2,
+			Kind:      "step_started",
+			Message:   "Launching instance(s)",
+			Details:   map[string]interface{}{},
+			CreatedAt: ReservationTime.Add(-time.Minute),
+		},
+		{
+			ID:        3,
+			Kind:      "provider_call",
+			Message:   "RunInstances",
+			Details:   map[string]interface{}{"instance_type": "t3.small", "amount": "2"},
+			CreatedAt: ReservationTime.Add(-time.Minute),
+		},
+		{
+			ID:        4,
+			Kind:      "finished",
+			Message:   "All steps finished",
+			Details:   map[string]interface{}{},
+			CreatedAt: ReservationTime,
+		},
+	},
+	Meta:  &payloads.ListMeta{Count: 4, Total: 4},
+	Links: &payloads.ListLinks{},
 }
@@ -0,0 +1,56 @@
+package main
+
+import "github.com/RHEnVision/provisioning-backend/internal/payloads"
+
+var ReservationTemplateRequestExample = payloads.ReservationTemplateRequest{
+	Name:     "Weekly lab",
+	Provider: "aws",
+	Request: map[string]interface{}{
+		"source_id":     "654321",
+		"image_id":      "ami-7846387643232",
+		"region":        "us-east-1",
+		"instance_type": "t3.small",
+		"amount":        3,
+		"pubkey_id":     42,
+		"name":          "lab",
+	},
+}
+
+var ReservationTemplateResponseExample = payloads.ReservationTemplateResponse{
+	ID:        1,
+	Name:      ReservationTemplateRequestExample.Name,
+	Provider:  ReservationTemplateRequestExample.Provider,
+	Request:   ReservationTemplateRequestExample.Request,
+	CreatedBy: "jdoe",
+	CreatedAt: ReservationTime,
+}
+
+var ReservationTemplateListResponseExample = payloads.ReservationTemplateListResponse{
+	Data: []*payloads.ReservationTemplateResponse{
+		&ReservationTemplateResponseExample,
+	},
+	Meta: &payloads.ListMeta{
+		Count: 1,
+		Total: 1,
+	},
+	Links: &payloads.ListLinks{},
+}
+
+var ReservationTemplateScheduleRequestExample = payloads.ReservationTemplateScheduleRequest{
+	Cron: "0 7 * * 1",
+}
+
+var ReservationTemplateScheduledNextRun = MustParseTime("2013-05-20T07:00:00Z")
+
+var ReservationTemplateScheduledResponseExample = payloads.ReservationTemplateResponse{
+	ID:        1,
+	Name:      ReservationTemplateRequestExample.Name,
+	Provider:  ReservationTemplateRequestExample.Provider,
+	Request:   ReservationTemplateRequestExample.Request,
+	CreatedBy: "jdoe",
+	CreatedAt: ReservationTime,
+	Schedule: &payloads.ReservationTemplateScheduleResponse{
+		Cron:      ReservationTemplateScheduleRequestExample.Cron,
+		NextRunAt: &ReservationTemplateScheduledNextRun,
+	},
+}
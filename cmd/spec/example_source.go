@@ -8,13 +8,20 @@ import (
 var SourceListResponse = payloads.SourceListResponse{
 	Data: []*payloads.SourceResponse{
 		{
-			ID:   "654321",
-			Name: "My AWS account",
+			ID:       "654321",
+			Name:     "My AWS account",
+			Provider: "aws",
 		}, {
 			ID:   "543621",
 			Name: "My other AWS account",
 		},
 	},
+	Errors: []*payloads.SourceErrorResponse{
+		{
+			SourceID: "543621",
+			Error:    "source does not have an authentication",
+		},
+	},
 }
 
 var SourceUploadInfoAWSResponse = payloads.SourceUploadInfoResponse{
@@ -30,3 +37,16 @@ var SourceUploadInfoAzureResponse = payloads.SourceUploadInfoResponse{
 		ResourceGroups: []string{"MyGroup 1", "MyGroup 42"},
 	},
 }
+
+var SourceSettingsRequestExample = payloads.SourceSettingsRequest{
+	DefaultRegion:  "eu-central-1",
+	AllowedRegions: []string{"eu-central-1", "eu-west-1"},
+	EventTarget:    "arn:aws:sqs:eu-central-1:123456789012:provisioning-events",
+}
+
+var SourceSettingsResponseExample = payloads.SourceSettingsResponse{
+	SourceID:       "654321",
+	DefaultRegion:  "eu-central-1",
+	AllowedRegions: []string{"eu-central-1", "eu-west-1"},
+	EventTarget:    "arn:aws:sqs:eu-central-1:123456789012:provisioning-events",
+}
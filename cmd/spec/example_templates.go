@@ -10,3 +10,84 @@ var LaunchTemplateListResponse = payloads.LaunchTemplateListResponse{
 		},
 	},
 }
+
+var SubnetListResponse = payloads.SubnetListResponse{
+	Data: []*payloads.SubnetResponse{
+		{
+			ID:               "subnet-0d2b4f8e1a9c3b7e5",
+			Name:             "private-1a",
+			VpcID:            "vpc-0a1b2c3d4e5f67890",
+			CIDR:             "10.0.1.0/24",
+			AvailabilityZone: "us-east-1a",
+			Default:          false,
+		},
+	},
+}
+
+var SecurityGroupListResponse = payloads.SecurityGroupListResponse{
+	Data: []*payloads.SecurityGroupResponse{
+		{
+			ID:          "sg-07a7a4c3f5d6e8b91",
+			Name:        "default",
+			Description: "default VPC security group",
+			VpcID:       "vpc-0a1b2c3d4e5f67890",
+		},
+	},
+}
+
+var DedicatedHostListResponse = payloads.DedicatedHostListResponse{
+	Data: []*payloads.DedicatedHostResponse{
+		{
+			ID:               "h-0a1b2c3d4e5f67890",
+			Name:             "build-hosts",
+			InstanceFamily:   "m5",
+			InstanceType:     "",
+			AvailabilityZone: "us-east-1a",
+			State:            "available",
+			AutoPlacement:    true,
+			AvailableVCPUs:   44,
+			Instances:        1,
+		},
+	},
+}
+
+var CapacityReservationListResponse = payloads.CapacityReservationListResponse{
+	Data: []*payloads.CapacityReservationResponse{
+		{
+			ID:                    "cr-0a1b2c3d4e5f67890",
+			Name:                  "release-capacity",
+			InstanceType:          "t3.small",
+			AvailabilityZone:      "us-east-1a",
+			Platform:              "Linux/UNIX",
+			Tenancy:               "default",
+			InstanceMatchCriteria: "targeted",
+			TotalInstances:        10,
+			AvailableInstances:    4,
+		},
+	},
+}
+
+var ImageListResponse = payloads.ImageListResponse{
+	Data: []*payloads.ImageResponse{
+		{
+			Name:         "rhel-9-v20231010",
+			Family:       "rhel-9",
+			Project:      "rhel-cloud",
+			SelfLink:     "https://www.googleapis.com/compute/v1/projects/rhel-cloud/global/images/rhel-9-v20231010",
+			Architecture: "x86_64",
+		},
+	},
+}
+
+var AzureMarketplaceOfferListResponse = payloads.AzureMarketplaceOfferListResponse{
+	Data: []payloads.AzureMarketplaceOfferResponse{
+		{
+			Publisher:    "RedHat",
+			Offer:        "RHEL",
+			SKU:          "9-lvm-gen2",
+			URN:          "RedHat:RHEL:9-lvm-gen2:latest",
+			Description:  "Red Hat Enterprise Linux 9 (LVM, Gen2), pay as you go",
+			Architecture: "x86_64",
+		},
+	},
+}
@@ -18,6 +18,7 @@ func addPayloads(gen *APISchemaGen) {
 	gen.addSchema("v1.PubkeyRequest", &payloads.PubkeyRequest{})
 	gen.addSchema("v1.PubkeyResponse", &payloads.PubkeyResponse{})
 	gen.addSchema("v1.SourceResponse", &payloads.SourceResponse{})
+	gen.addSchema("v1.SourceErrorResponse", &payloads.SourceErrorResponse{})
 	gen.addSchema("v1.InstanceTypeResponse", &payloads.InstanceTypeResponse{})
 	gen.addSchema("v1.GenericReservationResponse", &payloads.GenericReservationResponse{})
 	gen.addSchema("v1.NoopReservationResponse", &payloads.NoopReservationResponse{})
@@ -27,16 +28,46 @@ func addPayloads(gen *APISchemaGen) {
 	gen.addSchema("v1.AzureReservationResponse", &payloads.AzureReservationResponse{})
 	gen.addSchema("v1.GCPReservationRequest", &payloads.GCPReservationRequest{})
 	gen.addSchema("v1.GCPReservationResponse", &payloads.GCPReservationResponse{})
+	gen.addSchema("v1.ReservationStatusRequest", &payloads.ReservationStatusRequest{})
+	gen.addSchema("v1.ReservationExportResponse", &payloads.ReservationExportResponse{})
+	gen.addSchema("v1.ReservationArchiveResponse", &payloads.ReservationArchiveResponse{})
+	gen.addSchema("v1.ReservationDiffResponse", &payloads.ReservationDiffResponse{})
+	gen.addSchema("v1.ArtifactLinkResponse", &payloads.ArtifactLinkResponse{})
 	gen.addSchema("v1.AvailabilityStatusRequest", &payloads.AvailabilityStatusRequest{})
 	gen.addSchema("v1.AccountIDTypeResponse", &payloads.AccountIdentityResponse{})
 	gen.addSchema("v1.SourceUploadInfoResponse", &payloads.SourceUploadInfoResponse{})
+	gen.addSchema("v1.SourceSettingsRequest", &payloads.SourceSettingsRequest{})
+	gen.addSchema("v1.SourceSettingsResponse", &payloads.SourceSettingsResponse{})
 	gen.addSchema("v1.LaunchTemplatesResponse", &payloads.LaunchTemplateResponse{})
+	gen.addSchema("v1.ImageResponse", &payloads.ImageResponse{})
+	gen.addSchema("v1.LimitsResponse", &payloads.LimitsResponse{})
+	gen.addSchema("v1.AccountUsageListResponse", &payloads.AccountUsageListResponse{})
+	gen.addSchema("v1.SettingsRequest", &payloads.SettingsRequest{})
+	gen.addSchema("v1.SettingsResponse", &payloads.SettingsResponse{})
+	gen.addSchema("v1.LabelsRequest", &payloads.LabelsRequest{})
+	gen.addSchema("v1.InstanceResponse", &payloads.InstanceResponse{})
+	gen.addSchema("v1.ResizeInstanceRequest", &payloads.ResizeInstanceRequest{})
+	gen.addSchema("v1.InstanceConsoleResponse", &payloads.InstanceConsoleResponse{})
+	gen.addSchema("v1.ReservationTemplateRequest", &payloads.ReservationTemplateRequest{})
+	gen.addSchema("v1.ReservationTemplateResponse", &payloads.ReservationTemplateResponse{})
+	gen.addSchema("v1.ReservationTemplateScheduleRequest", &payloads.ReservationTemplateScheduleRequest{})
 
 	gen.addSchema("v1.ListSourceResponse", &payloads.SourceListResponse{})
 	gen.addSchema("v1.ListPubkeyResponse", &payloads.PubkeyListResponse{})
 	gen.addSchema("v1.ListInstaceTypeResponse", &payloads.InstanceTypeListResponse{})
 	gen.addSchema("v1.ListGenericReservationResponse", &payloads.GenericReservationListResponse{})
+	gen.addSchema("v1.ListInstanceResponse", &payloads.InstanceListResponse{})
+	gen.addSchema("v1.ListOrphanedInstanceResponse", &payloads.OrphanedInstanceListResponse{})
 	gen.addSchema("v1.ListLaunchTemplateResponse", &payloads.LaunchTemplateListResponse{})
+	gen.addSchema("v1.ListSubnetResponse", &payloads.SubnetListResponse{})
+	gen.addSchema("v1.ListSecurityGroupResponse", &payloads.SecurityGroupListResponse{})
+	gen.addSchema("v1.ListDedicatedHostResponse", &payloads.DedicatedHostListResponse{})
+	gen.addSchema("v1.ListCapacityReservationResponse", &payloads.CapacityReservationListResponse{})
+	gen.addSchema("v1.ListImageResponse", &payloads.ImageListResponse{})
+	gen.addSchema("v1.ListAzureMarketplaceOfferResponse", &payloads.AzureMarketplaceOfferListResponse{})
+	gen.addSchema("v1.ListReservationTemplateResponse", &payloads.ReservationTemplateListResponse{})
+	gen.addSchema("v1.ListLabelStatsResponse", &payloads.LabelStatsListResponse{})
+	gen.addSchema("v1.ListReservationEventResponse", &payloads.ReservationEventListResponse{})
 }
 
 func addExamples(gen *APISchemaGen) {
@@ -46,12 +77,44 @@ func addExamples(gen *APISchemaGen) {
 	gen.addExample("v1.SourceListResponseExample", SourceListResponse)
 	gen.addExample("v1.SourceUploadInfoAWSResponse", SourceUploadInfoAWSResponse)
 	gen.addExample("v1.SourceUploadInfoAzureResponse", SourceUploadInfoAzureResponse)
+	gen.addExample("v1.SourceSettingsRequestExample", SourceSettingsRequestExample)
+	gen.addExample("v1.SourceSettingsResponseExample", SourceSettingsResponseExample)
 	gen.addExample("v1.LaunchTemplateListResponse", LaunchTemplateListResponse)
+	gen.addExample("v1.SubnetListResponse", SubnetListResponse)
+	gen.addExample("v1.SecurityGroupListResponse", SecurityGroupListResponse)
+	gen.addExample("v1.DedicatedHostListResponse", DedicatedHostListResponse)
+	gen.addExample("v1.CapacityReservationListResponse", CapacityReservationListResponse)
+	gen.addExample("v1.ImageListResponse", ImageListResponse)
+	gen.addExample("v1.AzureMarketplaceOfferListResponse", AzureMarketplaceOfferListResponse)
 	gen.addExample("v1.AvailabilityStatusRequest", AvailabilityStatusRequest)
+	gen.addExample("v1.LimitsResponseExample", LimitsResponse)
+	gen.addExample("v1.AccountUsageListResponseExample", AccountUsageListResponse)
+	gen.addExample("v1.SettingsRequestExample", SettingsRequestExample)
+	gen.addExample("v1.SettingsResponseExample", SettingsResponseExample)
+	gen.addExample("v1.LabelsRequestExample", LabelsRequestExample)
+	gen.addExample("v1.LabelStatsListResponseExample", LabelStatsListResponseExample)
+	gen.addExample("v1.InstanceResponseStopExample", InstanceResponseStopExample)
+	gen.addExample("v1.InstanceResponseStartExample", InstanceResponseStartExample)
+	gen.addExample("v1.ResizeInstanceRequestExample", ResizeInstanceRequestExample)
+	gen.addExample("v1.InstanceResponseResizeExample", InstanceResponseResizeExample)
+	gen.addExample("v1.InstanceConsoleResponseExample", InstanceConsoleResponseExample)
+	gen.addExample("v1.InstanceListResponseExample", InstanceListResponseExample)
+	gen.addExample("v1.OrphanedInstanceListResponseExample", OrphanedInstanceListResponseExample)
+	gen.addExample("v1.ReservationArchiveResponseExample", ReservationArchiveResponseExample)
+	gen.addExample("v1.ReservationDiffResponseExample", ReservationDiffResponseExample)
+	gen.addExample("v1.ArtifactLinkResponseExample", ArtifactLinkResponseExample)
+	gen.addExample("v1.ReservationEventListResponseExample", ReservationEventListResponseExample)
+	gen.addExample("v1.ReservationTemplateRequestExample", ReservationTemplateRequestExample)
+	gen.addExample("v1.ReservationTemplateResponseExample", ReservationTemplateResponseExample)
+	gen.addExample("v1.ReservationTemplateListResponseExample", ReservationTemplateListResponseExample)
+	gen.addExample("v1.ReservationTemplateScheduleRequestExample", ReservationTemplateScheduleRequestExample)
+	gen.addExample("v1.ReservationTemplateScheduledResponseExample", ReservationTemplateScheduledResponseExample)
 	gen.addExample("v1.GenericReservationResponsePayloadSuccessExample", GenericReservationResponsePayloadSuccessExample)
 	gen.addExample("v1.GenericReservationResponsePayloadPendingExample", GenericReservationResponsePayloadPendingExample)
 	gen.addExample("v1.GenericReservationResponsePayloadFailureExample", GenericReservationResponsePayloadFailureExample)
+	gen.addExample("v1.GenericReservationResponsePayloadApprovedExample", GenericReservationResponsePayloadApprovedExample)
 	gen.addExample("v1.GenericReservationResponsePayloadListExample", GenericReservationResponsePayloadListExample)
+	gen.addExample("v1.ReservationStatusRequestExample", ReservationStatusRequestExample)
 	gen.addExample("v1.AwsReservationRequestPayloadExample", AwsReservationRequestPayloadExample)
 	gen.addExample("v1.AwsReservationResponsePayloadPendingExample", AwsReservationResponsePayloadPendingExample)
 	gen.addExample("v1.AwsReservationResponsePayloadDoneExample", AwsReservationResponsePayloadDoneExample)
@@ -76,6 +139,7 @@ func addErrorSchemas(gen *APISchemaGen) {
 	gen.addResponse("NotFound", "The requested resource was not found", "#/components/schemas/v1.ResponseError", ResponseNotFoundErrorExample)
 	gen.addResponse("InternalError", "The server encountered an internal error", "#/components/schemas/v1.ResponseError", ResponseErrorGenericExample)
 	gen.addResponse("BadRequest", "The request's parameters are not valid", "#/components/schemas/v1.ResponseError", ResponseBadRequestErrorExample)
+	gen.addResponse("Conflict", "The request conflicts with the current state of the resource", "#/components/schemas/v1.ResponseError", ResponseConflictErrorExample)
 }
 
 type APISchemaGen struct {
@@ -103,12 +167,16 @@ func NewSchemaGenerator() *APISchemaGen {
 	return s
 }
 
-// Schema customizer allowing tagging with nullable to work
+// Schema customizer allowing tagging with nullable to work and free-form objects
 var enableNullableOpt = openapi3gen.SchemaCustomizer(
-	func(_name string, _t reflect.Type, tag reflect.StructTag, schema *openapi3.Schema) error {
+	func(_name string, t reflect.Type, tag reflect.StructTag, schema *openapi3.Schema) error {
 		if tag.Get("nullable") == "true" {
 			schema.Nullable = true
 		}
+		// additional properties do not survive YAML marshalling, a plain object is generated instead
+		if t.Kind() == reflect.Map {
+			schema.AdditionalProperties = openapi3.AdditionalProperties{}
+		}
 		return nil
 	},
 )
@@ -30,15 +30,23 @@ func generateTypesEC2() error {
 	regionalTypes := clients.NewRegionalInstanceTypes()
 	ctx := context.Background()
 
-	defaultClient, err := clients.GetServiceEC2Client(ctx, "")
-	if err != nil {
-		return fmt.Errorf("unable to get default EC2 client: %w", err)
-	}
-
 	fmt.Println("Warning: Account must have all regions enabled, otherwise this will return 4xx")
-	regions, err := defaultClient.ListAllRegions(ctx)
-	if err != nil {
-		return fmt.Errorf("unable to list EC2 regions: %w", err)
+	var regions []clients.Region
+	for _, partition := range []string{clients.AWSPartitionStandard, clients.AWSPartitionGovCloud, clients.AWSPartitionChina} {
+		// partitions have separate service accounts, types are only generated for configured ones
+		if !clients.AWSPartitionEnabled(partition) {
+			fmt.Printf("Skipping partition %s without service account\n", partition)
+			continue
+		}
+		defaultClient, err := clients.GetServiceEC2Client(ctx, clients.AWSDefaultRegion(partition))
+		if err != nil {
+			return fmt.Errorf("unable to get default EC2 client of partition %s: %w", partition, err)
+		}
+		partitionRegions, err := defaultClient.ListAllRegions(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to list EC2 regions of partition %s: %w", partition, err)
+		}
+		regions = append(regions, partitionRegions...)
 	}
 
 	// This will throw AuthFailure "AWS was not able to validate the provided access credentials" unless all regions
@@ -72,7 +80,7 @@ func generateTypesEC2() error {
 		}
 	}
 
-	err = instanceTypes.Save("internal/preload/ec2_types.yaml")
+	err := instanceTypes.Save("internal/preload/ec2_types.yaml")
 	if err != nil {
 		return fmt.Errorf("unable to generate types: %w", err)
 	}
@@ -0,0 +1,109 @@
+// Package apiusage counts API calls and launches of accounts in memory and periodically adds
+// them to daily usage records (see dao.AccountDao). Counts not flushed before a process exits
+// are lost, the records are meant for capacity planning and not for billing.
+package apiusage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+)
+
+type key struct {
+	accountId int64
+	day       time.Time
+}
+
+var (
+	mu      sync.Mutex
+	pending = make(map[key]*models.AccountUsage)
+)
+
+// Enabled returns true when usage of accounts is counted.
+func Enabled() bool {
+	return config.Application.AccountUsage.FlushInterval > 0
+}
+
+// Day returns the day of the usage record of a time, which is midnight UTC.
+func Day(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+func add(ctx context.Context, apiCalls, launches, instances int64) {
+	accountId := identity.AccountIdOrNil(ctx)
+	if !Enabled() || accountId == 0 {
+		return
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	k := key{accountId: accountId, day: Day(time.Now())}
+	u, ok := pending[k]
+	if !ok {
+		u = &models.AccountUsage{AccountID: accountId, Day: k.day}
+		pending[k] = u
+	}
+	u.APICalls += apiCalls
+	u.Launches += launches
+	u.Instances += instances
+}
+
+// RecordAPICall counts an API call of the account in the context.
+func RecordAPICall(ctx context.Context) {
+	add(ctx, 1, 0, 0)
+}
+
+// RecordLaunch counts a launch of instances of the account in the context.
+func RecordLaunch(ctx context.Context, instances int64) {
+	add(ctx, 0, 1, instances)
+}
+
+// take returns and resets the pending counts.
+func take() []*models.AccountUsage {
+	mu.Lock()
+	defer mu.Unlock()
+	result := make([]*models.AccountUsage, 0, len(pending))
+	for k, u := range pending {
+		result = append(result, u)
+		delete(pending, k)
+	}
+	return result
+}
+
+// restore adds counts back when they could not be flushed.
+func restore(usage []*models.AccountUsage) {
+	mu.Lock()
+	defer mu.Unlock()
+	for _, u := range usage {
+		k := key{accountId: u.AccountID, day: u.Day}
+		if p, ok := pending[k]; ok {
+			p.APICalls += u.APICalls
+			p.Launches += u.Launches
+			p.Instances += u.Instances
+		} else {
+			pending[k] = u
+		}
+	}
+}
+
+// Flush adds the pending counts to the usage records in the database. Counts are kept for the
+// next flush when an error is returned.
+func Flush(ctx context.Context) (int, error) {
+	usage := take()
+	if len(usage) == 0 {
+		return 0, nil
+	}
+
+	err := dao.GetAccountDao(ctx).UnscopedAddUsage(ctx, usage)
+	if err != nil {
+		restore(usage)
+		return 0, fmt.Errorf("unable to add account usage: %w", err)
+	}
+	return len(usage), nil
+}
@@ -0,0 +1,69 @@
+package apiusage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlush(t *testing.T) {
+	config.Application.AccountUsage.FlushInterval = time.Minute
+	defer func() { config.Application.AccountUsage.FlushInterval = 0 }()
+
+	ctx := stubs.WithFaults(stubs.WithAccountDaoOne(context.Background()))
+	ctx = identity.WithAccountId(ctx, 1)
+
+	t.Run("records", func(t *testing.T) {
+		RecordAPICall(ctx)
+		RecordAPICall(ctx)
+		RecordLaunch(ctx, 3)
+		RecordAPICall(context.Background())
+
+		n, err := Flush(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		usage, err := dao.GetAccountDao(ctx).ListUsage(ctx, Day(time.Now()))
+		require.NoError(t, err)
+		require.Len(t, usage, 1)
+		assert.Equal(t, int64(2), usage[0].APICalls)
+		assert.Equal(t, int64(1), usage[0].Launches)
+		assert.Equal(t, int64(3), usage[0].Instances)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		n, err := Flush(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, n)
+	})
+
+	t.Run("restore", func(t *testing.T) {
+		RecordAPICall(ctx)
+		stubs.InjectError(ctx, "AccountDao.UnscopedAddUsage", 0, dao.ErrNoRows)
+		_, err := Flush(ctx)
+		require.Error(t, err)
+
+		RecordAPICall(ctx)
+		pending := take()
+		require.Len(t, pending, 1)
+		assert.Equal(t, int64(2), pending[0].APICalls)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		config.Application.AccountUsage.FlushInterval = 0
+		RecordAPICall(ctx)
+		assert.Empty(t, take())
+	})
+}
+
+func TestDay(t *testing.T) {
+	local := time.Date(2023, 5, 2, 1, 30, 0, 0, time.FixedZone("CEST", 2*60*60))
+	assert.Equal(t, time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC), Day(local))
+}
@@ -0,0 +1,244 @@
+// Package archiver stores reservations with their details and audit trail in S3-compatible
+// object storage before the retention cleanup deletes them. Archives are gzip-compressed JSON
+// documents with deterministic keys, so they can be retrieved by organization and reservation ID.
+package archiver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog"
+)
+
+// Number of expired reservations archived in one batch.
+const expiredBatchSize = 100
+
+// Page size of audit records of a reservation.
+const auditPageSize = 100
+
+// Object metadata of archives, access to archives is checked without downloading them.
+const (
+	metadataCreatedBy = "created-by-user-id"
+	metadataWorkspace = "workspace-id"
+	metadataProvider  = "provider"
+)
+
+// Info is the creator, workspace and provider of an archived reservation.
+type Info struct {
+	CreatedByUserID string
+	WorkspaceID     string
+	Provider        models.ProviderType
+}
+
+// Key returns the object key of an archived reservation.
+func Key(orgId string, reservationId int64) string {
+	return path.Join(config.Archive.Prefix, orgId, fmt.Sprintf("%d.json.gz", reservationId))
+}
+
+// Purge deletes all archived reservations of an organization and returns their number, nothing is
+// deleted when archival is not enabled.
+func Purge(ctx context.Context, orgId string) (int64, error) {
+	if !config.Archive.Enabled {
+		return 0, nil
+	}
+	storage, err := clients.GetObjectStorageClient(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("cannot get object storage client: %w", err)
+	}
+
+	deleted, err := storage.DeletePrefix(ctx, path.Join(config.Archive.Prefix, orgId)+"/")
+	if err != nil {
+		return deleted, fmt.Errorf("cannot delete archives: %w", err)
+	}
+	return deleted, nil
+}
+
+// ArchiveExpired archives reservations older than the reservation lifetime and deletes each of
+// them once it is stored. Reservations which fail to archive are kept for the next run and stop
+// the processing, the number of archived reservations is returned.
+func ArchiveExpired(ctx context.Context) (int, error) {
+	logger := zerolog.Ctx(ctx)
+	storage, err := clients.GetObjectStorageClient(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("cannot get object storage client: %w", err)
+	}
+
+	rDao := dao.GetReservationDao(ctx)
+	accounts := make(map[int64]*models.Account)
+	var archived int
+	for {
+		reservations, err := rDao.UnscopedListExpired(ctx, expiredBatchSize)
+		if err != nil {
+			return archived, fmt.Errorf("cannot list expired reservations: %w", err)
+		}
+
+		for _, reservation := range reservations {
+			account, ok := accounts[reservation.AccountID]
+			if !ok {
+				account, err = dao.GetAccountDao(ctx).GetById(ctx, reservation.AccountID)
+				if err != nil {
+					return archived, fmt.Errorf("cannot get account %d: %w", reservation.AccountID, err)
+				}
+				accounts[reservation.AccountID] = account
+			}
+
+			aCtx := identity.WithAccountId(ctx, account.ID)
+			err = archive(aCtx, storage, account, reservation)
+			if err != nil {
+				return archived, fmt.Errorf("cannot archive reservation %d: %w", reservation.ID, err)
+			}
+
+			err = rDao.Delete(aCtx, reservation.ID)
+			if err != nil {
+				return archived, fmt.Errorf("cannot delete archived reservation %d: %w", reservation.ID, err)
+			}
+			archived++
+		}
+
+		if len(reservations) < expiredBatchSize {
+			break
+		}
+	}
+
+	logger.Trace().Msgf("Archived %d reservation(s) older than %s", archived, config.Reservation.Lifetime.String())
+	return archived, nil
+}
+
+func archive(ctx context.Context, storage clients.ObjectStorage, account *models.Account, reservation *models.Reservation) error {
+	detail, err := detailResponse(ctx, reservation)
+	if err != nil {
+		return err
+	}
+
+	var records []*models.AuditRecord
+	for offset := int64(0); ; offset += auditPageSize {
+		page, err := dao.GetAuditDao(ctx).ListByReservation(ctx, reservation.ID, auditPageSize, offset)
+		if err != nil {
+			return fmt.Errorf("cannot list audit records: %w", err)
+		}
+		records = append(records, page...)
+		if len(page) < auditPageSize {
+			break
+		}
+	}
+
+	document, err := payloads.NewReservationArchiveResponse(reservation, detail, records)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	err = json.NewEncoder(gz).Encode(document)
+	if err != nil {
+		return fmt.Errorf("cannot encode archive: %w", err)
+	}
+	if err = gz.Close(); err != nil {
+		return fmt.Errorf("cannot compress archive: %w", err)
+	}
+
+	metadata := map[string]string{
+		metadataCreatedBy: reservation.CreatedByUserID,
+		metadataWorkspace: reservation.WorkspaceID,
+		metadataProvider:  reservation.Provider.String(),
+	}
+	return storage.PutObject(ctx, Key(account.OrgID, reservation.ID), "application/gzip", metadata, buf.Bytes())
+}
+
+func detailResponse(ctx context.Context, reservation *models.Reservation) (render.Renderer, error) {
+	rDao := dao.GetReservationDao(ctx)
+	instances, err := rDao.ListInstances(ctx, reservation.ID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list instances: %w", err)
+	}
+
+	switch reservation.Provider {
+	case models.ProviderTypeAWS:
+		awsReservation, err := rDao.GetAWSById(ctx, reservation.ID)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get AWS reservation: %w", err)
+		}
+		return payloads.NewAWSReservationResponse(awsReservation, instances), nil
+	case models.ProviderTypeAzure:
+		azureReservation, err := rDao.GetAzureById(ctx, reservation.ID)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get Azure reservation: %w", err)
+		}
+		return payloads.NewAzureReservationResponse(azureReservation, instances), nil
+	case models.ProviderTypeGCP:
+		gcpReservation, err := rDao.GetGCPById(ctx, reservation.ID)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get GCP reservation: %w", err)
+		}
+		return payloads.NewGCPReservationResponse(gcpReservation, instances), nil
+	case models.ProviderTypeNoop, models.ProviderTypeUnknown:
+	}
+	return nil, nil
+}
+
+// Get returns an archived reservation of the organization or clients.ObjectNotFoundErr.
+func Get(ctx context.Context, orgId string, reservationId int64) (*payloads.ReservationArchiveResponse, error) {
+	storage, err := clients.GetObjectStorageClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get object storage client: %w", err)
+	}
+
+	data, err := storage.GetObject(ctx, Key(orgId, reservationId))
+	if err != nil {
+		return nil, err
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("cannot decompress archive: %w", err)
+	}
+	defer gz.Close()
+
+	result := &payloads.ReservationArchiveResponse{}
+	if err = json.NewDecoder(gz).Decode(result); err != nil {
+		return nil, fmt.Errorf("cannot decode archive: %w", err)
+	}
+	return result, nil
+}
+
+// Stat returns creator, workspace and provider of an archived reservation of the organization or
+// clients.ObjectNotFoundErr. Archives stored without metadata are downloaded.
+func Stat(ctx context.Context, orgId string, reservationId int64) (*Info, error) {
+	storage, err := clients.GetObjectStorageClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get object storage client: %w", err)
+	}
+
+	metadata, err := storage.HeadObject(ctx, Key(orgId, reservationId))
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	if provider, ok := metadata[metadataProvider]; ok {
+		return &Info{
+			CreatedByUserID: metadata[metadataCreatedBy],
+			WorkspaceID:     metadata[metadataWorkspace],
+			Provider:        models.ProviderTypeFromString(provider),
+		}, nil
+	}
+
+	archive, err := Get(ctx, orgId, reservationId)
+	if err != nil {
+		return nil, err
+	}
+	return &Info{
+		CreatedByUserID: archive.CreatedByUserID,
+		WorkspaceID:     archive.WorkspaceID,
+		Provider:        models.ProviderType(archive.Reservation.Provider),
+	}, nil
+}
@@ -0,0 +1,93 @@
+package archiver_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/archiver"
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	clientStubs "github.com/RHEnVision/provisioning-backend/internal/clients/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/factories"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/identity"
+	_ "github.com/RHEnVision/provisioning-backend/internal/testing/initialization"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveExpired(t *testing.T) {
+	config.Reservation.Lifetime = 24 * time.Hour
+	config.Archive.Prefix = "reservations"
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = identity.WithTenant(t, ctx)
+	ctx = stubs.WithReservationDao(ctx)
+	ctx = stubs.WithPubkeyDao(ctx)
+	ctx = stubs.WithAuditDao(ctx)
+	ctx = clientStubs.WithObjectStorageClient(ctx)
+
+	pk := factories.NewPubkeyRSA()
+	err := stubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to add stubbed key")
+
+	var reservations []*models.AWSReservation
+	for _, createdAt := range []time.Time{time.Now().Add(-48 * time.Hour), time.Now()} {
+		reservation := &models.AWSReservation{
+			PubkeyID: pk.ID,
+			SourceID: "1",
+			ImageID:  "ami-0c830793775595d4b",
+			Detail:   &models.AWSDetail{Region: "us-east-1", InstanceType: "t3.small", Amount: 1},
+		}
+		reservation.AccountID = 1
+		reservation.Provider = models.ProviderTypeAWS
+		reservation.CreatedAt = createdAt
+		err = stubs.AddAWSReservation(ctx, reservation)
+		require.NoError(t, err, "failed to add stubbed reservation")
+		reservations = append(reservations, reservation)
+	}
+	expired := reservations[0]
+	record := &models.AuditRecord{ReservationID: expired.ID, Action: models.AuditActionInstanceResize, Details: map[string]string{"instance_type": "t3.large"}}
+	err = dao.GetAuditDao(ctx).Create(ctx, record)
+	require.NoError(t, err, "failed to add stubbed audit record")
+
+	archived, err := archiver.ArchiveExpired(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, archived)
+	assert.Equal(t, []string{archiver.Key(identity.DefaultOrgId, expired.ID)}, clientStubs.ObjectKeys(ctx))
+
+	t.Run("get", func(t *testing.T) {
+		archive, err := archiver.Get(ctx, identity.DefaultOrgId, expired.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, expired.ID, archive.Reservation.ID)
+		assert.Equal(t, int(models.ProviderTypeAWS), archive.Reservation.Provider)
+		assert.Equal(t, "us-east-1", archive.Detail["region"])
+		assert.Equal(t, "t3.small", archive.Detail["instance_type"])
+		require.Len(t, archive.AuditTrail, 1)
+		assert.Equal(t, string(models.AuditActionInstanceResize), archive.AuditTrail[0].Action)
+		assert.Equal(t, "t3.large", archive.AuditTrail[0].Details["instance_type"])
+	})
+
+	t.Run("stat", func(t *testing.T) {
+		info, err := archiver.Stat(ctx, identity.DefaultOrgId, expired.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.ProviderTypeAWS, info.Provider)
+		assert.Equal(t, expired.CreatedByUserID, info.CreatedByUserID)
+
+		_, err = archiver.Stat(ctx, identity.DefaultOrgId, reservations[1].ID)
+		require.ErrorIs(t, err, clients.ObjectNotFoundErr)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		_, err := archiver.Get(ctx, identity.DefaultOrgId, reservations[1].ID)
+		require.ErrorIs(t, err, clients.ObjectNotFoundErr)
+	})
+}
+
+func TestKey(t *testing.T) {
+	config.Archive.Prefix = "reservations"
+	assert.Equal(t, "reservations/13/42.json.gz", archiver.Key("13", 42))
+}
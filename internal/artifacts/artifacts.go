@@ -0,0 +1,145 @@
+// Package artifacts serves files produced by the application (reservation archives and support
+// bundles) through short-lived signed URLs of the object storage, so large payloads are downloaded
+// directly by clients and not proxied through the API.
+package artifacts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+)
+
+// supportBundlePrefix is the object key prefix of support bundles, they are only kept while their
+// download links are valid.
+const supportBundlePrefix = "support"
+
+// ErrDisabled is returned when object storage is not configured.
+var ErrDisabled = errors.New("artifacts require object storage, archival is not enabled")
+
+// Link is a signed download URL of an artifact.
+type Link struct {
+	// URL downloads the artifact without credentials until it expires.
+	URL string
+
+	// ExpiresAt is the time when the URL expires.
+	ExpiresAt time.Time
+}
+
+// Enabled returns true when artifacts can be stored and signed, they share the object storage of
+// reservation archives.
+func Enabled() bool {
+	return config.Archive.Enabled
+}
+
+// Sign returns a signed download link of an object, callers check the object exists and the user
+// is allowed to read it.
+func Sign(ctx context.Context, key string) (*Link, error) {
+	if !Enabled() {
+		return nil, ErrDisabled
+	}
+	storage, err := clients.GetObjectStorageClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get object storage client: %w", err)
+	}
+	return presign(ctx, storage, key)
+}
+
+// Store uploads an artifact and returns its signed download link.
+func Store(ctx context.Context, key, contentType string, metadata map[string]string, data []byte) (*Link, error) {
+	if !Enabled() {
+		return nil, ErrDisabled
+	}
+	storage, err := clients.GetObjectStorageClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get object storage client: %w", err)
+	}
+
+	if err = storage.PutObject(ctx, key, contentType, metadata, data); err != nil {
+		return nil, fmt.Errorf("cannot store artifact: %w", err)
+	}
+	return presign(ctx, storage, key)
+}
+
+func presign(ctx context.Context, storage clients.ObjectStorage, key string) (*Link, error) {
+	expires := config.Archive.LinkExpiration
+	signed, err := storage.PresignGetObject(ctx, key, expires)
+	if err != nil {
+		return nil, fmt.Errorf("cannot sign artifact URL: %w", err)
+	}
+	return &Link{
+		URL:       signed,
+		ExpiresAt: time.Now().Add(expires).Truncate(time.Second),
+	}, nil
+}
+
+// SupportBundleKey returns a unique object key of a support bundle of a reservation, links of older
+// bundles of the same reservation keep working until they expire. The creation time is a part of
+// the key, so expired bundles are found without reading them.
+func SupportBundleKey(orgId string, reservationId int64, createdAt time.Time) string {
+	return path.Join(supportBundlePrefix, orgId, fmt.Sprintf("reservation-%d-%d.json", reservationId, createdAt.UnixNano()))
+}
+
+// supportBundleCreatedAt returns the creation time encoded in a support bundle key.
+func supportBundleCreatedAt(key string) (time.Time, bool) {
+	name := strings.TrimSuffix(path.Base(key), ".json")
+	nanos, err := strconv.ParseInt(name[strings.LastIndex(name, "-")+1:], 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(0, nanos), true
+}
+
+// DeleteExpiredSupportBundles deletes support bundles with expired download links and returns their
+// number. Nothing is deleted when object storage is not configured.
+func DeleteExpiredSupportBundles(ctx context.Context) (int64, error) {
+	if !Enabled() {
+		return 0, nil
+	}
+	storage, err := clients.GetObjectStorageClient(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("cannot get object storage client: %w", err)
+	}
+
+	keys, err := storage.ListObjects(ctx, supportBundlePrefix+"/")
+	if err != nil {
+		return 0, fmt.Errorf("cannot list support bundles: %w", err)
+	}
+	expiredBefore := time.Now().Add(-config.Archive.LinkExpiration)
+	var deleted int64
+	for _, key := range keys {
+		createdAt, ok := supportBundleCreatedAt(key)
+		if !ok || !createdAt.Before(expiredBefore) {
+			continue
+		}
+		if err = storage.DeleteObject(ctx, key); err != nil {
+			return deleted, fmt.Errorf("cannot delete support bundle: %w", err)
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// PurgeSupportBundles deletes all support bundles of an organization and returns their number.
+// Nothing is deleted when object storage is not configured.
+func PurgeSupportBundles(ctx context.Context, orgId string) (int64, error) {
+	if !Enabled() {
+		return 0, nil
+	}
+	storage, err := clients.GetObjectStorageClient(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("cannot get object storage client: %w", err)
+	}
+
+	deleted, err := storage.DeletePrefix(ctx, path.Join(supportBundlePrefix, orgId)+"/")
+	if err != nil {
+		return deleted, fmt.Errorf("cannot delete support bundles: %w", err)
+	}
+	return deleted, nil
+}
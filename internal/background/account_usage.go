@@ -0,0 +1,42 @@
+package background
+
+import (
+	"context"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/apiusage"
+	"github.com/rs/zerolog"
+)
+
+// accountUsageFlush periodically writes usage counted by the process to the database.
+func accountUsageFlush(ctx context.Context, interval time.Duration) {
+	logger := zerolog.Ctx(ctx)
+	logger.Debug().Msgf("Started account usage routine with tick interval %.2f seconds", interval.Seconds())
+	defer func() {
+		logger.Debug().Msgf("Account usage routine exited")
+	}()
+	ticker := time.NewTicker(interval)
+
+	for {
+		select {
+		case <-ticker.C:
+			flushAccountUsage(ctx)
+
+		case <-ctx.Done():
+			ticker.Stop()
+			return
+		}
+	}
+}
+
+// flushAccountUsage writes usage counted since the last flush, records which were not written
+// are kept for the next flush.
+func flushAccountUsage(ctx context.Context) {
+	logger := zerolog.Ctx(ctx)
+	count, err := apiusage.Flush(ctx)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Unable to flush account usage, retrying on the next flush")
+	} else if count > 0 {
+		logger.Trace().Msgf("Flushed %d account usage records", count)
+	}
+}
@@ -0,0 +1,31 @@
+package background
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/apiusage"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStopApiFlushesAccountUsage(t *testing.T) {
+	config.Application.AccountUsage.FlushInterval = time.Minute
+	defer func() { config.Application.AccountUsage.FlushInterval = 0 }()
+
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = identity.WithAccountId(ctx, 1)
+
+	apiusage.RecordAPICall(ctx)
+	StopApi(ctx)
+
+	usage, err := dao.GetAccountDao(ctx).ListUsage(ctx, apiusage.Day(time.Now()))
+	require.NoError(t, err)
+	require.Len(t, usage, 1)
+	assert.Equal(t, int64(1), usage[0].APICalls)
+}
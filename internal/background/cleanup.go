@@ -4,6 +4,9 @@ import (
 	"context"
 	"time"
 
+	"github.com/RHEnVision/provisioning-backend/internal/archiver"
+	"github.com/RHEnVision/provisioning-backend/internal/artifacts"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
 	"github.com/RHEnVision/provisioning-backend/internal/dao"
 	"github.com/rs/zerolog"
 )
@@ -31,11 +34,53 @@ func dbCleanup(ctx context.Context, sleep time.Duration) {
 	}
 }
 
+// cleanupReservations deletes reservations older than the lifetime. With archival enabled,
+// only reservations stored in object storage are deleted.
 func cleanupReservations(ctx context.Context) {
 	logger := zerolog.Ctx(ctx)
+	if config.Archive.Enabled {
+		archived, err := archiver.ArchiveExpired(ctx)
+		if err != nil {
+			logger.Error().Err(err).Msgf("Error while archiving reservations, %d archived", archived)
+		}
+		return
+	}
+
 	sdao := dao.GetReservationDao(ctx)
 	err := sdao.Cleanup(ctx)
 	if err != nil {
 		logger.Error().Err(err).Msg("Error while performing reservation cleanup")
 	}
 }
+
+func supportBundleCleanup(ctx context.Context, sleep time.Duration) {
+	logger := zerolog.Ctx(ctx)
+	logger.Debug().Msgf("Started support bundle cleanup %s", sleep.String())
+	defer func() {
+		logger.Debug().Msgf("Support bundle cleanup routine exited")
+	}()
+
+	ticker := time.NewTicker(sleep)
+
+	for {
+		select {
+		case <-ticker.C:
+			cleanupSupportBundles(ctx)
+
+		case <-ctx.Done():
+			ticker.Stop()
+			return
+		}
+	}
+}
+
+// cleanupSupportBundles deletes support bundles once their download links expired.
+func cleanupSupportBundles(ctx context.Context) {
+	logger := zerolog.Ctx(ctx)
+	deleted, err := artifacts.DeleteExpiredSupportBundles(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msgf("Error while deleting expired support bundles, %d deleted", deleted)
+		return
+	}
+	logger.Trace().Msgf("Deleted %d expired support bundle(s)", deleted)
+}
@@ -0,0 +1,38 @@
+package background
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/artifacts"
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	clientStubs "github.com/RHEnVision/provisioning-backend/internal/clients/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCleanupSupportBundles(t *testing.T) {
+	ctx := clientStubs.WithObjectStorageClient(context.Background())
+	defer func(enabled bool, expiration time.Duration) {
+		config.Archive.Enabled = enabled
+		config.Archive.LinkExpiration = expiration
+	}(config.Archive.Enabled, config.Archive.LinkExpiration)
+	config.Archive.Enabled = true
+	config.Archive.LinkExpiration = 15 * time.Minute
+
+	storage, err := clients.GetObjectStorageClient(ctx)
+	require.NoError(t, err)
+	expired := artifacts.SupportBundleKey(identity.DefaultOrgId, 1, time.Now().Add(-time.Hour))
+	fresh := artifacts.SupportBundleKey(identity.DefaultOrgId, 2, time.Now())
+	for _, key := range []string{expired, fresh} {
+		err = storage.PutObject(ctx, key, "application/json", nil, []byte("{}"))
+		require.NoError(t, err)
+	}
+
+	cleanupSupportBundles(ctx)
+
+	assert.Equal(t, []string{fresh}, clientStubs.ObjectKeys(ctx))
+}
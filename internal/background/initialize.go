@@ -22,12 +22,34 @@ func InitializeApi(ctx context.Context) {
 
 	// start availability request batch sender
 	go sendAvailabilityRequestMessages(ctx, availabilityStatusBatchSize, 5*time.Second)
+
+	// write API calls and launches counted by this process
+	if config.Application.AccountUsage.FlushInterval > 0 {
+		go accountUsageFlush(ctx, config.Application.AccountUsage.FlushInterval)
+	}
+
+	// launch scheduled templates, claiming runs makes this safe for multiple API processes
+	if config.Reservation.ScheduleInterval > 0 {
+		go scheduledLaunches(ctx, config.Reservation.ScheduleInterval)
+	}
+}
+
+// StopApi writes account usage counted since the last tick. It must be called after the REST API
+// server is shut down, so usage of the last requests is not lost on exit.
+func StopApi(ctx context.Context) {
+	flushAccountUsage(ctx)
 }
 
 // InitializeWorker starts background goroutines for worker processes.
 // Use context cancellation to stop them.
 func InitializeWorker(ctx context.Context) {
-	// no background goroutines at the moment
+	logger := zerolog.Ctx(ctx).With().Bool("background", true).Logger()
+	ctx = logger.WithContext(ctx)
+
+	// enqueue launch jobs which wait for slots of crashed or hung jobs
+	if config.Worker.LaunchLimit > 0 && config.Worker.ResumeInterval > 0 {
+		go resumeWaitingJobs(ctx, config.Worker.ResumeInterval)
+	}
 }
 
 // InitializeStats starts background goroutines for the statuser process.
@@ -46,4 +68,24 @@ func InitializeStats(ctx context.Context) {
 	if config.Reservation.CleanupEnabled {
 		go dbCleanup(ctx, config.Reservation.CleanupInterval)
 	}
+
+	// delete support bundles with expired download links
+	if config.Archive.Enabled {
+		go supportBundleCleanup(ctx, config.Archive.LinkExpiration)
+	}
+
+	// reconcile instances in cloud accounts with the database
+	if config.Reservation.OrphanInterval > 0 {
+		go orphanDetection(ctx, config.Reservation.OrphanInterval)
+	}
+
+	// send usage records of launched and terminated instances
+	if config.Application.Usage.Enabled && config.Application.Usage.Interval > 0 {
+		go usageReconciliation(ctx, config.Application.Usage.Interval)
+	}
+
+	// purge data of deleted orgs
+	if config.BusEnabled() && config.Application.TenantPurge {
+		go tenantPurge(ctx)
+	}
 }
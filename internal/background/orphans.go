@@ -0,0 +1,296 @@
+package background
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/RHEnVision/provisioning-backend/internal/jobs"
+	"github.com/RHEnVision/provisioning-backend/internal/logging"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/notifications"
+	"github.com/rs/zerolog"
+)
+
+// Page size of stored instances compared with a cloud account.
+const orphanInstancePageSize = 100
+
+func orphanDetection(ctx context.Context, sleep time.Duration) {
+	logger := zerolog.Ctx(ctx)
+	logger.Debug().Msgf("Started orphan detection %s", sleep.String())
+	defer func() {
+		logger.Debug().Msgf("Orphan detection routine exited")
+	}()
+
+	ticker := time.NewTicker(sleep)
+
+	for {
+		select {
+		case <-ticker.C:
+			detectOrphans(ctx)
+
+		case <-ctx.Done():
+			ticker.Stop()
+			return
+		}
+	}
+}
+
+// detectOrphans reconciles instances of all sources with launched instances. Sources are
+// processed one by one with a service identity of the account.
+func detectOrphans(ctx context.Context) {
+	logger := zerolog.Ctx(ctx)
+	sources, err := dao.GetReservationDao(ctx).UnscopedListInstanceSources(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg("Error while listing sources with instances")
+		return
+	}
+
+	accounts := make(map[int64]*models.Account)
+	for _, source := range sources {
+		sCtx := logging.WithContextLogger(identity.WithAccountId(ctx, source.AccountID))
+		sLogger := zerolog.Ctx(sCtx).With().Str("source_id", source.SourceID).Str("region", source.Region).Logger()
+		sCtx = sLogger.WithContext(sCtx)
+
+		account, ok := accounts[source.AccountID]
+		if !ok {
+			account, err = dao.GetAccountDao(sCtx).GetById(sCtx, source.AccountID)
+			if err != nil {
+				sLogger.Warn().Err(err).Msg("Unable to get account, skipping orphan detection")
+				continue
+			}
+			accounts[source.AccountID] = account
+		}
+
+		err = reconcileSource(accountContext(sCtx, account), source)
+		if err != nil {
+			sLogger.Warn().Err(err).Msg("Orphan detection of source failed")
+		}
+	}
+}
+
+// accountContext returns context with a service identity of the account.
+func accountContext(ctx context.Context, account *models.Account) context.Context {
+	principal := identity.Principal{}
+	principal.Identity.OrgID = account.OrgID
+	principal.Identity.AccountNumber = account.AccountNumber.String
+	ctx = identity.WithIdentity(ctx, identity.ServicePrincipal(principal))
+	return logging.WithContextLogger(identity.WithAccountId(ctx, account.ID))
+}
+
+// cloudInstances lists tagged instances of a source and checks instances which were not found.
+type cloudInstances struct {
+	list       func(ctx context.Context) ([]*clients.TaggedInstance, error)
+	powerState func(ctx context.Context, instance *models.Instance) (models.PowerState, error)
+}
+
+func newCloudInstances(ctx context.Context, source *models.InstanceSource, auth *clients.Authentication) (*cloudInstances, error) {
+	switch source.Provider {
+	case models.ProviderTypeAWS:
+		ec2Client, err := clients.GetEC2Client(ctx, auth, source.Region)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create new ec2 client from config: %w", err)
+		}
+		return &cloudInstances{
+			list: ec2Client.ListTaggedInstances,
+			powerState: func(ctx context.Context, instance *models.Instance) (models.PowerState, error) {
+				return ec2Client.GetPowerState(ctx, instance.InstanceID)
+			},
+		}, nil
+	case models.ProviderTypeAzure:
+		azureClient, err := clients.GetAzureClient(ctx, auth)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create new azure client: %w", err)
+		}
+		return &cloudInstances{
+			list: azureClient.ListTaggedInstances,
+			powerState: func(ctx context.Context, instance *models.Instance) (models.PowerState, error) {
+				return azureClient.GetPowerState(ctx, instance.InstanceID)
+			},
+		}, nil
+	case models.ProviderTypeGCP:
+		gcpClient, err := clients.GetGCPClient(ctx, auth)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create new GCP client: %w", err)
+		}
+		return &cloudInstances{
+			list: gcpClient.ListTaggedInstances,
+			powerState: func(ctx context.Context, instance *models.Instance) (models.PowerState, error) {
+				return gcpClient.GetPowerState(ctx, instance.InstanceID, instance.Location)
+			},
+		}, nil
+	case models.ProviderTypeNoop, models.ProviderTypeUnknown:
+	}
+	return nil, clients.UnknownProviderErr
+}
+
+// reconcileSource compares tagged instances of a source with stored instances, stores the
+// orphans found and notifies about the newly detected ones.
+func reconcileSource(ctx context.Context, source *models.InstanceSource) error {
+	sourcesClient, err := clients.GetSourcesClient(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot get sources client: %w", err)
+	}
+	auth, err := sourcesClient.GetAuthentication(ctx, source.SourceID)
+	if err != nil {
+		return fmt.Errorf("cannot get authentication: %w", err)
+	}
+	if err = auth.MustBe(source.Provider); err != nil {
+		return fmt.Errorf("unexpected authentication: %w", err)
+	}
+
+	cloud, err := newCloudInstances(ctx, source, auth)
+	if err != nil {
+		return err
+	}
+	tagged, err := cloud.list(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot list tagged instances: %w", err)
+	}
+
+	rDao := dao.GetReservationDao(ctx)
+	filter := &dao.InstanceFilter{Provider: source.Provider, Region: source.Region, SourceID: source.SourceID}
+	var stored []*models.Instance
+	for offset := int64(0); ; offset += orphanInstancePageSize {
+		page, err := rDao.ListAllInstances(ctx, filter, orphanInstancePageSize, offset)
+		if err != nil {
+			return fmt.Errorf("cannot list stored instances: %w", err)
+		}
+		stored = append(stored, page...)
+		if len(page) < orphanInstancePageSize {
+			break
+		}
+	}
+
+	orphans := findOrphans(tagged, stored)
+	orphans, gone := confirmMissing(ctx, cloud, stored, orphans)
+
+	detected, err := dao.GetOrphanDao(ctx).ReplaceBySource(ctx, source.Provider, source.SourceID, source.Region, orphans)
+	if err != nil {
+		return fmt.Errorf("cannot store orphaned instances: %w", err)
+	}
+
+	terminated := terminatedInstances(stored, gone)
+	if source.Provider == models.ProviderTypeAWS {
+		releaseElasticIPs(ctx, auth, terminated)
+	}
+	deleteDNSRecords(ctx, auth, terminated)
+
+	// missing instances are not stored as terminated, terminated records are sent for them here
+	if config.Application.Usage.Enabled {
+		err = sendUsage(ctx, gone, func(_ *models.Instance) bool { return true })
+		if err != nil {
+			zerolog.Ctx(ctx).Warn().Err(err).Msg("Unable to send usage records of missing instances")
+		}
+	}
+
+	zerolog.Ctx(ctx).Info().Msgf("Found %d orphaned instance(s), %d newly detected", len(orphans), len(detected))
+	if len(detected) > 0 {
+		notifications.GetNotificationClient(ctx).OrphansDetected(ctx, detected)
+	}
+	return nil
+}
+
+// findOrphans returns tagged instances which are not stored or are stored as terminated and
+// stored instances which are not tagged. IDs are compared case-insensitively, because Azure
+// resource IDs are not case-sensitive.
+func findOrphans(tagged []*clients.TaggedInstance, stored []*models.Instance) []*models.OrphanedInstance {
+	storedByID := make(map[string]*models.Instance, len(stored))
+	for _, instance := range stored {
+		storedByID[strings.ToLower(instance.InstanceID)] = instance
+	}
+	taggedByID := make(map[string]bool, len(tagged))
+
+	var orphans []*models.OrphanedInstance
+	for _, instance := range tagged {
+		taggedByID[strings.ToLower(instance.ID)] = true
+		if s, ok := storedByID[strings.ToLower(instance.ID)]; ok && s.PowerState != models.PowerStateTerminated {
+			continue
+		}
+		orphans = append(orphans, &models.OrphanedInstance{
+			Location:      instance.Location,
+			InstanceID:    instance.ID,
+			ReservationID: instance.ReservationID,
+			Kind:          models.OrphanKindUntracked,
+		})
+	}
+	for _, instance := range stored {
+		if instance.PowerState == models.PowerStateTerminated || taggedByID[strings.ToLower(instance.InstanceID)] {
+			continue
+		}
+		orphans = append(orphans, &models.OrphanedInstance{
+			Location:      instance.Location,
+			InstanceID:    instance.InstanceID,
+			ReservationID: instance.ReservationID,
+			Kind:          models.OrphanKindMissing,
+		})
+	}
+	return orphans
+}
+
+// confirmMissing keeps only missing instances which do not exist, instances launched before
+// tagging was introduced exist without the tag. Instances which cannot be checked are kept. Stored
+// instances confirmed terminated are returned too.
+func confirmMissing(ctx context.Context, cloud *cloudInstances, stored []*models.Instance, orphans []*models.OrphanedInstance) ([]*models.OrphanedInstance, []*models.Instance) {
+	storedByID := make(map[string]*models.Instance, len(stored))
+	for _, instance := range stored {
+		storedByID[instance.InstanceID] = instance
+	}
+
+	result := make([]*models.OrphanedInstance, 0, len(orphans))
+	var gone []*models.Instance
+	for _, orphan := range orphans {
+		if orphan.Kind == models.OrphanKindMissing {
+			state, err := cloud.powerState(ctx, storedByID[orphan.InstanceID])
+			switch {
+			case err != nil:
+				zerolog.Ctx(ctx).Warn().Err(err).Str("instance_id", orphan.InstanceID).Msg("Unable to check missing instance")
+			case state != models.PowerStateTerminated:
+				continue
+			default:
+				gone = append(gone, storedByID[orphan.InstanceID])
+			}
+		}
+		result = append(result, orphan)
+	}
+	return result, gone
+}
+
+// terminatedInstances returns stored instances which are stored as terminated and missing
+// instances which were confirmed terminated.
+func terminatedInstances(stored []*models.Instance, gone []*models.Instance) []*models.Instance {
+	var result []*models.Instance
+	for _, instance := range stored {
+		if instance.PowerState == models.PowerStateTerminated {
+			result = append(result, instance)
+		}
+	}
+	return append(result, gone...)
+}
+
+// releaseElasticIPs releases addresses allocated for terminated instances, they would be charged
+// until released. Errors are only logged, the release is retried in the next run.
+func releaseElasticIPs(ctx context.Context, auth *clients.Authentication, terminated []*models.Instance) {
+	for _, instance := range terminated {
+		err := jobs.ReleaseInstanceElasticIP(ctx, instance, auth)
+		if err != nil {
+			zerolog.Ctx(ctx).Warn().Err(err).Str("instance_id", instance.InstanceID).Msg("Unable to release Elastic IP")
+		}
+	}
+}
+
+// deleteDNSRecords deletes records created for terminated instances, the address can be reused
+// by other resources. Errors are only logged, the deletion is retried in the next run.
+func deleteDNSRecords(ctx context.Context, auth *clients.Authentication, terminated []*models.Instance) {
+	for _, instance := range terminated {
+		err := jobs.DeleteInstanceDNSRecord(ctx, instance, auth)
+		if err != nil {
+			zerolog.Ctx(ctx).Warn().Err(err).Str("instance_id", instance.InstanceID).Msg("Unable to delete DNS record")
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package background
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	clientStubs "github.com/RHEnVision/provisioning-backend/internal/clients/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/factories"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindOrphans(t *testing.T) {
+	tagged := []*clients.TaggedInstance{
+		{ID: "/subscriptions/S/resourceGroups/RG/providers/Microsoft.Compute/virtualMachines/vm-1", ReservationID: 1, Location: "eastus"},
+		{ID: "vm-2", ReservationID: 2, Location: "eastus"},
+		{ID: "vm-3", ReservationID: 3, Location: "eastus"},
+	}
+	stored := []*models.Instance{
+		{ReservationInstance: models.ReservationInstance{ReservationID: 1, InstanceID: "/subscriptions/s/resourcegroups/rg/providers/Microsoft.Compute/virtualMachines/vm-1", PowerState: models.PowerStateRunning}},
+		{ReservationInstance: models.ReservationInstance{ReservationID: 2, InstanceID: "vm-2", PowerState: models.PowerStateTerminated}},
+		{ReservationInstance: models.ReservationInstance{ReservationID: 4, InstanceID: "vm-4", PowerState: models.PowerStateStopped}, Location: "westus"},
+		{ReservationInstance: models.ReservationInstance{ReservationID: 5, InstanceID: "vm-5", PowerState: models.PowerStateTerminated}},
+	}
+
+	orphans := findOrphans(tagged, stored)
+
+	require.Len(t, orphans, 3)
+	assert.Equal(t, "vm-2", orphans[0].InstanceID)
+	assert.Equal(t, models.OrphanKindUntracked, orphans[0].Kind)
+	assert.Equal(t, "vm-3", orphans[1].InstanceID)
+	assert.Equal(t, models.OrphanKindUntracked, orphans[1].Kind)
+	assert.Equal(t, int64(3), orphans[1].ReservationID)
+	assert.Equal(t, "vm-4", orphans[2].InstanceID)
+	assert.Equal(t, models.OrphanKindMissing, orphans[2].Kind)
+	assert.Equal(t, "westus", orphans[2].Location)
+}
+
+func TestDetectOrphans(t *testing.T) {
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = identity.WithTenant(t, ctx)
+	ctx = stubs.WithReservationDao(ctx)
+	ctx = stubs.WithOrphanDao(ctx)
+	ctx = stubs.WithPubkeyDao(ctx)
+	ctx = clientStubs.WithSourcesClient(ctx)
+	ctx = clientStubs.WithEC2Client(ctx)
+
+	pk := factories.NewPubkeyRSA()
+	err := stubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to add stubbed key")
+	reservation := &models.AWSReservation{
+		PubkeyID: pk.ID,
+		SourceID: "1",
+		ImageID:  "ami-0c830793775595d4b",
+		Detail:   &models.AWSDetail{Region: "us-east-1", InstanceType: "t3.small", Amount: 3},
+	}
+	reservation.AccountID = 1
+	reservation.Provider = models.ProviderTypeAWS
+	err = stubs.AddAWSReservation(ctx, reservation)
+	require.NoError(t, err, "failed to add stubbed reservation")
+	// the first one is tagged, the other ones are not and only the terminated one is gone
+	for i, id := range []string{"i-0a4caa2cf5b097ce1", clientStubs.TerminatedInstanceID, "i-0untagged00000000"} {
+		instance := &models.ReservationInstance{ReservationID: reservation.ID, InstanceID: id, PowerState: models.PowerStateRunning}
+		instance.ElasticIP = models.ElasticIP{AllocationID: fmt.Sprintf("eipalloc-%017d", i+1), AssociationID: fmt.Sprintf("eipassoc-%017d", i+1)}
+		instance.DNSRecord = models.DNSRecord{Zone: "Z0123456789ABCDEFGHIJ", Name: fmt.Sprintf("web-%d.example.com", i+1), IPv4: "203.0.113.10", TTL: 300}
+		err = dao.GetReservationDao(ctx).CreateInstance(ctx, instance)
+		require.NoError(t, err, "failed to add stubbed instance")
+	}
+
+	detectOrphans(ctx)
+
+	oDao := dao.GetOrphanDao(ctx)
+	orphans, err := oDao.List(ctx, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, orphans, 2)
+	assert.Equal(t, clientStubs.UntrackedInstanceID, orphans[0].InstanceID)
+	assert.Equal(t, models.OrphanKindUntracked, orphans[0].Kind)
+	assert.Equal(t, "1", orphans[0].SourceID)
+	assert.Equal(t, "us-east-1", orphans[0].Location)
+	assert.Equal(t, clientStubs.TerminatedInstanceID, orphans[1].InstanceID)
+	assert.Equal(t, models.OrphanKindMissing, orphans[1].Kind)
+	assert.Equal(t, reservation.ID, orphans[1].ReservationID)
+
+	t.Run("elastic IPs of terminated instances released", func(t *testing.T) {
+		_, released, err := clientStubs.StubbedEC2ElasticIPs(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"eipalloc-00000000000000002"}, released)
+	})
+
+	t.Run("DNS records of terminated instances deleted", func(t *testing.T) {
+		instances, err := dao.GetReservationDao(ctx).ListInstances(ctx, reservation.ID)
+		require.NoError(t, err)
+		require.Len(t, instances, 3)
+		for _, instance := range instances {
+			assert.Equal(t, instance.InstanceID == clientStubs.TerminatedInstanceID, instance.DNSRecord.Deleted, instance.InstanceID)
+		}
+	})
+
+	t.Run("repeated", func(t *testing.T) {
+		detectOrphans(ctx)
+
+		again, err := oDao.List(ctx, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, again, 2)
+		assert.Equal(t, orphans[0].ID, again[0].ID)
+		assert.Equal(t, orphans[0].DetectedAt, again[0].DetectedAt)
+	})
+}
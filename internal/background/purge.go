@@ -0,0 +1,148 @@
+package background
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/archiver"
+	"github.com/RHEnVision/provisioning-backend/internal/artifacts"
+	"github.com/RHEnVision/provisioning-backend/internal/cache"
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/RHEnVision/provisioning-backend/internal/kafka"
+	"github.com/RHEnVision/provisioning-backend/internal/logging"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/rs/zerolog"
+)
+
+func tenantPurge(ctx context.Context) {
+	logger := zerolog.Ctx(ctx)
+	logger.Debug().Msg("Started tenant lifecycle consumer")
+	defer func() {
+		logger.Debug().Msgf("Tenant lifecycle consumer exited")
+	}()
+
+	kafka.Consume(ctx, kafka.TenantLifecycleTopic, time.Now(), processTenantMessage)
+}
+
+func processTenantMessage(ctx context.Context, message *kafka.GenericMessage) {
+	logger := zerolog.Ctx(ctx)
+	tlm, err := kafka.NewTenantLifecycleMessage(message)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Could not get tenant lifecycle message")
+		return
+	}
+	if tlm.EventType != kafka.TenantOrgDeletedEventType {
+		logger.Trace().Msgf("Ignoring tenant lifecycle event %s", tlm.EventType)
+		return
+	}
+	if tlm.OrgID == "" {
+		logger.Warn().Msg("Org deletion event without org id")
+		return
+	}
+
+	// the org is purged and messages are sent with a service identity of the org, the one of
+	// the event is not required
+	principal := identity.Principal{}
+	principal.Identity.OrgID = tlm.OrgID
+	ctx = logging.WithContextLogger(identity.WithIdentity(ctx, identity.ServicePrincipal(principal)))
+	logger = zerolog.Ctx(ctx)
+	report := purgeOrg(ctx, tlm.OrgID)
+
+	msg, err := report.GenericMessage(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg("Unable to create purge report message")
+		return
+	}
+	err = kafka.Send(ctx, &msg)
+	if err != nil {
+		logger.Error().Err(err).Msg("Unable to send purge report message via kafka")
+	}
+}
+
+// purgeOrg deletes data of the org and its cached entries. Purging an org which is not known
+// or was already purged completes with zero counts.
+func purgeOrg(ctx context.Context, orgId string) *kafka.PurgeReportMessage {
+	logger := zerolog.Ctx(ctx)
+	report := &kafka.PurgeReportMessage{OrgID: orgId, Status: kafka.PurgeCompletedStatus}
+	fail := func(err error) *kafka.PurgeReportMessage {
+		logger.Error().Err(err).Msg("Org data purge failed")
+		report.Status = kafka.PurgeFailedStatus
+		report.Error = err.Error()
+		report.FinishedAt = time.Now()
+		return report
+	}
+
+	aDao := dao.GetAccountDao(ctx)
+	account, err := aDao.GetByOrgId(ctx, orgId)
+	if errors.Is(err, dao.ErrNoRows) {
+		// archives can outlive the account when an earlier purge failed
+		logger.Info().Msg("Org to purge has no account")
+		if err = purgeArtifacts(ctx, orgId, report); err != nil {
+			return fail(err)
+		}
+		report.FinishedAt = time.Now()
+		return report
+	} else if err != nil {
+		return fail(fmt.Errorf("cannot get account: %w", err))
+	}
+
+	purge, err := aDao.UnscopedPurge(ctx, account.ID)
+	if err != nil {
+		return fail(fmt.Errorf("cannot purge account data: %w", err))
+	}
+	report.Reservations = purge.Reservations
+	report.Pubkeys = purge.Pubkeys
+	report.ReservationTemplates = purge.ReservationTemplates
+	report.OrphanedInstances = purge.OrphanedInstances
+	report.AuditRecords = purge.AuditRecords
+
+	report.CacheEntries, err = purgeCache(ctx, account, purge.SourceIDs)
+	if err != nil {
+		return fail(fmt.Errorf("cannot purge cached data: %w", err))
+	}
+
+	// archives are deleted last, so reservations are not archived again after they are deleted
+	if err = purgeArtifacts(ctx, orgId, report); err != nil {
+		return fail(err)
+	}
+
+	logger.Info().Interface("report", report).Msg("Org data purged")
+	report.FinishedAt = time.Now()
+	return report
+}
+
+// purgeArtifacts deletes archived reservations and support bundles of the org from object storage.
+func purgeArtifacts(ctx context.Context, orgId string, report *kafka.PurgeReportMessage) error {
+	var err error
+	report.Archives, err = archiver.Purge(ctx, orgId)
+	if err != nil {
+		return fmt.Errorf("cannot purge archives: %w", err)
+	}
+	report.SupportBundles, err = artifacts.PurgeSupportBundles(ctx, orgId)
+	if err != nil {
+		return fmt.Errorf("cannot purge support bundles: %w", err)
+	}
+	return nil
+}
+
+// purgeCache deletes the cached account and cached details of sources of the account.
+func purgeCache(ctx context.Context, account *models.Account, sourceIds []string) (int64, error) {
+	deleted, err := cache.Delete(ctx, account.OrgID+account.AccountNumber.String, &models.Account{})
+	if err != nil {
+		return deleted, fmt.Errorf("cannot delete cached account: %w", err)
+	}
+	for _, sourceId := range sourceIds {
+		for _, value := range []cache.Cacheable{&clients.AccountDetailsAWS{}, clients.AzureTenantId("")} {
+			n, err := cache.Delete(ctx, sourceId, value)
+			if err != nil {
+				return deleted, fmt.Errorf("cannot delete cached source: %w", err)
+			}
+			deleted += n
+		}
+	}
+	return deleted, nil
+}
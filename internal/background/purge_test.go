@@ -0,0 +1,93 @@
+package background
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/archiver"
+	"github.com/RHEnVision/provisioning-backend/internal/artifacts"
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	clientStubs "github.com/RHEnVision/provisioning-backend/internal/clients/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/kafka"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/factories"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurgeOrg(t *testing.T) {
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = identity.WithTenant(t, ctx)
+	ctx = stubs.WithReservationDao(ctx)
+	ctx = stubs.WithPubkeyDao(ctx)
+	ctx = stubs.WithAuditDao(ctx)
+	ctx = stubs.WithOrphanDao(ctx)
+	ctx = clientStubs.WithObjectStorageClient(ctx)
+	defer func(enabled bool) {
+		config.Archive.Enabled = enabled
+	}(config.Archive.Enabled)
+	config.Archive.Enabled = true
+	config.Archive.Prefix = "reservations"
+	storage, err := clients.GetObjectStorageClient(ctx)
+	require.NoError(t, err)
+	for _, key := range []string{archiver.Key(identity.DefaultOrgId, 1), archiver.Key(identity.DefaultOrgId+"0", 2), artifacts.SupportBundleKey(identity.DefaultOrgId, 1, time.Now())} {
+		err = storage.PutObject(ctx, key, "application/gzip", nil, []byte("archive"))
+		require.NoError(t, err)
+	}
+
+	pk := factories.NewPubkeyRSA()
+	err = stubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to add stubbed key")
+	reservation := &models.AWSReservation{
+		PubkeyID: pk.ID,
+		SourceID: "1",
+		ImageID:  "ami-0c830793775595d4b",
+		Detail:   &models.AWSDetail{Region: "us-east-1", InstanceType: "t3.small", Amount: 1},
+	}
+	reservation.AccountID = 1
+	reservation.Provider = models.ProviderTypeAWS
+	err = stubs.AddAWSReservation(ctx, reservation)
+	require.NoError(t, err, "failed to add stubbed reservation")
+	record := &models.AuditRecord{ReservationID: reservation.ID, Action: models.AuditActionInstanceResize, Details: map[string]string{"to": "t3.large"}}
+	err = dao.GetAuditDao(ctx).Create(ctx, record)
+	require.NoError(t, err, "failed to add stubbed audit record")
+
+	report := purgeOrg(ctx, identity.DefaultOrgId)
+
+	assert.Equal(t, kafka.PurgeCompletedStatus, report.Status)
+	assert.Empty(t, report.Error)
+	assert.Equal(t, int64(1), report.Reservations)
+	assert.Equal(t, int64(1), report.Pubkeys)
+	assert.Equal(t, int64(1), report.AuditRecords)
+	assert.Equal(t, int64(1), report.Archives)
+	assert.Equal(t, int64(1), report.SupportBundles)
+	assert.False(t, report.FinishedAt.IsZero())
+	assert.Equal(t, []string{archiver.Key(identity.DefaultOrgId+"0", 2)}, clientStubs.ObjectKeys(ctx))
+
+	count, err := dao.GetReservationDao(ctx).Count(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	records, err := dao.GetAuditDao(ctx).ListByReservation(ctx, reservation.ID, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Empty(t, records[0].Actor)
+	assert.Empty(t, records[0].Details)
+
+	t.Run("repeated", func(t *testing.T) {
+		report := purgeOrg(ctx, identity.DefaultOrgId)
+		assert.Equal(t, kafka.PurgeCompletedStatus, report.Status)
+		assert.Zero(t, report.Reservations)
+		assert.Zero(t, report.AuditRecords)
+	})
+
+	t.Run("unknown org", func(t *testing.T) {
+		report := purgeOrg(ctx, "unknown")
+		assert.Equal(t, kafka.PurgeCompletedStatus, report.Status)
+		assert.Equal(t, "unknown", report.OrgID)
+	})
+}
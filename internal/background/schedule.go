@@ -0,0 +1,106 @@
+package background
+
+import (
+	"context"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/cron"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/RHEnVision/provisioning-backend/internal/logging"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/rs/zerolog"
+)
+
+// Maximum number of scheduled templates launched in one iteration.
+const scheduleBatchSize = 50
+
+// TemplateLauncher creates a reservation from the template with the identity of the user who
+// scheduled it and returns the reservation ID. It is set by the services package.
+var TemplateLauncher func(ctx context.Context, template *models.ReservationTemplate) (int64, error)
+
+func scheduledLaunches(ctx context.Context, sleep time.Duration) {
+	logger := zerolog.Ctx(ctx)
+	logger.Debug().Msgf("Started scheduled launches %s", sleep.String())
+	defer func() {
+		logger.Debug().Msgf("Scheduled launches routine exited")
+	}()
+
+	ticker := time.NewTicker(sleep)
+
+	for {
+		select {
+		case <-ticker.C:
+			launchDueTemplates(ctx, time.Now().UTC())
+
+		case <-ctx.Done():
+			ticker.Stop()
+			return
+		}
+	}
+}
+
+// nextRun returns the next run after now for recurring schedules or nil for one-shot schedules.
+// Runs missed while the service was down are skipped.
+func nextRun(ctx context.Context, template *models.ReservationTemplate, now time.Time) *time.Time {
+	if template.ScheduleCron == "" {
+		return nil
+	}
+
+	schedule, err := cron.Parse(template.ScheduleCron)
+	if err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Int64("template_id", template.ID).Msg("Invalid cron expression, removing schedule")
+		return nil
+	}
+
+	next := schedule.Next(now)
+	if next.IsZero() {
+		return nil
+	}
+	return &next
+}
+
+// launchDueTemplates launches all templates scheduled before now. Runs are claimed first, so
+// when multiple API processes are running each run is launched only once.
+func launchDueTemplates(ctx context.Context, now time.Time) {
+	logger := zerolog.Ctx(ctx)
+	if TemplateLauncher == nil {
+		logger.Warn().Msg("Template launcher not set, skipping scheduled launches")
+		return
+	}
+
+	templateDao := dao.GetReservationTemplateDao(ctx)
+	templates, err := templateDao.UnscopedListDue(ctx, now, scheduleBatchSize)
+	if err != nil {
+		logger.Error().Err(err).Msg("Error while listing scheduled templates")
+		return
+	}
+
+	for _, template := range templates {
+		tCtx := logging.WithContextLogger(identity.WithAccountId(ctx, template.AccountID))
+		tLogger := zerolog.Ctx(tCtx).With().Int64("template_id", template.ID).Logger()
+		tCtx = tLogger.WithContext(tCtx)
+
+		claimed, err := templateDao.UnscopedClaimRun(ctx, template.ID, template.NextRunAt.Time, nextRun(ctx, template, now))
+		if err != nil {
+			tLogger.Error().Err(err).Msg("Error while claiming scheduled launch")
+			continue
+		}
+		if !claimed {
+			tLogger.Debug().Msg("Scheduled launch already claimed by another process")
+			continue
+		}
+
+		reservationId, err := TemplateLauncher(tCtx, template)
+		if err != nil {
+			tLogger.Warn().Err(err).Msg("Scheduled launch failed")
+			continue
+		}
+
+		zerolog.Ctx(logging.WithReservationId(tCtx, reservationId)).Info().Msg("Scheduled launch created reservation")
+		err = templateDao.UnscopedUpdateLastReservation(ctx, template.ID, reservationId)
+		if err != nil {
+			tLogger.Warn().Err(err).Msg("Unable to store reservation of the scheduled launch")
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package background
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/identity"
+	_ "github.com/RHEnVision/provisioning-backend/internal/testing/initialization"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLaunchDueTemplates(t *testing.T) {
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = identity.WithTenant(t, ctx)
+	ctx = stubs.WithReservationTemplateDao(ctx)
+
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	oneShot := &models.ReservationTemplate{
+		Name:      "one-shot",
+		Provider:  models.ProviderTypeAWS,
+		Request:   []byte(`{}`),
+		NextRunAt: sql.NullTime{Time: now.Add(-time.Minute), Valid: true},
+	}
+	recurring := &models.ReservationTemplate{
+		Name:         "recurring",
+		Provider:     models.ProviderTypeAWS,
+		Request:      []byte(`{}`),
+		ScheduleCron: "0 7 * * *",
+		NextRunAt:    sql.NullTime{Time: now.Add(-5 * time.Hour), Valid: true},
+	}
+	future := &models.ReservationTemplate{
+		Name:      "future",
+		Provider:  models.ProviderTypeAWS,
+		Request:   []byte(`{}`),
+		NextRunAt: sql.NullTime{Time: now.Add(time.Hour), Valid: true},
+	}
+	for _, template := range []*models.ReservationTemplate{oneShot, recurring, future} {
+		require.NoError(t, stubs.AddReservationTemplate(ctx, template), "failed to add stubbed template")
+	}
+
+	launched := make([]int64, 0)
+	TemplateLauncher = func(ctx context.Context, template *models.ReservationTemplate) (int64, error) {
+		launched = append(launched, template.ID)
+		return template.ID * 100, nil
+	}
+	defer func() { TemplateLauncher = nil }()
+
+	launchDueTemplates(ctx, now)
+
+	assert.Equal(t, []int64{recurring.ID, oneShot.ID}, launched)
+	assert.False(t, oneShot.NextRunAt.Valid, "one-shot schedule must be removed")
+	assert.Equal(t, oneShot.ID*100, oneShot.LastReservationID.Int64)
+	assert.Equal(t, time.Date(2023, 5, 2, 7, 0, 0, 0, time.UTC), recurring.NextRunAt.Time)
+	assert.Equal(t, recurring.ID*100, recurring.LastReservationID.Int64)
+	assert.False(t, future.LastRunAt.Valid, "future template must not be launched")
+
+	launchDueTemplates(ctx, now)
+	assert.Len(t, launched, 2, "templates must be launched only once")
+}
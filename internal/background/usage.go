@@ -0,0 +1,139 @@
+package background
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/billing"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/rs/zerolog"
+)
+
+// Page size of instances with usage records which were not sent yet.
+const usagePageSize = 100
+
+func usageReconciliation(ctx context.Context, sleep time.Duration) {
+	logger := zerolog.Ctx(ctx)
+	logger.Debug().Msgf("Started usage reconciliation %s", sleep.String())
+	defer func() {
+		logger.Debug().Msgf("Usage reconciliation routine exited")
+	}()
+
+	ticker := time.NewTicker(sleep)
+
+	for {
+		select {
+		case <-ticker.C:
+			reconcileUsage(ctx)
+
+		case <-ctx.Done():
+			ticker.Stop()
+			return
+		}
+	}
+}
+
+// reconcileUsage sends usage records which were not sent yet: started records of all stored
+// instances, including instances of partially failed launches, and terminated records of
+// instances stored as terminated. Instances are marked only after their records were sent, so
+// records which failed are sent in the next run.
+func reconcileUsage(ctx context.Context) {
+	logger := zerolog.Ctx(ctx)
+	rDao := dao.GetReservationDao(ctx)
+	accounts := make(map[int64]*models.Account)
+	for {
+		pending, err := rDao.UnscopedListPendingUsage(ctx, usagePageSize)
+		if err != nil {
+			logger.Error().Err(err).Msg("Error while listing instances with pending usage records")
+			return
+		}
+
+		// instances are ordered by account
+		for start := 0; start < len(pending); {
+			end := start
+			var instances []*models.Instance
+			for ; end < len(pending) && pending[end].AccountID == pending[start].AccountID; end++ {
+				instances = append(instances, &pending[end].Instance)
+			}
+
+			err = sendAccountUsage(ctx, accounts, pending[start].AccountID, instances)
+			if err != nil {
+				// the same instances would be listed again
+				logger.Error().Err(err).Int64("account_id", pending[start].AccountID).Msg("Unable to send usage records")
+				return
+			}
+			start = end
+		}
+
+		if len(pending) < usagePageSize {
+			return
+		}
+	}
+}
+
+// sendAccountUsage sends pending usage records of instances of one account with a service
+// identity of the account.
+func sendAccountUsage(ctx context.Context, accounts map[int64]*models.Account, accountId int64, instances []*models.Instance) error {
+	account, ok := accounts[accountId]
+	if !ok {
+		var err error
+		account, err = dao.GetAccountDao(ctx).GetById(ctx, accountId)
+		if err != nil {
+			return fmt.Errorf("cannot get account: %w", err)
+		}
+		accounts[accountId] = account
+	}
+
+	return sendUsage(accountContext(ctx, account), instances, func(instance *models.Instance) bool {
+		return instance.PowerState == models.PowerStateTerminated
+	})
+}
+
+// sendUsage sends started records of instances without them and terminated records of
+// terminated instances without them, the instances are marked as sent afterwards.
+func sendUsage(ctx context.Context, instances []*models.Instance, terminated func(instance *models.Instance) bool) error {
+	var started, stopped []*models.Instance
+	for _, instance := range instances {
+		if !instance.UsageStarted {
+			started = append(started, instance)
+		}
+		if !instance.UsageTerminated && terminated(instance) {
+			stopped = append(stopped, instance)
+		}
+	}
+
+	uc := billing.GetUsageClient(ctx)
+	err := uc.InstancesStarted(ctx, started)
+	if err != nil {
+		return fmt.Errorf("cannot send started records: %w", err)
+	}
+	for _, instance := range started {
+		instance.UsageStarted = true
+	}
+	err = markUsage(ctx, started)
+	if err != nil {
+		return err
+	}
+
+	err = uc.InstancesTerminated(ctx, stopped)
+	if err != nil {
+		return fmt.Errorf("cannot send terminated records: %w", err)
+	}
+	for _, instance := range stopped {
+		instance.UsageTerminated = true
+	}
+	return markUsage(ctx, stopped)
+}
+
+func markUsage(ctx context.Context, instances []*models.Instance) error {
+	rDao := dao.GetReservationDao(ctx)
+	for _, instance := range instances {
+		err := rDao.UnscopedUpdateInstanceUsage(ctx, instance.ReservationID, instance.InstanceID, instance.UsageStarted, instance.UsageTerminated)
+		if err != nil {
+			return fmt.Errorf("cannot mark usage records as sent: %w", err)
+		}
+	}
+	return nil
+}
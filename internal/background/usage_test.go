@@ -0,0 +1,104 @@
+package background
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/billing"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/factories"
+	testIdentity "github.com/RHEnVision/provisioning-backend/internal/testing/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errSendUsage = errors.New("send failed")
+
+// recordingUsageClient records instance IDs of sent usage records.
+type recordingUsageClient struct {
+	started, terminated []string
+	orgs                []string
+	failTerminated      bool
+}
+
+func (c *recordingUsageClient) InstancesStarted(ctx context.Context, instances []*models.Instance) error {
+	for _, instance := range instances {
+		c.started = append(c.started, instance.InstanceID)
+		c.orgs = append(c.orgs, identity.Identity(ctx).Identity.OrgID)
+	}
+	return nil
+}
+
+func (c *recordingUsageClient) InstancesTerminated(ctx context.Context, instances []*models.Instance) error {
+	if c.failTerminated && len(instances) > 0 {
+		return errSendUsage
+	}
+	for _, instance := range instances {
+		c.terminated = append(c.terminated, instance.InstanceID)
+	}
+	return nil
+}
+
+func prepareUsageContext(t *testing.T) (context.Context, *recordingUsageClient) {
+	t.Helper()
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = testIdentity.WithTenant(t, ctx)
+	ctx = stubs.WithReservationDao(ctx)
+	ctx = stubs.WithPubkeyDao(ctx)
+
+	pk := factories.NewPubkeyRSA()
+	err := stubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to add stubbed key")
+	reservation := &models.AWSReservation{
+		PubkeyID: pk.ID,
+		SourceID: "1",
+		ImageID:  "ami-0c830793775595d4b",
+		Detail:   &models.AWSDetail{Region: "us-east-1", InstanceType: "t3.small", Amount: 2},
+	}
+	reservation.AccountID = 1
+	reservation.Provider = models.ProviderTypeAWS
+	err = stubs.AddAWSReservation(ctx, reservation)
+	require.NoError(t, err, "failed to add stubbed reservation")
+	for id, state := range map[string]models.PowerState{"i-1": models.PowerStateRunning, "i-2": models.PowerStateTerminated} {
+		instance := &models.ReservationInstance{ReservationID: reservation.ID, InstanceID: id, PowerState: state}
+		err = dao.GetReservationDao(ctx).CreateInstance(ctx, instance)
+		require.NoError(t, err, "failed to add stubbed instance")
+	}
+
+	uc := &recordingUsageClient{}
+	getter := billing.GetUsageClient
+	t.Cleanup(func() { billing.GetUsageClient = getter })
+	billing.GetUsageClient = func(ctx context.Context) billing.UsageClient { return uc }
+	return ctx, uc
+}
+
+func TestReconcileUsage(t *testing.T) {
+	t.Run("sends pending records once", func(t *testing.T) {
+		ctx, uc := prepareUsageContext(t)
+
+		reconcileUsage(ctx)
+		reconcileUsage(ctx)
+
+		assert.ElementsMatch(t, []string{"i-1", "i-2"}, uc.started)
+		assert.Equal(t, []string{"i-2"}, uc.terminated)
+		assert.Equal(t, []string{testIdentity.DefaultOrgId, testIdentity.DefaultOrgId}, uc.orgs)
+	})
+
+	t.Run("retries failed records", func(t *testing.T) {
+		ctx, uc := prepareUsageContext(t)
+		uc.failTerminated = true
+
+		reconcileUsage(ctx)
+		assert.Empty(t, uc.terminated)
+
+		uc.failTerminated = false
+		reconcileUsage(ctx)
+
+		assert.ElementsMatch(t, []string{"i-1", "i-2"}, uc.started)
+		assert.Equal(t, []string{"i-2"}, uc.terminated)
+	})
+}
@@ -0,0 +1,33 @@
+package background
+
+import (
+	"context"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/queue/jq"
+	"github.com/rs/zerolog"
+)
+
+// resumeWaitingJobs periodically enqueues waiting launch jobs. Slots are normally handed over to
+// waiting jobs when a running job finishes, this only picks up jobs whose slot holder was killed
+// or hung and its slot expired.
+func resumeWaitingJobs(ctx context.Context, sleep time.Duration) {
+	logger := zerolog.Ctx(ctx)
+	logger.Debug().Msgf("Started waiting jobs routine %s", sleep.String())
+	defer func() {
+		logger.Debug().Msgf("Waiting jobs routine exited")
+	}()
+
+	ticker := time.NewTicker(sleep)
+
+	for {
+		select {
+		case <-ticker.C:
+			jq.ResumeWaiting(ctx)
+
+		case <-ctx.Done():
+			ticker.Stop()
+			return
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package billing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/kafka"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/rs/zerolog"
+)
+
+type client struct{}
+
+func getUsageClient(ctx context.Context) UsageClient {
+	zerolog.Ctx(ctx).Debug().Msg("Using kafka usage client")
+	return &client{}
+}
+
+func Initialize(ctx context.Context) {
+	if config.Application.Usage.Enabled {
+		zerolog.Ctx(ctx).Debug().Msg("Initialized kafka usage client")
+		GetUsageClient = getUsageClient
+	} else {
+		zerolog.Ctx(ctx).Debug().Msg("Initialized noop usage client")
+	}
+}
+
+func (x *client) InstancesStarted(ctx context.Context, instances []*models.Instance) error {
+	return x.send(ctx, kafka.UsageStartedEventType, instances)
+}
+
+func (x *client) InstancesTerminated(ctx context.Context, instances []*models.Instance) error {
+	return x.send(ctx, kafka.UsageTerminatedEventType, instances)
+}
+
+func (x *client) send(ctx context.Context, eventType string, instances []*models.Instance) error {
+	if len(instances) == 0 {
+		return nil
+	}
+
+	messages := make([]*kafka.GenericMessage, len(instances))
+	for i, instance := range instances {
+		msg, err := kafka.UsageMessage{
+			EventType:     eventType,
+			ReservationID: instance.ReservationID,
+			InstanceID:    instance.InstanceID,
+			Provider:      instance.Provider.String(),
+			SourceID:      instance.SourceID,
+			Location:      instance.Location,
+			InstanceType:  instance.InstanceType,
+		}.GenericMessage(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to create usage message: %w", err)
+		}
+		messages[i] = &msg
+	}
+
+	zerolog.Ctx(ctx).Info().Msgf("Sending %d %s usage record(s)", len(messages), eventType)
+	err := kafka.Send(ctx, messages...)
+	if err != nil {
+		return fmt.Errorf("unable to send usage records via kafka: %w", err)
+	}
+	return nil
+}
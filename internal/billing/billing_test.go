@@ -0,0 +1,74 @@
+package billing
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/kafka"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/factories"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/identity"
+	_ "github.com/RHEnVision/provisioning-backend/internal/testing/initialization"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstancesStarted(t *testing.T) {
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = identity.WithTenant(t, ctx)
+	ctx = stubs.WithReservationDao(ctx)
+	ctx = stubs.WithPubkeyDao(ctx)
+	err := kafka.InitializeStubBroker(16)
+	require.NoError(t, err)
+
+	pk := factories.NewPubkeyRSA()
+	err = stubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to add stubbed key")
+	reservation := &models.AWSReservation{
+		PubkeyID: pk.ID,
+		SourceID: "1",
+		ImageID:  "ami-0c830793775595d4b",
+		Detail:   &models.AWSDetail{Region: "us-east-1", InstanceType: "t3.small", Amount: 2},
+	}
+	reservation.AccountID = 1
+	reservation.Provider = models.ProviderTypeAWS
+	err = stubs.AddAWSReservation(ctx, reservation)
+	require.NoError(t, err, "failed to add stubbed reservation")
+	for _, id := range []string{"i-1", "i-2"} {
+		instance := &models.ReservationInstance{ReservationID: reservation.ID, InstanceID: id, PowerState: models.PowerStateRunning}
+		err = dao.GetReservationDao(ctx).CreateInstance(ctx, instance)
+		require.NoError(t, err, "failed to add stubbed instance")
+	}
+
+	received := make(chan *kafka.GenericMessage, 2)
+	cCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go kafka.Consume(cCtx, kafka.UsageTopic, time.Now(), func(_ context.Context, msg *kafka.GenericMessage) {
+		received <- msg
+	})
+
+	instances, err := dao.GetReservationDao(ctx).ListAllInstances(ctx, &dao.InstanceFilter{ReservationID: reservation.ID}, 10, 0)
+	require.NoError(t, err)
+	err = (&client{}).InstancesStarted(ctx, instances)
+	require.NoError(t, err)
+
+	for _, id := range []string{"i-1", "i-2"} {
+		msg := <-received
+		assert.EqualValues(t, id, msg.Key)
+
+		var record kafka.UsageMessage
+		err = json.Unmarshal(msg.Value, &record)
+		require.NoError(t, err)
+		assert.Equal(t, kafka.UsageStartedEventType, record.EventType)
+		assert.Equal(t, reservation.ID, record.ReservationID)
+		assert.Equal(t, "aws", record.Provider)
+		assert.Equal(t, "us-east-1", record.Location)
+		assert.Equal(t, "t3.small", record.InstanceType)
+		assert.NotEmpty(t, record.OrgID)
+		assert.False(t, record.Timestamp.IsZero())
+	}
+}
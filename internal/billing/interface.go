@@ -0,0 +1,19 @@
+package billing
+
+import (
+	"context"
+
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+)
+
+var GetUsageClient func(ctx context.Context) UsageClient = getNoopUsageClient
+
+// UsageClient emits usage records of launched capacity for cost attribution. Records are sent
+// by the periodic usage reconciliation of the stats process.
+type UsageClient interface {
+	// InstancesStarted emits a started record for every instance.
+	InstancesStarted(ctx context.Context, instances []*models.Instance) error
+
+	// InstancesTerminated emits a terminated record for every instance.
+	InstancesTerminated(ctx context.Context, instances []*models.Instance) error
+}
@@ -0,0 +1,27 @@
+package billing
+
+import (
+	"context"
+
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/rs/zerolog"
+)
+
+type noopUsageClient struct{}
+
+var _ UsageClient = &noopUsageClient{}
+
+func getNoopUsageClient(ctx context.Context) UsageClient {
+	zerolog.Ctx(ctx).Debug().Msg("Using noop usage client")
+	return &noopUsageClient{}
+}
+
+func (s *noopUsageClient) InstancesStarted(ctx context.Context, instances []*models.Instance) error {
+	zerolog.Ctx(ctx).Debug().Msg("InstancesStarted not sent (Usage records not configured)")
+	return nil
+}
+
+func (s *noopUsageClient) InstancesTerminated(ctx context.Context, instances []*models.Instance) error {
+	zerolog.Ctx(ctx).Debug().Msg("InstancesTerminated not sent (Usage records not configured)")
+	return nil
+}
@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppTypeIdExpiration(t *testing.T) {
+	ctx := context.Background()
+	defer func(ttl time.Duration) {
+		config.Application.Cache.AppTypeIdTTL = ttl
+		InvalidateAppTypeId(ctx)
+	}(config.Application.Cache.AppTypeIdTTL)
+
+	t.Run("not found", func(t *testing.T) {
+		InvalidateAppTypeId(ctx)
+		_, err := FindAppTypeId(ctx)
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("found", func(t *testing.T) {
+		config.Application.Cache.AppTypeIdTTL = time.Hour
+		require.NoError(t, SetAppTypeId(ctx, "5"))
+		value, err := FindAppTypeId(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "5", value)
+	})
+
+	t.Run("expired once", func(t *testing.T) {
+		config.Application.Cache.AppTypeIdTTL = time.Nanosecond
+		require.NoError(t, SetAppTypeId(ctx, "5"))
+		time.Sleep(time.Millisecond)
+
+		value, err := FindAppTypeId(ctx)
+		require.ErrorIs(t, err, ErrExpired)
+		require.Equal(t, "5", value)
+
+		// other callers get the stale value until refreshed
+		value, err = FindAppTypeId(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "5", value)
+	})
+
+	t.Run("forever", func(t *testing.T) {
+		config.Application.Cache.AppTypeIdTTL = 0
+		require.NoError(t, SetAppTypeId(ctx, "5"))
+		_, err := FindAppTypeId(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("invalidated", func(t *testing.T) {
+		require.NoError(t, SetAppTypeId(ctx, "5"))
+		InvalidateAppTypeId(ctx)
+		_, err := FindAppTypeId(ctx)
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
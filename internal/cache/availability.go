@@ -0,0 +1,26 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+)
+
+func availabilityCheckKey(sourceID string) string {
+	return "availability_check:" + sourceID
+}
+
+// AllowAvailabilityCheck returns true when the source was not checked during the statuser check
+// interval. The interval is counted from the first allowed check, all instances share it when
+// Redis is enabled.
+func AllowAvailabilityCheck(ctx context.Context, sourceID string) (bool, error) {
+	if config.Statuser.CheckInterval <= 0 {
+		return true, nil
+	}
+
+	count, _, err := Increment(ctx, availabilityCheckKey(sourceID), config.Statuser.CheckInterval)
+	if err != nil {
+		return false, err
+	}
+	return count == 1, nil
+}
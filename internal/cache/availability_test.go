@@ -0,0 +1,40 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllowAvailabilityCheck(t *testing.T) {
+	ctx := context.Background()
+	defer func(interval time.Duration) { config.Statuser.CheckInterval = interval }(config.Statuser.CheckInterval)
+
+	t.Run("no limit", func(t *testing.T) {
+		config.Statuser.CheckInterval = 0
+		for i := 0; i < 2; i++ {
+			allowed, err := AllowAvailabilityCheck(ctx, "1")
+			require.NoError(t, err)
+			assert.True(t, allowed)
+		}
+	})
+
+	t.Run("limited", func(t *testing.T) {
+		config.Statuser.CheckInterval = time.Minute
+		allowed, err := AllowAvailabilityCheck(ctx, "2")
+		require.NoError(t, err)
+		assert.True(t, allowed)
+
+		allowed, err = AllowAvailabilityCheck(ctx, "2")
+		require.NoError(t, err)
+		assert.False(t, allowed, "checked twice within the interval")
+
+		allowed, err = AllowAvailabilityCheck(ctx, "3")
+		require.NoError(t, err)
+		assert.True(t, allowed, "other source")
+	})
+}
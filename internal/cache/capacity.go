@@ -0,0 +1,29 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+)
+
+// capacityKey returns the counter key of insufficient capacity failures, zone is empty for
+// launches without a zone (AWS picks the zone).
+func capacityKey(provider models.ProviderType, region, zone, instanceType string) string {
+	return fmt.Sprintf("capacity:%s:%s:%s:%s", provider, region, zone, instanceType)
+}
+
+// RecordInsufficientCapacity remembers a launch failed on insufficient capacity of the instance
+// type for the capacity history period, so following reservations can be warned in advance.
+func RecordInsufficientCapacity(ctx context.Context, provider models.ProviderType, region, zone, instanceType string) error {
+	_, _, err := Increment(ctx, capacityKey(provider, region, zone, instanceType), config.Reservation.CapacityHistory)
+	return err
+}
+
+// InsufficientCapacityFailures returns the number of launches failed on insufficient capacity
+// of the instance type during the capacity history period.
+func InsufficientCapacityFailures(ctx context.Context, provider models.ProviderType, region, zone, instanceType string) (int64, error) {
+	count, _, err := Counter(ctx, capacityKey(provider, region, zone, instanceType))
+	return count, err
+}
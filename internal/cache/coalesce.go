@@ -0,0 +1,26 @@
+package cache
+
+import (
+	"golang.org/x/sync/singleflight"
+)
+
+// loads coalesces concurrent loads of missing cache entries
+var loads singleflight.Group
+
+// Coalesce calls the function once for concurrent calls with the same key, other callers wait
+// and receive the same result. It is meant for expensive loads on cache miss, so a cold cache
+// after deploy or flush does not cause a thundering herd of identical upstream calls. Keys must
+// contain all parameters (e.g. organization) the load depends on. The context of the first
+// caller is used for the load.
+func Coalesce[T any](key string, fn func() (T, error)) (T, error) {
+	value, err, _ := loads.Do(key, func() (any, error) {
+		return fn()
+	})
+	if err != nil {
+		var zero T
+		return zero, err //nolint:wrapcheck
+	}
+
+	result, _ := value.(T)
+	return result, nil
+}
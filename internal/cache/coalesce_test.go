@@ -0,0 +1,36 @@
+package cache
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoalesceCallsOnce(t *testing.T) {
+	var calls int32
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			value, err := Coalesce("test", func() (string, error) {
+				atomic.AddInt32(&calls, 1)
+				time.Sleep(50 * time.Millisecond)
+				return "loaded", nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, "loaded", value)
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
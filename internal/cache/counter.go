@@ -0,0 +1,122 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// counterPrefix is the key prefix of fixed window counters
+const counterPrefix = "counter:"
+
+type windowCounter struct {
+	count int64
+	reset time.Time
+}
+
+var (
+	// counters is used when redis is not enabled, values are not shared between processes
+	counters      = make(map[string]*windowCounter)
+	countersMutex sync.Mutex
+)
+
+// Increment increases a fixed window counter and returns the new value together with the time
+// remaining until the window resets. Counters are stored in Redis when enabled, so they are
+// shared by all instances, otherwise they are kept in memory of the process.
+func Increment(ctx context.Context, key string, window time.Duration) (int64, time.Duration, error) {
+	if !redisEnabled {
+		return incrementMemory(key, window)
+	}
+
+	var incr *redis.IntCmd
+	var ttl *redis.DurationCmd
+	_, err := client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, counterPrefix+key)
+		ttl = pipe.PTTL(ctx, counterPrefix+key)
+		return nil
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("redis incr error: %w", err)
+	}
+
+	// the key was just created or has lost its expiration
+	reset := ttl.Val()
+	if reset <= 0 {
+		reset = window
+		if err := client.PExpire(ctx, counterPrefix+key, window).Err(); err != nil {
+			return 0, 0, fmt.Errorf("redis expire error: %w", err)
+		}
+	}
+
+	return incr.Val(), reset, nil
+}
+
+// Counter returns current value of a fixed window counter and the time remaining until
+// the window resets, or zero values when the counter does not exist.
+func Counter(ctx context.Context, key string) (int64, time.Duration, error) {
+	if !redisEnabled {
+		return counterMemory(key)
+	}
+
+	var get *redis.StringCmd
+	var ttl *redis.DurationCmd
+	_, err := client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		get = pipe.Get(ctx, counterPrefix+key)
+		ttl = pipe.PTTL(ctx, counterPrefix+key)
+		return nil
+	})
+	if errors.Is(err, redis.Nil) {
+		return 0, 0, nil
+	} else if err != nil {
+		return 0, 0, fmt.Errorf("redis get error: %w", err)
+	}
+
+	count, err := get.Int64()
+	if err != nil {
+		return 0, 0, fmt.Errorf("redis counter conversion error: %w", err)
+	}
+	reset := ttl.Val()
+	if reset < 0 {
+		reset = 0
+	}
+
+	return count, reset, nil
+}
+
+func incrementMemory(key string, window time.Duration) (int64, time.Duration, error) {
+	countersMutex.Lock()
+	defer countersMutex.Unlock()
+
+	now := time.Now()
+	counter, ok := counters[key]
+	if !ok || !now.Before(counter.reset) {
+		// drop all expired windows to keep the map from growing
+		for k, c := range counters {
+			if !now.Before(c.reset) {
+				delete(counters, k)
+			}
+		}
+		counter = &windowCounter{reset: now.Add(window)}
+		counters[key] = counter
+	}
+	counter.count++
+
+	return counter.count, counter.reset.Sub(now), nil
+}
+
+func counterMemory(key string) (int64, time.Duration, error) {
+	countersMutex.Lock()
+	defer countersMutex.Unlock()
+
+	now := time.Now()
+	counter, ok := counters[key]
+	if !ok || !now.Before(counter.reset) {
+		return 0, 0, nil
+	}
+
+	return counter.count, counter.reset.Sub(now), nil
+}
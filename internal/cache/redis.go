@@ -24,6 +24,7 @@ import (
 var (
 	ErrNotFound = errors.New("not found in cache")
 	ErrNilValue = errors.New("value is nil")
+	ErrExpired  = errors.New("expired in cache")
 
 	// when redis is enabled via configuration
 	redisEnabled bool
@@ -32,10 +33,14 @@ var (
 	client *redis.Client
 
 	// application id "constant" memory-only cache
-	appTypeId      *string
-	appTypeIdMutex sync.Mutex
+	appTypeId        *string
+	appTypeIdExpires time.Time
+	appTypeIdMutex   sync.Mutex
 )
 
+// appTypeIdRetry is the time a stale application id is served when its refresh fails
+const appTypeIdRetry = time.Minute
+
 // Forever is used for items that should be cached "forever". Expiration of 30 days
 // is used to allow cleanup of unused items.
 const Forever time.Duration = 24 * time.Hour * 30
@@ -44,6 +49,9 @@ type Cacheable interface {
 	CacheKeyName() string
 }
 
+// cached are all types stored in the cache, their key prefixes are used for flushing
+var cached = []Cacheable{&models.Account{}, &clients.AccountDetailsAWS{}, clients.AzureTenantId(""), clients.InstanceTypeList{}}
+
 // Initialize creates new Redis client if allowed by application config, or does nothing.
 func Initialize() {
 	if config.Application.Cache.Type == "redis" {
@@ -53,6 +61,7 @@ func Initialize() {
 		// register all Cacheable types
 		gob.Register(&models.Account{})
 		gob.Register(&clients.AccountDetailsAWS{})
+		gob.Register(&clients.InstanceTypeList{})
 
 		client = redis.NewClient(&redis.Options{
 			Addr:     config.RedisHostAndPort(),
@@ -65,7 +74,15 @@ func Initialize() {
 	}
 }
 
+// Enabled returns true when items are stored, Find always returns ErrNotFound otherwise.
+func Enabled() bool {
+	return redisEnabled
+}
+
 // FindAppTypeId returns "application id" special identifier, or returns ErrNotFound.
+// When the identifier is older than the configured TTL, it is returned together with
+// ErrExpired. Only one caller receives ErrExpired and it is expected to refresh the value,
+// other callers receive the stale value until the refresh finishes or retry interval passes.
 func FindAppTypeId(_ context.Context) (string, error) {
 	appTypeIdMutex.Lock()
 	defer appTypeIdMutex.Unlock()
@@ -73,6 +90,10 @@ func FindAppTypeId(_ context.Context) (string, error) {
 	if appTypeId == nil {
 		return "", ErrNotFound
 	}
+	if !appTypeIdExpires.IsZero() && time.Now().After(appTypeIdExpires) {
+		appTypeIdExpires = time.Now().Add(appTypeIdRetry)
+		return *appTypeId, ErrExpired
+	}
 	return *appTypeId, nil
 }
 
@@ -82,9 +103,26 @@ func SetAppTypeId(_ context.Context, value string) error {
 	defer appTypeIdMutex.Unlock()
 
 	appTypeId = &value
+	if config.Application.Cache.AppTypeIdTTL > 0 {
+		appTypeIdExpires = time.Now().Add(config.Application.Cache.AppTypeIdTTL)
+	} else {
+		appTypeIdExpires = time.Time{}
+	}
 	return nil
 }
 
+// InvalidateAppTypeId removes "application id" special identifier, next call to FindAppTypeId
+// returns ErrNotFound.
+func InvalidateAppTypeId(ctx context.Context) {
+	appTypeIdMutex.Lock()
+	defer appTypeIdMutex.Unlock()
+
+	if appTypeId != nil {
+		zerolog.Ctx(ctx).Info().Bool("cache", true).Msgf("Invalidating application type id %s", *appTypeId)
+	}
+	appTypeId = nil
+}
+
 // Find returns an item from cache. ErrNotFound is returned on cache miss or when
 // the item cannot be deserialized
 func Find(ctx context.Context, key string, value Cacheable) error {
@@ -170,3 +208,49 @@ func SetForever(ctx context.Context, key string, value Cacheable) error {
 func Set(ctx context.Context, key string, value Cacheable) error {
 	return SetExpires(ctx, key, value, config.Application.Cache.Expiration)
 }
+
+// Delete deletes a cache entry and returns the number of deleted keys.
+func Delete(ctx context.Context, key string, value Cacheable) (int64, error) {
+	if !redisEnabled {
+		return 0, nil
+	}
+
+	if value == nil {
+		return 0, ErrNilValue
+	}
+
+	deleted, err := client.Del(ctx, value.CacheKeyName()+key).Result()
+	if err != nil {
+		return 0, fmt.Errorf("redis del error: %w", err)
+	}
+	return deleted, nil
+}
+
+// Flush deletes all cache entries and returns the number of deleted keys. Only keys of cached
+// types are deleted, other data in the same Redis database (e.g. job queue) are kept. The memory-only
+// application type id is reset in the current process only.
+func Flush(ctx context.Context) (int64, error) {
+	InvalidateAppTypeId(ctx)
+
+	if !redisEnabled {
+		return 0, nil
+	}
+
+	var deleted int64
+	for _, value := range cached {
+		iter := client.Scan(ctx, 0, value.CacheKeyName()+"*", 1000).Iterator()
+		for iter.Next(ctx) {
+			n, err := client.Del(ctx, iter.Val()).Result()
+			if err != nil {
+				return deleted, fmt.Errorf("redis del error: %w", err)
+			}
+			deleted += n
+		}
+		if err := iter.Err(); err != nil {
+			return deleted, fmt.Errorf("redis scan error: %w", err)
+		}
+	}
+
+	zerolog.Ctx(ctx).Info().Bool("cache", true).Msgf("Flushed %d cache entries", deleted)
+	return deleted, nil
+}
@@ -0,0 +1,146 @@
+// Package chaos provides fault injection rules for resilience testing. Rules are applied by the
+// chaos middleware on incoming requests and by the platform HTTP client on outbound calls, chaos
+// can be only enabled in development and stage environments.
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/random"
+	"github.com/rs/zerolog"
+)
+
+// ErrInjected is returned from outbound calls when an error was injected
+var ErrInjected = errors.New("chaos error injected")
+
+// ErrInvalidRule is returned when a rule cannot be parsed
+var ErrInvalidRule = errors.New("invalid chaos rule")
+
+// Rule describes faults injected into requests matching the prefix.
+type Rule struct {
+	// Prefix of the URL path (incoming requests) or host and path (outbound requests), the
+	// asterisk matches all requests.
+	Prefix string
+
+	// Latency added before the request is processed.
+	Latency time.Duration
+
+	// ErrorRate is the probability of the request to fail (0.0 - 1.0).
+	ErrorRate float32
+}
+
+// ParseRule parses a rule in the "prefix=latency:rate" format, e.g. "/api/provisioning/v1/sources=500ms:0.1".
+func ParseRule(str string) (Rule, error) {
+	prefix, spec, found := strings.Cut(str, "=")
+	if !found || prefix == "" {
+		return Rule{}, fmt.Errorf("%w: %s", ErrInvalidRule, str)
+	}
+
+	latencyStr, rateStr, _ := strings.Cut(spec, ":")
+	rule := Rule{Prefix: prefix}
+	if latencyStr != "" {
+		latency, err := time.ParseDuration(latencyStr)
+		if err != nil {
+			return Rule{}, fmt.Errorf("%w: %s: %s", ErrInvalidRule, str, err.Error())
+		}
+		rule.Latency = latency
+	}
+	if rateStr != "" {
+		rate, err := strconv.ParseFloat(rateStr, 32)
+		if err != nil || rate < 0 || rate > 1 {
+			return Rule{}, fmt.Errorf("%w: %s: error rate must be between 0.0 and 1.0", ErrInvalidRule, str)
+		}
+		rule.ErrorRate = float32(rate)
+	}
+	return rule, nil
+}
+
+// ParseRules parses all rules, see ParseRule for the format.
+func ParseRules(strs []string) ([]Rule, error) {
+	rules := make([]Rule, 0, len(strs))
+	for _, str := range strs {
+		if str == "" {
+			continue
+		}
+		rule, err := ParseRule(str)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// Match returns the rule with the longest matching prefix or nil when no rule matches.
+func Match(rules []Rule, path string) *Rule {
+	var result *Rule
+	for i := range rules {
+		rule := &rules[i]
+		if rule.Prefix != "*" && !strings.HasPrefix(path, rule.Prefix) {
+			continue
+		}
+		if result == nil || result.Prefix == "*" || len(rule.Prefix) > len(result.Prefix) {
+			result = rule
+		}
+	}
+	return result
+}
+
+// Apply sleeps for the rule latency and returns true when an error must be injected.
+func (r *Rule) Apply(ctx context.Context) (bool, error) {
+	if r.Latency > 0 {
+		select {
+		case <-ctx.Done():
+			return false, fmt.Errorf("chaos latency interrupted: %w", ctx.Err())
+		case <-time.After(r.Latency):
+		}
+	}
+	return r.ErrorRate > 0 && random.Float32() <= r.ErrorRate, nil
+}
+
+var (
+	parseOnce     sync.Once
+	serverRules   []Rule
+	clientRules   []Rule
+	errParseRules error
+)
+
+func parse() {
+	serverRules, errParseRules = ParseRules(config.Chaos.Rules)
+	if errParseRules != nil {
+		return
+	}
+	clientRules, errParseRules = ParseRules(config.Chaos.ClientRules)
+}
+
+// Enabled returns true when chaos is enabled and the environment is not production.
+func Enabled() bool {
+	return config.Chaos.Enabled && !config.InProdClowder()
+}
+
+// ServerRules returns parsed rules for incoming requests from the configuration.
+func ServerRules(ctx context.Context) []Rule {
+	parseOnce.Do(parse)
+	if errParseRules != nil {
+		zerolog.Ctx(ctx).Error().Err(errParseRules).Msg("Unable to parse chaos rules, chaos is disabled")
+		return nil
+	}
+	return serverRules
+}
+
+// ClientRules returns parsed rules for outbound requests from the configuration.
+func ClientRules(ctx context.Context) []Rule {
+	parseOnce.Do(parse)
+	if errParseRules != nil {
+		zerolog.Ctx(ctx).Error().Err(errParseRules).Msg("Unable to parse chaos rules, chaos is disabled")
+		return nil
+	}
+	return clientRules
+}
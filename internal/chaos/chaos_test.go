@@ -0,0 +1,54 @@
+package chaos
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRule(t *testing.T) {
+	rule, err := ParseRule("/api/provisioning/v1/sources=500ms:0.1")
+	require.NoError(t, err)
+	require.Equal(t, Rule{Prefix: "/api/provisioning/v1/sources", Latency: 500 * time.Millisecond, ErrorRate: 0.1}, rule)
+
+	rule, err = ParseRule("*=:1")
+	require.NoError(t, err)
+	require.Equal(t, Rule{Prefix: "*", ErrorRate: 1}, rule)
+
+	rule, err = ParseRule("sources=1s")
+	require.NoError(t, err)
+	require.Equal(t, Rule{Prefix: "sources", Latency: time.Second}, rule)
+
+	for _, invalid := range []string{"", "=1s:0.5", "/path", "/path=1x", "/path=1s:2", "/path=1s:x"} {
+		_, err = ParseRule(invalid)
+		require.ErrorIs(t, err, ErrInvalidRule, invalid)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	rules, err := ParseRules([]string{"*=1s", "/api=2s", "/api/provisioning/v1/sources=3s", ""})
+	require.NoError(t, err)
+	require.Len(t, rules, 3)
+
+	require.Equal(t, "*", Match(rules, "/other").Prefix)
+	require.Equal(t, "/api", Match(rules, "/api/provisioning/v1/pubkeys").Prefix)
+	require.Equal(t, "/api/provisioning/v1/sources", Match(rules, "/api/provisioning/v1/sources/1").Prefix)
+	require.Nil(t, Match(rules[1:], "/other"))
+}
+
+func TestApply(t *testing.T) {
+	inject, err := (&Rule{ErrorRate: 1}).Apply(context.Background())
+	require.NoError(t, err)
+	require.True(t, inject)
+
+	inject, err = (&Rule{ErrorRate: 0}).Apply(context.Background())
+	require.NoError(t, err)
+	require.False(t, inject)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = (&Rule{Latency: time.Hour}).Apply(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -9,11 +9,18 @@ const (
 
 type AccessList []Access
 
-// Access represents a permission. ResourceDefinitions are ignored.
+// WorkspaceAttribute is the RBAC resource definition attribute which restricts a permission
+// to a list of workspaces.
+const WorkspaceAttribute = "provisioning.workspace"
+
+// Access represents a permission. Only workspace ResourceDefinitions are taken into account.
 // Inspired by https://github.com/RedHatInsights/rbac-client-go
 type Access struct {
 	Resource string `json:"resource"`
 	Verb     string `json:"verb"`
+
+	// Workspace IDs the permission is restricted to, empty for all workspaces.
+	Workspaces []string `json:"workspaces,omitempty"`
 }
 
 // NewAccess constructs new Access from a string in the form of
@@ -30,6 +37,13 @@ func NewAccess(access string) Access {
 	return a
 }
 
+// NewWorkspaceAccess constructs new Access restricted to workspaces, see NewAccess.
+func NewWorkspaceAccess(access string, workspaces []string) Access {
+	a := NewAccess(access)
+	a.Workspaces = workspaces
+	return a
+}
+
 // IsAllowed returns whether an action against a resource is allowed by an AccessList
 // taking wildcards into consideration.
 func (l AccessList) IsAllowed(res, verb string) bool {
@@ -41,6 +55,21 @@ func (l AccessList) IsAllowed(res, verb string) bool {
 	return false
 }
 
+// Workspaces returns workspace IDs an action against a resource is allowed in, or nil when the
+// action is not restricted to workspaces. The result is only meaningful when IsAllowed is true.
+func (l AccessList) Workspaces(res, verb string) []string {
+	var result []string
+	for _, a := range l {
+		if matchWildcard(a.Resource, res) && matchWildcard(a.Verb, verb) {
+			if len(a.Workspaces) == 0 {
+				return nil
+			}
+			result = append(result, a.Workspaces...)
+		}
+	}
+	return result
+}
+
 func matchWildcard(s1, s2 string) bool {
 	return s1 == s2 || s1 == wildcard
 }
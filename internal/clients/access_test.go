@@ -66,3 +66,36 @@ func TestIsAllowed(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkspaces(t *testing.T) {
+	tests := map[string]struct {
+		input      AccessList
+		workspaces []string
+	}{
+		"unrestricted": {input: AccessList{
+			NewAccess("provisioning:b:c"),
+		}, workspaces: nil},
+		"restricted": {input: AccessList{
+			NewWorkspaceAccess("provisioning:b:c", []string{"w1", "w2"}),
+		}, workspaces: []string{"w1", "w2"}},
+		"multiple restricted": {input: AccessList{
+			NewWorkspaceAccess("provisioning:b:c", []string{"w1"}),
+			NewWorkspaceAccess("provisioning:b:*", []string{"w2"}),
+		}, workspaces: []string{"w1", "w2"}},
+		"restricted and unrestricted": {input: AccessList{
+			NewWorkspaceAccess("provisioning:b:c", []string{"w1"}),
+			NewAccess("provisioning:*:*"),
+		}, workspaces: nil},
+		"other resource": {input: AccessList{
+			NewWorkspaceAccess("provisioning:e:c", []string{"w1"}),
+			NewWorkspaceAccess("provisioning:b:c", []string{"w2"}),
+		}, workspaces: []string{"w2"}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := tc.input.Workspaces("b", "c")
+			assert.Equal(t, tc.workspaces, got)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package clients
+
+import "fmt"
+
+// AWSMarketplaceURL returns the AWS Marketplace page of a product code, subscriptions to the
+// product are accepted there.
+func AWSMarketplaceURL(productCode string) string {
+	return "https://aws.amazon.com/marketplace/pp?sku=" + productCode
+}
+
+// MarketplaceSubscriptionError is a launch of an AWS Marketplace image without an accepted
+// subscription to the product of the image. Callers can check for MarketplaceSubscriptionErr with
+// errors.Is or get the product with errors.As. The wrapped error is the original API error.
+type MarketplaceSubscriptionError struct {
+	// ProductCode of the marketplace product, for example "cpkwgozd4by0cmoinfftz1qqr".
+	ProductCode string
+
+	// URL of the marketplace page where the subscription is accepted.
+	URL string
+
+	// Err is the wrapped error.
+	Err error
+}
+
+// NewMarketplaceSubscriptionError wraps an error of a launch of an image with the product code.
+func NewMarketplaceSubscriptionError(productCode string, err error) *MarketplaceSubscriptionError {
+	return &MarketplaceSubscriptionError{
+		ProductCode: productCode,
+		URL:         AWSMarketplaceURL(productCode),
+		Err:         err,
+	}
+}
+
+func (e *MarketplaceSubscriptionError) Error() string {
+	return fmt.Sprintf("%s: subscribe to product %s at %s and retry the launch", MarketplaceSubscriptionErr, e.ProductCode, e.URL)
+}
+
+func (e *MarketplaceSubscriptionError) Unwrap() error {
+	return e.Err
+}
+
+func (e *MarketplaceSubscriptionError) Is(target error) bool {
+	return target == MarketplaceSubscriptionErr
+}
@@ -0,0 +1,78 @@
+package clients
+
+import (
+	"strings"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+)
+
+// AWS partitions are isolated groups of regions, roles of one partition can only be assumed with
+// credentials of the same partition.
+const (
+	AWSPartitionStandard = "aws"
+	AWSPartitionGovCloud = "aws-us-gov"
+	AWSPartitionChina    = "aws-cn"
+)
+
+// AWSPartitionRegions are the regions of the GovCloud and China partitions.
+var AWSPartitionRegions = []string{"us-gov-west-1", "us-gov-east-1", "cn-north-1", "cn-northwest-1"}
+
+// AWSPartition returns the partition of an ARN, the standard partition when it cannot be parsed.
+func AWSPartition(arn string) string {
+	parts := strings.SplitN(arn, ":", 3)
+	if len(parts) < 3 || parts[0] != "arn" {
+		return AWSPartitionStandard
+	}
+	switch parts[1] {
+	case AWSPartitionGovCloud, AWSPartitionChina:
+		return parts[1]
+	default:
+		return AWSPartitionStandard
+	}
+}
+
+// AWSAccountID returns the account ID of an ARN, blank when it cannot be parsed.
+func AWSAccountID(arn string) string {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) < 6 || parts[0] != "arn" {
+		return ""
+	}
+	return parts[4]
+}
+
+// AWSRegionPartition returns the partition of a region name.
+func AWSRegionPartition(region string) string {
+	switch {
+	case strings.HasPrefix(region, "us-gov-"):
+		return AWSPartitionGovCloud
+	case strings.HasPrefix(region, "cn-"):
+		return AWSPartitionChina
+	default:
+		return AWSPartitionStandard
+	}
+}
+
+// AWSDefaultRegion returns the configured region of the partition used when no region is provided.
+func AWSDefaultRegion(partition string) string {
+	switch partition {
+	case AWSPartitionGovCloud:
+		return config.AWS.GovCloud.DefaultRegion
+	case AWSPartitionChina:
+		return config.AWS.China.DefaultRegion
+	default:
+		return config.AWS.DefaultRegion
+	}
+}
+
+// AWSPartitionEnabled returns true when service account credentials of the partition are configured,
+// the standard partition is always enabled.
+func AWSPartitionEnabled(partition string) bool {
+	switch partition {
+	case AWSPartitionGovCloud:
+		return config.AWS.GovCloud.Key != ""
+	case AWSPartitionChina:
+		return config.AWS.China.Key != ""
+	default:
+		return true
+	}
+}
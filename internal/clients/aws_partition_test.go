@@ -0,0 +1,27 @@
+package clients
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAWSPartition(t *testing.T) {
+	assert.Equal(t, AWSPartitionStandard, AWSPartition("arn:aws:iam::123456789012:role/name"))
+	assert.Equal(t, AWSPartitionGovCloud, AWSPartition("arn:aws-us-gov:iam::123456789012:role/name"))
+	assert.Equal(t, AWSPartitionChina, AWSPartition("arn:aws-cn:iam::123456789012:role/name"))
+	assert.Equal(t, AWSPartitionStandard, AWSPartition("arn:aws-iso:iam::123456789012:role/name"))
+	assert.Equal(t, AWSPartitionStandard, AWSPartition("not-an-arn"))
+}
+
+func TestAWSAccountID(t *testing.T) {
+	assert.Equal(t, "123456789012", AWSAccountID("arn:aws:iam::123456789012:role/name"))
+	assert.Equal(t, "123456789012", AWSAccountID("arn:aws:sqs:us-east-1:123456789012:queue"))
+	assert.Empty(t, AWSAccountID("not-an-arn"))
+}
+
+func TestAWSRegionPartition(t *testing.T) {
+	assert.Equal(t, AWSPartitionStandard, AWSRegionPartition("us-east-1"))
+	assert.Equal(t, AWSPartitionGovCloud, AWSRegionPartition("us-gov-west-1"))
+	assert.Equal(t, AWSPartitionChina, AWSRegionPartition("cn-north-1"))
+}
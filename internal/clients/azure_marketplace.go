@@ -0,0 +1,54 @@
+package clients
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AzureMarketplaceImage is an image published in Azure Marketplace referenced by its URN.
+type AzureMarketplaceImage struct {
+	Publisher string
+	Offer     string
+	SKU       string
+
+	// Version of the image, "latest" when not resolved.
+	Version string
+
+	// Plan is the purchase plan of the image, nil for images without plan.
+	Plan *AzureImagePlan
+
+	// Architecture of the image, blank when not resolved.
+	Architecture ArchitectureType
+}
+
+// AzureImagePlan is a purchase plan of a marketplace image, terms of the plan must be accepted
+// in the subscription before the image can be launched.
+type AzureImagePlan struct {
+	Publisher string
+	Product   string
+	Name      string
+}
+
+// ParseAzureImageURN parses URN in the "publisher:offer:sku:version" form.
+func ParseAzureImageURN(urn string) (*AzureMarketplaceImage, bool) {
+	parts := strings.Split(urn, ":")
+	if len(parts) != 4 {
+		return nil, false
+	}
+	for _, part := range parts {
+		if part == "" || strings.ContainsAny(part, " /") {
+			return nil, false
+		}
+	}
+	return &AzureMarketplaceImage{
+		Publisher: parts[0],
+		Offer:     parts[1],
+		SKU:       parts[2],
+		Version:   parts[3],
+	}, true
+}
+
+// URN returns the "publisher:offer:sku:version" form of the image.
+func (i *AzureMarketplaceImage) URN() string {
+	return fmt.Sprintf("%s:%s:%s:%s", i.Publisher, i.Offer, i.SKU, i.Version)
+}
@@ -0,0 +1,30 @@
+package clients
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAzureImageURN(t *testing.T) {
+	image, ok := ParseAzureImageURN("RedHat:RHEL:9-lvm-gen2:latest")
+	require.True(t, ok)
+	assert.Equal(t, "RedHat", image.Publisher)
+	assert.Equal(t, "RHEL", image.Offer)
+	assert.Equal(t, "9-lvm-gen2", image.SKU)
+	assert.Equal(t, "latest", image.Version)
+	assert.Equal(t, "RedHat:RHEL:9-lvm-gen2:latest", image.URN())
+}
+
+func TestParseAzureImageURNInvalid(t *testing.T) {
+	for _, urn := range []string{
+		"RedHat:RHEL:9-lvm-gen2",
+		"RedHat:RHEL::latest",
+		"RedHat:RHEL:9-lvm-gen2:latest:extra",
+		"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/images/image",
+	} {
+		_, ok := ParseAzureImageURN(urn)
+		assert.False(t, ok, urn)
+	}
+}
@@ -0,0 +1,23 @@
+package clients
+
+import "golang.org/x/exp/slices"
+
+// Capacity is the availability of an instance type in a region for the account, as reported by
+// the cloud provider. It does not guarantee the capacity, launch can still fail on insufficient
+// capacity of the provider.
+type Capacity struct {
+	// Regional is true when the instance type can be launched in the region without a zone
+	Regional bool `json:"regional" yaml:"regional"`
+
+	// Zones where the instance type is offered and not restricted for the account
+	Zones []string `json:"zones" yaml:"zones"`
+}
+
+// Available returns true when the instance type can be launched in the zone, or in the region
+// when the zone is empty.
+func (c *Capacity) Available(zone string) bool {
+	if zone == "" {
+		return c.Regional
+	}
+	return slices.Contains(c.Zones, zone)
+}
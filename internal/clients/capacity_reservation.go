@@ -0,0 +1,37 @@
+package clients
+
+import "time"
+
+// CapacityReservation represents an on-demand capacity reservation purchased in the account.
+type CapacityReservation struct {
+	// ID is the capacity reservation identifier, for example "cr-0a1b2c3d4e5f67890" for AWS EC2.
+	ID string
+
+	// Name of the capacity reservation from the Name tag, blank when not tagged.
+	Name string
+
+	// InstanceType of the reserved capacity, for example "m5.large".
+	InstanceType string
+
+	// AvailabilityZone of the reserved capacity.
+	AvailabilityZone string
+
+	// Platform of the reserved capacity, for example "Linux/UNIX".
+	Platform string
+
+	// Tenancy of the reserved capacity, "default" or "dedicated".
+	Tenancy string
+
+	// InstanceMatchCriteria is "open" when matching instances use the capacity automatically,
+	// or "targeted" when instances must target the capacity reservation.
+	InstanceMatchCriteria string
+
+	// TotalInstances is the number of instances the capacity is reserved for.
+	TotalInstances int32
+
+	// AvailableInstances is the number of instances which can still be launched into the capacity.
+	AvailableInstances int32
+
+	// EndDate is the time the capacity reservation expires, nil for reservations without end.
+	EndDate *time.Time
+}
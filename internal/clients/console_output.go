@@ -0,0 +1,24 @@
+package clients
+
+// MaxConsoleOutput is the maximum length of console output returned by clients in bytes.
+const MaxConsoleOutput = 64 * 1024
+
+// ConsoleOutput is the recent serial console output of an instance.
+type ConsoleOutput struct {
+	// Output is the serial console output, only the most recent part of long outputs.
+	Output string
+
+	// Screenshot is a base64 encoded screenshot of the console, empty when not available.
+	Screenshot string
+
+	// ScreenshotURL is a temporary URL of the console screenshot, empty when not available.
+	ScreenshotURL string
+}
+
+// TrimConsoleOutput returns the last MaxConsoleOutput bytes of the output.
+func TrimConsoleOutput(output string) string {
+	if len(output) <= MaxConsoleOutput {
+		return output
+	}
+	return output[len(output)-MaxConsoleOutput:]
+}
@@ -0,0 +1,17 @@
+package clients
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrimConsoleOutput(t *testing.T) {
+	assert.Equal(t, "login: ", TrimConsoleOutput("login: "))
+
+	long := strings.Repeat("a", MaxConsoleOutput) + "login: "
+	trimmed := TrimConsoleOutput(long)
+	assert.Len(t, trimmed, MaxConsoleOutput)
+	assert.True(t, strings.HasSuffix(trimmed, "login: "))
+}
@@ -0,0 +1,32 @@
+package clients
+
+// DedicatedHost represents a dedicated host allocated in the account.
+type DedicatedHost struct {
+	// ID is the host identifier, for example "h-0a1b2c3d4e5f67890" for AWS EC2.
+	ID string
+
+	// Name of the host from the Name tag, blank when not tagged.
+	Name string
+
+	// InstanceFamily the host supports, for example "m5".
+	InstanceFamily string
+
+	// InstanceType the host supports, blank when the host supports multiple types of the family.
+	InstanceType string
+
+	// AvailabilityZone of the host.
+	AvailabilityZone string
+
+	// State of the host allocation, for example "available".
+	State string
+
+	// AutoPlacement is true when instances launched with host tenancy and without host ID can be
+	// placed on the host.
+	AutoPlacement bool
+
+	// AvailableVCPUs is the number of vCPUs available for new instances.
+	AvailableVCPUs int32
+
+	// Instances is the number of instances running on the host.
+	Instances int
+}
@@ -0,0 +1,5 @@
+package clients
+
+// ElasticIPPoolTagKey is the tag of Elastic IPs in the customer account forming a pool, addresses
+// of a pool which are not associated are reused by reservations with the pool name.
+const ElasticIPPoolTagKey = "rh-eip-pool"
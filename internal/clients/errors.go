@@ -18,4 +18,23 @@ var (
 	UnknownAuthenticationTypeErr = errors.New("unknown authentication type")
 	UnknownProviderErr           = errors.New("unknown provider type")
 	MissingProvisioningSources   = errors.New("missing provisioning source authentication")
+
+	// Launch errors
+	InsufficientCapacityErr = errors.New("insufficient capacity of the instance type in the cloud provider")
+	SpotUnavailableErr      = errors.New("spot instances are not available for the requested price or amount")
+	RootVolumeTooSmallErr   = errors.New("root volume is smaller than the image")
+	ElasticIPInUseErr       = errors.New("elastic IP is already associated")
+	ElasticIPLimitErr       = errors.New("elastic IP address limit of the region exceeded")
+
+	// Marketplace errors, see MarketplaceSubscriptionError
+	MarketplaceSubscriptionErr = errors.New("AWS Marketplace subscription of the image has not been accepted")
+
+	// DNS errors
+	DNSZoneNotFoundErr = errors.New("DNS zone not found in the cloud account")
+
+	// Event target errors
+	InvalidEventTargetErr = errors.New("invalid event target ARN")
+
+	// Object storage errors
+	ObjectNotFoundErr = fmt.Errorf("%w: object not found in the bucket", NotFoundErr)
 )
@@ -0,0 +1,73 @@
+package clients
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+)
+
+// Services of event targets receiving reservation lifecycle events.
+const (
+	EventTargetSQS         = "sqs"
+	EventTargetEventBridge = "events"
+)
+
+// eventBusPrefix is the resource prefix of EventBridge event bus ARNs
+const eventBusPrefix = "event-bus/"
+
+// EventTarget is an SQS queue or an EventBridge event bus in the customer account, reservation
+// lifecycle events are published there using the role of the source.
+type EventTarget struct {
+	// ARN of the queue or the event bus
+	ARN string
+
+	// Service of the target: sqs or events
+	Service string
+
+	// Partition of the target
+	Partition string
+
+	// Region of the target
+	Region string
+
+	// Account ID owning the target
+	AccountID string
+
+	// Name of the queue or the event bus
+	Name string
+}
+
+// ParseEventTarget parses an SQS queue ARN (arn:aws:sqs:region:account:name) or an EventBridge
+// event bus ARN (arn:aws:events:region:account:event-bus/name).
+func ParseEventTarget(value string) (*EventTarget, error) {
+	parsed, err := arn.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", InvalidEventTargetErr, err.Error())
+	}
+	if parsed.Region == "" || parsed.AccountID == "" {
+		return nil, fmt.Errorf("%w: region and account ID are required", InvalidEventTargetErr)
+	}
+
+	target := &EventTarget{
+		ARN:       value,
+		Service:   parsed.Service,
+		Partition: parsed.Partition,
+		Region:    parsed.Region,
+		AccountID: parsed.AccountID,
+	}
+	switch parsed.Service {
+	case EventTargetSQS:
+		target.Name = parsed.Resource
+	case EventTargetEventBridge:
+		if strings.HasPrefix(parsed.Resource, eventBusPrefix) {
+			target.Name = strings.TrimPrefix(parsed.Resource, eventBusPrefix)
+		}
+	default:
+		return nil, fmt.Errorf("%w: only SQS queues and EventBridge event buses are supported", InvalidEventTargetErr)
+	}
+	if target.Name == "" || strings.ContainsAny(target.Name, ":/") {
+		return nil, fmt.Errorf("%w: invalid %s resource %s", InvalidEventTargetErr, parsed.Service, parsed.Resource)
+	}
+	return target, nil
+}
@@ -0,0 +1,38 @@
+package clients
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEventTarget(t *testing.T) {
+	t.Run("queue", func(t *testing.T) {
+		target, err := ParseEventTarget("arn:aws:sqs:us-east-1:123456789012:launches")
+		require.NoError(t, err)
+		assert.Equal(t, EventTargetSQS, target.Service)
+		assert.Equal(t, "us-east-1", target.Region)
+		assert.Equal(t, "123456789012", target.AccountID)
+		assert.Equal(t, "launches", target.Name)
+	})
+
+	t.Run("event bus", func(t *testing.T) {
+		target, err := ParseEventTarget("arn:aws-us-gov:events:us-gov-west-1:123456789012:event-bus/provisioning")
+		require.NoError(t, err)
+		assert.Equal(t, EventTargetEventBridge, target.Service)
+		assert.Equal(t, AWSPartitionGovCloud, target.Partition)
+		assert.Equal(t, "provisioning", target.Name)
+	})
+
+	for _, value := range []string{
+		"launches",
+		"arn:aws:sns:us-east-1:123456789012:topic",
+		"arn:aws:sqs::123456789012:launches",
+		"arn:aws:sqs:us-east-1:123456789012:",
+		"arn:aws:events:us-east-1:123456789012:rule/name",
+	} {
+		_, err := ParseEventTarget(value)
+		assert.ErrorIs(t, err, InvalidEventTargetErr, value)
+	}
+}
@@ -0,0 +1,105 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+)
+
+const azureProvider = "azure"
+
+type azureClient struct {
+	subscriptionID string
+}
+
+type serviceAzureClient struct{}
+
+func newAzureClient(_ context.Context, auth *clients.Authentication) (clients.Azure, error) {
+	return &azureClient{subscriptionID: auth.Payload}, nil
+}
+
+func newServiceAzureClient(_ context.Context) (clients.ServiceAzure, error) {
+	return &serviceAzureClient{}, nil
+}
+
+func (c *azureClient) Status(_ context.Context) error {
+	return nil
+}
+
+func (c *azureClient) TenantId(_ context.Context) (clients.AzureTenantId, error) {
+	return "00000000-0000-0000-0000-000000000000", nil
+}
+
+func (c *azureClient) EnsureResourceGroup(_ context.Context, name string, _ string) (*string, error) {
+	id := fmt.Sprintf("/subscriptions/%s/resourceGroups/%s", c.subscriptionID, name)
+	return &id, nil
+}
+
+func (c *azureClient) CreateVMs(_ context.Context, params clients.AzureInstanceParams, amount int64, vmNamePrefix string) ([]clients.InstanceDescription, error) {
+	result := make([]clients.InstanceDescription, amount)
+	for i := range result {
+		id := fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Compute/virtualMachines/%s-%d",
+			c.subscriptionID, params.ResourceGroupName, vmNamePrefix, i)
+		tag := clients.TaggedInstance{ID: id, ReservationID: params.ReservationID, Location: params.Location}
+		result[i] = *newInstance(azureProvider, tag, hash(azureProvider, id))
+	}
+	return result, nil
+}
+
+func (c *azureClient) ListResourceGroups(_ context.Context) ([]string, error) {
+	return []string{"redhat-deployed"}, nil
+}
+
+func (c *azureClient) GetVCPUQuota(_ context.Context, _, _ string) (*clients.Quota, error) {
+	return &clients.Quota{Name: "Fake total regional vCPUs", Limit: 1024}, nil
+}
+
+func (c *azureClient) ProbeCapacity(_ context.Context, _, _ string) (*clients.Capacity, error) {
+	return &clients.Capacity{Regional: true, Zones: []string{"1", "2", "3"}}, nil
+}
+
+func (c *azureClient) DescribeMarketplaceImage(_ context.Context, _ string, image *clients.AzureMarketplaceImage) (*clients.AzureMarketplaceImage, error) {
+	result := *image
+	if result.Version == "latest" {
+		result.Version = "9.2.2023100409"
+	}
+	result.Architecture = clients.ArchitectureTypeX86_64
+	return &result, nil
+}
+
+func (c *azureClient) AcceptMarketplaceTerms(_ context.Context, _ *clients.AzureImagePlan) error {
+	return nil
+}
+
+func (c *serviceAzureClient) RegisterInstanceTypes(_ context.Context, _ *clients.RegisteredInstanceTypes, _ *clients.RegionalTypeAvailability) error {
+	return nil
+}
+
+func (c *azureClient) DeallocateVM(_ context.Context, vmId string) error {
+	return requireInstances(azureProvider, vmId)
+}
+
+func (c *azureClient) StartVM(_ context.Context, vmId string) error {
+	return requireInstances(azureProvider, vmId)
+}
+
+func (c *azureClient) ResizeVM(_ context.Context, vmId, _ string) error {
+	return requireInstances(azureProvider, vmId)
+}
+
+func (c *azureClient) ListTaggedInstances(_ context.Context) ([]*clients.TaggedInstance, error) {
+	return taggedInstances(azureProvider, ""), nil
+}
+
+func (c *azureClient) GetPowerState(_ context.Context, vmId string) (models.PowerState, error) {
+	return powerState(azureProvider, vmId), nil
+}
+
+func (c *azureClient) GetConsoleOutput(_ context.Context, vmId string) (*clients.ConsoleOutput, error) {
+	if err := requireInstances(azureProvider, vmId); err != nil {
+		return nil, err
+	}
+	return &clients.ConsoleOutput{Output: "Fake console output\n"}, nil
+}
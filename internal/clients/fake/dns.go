@@ -0,0 +1,36 @@
+package fake
+
+import (
+	"context"
+
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+)
+
+// fakeDNSRecord returns a record in a per-provider domain, records are not stored
+func fakeDNSRecord(provider, zone, name, ipv4 string, ttl int64) *models.DNSRecord {
+	return &models.DNSRecord{Zone: zone, Name: name + "." + provider + ".example.com", IPv4: ipv4, TTL: ttl}
+}
+
+func (c *ec2Client) CreateDNSRecord(_ context.Context, zone, name, ipv4 string, ttl int64) (*models.DNSRecord, error) {
+	return fakeDNSRecord(ec2Provider, zone, name, ipv4, ttl), nil
+}
+
+func (c *ec2Client) DeleteDNSRecord(_ context.Context, _ *models.DNSRecord) error {
+	return nil
+}
+
+func (c *azureClient) CreateDNSRecord(_ context.Context, zone, name, ipv4 string, ttl int64) (*models.DNSRecord, error) {
+	return fakeDNSRecord(azureProvider, zone, name, ipv4, ttl), nil
+}
+
+func (c *azureClient) DeleteDNSRecord(_ context.Context, _ *models.DNSRecord) error {
+	return nil
+}
+
+func (c *gcpClient) CreateDNSRecord(_ context.Context, zone, name, ipv4 string, ttl int64) (*models.DNSRecord, error) {
+	return fakeDNSRecord(gcpProvider, zone, name, ipv4, ttl), nil
+}
+
+func (c *gcpClient) DeleteDNSRecord(_ context.Context, _ *models.DNSRecord) error {
+	return nil
+}
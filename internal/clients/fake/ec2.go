@@ -0,0 +1,225 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/clients/http"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+)
+
+const ec2Provider = "aws"
+
+type ec2Client struct {
+	region string
+}
+
+func newEC2Client(_ context.Context, _ *clients.Authentication, region string) (clients.EC2, error) {
+	return &ec2Client{region: region}, nil
+}
+
+func newServiceEC2Client(_ context.Context, region string) (clients.EC2, error) {
+	return &ec2Client{region: region}, nil
+}
+
+func (c *ec2Client) Status(_ context.Context) error {
+	return nil
+}
+
+func (c *ec2Client) ListAllRegions(_ context.Context) ([]clients.Region, error) {
+	return []clients.Region{"us-east-1", "us-west-2", "eu-central-1"}, nil
+}
+
+func (c *ec2Client) ListAllZones(_ context.Context, region clients.Region) ([]clients.Zone, error) {
+	return []clients.Zone{
+		clients.Zone(region + "a"),
+		clients.Zone(region + "b"),
+		clients.Zone(region + "c"),
+	}, nil
+}
+
+func (c *ec2Client) ImportPubkey(ctx context.Context, key *models.Pubkey, _ string) (string, error) {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	state.pubkeys[ec2Provider+"/"+key.FindAwsFingerprint(ctx)] = key.Name
+	return fmt.Sprintf("key-%016x", hash(key.Body)), nil
+}
+
+func (c *ec2Client) GetPubkeyName(_ context.Context, fingerprint string) (string, error) {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	if name, ok := state.pubkeys[ec2Provider+"/"+fingerprint]; ok {
+		return name, nil
+	}
+	return "", http.PubkeyNotFoundErr
+}
+
+func (c *ec2Client) DeleteSSHKey(_ context.Context, _ string) error {
+	return nil
+}
+
+func (c *ec2Client) ListInstanceTypes(_ context.Context) ([]*clients.InstanceType, error) {
+	return []*clients.InstanceType{
+		{Name: "t3.small", VCPUs: 2, Cores: 1, MemoryMiB: 2048, Supported: true, Architecture: clients.ArchitectureTypeX86_64},
+		{Name: "t4g.small", VCPUs: 2, Cores: 2, MemoryMiB: 2048, Supported: true, Architecture: clients.ArchitectureTypeArm64},
+	}, nil
+}
+
+func (c *ec2Client) ListLaunchTemplates(_ context.Context) ([]*clients.LaunchTemplate, error) {
+	return []*clients.LaunchTemplate{{ID: "lt-00000000000000001", Name: "Fake launch template"}}, nil
+}
+
+func (c *ec2Client) GetLaunchTemplateVersion(_ context.Context, _ string) (*clients.LaunchTemplateVersion, error) {
+	return &clients.LaunchTemplateVersion{InstanceType: "t3.micro", ImageID: "ami-00000000000000001"}, nil
+}
+
+func (c *ec2Client) RunInstances(_ context.Context, _ *clients.AWSInstanceParams, amount int32, _ *string, reservation *models.AWSReservation) ([]*string, *string, error) {
+	ids := make([]*string, amount)
+	for i := range ids {
+		seed := hash(ec2Provider, reservation.ID, i)
+		tag := clients.TaggedInstance{ID: fmt.Sprintf("i-%017x", seed>>4), ReservationID: reservation.ID, Location: c.region}
+		instance := newInstance(ec2Provider, tag, seed)
+		ids[i] = &instance.ID
+	}
+	awsReservationId := fmt.Sprintf("r-%017x", hash(ec2Provider, reservation.ID)>>4)
+	return ids, &awsReservationId, nil
+}
+
+func (c *ec2Client) CreateFleet(ctx context.Context, params *clients.AWSInstanceParams, pools []clients.FleetPool, amount int32, name *string, reservation *models.AWSReservation) ([]*clients.FleetInstance, *string, error) {
+	ids, _, err := c.RunInstances(ctx, params, amount, name, reservation)
+	if err != nil {
+		return nil, nil, err
+	}
+	result := make([]*clients.FleetInstance, len(ids))
+	for i, id := range ids {
+		pool := pools[i%len(pools)]
+		result[i] = &clients.FleetInstance{
+			ID:         *id,
+			Allocation: models.FleetAllocation{InstanceType: pool.InstanceType, SubnetID: pool.SubnetID, Lifecycle: "on-demand"},
+		}
+	}
+	fleetId := fmt.Sprintf("fleet-%017x", hash(ec2Provider, reservation.ID)>>4)
+	return result, &fleetId, nil
+}
+
+func (c *ec2Client) GetAccountId(_ context.Context) (string, error) {
+	return "000000000000", nil
+}
+
+func (c *ec2Client) CheckPermission(_ context.Context, _ *clients.Authentication) ([]string, error) {
+	return nil, nil
+}
+
+func (c *ec2Client) CheckKMSKeyAccess(_ context.Context, _ *clients.Authentication, _ string) ([]string, error) {
+	return nil, nil
+}
+
+func (c *ec2Client) CheckInstanceProfile(_ context.Context, _ *clients.Authentication, _ string) ([]string, error) {
+	return nil, nil
+}
+
+func (c *ec2Client) DescribeInstanceDetails(_ context.Context, ids []string) ([]*clients.InstanceDescription, error) {
+	result := make([]*clients.InstanceDescription, 0, len(ids))
+	for _, id := range ids {
+		if instance, ok := findInstance(ec2Provider, id); ok {
+			result = append(result, instance)
+		}
+	}
+	return result, nil
+}
+
+func (c *ec2Client) GetImageArchitecture(_ context.Context, _ string) (clients.ArchitectureType, error) {
+	return clients.ArchitectureTypeX86_64, nil
+}
+
+func (c *ec2Client) GetConsoleOutput(_ context.Context, _ string) (*clients.ConsoleOutput, error) {
+	return &clients.ConsoleOutput{Output: "Fake console output\n"}, nil
+}
+
+func (c *ec2Client) ListTaggedInstances(_ context.Context) ([]*clients.TaggedInstance, error) {
+	return taggedInstances(ec2Provider, c.region), nil
+}
+
+func (c *ec2Client) GetPowerState(_ context.Context, instanceId string) (models.PowerState, error) {
+	return powerState(ec2Provider, instanceId), nil
+}
+
+func (c *ec2Client) IsWindowsImage(_ context.Context, _ string) (bool, error) {
+	return false, nil
+}
+
+func (c *ec2Client) GetPasswordData(_ context.Context, _ string) (string, error) {
+	return "", nil
+}
+
+func (c *ec2Client) GetSubnetCIDR(_ context.Context, _ string) (string, error) {
+	return "10.0.0.0/8", nil
+}
+
+func (c *ec2Client) ListSubnets(_ context.Context) ([]*clients.Subnet, error) {
+	return []*clients.Subnet{{ID: "subnet-00000000000000001", Name: "Fake subnet", VpcID: "vpc-00000000000000001", CIDR: "10.0.0.0/8", Default: true}}, nil
+}
+
+func (c *ec2Client) ListSecurityGroups(_ context.Context) ([]*clients.SecurityGroup, error) {
+	return []*clients.SecurityGroup{{ID: "sg-00000000000000001", Name: "default", VpcID: "vpc-00000000000000001"}}, nil
+}
+
+func (c *ec2Client) ListDedicatedHosts(_ context.Context) ([]*clients.DedicatedHost, error) {
+	return []*clients.DedicatedHost{}, nil
+}
+
+func (c *ec2Client) ListCapacityReservations(_ context.Context) ([]*clients.CapacityReservation, error) {
+	return []*clients.CapacityReservation{}, nil
+}
+
+func (c *ec2Client) GetVCPUQuota(_ context.Context, _ string) (*clients.Quota, error) {
+	return &clients.Quota{Name: "Fake on-demand standard instances", Limit: 1024}, nil
+}
+
+func (c *ec2Client) ProbeCapacity(_ context.Context, _ string) (*clients.Capacity, error) {
+	return &clients.Capacity{Regional: true, Zones: []string{c.region + "a", c.region + "b", c.region + "c"}}, nil
+}
+
+// DescribeSpotRequests returns fulfilled requests, fake spot instances are never interrupted.
+func (c *ec2Client) DescribeSpotRequests(_ context.Context, ids []string) ([]*clients.SpotRequest, error) {
+	result := make([]*clients.SpotRequest, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := findInstance(ec2Provider, id); ok {
+			result = append(result, &clients.SpotRequest{
+				ID:         "sir-" + strings.TrimPrefix(id, "i-")[:8],
+				InstanceID: id,
+				State:      clients.SpotStateActive,
+				Status:     "fulfilled",
+			})
+		}
+	}
+	return result, nil
+}
+
+func (c *ec2Client) CancelSpotInstances(_ context.Context, _, ids []string) error {
+	return requireInstances(ec2Provider, ids...)
+}
+
+func (c *ec2Client) StopInstances(_ context.Context, ids []string) error {
+	return requireInstances(ec2Provider, ids...)
+}
+
+func (c *ec2Client) StartInstances(_ context.Context, ids []string) error {
+	return requireInstances(ec2Provider, ids...)
+}
+
+func (c *ec2Client) ModifyInstanceType(_ context.Context, id, _ string) error {
+	return requireInstances(ec2Provider, id)
+}
+
+// PublishEvent drops events, the fake provider has no event targets.
+func (c *ec2Client) PublishEvent(_ context.Context, target *clients.EventTarget, _ string, _ []byte) error {
+	if target.Region != c.region {
+		return fmt.Errorf("%w: target region %s, client region %s", clients.InvalidEventTargetErr, target.Region, c.region)
+	}
+	return nil
+}
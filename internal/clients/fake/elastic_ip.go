@@ -0,0 +1,54 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+)
+
+// ListPoolElasticIPs returns no addresses, fake pools are always empty.
+func (c *ec2Client) ListPoolElasticIPs(_ context.Context, _ string) ([]*models.ElasticIP, error) {
+	return nil, nil
+}
+
+func (c *ec2Client) AllocateElasticIP(_ context.Context, reservationId int64) (*models.ElasticIP, error) {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	seed := hash(ec2Provider, "eip", reservationId, len(state.addresses))
+	eip := &models.ElasticIP{
+		AllocationID: fmt.Sprintf("eipalloc-%017x", seed>>4),
+		PublicIP:     fmt.Sprintf("203.0.%d.%d", byte(seed>>8), byte(seed)|1),
+	}
+	state.addresses[eip.AllocationID] = eip.PublicIP
+	return eip, nil
+}
+
+// AssociateElasticIP replaces the public address of the fake instance.
+func (c *ec2Client) AssociateElasticIP(_ context.Context, allocationId, instanceId string) (string, error) {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	ip, ok := state.addresses[allocationId]
+	if !ok {
+		return "", fmt.Errorf("fake elastic IP %s: %w", allocationId, clients.NotFoundErr)
+	}
+	instance, ok := state.instances[ec2Provider+"/"+instanceId]
+	if !ok {
+		return "", fmt.Errorf("fake instance %s: %w", instanceId, clients.NotFoundErr)
+	}
+	instance.PublicIPv4 = ip
+	return "eipassoc-" + strings.TrimPrefix(allocationId, "eipalloc-"), nil
+}
+
+func (c *ec2Client) DisassociateElasticIP(_ context.Context, _ string) error {
+	return nil
+}
+
+func (c *ec2Client) ReleaseElasticIP(_ context.Context, allocationId string) error {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	delete(state.addresses, allocationId)
+	return nil
+}
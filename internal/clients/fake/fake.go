@@ -0,0 +1,130 @@
+// Package fake provides in-memory cloud provider clients for development without cloud
+// credentials. Pubkeys are kept in memory and launches return fake instances with IDs and
+// addresses derived from the reservation, so the whole reservation flow can be tested locally.
+//
+// The clients are enabled via APP_CLOUD_CLIENTS=fake, call Initialize after the configuration
+// was loaded to replace the SDK implementations.
+package fake
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"sync"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/rs/zerolog"
+)
+
+// Initialize replaces cloud provider and image builder clients with in-memory implementations
+// when enabled in the configuration.
+func Initialize(ctx context.Context) {
+	if config.Application.CloudClients != config.CloudClientsFake {
+		return
+	}
+	zerolog.Ctx(ctx).Warn().Msg("Using fake in-memory cloud clients, no instances will be launched")
+
+	clients.GetEC2Client = newEC2Client
+	clients.GetServiceEC2Client = newServiceEC2Client
+	clients.GetAzureClient = newAzureClient
+	clients.GetServiceAzureClient = newServiceAzureClient
+	clients.GetGCPClient = newGCPClient
+	clients.GetServiceGCPClient = newServiceGCPClient
+	clients.GetImageBuilderClient = newImageBuilderClient
+}
+
+// store keeps state of all fake clients, it is shared across all tenants
+type store struct {
+	mu sync.Mutex
+
+	// pubkeys by provider and fingerprint
+	pubkeys map[string]string
+
+	// instances by provider and ID
+	instances map[string]*clients.InstanceDescription
+
+	// reservation tags of instances by provider and ID
+	tagged map[string]*clients.TaggedInstance
+
+	// instance IDs by GCP label (reservation UUID)
+	labels map[string][]*string
+
+	// public IPs of elastic IPs by allocation ID
+	addresses map[string]string
+}
+
+var state = store{
+	pubkeys:   make(map[string]string),
+	instances: make(map[string]*clients.InstanceDescription),
+	tagged:    make(map[string]*clients.TaggedInstance),
+	labels:    make(map[string][]*string),
+	addresses: make(map[string]string),
+}
+
+// hash returns a deterministic number for the given values
+func hash(values ...any) uint64 {
+	h := fnv.New64a()
+	for _, v := range values {
+		_, _ = fmt.Fprint(h, v, "/")
+	}
+	return h.Sum64()
+}
+
+// newInstance creates a deterministic instance description and stores it with its tag
+func newInstance(provider string, tag clients.TaggedInstance, seed uint64) *clients.InstanceDescription {
+	ip := fmt.Sprintf("198.51.%d.%d", byte(seed>>8), byte(seed)|1)
+	instance := &clients.InstanceDescription{
+		ID:          tag.ID,
+		PublicIPv4:  ip,
+		PublicDNS:   fmt.Sprintf("%s.%s.fake.example.com", tag.ID, provider),
+		PrivateIPv4: fmt.Sprintf("10.%d.%d.%d", byte(seed>>16), byte(seed>>8), byte(seed)|1),
+	}
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	state.instances[provider+"/"+tag.ID] = instance
+	state.tagged[provider+"/"+tag.ID] = &tag
+	return instance
+}
+
+func findInstance(provider, id string) (*clients.InstanceDescription, bool) {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	instance, ok := state.instances[provider+"/"+id]
+	return instance, ok
+}
+
+// requireInstances returns not found error unless all instances exist, power state is not tracked
+func requireInstances(provider string, ids ...string) error {
+	for _, id := range ids {
+		if _, ok := findInstance(provider, id); !ok {
+			return fmt.Errorf("fake instance %s: %w", id, clients.NotFoundErr)
+		}
+	}
+	return nil
+}
+
+// powerState returns running for launched instances, fake instances are never stopped.
+func powerState(provider, id string) models.PowerState {
+	if _, ok := findInstance(provider, id); !ok {
+		return models.PowerStateTerminated
+	}
+	return models.PowerStateRunning
+}
+
+// taggedInstances returns tagged instances of the provider of all tenants, optionally only of
+// a location.
+func taggedInstances(provider, location string) []*clients.TaggedInstance {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	var result []*clients.TaggedInstance
+	for key, tag := range state.tagged {
+		if strings.HasPrefix(key, provider+"/") && (location == "" || tag.Location == location) {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
@@ -0,0 +1,87 @@
+package fake_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/clients/fake"
+	"github.com/RHEnVision/provisioning-backend/internal/clients/http"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func initialize(t *testing.T) context.Context {
+	t.Helper()
+	config.Application.CloudClients = config.CloudClientsFake
+	ctx := context.Background()
+	fake.Initialize(ctx)
+	return ctx
+}
+
+func TestEC2Pubkey(t *testing.T) {
+	ctx := initialize(t)
+	ec2, err := clients.GetEC2Client(ctx, &clients.Authentication{}, "us-east-1")
+	require.NoError(t, err)
+
+	pk := &models.Pubkey{
+		Name: "lzap",
+		Body: "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIEhnn80ZywmjeBFFOGm+cm+5HUwm62qTVnjKlOdYFLHN lzap",
+	}
+	_, err = ec2.GetPubkeyName(ctx, pk.FindAwsFingerprint(ctx))
+	require.ErrorIs(t, err, http.PubkeyNotFoundErr)
+
+	_, err = ec2.ImportPubkey(ctx, pk, "tag")
+	require.NoError(t, err)
+
+	name, err := ec2.GetPubkeyName(ctx, pk.FindAwsFingerprint(ctx))
+	require.NoError(t, err)
+	assert.Equal(t, "lzap", name)
+}
+
+func TestEC2RunInstances(t *testing.T) {
+	ctx := initialize(t)
+	ec2, err := clients.GetEC2Client(ctx, &clients.Authentication{}, "us-east-1")
+	require.NoError(t, err)
+
+	reservation := &models.AWSReservation{Reservation: models.Reservation{ID: 42}}
+	ids, awsReservationId, err := ec2.RunInstances(ctx, &clients.AWSInstanceParams{}, 2, nil, reservation)
+	require.NoError(t, err)
+	require.Len(t, ids, 2)
+	require.NotNil(t, awsReservationId)
+	assert.NotEqual(t, *ids[0], *ids[1])
+
+	again, _, err := ec2.RunInstances(ctx, &clients.AWSInstanceParams{}, 2, nil, reservation)
+	require.NoError(t, err)
+	assert.Equal(t, *ids[0], *again[0], "instance IDs must be deterministic")
+
+	details, err := ec2.DescribeInstanceDetails(ctx, []string{*ids[0], *ids[1], "i-missing"})
+	require.NoError(t, err)
+	require.Len(t, details, 2)
+	assert.Equal(t, *ids[0], details[0].ID)
+	assert.NotEmpty(t, details[0].PublicIPv4)
+	assert.NotEmpty(t, details[0].PublicDNS)
+}
+
+func TestGCPInsertInstances(t *testing.T) {
+	ctx := initialize(t)
+	gcp, err := clients.GetGCPClient(ctx, &clients.Authentication{})
+	require.NoError(t, err)
+
+	ids, _, err := gcp.InsertInstances(ctx, &clients.GCPInstanceParams{ReservationID: 13, UUID: "uuid-13"}, 3)
+	require.NoError(t, err)
+	require.Len(t, ids, 3)
+
+	listed, err := gcp.ListInstancesIDsByLabel(ctx, "uuid-13")
+	require.NoError(t, err)
+	assert.Equal(t, ids, listed)
+
+	instance, err := gcp.GetInstanceDescriptionByID(ctx, *ids[0], "us-east4-a")
+	require.NoError(t, err)
+	assert.NotEmpty(t, instance.PublicIPv4)
+
+	_, err = gcp.GetInstanceDescriptionByID(ctx, "0", "us-east4-a")
+	require.ErrorIs(t, err, clients.NotFoundErr)
+}
@@ -0,0 +1,134 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+)
+
+const gcpProvider = "gcp"
+
+type gcpClient struct{}
+
+type serviceGCPClient struct{}
+
+func newGCPClient(_ context.Context, _ *clients.Authentication) (clients.GCP, error) {
+	return &gcpClient{}, nil
+}
+
+func newServiceGCPClient(_ context.Context) (clients.ServiceGCP, error) {
+	return &serviceGCPClient{}, nil
+}
+
+func (c *gcpClient) Status(_ context.Context) error {
+	return nil
+}
+
+func (c *gcpClient) ListAllRegions(_ context.Context) ([]clients.Region, error) {
+	return []clients.Region{"us-east4", "europe-west1"}, nil
+}
+
+func (c *gcpClient) InsertInstances(_ context.Context, params *clients.GCPInstanceParams, amount int64) ([]*string, *string, error) {
+	ids := make([]*string, amount)
+	for i := range ids {
+		seed := hash(gcpProvider, params.ReservationID, i)
+		// GCP instance IDs are numeric
+		tag := clients.TaggedInstance{ID: fmt.Sprintf("%d", seed>>1), ReservationID: params.ReservationID, Location: params.Zone}
+		instance := newInstance(gcpProvider, tag, seed)
+		ids[i] = &instance.ID
+	}
+
+	state.mu.Lock()
+	state.labels[params.UUID] = ids
+	state.mu.Unlock()
+
+	opName := fmt.Sprintf("operation-%016x", hash(gcpProvider, params.ReservationID))
+	return ids, &opName, nil
+}
+
+func (c *gcpClient) ListInstancesIDsByLabel(_ context.Context, uuid string) ([]*string, error) {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	return state.labels[uuid], nil
+}
+
+func (c *gcpClient) GetInstanceDescriptionByID(_ context.Context, id, _ string) (*clients.InstanceDescription, error) {
+	if instance, ok := findInstance(gcpProvider, id); ok {
+		return instance, nil
+	}
+	return nil, fmt.Errorf("fake instance %s: %w", id, clients.NotFoundErr)
+}
+
+func (c *gcpClient) StopInstance(_ context.Context, id, _ string) error {
+	return requireInstances(gcpProvider, id)
+}
+
+func (c *gcpClient) StartInstance(_ context.Context, id, _ string) error {
+	return requireInstances(gcpProvider, id)
+}
+
+func (c *gcpClient) SetMachineType(_ context.Context, id, _, _ string) error {
+	return requireInstances(gcpProvider, id)
+}
+
+func (c *gcpClient) ListTaggedInstances(_ context.Context) ([]*clients.TaggedInstance, error) {
+	return taggedInstances(gcpProvider, ""), nil
+}
+
+func (c *gcpClient) GetPowerState(_ context.Context, id, _ string) (models.PowerState, error) {
+	return powerState(gcpProvider, id), nil
+}
+
+func (c *gcpClient) GetConsoleOutput(_ context.Context, id, _ string) (*clients.ConsoleOutput, error) {
+	if err := requireInstances(gcpProvider, id); err != nil {
+		return nil, err
+	}
+	return &clients.ConsoleOutput{Output: "Fake console output\n"}, nil
+}
+
+func (c *gcpClient) ListLaunchTemplates(_ context.Context) ([]*clients.LaunchTemplate, error) {
+	return []*clients.LaunchTemplate{{ID: "1000000000000000001", Name: "fake-instance-template"}}, nil
+}
+
+func (c *gcpClient) ListImages(_ context.Context, projects []string) ([]*clients.Image, error) {
+	images := make([]*clients.Image, 0, len(projects))
+	for _, project := range projects {
+		images = append(images, fakeGCPImage(project, "rhel-9"))
+	}
+	return images, nil
+}
+
+func (c *gcpClient) GetImageFromFamily(_ context.Context, project, family string) (*clients.Image, error) {
+	return fakeGCPImage(project, family), nil
+}
+
+func fakeGCPImage(project, family string) *clients.Image {
+	name := family + "-v20231010"
+	return &clients.Image{
+		Name:         name,
+		Family:       family,
+		Project:      project,
+		SelfLink:     fmt.Sprintf("https://www.googleapis.com/compute/v1/projects/%s/global/images/%s", project, name),
+		Architecture: clients.ArchitectureTypeX86_64,
+	}
+}
+
+func (c *gcpClient) GetVCPUQuota(_ context.Context, _, _ string) (*clients.Quota, error) {
+	return &clients.Quota{Name: "CPUS", Limit: 1024}, nil
+}
+
+func (c *serviceGCPClient) RegisterInstanceTypes(_ context.Context, _ *clients.RegisteredInstanceTypes, _ *clients.RegionalTypeAvailability) error {
+	return nil
+}
+
+func (c *serviceGCPClient) ListMachineTypes(_ context.Context, _ string) ([]*clients.InstanceType, error) {
+	return []*clients.InstanceType{
+		{Name: "e2-small", VCPUs: 2, Cores: 1, MemoryMiB: 2048, Supported: true, Architecture: clients.ArchitectureTypeX86_64},
+	}, nil
+}
+
+func (c *serviceGCPClient) ListAllRegionsAndZones(_ context.Context) ([]clients.Region, []clients.Zone, error) {
+	return []clients.Region{"us-east4"}, []clients.Zone{"us-east4-a", "us-east4-b", "us-east4-c"}, nil
+}
@@ -0,0 +1,34 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+)
+
+type imageBuilderClient struct{}
+
+func newImageBuilderClient(_ context.Context) (clients.ImageBuilder, error) {
+	return &imageBuilderClient{}, nil
+}
+
+func (c *imageBuilderClient) GetAWSAmi(_ context.Context, composeID string) (string, error) {
+	return fmt.Sprintf("ami-%017x", hash(composeID)>>4), nil
+}
+
+func (c *imageBuilderClient) GetAzureImageID(_ context.Context, composeID string) (string, error) {
+	return fmt.Sprintf("/resourceGroups/redhat-deployed/providers/Microsoft.Compute/images/composer-api-%s", composeID), nil
+}
+
+func (c *imageBuilderClient) GetGCPImageName(_ context.Context, composeID string) (string, error) {
+	return fmt.Sprintf("projects/fake-project/global/images/composer-api-%s", composeID), nil
+}
+
+func (c *imageBuilderClient) GetImageArchitecture(_ context.Context, _ string) (clients.ArchitectureType, error) {
+	return clients.ArchitectureTypeX86_64, nil
+}
+
+func (c *imageBuilderClient) Ready(_ context.Context) error {
+	return nil
+}
@@ -0,0 +1,22 @@
+package clients
+
+import "github.com/RHEnVision/provisioning-backend/internal/models"
+
+// FleetPool is a capacity pool of an EC2 Fleet launch: an instance type in a subnet. Pools are
+// tried in the order of the slice, the first pool has the highest priority.
+type FleetPool struct {
+	// InstanceType of the pool
+	InstanceType string
+
+	// SubnetID of the pool, the default subnet is used when blank
+	SubnetID string
+}
+
+// FleetInstance is an instance launched by an EC2 Fleet together with the pool it was launched in.
+type FleetInstance struct {
+	// Instance ID
+	ID string
+
+	// Allocation is the pool which provided the capacity
+	Allocation models.FleetAllocation
+}
@@ -37,7 +37,7 @@ func newAzureClient(ctx context.Context, auth *clients.Authentication) (clients.
 }
 
 func (c *client) newResourceGroupsClient(ctx context.Context) (*armresources.ResourceGroupsClient, error) {
-	client, err := armresources.NewResourceGroupsClient(c.subscriptionID, c.credential, nil)
+	client, err := armresources.NewResourceGroupsClient(c.subscriptionID, c.credential, clientOptions)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create resources Azure client: %w", err)
 	}
@@ -45,7 +45,7 @@ func (c *client) newResourceGroupsClient(ctx context.Context) (*armresources.Res
 }
 
 func (c *client) newImagesClient(ctx context.Context) (*armcompute.ImagesClient, error) {
-	vmClient, err := armcompute.NewImagesClient(c.subscriptionID, c.credential, nil)
+	vmClient, err := armcompute.NewImagesClient(c.subscriptionID, c.credential, clientOptions)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create Image Azure client: %w", err)
 	}
@@ -53,7 +53,7 @@ func (c *client) newImagesClient(ctx context.Context) (*armcompute.ImagesClient,
 }
 
 func (c *client) newVirtualMachinesClient(ctx context.Context) (*armcompute.VirtualMachinesClient, error) {
-	vmClient, err := armcompute.NewVirtualMachinesClient(c.subscriptionID, c.credential, nil)
+	vmClient, err := armcompute.NewVirtualMachinesClient(c.subscriptionID, c.credential, clientOptions)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create VM Azure client: %w", err)
 	}
@@ -61,7 +61,7 @@ func (c *client) newVirtualMachinesClient(ctx context.Context) (*armcompute.Virt
 }
 
 func (c *client) newSubscriptionsClient(ctx context.Context) (*armsubscriptions.Client, error) {
-	client, err := armsubscriptions.NewClient(c.credential, nil)
+	client, err := armsubscriptions.NewClient(c.credential, clientOptions)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create subscriptioons Azure client: %w", err)
 	}
@@ -69,7 +69,7 @@ func (c *client) newSubscriptionsClient(ctx context.Context) (*armsubscriptions.
 }
 
 func (c *client) newSshKeysClient(ctx context.Context) (*armcompute.SSHPublicKeysClient, error) {
-	client, err := armcompute.NewSSHPublicKeysClient(c.subscriptionID, c.credential, nil)
+	client, err := armcompute.NewSSHPublicKeysClient(c.subscriptionID, c.credential, clientOptions)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create SSH keys Azure client: %w", err)
 	}
@@ -77,7 +77,7 @@ func (c *client) newSshKeysClient(ctx context.Context) (*armcompute.SSHPublicKey
 }
 
 func (c *client) newVirtualNetworksClient(ctx context.Context) (*armnetwork.VirtualNetworksClient, error) {
-	vnetClient, err := armnetwork.NewVirtualNetworksClient(c.subscriptionID, c.credential, nil)
+	vnetClient, err := armnetwork.NewVirtualNetworksClient(c.subscriptionID, c.credential, clientOptions)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create Virtual networks Azure client: %w", err)
 	}
@@ -85,7 +85,7 @@ func (c *client) newVirtualNetworksClient(ctx context.Context) (*armnetwork.Virt
 }
 
 func (c *client) newSubnetsClient(ctx context.Context) (*armnetwork.SubnetsClient, error) {
-	subnetClient, err := armnetwork.NewSubnetsClient(c.subscriptionID, c.credential, nil)
+	subnetClient, err := armnetwork.NewSubnetsClient(c.subscriptionID, c.credential, clientOptions)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create SSH keys Azure client: %w", err)
 	}
@@ -93,7 +93,7 @@ func (c *client) newSubnetsClient(ctx context.Context) (*armnetwork.SubnetsClien
 }
 
 func (c *client) newPublicIPAddressesClient(ctx context.Context) (*armnetwork.PublicIPAddressesClient, error) {
-	publicIPAddressClient, err := armnetwork.NewPublicIPAddressesClient(c.subscriptionID, c.credential, nil)
+	publicIPAddressClient, err := armnetwork.NewPublicIPAddressesClient(c.subscriptionID, c.credential, clientOptions)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create public IP addresses Azure client: %w", err)
 	}
@@ -101,7 +101,7 @@ func (c *client) newPublicIPAddressesClient(ctx context.Context) (*armnetwork.Pu
 }
 
 func (c *client) newSecurityGroupsClient(ctx context.Context) (*armnetwork.SecurityGroupsClient, error) {
-	nsgClient, err := armnetwork.NewSecurityGroupsClient(c.subscriptionID, c.credential, nil)
+	nsgClient, err := armnetwork.NewSecurityGroupsClient(c.subscriptionID, c.credential, clientOptions)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create security groups Azure client: %w", err)
 	}
@@ -109,7 +109,7 @@ func (c *client) newSecurityGroupsClient(ctx context.Context) (*armnetwork.Secur
 }
 
 func (c *client) newInterfacesClient(ctx context.Context) (*armnetwork.InterfacesClient, error) {
-	nicClient, err := armnetwork.NewInterfacesClient(c.subscriptionID, c.credential, nil)
+	nicClient, err := armnetwork.NewInterfacesClient(c.subscriptionID, c.credential, clientOptions)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create interfaces Azure client: %w", err)
 	}
@@ -0,0 +1,69 @@
+package azure
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	httpClients "github.com/RHEnVision/provisioning-backend/internal/clients/http"
+)
+
+// clientOptions are options of all ARM clients. Throttled requests are retried by the shared
+// retry transport, the SDK retry policy only repeats timeouts and server errors.
+var clientOptions = &arm.ClientOptions{
+	ClientOptions: policy.ClientOptions{
+		PerCallPolicies: []policy.Policy{providerErrorPolicy{}},
+		Retry: policy.RetryOptions{
+			StatusCodes: []int{
+				http.StatusRequestTimeout,
+				http.StatusInternalServerError,
+				http.StatusBadGateway,
+				http.StatusServiceUnavailable,
+				http.StatusGatewayTimeout,
+			},
+		},
+		Transport: &http.Client{
+			Transport: httpClients.NewRetryTransport(clients.ProviderAzure, http.DefaultTransport),
+		},
+	},
+}
+
+// providerErrorPolicy returns unsuccessful responses as clients.ProviderError wrapping
+// azcore.ResponseError. The policy is called once per operation, the error is returned after
+// all retries failed.
+type providerErrorPolicy struct{}
+
+func (providerErrorPolicy) Do(req *policy.Request) (*http.Response, error) {
+	resp, err := req.Next()
+	if err != nil {
+		return resp, clients.NewProviderError(clients.ProviderAzure, operation(req.Raw()), 0, "", err)
+	}
+	if resp.StatusCode < http.StatusBadRequest {
+		return resp, nil
+	}
+
+	respErr := runtime.NewResponseError(resp)
+	var azErr *azcore.ResponseError
+	code := ""
+	if errors.As(respErr, &azErr) {
+		code = azErr.ErrorCode
+	}
+	return resp, clients.NewProviderError(clients.ProviderAzure, operation(req.Raw()), resp.StatusCode, code, respErr)
+}
+
+// operation returns the HTTP method and the resource type of an ARM request, for example
+// "PUT Microsoft.Compute/virtualMachines".
+func operation(req *http.Request) string {
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	for i := len(segments) - 2; i >= 0; i-- {
+		if strings.EqualFold(segments[i], "providers") && i+2 < len(segments) {
+			return req.Method + " " + segments[i+1] + "/" + segments[i+2]
+		}
+	}
+	return req.Method + " " + req.URL.Path
+}
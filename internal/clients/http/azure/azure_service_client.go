@@ -35,7 +35,7 @@ func newServiceClient(ctx context.Context) (clients.ServiceAzure, error) {
 func (c *serviceClient) RegisterInstanceTypes(ctx context.Context, instanceTypes *clients.RegisteredInstanceTypes, regionalTypes *clients.RegionalTypeAvailability) error {
 	restricted := make(map[armcompute.ResourceSKURestrictionsReasonCode]int, 0)
 
-	skuClient, err := armcompute.NewResourceSKUsClient(config.Azure.SubscriptionID, c.credential, nil)
+	skuClient, err := armcompute.NewResourceSKUsClient(config.Azure.SubscriptionID, c.credential, clientOptions)
 	if err != nil {
 		return fmt.Errorf("unable to generate types: %w", err)
 	}
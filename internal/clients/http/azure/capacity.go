@@ -0,0 +1,114 @@
+package azure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute"
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/ptr"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"golang.org/x/exp/slices"
+)
+
+// allocationErrorCodes are returned by Azure when there is not enough capacity for the VM size
+var allocationErrorCodes = []string{
+	"AllocationFailed",
+	"ZonalAllocationFailed",
+	"OverconstrainedAllocationRequest",
+	"OverconstrainedZonalAllocationRequest",
+	"SkuNotAvailable",
+}
+
+func (c *client) newResourceSKUsClient(ctx context.Context) (*armcompute.ResourceSKUsClient, error) {
+	skuClient, err := armcompute.NewResourceSKUsClient(c.subscriptionID, c.credential, clientOptions)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create resource SKUs Azure client: %w", err)
+	}
+	return skuClient, nil
+}
+
+func (c *client) ProbeCapacity(ctx context.Context, location, size string) (*clients.Capacity, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "ProbeCapacity")
+	defer span.End()
+
+	skuClient, err := c.newResourceSKUsClient(ctx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	capacity := &clients.Capacity{}
+	pager := skuClient.NewListPager(&armcompute.ResourceSKUsClientListOptions{
+		Filter: to.Ptr(fmt.Sprintf("location eq '%s'", location)),
+	})
+	for pager.More() {
+		page, pagerErr := pager.NextPage(ctx)
+		if pagerErr != nil {
+			span.SetStatus(codes.Error, pagerErr.Error())
+			return nil, fmt.Errorf("failed to fetch resource SKUs: %w", pagerErr)
+		}
+		for _, sku := range page.Value {
+			if ptr.From(sku.ResourceType) != "virtualMachines" || !strings.EqualFold(ptr.From(sku.Name), size) {
+				continue
+			}
+			applySKURestrictions(capacity, sku, location)
+			return capacity, nil
+		}
+	}
+
+	// the size is not offered in the location at all
+	return capacity, nil
+}
+
+// applySKURestrictions fills the capacity with zones of the SKU in the location which are not
+// restricted for the subscription.
+func applySKURestrictions(capacity *clients.Capacity, sku *armcompute.ResourceSKU, location string) {
+	var zones []string
+	for _, info := range sku.LocationInfo {
+		if !strings.EqualFold(ptr.From(info.Location), location) {
+			continue
+		}
+		for _, zone := range info.Zones {
+			zones = append(zones, ptr.From(zone))
+		}
+	}
+
+	capacity.Regional = true
+	var restrictedZones []string
+	for _, restriction := range sku.Restrictions {
+		switch ptr.From(restriction.Type) {
+		case armcompute.ResourceSKURestrictionsTypeLocation:
+			capacity.Regional = false
+			zones = nil
+		case armcompute.ResourceSKURestrictionsTypeZone:
+			if restriction.RestrictionInfo != nil {
+				for _, zone := range restriction.RestrictionInfo.Zones {
+					restrictedZones = append(restrictedZones, ptr.From(zone))
+				}
+			}
+		}
+	}
+
+	for _, zone := range zones {
+		if !slices.Contains(restrictedZones, zone) {
+			capacity.Zones = append(capacity.Zones, zone)
+		}
+	}
+	sort.Strings(capacity.Zones)
+}
+
+// allocationError marks errors of insufficient capacity, other errors are returned unchanged.
+func allocationError(err error) error {
+	var azErr *azcore.ResponseError
+	if errors.As(err, &azErr) && slices.Contains(allocationErrorCodes, azErr.ErrorCode) {
+		return fmt.Errorf("%w: %s", clients.InsufficientCapacityErr, err.Error())
+	}
+	return err
+}
@@ -0,0 +1,81 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	httpClients "github.com/RHEnVision/provisioning-backend/internal/clients/http"
+	"github.com/RHEnVision/provisioning-backend/internal/ptr"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func (c *client) GetConsoleOutput(ctx context.Context, vmId string) (*clients.ConsoleOutput, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "GetConsoleOutput")
+	defer span.End()
+
+	resourceId, err := arm.ParseResourceID(vmId)
+	if err != nil {
+		span.SetStatus(codes.Error, "invalid virtual machine id")
+		return nil, fmt.Errorf("cannot parse virtual machine id %s: %w", vmId, err)
+	}
+
+	vmClient, err := c.newVirtualMachinesClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := vmClient.RetrieveBootDiagnosticsData(ctx, resourceId.ResourceGroupName, resourceId.Name, nil)
+	if err != nil {
+		span.SetStatus(codes.Error, "cannot retrieve boot diagnostics data")
+		return nil, fmt.Errorf("cannot retrieve boot diagnostics data: %w", err)
+	}
+
+	// the serial log is a blob readable with the returned SAS URI
+	output, err := downloadBlob(ctx, ptr.FromOrEmpty(resp.SerialConsoleLogBlobURI))
+	if err != nil {
+		span.SetStatus(codes.Error, "cannot download serial console log")
+		return nil, fmt.Errorf("cannot download serial console log: %w", err)
+	}
+
+	return &clients.ConsoleOutput{
+		Output:        clients.TrimConsoleOutput(string(output)),
+		ScreenshotURL: ptr.FromOrEmpty(resp.ConsoleScreenshotBlobURI),
+	}, nil
+}
+
+// downloadBlob reads the last clients.MaxConsoleOutput bytes of a blob, empty URI is an empty blob.
+func downloadBlob(ctx context.Context, uri string) ([]byte, error) {
+	if uri == "" {
+		return nil, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create blob request: %w", err)
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=-%d", clients.MaxConsoleOutput))
+
+	resp, err := httpClients.NewPlatformClient(ctx, "").Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get blob: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// the range of an empty blob is not satisfiable
+	if resp.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+		return nil, nil
+	}
+	if err = httpClients.HandleHTTPResponses(ctx, clients.ProviderAzure, "GetBlob", resp.StatusCode); err != nil {
+		return nil, fmt.Errorf("cannot get blob: %w", err)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, clients.MaxConsoleOutput))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read blob: %w", err)
+	}
+	return body, nil
+}
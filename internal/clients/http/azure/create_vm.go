@@ -18,6 +18,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
 	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
 	"github.com/RHEnVision/provisioning-backend/internal/ptr"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/codes"
@@ -28,12 +29,13 @@ const (
 	subnetName            = "redhat-subnet"
 	nsgName               = "redhat-nsg"
 	adminUsername         = "azureuser"
-	vpnIPAddress          = "172.22.0.0/16"
+	vpnIPAddress          = clients.AzureSubnetAddressPrefix
 	resourcePollFrequency = 5 * time.Second
 	vmPollFrequency       = 10 * time.Second
 )
 
-func (c *client) BeginCreateVM(ctx context.Context, networkInterface *armnetwork.Interface, vmParams clients.AzureInstanceParams, vmName string) (string, error) {
+// BeginCreateVM starts creation of a VM, the first network interface is the primary one.
+func (c *client) BeginCreateVM(ctx context.Context, networkInterfaces []*armnetwork.Interface, vmParams clients.AzureInstanceParams, vmName string) (string, error) {
 	ctx, span := otel.Tracer(TraceName).Start(ctx, "BeginCreateVM")
 	defer span.End()
 
@@ -45,13 +47,55 @@ func (c *client) BeginCreateVM(ctx context.Context, networkInterface *armnetwork
 		return "", err
 	}
 
-	vmAzureParams := c.prepareVirtualMachineParameters(vmParams.Location, armcompute.VirtualMachineSizeTypes(vmParams.InstanceType), networkInterface, vmParams.ImageID, vmParams.Pubkey.Body, vmParams.UserData, vmName)
+	vmAzureParams := c.prepareVirtualMachineParameters(vmParams.Location, armcompute.VirtualMachineSizeTypes(vmParams.InstanceType), networkInterfaces, vmParams.ImageID, vmParams.Pubkey.Body, vmParams.UserData, vmName)
+	vmAzureParams.Tags = map[string]*string{
+		clients.ReservationTagKey: to.Ptr(clients.ReservationTagValue(vmParams.ReservationID)),
+	}
+	if image, ok := clients.ParseAzureImageURN(vmParams.ImageID); ok {
+		vmAzureParams.Properties.StorageProfile.ImageReference = &armcompute.ImageReference{
+			Publisher: to.Ptr(image.Publisher),
+			Offer:     to.Ptr(image.Offer),
+			SKU:       to.Ptr(image.SKU),
+			Version:   to.Ptr(image.Version),
+		}
+	}
+	if vmParams.Plan != nil {
+		vmAzureParams.Plan = &armcompute.Plan{
+			Publisher: to.Ptr(vmParams.Plan.Publisher),
+			Product:   to.Ptr(vmParams.Plan.Product),
+			Name:      to.Ptr(vmParams.Plan.Name),
+		}
+	}
+	if vmParams.Zone != "" {
+		vmAzureParams.Zones = []*string{to.Ptr(vmParams.Zone)}
+	}
+	if vmParams.ProximityPlacementGroupID != "" {
+		vmAzureParams.Properties.ProximityPlacementGroup = &armcompute.SubResource{ID: to.Ptr(vmParams.ProximityPlacementGroupID)}
+	}
+	if vmParams.Hibernation {
+		vmAzureParams.Properties.AdditionalCapabilities = &armcompute.AdditionalCapabilities{HibernationEnabled: to.Ptr(true)}
+	}
+	if vmParams.SecurityType != models.AzureSecurityTypeStandard {
+		vmAzureParams.Properties.SecurityProfile = &armcompute.SecurityProfile{
+			SecurityType: to.Ptr(armcompute.SecurityTypes(vmParams.SecurityType)),
+			UefiSettings: &armcompute.UefiSettings{
+				SecureBootEnabled: to.Ptr(vmParams.SecureBoot),
+				VTpmEnabled:       to.Ptr(vmParams.VTPM),
+			},
+		}
+	}
+	if vmParams.SecurityType == models.AzureSecurityTypeConfidentialVM {
+		// only the VM guest state is encrypted, the OS disk keeps the platform managed encryption
+		vmAzureParams.Properties.StorageProfile.OSDisk.ManagedDisk.SecurityProfile = &armcompute.VMDiskSecurityProfile{
+			SecurityEncryptionType: to.Ptr(armcompute.SecurityEncryptionTypesVMGuestStateOnly),
+		}
+	}
 
 	poller, err := vmClient.BeginCreateOrUpdate(ctx, vmParams.ResourceGroupName, vmName, *vmAzureParams, nil)
 	if err != nil {
 		span.SetStatus(codes.Error, "cannot create virtual machine")
 		logger.Error().Err(err).Msg("cannot create virtual machine")
-		return "", fmt.Errorf("create of virtual machine failed to start: %w", err)
+		return "", fmt.Errorf("create of virtual machine failed to start: %w", allocationError(err))
 	}
 
 	resumeToken, err := poller.ResumeToken()
@@ -88,7 +132,7 @@ func (c *client) WaitForVM(ctx context.Context, resumeToken string) (clients.Azu
 	})
 	if err != nil {
 		span.SetStatus(codes.Error, "failed to poll for create virtual machine status")
-		return "", fmt.Errorf("failed to poll for create virtual machine status: %w", err)
+		return "", fmt.Errorf("failed to poll for create virtual machine status: %w", allocationError(err))
 	}
 
 	logger.Debug().Msgf("Done creating virtual machine id=%s", *resp.VirtualMachine.ID)
@@ -129,14 +173,16 @@ func (c *client) ensureSharedNetworking(ctx context.Context, location, resourceG
 	return subnet, nsg, nil
 }
 
-func (c *client) prepareVMNetworking(ctx context.Context, subnet *armnetwork.Subnet, securityGroup *armnetwork.SecurityGroup, vmParams clients.AzureInstanceParams, vmName string) (*armnetwork.Interface, *armnetwork.PublicIPAddress, error) {
+// prepareVMNetworking creates the public IP and the primary network interface, the private IP is
+// allocated dynamically when empty.
+func (c *client) prepareVMNetworking(ctx context.Context, subnet *armnetwork.Subnet, securityGroup *armnetwork.SecurityGroup, vmParams clients.AzureInstanceParams, vmName string, privateIP string) (*armnetwork.Interface, *armnetwork.PublicIPAddress, error) {
 	ctx, span := otel.Tracer(TraceName).Start(ctx, "prepareVMNetworking")
 	defer span.End()
 
 	logger := logger(ctx)
 
 	publicIPName := vmName + "_ip"
-	publicIP, err := c.createPublicIP(ctx, vmParams.Location, vmParams.ResourceGroupName, publicIPName)
+	publicIP, err := c.createPublicIP(ctx, vmParams.Location, vmParams.Zone, vmParams.ResourceGroupName, publicIPName)
 	if err != nil {
 		span.SetStatus(codes.Error, "cannot create public IP address")
 		logger.Error().Err(err).Msg("cannot create public IP address")
@@ -144,7 +190,7 @@ func (c *client) prepareVMNetworking(ctx context.Context, subnet *armnetwork.Sub
 	}
 	logger.Trace().Msgf("Using public IP address id=%s", *publicIP.ID)
 	nicName := vmName + "_nic"
-	networkInterface, err := c.createNetworkInterface(ctx, vmParams.Location, vmParams.ResourceGroupName, subnet, publicIP, securityGroup, nicName)
+	networkInterface, err := c.createNetworkInterface(ctx, vmParams.Location, vmParams.ResourceGroupName, subnet, publicIP, securityGroup, nicName, privateIP)
 	if err != nil {
 		span.SetStatus(codes.Error, "cannot create network interface")
 		logger.Error().Err(err).Msg("cannot create network interface")
@@ -339,7 +385,9 @@ func (c *client) createNetworkSecurityGroup(ctx context.Context, location string
 	return &resp.SecurityGroup, nil
 }
 
-func (c *client) createPublicIP(ctx context.Context, location string, resourceGroupName string, name string) (*armnetwork.PublicIPAddress, error) {
+// createPublicIP creates a static IP address, Standard SKU is used for zonal VMs because Basic SKU
+// addresses do not support availability zones.
+func (c *client) createPublicIP(ctx context.Context, location string, zone string, resourceGroupName string, name string) (*armnetwork.PublicIPAddress, error) {
 	ctx, span := otel.Tracer(TraceName).Start(ctx, "createPublicIP")
 	defer span.End()
 
@@ -354,6 +402,10 @@ func (c *client) createPublicIP(ctx context.Context, location string, resourceGr
 			PublicIPAllocationMethod: to.Ptr(armnetwork.IPAllocationMethodStatic), // Static or Dynamic
 		},
 	}
+	if zone != "" {
+		parameters.SKU = &armnetwork.PublicIPAddressSKU{Name: to.Ptr(armnetwork.PublicIPAddressSKUNameStandard)}
+		parameters.Zones = []*string{to.Ptr(zone)}
+	}
 
 	pollerResponse, err := publicIPAddressClient.BeginCreateOrUpdate(ctx, resourceGroupName, name, parameters, nil)
 	if err != nil {
@@ -369,7 +421,7 @@ func (c *client) createPublicIP(ctx context.Context, location string, resourceGr
 	return &resp.PublicIPAddress, nil
 }
 
-func (c *client) createNetworkInterface(ctx context.Context, location string, resourceGroupName string, subnet *armnetwork.Subnet, publicIP *armnetwork.PublicIPAddress, nsg *armnetwork.SecurityGroup, name string) (*armnetwork.Interface, error) {
+func (c *client) createNetworkInterface(ctx context.Context, location string, resourceGroupName string, subnet *armnetwork.Subnet, publicIP *armnetwork.PublicIPAddress, nsg *armnetwork.SecurityGroup, name string, privateIP string) (*armnetwork.Interface, error) {
 	ctx, span := otel.Tracer(TraceName).Start(ctx, "createNetworkInterface")
 	defer span.End()
 
@@ -378,21 +430,27 @@ func (c *client) createNetworkInterface(ctx context.Context, location string, re
 		return nil, err
 	}
 
+	ipConfig := &armnetwork.InterfaceIPConfigurationPropertiesFormat{
+		PrivateIPAllocationMethod: to.Ptr(armnetwork.IPAllocationMethodDynamic),
+		Subnet: &armnetwork.Subnet{
+			ID: subnet.ID,
+		},
+		PublicIPAddress: &armnetwork.PublicIPAddress{
+			ID: publicIP.ID,
+		},
+	}
+	if privateIP != "" {
+		ipConfig.PrivateIPAllocationMethod = to.Ptr(armnetwork.IPAllocationMethodStatic)
+		ipConfig.PrivateIPAddress = to.Ptr(privateIP)
+	}
+
 	parameters := armnetwork.Interface{
 		Location: to.Ptr(location),
 		Properties: &armnetwork.InterfacePropertiesFormat{
 			IPConfigurations: []*armnetwork.InterfaceIPConfiguration{
 				{
-					Name: to.Ptr("ipConfig"),
-					Properties: &armnetwork.InterfaceIPConfigurationPropertiesFormat{
-						PrivateIPAllocationMethod: to.Ptr(armnetwork.IPAllocationMethodDynamic),
-						Subnet: &armnetwork.Subnet{
-							ID: subnet.ID,
-						},
-						PublicIPAddress: &armnetwork.PublicIPAddress{
-							ID: publicIP.ID,
-						},
-					},
+					Name:       to.Ptr("ipConfig"),
+					Properties: ipConfig,
 				},
 			},
 			NetworkSecurityGroup: &armnetwork.SecurityGroup{
@@ -416,10 +474,89 @@ func (c *client) createNetworkInterface(ctx context.Context, location string, re
 	return &resp.Interface, nil
 }
 
-func (c *client) prepareVirtualMachineParameters(location string, instanceType armcompute.VirtualMachineSizeTypes, networkInterface *armnetwork.Interface, imageID string, sshKeyBody string, userData []byte, vmName string) *armcompute.VirtualMachine {
+// createAdditionalNetworkInterface creates an interface without public IP address in an existing
+// subnet, Azure requires all interfaces of a VM to be in the same virtual network.
+func (c *client) createAdditionalNetworkInterface(ctx context.Context, location string, resourceGroupName string, nic models.NetworkInterface, name string) (*armnetwork.Interface, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "createAdditionalNetworkInterface")
+	defer span.End()
+
+	nicClient, err := c.newInterfacesClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ipConfig := &armnetwork.InterfaceIPConfigurationPropertiesFormat{
+		PrivateIPAllocationMethod: to.Ptr(armnetwork.IPAllocationMethodDynamic),
+		Subnet: &armnetwork.Subnet{
+			ID: to.Ptr(nic.SubnetID),
+		},
+	}
+	if nic.PrivateIPv4 != "" {
+		ipConfig.PrivateIPAllocationMethod = to.Ptr(armnetwork.IPAllocationMethodStatic)
+		ipConfig.PrivateIPAddress = to.Ptr(nic.PrivateIPv4)
+	}
+	parameters := armnetwork.Interface{
+		Location: to.Ptr(location),
+		Properties: &armnetwork.InterfacePropertiesFormat{
+			IPConfigurations: []*armnetwork.InterfaceIPConfiguration{
+				{
+					Name:       to.Ptr("ipConfig"),
+					Properties: ipConfig,
+				},
+			},
+		},
+	}
+	if len(nic.SecurityGroupIDs) > 0 {
+		parameters.Properties.NetworkSecurityGroup = &armnetwork.SecurityGroup{ID: to.Ptr(nic.SecurityGroupIDs[0])}
+	}
+
+	pollerResponse, err := nicClient.BeginCreateOrUpdate(ctx, resourceGroupName, name, parameters, nil)
+	if err != nil {
+		span.SetStatus(codes.Error, "cannot create network interface")
+		return nil, fmt.Errorf("create of network interface failed to start: %w", err)
+	}
+
+	resp, err := pollerResponse.PollUntilDone(ctx, &runtime.PollUntilDoneOptions{
+		Frequency: resourcePollFrequency,
+	})
+	if err != nil {
+		span.SetStatus(codes.Error, "cannot create network interface")
+		return nil, fmt.Errorf("failed to poll for create network interface result: %w", err)
+	}
+
+	return &resp.Interface, nil
+}
+
+// describeNetworkInterfaces returns addressing of created interfaces
+func describeNetworkInterfaces(nics []*armnetwork.Interface) []models.InstanceNetworkInterface {
+	result := make([]models.InstanceNetworkInterface, len(nics))
+	for i, nic := range nics {
+		result[i].ID = ptr.FromOrEmpty(nic.ID)
+		if nic.Properties == nil || len(nic.Properties.IPConfigurations) == 0 || nic.Properties.IPConfigurations[0].Properties == nil {
+			continue
+		}
+		ipConfig := nic.Properties.IPConfigurations[0].Properties
+		result[i].PrivateIPv4 = ptr.FromOrEmpty(ipConfig.PrivateIPAddress)
+		if ipConfig.Subnet != nil {
+			result[i].SubnetID = ptr.FromOrEmpty(ipConfig.Subnet.ID)
+		}
+	}
+	return result
+}
+
+func (c *client) prepareVirtualMachineParameters(location string, instanceType armcompute.VirtualMachineSizeTypes, networkInterfaces []*armnetwork.Interface, imageID string, sshKeyBody string, userData []byte, vmName string) *armcompute.VirtualMachine {
 	userDataEncoded := make([]byte, base64.StdEncoding.EncodedLen(len(userData)))
 	base64.StdEncoding.Encode(userDataEncoded, userData)
 
+	nicReferences := make([]*armcompute.NetworkInterfaceReference, len(networkInterfaces))
+	for i, nic := range networkInterfaces {
+		nicReferences[i] = &armcompute.NetworkInterfaceReference{ID: nic.ID}
+		// primary interface must be set when a VM has multiple interfaces
+		if len(networkInterfaces) > 1 {
+			nicReferences[i].Properties = &armcompute.NetworkInterfaceReferenceProperties{Primary: to.Ptr(i == 0)}
+		}
+	}
+
 	return &armcompute.VirtualMachine{
 		Location: to.Ptr(location),
 		Identity: &armcompute.VirtualMachineIdentity{
@@ -460,10 +597,12 @@ func (c *client) prepareVirtualMachineParameters(location string, instanceType a
 				},
 			},
 			NetworkProfile: &armcompute.NetworkProfile{
-				NetworkInterfaces: []*armcompute.NetworkInterfaceReference{
-					{
-						ID: networkInterface.ID,
-					},
+				NetworkInterfaces: nicReferences,
+			},
+			// managed storage account is used for the serial log and screenshots
+			DiagnosticsProfile: &armcompute.DiagnosticsProfile{
+				BootDiagnostics: &armcompute.BootDiagnostics{
+					Enabled: to.Ptr(true),
 				},
 			},
 			UserData: to.Ptr(string(userDataEncoded)),
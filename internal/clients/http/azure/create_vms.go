@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork"
 	"github.com/RHEnVision/provisioning-backend/internal/clients"
 	"github.com/google/uuid"
 	"go.opentelemetry.io/otel"
@@ -32,14 +33,34 @@ func (c *client) CreateVMs(ctx context.Context, vmParams clients.AzureInstancePa
 		}
 		vmName := fmt.Sprintf("%s-%s", vmNamePrefix, uuid.String())
 
-		networkInterface, publicIP, err := c.prepareVMNetworking(ctx, subnet, nsg, vmParams, vmName)
+		var privateIP string
+		if i < int64(len(vmParams.PrivateIPs)) {
+			privateIP = vmParams.PrivateIPs[i]
+		}
+		networkInterface, publicIP, err := c.prepareVMNetworking(ctx, subnet, nsg, vmParams, vmName, privateIP)
 		if err != nil {
 			return nil, err
 		}
 
+		// both addresses are allocated with the network interface before the VM is created
 		vmDescriptions[i].PublicIPv4 = *publicIP.Properties.IPAddress
+		vmDescriptions[i].PrivateIPv4 = describeNetworkInterfaces([]*armnetwork.Interface{networkInterface})[0].PrivateIPv4
+
+		networkInterfaces := []*armnetwork.Interface{networkInterface}
+		for n := range vmParams.NetworkInterfaces {
+			nicName := fmt.Sprintf("%s_nic%d", vmName, n+1)
+			additional, err := c.createAdditionalNetworkInterface(ctx, vmParams.Location, vmParams.ResourceGroupName, vmParams.NetworkInterfaces[n], nicName)
+			if err != nil {
+				span.SetStatus(codes.Error, "cannot create additional network interface")
+				return nil, err
+			}
+			networkInterfaces = append(networkInterfaces, additional)
+		}
+		if len(networkInterfaces) > 1 {
+			vmDescriptions[i].NetworkInterfaces = describeNetworkInterfaces(networkInterfaces)
+		}
 
-		resumeTokens[i], err = c.BeginCreateVM(ctx, networkInterface, vmParams, vmName)
+		resumeTokens[i], err = c.BeginCreateVM(ctx, networkInterfaces, vmParams, vmName)
 		if err != nil {
 			span.SetStatus(codes.Error, "failed to start creation of Azure instance")
 			return vmDescriptions, fmt.Errorf("cannot start a create of Azure instance(s): %w", err)
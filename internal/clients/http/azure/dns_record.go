@@ -0,0 +1,95 @@
+package azure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/dns/armdns"
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// newRecordSetsClient returns a client for the subscription of the DNS zone, which can be
+// different from the subscription of the source.
+func (c *client) newRecordSetsClient(ctx context.Context, zone *arm.ResourceID) (*armdns.RecordSetsClient, error) {
+	client, err := armdns.NewRecordSetsClient(zone.SubscriptionID, c.credential, clientOptions)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create DNS record sets Azure client: %w", err)
+	}
+	return client, nil
+}
+
+func (c *client) CreateDNSRecord(ctx context.Context, zone, name, ipv4 string, ttl int64) (*models.DNSRecord, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "CreateDNSRecord")
+	defer span.End()
+
+	zoneId, err := arm.ParseResourceID(zone)
+	if err != nil {
+		span.SetStatus(codes.Error, "invalid DNS zone id")
+		return nil, fmt.Errorf("cannot parse DNS zone id %s: %w", zone, err)
+	}
+
+	recordClient, err := c.newRecordSetsClient(ctx, zoneId)
+	if err != nil {
+		return nil, err
+	}
+
+	parameters := armdns.RecordSet{
+		Properties: &armdns.RecordSetProperties{
+			TTL:      to.Ptr(ttl),
+			ARecords: []*armdns.ARecord{{IPv4Address: to.Ptr(ipv4)}},
+		},
+	}
+	_, err = recordClient.CreateOrUpdate(ctx, zoneId.ResourceGroupName, zoneId.Name, name, armdns.RecordTypeA, parameters, nil)
+	if err != nil {
+		span.SetStatus(codes.Error, "cannot create DNS record")
+		return nil, fmt.Errorf("cannot create DNS record %s: %w", name, dnsZoneError(err))
+	}
+
+	return &models.DNSRecord{
+		Zone: zone,
+		Name: name + "." + zoneId.Name,
+		IPv4: ipv4,
+		TTL:  ttl,
+	}, nil
+}
+
+func (c *client) DeleteDNSRecord(ctx context.Context, record *models.DNSRecord) error {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "DeleteDNSRecord")
+	defer span.End()
+
+	zoneId, err := arm.ParseResourceID(record.Zone)
+	if err != nil {
+		span.SetStatus(codes.Error, "invalid DNS zone id")
+		return fmt.Errorf("cannot parse DNS zone id %s: %w", record.Zone, err)
+	}
+
+	recordClient, err := c.newRecordSetsClient(ctx, zoneId)
+	if err != nil {
+		return err
+	}
+
+	name := strings.TrimSuffix(record.Name, "."+zoneId.Name)
+	_, err = recordClient.Delete(ctx, zoneId.ResourceGroupName, zoneId.Name, name, armdns.RecordTypeA, nil)
+	if err != nil {
+		span.SetStatus(codes.Error, "cannot delete DNS record")
+		return fmt.Errorf("cannot delete DNS record %s: %w", record.Name, dnsZoneError(err))
+	}
+	return nil
+}
+
+func dnsZoneError(err error) error {
+	var azErr *azcore.ResponseError
+	if errors.As(err, &azErr) && azErr.StatusCode == http.StatusNotFound {
+		return clients.DNSZoneNotFoundErr
+	}
+	return err
+}
@@ -0,0 +1,174 @@
+package azure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute"
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/ptr"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// marketplaceAgreementsAPIVersion of the Microsoft.MarketplaceOrdering provider, the SDK does not
+// provide a client for the provider, so the agreements are accessed through the ARM pipeline.
+const marketplaceAgreementsAPIVersion = "2021-01-01"
+
+type marketplaceAgreement struct {
+	ID         string                         `json:"id,omitempty"`
+	Name       string                         `json:"name,omitempty"`
+	Type       string                         `json:"type,omitempty"`
+	Properties marketplaceAgreementProperties `json:"properties"`
+}
+
+type marketplaceAgreementProperties struct {
+	Publisher            string `json:"publisher,omitempty"`
+	Product              string `json:"product,omitempty"`
+	Plan                 string `json:"plan,omitempty"`
+	LicenseTextLink      string `json:"licenseTextLink,omitempty"`
+	PrivacyPolicyLink    string `json:"privacyPolicyLink,omitempty"`
+	RetrieveDatetime     string `json:"retrieveDatetime,omitempty"`
+	Signature            string `json:"signature,omitempty"`
+	MarketplaceTermsLink string `json:"marketplaceTermsLink,omitempty"`
+	Accepted             bool   `json:"accepted"`
+}
+
+func (c *client) newVirtualMachineImagesClient(ctx context.Context) (*armcompute.VirtualMachineImagesClient, error) {
+	imagesClient, err := armcompute.NewVirtualMachineImagesClient(c.subscriptionID, c.credential, clientOptions)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create VM images Azure client: %w", err)
+	}
+	return imagesClient, nil
+}
+
+func (c *client) DescribeMarketplaceImage(ctx context.Context, location string, image *clients.AzureMarketplaceImage) (*clients.AzureMarketplaceImage, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "DescribeMarketplaceImage")
+	defer span.End()
+
+	imagesClient, err := c.newVirtualMachineImagesClient(ctx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	result := *image
+	if result.Version == "latest" {
+		list, listErr := imagesClient.List(ctx, location, image.Publisher, image.Offer, image.SKU, &armcompute.VirtualMachineImagesClientListOptions{
+			Orderby: to.Ptr("name desc"),
+			Top:     to.Ptr[int32](1),
+		})
+		if listErr != nil {
+			span.SetStatus(codes.Error, listErr.Error())
+			return nil, fmt.Errorf("cannot list versions of image %s: %w", image.URN(), marketplaceError(listErr))
+		}
+		if len(list.VirtualMachineImageResourceArray) == 0 {
+			return nil, fmt.Errorf("image %s in %s: %w", image.URN(), location, clients.NotFoundErr)
+		}
+		result.Version = ptr.From(list.VirtualMachineImageResourceArray[0].Name)
+	}
+
+	resp, err := imagesClient.Get(ctx, location, result.Publisher, result.Offer, result.SKU, result.Version, nil)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("cannot get image %s: %w", result.URN(), marketplaceError(err))
+	}
+
+	if props := resp.Properties; props != nil {
+		if props.Plan != nil {
+			result.Plan = &clients.AzureImagePlan{
+				Publisher: ptr.From(props.Plan.Publisher),
+				Product:   ptr.From(props.Plan.Product),
+				Name:      ptr.From(props.Plan.Name),
+			}
+		}
+		if props.Architecture != nil {
+			if arch, archErr := clients.MapArchitectures(ctx, string(*props.Architecture)); archErr == nil {
+				result.Architecture = arch
+			}
+		}
+	}
+
+	return &result, nil
+}
+
+func (c *client) AcceptMarketplaceTerms(ctx context.Context, plan *clients.AzureImagePlan) error {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "AcceptMarketplaceTerms")
+	defer span.End()
+
+	logger := logger(ctx)
+
+	armClient, err := arm.NewClient("provisioning-backend", "v1.0.0", c.credential, clientOptions)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("unable to create ARM Azure client: %w", err)
+	}
+
+	agreementURL := runtime.JoinPaths(armClient.Endpoint(), fmt.Sprintf(
+		"/subscriptions/%s/providers/Microsoft.MarketplaceOrdering/offerTypes/virtualmachine/publishers/%s/offers/%s/plans/%s/agreements/current",
+		url.PathEscape(c.subscriptionID), url.PathEscape(plan.Publisher), url.PathEscape(plan.Product), url.PathEscape(plan.Name)))
+
+	agreement := &marketplaceAgreement{}
+	err = c.doAgreementRequest(ctx, armClient, http.MethodGet, agreementURL, nil, agreement)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("cannot get terms of plan %s: %w", plan.Name, err)
+	}
+	if agreement.Properties.Accepted {
+		logger.Trace().Msgf("Terms of plan %s are already accepted", plan.Name)
+		return nil
+	}
+
+	agreement.Properties.Accepted = true
+	err = c.doAgreementRequest(ctx, armClient, http.MethodPut, agreementURL, agreement, agreement)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("cannot accept terms of plan %s: %w", plan.Name, err)
+	}
+	logger.Info().Msgf("Accepted terms of marketplace plan %s of %s", plan.Name, plan.Publisher)
+
+	return nil
+}
+
+func (c *client) doAgreementRequest(ctx context.Context, armClient *arm.Client, method, agreementURL string, body, result *marketplaceAgreement) error {
+	req, err := runtime.NewRequest(ctx, method, agreementURL)
+	if err != nil {
+		return fmt.Errorf("unable to create request: %w", err)
+	}
+	query := req.Raw().URL.Query()
+	query.Set("api-version", marketplaceAgreementsAPIVersion)
+	req.Raw().URL.RawQuery = query.Encode()
+	req.Raw().Header["Accept"] = []string{"application/json"}
+	if body != nil {
+		if err = runtime.MarshalAsJSON(req, body); err != nil {
+			return fmt.Errorf("unable to marshal agreement: %w", err)
+		}
+	}
+
+	resp, err := armClient.Pipeline().Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	if !runtime.HasStatusCode(resp, http.StatusOK) {
+		return runtime.NewResponseError(resp)
+	}
+	if err = runtime.UnmarshalAsJSON(resp, result); err != nil {
+		return fmt.Errorf("unable to unmarshal agreement: %w", err)
+	}
+	return nil
+}
+
+func marketplaceError(err error) error {
+	var azErr *azcore.ResponseError
+	if errors.As(err, &azErr) && azErr.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", clients.NotFoundErr, azErr.ErrorCode)
+	}
+	return err
+}
@@ -0,0 +1,203 @@
+package azure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute"
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/ptr"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func (c *client) DeallocateVM(ctx context.Context, vmId string) error {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "DeallocateVM")
+	defer span.End()
+
+	logger := logger(ctx)
+
+	resourceId, err := arm.ParseResourceID(vmId)
+	if err != nil {
+		span.SetStatus(codes.Error, "invalid virtual machine id")
+		return fmt.Errorf("cannot parse virtual machine id %s: %w", vmId, err)
+	}
+
+	vmClient, err := c.newVirtualMachinesClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger.Debug().Msgf("Deallocating virtual machine id=%s", vmId)
+	poller, err := vmClient.BeginDeallocate(ctx, resourceId.ResourceGroupName, resourceId.Name, nil)
+	if err != nil {
+		span.SetStatus(codes.Error, "cannot deallocate virtual machine")
+		return fmt.Errorf("cannot deallocate virtual machine: %w", err)
+	}
+	_, err = poller.PollUntilDone(ctx, &runtime.PollUntilDoneOptions{
+		Frequency: vmPollFrequency,
+	})
+	if err != nil {
+		span.SetStatus(codes.Error, "failed to poll for deallocate virtual machine status")
+		return fmt.Errorf("failed to poll for deallocate virtual machine status: %w", err)
+	}
+
+	return nil
+}
+
+func (c *client) StartVM(ctx context.Context, vmId string) error {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "StartVM")
+	defer span.End()
+
+	logger := logger(ctx)
+
+	resourceId, err := arm.ParseResourceID(vmId)
+	if err != nil {
+		span.SetStatus(codes.Error, "invalid virtual machine id")
+		return fmt.Errorf("cannot parse virtual machine id %s: %w", vmId, err)
+	}
+
+	vmClient, err := c.newVirtualMachinesClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger.Debug().Msgf("Starting virtual machine id=%s", vmId)
+	poller, err := vmClient.BeginStart(ctx, resourceId.ResourceGroupName, resourceId.Name, nil)
+	if err != nil {
+		span.SetStatus(codes.Error, "cannot start virtual machine")
+		return fmt.Errorf("cannot start virtual machine: %w", err)
+	}
+	_, err = poller.PollUntilDone(ctx, &runtime.PollUntilDoneOptions{
+		Frequency: vmPollFrequency,
+	})
+	if err != nil {
+		span.SetStatus(codes.Error, "failed to poll for start virtual machine status")
+		return fmt.Errorf("failed to poll for start virtual machine status: %w", err)
+	}
+
+	return nil
+}
+
+func (c *client) ResizeVM(ctx context.Context, vmId, size string) error {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "ResizeVM")
+	defer span.End()
+
+	logger := logger(ctx)
+
+	resourceId, err := arm.ParseResourceID(vmId)
+	if err != nil {
+		span.SetStatus(codes.Error, "invalid virtual machine id")
+		return fmt.Errorf("cannot parse virtual machine id %s: %w", vmId, err)
+	}
+
+	vmClient, err := c.newVirtualMachinesClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger.Debug().Msgf("Resizing virtual machine id=%s to %s", vmId, size)
+	params := armcompute.VirtualMachineUpdate{
+		Properties: &armcompute.VirtualMachineProperties{
+			HardwareProfile: &armcompute.HardwareProfile{
+				VMSize: ptr.To(armcompute.VirtualMachineSizeTypes(size)),
+			},
+		},
+	}
+	poller, err := vmClient.BeginUpdate(ctx, resourceId.ResourceGroupName, resourceId.Name, params, nil)
+	if err != nil {
+		span.SetStatus(codes.Error, "cannot resize virtual machine")
+		return fmt.Errorf("cannot resize virtual machine: %w", err)
+	}
+	_, err = poller.PollUntilDone(ctx, &runtime.PollUntilDoneOptions{
+		Frequency: vmPollFrequency,
+	})
+	if err != nil {
+		span.SetStatus(codes.Error, "failed to poll for resize virtual machine status")
+		return fmt.Errorf("failed to poll for resize virtual machine status: %w", err)
+	}
+
+	return nil
+}
+
+func (c *client) GetPowerState(ctx context.Context, vmId string) (models.PowerState, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "GetPowerState")
+	defer span.End()
+
+	resourceId, err := arm.ParseResourceID(vmId)
+	if err != nil {
+		span.SetStatus(codes.Error, "invalid virtual machine id")
+		return "", fmt.Errorf("cannot parse virtual machine id %s: %w", vmId, err)
+	}
+
+	vmClient, err := c.newVirtualMachinesClient(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := vmClient.InstanceView(ctx, resourceId.ResourceGroupName, resourceId.Name, nil)
+	if err != nil {
+		var azErr *azcore.ResponseError
+		if errors.As(err, &azErr) && azErr.StatusCode == http.StatusNotFound {
+			return models.PowerStateTerminated, nil
+		}
+		span.SetStatus(codes.Error, "cannot get virtual machine instance view")
+		return "", fmt.Errorf("cannot get virtual machine instance view: %w", err)
+	}
+
+	// the power state is one of the statuses, e.g. "PowerState/running"
+	for _, status := range resp.Statuses {
+		switch ptr.FromOrEmpty(status.Code) {
+		case "PowerState/starting":
+			return models.PowerStateStarting, nil
+		case "PowerState/running":
+			return models.PowerStateRunning, nil
+		case "PowerState/stopping", "PowerState/deallocating":
+			return models.PowerStateStopping, nil
+		case "PowerState/stopped", "PowerState/deallocated":
+			return models.PowerStateStopped, nil
+		}
+	}
+	return models.PowerStateUnknown, nil
+}
+
+func (c *client) ListTaggedInstances(ctx context.Context) ([]*clients.TaggedInstance, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "ListTaggedInstances")
+	defer span.End()
+
+	vmClient, err := c.newVirtualMachinesClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*clients.TaggedInstance
+	pager := vmClient.NewListAllPager(nil)
+	for pager.More() {
+		page, pagerErr := pager.NextPage(ctx)
+		if pagerErr != nil {
+			span.SetStatus(codes.Error, "cannot list virtual machines")
+			return nil, fmt.Errorf("cannot list virtual machines: %w", pagerErr)
+		}
+		for _, vm := range page.Value {
+			tag, ok := vm.Tags[clients.ReservationTagKey]
+			if !ok {
+				continue
+			}
+			if id, ok := clients.ReservationIDFromTag(ptr.FromOrEmpty(tag)); ok {
+				result = append(result, &clients.TaggedInstance{
+					ID:            ptr.FromOrEmpty(vm.ID),
+					ReservationID: id,
+					Location:      ptr.FromOrEmpty(vm.Location),
+				})
+			}
+		}
+	}
+
+	return result, nil
+}
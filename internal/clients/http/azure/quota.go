@@ -0,0 +1,104 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute"
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/ptr"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// totalRegionalVCPUsUsageName is the usage name of "Total Regional vCPUs"
+const totalRegionalVCPUsUsageName = "cores"
+
+func (c *client) newUsageClient(ctx context.Context) (*armcompute.UsageClient, error) {
+	usageClient, err := armcompute.NewUsageClient(c.subscriptionID, c.credential, clientOptions)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create usage Azure client: %w", err)
+	}
+	return usageClient, nil
+}
+
+func (c *client) GetVCPUQuota(ctx context.Context, location, size string) (*clients.Quota, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "GetVCPUQuota")
+	defer span.End()
+
+	logger := logger(ctx)
+	family, err := c.sizeFamily(ctx, location, size)
+	if err != nil {
+		// the total regional quota is still checked
+		logger.Warn().Err(err).Msgf("Unable to find family of Azure VM size %s", size)
+	}
+	logger.Trace().Msgf("Fetching Azure vCPU quota of family '%s' in %s", family, location)
+
+	usageClient, err := c.newUsageClient(ctx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	// both the total regional and the family quota must allow the launch, the one with less
+	// remaining vCPUs is returned
+	var result *clients.Quota
+	pager := usageClient.NewListPager(location, nil)
+	for pager.More() {
+		page, pagerErr := pager.NextPage(ctx)
+		if pagerErr != nil {
+			span.SetStatus(codes.Error, pagerErr.Error())
+			return nil, fmt.Errorf("failed to fetch compute usages: %w", pagerErr)
+		}
+		for _, usage := range page.Value {
+			if usage.Name == nil {
+				continue
+			}
+			name := ptr.From(usage.Name.Value)
+			if name != totalRegionalVCPUsUsageName && (family == "" || !strings.EqualFold(name, family)) {
+				continue
+			}
+			quota := &clients.Quota{
+				Name:  ptr.From(usage.Name.LocalizedValue),
+				Limit: ptr.From(usage.Limit),
+				Usage: int64(ptr.From(usage.CurrentValue)),
+			}
+			if result == nil || quota.Remaining() < result.Remaining() {
+				result = quota
+			}
+		}
+	}
+
+	if result == nil {
+		span.SetStatus(codes.Error, "vCPU usage not found")
+		return nil, fmt.Errorf("vCPU usage not found in %s: %w", location, clients.NotFoundErr)
+	}
+	return result, nil
+}
+
+// sizeFamily returns the family of the VM size (e.g. standardDSv3Family) which is also the name of
+// the family vCPU usage.
+func (c *client) sizeFamily(ctx context.Context, location, size string) (string, error) {
+	skuClient, err := c.newResourceSKUsClient(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	pager := skuClient.NewListPager(&armcompute.ResourceSKUsClientListOptions{
+		Filter: to.Ptr(fmt.Sprintf("location eq '%s'", location)),
+	})
+	for pager.More() {
+		page, pagerErr := pager.NextPage(ctx)
+		if pagerErr != nil {
+			return "", fmt.Errorf("failed to fetch resource SKUs: %w", pagerErr)
+		}
+		for _, sku := range page.Value {
+			if ptr.From(sku.ResourceType) == "virtualMachines" && strings.EqualFold(ptr.From(sku.Name), size) {
+				return ptr.From(sku.Family), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("size %s not found in %s: %w", size, location, clients.NotFoundErr)
+}
@@ -0,0 +1,40 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/RHEnVision/provisioning-backend/internal/chaos"
+	"github.com/rs/zerolog"
+)
+
+// ChaosTransport injects latency and errors into outbound requests matching the rules, rules
+// are matched against host and path of the request URL.
+type ChaosTransport struct {
+	rules     []chaos.Rule
+	transport http.RoundTripper
+}
+
+func NewChaosTransport(rules []chaos.Rule, transport http.RoundTripper) *ChaosTransport {
+	return &ChaosTransport{
+		rules:     rules,
+		transport: transport,
+	}
+}
+
+func (t *ChaosTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rule := chaos.Match(t.rules, req.URL.Host+req.URL.Path)
+	if rule == nil {
+		return t.transport.RoundTrip(req)
+	}
+
+	inject, err := rule.Apply(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("chaos transport: %w", err)
+	}
+	if inject {
+		zerolog.Ctx(req.Context()).Warn().Msgf("Chaos error injected to %s %s", req.Method, req.URL.Redacted())
+		return nil, fmt.Errorf("%w: %s %s", chaos.ErrInjected, req.Method, req.URL.Redacted())
+	}
+	return t.transport.RoundTrip(req)
+}
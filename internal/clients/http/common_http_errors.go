@@ -25,20 +25,21 @@ func IsHTTPForbidden(status int) bool {
 
 // HandleHTTPResponses parses HTTP status code and returns on of the errors
 // defined in the client package (NotFoundErr, UnauthorizedErr, Non2xxResponseErr)
-// or nil when the response was 2xx (200-299 range).
-func HandleHTTPResponses(ctx context.Context, statusCode int) error {
-	if IsHTTPNotFound(statusCode) {
-		return clients.NotFoundErr
-	}
-	if IsHTTPUnauthorized(statusCode) {
-		return clients.UnauthorizedErr
-	}
-	if IsHTTPForbidden(statusCode) {
-		return clients.ForbiddenErr
+// wrapped in clients.ProviderError or nil when the response was 2xx (200-299 range).
+func HandleHTTPResponses(ctx context.Context, provider, operation string, statusCode int) error {
+	if IsHTTPStatus2xx(statusCode) {
+		return nil
 	}
-	if !IsHTTPStatus2xx(statusCode) {
+
+	err := clients.Non2xxResponseErr
+	if IsHTTPNotFound(statusCode) {
+		err = clients.NotFoundErr
+	} else if IsHTTPUnauthorized(statusCode) {
+		err = clients.UnauthorizedErr
+	} else if IsHTTPForbidden(statusCode) {
+		err = clients.ForbiddenErr
+	} else {
 		zerolog.Ctx(ctx).Warn().Msgf("Non-200 HTTP response seen: %v", statusCode)
-		return clients.Non2xxResponseErr
 	}
-	return nil
+	return clients.NewProviderError(provider, operation, statusCode, "", err)
 }
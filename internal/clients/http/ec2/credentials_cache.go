@@ -0,0 +1,83 @@
+package ec2
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/cache"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/RHEnVision/provisioning-backend/internal/metrics"
+	stsTypes "github.com/aws/aws-sdk-go-v2/service/sts/types"
+)
+
+// credentialsCacheType is the cache type reported in cache hit metrics
+const credentialsCacheType = "assumed_role"
+
+var (
+	// assumedCredentials are credentials of assumed roles by account and role ARN, they are kept in
+	// memory only and never shared between processes
+	assumedCredentials      = make(map[string]*stsTypes.Credentials)
+	assumedCredentialsMutex sync.Mutex
+
+	// assumeRole calls STS AssumeRole, tests replace it
+	assumeRole = getStsAssumedCredentials
+)
+
+// getAssumedCredentials returns credentials of the role, credentials are reused per account and
+// role ARN until they expire within the configured refresh interval. Concurrent calls for the same
+// role share a single AssumeRole call.
+func getAssumedCredentials(ctx context.Context, arn string, region string) (*stsTypes.Credentials, error) {
+	if !config.AWS.RoleCache {
+		return assumeRole(ctx, arn, region)
+	}
+
+	key := strconv.FormatInt(identity.AccountIdOrNil(ctx), 10) + "/" + arn
+	if creds := cachedCredentials(key); creds != nil {
+		metrics.IncCacheHit(credentialsCacheType, "hit")
+		return creds, nil
+	}
+	metrics.IncCacheHit(credentialsCacheType, "miss")
+
+	return cache.Coalesce(credentialsCacheType+"/"+key, func() (*stsTypes.Credentials, error) {
+		creds, err := assumeRole(ctx, arn, region)
+		if err != nil {
+			return nil, err
+		}
+
+		assumedCredentialsMutex.Lock()
+		defer assumedCredentialsMutex.Unlock()
+		for k, c := range assumedCredentials {
+			if !validCredentials(c) {
+				delete(assumedCredentials, k)
+			}
+		}
+		if validCredentials(creds) {
+			assumedCredentials[key] = creds
+		}
+		return creds, nil
+	})
+}
+
+func cachedCredentials(key string) *stsTypes.Credentials {
+	assumedCredentialsMutex.Lock()
+	defer assumedCredentialsMutex.Unlock()
+
+	creds, ok := assumedCredentials[key]
+	if !ok {
+		return nil
+	}
+	if !validCredentials(creds) {
+		delete(assumedCredentials, key)
+		return nil
+	}
+	return creds
+}
+
+// validCredentials returns true for credentials which do not expire within the refresh interval,
+// credentials without expiration are never reused.
+func validCredentials(creds *stsTypes.Credentials) bool {
+	return creds != nil && creds.Expiration != nil && time.Until(*creds.Expiration) > config.AWS.RoleRefresh
+}
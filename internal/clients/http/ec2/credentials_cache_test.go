@@ -0,0 +1,97 @@
+package ec2
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/RHEnVision/provisioning-backend/internal/ptr"
+	stsTypes "github.com/aws/aws-sdk-go-v2/service/sts/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stubAssumeRole(t *testing.T, expiration time.Duration, err error) *int {
+	t.Helper()
+	var mu sync.Mutex
+	calls := 0
+	previous, enabled, refresh := assumeRole, config.AWS.RoleCache, config.AWS.RoleRefresh
+	config.AWS.RoleCache, config.AWS.RoleRefresh = true, 5*time.Minute
+	assumeRole = func(_ context.Context, arn string, _ string) (*stsTypes.Credentials, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		if err != nil {
+			return nil, err
+		}
+		return &stsTypes.Credentials{
+			AccessKeyId:     ptr.To(arn),
+			SecretAccessKey: ptr.To("secret"),
+			SessionToken:    ptr.To("token"),
+			Expiration:      ptr.To(time.Now().Add(expiration)),
+		}, nil
+	}
+	t.Cleanup(func() {
+		assumeRole, config.AWS.RoleCache, config.AWS.RoleRefresh = previous, enabled, refresh
+		assumedCredentials = make(map[string]*stsTypes.Credentials)
+	})
+	return &calls
+}
+
+func TestGetAssumedCredentials(t *testing.T) {
+	ctx := identity.WithAccountId(context.Background(), 1)
+	arn := "arn:aws:iam::123456789012:role/test"
+
+	t.Run("reused", func(t *testing.T) {
+		calls := stubAssumeRole(t, time.Hour, nil)
+		for i := 0; i < 3; i++ {
+			creds, err := getAssumedCredentials(ctx, arn, "us-east-1")
+			require.NoError(t, err)
+			assert.Equal(t, arn, *creds.AccessKeyId)
+		}
+		assert.Equal(t, 1, *calls)
+	})
+
+	t.Run("per account and role", func(t *testing.T) {
+		calls := stubAssumeRole(t, time.Hour, nil)
+		_, err := getAssumedCredentials(ctx, arn, "us-east-1")
+		require.NoError(t, err)
+		_, err = getAssumedCredentials(identity.WithAccountId(context.Background(), 2), arn, "us-east-1")
+		require.NoError(t, err)
+		_, err = getAssumedCredentials(ctx, "arn:aws:iam::123456789012:role/other", "us-east-1")
+		require.NoError(t, err)
+		assert.Equal(t, 3, *calls)
+	})
+
+	t.Run("expiring", func(t *testing.T) {
+		calls := stubAssumeRole(t, time.Minute, nil)
+		_, err := getAssumedCredentials(ctx, arn, "us-east-1")
+		require.NoError(t, err)
+		_, err = getAssumedCredentials(ctx, arn, "us-east-1")
+		require.NoError(t, err)
+		assert.Equal(t, 2, *calls)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		calls := stubAssumeRole(t, time.Hour, errors.New("access denied"))
+		_, err := getAssumedCredentials(ctx, arn, "us-east-1")
+		require.Error(t, err)
+		_, err = getAssumedCredentials(ctx, arn, "us-east-1")
+		require.Error(t, err)
+		assert.Equal(t, 2, *calls)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		calls := stubAssumeRole(t, time.Hour, nil)
+		config.AWS.RoleCache = false
+		_, err := getAssumedCredentials(ctx, arn, "us-east-1")
+		require.NoError(t, err)
+		_, err = getAssumedCredentials(ctx, arn, "us-east-1")
+		require.NoError(t, err)
+		assert.Equal(t, 2, *calls)
+	})
+}
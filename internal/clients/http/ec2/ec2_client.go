@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
-	"strconv"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/RHEnVision/provisioning-backend/internal/clients"
 	"github.com/RHEnVision/provisioning-backend/internal/clients/http"
@@ -17,7 +19,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/servicequotas"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 	stsTypes "github.com/aws/aws-sdk-go-v2/service/sts/types"
 	"github.com/rs/zerolog"
@@ -28,10 +35,17 @@ import (
 const TraceName = telemetry.TracePrefix + "internal/clients/http/ec2"
 
 type ec2Client struct {
-	ec2     *ec2.Client
-	sts     *sts.Client
-	iam     *iam.Client
-	assumed bool
+	ec2       *ec2.Client
+	sts       *sts.Client
+	iam       *iam.Client
+	kms       *kms.Client
+	sq        *servicequotas.Client
+	r53       *route53.Client
+	sqs       *sqs.Client
+	events    *eventbridge.Client
+	region    string
+	partition string
+	assumed   bool
 }
 
 func init() {
@@ -56,31 +70,85 @@ func awsConfig(ctx context.Context, region string, optFns ...func(*awsCfg.LoadOp
 
 	optFns = append(optFns, loggingOpt,
 		awsCfg.WithLogger(NewEC2Logger(ctx)),
-		awsCfg.WithRegion(region))
+		awsCfg.WithRegion(region),
+		awsCfg.WithRetryer(newRetryer))
+	if config.AWS.Endpoint != "" {
+		optFns = append(optFns, awsCfg.WithEndpointResolverWithOptions(endpointResolver(config.AWS.Endpoint, config.AWS.PathStyle)))
+	}
 
 	newCfg, err := awsCfg.LoadDefaultConfig(ctx, optFns...)
 	if err != nil {
 		return nil, fmt.Errorf("config error: %w", err)
 	}
+	newCfg.APIOptions = append(newCfg.APIOptions, withProviderErrors)
 	return &newCfg, nil
 }
 
+// endpointResolver returns resolver pointing all services to a single custom endpoint, this is
+// used for testing against LocalStack. The immutable hostname prevents the SDK from prepending
+// host prefixes, so the endpoint is used path-style.
+func endpointResolver(url string, pathStyle bool) aws.EndpointResolverWithOptions {
+	return aws.EndpointResolverWithOptionsFunc(func(service, region string, _ ...any) (aws.Endpoint, error) {
+		return aws.Endpoint{
+			URL:               url,
+			SigningRegion:     region,
+			HostnameImmutable: pathStyle,
+			Source:            aws.EndpointSourceCustom,
+		}, nil
+	})
+}
+
+// serviceCredentials returns static credentials of the service account in the partition, roles can
+// only be assumed by credentials of their own partition.
+func serviceCredentials(partition string) (aws.CredentialsProvider, error) {
+	if !clients.AWSPartitionEnabled(partition) {
+		return nil, fmt.Errorf("%w: %s", http.PartitionNotConfiguredErr, partition)
+	}
+	switch partition {
+	case clients.AWSPartitionGovCloud:
+		return credentials.NewStaticCredentialsProvider(config.AWS.GovCloud.Key, config.AWS.GovCloud.Secret, config.AWS.GovCloud.Session), nil
+	case clients.AWSPartitionChina:
+		return credentials.NewStaticCredentialsProvider(config.AWS.China.Key, config.AWS.China.Secret, config.AWS.China.Session), nil
+	default:
+		return credentials.NewStaticCredentialsProvider(config.AWS.Key, config.AWS.Secret, config.AWS.Session), nil
+	}
+}
+
+// newEC2FromConfig creates EC2 client, Describe* operations are throttled when bucket is not nil.
+func newEC2FromConfig(cfg *aws.Config, bucket *tokenBucket) *ec2.Client {
+	if bucket == nil {
+		return ec2.NewFromConfig(*cfg)
+	}
+	return ec2.NewFromConfig(*cfg, ec2.WithAPIOptions(throttleDescribe(bucket)))
+}
+
 func newEC2ClientWithRegion(ctx context.Context, region string) (clients.EC2, error) {
 	if region == "" {
 		region = config.AWS.DefaultRegion
 	}
+	partition := clients.AWSRegionPartition(region)
 
-	cfg, err := awsConfig(ctx, region,
-		awsCfg.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(config.AWS.Key, config.AWS.Secret, config.AWS.Session)))
+	serviceCreds, err := serviceCredentials(partition)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := awsConfig(ctx, region, awsCfg.WithCredentialsProvider(serviceCreds))
 	if err != nil {
 		return nil, fmt.Errorf("aws: %w", err)
 	}
 
 	return &ec2Client{
-		ec2:     ec2.NewFromConfig(*cfg),
-		sts:     sts.NewFromConfig(*cfg),
-		iam:     iam.NewFromConfig(*cfg),
-		assumed: false,
+		ec2:       newEC2FromConfig(cfg, describeBucket("", region)),
+		sts:       sts.NewFromConfig(*cfg),
+		iam:       iam.NewFromConfig(*cfg),
+		kms:       kms.NewFromConfig(*cfg),
+		sq:        servicequotas.NewFromConfig(*cfg),
+		r53:       route53.NewFromConfig(*cfg),
+		sqs:       sqs.NewFromConfig(*cfg),
+		events:    eventbridge.NewFromConfig(*cfg),
+		region:    region,
+		partition: partition,
+		assumed:   false,
 	}, nil
 }
 
@@ -89,29 +157,39 @@ func newAssumedEC2ClientWithRegion(ctx context.Context, auth *clients.Authentica
 		return nil, fmt.Errorf("unexpected authentication: %w", typeErr)
 	}
 
+	partition := clients.AWSPartition(auth.Payload)
 	if region == "" {
-		region = config.AWS.DefaultRegion
+		region = clients.AWSDefaultRegion(partition)
+	} else if clients.AWSRegionPartition(region) != partition {
+		return nil, fmt.Errorf("%w: region %s, partition %s", http.RegionPartitionMismatchErr, region, partition)
 	}
 
-	assumedCredentials, err := getStsAssumedCredentials(ctx, auth.Payload, region)
+	assumed, err := getAssumedCredentials(ctx, auth.Payload, region)
 	if err != nil {
 		return nil, err
 	}
 
 	cfg, err := awsConfig(ctx, region,
 		awsCfg.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(
-			*assumedCredentials.AccessKeyId,
-			*assumedCredentials.SecretAccessKey,
-			*assumedCredentials.SessionToken)))
+			*assumed.AccessKeyId,
+			*assumed.SecretAccessKey,
+			*assumed.SessionToken)))
 	if err != nil {
 		return nil, fmt.Errorf("aws: %w", err)
 	}
 
 	return &ec2Client{
-		ec2:     ec2.NewFromConfig(*cfg),
-		sts:     sts.NewFromConfig(*cfg),
-		iam:     iam.NewFromConfig(*cfg),
-		assumed: true,
+		ec2:       newEC2FromConfig(cfg, describeBucket(auth.Payload, region)),
+		sts:       sts.NewFromConfig(*cfg),
+		iam:       iam.NewFromConfig(*cfg),
+		kms:       kms.NewFromConfig(*cfg),
+		sq:        servicequotas.NewFromConfig(*cfg),
+		r53:       route53.NewFromConfig(*cfg),
+		sqs:       sqs.NewFromConfig(*cfg),
+		events:    eventbridge.NewFromConfig(*cfg),
+		region:    region,
+		partition: partition,
+		assumed:   true,
 	}, nil
 }
 
@@ -123,8 +201,11 @@ func (c *ec2Client) Status(ctx context.Context) error {
 func getStsAssumedCredentials(ctx context.Context, arn string, region string) (*stsTypes.Credentials, error) {
 	logger := logger(ctx)
 
-	cfg, err := awsConfig(ctx, region,
-		awsCfg.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(config.AWS.Key, config.AWS.Secret, config.AWS.Session)))
+	serviceCreds, err := serviceCredentials(clients.AWSRegionPartition(region))
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := awsConfig(ctx, region, awsCfg.WithCredentialsProvider(serviceCreds))
 	if err != nil {
 		return nil, fmt.Errorf("aws sts: %w", err)
 	}
@@ -338,6 +419,683 @@ func (c *ec2Client) DescribeInstanceDetails(ctx context.Context, InstanceIds []s
 	return instanceDetailList, nil
 }
 
+// powerStateTimeout is the maximum time to wait for instances to stop or start
+const powerStateTimeout = 10 * time.Minute
+
+func (c *ec2Client) StopInstances(ctx context.Context, instanceIds []string) error {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "StopInstances")
+	defer span.End()
+
+	_, err := c.ec2.StopInstances(ctx, &ec2.StopInstancesInput{InstanceIds: instanceIds})
+	if err != nil {
+		if isAWSUnauthorizedError(err) {
+			err = clients.UnauthorizedErr
+		}
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("cannot stop instances: %w", err)
+	}
+
+	waiter := ec2.NewInstanceStoppedWaiter(c.ec2)
+	err = waiter.Wait(ctx, &ec2.DescribeInstancesInput{InstanceIds: instanceIds}, powerStateTimeout)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("cannot wait for instances to stop: %w", err)
+	}
+	return nil
+}
+
+func (c *ec2Client) StartInstances(ctx context.Context, instanceIds []string) error {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "StartInstances")
+	defer span.End()
+
+	_, err := c.ec2.StartInstances(ctx, &ec2.StartInstancesInput{InstanceIds: instanceIds})
+	if err != nil {
+		if isAWSUnauthorizedError(err) {
+			err = clients.UnauthorizedErr
+		}
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("cannot start instances: %w", err)
+	}
+
+	waiter := ec2.NewInstanceRunningWaiter(c.ec2)
+	err = waiter.Wait(ctx, &ec2.DescribeInstancesInput{InstanceIds: instanceIds}, powerStateTimeout)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("cannot wait for instances to start: %w", err)
+	}
+	return nil
+}
+
+func (c *ec2Client) ModifyInstanceType(ctx context.Context, instanceId, instanceType string) error {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "ModifyInstanceType")
+	defer span.End()
+
+	input := &ec2.ModifyInstanceAttributeInput{
+		InstanceId:   ptr.To(instanceId),
+		InstanceType: &types.AttributeValue{Value: ptr.To(instanceType)},
+	}
+	_, err := c.ec2.ModifyInstanceAttribute(ctx, input)
+	if err != nil {
+		if isAWSUnauthorizedError(err) {
+			err = clients.UnauthorizedErr
+		}
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("cannot modify instance type of %s: %w", instanceId, err)
+	}
+	return nil
+}
+
+func (c *ec2Client) GetConsoleOutput(ctx context.Context, instanceId string) (*clients.ConsoleOutput, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "GetConsoleOutput")
+	defer span.End()
+
+	resp, err := c.ec2.GetConsoleOutput(ctx, &ec2.GetConsoleOutputInput{
+		InstanceId: ptr.To(instanceId),
+	})
+	if err != nil {
+		if isAWSUnauthorizedError(err) {
+			err = clients.UnauthorizedErr
+		}
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("cannot get console output of %s: %w", instanceId, err)
+	}
+	output, err := base64.StdEncoding.DecodeString(ptr.FromOrEmpty(resp.Output))
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("cannot decode console output of %s: %w", instanceId, err)
+	}
+	result := &clients.ConsoleOutput{
+		Output: clients.TrimConsoleOutput(string(output)),
+	}
+
+	// screenshots are only supported by some instance types, the output is returned without it
+	screenshot, err := c.ec2.GetConsoleScreenshot(ctx, &ec2.GetConsoleScreenshotInput{
+		InstanceId: ptr.To(instanceId),
+	})
+	if err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Str("instance_id", instanceId).Msg("Unable to get console screenshot")
+	} else {
+		result.Screenshot = ptr.FromOrEmpty(screenshot.ImageData)
+	}
+	return result, nil
+}
+
+func (c *ec2Client) GetPowerState(ctx context.Context, instanceId string) (models.PowerState, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "GetPowerState")
+	defer span.End()
+
+	resp, err := c.ec2.DescribeInstances(ctx, &ec2.DescribeInstancesInput{
+		InstanceIds: []string{instanceId},
+	})
+	if err != nil {
+		if isAWSOperationError(err, "InvalidInstanceID.NotFound") {
+			return models.PowerStateTerminated, nil
+		}
+		if isAWSUnauthorizedError(err) {
+			err = clients.UnauthorizedErr
+		}
+		span.SetStatus(codes.Error, err.Error())
+		return "", fmt.Errorf("cannot describe instance %s: %w", instanceId, err)
+	}
+	if len(resp.Reservations) == 0 || len(resp.Reservations[0].Instances) == 0 {
+		return models.PowerStateTerminated, nil
+	}
+
+	instance := resp.Reservations[0].Instances[0]
+	if instance.State == nil {
+		return models.PowerStateUnknown, nil
+	}
+	switch instance.State.Name {
+	case types.InstanceStateNamePending:
+		return models.PowerStateStarting, nil
+	case types.InstanceStateNameRunning:
+		return models.PowerStateRunning, nil
+	case types.InstanceStateNameStopping, types.InstanceStateNameShuttingDown:
+		return models.PowerStateStopping, nil
+	case types.InstanceStateNameStopped:
+		return models.PowerStateStopped, nil
+	case types.InstanceStateNameTerminated:
+		return models.PowerStateTerminated, nil
+	}
+	return models.PowerStateUnknown, nil
+}
+
+func (c *ec2Client) ListTaggedInstances(ctx context.Context) ([]*clients.TaggedInstance, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "ListTaggedInstances")
+	defer span.End()
+
+	input := &ec2.DescribeInstancesInput{
+		MaxResults: ptr.ToInt32(1000),
+		Filters: []types.Filter{
+			{
+				Name:   ptr.To("tag-key"),
+				Values: []string{clients.ReservationTagKey},
+			},
+			{
+				Name:   ptr.To("instance-state-name"),
+				Values: []string{"pending", "running", "stopping", "stopped"},
+			},
+		},
+	}
+	pag := ec2.NewDescribeInstancesPaginator(c.ec2, input)
+
+	var result []*clients.TaggedInstance
+	for pag.HasMorePages() {
+		resp, err := pag.NextPage(ctx)
+		if err != nil {
+			if isAWSUnauthorizedError(err) {
+				err = clients.UnauthorizedErr
+			}
+			span.SetStatus(codes.Error, err.Error())
+			return nil, fmt.Errorf("cannot describe tagged instances: %w", err)
+		}
+
+		for _, reservation := range resp.Reservations {
+			for _, instance := range reservation.Instances {
+				for _, tag := range instance.Tags {
+					if ptr.From(tag.Key) != clients.ReservationTagKey {
+						continue
+					}
+					if id, ok := clients.ReservationIDFromTag(ptr.From(tag.Value)); ok {
+						result = append(result, &clients.TaggedInstance{
+							ID:            ptr.From(instance.InstanceId),
+							ReservationID: id,
+							Location:      c.region,
+						})
+					}
+				}
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func (c *ec2Client) GetImageArchitecture(ctx context.Context, ami string) (clients.ArchitectureType, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "GetImageArchitecture")
+	defer span.End()
+
+	input := &ec2.DescribeImagesInput{
+		ImageIds: []string{ami},
+	}
+	resp, err := c.ec2.DescribeImages(ctx, input)
+	if err != nil {
+		if isAWSUnauthorizedError(err) {
+			err = clients.UnauthorizedErr
+		} else if isAWSOperationError(err, "InvalidAMIID.NotFound") || isAWSOperationError(err, "InvalidAMIID.Malformed") {
+			err = http.ImageNotFoundErr
+		}
+		span.SetStatus(codes.Error, err.Error())
+		return "", fmt.Errorf("cannot describe image %s: %w", ami, err)
+	}
+	if len(resp.Images) == 0 {
+		span.SetStatus(codes.Error, "no image found")
+		return "", fmt.Errorf("cannot describe image %s: %w", ami, http.ImageNotFoundErr)
+	}
+
+	arch, err := clients.MapArchitectures(ctx, string(resp.Images[0].Architecture))
+	if err != nil {
+		return "", fmt.Errorf("image %s: %w", ami, err)
+	}
+	return arch, nil
+}
+
+// marketplaceSubscriptionError returns clients.MarketplaceSubscriptionError for OptInRequired errors
+// of AWS Marketplace images. The product code is taken from the error message, or from product
+// codes of the image when the message has none. Other errors are returned unchanged.
+func (c *ec2Client) marketplaceSubscriptionError(ctx context.Context, ami string, err error) error {
+	productCode := marketplaceProductCode(awsErrorMessage(err))
+	if productCode == "" && ami != "" {
+		resp, imageErr := c.ec2.DescribeImages(ctx, &ec2.DescribeImagesInput{ImageIds: []string{ami}})
+		if imageErr != nil {
+			logger(ctx).Warn().Err(imageErr).Msgf("Unable to describe product codes of image %s", ami)
+			return err
+		}
+		for _, image := range resp.Images {
+			for _, code := range image.ProductCodes {
+				if code.ProductCodeType == types.ProductCodeValuesMarketplace {
+					productCode = ptr.FromOrEmpty(code.ProductCodeId)
+					break
+				}
+			}
+		}
+	}
+	if productCode == "" {
+		return err
+	}
+	return clients.NewMarketplaceSubscriptionError(productCode, err)
+}
+
+func (c *ec2Client) IsWindowsImage(ctx context.Context, ami string) (bool, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "IsWindowsImage")
+	defer span.End()
+
+	input := &ec2.DescribeImagesInput{
+		ImageIds: []string{ami},
+	}
+	resp, err := c.ec2.DescribeImages(ctx, input)
+	if err != nil {
+		if isAWSUnauthorizedError(err) {
+			err = clients.UnauthorizedErr
+		} else if isAWSOperationError(err, "InvalidAMIID.NotFound") || isAWSOperationError(err, "InvalidAMIID.Malformed") {
+			err = http.ImageNotFoundErr
+		}
+		span.SetStatus(codes.Error, err.Error())
+		return false, fmt.Errorf("cannot describe image %s: %w", ami, err)
+	}
+	if len(resp.Images) == 0 {
+		span.SetStatus(codes.Error, "no image found")
+		return false, fmt.Errorf("cannot describe image %s: %w", ami, http.ImageNotFoundErr)
+	}
+
+	return resp.Images[0].Platform == types.PlatformValuesWindows, nil
+}
+
+func (c *ec2Client) GetPasswordData(ctx context.Context, instanceId string) (string, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "GetPasswordData")
+	defer span.End()
+
+	input := &ec2.GetPasswordDataInput{
+		InstanceId: &instanceId,
+	}
+	resp, err := c.ec2.GetPasswordData(ctx, input)
+	if err != nil {
+		if isAWSUnauthorizedError(err) {
+			err = clients.UnauthorizedErr
+		}
+		span.SetStatus(codes.Error, err.Error())
+		return "", fmt.Errorf("cannot get password data of %s: %w", instanceId, err)
+	}
+
+	return strings.TrimSpace(ptr.FromOrEmpty(resp.PasswordData)), nil
+}
+
+func (c *ec2Client) GetSubnetCIDR(ctx context.Context, subnetId string) (string, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "GetSubnetCIDR")
+	defer span.End()
+
+	input := &ec2.DescribeSubnetsInput{
+		SubnetIds: []string{subnetId},
+	}
+	resp, err := c.ec2.DescribeSubnets(ctx, input)
+	if err != nil {
+		if isAWSUnauthorizedError(err) {
+			err = clients.UnauthorizedErr
+		} else if isAWSOperationError(err, "InvalidSubnetID.NotFound") || isAWSOperationError(err, "InvalidSubnetID.Malformed") {
+			err = http.SubnetNotFoundErr
+		}
+		span.SetStatus(codes.Error, err.Error())
+		return "", fmt.Errorf("cannot describe subnet %s: %w", subnetId, err)
+	}
+	if len(resp.Subnets) == 0 {
+		span.SetStatus(codes.Error, "no subnet found")
+		return "", fmt.Errorf("cannot describe subnet %s: %w", subnetId, http.SubnetNotFoundErr)
+	}
+
+	return ptr.FromOrEmpty(resp.Subnets[0].CidrBlock), nil
+}
+
+func (c *ec2Client) ProbeCapacity(ctx context.Context, instanceType string) (*clients.Capacity, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "ProbeCapacity")
+	defer span.End()
+
+	input := &ec2.DescribeInstanceTypeOfferingsInput{
+		LocationType: types.LocationTypeAvailabilityZone,
+		Filters: []types.Filter{
+			{
+				Name:   ptr.To("instance-type"),
+				Values: []string{instanceType},
+			},
+		},
+	}
+	capacity := &clients.Capacity{}
+	pag := ec2.NewDescribeInstanceTypeOfferingsPaginator(c.ec2, input)
+	for pag.HasMorePages() {
+		resp, err := pag.NextPage(ctx)
+		if err != nil {
+			if isAWSUnauthorizedError(err) {
+				err = clients.UnauthorizedErr
+			}
+			span.SetStatus(codes.Error, err.Error())
+			return nil, fmt.Errorf("cannot describe offerings of %s: %w", instanceType, err)
+		}
+		for _, offering := range resp.InstanceTypeOfferings {
+			capacity.Zones = append(capacity.Zones, ptr.FromOrEmpty(offering.Location))
+		}
+	}
+	sort.Strings(capacity.Zones)
+	capacity.Regional = len(capacity.Zones) > 0
+
+	return capacity, nil
+}
+
+func (c *ec2Client) DescribeSpotRequests(ctx context.Context, instanceIds []string) ([]*clients.SpotRequest, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "DescribeSpotRequests")
+	defer span.End()
+
+	input := &ec2.DescribeSpotInstanceRequestsInput{
+		Filters: []types.Filter{
+			{
+				Name:   ptr.To("instance-id"),
+				Values: instanceIds,
+			},
+		},
+	}
+	var result []*clients.SpotRequest
+	pag := ec2.NewDescribeSpotInstanceRequestsPaginator(c.ec2, input)
+	for pag.HasMorePages() {
+		resp, err := pag.NextPage(ctx)
+		if err != nil {
+			if isAWSUnauthorizedError(err) {
+				err = clients.UnauthorizedErr
+			}
+			span.SetStatus(codes.Error, err.Error())
+			return nil, fmt.Errorf("cannot describe spot requests: %w", err)
+		}
+		for _, request := range resp.SpotInstanceRequests {
+			spot := &clients.SpotRequest{
+				ID:         ptr.FromOrEmpty(request.SpotInstanceRequestId),
+				InstanceID: ptr.FromOrEmpty(request.InstanceId),
+				State:      string(request.State),
+			}
+			if request.Status != nil {
+				spot.Status = ptr.FromOrEmpty(request.Status.Code)
+			}
+			result = append(result, spot)
+		}
+	}
+
+	return result, nil
+}
+
+func (c *ec2Client) CancelSpotInstances(ctx context.Context, requestIds, instanceIds []string) error {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "CancelSpotInstances")
+	defer span.End()
+
+	if len(requestIds) > 0 {
+		_, err := c.ec2.CancelSpotInstanceRequests(ctx, &ec2.CancelSpotInstanceRequestsInput{SpotInstanceRequestIds: requestIds})
+		if err != nil {
+			if isAWSUnauthorizedError(err) {
+				err = clients.UnauthorizedErr
+			}
+			span.SetStatus(codes.Error, err.Error())
+			return fmt.Errorf("cannot cancel spot requests: %w", err)
+		}
+	}
+
+	// canceled requests keep their instances running
+	if len(instanceIds) > 0 {
+		_, err := c.ec2.TerminateInstances(ctx, &ec2.TerminateInstancesInput{InstanceIds: instanceIds})
+		if err != nil {
+			if isAWSUnauthorizedError(err) {
+				err = clients.UnauthorizedErr
+			}
+			span.SetStatus(codes.Error, err.Error())
+			return fmt.Errorf("cannot terminate spot instances: %w", err)
+		}
+	}
+	return nil
+}
+
+// blockDeviceMappings returns EBS block device mappings of the AMI with encryption enabled and the
+// root volume customized. The default EBS key of the account is used when the KMS key is empty.
+func (c *ec2Client) blockDeviceMappings(ctx context.Context, params *clients.AWSInstanceParams) ([]types.BlockDeviceMapping, error) {
+	input := &ec2.DescribeImagesInput{
+		ImageIds: []string{params.AMI},
+	}
+	resp, err := c.ec2.DescribeImages(ctx, input)
+	if err != nil {
+		if isAWSUnauthorizedError(err) {
+			err = clients.UnauthorizedErr
+		} else if isAWSOperationError(err, "InvalidAMIID.NotFound") || isAWSOperationError(err, "InvalidAMIID.Malformed") {
+			err = http.ImageNotFoundErr
+		}
+		return nil, fmt.Errorf("cannot describe image %s: %w", params.AMI, err)
+	}
+	if len(resp.Images) == 0 {
+		return nil, fmt.Errorf("cannot describe image %s: %w", params.AMI, http.ImageNotFoundErr)
+	}
+
+	image := resp.Images[0]
+	rootFound := false
+	var result []types.BlockDeviceMapping
+	for _, mapping := range image.BlockDeviceMappings {
+		if mapping.Ebs == nil {
+			continue
+		}
+		ebs := &types.EbsBlockDevice{
+			DeleteOnTermination: mapping.Ebs.DeleteOnTermination,
+			VolumeSize:          mapping.Ebs.VolumeSize,
+			VolumeType:          mapping.Ebs.VolumeType,
+			Iops:                mapping.Ebs.Iops,
+			Throughput:          mapping.Ebs.Throughput,
+		}
+		if params.EncryptVolumes {
+			ebs.Encrypted = ptr.To(true)
+			if params.KMSKeyID != "" {
+				ebs.KmsKeyId = ptr.To(params.KMSKeyID)
+			}
+		}
+		if params.RootVolume != nil && ptr.FromOrEmpty(mapping.DeviceName) == ptr.FromOrEmpty(image.RootDeviceName) {
+			if err := customizeRootVolume(ebs, params.RootVolume); err != nil {
+				return nil, err
+			}
+			rootFound = true
+		}
+		result = append(result, types.BlockDeviceMapping{DeviceName: mapping.DeviceName, Ebs: ebs})
+	}
+	if params.RootVolume != nil && !rootFound {
+		return nil, fmt.Errorf("image %s has no EBS root volume", params.AMI)
+	}
+	return result, nil
+}
+
+// customizeRootVolume applies root volume options to the EBS volume of the AMI, the size must not be
+// smaller than the size of the AMI snapshot.
+func customizeRootVolume(ebs *types.EbsBlockDevice, volume *models.AWSRootVolume) error {
+	if volume.Size > 0 {
+		if imageSize := ptr.FromOrEmpty(ebs.VolumeSize); volume.Size < imageSize {
+			return fmt.Errorf("%w: %d GiB requested, the image requires at least %d GiB", clients.RootVolumeTooSmallErr, volume.Size, imageSize)
+		}
+		ebs.VolumeSize = ptr.To(volume.Size)
+	}
+	if volume.Type != "" {
+		// performance of the image volume type does not apply to the requested type
+		ebs.VolumeType = types.VolumeType(volume.Type)
+		ebs.Iops, ebs.Throughput = nil, nil
+	}
+	if volume.IOPS > 0 {
+		ebs.Iops = ptr.To(volume.IOPS)
+	}
+	return nil
+}
+
+// customTags returns EC2 tags sorted by key.
+func customTags(tags map[string]string) []types.Tag {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := make([]types.Tag, len(keys))
+	for i, key := range keys {
+		result[i] = types.Tag{Key: ptr.To(key), Value: ptr.To(tags[key])}
+	}
+	return result
+}
+
+// launchTemplateSpecification returns the launch template by ID ("lt-" prefix) or name, or nil
+// when no template is set.
+func launchTemplateSpecification(template string) *types.LaunchTemplateSpecification {
+	if template == "" {
+		return nil
+	}
+	if strings.HasPrefix(template, "lt-") {
+		return &types.LaunchTemplateSpecification{LaunchTemplateId: ptr.To(template)}
+	}
+	return &types.LaunchTemplateSpecification{LaunchTemplateName: ptr.To(template)}
+}
+
+func (c *ec2Client) ListSubnets(ctx context.Context) ([]*clients.Subnet, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "ListSubnets")
+	defer span.End()
+
+	input := &ec2.DescribeSubnetsInput{MaxResults: ptr.ToInt32(100)}
+	pag := ec2.NewDescribeSubnetsPaginator(c.ec2, input)
+
+	var res []*clients.Subnet
+	for pag.HasMorePages() {
+		resp, err := pag.NextPage(ctx)
+		if err != nil {
+			if isAWSUnauthorizedError(err) {
+				err = clients.UnauthorizedErr
+			}
+			span.SetStatus(codes.Error, err.Error())
+			return nil, fmt.Errorf("cannot list subnets: %w", err)
+		}
+
+		for _, awsSubnet := range resp.Subnets {
+			res = append(res, &clients.Subnet{
+				ID:               ptr.FromOrEmpty(awsSubnet.SubnetId),
+				Name:             nameTag(awsSubnet.Tags),
+				VpcID:            ptr.FromOrEmpty(awsSubnet.VpcId),
+				CIDR:             ptr.FromOrEmpty(awsSubnet.CidrBlock),
+				AvailabilityZone: ptr.FromOrEmpty(awsSubnet.AvailabilityZone),
+				Default:          ptr.FromOrEmpty(awsSubnet.DefaultForAz),
+			})
+		}
+	}
+
+	return res, nil
+}
+
+func (c *ec2Client) ListSecurityGroups(ctx context.Context) ([]*clients.SecurityGroup, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "ListSecurityGroups")
+	defer span.End()
+
+	input := &ec2.DescribeSecurityGroupsInput{MaxResults: ptr.ToInt32(100)}
+	pag := ec2.NewDescribeSecurityGroupsPaginator(c.ec2, input)
+
+	var res []*clients.SecurityGroup
+	for pag.HasMorePages() {
+		resp, err := pag.NextPage(ctx)
+		if err != nil {
+			if isAWSUnauthorizedError(err) {
+				err = clients.UnauthorizedErr
+			}
+			span.SetStatus(codes.Error, err.Error())
+			return nil, fmt.Errorf("cannot list security groups: %w", err)
+		}
+
+		for _, awsGroup := range resp.SecurityGroups {
+			res = append(res, &clients.SecurityGroup{
+				ID:          ptr.FromOrEmpty(awsGroup.GroupId),
+				Name:        ptr.FromOrEmpty(awsGroup.GroupName),
+				Description: ptr.FromOrEmpty(awsGroup.Description),
+				VpcID:       ptr.FromOrEmpty(awsGroup.VpcId),
+			})
+		}
+	}
+
+	return res, nil
+}
+
+func (c *ec2Client) ListDedicatedHosts(ctx context.Context) ([]*clients.DedicatedHost, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "ListDedicatedHosts")
+	defer span.End()
+
+	input := &ec2.DescribeHostsInput{MaxResults: ptr.ToInt32(100)}
+	pag := ec2.NewDescribeHostsPaginator(c.ec2, input)
+
+	var res []*clients.DedicatedHost
+	for pag.HasMorePages() {
+		resp, err := pag.NextPage(ctx)
+		if err != nil {
+			if isAWSUnauthorizedError(err) {
+				err = clients.UnauthorizedErr
+			}
+			span.SetStatus(codes.Error, err.Error())
+			return nil, fmt.Errorf("cannot list dedicated hosts: %w", err)
+		}
+
+		for _, awsHost := range resp.Hosts {
+			host := &clients.DedicatedHost{
+				ID:               ptr.FromOrEmpty(awsHost.HostId),
+				Name:             nameTag(awsHost.Tags),
+				AvailabilityZone: ptr.FromOrEmpty(awsHost.AvailabilityZone),
+				State:            string(awsHost.State),
+				AutoPlacement:    awsHost.AutoPlacement == types.AutoPlacementOn,
+				Instances:        len(awsHost.Instances),
+			}
+			if awsHost.HostProperties != nil {
+				host.InstanceFamily = ptr.FromOrEmpty(awsHost.HostProperties.InstanceFamily)
+				host.InstanceType = ptr.FromOrEmpty(awsHost.HostProperties.InstanceType)
+			}
+			if awsHost.AvailableCapacity != nil {
+				host.AvailableVCPUs = ptr.FromOrEmpty(awsHost.AvailableCapacity.AvailableVCpus)
+			}
+			res = append(res, host)
+		}
+	}
+
+	return res, nil
+}
+
+func (c *ec2Client) ListCapacityReservations(ctx context.Context) ([]*clients.CapacityReservation, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "ListCapacityReservations")
+	defer span.End()
+
+	input := &ec2.DescribeCapacityReservationsInput{
+		Filters:    []types.Filter{{Name: ptr.To("state"), Values: []string{string(types.CapacityReservationStateActive)}}},
+		MaxResults: ptr.ToInt32(100),
+	}
+	pag := ec2.NewDescribeCapacityReservationsPaginator(c.ec2, input)
+
+	var res []*clients.CapacityReservation
+	for pag.HasMorePages() {
+		resp, err := pag.NextPage(ctx)
+		if err != nil {
+			if isAWSUnauthorizedError(err) {
+				err = clients.UnauthorizedErr
+			}
+			span.SetStatus(codes.Error, err.Error())
+			return nil, fmt.Errorf("cannot list capacity reservations: %w", err)
+		}
+
+		for _, awsReservation := range resp.CapacityReservations {
+			available := ptr.FromOrEmpty(awsReservation.AvailableInstanceCount)
+			if available == 0 {
+				continue
+			}
+			res = append(res, &clients.CapacityReservation{
+				ID:                    ptr.FromOrEmpty(awsReservation.CapacityReservationId),
+				Name:                  nameTag(awsReservation.Tags),
+				InstanceType:          ptr.FromOrEmpty(awsReservation.InstanceType),
+				AvailabilityZone:      ptr.FromOrEmpty(awsReservation.AvailabilityZone),
+				Platform:              string(awsReservation.InstancePlatform),
+				Tenancy:               string(awsReservation.Tenancy),
+				InstanceMatchCriteria: string(awsReservation.InstanceMatchCriteria),
+				TotalInstances:        ptr.FromOrEmpty(awsReservation.TotalInstanceCount),
+				AvailableInstances:    available,
+				EndDate:               awsReservation.EndDate,
+			})
+		}
+	}
+
+	return res, nil
+}
+
+// nameTag returns the value of the Name tag, blank when the resource is not tagged.
+func nameTag(tags []types.Tag) string {
+	for _, tag := range tags {
+		if ptr.FromOrEmpty(tag.Key) == "Name" {
+			return ptr.FromOrEmpty(tag.Value)
+		}
+	}
+	return ""
+}
+
 func (c *ec2Client) ListLaunchTemplates(ctx context.Context) ([]*clients.LaunchTemplate, error) {
 	ctx, span := otel.Tracer(TraceName).Start(ctx, "ListLaunchTemplates")
 	defer span.End()
@@ -368,6 +1126,84 @@ func (c *ec2Client) ListLaunchTemplates(ctx context.Context) ([]*clients.LaunchT
 	return res, nil
 }
 
+func (c *ec2Client) GetLaunchTemplateVersion(ctx context.Context, template string) (*clients.LaunchTemplateVersion, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "GetLaunchTemplateVersion")
+	defer span.End()
+
+	spec := launchTemplateSpecification(template)
+	input := &ec2.DescribeLaunchTemplateVersionsInput{
+		LaunchTemplateId:   spec.LaunchTemplateId,
+		LaunchTemplateName: spec.LaunchTemplateName,
+		Versions:           []string{"$Default"},
+	}
+	resp, err := c.ec2.DescribeLaunchTemplateVersions(ctx, input)
+	if err != nil {
+		if isAWSUnauthorizedError(err) {
+			err = clients.UnauthorizedErr
+		} else if isAWSOperationError(err, "InvalidLaunchTemplateId.NotFound") ||
+			isAWSOperationError(err, "InvalidLaunchTemplateId.Malformed") ||
+			isAWSOperationError(err, "InvalidLaunchTemplateName.NotFoundException") {
+			err = http.LaunchTemplateNotFoundErr
+		}
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("cannot describe launch template %s: %w", template, err)
+	}
+	if len(resp.LaunchTemplateVersions) == 0 || resp.LaunchTemplateVersions[0].LaunchTemplateData == nil {
+		span.SetStatus(codes.Error, "no launch template version found")
+		return nil, fmt.Errorf("cannot describe launch template %s: %w", template, http.LaunchTemplateNotFoundErr)
+	}
+
+	data := resp.LaunchTemplateVersions[0].LaunchTemplateData
+	return &clients.LaunchTemplateVersion{
+		InstanceType: string(data.InstanceType),
+		ImageID:      ptr.FromOrEmpty(data.ImageId),
+	}, nil
+}
+
+// networkInterfaceSpecifications returns the requested interfaces, the primary one is created from
+// the subnet and security groups when only addressing options are set. Public IPv4 addresses are
+// assigned to single interfaces unless disabled.
+func networkInterfaceSpecifications(params *clients.AWSInstanceParams) []types.InstanceNetworkInterfaceSpecification {
+	var specs []types.InstanceNetworkInterfaceSpecification
+	for i, nic := range params.NetworkInterfaces {
+		spec := types.InstanceNetworkInterfaceSpecification{
+			DeviceIndex:         ptr.To(int32(i)),
+			SubnetId:            ptr.To(nic.SubnetID),
+			Groups:              nic.SecurityGroupIDs,
+			DeleteOnTermination: ptr.To(true),
+		}
+		if nic.PrivateIPv4 != "" {
+			spec.PrivateIpAddress = ptr.To(nic.PrivateIPv4)
+		}
+		specs = append(specs, spec)
+	}
+	// addressing can only be set on interfaces, the primary one is created from subnet and groups
+	if len(specs) == 0 && params.Addressing != nil {
+		spec := types.InstanceNetworkInterfaceSpecification{
+			DeviceIndex:         ptr.To(int32(0)),
+			Groups:              params.SecurityGroupIDs,
+			DeleteOnTermination: ptr.To(true),
+		}
+		if params.SubnetID != "" {
+			spec.SubnetId = ptr.To(params.SubnetID)
+		}
+		specs = append(specs, spec)
+	}
+	// AWS does not assign public IPv4 addresses to instances launched with multiple interfaces
+	if len(specs) == 1 {
+		specs[0].AssociatePublicIpAddress = ptr.To(true)
+	}
+	if params.Addressing != nil && len(specs) > 0 {
+		if params.Addressing.PublicIPv4 != nil {
+			specs[0].AssociatePublicIpAddress = params.Addressing.PublicIPv4
+		}
+		if params.Addressing.IPv6 {
+			specs[0].Ipv6AddressCount = ptr.To(int32(1))
+		}
+	}
+	return specs
+}
+
 func (c *ec2Client) RunInstances(ctx context.Context, params *clients.AWSInstanceParams, amount int32, name *string, reservation *models.AWSReservation) ([]*string, *string, error) {
 	ctx, span := otel.Tracer(TraceName).Start(ctx, "RunInstances")
 	defer span.End()
@@ -378,48 +1214,97 @@ func (c *ec2Client) RunInstances(ctx context.Context, params *clients.AWSInstanc
 	logger := logger(ctx)
 	logger.Trace().Msg("Run AWS EC2 instance")
 
-	var templateSpec *types.LaunchTemplateSpecification
-	if params.LaunchTemplateID != "" {
-		templateSpec = &types.LaunchTemplateSpecification{
-			LaunchTemplateId: ptr.To(params.LaunchTemplateID),
-		}
-	}
-
-	encodedUserData := base64.StdEncoding.EncodeToString(params.UserData)
+	// The default version of the template is used, parameters set below override the template
 	input := &ec2.RunInstancesInput{
-		LaunchTemplate: templateSpec,
+		LaunchTemplate: launchTemplateSpecification(params.LaunchTemplateID),
 		MaxCount:       ptr.To(amount),
 		MinCount:       ptr.To(amount),
 		InstanceType:   params.InstanceType,
-		ImageId:        ptr.To(params.AMI),
-		KeyName:        &params.KeyName,
-		UserData:       &encodedUserData,
+	}
+	if params.AMI != "" {
+		input.ImageId = ptr.To(params.AMI)
+	}
+	if params.KeyName != "" {
+		input.KeyName = ptr.To(params.KeyName)
+	}
+	if len(params.UserData) > 0 {
+		input.UserData = ptr.To(base64.StdEncoding.EncodeToString(params.UserData))
 	}
 
-	input.TagSpecifications = []types.TagSpecification{
-		{
-			ResourceType: types.ResourceTypeInstance,
-			Tags: []types.Tag{
-				{
-					Key:   ptr.To("rh-rid"),
-					Value: ptr.To(config.EnvironmentPrefix("r", strconv.FormatInt(reservation.ID, 10))),
-				},
-			},
-		},
+	if params.EncryptVolumes || params.RootVolume != nil {
+		mappings, err := c.blockDeviceMappings(ctx, params)
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return nil, nil, err
+		}
+		input.BlockDeviceMappings = mappings
 	}
 
-	if name != nil {
-		t := types.Tag{
-			Key:   ptr.To("Name"),
-			Value: name,
+	input.NetworkInterfaces = networkInterfaceSpecifications(params)
+	if len(input.NetworkInterfaces) == 0 {
+		if params.SubnetID != "" {
+			input.SubnetId = ptr.To(params.SubnetID)
 		}
-		input.TagSpecifications[0].Tags = append(input.TagSpecifications[0].Tags, t)
+		input.SecurityGroupIds = params.SecurityGroupIDs
 	}
 
+	if params.Tenancy != "" {
+		input.Placement = &types.Placement{Tenancy: types.Tenancy(params.Tenancy)}
+		if params.HostID != "" {
+			input.Placement.HostId = ptr.To(params.HostID)
+		}
+	}
+
+	if params.CapacityReservationID != "" || params.CapacityReservationGroupARN != "" {
+		target := &types.CapacityReservationTarget{}
+		if params.CapacityReservationID != "" {
+			target.CapacityReservationId = ptr.To(params.CapacityReservationID)
+		} else {
+			target.CapacityReservationResourceGroupArn = ptr.To(params.CapacityReservationGroupARN)
+		}
+		input.CapacityReservationSpecification = &types.CapacityReservationSpecification{CapacityReservationTarget: target}
+	}
+
+	if params.Hibernation {
+		input.HibernationOptions = &types.HibernationOptionsRequest{Configured: ptr.To(true)}
+	}
+
+	if params.NitroEnclaves {
+		input.EnclaveOptions = &types.EnclaveOptionsRequest{Enabled: ptr.To(true)}
+	}
+
+	if params.Spot {
+		spotOptions := &types.SpotMarketOptions{
+			SpotInstanceType:             types.SpotInstanceTypeOneTime,
+			InstanceInterruptionBehavior: types.InstanceInterruptionBehaviorTerminate,
+		}
+		if params.SpotMaxPrice != "" {
+			spotOptions.MaxPrice = ptr.To(params.SpotMaxPrice)
+		}
+		input.InstanceMarketOptions = &types.InstanceMarketOptionsRequest{
+			MarketType:  types.MarketTypeSpot,
+			SpotOptions: spotOptions,
+		}
+	}
+
+	if strings.HasPrefix(params.InstanceProfile, "arn:") {
+		input.IamInstanceProfile = &types.IamInstanceProfileSpecification{Arn: ptr.To(params.InstanceProfile)}
+	} else if params.InstanceProfile != "" {
+		input.IamInstanceProfile = &types.IamInstanceProfileSpecification{Name: ptr.To(params.InstanceProfile)}
+	}
+
+	input.TagSpecifications = tagSpecifications(params, name, reservation)
+
 	resp, err := c.ec2.RunInstances(ctx, input)
 	if err != nil {
 		if isAWSUnauthorizedError(err) {
 			err = clients.UnauthorizedErr
+		} else if isAWSOperationError(err, "OptInRequired") {
+			err = c.marketplaceSubscriptionError(ctx, params.AMI, err)
+		} else if isAWSOperationError(err, "InsufficientInstanceCapacity") || isAWSOperationError(err, "ReservationCapacityExceeded") {
+			err = fmt.Errorf("%w: %s", clients.InsufficientCapacityErr, err.Error())
+		} else if isAWSOperationError(err, "SpotMaxPriceTooLow") || isAWSOperationError(err, "MaxSpotInstanceCountExceeded") {
+			err = fmt.Errorf("%w: %s", clients.SpotUnavailableErr, err.Error())
 		}
 		span.SetStatus(codes.Error, err.Error())
 		return nil, nil, fmt.Errorf("cannot run instances: %w", err)
@@ -434,6 +1319,45 @@ func (c *ec2Client) RunInstances(ctx context.Context, params *clients.AWSInstanc
 	return instances, resp.ReservationId, nil
 }
 
+// tagSpecifications returns tags of launched instances: the reservation tag, the name and custom tags.
+func tagSpecifications(params *clients.AWSInstanceParams, name *string, reservation *models.AWSReservation) []types.TagSpecification {
+	result := []types.TagSpecification{
+		{
+			ResourceType: types.ResourceTypeInstance,
+			Tags: []types.Tag{
+				{
+					Key:   ptr.To(clients.ReservationTagKey),
+					Value: ptr.To(clients.ReservationTagValue(reservation.ID)),
+				},
+			},
+		},
+	}
+
+	if name != nil {
+		t := types.Tag{
+			Key:   ptr.To("Name"),
+			Value: name,
+		}
+		result[0].Tags = append(result[0].Tags, t)
+	}
+
+	// Custom tags are applied to volumes too, volumes are not tagged without them
+	if len(params.Tags) > 0 {
+		custom := customTags(params.Tags)
+		result[0].Tags = append(result[0].Tags, custom...)
+		result = append(result, types.TagSpecification{
+			ResourceType: types.ResourceTypeVolume,
+			Tags: append([]types.Tag{
+				{
+					Key:   ptr.To(clients.ReservationTagKey),
+					Value: ptr.To(clients.ReservationTagValue(reservation.ID)),
+				},
+			}, custom...),
+		})
+	}
+	return result
+}
+
 func (c *ec2Client) parseRunInstancesResponse(respAWS *ec2.RunInstancesOutput) []*string {
 	instances := respAWS.Instances
 	list := make([]*string, len(instances))
@@ -447,18 +1371,55 @@ func (c *ec2Client) parseDescribeInstances(respAWS *ec2.DescribeInstancesOutput)
 	if len(respAWS.Reservations) == 0 {
 		return nil, http.NoReservationErr
 	}
-	instances := respAWS.Reservations[0].Instances
-	list := make([]*clients.InstanceDescription, len(instances))
-	for i, instance := range instances {
-		list[i] = &clients.InstanceDescription{
-			ID:         *instance.InstanceId,
-			PublicIPv4: ptr.FromOrEmpty(instance.PublicIpAddress),
-			PublicDNS:  ptr.FromOrEmpty(instance.PublicDnsName),
+	// instances launched by a fleet can belong to multiple EC2 reservations
+	var list []*clients.InstanceDescription
+	for _, reservation := range respAWS.Reservations {
+		for _, instance := range reservation.Instances {
+			description := &clients.InstanceDescription{
+				ID:          *instance.InstanceId,
+				PublicIPv4:  ptr.FromOrEmpty(instance.PublicIpAddress),
+				PublicDNS:   ptr.FromOrEmpty(instance.PublicDnsName),
+				PrivateIPv4: ptr.FromOrEmpty(instance.PrivateIpAddress),
+				PrivateDNS:  ptr.FromOrEmpty(instance.PrivateDnsName),
+			}
+			if instance.State != nil {
+				description.Pending = instance.State.Name == types.InstanceStateNamePending
+			}
+			if len(instance.NetworkInterfaces) > 1 {
+				description.NetworkInterfaces = parseNetworkInterfaces(instance.NetworkInterfaces)
+			}
+			list = append(list, description)
 		}
 	}
 	return list, nil
 }
 
+// parseNetworkInterfaces returns interfaces of an instance ordered by the device index
+func parseNetworkInterfaces(nics []types.InstanceNetworkInterface) []models.InstanceNetworkInterface {
+	sorted := make([]types.InstanceNetworkInterface, len(nics))
+	copy(sorted, nics)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return deviceIndex(sorted[i]) < deviceIndex(sorted[j])
+	})
+
+	result := make([]models.InstanceNetworkInterface, len(sorted))
+	for i, nic := range sorted {
+		result[i] = models.InstanceNetworkInterface{
+			ID:          ptr.FromOrEmpty(nic.NetworkInterfaceId),
+			SubnetID:    ptr.FromOrEmpty(nic.SubnetId),
+			PrivateIPv4: ptr.FromOrEmpty(nic.PrivateIpAddress),
+		}
+	}
+	return result
+}
+
+func deviceIndex(nic types.InstanceNetworkInterface) int32 {
+	if nic.Attachment == nil || nic.Attachment.DeviceIndex == nil {
+		return 0
+	}
+	return *nic.Attachment.DeviceIndex
+}
+
 func (c *ec2Client) GetAccountId(ctx context.Context) (string, error) {
 	ctx, span := otel.Tracer(TraceName).Start(ctx, "GetAccountId")
 	defer span.End()
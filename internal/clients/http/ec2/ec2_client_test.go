@@ -0,0 +1,134 @@
+package ec2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/clients/http"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointResolver(t *testing.T) {
+	resolver := endpointResolver("http://localhost:4566", true)
+
+	for _, service := range []string{"EC2", "STS", "IAM"} {
+		endpoint, err := resolver.ResolveEndpoint(service, "eu-central-1")
+		require.NoError(t, err)
+		assert.Equal(t, "http://localhost:4566", endpoint.URL)
+		assert.Equal(t, "eu-central-1", endpoint.SigningRegion)
+		assert.True(t, endpoint.HostnameImmutable)
+		assert.Equal(t, aws.EndpointSourceCustom, endpoint.Source)
+	}
+}
+
+func TestPartitions(t *testing.T) {
+	t.Run("region outside of the partition", func(t *testing.T) {
+		auth := clients.NewAuthentication("arn:aws-us-gov:iam::123456789012:role/name", models.ProviderTypeAWS)
+		_, err := newAssumedEC2ClientWithRegion(context.Background(), auth, "us-east-1")
+		require.ErrorIs(t, err, http.RegionPartitionMismatchErr)
+	})
+
+	t.Run("partition without service account", func(t *testing.T) {
+		_, err := serviceCredentials(clients.AWSPartitionChina)
+		require.ErrorIs(t, err, http.PartitionNotConfiguredErr)
+
+		_, err = serviceCredentials(clients.AWSPartitionStandard)
+		require.NoError(t, err)
+	})
+}
+
+func TestLaunchTemplateSpecification(t *testing.T) {
+	assert.Nil(t, launchTemplateSpecification(""))
+
+	spec := launchTemplateSpecification("lt-0abcd1234ef567890")
+	require.NotNil(t, spec)
+	assert.Equal(t, "lt-0abcd1234ef567890", aws.ToString(spec.LaunchTemplateId))
+	assert.Nil(t, spec.LaunchTemplateName)
+
+	spec = launchTemplateSpecification("my-template")
+	require.NotNil(t, spec)
+	assert.Equal(t, "my-template", aws.ToString(spec.LaunchTemplateName))
+	assert.Nil(t, spec.LaunchTemplateId)
+}
+
+func TestCustomTags(t *testing.T) {
+	tags := customTags(map[string]string{"team": "qe", "cost-center": "1234"})
+	require.Len(t, tags, 2)
+	assert.Equal(t, "cost-center", aws.ToString(tags[0].Key))
+	assert.Equal(t, "1234", aws.ToString(tags[0].Value))
+	assert.Equal(t, "team", aws.ToString(tags[1].Key))
+}
+
+func TestCustomizeRootVolume(t *testing.T) {
+	image := func() *types.EbsBlockDevice {
+		return &types.EbsBlockDevice{
+			VolumeSize: aws.Int32(10),
+			VolumeType: types.VolumeTypeGp2,
+			Iops:       aws.Int32(100),
+		}
+	}
+
+	t.Run("size", func(t *testing.T) {
+		ebs := image()
+		err := customizeRootVolume(ebs, &models.AWSRootVolume{Size: 50})
+		require.NoError(t, err)
+		assert.Equal(t, int32(50), aws.ToInt32(ebs.VolumeSize))
+		assert.Equal(t, types.VolumeTypeGp2, ebs.VolumeType)
+	})
+
+	t.Run("type and IOPS", func(t *testing.T) {
+		ebs := image()
+		err := customizeRootVolume(ebs, &models.AWSRootVolume{Type: "io2", IOPS: 5000})
+		require.NoError(t, err)
+		assert.Equal(t, int32(10), aws.ToInt32(ebs.VolumeSize))
+		assert.Equal(t, types.VolumeTypeIo2, ebs.VolumeType)
+		assert.Equal(t, int32(5000), aws.ToInt32(ebs.Iops))
+	})
+
+	t.Run("type", func(t *testing.T) {
+		ebs := image()
+		err := customizeRootVolume(ebs, &models.AWSRootVolume{Type: "gp3"})
+		require.NoError(t, err)
+		assert.Nil(t, ebs.Iops, "IOPS of the image volume type must not be kept")
+	})
+
+	t.Run("smaller than image", func(t *testing.T) {
+		err := customizeRootVolume(image(), &models.AWSRootVolume{Size: 8})
+		assert.ErrorIs(t, err, clients.RootVolumeTooSmallErr)
+	})
+}
+
+func TestNetworkInterfaceSpecifications(t *testing.T) {
+	t.Run("subnet default", func(t *testing.T) {
+		assert.Empty(t, networkInterfaceSpecifications(&clients.AWSInstanceParams{SubnetID: "subnet-1"}))
+	})
+
+	t.Run("private dual stack", func(t *testing.T) {
+		specs := networkInterfaceSpecifications(&clients.AWSInstanceParams{
+			SubnetID:         "subnet-1",
+			SecurityGroupIDs: []string{"sg-1"},
+			Addressing:       &models.Addressing{PublicIPv4: aws.Bool(false), IPv6: true},
+		})
+		require.Len(t, specs, 1)
+		assert.Equal(t, "subnet-1", aws.ToString(specs[0].SubnetId))
+		assert.Equal(t, []string{"sg-1"}, specs[0].Groups)
+		assert.False(t, aws.ToBool(specs[0].AssociatePublicIpAddress))
+		assert.Equal(t, int32(1), aws.ToInt32(specs[0].Ipv6AddressCount))
+	})
+
+	t.Run("multiple interfaces", func(t *testing.T) {
+		specs := networkInterfaceSpecifications(&clients.AWSInstanceParams{
+			NetworkInterfaces: []models.NetworkInterface{{SubnetID: "subnet-1"}, {SubnetID: "subnet-2"}},
+			Addressing:        &models.Addressing{IPv6: true},
+		})
+		require.Len(t, specs, 2)
+		assert.Nil(t, specs[0].AssociatePublicIpAddress)
+		assert.Equal(t, int32(1), aws.ToInt32(specs[0].Ipv6AddressCount))
+		assert.Nil(t, specs[1].Ipv6AddressCount)
+	})
+}
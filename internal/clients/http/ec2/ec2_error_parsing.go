@@ -1,20 +1,87 @@
 package ec2
 
 import (
+	"context"
 	"errors"
-	"strings"
+	"regexp"
 
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsMiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
 	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
 )
 
 func isAWSUnauthorizedError(err error) bool {
-	return isAWSOperationError(err, "api error UnauthorizedOperation")
+	return isAWSOperationError(err, "UnauthorizedOperation")
 }
 
-func isAWSOperationError(err error, substr string) bool {
-	var oe *smithy.OperationError
-	if errors.As(err, &oe) {
-		return strings.Contains(oe.Unwrap().Error(), substr)
+// isAWSOperationError returns true when err is an API error with the error code.
+func isAWSOperationError(err error, code string) bool {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.ErrorCode() == code
 	}
 	return false
 }
+
+// marketplaceURLRegexp matches the subscription URL in OptInRequired errors of AWS Marketplace images
+var marketplaceURLRegexp = regexp.MustCompile(`https?://aws\.amazon\.com/marketplace/pp\?sku=([0-9A-Za-z]+)`)
+
+// marketplaceProductCode returns the product code from the subscription URL of an OptInRequired
+// message, blank when the message has no marketplace URL (e.g. regions which are not enabled).
+func marketplaceProductCode(message string) string {
+	match := marketplaceURLRegexp.FindStringSubmatch(message)
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
+
+// awsErrorMessage returns the message of an API error, blank for other errors.
+func awsErrorMessage(err error) string {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.ErrorMessage()
+	}
+	return ""
+}
+
+// withProviderErrors is an API option wrapping errors of all operations into clients.ProviderError.
+// The middleware is the first one of the stack, errors are wrapped after all retry attempts failed.
+func withProviderErrors(stack *middleware.Stack) error {
+	fn := middleware.InitializeMiddlewareFunc("ProviderError", func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+		out, metadata, err := next.HandleInitialize(ctx, in)
+		if err != nil {
+			err = newProviderError(awsMiddleware.GetOperationName(ctx), err)
+		}
+		return out, metadata, err
+	})
+	return stack.Initialize.Add(fn, middleware.Before)
+}
+
+func newProviderError(operation string, err error) *clients.ProviderError {
+	pe := &clients.ProviderError{
+		Provider:  clients.ProviderAWS,
+		Operation: operation,
+		Err:       err,
+	}
+	var respErr *smithyhttp.ResponseError
+	if errors.As(err, &respErr) {
+		pe.StatusCode = respErr.HTTPStatusCode()
+	}
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		pe.Code = apiErr.ErrorCode()
+	}
+	pe.Throttled = isThrottleError(err)
+	pe.Retryable = pe.Throttled || retry.IsErrorRetryables(retry.DefaultRetryables).IsErrorRetryable(err) == aws.TrueTernary
+	return pe
+}
+
+// isThrottleError returns true when err was caused by API rate limits (e.g. RequestLimitExceeded).
+func isThrottleError(err error) bool {
+	return retry.IsErrorThrottles(retry.DefaultThrottles).IsErrorThrottle(err) == aws.TrueTernary
+}
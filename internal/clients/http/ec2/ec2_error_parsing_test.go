@@ -0,0 +1,58 @@
+package ec2
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func apiError(status int, code string) error {
+	return &smithy.OperationError{
+		ServiceID:     "EC2",
+		OperationName: "RunInstances",
+		Err: &smithyhttp.ResponseError{
+			Response: &smithyhttp.Response{Response: &http.Response{StatusCode: status}},
+			Err:      &smithy.GenericAPIError{Code: code, Message: "api error"},
+		},
+	}
+}
+
+func TestIsAWSOperationError(t *testing.T) {
+	err := fmt.Errorf("cannot run instances: %w", newProviderError("RunInstances", apiError(400, "InvalidAMIID.NotFound")))
+
+	assert.True(t, isAWSOperationError(err, "InvalidAMIID.NotFound"))
+	assert.False(t, isAWSOperationError(err, "InvalidAMIID"))
+	assert.False(t, isAWSUnauthorizedError(err))
+	assert.True(t, isAWSUnauthorizedError(apiError(403, "UnauthorizedOperation")))
+}
+
+func TestNewProviderError(t *testing.T) {
+	t.Run("throttled", func(t *testing.T) {
+		pe := newProviderError("RunInstances", apiError(503, "RequestLimitExceeded"))
+		assert.Equal(t, clients.ProviderAWS, pe.Provider)
+		assert.Equal(t, "RunInstances", pe.Operation)
+		assert.Equal(t, 503, pe.StatusCode)
+		assert.Equal(t, "RequestLimitExceeded", pe.Code)
+		assert.True(t, pe.Throttled)
+		assert.True(t, pe.Retryable)
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		pe := newProviderError("RunInstances", apiError(400, "InsufficientInstanceCapacity"))
+		require.Equal(t, 400, pe.StatusCode)
+		assert.False(t, pe.Throttled)
+		assert.False(t, pe.Retryable)
+	})
+}
+
+func TestMarketplaceProductCode(t *testing.T) {
+	message := "In order to use this AWS Marketplace product you need to accept terms and subscribe. To do so please visit https://aws.amazon.com/marketplace/pp?sku=cpkwgozd4by0cmoinfftz1qqr"
+	assert.Equal(t, "cpkwgozd4by0cmoinfftz1qqr", marketplaceProductCode(message))
+	assert.Empty(t, marketplaceProductCode("You are not subscribed to this service. Please go to http://aws.amazon.com to subscribe."))
+}
@@ -0,0 +1,133 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/ptr"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func (c *ec2Client) ListPoolElasticIPs(ctx context.Context, pool string) ([]*models.ElasticIP, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "ListPoolElasticIPs")
+	defer span.End()
+
+	input := &ec2.DescribeAddressesInput{
+		Filters: []types.Filter{
+			{Name: ptr.To("tag:" + clients.ElasticIPPoolTagKey), Values: []string{pool}},
+			{Name: ptr.To("domain"), Values: []string{string(types.DomainTypeVpc)}},
+		},
+	}
+	resp, err := c.ec2.DescribeAddresses(ctx, input)
+	if err != nil {
+		if isAWSUnauthorizedError(err) {
+			err = clients.UnauthorizedErr
+		}
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("cannot list elastic IPs of pool %s: %w", pool, err)
+	}
+
+	var result []*models.ElasticIP
+	for _, address := range resp.Addresses {
+		if address.AssociationId != nil {
+			continue
+		}
+		result = append(result, &models.ElasticIP{
+			AllocationID: ptr.FromOrEmpty(address.AllocationId),
+			PublicIP:     ptr.FromOrEmpty(address.PublicIp),
+			Pooled:       true,
+		})
+	}
+	return result, nil
+}
+
+func (c *ec2Client) AllocateElasticIP(ctx context.Context, reservationId int64) (*models.ElasticIP, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "AllocateElasticIP")
+	defer span.End()
+
+	input := &ec2.AllocateAddressInput{
+		Domain: types.DomainTypeVpc,
+		TagSpecifications: []types.TagSpecification{{
+			ResourceType: types.ResourceTypeElasticIp,
+			Tags: []types.Tag{{
+				Key:   ptr.To(clients.ReservationTagKey),
+				Value: ptr.To(clients.ReservationTagValue(reservationId)),
+			}},
+		}},
+	}
+	resp, err := c.ec2.AllocateAddress(ctx, input)
+	if err != nil {
+		if isAWSUnauthorizedError(err) {
+			err = clients.UnauthorizedErr
+		} else if isAWSOperationError(err, "AddressLimitExceeded") {
+			err = clients.ElasticIPLimitErr
+		}
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("cannot allocate elastic IP: %w", err)
+	}
+
+	return &models.ElasticIP{
+		AllocationID: ptr.FromOrEmpty(resp.AllocationId),
+		PublicIP:     ptr.FromOrEmpty(resp.PublicIp),
+	}, nil
+}
+
+func (c *ec2Client) AssociateElasticIP(ctx context.Context, allocationId, instanceId string) (string, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "AssociateElasticIP")
+	defer span.End()
+
+	input := &ec2.AssociateAddressInput{
+		AllocationId:       ptr.To(allocationId),
+		InstanceId:         ptr.To(instanceId),
+		AllowReassociation: ptr.To(false),
+	}
+	resp, err := c.ec2.AssociateAddress(ctx, input)
+	if err != nil {
+		if isAWSUnauthorizedError(err) {
+			err = clients.UnauthorizedErr
+		} else if isAWSOperationError(err, "Resource.AlreadyAssociated") {
+			err = clients.ElasticIPInUseErr
+		}
+		span.SetStatus(codes.Error, err.Error())
+		return "", fmt.Errorf("cannot associate elastic IP %s to %s: %w", allocationId, instanceId, err)
+	}
+
+	return ptr.FromOrEmpty(resp.AssociationId), nil
+}
+
+func (c *ec2Client) DisassociateElasticIP(ctx context.Context, associationId string) error {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "DisassociateElasticIP")
+	defer span.End()
+
+	_, err := c.ec2.DisassociateAddress(ctx, &ec2.DisassociateAddressInput{AssociationId: ptr.To(associationId)})
+	if err != nil {
+		if isAWSUnauthorizedError(err) {
+			err = clients.UnauthorizedErr
+		} else if isAWSOperationError(err, "InvalidAssociationID.NotFound") {
+			err = clients.NotFoundErr
+		}
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("cannot disassociate elastic IP %s: %w", associationId, err)
+	}
+	return nil
+}
+
+func (c *ec2Client) ReleaseElasticIP(ctx context.Context, allocationId string) error {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "ReleaseElasticIP")
+	defer span.End()
+
+	_, err := c.ec2.ReleaseAddress(ctx, &ec2.ReleaseAddressInput{AllocationId: ptr.To(allocationId)})
+	if err != nil {
+		if isAWSUnauthorizedError(err) {
+			err = clients.UnauthorizedErr
+		}
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("cannot release elastic IP %s: %w", allocationId, err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/clients/http"
+	"github.com/RHEnVision/provisioning-backend/internal/ptr"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	ebTypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqsTypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// EventSource is the source of events published to EventBridge event buses
+const EventSource = "redhat.provisioning"
+
+// detailTypeAttribute is the SQS message attribute with the detail type of the event
+const detailTypeAttribute = "DetailType"
+
+func (c *ec2Client) PublishEvent(ctx context.Context, target *clients.EventTarget, detailType string, detail []byte) error {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "PublishEvent")
+	defer span.End()
+
+	if !c.assumed {
+		return http.ServiceAccountUnsupportedOperationErr
+	}
+	if target.Region != c.region {
+		return fmt.Errorf("%w: target region %s, client region %s", clients.InvalidEventTargetErr, target.Region, c.region)
+	}
+
+	var err error
+	switch target.Service {
+	case clients.EventTargetSQS:
+		err = c.sendMessage(ctx, target, detailType, detail)
+	case clients.EventTargetEventBridge:
+		err = c.putEvent(ctx, target, detailType, detail)
+	default:
+		err = fmt.Errorf("%w: unsupported service %s", clients.InvalidEventTargetErr, target.Service)
+	}
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	return nil
+}
+
+func (c *ec2Client) sendMessage(ctx context.Context, target *clients.EventTarget, detailType string, detail []byte) error {
+	queue, err := c.sqs.GetQueueUrl(ctx, &sqs.GetQueueUrlInput{
+		QueueName:              ptr.To(target.Name),
+		QueueOwnerAWSAccountId: ptr.To(target.AccountID),
+	})
+	if err != nil {
+		return fmt.Errorf("cannot get url of queue %s: %w", target.Name, err)
+	}
+
+	_, err = c.sqs.SendMessage(ctx, &sqs.SendMessageInput{
+		QueueUrl:    queue.QueueUrl,
+		MessageBody: ptr.To(string(detail)),
+		MessageAttributes: map[string]sqsTypes.MessageAttributeValue{
+			detailTypeAttribute: {DataType: ptr.To("String"), StringValue: ptr.To(detailType)},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("cannot send message to queue %s: %w", target.Name, err)
+	}
+	return nil
+}
+
+func (c *ec2Client) putEvent(ctx context.Context, target *clients.EventTarget, detailType string, detail []byte) error {
+	output, err := c.events.PutEvents(ctx, &eventbridge.PutEventsInput{
+		Entries: []ebTypes.PutEventsRequestEntry{{
+			EventBusName: ptr.To(target.ARN),
+			Source:       ptr.To(EventSource),
+			DetailType:   ptr.To(detailType),
+			Detail:       ptr.To(string(detail)),
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("cannot put event to bus %s: %w", target.Name, err)
+	}
+	if output.FailedEntryCount > 0 && len(output.Entries) > 0 {
+		entry := output.Entries[0]
+		return fmt.Errorf("%w: bus %s: %s (%s)", http.EventRejectedErr, target.Name,
+			ptr.FromOrEmpty(entry.ErrorMessage), ptr.FromOrEmpty(entry.ErrorCode))
+	}
+	return nil
+}
@@ -0,0 +1,269 @@
+package ec2
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/clients/http"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/ptr"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// capacityErrorCodes are CreateFleet error codes of pools without enough capacity
+var capacityErrorCodes = []string{"InsufficientInstanceCapacity", "InsufficientCapacity", "UnfulfillableCapacity"}
+
+// spotErrorCodes are CreateFleet error codes of pools without spot capacity for the price
+var spotErrorCodes = []string{"SpotMaxPriceTooLow", "MaxSpotInstanceCountExceeded"}
+
+func (c *ec2Client) CreateFleet(ctx context.Context, params *clients.AWSInstanceParams, pools []clients.FleetPool, amount int32, name *string, reservation *models.AWSReservation) ([]*clients.FleetInstance, *string, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "CreateFleet")
+	defer span.End()
+
+	if !c.assumed {
+		return nil, nil, http.ServiceAccountUnsupportedOperationErr
+	}
+	if params.LaunchTemplateID != "" || len(params.NetworkInterfaces) > 0 || params.HostID != "" ||
+		params.CapacityReservationID != "" || params.CapacityReservationGroupARN != "" {
+		return nil, nil, fmt.Errorf("%w: launch templates, network interfaces, dedicated hosts and capacity reservations", http.FleetUnsupportedErr)
+	}
+	logger := logger(ctx)
+	logger.Trace().Msgf("Create AWS EC2 fleet with %d pools", len(pools))
+
+	templateId, err := c.createFleetLaunchTemplate(ctx, params, name, reservation)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, nil, err
+	}
+	defer c.deleteFleetLaunchTemplate(ctx, templateId)
+
+	overrides := make([]types.FleetLaunchTemplateOverridesRequest, len(pools))
+	for i, pool := range pools {
+		overrides[i] = types.FleetLaunchTemplateOverridesRequest{
+			InstanceType: types.InstanceType(pool.InstanceType),
+			Priority:     ptr.To(float64(i)),
+		}
+		if pool.SubnetID != "" {
+			overrides[i].SubnetId = ptr.To(pool.SubnetID)
+		}
+		if params.Spot && params.SpotMaxPrice != "" {
+			overrides[i].MaxPrice = ptr.To(params.SpotMaxPrice)
+		}
+	}
+
+	input := &ec2.CreateFleetInput{
+		Type: types.FleetTypeInstant,
+		LaunchTemplateConfigs: []types.FleetLaunchTemplateConfigRequest{
+			{
+				LaunchTemplateSpecification: &types.FleetLaunchTemplateSpecificationRequest{
+					LaunchTemplateId: templateId,
+					Version:          ptr.To("$Latest"),
+				},
+				Overrides: overrides,
+			},
+		},
+		TargetCapacitySpecification: &types.TargetCapacitySpecificationRequest{
+			TotalTargetCapacity:       ptr.To(amount),
+			DefaultTargetCapacityType: types.DefaultTargetCapacityTypeOnDemand,
+		},
+		OnDemandOptions: &types.OnDemandOptionsRequest{
+			AllocationStrategy: types.FleetOnDemandAllocationStrategyPrioritized,
+		},
+	}
+	if params.Spot {
+		input.TargetCapacitySpecification.DefaultTargetCapacityType = types.DefaultTargetCapacityTypeSpot
+		input.SpotOptions = &types.SpotOptionsRequest{
+			AllocationStrategy:           types.SpotAllocationStrategyCapacityOptimizedPrioritized,
+			InstanceInterruptionBehavior: types.SpotInstanceInterruptionBehaviorTerminate,
+		}
+	}
+
+	resp, err := c.ec2.CreateFleet(ctx, input)
+	if err != nil {
+		if isAWSUnauthorizedError(err) {
+			err = clients.UnauthorizedErr
+		}
+		span.SetStatus(codes.Error, err.Error())
+		return nil, nil, fmt.Errorf("cannot create fleet: %w", err)
+	}
+
+	instances := parseCreateFleetResponse(resp)
+	if len(instances) < int(amount) {
+		err = fleetError(resp.Errors, len(instances), amount)
+		span.SetStatus(codes.Error, err.Error())
+		return instances, resp.FleetId, fmt.Errorf("cannot create fleet: %w", err)
+	}
+
+	return instances, resp.FleetId, nil
+}
+
+// createFleetLaunchTemplate creates a launch template with all parameters of the launch except the
+// instance type and subnet which are set by pools of the fleet.
+func (c *ec2Client) createFleetLaunchTemplate(ctx context.Context, params *clients.AWSInstanceParams, name *string, reservation *models.AWSReservation) (*string, error) {
+	data := &types.RequestLaunchTemplateData{
+		SecurityGroupIds: params.SecurityGroupIDs,
+	}
+	if params.AMI != "" {
+		data.ImageId = ptr.To(params.AMI)
+	}
+	if params.KeyName != "" {
+		data.KeyName = ptr.To(params.KeyName)
+	}
+	if len(params.UserData) > 0 {
+		data.UserData = ptr.To(base64.StdEncoding.EncodeToString(params.UserData))
+	}
+
+	if params.EncryptVolumes || params.RootVolume != nil {
+		mappings, err := c.blockDeviceMappings(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		for _, mapping := range mappings {
+			data.BlockDeviceMappings = append(data.BlockDeviceMappings, types.LaunchTemplateBlockDeviceMappingRequest{
+				DeviceName: mapping.DeviceName,
+				Ebs: &types.LaunchTemplateEbsBlockDeviceRequest{
+					DeleteOnTermination: mapping.Ebs.DeleteOnTermination,
+					Encrypted:           mapping.Ebs.Encrypted,
+					Iops:                mapping.Ebs.Iops,
+					KmsKeyId:            mapping.Ebs.KmsKeyId,
+					Throughput:          mapping.Ebs.Throughput,
+					VolumeSize:          mapping.Ebs.VolumeSize,
+					VolumeType:          mapping.Ebs.VolumeType,
+				},
+			})
+		}
+	}
+
+	if params.Tenancy != "" {
+		data.Placement = &types.LaunchTemplatePlacementRequest{Tenancy: types.Tenancy(params.Tenancy)}
+	}
+	if params.Hibernation {
+		data.HibernationOptions = &types.LaunchTemplateHibernationOptionsRequest{Configured: ptr.To(true)}
+	}
+	if params.NitroEnclaves {
+		data.EnclaveOptions = &types.LaunchTemplateEnclaveOptionsRequest{Enabled: ptr.To(true)}
+	}
+
+	if strings.HasPrefix(params.InstanceProfile, "arn:") {
+		data.IamInstanceProfile = &types.LaunchTemplateIamInstanceProfileSpecificationRequest{Arn: ptr.To(params.InstanceProfile)}
+	} else if params.InstanceProfile != "" {
+		data.IamInstanceProfile = &types.LaunchTemplateIamInstanceProfileSpecificationRequest{Name: ptr.To(params.InstanceProfile)}
+	}
+
+	for _, spec := range tagSpecifications(params, name, reservation) {
+		data.TagSpecifications = append(data.TagSpecifications, types.LaunchTemplateTagSpecificationRequest{
+			ResourceType: spec.ResourceType,
+			Tags:         spec.Tags,
+		})
+	}
+
+	// retried jobs create a new template, the previous one could not be deleted
+	input := &ec2.CreateLaunchTemplateInput{
+		LaunchTemplateName: ptr.To(fmt.Sprintf("provisioning-fleet-%d-%d", reservation.ID, time.Now().Unix())),
+		LaunchTemplateData: data,
+		TagSpecifications: []types.TagSpecification{
+			{
+				ResourceType: types.ResourceTypeLaunchTemplate,
+				Tags: []types.Tag{
+					{
+						Key:   ptr.To(clients.ReservationTagKey),
+						Value: ptr.To(clients.ReservationTagValue(reservation.ID)),
+					},
+				},
+			},
+		},
+	}
+	resp, err := c.ec2.CreateLaunchTemplate(ctx, input)
+	if err != nil {
+		if isAWSUnauthorizedError(err) {
+			err = clients.UnauthorizedErr
+		}
+		return nil, fmt.Errorf("cannot create fleet launch template: %w", err)
+	}
+	return resp.LaunchTemplate.LaunchTemplateId, nil
+}
+
+// deleteFleetLaunchTemplate deletes the temporary launch template, instant fleets do not need it
+// once they are created. Errors are only logged.
+func (c *ec2Client) deleteFleetLaunchTemplate(ctx context.Context, templateId *string) {
+	_, err := c.ec2.DeleteLaunchTemplate(ctx, &ec2.DeleteLaunchTemplateInput{LaunchTemplateId: templateId})
+	if err != nil {
+		logger(ctx).Warn().Err(err).Str("launch_template_id", ptr.FromOrEmpty(templateId)).Msg("Unable to delete fleet launch template")
+	}
+}
+
+func parseCreateFleetResponse(resp *ec2.CreateFleetOutput) []*clients.FleetInstance {
+	var result []*clients.FleetInstance
+	for _, instance := range resp.Instances {
+		allocation := models.FleetAllocation{
+			InstanceType: string(instance.InstanceType),
+			Lifecycle:    string(instance.Lifecycle),
+		}
+		if instance.LaunchTemplateAndOverrides != nil && instance.LaunchTemplateAndOverrides.Overrides != nil {
+			overrides := instance.LaunchTemplateAndOverrides.Overrides
+			allocation.SubnetID = ptr.FromOrEmpty(overrides.SubnetId)
+			allocation.AvailabilityZone = ptr.FromOrEmpty(overrides.AvailabilityZone)
+		}
+		if allocation.Lifecycle == "" {
+			allocation.Lifecycle = string(types.InstanceLifecycleOnDemand)
+		}
+		for _, id := range instance.InstanceIds {
+			result = append(result, &clients.FleetInstance{ID: id, Allocation: allocation})
+		}
+	}
+	return result
+}
+
+// fleetError returns an error of a fleet which did not launch all instances. Capacity errors of all
+// pools are reported as insufficient capacity, spot price errors as spot unavailable and opt-in
+// errors of AWS Marketplace images as unaccepted subscriptions.
+func fleetError(errs []types.CreateFleetError, launched int, amount int32) error {
+	messages := make([]string, 0, len(errs))
+	capacity, spot := len(errs) > 0, len(errs) > 0
+	for _, e := range errs {
+		code := ptr.FromOrEmpty(e.ErrorCode)
+		messages = append(messages, fmt.Sprintf("%s: %s", code, ptr.FromOrEmpty(e.ErrorMessage)))
+		if !containsCode(capacityErrorCodes, code) {
+			capacity = false
+		}
+		if !containsCode(spotErrorCodes, code) {
+			spot = false
+		}
+	}
+
+	var err error
+	switch {
+	case capacity:
+		err = clients.InsufficientCapacityErr
+	case spot:
+		err = clients.SpotUnavailableErr
+	case len(errs) > 0 && ptr.FromOrEmpty(errs[0].ErrorCode) == "UnauthorizedOperation":
+		err = clients.UnauthorizedErr
+	default:
+		err = http.FleetIncompleteErr
+	}
+	err = fmt.Errorf("%w: %d of %d instances launched: %s", err, launched, amount, strings.Join(messages, ", "))
+
+	if len(errs) > 0 && ptr.FromOrEmpty(errs[0].ErrorCode) == "OptInRequired" {
+		if productCode := marketplaceProductCode(ptr.FromOrEmpty(errs[0].ErrorMessage)); productCode != "" {
+			return clients.NewMarketplaceSubscriptionError(productCode, err)
+		}
+	}
+	return err
+}
+
+func containsCode(codes []string, code string) bool {
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package ec2
+
+import (
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/clients/http"
+	"github.com/RHEnVision/provisioning-backend/internal/ptr"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCreateFleetResponse(t *testing.T) {
+	resp := &ec2.CreateFleetOutput{
+		Instances: []types.CreateFleetInstance{
+			{
+				InstanceIds:  []string{"i-1", "i-2"},
+				InstanceType: "t3.small",
+				LaunchTemplateAndOverrides: &types.LaunchTemplateAndOverridesResponse{
+					Overrides: &types.FleetLaunchTemplateOverrides{SubnetId: ptr.To("subnet-1"), AvailabilityZone: ptr.To("us-east-1a")},
+				},
+			},
+			{
+				InstanceIds:  []string{"i-3"},
+				InstanceType: "t3.large",
+				Lifecycle:    types.InstanceLifecycleSpot,
+			},
+		},
+	}
+
+	instances := parseCreateFleetResponse(resp)
+	require.Len(t, instances, 3)
+	assert.Equal(t, "i-2", instances[1].ID)
+	assert.Equal(t, "subnet-1", instances[1].Allocation.SubnetID)
+	assert.Equal(t, "us-east-1a", instances[1].Allocation.AvailabilityZone)
+	assert.Equal(t, "on-demand", instances[1].Allocation.Lifecycle)
+	assert.Equal(t, "t3.large", instances[2].Allocation.InstanceType)
+	assert.Equal(t, "spot", instances[2].Allocation.Lifecycle)
+}
+
+func TestFleetError(t *testing.T) {
+	fleetErr := func(codes ...string) []types.CreateFleetError {
+		result := make([]types.CreateFleetError, len(codes))
+		for i, code := range codes {
+			result[i] = types.CreateFleetError{ErrorCode: ptr.To(code), ErrorMessage: ptr.To("message")}
+		}
+		return result
+	}
+
+	t.Run("capacity", func(t *testing.T) {
+		err := fleetError(fleetErr("InsufficientInstanceCapacity", "UnfulfillableCapacity"), 1, 3)
+		require.ErrorIs(t, err, clients.InsufficientCapacityErr)
+		assert.Contains(t, err.Error(), "1 of 3 instances launched")
+	})
+
+	t.Run("spot", func(t *testing.T) {
+		err := fleetError(fleetErr("SpotMaxPriceTooLow"), 0, 3)
+		require.ErrorIs(t, err, clients.SpotUnavailableErr)
+	})
+
+	t.Run("mixed", func(t *testing.T) {
+		err := fleetError(fleetErr("InsufficientInstanceCapacity", "InvalidParameterValue"), 0, 3)
+		require.ErrorIs(t, err, http.FleetIncompleteErr)
+	})
+
+	t.Run("marketplace", func(t *testing.T) {
+		errs := []types.CreateFleetError{{
+			ErrorCode:    ptr.To("OptInRequired"),
+			ErrorMessage: ptr.To("In order to use this AWS Marketplace product you need to accept terms and subscribe. To do so please visit https://aws.amazon.com/marketplace/pp?sku=cpkwgozd4by0cmoinfftz1qqr"),
+		}}
+		err := fleetError(errs, 0, 3)
+		require.ErrorIs(t, err, clients.MarketplaceSubscriptionErr)
+
+		var subscriptionErr *clients.MarketplaceSubscriptionError
+		require.ErrorAs(t, err, &subscriptionErr)
+		assert.Equal(t, "cpkwgozd4by0cmoinfftz1qqr", subscriptionErr.ProductCode)
+		assert.Equal(t, "https://aws.amazon.com/marketplace/pp?sku=cpkwgozd4by0cmoinfftz1qqr", subscriptionErr.URL)
+	})
+
+	t.Run("region opt-in", func(t *testing.T) {
+		err := fleetError(fleetErr("OptInRequired"), 0, 3)
+		require.ErrorIs(t, err, http.FleetIncompleteErr)
+		require.NotErrorIs(t, err, clients.MarketplaceSubscriptionErr)
+	})
+
+	t.Run("no errors", func(t *testing.T) {
+		err := fleetError(nil, 2, 3)
+		require.ErrorIs(t, err, http.FleetIncompleteErr)
+	})
+}
@@ -9,11 +9,13 @@ import (
 	"strings"
 
 	iamTypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	kmsTypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
 
 	"github.com/RHEnVision/provisioning-backend/internal/clients"
 	"github.com/RHEnVision/provisioning-backend/internal/clients/http"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
 )
 
 var AWSPermissionsMissing = errors.New("AWS permissions missing")
@@ -303,3 +305,105 @@ func (c *ec2Client) CheckPermission(ctx context.Context, auth *clients.Authentic
 
 	return nil, nil
 }
+
+// instanceProfileName returns name of an IAM instance profile name or ARN, the path of the ARN is dropped
+func instanceProfileName(profile string) string {
+	if strings.HasPrefix(profile, "arn:") {
+		return profile[strings.LastIndex(profile, "/")+1:]
+	}
+	return profile
+}
+
+func (c *ec2Client) CheckInstanceProfile(ctx context.Context, auth *clients.Authentication, profile string) ([]string, error) {
+	input := &iam.GetInstanceProfileInput{
+		InstanceProfileName: aws.String(instanceProfileName(profile)),
+	}
+	output, err := c.iam.GetInstanceProfile(ctx, input)
+	if err != nil {
+		if isAWSOperationError(err, "NoSuchEntity") {
+			err = http.InstanceProfileNotFoundErr
+		} else if isAWSOperationError(err, "AccessDenied") {
+			err = clients.UnauthorizedErr
+		}
+		return nil, fmt.Errorf("cannot get instance profile %s: %w", profile, err)
+	}
+
+	roleARNs := make([]string, 0, len(output.InstanceProfile.Roles))
+	for _, role := range output.InstanceProfile.Roles {
+		roleARNs = append(roleARNs, aws.ToString(role.Arn))
+	}
+	if len(roleARNs) == 0 {
+		return nil, nil
+	}
+
+	simulation, err := c.iam.SimulatePrincipalPolicy(ctx, &iam.SimulatePrincipalPolicyInput{
+		PolicySourceArn: aws.String(auth.Payload),
+		ActionNames:     []string{"iam:PassRole"},
+		ResourceArns:    roleARNs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot simulate principal policy: %w", err)
+	}
+
+	var denied []string
+	for _, result := range simulation.EvaluationResults {
+		if result.EvalDecision != iamTypes.PolicyEvaluationDecisionTypeAllowed {
+			denied = append(denied, aws.ToString(result.EvalActionName))
+			break
+		}
+	}
+	return denied, nil
+}
+
+// kmsKeyActions are needed to launch instances with volumes encrypted by a customer managed key
+var kmsKeyActions = []string{
+	"kms:CreateGrant",
+	"kms:Decrypt",
+	"kms:DescribeKey",
+	"kms:GenerateDataKeyWithoutPlaintext",
+	"kms:ReEncryptFrom",
+	"kms:ReEncryptTo",
+}
+
+// describeKMSKey returns ARN of a KMS key ID, alias name or ARN. Unlike the policy simulation,
+// describing the key with the role also takes the key policy into account.
+func (c *ec2Client) describeKMSKey(ctx context.Context, keyId string) (string, error) {
+	output, err := c.kms.DescribeKey(ctx, &kms.DescribeKeyInput{KeyId: aws.String(keyId)})
+	if err != nil {
+		if isAWSOperationError(err, "NotFoundException") {
+			err = http.KMSKeyNotFoundErr
+		} else if isAWSOperationError(err, "AccessDeniedException") {
+			err = http.KMSKeyAccessDeniedErr
+		}
+		return "", fmt.Errorf("cannot describe KMS key %s: %w", keyId, err)
+	}
+	if output.KeyMetadata.KeyState != kmsTypes.KeyStateEnabled {
+		return "", fmt.Errorf("%w: %s is %s", http.KMSKeyDisabledErr, keyId, output.KeyMetadata.KeyState)
+	}
+	return aws.ToString(output.KeyMetadata.Arn), nil
+}
+
+func (c *ec2Client) CheckKMSKeyAccess(ctx context.Context, auth *clients.Authentication, keyId string) ([]string, error) {
+	keyARN, err := c.describeKMSKey(ctx, keyId)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &iam.SimulatePrincipalPolicyInput{
+		PolicySourceArn: aws.String(auth.Payload),
+		ActionNames:     kmsKeyActions,
+		ResourceArns:    []string{keyARN},
+	}
+	output, err := c.iam.SimulatePrincipalPolicy(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("cannot simulate principal policy: %w", err)
+	}
+
+	var denied []string
+	for _, result := range output.EvaluationResults {
+		if result.EvalDecision != iamTypes.PolicyEvaluationDecisionTypeAllowed {
+			denied = append(denied, aws.ToString(result.EvalActionName))
+		}
+	}
+	return denied, nil
+}
@@ -163,6 +163,12 @@ func TestListMissingPermissions(t *testing.T) {
 		require.Error(t, err)
 	})
 
+	t.Run("get instance profile name", func(t *testing.T) {
+		assert.Equal(t, "profile-name", instanceProfileName("profile-name"))
+		assert.Equal(t, "profile-name", instanceProfileName("arn:aws:iam::123456789990:instance-profile/profile-name"))
+		assert.Equal(t, "profile-name", instanceProfileName("arn:aws:iam::123456789990:instance-profile/path/profile-name"))
+	})
+
 	t.Run("list missing permissions", func(t *testing.T) {
 		missingStatements := listMissingPermissions(missingLastPermission, expected)
 		assert.Equal(t, 1, len(missingStatements))
@@ -0,0 +1,127 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/ptr"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/servicequotas"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// ec2ServiceCode is the Service Quotas code of EC2
+const ec2ServiceCode = "ec2"
+
+// standardInstancesQuotaCode is "Running On-Demand Standard (A, C, D, H, I, M, R, T, Z) instances"
+// which is expressed in vCPUs
+const standardInstancesQuotaCode = "L-1216C47A"
+
+// familyQuotaCodes maps instance families which are not counted against the standard quota to
+// their "Running On-Demand ... instances" quota codes, all of them are expressed in vCPUs
+var familyQuotaCodes = map[string]string{
+	"dl":  "L-6E869C2A",
+	"f":   "L-74FC7D96",
+	"g":   "L-DB2E81BA",
+	"hpc": "L-F7808C92",
+	"inf": "L-1945791B",
+	"p":   "L-417A185B",
+	"trn": "L-2C3B7624",
+	"u":   "L-43DA4232",
+	"vt":  "L-DB2E81BA",
+	"x":   "L-7295265B",
+}
+
+// vcpuQuotaCode returns the on-demand vCPU quota code of the instance type family, the standard
+// quota is returned for other and unknown instance types
+func vcpuQuotaCode(instanceType string) string {
+	family := strings.ToLower(instanceType)
+	if i := strings.IndexFunc(family, func(r rune) bool { return !unicode.IsLetter(r) }); i >= 0 {
+		family = family[:i]
+	}
+	if code, ok := familyQuotaCodes[family]; ok {
+		return code
+	}
+	return standardInstancesQuotaCode
+}
+
+func (c *ec2Client) GetVCPUQuota(ctx context.Context, instanceType string) (*clients.Quota, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "GetVCPUQuota")
+	defer span.End()
+
+	logger := logger(ctx)
+	quotaCode := vcpuQuotaCode(instanceType)
+	logger.Trace().Msgf("Fetching AWS vCPU quota %s", quotaCode)
+
+	output, err := c.sq.GetServiceQuota(ctx, &servicequotas.GetServiceQuotaInput{
+		ServiceCode: ptr.To(ec2ServiceCode),
+		QuotaCode:   ptr.To(quotaCode),
+	})
+	if err != nil {
+		if isAWSUnauthorizedError(err) {
+			err = clients.UnauthorizedErr
+		}
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("cannot get vCPU service quota: %w", err)
+	}
+	if output.Quota == nil {
+		span.SetStatus(codes.Error, "empty quota response")
+		return nil, fmt.Errorf("cannot get vCPU service quota: %w", clients.NotFoundErr)
+	}
+
+	usage, err := c.countRunningVCPUs(ctx, quotaCode)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return &clients.Quota{
+		Name:  ptr.FromOrEmpty(output.Quota.QuotaName),
+		Limit: int64(math.Floor(ptr.From(output.Quota.Value))),
+		Usage: usage,
+	}, nil
+}
+
+// countRunningVCPUs sums vCPUs of pending and running instances in the region counted against
+// the quota code. Service Quotas only report usage through CloudWatch, describing the instances
+// is cheaper.
+func (c *ec2Client) countRunningVCPUs(ctx context.Context, quotaCode string) (int64, error) {
+	input := &ec2.DescribeInstancesInput{
+		MaxResults: ptr.ToInt32(1000),
+		Filters: []types.Filter{
+			{
+				Name:   ptr.To("instance-state-name"),
+				Values: []string{"pending", "running"},
+			},
+		},
+	}
+	pag := ec2.NewDescribeInstancesPaginator(c.ec2, input)
+
+	var usage int64
+	for pag.HasMorePages() {
+		resp, err := pag.NextPage(ctx)
+		if err != nil {
+			if isAWSUnauthorizedError(err) {
+				err = clients.UnauthorizedErr
+			}
+			return 0, fmt.Errorf("cannot describe running instances: %w", err)
+		}
+
+		for _, reservation := range resp.Reservations {
+			for _, instance := range reservation.Instances {
+				if instance.CpuOptions == nil || vcpuQuotaCode(string(instance.InstanceType)) != quotaCode {
+					continue
+				}
+				usage += int64(ptr.From(instance.CpuOptions.CoreCount) * ptr.From(instance.CpuOptions.ThreadsPerCore))
+			}
+		}
+	}
+
+	return usage, nil
+}
@@ -0,0 +1,29 @@
+package ec2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVCPUQuotaCode(t *testing.T) {
+	tests := []struct {
+		instanceType string
+		quotaCode    string
+	}{
+		{"t3.small", standardInstancesQuotaCode},
+		{"m6gd.xlarge", standardInstancesQuotaCode},
+		{"g4dn.xlarge", "L-DB2E81BA"},
+		{"vt1.3xlarge", "L-DB2E81BA"},
+		{"p4d.24xlarge", "L-417A185B"},
+		{"inf1.xlarge", "L-1945791B"},
+		{"x2iedn.xlarge", "L-7295265B"},
+		{"u-6tb1.metal", "L-43DA4232"},
+		{"", standardInstancesQuotaCode},
+	}
+	for _, tt := range tests {
+		t.Run(tt.instanceType, func(t *testing.T) {
+			assert.Equal(t, tt.quotaCode, vcpuQuotaCode(tt.instanceType))
+		})
+	}
+}
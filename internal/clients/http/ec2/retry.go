@@ -0,0 +1,33 @@
+package ec2
+
+import (
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+)
+
+// retryBackoff delays retries of AWS SDK calls by the shared jittered exponential backoff and
+// reports every retry in metrics.
+var retryBackoff = retry.BackoffDelayerFunc(func(attempt int, err error) (time.Duration, error) {
+	clients.CountRetry(clients.ProviderAWS, isThrottleError(err))
+	return clients.RetryDelay(attempt), nil
+})
+
+// newRetryer returns the SDK retryer of the configured mode, the adaptive mode also slows down
+// all calls of the client on throttling errors.
+func newRetryer() aws.Retryer {
+	standardOptions := func(o *retry.StandardOptions) {
+		o.MaxAttempts = config.AWS.MaxAttempts
+		o.MaxBackoff = config.Application.Retry.MaxDelay
+		o.Backoff = retryBackoff
+	}
+	if aws.RetryMode(config.AWS.RetryMode) == aws.RetryModeAdaptive {
+		return retry.NewAdaptiveMode(func(o *retry.AdaptiveModeOptions) {
+			o.StandardOptions = append(o.StandardOptions, standardOptions)
+		})
+	}
+	return retry.NewStandard(standardOptions)
+}
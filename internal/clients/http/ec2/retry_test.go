@@ -0,0 +1,35 @@
+package ec2
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRetryer(t *testing.T) {
+	previous := config.AWS.RetryMode
+	config.AWS.RetryMode = "adaptive"
+	config.AWS.MaxAttempts = 7
+	defer func() {
+		config.AWS.RetryMode = previous
+		config.AWS.MaxAttempts = 0
+	}()
+
+	retryer := newRetryer()
+	assert.IsType(t, &retry.AdaptiveMode{}, retryer)
+	assert.Equal(t, 7, retryer.MaxAttempts())
+	assert.True(t, retryer.IsErrorRetryable(apiError(503, "RequestLimitExceeded")))
+	assert.False(t, retryer.IsErrorRetryable(apiError(400, "InvalidAMIID.NotFound")))
+
+	config.AWS.RetryMode = "standard"
+	assert.IsType(t, &retry.Standard{}, newRetryer())
+}
+
+func TestIsThrottleError(t *testing.T) {
+	assert.True(t, isThrottleError(apiError(503, "RequestLimitExceeded")))
+	assert.True(t, isThrottleError(apiError(400, "Throttling")))
+	assert.False(t, isThrottleError(errors.New("connection reset")))
+}
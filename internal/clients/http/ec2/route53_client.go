@@ -0,0 +1,96 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/clients/http"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/ptr"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	r53Types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func (c *ec2Client) CreateDNSRecord(ctx context.Context, zone, name, ipv4 string, ttl int64) (*models.DNSRecord, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "CreateDNSRecord")
+	defer span.End()
+
+	if !c.assumed {
+		return nil, http.ServiceAccountUnsupportedOperationErr
+	}
+
+	output, err := c.r53.GetHostedZone(ctx, &route53.GetHostedZoneInput{Id: ptr.To(zone)})
+	if err != nil {
+		err = route53Error(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("cannot get hosted zone %s: %w", zone, err)
+	}
+
+	record := &models.DNSRecord{
+		Zone: zone,
+		Name: name + "." + strings.TrimSuffix(ptr.FromOrEmpty(output.HostedZone.Name), "."),
+		IPv4: ipv4,
+		TTL:  ttl,
+	}
+	err = c.changeDNSRecord(ctx, r53Types.ChangeActionUpsert, record)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	return record, nil
+}
+
+func (c *ec2Client) DeleteDNSRecord(ctx context.Context, record *models.DNSRecord) error {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "DeleteDNSRecord")
+	defer span.End()
+
+	if !c.assumed {
+		return http.ServiceAccountUnsupportedOperationErr
+	}
+
+	err := c.changeDNSRecord(ctx, r53Types.ChangeActionDelete, record)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	return nil
+}
+
+// changeDNSRecord applies the change without waiting for propagation, deletion requires the
+// exact TTL and address of the record.
+func (c *ec2Client) changeDNSRecord(ctx context.Context, action r53Types.ChangeAction, record *models.DNSRecord) error {
+	input := &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: ptr.To(record.Zone),
+		ChangeBatch: &r53Types.ChangeBatch{
+			Changes: []r53Types.Change{
+				{
+					Action: action,
+					ResourceRecordSet: &r53Types.ResourceRecordSet{
+						Name:            ptr.To(record.Name),
+						Type:            r53Types.RRTypeA,
+						TTL:             ptr.To(record.TTL),
+						ResourceRecords: []r53Types.ResourceRecord{{Value: ptr.To(record.IPv4)}},
+					},
+				},
+			},
+		},
+	}
+	_, err := c.r53.ChangeResourceRecordSets(ctx, input)
+	if err != nil {
+		return fmt.Errorf("cannot %s record %s: %w", strings.ToLower(string(action)), record.Name, route53Error(err))
+	}
+	return nil
+}
+
+func route53Error(err error) error {
+	if isAWSOperationError(err, "AccessDenied") {
+		return clients.UnauthorizedErr
+	} else if isAWSOperationError(err, "NoSuchHostedZone") {
+		return clients.DNSZoneNotFoundErr
+	}
+	return err
+}
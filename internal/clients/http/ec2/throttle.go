@@ -0,0 +1,111 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	awsMiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// tokenBucket limits rate of operations, up to burst operations are allowed at once and
+// tokens are refilled at the rate per second.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token and returns how long the caller must wait before it can proceed.
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// Wait blocks until a token is available or the context is done.
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	delay := b.reserve()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("describe throttling: %w", ctx.Err())
+	}
+}
+
+var (
+	// buckets are shared by all clients of the same AWS account and region, EC2 API rate
+	// limits are also applied per account and region
+	buckets      = make(map[string]*tokenBucket)
+	bucketsMutex sync.Mutex
+)
+
+// describeBucket returns token bucket for the account (role ARN or empty for the service
+// account) and region, or nil when throttling is disabled.
+func describeBucket(account, region string) *tokenBucket {
+	if config.AWS.DescribeRate <= 0 {
+		return nil
+	}
+
+	bucketsMutex.Lock()
+	defer bucketsMutex.Unlock()
+
+	key := account + "/" + region
+	bucket, ok := buckets[key]
+	if !ok {
+		bucket = newTokenBucket(config.AWS.DescribeRate, config.AWS.DescribeBurst)
+		buckets[key] = bucket
+	}
+	return bucket
+}
+
+// throttleDescribe returns EC2 client option which waits for the token bucket before every
+// Describe* operation, pages of paginated operations are counted separately. SDK retries
+// are not counted, the adaptive retry mode slows them down on throttling errors.
+func throttleDescribe(bucket *tokenBucket) func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		fn := middleware.InitializeMiddlewareFunc("DescribeThrottle", func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+			if strings.HasPrefix(awsMiddleware.GetOperationName(ctx), "Describe") {
+				if err := bucket.Wait(ctx); err != nil {
+					return middleware.InitializeOutput{}, middleware.Metadata{}, err
+				}
+			}
+			return next.HandleInitialize(ctx, in)
+		})
+		return stack.Initialize.Add(fn, middleware.After)
+	}
+}
@@ -0,0 +1,31 @@
+package ec2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenBucket(t *testing.T) {
+	bucket := newTokenBucket(10, 2)
+
+	t.Run("burst", func(t *testing.T) {
+		assert.Zero(t, bucket.reserve())
+		assert.Zero(t, bucket.reserve())
+	})
+
+	t.Run("over burst", func(t *testing.T) {
+		delay := bucket.reserve()
+		assert.Greater(t, delay, time.Duration(0))
+		assert.LessOrEqual(t, delay, 100*time.Millisecond)
+	})
+
+	t.Run("canceled wait", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		require.ErrorIs(t, bucket.Wait(ctx), context.Canceled)
+	})
+}
@@ -8,4 +8,16 @@ var (
 	ServiceAccountUnsupportedOperationErr = errors.New("unsupported operation on service account")
 	ARNParsingError                       = errors.New("ARN parsing error")
 	NoReservationErr                      = errors.New("no reservation has found in AWS response")
+	ImageNotFoundErr                      = errors.New("image not found in AWS account")
+	SubnetNotFoundErr                     = errors.New("subnet not found in AWS account")
+	InstanceProfileNotFoundErr            = errors.New("instance profile not found in AWS account")
+	KMSKeyNotFoundErr                     = errors.New("KMS key not found in AWS account")
+	KMSKeyAccessDeniedErr                 = errors.New("KMS key cannot be described by the role")
+	KMSKeyDisabledErr                     = errors.New("KMS key is not enabled")
+	LaunchTemplateNotFoundErr             = errors.New("launch template not found in AWS account")
+	PartitionNotConfiguredErr             = errors.New("AWS partition of the source is not supported")
+	RegionPartitionMismatchErr            = errors.New("region is not in the AWS partition of the source")
+	FleetUnsupportedErr                   = errors.New("launch is not supported by EC2 Fleet")
+	FleetIncompleteErr                    = errors.New("EC2 Fleet did not launch all instances")
+	EventRejectedErr                      = errors.New("event rejected by EventBridge")
 )
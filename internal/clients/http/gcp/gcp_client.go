@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/RHEnVision/provisioning-backend/internal/logging"
 	"github.com/RHEnVision/provisioning-backend/internal/models"
@@ -17,6 +19,7 @@ import (
 	"github.com/RHEnVision/provisioning-backend/internal/ptr"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/codes"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 )
@@ -41,6 +44,10 @@ func newGCPClient(ctx context.Context, auth *clients.Authentication) (clients.GC
 		option.WithQuotaProject(auth.Payload),
 		option.WithRequestReason(logging.TraceId(ctx)),
 	}
+	options, err := retryOptions(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
 	return &gcpClient{
 		auth:    auth,
 		options: options,
@@ -77,7 +84,7 @@ func (c *gcpClient) ListAllRegions(ctx context.Context) ([]clients.Region, error
 		}
 		if err != nil {
 			span.SetStatus(codes.Error, err.Error())
-			return nil, fmt.Errorf("iterator error: %w", err)
+			return nil, fmt.Errorf("iterator error: %w", providerError("Regions.List", err))
 		}
 		regions = append(regions, clients.Region(*region.Name))
 	}
@@ -127,7 +134,7 @@ func (c *gcpClient) ListLaunchTemplates(ctx context.Context) ([]*clients.LaunchT
 		} else if err != nil {
 			logger.Error().Err(err).Msg("An error occurred during listing launch templates")
 			span.SetStatus(codes.Error, err.Error())
-			return nil, fmt.Errorf("cannot list launch templates: %w", err)
+			return nil, fmt.Errorf("cannot list launch templates: %w", providerError("InstanceTemplates.AggregatedList", err))
 		} else {
 			instancesTemplates := pair.Value.InstanceTemplates
 			for _, template := range instancesTemplates {
@@ -140,6 +147,36 @@ func (c *gcpClient) ListLaunchTemplates(ctx context.Context) ([]*clients.LaunchT
 	return templatesList, nil
 }
 
+// networkInterface returns the interface in the default network, an ephemeral public IPv4 address
+// is assigned unless disabled. Dual-stack interfaces get an external IPv6 address when public.
+func networkInterface(addressing *models.Addressing) *computepb.NetworkInterface {
+	nic := &computepb.NetworkInterface{
+		Name: ptr.To("global/networks/default"),
+	}
+	public := addressing == nil || addressing.PublicIPv4 == nil || *addressing.PublicIPv4
+	if public {
+		nic.AccessConfigs = []*computepb.AccessConfig{
+			{
+				Name: ptr.To("External NAT"),
+				Type: ptr.To("ONE_TO_ONE_NAT"),
+			},
+		}
+	}
+	if addressing != nil && addressing.IPv6 {
+		nic.StackType = ptr.To(computepb.NetworkInterface_IPV4_IPV6.String())
+		if public {
+			nic.Ipv6AccessConfigs = []*computepb.AccessConfig{
+				{
+					Name:        ptr.To("External IPv6"),
+					Type:        ptr.To(computepb.AccessConfig_DIRECT_IPV6.String()),
+					NetworkTier: ptr.To(computepb.AccessConfig_PREMIUM.String()),
+				},
+			}
+		}
+	}
+	return nic
+}
+
 func (c *gcpClient) InsertInstances(ctx context.Context, params *clients.GCPInstanceParams, amount int64) ([]*string, *string, error) {
 	ctx, span := otel.Tracer(TraceName).Start(ctx, "InsertInstances")
 	defer span.End()
@@ -176,6 +213,12 @@ func (c *gcpClient) InsertInstances(ctx context.Context, params *clients.GCPInst
 			Value: ptr.To(params.StartupScript),
 		})
 	}
+	if params.UserData != "" {
+		metadata = append(metadata, &computepb.Items{
+			Key:   ptr.To("user-data"),
+			Value: ptr.To(params.UserData),
+		})
+	}
 
 	req := &computepb.BulkInsertInstanceRequest{
 		Project: c.auth.Payload,
@@ -186,8 +229,8 @@ func (c *gcpClient) InsertInstances(ctx context.Context, params *clients.GCPInst
 			MinCount:    &amount,
 			InstanceProperties: &computepb.InstanceProperties{
 				Labels: map[string]string{
-					"rh-rid":  config.EnvironmentPrefix("r", strconv.FormatInt(params.ReservationID, 10)),
-					"rh-uuid": params.UUID,
+					clients.ReservationTagKey: clients.ReservationTagValue(params.ReservationID),
+					"rh-uuid":                 params.UUID,
 				},
 				Disks: []*computepb.AttachedDisk{
 					{
@@ -199,18 +242,8 @@ func (c *gcpClient) InsertInstances(ctx context.Context, params *clients.GCPInst
 						Type:       ptr.To(computepb.AttachedDisk_PERSISTENT.String()),
 					},
 				},
-				MachineType: ptr.To(params.MachineType),
-				NetworkInterfaces: []*computepb.NetworkInterface{
-					{
-						AccessConfigs: []*computepb.AccessConfig{
-							{
-								Name: ptr.To("External NAT"),
-								Type: ptr.To("ONE_TO_ONE_NAT"),
-							},
-						},
-						Name: ptr.To("global/networks/default"),
-					},
-				},
+				MachineType:       ptr.To(params.MachineType),
+				NetworkInterfaces: []*computepb.NetworkInterface{networkInterface(params.Addressing)},
 				Metadata: &computepb.Metadata{
 					Items: metadata,
 				},
@@ -218,6 +251,23 @@ func (c *gcpClient) InsertInstances(ctx context.Context, params *clients.GCPInst
 		},
 	}
 
+	if params.ServiceAccountEmail != "" {
+		req.BulkInsertInstanceResourceResource.InstanceProperties.ServiceAccounts = []*computepb.ServiceAccount{
+			{
+				Email:  ptr.To(params.ServiceAccountEmail),
+				Scopes: params.Scopes,
+			},
+		}
+	}
+
+	if params.ShieldedSecureBoot || params.ShieldedVTPM || params.ShieldedIntegrityMonitoring {
+		req.BulkInsertInstanceResourceResource.InstanceProperties.ShieldedInstanceConfig = &computepb.ShieldedInstanceConfig{
+			EnableSecureBoot:          ptr.To(params.ShieldedSecureBoot),
+			EnableVtpm:                ptr.To(params.ShieldedVTPM),
+			EnableIntegrityMonitoring: ptr.To(params.ShieldedIntegrityMonitoring),
+		}
+	}
+
 	if params.LaunchTemplateID != "" {
 		template := fmt.Sprintf("global/instanceTemplates/%s", params.LaunchTemplateID)
 		req.BulkInsertInstanceResourceResource.SourceInstanceTemplate = &template
@@ -227,12 +277,12 @@ func (c *gcpClient) InsertInstances(ctx context.Context, params *clients.GCPInst
 	if err != nil {
 		span.SetStatus(codes.Error, err.Error())
 		logger.Error().Err(err).Msg("Bulk insert operation failed")
-		return nil, nil, fmt.Errorf("cannot bulk insert instances: %w", err)
+		return nil, nil, fmt.Errorf("cannot bulk insert instances: %w", providerError("Instances.BulkInsert", err))
 	}
 	if err = op.Wait(ctx); err != nil {
 		logger.Error().Err(err).Msg("Bulk wait operation failed")
 		span.SetStatus(codes.Error, err.Error())
-		return nil, nil, fmt.Errorf("cannot bulk insert instances: %w", err)
+		return nil, nil, fmt.Errorf("cannot bulk insert instances: %w", providerError("Instances.BulkInsert", err))
 	}
 
 	if !op.Done() {
@@ -274,7 +324,7 @@ func (c *gcpClient) ListInstancesIDsByLabel(ctx context.Context, uuid string) ([
 		} else if err != nil {
 			logger.Error().Err(err).Msg("An error occurred during fetching instance ids")
 			span.SetStatus(codes.Error, err.Error())
-			return nil, fmt.Errorf("cannot fetch instance ids: %w", err)
+			return nil, fmt.Errorf("cannot fetch instance ids: %w", providerError("Instances.AggregatedList", err))
 		} else {
 			instances := pair.Value.Instances
 			for _, insta := range instances {
@@ -303,15 +353,225 @@ func (c *gcpClient) GetInstanceDescriptionByID(ctx context.Context, id, zone str
 
 	instance, err := client.Get(ctx, &computepb.GetInstanceRequest{Instance: id, Project: projectId, Zone: zone})
 	if err != nil {
-		return nil, fmt.Errorf("unable to get instance: %w", err)
+		return nil, fmt.Errorf("unable to get instance: %w", providerError("Instances.Get", err))
 	}
 	instanceId := strconv.FormatUint(instance.GetId(), 10)
 	instanceDesc := clients.InstanceDescription{ID: instanceId}
 	for _, n := range instance.NetworkInterfaces {
 		if len(n.AccessConfigs) > 0 && n.AccessConfigs[0] != nil {
-			instanceDesc.PublicIPv4 = *n.AccessConfigs[0].NatIP
+			instanceDesc.PublicIPv4 = n.AccessConfigs[0].GetNatIP()
 			break
 		}
 	}
+	if len(instance.NetworkInterfaces) > 0 {
+		instanceDesc.PrivateIPv4 = instance.NetworkInterfaces[0].GetNetworkIP()
+	}
+	// addresses are assigned before the instance is running
+	status := instance.GetStatus()
+	instanceDesc.Pending = status == computepb.Instance_PROVISIONING.String() || status == computepb.Instance_STAGING.String()
 	return &instanceDesc, nil
 }
+
+func (c *gcpClient) StopInstance(ctx context.Context, id, zone string) error {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "StopInstance")
+	defer span.End()
+
+	logger := logger(ctx)
+
+	client, err := c.newInstancesClient(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg("Could not get instances client")
+		return fmt.Errorf("unable to get instances client: %w", err)
+	}
+	defer client.Close()
+
+	op, err := client.Stop(ctx, &computepb.StopInstanceRequest{Instance: id, Project: c.auth.Payload, Zone: zone})
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("cannot stop instance: %w", providerError("Instances.Stop", err))
+	}
+	if err = op.Wait(ctx); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("cannot stop instance: %w", providerError("Instances.Stop", err))
+	}
+	return nil
+}
+
+func (c *gcpClient) StartInstance(ctx context.Context, id, zone string) error {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "StartInstance")
+	defer span.End()
+
+	logger := logger(ctx)
+
+	client, err := c.newInstancesClient(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg("Could not get instances client")
+		return fmt.Errorf("unable to get instances client: %w", err)
+	}
+	defer client.Close()
+
+	op, err := client.Start(ctx, &computepb.StartInstanceRequest{Instance: id, Project: c.auth.Payload, Zone: zone})
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("cannot start instance: %w", providerError("Instances.Start", err))
+	}
+	if err = op.Wait(ctx); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("cannot start instance: %w", providerError("Instances.Start", err))
+	}
+	return nil
+}
+
+func (c *gcpClient) GetConsoleOutput(ctx context.Context, id, zone string) (*clients.ConsoleOutput, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "GetConsoleOutput")
+	defer span.End()
+
+	logger := logger(ctx)
+
+	client, err := c.newInstancesClient(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg("Could not get instances client")
+		return nil, fmt.Errorf("unable to get instances client: %w", err)
+	}
+	defer client.Close()
+
+	output, err := client.GetSerialPortOutput(ctx, &computepb.GetSerialPortOutputInstanceRequest{
+		Instance: id,
+		Project:  c.auth.Payload,
+		Zone:     zone,
+	})
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("cannot get serial port output: %w", providerError("Instances.GetSerialPortOutput", err))
+	}
+	result := &clients.ConsoleOutput{
+		Output: clients.TrimConsoleOutput(output.GetContents()),
+	}
+
+	// screenshots require the display device, the output is returned without it
+	screenshot, err := client.GetScreenshot(ctx, &computepb.GetScreenshotInstanceRequest{
+		Instance: id,
+		Project:  c.auth.Payload,
+		Zone:     zone,
+	})
+	if err != nil {
+		logger.Warn().Err(err).Str("instance_id", id).Msg("Unable to get console screenshot")
+	} else {
+		result.Screenshot = screenshot.GetContents()
+	}
+	return result, nil
+}
+
+func (c *gcpClient) GetPowerState(ctx context.Context, id, zone string) (models.PowerState, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "GetPowerState")
+	defer span.End()
+
+	logger := logger(ctx)
+
+	client, err := c.newInstancesClient(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg("Could not get instances client")
+		return "", fmt.Errorf("unable to get instances client: %w", err)
+	}
+	defer client.Close()
+
+	instance, err := client.Get(ctx, &computepb.GetInstanceRequest{Instance: id, Project: c.auth.Payload, Zone: zone})
+	if err != nil {
+		var apiErr *googleapi.Error
+		if errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound {
+			return models.PowerStateTerminated, nil
+		}
+		span.SetStatus(codes.Error, err.Error())
+		return "", fmt.Errorf("unable to get instance: %w", providerError("Instances.Get", err))
+	}
+
+	// stopped instances have TERMINATED status in GCP
+	switch computepb.Instance_Status(computepb.Instance_Status_value[instance.GetStatus()]) {
+	case computepb.Instance_PROVISIONING, computepb.Instance_STAGING:
+		return models.PowerStateStarting, nil
+	case computepb.Instance_RUNNING, computepb.Instance_REPAIRING:
+		return models.PowerStateRunning, nil
+	case computepb.Instance_STOPPING, computepb.Instance_SUSPENDING:
+		return models.PowerStateStopping, nil
+	case computepb.Instance_TERMINATED, computepb.Instance_SUSPENDED:
+		return models.PowerStateStopped, nil
+	case computepb.Instance_UNDEFINED_STATUS, computepb.Instance_STOPPED:
+	}
+	return models.PowerStateUnknown, nil
+}
+
+func (c *gcpClient) ListTaggedInstances(ctx context.Context) ([]*clients.TaggedInstance, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "ListTaggedInstances")
+	defer span.End()
+
+	logger := logger(ctx)
+	filter := fmt.Sprintf("labels.%s:*", clients.ReservationTagKey)
+	lstReq := &computepb.AggregatedListInstancesRequest{
+		Project: c.auth.Payload,
+		Filter:  &filter,
+	}
+
+	client, err := c.newInstancesClient(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg("Could not get instances client")
+		return nil, fmt.Errorf("unable to get instances client: %w", err)
+	}
+	defer client.Close()
+
+	var result []*clients.TaggedInstance
+	instances := client.AggregatedList(ctx, lstReq)
+	for {
+		pair, err := instances.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		} else if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return nil, fmt.Errorf("cannot list tagged instances: %w", providerError("Instances.AggregatedList", err))
+		}
+		for _, instance := range pair.Value.Instances {
+			if id, ok := clients.ReservationIDFromTag(instance.GetLabels()[clients.ReservationTagKey]); ok {
+				// zone is a URL, e.g. https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a
+				zone := instance.GetZone()
+				result = append(result, &clients.TaggedInstance{
+					ID:            strconv.FormatUint(instance.GetId(), 10),
+					ReservationID: id,
+					Location:      zone[strings.LastIndex(zone, "/")+1:],
+				})
+			}
+		}
+	}
+	return result, nil
+}
+
+func (c *gcpClient) SetMachineType(ctx context.Context, id, zone, machineType string) error {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "SetMachineType")
+	defer span.End()
+
+	logger := logger(ctx)
+
+	client, err := c.newInstancesClient(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg("Could not get instances client")
+		return fmt.Errorf("unable to get instances client: %w", err)
+	}
+	defer client.Close()
+
+	req := &computepb.SetMachineTypeInstanceRequest{
+		Instance: id,
+		Project:  c.auth.Payload,
+		Zone:     zone,
+		InstancesSetMachineTypeRequestResource: &computepb.InstancesSetMachineTypeRequest{
+			MachineType: ptr.To(fmt.Sprintf("zones/%s/machineTypes/%s", zone, machineType)),
+		},
+	}
+	op, err := client.SetMachineType(ctx, req)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("cannot set machine type: %w", providerError("Instances.SetMachineType", err))
+	}
+	if err = op.Wait(ctx); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("cannot set machine type: %w", providerError("Instances.SetMachineType", err))
+	}
+	return nil
+}
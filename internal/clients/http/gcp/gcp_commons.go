@@ -2,10 +2,31 @@ package gcp
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	httpClients "github.com/RHEnVision/provisioning-backend/internal/clients/http"
 	"github.com/rs/zerolog"
+	"google.golang.org/api/option"
+	htransport "google.golang.org/api/transport/http"
 )
 
+// cloudPlatformScope covers both Compute and DNS APIs
+const cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
 func logger(ctx context.Context) zerolog.Logger {
 	return zerolog.Ctx(ctx).With().Str("client", "gcp").Logger()
 }
+
+// retryOptions returns client options with an authenticated HTTP client which retries throttled
+// requests. Credentials and other options are applied by the transport, SDK clients ignore them
+// when a HTTP client is given.
+func retryOptions(ctx context.Context, options ...option.ClientOption) ([]option.ClientOption, error) {
+	options = append(options, option.WithScopes(cloudPlatformScope))
+	transport, err := htransport.NewTransport(ctx, httpClients.NewRetryTransport(clients.ProviderGCP, http.DefaultTransport), options...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to init GCP transport: %w", err)
+	}
+	return []option.ClientOption{option.WithHTTPClient(&http.Client{Transport: transport})}, nil
+}
@@ -0,0 +1,90 @@
+package gcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"google.golang.org/api/dns/v1"
+	"google.golang.org/api/googleapi"
+)
+
+func (c *gcpClient) newDNSService(ctx context.Context) (*dns.Service, error) {
+	service, err := dns.NewService(ctx, c.options...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create GCP DNS client: %w", err)
+	}
+	return service, nil
+}
+
+func (c *gcpClient) CreateDNSRecord(ctx context.Context, zone, name, ipv4 string, ttl int64) (*models.DNSRecord, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "CreateDNSRecord")
+	defer span.End()
+
+	service, err := c.newDNSService(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	managedZone, err := service.ManagedZones.Get(c.auth.Payload, zone).Context(ctx).Do()
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("cannot get managed zone %s: %w", zone, dnsError("ManagedZones.Get", err))
+	}
+
+	record := &models.DNSRecord{
+		Zone: zone,
+		Name: name + "." + strings.TrimSuffix(managedZone.DnsName, "."),
+		IPv4: ipv4,
+		TTL:  ttl,
+	}
+	rrset := &dns.ResourceRecordSet{
+		Name:    record.Name + ".",
+		Type:    "A",
+		Ttl:     ttl,
+		Rrdatas: []string{ipv4},
+	}
+	_, err = service.ResourceRecordSets.Create(c.auth.Payload, zone, rrset).Context(ctx).Do()
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) && apiErr.Code == http.StatusConflict {
+		// replace the existing record like AWS and Azure do
+		_, err = service.ResourceRecordSets.Patch(c.auth.Payload, zone, rrset.Name, rrset.Type, rrset).Context(ctx).Do()
+	}
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("cannot create DNS record %s: %w", record.Name, dnsError("ResourceRecordSets.Create", err))
+	}
+	return record, nil
+}
+
+func (c *gcpClient) DeleteDNSRecord(ctx context.Context, record *models.DNSRecord) error {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "DeleteDNSRecord")
+	defer span.End()
+
+	service, err := c.newDNSService(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = service.ResourceRecordSets.Delete(c.auth.Payload, record.Zone, record.Name+".", "A").Context(ctx).Do()
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("cannot delete DNS record %s: %w", record.Name, dnsError("ResourceRecordSets.Delete", err))
+	}
+	return nil
+}
+
+// dnsError wraps an error of a DNS operation, zones and records not found are DNSZoneNotFoundErr.
+func dnsError(operation string, err error) error {
+	pe := providerError(operation, err)
+	if pe.StatusCode == http.StatusNotFound {
+		pe.Err = clients.DNSZoneNotFoundErr
+	}
+	return pe
+}
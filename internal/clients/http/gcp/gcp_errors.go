@@ -1,5 +1,31 @@
 package gcp
 
-import "errors"
+import (
+	"errors"
+	"net/http"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"google.golang.org/api/googleapi"
+)
 
 var ErrOperationFailed = errors.New("operation has failed to finish within expected time")
+
+// providerError wraps an error of a Compute or DNS API operation into clients.ProviderError.
+func providerError(operation string, err error) *clients.ProviderError {
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		return clients.NewProviderError(clients.ProviderGCP, operation, 0, "", err)
+	}
+
+	reason := ""
+	if len(apiErr.Errors) > 0 {
+		reason = apiErr.Errors[0].Reason
+	}
+	pe := clients.NewProviderError(clients.ProviderGCP, operation, apiErr.Code, reason, err)
+	// rate limits of Google APIs are also reported as forbidden
+	if apiErr.Code == http.StatusForbidden && (reason == "rateLimitExceeded" || reason == "userRateLimitExceeded") {
+		pe.Throttled = true
+		pe.Retryable = true
+	}
+	return pe
+}
@@ -0,0 +1,105 @@
+package gcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/ptr"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/iterator"
+)
+
+// deprecatedImagesFilter skips images deprecated, obsoleted or deleted by the publisher
+const deprecatedImagesFilter = "deprecated.state != DEPRECATED AND deprecated.state != OBSOLETE AND deprecated.state != DELETED"
+
+func (c *gcpClient) ListImages(ctx context.Context, projects []string) ([]*clients.Image, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "ListImages")
+	defer span.End()
+
+	logger := logger(ctx)
+	logger.Trace().Msgf("Listing images of projects %v", projects)
+
+	client, err := compute.NewImagesRESTClient(ctx, c.options...)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("unable to create GCP images client: %w", err)
+	}
+	defer client.Close()
+
+	var images []*clients.Image
+	for _, project := range projects {
+		req := &computepb.ListImagesRequest{
+			Project: project,
+			Filter:  ptr.To(deprecatedImagesFilter),
+		}
+		iter := client.List(ctx, req)
+		for {
+			image, err := iter.Next()
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			if err != nil {
+				span.SetStatus(codes.Error, err.Error())
+				return nil, fmt.Errorf("cannot list images of project %s: %w", project, providerError("Images.List", err))
+			}
+			images = append(images, newImage(ctx, project, image))
+		}
+	}
+
+	return images, nil
+}
+
+func (c *gcpClient) GetImageFromFamily(ctx context.Context, project, family string) (*clients.Image, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "GetImageFromFamily")
+	defer span.End()
+
+	logger := logger(ctx)
+	logger.Trace().Msgf("Fetching image of family %s in project %s", family, project)
+
+	client, err := compute.NewImagesRESTClient(ctx, c.options...)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("unable to create GCP images client: %w", err)
+	}
+	defer client.Close()
+
+	req := &computepb.GetFromFamilyImageRequest{
+		Project: project,
+		Family:  family,
+	}
+	image, err := client.GetFromFamily(ctx, req)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		var apiErr *googleapi.Error
+		if errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound {
+			return nil, fmt.Errorf("image family %s in project %s: %w", family, project, clients.NotFoundErr)
+		}
+		return nil, fmt.Errorf("cannot get image of family %s in project %s: %w", family, project, providerError("Images.GetFromFamily", err))
+	}
+
+	return newImage(ctx, project, image), nil
+}
+
+func newImage(ctx context.Context, project string, image *computepb.Image) *clients.Image {
+	result := &clients.Image{
+		Name:     image.GetName(),
+		Family:   image.GetFamily(),
+		Project:  project,
+		SelfLink: image.GetSelfLink(),
+	}
+	// architecture is optional for images, it is reported as X86_64 or ARM64
+	if image.Architecture != nil {
+		if arch, err := clients.MapArchitectures(ctx, strings.ToLower(image.GetArchitecture())); err == nil {
+			result.Architecture = arch
+		}
+	}
+	return result
+}
@@ -0,0 +1,81 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// cpusQuotaMetric is the regional vCPU quota metric for the default machine family
+const cpusQuotaMetric = "CPUS"
+
+// seriesQuotaMetrics maps machine series with a dedicated regional vCPU quota to its metric,
+// other series (e.g. N1 or E2) are counted against the default metric
+var seriesQuotaMetrics = map[string]string{
+	"a2":  "A2_CPUS",
+	"c2":  "C2_CPUS",
+	"c2d": "C2D_CPUS",
+	"c3":  "C3_CPUS",
+	"m1":  "M1_CPUS",
+	"m2":  "M2_CPUS",
+	"m3":  "M3_CPUS",
+	"n2":  "N2_CPUS",
+	"n2d": "N2D_CPUS",
+	"t2d": "T2D_CPUS",
+}
+
+// vcpuQuotaMetric returns the regional vCPU quota metric of the machine type series
+func vcpuQuotaMetric(machineType string) string {
+	series, _, _ := strings.Cut(strings.ToLower(machineType), "-")
+	if metric, ok := seriesQuotaMetrics[series]; ok {
+		return metric
+	}
+	return cpusQuotaMetric
+}
+
+func (c *gcpClient) GetVCPUQuota(ctx context.Context, region, machineType string) (*clients.Quota, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "GetVCPUQuota")
+	defer span.End()
+
+	logger := logger(ctx)
+	metric := vcpuQuotaMetric(machineType)
+	logger.Trace().Msgf("Fetching GCP vCPU quota %s in %s", metric, region)
+
+	client, err := compute.NewRegionsRESTClient(ctx, c.options...)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("unable to create GCP regions client: %w", err)
+	}
+	defer client.Close()
+
+	req := &computepb.GetRegionRequest{
+		Project: c.auth.Payload,
+		Region:  region,
+	}
+	result, err := client.Get(ctx, req)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("cannot get region %s: %w", region, providerError("Regions.Get", err))
+	}
+
+	for _, quota := range result.Quotas {
+		if quota.GetMetric() != metric {
+			continue
+		}
+		return &clients.Quota{
+			Name:  quota.GetMetric(),
+			Limit: int64(math.Floor(quota.GetLimit())),
+			Usage: int64(math.Ceil(quota.GetUsage())),
+		}, nil
+	}
+
+	span.SetStatus(codes.Error, metric+" quota not found")
+	return nil, fmt.Errorf("vCPU quota %s not found in %s: %w", metric, region, clients.NotFoundErr)
+}
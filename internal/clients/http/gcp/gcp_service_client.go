@@ -28,6 +28,10 @@ func newServiceGCPClient(ctx context.Context) (clients.ServiceGCP, error) {
 		option.WithCredentialsJSON([]byte(config.GCP.JSON)),
 		option.WithRequestReason(logging.TraceId(ctx)),
 	}
+	options, err := retryOptions(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
 	return &gcpServiceClient{
 		options: options,
 	}, nil
@@ -82,7 +86,7 @@ func (c *gcpServiceClient) ListAllRegionsAndZones(ctx context.Context) ([]client
 			break
 		}
 		if err != nil {
-			return nil, nil, fmt.Errorf("iterator error: %w", err)
+			return nil, nil, fmt.Errorf("iterator error: %w", providerError("Regions.List", err))
 		}
 		regions = append(regions, clients.Region(*region.Name))
 		for _, zone := range region.Zones {
@@ -124,7 +128,7 @@ func (c *gcpServiceClient) ListMachineTypes(ctx context.Context, zone string) ([
 			break
 		}
 		if err != nil {
-			return nil, fmt.Errorf("iterator error: %w", err)
+			return nil, fmt.Errorf("iterator error: %w", providerError("MachineTypes.List", err))
 		}
 		arch := clients.ArchitectureTypeX86_64
 		if getMachineFamily(*machineType.Name) == "t2a" {
@@ -30,8 +30,14 @@ func logger(ctx context.Context) zerolog.Logger {
 }
 
 func newImageBuilderClient(ctx context.Context) (clients.ImageBuilder, error) {
-	c, err := NewClientWithResponses(config.ImageBuilder.URL, func(c *Client) error {
-		c.Client = http.NewPlatformClient(ctx, config.ImageBuilder.Proxy.URL)
+	return NewImageBuilderClientWithDoer(ctx, config.ImageBuilder.URL, http.NewPlatformClient(ctx, config.ImageBuilder.Proxy.URL))
+}
+
+// NewImageBuilderClientWithDoer allows customization of the URL and the HTTP client (e.g. recording
+// transport). It is meant for testing only, for production please use clients.GetImageBuilderClient.
+func NewImageBuilderClientWithDoer(_ context.Context, url string, doer http.HttpRequestDoer) (clients.ImageBuilder, error) {
+	c, err := NewClientWithResponses(url, func(c *Client) error {
+		c.Client = doer
 		return nil
 	})
 	if err != nil {
@@ -52,7 +58,7 @@ func (c *ibClient) Ready(ctx context.Context) error {
 	}
 	defer resp.Body.Close()
 
-	err = http.HandleHTTPResponses(ctx, resp.StatusCode)
+	err = http.HandleHTTPResponses(ctx, clients.ProviderImageBuilder, "Ready", resp.StatusCode)
 	if err != nil {
 		return fmt.Errorf("ready call: %w", err)
 	}
@@ -139,6 +145,29 @@ func (c *ibClient) GetGCPImageName(ctx context.Context, composeID string) (strin
 	return result, nil
 }
 
+func (c *ibClient) GetImageArchitecture(ctx context.Context, composeID string) (clients.ArchitectureType, error) {
+	logger := logger(ctx)
+	logger.Trace().Str("compose_id", composeID).Msgf("Getting architecture of compose %s", composeID)
+
+	composeStatus, err := c.getComposeStatus(ctx, composeID)
+	if errors.Is(err, http.ComposeNotFoundErr) {
+		// clones do not carry the original request
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	if len(composeStatus.Request.ImageRequests) < 1 {
+		return "", http.ImageRequestNotFoundErr
+	}
+
+	arch, err := clients.MapArchitectures(ctx, string(composeStatus.Request.ImageRequests[0].Architecture))
+	if err != nil {
+		return "", fmt.Errorf("compose %s: %w", composeID, err)
+	}
+	return arch, nil
+}
+
 func (c *ibClient) fetchImageStatus(ctx context.Context, composeID string) (*UploadStatus, error) {
 	ctx, span := otel.Tracer(TraceName).Start(ctx, "fetchImageStatus")
 	defer span.End()
@@ -170,7 +199,7 @@ func (c *ibClient) getComposeStatus(ctx context.Context, composeID string) (*Com
 		return nil, fmt.Errorf("cannot get compose status: %w", err)
 	}
 
-	err = http.HandleHTTPResponses(ctx, resp.StatusCode())
+	err = http.HandleHTTPResponses(ctx, clients.ProviderImageBuilder, "GetComposeStatus", resp.StatusCode())
 	if err != nil {
 		if errors.Is(err, clients.NotFoundErr) {
 			return nil, fmt.Errorf("fetch image status call: %w", http.ComposeNotFoundErr)
@@ -213,7 +242,7 @@ func (c *ibClient) checkClone(ctx context.Context, composeID string) (*UploadSta
 		return nil, fmt.Errorf("cannot get compose status: %w", err)
 	}
 
-	err = http.HandleHTTPResponses(ctx, resp.StatusCode())
+	err = http.HandleHTTPResponses(ctx, clients.ProviderImageBuilder, "GetCloneStatus", resp.StatusCode())
 	if err != nil {
 		if errors.Is(err, clients.NotFoundErr) {
 			return nil, fmt.Errorf("fetch image status call: %w", http.CloneNotFoundErr)
@@ -0,0 +1,38 @@
+package image_builder_test
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients/http/image_builder"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/vcr"
+	"github.com/stretchr/testify/require"
+)
+
+// composeID of a successful AWS compose, use VCR_AWS_COMPOSE_ID to record a different one.
+const composeID = "3a7c8fb5-5ba4-4a3e-9a57-d5a1c9e3e6f8"
+
+// Contract tests run against recorded interactions, to refresh them from stage run "make record-cassettes".
+func TestImageBuilderContract(t *testing.T) {
+	id := composeID
+	if vcr.ModeFromEnv() == vcr.ModeRecord {
+		config.Initialize()
+		if envID := os.Getenv("VCR_AWS_COMPOSE_ID"); envID != "" {
+			id = envID
+		}
+	}
+	rec := vcr.New(t, "fixtures/cassettes/image_builder.json", config.ImageBuilder.URL)
+
+	ctx := context.Background()
+	client, err := image_builder.NewImageBuilderClientWithDoer(ctx, rec.BaseURL(), rec.Client())
+	require.NoError(t, err, "failed to initialize image builder client with recorder")
+
+	t.Run("GetAWSAmi", func(t *testing.T) {
+		ami, err := client.GetAWSAmi(ctx, id)
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(ami, "ami-"), "unexpected AMI format: %s", ami)
+	})
+}
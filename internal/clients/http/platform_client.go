@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/RHEnVision/provisioning-backend/internal/chaos"
 	"github.com/RHEnVision/provisioning-backend/internal/config"
 	"github.com/rs/zerolog"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
@@ -12,8 +13,9 @@ import (
 // Shared HTTP transport for all platform clients to utilize connection caching
 var transport = &http.Transport{}
 
-// NewPlatformClient returns new HTTP client (doer) with W3C Trace Context, logging tracing
-// and/or HTTP proxy (non-clowder environment only) according to application configuration.
+// NewPlatformClient returns new HTTP client (doer) with W3C Trace Context, logging tracing,
+// request timeout propagation and/or HTTP proxy (non-clowder environment only) according to
+// application configuration.
 // Use this function to create HTTP clients for communication with all platform services.
 func NewPlatformClient(ctx context.Context, proxy string) HttpRequestDoer {
 	var rt http.RoundTripper = transport
@@ -27,6 +29,14 @@ func NewPlatformClient(ctx context.Context, proxy string) HttpRequestDoer {
 		}
 	}
 
+	if chaos.Enabled() {
+		rt = NewChaosTransport(chaos.ClientRules(ctx), rt)
+	}
+
+	if config.Application.RequestTimeout.Header != "" {
+		rt = NewTimeoutTransport(config.Application.RequestTimeout.Header, rt)
+	}
+
 	if config.Telemetry.Enabled {
 		rt = otelhttp.NewTransport(rt)
 	}
@@ -15,6 +15,11 @@ func (r allPermRbac) GetPrincipalAccess(ctx context.Context) (clients.RbacAcl, e
 	return clients.AllPermissionsRbacAcl, nil
 }
 
+// GetUserAccess returns ACL that has all permissions. Used when Rbac is disabled by the configuration.
+func (r allPermRbac) GetUserAccess(ctx context.Context, username string) (clients.RbacAcl, error) {
+	return clients.AllPermissionsRbacAcl, nil
+}
+
 // Ready returns no error
 func (r allPermRbac) Ready(ctx context.Context) error {
 	return nil
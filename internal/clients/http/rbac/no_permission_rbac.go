@@ -16,6 +16,11 @@ func (r noPermRbac) GetPrincipalAccess(ctx context.Context) (clients.RbacAcl, er
 	return clients.NoPermissionsRbacAcl, nil
 }
 
+// GetUserAccess returns ACL that has no permissions. Used when Rbac connection could not be established.
+func (r noPermRbac) GetUserAccess(ctx context.Context, username string) (clients.RbacAcl, error) {
+	return clients.NoPermissionsRbacAcl, nil
+}
+
 var ErrNoPermissionRbac = errors.New("RBAC client could not be established")
 
 // Ready returns an error that RBAC could not be established
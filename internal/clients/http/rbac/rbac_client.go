@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"strings"
 	"time"
 
 	"github.com/RHEnVision/provisioning-backend/internal/clients"
@@ -59,7 +60,7 @@ func (c *rbac) Ready(ctx context.Context) error {
 	}
 	defer resp.Body.Close()
 
-	err = http.HandleHTTPResponses(ctx, resp.StatusCode)
+	err = http.HandleHTTPResponses(ctx, clients.ProviderRbac, "Ready", resp.StatusCode)
 	if err != nil {
 		return fmt.Errorf("ready call: %w", err)
 	}
@@ -76,6 +77,19 @@ func (c *rbac) GetPrincipalAccess(ctx context.Context) (clients.RbacAcl, error)
 	ctx, span := otel.Tracer(TraceName).Start(ctx, "GetPrincipalAccess")
 	defer span.End()
 
+	return c.principalAccess(ctx, nil)
+}
+
+func (c *rbac) GetUserAccess(ctx context.Context, username string) (clients.RbacAcl, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "GetUserAccess")
+	defer span.End()
+
+	return c.principalAccess(ctx, &username)
+}
+
+// principalAccess fetches access of the principal in the identity headers, or of the user
+// with the given username when it is not nil.
+func (c *rbac) principalAccess(ctx context.Context, username *string) (clients.RbacAcl, error) {
 	start := time.Now()
 	defer func() {
 		metrics.RbacAclFetchDuration.Observe(float64(time.Since(start).Nanoseconds()) / 1000000)
@@ -92,13 +106,14 @@ func (c *rbac) GetPrincipalAccess(ctx context.Context) (clients.RbacAcl, error)
 			Application: "provisioning",
 			Limit:       FetchLimit,
 			Offset:      &offset,
+			Username:    username,
 		}
 		resp, err := c.client.GetPrincipalAccessWithResponse(ctx, &params, headers.AddRbacIdentityHeader, headers.AddEdgeRequestIdHeader)
 		if err != nil {
 			return nil, fmt.Errorf("get principal access: %w", err)
 		}
 
-		err = http.HandleHTTPResponses(ctx, resp.StatusCode())
+		err = http.HandleHTTPResponses(ctx, clients.ProviderRbac, "GetPrincipalAccess", resp.StatusCode())
 		if err != nil {
 			return nil, fmt.Errorf("get principal access: %w", err)
 		}
@@ -116,7 +131,7 @@ func (c *rbac) GetPrincipalAccess(ctx context.Context) (clients.RbacAcl, error)
 		records = int(*resp.JSON200.Meta.Count)
 
 		for _, a := range resp.JSON200.Data {
-			result = append(result, clients.NewAccess(a.Permission))
+			result = append(result, clients.NewWorkspaceAccess(a.Permission, workspaces(a.ResourceDefinitions)))
 		}
 		offset += *FetchLimit
 
@@ -130,3 +145,22 @@ func (c *rbac) GetPrincipalAccess(ctx context.Context) (clients.RbacAcl, error)
 	zerolog.Ctx(ctx).Trace().Msgf("Access list: %s", result.String())
 	return result, nil
 }
+
+// workspaces returns workspace IDs from resource definitions, other attributes are ignored.
+func workspaces(definitions []ResourceDefinition) []string {
+	var result []string
+	for _, rd := range definitions {
+		if rd.AttributeFilter.Key != clients.WorkspaceAttribute {
+			continue
+		}
+		switch rd.AttributeFilter.Operation {
+		case Equal:
+			result = append(result, rd.AttributeFilter.Value)
+		case In:
+			for _, id := range strings.Split(rd.AttributeFilter.Value, ",") {
+				result = append(result, strings.TrimSpace(id))
+			}
+		}
+	}
+	return result
+}
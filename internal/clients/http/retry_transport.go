@@ -0,0 +1,113 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/rs/zerolog"
+)
+
+// maxThrottleBodySize limits how much of forbidden responses is read to find rate limit reasons
+const maxThrottleBodySize = 64 * 1024
+
+// RetryTransport repeats requests rejected by API rate limits with jittered exponential backoff
+// (see clients.RetryDelay), Retry-After response headers are honored. Only throttled requests are
+// repeated, they were not executed by the provider, so it is safe for non-idempotent calls too.
+// Requests with a body which cannot be rewound are not repeated.
+type RetryTransport struct {
+	provider  string
+	transport http.RoundTripper
+}
+
+func NewRetryTransport(provider string, transport http.RoundTripper) *RetryTransport {
+	return &RetryTransport{
+		provider:  provider,
+		transport: transport,
+	}
+}
+
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		resp, err := t.transport.RoundTrip(req)
+		if err != nil || attempt >= config.Application.Retry.MaxAttempts || !isThrottledResponse(resp) {
+			return resp, err //nolint:wrapcheck
+		}
+		if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			return resp, nil
+		}
+
+		delay, ok := retryAfter(resp)
+		if !ok {
+			delay = clients.RetryDelay(attempt)
+		} else if delay > config.Application.Retry.MaxDelay {
+			return resp, nil
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("cannot rewind request body: %w", err)
+			}
+			// round trippers must not modify the original request
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+
+		clients.CountRetry(t.provider, true)
+		zerolog.Ctx(ctx).Debug().Msgf("Request %s %s throttled by %s, retrying in %s", req.Method, req.URL.Redacted(), t.provider, delay)
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("throttled request cancelled: %w", ctx.Err())
+		}
+	}
+}
+
+// isThrottledResponse returns true for too many requests responses and Google APIs rate limit
+// errors which are returned as forbidden.
+func isThrottledResponse(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusForbidden:
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxThrottleBodySize))
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return err == nil && (bytes.Contains(body, []byte("rateLimitExceeded")) || bytes.Contains(body, []byte("userRateLimitExceeded")))
+	default:
+		return false
+	}
+}
+
+// retryAfter returns delay from the Retry-After header in seconds or HTTP date format.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	value := strings.TrimSpace(resp.Header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
@@ -0,0 +1,106 @@
+package http
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func useRetryConfig(t *testing.T) {
+	t.Helper()
+	previous := config.Application.Retry
+	config.Application.Retry.MaxAttempts = 3
+	config.Application.Retry.BaseDelay = time.Millisecond
+	config.Application.Retry.MaxDelay = 10 * time.Millisecond
+	t.Cleanup(func() { config.Application.Retry = previous })
+}
+
+// throttlingServer rejects the first throttled requests with the status and body
+func throttlingServer(t *testing.T, throttled int, status int, body string, header http.Header) (*httptest.Server, *[]string) {
+	t.Helper()
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if len(bodies) <= throttled {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(body))
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &bodies
+}
+
+func post(t *testing.T, url string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader("payload"))
+	require.NoError(t, err)
+	resp, err := NewRetryTransport("test", http.DefaultTransport).RoundTrip(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	return resp
+}
+
+func TestRetryTransport(t *testing.T) {
+	useRetryConfig(t)
+
+	t.Run("too many requests", func(t *testing.T) {
+		srv, bodies := throttlingServer(t, 2, http.StatusTooManyRequests, "", nil)
+		resp := post(t, srv.URL)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []string{"payload", "payload", "payload"}, *bodies)
+	})
+
+	t.Run("attempts exhausted", func(t *testing.T) {
+		srv, bodies := throttlingServer(t, 5, http.StatusTooManyRequests, "slow down", nil)
+		resp := post(t, srv.URL)
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Len(t, *bodies, 3)
+		b, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "slow down", string(b))
+	})
+
+	t.Run("rate limit exceeded", func(t *testing.T) {
+		srv, bodies := throttlingServer(t, 1, http.StatusForbidden, `{"error":{"errors":[{"reason":"rateLimitExceeded"}]}}`, nil)
+		resp := post(t, srv.URL)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Len(t, *bodies, 2)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		srv, bodies := throttlingServer(t, 1, http.StatusForbidden, "access denied", nil)
+		resp := post(t, srv.URL)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		assert.Len(t, *bodies, 1)
+		b, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "access denied", string(b))
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		srv, bodies := throttlingServer(t, 1, http.StatusServiceUnavailable, "", nil)
+		resp := post(t, srv.URL)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Len(t, *bodies, 1)
+	})
+
+	t.Run("retry after too long", func(t *testing.T) {
+		srv, bodies := throttlingServer(t, 1, http.StatusTooManyRequests, "", http.Header{"Retry-After": {"60"}})
+		resp := post(t, srv.URL)
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Len(t, *bodies, 1)
+	})
+}
@@ -0,0 +1,323 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	httpClients "github.com/RHEnVision/provisioning-backend/internal/clients/http"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/telemetry"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"go.opentelemetry.io/otel"
+)
+
+const TraceName = telemetry.TracePrefix + "internal/clients/http/s3"
+
+// s3Client is a minimal S3-compatible client of a single bucket. Requests are path-style and
+// signed with Signature Version 4, so it works with AWS S3 as well as MinIO or Ceph.
+type s3Client struct {
+	doer        httpClients.HttpRequestDoer
+	endpoint    string
+	public      string
+	bucket      string
+	region      string
+	credentials aws.Credentials
+	signer      *v4.Signer
+}
+
+func init() {
+	clients.GetObjectStorageClient = newObjectStorageClient
+}
+
+func newObjectStorageClient(ctx context.Context) (clients.ObjectStorage, error) {
+	endpoint := config.Archive.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", config.Archive.Region)
+	}
+	return NewObjectStorageClientWithDoer(ctx, endpoint, httpClients.NewPlatformClient(ctx, ""))
+}
+
+// NewObjectStorageClientWithDoer allows customization of the URL and the HTTP client (e.g. recording
+// transport). It is meant for testing only, for production please use clients.GetObjectStorageClient.
+func NewObjectStorageClientWithDoer(_ context.Context, endpoint string, doer httpClients.HttpRequestDoer) (clients.ObjectStorage, error) {
+	public := config.Archive.PublicEndpoint
+	if public == "" {
+		public = endpoint
+	}
+	return &s3Client{
+		doer:     doer,
+		endpoint: endpoint,
+		public:   public,
+		bucket:   config.Archive.Bucket,
+		region:   config.Archive.Region,
+		credentials: aws.Credentials{
+			AccessKeyID:     config.Archive.AccessKey,
+			SecretAccessKey: config.Archive.SecretKey,
+		},
+		signer: v4.NewSigner(),
+	}, nil
+}
+
+// metadataHeader is the prefix of user metadata headers
+const metadataHeader = "X-Amz-Meta-"
+
+// listPageSize is the maximum number of keys listed in one request
+const listPageSize = 1000
+
+func (c *s3Client) newRequest(ctx context.Context, method, key string, query url.Values, metadata map[string]string, body []byte) (*http.Request, error) {
+	objectURL, err := url.JoinPath(c.endpoint, c.bucket, key)
+	if err != nil {
+		return nil, fmt.Errorf("cannot build object URL: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, objectURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("cannot create request: %w", err)
+	}
+	// signature requires spaces encoded as %20
+	req.URL.RawQuery = strings.ReplaceAll(query.Encode(), "+", "%20")
+	for name, value := range metadata {
+		req.Header.Set(metadataHeader+name, value)
+	}
+
+	sum := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(sum[:])
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	err = c.signer.SignHTTP(ctx, c.credentials, req, payloadHash, "s3", c.region, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("cannot sign request: %w", err)
+	}
+	return req, nil
+}
+
+func (c *s3Client) PutObject(ctx context.Context, key, contentType string, metadata map[string]string, data []byte) error {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "PutObject")
+	defer span.End()
+
+	req, err := c.newRequest(ctx, http.MethodPut, key, nil, metadata, data)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := c.doer.Do(req)
+	if err != nil {
+		return fmt.Errorf("cannot put object %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if err = httpClients.HandleHTTPResponses(ctx, clients.ProviderS3, "PutObject", resp.StatusCode); err != nil {
+		return fmt.Errorf("cannot put object %s: %w", key, err)
+	}
+	return nil
+}
+
+func (c *s3Client) GetObject(ctx context.Context, key string) ([]byte, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "GetObject")
+	defer span.End()
+
+	req, err := c.newRequest(ctx, http.MethodGet, key, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doer.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get object %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if httpClients.IsHTTPNotFound(resp.StatusCode) {
+		return nil, fmt.Errorf("%w: %s", clients.ObjectNotFoundErr, key)
+	}
+	if err = httpClients.HandleHTTPResponses(ctx, clients.ProviderS3, "GetObject", resp.StatusCode); err != nil {
+		return nil, fmt.Errorf("cannot get object %s: %w", key, err)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read object %s: %w", key, err)
+	}
+	return data, nil
+}
+
+func (c *s3Client) HeadObject(ctx context.Context, key string) (map[string]string, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "HeadObject")
+	defer span.End()
+
+	req, err := c.newRequest(ctx, http.MethodHead, key, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doer.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot head object %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if httpClients.IsHTTPNotFound(resp.StatusCode) {
+		return nil, fmt.Errorf("%w: %s", clients.ObjectNotFoundErr, key)
+	}
+	if err = httpClients.HandleHTTPResponses(ctx, clients.ProviderS3, "HeadObject", resp.StatusCode); err != nil {
+		return nil, fmt.Errorf("cannot head object %s: %w", key, err)
+	}
+
+	metadata := make(map[string]string)
+	for name, values := range resp.Header {
+		if strings.HasPrefix(name, metadataHeader) && len(values) > 0 {
+			metadata[strings.ToLower(strings.TrimPrefix(name, metadataHeader))] = values[0]
+		}
+	}
+	return metadata, nil
+}
+
+func (c *s3Client) DeleteObject(ctx context.Context, key string) error {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "DeleteObject")
+	defer span.End()
+
+	req, err := c.newRequest(ctx, http.MethodDelete, key, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.doer.Do(req)
+	if err != nil {
+		return fmt.Errorf("cannot delete object %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if httpClients.IsHTTPNotFound(resp.StatusCode) {
+		return nil
+	}
+	if err = httpClients.HandleHTTPResponses(ctx, clients.ProviderS3, "DeleteObject", resp.StatusCode); err != nil {
+		return fmt.Errorf("cannot delete object %s: %w", key, err)
+	}
+	return nil
+}
+
+// listBucketResult is the response of ListObjectsV2
+type listBucketResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// listObjects returns a page of keys starting with the prefix and the token of the next page,
+// which is blank for the last page.
+func (c *s3Client) listObjects(ctx context.Context, prefix, token string) ([]string, string, error) {
+	query := url.Values{}
+	query.Set("list-type", "2")
+	query.Set("prefix", prefix)
+	query.Set("max-keys", strconv.Itoa(listPageSize))
+	if token != "" {
+		query.Set("continuation-token", token)
+	}
+	req, err := c.newRequest(ctx, http.MethodGet, "", query, nil, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	resp, err := c.doer.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("cannot list objects %s: %w", prefix, err)
+	}
+	defer resp.Body.Close()
+
+	if err = httpClients.HandleHTTPResponses(ctx, clients.ProviderS3, "ListObjectsV2", resp.StatusCode); err != nil {
+		return nil, "", fmt.Errorf("cannot list objects %s: %w", prefix, err)
+	}
+
+	var result listBucketResult
+	if err = xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, "", fmt.Errorf("cannot decode object list %s: %w", prefix, err)
+	}
+	keys := make([]string, 0, len(result.Contents))
+	for _, content := range result.Contents {
+		keys = append(keys, content.Key)
+	}
+	if !result.IsTruncated {
+		return keys, "", nil
+	}
+	return keys, result.NextContinuationToken, nil
+}
+
+func (c *s3Client) ListObjects(ctx context.Context, prefix string) ([]string, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "ListObjects")
+	defer span.End()
+
+	var result []string
+	token := ""
+	for {
+		keys, next, err := c.listObjects(ctx, prefix, token)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, keys...)
+		if next == "" {
+			return result, nil
+		}
+		token = next
+	}
+}
+
+func (c *s3Client) DeletePrefix(ctx context.Context, prefix string) (int64, error) {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "DeletePrefix")
+	defer span.End()
+
+	var deleted int64
+	token := ""
+	for {
+		keys, next, err := c.listObjects(ctx, prefix, token)
+		if err != nil {
+			return deleted, err
+		}
+		for _, key := range keys {
+			if err = c.DeleteObject(ctx, key); err != nil {
+				return deleted, err
+			}
+			deleted++
+		}
+		if next == "" {
+			return deleted, nil
+		}
+		token = next
+	}
+}
+
+// PresignGetObject signs the URL with query parameters, the public endpoint is used because the
+// host is a part of the signature.
+func (c *s3Client) PresignGetObject(ctx context.Context, key string, expires time.Duration) (string, error) {
+	objectURL, err := url.JoinPath(c.public, c.bucket, key)
+	if err != nil {
+		return "", fmt.Errorf("cannot build object URL: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, objectURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("cannot create request: %w", err)
+	}
+	query := req.URL.Query()
+	query.Set("X-Amz-Expires", strconv.FormatInt(int64(expires/time.Second), 10))
+	req.URL.RawQuery = query.Encode()
+
+	signed, _, err := c.signer.PresignHTTP(ctx, c.credentials, req, "UNSIGNED-PAYLOAD", "s3", c.region, time.Now())
+	if err != nil {
+		return "", fmt.Errorf("cannot presign object %s: %w", key, err)
+	}
+	return signed, nil
+}
@@ -0,0 +1,127 @@
+package s3_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/clients/http/s3"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectStorageClient(t *testing.T) {
+	config.Archive.Bucket = "archive"
+	config.Archive.Region = "us-east-1"
+	config.Archive.AccessKey = "key"
+	config.Archive.SecretKey = "secret"
+
+	objects := make(map[string][]byte)
+	provider := make(map[string]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=key/") || r.Header.Get("X-Amz-Content-Sha256") == "" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.Method {
+		case http.MethodPut:
+			data, _ := io.ReadAll(r.Body)
+			objects[r.URL.Path] = data
+			provider[r.URL.Path] = r.Header.Get("X-Amz-Meta-Provider")
+		case http.MethodHead:
+			if _, ok := objects[r.URL.Path]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("X-Amz-Meta-Provider", provider[r.URL.Path])
+		case http.MethodDelete:
+			delete(objects, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodGet:
+			if r.URL.Query().Get("list-type") == "2" {
+				prefix := r.URL.Query().Get("prefix")
+				_, _ = w.Write([]byte("<ListBucketResult><IsTruncated>false</IsTruncated>"))
+				for key := range objects {
+					if name := strings.TrimPrefix(key, "/archive/"); strings.HasPrefix(name, prefix) {
+						_, _ = w.Write([]byte("<Contents><Key>" + name + "</Key></Contents>"))
+					}
+				}
+				_, _ = w.Write([]byte("</ListBucketResult>"))
+				return
+			}
+			data, ok := objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(data)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client, err := s3.NewObjectStorageClientWithDoer(ctx, server.URL, server.Client())
+	require.NoError(t, err)
+
+	err = client.PutObject(ctx, "reservations/1/42.json.gz", "application/gzip", map[string]string{"provider": "aws"}, []byte("data"))
+	require.NoError(t, err)
+	assert.Contains(t, objects, "/archive/reservations/1/42.json.gz")
+
+	data, err := client.GetObject(ctx, "reservations/1/42.json.gz")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("data"), data)
+
+	_, err = client.GetObject(ctx, "reservations/1/43.json.gz")
+	require.ErrorIs(t, err, clients.ObjectNotFoundErr)
+
+	metadata, err := client.HeadObject(ctx, "reservations/1/42.json.gz")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"provider": "aws"}, metadata)
+
+	_, err = client.HeadObject(ctx, "reservations/1/43.json.gz")
+	require.ErrorIs(t, err, clients.ObjectNotFoundErr)
+	err = client.DeleteObject(ctx, "reservations/1/42.json.gz")
+	require.NoError(t, err)
+	assert.NotContains(t, objects, "/archive/reservations/1/42.json.gz")
+
+	for _, key := range []string{"reservations/1/42.json.gz", "reservations/1/43.json.gz", "reservations/10/44.json.gz"} {
+		err = client.PutObject(ctx, key, "application/gzip", nil, []byte("data"))
+		require.NoError(t, err)
+	}
+	deleted, err := client.DeletePrefix(ctx, "reservations/1/")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+	assert.Len(t, objects, 1)
+	assert.Contains(t, objects, "/archive/reservations/10/44.json.gz")
+}
+
+func TestPresignGetObject(t *testing.T) {
+	config.Archive.Bucket = "archive"
+	config.Archive.Region = "us-east-1"
+	config.Archive.AccessKey = "key"
+	config.Archive.SecretKey = "secret"
+	config.Archive.PublicEndpoint = "https://objects.example.com"
+	defer func() { config.Archive.PublicEndpoint = "" }()
+
+	ctx := context.Background()
+	client, err := s3.NewObjectStorageClientWithDoer(ctx, "http://minio:9000", http.DefaultClient)
+	require.NoError(t, err)
+
+	signed, err := client.PresignGetObject(ctx, "reservations/1/42.json.gz", 15*time.Minute)
+	require.NoError(t, err)
+
+	u, err := url.Parse(signed)
+	require.NoError(t, err)
+	assert.Equal(t, "objects.example.com", u.Host)
+	assert.Equal(t, "/archive/reservations/1/42.json.gz", u.Path)
+	assert.Equal(t, "900", u.Query().Get("X-Amz-Expires"))
+	assert.True(t, strings.HasPrefix(u.Query().Get("X-Amz-Credential"), "key/"))
+	assert.NotEmpty(t, u.Query().Get("X-Amz-Signature"))
+}
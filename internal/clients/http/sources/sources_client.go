@@ -1,6 +1,7 @@
 package sources
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -14,6 +15,7 @@ import (
 	"github.com/RHEnVision/provisioning-backend/internal/clients/http"
 	"github.com/RHEnVision/provisioning-backend/internal/config"
 	"github.com/RHEnVision/provisioning-backend/internal/headers"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
 	"github.com/RHEnVision/provisioning-backend/internal/models"
 	"github.com/RHEnVision/provisioning-backend/internal/ptr"
 	"github.com/RHEnVision/provisioning-backend/internal/telemetry"
@@ -42,8 +44,14 @@ func newSourcesClient(ctx context.Context) (clients.Sources, error) {
 // NewSourcesClientWithUrl allows customization of the URL for the underlying client.
 // It is meant for testing only, for production please use clients.GetSourcesClient.
 func NewSourcesClientWithUrl(ctx context.Context, url string) (clients.Sources, error) {
+	return NewSourcesClientWithDoer(ctx, url, http.NewPlatformClient(ctx, config.Sources.Proxy.URL))
+}
+
+// NewSourcesClientWithDoer allows customization of the URL and the HTTP client (e.g. recording
+// transport). It is meant for testing only, for production please use clients.GetSourcesClient.
+func NewSourcesClientWithDoer(_ context.Context, url string, doer http.HttpRequestDoer) (clients.Sources, error) {
 	c, err := NewClientWithResponses(url, func(c *Client) error {
-		c.Client = http.NewPlatformClient(ctx, config.Sources.Proxy.URL)
+		c.Client = doer
 		return nil
 	})
 	if err != nil {
@@ -74,7 +82,7 @@ func (c *sourcesClient) Ready(ctx context.Context) error {
 	}
 	defer resp.Body.Close()
 
-	err = http.HandleHTTPResponses(ctx, resp.StatusCode)
+	err = http.HandleHTTPResponses(ctx, clients.ProviderSources, "Ready", resp.StatusCode)
 	if err != nil {
 		return fmt.Errorf("ready call: %w", err)
 	}
@@ -105,9 +113,11 @@ func (c *sourcesClient) ListProvisioningSourcesByProvider(ctx context.Context, p
 		return nil, fmt.Errorf("failed to get ApplicationTypes: %w", err)
 	}
 
-	err = http.HandleHTTPResponses(ctx, resp.StatusCode())
+	err = http.HandleHTTPResponses(ctx, clients.ProviderSources, "ListApplicationTypeSources", resp.StatusCode())
 	if err != nil {
 		if errors.Is(err, clients.NotFoundErr) {
+			// the application type was likely re-registered in Sources under a new id
+			cache.InvalidateAppTypeId(ctx)
 			return nil, fmt.Errorf("list provisioning sources call: %w", http.SourceNotFoundErr)
 		}
 		return nil, fmt.Errorf("list provisioning sources call: %w", err)
@@ -145,9 +155,11 @@ func (c *sourcesClient) ListAllProvisioningSources(ctx context.Context) ([]*clie
 		return nil, fmt.Errorf("failed to get ApplicationTypes: %w", err)
 	}
 
-	err = http.HandleHTTPResponses(ctx, resp.StatusCode())
+	err = http.HandleHTTPResponses(ctx, clients.ProviderSources, "ListApplicationTypeSources", resp.StatusCode())
 	if err != nil {
 		if errors.Is(err, clients.NotFoundErr) {
+			// the application type was likely re-registered in Sources under a new id
+			cache.InvalidateAppTypeId(ctx)
 			return nil, fmt.Errorf("list provisioning sources call: %w", http.SourceNotFoundErr)
 		}
 		return nil, fmt.Errorf("list provisioning sources call: %w", err)
@@ -177,7 +189,7 @@ func (c *sourcesClient) GetAuthentication(ctx context.Context, sourceId string)
 		return nil, fmt.Errorf("cannot list source authentication: %w", err)
 	}
 
-	err = http.HandleHTTPResponses(ctx, resp.StatusCode())
+	err = http.HandleHTTPResponses(ctx, clients.ProviderSources, "ListSourceAuthentications", resp.StatusCode())
 	if err != nil {
 		if errors.Is(err, clients.NotFoundErr) {
 			return nil, fmt.Errorf("get source authentication call: %w", http.AuthenticationForSourcesNotFoundErr)
@@ -205,17 +217,109 @@ func (c *sourcesClient) GetAuthentication(ctx context.Context, sourceId string)
 	return authentication, nil
 }
 
+type bulkSource struct {
+	Name                string `json:"name"`
+	SourceTypeName      string `json:"source_type_name"`
+	AppCreationWorkflow string `json:"app_creation_workflow"`
+}
+
+type bulkApplication struct {
+	SourceName          string `json:"source_name"`
+	ApplicationTypeName string `json:"application_type_name"`
+}
+
+type bulkAuthentication struct {
+	ResourceType string `json:"resource_type"`
+	ResourceName string `json:"resource_name"`
+	Username     string `json:"username"`
+	Authtype     string `json:"authtype"`
+}
+
+type bulkCreatePayload struct {
+	Sources         []bulkSource         `json:"sources"`
+	Applications    []bulkApplication    `json:"applications"`
+	Authentications []bulkAuthentication `json:"authentications"`
+}
+
+// authTypeForProvider returns Sources authentication type of the provisioning application,
+// see filterSourceAuthentications for the opposite direction.
+//
+//nolint:exhaustive
+func authTypeForProvider(provider models.ProviderType) string {
+	switch provider {
+	case models.ProviderTypeAWS:
+		return "provisioning-arn"
+	case models.ProviderTypeAzure:
+		return "provisioning_lighthouse_subscription_id"
+	case models.ProviderTypeGCP:
+		return "provisioning_project_id"
+	default:
+		return ""
+	}
+}
+
+func (c *sourcesClient) CreateProvisioningSource(ctx context.Context, provider models.ProviderType, name, authentication string) error {
+	ctx, span := otel.Tracer(TraceName).Start(ctx, "CreateProvisioningSource")
+	defer span.End()
+
+	logger := logger(ctx)
+	sourceTypeName := provider.SourcesProviderName()
+	authType := authTypeForProvider(provider)
+	if sourceTypeName == "" || authType == "" {
+		return fmt.Errorf("cannot create source for provider %s: %w", provider.String(), http.SourceTypeNameNotFoundErr)
+	}
+
+	payload := bulkCreatePayload{
+		Sources: []bulkSource{{
+			Name:                name,
+			SourceTypeName:      sourceTypeName,
+			AppCreationWorkflow: "manual_configuration",
+		}},
+		Applications: []bulkApplication{{
+			SourceName:          name,
+			ApplicationTypeName: "provisioning",
+		}},
+		Authentications: []bulkAuthentication{{
+			ResourceType: "Application",
+			ResourceName: "provisioning",
+			Username:     authentication,
+			Authtype:     authType,
+		}},
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("unable to marshal bulk create payload: %w", err)
+	}
+
+	logger.Debug().Msgf("Creating %s source '%s'", sourceTypeName, name)
+	resp, err := c.client.BulkCreateWithBody(ctx, "application/json", bytes.NewReader(body), headers.AddSourcesIdentityHeader, headers.AddEdgeRequestIdHeader)
+	if err != nil {
+		return fmt.Errorf("failed to create source: %w", err)
+	}
+	defer resp.Body.Close()
+
+	err = http.HandleHTTPResponses(ctx, clients.ProviderSources, "BulkCreate", resp.StatusCode)
+	if err != nil {
+		return fmt.Errorf("create source call: %w", err)
+	}
+	return nil
+}
+
 func (c *sourcesClient) GetProvisioningTypeId(ctx context.Context) (string, error) {
 	appTypeId, err := cache.FindAppTypeId(ctx)
-	if errors.Is(err, cache.ErrNotFound) {
-		appTypeId, err = c.loadAppId(ctx)
+	if errors.Is(err, cache.ErrExpired) {
+		// serve the stale value, the request context can be canceled before the refresh finishes
+		bgCtx := identity.WithIdentity(zerolog.Ctx(ctx).WithContext(context.Background()), identity.Identity(ctx))
+		go func() {
+			if _, refreshErr := c.refreshAppId(bgCtx); refreshErr != nil {
+				zerolog.Ctx(bgCtx).Warn().Err(refreshErr).Msg("Unable to refresh app type id, using stale value")
+			}
+		}()
+	} else if errors.Is(err, cache.ErrNotFound) {
+		appTypeId, err = c.refreshAppId(ctx)
 		if err != nil {
 			return "", err
 		}
-		err = cache.SetAppTypeId(ctx, appTypeId)
-		if err != nil {
-			return "", fmt.Errorf("unable to store app type id to cache: %w", err)
-		}
 	} else if err != nil {
 		return "", fmt.Errorf("unable to get app type id from cache: %w", err)
 	}
@@ -223,6 +327,24 @@ func (c *sourcesClient) GetProvisioningTypeId(ctx context.Context) (string, erro
 	return appTypeId, nil
 }
 
+// refreshAppId loads the application type id and stores it to the cache.
+func (c *sourcesClient) refreshAppId(ctx context.Context) (string, error) {
+	// application type id is the same for all tenants
+	appTypeId, err := cache.Coalesce("app_type_id", func() (string, error) {
+		return c.loadAppId(ctx)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	err = cache.SetAppTypeId(ctx, appTypeId)
+	if err != nil {
+		return "", fmt.Errorf("unable to store app type id to cache: %w", err)
+	}
+
+	return appTypeId, nil
+}
+
 func (c *sourcesClient) loadAppId(ctx context.Context) (string, error) {
 	logger := logger(ctx)
 	logger.Trace().Msg("Fetching the Application Type ID of Provisioning for Sources")
@@ -234,7 +356,7 @@ func (c *sourcesClient) loadAppId(ctx context.Context) (string, error) {
 	}
 	defer resp.Body.Close()
 
-	err = http.HandleHTTPResponses(ctx, resp.StatusCode)
+	err = http.HandleHTTPResponses(ctx, clients.ProviderSources, "ListApplicationTypes", resp.StatusCode)
 	if err != nil {
 		if errors.Is(err, clients.NotFoundErr) {
 			return "", fmt.Errorf("load app ID call: %w", http.ApplicationTypeNotFoundErr)
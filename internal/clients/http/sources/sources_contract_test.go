@@ -0,0 +1,51 @@
+package sources_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients/http/sources"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/vcr"
+	"github.com/stretchr/testify/require"
+)
+
+// Contract tests run against recorded interactions, to refresh them from stage run "make record-cassettes".
+func TestSourcesContract(t *testing.T) {
+	if vcr.ModeFromEnv() == vcr.ModeRecord {
+		config.Initialize()
+	}
+	rec := vcr.New(t, "fixtures/cassettes/sources.json", config.Sources.URL)
+
+	ctx := context.Background()
+	client, err := sources.NewSourcesClientWithDoer(ctx, rec.BaseURL(), rec.Client())
+	require.NoError(t, err, "failed to initialize sources client with recorder")
+
+	t.Run("GetProvisioningTypeId", func(t *testing.T) {
+		appTypeId, err := client.GetProvisioningTypeId(ctx)
+		require.NoError(t, err)
+		require.NotEmpty(t, appTypeId)
+	})
+
+	t.Run("ListAllProvisioningSources", func(t *testing.T) {
+		result, err := client.ListAllProvisioningSources(ctx)
+		require.NoError(t, err)
+		require.NotEmpty(t, result, "record with an account that has provisioning sources")
+		for _, source := range result {
+			require.NotEmpty(t, source.ID)
+			require.NotEmpty(t, source.Name)
+			require.NotEmpty(t, source.SourceTypeID)
+			require.NotEmpty(t, source.Uid)
+		}
+	})
+
+	t.Run("ListProvisioningSourcesByProvider", func(t *testing.T) {
+		result, err := client.ListProvisioningSourcesByProvider(ctx, models.ProviderTypeAWS)
+		require.NoError(t, err)
+		for _, source := range result {
+			require.NotEmpty(t, source.ID)
+			require.NotEmpty(t, source.SourceTypeID)
+		}
+	})
+}
@@ -0,0 +1,38 @@
+package http
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// TimeoutTransport sends the remaining time of the request context deadline in seconds (rounded
+// up) in the timeout header, so platform services can abandon work the caller will not wait for.
+type TimeoutTransport struct {
+	header    string
+	transport http.RoundTripper
+}
+
+func NewTimeoutTransport(header string, transport http.RoundTripper) *TimeoutTransport {
+	return &TimeoutTransport{
+		header:    header,
+		transport: transport,
+	}
+}
+
+func (t *TimeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	deadline, ok := req.Context().Deadline()
+	if !ok || req.Header.Get(t.header) != "" {
+		return t.transport.RoundTrip(req)
+	}
+
+	remaining := math.Ceil(time.Until(deadline).Seconds())
+	if remaining < 1 {
+		remaining = 1
+	}
+	// round trippers must not modify the original request
+	req = req.Clone(req.Context())
+	req.Header.Set(t.header, strconv.FormatInt(int64(remaining), 10))
+	return t.transport.RoundTrip(req)
+}
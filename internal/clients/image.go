@@ -0,0 +1,19 @@
+package clients
+
+// Image represents a bootable image available through hyperscaler API.
+type Image struct {
+	// Name of the image, for example "rhel-9-v20231010" for GCP.
+	Name string
+
+	// Family groups image versions, the newest non-deprecated image is the family head.
+	Family string
+
+	// Project owning the image, for example "rhel-cloud" for GCP public images.
+	Project string
+
+	// SelfLink is the full resource URL used to launch instances.
+	SelfLink string
+
+	// Architecture of the image, blank when unknown.
+	Architecture ArchitectureType
+}
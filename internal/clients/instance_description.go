@@ -1,5 +1,7 @@
 package clients
 
+import "github.com/RHEnVision/provisioning-backend/internal/models"
+
 type AzureInstanceID string
 
 // InstanceDescription defines a model for an instance description
@@ -12,4 +14,16 @@ type InstanceDescription struct {
 
 	// the public ipv4 of the instance
 	PublicIPv4 string `json:"ipv4,omitempty" yaml:"ipv4"`
+
+	// The private ipv4 dns of the instance
+	PrivateDNS string `json:"private_dns,omitempty" yaml:"private_dns"`
+
+	// The private ipv4 of the instance
+	PrivateIPv4 string `json:"private_ipv4,omitempty" yaml:"private_ipv4"`
+
+	// Pending is true while the instance is being created, addresses may not be assigned yet
+	Pending bool `json:"pending,omitempty" yaml:"pending"`
+
+	// Network interfaces of the instance, primary interface first
+	NetworkInterfaces []models.InstanceNetworkInterface `json:"network_interfaces,omitempty" yaml:"network_interfaces"`
 }
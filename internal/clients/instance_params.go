@@ -32,10 +32,28 @@ type GCPInstanceParams struct {
 
 	// StartupScript contains metadata startup script (GCP tools must be installed on the image)
 	StartupScript string
+
+	// UserData contains cloud-init user data metadata (cloud-init must be installed on the image)
+	UserData string
+
+	// ServiceAccountEmail attached to the instances, none when empty
+	ServiceAccountEmail string
+
+	// Scopes are OAuth scopes of the service account
+	Scopes []string
+
+	// Shielded VM options, no shielded instance config is set when all are false
+	ShieldedSecureBoot          bool
+	ShieldedVTPM                bool
+	ShieldedIntegrityMonitoring bool
+
+	// Addressing of the network interface, an ephemeral public IPv4 address is assigned when nil
+	Addressing *models.Addressing
 }
 
 type AWSInstanceParams struct {
-	// The template id to use in order to launch an instance
+	// The template ID ("lt-" prefix) or name to use in order to launch an instance, AMI, instance
+	// type and key name override the template when set
 	LaunchTemplateID string
 
 	// ami of the instance will be launched from
@@ -52,10 +70,69 @@ type AWSInstanceParams struct {
 
 	// UserData for the instance launch
 	UserData []byte
+
+	// InstanceProfile name or ARN, no profile is attached when empty
+	InstanceProfile string
+
+	// EncryptVolumes of the AMI block device mappings
+	EncryptVolumes bool
+
+	// KMSKeyID used for the volume encryption, default EBS key when empty
+	KMSKeyID string
+
+	// RootVolume overrides the root volume of the AMI block device mappings when not nil
+	RootVolume *models.AWSRootVolume
+
+	// NitroEnclaves enabled on the instances
+	NitroEnclaves bool
+
+	// Hibernation configured on the instances, requires encrypted volumes
+	Hibernation bool
+
+	// NetworkInterfaces of the instances, the first is the primary one, default subnet when empty
+	NetworkInterfaces []models.NetworkInterface
+
+	// SubnetID of the primary interface when there are no network interfaces, default subnet when empty
+	SubnetID string
+
+	// SecurityGroupIDs of the primary interface when there are no network interfaces
+	SecurityGroupIDs []string
+
+	// Addressing of the primary interface, the interface is created from SubnetID and
+	// SecurityGroupIDs when there are no network interfaces. Provider defaults when nil.
+	Addressing *models.Addressing
+
+	// Tenancy of the instances ("dedicated" or "host"), shared hardware when empty
+	Tenancy string
+
+	// HostID of the dedicated host with host tenancy, any host with auto-placement when empty
+	HostID string
+
+	// CapacityReservationID of the reserved capacity instances are launched into, matching open
+	// capacity reservations are used when both the ID and the group ARN are empty
+	CapacityReservationID string
+
+	// CapacityReservationGroupARN of the resource group with reserved capacity
+	CapacityReservationGroupARN string
+
+	// Spot launches the instances as one-time spot requests terminated on interruption
+	Spot bool
+
+	// SpotMaxPrice is the maximum hourly price in USD, the on-demand price when empty
+	SpotMaxPrice string
+
+	// Tags applied to the instances and volumes in addition to the managed tags
+	Tags map[string]string
 }
 
+// AzureSubnetAddressPrefix is the address space of the shared subnet of VMs in a resource group
+const AzureSubnetAddressPrefix = "172.22.0.0/16"
+
 // AzureInstanceParams define parameters for a single instance launch on Azure.
 type AzureInstanceParams struct {
+	// ReservationID the VM is tagged with
+	ReservationID int64
+
 	// Location - to deploy into
 	Location string
 
@@ -64,8 +141,12 @@ type AzureInstanceParams struct {
 
 	// ImageID - the Image ID in format of full Azure ID as
 	// for example /subscriptions/{subscriptionID}/resourceGroups/{resourceGroupName}/providers/Microsoft.Compute/images/{imageName}
+	// or a marketplace image URN in the publisher:offer:sku:version form
 	ImageID string
 
+	// Plan - purchase plan of the marketplace image, nil for images without plan
+	Plan *AzureImagePlan
+
 	// Pubkey to use for the instance access
 	Pubkey *models.Pubkey
 
@@ -74,4 +155,26 @@ type AzureInstanceParams struct {
 
 	// UserData for the instance launch
 	UserData []byte
+
+	// Zone - optional availability zone to deploy into
+	Zone string
+
+	// ProximityPlacementGroupID - optional resource ID of an existing proximity placement group
+	ProximityPlacementGroupID string
+
+	// SecurityType - optional trusted launch or confidential VM
+	SecurityType models.AzureSecurityType
+
+	// SecureBoot and VTPM UEFI settings, only applied with a security type
+	SecureBoot bool
+	VTPM       bool
+
+	// Hibernation enabled on the VM
+	Hibernation bool
+
+	// NetworkInterfaces attached to the VM in addition to the primary interface
+	NetworkInterfaces []models.NetworkInterface
+
+	// PrivateIPs - optional static addresses of the primary interface, one per VM
+	PrivateIPs []string
 }
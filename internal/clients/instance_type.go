@@ -40,6 +40,13 @@ type InstanceType struct {
 	AzureDetail *InstanceTypeDetailAzure `json:"azure,omitempty" yaml:"azure,omitempty"`
 }
 
+// InstanceTypeList is a cacheable list of instance types available in a region.
+type InstanceTypeList []*InstanceType
+
+func (l InstanceTypeList) CacheKeyName() string {
+	return "instance_types"
+}
+
 // InstanceTypeDetailAzure contains specific details for Azure.
 type InstanceTypeDetailAzure struct {
 	GenV1 bool `json:"gen_v1" yaml:"gen_v1"`
@@ -2,6 +2,7 @@ package clients
 
 import (
 	"context"
+	"time"
 
 	"github.com/RHEnVision/provisioning-backend/internal/models"
 )
@@ -24,6 +25,11 @@ type Sources interface {
 	// GetProvisioningTypeId returns provisioning type ID
 	GetProvisioningTypeId(ctx context.Context) (string, error)
 
+	// CreateProvisioningSource creates a source with provisioning application and authentication
+	// (ARN, subscription ID or project ID) in a single call. Meant for development and ephemeral
+	// environments, tenants create sources through the UI.
+	CreateProvisioningSource(ctx context.Context, provider models.ProviderType, name, authentication string) error
+
 	// Ready returns readiness information
 	Ready(ctx context.Context) error
 }
@@ -45,10 +51,43 @@ type ImageBuilder interface {
 	// GetGCPImageName returns GCP image name
 	GetGCPImageName(ctx context.Context, composeID string) (string, error)
 
+	// GetImageArchitecture returns architecture of the compose image, or empty string when
+	// it cannot be determined (image clones do not carry the original request)
+	GetImageArchitecture(ctx context.Context, composeID string) (ArchitectureType, error)
+
 	// Ready returns readiness information
 	Ready(ctx context.Context) error
 }
 
+// GetObjectStorageClient returns ObjectStorage interface implementation. There are currently
+// two implementations available: HTTP (S3-compatible) and stub
+var GetObjectStorageClient func(ctx context.Context) (ObjectStorage, error)
+
+// ObjectStorage interface provides access to objects of the configured S3-compatible bucket
+type ObjectStorage interface {
+	// PutObject creates or replaces an object with user metadata, metadata can be nil
+	PutObject(ctx context.Context, key, contentType string, metadata map[string]string, data []byte) error
+
+	// GetObject returns content of an object or ObjectNotFoundErr
+	GetObject(ctx context.Context, key string) ([]byte, error)
+
+	// HeadObject returns user metadata of an object without its content or ObjectNotFoundErr
+	HeadObject(ctx context.Context, key string) (map[string]string, error)
+
+	// PresignGetObject returns URL which downloads an object without credentials until it expires,
+	// the object is not checked for existence
+	PresignGetObject(ctx context.Context, key string, expires time.Duration) (string, error)
+
+	// ListObjects returns keys of all objects starting with the prefix
+	ListObjects(ctx context.Context, prefix string) ([]string, error)
+
+	// DeleteObject deletes an object, deleting an object which does not exist is not an error
+	DeleteObject(ctx context.Context, key string) error
+
+	// DeletePrefix deletes all objects with keys starting with the prefix and returns their number
+	DeletePrefix(ctx context.Context, prefix string) (int64, error)
+}
+
 // ClientStatuser provides a function to test client connection. Since most clouds do not
 // provide any "ping" or "status" call, it is usually implemented via some "cheap" operation
 // which is fast and returns minimum amount of data (e.g. list regions or ssh-keys).
@@ -56,6 +95,16 @@ type ClientStatuser interface {
 	Status(ctx context.Context) error
 }
 
+// DNSRecorder manages A records of instances in DNS zones of the cloud account: Route 53 hosted
+// zones (AWS), DNS zones (Azure) or Cloud DNS managed zones (GCP).
+type DNSRecorder interface {
+	// CreateDNSRecord creates or replaces an A record, the name is relative to the zone.
+	CreateDNSRecord(ctx context.Context, zone, name, ipv4 string, ttl int64) (*models.DNSRecord, error)
+
+	// DeleteDNSRecord deletes an A record created by CreateDNSRecord.
+	DeleteDNSRecord(ctx context.Context, record *models.DNSRecord) error
+}
+
 // GetEC2Client returns an EC2 facade interface with assumed role.
 var GetEC2Client func(ctx context.Context, auth *Authentication, region string) (EC2, error)
 
@@ -64,6 +113,7 @@ var GetServiceEC2Client func(ctx context.Context, region string) (EC2, error)
 
 type EC2 interface {
 	ClientStatuser
+	DNSRecorder
 
 	// ListAllRegions returns list of all EC2 regions.
 	ListAllRegions(ctx context.Context) ([]Region, error)
@@ -86,18 +136,121 @@ type EC2 interface {
 	// ListLaunchTemplates lists all launch templates.
 	ListLaunchTemplates(ctx context.Context) ([]*LaunchTemplate, error)
 
+	// GetLaunchTemplateVersion returns the default version of the launch template found by ID ("lt-"
+	// prefix) or name, the same version used by launches.
+	GetLaunchTemplateVersion(ctx context.Context, template string) (*LaunchTemplateVersion, error)
+
 	// RunInstances launches one or more instances.
 	//
 	// All arguments are required except: launchTemplateID (empty string means no template in use).
 	//
 	RunInstances(ctx context.Context, details *AWSInstanceParams, amount int32, name *string, reservation *models.AWSReservation) ([]*string, *string, error)
 
+	// CreateFleet launches instances by an instant EC2 Fleet filling capacity from the pools in order
+	// of their priority, instance type and subnet of the details are ignored. Returns instances with
+	// their pools and the fleet ID. Instances launched before an error are returned together with it.
+	// Launch templates, network interfaces and dedicated hosts are not supported.
+	CreateFleet(ctx context.Context, details *AWSInstanceParams, pools []FleetPool, amount int32, name *string, reservation *models.AWSReservation) ([]*FleetInstance, *string, error)
+
 	// GetAccountId returns AWS account number.
 	GetAccountId(ctx context.Context) (string, error)
 
 	CheckPermission(ctx context.Context, auth *Authentication) ([]string, error)
 
+	// CheckKMSKeyAccess verifies the role can use the KMS key for volume encryption. Key ID, alias
+	// ("alias/name") or ARN can be passed. The key is described with the role and an error is returned
+	// when it is not found, not accessible or not enabled. The identity policy of the role is simulated
+	// on the key ARN and denied actions are returned, they are advisory as the key policy can allow them.
+	CheckKMSKeyAccess(ctx context.Context, auth *Authentication, keyId string) ([]string, error)
+
+	// CheckInstanceProfile verifies the IAM instance profile name or ARN exists in the account and
+	// simulates the policy of the role to verify it can pass the profile role. Returns the denied actions.
+	CheckInstanceProfile(ctx context.Context, auth *Authentication, profile string) ([]string, error)
+
 	DescribeInstanceDetails(ctx context.Context, InstanceIds []string) ([]*InstanceDescription, error)
+
+	// StopInstances stops instances and waits until they are stopped.
+	StopInstances(ctx context.Context, instanceIds []string) error
+
+	// StartInstances starts stopped instances and waits until they are running.
+	StartInstances(ctx context.Context, instanceIds []string) error
+
+	// ModifyInstanceType changes the instance type of a stopped instance.
+	ModifyInstanceType(ctx context.Context, instanceId, instanceType string) error
+
+	// GetConsoleOutput returns the serial console output and a screenshot of an instance.
+	GetConsoleOutput(ctx context.Context, instanceId string) (*ConsoleOutput, error)
+
+	// GetPowerState returns the current power state of an instance, terminated for instances
+	// which do not exist.
+	GetPowerState(ctx context.Context, instanceId string) (models.PowerState, error)
+
+	// ListTaggedInstances returns instances of the region with the reservation tag which are not
+	// terminated.
+	ListTaggedInstances(ctx context.Context) ([]*TaggedInstance, error)
+
+	// GetImageArchitecture returns architecture of an AMI available to the account.
+	GetImageArchitecture(ctx context.Context, ami string) (ArchitectureType, error)
+
+	// IsWindowsImage returns true when the platform of an AMI available to the account is Windows.
+	IsWindowsImage(ctx context.Context, ami string) (bool, error)
+
+	// GetPasswordData returns the Administrator password of a Windows instance encrypted with
+	// the public key of its key pair (base64 encoded), or empty string when it is not available yet.
+	GetPasswordData(ctx context.Context, instanceId string) (string, error)
+
+	// GetSubnetCIDR returns the IPv4 CIDR block of a subnet available to the account.
+	GetSubnetCIDR(ctx context.Context, subnetId string) (string, error)
+
+	// ListSubnets lists all subnets of the region available to the account.
+	ListSubnets(ctx context.Context) ([]*Subnet, error)
+
+	// ListSecurityGroups lists all security groups of the region available to the account.
+	ListSecurityGroups(ctx context.Context) ([]*SecurityGroup, error)
+
+	// ListDedicatedHosts lists all dedicated hosts of the region allocated in the account.
+	ListDedicatedHosts(ctx context.Context) ([]*DedicatedHost, error)
+
+	// ListCapacityReservations lists active capacity reservations of the region purchased in the
+	// account with instances still available.
+	ListCapacityReservations(ctx context.Context) ([]*CapacityReservation, error)
+
+	// GetVCPUQuota returns the on-demand vCPU quota of the region the instance type family is counted
+	// against and the amount of vCPUs currently used by pending or running instances of the quota. The
+	// standard instances quota is returned for an empty instance type.
+	GetVCPUQuota(ctx context.Context, instanceType string) (*Quota, error)
+
+	// ProbeCapacity returns availability zones of the region offering the instance type.
+	ProbeCapacity(ctx context.Context, instanceType string) (*Capacity, error)
+
+	// DescribeSpotRequests returns spot requests of spot instances, instances launched on-demand
+	// have no request.
+	DescribeSpotRequests(ctx context.Context, instanceIds []string) ([]*SpotRequest, error)
+
+	// CancelSpotInstances cancels spot requests and terminates their instances.
+	CancelSpotInstances(ctx context.Context, requestIds, instanceIds []string) error
+
+	// ListPoolElasticIPs returns Elastic IPs of the region tagged with the pool name which are not
+	// associated to an instance or a network interface.
+	ListPoolElasticIPs(ctx context.Context, pool string) ([]*models.ElasticIP, error)
+
+	// AllocateElasticIP allocates a new Elastic IP tagged with the reservation tag.
+	AllocateElasticIP(ctx context.Context, reservationId int64) (*models.ElasticIP, error)
+
+	// AssociateElasticIP associates an Elastic IP to the primary network interface of an instance
+	// and returns the association ID. Addresses which are already associated are never reassociated,
+	// ElasticIPInUseErr is returned instead.
+	AssociateElasticIP(ctx context.Context, allocationId, instanceId string) (string, error)
+
+	// DisassociateElasticIP disassociates an Elastic IP from its instance.
+	DisassociateElasticIP(ctx context.Context, associationId string) error
+
+	// ReleaseElasticIP releases an allocated Elastic IP, it must not be associated.
+	ReleaseElasticIP(ctx context.Context, allocationId string) error
+
+	// PublishEvent sends an event with the JSON detail to an SQS queue or an EventBridge event bus,
+	// the client must be created in the region of the target.
+	PublishEvent(ctx context.Context, target *EventTarget, detailType string, detail []byte) error
 }
 
 // GetAzureClient returns an Azure client with customer's subscription ID.
@@ -108,6 +261,7 @@ var GetServiceAzureClient func(ctx context.Context) (ServiceAzure, error)
 
 type Azure interface {
 	ClientStatuser
+	DNSRecorder
 
 	// TenantId returns current subscription's tenant
 	TenantId(ctx context.Context) (AzureTenantId, error)
@@ -120,6 +274,43 @@ type Azure interface {
 	CreateVMs(ctx context.Context, instanceParams AzureInstanceParams, amount int64, vmNamePrefix string) (vmIds []InstanceDescription, err error)
 
 	ListResourceGroups(ctx context.Context) ([]string, error)
+
+	// DeallocateVM stops a virtual machine by its resource ID and releases its compute resources,
+	// so it is not billed, and waits until it is deallocated.
+	DeallocateVM(ctx context.Context, vmId string) error
+
+	// StartVM starts a deallocated virtual machine by its resource ID and waits until it is running.
+	StartVM(ctx context.Context, vmId string) error
+
+	// ResizeVM changes the size of a deallocated virtual machine by its resource ID and waits until
+	// the change is applied.
+	ResizeVM(ctx context.Context, vmId, size string) error
+
+	// GetConsoleOutput returns the serial console log and a screenshot URL of a virtual machine
+	// by its resource ID, boot diagnostics must be enabled.
+	GetConsoleOutput(ctx context.Context, vmId string) (*ConsoleOutput, error)
+
+	// GetPowerState returns the current power state of a virtual machine by its resource ID,
+	// terminated for virtual machines which do not exist.
+	GetPowerState(ctx context.Context, vmId string) (models.PowerState, error)
+
+	// ListTaggedInstances returns virtual machines of the subscription with the reservation tag.
+	ListTaggedInstances(ctx context.Context) ([]*TaggedInstance, error)
+
+	// GetVCPUQuota returns the vCPU quota and its usage for the VM size in the given location. Of the
+	// total regional quota and the quota of the size family, the one with less remaining vCPUs is returned.
+	GetVCPUQuota(ctx context.Context, location, size string) (*Quota, error)
+
+	// ProbeCapacity returns availability of the VM size in the location and its zones with SKU
+	// restrictions of the subscription applied.
+	ProbeCapacity(ctx context.Context, location, size string) (*Capacity, error)
+
+	// DescribeMarketplaceImage returns the marketplace image with resolved version, purchase plan
+	// and architecture, NotFoundErr is returned when the image is not published in the location
+	DescribeMarketplaceImage(ctx context.Context, location string, image *AzureMarketplaceImage) (*AzureMarketplaceImage, error)
+
+	// AcceptMarketplaceTerms accepts terms of the purchase plan in the subscription, accepted terms are kept
+	AcceptMarketplaceTerms(ctx context.Context, plan *AzureImagePlan) error
 }
 
 type ServiceAzure interface {
@@ -144,6 +335,7 @@ type ServiceGCP interface {
 }
 type GCP interface {
 	ClientStatuser
+	DNSRecorder
 
 	// ListAllRegions returns list of all GCP regions
 	ListAllRegions(ctx context.Context) ([]Region, error)
@@ -156,5 +348,34 @@ type GCP interface {
 
 	GetInstanceDescriptionByID(ctx context.Context, id, zone string) (*InstanceDescription, error)
 
+	// StopInstance stops an instance and waits until it is stopped.
+	StopInstance(ctx context.Context, id, zone string) error
+
+	// StartInstance starts a stopped instance and waits until it is running.
+	StartInstance(ctx context.Context, id, zone string) error
+
+	// SetMachineType changes the machine type of a stopped instance and waits until the change is applied.
+	SetMachineType(ctx context.Context, id, zone, machineType string) error
+
+	// GetConsoleOutput returns the serial port output and a screenshot of an instance.
+	GetConsoleOutput(ctx context.Context, id, zone string) (*ConsoleOutput, error)
+
+	// GetPowerState returns the current power state of an instance, terminated for instances
+	// which do not exist.
+	GetPowerState(ctx context.Context, id, zone string) (models.PowerState, error)
+
+	// ListTaggedInstances returns instances of all zones of the project with the reservation label.
+	ListTaggedInstances(ctx context.Context) ([]*TaggedInstance, error)
+
 	ListLaunchTemplates(ctx context.Context) ([]*LaunchTemplate, error)
+
+	// ListImages returns non-deprecated images of the given projects
+	ListImages(ctx context.Context, projects []string) ([]*Image, error)
+
+	// GetImageFromFamily returns the newest non-deprecated image of a family in the project
+	GetImageFromFamily(ctx context.Context, project, family string) (*Image, error)
+
+	// GetVCPUQuota returns the vCPU quota of the machine type series and its usage for the given
+	// region, the CPUS quota is returned for series without a dedicated quota
+	GetVCPUQuota(ctx context.Context, region, machineType string) (*Quota, error)
 }
@@ -16,6 +16,10 @@ type Rbac interface {
 	// GetPrincipalAccess return an ACL object that can be used to check permissions
 	GetPrincipalAccess(ctx context.Context) (RbacAcl, error)
 
+	// GetUserAccess return an ACL object of a user of the tenant in the identity headers, it is used
+	// by service identities to check permissions of the user an operation is performed for.
+	GetUserAccess(ctx context.Context, username string) (RbacAcl, error)
+
 	// Ready returns readiness information
 	Ready(ctx context.Context) error
 }
@@ -25,6 +29,9 @@ type Rbac interface {
 type RbacAcl interface {
 	// IsAllowed checks if current account can perform "verb" on particular "resource"
 	IsAllowed(res, verb string) bool
+
+	// Workspaces returns workspace IDs "verb" on "resource" is restricted to, nil means no restriction
+	Workspaces(res, verb string) []string
 }
 
 // NoPermissionsRbacAcl is an access list which denies all access. This is used in case there is no ACL in context.
@@ -39,8 +46,16 @@ func (r noPermAcl) IsAllowed(_, _ string) bool {
 	return false
 }
 
+func (r noPermAcl) Workspaces(_, _ string) []string {
+	return nil
+}
+
 type allPermAcl struct{}
 
 func (r allPermAcl) IsAllowed(_, _ string) bool {
 	return true
 }
+
+func (r allPermAcl) Workspaces(_, _ string) []string {
+	return nil
+}
@@ -8,3 +8,12 @@ type LaunchTemplate struct {
 	// Name describes the launch template, user defined.
 	Name string
 }
+
+// LaunchTemplateVersion is the launch configuration of the default version of a launch template.
+type LaunchTemplateVersion struct {
+	// InstanceType instances are launched with, blank when the template does not define it.
+	InstanceType string
+
+	// ImageID instances are launched from, blank when the template does not define it.
+	ImageID string
+}
@@ -0,0 +1,37 @@
+package clients
+
+// Subnet represents a subnet of a virtual network (VPC) available to the account.
+type Subnet struct {
+	// ID is the subnet identifier, for example "subnet-0d2b4f8e1a9c3b7e5" for AWS EC2.
+	ID string
+
+	// Name of the subnet from the Name tag, blank when not tagged.
+	Name string
+
+	// VpcID is the identifier of the virtual network of the subnet.
+	VpcID string
+
+	// CIDR is the IPv4 CIDR block of the subnet.
+	CIDR string
+
+	// AvailabilityZone of the subnet.
+	AvailabilityZone string
+
+	// Default is true for the default subnet of the zone.
+	Default bool
+}
+
+// SecurityGroup represents a security group of a virtual network (VPC) available to the account.
+type SecurityGroup struct {
+	// ID is the security group identifier, for example "sg-07a7a4c3f5d6e8b91" for AWS EC2.
+	ID string
+
+	// Name of the security group, user defined.
+	Name string
+
+	// Description of the security group, user defined.
+	Description string
+
+	// VpcID is the identifier of the virtual network of the security group.
+	VpcID string
+}
@@ -0,0 +1,89 @@
+package clients
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Providers of ProviderError.
+const (
+	ProviderAWS          = "aws"
+	ProviderAzure        = "azure"
+	ProviderGCP          = "gcp"
+	ProviderSources      = "sources"
+	ProviderImageBuilder = "image_builder"
+	ProviderRbac         = "rbac"
+	ProviderS3           = "s3"
+)
+
+// ProviderError is a failed operation of a cloud provider or backend service API. Clients wrap
+// errors of SDKs and HTTP calls into it, so callers can check status of the operation with
+// errors.As instead of matching error messages. The message of the wrapped error is kept.
+type ProviderError struct {
+	// Provider is the cloud provider or backend service, e.g. aws or sources.
+	Provider string
+
+	// Operation is the API operation, e.g. RunInstances.
+	Operation string
+
+	// StatusCode is the HTTP status code of the response, zero when no response was received.
+	StatusCode int
+
+	// Code is the error code returned by the API, blank when not available.
+	Code string
+
+	// Throttled is true when the request was rejected by API rate limits.
+	Throttled bool
+
+	// Retryable is true when the operation may succeed when repeated later.
+	Retryable bool
+
+	// Err is the wrapped error.
+	Err error
+}
+
+// NewProviderError wraps an error with throttling and retryability derived from the HTTP status
+// code: too many requests responses are throttled, they and server errors are retryable. Err
+// must not be nil.
+func NewProviderError(provider, operation string, statusCode int, code string, err error) *ProviderError {
+	throttled := statusCode == http.StatusTooManyRequests
+	return &ProviderError{
+		Provider:   provider,
+		Operation:  operation,
+		StatusCode: statusCode,
+		Code:       code,
+		Throttled:  throttled,
+		Retryable:  throttled || statusCode >= http.StatusInternalServerError,
+		Err:        err,
+	}
+}
+
+func (e *ProviderError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ProviderError) Unwrap() error {
+	return e.Err
+}
+
+// AsProviderError returns the first provider error in the chain of err.
+func AsProviderError(err error) (*ProviderError, bool) {
+	var pe *ProviderError
+	if errors.As(err, &pe) {
+		return pe, true
+	}
+	return nil, false
+}
+
+// IsThrottled returns true when err was caused by API rate limits of a provider.
+func IsThrottled(err error) bool {
+	pe, ok := AsProviderError(err)
+	return ok && pe.Throttled
+}
+
+// IsRetryable returns true when err was caused by a provider operation that may succeed when
+// repeated later.
+func IsRetryable(err error) bool {
+	pe, ok := AsProviderError(err)
+	return ok && pe.Retryable
+}
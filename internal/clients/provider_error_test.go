@@ -0,0 +1,55 @@
+package clients
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProviderError(t *testing.T) {
+	cause := errors.New("rate exceeded")
+
+	t.Run("throttled", func(t *testing.T) {
+		err := NewProviderError(ProviderSources, "GetAuthentication", 429, "", cause)
+		assert.True(t, err.Throttled)
+		assert.True(t, err.Retryable)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		err := NewProviderError(ProviderSources, "GetAuthentication", 503, "", cause)
+		assert.False(t, err.Throttled)
+		assert.True(t, err.Retryable)
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		err := NewProviderError(ProviderSources, "GetAuthentication", 404, "", cause)
+		assert.False(t, err.Throttled)
+		assert.False(t, err.Retryable)
+	})
+}
+
+func TestProviderErrorWrapping(t *testing.T) {
+	pe := NewProviderError(ProviderAWS, "RunInstances", 400, "RequestLimitExceeded", NotFoundErr)
+	pe.Throttled = true
+	err := fmt.Errorf("cannot run instances: %w", pe)
+
+	assert.Equal(t, "cannot run instances: "+NotFoundErr.Error(), err.Error())
+	assert.ErrorIs(t, err, NotFoundErr)
+	assert.True(t, IsThrottled(err))
+	assert.False(t, IsRetryable(err))
+
+	found, ok := AsProviderError(err)
+	assert.True(t, ok)
+	assert.Equal(t, "RunInstances", found.Operation)
+	assert.Equal(t, "RequestLimitExceeded", found.Code)
+}
+
+func TestProviderErrorOther(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", NotFoundErr)
+	_, ok := AsProviderError(err)
+	assert.False(t, ok)
+	assert.False(t, IsThrottled(err))
+	assert.False(t, IsRetryable(err))
+}
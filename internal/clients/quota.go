@@ -0,0 +1,28 @@
+package clients
+
+// Quota represents a cloud provider limit of a resource (e.g. vCPUs in a region)
+// together with its current usage.
+type Quota struct {
+	// Name of the quota as reported by the provider
+	Name string `json:"name" yaml:"name"`
+
+	// Limit is the maximum amount of the resource
+	Limit int64 `json:"limit" yaml:"limit"`
+
+	// Usage is the amount of the resource currently in use
+	Usage int64 `json:"usage" yaml:"usage"`
+}
+
+// Remaining returns the amount of the resource which is still available, it never
+// returns a negative number.
+func (q *Quota) Remaining() int64 {
+	if q.Usage >= q.Limit {
+		return 0
+	}
+	return q.Limit - q.Usage
+}
+
+// Allows returns true when the requested amount fits into the remaining quota.
+func (q *Quota) Allows(requested int64) bool {
+	return requested <= q.Remaining()
+}
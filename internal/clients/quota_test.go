@@ -0,0 +1,23 @@
+package clients
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuota_Remaining(t *testing.T) {
+	q := Quota{Limit: 32, Usage: 30}
+	assert.Equal(t, int64(2), q.Remaining())
+}
+
+func TestQuota_RemainingOverLimit(t *testing.T) {
+	q := Quota{Limit: 32, Usage: 40}
+	assert.Equal(t, int64(0), q.Remaining())
+}
+
+func TestQuota_Allows(t *testing.T) {
+	q := Quota{Limit: 32, Usage: 30}
+	assert.True(t, q.Allows(2))
+	assert.False(t, q.Allows(3))
+}
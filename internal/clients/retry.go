@@ -0,0 +1,46 @@
+package clients
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/metrics"
+)
+
+// Reasons of provider retries reported in metrics.
+const (
+	RetryReasonThrottled = "throttled"
+	RetryReasonError     = "error"
+)
+
+// RetryDelay returns the delay before the retry attempt (1 for the first retry) of a cloud
+// provider call. The delay starts at the configured base delay and doubles with every attempt up
+// to the maximum delay, a random half of it is dropped so throttled callers do not retry at once.
+func RetryDelay(attempt int) time.Duration {
+	delay := config.Application.Retry.MaxDelay
+	if attempt < 1 {
+		attempt = 1
+	}
+	// larger shifts would overflow, the maximum delay is reached far earlier
+	if attempt <= 30 {
+		if d := config.Application.Retry.BaseDelay << (attempt - 1); d < delay {
+			delay = d
+		}
+	}
+	if delay <= 0 {
+		return 0
+	}
+	half := delay / 2
+	//nolint:gosec
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
+
+// CountRetry reports a retry of a cloud provider call in metrics.
+func CountRetry(provider string, throttled bool) {
+	reason := RetryReasonError
+	if throttled {
+		reason = RetryReasonThrottled
+	}
+	metrics.IncProviderRetry(provider, reason)
+}
@@ -0,0 +1,32 @@
+package clients
+
+import (
+	"testing"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryDelay(t *testing.T) {
+	config.Application.Retry.BaseDelay = 100 * time.Millisecond
+	config.Application.Retry.MaxDelay = time.Second
+	defer func() {
+		config.Application.Retry.BaseDelay = 0
+		config.Application.Retry.MaxDelay = 0
+	}()
+
+	for attempt, max := range map[int]time.Duration{
+		1:  100 * time.Millisecond,
+		2:  200 * time.Millisecond,
+		4:  800 * time.Millisecond,
+		5:  time.Second,
+		64: time.Second,
+	} {
+		for i := 0; i < 100; i++ {
+			delay := RetryDelay(attempt)
+			assert.GreaterOrEqual(t, delay, max/2, "attempt %d", attempt)
+			assert.LessOrEqual(t, delay, max, "attempt %d", attempt)
+		}
+	}
+}
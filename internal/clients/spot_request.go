@@ -0,0 +1,34 @@
+package clients
+
+const (
+	// SpotStateActive is the state of fulfilled spot requests with a running instance
+	SpotStateActive = "active"
+
+	// SpotStateOpen is the state of spot requests waiting for fulfillment
+	SpotStateOpen = "open"
+)
+
+// SpotRequest is an AWS spot instance request.
+type SpotRequest struct {
+	// Spot request ID ("sir-abcd1234")
+	ID string
+
+	// Instance ID of the request, blank until fulfilled
+	InstanceID string
+
+	// State of the request: open, active, closed, cancelled or failed
+	State string
+
+	// Status code of the request (e.g. fulfilled, capacity-not-available, price-too-low)
+	Status string
+}
+
+// Fulfilled returns true when the request launched its instance.
+func (r *SpotRequest) Fulfilled() bool {
+	return r.State == SpotStateActive
+}
+
+// Pending returns true when the request can be still fulfilled.
+func (r *SpotRequest) Pending() bool {
+	return r.State == SpotStateOpen
+}
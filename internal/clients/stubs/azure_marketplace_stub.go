@@ -0,0 +1,49 @@
+package stubs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+)
+
+// Marketplace offers known to the stubbed Azure client, the BYOS offer has a purchase plan
+const (
+	AzureMarketplaceOffer     = "RHEL"
+	AzureMarketplaceBYOSOffer = "rhel-byos"
+)
+
+// DidAcceptAzureMarketplaceTerms returns true when terms of the plan were accepted
+func DidAcceptAzureMarketplaceTerms(ctx context.Context, planName string) bool {
+	client, err := getAzureClientStub(ctx)
+	if err != nil {
+		return false
+	}
+	for _, plan := range client.acceptedPlans {
+		if plan.Name == planName {
+			return true
+		}
+	}
+	return false
+}
+
+func (stub *AzureClientStub) DescribeMarketplaceImage(ctx context.Context, location string, image *clients.AzureMarketplaceImage) (*clients.AzureMarketplaceImage, error) {
+	if image.Publisher != "RedHat" || (image.Offer != AzureMarketplaceOffer && image.Offer != AzureMarketplaceBYOSOffer) {
+		return nil, fmt.Errorf("image %s in %s: %w", image.URN(), location, clients.NotFoundErr)
+	}
+
+	result := *image
+	if result.Version == "latest" {
+		result.Version = "9.2.2023100409"
+	}
+	if image.Offer == AzureMarketplaceBYOSOffer {
+		result.Plan = &clients.AzureImagePlan{Publisher: image.Publisher, Product: image.Offer, Name: image.SKU}
+	}
+	result.Architecture = clients.ArchitectureTypeX86_64
+	return &result, nil
+}
+
+func (stub *AzureClientStub) AcceptMarketplaceTerms(ctx context.Context, plan *clients.AzureImagePlan) error {
+	stub.acceptedPlans = append(stub.acceptedPlans, plan)
+	return nil
+}
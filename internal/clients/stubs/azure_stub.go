@@ -9,6 +9,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
 	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
 )
 
 var ErrNotStartedVM = errors.New("the VM under given resumeToken not started")
@@ -17,6 +18,8 @@ type AzureClientStub struct {
 	startedVms []*armcompute.VirtualMachine
 	createdVms []*armcompute.VirtualMachine
 	createdRgs []*armresources.ResourceGroup
+
+	acceptedPlans []*clients.AzureImagePlan
 }
 
 func DidCreateAzureResourceGroup(ctx context.Context, name string) bool {
@@ -111,3 +114,38 @@ func (stub *AzureClientStub) TenantId(ctx context.Context) (clients.AzureTenantI
 func (stub *AzureClientStub) ListResourceGroups(ctx context.Context) ([]string, error) {
 	return []string{"firstGroup", "secondGroup", "test"}, nil
 }
+
+func (stub *AzureClientStub) GetVCPUQuota(ctx context.Context, location, size string) (*clients.Quota, error) {
+	return &clients.Quota{
+		Name:  "Total Regional vCPUs",
+		Limit: 10,
+		Usage: int64(len(stub.createdVms)),
+	}, nil
+}
+
+func (stub *AzureClientStub) DeallocateVM(ctx context.Context, vmId string) error {
+	return nil
+}
+
+func (stub *AzureClientStub) StartVM(ctx context.Context, vmId string) error {
+	return nil
+}
+
+func (stub *AzureClientStub) ResizeVM(ctx context.Context, vmId, size string) error {
+	return nil
+}
+
+func (stub *AzureClientStub) GetConsoleOutput(ctx context.Context, vmId string) (*clients.ConsoleOutput, error) {
+	return &clients.ConsoleOutput{
+		Output:        "[    0.000000] Linux version 6.2.9\n",
+		ScreenshotURL: "https://md-stub.blob.core.windows.net/screenshot.bmp?sig=stub",
+	}, nil
+}
+
+func (stub *AzureClientStub) GetPowerState(ctx context.Context, vmId string) (models.PowerState, error) {
+	return models.PowerStateRunning, nil
+}
+
+func (stub *AzureClientStub) ListTaggedInstances(ctx context.Context) ([]*clients.TaggedInstance, error) {
+	return nil, nil
+}
@@ -0,0 +1,29 @@
+package stubs
+
+import (
+	"context"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+)
+
+const (
+	// NoCapacityInstanceType is not offered in any zone by the stubbed EC2 client
+	NoCapacityInstanceType = "m5.large"
+
+	// RestrictedZoneInstanceSize is restricted in zone 1 by the stubbed Azure client
+	RestrictedZoneInstanceSize = "Standard_B2s"
+)
+
+func (mock *EC2ClientStub) ProbeCapacity(ctx context.Context, instanceType string) (*clients.Capacity, error) {
+	if instanceType == NoCapacityInstanceType {
+		return &clients.Capacity{}, nil
+	}
+	return &clients.Capacity{Regional: true, Zones: []string{"us-east-1a", "us-east-1b", "us-east-1c"}}, nil
+}
+
+func (stub *AzureClientStub) ProbeCapacity(ctx context.Context, location, size string) (*clients.Capacity, error) {
+	if size == RestrictedZoneInstanceSize {
+		return &clients.Capacity{Regional: true, Zones: []string{"2", "3"}}, nil
+	}
+	return &clients.Capacity{Regional: true, Zones: []string{"1", "2", "3"}}, nil
+}
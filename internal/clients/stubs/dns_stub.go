@@ -0,0 +1,42 @@
+package stubs
+
+import (
+	"context"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+)
+
+// MissingDNSZone is not found by stubbed clients
+const MissingDNSZone = "missing"
+
+func stubDNSRecord(zone, name, ipv4 string, ttl int64) (*models.DNSRecord, error) {
+	if zone == MissingDNSZone {
+		return nil, clients.DNSZoneNotFoundErr
+	}
+	return &models.DNSRecord{Zone: zone, Name: name + ".example.com", IPv4: ipv4, TTL: ttl}, nil
+}
+
+func (mock *EC2ClientStub) CreateDNSRecord(ctx context.Context, zone, name, ipv4 string, ttl int64) (*models.DNSRecord, error) {
+	return stubDNSRecord(zone, name, ipv4, ttl)
+}
+
+func (mock *EC2ClientStub) DeleteDNSRecord(ctx context.Context, record *models.DNSRecord) error {
+	return nil
+}
+
+func (stub *AzureClientStub) CreateDNSRecord(ctx context.Context, zone, name, ipv4 string, ttl int64) (*models.DNSRecord, error) {
+	return stubDNSRecord(zone, name, ipv4, ttl)
+}
+
+func (stub *AzureClientStub) DeleteDNSRecord(ctx context.Context, record *models.DNSRecord) error {
+	return nil
+}
+
+func (mock *GCPClientStub) CreateDNSRecord(ctx context.Context, zone, name, ipv4 string, ttl int64) (*models.DNSRecord, error) {
+	return stubDNSRecord(zone, name, ipv4, ttl)
+}
+
+func (mock *GCPClientStub) DeleteDNSRecord(ctx context.Context, record *models.DNSRecord) error {
+	return nil
+}
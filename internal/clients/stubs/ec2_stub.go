@@ -16,7 +16,22 @@ type ec2CtxKeyType int
 const ec2CtxKey ec2CtxKeyType = iota
 
 type EC2ClientStub struct {
-	Imported []*types.KeyPairInfo
+	Imported  []*types.KeyPairInfo
+	Published []*PublishedEvent
+
+	// PoolElasticIPs are unassociated addresses of all pools
+	PoolElasticIPs []*models.ElasticIP
+	// AllocatedElasticIPs are allocation IDs of addresses allocated by the stub
+	AllocatedElasticIPs []string
+	// ReleasedElasticIPs are allocation IDs of released addresses
+	ReleasedElasticIPs []string
+}
+
+// PublishedEvent is an event sent to an event target by the stub.
+type PublishedEvent struct {
+	Target     *clients.EventTarget
+	DetailType string
+	Detail     []byte
 }
 
 func init() {
@@ -38,6 +53,34 @@ func AddStubbedEC2KeyPair(ctx context.Context, info *types.KeyPairInfo) error {
 	return nil
 }
 
+// AddStubbedEC2ElasticIP adds an unassociated pool address to the stub.
+func AddStubbedEC2ElasticIP(ctx context.Context, eip *models.ElasticIP) error {
+	si, err := getEC2StubFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	si.PoolElasticIPs = append(si.PoolElasticIPs, eip)
+	return nil
+}
+
+// StubbedEC2ElasticIPs returns allocation IDs of addresses allocated and released by the stub.
+func StubbedEC2ElasticIPs(ctx context.Context) ([]string, []string, error) {
+	si, err := getEC2StubFromContext(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return si.AllocatedElasticIPs, si.ReleasedElasticIPs, nil
+}
+
+// StubbedEC2Events returns events published by the stub.
+func StubbedEC2Events(ctx context.Context) ([]*PublishedEvent, error) {
+	si, err := getEC2StubFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return si.Published, nil
+}
+
 func newEC2ServiceClientStubWithRegion(ctx context.Context, region string) (clients.EC2, error) {
 	return nil, nil
 }
@@ -159,23 +202,311 @@ func (mock *EC2ClientStub) CheckPermission(ctx context.Context, auth *clients.Au
 	return nil, nil
 }
 
+// CheckKMSKeyAccess denies access to key "alias/denied", policy of key "alias/restricted" is
+// simulated with denied actions
+func (mock *EC2ClientStub) CheckKMSKeyAccess(ctx context.Context, auth *clients.Authentication, keyId string) ([]string, error) {
+	switch keyId {
+	case "alias/denied":
+		return nil, fmt.Errorf("cannot describe KMS key %s: %w", keyId, http.KMSKeyAccessDeniedErr)
+	case "alias/restricted":
+		return []string{"kms:CreateGrant"}, nil
+	}
+	return nil, nil
+}
+
+// CheckInstanceProfile reports profiles named "missing-profile" as not found and denies passing
+// role of profiles named "unpassable-profile"
+func (mock *EC2ClientStub) CheckInstanceProfile(ctx context.Context, auth *clients.Authentication, profile string) ([]string, error) {
+	switch profile {
+	case "missing-profile":
+		return nil, fmt.Errorf("cannot get instance profile %s: %w", profile, http.InstanceProfileNotFoundErr)
+	case "unpassable-profile":
+		return []string{"iam:PassRole"}, nil
+	}
+	return nil, nil
+}
+
+// UnsubscribedMarketplaceAMI is an AWS Marketplace image without an accepted subscription
+const UnsubscribedMarketplaceAMI = "ami-0marketplace000000"
+
 func (mock *EC2ClientStub) RunInstances(ctx context.Context, details *clients.AWSInstanceParams, amount int32, name *string, reservation *models.AWSReservation) ([]*string, *string, error) {
-	return nil, nil, nil
+	if details.AMI == UnsubscribedMarketplaceAMI {
+		return nil, nil, clients.NewMarketplaceSubscriptionError("cpkwgozd4by0cmoinfftz1qqr", fmt.Errorf("OptInRequired: image %s", details.AMI))
+	}
+	if details.InstanceType == NoCapacityInstanceType {
+		return nil, nil, fmt.Errorf("%w: %s", clients.InsufficientCapacityErr, details.InstanceType)
+	}
+	if details.SubnetID == NoCapacitySubnetID {
+		return nil, nil, fmt.Errorf("%w: %s", clients.InsufficientCapacityErr, details.SubnetID)
+	}
+	if details.Spot && details.SpotMaxPrice == SpotUnavailablePrice {
+		return nil, nil, fmt.Errorf("%w: price %s", clients.SpotUnavailableErr, details.SpotMaxPrice)
+	}
+	if details.Spot && details.SpotMaxPrice == SpotPendingPrice {
+		return []*string{ptr.To(SpotPendingInstanceID)}, ptr.To("r-0bc6a4c1c88eb2d2e"), nil
+	}
+	return []*string{ptr.To("i-0a4caa2cf5b097ce1")}, ptr.To("r-0bc6a4c1c88eb2d2e"), nil
+}
+
+// CreateFleet launches all instances in the first pool with capacity
+func (mock *EC2ClientStub) CreateFleet(ctx context.Context, details *clients.AWSInstanceParams, pools []clients.FleetPool, amount int32, name *string, reservation *models.AWSReservation) ([]*clients.FleetInstance, *string, error) {
+	for _, pool := range pools {
+		if pool.InstanceType == NoCapacityInstanceType || pool.SubnetID == NoCapacitySubnetID {
+			continue
+		}
+		lifecycle := "on-demand"
+		if details.Spot {
+			lifecycle = "spot"
+		}
+		result := make([]*clients.FleetInstance, amount)
+		for i := range result {
+			result[i] = &clients.FleetInstance{
+				ID: fmt.Sprintf("i-0f1ee7%011d", i+1),
+				Allocation: models.FleetAllocation{
+					InstanceType: pool.InstanceType,
+					SubnetID:     pool.SubnetID,
+					Lifecycle:    lifecycle,
+				},
+			}
+		}
+		return result, ptr.To("fleet-0b5b2e6c8c1d4c6a9"), nil
+	}
+	return nil, ptr.To("fleet-0b5b2e6c8c1d4c6a9"), fmt.Errorf("%w: 0 of %d instances launched", clients.InsufficientCapacityErr, amount)
+}
+
+const (
+	// SpotUnavailablePrice is a spot price the stubbed EC2 client has no capacity for
+	SpotUnavailablePrice = "0.0001"
+
+	// SpotPendingPrice is a spot price of requests which are never fulfilled by the stubbed EC2 client
+	SpotPendingPrice = "0.0002"
+
+	// SpotPendingInstanceID is the instance of spot requests which are never fulfilled
+	SpotPendingInstanceID = "i-0spotpending00000"
+
+	// NoCapacitySubnetID is a subnet the stubbed EC2 client has no capacity in
+	NoCapacitySubnetID = "subnet-0nocapacity000000"
+)
+
+func (mock *EC2ClientStub) DescribeSpotRequests(ctx context.Context, instanceIds []string) ([]*clients.SpotRequest, error) {
+	result := make([]*clients.SpotRequest, len(instanceIds))
+	for i, id := range instanceIds {
+		result[i] = &clients.SpotRequest{ID: fmt.Sprintf("sir-%08d", i+1), InstanceID: id, State: clients.SpotStateActive, Status: "fulfilled"}
+		if id == SpotPendingInstanceID {
+			result[i].State = clients.SpotStateOpen
+			result[i].Status = "pending-fulfillment"
+		}
+	}
+	return result, nil
+}
+
+func (mock *EC2ClientStub) CancelSpotInstances(ctx context.Context, requestIds, instanceIds []string) error {
+	return nil
+}
+
+const (
+	// InUseElasticIPAllocationID is the pool address which was associated by someone else meanwhile
+	InUseElasticIPAllocationID = "eipalloc-0inuse000000000"
+	// FailingElasticIPInstanceID is the instance which fails all address associations
+	FailingElasticIPInstanceID = "i-0eipfailing00000"
+)
+
+func (mock *EC2ClientStub) ListPoolElasticIPs(ctx context.Context, pool string) ([]*models.ElasticIP, error) {
+	return mock.PoolElasticIPs, nil
+}
+
+func (mock *EC2ClientStub) AllocateElasticIP(ctx context.Context, reservationId int64) (*models.ElasticIP, error) {
+	n := len(mock.AllocatedElasticIPs) + 1
+	eip := &models.ElasticIP{
+		AllocationID: fmt.Sprintf("eipalloc-%017d", n),
+		PublicIP:     fmt.Sprintf("203.0.113.%d", n),
+	}
+	mock.AllocatedElasticIPs = append(mock.AllocatedElasticIPs, eip.AllocationID)
+	return eip, nil
+}
+
+func (mock *EC2ClientStub) AssociateElasticIP(ctx context.Context, allocationId, instanceId string) (string, error) {
+	if allocationId == InUseElasticIPAllocationID {
+		return "", clients.ElasticIPInUseErr
+	}
+	if instanceId == FailingElasticIPInstanceID {
+		return "", fmt.Errorf("stubbed association of %s: %w", instanceId, clients.NotFoundErr)
+	}
+	return "eipassoc-" + allocationId[len("eipalloc-"):], nil
+}
+
+func (mock *EC2ClientStub) DisassociateElasticIP(ctx context.Context, associationId string) error {
+	return nil
+}
+
+func (mock *EC2ClientStub) ReleaseElasticIP(ctx context.Context, allocationId string) error {
+	mock.ReleasedElasticIPs = append(mock.ReleasedElasticIPs, allocationId)
+	return nil
+}
+
+func (mock *EC2ClientStub) PublishEvent(ctx context.Context, target *clients.EventTarget, detailType string, detail []byte) error {
+	mock.Published = append(mock.Published, &PublishedEvent{Target: target, DetailType: detailType, Detail: detail})
+	return nil
 }
 
 func (mock *EC2ClientStub) GetAccountId(ctx context.Context) (string, error) {
 	return "", nil
 }
 
+// AddressPendingInstanceID is an instance which never leaves the pending state
+const AddressPendingInstanceID = "i-0addrpending00000"
+
+// DescribeInstanceDetails returns the same addresses for all instances, the stubbed instance
+// is described when no IDs are given
 func (mock *EC2ClientStub) DescribeInstanceDetails(ctx context.Context, InstanceIds []string) ([]*clients.InstanceDescription, error) {
-	id := "i-0a4caa2cf5b097ce1"
-	dns := "ec2-51-83-81-17.compute-1.amazonaws.com"
-	ip := "54.11.88.17"
-	return []*clients.InstanceDescription{
+	if len(InstanceIds) == 0 {
+		InstanceIds = []string{"i-0a4caa2cf5b097ce1"}
+	}
+	result := make([]*clients.InstanceDescription, len(InstanceIds))
+	for i, id := range InstanceIds {
+		result[i] = &clients.InstanceDescription{
+			ID:          id,
+			PublicDNS:   "ec2-51-83-81-17.compute-1.amazonaws.com",
+			PublicIPv4:  "54.11.88.17",
+			PrivateDNS:  "ip-172-31-5-17.ec2.internal",
+			PrivateIPv4: "172.31.5.17",
+		}
+		if id == AddressPendingInstanceID {
+			result[i] = &clients.InstanceDescription{ID: id, PrivateIPv4: "172.31.5.18", Pending: true}
+		}
+	}
+	return result, nil
+}
+
+func (mock *EC2ClientStub) GetImageArchitecture(ctx context.Context, ami string) (clients.ArchitectureType, error) {
+	return clients.ArchitectureTypeX86_64, nil
+}
+
+func (mock *EC2ClientStub) GetConsoleOutput(ctx context.Context, instanceId string) (*clients.ConsoleOutput, error) {
+	return &clients.ConsoleOutput{
+		Output: fmt.Sprintf("[    0.000000] Linux version 6.2.9\n%s login: ", instanceId),
+	}, nil
+}
+
+// TerminatedInstanceID is the only instance which does not exist in the stubbed account.
+const TerminatedInstanceID = "i-0terminated000000"
+
+// UntrackedInstanceID is a tagged instance of the stubbed account, it is not stored in stubbed DAO by default.
+const UntrackedInstanceID = "i-0untracked0000000"
+
+func (mock *EC2ClientStub) ListTaggedInstances(ctx context.Context) ([]*clients.TaggedInstance, error) {
+	return []*clients.TaggedInstance{
+		{ID: "i-0a4caa2cf5b097ce1", ReservationID: 1, Location: "us-east-1"},
+		{ID: UntrackedInstanceID, ReservationID: 1, Location: "us-east-1"},
+	}, nil
+}
+
+func (mock *EC2ClientStub) GetPowerState(ctx context.Context, instanceId string) (models.PowerState, error) {
+	if instanceId == TerminatedInstanceID {
+		return models.PowerStateTerminated, nil
+	}
+	return models.PowerStateRunning, nil
+}
+
+// WindowsAMI is the only AMI with Windows platform known to the stub.
+const WindowsAMI = "ami-0windows0000000000"
+
+func (mock *EC2ClientStub) IsWindowsImage(ctx context.Context, ami string) (bool, error) {
+	return ami == WindowsAMI, nil
+}
+
+func (mock *EC2ClientStub) GetPasswordData(ctx context.Context, instanceId string) (string, error) {
+	return "RW5jcnlwdGVkIHBhc3N3b3Jk", nil
+}
+
+func (mock *EC2ClientStub) GetLaunchTemplateVersion(ctx context.Context, template string) (*clients.LaunchTemplateVersion, error) {
+	return &clients.LaunchTemplateVersion{InstanceType: "t4g.nano", ImageID: "ami-0e4b2a5c7d9f13a68"}, nil
+}
+
+func (mock *EC2ClientStub) GetSubnetCIDR(ctx context.Context, subnetId string) (string, error) {
+	return "10.0.0.0/16", nil
+}
+
+func (mock *EC2ClientStub) ListSubnets(ctx context.Context) ([]*clients.Subnet, error) {
+	return []*clients.Subnet{
+		{
+			ID:               "subnet-0d2b4f8e1a9c3b7e5",
+			Name:             "private-1a",
+			VpcID:            "vpc-0a1b2c3d4e5f67890",
+			CIDR:             "10.0.1.0/24",
+			AvailabilityZone: "us-east-1a",
+		},
+		{
+			ID:               "subnet-0f3c5a9b2e8d1c4a6",
+			VpcID:            "vpc-0a1b2c3d4e5f67890",
+			CIDR:             "10.0.2.0/24",
+			AvailabilityZone: "us-east-1b",
+			Default:          true,
+		},
+	}, nil
+}
+
+func (mock *EC2ClientStub) ListSecurityGroups(ctx context.Context) ([]*clients.SecurityGroup, error) {
+	return []*clients.SecurityGroup{
+		{
+			ID:          "sg-07a7a4c3f5d6e8b91",
+			Name:        "default",
+			Description: "default VPC security group",
+			VpcID:       "vpc-0a1b2c3d4e5f67890",
+		},
+	}, nil
+}
+
+func (mock *EC2ClientStub) ListDedicatedHosts(ctx context.Context) ([]*clients.DedicatedHost, error) {
+	return []*clients.DedicatedHost{
+		{
+			ID:               "h-0a1b2c3d4e5f67890",
+			Name:             "build-hosts",
+			InstanceFamily:   "m5",
+			AvailabilityZone: "us-east-1a",
+			State:            "available",
+			AutoPlacement:    true,
+			AvailableVCPUs:   44,
+			Instances:        1,
+		},
+	}, nil
+}
+
+func (mock *EC2ClientStub) ListCapacityReservations(ctx context.Context) ([]*clients.CapacityReservation, error) {
+	return []*clients.CapacityReservation{
 		{
-			ID:         id,
-			PublicDNS:  dns,
-			PublicIPv4: ip,
+			ID:                    "cr-0a1b2c3d4e5f67890",
+			Name:                  "release-capacity",
+			InstanceType:          "t3.small",
+			AvailabilityZone:      "us-east-1a",
+			Platform:              "Linux/UNIX",
+			Tenancy:               "default",
+			InstanceMatchCriteria: "targeted",
+			TotalInstances:        10,
+			AvailableInstances:    4,
 		},
 	}, nil
 }
+
+func (mock *EC2ClientStub) GetVCPUQuota(ctx context.Context, instanceType string) (*clients.Quota, error) {
+	return &clients.Quota{
+		Name:  "Running On-Demand Standard (A, C, D, H, I, M, R, T, Z) instances",
+		Limit: 32,
+		Usage: 4,
+	}, nil
+}
+
+func (mock *EC2ClientStub) StopInstances(ctx context.Context, instanceIds []string) error {
+	return nil
+}
+
+func (mock *EC2ClientStub) StartInstances(ctx context.Context, instanceIds []string) error {
+	return nil
+}
+
+func (mock *EC2ClientStub) ModifyInstanceType(ctx context.Context, instanceId, instanceType string) error {
+	if instanceType == NoCapacityInstanceType {
+		return fmt.Errorf("%w: %s", clients.InsufficientCapacityErr, instanceType)
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
 	"github.com/RHEnVision/provisioning-backend/internal/ptr"
 )
 
@@ -131,3 +132,65 @@ func (mock *GCPServiceClientStub) ListAllRegionsAndZones(ctx context.Context) ([
 	}
 	return regions, zones, nil
 }
+
+func (mock *GCPClientStub) GetVCPUQuota(ctx context.Context, region, machineType string) (*clients.Quota, error) {
+	return &clients.Quota{
+		Name:  "CPUS",
+		Limit: 24,
+		Usage: int64(len(mock.Instances)),
+	}, nil
+}
+
+func (mock *GCPClientStub) StopInstance(ctx context.Context, id, zone string) error {
+	return nil
+}
+
+func (mock *GCPClientStub) StartInstance(ctx context.Context, id, zone string) error {
+	return nil
+}
+
+func (mock *GCPClientStub) SetMachineType(ctx context.Context, id, zone, machineType string) error {
+	return nil
+}
+
+func (mock *GCPClientStub) ListTaggedInstances(ctx context.Context) ([]*clients.TaggedInstance, error) {
+	return nil, nil
+}
+
+func (mock *GCPClientStub) GetPowerState(ctx context.Context, id, zone string) (models.PowerState, error) {
+	return models.PowerStateRunning, nil
+}
+
+func (mock *GCPClientStub) GetConsoleOutput(ctx context.Context, id, zone string) (*clients.ConsoleOutput, error) {
+	return &clients.ConsoleOutput{
+		Output: "[    0.000000] Linux version 6.2.9\n",
+	}, nil
+}
+
+// StubbedGCPImageFamily is the only image family known to the stubbed GCP client
+const StubbedGCPImageFamily = "rhel-9"
+
+func (mock *GCPClientStub) ListImages(ctx context.Context, projects []string) ([]*clients.Image, error) {
+	images := make([]*clients.Image, 0, len(projects))
+	for _, project := range projects {
+		images = append(images, newStubbedGCPImage(project))
+	}
+	return images, nil
+}
+
+func (mock *GCPClientStub) GetImageFromFamily(ctx context.Context, project, family string) (*clients.Image, error) {
+	if family != StubbedGCPImageFamily {
+		return nil, fmt.Errorf("image family %s in project %s: %w", family, project, clients.NotFoundErr)
+	}
+	return newStubbedGCPImage(project), nil
+}
+
+func newStubbedGCPImage(project string) *clients.Image {
+	return &clients.Image{
+		Name:         "rhel-9-v20231010",
+		Family:       StubbedGCPImageFamily,
+		Project:      project,
+		SelfLink:     fmt.Sprintf("https://www.googleapis.com/compute/v1/projects/%s/global/images/rhel-9-v20231010", project),
+		Architecture: clients.ArchitectureTypeX86_64,
+	}
+}
@@ -41,6 +41,10 @@ func (mock *ImageBuilderClientStub) GetAzureImageID(ctx context.Context, compose
 	return "/resourceGroups/redhat-deployed/providers/Microsoft.Compute/images/composer-api-92ea98f8-7697-472e-80b1-7454fa0e7fa7", nil
 }
 
+func (mock *ImageBuilderClientStub) GetImageArchitecture(ctx context.Context, composeID string) (clients.ArchitectureType, error) {
+	return clients.ArchitectureTypeX86_64, nil
+}
+
 func (mock *ImageBuilderClientStub) GetGCPImageName(ctx context.Context, composeID string) (string, error) {
 	return "projects/red-hat-image-builder/global/images/composer-api-871fa36d-0b5b-4001-8c95-a11f751a4d66-test", nil
 }
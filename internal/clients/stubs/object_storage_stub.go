@@ -0,0 +1,131 @@
+package stubs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+)
+
+type objectStorageCtxKeyType string
+
+var objectStorageCtxKey objectStorageCtxKeyType = "object-storage-interface"
+
+// ObjectStorageClientStub keeps objects in memory
+type ObjectStorageClientStub struct {
+	mu       sync.Mutex
+	objects  map[string][]byte
+	metadata map[string]map[string]string
+}
+
+func init() {
+	clients.GetObjectStorageClient = getObjectStorageClientStub
+}
+
+func WithObjectStorageClient(parent context.Context) context.Context {
+	ctx := context.WithValue(parent, objectStorageCtxKey, &ObjectStorageClientStub{objects: make(map[string][]byte), metadata: make(map[string]map[string]string)})
+	return ctx
+}
+
+func getObjectStorageClientStub(ctx context.Context) (si clients.ObjectStorage, err error) {
+	var ok bool
+	if si, ok = ctx.Value(objectStorageCtxKey).(*ObjectStorageClientStub); !ok {
+		err = ContextReadError
+	}
+	return si, err
+}
+
+// ObjectKeys returns keys of all stored objects
+func ObjectKeys(ctx context.Context) []string {
+	stub, ok := ctx.Value(objectStorageCtxKey).(*ObjectStorageClientStub)
+	if !ok {
+		return nil
+	}
+	stub.mu.Lock()
+	defer stub.mu.Unlock()
+
+	keys := make([]string, 0, len(stub.objects))
+	for key := range stub.objects {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func (stub *ObjectStorageClientStub) PutObject(ctx context.Context, key, contentType string, metadata map[string]string, data []byte) error {
+	stub.mu.Lock()
+	defer stub.mu.Unlock()
+
+	stub.objects[key] = data
+	stub.metadata[key] = metadata
+	return nil
+}
+
+func (stub *ObjectStorageClientStub) GetObject(ctx context.Context, key string) ([]byte, error) {
+	stub.mu.Lock()
+	defer stub.mu.Unlock()
+
+	data, ok := stub.objects[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", clients.ObjectNotFoundErr, key)
+	}
+	return data, nil
+}
+
+func (stub *ObjectStorageClientStub) HeadObject(ctx context.Context, key string) (map[string]string, error) {
+	stub.mu.Lock()
+	defer stub.mu.Unlock()
+
+	if _, ok := stub.objects[key]; !ok {
+		return nil, fmt.Errorf("%w: %s", clients.ObjectNotFoundErr, key)
+	}
+	metadata := make(map[string]string, len(stub.metadata[key]))
+	for name, value := range stub.metadata[key] {
+		metadata[name] = value
+	}
+	return metadata, nil
+}
+
+// PresignGetObject returns a fake URL of the object with the expiration in seconds
+func (stub *ObjectStorageClientStub) PresignGetObject(ctx context.Context, key string, expires time.Duration) (string, error) {
+	return fmt.Sprintf("https://objects.example.com/%s?X-Amz-Expires=%d&X-Amz-Signature=stub", key, int64(expires/time.Second)), nil
+}
+
+func (stub *ObjectStorageClientStub) ListObjects(ctx context.Context, prefix string) ([]string, error) {
+	stub.mu.Lock()
+	defer stub.mu.Unlock()
+
+	var keys []string
+	for key := range stub.objects {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (stub *ObjectStorageClientStub) DeleteObject(ctx context.Context, key string) error {
+	stub.mu.Lock()
+	defer stub.mu.Unlock()
+
+	delete(stub.objects, key)
+	delete(stub.metadata, key)
+	return nil
+}
+
+func (stub *ObjectStorageClientStub) DeletePrefix(ctx context.Context, prefix string) (int64, error) {
+	stub.mu.Lock()
+	defer stub.mu.Unlock()
+
+	var deleted int64
+	for key := range stub.objects {
+		if strings.HasPrefix(key, prefix) {
+			delete(stub.objects, key)
+			delete(stub.metadata, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
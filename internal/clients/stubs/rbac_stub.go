@@ -20,6 +20,10 @@ func (r rbacClient) GetPrincipalAccess(ctx context.Context) (clients.RbacAcl, er
 	return clients.AllPermissionsRbacAcl, nil
 }
 
+func (r rbacClient) GetUserAccess(ctx context.Context, username string) (clients.RbacAcl, error) {
+	return clients.AllPermissionsRbacAcl, nil
+}
+
 func (r rbacClient) Ready(ctx context.Context) error {
 	return nil
 }
@@ -39,7 +39,17 @@ func AddSource(ctx context.Context, provider models.ProviderType) (*clients.Sour
 	if err != nil {
 		return nil, err
 	}
-	return stub.addSource(ctx, provider)
+	return stub.addSource(ctx, provider, "")
+}
+
+// AddSourceWithAuthentication adds a source with the authentication payload, e.g. a role of
+// another AWS partition.
+func AddSourceWithAuthentication(ctx context.Context, provider models.ProviderType, payload string) (*clients.Source, error) {
+	stub, err := getSourcesClientStub(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return stub.addSource(ctx, provider, payload)
 }
 
 func getSourcesClient(ctx context.Context) (clients.Sources, error) {
@@ -54,7 +64,7 @@ func getSourcesClientStub(ctx context.Context) (si *SourcesClientStub, err error
 	return si, err
 }
 
-func (stub *SourcesClientStub) addSource(ctx context.Context, provider models.ProviderType) (*clients.Source, error) {
+func (stub *SourcesClientStub) addSource(ctx context.Context, provider models.ProviderType, payload string) (*clients.Source, error) {
 	id := strconv.Itoa(len(stub.sources) + 2) // starts at 2 as 1 is reserved - TODO migrate users of the implicit id = 1
 	source := &clients.Source{
 		ID:   id,
@@ -72,6 +82,10 @@ func (stub *SourcesClientStub) addSource(ctx context.Context, provider models.Pr
 		return nil, NotImplementedErr
 	}
 
+	if payload != "" {
+		stub.auths[id] = clients.NewAuthentication(payload, provider)
+	}
+
 	stub.sources = append(stub.sources, source)
 	return source, nil
 }
@@ -98,6 +112,16 @@ func (mock *SourcesClientStub) GetProvisioningTypeId(ctx context.Context) (strin
 	return "11", nil
 }
 
+func (stub *SourcesClientStub) CreateProvisioningSource(ctx context.Context, provider models.ProviderType, name, authentication string) error {
+	source, err := stub.addSource(ctx, provider, "")
+	if err != nil {
+		return err
+	}
+	source.Name = name
+	stub.auths[source.ID] = clients.NewAuthentication(authentication, provider)
+	return nil
+}
+
 func (mock *SourcesClientStub) ListAllProvisioningSources(ctx context.Context) ([]*clients.Source, error) {
 	TestSourceData := []*clients.Source{
 		{
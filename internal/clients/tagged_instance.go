@@ -0,0 +1,41 @@
+package clients
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+)
+
+// ReservationTagKey is the tag (label on GCP) of instances launched by the service.
+const ReservationTagKey = "rh-rid"
+
+// ReservationTagValue returns the value of the reservation tag, the reservation ID is prefixed
+// and suffixed by the environment in non-production environments.
+func ReservationTagValue(reservationId int64) string {
+	return config.EnvironmentPrefix("r", strconv.FormatInt(reservationId, 10))
+}
+
+// ReservationIDFromTag returns the reservation ID of a reservation tag value, tags of other
+// environments are not matched.
+func ReservationIDFromTag(value string) (int64, bool) {
+	id, _, _ := strings.Cut(strings.TrimPrefix(value, "r-"), "-")
+	reservationId, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || ReservationTagValue(reservationId) != value {
+		return 0, false
+	}
+	return reservationId, true
+}
+
+// TaggedInstance is an instance found in a cloud account with the reservation tag of the
+// current environment.
+type TaggedInstance struct {
+	// ID of the instance as stored in reservation instances.
+	ID string
+
+	// ReservationID from the tag.
+	ReservationID int64
+
+	// AWS region, Azure location or GCP zone of the instance.
+	Location string
+}
@@ -0,0 +1,19 @@
+package clients
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReservationIDFromTag(t *testing.T) {
+	id, ok := ReservationIDFromTag(ReservationTagValue(42))
+	assert.True(t, ok)
+	assert.Equal(t, int64(42), id)
+
+	_, ok = ReservationIDFromTag("r-42-other")
+	assert.False(t, ok)
+
+	_, ok = ReservationIDFromTag("web")
+	assert.False(t, ok)
+}
@@ -20,14 +20,50 @@ var config struct {
 	App struct {
 		Port           int    `env:"PORT" env-default:"8000" env-description:"HTTP port of the API service"`
 		InstancePrefix string `env:"INSTANCE_PREFIX" env-default:"" env-description:"prefix for all VMs names"`
+		NamePattern    string `env:"NAME_PATTERN" env-default:"{provider}-{region}-{seq}" env-description:"pattern of generated reservation names when no name is given ({provider}, {region}, {type} and {seq} placeholders, {seq} is a per-account sequence)"`
 		RbacEnabled    bool   `env:"RBAC_ENABLED" env-default:"false" env-description:"RBAC checking (REST_ENDPOINTS_RBAC_URL must be present)"`
+		CloudClients   string `env:"CLOUD_CLIENTS" env-default:"sdk" env-description:"cloud provider clients (sdk, fake - in-memory without cloud credentials for development)"`
+		UserScoped     bool   `env:"USER_SCOPED" env-default:"false" env-description:"users without reservation admin permission only see reservations they created"`
+		TenantPurge    bool   `env:"TENANT_PURGE" env-default:"false" env-description:"org deletion events from the tenant lifecycle topic purge data of the org (stats process)"`
 		Notifications  struct {
 			Enabled bool `env:"ENABLED" env-default:"false" env-description:"notifications enabled"`
 		} `env-prefix:"NOTIFICATIONS_"`
+		Usage struct {
+			Enabled  bool          `env:"ENABLED" env-default:"false" env-description:"usage records of launched and terminated instances are sent to the billing kafka topic by the stats process"`
+			Interval time.Duration `env:"INTERVAL" env-default:"1m" env-description:"how often the stats process sends usage records which were not sent yet"`
+		} `env-prefix:"USAGE_"`
+		AccountUsage struct {
+			FlushInterval time.Duration `env:"FLUSH_INTERVAL" env-default:"1m" env-description:"interval of adding API calls and launches counted by API processes to daily usage of accounts in the database (0 = usage is not counted)"`
+		} `env-prefix:"ACCOUNT_USAGE_"`
+		RateLimit struct {
+			Enabled  bool          `env:"ENABLED" env-default:"false" env-description:"per-account API rate limiting (shared via redis application cache when enabled)"`
+			Requests int64         `env:"REQUESTS" env-default:"600" env-description:"maximum number of requests per account in one window"`
+			Window   time.Duration `env:"WINDOW" env-default:"1m" env-description:"rate limit window (time interval syntax)"`
+		} `env-prefix:"RATE_LIMIT_"`
+		CORS struct {
+			Origins        []string      `env:"ORIGINS" env-default:"" env-description:"origins allowed to call the API directly from a browser (e.g. http://localhost:1337 or https://*.example.com), asterisk allows all origins, CORS is disabled when blank"`
+			Methods        []string      `env:"METHODS" env-default:"GET,HEAD,POST,PUT,PATCH,DELETE,OPTIONS" env-description:"methods allowed in cross-origin requests"`
+			Headers        []string      `env:"HEADERS" env-default:"Accept,Authorization,Content-Type,If-None-Match,X-Correlation-Id,X-Rh-Identity" env-description:"request headers allowed in cross-origin requests"`
+			ExposedHeaders []string      `env:"EXPOSED_HEADERS" env-default:"ETag,Retry-After,X-Correlation-Id,X-RateLimit-Limit,X-RateLimit-Remaining,X-RateLimit-Reset,X-Rh-Version" env-description:"response headers readable by cross-origin clients"`
+			Credentials    bool          `env:"CREDENTIALS" env-default:"false" env-description:"allow cookies and authorization in cross-origin requests (cannot be combined with wildcard origins)"`
+			MaxAge         time.Duration `env:"MAX_AGE" env-default:"10m" env-description:"how long browsers cache preflight responses (time interval syntax)"`
+		} `env-prefix:"CORS_"`
+		RequestTimeout struct {
+			Header string        `env:"HEADER" env-default:"X-Rh-Timeout" env-description:"request header with the gateway timeout (seconds or time interval syntax), requests are cancelled when it elapses and the remaining time is sent to platform services, blank disables it"`
+			Margin time.Duration `env:"MARGIN" env-default:"1s" env-description:"subtracted from the gateway timeout, so an error is returned before the gateway gives up (time interval syntax)"`
+			Max    time.Duration `env:"MAX" env-default:"5m" env-description:"maximum accepted gateway timeout, larger values are capped (time interval syntax)"`
+		} `env-prefix:"REQUEST_TIMEOUT_"`
+		Retry struct {
+			MaxAttempts int           `env:"MAX_ATTEMPTS" env-default:"5" env-description:"maximum number of attempts of throttled Azure and GCP calls including the first one (AWS calls use AWS_MAX_ATTEMPTS)"`
+			BaseDelay   time.Duration `env:"BASE_DELAY" env-default:"500ms" env-description:"delay before the first retry of throttled cloud provider calls, doubled with every retry and jittered (time interval syntax)"`
+			MaxDelay    time.Duration `env:"MAX_DELAY" env-default:"20s" env-description:"maximum delay between retries of throttled cloud provider calls, longer Retry-After responses are not retried (time interval syntax)"`
+		} `env-prefix:"RETRY_"`
 		Cache struct {
-			Type       string        `env:"TYPE" env-default:"none" env-description:"application cache (none, redis)"`
-			Expiration time.Duration `env:"EXPIRATION" env-default:"1h" env-description:"expiration for both memory and Redis (time interval syntax)"`
-			Redis      struct {
+			Type          string        `env:"TYPE" env-default:"none" env-description:"application cache (none, redis)"`
+			Expiration    time.Duration `env:"EXPIRATION" env-default:"1h" env-description:"expiration for both memory and Redis (time interval syntax)"`
+			AppTypeIdTTL  time.Duration `env:"APP_TYPE_ID_TTL" env-default:"1h" env-description:"expiration of the Sources application type id, stale value is refreshed in background (0 = forever)"`
+			PrefetchTypes bool          `env:"PREFETCH_TYPES" env-default:"true" env-description:"load instance types of the default region in background when upload info of an AWS source is requested (redis only)"`
+			Redis         struct {
 				Host     string `env:"HOST" env-default:"localhost" env-description:"redis hostname"`
 				Port     int    `env:"PORT" env-default:"6379" env-description:"redis port"`
 				User     string `env:"USER" env-default:"" env-description:"redis username"`
@@ -37,6 +73,12 @@ var config struct {
 			Memory struct {
 				CleanupInterval time.Duration `env:"CLEANUP_INTERVAL" env-default:"5m" env-description:"in-memory expiration interval (time interval syntax)"`
 			} `env-prefix:"MEM_"`
+			WarmUp struct {
+				Enabled  bool          `env:"ENABLED" env-default:"false" env-description:"prefetch application type id and most active accounts on API start"`
+				Accounts int64         `env:"ACCOUNTS" env-default:"100" env-description:"number of most active accounts to preload"`
+				Since    time.Duration `env:"SINCE" env-default:"168h" env-description:"accounts with reservations created in this interval are considered active (time interval syntax)"`
+				Timeout  time.Duration `env:"TIMEOUT" env-default:"30s" env-description:"maximum duration of the warm-up, API starts regardless (time interval syntax)"`
+			} `env-prefix:"WARM_UP_"`
 		} `env-prefix:"CACHE_"`
 	} `env-prefix:"APP_"`
 	Stats struct {
@@ -44,12 +86,30 @@ var config struct {
 		ReservationsInterval time.Duration `env:"RESERVATIONS_INTERVAL" env-default:"10m" env-description:"how often to pull reservation statistics"`
 	} `env-prefix:"STATS_"`
 	Reservation struct {
-		CleanupEnabled  bool          `env:"CLEANUP_ENABLED" env-default:"false" env-description:"reservation cleanup enabled"`
-		Lifetime        time.Duration `env:"LIFETIME" env-default:"8760h" env-description:"how old reservation should be deleted, default equal to 365 days"`
-		CleanupInterval time.Duration `env:"CLEANUP_INTERVAL" env-default:"1h" env-description:"how often to cleanup the reservation"`
+		CleanupEnabled   bool          `env:"CLEANUP_ENABLED" env-default:"false" env-description:"reservation cleanup enabled"`
+		Lifetime         time.Duration `env:"LIFETIME" env-default:"8760h" env-description:"how old reservation should be deleted, default equal to 365 days"`
+		CleanupInterval  time.Duration `env:"CLEANUP_INTERVAL" env-default:"1h" env-description:"how often to cleanup the reservation"`
+		QuotaCheck       string        `env:"QUOTA_CHECK" env-default:"warn" env-description:"cloud provider vCPU quota check before launch (off, warn, deny)"`
+		CapacityCheck    string        `env:"CAPACITY_CHECK" env-default:"warn" env-description:"instance type capacity probe before launch (off, warn, deny)"`
+		CapacityHistory  time.Duration `env:"CAPACITY_HISTORY" env-default:"30m" env-description:"how long a launch failed on insufficient capacity marks the instance type and zone as likely unavailable"`
+		ScheduleInterval time.Duration `env:"SCHEDULE_INTERVAL" env-default:"1m" env-description:"how often to launch scheduled templates, zero disables scheduled launches"`
+		OrphanInterval   time.Duration `env:"ORPHAN_INTERVAL" env-default:"0" env-description:"how often the stats process detects orphaned instances in cloud accounts, zero disables the detection"`
+		DNSPattern       string        `env:"DNS_PATTERN" env-default:"{name}-{index}" env-description:"pattern of DNS record names of instances of reservations with a DNS zone ({name}, {id} and {index} placeholders, {index} starts at 1)"`
+		DNSTTL           int64         `env:"DNS_TTL" env-default:"300" env-description:"TTL of DNS records of instances in seconds"`
 	} `env-prefix:"RESERVATION_"`
+	Archive struct {
+		Enabled        bool          `env:"ENABLED" env-default:"false" env-description:"archive reservations to object storage before the cleanup deletes them"`
+		Endpoint       string        `env:"ENDPOINT" env-default:"" env-description:"S3-compatible object storage URL (e.g. http://localhost:9000 for MinIO), AWS S3 of the region when blank"`
+		Region         string        `env:"REGION" env-default:"us-east-1" env-description:"object storage region used for request signing"`
+		Bucket         string        `env:"BUCKET" env-default:"provisioning-archive" env-description:"object storage bucket name"`
+		Prefix         string        `env:"PREFIX" env-default:"reservations" env-description:"object key prefix of archived reservations"`
+		AccessKey      string        `env:"ACCESS_KEY" env-default:"" env-description:"object storage access key"`
+		SecretKey      string        `env:"SECRET_KEY" env-default:"" env-description:"object storage secret key"`
+		PublicEndpoint string        `env:"PUBLIC_ENDPOINT" env-default:"" env-description:"object storage URL used in signed download links of archives and support bundles, ENDPOINT when blank"`
+		LinkExpiration time.Duration `env:"LINK_EXPIRATION" env-default:"15m" env-description:"expiration of signed download links of archives and support bundles (time interval syntax, at most 7 days)"`
+	} `env-prefix:"ARCHIVE_"`
 	Database struct {
-		Host        string        `env:"HOST" env-default:"localhost" env-description:"main database hostname"`
+		Host        string        `env:"HOST" env-default:"localhost" env-description:"main database hostname or comma separated host[:port] list for failover"`
 		Port        uint16        `env:"PORT" env-default:"5432" env-description:"main database port"`
 		Name        string        `env:"NAME" env-default:"provisioning" env-description:"main database name"`
 		User        string        `env:"USER" env-default:"postgres" env-description:"main database username"`
@@ -60,6 +120,17 @@ var config struct {
 		MaxIdleTime time.Duration `env:"MAX_IDLE_TIME" env-default:"15m" env-description:"connection pool idle time (time interval syntax)"`
 		MaxLifetime time.Duration `env:"MAX_LIFETIME" env-default:"2h" env-description:"connection pool total lifetime (time interval syntax)"`
 		LogLevel    string        `env:"LOG_LEVEL" env-default:"info" env-description:"logging level of database logs"`
+		TargetAttrs string        `env:"TARGET_SESSION_ATTRS" env-default:"" env-description:"session type required from multiple hosts (any, read-write, primary, standby, prefer-standby)"`
+		ConnRetry   time.Duration `env:"CONN_RETRY" env-default:"1m" env-description:"how long to retry the initial connection (time interval syntax)"`
+		HealthCheck time.Duration `env:"HEALTH_CHECK" env-default:"10s" env-description:"connection health check interval (time interval syntax)"`
+		Timeout     time.Duration `env:"TIMEOUT" env-default:"30s" env-description:"default timeout of DAO operations, zero disables it (time interval syntax)"`
+		StmtTimeout time.Duration `env:"STATEMENT_TIMEOUT" env-default:"1m" env-description:"statement_timeout of all connections, zero disables it, not set in PgBouncer mode (time interval syntax)"`
+		PgBouncer   bool          `env:"PGBOUNCER" env-default:"false" env-description:"transaction pooling (PgBouncer) mode: simple protocol without prepared statements, notifications are polled, migrations must connect directly"`
+		Encryption  struct {
+			Keys      []string `env:"KEYS" env-default:"" env-description:"data keys of column encryption in id:base64 format (32 bytes), the first key encrypts and all keys decrypt, user submitted material is stored in plain text when blank"`
+			KMS       bool     `env:"KMS" env-default:"false" env-description:"data keys are AWS KMS ciphertext blobs decrypted with the AWS service account on start (envelope encryption)"`
+			KMSRegion string   `env:"KMS_REGION" env-default:"" env-description:"AWS KMS region, AWS_DEFAULT_REGION when blank"`
+		} `env-prefix:"ENCRYPTION_"`
 	} `env-prefix:"DATABASE_"`
 	Logging struct {
 		Level    string `env:"LEVEL" env-default:"info" env-description:"logger level (trace, debug, info, warn, error, fatal, panic)"`
@@ -93,6 +164,28 @@ var config struct {
 		Logging           bool          `env:"LOGGING" env-default:"false" env-description:"AWS service account logging (verbose)"`
 		AvailabilityDelay time.Duration `env:"AVAILABILITY_DELAY" env-default:"1s" env-description:"arbitrary delay between sources availability checks (time interval syntax)"`
 		AvailabilityRate  float32       `env:"AVAILABILITY_RATE" env-default:"1.0" env-description:"probability rate for availability checks (0.0 = all skipped, 1.0 = nothing skipped)"`
+		Endpoint          string        `env:"ENDPOINT" env-default:"" env-description:"custom endpoint URL for EC2, STS, IAM and service quotas (e.g. http://localhost:4566 for LocalStack), AWS_KEY and AWS_SECRET are used as static credentials"`
+		PathStyle         bool          `env:"PATH_STYLE" env-default:"true" env-description:"send all requests to the custom endpoint as-is without service or operation host prefixes"`
+		RetryMode         string        `env:"RETRY_MODE" env-default:"adaptive" env-description:"AWS SDK retry mode (standard, adaptive - client side rate limiting on throttling errors)"`
+		MaxAttempts       int           `env:"MAX_ATTEMPTS" env-default:"5" env-description:"maximum number of attempts of AWS SDK calls including the first one"`
+		DescribeRate      float64       `env:"DESCRIBE_RATE" env-default:"5" env-description:"maximum rate of EC2 Describe calls per second for every AWS account and region (0 = unlimited)"`
+		DescribeBurst     int           `env:"DESCRIBE_BURST" env-default:"10" env-description:"maximum number of EC2 Describe calls at once for every AWS account and region"`
+		SpotTimeout       time.Duration `env:"SPOT_TIMEOUT" env-default:"2m" env-description:"maximum wait for fulfillment of spot requests, unfulfilled requests are canceled (time interval syntax)"`
+		FleetThreshold    int32         `env:"FLEET_THRESHOLD" env-default:"0" env-description:"launches of more instances use EC2 Fleet (CreateFleet) to fill capacity across instance types and zones (0 = disabled)"`
+		RoleCache         bool          `env:"ROLE_CACHE" env-default:"true" env-description:"reuse assumed role credentials per account and role ARN instead of calling STS AssumeRole for every client"`
+		RoleRefresh       time.Duration `env:"ROLE_REFRESH" env-default:"5m" env-description:"cached assumed role credentials are renewed when they expire within the interval (time interval syntax)"`
+		GovCloud          struct {
+			Key           string `env:"KEY" env-default:"" env-description:"AWS GovCloud (aws-us-gov partition) service account key, GovCloud sources are not supported when blank"`
+			Secret        string `env:"SECRET" env-default:"" env-description:"AWS GovCloud service account secret"`
+			Session       string `env:"SESSION" env-default:"" env-description:"AWS GovCloud service account session"`
+			DefaultRegion string `env:"DEFAULT_REGION" env-default:"us-gov-west-1" env-description:"AWS GovCloud region when not provided"`
+		} `env-prefix:"GOVCLOUD_"`
+		China struct {
+			Key           string `env:"KEY" env-default:"" env-description:"AWS China (aws-cn partition) service account key, China sources are not supported when blank"`
+			Secret        string `env:"SECRET" env-default:"" env-description:"AWS China service account secret"`
+			Session       string `env:"SESSION" env-default:"" env-description:"AWS China service account session"`
+			DefaultRegion string `env:"DEFAULT_REGION" env-default:"cn-north-1" env-description:"AWS China region when not provided"`
+		} `env-prefix:"CHINA_"`
 	} `env-prefix:"AWS_"`
 	Azure struct {
 		TenantID            string `env:"TENANT_ID" env-default:"" env-description:"Azure service account tenant id"`
@@ -110,6 +203,7 @@ var config struct {
 		ProjectID         string        `env:"PROJECT_ID" env-default:"" env-description:"GCP service account project id"`
 		JSON              string        `env:"JSON" env-default:"e30K" env-description:"GCP service account credentials (base64 encoded)"`
 		DefaultZone       string        `env:"DEFAULT_ZONE" env-default:"us-east4" env-description:"GCP region when not provided"`
+		ImageProjects     []string      `env:"IMAGE_PROJECTS" env-default:"rhel-cloud" env-description:"GCP projects with public images listed together with the customer project"`
 		AvailabilityDelay time.Duration `env:"AVAILABILITY_DELAY" env-default:"1s" env-description:"arbitrary delay between sources availability checks (time interval syntax)"`
 		AvailabilityRate  float32       `env:"AVAILABILITY_RATE" env-default:"1.0" env-description:"probability rate for availability checks (0.0 = all skipped, 1.0 = nothing skipped)"`
 	} `env-prefix:"GCP_"`
@@ -135,14 +229,21 @@ var config struct {
 			Username string `env:"USERNAME" env-default:"" env-description:"sources credentials (dev only)"`
 			Password string `env:"PASSWORD" env-default:"" env-description:"sources credentials (dev only)"`
 			Proxy    proxy  `env-prefix:"PROXY_" env-description:"sources HTTP proxy (dev only)"`
+
+			Concurrency int `env:"CONCURRENCY" env-default:"8" env-description:"maximum parallel sources calls when listing details of all sources"`
 		} `env-prefix:"SOURCES_"`
 		TraceData bool `env:"TRACE_DATA" env-default:"true" env-description:"open telemetry HTTP context pass and trace"`
 	} `env-prefix:"REST_ENDPOINTS_"`
 	Worker struct {
-		Queue        string        `env:"QUEUE" env-default:"memory" env-description:"job worker implementation (memory, redis, sqs, postgres)"`
-		PollInterval time.Duration `env:"POLL_INTERVAL" env-default:"5s" env-description:"polling interval (network timeout)"`
-		Concurrency  int           `env:"CONCURRENCY" env-default:"33" env-description:"amount of worker polling goroutines (effective concurrency)"`
-		Timeout      time.Duration `env:"TIMEOUT" env-default:"30m" env-description:"total timeout for a single job to complete (duration)"`
+		Queue            string        `env:"QUEUE" env-default:"memory" env-description:"job worker implementation (memory, redis, sqs, postgres)"`
+		PollInterval     time.Duration `env:"POLL_INTERVAL" env-default:"5s" env-description:"polling interval (network timeout)"`
+		Concurrency      int           `env:"CONCURRENCY" env-default:"33" env-description:"amount of worker polling goroutines (effective concurrency)"`
+		Timeout          time.Duration `env:"TIMEOUT" env-default:"30m" env-description:"total timeout for a single job to complete (duration)"`
+		LaunchLimit      int           `env:"LAUNCH_LIMIT" env-default:"0" env-description:"maximum simultaneously running launch jobs per organization, excess jobs wait (0 = unlimited)"`
+		ResumeInterval   time.Duration `env:"RESUME_INTERVAL" env-default:"1m" env-description:"how often waiting launch jobs are enqueued when slots of crashed or hung jobs expire (0 = disabled)"`
+		IdentityLifetime time.Duration `env:"IDENTITY_LIFETIME" env-default:"15m" env-description:"age of token-based identity after which allowed job steps use service identity (0 = never)"`
+		AddressTimeout   time.Duration `env:"ADDRESS_TIMEOUT" env-default:"3m" env-description:"maximum wait for IP addresses and DNS names of launched instances, addresses of slower instances are not stored (time interval syntax)"`
+		ProbeTimeout     time.Duration `env:"PROBE_TIMEOUT" env-default:"5m" env-description:"default wait for launched instances to pass the health probe, slower reservations are marked unreachable (time interval syntax)"`
 	} `env-prefix:"WORKER_"`
 	Unleash struct {
 		Enabled     bool   `env:"ENABLED" env-default:"false" env-description:"unleash service (feature flags)"`
@@ -165,7 +266,25 @@ var config struct {
 			SaslMechanism    string `env:"MECHANISM" env-default:"" env-description:"kafka SASL mechanism (scram-sha-512, scram-sha-256 or plain)"`
 			SecurityProtocol string `env:"PROTOCOL" env-default:"" env-description:"kafka SASL security protocol"`
 		} `env-prefix:"SASL_"`
+		MaxInFlight     int `env:"MAX_IN_FLIGHT" env-default:"16" env-description:"maximum number of messages handled concurrently by a consumer, reading waits until a handler finishes"`
+		MaxMessageBytes int `env:"MAX_MESSAGE_BYTES" env-default:"1048576" env-description:"messages with larger key and value are skipped by consumers (0 for no limit)"`
 	} `env-prefix:"KAFKA_"`
+	Statuser struct {
+		ConsumerGroup string        `env:"CONSUMER_GROUP" env-default:"" env-description:"kafka consumer group of statuser replicas, partitions of the availability topic are split among them (blank for a single replica without committed offsets)"`
+		CheckInterval time.Duration `env:"CHECK_INTERVAL" env-default:"30s" env-description:"minimum interval between availability checks of the same source, more requests are skipped (time interval syntax, 0 = no limit)"`
+	} `env-prefix:"STATUSER_"`
+	Bus struct {
+		Transport  string `env:"TRANSPORT" env-default:"" env-description:"message bus implementation (kafka, memory or none), kafka when KAFKA_ENABLED is set and none otherwise when blank"`
+		BufferSize int    `env:"BUFFER_SIZE" env-default:"1024" env-description:"messages per topic buffered by the memory bus for consumers, more messages are dropped"`
+	} `env-prefix:"BUS_"`
+	Chaos struct {
+		Enabled     bool     `env:"ENABLED" env-default:"false" env-description:"chaos fault injection for resilience testing (dev and stage only)"`
+		Rules       []string `env:"RULES" env-default:"" env-description:"incoming request rules (path_prefix=latency:error_rate list), asterisk matches all paths"`
+		ClientRules []string `env:"CLIENT_RULES" env-default:"" env-description:"outbound request rules (host_and_path_prefix=latency:error_rate list), asterisk matches all requests"`
+	} `env-prefix:"CHAOS_"`
+	Admin struct {
+		Token string `env:"TOKEN" env-default:"" env-description:"pre-shared token for the admin API on the metrics port, the API is disabled when blank"`
+	} `env-prefix:"ADMIN_"`
 }
 
 // Config shortcuts
@@ -173,6 +292,7 @@ var (
 	Application   = &config.App
 	Stats         = &config.Stats
 	Reservation   = &config.Reservation
+	Archive       = &config.Archive
 	Database      = &config.Database
 	Prometheus    = &config.Prometheus
 	Logging       = &config.Logging
@@ -189,12 +309,59 @@ var (
 	Unleash       = &config.Unleash
 	Sentry        = &config.Sentry
 	Kafka         = &config.Kafka
+	Statuser      = &config.Statuser
+	Bus           = &config.Bus
+	Chaos         = &config.Chaos
+	Admin         = &config.Admin
+)
+
+// Message bus transports
+const (
+	BusTransportKafka  = "kafka"
+	BusTransportMemory = "memory"
+	BusTransportNone   = "none"
+)
+
+// Reservation quota check modes
+const (
+	QuotaCheckOff  = "off"
+	QuotaCheckWarn = "warn"
+	QuotaCheckDeny = "deny"
+)
+
+// Reservation capacity check modes
+const (
+	CapacityCheckOff  = "off"
+	CapacityCheckWarn = "warn"
+	CapacityCheckDeny = "deny"
 )
 
+// Cloud provider client implementations
+const (
+	CloudClientsSDK  = "sdk"
+	CloudClientsFake = "fake"
+)
+
+// ArchiveBucketName is the object store bucket requested in the ClowdApp
+const ArchiveBucketName = "provisioning-archive"
+
 // Errors
 var (
 	validateMissingSecretError = errors.New("config error: Cloudwatch enabled but Region or Key or Secret are blank")
 	validateGroupStreamError   = errors.New("config error: Cloudwatch enabled but Group or Stream is blank")
+	validateQuotaCheckError    = errors.New("config error: Reservation quota check must be off, warn or deny")
+	validateCapacityCheckError = errors.New("config error: Reservation capacity check must be off, warn or deny")
+	validateChaosProdError     = errors.New("config error: Chaos must not be enabled in production")
+	validateCORSError          = errors.New("config error: CORS credentials cannot be allowed for wildcard origins")
+	validateCloudClientsError  = errors.New("config error: Cloud clients must be sdk or fake")
+	validateAWSEndpointError   = errors.New("config error: AWS endpoint requires static Key and Secret and is not allowed in production")
+	validateFakeClientsError   = errors.New("config error: Fake cloud clients are only allowed in development or ephemeral")
+	validateAWSRetryModeError  = errors.New("config error: AWS retry mode must be standard or adaptive")
+	validateRetryError         = errors.New("config error: Retry max attempts must be at least 1 and max delay must not be shorter than base delay")
+	validateArchiveError       = errors.New("config error: Archive enabled but Bucket or AccessKey or SecretKey are blank")
+	validateArchiveLinkError   = errors.New("config error: Archive link expiration must be between 1 second and 7 days")
+	validateAWSPartitionError  = errors.New("config error: AWS GovCloud or China Key requires Secret and DefaultRegion")
+	validateAWSRoleCacheError  = errors.New("config error: AWS role refresh must be between 0 and 45 minutes")
 )
 
 var hostname string
@@ -314,6 +481,23 @@ func Initialize(configFiles ...string) {
 			config.Cloudwatch.Stream = BinaryName()
 		}
 
+		// object storage
+		if bucket, ok := clowder.ObjectBuckets[ArchiveBucketName]; ok && cfg.ObjectStore != nil {
+			scheme := "http"
+			if cfg.ObjectStore.Tls {
+				scheme = "https"
+			}
+			config.Archive.Endpoint = fmt.Sprintf("%s://%s:%d", scheme, cfg.ObjectStore.Hostname, cfg.ObjectStore.Port)
+			config.Archive.Bucket = bucket.Name
+			if bucket.Region != nil && *bucket.Region != "" {
+				config.Archive.Region = *bucket.Region
+			}
+			if bucket.AccessKey != nil && bucket.SecretKey != nil {
+				config.Archive.AccessKey = *bucket.AccessKey
+				config.Archive.SecretKey = *bucket.SecretKey
+			}
+		}
+
 		// HTTP proxies are not allowed in clowder environment
 		config.RestEndpoints.Sources.Proxy.URL = ""
 		config.RestEndpoints.ImageBuilder.Proxy.URL = ""
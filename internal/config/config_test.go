@@ -21,3 +21,10 @@ func TestBlankNon1(t *testing.T) {
 func TestBlankNon2(t *testing.T) {
 	require.True(t, present("x", "x"))
 }
+
+func TestWildcardOrigin(t *testing.T) {
+	require.True(t, isWildcardOrigin("*"))
+	require.True(t, isWildcardOrigin("https://*"))
+	require.True(t, isWildcardOrigin("https://*.example.com"))
+	require.False(t, isWildcardOrigin("https://console.example.com"))
+}
@@ -29,6 +29,21 @@ func LaunchEnabled(ctx context.Context) bool {
 	return FeatureEnabled(ctx, fmt.Sprintf("%s.launch", config.Unleash.Prefix))
 }
 
+// LoadTestingEnabled: bulk noop reservations with delays and failures for job queue load testing.
+// This internal flag is disabled when not found in unleash and it is never enabled in production.
+func LoadTestingEnabled(ctx context.Context) bool {
+	if InProdClowder() {
+		return false
+	}
+	if !config.Unleash.Enabled {
+		return true
+	}
+
+	uctx := UnleashContext(ctx)
+	name := fmt.Sprintf("%s.load-testing", config.Unleash.Prefix)
+	return unleash.IsEnabled(name, unleash.WithContext(uctx), unleash.WithFallback(false))
+}
+
 func unleashLogger(ctx context.Context) *zerolog.Logger {
 	logger := zerolog.Ctx(ctx).With().Bool("unleash", true).Logger()
 	return &logger
@@ -49,6 +49,23 @@ func InEphemeralClowder() bool {
 	return InClowder() && strings.Contains(*clowder.LoadedConfig.Metadata.EnvName, "ephemeral")
 }
 
+// BusTransport returns the message bus implementation. When not set, Kafka is used when enabled
+// (always in clowder) and no transport otherwise.
+func BusTransport() string {
+	if Bus.Transport != "" {
+		return Bus.Transport
+	}
+	if Kafka.Enabled {
+		return BusTransportKafka
+	}
+	return BusTransportNone
+}
+
+// BusEnabled returns true when messages are sent and consumed via a message bus transport.
+func BusEnabled() bool {
+	return BusTransport() != BusTransportNone
+}
+
 func RedisHostAndPort() string {
 	return fmt.Sprintf("%s:%d", Application.Cache.Redis.Host, Application.Cache.Redis.Port)
 }
@@ -113,12 +130,25 @@ func DumpConfig(logger zerolog.Logger) {
 	replacement := "****"
 	configCopy := config
 	configCopy.Database.Password = replacement
+	if len(configCopy.Database.Encryption.Keys) > 0 {
+		configCopy.Database.Encryption.Keys = []string{replacement}
+	}
+	configCopy.App.Cache.Redis.Password = replacement
+	configCopy.Archive.SecretKey = replacement
+	configCopy.Admin.Token = replacement
+	configCopy.RestEndpoints.RBAC.Password = replacement
 	configCopy.Cloudwatch.Key = replacement
 	configCopy.Cloudwatch.Secret = replacement
 	configCopy.Cloudwatch.Session = replacement
 	configCopy.AWS.Key = replacement
 	configCopy.AWS.Secret = replacement
 	configCopy.AWS.Session = replacement
+	configCopy.AWS.GovCloud.Key = replacement
+	configCopy.AWS.GovCloud.Secret = replacement
+	configCopy.AWS.GovCloud.Session = replacement
+	configCopy.AWS.China.Key = replacement
+	configCopy.AWS.China.Secret = replacement
+	configCopy.AWS.China.Session = replacement
 	configCopy.RestEndpoints.Sources.Password = replacement
 	configCopy.RestEndpoints.ImageBuilder.Password = replacement
 	configCopy.Azure.ClientID = replacement
@@ -133,3 +163,39 @@ func DumpConfig(logger zerolog.Logger) {
 	}
 	logger.Info().Msgf("Configuration: %+v", configCopy)
 }
+
+// Secrets returns configured credentials which must never appear in logs or responses, blank
+// values are skipped.
+func Secrets() []string {
+	values := []string{
+		config.Database.Password,
+		config.App.Cache.Redis.Password,
+		config.Cloudwatch.Secret,
+		config.Cloudwatch.Session,
+		config.AWS.Secret,
+		config.AWS.Session,
+		config.AWS.GovCloud.Secret,
+		config.AWS.GovCloud.Session,
+		config.AWS.China.Secret,
+		config.AWS.China.Session,
+		config.Azure.ClientSecret,
+		config.GCP.JSON,
+		config.Archive.SecretKey,
+		config.RestEndpoints.RBAC.Password,
+		config.RestEndpoints.Sources.Password,
+		config.RestEndpoints.ImageBuilder.Password,
+		config.Unleash.Token,
+		config.Kafka.SASL.Username,
+		config.Kafka.SASL.Password,
+		config.Admin.Token,
+	}
+	values = append(values, config.Database.Encryption.Keys...)
+
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
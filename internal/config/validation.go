@@ -4,6 +4,10 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/slices"
 )
 
 // present checks if all arguments are not blank
@@ -26,6 +30,68 @@ func validate() error {
 		}
 	}
 
+	switch Reservation.QuotaCheck {
+	case QuotaCheckOff, QuotaCheckWarn, QuotaCheckDeny:
+	default:
+		return validateQuotaCheckError
+	}
+
+	switch Reservation.CapacityCheck {
+	case CapacityCheckOff, CapacityCheckWarn, CapacityCheckDeny:
+	default:
+		return validateCapacityCheckError
+	}
+
+	switch Application.CloudClients {
+	case CloudClientsSDK:
+	case CloudClientsFake:
+		if InClowder() && !InEphemeralClowder() {
+			return validateFakeClientsError
+		}
+	default:
+		return validateCloudClientsError
+	}
+
+	switch AWS.RetryMode {
+	case "standard", "adaptive":
+	default:
+		return validateAWSRetryModeError
+	}
+
+	if Application.Retry.MaxAttempts < 1 || Application.Retry.MaxDelay < Application.Retry.BaseDelay {
+		return validateRetryError
+	}
+
+	if AWS.Endpoint != "" && (!present(AWS.Key, AWS.Secret) || InProdClowder()) {
+		return validateAWSEndpointError
+	}
+
+	if AWS.GovCloud.Key != "" && !present(AWS.GovCloud.Secret, AWS.GovCloud.DefaultRegion) ||
+		AWS.China.Key != "" && !present(AWS.China.Secret, AWS.China.DefaultRegion) {
+		return validateAWSPartitionError
+	}
+
+	// assumed role credentials expire after an hour, there would be nothing to reuse
+	if AWS.RoleRefresh < 0 || AWS.RoleRefresh > 45*time.Minute {
+		return validateAWSRoleCacheError
+	}
+
+	if Archive.Enabled && !present(Archive.Bucket, Archive.AccessKey, Archive.SecretKey) {
+		return validateArchiveError
+	}
+
+	if Archive.Enabled && (Archive.LinkExpiration < time.Second || Archive.LinkExpiration > 7*24*time.Hour) {
+		return validateArchiveLinkError
+	}
+
+	if Chaos.Enabled && InProdClowder() {
+		return validateChaosProdError
+	}
+
+	if Application.CORS.Credentials && slices.ContainsFunc(Application.CORS.Origins, isWildcardOrigin) {
+		return validateCORSError
+	}
+
 	slice, err := base64.StdEncoding.DecodeString(config.GCP.JSON)
 	config.GCP.JSON = string(slice)
 	if err != nil {
@@ -40,3 +106,7 @@ func validate() error {
 
 	return nil
 }
+
+func isWildcardOrigin(origin string) bool {
+	return strings.Contains(origin, "*")
+}
@@ -0,0 +1,157 @@
+// Package cron parses standard five field cron expressions (minute, hour, day of month, month
+// and day of week). Lists, ranges, steps and asterisks are supported, names of months and days
+// and special strings like @daily are not.
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidExpression = errors.New("invalid cron expression")
+
+// maxSearch limits searching for the next activation, expressions like "0 0 30 2 *"
+// never activate.
+const maxSearch = 5 * 366 * 24 * time.Hour
+
+type field struct {
+	min, max int
+}
+
+var fields = []field{
+	{0, 59}, // minute
+	{0, 23}, // hour
+	{1, 31}, // day of month
+	{1, 12}, // month
+	{0, 7},  // day of week, both 0 and 7 are Sunday
+}
+
+// Schedule is a parsed cron expression.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// day of month and day of week restrictions are combined with OR when both are set
+	domStar, dowStar bool
+}
+
+// Parse returns schedule for the expression.
+func Parse(expr string) (*Schedule, error) {
+	parts := strings.Fields(expr)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("%w: expected %d fields in '%s'", ErrInvalidExpression, len(fields), expr)
+	}
+
+	bits := make([]uint64, len(fields))
+	for i, part := range parts {
+		var err error
+		bits[i], err = parseField(part, fields[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Sunday is 0
+	if bits[4]&(1<<7) != 0 {
+		bits[4] |= 1
+	}
+
+	return &Schedule{
+		minute:  bits[0],
+		hour:    bits[1],
+		dom:     bits[2],
+		month:   bits[3],
+		dow:     bits[4],
+		domStar: strings.HasPrefix(parts[2], "*"),
+		dowStar: strings.HasPrefix(parts[4], "*"),
+	}, nil
+}
+
+func parseField(expr string, f field) (uint64, error) {
+	var result uint64
+	for _, item := range strings.Split(expr, ",") {
+		rng, stepStr, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepStr)
+			if err != nil || step < 1 {
+				return 0, fmt.Errorf("%w: step '%s'", ErrInvalidExpression, item)
+			}
+		}
+
+		start, end := f.min, f.max
+		if rng != "*" {
+			lo, hi, isRange := strings.Cut(rng, "-")
+			var err error
+			start, err = parseValue(lo, f)
+			if err != nil {
+				return 0, err
+			}
+			end = start
+			if isRange {
+				end, err = parseValue(hi, f)
+				if err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				end = f.max
+			}
+			if end < start {
+				return 0, fmt.Errorf("%w: range '%s'", ErrInvalidExpression, item)
+			}
+		}
+
+		for v := start; v <= end; v += step {
+			result |= 1 << uint(v)
+		}
+	}
+	return result, nil
+}
+
+func parseValue(str string, f field) (int, error) {
+	v, err := strconv.Atoi(str)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("%w: value '%s' out of range %d-%d", ErrInvalidExpression, str, f.min, f.max)
+	}
+	return v, nil
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// Next returns the first activation time after t in the location of t, or zero time when
+// the schedule never activates.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxSearch)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
@@ -0,0 +1,58 @@
+package cron_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/cron"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustTime(t *testing.T, str string) time.Time {
+	t.Helper()
+	result, err := time.Parse(time.RFC3339, str)
+	require.NoError(t, err)
+	return result
+}
+
+func TestNext(t *testing.T) {
+	tests := []struct {
+		expr     string
+		from     string
+		expected string
+	}{
+		{"* * * * *", "2023-05-01T10:00:30Z", "2023-05-01T10:01:00Z"},
+		{"*/15 * * * *", "2023-05-01T10:01:00Z", "2023-05-01T10:15:00Z"},
+		{"0 8 * * 1", "2023-05-01T08:00:00Z", "2023-05-08T08:00:00Z"},
+		{"0 8 * * 1", "2023-05-02T09:00:00Z", "2023-05-08T08:00:00Z"},
+		{"30 6 1 * *", "2023-12-15T00:00:00Z", "2024-01-01T06:30:00Z"},
+		{"0 0 29 2 *", "2023-01-01T00:00:00Z", "2024-02-29T00:00:00Z"},
+		{"0 9-17/4 * * 1-5", "2023-05-05T17:30:00Z", "2023-05-08T09:00:00Z"},
+		{"0 12 * * 7", "2023-05-01T00:00:00Z", "2023-05-07T12:00:00Z"},
+		{"0 0 1,15 * 3", "2023-05-02T00:00:00Z", "2023-05-03T00:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			schedule, err := cron.Parse(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, mustTime(t, tt.expected), schedule.Next(mustTime(t, tt.from)))
+		})
+	}
+}
+
+func TestNextNever(t *testing.T) {
+	schedule, err := cron.Parse("0 0 30 2 *")
+	require.NoError(t, err)
+	assert.True(t, schedule.Next(mustTime(t, "2023-01-01T00:00:00Z")).IsZero())
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "a * * * *", "@daily"} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := cron.Parse(expr)
+			require.ErrorIs(t, err, cron.ErrInvalidExpression)
+		})
+	}
+}
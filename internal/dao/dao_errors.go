@@ -16,6 +16,10 @@ var (
 	// Typically, REST requests should end up with 409 error
 	ErrAffectedMismatch = errors.New("unexpected affected rows")
 
+	// ErrTimeout is returned when an operation exceeds the query timeout or statement_timeout.
+	// Typically, REST requests should end up with 503 error
+	ErrTimeout = errors.New("database operation timed out")
+
 	// ErrValidation is returned when model does not validate
 	ErrValidation = errors.New("validation error")
 
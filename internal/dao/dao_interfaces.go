@@ -12,11 +12,16 @@ package dao
 
 import (
 	"context"
+	"time"
 
 	"github.com/RHEnVision/provisioning-backend/internal/clients"
 	"github.com/RHEnVision/provisioning-backend/internal/models"
 )
 
+// MaxCountTotal is the maximum number of rows counted for list totals, counting stops there
+// so large tables are not fully scanned.
+const MaxCountTotal = 10000
+
 var GetAccountDao func(ctx context.Context) AccountDao
 
 // AccountDao represents an account (tenant)
@@ -27,6 +32,44 @@ type AccountDao interface {
 	GetOrCreateByIdentity(ctx context.Context, orgId string, accountNumber string) (*models.Account, error)
 	GetByOrgId(ctx context.Context, orgId string) (*models.Account, error)
 	List(ctx context.Context, limit, offset int64) ([]*models.Account, error)
+
+	// UnscopedListMostActive returns accounts ordered by number of reservations created since
+	// the given time, accounts without reservations are not returned.
+	UnscopedListMostActive(ctx context.Context, since time.Time, limit int64) ([]*models.Account, error)
+
+	// NextNameSequence increments and returns the sequence of generated reservation names for
+	// a particular account, the first value is 1.
+	NextNameSequence(ctx context.Context) (int64, error)
+
+	// GetSettings returns settings of a particular account, defaults are returned when the
+	// account has no settings stored.
+	GetSettings(ctx context.Context) (*models.AccountSettings, error)
+
+	// UpdateSettings validates and stores settings of a particular account.
+	UpdateSettings(ctx context.Context, settings *models.AccountSettings) error
+
+	// GetSourceSettings returns settings of a source of a particular account, defaults are
+	// returned when the source has no settings stored.
+	GetSourceSettings(ctx context.Context, sourceId string) (*models.SourceSettings, error)
+
+	// UpdateSourceSettings validates and stores settings of a source of a particular account.
+	UpdateSourceSettings(ctx context.Context, settings *models.SourceSettings) error
+
+	// UnscopedAddUsage adds the counts to daily usage of their accounts, records are created
+	// when missing.
+	UnscopedAddUsage(ctx context.Context, usage []*models.AccountUsage) error
+
+	// ListUsage returns daily usage of a particular account since the given day, newest first.
+	ListUsage(ctx context.Context, since time.Time) ([]*models.AccountUsage, error)
+
+	// UnscopedListTopUsage returns accounts ordered by number of API calls since the given day,
+	// usage is summed over the period.
+	UnscopedListTopUsage(ctx context.Context, since time.Time, limit int64) ([]*models.AccountUsageTotal, error)
+
+	// UnscopedPurge deletes reservations, pubkeys, reservation templates, orphaned instances, usage and
+	// settings of the account and its sources and anonymizes its audit records in one transaction. The account is kept,
+	// so the audit records stay attributed to the organization.
+	UnscopedPurge(ctx context.Context, id int64) (*models.AccountPurge, error)
 }
 
 var GetPubkeyDao func(ctx context.Context) PubkeyDao
@@ -38,6 +81,9 @@ type PubkeyDao interface {
 	Update(ctx context.Context, pk *models.Pubkey) error
 	GetById(ctx context.Context, id int64) (*models.Pubkey, error)
 	List(ctx context.Context, limit, offset int64) ([]*models.Pubkey, error)
+
+	// Count returns number of pubkeys for a particular account, capped at MaxCountTotal.
+	Count(ctx context.Context) (int64, error)
 	Delete(ctx context.Context, id int64) error
 
 	UnscopedCreateResource(ctx context.Context, pkr *models.PubkeyResource) error
@@ -48,6 +94,45 @@ type PubkeyDao interface {
 
 var GetReservationDao func(ctx context.Context) ReservationDao
 
+// InstanceFilter restricts instances listed across reservations, zero values match all instances.
+type InstanceFilter struct {
+	// Provider of the reservation.
+	Provider models.ProviderType
+
+	// Region matches AWS region, Azure location and GCP region or zone.
+	Region string
+
+	// PowerState of the instance.
+	PowerState models.PowerState
+
+	// ByCreator restricts instances to reservations created by CreatedByUserID.
+	ByCreator bool
+
+	// CreatedByUserID matches instances of reservations created by the user when ByCreator is set. A
+	// blank value matches reservations created by identities without a user (e.g. service accounts).
+	CreatedByUserID string
+
+	// SourceID of the reservation.
+	SourceID string
+
+	// ReservationID matches instances of the reservation.
+	ReservationID int64
+}
+
+// ReservationFilter restricts reservations listed or exported for a particular account, zero
+// values match all reservations.
+type ReservationFilter struct {
+	// Label the reservations have.
+	Label string
+
+	// ByCreator restricts reservations to those created by CreatedByUserID.
+	ByCreator bool
+
+	// CreatedByUserID matches reservations created by the user when ByCreator is set. A blank value
+	// matches reservations created by identities without a user (e.g. service accounts).
+	CreatedByUserID string
+}
+
 // ReservationDao represents a reservation, an abstraction of one or more background jobs with
 // associated detail information different for different cloud providers (like number of vCPUs,
 // instance IDs created etc).
@@ -82,10 +167,52 @@ type ReservationDao interface {
 	// List returns reservation for a particular account.
 	List(ctx context.Context, limit, offset int64) ([]*models.Reservation, error)
 
+	// ListByCreator returns reservations created by the user for a particular account.
+	ListByCreator(ctx context.Context, userId string, limit, offset int64) ([]*models.Reservation, error)
+
+	// Count returns number of reservations for a particular account, capped at MaxCountTotal.
+	Count(ctx context.Context) (int64, error)
+
+	// CountByCreator returns number of reservations created by the user for a particular account,
+	// capped at MaxCountTotal.
+	CountByCreator(ctx context.Context, userId string) (int64, error)
+
+	// ListFiltered returns reservations matching the filter for a particular account.
+	ListFiltered(ctx context.Context, filter *ReservationFilter, limit, offset int64) ([]*models.Reservation, error)
+
+	// CountFiltered returns number of reservations matching the filter for a particular account,
+	// capped at MaxCountTotal.
+	CountFiltered(ctx context.Context, filter *ReservationFilter) (int64, error)
+
+	// ListLabelStats returns statistics of reservations matching the filter per label for a particular
+	// account ordered by label.
+	ListLabelStats(ctx context.Context, filter *ReservationFilter) ([]*models.LabelStats, error)
+
+	// ListByIDs returns reservations with given IDs matching the filter for a particular account. IDs
+	// which do not exist, do not match or belong to another account are silently skipped.
+	ListByIDs(ctx context.Context, ids []int64, filter *ReservationFilter) ([]*models.Reservation, error)
+
+	// Export calls the function for every reservation matching the filter of a particular account
+	// with provider details flattened, ordered by ID. Rows are streamed and not loaded into memory at once, the first error
+	// returned by the function stops the iteration and is returned.
+	Export(ctx context.Context, filter *ReservationFilter, fn func(*models.ReservationExport) error) error
+
 	// ListInstances returns instances associated to a reservation. UNSCOPED.
 	// It currently lists all instances and not instances for a reservation, this is a TODO.
 	ListInstances(ctx context.Context, reservationId int64) ([]*models.ReservationInstance, error)
 
+	// ListAllInstances returns instances of all reservations for a particular account matching
+	// the filter, ordered by reservation and instance ID.
+	ListAllInstances(ctx context.Context, filter *InstanceFilter, limit, offset int64) ([]*models.Instance, error)
+
+	// CountAllInstances returns number of instances of all reservations for a particular account
+	// matching the filter, capped at MaxCountTotal.
+	CountAllInstances(ctx context.Context, filter *InstanceFilter) (int64, error)
+
+	// WaitForUpdate blocks until status, step or finish time of the reservation differ from the given
+	// reservation read earlier, or the context is done. UNSCOPED.
+	WaitForUpdate(ctx context.Context, reservation *models.Reservation) error
+
 	// UpdateStatus sets status field and increment step counter by addSteps. UNSCOPED.
 	UpdateStatus(ctx context.Context, id int64, status string, addSteps int32) error
 
@@ -98,9 +225,33 @@ type ReservationDao interface {
 	// UpdateOperationNameForGCP updates GCP operation name field. UNSCOPED.
 	UpdateOperationNameForGCP(ctx context.Context, id int64, gcpOperationName string) error
 
-	// UpdateReservationInstance updates an instance with its description
+	// UpdateReservationInstance updates addresses of an instance from its description, other details are kept
 	UpdateReservationInstance(ctx context.Context, reservationID int64, instance *clients.InstanceDescription) error
 
+	// UpdateInstanceDNSRecord sets the DNS record of an instance and its name in the detail. UNSCOPED.
+	UpdateInstanceDNSRecord(ctx context.Context, reservationID int64, instanceID string, record *models.DNSRecord) error
+
+	// UpdateInstanceElasticIP sets the Elastic IP associated to an instance. UNSCOPED.
+	UpdateInstanceElasticIP(ctx context.Context, reservationID int64, instanceID string, eip *models.ElasticIP) error
+
+	// UpdateInstancePasswordData sets the encrypted Administrator password in the detail of an instance. UNSCOPED.
+	UpdateInstancePasswordData(ctx context.Context, reservationID int64, instanceID string, passwordData string) error
+
+	// UpdateInstancePowerState sets power state of an instance. When from states are given, the state
+	// is only changed from one of them and ErrAffectedMismatch is returned otherwise. UNSCOPED.
+	UpdateInstancePowerState(ctx context.Context, reservationID int64, instanceID string, state models.PowerState, from ...models.PowerState) error
+
+	// UpdateApproval changes the approval state of a reservation for a particular account from
+	// the given state and records the approver, ErrAffectedMismatch is returned when the
+	// reservation is in a different state.
+	UpdateApproval(ctx context.Context, id int64, from, to models.ApprovalState, approvedBy string) error
+
+	// UpdateLabels replaces labels of a reservation for a particular account.
+	UpdateLabels(ctx context.Context, id int64, labels []string) error
+
+	// UpdateReachable sets the health probe result of a reservation. UNSCOPED.
+	UpdateReachable(ctx context.Context, id int64, reachable bool) error
+
 	// FinishWithSuccess sets Success flag. UNSCOPED.
 	FinishWithSuccess(ctx context.Context, id int64) error
 
@@ -110,10 +261,119 @@ type ReservationDao interface {
 	// Delete deletes a reservation. Only used in tests and background cleanup job. UNSCOPED.
 	Delete(ctx context.Context, id int64) error
 
+	// UnscopedListInstanceSources returns sources of all accounts with instances, AWS sources
+	// are returned once per region. UNSCOPED.
+	UnscopedListInstanceSources(ctx context.Context) ([]*models.InstanceSource, error)
+
+	// UnscopedListPendingUsage returns instances of all accounts without the started usage record
+	// and terminated instances without the terminated usage record, ordered by account. UNSCOPED.
+	UnscopedListPendingUsage(ctx context.Context, limit int64) ([]*models.UsageInstance, error)
+
+	// UnscopedUpdateInstanceUsage sets which usage records of an instance were sent. UNSCOPED.
+	UnscopedUpdateInstanceUsage(ctx context.Context, reservationID int64, instanceID string, started, terminated bool) error
+
+	// UnscopedGetById returns reservation of any account. UNSCOPED.
+	UnscopedGetById(ctx context.Context, id int64) (*models.Reservation, error)
+
+	// UnscopedList returns reservations of all accounts ordered by ID, optionally only those which
+	// are still processing. Only used by administrators. UNSCOPED.
+	UnscopedList(ctx context.Context, pendingOnly bool, limit, offset int64) ([]*models.Reservation, error)
+
+	// UnscopedRequeue resets status, step, error and finish flags of a reservation, so it can be
+	// processed again. Only finished reservations are requeued, ErrAffectedMismatch is returned
+	// for reservations which are still processing. UNSCOPED.
+	UnscopedRequeue(ctx context.Context, id int64) error
+
+	// CreateJob stores the job of a reservation when it is enqueued. UNSCOPED.
+	CreateJob(ctx context.Context, job *models.ReservationJob) error
+
+	// UnscopedGetJob returns the stored job of a reservation. UNSCOPED.
+	UnscopedGetJob(ctx context.Context, reservationId int64) (*models.ReservationJob, error)
+
+	// CreateEvent validates and stores an event in the reservation timeline. UNSCOPED.
+	CreateEvent(ctx context.Context, event *models.ReservationEvent) error
+
+	// ListEvents returns the timeline of a reservation for a particular account ordered by ID.
+	ListEvents(ctx context.Context, reservationId int64, limit, offset int64) ([]*models.ReservationEvent, error)
+
+	// CountEvents returns number of events of a reservation for a particular account, capped at
+	// MaxCountTotal.
+	CountEvents(ctx context.Context, reservationId int64) (int64, error)
+
+	// UnscopedListEvents returns the timeline of a reservation of any account ordered by ID. UNSCOPED.
+	UnscopedListEvents(ctx context.Context, reservationId int64, limit int64) ([]*models.ReservationEvent, error)
+
+	// UnscopedListExpired returns reservations of all accounts older than the reservation
+	// lifetime ordered by ID. UNSCOPED.
+	UnscopedListExpired(ctx context.Context, limit int64) ([]*models.Reservation, error)
+
 	// Cleanup old reservations
 	Cleanup(ctx context.Context) error
 }
 
+var GetReservationTemplateDao func(ctx context.Context) ReservationTemplateDao
+
+// ReservationTemplateDao represents saved launch definitions which reservations can be created from.
+type ReservationTemplateDao interface {
+	// Create validates and creates a template for a particular account.
+	Create(ctx context.Context, template *models.ReservationTemplate) error
+
+	// GetById returns template for a particular account.
+	GetById(ctx context.Context, id int64) (*models.ReservationTemplate, error)
+
+	// List returns templates for a particular account ordered by ID.
+	List(ctx context.Context, limit, offset int64) ([]*models.ReservationTemplate, error)
+
+	// Count returns number of templates for a particular account, capped at MaxCountTotal.
+	Count(ctx context.Context) (int64, error)
+
+	// Delete deletes template for a particular account.
+	Delete(ctx context.Context, id int64) error
+
+	// UpdateSchedule sets the schedule of a template and the user it is launched for in a
+	// particular account, nil nextRunAt removes the schedule.
+	UpdateSchedule(ctx context.Context, id int64, cron string, nextRunAt *time.Time, userID, username string) error
+
+	// UnscopedListDue returns scheduled templates of all accounts with next run before the given
+	// time ordered by the next run. UNSCOPED.
+	UnscopedListDue(ctx context.Context, before time.Time, limit int64) ([]*models.ReservationTemplate, error)
+
+	// UnscopedClaimRun moves the next run of a template from runAt to nextRunAt (nil for one-shot
+	// schedules) and returns false when another process already claimed the run. UNSCOPED.
+	UnscopedClaimRun(ctx context.Context, id int64, runAt time.Time, nextRunAt *time.Time) (bool, error)
+
+	// UnscopedUpdateLastReservation stores the reservation created by the last run. UNSCOPED.
+	UnscopedUpdateLastReservation(ctx context.Context, id int64, reservationId int64) error
+}
+
+var GetAuditDao func(ctx context.Context) AuditDao
+
+// AuditDao represents changes of provisioned resources performed on behalf of users.
+type AuditDao interface {
+	// Create validates and stores a record for a particular account, the actor is taken
+	// from the identity.
+	Create(ctx context.Context, record *models.AuditRecord) error
+
+	// ListByReservation returns records of a reservation for a particular account ordered by ID.
+	ListByReservation(ctx context.Context, reservationId int64, limit, offset int64) ([]*models.AuditRecord, error)
+}
+
+var GetOrphanDao func(ctx context.Context) OrphanDao
+
+// OrphanDao represents instances out of sync between cloud accounts and the database.
+type OrphanDao interface {
+	// List returns orphans of a particular account ordered by ID.
+	List(ctx context.Context, limit, offset int64) ([]*models.OrphanedInstance, error)
+
+	// Count returns number of orphans of a particular account, capped at MaxCountTotal.
+	Count(ctx context.Context) (int64, error)
+
+	// ReplaceBySource replaces orphans of a source for a particular account, AWS orphans only in
+	// the region unless it is blank. Orphans which were already known keep their detection time,
+	// the newly detected ones are returned.
+	ReplaceBySource(ctx context.Context, provider models.ProviderType, sourceId, region string, orphans []*models.OrphanedInstance) ([]*models.OrphanedInstance, error)
+}
+
 var GetStatDao func(ctx context.Context) StatDao
 
 // StatDao represents an account (tenant)
@@ -0,0 +1,751 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/metrics"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+)
+
+// DAO decorators which record duration and errors of every method call. Missing rows are not
+// counted as errors, they are expected results of lookups.
+
+func observe(dao, method string, start time.Time, err error) {
+	metrics.ObserveDaoDuration(dao, method, time.Since(start))
+	if err != nil && !errors.Is(err, ErrNoRows) {
+		metrics.IncDaoError(dao, method)
+	}
+}
+
+type accountDaoMetrics struct {
+	next AccountDao
+}
+
+// InstrumentAccountDao wraps the DAO with latency and error metrics.
+func InstrumentAccountDao(next AccountDao) AccountDao {
+	return &accountDaoMetrics{next: next}
+}
+
+func (d *accountDaoMetrics) Create(ctx context.Context, pk *models.Account) error {
+	start := time.Now()
+	err := d.next.Create(ctx, pk)
+	observe("account", "Create", start, err)
+	return err
+}
+
+func (d *accountDaoMetrics) GetById(ctx context.Context, id int64) (*models.Account, error) {
+	start := time.Now()
+	result, err := d.next.GetById(ctx, id)
+	observe("account", "GetById", start, err)
+	return result, err
+}
+
+func (d *accountDaoMetrics) GetOrCreateByIdentity(ctx context.Context, orgId string, accountNumber string) (*models.Account, error) {
+	start := time.Now()
+	result, err := d.next.GetOrCreateByIdentity(ctx, orgId, accountNumber)
+	observe("account", "GetOrCreateByIdentity", start, err)
+	return result, err
+}
+
+func (d *accountDaoMetrics) GetByOrgId(ctx context.Context, orgId string) (*models.Account, error) {
+	start := time.Now()
+	result, err := d.next.GetByOrgId(ctx, orgId)
+	observe("account", "GetByOrgId", start, err)
+	return result, err
+}
+
+func (d *accountDaoMetrics) List(ctx context.Context, limit, offset int64) ([]*models.Account, error) {
+	start := time.Now()
+	result, err := d.next.List(ctx, limit, offset)
+	observe("account", "List", start, err)
+	return result, err
+}
+
+func (d *accountDaoMetrics) UnscopedListMostActive(ctx context.Context, since time.Time, limit int64) ([]*models.Account, error) {
+	start := time.Now()
+	result, err := d.next.UnscopedListMostActive(ctx, since, limit)
+	observe("account", "UnscopedListMostActive", start, err)
+	return result, err
+}
+
+func (d *accountDaoMetrics) NextNameSequence(ctx context.Context) (int64, error) {
+	start := time.Now()
+	result, err := d.next.NextNameSequence(ctx)
+	observe("account", "NextNameSequence", start, err)
+	return result, err
+}
+
+func (d *accountDaoMetrics) GetSettings(ctx context.Context) (*models.AccountSettings, error) {
+	start := time.Now()
+	result, err := d.next.GetSettings(ctx)
+	observe("account", "GetSettings", start, err)
+	return result, err
+}
+
+func (d *accountDaoMetrics) UpdateSettings(ctx context.Context, settings *models.AccountSettings) error {
+	start := time.Now()
+	err := d.next.UpdateSettings(ctx, settings)
+	observe("account", "UpdateSettings", start, err)
+	return err
+}
+
+func (d *accountDaoMetrics) GetSourceSettings(ctx context.Context, sourceId string) (*models.SourceSettings, error) {
+	start := time.Now()
+	result, err := d.next.GetSourceSettings(ctx, sourceId)
+	observe("account", "GetSourceSettings", start, err)
+	return result, err
+}
+
+func (d *accountDaoMetrics) UpdateSourceSettings(ctx context.Context, settings *models.SourceSettings) error {
+	start := time.Now()
+	err := d.next.UpdateSourceSettings(ctx, settings)
+	observe("account", "UpdateSourceSettings", start, err)
+	return err
+}
+
+func (d *accountDaoMetrics) UnscopedAddUsage(ctx context.Context, usage []*models.AccountUsage) error {
+	start := time.Now()
+	err := d.next.UnscopedAddUsage(ctx, usage)
+	observe("account", "UnscopedAddUsage", start, err)
+	return err
+}
+
+func (d *accountDaoMetrics) ListUsage(ctx context.Context, since time.Time) ([]*models.AccountUsage, error) {
+	start := time.Now()
+	result, err := d.next.ListUsage(ctx, since)
+	observe("account", "ListUsage", start, err)
+	return result, err
+}
+
+func (d *accountDaoMetrics) UnscopedListTopUsage(ctx context.Context, since time.Time, limit int64) ([]*models.AccountUsageTotal, error) {
+	start := time.Now()
+	result, err := d.next.UnscopedListTopUsage(ctx, since, limit)
+	observe("account", "UnscopedListTopUsage", start, err)
+	return result, err
+}
+
+func (d *accountDaoMetrics) UnscopedPurge(ctx context.Context, id int64) (*models.AccountPurge, error) {
+	start := time.Now()
+	result, err := d.next.UnscopedPurge(ctx, id)
+	observe("account", "UnscopedPurge", start, err)
+	return result, err
+}
+
+type pubkeyDaoMetrics struct {
+	next PubkeyDao
+}
+
+// InstrumentPubkeyDao wraps the DAO with latency and error metrics.
+func InstrumentPubkeyDao(next PubkeyDao) PubkeyDao {
+	return &pubkeyDaoMetrics{next: next}
+}
+
+func (d *pubkeyDaoMetrics) Create(ctx context.Context, pk *models.Pubkey) error {
+	start := time.Now()
+	err := d.next.Create(ctx, pk)
+	observe("pubkey", "Create", start, err)
+	return err
+}
+
+func (d *pubkeyDaoMetrics) Update(ctx context.Context, pk *models.Pubkey) error {
+	start := time.Now()
+	err := d.next.Update(ctx, pk)
+	observe("pubkey", "Update", start, err)
+	return err
+}
+
+func (d *pubkeyDaoMetrics) GetById(ctx context.Context, id int64) (*models.Pubkey, error) {
+	start := time.Now()
+	result, err := d.next.GetById(ctx, id)
+	observe("pubkey", "GetById", start, err)
+	return result, err
+}
+
+func (d *pubkeyDaoMetrics) List(ctx context.Context, limit, offset int64) ([]*models.Pubkey, error) {
+	start := time.Now()
+	result, err := d.next.List(ctx, limit, offset)
+	observe("pubkey", "List", start, err)
+	return result, err
+}
+
+func (d *pubkeyDaoMetrics) Count(ctx context.Context) (int64, error) {
+	start := time.Now()
+	result, err := d.next.Count(ctx)
+	observe("pubkey", "Count", start, err)
+	return result, err
+}
+
+func (d *pubkeyDaoMetrics) Delete(ctx context.Context, id int64) error {
+	start := time.Now()
+	err := d.next.Delete(ctx, id)
+	observe("pubkey", "Delete", start, err)
+	return err
+}
+
+func (d *pubkeyDaoMetrics) UnscopedCreateResource(ctx context.Context, pkr *models.PubkeyResource) error {
+	start := time.Now()
+	err := d.next.UnscopedCreateResource(ctx, pkr)
+	observe("pubkey", "UnscopedCreateResource", start, err)
+	return err
+}
+
+func (d *pubkeyDaoMetrics) UnscopedGetResourceBySourceAndRegion(ctx context.Context, pubkeyId int64, sourceId string, region string) (*models.PubkeyResource, error) {
+	start := time.Now()
+	result, err := d.next.UnscopedGetResourceBySourceAndRegion(ctx, pubkeyId, sourceId, region)
+	observe("pubkey", "UnscopedGetResourceBySourceAndRegion", start, err)
+	return result, err
+}
+
+func (d *pubkeyDaoMetrics) UnscopedListResourcesByPubkeyId(ctx context.Context, pkId int64) ([]*models.PubkeyResource, error) {
+	start := time.Now()
+	result, err := d.next.UnscopedListResourcesByPubkeyId(ctx, pkId)
+	observe("pubkey", "UnscopedListResourcesByPubkeyId", start, err)
+	return result, err
+}
+
+func (d *pubkeyDaoMetrics) UnscopedDeleteResource(ctx context.Context, id int64) error {
+	start := time.Now()
+	err := d.next.UnscopedDeleteResource(ctx, id)
+	observe("pubkey", "UnscopedDeleteResource", start, err)
+	return err
+}
+
+type reservationDaoMetrics struct {
+	next ReservationDao
+}
+
+// InstrumentReservationDao wraps the DAO with latency and error metrics.
+func InstrumentReservationDao(next ReservationDao) ReservationDao {
+	return &reservationDaoMetrics{next: next}
+}
+
+func (d *reservationDaoMetrics) CreateNoop(ctx context.Context, reservation *models.NoopReservation) error {
+	start := time.Now()
+	err := d.next.CreateNoop(ctx, reservation)
+	observe("reservation", "CreateNoop", start, err)
+	return err
+}
+
+func (d *reservationDaoMetrics) CreateAWS(ctx context.Context, reservation *models.AWSReservation) error {
+	start := time.Now()
+	err := d.next.CreateAWS(ctx, reservation)
+	observe("reservation", "CreateAWS", start, err)
+	return err
+}
+
+func (d *reservationDaoMetrics) CreateAzure(ctx context.Context, reservation *models.AzureReservation) error {
+	start := time.Now()
+	err := d.next.CreateAzure(ctx, reservation)
+	observe("reservation", "CreateAzure", start, err)
+	return err
+}
+
+func (d *reservationDaoMetrics) CreateGCP(ctx context.Context, reservation *models.GCPReservation) error {
+	start := time.Now()
+	err := d.next.CreateGCP(ctx, reservation)
+	observe("reservation", "CreateGCP", start, err)
+	return err
+}
+
+func (d *reservationDaoMetrics) CreateInstance(ctx context.Context, reservation *models.ReservationInstance) error {
+	start := time.Now()
+	err := d.next.CreateInstance(ctx, reservation)
+	observe("reservation", "CreateInstance", start, err)
+	return err
+}
+
+func (d *reservationDaoMetrics) GetById(ctx context.Context, id int64) (*models.Reservation, error) {
+	start := time.Now()
+	result, err := d.next.GetById(ctx, id)
+	observe("reservation", "GetById", start, err)
+	return result, err
+}
+
+func (d *reservationDaoMetrics) GetAWSById(ctx context.Context, id int64) (*models.AWSReservation, error) {
+	start := time.Now()
+	result, err := d.next.GetAWSById(ctx, id)
+	observe("reservation", "GetAWSById", start, err)
+	return result, err
+}
+
+func (d *reservationDaoMetrics) GetAzureById(ctx context.Context, id int64) (*models.AzureReservation, error) {
+	start := time.Now()
+	result, err := d.next.GetAzureById(ctx, id)
+	observe("reservation", "GetAzureById", start, err)
+	return result, err
+}
+
+func (d *reservationDaoMetrics) GetGCPById(ctx context.Context, id int64) (*models.GCPReservation, error) {
+	start := time.Now()
+	result, err := d.next.GetGCPById(ctx, id)
+	observe("reservation", "GetGCPById", start, err)
+	return result, err
+}
+
+func (d *reservationDaoMetrics) List(ctx context.Context, limit, offset int64) ([]*models.Reservation, error) {
+	start := time.Now()
+	result, err := d.next.List(ctx, limit, offset)
+	observe("reservation", "List", start, err)
+	return result, err
+}
+
+func (d *reservationDaoMetrics) ListByCreator(ctx context.Context, userId string, limit, offset int64) ([]*models.Reservation, error) {
+	start := time.Now()
+	result, err := d.next.ListByCreator(ctx, userId, limit, offset)
+	observe("reservation", "ListByCreator", start, err)
+	return result, err
+}
+
+func (d *reservationDaoMetrics) Count(ctx context.Context) (int64, error) {
+	start := time.Now()
+	result, err := d.next.Count(ctx)
+	observe("reservation", "Count", start, err)
+	return result, err
+}
+
+func (d *reservationDaoMetrics) CountByCreator(ctx context.Context, userId string) (int64, error) {
+	start := time.Now()
+	result, err := d.next.CountByCreator(ctx, userId)
+	observe("reservation", "CountByCreator", start, err)
+	return result, err
+}
+
+func (d *reservationDaoMetrics) ListByIDs(ctx context.Context, ids []int64, filter *ReservationFilter) ([]*models.Reservation, error) {
+	start := time.Now()
+	result, err := d.next.ListByIDs(ctx, ids, filter)
+	observe("reservation", "ListByIDs", start, err)
+	return result, err
+}
+
+func (d *reservationDaoMetrics) Export(ctx context.Context, filter *ReservationFilter, fn func(*models.ReservationExport) error) error {
+	start := time.Now()
+	err := d.next.Export(ctx, filter, fn)
+	observe("reservation", "Export", start, err)
+	return err
+}
+
+func (d *reservationDaoMetrics) ListInstances(ctx context.Context, reservationId int64) ([]*models.ReservationInstance, error) {
+	start := time.Now()
+	result, err := d.next.ListInstances(ctx, reservationId)
+	observe("reservation", "ListInstances", start, err)
+	return result, err
+}
+
+func (d *reservationDaoMetrics) WaitForUpdate(ctx context.Context, reservation *models.Reservation) error {
+	start := time.Now()
+	err := d.next.WaitForUpdate(ctx, reservation)
+	observe("reservation", "WaitForUpdate", start, err)
+	return err
+}
+
+func (d *reservationDaoMetrics) UpdateStatus(ctx context.Context, id int64, status string, addSteps int32) error {
+	start := time.Now()
+	err := d.next.UpdateStatus(ctx, id, status, addSteps)
+	observe("reservation", "UpdateStatus", start, err)
+	return err
+}
+
+func (d *reservationDaoMetrics) UnscopedUpdateAWSDetail(ctx context.Context, id int64, awsDetail *models.AWSDetail) error {
+	start := time.Now()
+	err := d.next.UnscopedUpdateAWSDetail(ctx, id, awsDetail)
+	observe("reservation", "UnscopedUpdateAWSDetail", start, err)
+	return err
+}
+
+func (d *reservationDaoMetrics) UpdateReservationIDForAWS(ctx context.Context, id int64, awsReservationId string) error {
+	start := time.Now()
+	err := d.next.UpdateReservationIDForAWS(ctx, id, awsReservationId)
+	observe("reservation", "UpdateReservationIDForAWS", start, err)
+	return err
+}
+
+func (d *reservationDaoMetrics) UpdateOperationNameForGCP(ctx context.Context, id int64, gcpOperationName string) error {
+	start := time.Now()
+	err := d.next.UpdateOperationNameForGCP(ctx, id, gcpOperationName)
+	observe("reservation", "UpdateOperationNameForGCP", start, err)
+	return err
+}
+
+func (d *reservationDaoMetrics) UpdateReservationInstance(ctx context.Context, reservationID int64, instance *clients.InstanceDescription) error {
+	start := time.Now()
+	err := d.next.UpdateReservationInstance(ctx, reservationID, instance)
+	observe("reservation", "UpdateReservationInstance", start, err)
+	return err
+}
+
+func (d *reservationDaoMetrics) UpdateInstanceDNSRecord(ctx context.Context, reservationID int64, instanceID string, record *models.DNSRecord) error {
+	start := time.Now()
+	err := d.next.UpdateInstanceDNSRecord(ctx, reservationID, instanceID, record)
+	observe("reservation", "UpdateInstanceDNSRecord", start, err)
+	return err
+}
+
+func (d *reservationDaoMetrics) UpdateInstanceElasticIP(ctx context.Context, reservationID int64, instanceID string, eip *models.ElasticIP) error {
+	start := time.Now()
+	err := d.next.UpdateInstanceElasticIP(ctx, reservationID, instanceID, eip)
+	observe("reservation", "UpdateInstanceElasticIP", start, err)
+	return err
+}
+
+func (d *reservationDaoMetrics) ListAllInstances(ctx context.Context, filter *InstanceFilter, limit, offset int64) ([]*models.Instance, error) {
+	start := time.Now()
+	result, err := d.next.ListAllInstances(ctx, filter, limit, offset)
+	observe("reservation", "ListAllInstances", start, err)
+	return result, err
+}
+
+func (d *reservationDaoMetrics) CountAllInstances(ctx context.Context, filter *InstanceFilter) (int64, error) {
+	start := time.Now()
+	result, err := d.next.CountAllInstances(ctx, filter)
+	observe("reservation", "CountAllInstances", start, err)
+	return result, err
+}
+
+func (d *reservationDaoMetrics) UpdateInstancePasswordData(ctx context.Context, reservationID int64, instanceID string, passwordData string) error {
+	start := time.Now()
+	err := d.next.UpdateInstancePasswordData(ctx, reservationID, instanceID, passwordData)
+	observe("reservation", "UpdateInstancePasswordData", start, err)
+	return err
+}
+
+func (d *reservationDaoMetrics) UpdateInstancePowerState(ctx context.Context, reservationID int64, instanceID string, state models.PowerState, from ...models.PowerState) error {
+	start := time.Now()
+	err := d.next.UpdateInstancePowerState(ctx, reservationID, instanceID, state, from...)
+	observe("reservation", "UpdateInstancePowerState", start, err)
+	return err
+}
+
+func (d *reservationDaoMetrics) UpdateReachable(ctx context.Context, id int64, reachable bool) error {
+	start := time.Now()
+	err := d.next.UpdateReachable(ctx, id, reachable)
+	observe("reservation", "UpdateReachable", start, err)
+	return err
+}
+
+func (d *reservationDaoMetrics) FinishWithSuccess(ctx context.Context, id int64) error {
+	start := time.Now()
+	err := d.next.FinishWithSuccess(ctx, id)
+	observe("reservation", "FinishWithSuccess", start, err)
+	return err
+}
+
+func (d *reservationDaoMetrics) FinishWithError(ctx context.Context, id int64, errorString string) error {
+	start := time.Now()
+	err := d.next.FinishWithError(ctx, id, errorString)
+	observe("reservation", "FinishWithError", start, err)
+	return err
+}
+
+func (d *reservationDaoMetrics) Delete(ctx context.Context, id int64) error {
+	start := time.Now()
+	err := d.next.Delete(ctx, id)
+	observe("reservation", "Delete", start, err)
+	return err
+}
+
+func (d *reservationDaoMetrics) UnscopedListInstanceSources(ctx context.Context) ([]*models.InstanceSource, error) {
+	start := time.Now()
+	result, err := d.next.UnscopedListInstanceSources(ctx)
+	observe("reservation", "UnscopedListInstanceSources", start, err)
+	return result, err
+}
+
+func (d *reservationDaoMetrics) UnscopedListPendingUsage(ctx context.Context, limit int64) ([]*models.UsageInstance, error) {
+	start := time.Now()
+	result, err := d.next.UnscopedListPendingUsage(ctx, limit)
+	observe("reservation", "UnscopedListPendingUsage", start, err)
+	return result, err
+}
+
+func (d *reservationDaoMetrics) UnscopedUpdateInstanceUsage(ctx context.Context, reservationID int64, instanceID string, started, terminated bool) error {
+	start := time.Now()
+	err := d.next.UnscopedUpdateInstanceUsage(ctx, reservationID, instanceID, started, terminated)
+	observe("reservation", "UnscopedUpdateInstanceUsage", start, err)
+	return err
+}
+
+func (d *reservationDaoMetrics) UnscopedGetById(ctx context.Context, id int64) (*models.Reservation, error) {
+	start := time.Now()
+	result, err := d.next.UnscopedGetById(ctx, id)
+	observe("reservation", "UnscopedGetById", start, err)
+	return result, err
+}
+
+func (d *reservationDaoMetrics) UnscopedList(ctx context.Context, pendingOnly bool, limit, offset int64) ([]*models.Reservation, error) {
+	start := time.Now()
+	result, err := d.next.UnscopedList(ctx, pendingOnly, limit, offset)
+	observe("reservation", "UnscopedList", start, err)
+	return result, err
+}
+
+func (d *reservationDaoMetrics) UnscopedRequeue(ctx context.Context, id int64) error {
+	start := time.Now()
+	err := d.next.UnscopedRequeue(ctx, id)
+	observe("reservation", "UnscopedRequeue", start, err)
+	return err
+}
+
+func (d *reservationDaoMetrics) CreateJob(ctx context.Context, job *models.ReservationJob) error {
+	start := time.Now()
+	err := d.next.CreateJob(ctx, job)
+	observe("reservation", "CreateJob", start, err)
+	return err
+}
+
+func (d *reservationDaoMetrics) UnscopedGetJob(ctx context.Context, reservationId int64) (*models.ReservationJob, error) {
+	start := time.Now()
+	result, err := d.next.UnscopedGetJob(ctx, reservationId)
+	observe("reservation", "UnscopedGetJob", start, err)
+	return result, err
+}
+
+func (d *reservationDaoMetrics) CreateEvent(ctx context.Context, event *models.ReservationEvent) error {
+	start := time.Now()
+	err := d.next.CreateEvent(ctx, event)
+	observe("reservation", "CreateEvent", start, err)
+	return err
+}
+
+func (d *reservationDaoMetrics) ListEvents(ctx context.Context, reservationId int64, limit, offset int64) ([]*models.ReservationEvent, error) {
+	start := time.Now()
+	result, err := d.next.ListEvents(ctx, reservationId, limit, offset)
+	observe("reservation", "ListEvents", start, err)
+	return result, err
+}
+
+func (d *reservationDaoMetrics) CountEvents(ctx context.Context, reservationId int64) (int64, error) {
+	start := time.Now()
+	result, err := d.next.CountEvents(ctx, reservationId)
+	observe("reservation", "CountEvents", start, err)
+	return result, err
+}
+
+func (d *reservationDaoMetrics) UnscopedListEvents(ctx context.Context, reservationId int64, limit int64) ([]*models.ReservationEvent, error) {
+	start := time.Now()
+	result, err := d.next.UnscopedListEvents(ctx, reservationId, limit)
+	observe("reservation", "UnscopedListEvents", start, err)
+	return result, err
+}
+
+func (d *reservationDaoMetrics) ListFiltered(ctx context.Context, filter *ReservationFilter, limit, offset int64) ([]*models.Reservation, error) {
+	start := time.Now()
+	result, err := d.next.ListFiltered(ctx, filter, limit, offset)
+	observe("reservation", "ListFiltered", start, err)
+	return result, err
+}
+
+func (d *reservationDaoMetrics) CountFiltered(ctx context.Context, filter *ReservationFilter) (int64, error) {
+	start := time.Now()
+	result, err := d.next.CountFiltered(ctx, filter)
+	observe("reservation", "CountFiltered", start, err)
+	return result, err
+}
+
+func (d *reservationDaoMetrics) ListLabelStats(ctx context.Context, filter *ReservationFilter) ([]*models.LabelStats, error) {
+	start := time.Now()
+	result, err := d.next.ListLabelStats(ctx, filter)
+	observe("reservation", "ListLabelStats", start, err)
+	return result, err
+}
+
+func (d *reservationDaoMetrics) UpdateLabels(ctx context.Context, id int64, labels []string) error {
+	start := time.Now()
+	err := d.next.UpdateLabels(ctx, id, labels)
+	observe("reservation", "UpdateLabels", start, err)
+	return err
+}
+
+func (d *reservationDaoMetrics) UpdateApproval(ctx context.Context, id int64, from, to models.ApprovalState, approvedBy string) error {
+	start := time.Now()
+	err := d.next.UpdateApproval(ctx, id, from, to, approvedBy)
+	observe("reservation", "UpdateApproval", start, err)
+	return err
+}
+
+func (d *reservationDaoMetrics) UnscopedListExpired(ctx context.Context, limit int64) ([]*models.Reservation, error) {
+	start := time.Now()
+	result, err := d.next.UnscopedListExpired(ctx, limit)
+	observe("reservation", "UnscopedListExpired", start, err)
+	return result, err
+}
+
+func (d *reservationDaoMetrics) Cleanup(ctx context.Context) error {
+	start := time.Now()
+	err := d.next.Cleanup(ctx)
+	observe("reservation", "Cleanup", start, err)
+	return err
+}
+
+type reservationTemplateDaoMetrics struct {
+	next ReservationTemplateDao
+}
+
+// InstrumentReservationTemplateDao wraps the DAO with latency and error metrics.
+func InstrumentReservationTemplateDao(next ReservationTemplateDao) ReservationTemplateDao {
+	return &reservationTemplateDaoMetrics{next: next}
+}
+
+func (d *reservationTemplateDaoMetrics) Create(ctx context.Context, template *models.ReservationTemplate) error {
+	start := time.Now()
+	err := d.next.Create(ctx, template)
+	observe("reservation_template", "Create", start, err)
+	return err
+}
+
+func (d *reservationTemplateDaoMetrics) GetById(ctx context.Context, id int64) (*models.ReservationTemplate, error) {
+	start := time.Now()
+	result, err := d.next.GetById(ctx, id)
+	observe("reservation_template", "GetById", start, err)
+	return result, err
+}
+
+func (d *reservationTemplateDaoMetrics) List(ctx context.Context, limit, offset int64) ([]*models.ReservationTemplate, error) {
+	start := time.Now()
+	result, err := d.next.List(ctx, limit, offset)
+	observe("reservation_template", "List", start, err)
+	return result, err
+}
+
+func (d *reservationTemplateDaoMetrics) Count(ctx context.Context) (int64, error) {
+	start := time.Now()
+	result, err := d.next.Count(ctx)
+	observe("reservation_template", "Count", start, err)
+	return result, err
+}
+
+func (d *reservationTemplateDaoMetrics) Delete(ctx context.Context, id int64) error {
+	start := time.Now()
+	err := d.next.Delete(ctx, id)
+	observe("reservation_template", "Delete", start, err)
+	return err
+}
+
+func (d *reservationTemplateDaoMetrics) UpdateSchedule(ctx context.Context, id int64, cron string, nextRunAt *time.Time, userID, username string) error {
+	start := time.Now()
+	err := d.next.UpdateSchedule(ctx, id, cron, nextRunAt, userID, username)
+	observe("reservation_template", "UpdateSchedule", start, err)
+	return err
+}
+
+func (d *reservationTemplateDaoMetrics) UnscopedListDue(ctx context.Context, before time.Time, limit int64) ([]*models.ReservationTemplate, error) {
+	start := time.Now()
+	result, err := d.next.UnscopedListDue(ctx, before, limit)
+	observe("reservation_template", "UnscopedListDue", start, err)
+	return result, err
+}
+
+func (d *reservationTemplateDaoMetrics) UnscopedClaimRun(ctx context.Context, id int64, runAt time.Time, nextRunAt *time.Time) (bool, error) {
+	start := time.Now()
+	result, err := d.next.UnscopedClaimRun(ctx, id, runAt, nextRunAt)
+	observe("reservation_template", "UnscopedClaimRun", start, err)
+	return result, err
+}
+
+func (d *reservationTemplateDaoMetrics) UnscopedUpdateLastReservation(ctx context.Context, id int64, reservationId int64) error {
+	start := time.Now()
+	err := d.next.UnscopedUpdateLastReservation(ctx, id, reservationId)
+	observe("reservation_template", "UnscopedUpdateLastReservation", start, err)
+	return err
+}
+
+type statDaoMetrics struct {
+	next StatDao
+}
+
+// InstrumentStatDao wraps the DAO with latency and error metrics.
+func InstrumentStatDao(next StatDao) StatDao {
+	return &statDaoMetrics{next: next}
+}
+
+func (d *statDaoMetrics) Get(ctx context.Context, delayMin int) (*models.Statistics, error) {
+	start := time.Now()
+	result, err := d.next.Get(ctx, delayMin)
+	observe("stat", "Get", start, err)
+	return result, err
+}
+
+type serviceDaoMetrics struct {
+	next ServiceDao
+}
+
+// InstrumentServiceDao wraps the DAO with latency and error metrics.
+func InstrumentServiceDao(next ServiceDao) ServiceDao {
+	return &serviceDaoMetrics{next: next}
+}
+
+func (d *serviceDaoMetrics) RecalculatePubkeyFingerprints(ctx context.Context) (int, error) {
+	start := time.Now()
+	result, err := d.next.RecalculatePubkeyFingerprints(ctx)
+	observe("service", "RecalculatePubkeyFingerprints", start, err)
+	return result, err
+}
+
+func (d *serviceDaoMetrics) ReencryptPubkeys(ctx context.Context) (int, error) {
+	start := time.Now()
+	result, err := d.next.ReencryptPubkeys(ctx)
+	observe("service", "ReencryptPubkeys", start, err)
+	return result, err
+}
+
+func (d *serviceDaoMetrics) ReencryptUserData(ctx context.Context) (int, error) {
+	start := time.Now()
+	result, err := d.next.ReencryptUserData(ctx)
+	observe("service", "ReencryptUserData", start, err)
+	return result, err
+}
+
+type auditDaoMetrics struct {
+	next AuditDao
+}
+
+// InstrumentAuditDao wraps the DAO with latency and error metrics.
+func InstrumentAuditDao(next AuditDao) AuditDao {
+	return &auditDaoMetrics{next: next}
+}
+
+func (d *auditDaoMetrics) Create(ctx context.Context, record *models.AuditRecord) error {
+	start := time.Now()
+	err := d.next.Create(ctx, record)
+	observe("audit", "Create", start, err)
+	return err
+}
+
+func (d *auditDaoMetrics) ListByReservation(ctx context.Context, reservationId int64, limit, offset int64) ([]*models.AuditRecord, error) {
+	start := time.Now()
+	result, err := d.next.ListByReservation(ctx, reservationId, limit, offset)
+	observe("audit", "ListByReservation", start, err)
+	return result, err
+}
+
+type orphanDaoMetrics struct {
+	next OrphanDao
+}
+
+// InstrumentOrphanDao wraps the DAO with latency and error metrics.
+func InstrumentOrphanDao(next OrphanDao) OrphanDao {
+	return &orphanDaoMetrics{next: next}
+}
+
+func (d *orphanDaoMetrics) List(ctx context.Context, limit, offset int64) ([]*models.OrphanedInstance, error) {
+	start := time.Now()
+	result, err := d.next.List(ctx, limit, offset)
+	observe("orphan", "List", start, err)
+	return result, err
+}
+
+func (d *orphanDaoMetrics) Count(ctx context.Context) (int64, error) {
+	start := time.Now()
+	result, err := d.next.Count(ctx)
+	observe("orphan", "Count", start, err)
+	return result, err
+}
+
+func (d *orphanDaoMetrics) ReplaceBySource(ctx context.Context, provider models.ProviderType, sourceId, region string, orphans []*models.OrphanedInstance) ([]*models.OrphanedInstance, error) {
+	start := time.Now()
+	result, err := d.next.ReplaceBySource(ctx, provider, sourceId, region, orphans)
+	observe("orphan", "ReplaceBySource", start, err)
+	return result, err
+}
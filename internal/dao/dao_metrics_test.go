@@ -0,0 +1,33 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/metrics"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingStatDao struct {
+	err error
+}
+
+func (d *failingStatDao) Get(_ context.Context, _ int) (*models.Statistics, error) {
+	return nil, d.err
+}
+
+func TestInstrumentedDaoCountsErrors(t *testing.T) {
+	errors0 := testutil.ToFloat64(metrics.DaoErrors.WithLabelValues("stat", "Get"))
+
+	_, err := InstrumentStatDao(&failingStatDao{err: errors.New("test")}).Get(context.Background(), 0)
+	require.Error(t, err)
+	assert.Equal(t, errors0+1, testutil.ToFloat64(metrics.DaoErrors.WithLabelValues("stat", "Get")))
+
+	_, err = InstrumentStatDao(&failingStatDao{err: ErrNoRows}).Get(context.Background(), 0)
+	require.ErrorIs(t, err, ErrNoRows)
+	assert.Equal(t, errors0+1, testutil.ToFloat64(metrics.DaoErrors.WithLabelValues("stat", "Get")), "no rows is not an error")
+}
@@ -10,4 +10,6 @@ var GetServiceDao func(ctx context.Context) ServiceDao
 // See pgx/service_pgx.go for documentation.
 type ServiceDao interface {
 	RecalculatePubkeyFingerprints(ctx context.Context) (int, error)
+	ReencryptPubkeys(ctx context.Context) (int, error)
+	ReencryptUserData(ctx context.Context) (int, error)
 }
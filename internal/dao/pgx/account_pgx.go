@@ -3,12 +3,16 @@ package pgx
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/RHEnVision/provisioning-backend/internal/dao"
 	"github.com/RHEnVision/provisioning-backend/internal/db"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
 	"github.com/RHEnVision/provisioning-backend/internal/models"
 	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
 )
 
 func init() {
@@ -18,26 +22,32 @@ func init() {
 type accountDao struct{}
 
 func getAccountDao(ctx context.Context) dao.AccountDao {
-	return &accountDao{}
+	return dao.InstrumentAccountDao(&accountDao{})
 }
 
 func (x *accountDao) Create(ctx context.Context, account *models.Account) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
 	query := `INSERT INTO accounts (account_number, org_id) VALUES ($1, $2) RETURNING id`
 	err := db.Pool.QueryRow(ctx, query, account.AccountNumber, account.OrgID).Scan(&account.ID)
 	if err != nil {
-		return fmt.Errorf("pgx error: %w", err)
+		return pgxError(err)
 	}
 
 	return nil
 }
 
 func (x *accountDao) GetById(ctx context.Context, id int64) (*models.Account, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
 	query := `SELECT * FROM accounts WHERE id = $1 LIMIT 1`
 	result := &models.Account{}
 
 	err := pgxscan.Get(ctx, db.Pool, result, query, id)
 	if err != nil {
-		return nil, fmt.Errorf("pgx error: %w", err)
+		return nil, pgxError(err)
 	}
 	return result, nil
 }
@@ -45,6 +55,9 @@ func (x *accountDao) GetById(ctx context.Context, id int64) (*models.Account, er
 // GetOrCreateByIdentity can be called multiple times on concurrent requests of new user accounts.
 // Parameter accountNumber is stored as NULL when empty.
 func (x *accountDao) GetOrCreateByIdentity(ctx context.Context, orgId string, accountNumber string) (*models.Account, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
 	result := &models.Account{}
 	account := sql.NullString{
 		String: accountNumber,
@@ -73,35 +86,307 @@ func (x *accountDao) GetOrCreateByIdentity(ctx context.Context, orgId string, ac
 
 	err := pgxscan.Get(ctx, db.Pool, result, query, orgId, account)
 	if err != nil {
-		return nil, fmt.Errorf("pgx error: %w", err)
+		return nil, pgxError(err)
 	}
 
 	return result, nil
 }
 
 func (x *accountDao) GetByOrgId(ctx context.Context, orgId string) (*models.Account, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
 	query := `SELECT * FROM accounts WHERE org_id = $1 LIMIT 1`
 	result := &models.Account{}
 
 	err := pgxscan.Get(ctx, db.Pool, result, query, orgId)
 	if err != nil {
-		return nil, fmt.Errorf("pgx error: %w", err)
+		return nil, pgxError(err)
 	}
 	return result, nil
 }
 
 func (x *accountDao) List(ctx context.Context, limit, offset int64) ([]*models.Account, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
 	query := `SELECT * FROM accounts ORDER BY id LIMIT $1 OFFSET $2`
 	var result []*models.Account
 
 	rows, err := db.Pool.Query(ctx, query, limit, offset)
 	if err != nil {
-		return nil, fmt.Errorf("pgx error: %w", err)
+		return nil, pgxError(err)
+	}
+
+	err = pgxscan.ScanAll(&result, rows)
+	if err != nil {
+		return nil, pgxError(err)
+	}
+	return result, nil
+}
+
+func (x *accountDao) UnscopedListMostActive(ctx context.Context, since time.Time, limit int64) ([]*models.Account, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT accounts.* FROM accounts
+		JOIN (SELECT account_id, COUNT(*) AS total FROM reservations WHERE created_at >= $1 GROUP BY account_id) AS active
+		ON active.account_id = accounts.id
+		ORDER BY active.total DESC, accounts.id LIMIT $2`
+	var result []*models.Account
+
+	rows, err := db.Pool.Query(ctx, query, since, limit)
+	if err != nil {
+		return nil, pgxError(err)
 	}
 
 	err = pgxscan.ScanAll(&result, rows)
 	if err != nil {
-		return nil, fmt.Errorf("pgx error: %w", err)
+		return nil, pgxError(err)
+	}
+	return result, nil
+}
+
+func (x *accountDao) NextNameSequence(ctx context.Context) (int64, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `INSERT INTO account_name_sequences (account_id, last_value) VALUES ($1, 1)
+		ON CONFLICT (account_id) DO UPDATE SET last_value = account_name_sequences.last_value + 1
+		RETURNING last_value`
+	var result int64
+
+	err := db.Pool.QueryRow(ctx, query, identity.AccountId(ctx)).Scan(&result)
+	if err != nil {
+		return 0, pgxError(err)
+	}
+	return result, nil
+}
+
+func (x *accountDao) GetSettings(ctx context.Context) (*models.AccountSettings, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT * FROM account_settings WHERE account_id = $1`
+	accountId := identity.AccountId(ctx)
+	result := &models.AccountSettings{}
+
+	err := pgxscan.Get(ctx, db.Pool, result, query, accountId)
+	if errors.Is(err, dao.ErrNoRows) {
+		return &models.AccountSettings{AccountID: accountId}, nil
+	} else if err != nil {
+		return nil, pgxError(err)
+	}
+	return result, nil
+}
+
+func (x *accountDao) UpdateSettings(ctx context.Context, settings *models.AccountSettings) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	settings.AccountID = identity.AccountId(ctx)
+	if settings.AllowedInstanceTypes == nil {
+		settings.AllowedInstanceTypes = []string{}
+	}
+	if settings.DeniedInstanceTypes == nil {
+		settings.DeniedInstanceTypes = []string{}
+	}
+	if settings.AllowedImages == nil {
+		settings.AllowedImages = []string{}
+	}
+	if vError := models.Validate(ctx, settings); vError != nil {
+		return fmt.Errorf("settings validation: %w", vError)
+	}
+
+	query := `INSERT INTO account_settings (account_id, approval_threshold, allowed_instance_types, denied_instance_types, max_vcpus,
+			composed_images_only, allowed_images)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (account_id) DO UPDATE SET
+			approval_threshold = EXCLUDED.approval_threshold, allowed_instance_types = EXCLUDED.allowed_instance_types,
+			denied_instance_types = EXCLUDED.denied_instance_types, max_vcpus = EXCLUDED.max_vcpus,
+			composed_images_only = EXCLUDED.composed_images_only, allowed_images = EXCLUDED.allowed_images, updated_at = now()
+		RETURNING updated_at`
+	err := db.Pool.QueryRow(ctx, query,
+		settings.AccountID,
+		settings.ApprovalThreshold,
+		settings.AllowedInstanceTypes,
+		settings.DeniedInstanceTypes,
+		settings.MaxVCPUs,
+		settings.ComposedImagesOnly,
+		settings.AllowedImages).Scan(&settings.UpdatedAt)
+	if err != nil {
+		return pgxError(err)
+	}
+	return nil
+}
+
+func (x *accountDao) GetSourceSettings(ctx context.Context, sourceId string) (*models.SourceSettings, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT * FROM source_settings WHERE account_id = $1 AND source_id = $2`
+	accountId := identity.AccountId(ctx)
+	result := &models.SourceSettings{}
+
+	err := pgxscan.Get(ctx, db.Pool, result, query, accountId, sourceId)
+	if errors.Is(err, dao.ErrNoRows) {
+		return &models.SourceSettings{AccountID: accountId, SourceID: sourceId}, nil
+	} else if err != nil {
+		return nil, pgxError(err)
 	}
 	return result, nil
 }
+
+func (x *accountDao) UpdateSourceSettings(ctx context.Context, settings *models.SourceSettings) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	settings.AccountID = identity.AccountId(ctx)
+	if settings.AllowedRegions == nil {
+		settings.AllowedRegions = []string{}
+	}
+	if vError := models.Validate(ctx, settings); vError != nil {
+		return fmt.Errorf("source settings validation: %w", vError)
+	}
+
+	query := `INSERT INTO source_settings (account_id, source_id, default_region, allowed_regions, event_target) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (account_id, source_id) DO UPDATE SET
+			default_region = EXCLUDED.default_region, allowed_regions = EXCLUDED.allowed_regions,
+			event_target = EXCLUDED.event_target, updated_at = now()
+		RETURNING updated_at`
+	err := db.Pool.QueryRow(ctx, query,
+		settings.AccountID,
+		settings.SourceID,
+		settings.DefaultRegion,
+		settings.AllowedRegions,
+		settings.EventTarget).Scan(&settings.UpdatedAt)
+	if err != nil {
+		return pgxError(err)
+	}
+	return nil
+}
+
+func (x *accountDao) UnscopedAddUsage(ctx context.Context, usage []*models.AccountUsage) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `INSERT INTO account_usage (account_id, day, api_calls, launches, instances) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (account_id, day) DO UPDATE SET
+			api_calls = account_usage.api_calls + EXCLUDED.api_calls, launches = account_usage.launches + EXCLUDED.launches,
+			instances = account_usage.instances + EXCLUDED.instances`
+
+	txErr := dao.WithTransaction(ctx, func(tx pgx.Tx) error {
+		for _, u := range usage {
+			_, err := tx.Exec(ctx, query, u.AccountID, u.Day, u.APICalls, u.Launches, u.Instances)
+			if err != nil {
+				return pgxError(err)
+			}
+		}
+		return nil
+	})
+	if txErr != nil {
+		return fmt.Errorf("pgx tx error: %w", txErr)
+	}
+	return nil
+}
+
+func (x *accountDao) ListUsage(ctx context.Context, since time.Time) ([]*models.AccountUsage, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT * FROM account_usage WHERE account_id = $1 AND day >= $2 ORDER BY day DESC`
+	var result []*models.AccountUsage
+
+	rows, err := db.Pool.Query(ctx, query, identity.AccountId(ctx), since)
+	if err != nil {
+		return nil, pgxError(err)
+	}
+
+	err = pgxscan.ScanAll(&result, rows)
+	if err != nil {
+		return nil, pgxError(err)
+	}
+	return result, nil
+}
+
+func (x *accountDao) UnscopedListTopUsage(ctx context.Context, since time.Time, limit int64) ([]*models.AccountUsageTotal, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT u.account_id, a.org_id, SUM(u.api_calls) AS api_calls, SUM(u.launches) AS launches, SUM(u.instances) AS instances
+		FROM account_usage u JOIN accounts a ON a.id = u.account_id
+		WHERE u.day >= $1 GROUP BY u.account_id, a.org_id
+		ORDER BY api_calls DESC, u.account_id LIMIT $2`
+	var result []*models.AccountUsageTotal
+
+	rows, err := db.Pool.Query(ctx, query, since, limit)
+	if err != nil {
+		return nil, pgxError(err)
+	}
+
+	err = pgxscan.ScanAll(&result, rows)
+	if err != nil {
+		return nil, pgxError(err)
+	}
+	return result, nil
+}
+
+func (x *accountDao) UnscopedPurge(ctx context.Context, id int64) (*models.AccountPurge, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	sourcesQuery := `SELECT d.source_id FROM aws_reservation_details d JOIN reservations r ON r.id = d.reservation_id WHERE r.account_id = $1
+		UNION SELECT d.source_id FROM azure_reservation_details d JOIN reservations r ON r.id = d.reservation_id WHERE r.account_id = $1
+		UNION SELECT d.source_id FROM gcp_reservation_details d JOIN reservations r ON r.id = d.reservation_id WHERE r.account_id = $1`
+	// templates go first, they reference reservations of their last run
+	templatesQuery := `DELETE FROM reservation_templates WHERE account_id = $1`
+	reservationsQuery := `DELETE FROM reservations WHERE account_id = $1`
+	pubkeysQuery := `DELETE FROM pubkeys WHERE account_id = $1`
+	orphansQuery := `DELETE FROM orphaned_instances WHERE account_id = $1`
+	settingsQuery := `DELETE FROM account_settings WHERE account_id = $1`
+	sourceSettingsQuery := `DELETE FROM source_settings WHERE account_id = $1`
+	usageQuery := `DELETE FROM account_usage WHERE account_id = $1`
+	auditQuery := `UPDATE audit_log SET actor = '', details = '{}' WHERE account_id = $1 AND (actor <> '' OR details <> '{}')`
+
+	result := &models.AccountPurge{}
+	txErr := dao.WithTransaction(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, sourcesQuery, id)
+		if err != nil {
+			return pgxError(err)
+		}
+		err = pgxscan.ScanAll(&result.SourceIDs, rows)
+		if err != nil {
+			return pgxError(err)
+		}
+
+		for _, step := range []struct {
+			query    string
+			affected *int64
+		}{
+			{templatesQuery, &result.ReservationTemplates},
+			{reservationsQuery, &result.Reservations},
+			{pubkeysQuery, &result.Pubkeys},
+			{orphansQuery, &result.OrphanedInstances},
+			{auditQuery, &result.AuditRecords},
+		} {
+			tag, err := tx.Exec(ctx, step.query, id)
+			if err != nil {
+				return pgxError(err)
+			}
+			*step.affected = tag.RowsAffected()
+		}
+
+		for _, query := range []string{settingsQuery, sourceSettingsQuery, usageQuery} {
+			_, err = tx.Exec(ctx, query, id)
+			if err != nil {
+				return pgxError(err)
+			}
+		}
+		return nil
+	})
+	if txErr != nil {
+		return nil, fmt.Errorf("pgx tx error: %w", txErr)
+	}
+
+	return result, nil
+}
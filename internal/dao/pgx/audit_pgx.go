@@ -0,0 +1,74 @@
+package pgx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/db"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+func init() {
+	dao.GetAuditDao = getAuditDao
+}
+
+type auditDao struct{}
+
+func getAuditDao(ctx context.Context) dao.AuditDao {
+	return dao.InstrumentAuditDao(&auditDao{})
+}
+
+func (x *auditDao) Create(ctx context.Context, record *models.AuditRecord) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO audit_log (account_id, reservation_id, instance_id, action, actor, details)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at`
+
+	record.AccountID = identity.AccountId(ctx)
+	record.Actor = identity.Identity(ctx).Identity.User.Username
+	if record.Details == nil {
+		record.Details = map[string]string{}
+	}
+
+	if vError := models.Validate(ctx, record); vError != nil {
+		return fmt.Errorf("audit record validation: %w", vError)
+	}
+
+	err := db.Pool.QueryRow(ctx, query,
+		record.AccountID,
+		record.ReservationID,
+		record.InstanceID,
+		record.Action,
+		record.Actor,
+		record.Details).Scan(&record.ID, &record.CreatedAt)
+	if err != nil {
+		return pgxError(err)
+	}
+
+	return nil
+}
+
+func (x *auditDao) ListByReservation(ctx context.Context, reservationId int64, limit, offset int64) ([]*models.AuditRecord, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT * FROM audit_log WHERE account_id = $1 AND reservation_id = $2 ORDER BY id LIMIT $3 OFFSET $4`
+	accountId := identity.AccountId(ctx)
+	var result []*models.AuditRecord
+
+	rows, err := db.Pool.Query(ctx, query, accountId, reservationId, limit, offset)
+	if err != nil {
+		return nil, pgxError(err)
+	}
+
+	err = pgxscan.ScanAll(&result, rows)
+	if err != nil {
+		return nil, pgxError(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,36 @@
+package pgx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/db"
+)
+
+// pgxError wraps an error returned by pgx, exceeded query timeout (context deadline) or
+// statement_timeout are returned as dao.ErrTimeout which still matches the original error.
+func pgxError(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) || db.IsPostgresError(err, db.QueryCanceledErrorCode) != nil {
+		return &timeoutError{err: err}
+	}
+	return fmt.Errorf("pgx error: %w", err)
+}
+
+// timeoutError is dao.ErrTimeout caused by a pgx error, errors.Is matches both of them.
+type timeoutError struct {
+	err error
+}
+
+func (e *timeoutError) Error() string {
+	return fmt.Sprintf("pgx error: %s: %s", dao.ErrTimeout.Error(), e.err.Error())
+}
+
+func (e *timeoutError) Is(target error) bool {
+	return target == dao.ErrTimeout
+}
+
+func (e *timeoutError) Unwrap() error {
+	return e.err
+}
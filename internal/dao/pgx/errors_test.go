@@ -0,0 +1,27 @@
+package pgx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPgxErrorTimeout(t *testing.T) {
+	err := pgxError(fmt.Errorf("wait for notification: %w", context.DeadlineExceeded))
+
+	assert.ErrorIs(t, err, dao.ErrTimeout)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "wait for notification")
+}
+
+func TestPgxErrorOther(t *testing.T) {
+	cause := errors.New("connection refused")
+	err := pgxError(cause)
+
+	assert.ErrorIs(t, err, cause)
+	assert.NotErrorIs(t, err, dao.ErrTimeout)
+}
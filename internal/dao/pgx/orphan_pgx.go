@@ -0,0 +1,118 @@
+package pgx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/db"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+)
+
+func init() {
+	dao.GetOrphanDao = getOrphanDao
+}
+
+type orphanDao struct{}
+
+func getOrphanDao(ctx context.Context) dao.OrphanDao {
+	return dao.InstrumentOrphanDao(&orphanDao{})
+}
+
+func (x *orphanDao) List(ctx context.Context, limit, offset int64) ([]*models.OrphanedInstance, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT * FROM orphaned_instances WHERE account_id = $1 ORDER BY id LIMIT $2 OFFSET $3`
+	accountId := identity.AccountId(ctx)
+	var result []*models.OrphanedInstance
+
+	rows, err := db.Pool.Query(ctx, query, accountId, limit, offset)
+	if err != nil {
+		return nil, pgxError(err)
+	}
+
+	err = pgxscan.ScanAll(&result, rows)
+	if err != nil {
+		return nil, pgxError(err)
+	}
+	return result, nil
+}
+
+func (x *orphanDao) Count(ctx context.Context) (int64, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT COUNT(*) FROM (SELECT 1 FROM orphaned_instances WHERE account_id = $1 LIMIT $2) AS capped`
+	accountId := identity.AccountId(ctx)
+	var result int64
+
+	err := db.Pool.QueryRow(ctx, query, accountId, dao.MaxCountTotal).Scan(&result)
+	if err != nil {
+		return 0, pgxError(err)
+	}
+	return result, nil
+}
+
+func (x *orphanDao) ReplaceBySource(ctx context.Context, provider models.ProviderType, sourceId, region string, orphans []*models.OrphanedInstance) ([]*models.OrphanedInstance, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	deleteQuery := `DELETE FROM orphaned_instances
+		WHERE account_id = $1 AND provider = $2 AND source_id = $3 AND ($4 = '' OR location = $4)
+		AND NOT (instance_id = ANY($5))`
+	// xmax is zero for inserted rows and set for updated ones
+	upsertQuery := `INSERT INTO orphaned_instances (account_id, provider, source_id, location, instance_id, reservation_id, kind)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (account_id, provider, instance_id) DO UPDATE SET
+			source_id = EXCLUDED.source_id, location = EXCLUDED.location,
+			reservation_id = EXCLUDED.reservation_id, kind = EXCLUDED.kind
+		RETURNING id, detected_at, xmax = 0`
+
+	accountId := identity.AccountId(ctx)
+	ids := make([]string, len(orphans))
+	for i, orphan := range orphans {
+		orphan.AccountID = accountId
+		orphan.Provider = provider
+		orphan.SourceID = sourceId
+		if vError := models.Validate(ctx, orphan); vError != nil {
+			return nil, fmt.Errorf("orphaned instance validation: %w", vError)
+		}
+		ids[i] = orphan.InstanceID
+	}
+
+	var detected []*models.OrphanedInstance
+	txErr := dao.WithTransaction(ctx, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, deleteQuery, accountId, provider, sourceId, region, ids)
+		if err != nil {
+			return pgxError(err)
+		}
+
+		for _, orphan := range orphans {
+			var inserted bool
+			err = tx.QueryRow(ctx, upsertQuery,
+				orphan.AccountID,
+				orphan.Provider,
+				orphan.SourceID,
+				orphan.Location,
+				orphan.InstanceID,
+				orphan.ReservationID,
+				orphan.Kind).Scan(&orphan.ID, &orphan.DetectedAt, &inserted)
+			if err != nil {
+				return pgxError(err)
+			}
+			if inserted {
+				detected = append(detected, orphan)
+			}
+		}
+		return nil
+	})
+	if txErr != nil {
+		return nil, fmt.Errorf("pgx tx error: %w", txErr)
+	}
+
+	return detected, nil
+}
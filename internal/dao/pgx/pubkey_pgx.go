@@ -6,6 +6,7 @@ import (
 
 	"github.com/RHEnVision/provisioning-backend/internal/dao"
 	"github.com/RHEnVision/provisioning-backend/internal/db"
+	"github.com/RHEnVision/provisioning-backend/internal/encryption"
 	"github.com/RHEnVision/provisioning-backend/internal/identity"
 	"github.com/RHEnVision/provisioning-backend/internal/models"
 	"github.com/georgysavva/scany/v2/pgxscan"
@@ -18,7 +19,31 @@ func init() {
 type pubkeyDao struct{}
 
 func getPubkeyDao(ctx context.Context) dao.PubkeyDao {
-	return &pubkeyDao{}
+	return dao.InstrumentPubkeyDao(&pubkeyDao{})
+}
+
+// pubkeyBodyColumn is authenticated together with encrypted pubkey bodies
+const pubkeyBodyColumn = "pubkeys.body"
+
+// encryptPubkeyBody returns the body of the pubkey as stored in the database.
+func encryptPubkeyBody(pubkey *models.Pubkey) (string, error) {
+	body, err := encryption.Encrypt(pubkeyBodyColumn, pubkey.Body)
+	if err != nil {
+		return "", fmt.Errorf("pubkey body: %w", err)
+	}
+	return body, nil
+}
+
+// decryptPubkeyBodies replaces stored bodies of pubkeys with plain text.
+func decryptPubkeyBodies(pubkeys ...*models.Pubkey) error {
+	for _, pubkey := range pubkeys {
+		body, err := encryption.Decrypt(pubkeyBodyColumn, pubkey.Body)
+		if err != nil {
+			return fmt.Errorf("pubkey %d body: %w", pubkey.ID, err)
+		}
+		pubkey.Body = body
+	}
+	return nil
 }
 
 func (x *pubkeyDao) validate(ctx context.Context, pubkey *models.Pubkey) error {
@@ -38,37 +63,55 @@ func (x *pubkeyDao) validate(ctx context.Context, pubkey *models.Pubkey) error {
 }
 
 func (x *pubkeyDao) Create(ctx context.Context, pubkey *models.Pubkey) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
 	query := `
-		INSERT INTO pubkeys (account_id, type, name, body, fingerprint, fingerprint_legacy)
-		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+		INSERT INTO pubkeys (account_id, workspace_id, type, name, body, fingerprint, fingerprint_legacy)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
 
 	pubkey.AccountID = identity.AccountId(ctx)
+	pubkey.WorkspaceID = identity.DefaultWorkspace(ctx)
 
 	if vError := x.validate(ctx, pubkey); vError != nil {
 		return fmt.Errorf("pubkey validation: %w", vError)
 	}
 
-	err := db.Pool.QueryRow(ctx, query, pubkey.AccountID, pubkey.Type, pubkey.Name, pubkey.Body, pubkey.Fingerprint, pubkey.FingerprintLegacy).Scan(&pubkey.ID)
+	body, err := encryptPubkeyBody(pubkey)
+	if err != nil {
+		return err
+	}
+
+	err = db.Pool.QueryRow(ctx, query, pubkey.AccountID, pubkey.WorkspaceID, pubkey.Type, pubkey.Name, body, pubkey.Fingerprint, pubkey.FingerprintLegacy).Scan(&pubkey.ID)
 	if err != nil {
-		return fmt.Errorf("pgx error: %w", err)
+		return pgxError(err)
 	}
 
 	return nil
 }
 
 func (x *pubkeyDao) GetById(ctx context.Context, id int64) (*models.Pubkey, error) {
-	query := `SELECT * FROM pubkeys WHERE account_id = $1 AND id = $2 LIMIT 1`
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT * FROM pubkeys WHERE account_id = $1 AND id = $2 AND ($3::text[] IS NULL OR workspace_id = ANY($3)) LIMIT 1`
 	accountId := identity.AccountId(ctx)
 	result := &models.Pubkey{}
 
-	err := pgxscan.Get(ctx, db.Pool, result, query, accountId, id)
+	err := pgxscan.Get(ctx, db.Pool, result, query, accountId, id, identity.Workspaces(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("pgx error: %w", err)
+		return nil, pgxError(err)
+	}
+	if err = decryptPubkeyBodies(result); err != nil {
+		return nil, err
 	}
 	return result, nil
 }
 
 func (x *pubkeyDao) Update(ctx context.Context, pubkey *models.Pubkey) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
 	query := `
 		UPDATE pubkeys SET
 			type = $3,
@@ -83,9 +126,14 @@ func (x *pubkeyDao) Update(ctx context.Context, pubkey *models.Pubkey) error {
 		return fmt.Errorf("pubkey validation: %w", vError)
 	}
 
-	tag, err := db.Pool.Exec(ctx, query, accountId, pubkey.ID, pubkey.Type, pubkey.Name, pubkey.Body, pubkey.Fingerprint, pubkey.FingerprintLegacy)
+	body, err := encryptPubkeyBody(pubkey)
+	if err != nil {
+		return err
+	}
+
+	tag, err := db.Pool.Exec(ctx, query, accountId, pubkey.ID, pubkey.Type, pubkey.Name, body, pubkey.Fingerprint, pubkey.FingerprintLegacy)
 	if err != nil {
-		return fmt.Errorf("pgx error: %w", err)
+		return pgxError(err)
 	}
 	if tag.RowsAffected() != 1 {
 		return fmt.Errorf("expected 1 row: %w", dao.ErrAffectedMismatch)
@@ -94,29 +142,54 @@ func (x *pubkeyDao) Update(ctx context.Context, pubkey *models.Pubkey) error {
 }
 
 func (x *pubkeyDao) List(ctx context.Context, limit, offset int64) ([]*models.Pubkey, error) {
-	query := `SELECT * FROM pubkeys WHERE account_id = $1 ORDER BY id LIMIT $2 OFFSET $3`
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT * FROM pubkeys WHERE account_id = $1 AND ($4::text[] IS NULL OR workspace_id = ANY($4)) ORDER BY id LIMIT $2 OFFSET $3`
 	accountId := identity.AccountId(ctx)
 	var result []*models.Pubkey
 
-	rows, err := db.Pool.Query(ctx, query, accountId, limit, offset)
+	rows, err := db.Pool.Query(ctx, query, accountId, limit, offset, identity.Workspaces(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("pgx error: %w", err)
+		return nil, pgxError(err)
 	}
 
 	err = pgxscan.ScanAll(&result, rows)
 	if err != nil {
-		return nil, fmt.Errorf("pgx error: %w", err)
+		return nil, pgxError(err)
+	}
+	if err = decryptPubkeyBodies(result...); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (x *pubkeyDao) Count(ctx context.Context) (int64, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT COUNT(*) FROM (SELECT 1 FROM pubkeys WHERE account_id = $1
+		AND ($2::text[] IS NULL OR workspace_id = ANY($2)) LIMIT $3) AS capped`
+	accountId := identity.AccountId(ctx)
+	var result int64
+
+	err := db.Pool.QueryRow(ctx, query, accountId, identity.Workspaces(ctx), dao.MaxCountTotal).Scan(&result)
+	if err != nil {
+		return 0, pgxError(err)
 	}
 	return result, nil
 }
 
 func (x *pubkeyDao) Delete(ctx context.Context, id int64) error {
-	query := `DELETE FROM pubkeys WHERE account_id = $1 AND id = $2`
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `DELETE FROM pubkeys WHERE account_id = $1 AND id = $2 AND ($3::text[] IS NULL OR workspace_id = ANY($3))`
 	accountId := identity.AccountId(ctx)
 
-	tag, err := db.Pool.Exec(ctx, query, accountId, id)
+	tag, err := db.Pool.Exec(ctx, query, accountId, id, identity.Workspaces(ctx))
 	if err != nil {
-		return fmt.Errorf("pgx error: %w", err)
+		return pgxError(err)
 	}
 	if tag.RowsAffected() != 1 {
 		return fmt.Errorf("expected 1 row: %w", dao.ErrAffectedMismatch)
@@ -125,6 +198,9 @@ func (x *pubkeyDao) Delete(ctx context.Context, id int64) error {
 }
 
 func (x *pubkeyDao) UnscopedCreateResource(ctx context.Context, pkr *models.PubkeyResource) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
 	query := `INSERT INTO pubkey_resources
     	(pubkey_id, provider, source_id, handle, tag, region)
 		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, tag`
@@ -137,45 +213,54 @@ func (x *pubkeyDao) UnscopedCreateResource(ctx context.Context, pkr *models.Pubk
 		pkr.Tag,
 		pkr.Region).Scan(&pkr.ID, &pkr.Tag)
 	if err != nil {
-		return fmt.Errorf("pgx error: %w", err)
+		return pgxError(err)
 	}
 
 	return nil
 }
 
 func (x *pubkeyDao) UnscopedGetResourceBySourceAndRegion(ctx context.Context, pubkeyId int64, sourceId string, region string) (*models.PubkeyResource, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
 	query := `SELECT * FROM pubkey_resources WHERE pubkey_id = $1 AND source_id = $2 AND region = $3`
 	result := &models.PubkeyResource{}
 
 	err := pgxscan.Get(ctx, db.Pool, result, query, pubkeyId, sourceId, region)
 	if err != nil {
-		return nil, fmt.Errorf("pgx error: %w", err)
+		return nil, pgxError(err)
 	}
 	return result, nil
 }
 
 func (x *pubkeyDao) UnscopedListResourcesByPubkeyId(ctx context.Context, id int64) ([]*models.PubkeyResource, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
 	query := `SELECT * FROM pubkey_resources WHERE pubkey_id = $1`
 	var result []*models.PubkeyResource
 
 	rows, err := db.Pool.Query(ctx, query, id)
 	if err != nil {
-		return nil, fmt.Errorf("pgx error: %w", err)
+		return nil, pgxError(err)
 	}
 
 	err = pgxscan.ScanAll(&result, rows)
 	if err != nil {
-		return nil, fmt.Errorf("pgx error: %w", err)
+		return nil, pgxError(err)
 	}
 	return result, nil
 }
 
 func (x *pubkeyDao) UnscopedDeleteResource(ctx context.Context, id int64) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
 	query := `DELETE FROM pubkey_resources WHERE id = $1`
 
 	tag, err := db.Pool.Exec(ctx, query, id)
 	if err != nil {
-		return fmt.Errorf("pgx error: %w", err)
+		return pgxError(err)
 	}
 	if tag.RowsAffected() != 1 {
 		return fmt.Errorf("expected 1 row: %w", dao.ErrAffectedMismatch)
@@ -1,13 +1,18 @@
 package pgx
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/RHEnVision/provisioning-backend/internal/clients"
 	"github.com/RHEnVision/provisioning-backend/internal/config"
 	"github.com/RHEnVision/provisioning-backend/internal/dao"
 	"github.com/RHEnVision/provisioning-backend/internal/db"
+	"github.com/RHEnVision/provisioning-backend/internal/encryption"
 	"github.com/RHEnVision/provisioning-backend/internal/identity"
 	"github.com/RHEnVision/provisioning-backend/internal/models"
 	"github.com/georgysavva/scany/v2/pgxscan"
@@ -19,13 +24,79 @@ func init() {
 	dao.GetReservationDao = getReservationDao
 }
 
+// pollInterval is used to check for reservation updates when notifications are not available
+const pollInterval = time.Second
+
 type reservationDao struct{}
 
+// columns authenticated together with encrypted custom user data of reservation details
+const (
+	awsUserDataColumn   = "aws_reservation_details.detail.user_data"
+	azureUserDataColumn = "azure_reservation_details.detail.user_data"
+	gcpUserDataColumn   = "gcp_reservation_details.detail.user_data"
+	jobUserDataColumn   = "reservation_jobs.job.args.Detail.user_data"
+)
+
+// encryptUserData replaces custom user data with the value stored in the database, blank user
+// data is kept blank.
+func encryptUserData(column string, userData *string) error {
+	if *userData == "" {
+		return nil
+	}
+	value, err := encryption.Encrypt(column, *userData)
+	if err != nil {
+		return fmt.Errorf("user data: %w", err)
+	}
+	*userData = value
+	return nil
+}
+
+// decryptUserData replaces stored custom user data with plain text.
+func decryptUserData(column string, userData *string) error {
+	value, err := encryption.Decrypt(column, *userData)
+	if err != nil {
+		return fmt.Errorf("user data: %w", err)
+	}
+	*userData = value
+	return nil
+}
+
+// transformJobUserData applies the function to custom user data of launch job arguments (the
+// Detail field of AWS and GCP jobs), jobs without user data are returned unchanged.
+func transformJobUserData(job []byte, transform func(column string, userData *string) error) ([]byte, error) {
+	var stored map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(job))
+	decoder.UseNumber()
+	if err := decoder.Decode(&stored); err != nil {
+		return nil, fmt.Errorf("unable to decode job: %w", err)
+	}
+
+	args, _ := stored["args"].(map[string]any)
+	detail, _ := args["Detail"].(map[string]any)
+	userData, _ := detail["user_data"].(string)
+	if userData == "" {
+		return job, nil
+	}
+	if err := transform(jobUserDataColumn, &userData); err != nil {
+		return nil, err
+	}
+	detail["user_data"] = userData
+
+	result, err := json.Marshal(stored)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode job: %w", err)
+	}
+	return result, nil
+}
+
 func getReservationDao(ctx context.Context) dao.ReservationDao {
-	return &reservationDao{}
+	return dao.InstrumentReservationDao(&reservationDao{})
 }
 
 func (x *reservationDao) CreateNoop(ctx context.Context, reservation *models.NoopReservation) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
 	reservation.Provider = models.ProviderTypeNoop
 	if err := x.createGenericReservation(ctx, &reservation.Reservation); err != nil {
 		return fmt.Errorf("failed to create reservation record: %w", err)
@@ -34,6 +105,9 @@ func (x *reservationDao) CreateNoop(ctx context.Context, reservation *models.Noo
 }
 
 func (x *reservationDao) CreateAWS(ctx context.Context, reservation *models.AWSReservation) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
 	txErr := dao.WithTransaction(ctx, func(tx pgx.Tx) error {
 		reservation.Provider = models.ProviderTypeAWS
 		if err := x.createGenericReservation(ctx, &reservation.Reservation); err != nil {
@@ -42,14 +116,22 @@ func (x *reservationDao) CreateAWS(ctx context.Context, reservation *models.AWSR
 
 		awsQuery := `INSERT INTO aws_reservation_details (reservation_id, pubkey_id, source_id, image_id, detail)
 		VALUES ($1, $2, $3, $4, $5)`
+		detail := reservation.Detail
+		if detail != nil {
+			stored := *detail
+			if err := encryptUserData(awsUserDataColumn, &stored.UserData); err != nil {
+				return err
+			}
+			detail = &stored
+		}
 		tag, err := db.Pool.Exec(ctx, awsQuery,
 			reservation.ID,
 			reservation.PubkeyID,
 			reservation.SourceID,
 			reservation.ImageID,
-			reservation.Detail)
+			detail)
 		if err != nil {
-			return fmt.Errorf("pgx error: %w", err)
+			return pgxError(err)
 		}
 		if tag.RowsAffected() != 1 {
 			return fmt.Errorf("expected 1 row: %w", dao.ErrAffectedMismatch)
@@ -65,6 +147,9 @@ func (x *reservationDao) CreateAWS(ctx context.Context, reservation *models.AWSR
 }
 
 func (x *reservationDao) CreateAzure(ctx context.Context, reservation *models.AzureReservation) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
 	txErr := dao.WithTransaction(ctx, func(tx pgx.Tx) error {
 		reservation.Provider = models.ProviderTypeAzure
 		if err := x.createGenericReservation(ctx, &reservation.Reservation); err != nil {
@@ -73,14 +158,22 @@ func (x *reservationDao) CreateAzure(ctx context.Context, reservation *models.Az
 
 		azureQuery := `INSERT INTO azure_reservation_details (reservation_id, pubkey_id, source_id, image_id, detail)
 			VALUES ($1, $2, $3, $4, $5)`
+		detail := reservation.Detail
+		if detail != nil {
+			stored := *detail
+			if err := encryptUserData(azureUserDataColumn, &stored.UserData); err != nil {
+				return err
+			}
+			detail = &stored
+		}
 		tag, err := db.Pool.Exec(ctx, azureQuery,
 			reservation.ID,
 			reservation.PubkeyID,
 			reservation.SourceID,
 			reservation.ImageID,
-			reservation.Detail)
+			detail)
 		if err != nil {
-			return fmt.Errorf("pgx error: %w", err)
+			return pgxError(err)
 		}
 		if tag.RowsAffected() != 1 {
 			return fmt.Errorf("expected 1 row: %w", dao.ErrAffectedMismatch)
@@ -96,6 +189,9 @@ func (x *reservationDao) CreateAzure(ctx context.Context, reservation *models.Az
 }
 
 func (x *reservationDao) CreateGCP(ctx context.Context, reservation *models.GCPReservation) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
 	txErr := dao.WithTransaction(ctx, func(tx pgx.Tx) error {
 		reservation.Provider = models.ProviderTypeGCP
 		if err := x.createGenericReservation(ctx, &reservation.Reservation); err != nil {
@@ -104,14 +200,22 @@ func (x *reservationDao) CreateGCP(ctx context.Context, reservation *models.GCPR
 
 		gcpQuery := `INSERT INTO gcp_reservation_details (reservation_id, pubkey_id, source_id, image_id, detail)
 			VALUES ($1, $2, $3, $4, $5)`
+		detail := reservation.Detail
+		if detail != nil {
+			stored := *detail
+			if err := encryptUserData(gcpUserDataColumn, &stored.UserData); err != nil {
+				return err
+			}
+			detail = &stored
+		}
 		tag, err := db.Pool.Exec(ctx, gcpQuery,
 			reservation.ID,
 			reservation.PubkeyID,
 			reservation.SourceID,
 			reservation.ImageID,
-			reservation.Detail)
+			detail)
 		if err != nil {
-			return fmt.Errorf("pgx error: %w", err)
+			return pgxError(err)
 		}
 		if tag.RowsAffected() != 1 {
 			return fmt.Errorf("expected 1 row: %w", dao.ErrAffectedMismatch)
@@ -128,16 +232,26 @@ func (x *reservationDao) CreateGCP(ctx context.Context, reservation *models.GCPR
 
 func (x *reservationDao) createGenericReservation(ctx context.Context, reservation *models.Reservation) error {
 	reservation.AccountID = identity.AccountId(ctx)
+	reservation.CreatedByUserID = identity.Identity(ctx).Identity.User.UserID
+	reservation.CreatedBy = identity.Identity(ctx).Identity.User.Username
+	reservation.WorkspaceID = identity.DefaultWorkspace(ctx)
 	reservation.Status = "Created"
+	if reservation.Labels == nil {
+		reservation.Labels = []string{}
+	}
 
-	reservationQuery := `INSERT INTO reservations (provider, account_id, steps, step_titles, status)
-		VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at`
+	reservationQuery := `INSERT INTO reservations (provider, account_id, created_by_user_id, created_by, workspace_id, steps, step_titles, status, labels)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id, created_at`
 	err := db.Pool.QueryRow(ctx, reservationQuery,
 		reservation.Provider,
 		reservation.AccountID,
+		reservation.CreatedByUserID,
+		reservation.CreatedBy,
+		reservation.WorkspaceID,
 		reservation.Steps,
 		reservation.StepTitles,
-		reservation.Status).Scan(&reservation.ID, &reservation.CreatedAt)
+		reservation.Status,
+		reservation.Labels).Scan(&reservation.ID, &reservation.CreatedAt)
 	if err != nil {
 		return fmt.Errorf("failed to create reservation record: %w", err)
 	}
@@ -145,14 +259,19 @@ func (x *reservationDao) createGenericReservation(ctx context.Context, reservati
 }
 
 func (x *reservationDao) CreateInstance(ctx context.Context, instance *models.ReservationInstance) error {
-	query := `INSERT INTO reservation_instances (reservation_id, instance_id, detail) VALUES ($1, $2, $3)`
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `INSERT INTO reservation_instances (reservation_id, instance_id, detail, spot_request_id, fleet_allocation) VALUES ($1, $2, $3, $4, $5)`
 
 	tag, err := db.Pool.Exec(ctx, query,
 		instance.ReservationID,
 		instance.InstanceID,
-		instance.Detail)
+		instance.Detail,
+		instance.SpotRequestID,
+		instance.FleetAllocation)
 	if err != nil {
-		return fmt.Errorf("pgx error: %w", err)
+		return pgxError(err)
 	}
 	if tag.RowsAffected() != 1 {
 		return fmt.Errorf("expected 1 row: %w", dao.ErrAffectedMismatch)
@@ -162,14 +281,93 @@ func (x *reservationDao) CreateInstance(ctx context.Context, instance *models.Re
 }
 
 func (x *reservationDao) UpdateReservationInstance(ctx context.Context, reservationID int64, instance *clients.InstanceDescription) error {
-	query := `UPDATE reservation_instances SET detail = $3 WHERE reservation_id = $1 AND instance_id = $2`
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	// merged with the stored detail, DNS record names and passwords are kept
+	query := `UPDATE reservation_instances SET detail = detail || $3::jsonb WHERE reservation_id = $1 AND instance_id = $2`
 	detail := &models.ReservationInstanceDetail{
-		PublicIPv4: instance.PublicIPv4,
-		PublicDNS:  instance.PublicDNS,
+		PublicIPv4:        instance.PublicIPv4,
+		PublicDNS:         instance.PublicDNS,
+		PrivateIPv4:       instance.PrivateIPv4,
+		PrivateDNS:        instance.PrivateDNS,
+		NetworkInterfaces: instance.NetworkInterfaces,
 	}
 	tag, err := db.Pool.Exec(ctx, query, reservationID, instance.ID, detail)
 	if err != nil {
-		return fmt.Errorf("pgx error: %w", err)
+		return pgxError(err)
+	}
+	if tag.RowsAffected() != 1 {
+		return fmt.Errorf("expected 1 row: %w", dao.ErrAffectedMismatch)
+	}
+
+	return nil
+}
+
+func (x *reservationDao) UpdateInstanceDNSRecord(ctx context.Context, reservationID int64, instanceID string, record *models.DNSRecord) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `UPDATE reservation_instances SET dns_record = $3, detail = jsonb_set(detail, '{dns_name}', to_jsonb($4::text))
+		WHERE reservation_id = $1 AND instance_id = $2`
+	tag, err := db.Pool.Exec(ctx, query, reservationID, instanceID, record, record.Name)
+	if err != nil {
+		return pgxError(err)
+	}
+	if tag.RowsAffected() != 1 {
+		return fmt.Errorf("expected 1 row: %w", dao.ErrAffectedMismatch)
+	}
+
+	return nil
+}
+
+func (x *reservationDao) UpdateInstanceElasticIP(ctx context.Context, reservationID int64, instanceID string, eip *models.ElasticIP) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `UPDATE reservation_instances SET elastic_ip = $3 WHERE reservation_id = $1 AND instance_id = $2`
+	tag, err := db.Pool.Exec(ctx, query, reservationID, instanceID, eip)
+	if err != nil {
+		return pgxError(err)
+	}
+	if tag.RowsAffected() != 1 {
+		return fmt.Errorf("expected 1 row: %w", dao.ErrAffectedMismatch)
+	}
+
+	return nil
+}
+
+func (x *reservationDao) UpdateInstancePasswordData(ctx context.Context, reservationID int64, instanceID string, passwordData string) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `UPDATE reservation_instances SET detail = jsonb_set(detail, '{password_data}', to_jsonb($3::text))
+		WHERE reservation_id = $1 AND instance_id = $2`
+	tag, err := db.Pool.Exec(ctx, query, reservationID, instanceID, passwordData)
+	if err != nil {
+		return pgxError(err)
+	}
+	if tag.RowsAffected() != 1 {
+		return fmt.Errorf("expected 1 row: %w", dao.ErrAffectedMismatch)
+	}
+
+	return nil
+}
+
+func (x *reservationDao) UpdateInstancePowerState(ctx context.Context, reservationID int64, instanceID string, state models.PowerState, from ...models.PowerState) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	var fromStates []string
+	for _, f := range from {
+		fromStates = append(fromStates, string(f))
+	}
+
+	query := `UPDATE reservation_instances SET power_state = $3
+		WHERE reservation_id = $1 AND instance_id = $2 AND ($4::text[] IS NULL OR power_state = ANY($4))`
+	tag, err := db.Pool.Exec(ctx, query, reservationID, instanceID, state, fromStates)
+	if err != nil {
+		return pgxError(err)
 	}
 	if tag.RowsAffected() != 1 {
 		return fmt.Errorf("expected 1 row: %w", dao.ErrAffectedMismatch)
@@ -179,105 +377,464 @@ func (x *reservationDao) UpdateReservationInstance(ctx context.Context, reservat
 }
 
 func (x *reservationDao) GetById(ctx context.Context, id int64) (*models.Reservation, error) {
-	query := `SELECT * FROM reservations WHERE account_id = $1 AND id = $2 LIMIT 1`
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT * FROM reservations WHERE account_id = $1 AND id = $2 AND ($3::text[] IS NULL OR workspace_id = ANY($3)) LIMIT 1`
 	accountId := identity.AccountId(ctx)
 	result := &models.Reservation{}
 
-	err := pgxscan.Get(ctx, db.Pool, result, query, accountId, id)
+	err := pgxscan.Get(ctx, db.Pool, result, query, accountId, id, identity.Workspaces(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("pgx error: %w", err)
+		return nil, pgxError(err)
 	}
 	return result, nil
 }
 
 func (x *reservationDao) GetAWSById(ctx context.Context, id int64) (*models.AWSReservation, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
 	query := `SELECT id, provider, account_id, created_at, steps, step, status, error, finished_at, success,
     	pubkey_id, source_id, image_id, aws_reservation_id, detail
 		FROM reservations, aws_reservation_details
-		WHERE account_id = $1 AND id = $2 AND id = reservation_id AND provider = provider_type_aws() LIMIT 1`
+		WHERE account_id = $1 AND id = $2 AND id = reservation_id AND provider = provider_type_aws()
+		AND ($3::text[] IS NULL OR workspace_id = ANY($3)) LIMIT 1`
 	accountId := identity.AccountId(ctx)
 	result := &models.AWSReservation{}
 
-	err := pgxscan.Get(ctx, db.Pool, result, query, accountId, id)
+	err := pgxscan.Get(ctx, db.Pool, result, query, accountId, id, identity.Workspaces(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("pgx error: %w", err)
+		return nil, pgxError(err)
+	}
+	if result.Detail != nil {
+		if err = decryptUserData(awsUserDataColumn, &result.Detail.UserData); err != nil {
+			return nil, err
+		}
 	}
 	return result, nil
 }
 
 func (x *reservationDao) GetAzureById(ctx context.Context, id int64) (*models.AzureReservation, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
 	query := `SELECT id, reservations.provider, account_id, created_at, steps, step, status, error, finished_at, success,
     	pubkey_id, source_id, image_id, detail
 		FROM reservations, azure_reservation_details
-		WHERE account_id = $1 AND id = $2 AND id = reservation_id AND reservations.provider = provider_type_azure() LIMIT 1`
+		WHERE account_id = $1 AND id = $2 AND id = reservation_id AND reservations.provider = provider_type_azure()
+		AND ($3::text[] IS NULL OR workspace_id = ANY($3)) LIMIT 1`
 	accountId := identity.AccountId(ctx)
 	result := &models.AzureReservation{}
 
-	err := pgxscan.Get(ctx, db.Pool, result, query, accountId, id)
+	err := pgxscan.Get(ctx, db.Pool, result, query, accountId, id, identity.Workspaces(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("pgx error: %w", err)
+		return nil, pgxError(err)
+	}
+	if result.Detail != nil {
+		if err = decryptUserData(azureUserDataColumn, &result.Detail.UserData); err != nil {
+			return nil, err
+		}
 	}
 	return result, nil
 }
 
 func (x *reservationDao) GetGCPById(ctx context.Context, id int64) (*models.GCPReservation, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
 	query := `SELECT id, provider, account_id, created_at, steps, step, status, error, finished_at, success,
     	pubkey_id, source_id, image_id, detail
 		FROM reservations, gcp_reservation_details
-		WHERE account_id = $1 AND id = $2 AND id = reservation_id AND provider = provider_type_gcp() LIMIT 1`
+		WHERE account_id = $1 AND id = $2 AND id = reservation_id AND provider = provider_type_gcp()
+		AND ($3::text[] IS NULL OR workspace_id = ANY($3)) LIMIT 1`
 	accountId := identity.AccountId(ctx)
 	result := &models.GCPReservation{}
 
-	err := pgxscan.Get(ctx, db.Pool, result, query, accountId, id)
+	err := pgxscan.Get(ctx, db.Pool, result, query, accountId, id, identity.Workspaces(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("pgx error: %w", err)
+		return nil, pgxError(err)
+	}
+	if result.Detail != nil {
+		if err = decryptUserData(gcpUserDataColumn, &result.Detail.UserData); err != nil {
+			return nil, err
+		}
 	}
 	return result, nil
 }
 
 func (x *reservationDao) List(ctx context.Context, limit, offset int64) ([]*models.Reservation, error) {
-	query := `SELECT * FROM reservations WHERE account_id = $1 ORDER BY id LIMIT $2 OFFSET $3`
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT * FROM reservations WHERE account_id = $1 AND ($4::text[] IS NULL OR workspace_id = ANY($4))
+		ORDER BY id LIMIT $2 OFFSET $3`
 
 	accountId := identity.AccountId(ctx)
 	var result []*models.Reservation
 
-	rows, err := db.Pool.Query(ctx, query, accountId, limit, offset)
+	rows, err := db.Pool.Query(ctx, query, accountId, limit, offset, identity.Workspaces(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("pgx error: %w", err)
+		return nil, pgxError(err)
 	}
 
 	err = pgxscan.ScanAll(&result, rows)
 	if err != nil {
-		return nil, fmt.Errorf("pgx error: %w", err)
+		return nil, pgxError(err)
 	}
 	return result, nil
 }
 
+func (x *reservationDao) ListByCreator(ctx context.Context, userId string, limit, offset int64) ([]*models.Reservation, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT * FROM reservations WHERE account_id = $1 AND created_by_user_id = $2
+		AND ($5::text[] IS NULL OR workspace_id = ANY($5)) ORDER BY id LIMIT $3 OFFSET $4`
+
+	accountId := identity.AccountId(ctx)
+	var result []*models.Reservation
+
+	rows, err := db.Pool.Query(ctx, query, accountId, userId, limit, offset, identity.Workspaces(ctx))
+	if err != nil {
+		return nil, pgxError(err)
+	}
+
+	err = pgxscan.ScanAll(&result, rows)
+	if err != nil {
+		return nil, pgxError(err)
+	}
+	return result, nil
+}
+
+func (x *reservationDao) ListFiltered(ctx context.Context, filter *dao.ReservationFilter, limit, offset int64) ([]*models.Reservation, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT * FROM reservations WHERE account_id = $1
+		AND ($2::text = '' OR labels @> ARRAY[$2::text]) AND (NOT $3::boolean OR created_by_user_id = $4::text)
+		AND ($7::text[] IS NULL OR workspace_id = ANY($7)) ORDER BY id LIMIT $5 OFFSET $6`
+
+	accountId := identity.AccountId(ctx)
+	var result []*models.Reservation
+
+	rows, err := db.Pool.Query(ctx, query, accountId, filter.Label, filter.ByCreator, filter.CreatedByUserID,
+		limit, offset, identity.Workspaces(ctx))
+	if err != nil {
+		return nil, pgxError(err)
+	}
+
+	err = pgxscan.ScanAll(&result, rows)
+	if err != nil {
+		return nil, pgxError(err)
+	}
+	return result, nil
+}
+
+func (x *reservationDao) CountFiltered(ctx context.Context, filter *dao.ReservationFilter) (int64, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT COUNT(*) FROM (SELECT 1 FROM reservations WHERE account_id = $1
+		AND ($2::text = '' OR labels @> ARRAY[$2::text]) AND (NOT $3::boolean OR created_by_user_id = $4::text)
+		AND ($5::text[] IS NULL OR workspace_id = ANY($5)) LIMIT $6) AS capped`
+	accountId := identity.AccountId(ctx)
+	var result int64
+
+	err := db.Pool.QueryRow(ctx, query, accountId, filter.Label, filter.ByCreator, filter.CreatedByUserID,
+		identity.Workspaces(ctx), dao.MaxCountTotal).Scan(&result)
+	if err != nil {
+		return 0, pgxError(err)
+	}
+	return result, nil
+}
+
+func (x *reservationDao) ListLabelStats(ctx context.Context, filter *dao.ReservationFilter) ([]*models.LabelStats, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT label,
+			COUNT(*) AS reservations,
+			COUNT(*) FILTER (WHERE r.success IS TRUE) AS succeeded,
+			COUNT(*) FILTER (WHERE r.success IS FALSE) AS failed,
+			COALESCE(SUM(i.instances), 0) AS instances
+		FROM reservations r
+		CROSS JOIN LATERAL unnest(r.labels) AS label
+		CROSS JOIN LATERAL (SELECT COUNT(*) AS instances FROM reservation_instances ri WHERE ri.reservation_id = r.id) AS i
+		WHERE r.account_id = $1 AND ($2::text[] IS NULL OR r.workspace_id = ANY($2))
+		AND ($3::text = '' OR r.labels @> ARRAY[$3::text]) AND (NOT $4::boolean OR r.created_by_user_id = $5::text)
+		GROUP BY label ORDER BY label`
+
+	accountId := identity.AccountId(ctx)
+	var result []*models.LabelStats
+
+	rows, err := db.Pool.Query(ctx, query, accountId, identity.Workspaces(ctx),
+		filter.Label, filter.ByCreator, filter.CreatedByUserID)
+	if err != nil {
+		return nil, pgxError(err)
+	}
+
+	err = pgxscan.ScanAll(&result, rows)
+	if err != nil {
+		return nil, pgxError(err)
+	}
+	return result, nil
+}
+
+func (x *reservationDao) Count(ctx context.Context) (int64, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT COUNT(*) FROM (SELECT 1 FROM reservations WHERE account_id = $1
+		AND ($2::text[] IS NULL OR workspace_id = ANY($2)) LIMIT $3) AS capped`
+	accountId := identity.AccountId(ctx)
+	var result int64
+
+	err := db.Pool.QueryRow(ctx, query, accountId, identity.Workspaces(ctx), dao.MaxCountTotal).Scan(&result)
+	if err != nil {
+		return 0, pgxError(err)
+	}
+	return result, nil
+}
+
+func (x *reservationDao) CountByCreator(ctx context.Context, userId string) (int64, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT COUNT(*) FROM (SELECT 1 FROM reservations WHERE account_id = $1 AND created_by_user_id = $2
+		AND ($3::text[] IS NULL OR workspace_id = ANY($3)) LIMIT $4) AS capped`
+	accountId := identity.AccountId(ctx)
+	var result int64
+
+	err := db.Pool.QueryRow(ctx, query, accountId, userId, identity.Workspaces(ctx), dao.MaxCountTotal).Scan(&result)
+	if err != nil {
+		return 0, pgxError(err)
+	}
+	return result, nil
+}
+
+func (x *reservationDao) ListByIDs(ctx context.Context, ids []int64, filter *dao.ReservationFilter) ([]*models.Reservation, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT * FROM reservations WHERE account_id = $1 AND id = ANY($2)
+		AND ($3::text[] IS NULL OR workspace_id = ANY($3))
+		AND ($4::text = '' OR labels @> ARRAY[$4::text]) AND (NOT $5::boolean OR created_by_user_id = $6::text)
+		ORDER BY id`
+
+	accountId := identity.AccountId(ctx)
+	var result []*models.Reservation
+
+	rows, err := db.Pool.Query(ctx, query, accountId, ids, identity.Workspaces(ctx),
+		filter.Label, filter.ByCreator, filter.CreatedByUserID)
+	if err != nil {
+		return nil, pgxError(err)
+	}
+
+	err = pgxscan.ScanAll(&result, rows)
+	if err != nil {
+		return nil, pgxError(err)
+	}
+	return result, nil
+}
+
+func (x *reservationDao) Export(ctx context.Context, filter *dao.ReservationFilter, fn func(*models.ReservationExport) error) error {
+	query := `SELECT r.id, r.provider, r.account_id, r.created_at, r.steps, r.step_titles, r.step, r.status, r.error,
+		r.finished_at, r.success,
+		COALESCE(aws.pubkey_id, az.pubkey_id, gcp.pubkey_id, 0) AS pubkey_id,
+		COALESCE(aws.source_id, az.source_id, gcp.source_id, '') AS source_id,
+		COALESCE(aws.image_id, az.image_id, gcp.image_id, '') AS image_id,
+		COALESCE(aws.detail->>'region', az.detail->>'location', gcp.detail->>'zone', '') AS location,
+		COALESCE(aws.detail->>'instance_type', az.detail->>'instance_size', gcp.detail->>'machine_type', '') AS instance_type,
+		COALESCE((aws.detail->>'amount')::bigint, (az.detail->>'amount')::bigint, (gcp.detail->>'amount')::bigint, 0) AS amount
+		FROM reservations r
+		LEFT JOIN aws_reservation_details aws ON aws.reservation_id = r.id
+		LEFT JOIN azure_reservation_details az ON az.reservation_id = r.id
+		LEFT JOIN gcp_reservation_details gcp ON gcp.reservation_id = r.id
+		WHERE r.account_id = $1 AND ($2::text[] IS NULL OR r.workspace_id = ANY($2))
+		AND ($3::text = '' OR r.labels @> ARRAY[$3::text]) AND (NOT $4::boolean OR r.created_by_user_id = $5::text)
+		ORDER BY r.id`
+
+	accountId := identity.AccountId(ctx)
+	rows, err := db.Pool.Query(ctx, query, accountId, identity.Workspaces(ctx),
+		filter.Label, filter.ByCreator, filter.CreatedByUserID)
+	if err != nil {
+		return pgxError(err)
+	}
+	defer rows.Close()
+
+	scanner := pgxscan.NewRowScanner(rows)
+	for rows.Next() {
+		export := &models.ReservationExport{}
+		err = scanner.Scan(export)
+		if err != nil {
+			return pgxError(err)
+		}
+
+		err = fn(export)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return pgxError(err)
+	}
+	return nil
+}
+
 func (x *reservationDao) ListInstances(ctx context.Context, reservationId int64) ([]*models.ReservationInstance, error) {
-	query := `SELECT reservation_id, instance_id, detail FROM reservation_instances, reservations
-         WHERE reservation_id = reservations.id AND account_id = $1 AND reservation_id = $2`
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT reservation_id, instance_id, detail, power_state, spot_request_id, fleet_allocation, elastic_ip, dns_record FROM reservation_instances, reservations
+         WHERE reservation_id = reservations.id AND account_id = $1 AND reservation_id = $2
+         AND ($3::text[] IS NULL OR workspace_id = ANY($3))`
 
 	accountId := identity.AccountId(ctx)
 	var result []*models.ReservationInstance
 
-	rows, err := db.Pool.Query(ctx, query, accountId, reservationId)
+	rows, err := db.Pool.Query(ctx, query, accountId, reservationId, identity.Workspaces(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("pgx error: %w", err)
+		return nil, pgxError(err)
 	}
 
 	err = pgxscan.ScanAll(&result, rows)
 	if err != nil {
-		return nil, fmt.Errorf("pgx error: %w", err)
+		return nil, pgxError(err)
 	}
 	return result, nil
 }
 
+// instanceColumns are columns of models.Instance selected from instanceJoins.
+const instanceColumns = `ri.reservation_id, ri.instance_id, ri.detail, ri.power_state, ri.spot_request_id,
+		ri.fleet_allocation, ri.elastic_ip, ri.dns_record, ri.usage_started, ri.usage_terminated, r.provider, r.created_at, r.created_by_user_id,
+		COALESCE(aws.source_id, az.source_id, gcp.source_id, '') AS source_id,
+		COALESCE(aws.detail->>'region', az.detail->>'location', gcp.detail->>'zone', '') AS location,
+		COALESCE(NULLIF(ri.fleet_allocation->>'instance_type', ''), NULLIF(aws.detail->>'launched_instance_type', ''), aws.detail->>'instance_type',
+			az.detail->>'instance_size', gcp.detail->>'machine_type', '') AS instance_type`
+
+const instanceJoins = `reservation_instances ri
+		JOIN reservations r ON r.id = ri.reservation_id
+		LEFT JOIN aws_reservation_details aws ON aws.reservation_id = r.id
+		LEFT JOIN azure_reservation_details az ON az.reservation_id = r.id
+		LEFT JOIN gcp_reservation_details gcp ON gcp.reservation_id = r.id`
+
+// instancesQuery selects instances of all reservations of an account ($1) and workspaces ($2)
+// filtered by provider ($3), region ($4), power state ($5), creator ($6 and $7), source ($8) and
+// reservation ($9).
+const instancesQuery = `SELECT * FROM (SELECT ` + instanceColumns + ` FROM ` + instanceJoins + `
+		WHERE r.account_id = $1 AND ($2::text[] IS NULL OR r.workspace_id = ANY($2))) AS instances
+	WHERE ($3::integer = 0 OR provider = $3)
+		AND ($4::text = '' OR location = $4 OR location LIKE $4 || '-%')
+		AND ($5::text = '' OR power_state = $5)
+		AND (NOT $6::boolean OR created_by_user_id = $7::text)
+		AND ($8::text = '' OR source_id = $8)
+		AND ($9::bigint = 0 OR reservation_id = $9)`
+
+func (x *reservationDao) ListAllInstances(ctx context.Context, filter *dao.InstanceFilter, limit, offset int64) ([]*models.Instance, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := instancesQuery + ` ORDER BY reservation_id, instance_id LIMIT $10 OFFSET $11`
+
+	accountId := identity.AccountId(ctx)
+	var result []*models.Instance
+
+	rows, err := db.Pool.Query(ctx, query, accountId, identity.Workspaces(ctx), filter.Provider, filter.Region,
+		filter.PowerState, filter.ByCreator, filter.CreatedByUserID, filter.SourceID, filter.ReservationID, limit, offset)
+	if err != nil {
+		return nil, pgxError(err)
+	}
+
+	err = pgxscan.ScanAll(&result, rows)
+	if err != nil {
+		return nil, pgxError(err)
+	}
+	return result, nil
+}
+
+func (x *reservationDao) CountAllInstances(ctx context.Context, filter *dao.InstanceFilter) (int64, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT COUNT(*) FROM (` + instancesQuery + ` LIMIT $10) AS capped`
+	accountId := identity.AccountId(ctx)
+	var result int64
+
+	err := db.Pool.QueryRow(ctx, query, accountId, identity.Workspaces(ctx), filter.Provider, filter.Region,
+		filter.PowerState, filter.ByCreator, filter.CreatedByUserID, filter.SourceID, filter.ReservationID, dao.MaxCountTotal).Scan(&result)
+	if err != nil {
+		return 0, pgxError(err)
+	}
+	return result, nil
+}
+
+func (x *reservationDao) WaitForUpdate(ctx context.Context, reservation *models.Reservation) error {
+	// LISTEN does not work through transaction pooling
+	if config.Database.PgBouncer {
+		return x.pollForUpdate(ctx, reservation)
+	}
+
+	sub := db.Subscribe(db.ReservationStatusChannel, strconv.FormatInt(reservation.ID, 10))
+	defer sub.Close()
+
+	// the reservation could have changed between the read and the subscription, subscriptions are
+	// also woken up without a change when the listener reconnects
+	for {
+		changed, err := x.changedSince(ctx, reservation)
+		if err != nil || changed {
+			return err
+		}
+
+		err = sub.Wait(ctx)
+		if err != nil {
+			return pgxError(err)
+		}
+	}
+}
+
+// pollForUpdate periodically compares reservation progress until it changes or context is done.
+func (x *reservationDao) pollForUpdate(ctx context.Context, reservation *models.Reservation) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		changed, err := x.changedSince(ctx, reservation)
+		if err != nil || changed {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("wait for update: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// changedSince compares status, step and finish time of the stored reservation with the given one.
+func (x *reservationDao) changedSince(ctx context.Context, reservation *models.Reservation) (bool, error) {
+	query := `SELECT status, step, finished_at FROM reservations WHERE id = $1`
+
+	var current models.Reservation
+	err := db.Pool.QueryRow(ctx, query, reservation.ID).Scan(&current.Status, &current.Step, &current.FinishedAt)
+	if err != nil {
+		return false, pgxError(err)
+	}
+	return current.Status != reservation.Status || current.Step != reservation.Step ||
+		current.FinishedAt.Valid != reservation.FinishedAt.Valid ||
+		!current.FinishedAt.Time.Equal(reservation.FinishedAt.Time), nil
+}
+
 func (x *reservationDao) UpdateStatus(ctx context.Context, id int64, status string, addSteps int32) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
 	query := `UPDATE reservations SET status = $2, step = step + $3 WHERE id = $1`
 
 	tag, err := db.Pool.Exec(ctx, query, id, status, addSteps)
 	if err != nil {
-		return fmt.Errorf("pgx error: %w", err)
+		return pgxError(err)
 	}
 	if tag.RowsAffected() != 1 {
 		return fmt.Errorf("expected 1 row: %w", dao.ErrAffectedMismatch)
@@ -286,11 +843,18 @@ func (x *reservationDao) UpdateStatus(ctx context.Context, id int64, status stri
 }
 
 func (x *reservationDao) UnscopedUpdateAWSDetail(ctx context.Context, id int64, awsDetail *models.AWSDetail) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
 	query := `UPDATE aws_reservation_details SET detail = $2 WHERE reservation_id = $1`
 
-	tag, err := db.Pool.Exec(ctx, query, id, awsDetail)
+	stored := *awsDetail
+	if err := encryptUserData(awsUserDataColumn, &stored.UserData); err != nil {
+		return err
+	}
+	tag, err := db.Pool.Exec(ctx, query, id, &stored)
 	if err != nil {
-		return fmt.Errorf("pgx error: %w", err)
+		return pgxError(err)
 	}
 	if tag.RowsAffected() != 1 {
 		return fmt.Errorf("expected 1 row: %w", dao.ErrAffectedMismatch)
@@ -299,11 +863,14 @@ func (x *reservationDao) UnscopedUpdateAWSDetail(ctx context.Context, id int64,
 }
 
 func (x *reservationDao) UpdateReservationIDForAWS(ctx context.Context, id int64, awsReservationId string) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
 	query := `UPDATE aws_reservation_details SET aws_reservation_id = $2 WHERE reservation_id = $1`
 
 	tag, err := db.Pool.Exec(ctx, query, id, awsReservationId)
 	if err != nil {
-		return fmt.Errorf("pgx error: %w", err)
+		return pgxError(err)
 	}
 	if tag.RowsAffected() != 1 {
 		return fmt.Errorf("expected 1 row: %w", dao.ErrAffectedMismatch)
@@ -312,11 +879,30 @@ func (x *reservationDao) UpdateReservationIDForAWS(ctx context.Context, id int64
 }
 
 func (x *reservationDao) UpdateOperationNameForGCP(ctx context.Context, id int64, gcpOperationName string) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
 	query := `UPDATE gcp_reservation_details SET gcp_operation_name = $2 WHERE reservation_id = $1`
 
 	tag, err := db.Pool.Exec(ctx, query, id, gcpOperationName)
 	if err != nil {
-		return fmt.Errorf("pgx error: %w", err)
+		return pgxError(err)
+	}
+	if tag.RowsAffected() != 1 {
+		return fmt.Errorf("expected 1 row: %w", dao.ErrAffectedMismatch)
+	}
+	return nil
+}
+
+func (x *reservationDao) UpdateReachable(ctx context.Context, id int64, reachable bool) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `UPDATE reservations SET reachable = $2 WHERE id = $1`
+
+	tag, err := db.Pool.Exec(ctx, query, id, reachable)
+	if err != nil {
+		return pgxError(err)
 	}
 	if tag.RowsAffected() != 1 {
 		return fmt.Errorf("expected 1 row: %w", dao.ErrAffectedMismatch)
@@ -325,11 +911,53 @@ func (x *reservationDao) UpdateOperationNameForGCP(ctx context.Context, id int64
 }
 
 func (x *reservationDao) FinishWithSuccess(ctx context.Context, id int64) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
 	query := `UPDATE reservations SET success = true, finished_at = now() WHERE id = $1`
 
 	tag, err := db.Pool.Exec(ctx, query, id)
 	if err != nil {
-		return fmt.Errorf("pgx error: %w", err)
+		return pgxError(err)
+	}
+	if tag.RowsAffected() != 1 {
+		return fmt.Errorf("expected 1 row: %w", dao.ErrAffectedMismatch)
+	}
+	return nil
+}
+
+func (x *reservationDao) UpdateApproval(ctx context.Context, id int64, from, to models.ApprovalState, approvedBy string) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `UPDATE reservations SET approval = $4, approved_by = $5
+		WHERE account_id = $1 AND id = $2 AND approval = $3 AND ($6::text[] IS NULL OR workspace_id = ANY($6))`
+	accountId := identity.AccountId(ctx)
+
+	tag, err := db.Pool.Exec(ctx, query, accountId, id, from, to, approvedBy, identity.Workspaces(ctx))
+	if err != nil {
+		return pgxError(err)
+	}
+	if tag.RowsAffected() != 1 {
+		return fmt.Errorf("expected 1 row: %w", dao.ErrAffectedMismatch)
+	}
+	return nil
+}
+
+func (x *reservationDao) UpdateLabels(ctx context.Context, id int64, labels []string) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	if labels == nil {
+		labels = []string{}
+	}
+	query := `UPDATE reservations SET labels = $3
+		WHERE account_id = $1 AND id = $2 AND ($4::text[] IS NULL OR workspace_id = ANY($4))`
+	accountId := identity.AccountId(ctx)
+
+	tag, err := db.Pool.Exec(ctx, query, accountId, id, labels, identity.Workspaces(ctx))
+	if err != nil {
+		return pgxError(err)
 	}
 	if tag.RowsAffected() != 1 {
 		return fmt.Errorf("expected 1 row: %w", dao.ErrAffectedMismatch)
@@ -338,11 +966,14 @@ func (x *reservationDao) FinishWithSuccess(ctx context.Context, id int64) error
 }
 
 func (x *reservationDao) FinishWithError(ctx context.Context, id int64, errorString string) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
 	query := `UPDATE reservations SET success = false, error = $2, finished_at = now() WHERE id = $1`
 
 	tag, err := db.Pool.Exec(ctx, query, id, errorString)
 	if err != nil {
-		return fmt.Errorf("pgx error: %w", err)
+		return pgxError(err)
 	}
 	if tag.RowsAffected() != 1 {
 		return fmt.Errorf("expected 1 row: %w", dao.ErrAffectedMismatch)
@@ -351,11 +982,128 @@ func (x *reservationDao) FinishWithError(ctx context.Context, id int64, errorStr
 }
 
 func (x *reservationDao) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
 	query := `DELETE FROM reservations WHERE id = $1`
 
 	tag, err := db.Pool.Exec(ctx, query, id)
 	if err != nil {
-		return fmt.Errorf("pgx error: %w", err)
+		return pgxError(err)
+	}
+	if tag.RowsAffected() != 1 {
+		return fmt.Errorf("expected 1 row: %w", dao.ErrAffectedMismatch)
+	}
+	return nil
+}
+
+func (x *reservationDao) UnscopedListInstanceSources(ctx context.Context) ([]*models.InstanceSource, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT DISTINCT r.account_id, r.provider,
+			COALESCE(aws.source_id, az.source_id, gcp.source_id) AS source_id,
+			COALESCE(aws.detail->>'region', '') AS region
+		FROM reservations r
+		LEFT JOIN aws_reservation_details aws ON aws.reservation_id = r.id
+		LEFT JOIN azure_reservation_details az ON az.reservation_id = r.id
+		LEFT JOIN gcp_reservation_details gcp ON gcp.reservation_id = r.id
+		WHERE EXISTS (SELECT 1 FROM reservation_instances ri WHERE ri.reservation_id = r.id)
+		ORDER BY r.account_id, r.provider, source_id, region`
+	var result []*models.InstanceSource
+
+	rows, err := db.Pool.Query(ctx, query)
+	if err != nil {
+		return nil, pgxError(err)
+	}
+
+	err = pgxscan.ScanAll(&result, rows)
+	if err != nil {
+		return nil, pgxError(err)
+	}
+	return result, nil
+}
+
+func (x *reservationDao) UnscopedListPendingUsage(ctx context.Context, limit int64) ([]*models.UsageInstance, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + instanceColumns + `, r.account_id FROM ` + instanceJoins + `
+		WHERE NOT ri.usage_started OR (ri.power_state = 'terminated' AND NOT ri.usage_terminated)
+		ORDER BY r.account_id, ri.reservation_id, ri.instance_id LIMIT $1`
+	var result []*models.UsageInstance
+
+	rows, err := db.Pool.Query(ctx, query, limit)
+	if err != nil {
+		return nil, pgxError(err)
+	}
+
+	err = pgxscan.ScanAll(&result, rows)
+	if err != nil {
+		return nil, pgxError(err)
+	}
+	return result, nil
+}
+
+func (x *reservationDao) UnscopedUpdateInstanceUsage(ctx context.Context, reservationID int64, instanceID string, started, terminated bool) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `UPDATE reservation_instances SET usage_started = $3, usage_terminated = $4 WHERE reservation_id = $1 AND instance_id = $2`
+	tag, err := db.Pool.Exec(ctx, query, reservationID, instanceID, started, terminated)
+	if err != nil {
+		return pgxError(err)
+	}
+	if tag.RowsAffected() != 1 {
+		return fmt.Errorf("expected 1 row: %w", dao.ErrAffectedMismatch)
+	}
+
+	return nil
+}
+
+func (x *reservationDao) UnscopedGetById(ctx context.Context, id int64) (*models.Reservation, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT * FROM reservations WHERE id = $1 LIMIT 1`
+	result := &models.Reservation{}
+
+	err := pgxscan.Get(ctx, db.Pool, result, query, id)
+	if err != nil {
+		return nil, pgxError(err)
+	}
+	return result, nil
+}
+
+func (x *reservationDao) UnscopedList(ctx context.Context, pendingOnly bool, limit, offset int64) ([]*models.Reservation, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT * FROM reservations WHERE NOT $1 OR finished_at IS NULL ORDER BY id LIMIT $2 OFFSET $3`
+	var result []*models.Reservation
+
+	rows, err := db.Pool.Query(ctx, query, pendingOnly, limit, offset)
+	if err != nil {
+		return nil, pgxError(err)
+	}
+
+	err = pgxscan.ScanAll(&result, rows)
+	if err != nil {
+		return nil, pgxError(err)
+	}
+	return result, nil
+}
+
+func (x *reservationDao) UnscopedRequeue(ctx context.Context, id int64) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `UPDATE reservations SET status = 'Requeued', step = 0, error = '', success = NULL, finished_at = NULL
+		WHERE id = $1 AND finished_at IS NOT NULL`
+
+	tag, err := db.Pool.Exec(ctx, query, id)
+	if err != nil {
+		return pgxError(err)
 	}
 	if tag.RowsAffected() != 1 {
 		return fmt.Errorf("expected 1 row: %w", dao.ErrAffectedMismatch)
@@ -363,14 +1111,153 @@ func (x *reservationDao) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
+func (x *reservationDao) CreateJob(ctx context.Context, job *models.ReservationJob) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	stored, err := transformJobUserData(job.Job, encryptUserData)
+	if err != nil {
+		return fmt.Errorf("job: %w", err)
+	}
+
+	query := `INSERT INTO reservation_jobs (reservation_id, job_type, job) VALUES ($1, $2, $3) RETURNING created_at`
+
+	err = db.Pool.QueryRow(ctx, query, job.ReservationID, job.JobType, stored).Scan(&job.CreatedAt)
+	if err != nil {
+		return pgxError(err)
+	}
+	return nil
+}
+
+func (x *reservationDao) UnscopedGetJob(ctx context.Context, reservationId int64) (*models.ReservationJob, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT * FROM reservation_jobs WHERE reservation_id = $1 LIMIT 1`
+	result := &models.ReservationJob{}
+
+	err := pgxscan.Get(ctx, db.Pool, result, query, reservationId)
+	if err != nil {
+		return nil, pgxError(err)
+	}
+	result.Job, err = transformJobUserData(result.Job, decryptUserData)
+	if err != nil {
+		return nil, fmt.Errorf("job: %w", err)
+	}
+	return result, nil
+}
+
+func (x *reservationDao) CreateEvent(ctx context.Context, event *models.ReservationEvent) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	if event.Details == nil {
+		event.Details = map[string]string{}
+	}
+	if vError := models.Validate(ctx, event); vError != nil {
+		return fmt.Errorf("reservation event validation: %w", vError)
+	}
+
+	query := `INSERT INTO reservation_events (reservation_id, kind, message, details) VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`
+
+	err := db.Pool.QueryRow(ctx, query,
+		event.ReservationID,
+		event.Kind,
+		event.Message,
+		event.Details).Scan(&event.ID, &event.CreatedAt)
+	if err != nil {
+		return pgxError(err)
+	}
+	return nil
+}
+
+func (x *reservationDao) ListEvents(ctx context.Context, reservationId int64, limit, offset int64) ([]*models.ReservationEvent, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT e.* FROM reservation_events e JOIN reservations r ON r.id = e.reservation_id
+		WHERE r.account_id = $1 AND e.reservation_id = $2 ORDER BY e.id LIMIT $3 OFFSET $4`
+	accountId := identity.AccountId(ctx)
+	var result []*models.ReservationEvent
+
+	rows, err := db.Pool.Query(ctx, query, accountId, reservationId, limit, offset)
+	if err != nil {
+		return nil, pgxError(err)
+	}
+
+	err = pgxscan.ScanAll(&result, rows)
+	if err != nil {
+		return nil, pgxError(err)
+	}
+	return result, nil
+}
+
+func (x *reservationDao) CountEvents(ctx context.Context, reservationId int64) (int64, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT COUNT(*) FROM (SELECT 1 FROM reservation_events e JOIN reservations r ON r.id = e.reservation_id
+		WHERE r.account_id = $1 AND e.reservation_id = $2 LIMIT $3) AS capped`
+	accountId := identity.AccountId(ctx)
+	var result int64
+
+	err := db.Pool.QueryRow(ctx, query, accountId, reservationId, dao.MaxCountTotal).Scan(&result)
+	if err != nil {
+		return 0, pgxError(err)
+	}
+	return result, nil
+}
+
+func (x *reservationDao) UnscopedListEvents(ctx context.Context, reservationId int64, limit int64) ([]*models.ReservationEvent, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT * FROM reservation_events WHERE reservation_id = $1 ORDER BY id LIMIT $2`
+	var result []*models.ReservationEvent
+
+	rows, err := db.Pool.Query(ctx, query, reservationId, limit)
+	if err != nil {
+		return nil, pgxError(err)
+	}
+
+	err = pgxscan.ScanAll(&result, rows)
+	if err != nil {
+		return nil, pgxError(err)
+	}
+	return result, nil
+}
+
+func (x *reservationDao) UnscopedListExpired(ctx context.Context, limit int64) ([]*models.Reservation, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT * FROM reservations WHERE created_at < now() - cast($1 as interval) ORDER BY id LIMIT $2`
+	var result []*models.Reservation
+
+	rows, err := db.Pool.Query(ctx, query, config.Reservation.Lifetime.String(), limit)
+	if err != nil {
+		return nil, pgxError(err)
+	}
+
+	err = pgxscan.ScanAll(&result, rows)
+	if err != nil {
+		return nil, pgxError(err)
+	}
+	return result, nil
+}
+
 func (x *reservationDao) Cleanup(ctx context.Context) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
 	logger := zerolog.Ctx(ctx)
 	query := `DELETE FROM reservations WHERE created_at < now() - cast($1 as interval)`
 	reservationLifetime := config.Reservation.Lifetime.String()
 
 	tag, err := db.Pool.Exec(ctx, query, reservationLifetime)
 	if err != nil {
-		return fmt.Errorf("pgx error: %w", err)
+		return pgxError(err)
 	}
 	logger.Trace().Msgf("Deleted %d reservation(s) older than %s", tag.RowsAffected(), reservationLifetime)
 
@@ -0,0 +1,31 @@
+package pgx
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransformJobUserData(t *testing.T) {
+	upper := func(column string, userData *string) error {
+		assert.Equal(t, jobUserDataColumn, column)
+		*userData = strings.ToUpper(*userData)
+		return nil
+	}
+
+	t.Run("user data", func(t *testing.T) {
+		job := []byte(`{"account_id":1,"args":{"ReservationID":9007199254740993,"Detail":{"user_data":"secret"}}}`)
+		result, err := transformJobUserData(job, upper)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"account_id":1,"args":{"ReservationID":9007199254740993,"Detail":{"user_data":"SECRET"}}}`, string(result))
+	})
+
+	t.Run("without user data", func(t *testing.T) {
+		job := []byte(`{"args":{"ReservationID":1}}`)
+		result, err := transformJobUserData(job, upper)
+		require.NoError(t, err)
+		assert.Equal(t, job, result)
+	})
+}
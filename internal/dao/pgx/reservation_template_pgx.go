@@ -0,0 +1,197 @@
+package pgx
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/db"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+func init() {
+	dao.GetReservationTemplateDao = getReservationTemplateDao
+}
+
+type reservationTemplateDao struct{}
+
+func getReservationTemplateDao(ctx context.Context) dao.ReservationTemplateDao {
+	return dao.InstrumentReservationTemplateDao(&reservationTemplateDao{})
+}
+
+func (x *reservationTemplateDao) Create(ctx context.Context, template *models.ReservationTemplate) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO reservation_templates (account_id, workspace_id, provider, name, request, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at`
+
+	template.AccountID = identity.AccountId(ctx)
+	template.WorkspaceID = identity.DefaultWorkspace(ctx)
+	template.CreatedBy = identity.Identity(ctx).Identity.User.Username
+
+	if vError := models.Validate(ctx, template); vError != nil {
+		return fmt.Errorf("template validation: %w", vError)
+	}
+
+	err := db.Pool.QueryRow(ctx, query,
+		template.AccountID,
+		template.WorkspaceID,
+		template.Provider,
+		template.Name,
+		template.Request,
+		template.CreatedBy).Scan(&template.ID, &template.CreatedAt)
+	if err != nil {
+		return pgxError(err)
+	}
+
+	return nil
+}
+
+func (x *reservationTemplateDao) GetById(ctx context.Context, id int64) (*models.ReservationTemplate, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT * FROM reservation_templates WHERE account_id = $1 AND id = $2 AND ($3::text[] IS NULL OR workspace_id = ANY($3)) LIMIT 1`
+	accountId := identity.AccountId(ctx)
+	result := &models.ReservationTemplate{}
+
+	err := pgxscan.Get(ctx, db.Pool, result, query, accountId, id, identity.Workspaces(ctx))
+	if err != nil {
+		return nil, pgxError(err)
+	}
+	return result, nil
+}
+
+func (x *reservationTemplateDao) List(ctx context.Context, limit, offset int64) ([]*models.ReservationTemplate, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT * FROM reservation_templates WHERE account_id = $1 AND ($4::text[] IS NULL OR workspace_id = ANY($4)) ORDER BY id LIMIT $2 OFFSET $3`
+	accountId := identity.AccountId(ctx)
+	var result []*models.ReservationTemplate
+
+	rows, err := db.Pool.Query(ctx, query, accountId, limit, offset, identity.Workspaces(ctx))
+	if err != nil {
+		return nil, pgxError(err)
+	}
+
+	err = pgxscan.ScanAll(&result, rows)
+	if err != nil {
+		return nil, pgxError(err)
+	}
+	return result, nil
+}
+
+func (x *reservationTemplateDao) Count(ctx context.Context) (int64, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT COUNT(*) FROM (SELECT 1 FROM reservation_templates WHERE account_id = $1
+		AND ($2::text[] IS NULL OR workspace_id = ANY($2)) LIMIT $3) AS capped`
+	accountId := identity.AccountId(ctx)
+	var result int64
+
+	err := db.Pool.QueryRow(ctx, query, accountId, identity.Workspaces(ctx), dao.MaxCountTotal).Scan(&result)
+	if err != nil {
+		return 0, pgxError(err)
+	}
+	return result, nil
+}
+
+func (x *reservationTemplateDao) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `DELETE FROM reservation_templates WHERE account_id = $1 AND id = $2 AND ($3::text[] IS NULL OR workspace_id = ANY($3))`
+	accountId := identity.AccountId(ctx)
+
+	tag, err := db.Pool.Exec(ctx, query, accountId, id, identity.Workspaces(ctx))
+	if err != nil {
+		return pgxError(err)
+	}
+	if tag.RowsAffected() != 1 {
+		return fmt.Errorf("expected 1 row: %w", dao.ErrAffectedMismatch)
+	}
+	return nil
+}
+
+func (x *reservationTemplateDao) UpdateSchedule(ctx context.Context, id int64, cron string, nextRunAt *time.Time, userID, username string) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE reservation_templates SET
+			schedule_cron = $4,
+			next_run_at = $5,
+			schedule_user_id = $6,
+			schedule_username = $7
+		WHERE account_id = $1 AND id = $2 AND ($3::text[] IS NULL OR workspace_id = ANY($3))`
+	accountId := identity.AccountId(ctx)
+
+	tag, err := db.Pool.Exec(ctx, query, accountId, id, identity.Workspaces(ctx), cron, nextRunAt, userID, username)
+	if err != nil {
+		return pgxError(err)
+	}
+	if tag.RowsAffected() != 1 {
+		return fmt.Errorf("expected 1 row: %w", dao.ErrAffectedMismatch)
+	}
+	return nil
+}
+
+func (x *reservationTemplateDao) UnscopedListDue(ctx context.Context, before time.Time, limit int64) ([]*models.ReservationTemplate, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT * FROM reservation_templates WHERE next_run_at <= $1 ORDER BY next_run_at LIMIT $2`
+	var result []*models.ReservationTemplate
+
+	rows, err := db.Pool.Query(ctx, query, before, limit)
+	if err != nil {
+		return nil, pgxError(err)
+	}
+
+	err = pgxscan.ScanAll(&result, rows)
+	if err != nil {
+		return nil, pgxError(err)
+	}
+	return result, nil
+}
+
+func (x *reservationTemplateDao) UnscopedClaimRun(ctx context.Context, id int64, runAt time.Time, nextRunAt *time.Time) (bool, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE reservation_templates SET
+			next_run_at = $3,
+			last_run_at = current_timestamp,
+			last_reservation_id = NULL
+		WHERE id = $1 AND next_run_at = $2`
+
+	tag, err := db.Pool.Exec(ctx, query, id, runAt, nextRunAt)
+	if err != nil {
+		return false, pgxError(err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (x *reservationTemplateDao) UnscopedUpdateLastReservation(ctx context.Context, id int64, reservationId int64) error {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	query := `UPDATE reservation_templates SET last_reservation_id = $2 WHERE id = $1`
+
+	tag, err := db.Pool.Exec(ctx, query, id, reservationId)
+	if err != nil {
+		return pgxError(err)
+	}
+	if tag.RowsAffected() != 1 {
+		return fmt.Errorf("expected 1 row: %w", dao.ErrAffectedMismatch)
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/RHEnVision/provisioning-backend/internal/dao"
 	"github.com/RHEnVision/provisioning-backend/internal/db"
+	"github.com/RHEnVision/provisioning-backend/internal/encryption"
 	"github.com/RHEnVision/provisioning-backend/internal/models"
 	"github.com/georgysavva/scany/v2/pgxscan"
 	"github.com/rs/zerolog"
@@ -18,7 +19,7 @@ func init() {
 type serviceDao struct{}
 
 func getServiceDao(_ context.Context) dao.ServiceDao {
-	return &serviceDao{}
+	return dao.InstrumentServiceDao(&serviceDao{})
 }
 
 func UnscopedUpdatePubkey(ctx context.Context, pubkey *models.Pubkey) error {
@@ -31,9 +32,14 @@ func UnscopedUpdatePubkey(ctx context.Context, pubkey *models.Pubkey) error {
 			fingerprint_legacy = $6
 		WHERE id = $1`
 
-	tag, err := db.Pool.Exec(ctx, query, pubkey.ID, pubkey.Type, pubkey.Name, pubkey.Body, pubkey.Fingerprint, pubkey.FingerprintLegacy)
+	body, err := encryptPubkeyBody(pubkey)
 	if err != nil {
-		return fmt.Errorf("pgx error: %w", err)
+		return err
+	}
+
+	tag, err := db.Pool.Exec(ctx, query, pubkey.ID, pubkey.Type, pubkey.Name, body, pubkey.Fingerprint, pubkey.FingerprintLegacy)
+	if err != nil {
+		return pgxError(err)
 	}
 	if tag.RowsAffected() != 1 {
 		return fmt.Errorf("expected 1 row: %w", dao.ErrAffectedMismatch)
@@ -51,7 +57,7 @@ func (x *serviceDao) RecalculatePubkeyFingerprints(ctx context.Context) (int, er
 
 	rows, err := db.Pool.Query(ctx, query)
 	if err != nil {
-		return total, fmt.Errorf("pgx error: %w", err)
+		return total, pgxError(err)
 	}
 	defer rows.Close()
 
@@ -60,7 +66,10 @@ func (x *serviceDao) RecalculatePubkeyFingerprints(ctx context.Context) (int, er
 
 		err = pgxscan.ScanRow(&pk, rows)
 		if err != nil {
-			return total, fmt.Errorf("pgx scan error: %w", err)
+			return total, pgxError(err)
+		}
+		if err = decryptPubkeyBodies(&pk); err != nil {
+			return total, err
 		}
 
 		logger.Trace().Msgf("Pubkey before: %+v", pk)
@@ -77,8 +86,153 @@ func (x *serviceDao) RecalculatePubkeyFingerprints(ctx context.Context) (int, er
 		total += 1
 	}
 	if err := rows.Err(); err != nil {
-		return total, fmt.Errorf("pgx error: %w", err)
+		return total, pgxError(err)
+	}
+
+	return total, nil
+}
+
+// reencryptBatchSize is the number of rows read at once by re-encryption
+const reencryptBatchSize = 100
+
+// ReencryptPubkeys encrypts bodies of all pubkeys which are stored in plain text or encrypted with
+// a different than the active data key. Nothing is updated when column encryption is disabled. Rows
+// are processed in batches ordered by id, bodies changed concurrently are skipped and encrypted by
+// the update itself. Returns the number of updated rows.
+func (x *serviceDao) ReencryptPubkeys(ctx context.Context) (int, error) {
+	total := 0
+	if !encryption.Enabled() {
+		return total, nil
 	}
+	selectQuery := `SELECT id, body FROM pubkeys WHERE id > $1 ORDER BY id LIMIT $2`
+	updateQuery := `UPDATE pubkeys SET body = $2 WHERE id = $1 AND body = $3`
+	logger := zerolog.Ctx(ctx)
+
+	var lastId int64
+	for {
+		var batch []*models.Pubkey
+		err := pgxscan.Select(ctx, db.Pool, &batch, selectQuery, lastId, reencryptBatchSize)
+		if err != nil {
+			return total, pgxError(err)
+		}
+		if len(batch) == 0 {
+			return total, nil
+		}
+
+		for _, pk := range batch {
+			lastId = pk.ID
+			if !encryption.NeedsReencryption(pk.Body) {
+				continue
+			}
+
+			stored := pk.Body
+			if err = decryptPubkeyBodies(pk); err != nil {
+				return total, err
+			}
+			body, err := encryptPubkeyBody(pk)
+			if err != nil {
+				return total, err
+			}
+
+			tag, err := db.Pool.Exec(ctx, updateQuery, pk.ID, body, stored)
+			if err != nil {
+				return total, pgxError(err)
+			}
+			if tag.RowsAffected() == 1 {
+				logger.Debug().Int64("pubkey_id", pk.ID).Msg("Re-encrypted pubkey body")
+				total += 1
+			}
+		}
+	}
+}
+
+// userDataTables are reservation detail and job tables with custom user data and columns authenticated
+// together with the encrypted value.
+var userDataTables = []struct {
+	table  string
+	column string
 
+	// document is the JSON column and path the path of user data in it
+	document string
+	path     string
+}{
+	{"aws_reservation_details", awsUserDataColumn, "detail", "{user_data}"},
+	{"azure_reservation_details", azureUserDataColumn, "detail", "{user_data}"},
+	{"gcp_reservation_details", gcpUserDataColumn, "detail", "{user_data}"},
+	{"reservation_jobs", jobUserDataColumn, "job", "{args,Detail,user_data}"},
+}
+
+// ReencryptUserData encrypts custom user data of all reservation details and stored jobs which is
+// stored in plain text or encrypted with a different than the active data key, see ReencryptPubkeys.
+// Returns the number of updated rows.
+func (x *serviceDao) ReencryptUserData(ctx context.Context) (int, error) {
+	total := 0
+	for _, t := range userDataTables {
+		value := t.document + ` #>> '` + t.path + `'`
+		selectQuery := `SELECT reservation_id AS id, ` + value + ` AS value FROM ` + t.table + `
+			WHERE reservation_id > $1 AND ` + value + ` <> '' ORDER BY reservation_id LIMIT $2`
+		updateQuery := `UPDATE ` + t.table + ` SET ` + t.document + ` = jsonb_set(` + t.document + `, '` + t.path + `', to_jsonb($2::text))
+			WHERE reservation_id = $1 AND ` + value + ` = $3`
+
+		count, err := reencryptColumn(ctx, t.column, selectQuery, updateQuery)
+		total += count
+		if err != nil {
+			return total, err
+		}
+	}
 	return total, nil
 }
+
+// reencryptedValue is a stored value of an encrypted column
+type reencryptedValue struct {
+	ID    int64  `db:"id"`
+	Value string `db:"value"`
+}
+
+// reencryptColumn re-encrypts values of the column in batches. The select query returns id and
+// value columns with ids greater than $1 limited by $2, the update query sets the value ($2) of
+// the row ($1) when the stored value is still $3.
+func reencryptColumn(ctx context.Context, column, selectQuery, updateQuery string) (int, error) {
+	total := 0
+	if !encryption.Enabled() {
+		return total, nil
+	}
+	logger := zerolog.Ctx(ctx)
+
+	var lastId int64
+	for {
+		var batch []*reencryptedValue
+		err := pgxscan.Select(ctx, db.Pool, &batch, selectQuery, lastId, reencryptBatchSize)
+		if err != nil {
+			return total, pgxError(err)
+		}
+		if len(batch) == 0 {
+			return total, nil
+		}
+
+		for _, row := range batch {
+			lastId = row.ID
+			if !encryption.NeedsReencryption(row.Value) {
+				continue
+			}
+
+			plain, err := encryption.Decrypt(column, row.Value)
+			if err != nil {
+				return total, fmt.Errorf("%s of %d: %w", column, row.ID, err)
+			}
+			value, err := encryption.Encrypt(column, plain)
+			if err != nil {
+				return total, fmt.Errorf("%s of %d: %w", column, row.ID, err)
+			}
+
+			tag, err := db.Pool.Exec(ctx, updateQuery, row.ID, value, row.Value)
+			if err != nil {
+				return total, pgxError(err)
+			}
+			if tag.RowsAffected() == 1 {
+				logger.Debug().Int64("id", row.ID).Str("column", column).Msg("Re-encrypted column value")
+				total += 1
+			}
+		}
+	}
+}
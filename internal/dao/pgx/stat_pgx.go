@@ -17,7 +17,7 @@ func init() {
 type statDao struct{}
 
 func getStatDao(ctx context.Context) dao.StatDao {
-	return &statDao{}
+	return dao.InstrumentStatDao(&statDao{})
 }
 
 func (x *statDao) getUsage(ctx context.Context, iStart, iEnd string) ([]*models.UsageStat, error) {
@@ -46,18 +46,21 @@ func (x *statDao) getUsage(ctx context.Context, iStart, iEnd string) ([]*models.
 	var result []*models.UsageStat
 	rows, err := db.Pool.Query(ctx, query, iStart, iEnd)
 	if err != nil {
-		return nil, fmt.Errorf("pgx error: %w", err)
+		return nil, pgxError(err)
 	}
 
 	err = pgxscan.ScanAll(&result, rows)
 	if err != nil {
-		return nil, fmt.Errorf("pgx error: %w", err)
+		return nil, pgxError(err)
 	}
 
 	return result, nil
 }
 
 func (x *statDao) Get(ctx context.Context, delayMin int) (*models.Statistics, error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
 	delay := fmt.Sprintf("%d minutes", delayMin)
 	usage24h, err := x.getUsage(ctx, "24 hours "+delay, delay)
 	if err != nil {
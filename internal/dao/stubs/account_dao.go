@@ -3,15 +3,22 @@ package stubs
 import (
 	"context"
 	"database/sql"
+	"sort"
+	"time"
 
 	"github.com/RHEnVision/provisioning-backend/internal/dao"
 	"github.com/RHEnVision/provisioning-backend/internal/models"
 	"github.com/RHEnVision/provisioning-backend/internal/testing/identity"
+	"golang.org/x/exp/slices"
 )
 
 type accountDaoStub struct {
-	store  []*models.Account
-	lastId int64
+	store     []*models.Account
+	lastId    int64
+	sequences map[int64]int64
+	settings  map[int64]*models.AccountSettings
+	sources   []*models.SourceSettings
+	usage     []*models.AccountUsage
 }
 
 func buildAccountDaoWithOneAccount() *accountDaoStub {
@@ -50,6 +57,9 @@ func AccountStubCount(ctx context.Context) int {
 }
 
 func (stub *accountDaoStub) Create(ctx context.Context, pk *models.Account) error {
+	if err := injectFault(ctx, "AccountDao.Create"); err != nil {
+		return err
+	}
 	pk.ID = stub.lastId + 1
 	stub.store = append(stub.store, pk)
 	stub.lastId++
@@ -57,6 +67,9 @@ func (stub *accountDaoStub) Create(ctx context.Context, pk *models.Account) erro
 }
 
 func (stub *accountDaoStub) GetById(ctx context.Context, id int64) (*models.Account, error) {
+	if err := injectFault(ctx, "AccountDao.GetById"); err != nil {
+		return nil, err
+	}
 	for _, acc := range stub.store {
 		if acc.ID == id {
 			return acc, nil
@@ -66,6 +79,9 @@ func (stub *accountDaoStub) GetById(ctx context.Context, id int64) (*models.Acco
 }
 
 func (stub *accountDaoStub) GetOrCreateByIdentity(ctx context.Context, orgId string, accountNumber string) (*models.Account, error) {
+	if err := injectFault(ctx, "AccountDao.GetOrCreateByIdentity"); err != nil {
+		return nil, err
+	}
 	acc, err := stub.GetByOrgId(ctx, orgId)
 	if err == nil {
 		return acc, nil
@@ -82,6 +98,9 @@ func (stub *accountDaoStub) GetOrCreateByIdentity(ctx context.Context, orgId str
 }
 
 func (stub *accountDaoStub) GetByOrgId(ctx context.Context, orgId string) (*models.Account, error) {
+	if err := injectFault(ctx, "AccountDao.GetByOrgId"); err != nil {
+		return nil, err
+	}
 	for _, acc := range stub.store {
 		if acc.OrgID == orgId {
 			return acc, nil
@@ -100,5 +119,222 @@ func (stub *accountDaoStub) getByAccountNumber(ctx context.Context, number strin
 }
 
 func (stub *accountDaoStub) List(ctx context.Context, limit, offset int64) ([]*models.Account, error) {
+	if err := injectFault(ctx, "AccountDao.List"); err != nil {
+		return nil, err
+	}
+	return stub.store, nil
+}
+
+func (stub *accountDaoStub) UnscopedListMostActive(ctx context.Context, since time.Time, limit int64) ([]*models.Account, error) {
+	if err := injectFault(ctx, "AccountDao.UnscopedListMostActive"); err != nil {
+		return nil, err
+	}
+	if int64(len(stub.store)) > limit {
+		return stub.store[:limit], nil
+	}
 	return stub.store, nil
 }
+
+func (stub *accountDaoStub) NextNameSequence(ctx context.Context) (int64, error) {
+	if err := injectFault(ctx, "AccountDao.NextNameSequence"); err != nil {
+		return 0, err
+	}
+	if stub.sequences == nil {
+		stub.sequences = make(map[int64]int64)
+	}
+	stub.sequences[ctxAccountId(ctx)]++
+	return stub.sequences[ctxAccountId(ctx)], nil
+}
+
+func (stub *accountDaoStub) GetSettings(ctx context.Context) (*models.AccountSettings, error) {
+	if err := injectFault(ctx, "AccountDao.GetSettings"); err != nil {
+		return nil, err
+	}
+	if settings, ok := stub.settings[ctxAccountId(ctx)]; ok {
+		return settings, nil
+	}
+	return &models.AccountSettings{AccountID: ctxAccountId(ctx)}, nil
+}
+
+func (stub *accountDaoStub) UpdateSettings(ctx context.Context, settings *models.AccountSettings) error {
+	if err := injectFault(ctx, "AccountDao.UpdateSettings"); err != nil {
+		return err
+	}
+	if stub.settings == nil {
+		stub.settings = make(map[int64]*models.AccountSettings)
+	}
+	settings.AccountID = ctxAccountId(ctx)
+	settings.UpdatedAt = time.Now()
+	stub.settings[settings.AccountID] = settings
+	return nil
+}
+
+func (stub *accountDaoStub) GetSourceSettings(ctx context.Context, sourceId string) (*models.SourceSettings, error) {
+	if err := injectFault(ctx, "AccountDao.GetSourceSettings"); err != nil {
+		return nil, err
+	}
+	for _, settings := range stub.sources {
+		if settings.AccountID == ctxAccountId(ctx) && settings.SourceID == sourceId {
+			return settings, nil
+		}
+	}
+	return &models.SourceSettings{AccountID: ctxAccountId(ctx), SourceID: sourceId}, nil
+}
+
+func (stub *accountDaoStub) UpdateSourceSettings(ctx context.Context, settings *models.SourceSettings) error {
+	if err := injectFault(ctx, "AccountDao.UpdateSourceSettings"); err != nil {
+		return err
+	}
+	settings.AccountID = ctxAccountId(ctx)
+	settings.UpdatedAt = time.Now()
+	for i, s := range stub.sources {
+		if s.AccountID == settings.AccountID && s.SourceID == settings.SourceID {
+			stub.sources[i] = settings
+			return nil
+		}
+	}
+	stub.sources = append(stub.sources, settings)
+	return nil
+}
+
+func (stub *accountDaoStub) UnscopedAddUsage(ctx context.Context, usage []*models.AccountUsage) error {
+	if err := injectFault(ctx, "AccountDao.UnscopedAddUsage"); err != nil {
+		return err
+	}
+	for _, u := range usage {
+		found := false
+		for _, s := range stub.usage {
+			if s.AccountID == u.AccountID && s.Day.Equal(u.Day) {
+				s.APICalls += u.APICalls
+				s.Launches += u.Launches
+				s.Instances += u.Instances
+				found = true
+			}
+		}
+		if !found {
+			copied := *u
+			stub.usage = append(stub.usage, &copied)
+		}
+	}
+	return nil
+}
+
+func (stub *accountDaoStub) ListUsage(ctx context.Context, since time.Time) ([]*models.AccountUsage, error) {
+	if err := injectFault(ctx, "AccountDao.ListUsage"); err != nil {
+		return nil, err
+	}
+	var result []*models.AccountUsage
+	for _, u := range stub.usage {
+		if u.AccountID == ctxAccountId(ctx) && !u.Day.Before(since) {
+			result = append(result, u)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Day.After(result[j].Day) })
+	return result, nil
+}
+
+func (stub *accountDaoStub) UnscopedListTopUsage(ctx context.Context, since time.Time, limit int64) ([]*models.AccountUsageTotal, error) {
+	if err := injectFault(ctx, "AccountDao.UnscopedListTopUsage"); err != nil {
+		return nil, err
+	}
+	totals := make(map[int64]*models.AccountUsageTotal)
+	var result []*models.AccountUsageTotal
+	for _, u := range stub.usage {
+		if u.Day.Before(since) {
+			continue
+		}
+		total, ok := totals[u.AccountID]
+		if !ok {
+			total = &models.AccountUsageTotal{AccountID: u.AccountID}
+			if account, err := stub.GetById(ctx, u.AccountID); err == nil {
+				total.OrgID = account.OrgID
+			}
+			totals[u.AccountID] = total
+			result = append(result, total)
+		}
+		total.APICalls += u.APICalls
+		total.Launches += u.Launches
+		total.Instances += u.Instances
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].APICalls > result[j].APICalls })
+	if int64(len(result)) > limit {
+		return result[:limit], nil
+	}
+	return result, nil
+}
+
+// UnscopedPurge purges data of stubs present in the context.
+func (stub *accountDaoStub) UnscopedPurge(ctx context.Context, id int64) (*models.AccountPurge, error) {
+	if err := injectFault(ctx, "AccountDao.UnscopedPurge"); err != nil {
+		return nil, err
+	}
+	result := &models.AccountPurge{}
+	delete(stub.settings, id)
+	stub.sources = slices.DeleteFunc(stub.sources, func(s *models.SourceSettings) bool { return s.AccountID == id })
+	stub.usage = slices.DeleteFunc(stub.usage, func(u *models.AccountUsage) bool { return u.AccountID == id })
+
+	if templates, ok := ctx.Value(templateCtxKey).(*reservationTemplateDaoStub); ok {
+		kept := templates.store[:0]
+		for _, t := range templates.store {
+			if t.AccountID == id {
+				result.ReservationTemplates++
+				continue
+			}
+			kept = append(kept, t)
+		}
+		templates.store = kept
+	}
+
+	if reservations, ok := ctx.Value(reservationCtxKey).(*reservationDaoStub); ok {
+		kept := reservations.storeAWS[:0]
+		for _, r := range reservations.storeAWS {
+			if r.AccountID == id {
+				result.Reservations++
+				if !slices.Contains(result.SourceIDs, r.SourceID) {
+					result.SourceIDs = append(result.SourceIDs, r.SourceID)
+				}
+				delete(reservations.instances, r.ID)
+				delete(reservations.jobs, r.ID)
+				continue
+			}
+			kept = append(kept, r)
+		}
+		reservations.storeAWS = kept
+	}
+
+	if pubkeys, ok := ctx.Value(pubkeyCtxKey).(*pubkeyDaoStub); ok {
+		kept := pubkeys.store[:0]
+		for _, pk := range pubkeys.store {
+			if pk.AccountID == id {
+				result.Pubkeys++
+				continue
+			}
+			kept = append(kept, pk)
+		}
+		pubkeys.store = kept
+	}
+
+	if orphans, ok := ctx.Value(orphanCtxKey).(*orphanDaoStub); ok {
+		kept := orphans.store[:0]
+		for _, o := range orphans.store {
+			if o.AccountID == id {
+				result.OrphanedInstances++
+				continue
+			}
+			kept = append(kept, o)
+		}
+		orphans.store = kept
+	}
+
+	if audit, ok := ctx.Value(auditCtxKey).(*auditDaoStub); ok {
+		for _, record := range audit.store {
+			if record.AccountID == id && (record.Actor != "" || len(record.Details) > 0) {
+				record.Actor = ""
+				record.Details = map[string]string{}
+				result.AuditRecords++
+			}
+		}
+	}
+
+	return result, nil
+}
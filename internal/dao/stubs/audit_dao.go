@@ -0,0 +1,53 @@
+package stubs
+
+import (
+	"context"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+)
+
+type auditDaoStub struct {
+	lastId int64
+	store  []*models.AuditRecord
+}
+
+func init() {
+	dao.GetAuditDao = getAuditDao
+}
+
+func getAuditDao(ctx context.Context) dao.AuditDao {
+	return getAuditDaoStub(ctx)
+}
+
+func (stub *auditDaoStub) Create(ctx context.Context, record *models.AuditRecord) error {
+	if err := injectFault(ctx, "AuditDao.Create"); err != nil {
+		return err
+	}
+	record.AccountID = ctxAccountId(ctx)
+	record.Actor = identity.Identity(ctx).Identity.User.Username
+	if err := models.Validate(ctx, record); err != nil {
+		return dao.ErrValidation
+	}
+
+	record.ID = stub.lastId + 1
+	record.CreatedAt = time.Now()
+	stub.store = append(stub.store, record)
+	stub.lastId++
+	return nil
+}
+
+func (stub *auditDaoStub) ListByReservation(ctx context.Context, reservationId int64, limit, offset int64) ([]*models.AuditRecord, error) {
+	if err := injectFault(ctx, "AuditDao.ListByReservation"); err != nil {
+		return nil, err
+	}
+	var filtered []*models.AuditRecord
+	for _, r := range stub.store {
+		if r.AccountID == ctxAccountId(ctx) && r.ReservationID == reservationId {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered, nil
+}
@@ -14,6 +14,10 @@ const (
 	accountCtxKey     daoStubCtxKeyType = iota
 	pubkeyCtxKey      daoStubCtxKeyType = iota
 	reservationCtxKey daoStubCtxKeyType = iota
+	faultsCtxKey      daoStubCtxKeyType = iota
+	templateCtxKey    daoStubCtxKeyType = iota
+	auditCtxKey       daoStubCtxKeyType = iota
+	orphanCtxKey      daoStubCtxKeyType = iota
 )
 
 func ctxAccountId(ctx context.Context) int64 {
@@ -45,6 +49,8 @@ func WithReservationDao(parent context.Context) context.Context {
 
 	ctx := context.WithValue(parent, reservationCtxKey, &reservationDaoStub{
 		instances: make(map[int64][]*models.ReservationInstance),
+		jobs:      make(map[int64]*models.ReservationJob),
+		usage:     make(map[instanceKey]usageSent),
 	})
 	return ctx
 }
@@ -58,6 +64,42 @@ func getReservationDaoStub(ctx context.Context) *reservationDaoStub {
 	return resDao
 }
 
+func WithReservationTemplateDao(parent context.Context) context.Context {
+	if parent.Value(templateCtxKey) != nil {
+		panic(dao.ErrStubContextAlreadySet)
+	}
+
+	ctx := context.WithValue(parent, templateCtxKey, &reservationTemplateDaoStub{lastId: 0, store: []*models.ReservationTemplate{}})
+	return ctx
+}
+
+func getReservationTemplateDaoStub(ctx context.Context) *reservationTemplateDaoStub {
+	var ok bool
+	var templateDao *reservationTemplateDaoStub
+	if templateDao, ok = ctx.Value(templateCtxKey).(*reservationTemplateDaoStub); !ok {
+		panic(dao.ErrStubMissingContext)
+	}
+	return templateDao
+}
+
+func WithAuditDao(parent context.Context) context.Context {
+	if parent.Value(auditCtxKey) != nil {
+		panic(dao.ErrStubContextAlreadySet)
+	}
+
+	ctx := context.WithValue(parent, auditCtxKey, &auditDaoStub{lastId: 0, store: []*models.AuditRecord{}})
+	return ctx
+}
+
+func getAuditDaoStub(ctx context.Context) *auditDaoStub {
+	var ok bool
+	var auditDao *auditDaoStub
+	if auditDao, ok = ctx.Value(auditCtxKey).(*auditDaoStub); !ok {
+		panic(dao.ErrStubMissingContext)
+	}
+	return auditDao
+}
+
 func WithAccountDaoOne(parent context.Context) context.Context {
 	if parent.Value(accountCtxKey) != nil {
 		panic(dao.ErrStubContextAlreadySet)
@@ -84,3 +126,21 @@ func getAccountDaoStub(ctx context.Context) *accountDaoStub {
 	}
 	return accdao
 }
+
+func WithOrphanDao(parent context.Context) context.Context {
+	if parent.Value(orphanCtxKey) != nil {
+		panic(dao.ErrStubContextAlreadySet)
+	}
+
+	ctx := context.WithValue(parent, orphanCtxKey, &orphanDaoStub{lastId: 0, store: []*models.OrphanedInstance{}})
+	return ctx
+}
+
+func getOrphanDaoStub(ctx context.Context) *orphanDaoStub {
+	var ok bool
+	var orphanDao *orphanDaoStub
+	if orphanDao, ok = ctx.Value(orphanCtxKey).(*orphanDaoStub); !ok {
+		panic(dao.ErrStubMissingContext)
+	}
+	return orphanDao
+}
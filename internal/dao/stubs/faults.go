@@ -0,0 +1,128 @@
+package stubs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/db"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Fault is an error or a delay injected into a stubbed DAO method. Methods are identified by
+// interface and method name, e.g. "PubkeyDao.Create" or "ReservationDao.GetById".
+type Fault struct {
+	// OnCall is the number of the call (starting from 1) the fault is injected to. Zero means
+	// the fault is injected to every call.
+	OnCall int
+
+	// Latency is applied before the call, it is interrupted when the context is done.
+	Latency time.Duration
+
+	// Err is returned instead of the result, no data is changed in the stub.
+	Err error
+}
+
+type faultInjector struct {
+	mu     sync.Mutex
+	calls  map[string]int
+	faults map[string][]Fault
+}
+
+// WithFaults enables fault injection for all DAO stubs in the context.
+func WithFaults(parent context.Context) context.Context {
+	if parent.Value(faultsCtxKey) != nil {
+		panic(dao.ErrStubContextAlreadySet)
+	}
+
+	ctx := context.WithValue(parent, faultsCtxKey, &faultInjector{
+		calls:  make(map[string]int),
+		faults: make(map[string][]Fault),
+	})
+	return ctx
+}
+
+func getFaultInjector(ctx context.Context) *faultInjector {
+	var ok bool
+	var injector *faultInjector
+	if injector, ok = ctx.Value(faultsCtxKey).(*faultInjector); !ok {
+		panic(dao.ErrStubMissingContext)
+	}
+	return injector
+}
+
+// InjectFault registers a fault for the method, multiple faults for one method are applied in
+// the order they were registered.
+func InjectFault(ctx context.Context, method string, fault Fault) {
+	injector := getFaultInjector(ctx)
+	injector.mu.Lock()
+	defer injector.mu.Unlock()
+
+	injector.faults[method] = append(injector.faults[method], fault)
+}
+
+// InjectError makes the Nth call of the method return the error, zero means every call.
+func InjectError(ctx context.Context, method string, onCall int, err error) {
+	InjectFault(ctx, method, Fault{OnCall: onCall, Err: err})
+}
+
+// InjectLatency delays every call of the method.
+func InjectLatency(ctx context.Context, method string, latency time.Duration) {
+	InjectFault(ctx, method, Fault{Latency: latency})
+}
+
+// InjectConstraintViolation makes the Nth call of the method fail with unique constraint violation
+// of the given constraint, the same error Postgres returns.
+func InjectConstraintViolation(ctx context.Context, method string, onCall int, constraint string) {
+	InjectError(ctx, method, onCall, &pgconn.PgError{
+		Severity:       "ERROR",
+		Code:           string(db.UniqueConstraintErrorCode),
+		Message:        fmt.Sprintf("duplicate key value violates unique constraint \"%s\"", constraint),
+		ConstraintName: constraint,
+	})
+}
+
+// FaultCallCount returns how many times the method was called since the faults were enabled.
+func FaultCallCount(ctx context.Context, method string) int {
+	injector := getFaultInjector(ctx)
+	injector.mu.Lock()
+	defer injector.mu.Unlock()
+
+	return injector.calls[method]
+}
+
+// injectFault is called at the beginning of every stubbed method. It does nothing unless faults
+// were enabled in the context.
+func injectFault(ctx context.Context, method string) error {
+	injector, ok := ctx.Value(faultsCtxKey).(*faultInjector)
+	if !ok {
+		return nil
+	}
+
+	injector.mu.Lock()
+	injector.calls[method]++
+	call := injector.calls[method]
+	var latency time.Duration
+	var err error
+	for _, fault := range injector.faults[method] {
+		if fault.OnCall != 0 && fault.OnCall != call {
+			continue
+		}
+		latency += fault.Latency
+		if err == nil {
+			err = fault.Err
+		}
+	}
+	injector.mu.Unlock()
+
+	if latency > 0 {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("injected latency in %s: %w", method, ctx.Err())
+		case <-time.After(latency):
+		}
+	}
+	return err
+}
@@ -15,3 +15,8 @@ func AddAWSReservation(ctx context.Context, reservation *models.AWSReservation)
 	reservationDao := getReservationDaoStub(ctx)
 	return reservationDao.CreateAWS(ctx, reservation)
 }
+
+func AddReservationTemplate(ctx context.Context, template *models.ReservationTemplate) error {
+	templateDao := getReservationTemplateDaoStub(ctx)
+	return templateDao.Create(ctx, template)
+}
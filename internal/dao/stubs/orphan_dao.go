@@ -0,0 +1,92 @@
+package stubs
+
+import (
+	"context"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+)
+
+type orphanDaoStub struct {
+	lastId int64
+	store  []*models.OrphanedInstance
+}
+
+func init() {
+	dao.GetOrphanDao = getOrphanDao
+}
+
+func getOrphanDao(ctx context.Context) dao.OrphanDao {
+	return getOrphanDaoStub(ctx)
+}
+
+func (stub *orphanDaoStub) List(ctx context.Context, limit, offset int64) ([]*models.OrphanedInstance, error) {
+	if err := injectFault(ctx, "OrphanDao.List"); err != nil {
+		return nil, err
+	}
+	var filtered []*models.OrphanedInstance
+	for _, o := range stub.store {
+		if o.AccountID == ctxAccountId(ctx) {
+			filtered = append(filtered, o)
+		}
+	}
+	if offset >= int64(len(filtered)) {
+		return nil, nil
+	}
+	filtered = filtered[offset:]
+	if limit < int64(len(filtered)) {
+		filtered = filtered[:limit]
+	}
+	return filtered, nil
+}
+
+func (stub *orphanDaoStub) Count(ctx context.Context) (int64, error) {
+	if err := injectFault(ctx, "OrphanDao.Count"); err != nil {
+		return 0, err
+	}
+	var count int64
+	for _, o := range stub.store {
+		if o.AccountID == ctxAccountId(ctx) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (stub *orphanDaoStub) ReplaceBySource(ctx context.Context, provider models.ProviderType, sourceId, region string, orphans []*models.OrphanedInstance) ([]*models.OrphanedInstance, error) {
+	if err := injectFault(ctx, "OrphanDao.ReplaceBySource"); err != nil {
+		return nil, err
+	}
+	known := make(map[string]*models.OrphanedInstance)
+	kept := make([]*models.OrphanedInstance, 0, len(stub.store))
+	for _, o := range stub.store {
+		if o.AccountID == ctxAccountId(ctx) && o.Provider == provider && o.SourceID == sourceId && (region == "" || o.Location == region) {
+			known[o.InstanceID] = o
+			continue
+		}
+		kept = append(kept, o)
+	}
+
+	var detected []*models.OrphanedInstance
+	for _, orphan := range orphans {
+		orphan.AccountID = ctxAccountId(ctx)
+		orphan.Provider = provider
+		orphan.SourceID = sourceId
+		if err := models.Validate(ctx, orphan); err != nil {
+			return nil, dao.ErrValidation
+		}
+		if previous, ok := known[orphan.InstanceID]; ok {
+			orphan.ID = previous.ID
+			orphan.DetectedAt = previous.DetectedAt
+		} else {
+			stub.lastId++
+			orphan.ID = stub.lastId
+			orphan.DetectedAt = time.Now()
+			detected = append(detected, orphan)
+		}
+		kept = append(kept, orphan)
+	}
+	stub.store = kept
+	return detected, nil
+}
@@ -27,6 +27,9 @@ func getPubkeyDao(ctx context.Context) dao.PubkeyDao {
 }
 
 func (stub *pubkeyDaoStub) Create(ctx context.Context, pubkey *models.Pubkey) error {
+	if err := injectFault(ctx, "PubkeyDao.Create"); err != nil {
+		return err
+	}
 	if pubkey.AccountID == 0 {
 		pubkey.AccountID = ctxAccountId(ctx)
 	}
@@ -47,6 +50,9 @@ func (stub *pubkeyDaoStub) Create(ctx context.Context, pubkey *models.Pubkey) er
 }
 
 func (stub *pubkeyDaoStub) Update(ctx context.Context, pubkey *models.Pubkey) error {
+	if err := injectFault(ctx, "PubkeyDao.Update"); err != nil {
+		return err
+	}
 	if pubkey.AccountID == 0 {
 		pubkey.AccountID = ctxAccountId(ctx)
 	}
@@ -64,6 +70,9 @@ func (stub *pubkeyDaoStub) Update(ctx context.Context, pubkey *models.Pubkey) er
 }
 
 func (stub *pubkeyDaoStub) GetById(ctx context.Context, id int64) (*models.Pubkey, error) {
+	if err := injectFault(ctx, "PubkeyDao.GetById"); err != nil {
+		return nil, err
+	}
 	for _, pk := range stub.store {
 		if pk.AccountID == ctxAccountId(ctx) && pk.ID == id {
 			return pk, nil
@@ -73,6 +82,9 @@ func (stub *pubkeyDaoStub) GetById(ctx context.Context, id int64) (*models.Pubke
 }
 
 func (stub *pubkeyDaoStub) List(ctx context.Context, limit, offset int64) ([]*models.Pubkey, error) {
+	if err := injectFault(ctx, "PubkeyDao.List"); err != nil {
+		return nil, err
+	}
 	var filtered []*models.Pubkey
 	for _, pk := range stub.store {
 		if pk.AccountID == ctxAccountId(ctx) {
@@ -82,7 +94,23 @@ func (stub *pubkeyDaoStub) List(ctx context.Context, limit, offset int64) ([]*mo
 	return filtered, nil
 }
 
+func (stub *pubkeyDaoStub) Count(ctx context.Context) (int64, error) {
+	if err := injectFault(ctx, "PubkeyDao.Count"); err != nil {
+		return 0, err
+	}
+	var count int64
+	for _, pk := range stub.store {
+		if pk.AccountID == ctxAccountId(ctx) {
+			count++
+		}
+	}
+	return count, nil
+}
+
 func (stub *pubkeyDaoStub) Delete(ctx context.Context, id int64) error {
+	if err := injectFault(ctx, "PubkeyDao.Delete"); err != nil {
+		return err
+	}
 	for idx, p := range stub.store {
 		if p.AccountID == ctxAccountId(ctx) && p.ID == id {
 			stub.store = append(stub.store[:idx], stub.store[idx+1:]...)
@@ -93,6 +121,9 @@ func (stub *pubkeyDaoStub) Delete(ctx context.Context, id int64) error {
 }
 
 func (stub *pubkeyDaoStub) UnscopedGetResourceBySourceAndRegion(ctx context.Context, pubkeyId int64, sourceId string, region string) (*models.PubkeyResource, error) {
+	if err := injectFault(ctx, "PubkeyDao.UnscopedGetResourceBySourceAndRegion"); err != nil {
+		return nil, err
+	}
 	for _, pkr := range stub.resourceStore {
 		if pkr.PubkeyID == pubkeyId && pkr.SourceID == sourceId && pkr.Region == region {
 			return pkr, nil
@@ -102,16 +133,25 @@ func (stub *pubkeyDaoStub) UnscopedGetResourceBySourceAndRegion(ctx context.Cont
 }
 
 func (stub *pubkeyDaoStub) UnscopedCreateResource(ctx context.Context, pkr *models.PubkeyResource) error {
+	if err := injectFault(ctx, "PubkeyDao.UnscopedCreateResource"); err != nil {
+		return err
+	}
 	pkr.ID = int64(len(stub.resourceStore)) + 1
 	stub.resourceStore = append(stub.resourceStore, pkr)
 	return nil
 }
 
 func (stub *pubkeyDaoStub) UnscopedDeleteResource(ctx context.Context, id int64) error {
+	if err := injectFault(ctx, "PubkeyDao.UnscopedDeleteResource"); err != nil {
+		return err
+	}
 	return nil
 }
 
 func (stub *pubkeyDaoStub) UnscopedListResourcesByPubkeyId(ctx context.Context, pkId int64) ([]*models.PubkeyResource, error) {
+	if err := injectFault(ctx, "PubkeyDao.UnscopedListResourcesByPubkeyId"); err != nil {
+		return nil, err
+	}
 	var result []*models.PubkeyResource
 	for _, pkr := range stub.resourceStore {
 		if pkr.PubkeyID == pkId {
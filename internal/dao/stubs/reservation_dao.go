@@ -2,11 +2,16 @@ package stubs
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
 	"github.com/RHEnVision/provisioning-backend/internal/dao"
 	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"golang.org/x/exp/slices"
 )
 
 type reservationDaoStub struct {
@@ -14,6 +19,18 @@ type reservationDaoStub struct {
 	storeAzure []*models.AzureReservation
 	storeGCP   []*models.GCPReservation
 	instances  map[int64][]*models.ReservationInstance
+	jobs       map[int64]*models.ReservationJob
+	events     []*models.ReservationEvent
+	usage      map[instanceKey]usageSent
+}
+
+type instanceKey struct {
+	reservationID int64
+	instanceID    string
+}
+
+type usageSent struct {
+	started, terminated bool
 }
 
 func init() {
@@ -40,34 +57,55 @@ func getReservationDao(ctx context.Context) dao.ReservationDao {
 }
 
 func (stub *reservationDaoStub) CreateAWS(ctx context.Context, reservation *models.AWSReservation) error {
+	if err := injectFault(ctx, "ReservationDao.CreateAWS"); err != nil {
+		return err
+	}
 	reservation.ID = int64(len(stub.storeAWS)) + 1
 	stub.storeAWS = append(stub.storeAWS, reservation)
 	return nil
 }
 
 func (stub *reservationDaoStub) CreateAzure(ctx context.Context, reservation *models.AzureReservation) error {
+	if err := injectFault(ctx, "ReservationDao.CreateAzure"); err != nil {
+		return err
+	}
 	reservation.ID = int64(len(stub.storeAzure)) + 1
 	stub.storeAzure = append(stub.storeAzure, reservation)
 	return nil
 }
 
 func (stub *reservationDaoStub) CreateGCP(ctx context.Context, reservation *models.GCPReservation) error {
+	if err := injectFault(ctx, "ReservationDao.CreateGCP"); err != nil {
+		return err
+	}
 	reservation.ID = int64(len(stub.storeGCP)) + 1
 	stub.storeGCP = append(stub.storeGCP, reservation)
 	return nil
 }
 
 func (stub *reservationDaoStub) CreateNoop(ctx context.Context, reservation *models.NoopReservation) error {
+	if err := injectFault(ctx, "ReservationDao.CreateNoop"); err != nil {
+		return err
+	}
 	return nil
 }
 
 func (stub *reservationDaoStub) CreateInstance(ctx context.Context, resInstance *models.ReservationInstance) error {
+	if err := injectFault(ctx, "ReservationDao.CreateInstance"); err != nil {
+		return err
+	}
 	resId := resInstance.ReservationID
+	if resInstance.PowerState == "" {
+		resInstance.PowerState = models.PowerStateRunning
+	}
 	stub.instances[resId] = append(stub.instances[resId], resInstance)
 	return nil
 }
 
 func (stub *reservationDaoStub) GetById(ctx context.Context, id int64) (*models.Reservation, error) {
+	if err := injectFault(ctx, "ReservationDao.GetById"); err != nil {
+		return nil, err
+	}
 	for _, awsReservation := range stub.storeAWS {
 		if awsReservation.AccountID == ctxAccountId(ctx) && awsReservation.ID == id {
 			return &awsReservation.Reservation, nil
@@ -77,6 +115,9 @@ func (stub *reservationDaoStub) GetById(ctx context.Context, id int64) (*models.
 }
 
 func (stub *reservationDaoStub) GetAWSById(ctx context.Context, id int64) (*models.AWSReservation, error) {
+	if err := injectFault(ctx, "ReservationDao.GetAWSById"); err != nil {
+		return nil, err
+	}
 	for _, awsReservation := range stub.storeAWS {
 		if awsReservation.AccountID == ctxAccountId(ctx) && awsReservation.ID == id {
 			return awsReservation, nil
@@ -86,6 +127,9 @@ func (stub *reservationDaoStub) GetAWSById(ctx context.Context, id int64) (*mode
 }
 
 func (stub *reservationDaoStub) GetAzureById(ctx context.Context, id int64) (*models.AzureReservation, error) {
+	if err := injectFault(ctx, "ReservationDao.GetAzureById"); err != nil {
+		return nil, err
+	}
 	for _, azureReservation := range stub.storeAzure {
 		if azureReservation.AccountID == ctxAccountId(ctx) && azureReservation.ID == id {
 			return azureReservation, nil
@@ -95,6 +139,9 @@ func (stub *reservationDaoStub) GetAzureById(ctx context.Context, id int64) (*mo
 }
 
 func (stub *reservationDaoStub) GetGCPById(ctx context.Context, id int64) (*models.GCPReservation, error) {
+	if err := injectFault(ctx, "ReservationDao.GetGCPById"); err != nil {
+		return nil, err
+	}
 	for _, gcpReservation := range stub.storeGCP {
 		if gcpReservation.AccountID == ctxAccountId(ctx) && gcpReservation.ID == id {
 			return gcpReservation, nil
@@ -104,55 +151,616 @@ func (stub *reservationDaoStub) GetGCPById(ctx context.Context, id int64) (*mode
 }
 
 func (stub *reservationDaoStub) List(ctx context.Context, limit, offset int64) ([]*models.Reservation, error) {
+	if err := injectFault(ctx, "ReservationDao.List"); err != nil {
+		return nil, err
+	}
 	return nil, nil
 }
 
+func (stub *reservationDaoStub) ListByCreator(ctx context.Context, userId string, limit, offset int64) ([]*models.Reservation, error) {
+	if err := injectFault(ctx, "ReservationDao.ListByCreator"); err != nil {
+		return nil, err
+	}
+	var result []*models.Reservation
+	for _, awsReservation := range stub.storeAWS {
+		if awsReservation.AccountID == ctxAccountId(ctx) && awsReservation.CreatedByUserID == userId {
+			result = append(result, &awsReservation.Reservation)
+		}
+	}
+	return result, nil
+}
+
+func (stub *reservationDaoStub) Count(ctx context.Context) (int64, error) {
+	if err := injectFault(ctx, "ReservationDao.Count"); err != nil {
+		return 0, err
+	}
+	return 0, nil
+}
+
+func (stub *reservationDaoStub) CountByCreator(ctx context.Context, userId string) (int64, error) {
+	if err := injectFault(ctx, "ReservationDao.CountByCreator"); err != nil {
+		return 0, err
+	}
+	var count int64
+	for _, awsReservation := range stub.storeAWS {
+		if awsReservation.AccountID == ctxAccountId(ctx) && awsReservation.CreatedByUserID == userId {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func matchesReservationFilter(reservation *models.Reservation, filter *dao.ReservationFilter) bool {
+	return (filter.Label == "" || slices.Contains(reservation.Labels, filter.Label)) &&
+		(!filter.ByCreator || reservation.CreatedByUserID == filter.CreatedByUserID)
+}
+
+func (stub *reservationDaoStub) ListFiltered(ctx context.Context, filter *dao.ReservationFilter, limit, offset int64) ([]*models.Reservation, error) {
+	if err := injectFault(ctx, "ReservationDao.ListFiltered"); err != nil {
+		return nil, err
+	}
+	var result []*models.Reservation
+	for _, awsReservation := range stub.storeAWS {
+		if awsReservation.AccountID == ctxAccountId(ctx) && matchesReservationFilter(&awsReservation.Reservation, filter) {
+			result = append(result, &awsReservation.Reservation)
+		}
+	}
+	return result, nil
+}
+
+func (stub *reservationDaoStub) CountFiltered(ctx context.Context, filter *dao.ReservationFilter) (int64, error) {
+	if err := injectFault(ctx, "ReservationDao.CountFiltered"); err != nil {
+		return 0, err
+	}
+	result, err := stub.ListFiltered(ctx, filter, 0, 0)
+	return int64(len(result)), err
+}
+
+func (stub *reservationDaoStub) ListLabelStats(ctx context.Context, filter *dao.ReservationFilter) ([]*models.LabelStats, error) {
+	if err := injectFault(ctx, "ReservationDao.ListLabelStats"); err != nil {
+		return nil, err
+	}
+	stats := make(map[string]*models.LabelStats)
+	for _, awsReservation := range stub.storeAWS {
+		if awsReservation.AccountID != ctxAccountId(ctx) || !matchesReservationFilter(&awsReservation.Reservation, filter) {
+			continue
+		}
+		for _, label := range awsReservation.Labels {
+			s, ok := stats[label]
+			if !ok {
+				s = &models.LabelStats{Label: label}
+				stats[label] = s
+			}
+			s.Reservations++
+			if awsReservation.Success.Valid && awsReservation.Success.Bool {
+				s.Succeeded++
+			} else if awsReservation.Success.Valid {
+				s.Failed++
+			}
+		}
+	}
+	result := make([]*models.LabelStats, 0, len(stats))
+	for _, s := range stats {
+		result = append(result, s)
+	}
+	slices.SortFunc(result, func(a, b *models.LabelStats) int { return strings.Compare(a.Label, b.Label) })
+	return result, nil
+}
+
+func (stub *reservationDaoStub) ListByIDs(ctx context.Context, ids []int64, filter *dao.ReservationFilter) ([]*models.Reservation, error) {
+	if err := injectFault(ctx, "ReservationDao.ListByIDs"); err != nil {
+		return nil, err
+	}
+	var result []*models.Reservation
+	for _, id := range ids {
+		for _, awsReservation := range stub.storeAWS {
+			if awsReservation.AccountID == ctxAccountId(ctx) && awsReservation.ID == id &&
+				matchesReservationFilter(&awsReservation.Reservation, filter) {
+				result = append(result, &awsReservation.Reservation)
+			}
+		}
+	}
+	return result, nil
+}
+
+func (stub *reservationDaoStub) Export(ctx context.Context, filter *dao.ReservationFilter, fn func(*models.ReservationExport) error) error {
+	if err := injectFault(ctx, "ReservationDao.Export"); err != nil {
+		return err
+	}
+	var result []*models.ReservationExport
+	for _, r := range stub.storeAWS {
+		result = append(result, &models.ReservationExport{
+			Reservation:  r.Reservation,
+			PubkeyID:     r.PubkeyID,
+			SourceID:     r.SourceID,
+			ImageID:      r.ImageID,
+			Location:     r.Detail.Region,
+			InstanceType: r.Detail.InstanceType,
+			Amount:       int64(r.Detail.Amount),
+		})
+	}
+	for _, r := range stub.storeAzure {
+		result = append(result, &models.ReservationExport{
+			Reservation:  r.Reservation,
+			PubkeyID:     r.PubkeyID,
+			SourceID:     r.SourceID,
+			ImageID:      r.ImageID,
+			Location:     r.Detail.Location,
+			InstanceType: r.Detail.InstanceSize,
+			Amount:       r.Detail.Amount,
+		})
+	}
+	for _, r := range stub.storeGCP {
+		result = append(result, &models.ReservationExport{
+			Reservation:  r.Reservation,
+			PubkeyID:     r.PubkeyID,
+			SourceID:     r.SourceID,
+			ImageID:      r.ImageID,
+			Location:     r.Detail.Zone,
+			InstanceType: r.Detail.MachineType,
+			Amount:       r.Detail.Amount,
+		})
+	}
+
+	for _, export := range result {
+		if export.AccountID != ctxAccountId(ctx) || !matchesReservationFilter(&export.Reservation, filter) {
+			continue
+		}
+		if err := fn(export); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (stub *reservationDaoStub) ListInstances(ctx context.Context, reservationId int64) ([]*models.ReservationInstance, error) {
+	if err := injectFault(ctx, "ReservationDao.ListInstances"); err != nil {
+		return nil, err
+	}
 	return stub.instances[reservationId], nil
 }
 
+// ListAllInstances returns instances of AWS reservations only, region prefix matching is not supported.
+func (stub *reservationDaoStub) ListAllInstances(ctx context.Context, filter *dao.InstanceFilter, limit, offset int64) ([]*models.Instance, error) {
+	if err := injectFault(ctx, "ReservationDao.ListAllInstances"); err != nil {
+		return nil, err
+	}
+	result := stub.filterInstances(ctx, filter)
+	if offset >= int64(len(result)) {
+		return nil, nil
+	}
+	result = result[offset:]
+	if limit < int64(len(result)) {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (stub *reservationDaoStub) CountAllInstances(ctx context.Context, filter *dao.InstanceFilter) (int64, error) {
+	if err := injectFault(ctx, "ReservationDao.CountAllInstances"); err != nil {
+		return 0, err
+	}
+	return int64(len(stub.filterInstances(ctx, filter))), nil
+}
+
+func (stub *reservationDaoStub) filterInstances(ctx context.Context, filter *dao.InstanceFilter) []*models.Instance {
+	var result []*models.Instance
+	for _, r := range stub.storeAWS {
+		if r.AccountID != ctxAccountId(ctx) {
+			continue
+		}
+		if (filter.Provider != models.ProviderTypeUnknown && filter.Provider != r.Provider) ||
+			(filter.Region != "" && filter.Region != r.Detail.Region) ||
+			(filter.ByCreator && filter.CreatedByUserID != r.CreatedByUserID) ||
+			(filter.SourceID != "" && filter.SourceID != r.SourceID) ||
+			(filter.ReservationID != 0 && filter.ReservationID != r.ID) {
+			continue
+		}
+		for _, instance := range stub.instances[r.ID] {
+			if filter.PowerState != "" && filter.PowerState != instance.PowerState {
+				continue
+			}
+			result = append(result, stub.instance(r, instance))
+		}
+	}
+	return result
+}
+
+// instance returns the instance of an AWS reservation with details of the reservation.
+func (stub *reservationDaoStub) instance(r *models.AWSReservation, instance *models.ReservationInstance) *models.Instance {
+	sent := stub.usage[instanceKey{r.ID, instance.InstanceID}]
+	return &models.Instance{
+		ReservationInstance: *instance,
+		Provider:            r.Provider,
+		SourceID:            r.SourceID,
+		Location:            r.Detail.Region,
+		InstanceType:        r.Detail.InstanceType,
+		CreatedAt:           r.CreatedAt,
+		CreatedByUserID:     r.CreatedByUserID,
+		UsageStarted:        sent.started,
+		UsageTerminated:     sent.terminated,
+	}
+}
+
+// WaitForUpdate blocks until the context is done because the stub never changes status on its own.
+func (stub *reservationDaoStub) WaitForUpdate(ctx context.Context, reservation *models.Reservation) error {
+	if err := injectFault(ctx, "ReservationDao.WaitForUpdate"); err != nil {
+		return err
+	}
+	<-ctx.Done()
+	return fmt.Errorf("wait for update: %w", ctx.Err())
+}
+
 func (stub *reservationDaoStub) UpdateStatus(ctx context.Context, id int64, status string, addSteps int32) error {
+	if err := injectFault(ctx, "ReservationDao.UpdateStatus"); err != nil {
+		return err
+	}
 	return nil
 }
 
 func (stub *reservationDaoStub) UnscopedUpdateAWSDetail(ctx context.Context, id int64, awsDetail *models.AWSDetail) error {
-	res, err := stub.GetAWSById(ctx, id)
-	if err != nil {
-		return fmt.Errorf("stubbed lookup of AWS reservation failed: %w", err)
+	if err := injectFault(ctx, "ReservationDao.UnscopedUpdateAWSDetail"); err != nil {
+		return err
 	}
-	res.Detail = awsDetail
-	return nil
+	for _, awsReservation := range stub.storeAWS {
+		if awsReservation.AccountID == ctxAccountId(ctx) && awsReservation.ID == id {
+			awsReservation.Detail = awsDetail
+			return nil
+		}
+	}
+	return fmt.Errorf("stubbed lookup of AWS reservation failed: %w", dao.ErrNoRows)
 }
 
 func (stub *reservationDaoStub) UpdateReservationIDForAWS(ctx context.Context, id int64, awsReservationId string) error {
+	if err := injectFault(ctx, "ReservationDao.UpdateReservationIDForAWS"); err != nil {
+		return err
+	}
 	return nil
 }
 
 func (stub *reservationDaoStub) UpdateOperationNameForGCP(ctx context.Context, id int64, gcpOperationName string) error {
+	if err := injectFault(ctx, "ReservationDao.UpdateOperationNameForGCP"); err != nil {
+		return err
+	}
 	return nil
 }
 
+func (stub *reservationDaoStub) UpdateReachable(ctx context.Context, id int64, reachable bool) error {
+	if err := injectFault(ctx, "ReservationDao.UpdateReachable"); err != nil {
+		return err
+	}
+	for _, reservation := range stub.storeAWS {
+		if reservation.ID == id {
+			reservation.Reachable = sql.NullBool{Bool: reachable, Valid: true}
+			return nil
+		}
+	}
+	for _, reservation := range stub.storeAzure {
+		if reservation.ID == id {
+			reservation.Reachable = sql.NullBool{Bool: reachable, Valid: true}
+			return nil
+		}
+	}
+	for _, reservation := range stub.storeGCP {
+		if reservation.ID == id {
+			reservation.Reachable = sql.NullBool{Bool: reachable, Valid: true}
+			return nil
+		}
+	}
+	return dao.ErrAffectedMismatch
+}
+
 func (stub *reservationDaoStub) FinishWithSuccess(ctx context.Context, id int64) error {
+	if err := injectFault(ctx, "ReservationDao.FinishWithSuccess"); err != nil {
+		return err
+	}
 	return nil
 }
 
 func (stub *reservationDaoStub) FinishWithError(ctx context.Context, id int64, errorString string) error {
+	if err := injectFault(ctx, "ReservationDao.FinishWithError"); err != nil {
+		return err
+	}
 	return nil
 }
 
+func (stub *reservationDaoStub) UpdateApproval(ctx context.Context, id int64, from, to models.ApprovalState, approvedBy string) error {
+	if err := injectFault(ctx, "ReservationDao.UpdateApproval"); err != nil {
+		return err
+	}
+	for _, awsReservation := range stub.storeAWS {
+		if awsReservation.AccountID == ctxAccountId(ctx) && awsReservation.ID == id {
+			if awsReservation.Approval != from {
+				return dao.ErrAffectedMismatch
+			}
+			awsReservation.Approval = to
+			awsReservation.ApprovedBy = approvedBy
+			return nil
+		}
+	}
+	return dao.ErrAffectedMismatch
+}
+
+func (stub *reservationDaoStub) UpdateLabels(ctx context.Context, id int64, labels []string) error {
+	if err := injectFault(ctx, "ReservationDao.UpdateLabels"); err != nil {
+		return err
+	}
+	for _, awsReservation := range stub.storeAWS {
+		if awsReservation.AccountID == ctxAccountId(ctx) && awsReservation.ID == id {
+			awsReservation.Labels = labels
+			return nil
+		}
+	}
+	return dao.ErrAffectedMismatch
+}
+
 func (stub *reservationDaoStub) Delete(ctx context.Context, id int64) error {
+	if err := injectFault(ctx, "ReservationDao.Delete"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (stub *reservationDaoStub) UnscopedListInstanceSources(ctx context.Context) ([]*models.InstanceSource, error) {
+	if err := injectFault(ctx, "ReservationDao.UnscopedListInstanceSources"); err != nil {
+		return nil, err
+	}
+	var result []*models.InstanceSource
+	for _, r := range stub.storeAWS {
+		if len(stub.instances[r.ID]) == 0 {
+			continue
+		}
+		source := &models.InstanceSource{AccountID: r.AccountID, Provider: r.Provider, SourceID: r.SourceID, Region: r.Detail.Region}
+		if !slices.ContainsFunc(result, func(s *models.InstanceSource) bool { return *s == *source }) {
+			result = append(result, source)
+		}
+	}
+	return result, nil
+}
+
+func (stub *reservationDaoStub) UnscopedListPendingUsage(ctx context.Context, limit int64) ([]*models.UsageInstance, error) {
+	if err := injectFault(ctx, "ReservationDao.UnscopedListPendingUsage"); err != nil {
+		return nil, err
+	}
+	var result []*models.UsageInstance
+	for _, r := range stub.storeAWS {
+		for _, ri := range stub.instances[r.ID] {
+			instance := stub.instance(r, ri)
+			if instance.UsageStarted && (instance.PowerState != models.PowerStateTerminated || instance.UsageTerminated) {
+				continue
+			}
+			if int64(len(result)) == limit {
+				return result, nil
+			}
+			result = append(result, &models.UsageInstance{Instance: *instance, AccountID: r.AccountID})
+		}
+	}
+	return result, nil
+}
+
+func (stub *reservationDaoStub) UnscopedUpdateInstanceUsage(ctx context.Context, reservationID int64, instanceID string, started, terminated bool) error {
+	if err := injectFault(ctx, "ReservationDao.UnscopedUpdateInstanceUsage"); err != nil {
+		return err
+	}
+	for _, instRes := range stub.instances[reservationID] {
+		if instRes.InstanceID == instanceID {
+			stub.usage[instanceKey{reservationID, instanceID}] = usageSent{started: started, terminated: terminated}
+			return nil
+		}
+	}
+	return dao.ErrAffectedMismatch
+}
+
+func (stub *reservationDaoStub) UnscopedGetById(ctx context.Context, id int64) (*models.Reservation, error) {
+	if err := injectFault(ctx, "ReservationDao.UnscopedGetById"); err != nil {
+		return nil, err
+	}
+	for _, awsReservation := range stub.storeAWS {
+		if awsReservation.ID == id {
+			return &awsReservation.Reservation, nil
+		}
+	}
+	return nil, dao.ErrNoRows
+}
+
+func (stub *reservationDaoStub) UnscopedList(ctx context.Context, pendingOnly bool, limit, offset int64) ([]*models.Reservation, error) {
+	if err := injectFault(ctx, "ReservationDao.UnscopedList"); err != nil {
+		return nil, err
+	}
+	var result []*models.Reservation
+	for _, awsReservation := range stub.storeAWS {
+		if pendingOnly && awsReservation.FinishedAt.Valid {
+			continue
+		}
+		result = append(result, &awsReservation.Reservation)
+	}
+	if offset >= int64(len(result)) {
+		return nil, nil
+	}
+	result = result[offset:]
+	if limit < int64(len(result)) {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (stub *reservationDaoStub) UnscopedRequeue(ctx context.Context, id int64) error {
+	if err := injectFault(ctx, "ReservationDao.UnscopedRequeue"); err != nil {
+		return err
+	}
+	for _, awsReservation := range stub.storeAWS {
+		if awsReservation.ID == id && awsReservation.FinishedAt.Valid {
+			awsReservation.Status = "Requeued"
+			awsReservation.Step = 0
+			awsReservation.Error = ""
+			awsReservation.Success = sql.NullBool{}
+			awsReservation.FinishedAt = sql.NullTime{}
+			return nil
+		}
+	}
+	return fmt.Errorf("expected 1 row: %w", dao.ErrAffectedMismatch)
+}
+
+func (stub *reservationDaoStub) CreateJob(ctx context.Context, job *models.ReservationJob) error {
+	if err := injectFault(ctx, "ReservationDao.CreateJob"); err != nil {
+		return err
+	}
+	stub.jobs[job.ReservationID] = job
+	return nil
+}
+
+func (stub *reservationDaoStub) UnscopedGetJob(ctx context.Context, reservationId int64) (*models.ReservationJob, error) {
+	if err := injectFault(ctx, "ReservationDao.UnscopedGetJob"); err != nil {
+		return nil, err
+	}
+	if job, ok := stub.jobs[reservationId]; ok {
+		return job, nil
+	}
+	return nil, dao.ErrNoRows
+}
+
+func (stub *reservationDaoStub) CreateEvent(ctx context.Context, event *models.ReservationEvent) error {
+	if err := injectFault(ctx, "ReservationDao.CreateEvent"); err != nil {
+		return err
+	}
+	if err := models.Validate(ctx, event); err != nil {
+		return dao.ErrValidation
+	}
+	event.ID = int64(len(stub.events) + 1)
+	event.CreatedAt = time.Now()
+	stub.events = append(stub.events, event)
 	return nil
 }
 
+func (stub *reservationDaoStub) ListEvents(ctx context.Context, reservationId int64, limit, offset int64) ([]*models.ReservationEvent, error) {
+	if err := injectFault(ctx, "ReservationDao.ListEvents"); err != nil {
+		return nil, err
+	}
+	var result []*models.ReservationEvent
+	for _, awsReservation := range stub.storeAWS {
+		if awsReservation.AccountID != ctxAccountId(ctx) || awsReservation.ID != reservationId {
+			continue
+		}
+		for _, event := range stub.events {
+			if event.ReservationID == reservationId {
+				result = append(result, event)
+			}
+		}
+	}
+	return result, nil
+}
+
+func (stub *reservationDaoStub) CountEvents(ctx context.Context, reservationId int64) (int64, error) {
+	if err := injectFault(ctx, "ReservationDao.CountEvents"); err != nil {
+		return 0, err
+	}
+	result, err := stub.ListEvents(ctx, reservationId, 0, 0)
+	return int64(len(result)), err
+}
+
+func (stub *reservationDaoStub) UnscopedListEvents(ctx context.Context, reservationId int64, limit int64) ([]*models.ReservationEvent, error) {
+	if err := injectFault(ctx, "ReservationDao.UnscopedListEvents"); err != nil {
+		return nil, err
+	}
+	var result []*models.ReservationEvent
+	for _, event := range stub.events {
+		if event.ReservationID == reservationId && int64(len(result)) < limit {
+			result = append(result, event)
+		}
+	}
+	return result, nil
+}
+
+func (stub *reservationDaoStub) UnscopedListExpired(ctx context.Context, limit int64) ([]*models.Reservation, error) {
+	if err := injectFault(ctx, "ReservationDao.UnscopedListExpired"); err != nil {
+		return nil, err
+	}
+	var result []*models.Reservation
+	expiration := time.Now().Add(-config.Reservation.Lifetime)
+	for _, awsReservation := range stub.storeAWS {
+		if awsReservation.CreatedAt.Before(expiration) && int64(len(result)) < limit {
+			result = append(result, &awsReservation.Reservation)
+		}
+	}
+	return result, nil
+}
+
 func (stub *reservationDaoStub) Cleanup(ctx context.Context) error {
+	if err := injectFault(ctx, "ReservationDao.Cleanup"); err != nil {
+		return err
+	}
 	return nil
 }
 
 func (stub *reservationDaoStub) UpdateReservationInstance(ctx context.Context, reservationID int64, instance *clients.InstanceDescription) error {
+	if err := injectFault(ctx, "ReservationDao.UpdateReservationInstance"); err != nil {
+		return err
+	}
 	for _, instRes := range stub.instances[reservationID] {
 		if instRes.InstanceID == instance.ID {
 			instRes.Detail.PublicIPv4 = instance.PublicIPv4
+			instRes.Detail.PublicDNS = instance.PublicDNS
+			instRes.Detail.PrivateIPv4 = instance.PrivateIPv4
+			instRes.Detail.PrivateDNS = instance.PrivateDNS
+			instRes.Detail.NetworkInterfaces = instance.NetworkInterfaces
 		}
 	}
 	return nil
 }
+
+func (stub *reservationDaoStub) UpdateInstanceDNSRecord(ctx context.Context, reservationID int64, instanceID string, record *models.DNSRecord) error {
+	if err := injectFault(ctx, "ReservationDao.UpdateInstanceDNSRecord"); err != nil {
+		return err
+	}
+	for _, instRes := range stub.instances[reservationID] {
+		if instRes.InstanceID == instanceID {
+			instRes.DNSRecord = *record
+			instRes.Detail.DNSName = record.Name
+			return nil
+		}
+	}
+	return dao.ErrAffectedMismatch
+}
+
+func (stub *reservationDaoStub) UpdateInstanceElasticIP(ctx context.Context, reservationID int64, instanceID string, eip *models.ElasticIP) error {
+	if err := injectFault(ctx, "ReservationDao.UpdateInstanceElasticIP"); err != nil {
+		return err
+	}
+	for _, instRes := range stub.instances[reservationID] {
+		if instRes.InstanceID == instanceID {
+			instRes.ElasticIP = *eip
+			return nil
+		}
+	}
+	return dao.ErrAffectedMismatch
+}
+
+func (stub *reservationDaoStub) UpdateInstancePasswordData(ctx context.Context, reservationID int64, instanceID string, passwordData string) error {
+	if err := injectFault(ctx, "ReservationDao.UpdateInstancePasswordData"); err != nil {
+		return err
+	}
+	for _, instRes := range stub.instances[reservationID] {
+		if instRes.InstanceID == instanceID {
+			instRes.Detail.PasswordData = passwordData
+			return nil
+		}
+	}
+	return dao.ErrAffectedMismatch
+}
+
+func (stub *reservationDaoStub) UpdateInstancePowerState(ctx context.Context, reservationID int64, instanceID string, state models.PowerState, from ...models.PowerState) error {
+	if err := injectFault(ctx, "ReservationDao.UpdateInstancePowerState"); err != nil {
+		return err
+	}
+	for _, instRes := range stub.instances[reservationID] {
+		if instRes.InstanceID != instanceID {
+			continue
+		}
+		if len(from) > 0 && !slices.Contains(from, instRes.PowerState) {
+			return dao.ErrAffectedMismatch
+		}
+		instRes.PowerState = state
+		return nil
+	}
+	return dao.ErrAffectedMismatch
+}
@@ -0,0 +1,169 @@
+package stubs
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+)
+
+type reservationTemplateDaoStub struct {
+	lastId int64
+	store  []*models.ReservationTemplate
+}
+
+func init() {
+	dao.GetReservationTemplateDao = getReservationTemplateDao
+}
+
+func ReservationTemplateStubCount(ctx context.Context) int {
+	templateDao := getReservationTemplateDaoStub(ctx)
+	return len(templateDao.store)
+}
+
+func getReservationTemplateDao(ctx context.Context) dao.ReservationTemplateDao {
+	return getReservationTemplateDaoStub(ctx)
+}
+
+func (stub *reservationTemplateDaoStub) Create(ctx context.Context, template *models.ReservationTemplate) error {
+	if err := injectFault(ctx, "ReservationTemplateDao.Create"); err != nil {
+		return err
+	}
+	if template.AccountID == 0 {
+		template.AccountID = ctxAccountId(ctx)
+	}
+	if template.AccountID != ctxAccountId(ctx) {
+		return dao.ErrWrongAccount
+	}
+	if err := models.Validate(ctx, template); err != nil {
+		return dao.ErrValidation
+	}
+
+	template.ID = stub.lastId + 1
+	stub.store = append(stub.store, template)
+	stub.lastId++
+	return nil
+}
+
+func (stub *reservationTemplateDaoStub) GetById(ctx context.Context, id int64) (*models.ReservationTemplate, error) {
+	if err := injectFault(ctx, "ReservationTemplateDao.GetById"); err != nil {
+		return nil, err
+	}
+	for _, t := range stub.store {
+		if t.AccountID == ctxAccountId(ctx) && t.ID == id {
+			return t, nil
+		}
+	}
+	return nil, dao.ErrNoRows
+}
+
+func (stub *reservationTemplateDaoStub) List(ctx context.Context, limit, offset int64) ([]*models.ReservationTemplate, error) {
+	if err := injectFault(ctx, "ReservationTemplateDao.List"); err != nil {
+		return nil, err
+	}
+	var filtered []*models.ReservationTemplate
+	for _, t := range stub.store {
+		if t.AccountID == ctxAccountId(ctx) {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered, nil
+}
+
+func (stub *reservationTemplateDaoStub) Count(ctx context.Context) (int64, error) {
+	if err := injectFault(ctx, "ReservationTemplateDao.Count"); err != nil {
+		return 0, err
+	}
+	var count int64
+	for _, t := range stub.store {
+		if t.AccountID == ctxAccountId(ctx) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (stub *reservationTemplateDaoStub) Delete(ctx context.Context, id int64) error {
+	if err := injectFault(ctx, "ReservationTemplateDao.Delete"); err != nil {
+		return err
+	}
+	for idx, t := range stub.store {
+		if t.AccountID == ctxAccountId(ctx) && t.ID == id {
+			stub.store = append(stub.store[:idx], stub.store[idx+1:]...)
+			return nil
+		}
+	}
+	return dao.ErrAffectedMismatch
+}
+
+func (stub *reservationTemplateDaoStub) UpdateSchedule(ctx context.Context, id int64, cron string, nextRunAt *time.Time, userID, username string) error {
+	if err := injectFault(ctx, "ReservationTemplateDao.UpdateSchedule"); err != nil {
+		return err
+	}
+	for _, t := range stub.store {
+		if t.AccountID == ctxAccountId(ctx) && t.ID == id {
+			t.ScheduleCron = cron
+			t.ScheduleUserID = userID
+			t.ScheduleUsername = username
+			t.NextRunAt = sql.NullTime{}
+			if nextRunAt != nil {
+				t.NextRunAt = sql.NullTime{Time: *nextRunAt, Valid: true}
+			}
+			return nil
+		}
+	}
+	return dao.ErrAffectedMismatch
+}
+
+func (stub *reservationTemplateDaoStub) UnscopedListDue(ctx context.Context, before time.Time, limit int64) ([]*models.ReservationTemplate, error) {
+	if err := injectFault(ctx, "ReservationTemplateDao.UnscopedListDue"); err != nil {
+		return nil, err
+	}
+	var filtered []*models.ReservationTemplate
+	for _, t := range stub.store {
+		if t.NextRunAt.Valid && !t.NextRunAt.Time.After(before) {
+			filtered = append(filtered, t)
+		}
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].NextRunAt.Time.Before(filtered[j].NextRunAt.Time)
+	})
+	if int64(len(filtered)) > limit {
+		filtered = filtered[:limit]
+	}
+	return filtered, nil
+}
+
+func (stub *reservationTemplateDaoStub) UnscopedClaimRun(ctx context.Context, id int64, runAt time.Time, nextRunAt *time.Time) (bool, error) {
+	if err := injectFault(ctx, "ReservationTemplateDao.UnscopedClaimRun"); err != nil {
+		return false, err
+	}
+	for _, t := range stub.store {
+		if t.ID == id && t.NextRunAt.Valid && t.NextRunAt.Time.Equal(runAt) {
+			t.NextRunAt = sql.NullTime{}
+			if nextRunAt != nil {
+				t.NextRunAt = sql.NullTime{Time: *nextRunAt, Valid: true}
+			}
+			t.LastRunAt = sql.NullTime{Time: time.Now(), Valid: true}
+			t.LastReservationID = sql.NullInt64{}
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (stub *reservationTemplateDaoStub) UnscopedUpdateLastReservation(ctx context.Context, id int64, reservationId int64) error {
+	if err := injectFault(ctx, "ReservationTemplateDao.UnscopedUpdateLastReservation"); err != nil {
+		return err
+	}
+	for _, t := range stub.store {
+		if t.ID == id {
+			t.LastReservationID = sql.NullInt64{Int64: reservationId, Valid: true}
+			return nil
+		}
+	}
+	return dao.ErrAffectedMismatch
+}
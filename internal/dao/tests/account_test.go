@@ -8,6 +8,7 @@ import (
 	"database/sql"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/RHEnVision/provisioning-backend/internal/dao"
 	"github.com/RHEnVision/provisioning-backend/internal/models"
@@ -97,6 +98,32 @@ func TestAccountList(t *testing.T) {
 	})
 }
 
+func TestAccountUnscopedListMostActive(t *testing.T) {
+	accDao, ctx := setupAccount(t)
+	reservationDao := dao.GetReservationDao(ctx)
+	defer reset()
+
+	t.Run("without reservations", func(t *testing.T) {
+		accounts, err := accDao.UnscopedListMostActive(ctx, time.Now().Add(-time.Hour), 10)
+		require.NoError(t, err)
+		assert.Empty(t, accounts)
+	})
+
+	t.Run("with reservations", func(t *testing.T) {
+		err := reservationDao.CreateNoop(ctx, newNoopReservation())
+		require.NoError(t, err)
+
+		accounts, err := accDao.UnscopedListMostActive(ctx, time.Now().Add(-time.Hour), 10)
+		require.NoError(t, err)
+		require.Len(t, accounts, 1)
+		assert.Equal(t, int64(1), accounts[0].ID)
+
+		accounts, err = accDao.UnscopedListMostActive(ctx, time.Now().Add(time.Hour), 10)
+		require.NoError(t, err)
+		assert.Empty(t, accounts)
+	})
+}
+
 func TestAccountGetById(t *testing.T) {
 	accDao, ctx := setupAccount(t)
 	defer reset()
@@ -155,3 +182,196 @@ func TestAccountGetOrCreateByIdentity(t *testing.T) {
 		assert.Equal(t, "1", account.AccountNumber.String)
 	})
 }
+
+func TestAccountNextNameSequence(t *testing.T) {
+	accDao, ctx := setupAccount(t)
+	defer reset()
+
+	seq, err := accDao.NextNameSequence(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), seq)
+
+	seq, err = accDao.NextNameSequence(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), seq)
+}
+
+func TestAccountSettings(t *testing.T) {
+	accDao, ctx := setupAccount(t)
+	defer reset()
+
+	t.Run("defaults", func(t *testing.T) {
+		settings, err := accDao.GetSettings(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), settings.AccountID)
+		assert.Zero(t, settings.ApprovalThreshold)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		err := accDao.UpdateSettings(ctx, &models.AccountSettings{ApprovalThreshold: 5})
+		require.NoError(t, err)
+		err = accDao.UpdateSettings(ctx, &models.AccountSettings{ApprovalThreshold: 10})
+		require.NoError(t, err)
+
+		settings, err := accDao.GetSettings(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), settings.ApprovalThreshold)
+	})
+
+	t.Run("instance types", func(t *testing.T) {
+		err := accDao.UpdateSettings(ctx, &models.AccountSettings{
+			DeniedInstanceTypes: []string{"*.metal"},
+			MaxVCPUs:            64,
+		})
+		require.NoError(t, err)
+
+		settings, err := accDao.GetSettings(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, settings.AllowedInstanceTypes)
+		assert.Equal(t, []string{"*.metal"}, settings.DeniedInstanceTypes)
+		assert.Equal(t, int32(64), settings.MaxVCPUs)
+	})
+
+	t.Run("images", func(t *testing.T) {
+		err := accDao.UpdateSettings(ctx, &models.AccountSettings{
+			ComposedImagesOnly: true,
+			AllowedImages:      []string{"ami-*"},
+		})
+		require.NoError(t, err)
+
+		settings, err := accDao.GetSettings(ctx)
+		require.NoError(t, err)
+		assert.True(t, settings.ComposedImagesOnly)
+		assert.Equal(t, []string{"ami-*"}, settings.AllowedImages)
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		err := accDao.UpdateSettings(ctx, &models.AccountSettings{ApprovalThreshold: -1})
+		require.Error(t, err)
+	})
+}
+
+func TestAccountSourceSettings(t *testing.T) {
+	accDao, ctx := setupAccount(t)
+	defer reset()
+
+	t.Run("defaults", func(t *testing.T) {
+		settings, err := accDao.GetSourceSettings(ctx, "1")
+		require.NoError(t, err)
+		assert.Equal(t, "1", settings.SourceID)
+		assert.Empty(t, settings.AllowedRegions)
+		assert.True(t, settings.AllowsRegion("us-east-1"))
+	})
+
+	t.Run("update", func(t *testing.T) {
+		err := accDao.UpdateSourceSettings(ctx, &models.SourceSettings{SourceID: "1", DefaultRegion: "us-east-1"})
+		require.NoError(t, err)
+		err = accDao.UpdateSourceSettings(ctx, &models.SourceSettings{
+			SourceID:       "1",
+			DefaultRegion:  "eu-west-1",
+			AllowedRegions: []string{"eu-west-1"},
+			EventTarget:    "arn:aws:sqs:eu-west-1:123456789012:launches",
+		})
+		require.NoError(t, err)
+
+		settings, err := accDao.GetSourceSettings(ctx, "1")
+		require.NoError(t, err)
+		assert.Equal(t, "eu-west-1", settings.DefaultRegion)
+		assert.Equal(t, []string{"eu-west-1"}, settings.AllowedRegions)
+		assert.Equal(t, "arn:aws:sqs:eu-west-1:123456789012:launches", settings.EventTarget)
+		assert.False(t, settings.AllowsRegion("us-east-1"))
+	})
+
+	t.Run("other source", func(t *testing.T) {
+		settings, err := accDao.GetSourceSettings(ctx, "2")
+		require.NoError(t, err)
+		assert.Empty(t, settings.DefaultRegion)
+	})
+}
+
+func TestAccountUnscopedPurge(t *testing.T) {
+	accDao, ctx := setupAccount(t)
+	defer reset()
+
+	reservation := newAWSReservation()
+	reservation.SourceID = "1"
+	err := dao.GetReservationDao(ctx).CreateAWS(ctx, reservation)
+	require.NoError(t, err)
+	err = dao.GetReservationDao(ctx).CreateInstance(ctx, newReservationInstance(reservation.ID))
+	require.NoError(t, err)
+	err = dao.GetAuditDao(ctx).Create(ctx, &models.AuditRecord{
+		ReservationID: reservation.ID,
+		Action:        models.AuditActionInstanceResize,
+		Details:       map[string]string{"instance_type": "t3.large"},
+	})
+	require.NoError(t, err)
+
+	purge, err := accDao.UnscopedPurge(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purge.Reservations)
+	assert.NotZero(t, purge.Pubkeys)
+	assert.Equal(t, int64(1), purge.AuditRecords)
+	assert.Equal(t, []string{"1"}, purge.SourceIDs)
+
+	count, err := dao.GetPubkeyDao(ctx).Count(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	records, err := dao.GetAuditDao(ctx).ListByReservation(ctx, reservation.ID, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Empty(t, records[0].Details)
+
+	t.Run("repeated", func(t *testing.T) {
+		purge, err := accDao.UnscopedPurge(ctx, 1)
+		require.NoError(t, err)
+		assert.Zero(t, purge.Reservations)
+		assert.Zero(t, purge.AuditRecords)
+		assert.Empty(t, purge.SourceIDs)
+	})
+
+	t.Run("account kept", func(t *testing.T) {
+		account, err := accDao.GetById(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), account.ID)
+	})
+}
+
+func TestAccountUsage(t *testing.T) {
+	accDao, ctx := setupAccount(t)
+	defer reset()
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	yesterday := today.AddDate(0, 0, -1)
+
+	t.Run("add", func(t *testing.T) {
+		err := accDao.UnscopedAddUsage(ctx, []*models.AccountUsage{
+			{AccountID: 1, Day: yesterday, APICalls: 10, Launches: 1, Instances: 3},
+			{AccountID: 1, Day: today, APICalls: 5},
+			{AccountID: 2, Day: today, APICalls: 50},
+		})
+		require.NoError(t, err)
+		err = accDao.UnscopedAddUsage(ctx, []*models.AccountUsage{{AccountID: 1, Day: today, APICalls: 2, Launches: 1, Instances: 1}})
+		require.NoError(t, err)
+
+		usage, err := accDao.ListUsage(ctx, yesterday)
+		require.NoError(t, err)
+		require.Len(t, usage, 2)
+		assert.Equal(t, today, usage[0].Day.UTC())
+		assert.Equal(t, int64(7), usage[0].APICalls)
+		assert.Equal(t, int64(1), usage[0].Launches)
+		assert.Equal(t, int64(3), usage[1].Instances)
+	})
+
+	t.Run("top", func(t *testing.T) {
+		top, err := accDao.UnscopedListTopUsage(ctx, yesterday, 10)
+		require.NoError(t, err)
+		require.Len(t, top, 2)
+		assert.Equal(t, "2", top[0].OrgID)
+		assert.Equal(t, int64(50), top[0].APICalls)
+		assert.Equal(t, int64(17), top[1].APICalls)
+
+		top, err = accDao.UnscopedListTopUsage(ctx, today, 1)
+		require.NoError(t, err)
+		require.Len(t, top, 1)
+	})
+}
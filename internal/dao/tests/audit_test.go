@@ -0,0 +1,56 @@
+//go:build integration
+// +build integration
+
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupAudit(t *testing.T) (dao.AuditDao, context.Context) {
+	ctx := identity.WithTenant(t, context.Background())
+	auditDao := dao.GetAuditDao(ctx)
+	return auditDao, ctx
+}
+
+func TestAuditCreate(t *testing.T) {
+	auditDao, ctx := setupAudit(t)
+	defer reset()
+
+	t.Run("success", func(t *testing.T) {
+		record := &models.AuditRecord{
+			ReservationID: 42,
+			InstanceID:    "i-0a4caa2cf5b097ce1",
+			Action:        models.AuditActionInstanceResize,
+			Details:       map[string]string{"instance_type": "t3.large"},
+		}
+		err := auditDao.Create(ctx, record)
+		require.NoError(t, err)
+		assert.NotZero(t, record.ID)
+		assert.Equal(t, int64(1), record.AccountID)
+
+		records, err := auditDao.ListByReservation(ctx, 42, 100, 0)
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, models.AuditActionInstanceResize, records[0].Action)
+		assert.Equal(t, "t3.large", records[0].Details["instance_type"])
+	})
+
+	t.Run("missing action", func(t *testing.T) {
+		err := auditDao.Create(ctx, &models.AuditRecord{ReservationID: 42})
+		require.Error(t, err)
+	})
+
+	t.Run("other reservation", func(t *testing.T) {
+		records, err := auditDao.ListByReservation(ctx, 43, 100, 0)
+		require.NoError(t, err)
+		assert.Empty(t, records)
+	})
+}
@@ -0,0 +1,81 @@
+//go:build integration
+// +build integration
+
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupOrphan(t *testing.T) (dao.OrphanDao, context.Context) {
+	ctx := identity.WithTenant(t, context.Background())
+	orphanDao := dao.GetOrphanDao(ctx)
+	return orphanDao, ctx
+}
+
+func newOrphan(instanceId string, kind models.OrphanKind) *models.OrphanedInstance {
+	return &models.OrphanedInstance{
+		Location:      "us-east-1",
+		InstanceID:    instanceId,
+		ReservationID: 1,
+		Kind:          kind,
+	}
+}
+
+func TestOrphanReplaceBySource(t *testing.T) {
+	orphanDao, ctx := setupOrphan(t)
+	defer reset()
+
+	detected, err := orphanDao.ReplaceBySource(ctx, models.ProviderTypeAWS, "1", "us-east-1", []*models.OrphanedInstance{
+		newOrphan("i-1", models.OrphanKindUntracked),
+		newOrphan("i-2", models.OrphanKindMissing),
+	})
+	require.NoError(t, err)
+	require.Len(t, detected, 2)
+
+	t.Run("list", func(t *testing.T) {
+		orphans, err := orphanDao.List(ctx, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, orphans, 2)
+		assert.Equal(t, "i-1", orphans[0].InstanceID)
+		assert.Equal(t, models.OrphanKindUntracked, orphans[0].Kind)
+		assert.Equal(t, models.ProviderTypeAWS, orphans[0].Provider)
+		assert.Equal(t, "1", orphans[0].SourceID)
+
+		count, err := orphanDao.Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		detected, err := orphanDao.ReplaceBySource(ctx, models.ProviderTypeAWS, "1", "us-east-1", []*models.OrphanedInstance{
+			newOrphan("i-2", models.OrphanKindMissing),
+			newOrphan("i-3", models.OrphanKindUntracked),
+		})
+		require.NoError(t, err)
+		require.Len(t, detected, 1)
+		assert.Equal(t, "i-3", detected[0].InstanceID)
+
+		orphans, err := orphanDao.List(ctx, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, orphans, 2)
+		assert.Equal(t, "i-2", orphans[0].InstanceID)
+		assert.Equal(t, "i-3", orphans[1].InstanceID)
+	})
+
+	t.Run("other region", func(t *testing.T) {
+		_, err := orphanDao.ReplaceBySource(ctx, models.ProviderTypeAWS, "1", "eu-west-1", nil)
+		require.NoError(t, err)
+
+		count, err := orphanDao.Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+	})
+}
@@ -5,10 +5,16 @@ package tests
 
 import (
 	"context"
+	"encoding/base64"
 	"math"
+	"strings"
 	"testing"
 
+	"github.com/RHEnVision/provisioning-backend/internal/config"
 	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/db"
+	"github.com/RHEnVision/provisioning-backend/internal/encryption"
+	pidentity "github.com/RHEnVision/provisioning-backend/internal/identity"
 	"github.com/RHEnVision/provisioning-backend/internal/testing/factories"
 	"github.com/RHEnVision/provisioning-backend/internal/testing/identity"
 	"github.com/go-playground/validator/v10"
@@ -83,6 +89,54 @@ func TestPubkeyList(t *testing.T) {
 	})
 }
 
+func TestPubkeyCount(t *testing.T) {
+	pkDao, ctx := setupPubkey(t)
+	defer reset()
+
+	t.Run("success", func(t *testing.T) {
+		err := pkDao.Create(ctx, factories.NewPubkeyRSA())
+		require.NoError(t, err)
+
+		count, err := pkDao.Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+	})
+}
+
+func TestPubkeyListWorkspaces(t *testing.T) {
+	pkDao, ctx := setupPubkey(t)
+	defer reset()
+
+	wsCtx := pidentity.WithWorkspaces(ctx, []string{"w1"})
+	newKey := factories.NewPubkeyRSA()
+	err := pkDao.Create(wsCtx, newKey)
+	require.NoError(t, err)
+	assert.Equal(t, "w1", newKey.WorkspaceID)
+
+	t.Run("restricted", func(t *testing.T) {
+		pubkeys, err := pkDao.List(wsCtx, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, pubkeys, 1)
+		assert.Equal(t, newKey.ID, pubkeys[0].ID)
+	})
+
+	t.Run("other workspace", func(t *testing.T) {
+		otherCtx := pidentity.WithWorkspaces(ctx, []string{"w2"})
+		pubkeys, err := pkDao.List(otherCtx, 10, 0)
+		require.NoError(t, err)
+		require.Empty(t, pubkeys)
+
+		_, err = pkDao.GetById(otherCtx, newKey.ID)
+		require.ErrorIs(t, err, dao.ErrNoRows)
+	})
+
+	t.Run("unrestricted", func(t *testing.T) {
+		pubkeys, err := pkDao.List(ctx, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 2, len(pubkeys))
+	})
+}
+
 func TestPubkeyUpdate(t *testing.T) {
 	pkDao, ctx := setupPubkey(t)
 	defer reset()
@@ -174,3 +228,65 @@ func TestPubkeyDeleteById(t *testing.T) {
 		require.ErrorIs(t, err, dao.ErrAffectedMismatch)
 	})
 }
+
+func TestPubkeyEncryption(t *testing.T) {
+	pkDao, ctx := setupPubkey(t)
+	defer reset()
+	defer func() {
+		config.Database.Encryption.Keys = nil
+		_ = encryption.Initialize(ctx)
+	}()
+
+	storedBody := func(t *testing.T, id int64) string {
+		t.Helper()
+		var body string
+		err := db.Pool.QueryRow(ctx, "SELECT body FROM pubkeys WHERE id = $1", id).Scan(&body)
+		require.NoError(t, err)
+		return body
+	}
+	useKeys := func(t *testing.T, keys ...string) {
+		t.Helper()
+		config.Database.Encryption.Keys = keys
+		require.NoError(t, encryption.Initialize(ctx))
+	}
+	k1 := "k1:" + base64.StdEncoding.EncodeToString([]byte(strings.Repeat("a", 32)))
+	k2 := "k2:" + base64.StdEncoding.EncodeToString([]byte(strings.Repeat("b", 32)))
+
+	// the seeded pubkey is stored in plain text
+	plain, err := pkDao.GetById(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, plain.Body, storedBody(t, plain.ID))
+
+	t.Run("encrypted on create", func(t *testing.T) {
+		useKeys(t, k1)
+		pk := factories.NewPubkeyRSA()
+		require.NoError(t, pkDao.Create(ctx, pk))
+		assert.True(t, strings.HasPrefix(storedBody(t, pk.ID), "enc:v1:k1:"))
+
+		pk2, err := pkDao.GetById(ctx, pk.ID)
+		require.NoError(t, err)
+		assert.Equal(t, pk, pk2)
+
+		pk3, err := pkDao.GetById(ctx, plain.ID)
+		require.NoError(t, err)
+		assert.Equal(t, plain.Body, pk3.Body)
+	})
+
+	t.Run("reencrypt", func(t *testing.T) {
+		useKeys(t, k2, k1)
+		count, err := dao.GetServiceDao(ctx).ReencryptPubkeys(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+		assert.True(t, strings.HasPrefix(storedBody(t, plain.ID), "enc:v1:k2:"))
+
+		count, err = dao.GetServiceDao(ctx).ReencryptPubkeys(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+
+		useKeys(t, k2)
+		pubkeys, err := pkDao.List(ctx, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, pubkeys, 2)
+		assert.Equal(t, plain.Body, pubkeys[0].Body)
+	})
+}
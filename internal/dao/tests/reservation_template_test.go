@@ -0,0 +1,123 @@
+//go:build integration
+// +build integration
+
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/db"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupReservationTemplate(t *testing.T) (dao.ReservationTemplateDao, context.Context) {
+	ctx := identity.WithTenant(t, context.Background())
+	templateDao := dao.GetReservationTemplateDao(ctx)
+	return templateDao, ctx
+}
+
+func newReservationTemplate(name string) *models.ReservationTemplate {
+	return &models.ReservationTemplate{
+		Name:     name,
+		Provider: models.ProviderTypeAWS,
+		Request:  []byte(`{"source_id": "1", "instance_type": "t3.small", "amount": 2}`),
+	}
+}
+
+func TestReservationTemplateCreate(t *testing.T) {
+	templateDao, ctx := setupReservationTemplate(t)
+	defer reset()
+
+	t.Run("success", func(t *testing.T) {
+		template := newReservationTemplate("lab")
+		err := templateDao.Create(ctx, template)
+		require.NoError(t, err)
+
+		template2, err := templateDao.GetById(ctx, template.ID)
+		require.NoError(t, err)
+		assert.Equal(t, template.Name, template2.Name)
+		assert.Equal(t, models.ProviderTypeAWS, template2.Provider)
+		assert.JSONEq(t, string(template.Request), string(template2.Request))
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		err := templateDao.Create(ctx, newReservationTemplate("lab"))
+		require.Error(t, err)
+		assert.NotNil(t, db.IsPostgresError(err, db.UniqueConstraintErrorCode))
+	})
+}
+
+func TestReservationTemplateListAndDelete(t *testing.T) {
+	templateDao, ctx := setupReservationTemplate(t)
+	defer reset()
+
+	for _, name := range []string{"first", "second"} {
+		err := templateDao.Create(ctx, newReservationTemplate(name))
+		require.NoError(t, err)
+	}
+
+	templates, err := templateDao.List(ctx, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, templates, 2)
+	assert.Equal(t, "first", templates[0].Name)
+
+	count, err := templateDao.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	err = templateDao.Delete(ctx, templates[0].ID)
+	require.NoError(t, err)
+
+	_, err = templateDao.GetById(ctx, templates[0].ID)
+	require.ErrorIs(t, err, dao.ErrNoRows)
+
+	err = templateDao.Delete(ctx, templates[0].ID)
+	require.ErrorIs(t, err, dao.ErrAffectedMismatch)
+}
+
+func TestReservationTemplateSchedule(t *testing.T) {
+	templateDao, ctx := setupReservationTemplate(t)
+	defer reset()
+
+	template := newReservationTemplate("scheduled")
+	err := templateDao.Create(ctx, template)
+	require.NoError(t, err)
+
+	runAt := time.Now().UTC().Add(-time.Minute).Truncate(time.Second)
+	err = templateDao.UpdateSchedule(ctx, template.ID, "0 7 * * *", &runAt, "1", "jdoe")
+	require.NoError(t, err)
+
+	due, err := templateDao.UnscopedListDue(ctx, time.Now().UTC(), 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, "1", due[0].ScheduleUserID)
+	assert.Equal(t, "jdoe", due[0].ScheduleUsername)
+	assert.True(t, runAt.Equal(due[0].NextRunAt.Time))
+
+	nextRunAt := runAt.Add(24 * time.Hour)
+	claimed, err := templateDao.UnscopedClaimRun(ctx, template.ID, runAt, &nextRunAt)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	claimed, err = templateDao.UnscopedClaimRun(ctx, template.ID, runAt, &nextRunAt)
+	require.NoError(t, err)
+	assert.False(t, claimed, "run must be claimed only once")
+
+	due, err = templateDao.UnscopedListDue(ctx, time.Now().UTC(), 10)
+	require.NoError(t, err)
+	assert.Empty(t, due)
+
+	err = templateDao.UpdateSchedule(ctx, template.ID, "", nil, "", "")
+	require.NoError(t, err)
+
+	template, err = templateDao.GetById(ctx, template.ID)
+	require.NoError(t, err)
+	assert.False(t, template.Scheduled())
+	assert.True(t, template.LastRunAt.Valid)
+}
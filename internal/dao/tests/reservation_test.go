@@ -5,12 +5,18 @@ package tests
 
 import (
 	"context"
+	"encoding/base64"
 	"math"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/RHEnVision/provisioning-backend/internal/config"
 	"github.com/RHEnVision/provisioning-backend/internal/dao"
 	"github.com/RHEnVision/provisioning-backend/internal/db"
+	"github.com/RHEnVision/provisioning-backend/internal/encryption"
+	pidentity "github.com/RHEnVision/provisioning-backend/internal/identity"
 	"github.com/RHEnVision/provisioning-backend/internal/models"
 	"github.com/RHEnVision/provisioning-backend/internal/testing/identity"
 	"github.com/stretchr/testify/assert"
@@ -117,6 +123,88 @@ func TestReservationGetById(t *testing.T) {
 		_, err := reservationDao.GetById(ctx, math.MaxInt64)
 		require.ErrorIs(t, err, dao.ErrNoRows)
 	})
+
+	t.Run("timeout", func(t *testing.T) {
+		timeout := config.Database.Timeout
+		config.Database.Timeout = time.Nanosecond
+		defer func() { config.Database.Timeout = timeout }()
+
+		_, err := reservationDao.GetById(ctx, math.MaxInt64)
+		require.ErrorIs(t, err, dao.ErrTimeout)
+	})
+}
+
+func TestReservationWorkspaces(t *testing.T) {
+	reservationDao, ctx := setupReservation(t)
+	defer reset()
+
+	wsCtx := pidentity.WithWorkspaces(ctx, []string{"w1"})
+	otherCtx := pidentity.WithWorkspaces(ctx, []string{"w2"})
+
+	aws := newAWSReservation()
+	err := reservationDao.CreateAWS(wsCtx, aws)
+	require.NoError(t, err)
+	assert.Equal(t, "w1", aws.WorkspaceID)
+	err = reservationDao.CreateInstance(wsCtx, newReservationInstance(aws.ID))
+	require.NoError(t, err)
+
+	azure := &models.AzureReservation{PubkeyID: 1}
+	err = reservationDao.CreateAzure(wsCtx, azure)
+	require.NoError(t, err)
+
+	gcp := newGCPReservation()
+	err = reservationDao.CreateGCP(wsCtx, gcp)
+	require.NoError(t, err)
+
+	t.Run("restricted", func(t *testing.T) {
+		_, err := reservationDao.GetAWSById(wsCtx, aws.ID)
+		require.NoError(t, err)
+		_, err = reservationDao.GetAzureById(wsCtx, azure.ID)
+		require.NoError(t, err)
+		_, err = reservationDao.GetGCPById(wsCtx, gcp.ID)
+		require.NoError(t, err)
+
+		instances, err := reservationDao.ListInstances(wsCtx, aws.ID)
+		require.NoError(t, err)
+		assert.Len(t, instances, 1)
+
+		err = reservationDao.UpdateApproval(wsCtx, aws.ID, models.ApprovalNone, models.ApprovalPending, "")
+		require.NoError(t, err)
+		err = reservationDao.UpdateLabels(wsCtx, aws.ID, []string{"team-a"})
+		require.NoError(t, err)
+	})
+
+	t.Run("other workspace", func(t *testing.T) {
+		_, err := reservationDao.GetAWSById(otherCtx, aws.ID)
+		require.ErrorIs(t, err, dao.ErrNoRows)
+		_, err = reservationDao.GetAzureById(otherCtx, azure.ID)
+		require.ErrorIs(t, err, dao.ErrNoRows)
+		_, err = reservationDao.GetGCPById(otherCtx, gcp.ID)
+		require.ErrorIs(t, err, dao.ErrNoRows)
+
+		instances, err := reservationDao.ListInstances(otherCtx, aws.ID)
+		require.NoError(t, err)
+		assert.Empty(t, instances)
+
+		err = reservationDao.UpdateApproval(otherCtx, aws.ID, models.ApprovalPending, models.ApprovalApproved, "jdoe")
+		require.ErrorIs(t, err, dao.ErrAffectedMismatch)
+		err = reservationDao.UpdateLabels(otherCtx, aws.ID, []string{"team-b"})
+		require.ErrorIs(t, err, dao.ErrAffectedMismatch)
+
+		res, err := reservationDao.GetById(wsCtx, aws.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.ApprovalPending, res.Approval)
+		assert.Equal(t, []string{"team-a"}, res.Labels)
+	})
+
+	t.Run("unrestricted", func(t *testing.T) {
+		_, err := reservationDao.GetAWSById(ctx, aws.ID)
+		require.NoError(t, err)
+
+		instances, err := reservationDao.ListInstances(ctx, aws.ID)
+		require.NoError(t, err)
+		assert.Len(t, instances, 1)
+	})
 }
 
 func TestReservationCreateAWS(t *testing.T) {
@@ -180,6 +268,199 @@ func TestReservationCreateAWSInstance(t *testing.T) {
 	})
 }
 
+func TestReservationUpdateInstancePowerState(t *testing.T) {
+	reservationDao, ctx := setupReservation(t)
+	defer reset()
+
+	reservation := newAWSReservation()
+	err := reservationDao.CreateAWS(ctx, reservation)
+	require.NoError(t, err)
+	instance := newReservationInstance(reservation.ID)
+	err = reservationDao.CreateInstance(ctx, instance)
+	require.NoError(t, err)
+
+	t.Run("transition", func(t *testing.T) {
+		err := reservationDao.UpdateInstancePowerState(ctx, reservation.ID, instance.InstanceID, models.PowerStateStopping, models.PowerStateRunning)
+		require.NoError(t, err)
+
+		instancesList, err := reservationDao.ListInstances(ctx, reservation.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.PowerStateStopping, instancesList[0].PowerState)
+	})
+
+	t.Run("invalid transition", func(t *testing.T) {
+		err := reservationDao.UpdateInstancePowerState(ctx, reservation.ID, instance.InstanceID, models.PowerStateStopping, models.PowerStateRunning)
+		require.ErrorIs(t, err, dao.ErrAffectedMismatch)
+	})
+
+	t.Run("any state", func(t *testing.T) {
+		err := reservationDao.UpdateInstancePowerState(ctx, reservation.ID, instance.InstanceID, models.PowerStateStopped)
+		require.NoError(t, err)
+	})
+}
+
+func TestReservationUpdateInstanceDNSRecord(t *testing.T) {
+	reservationDao, ctx := setupReservation(t)
+	defer reset()
+
+	reservation := newAWSReservation()
+	err := reservationDao.CreateAWS(ctx, reservation)
+	require.NoError(t, err)
+	instance := newReservationInstance(reservation.ID)
+	err = reservationDao.CreateInstance(ctx, instance)
+	require.NoError(t, err)
+
+	record := &models.DNSRecord{Zone: "Z0123456789", Name: "web-1.example.com", IPv4: instance.Detail.PublicIPv4, TTL: 300}
+	err = reservationDao.UpdateInstanceDNSRecord(ctx, reservation.ID, instance.InstanceID, record)
+	require.NoError(t, err)
+
+	instancesList, err := reservationDao.ListInstances(ctx, reservation.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "web-1.example.com", instancesList[0].Detail.DNSName)
+	assert.Equal(t, *record, instancesList[0].DNSRecord)
+	assert.Equal(t, instance.Detail.PublicIPv4, instancesList[0].Detail.PublicIPv4)
+}
+
+func TestReservationUpdateInstanceElasticIP(t *testing.T) {
+	reservationDao, ctx := setupReservation(t)
+	defer reset()
+
+	reservation := newAWSReservation()
+	err := reservationDao.CreateAWS(ctx, reservation)
+	require.NoError(t, err)
+	instance := newReservationInstance(reservation.ID)
+	err = reservationDao.CreateInstance(ctx, instance)
+	require.NoError(t, err)
+
+	eip := &models.ElasticIP{AllocationID: "eipalloc-1", AssociationID: "eipassoc-1", PublicIP: "3.5.7.9"}
+	err = reservationDao.UpdateInstanceElasticIP(ctx, reservation.ID, instance.InstanceID, eip)
+	require.NoError(t, err)
+
+	instancesList, err := reservationDao.ListInstances(ctx, reservation.ID)
+	require.NoError(t, err)
+	assert.Equal(t, *eip, instancesList[0].ElasticIP)
+
+	err = reservationDao.UpdateInstanceElasticIP(ctx, reservation.ID, "missing", eip)
+	require.ErrorIs(t, err, dao.ErrAffectedMismatch)
+}
+
+func TestReservationPendingUsage(t *testing.T) {
+	reservationDao, ctx := setupReservation(t)
+	defer reset()
+
+	reservation := newAWSReservation()
+	err := reservationDao.CreateAWS(ctx, reservation)
+	require.NoError(t, err)
+	instance := newReservationInstance(reservation.ID)
+	err = reservationDao.CreateInstance(ctx, instance)
+	require.NoError(t, err)
+
+	t.Run("started", func(t *testing.T) {
+		pending, err := reservationDao.UnscopedListPendingUsage(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, instance.InstanceID, pending[0].InstanceID)
+		assert.Equal(t, reservation.AccountID, pending[0].AccountID)
+		assert.False(t, pending[0].UsageStarted)
+	})
+
+	t.Run("sent", func(t *testing.T) {
+		err := reservationDao.UnscopedUpdateInstanceUsage(ctx, reservation.ID, instance.InstanceID, true, false)
+		require.NoError(t, err)
+
+		pending, err := reservationDao.UnscopedListPendingUsage(ctx, 10)
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+	})
+
+	t.Run("terminated", func(t *testing.T) {
+		err := reservationDao.UpdateInstancePowerState(ctx, reservation.ID, instance.InstanceID, models.PowerStateTerminated)
+		require.NoError(t, err)
+
+		pending, err := reservationDao.UnscopedListPendingUsage(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.True(t, pending[0].UsageStarted)
+		assert.False(t, pending[0].UsageTerminated)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		err := reservationDao.UnscopedUpdateInstanceUsage(ctx, reservation.ID, "missing", true, true)
+		require.ErrorIs(t, err, dao.ErrAffectedMismatch)
+	})
+}
+
+func TestReservationUpdateInstancePasswordData(t *testing.T) {
+	reservationDao, ctx := setupReservation(t)
+	defer reset()
+
+	reservation := newAWSReservation()
+	err := reservationDao.CreateAWS(ctx, reservation)
+	require.NoError(t, err)
+	instance := newReservationInstance(reservation.ID)
+	err = reservationDao.CreateInstance(ctx, instance)
+	require.NoError(t, err)
+
+	err = reservationDao.UpdateInstancePasswordData(ctx, reservation.ID, instance.InstanceID, "ZW5jcnlwdGVk")
+	require.NoError(t, err)
+
+	instancesList, err := reservationDao.ListInstances(ctx, reservation.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "ZW5jcnlwdGVk", instancesList[0].Detail.PasswordData)
+	assert.Equal(t, instance.Detail.PublicIPv4, instancesList[0].Detail.PublicIPv4)
+}
+
+func TestReservationListAllInstances(t *testing.T) {
+	reservationDao, ctx := setupReservation(t)
+	defer reset()
+
+	reservation := newAWSReservation()
+	reservation.Detail = &models.AWSDetail{Region: "us-east-1", InstanceType: "t3.small", Amount: 1}
+	err := reservationDao.CreateAWS(ctx, reservation)
+	require.NoError(t, err)
+	instance := newReservationInstance(reservation.ID)
+	err = reservationDao.CreateInstance(ctx, instance)
+	require.NoError(t, err)
+	gcpReservation := newGCPReservation()
+	err = reservationDao.CreateGCP(ctx, gcpReservation)
+	require.NoError(t, err)
+	err = reservationDao.CreateInstance(ctx, newReservationInstance(gcpReservation.ID))
+	require.NoError(t, err)
+
+	t.Run("all", func(t *testing.T) {
+		instances, err := reservationDao.ListAllInstances(ctx, &dao.InstanceFilter{}, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, instances, 2)
+		assert.Equal(t, models.ProviderTypeAWS, instances[0].Provider)
+		assert.Equal(t, "us-east-1", instances[0].Location)
+		assert.Equal(t, "t3.small", instances[0].InstanceType)
+		assert.Equal(t, instance.Detail.PublicIPv4, instances[0].Detail.PublicIPv4)
+
+		count, err := reservationDao.CountAllInstances(ctx, &dao.InstanceFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("filtered", func(t *testing.T) {
+		filter := &dao.InstanceFilter{Provider: models.ProviderTypeAWS, Region: "us-east-1", PowerState: models.PowerStateRunning}
+		instances, err := reservationDao.ListAllInstances(ctx, filter, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, instances, 1)
+		assert.Equal(t, reservation.ID, instances[0].ReservationID)
+
+		count, err := reservationDao.CountAllInstances(ctx, &dao.InstanceFilter{PowerState: models.PowerStateTerminated})
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+
+	t.Run("other account", func(t *testing.T) {
+		reservationDao, ctx := setupReservationOrg2(t)
+		instances, err := reservationDao.ListAllInstances(ctx, &dao.InstanceFilter{}, 10, 0)
+		require.NoError(t, err)
+		assert.Empty(t, instances)
+	})
+}
+
 func TestReservationList(t *testing.T) {
 	reservationDao, ctx := setupReservation(t)
 	defer reset()
@@ -205,6 +486,101 @@ func TestReservationList(t *testing.T) {
 	})
 }
 
+func TestReservationListByCreator(t *testing.T) {
+	reservationDao, ctx := setupReservation(t)
+	defer reset()
+
+	id := pidentity.Identity(ctx)
+	id.Identity.User.UserID = "1001"
+	id.Identity.User.Username = "jdoe"
+	ctx = pidentity.WithIdentity(ctx, id)
+
+	t.Run("success", func(t *testing.T) {
+		noopReservation := newNoopReservation()
+		err := reservationDao.CreateNoop(ctx, noopReservation)
+		require.NoError(t, err)
+
+		reservations, err := reservationDao.ListByCreator(ctx, "1001", 10, 0)
+		require.NoError(t, err)
+		require.Len(t, reservations, 1)
+		assert.Equal(t, "jdoe", reservations[0].CreatedBy)
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		reservations, err := reservationDao.ListByCreator(ctx, "1002", 10, 0)
+		require.NoError(t, err)
+		require.Empty(t, reservations)
+	})
+
+	t.Run("count", func(t *testing.T) {
+		count, err := reservationDao.CountByCreator(ctx, "1001")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+
+		count, err = reservationDao.CountByCreator(ctx, "1002")
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+
+		count, err = reservationDao.Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+}
+
+func TestReservationListByIDs(t *testing.T) {
+	reservationDao, ctx := setupReservation(t)
+	defer reset()
+
+	t.Run("only existing", func(t *testing.T) {
+		first := newNoopReservation()
+		err := reservationDao.CreateNoop(ctx, first)
+		require.NoError(t, err)
+
+		second := newNoopReservation()
+		err = reservationDao.CreateNoop(ctx, second)
+		require.NoError(t, err)
+
+		reservations, err := reservationDao.ListByIDs(ctx, []int64{second.ID, first.ID, 999999}, &dao.ReservationFilter{})
+		require.NoError(t, err)
+		require.Equal(t, 2, len(reservations))
+		assert.Equal(t, first.ID, reservations[0].ID)
+		assert.Equal(t, second.ID, reservations[1].ID)
+
+		filter := &dao.ReservationFilter{ByCreator: true, CreatedByUserID: "unknown"}
+		reservations, err = reservationDao.ListByIDs(ctx, []int64{second.ID, first.ID}, filter)
+		require.NoError(t, err)
+		assert.Empty(t, reservations)
+	})
+}
+
+func TestReservationExport(t *testing.T) {
+	reservationDao, ctx := setupReservation(t)
+	defer reset()
+
+	t.Run("flattened details", func(t *testing.T) {
+		awsReservation := newAWSReservation()
+		awsReservation.Detail = &models.AWSDetail{Region: "us-east-1", InstanceType: "t3.small", Amount: 2}
+		err := reservationDao.CreateAWS(ctx, awsReservation)
+		require.NoError(t, err)
+
+		noopReservation := newNoopReservation()
+		err = reservationDao.CreateNoop(ctx, noopReservation)
+		require.NoError(t, err)
+
+		var exports []*models.ReservationExport
+		err = reservationDao.Export(ctx, &dao.ReservationFilter{}, func(export *models.ReservationExport) error {
+			exports = append(exports, export)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 2, len(exports))
+		assert.Equal(t, awsReservation.Detail.Region, exports[0].Location)
+		assert.Equal(t, awsReservation.Detail.InstanceType, exports[0].InstanceType)
+		assert.Equal(t, int64(awsReservation.Detail.Amount), exports[0].Amount)
+		assert.Equal(t, "", exports[1].Location)
+	})
+}
+
 func TestUnscopedUpdateAWSDetail(t *testing.T) {
 	reservationDao, ctx := setupReservation(t)
 	defer reset()
@@ -231,6 +607,100 @@ func TestUnscopedUpdateAWSDetail(t *testing.T) {
 	})
 }
 
+func TestReservationUserDataEncryption(t *testing.T) {
+	reservationDao, ctx := setupReservation(t)
+	defer reset()
+	defer func() {
+		config.Database.Encryption.Keys = nil
+		_ = encryption.Initialize(ctx)
+	}()
+
+	config.Database.Encryption.Keys = []string{"k1:" + base64.StdEncoding.EncodeToString([]byte(strings.Repeat("a", 32)))}
+	require.NoError(t, encryption.Initialize(ctx))
+	storedUserData := func(t *testing.T, table string, id int64) string {
+		t.Helper()
+		var userData string
+		err := db.Pool.QueryRow(ctx, "SELECT detail->>'user_data' FROM "+table+" WHERE reservation_id = $1", id).Scan(&userData)
+		require.NoError(t, err)
+		return userData
+	}
+
+	t.Run("aws", func(t *testing.T) {
+		reservation := newAWSReservation()
+		reservation.Detail = &models.AWSDetail{Region: "us-east-1", Amount: 1, UserData: "#!/bin/sh\necho secret"}
+		require.NoError(t, reservationDao.CreateAWS(ctx, reservation))
+		assert.Equal(t, "#!/bin/sh\necho secret", reservation.Detail.UserData)
+		assert.True(t, strings.HasPrefix(storedUserData(t, "aws_reservation_details", reservation.ID), "enc:v1:k1:"))
+
+		reservationAfter, err := reservationDao.GetAWSById(ctx, reservation.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "#!/bin/sh\necho secret", reservationAfter.Detail.UserData)
+
+		reservationAfter.Detail.PubkeyName = "AWS name"
+		require.NoError(t, reservationDao.UnscopedUpdateAWSDetail(ctx, reservation.ID, reservationAfter.Detail))
+		assert.True(t, strings.HasPrefix(storedUserData(t, "aws_reservation_details", reservation.ID), "enc:v1:k1:"))
+
+		reservationAfter, err = reservationDao.GetAWSById(ctx, reservation.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "#!/bin/sh\necho secret", reservationAfter.Detail.UserData)
+	})
+
+	t.Run("gcp", func(t *testing.T) {
+		reservation := newGCPReservation()
+		reservation.Detail = &models.GCPDetail{Zone: "us-east1-b", Amount: 1, UserData: "#cloud-config\n"}
+		require.NoError(t, reservationDao.CreateGCP(ctx, reservation))
+		assert.True(t, strings.HasPrefix(storedUserData(t, "gcp_reservation_details", reservation.ID), "enc:v1:k1:"))
+
+		reservationAfter, err := reservationDao.GetGCPById(ctx, reservation.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "#cloud-config\n", reservationAfter.Detail.UserData)
+	})
+
+	t.Run("job", func(t *testing.T) {
+		reservation := newAWSReservation()
+		require.NoError(t, reservationDao.CreateAWS(ctx, reservation))
+		job := []byte(`{"type":"launch_instances_aws","account_id":1,"args":{"ReservationID":` + strconv.FormatInt(reservation.ID, 10) +
+			`,"Detail":{"region":"us-east-1","user_data":"#!/bin/sh\necho secret"}}}`)
+		require.NoError(t, reservationDao.CreateJob(ctx, &models.ReservationJob{ReservationID: reservation.ID, JobType: "launch_instances_aws", Job: job}))
+
+		var userData string
+		err := db.Pool.QueryRow(ctx, "SELECT job #>> '{args,Detail,user_data}' FROM reservation_jobs WHERE reservation_id = $1", reservation.ID).Scan(&userData)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(userData, "enc:v1:k1:"))
+
+		stored, err := reservationDao.UnscopedGetJob(ctx, reservation.ID)
+		require.NoError(t, err)
+		assert.JSONEq(t, string(job), string(stored.Job))
+	})
+
+	t.Run("reencrypt", func(t *testing.T) {
+		config.Database.Encryption.Keys = nil
+		require.NoError(t, encryption.Initialize(ctx))
+		reservation := newAWSReservation()
+		reservation.Detail = &models.AWSDetail{Region: "us-east-1", Amount: 1, UserData: "plain"}
+		require.NoError(t, reservationDao.CreateAWS(ctx, reservation))
+		require.Equal(t, "plain", storedUserData(t, "aws_reservation_details", reservation.ID))
+
+		config.Database.Encryption.Keys = []string{
+			"k2:" + base64.StdEncoding.EncodeToString([]byte(strings.Repeat("b", 32))),
+			"k1:" + base64.StdEncoding.EncodeToString([]byte(strings.Repeat("a", 32))),
+		}
+		require.NoError(t, encryption.Initialize(ctx))
+		count, err := dao.GetServiceDao(ctx).ReencryptUserData(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 4, count)
+		assert.True(t, strings.HasPrefix(storedUserData(t, "aws_reservation_details", reservation.ID), "enc:v1:k2:"))
+
+		count, err = dao.GetServiceDao(ctx).ReencryptUserData(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+
+		reservationAfter, err := reservationDao.GetAWSById(ctx, reservation.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "plain", reservationAfter.Detail.UserData)
+	})
+}
+
 func TestReservationUpdateIDForAWS(t *testing.T) {
 	reservationDao, ctx := setupReservation(t)
 	defer reset()
@@ -289,6 +759,255 @@ func TestReservationUpdateStatus(t *testing.T) {
 	})
 }
 
+func TestReservationUpdateReachable(t *testing.T) {
+	reservationDao, ctx := setupReservation(t)
+	defer reset()
+	res := newNoopReservation()
+	err := reservationDao.CreateNoop(ctx, res)
+	require.NoError(t, err)
+
+	newRes, err := reservationDao.GetById(ctx, res.ID)
+	require.NoError(t, err)
+	assert.False(t, newRes.Reachable.Valid)
+
+	err = reservationDao.UpdateReachable(ctx, res.ID, false)
+	require.NoError(t, err)
+
+	newRes, err = reservationDao.GetById(ctx, res.ID)
+	require.NoError(t, err)
+	assert.True(t, newRes.Reachable.Valid)
+	assert.False(t, newRes.Reachable.Bool)
+}
+
+func TestReservationUpdateApproval(t *testing.T) {
+	reservationDao, ctx := setupReservation(t)
+	defer reset()
+
+	res := newNoopReservation()
+	err := reservationDao.CreateNoop(ctx, res)
+	require.NoError(t, err)
+
+	t.Run("transition", func(t *testing.T) {
+		err := reservationDao.UpdateApproval(ctx, res.ID, models.ApprovalNone, models.ApprovalPending, "")
+		require.NoError(t, err)
+		err = reservationDao.UpdateApproval(ctx, res.ID, models.ApprovalPending, models.ApprovalApproved, "jdoe")
+		require.NoError(t, err)
+
+		newRes, err := reservationDao.GetById(ctx, res.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.ApprovalApproved, newRes.Approval)
+		assert.Equal(t, "jdoe", newRes.ApprovedBy)
+	})
+
+	t.Run("invalid transition", func(t *testing.T) {
+		err := reservationDao.UpdateApproval(ctx, res.ID, models.ApprovalPending, models.ApprovalRejected, "jdoe")
+		require.ErrorIs(t, err, dao.ErrAffectedMismatch)
+	})
+
+	t.Run("other account", func(t *testing.T) {
+		reservationDao, ctx := setupReservationOrg2(t)
+		err := reservationDao.UpdateApproval(ctx, res.ID, models.ApprovalApproved, models.ApprovalRejected, "jdoe")
+		require.ErrorIs(t, err, dao.ErrAffectedMismatch)
+	})
+}
+
+func TestReservationLabels(t *testing.T) {
+	reservationDao, ctx := setupReservation(t)
+	defer reset()
+
+	labeled := newNoopReservation()
+	labeled.Labels = []string{"hackathon", "team-a"}
+	err := reservationDao.CreateNoop(ctx, labeled)
+	require.NoError(t, err)
+	err = reservationDao.CreateInstance(ctx, newReservationInstance(labeled.ID))
+	require.NoError(t, err)
+	err = reservationDao.FinishWithSuccess(ctx, labeled.ID)
+	require.NoError(t, err)
+
+	other := newNoopReservation()
+	err = reservationDao.CreateNoop(ctx, other)
+	require.NoError(t, err)
+
+	t.Run("list by label", func(t *testing.T) {
+		reservations, err := reservationDao.ListFiltered(ctx, &dao.ReservationFilter{Label: "hackathon"}, 100, 0)
+		require.NoError(t, err)
+		require.Len(t, reservations, 1)
+		assert.Equal(t, labeled.ID, reservations[0].ID)
+		assert.Equal(t, []string{"hackathon", "team-a"}, reservations[0].Labels)
+
+		count, err := reservationDao.CountFiltered(ctx, &dao.ReservationFilter{Label: "hackathon"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("list by creator", func(t *testing.T) {
+		filter := &dao.ReservationFilter{Label: "hackathon", ByCreator: true, CreatedByUserID: "unknown"}
+		reservations, err := reservationDao.ListFiltered(ctx, filter, 100, 0)
+		require.NoError(t, err)
+		assert.Empty(t, reservations)
+
+		count, err := reservationDao.CountFiltered(ctx, filter)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		err := reservationDao.UpdateLabels(ctx, other.ID, []string{"team-a"})
+		require.NoError(t, err)
+
+		reservations, err := reservationDao.ListFiltered(ctx, &dao.ReservationFilter{Label: "team-a"}, 100, 0)
+		require.NoError(t, err)
+		assert.Len(t, reservations, 2)
+	})
+
+	t.Run("stats", func(t *testing.T) {
+		stats, err := reservationDao.ListLabelStats(ctx, &dao.ReservationFilter{})
+		require.NoError(t, err)
+		require.Len(t, stats, 2)
+		assert.Equal(t, models.LabelStats{Label: "hackathon", Reservations: 1, Succeeded: 1, Instances: 1}, *stats[0])
+		assert.Equal(t, models.LabelStats{Label: "team-a", Reservations: 2, Succeeded: 1, Instances: 1}, *stats[1])
+	})
+
+	t.Run("other account", func(t *testing.T) {
+		reservationDao, ctx := setupReservationOrg2(t)
+		err := reservationDao.UpdateLabels(ctx, other.ID, nil)
+		require.ErrorIs(t, err, dao.ErrAffectedMismatch)
+	})
+}
+
+func TestReservationEvents(t *testing.T) {
+	reservationDao, ctx := setupReservation(t)
+	defer reset()
+
+	res := newNoopReservation()
+	err := reservationDao.CreateNoop(ctx, res)
+	require.NoError(t, err)
+
+	for _, kind := range []models.ReservationEventKind{models.EventEnqueued, models.EventDequeued, models.EventFinished} {
+		err = reservationDao.CreateEvent(ctx, &models.ReservationEvent{
+			ReservationID: res.ID,
+			Kind:          kind,
+			Message:       string(kind),
+			Details:       map[string]string{"job_type": "no_operation"},
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("list", func(t *testing.T) {
+		events, err := reservationDao.ListEvents(ctx, res.ID, 100, 0)
+		require.NoError(t, err)
+		require.Len(t, events, 3)
+		assert.Equal(t, models.EventEnqueued, events[0].Kind)
+		assert.Equal(t, models.EventFinished, events[2].Kind)
+		assert.Equal(t, map[string]string{"job_type": "no_operation"}, events[0].Details)
+
+		count, err := reservationDao.CountEvents(ctx, res.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+	})
+
+	t.Run("paging", func(t *testing.T) {
+		events, err := reservationDao.ListEvents(ctx, res.ID, 1, 1)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, models.EventDequeued, events[0].Kind)
+	})
+
+	t.Run("kind required", func(t *testing.T) {
+		err := reservationDao.CreateEvent(ctx, &models.ReservationEvent{ReservationID: res.ID})
+		require.Error(t, err)
+	})
+
+	t.Run("other account", func(t *testing.T) {
+		reservationDao, ctx := setupReservationOrg2(t)
+		events, err := reservationDao.ListEvents(ctx, res.ID, 100, 0)
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+
+	t.Run("unscoped", func(t *testing.T) {
+		reservationDao, ctx := setupReservationOrg2(t)
+		events, err := reservationDao.UnscopedListEvents(ctx, res.ID, 2)
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		assert.Equal(t, models.EventEnqueued, events[0].Kind)
+	})
+}
+
+func TestReservationWaitForUpdate(t *testing.T) {
+	reservationDao, ctx := setupReservation(t)
+	defer reset()
+
+	t.Run("notified on status change", func(t *testing.T) {
+		res := newNoopReservation()
+		err := reservationDao.CreateNoop(ctx, res)
+		require.NoError(t, err)
+
+		waitCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		done := make(chan error, 1)
+		go func() {
+			done <- reservationDao.WaitForUpdate(waitCtx, &res.Reservation)
+		}()
+
+		// give the listener time to connect
+		time.Sleep(500 * time.Millisecond)
+		err = reservationDao.UpdateStatus(ctx, res.ID, "Changed", 0)
+		require.NoError(t, err)
+
+		require.NoError(t, <-done)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		res := newNoopReservation()
+		err := reservationDao.CreateNoop(ctx, res)
+		require.NoError(t, err)
+
+		waitCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		err = reservationDao.WaitForUpdate(waitCtx, &res.Reservation)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("changed before subscribing", func(t *testing.T) {
+		res := newNoopReservation()
+		err := reservationDao.CreateNoop(ctx, res)
+		require.NoError(t, err)
+		read, err := reservationDao.GetById(ctx, res.ID)
+		require.NoError(t, err)
+
+		// the change is missed by the listener, it happened before the wait
+		err = reservationDao.UpdateStatus(ctx, res.ID, "Changed", 1)
+		require.NoError(t, err)
+
+		waitCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		require.NoError(t, reservationDao.WaitForUpdate(waitCtx, read))
+	})
+
+	t.Run("polled in pgbouncer mode", func(t *testing.T) {
+		config.Database.PgBouncer = true
+		defer func() { config.Database.PgBouncer = false }()
+
+		res := newNoopReservation()
+		err := reservationDao.CreateNoop(ctx, res)
+		require.NoError(t, err)
+
+		waitCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		done := make(chan error, 1)
+		go func() {
+			done <- reservationDao.WaitForUpdate(waitCtx, &res.Reservation)
+		}()
+
+		time.Sleep(100 * time.Millisecond)
+		err = reservationDao.UpdateStatus(ctx, res.ID, "Changed", 0)
+		require.NoError(t, err)
+
+		require.NoError(t, <-done)
+	})
+}
+
 func TestReservationDelete(t *testing.T) {
 	reservationDao, ctx := setupReservation(t)
 	defer reset()
@@ -385,3 +1104,75 @@ func TestReservationRate(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestReservationUnscopedRequeue(t *testing.T) {
+	reservationDao, ctx := setupReservation(t)
+	defer reset()
+
+	t.Run("resets failed reservation", func(t *testing.T) {
+		res := newNoopReservation()
+		err := reservationDao.CreateNoop(ctx, res)
+		require.NoError(t, err)
+		err = reservationDao.CreateJob(ctx, &models.ReservationJob{ReservationID: res.ID, JobType: "no_operation", Job: []byte(`{}`)})
+		require.NoError(t, err)
+		err = reservationDao.FinishWithError(ctx, res.ID, "error")
+		require.NoError(t, err)
+
+		pending, err := reservationDao.UnscopedList(context.Background(), true, 100, 0)
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+
+		err = reservationDao.UnscopedRequeue(context.Background(), res.ID)
+		require.NoError(t, err)
+
+		newRes, err := reservationDao.UnscopedGetById(context.Background(), res.ID)
+		require.NoError(t, err)
+		assert.False(t, newRes.Success.Valid)
+		assert.False(t, newRes.FinishedAt.Valid)
+		assert.Empty(t, newRes.Error)
+
+		job, err := reservationDao.UnscopedGetJob(context.Background(), res.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "no_operation", job.JobType)
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		err := reservationDao.UnscopedRequeue(context.Background(), math.MaxInt64)
+		require.ErrorIs(t, err, dao.ErrAffectedMismatch)
+	})
+
+	t.Run("not finished", func(t *testing.T) {
+		res := newNoopReservation()
+		err := reservationDao.CreateNoop(ctx, res)
+		require.NoError(t, err)
+
+		err = reservationDao.UnscopedRequeue(context.Background(), res.ID)
+		require.ErrorIs(t, err, dao.ErrAffectedMismatch)
+	})
+}
+
+func TestReservationUnscopedListExpired(t *testing.T) {
+	reservationDao, ctx := setupReservation(t)
+	defer reset()
+
+	t.Run("only older than lifetime", func(t *testing.T) {
+		old := newNoopReservation()
+		err := reservationDao.CreateNoop(ctx, old)
+		require.NoError(t, err)
+		recent := newNoopReservation()
+		err = reservationDao.CreateNoop(ctx, recent)
+		require.NoError(t, err)
+		_, err = db.Pool.Exec(ctx, `UPDATE reservations SET created_at = now() - interval '2 days' WHERE id = $1`, old.ID)
+		require.NoError(t, err)
+
+		lifetime := config.Reservation.Lifetime
+		config.Reservation.Lifetime = 24 * time.Hour
+		defer func() { config.Reservation.Lifetime = lifetime }()
+
+		expired, err := reservationDao.UnscopedListExpired(context.Background(), 100)
+		require.NoError(t, err)
+		require.Len(t, expired, 1)
+		assert.Equal(t, old.ID, expired[0].ID)
+		assert.Equal(t, int64(1), expired[0].AccountID)
+	})
+}
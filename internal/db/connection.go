@@ -3,15 +3,19 @@ package db
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/IBM/pgxpoolprometheus"
 	"github.com/RHEnVision/provisioning-backend/internal/config"
-	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/RHEnVision/provisioning-backend/internal/encryption"
 	"github.com/RHEnVision/provisioning-backend/internal/logging"
 	"github.com/RHEnVision/provisioning-backend/internal/version"
 	"github.com/exaring/otelpgx"
 	pgxlog "github.com/jackc/pgx-zerolog"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/tracelog"
 	"github.com/prometheus/client_golang/prometheus"
@@ -24,24 +28,41 @@ import (
 // Pool is the main connection pool for the whole application
 var Pool *pgxpool.Pool
 
+// hostList returns host:port pairs separated by comma, hosts without port use the configured port.
+// Multiple hosts are tried in order until one satisfying target_session_attrs is found.
+func hostList() string {
+	hosts := strings.Split(config.Database.Host, ",")
+	for i, host := range hosts {
+		host = strings.TrimSpace(host)
+		if _, _, err := net.SplitHostPort(host); err != nil {
+			host = net.JoinHostPort(host, strconv.Itoa(int(config.Database.Port)))
+		}
+		hosts[i] = host
+	}
+	return strings.Join(hosts, ",")
+}
+
 func getConnString(prefix, schema string) string {
+	params := url.Values{"search_path": []string{schema}}
+	if config.Database.TargetAttrs != "" {
+		params.Set("target_session_attrs", config.Database.TargetAttrs)
+	}
+
 	if len(config.Database.Password) > 0 {
-		return fmt.Sprintf("%s://%s:%s@%s:%d/%s?search_path=%s",
+		return fmt.Sprintf("%s://%s:%s@%s/%s?%s",
 			prefix,
 			url.QueryEscape(config.Database.User),
 			url.QueryEscape(config.Database.Password),
-			config.Database.Host,
-			config.Database.Port,
+			hostList(),
 			config.Database.Name,
-			schema)
+			params.Encode())
 	} else {
-		return fmt.Sprintf("%s://%s@%s:%d/%s?search_path=%s",
+		return fmt.Sprintf("%s://%s@%s/%s?%s",
 			prefix,
 			url.QueryEscape(config.Database.User),
-			config.Database.Host,
-			config.Database.Port,
+			hostList(),
 			config.Database.Name,
-			schema)
+			params.Encode())
 	}
 }
 
@@ -52,6 +73,12 @@ func Initialize(ctx context.Context, schema string) error {
 		schema = "public"
 	}
 
+	// column encryption keys must be available before any data is read
+	err = encryption.Initialize(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to initialize column encryption: %w", err)
+	}
+
 	// register and setup logging configuration
 	connStr := getConnString("postgres", schema)
 	poolConfig, err := pgxpool.ParseConfig(connStr)
@@ -63,6 +90,18 @@ func Initialize(ctx context.Context, schema string) error {
 	poolConfig.MinConns = config.Database.MinConn
 	poolConfig.MaxConnLifetime = config.Database.MaxLifetime
 	poolConfig.MaxConnIdleTime = config.Database.MaxIdleTime
+	if config.Database.HealthCheck > 0 {
+		poolConfig.HealthCheckPeriod = config.Database.HealthCheck
+	}
+
+	if config.Database.PgBouncer {
+		// transaction pooling can switch server connections between statements, prepared
+		// statements created on one connection are not available on another
+		poolConfig.ConnConfig.DefaultQueryExecMode = pgx.QueryExecModeSimpleProtocol
+	} else if config.Database.StmtTimeout > 0 {
+		// PgBouncer rejects unknown startup parameters, configure the timeout on the role instead
+		poolConfig.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(config.Database.StmtTimeout.Milliseconds(), 10)
+	}
 
 	if config.Telemetry.Enabled {
 		poolConfig.ConnConfig.Tracer = otelpgx.NewTracer()
@@ -74,17 +113,7 @@ func Initialize(ctx context.Context, schema string) error {
 
 		if logLevel > 0 {
 			zeroLogger := pgxlog.NewLogger(log.Logger,
-				pgxlog.WithContextFunc(func(ctx context.Context, logWith zerolog.Context) zerolog.Context {
-					traceId := logging.TraceId(ctx)
-					if traceId != "" {
-						logWith = logWith.Str("trace_id", traceId)
-					}
-					accountId := identity.AccountIdOrNil(ctx)
-					if accountId != 0 {
-						logWith = logWith.Int64("account_id", accountId)
-					}
-					return logWith
-				}))
+				pgxlog.WithContextFunc(logging.ContextFields))
 			poolConfig.ConnConfig.Tracer = &tracelog.TraceLog{
 				Logger:   zeroLogger,
 				LogLevel: logLevel,
@@ -97,10 +126,17 @@ func Initialize(ctx context.Context, schema string) error {
 		return fmt.Errorf("unable to create connection pool: %w", err)
 	}
 
-	err = Pool.Ping(ctx)
+	// the pool connects lazily, wait until the database is available
+	err = pingWithRetry(ctx, config.Database.ConnRetry)
 	if err != nil {
+		Pool.Close()
 		return fmt.Errorf("unable to ping the database: %w", err)
 	}
+	healthy.Store(true)
+
+	monitorCtx, cancel := context.WithCancel(context.Background())
+	stopMonitor = cancel
+	go monitor(zerolog.Ctx(ctx).WithContext(monitorCtx), config.Database.HealthCheck)
 
 	// Register telemetry
 	labels := map[string]string{
@@ -114,7 +150,19 @@ func Initialize(ctx context.Context, schema string) error {
 	return nil
 }
 
+// WithTimeout returns a context with the default DAO operation timeout, an earlier deadline
+// of the parent context is kept.
+func WithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if config.Database.Timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, config.Database.Timeout)
+}
+
 func Close() {
 	log.Logger.Info().Msg("Closing all database connections")
+	if stopMonitor != nil {
+		stopMonitor()
+	}
 	Pool.Close()
 }
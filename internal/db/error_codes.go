@@ -11,6 +11,7 @@ type PostgresErrorCode string
 
 const (
 	UniqueConstraintErrorCode PostgresErrorCode = "23505"
+	QueryCanceledErrorCode    PostgresErrorCode = "57014"
 )
 
 func IsPostgresError(err error, code PostgresErrorCode) error {
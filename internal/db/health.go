@@ -0,0 +1,93 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	minRetryDelay = 250 * time.Millisecond
+	maxRetryDelay = 5 * time.Second
+	pingTimeout   = 5 * time.Second
+)
+
+var (
+	healthy     atomic.Bool
+	stopMonitor context.CancelFunc
+)
+
+// Healthy returns false when the last periodic database ping failed. The pool reconnects
+// automatically once the database is available again, this only reflects the current state
+// and it is used for readiness checks.
+func Healthy() bool {
+	return healthy.Load()
+}
+
+//nolint:wrapcheck
+func ping(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+
+	return Pool.Ping(ctx)
+}
+
+// pingWithRetry pings the database with exponential backoff until it succeeds or the maximum
+// duration elapses. The last error is returned.
+func pingWithRetry(ctx context.Context, maxDuration time.Duration) error {
+	logger := zerolog.Ctx(ctx)
+	deadline := time.Now().Add(maxDuration)
+	delay := minRetryDelay
+
+	for {
+		err := ping(ctx)
+		if err == nil {
+			return nil
+		}
+		if time.Now().Add(delay).After(deadline) {
+			return err
+		}
+
+		logger.Warn().Err(err).Msgf("Database is not available, retrying in %s", delay)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("database retry cancelled: %w", ctx.Err())
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > maxRetryDelay {
+			delay = maxRetryDelay
+		}
+	}
+}
+
+// monitor pings the database periodically and updates the health flag.
+func monitor(ctx context.Context, interval time.Duration) {
+	logger := zerolog.Ctx(ctx)
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			err := ping(ctx)
+			if err != nil && ctx.Err() == nil {
+				if healthy.Swap(false) {
+					logger.Error().Err(err).Msg("Database connection lost, service is degraded")
+				}
+			} else if err == nil && !healthy.Swap(true) {
+				logger.Info().Msg("Database connection restored")
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
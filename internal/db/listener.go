@@ -0,0 +1,172 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog/log"
+)
+
+// listenerReconnectDelay is how long to wait before a failed listener connection is reestablished
+const listenerReconnectDelay = time.Second
+
+// ReservationStatusChannel receives reservation IDs when reservation status changes
+const ReservationStatusChannel = "reservation_status"
+
+// listener holds a single dedicated connection for a notification channel and dispatches
+// incoming notifications to subscribers waiting for a particular payload.
+type listener struct {
+	channel string
+	mu      sync.Mutex
+	waiters map[string]map[chan struct{}]struct{}
+}
+
+var (
+	listenersMu sync.Mutex
+	listeners   = make(map[string]*listener)
+)
+
+// Subscription receives notifications with a payload, see Subscribe.
+type Subscription struct {
+	l       *listener
+	payload string
+	ch      chan struct{}
+}
+
+// StartListener connects the listener of the channel, so notifications are not missed by the first
+// subscribers while the connection is being established. Call it during process start.
+func StartListener(channel string) {
+	getListener(channel)
+}
+
+// Subscribe registers for notifications with the given payload on the channel. Notifications sent
+// after Subscribe returns are delivered, so state read after subscribing can be safely waited for.
+// Notifications sent while the connection reconnects are lost, all subscriptions are woken up once
+// the channel is listened to again, so subscribers must read the state again after Wait returns.
+// Connections used for listening are not taken from the pool, there is one connection per channel
+// shared by all subscriptions. It is started on the first call unless StartListener was called.
+// The subscription must be closed.
+func Subscribe(channel, payload string) *Subscription {
+	l := getListener(channel)
+	return &Subscription{
+		l:       l,
+		payload: payload,
+		ch:      l.subscribe(payload),
+	}
+}
+
+// Wait blocks until a notification is received or the context is done.
+func (s *Subscription) Wait(ctx context.Context) error {
+	select {
+	case <-s.ch:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("wait for notification: %w", ctx.Err())
+	}
+}
+
+// Close unregisters the subscription.
+func (s *Subscription) Close() {
+	s.l.unsubscribe(s.payload, s.ch)
+}
+
+func getListener(channel string) *listener {
+	listenersMu.Lock()
+	defer listenersMu.Unlock()
+
+	if l, ok := listeners[channel]; ok {
+		return l
+	}
+
+	l := &listener{
+		channel: channel,
+		waiters: make(map[string]map[chan struct{}]struct{}),
+	}
+	listeners[channel] = l
+	go l.loop()
+	return l
+}
+
+func (l *listener) subscribe(payload string) chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	ch := make(chan struct{}, 1)
+	if _, ok := l.waiters[payload]; !ok {
+		l.waiters[payload] = make(map[chan struct{}]struct{})
+	}
+	l.waiters[payload][ch] = struct{}{}
+	return ch
+}
+
+func (l *listener) unsubscribe(payload string, ch chan struct{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.waiters[payload], ch)
+	if len(l.waiters[payload]) == 0 {
+		delete(l.waiters, payload)
+	}
+}
+
+func (l *listener) notify(payload string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for ch := range l.waiters[payload] {
+		wake(ch)
+	}
+}
+
+// notifyAll wakes up all subscriptions, notifications could have been missed while not listening.
+func (l *listener) notifyAll() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, waiters := range l.waiters {
+		for ch := range waiters {
+			wake(ch)
+		}
+	}
+}
+
+func wake(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+		// already notified
+	}
+}
+
+func (l *listener) loop() {
+	for {
+		err := l.listen(context.Background())
+		log.Logger.Warn().Err(err).Msgf("Database listener for channel '%s' failed, reconnecting", l.channel)
+		time.Sleep(listenerReconnectDelay)
+	}
+}
+
+func (l *listener) listen(ctx context.Context) error {
+	conn, err := pgx.ConnectConfig(ctx, Pool.Config().ConnConfig.Copy())
+	if err != nil {
+		return fmt.Errorf("unable to connect listener: %w", err)
+	}
+	defer conn.Close(ctx)
+
+	_, err = conn.Exec(ctx, "LISTEN "+pgx.Identifier{l.channel}.Sanitize())
+	if err != nil {
+		return fmt.Errorf("unable to listen: %w", err)
+	}
+	l.notifyAll()
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to wait for notification: %w", err)
+		}
+		l.notify(notification.Payload)
+	}
+}
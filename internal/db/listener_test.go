@@ -0,0 +1,28 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListenerNotifyAll(t *testing.T) {
+	l := &listener{channel: "test", waiters: make(map[string]map[chan struct{}]struct{})}
+	first := &Subscription{l: l, payload: "1", ch: l.subscribe("1")}
+	defer first.Close()
+	second := &Subscription{l: l, payload: "2", ch: l.subscribe("2")}
+	defer second.Close()
+
+	l.notify("1")
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	require.NoError(t, first.Wait(ctx))
+	require.Error(t, second.Wait(ctx), "other payloads are not notified")
+
+	// reconnect wakes up all subscriptions
+	l.notifyAll()
+	require.NoError(t, first.Wait(context.Background()))
+	require.NoError(t, second.Wait(context.Background()))
+}
@@ -0,0 +1,188 @@
+// Package encryption encrypts sensitive database columns at rest. Values are sealed with
+// AES-256-GCM data keys provided in configuration, optionally wrapped by AWS KMS (envelope
+// encryption). The column name is authenticated together with the value, so ciphertext cannot be
+// moved into a different column.
+//
+// Encrypted values are stored as "enc:v1:<key id>:<base64 nonce and ciphertext>", values without
+// the prefix are plain text and are returned as-is. This allows to enable encryption on an existing
+// database and to rotate keys: the first configured key encrypts new values and all keys decrypt,
+// existing rows are re-encrypted with "pbackend reencrypt".
+package encryption
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+)
+
+// prefix of encrypted values, the version allows to change the format in the future
+const prefix = "enc:v1:"
+
+// keySize is the data key size, 32 bytes selects AES-256
+const keySize = 32
+
+var (
+	ErrInvalidKey  = errors.New("invalid column encryption key")
+	ErrKeyNotFound = errors.New("column encryption key not found")
+	ErrInvalidData = errors.New("invalid encrypted column value")
+)
+
+// unwrapFunc decrypts a wrapped data key
+type unwrapFunc func(ctx context.Context, wrapped []byte) ([]byte, error)
+
+type keyring struct {
+	active string
+	keys   map[string]cipher.AEAD
+}
+
+var (
+	ring   = &keyring{}
+	ringMu sync.RWMutex
+)
+
+// Initialize loads data keys from the configuration, KMS wrapped keys are decrypted first. Values
+// are stored in plain text when no keys are configured.
+func Initialize(ctx context.Context) error {
+	var unwrap unwrapFunc
+	if config.Database.Encryption.KMS {
+		unwrap = kmsUnwrap
+	}
+
+	kr, err := newKeyring(ctx, config.Database.Encryption.Keys, unwrap)
+	if err != nil {
+		return err
+	}
+
+	ringMu.Lock()
+	defer ringMu.Unlock()
+	ring = kr
+	return nil
+}
+
+func newKeyring(ctx context.Context, values []string, unwrap unwrapFunc) (*keyring, error) {
+	kr := &keyring{keys: make(map[string]cipher.AEAD, len(values))}
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		id, encoded, found := strings.Cut(value, ":")
+		if !found || id == "" {
+			return nil, fmt.Errorf("%w: expected id:base64 format", ErrInvalidKey)
+		}
+		if _, ok := kr.keys[id]; ok {
+			return nil, fmt.Errorf("%w: duplicate id %s", ErrInvalidKey, id)
+		}
+		data, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("%w: key %s is not base64: %s", ErrInvalidKey, id, err.Error())
+		}
+		if unwrap != nil {
+			data, err = unwrap(ctx, data)
+			if err != nil {
+				return nil, fmt.Errorf("unable to unwrap column encryption key %s: %w", id, err)
+			}
+		}
+		if len(data) != keySize {
+			return nil, fmt.Errorf("%w: key %s must be %d bytes long", ErrInvalidKey, id, keySize)
+		}
+
+		block, err := aes.NewCipher(data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidKey, err.Error())
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidKey, err.Error())
+		}
+
+		if kr.active == "" {
+			kr.active = id
+		}
+		kr.keys[id] = aead
+	}
+	return kr, nil
+}
+
+func current() *keyring {
+	ringMu.RLock()
+	defer ringMu.RUnlock()
+	return ring
+}
+
+// Enabled returns true when a data key is configured and new values are encrypted.
+func Enabled() bool {
+	return current().active != ""
+}
+
+// IsEncrypted returns true when the value was encrypted by Encrypt.
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+// Encrypt seals the value of the column with the active data key. The value is returned as-is
+// when encryption is not enabled.
+func Encrypt(column, value string) (string, error) {
+	kr := current()
+	if kr.active == "" {
+		return value, nil
+	}
+	aead := kr.keys[kr.active]
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(value)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("unable to generate nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(value), []byte(column))
+
+	return prefix + kr.active + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt opens the encrypted value of the column with the data key it was encrypted with. Plain
+// text values are returned as-is.
+func Decrypt(column, value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	id, encoded, found := strings.Cut(strings.TrimPrefix(value, prefix), ":")
+	if !found {
+		return "", fmt.Errorf("%w: missing key id", ErrInvalidData)
+	}
+	aead, ok := current().keys[id]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrKeyNotFound, id)
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidData, err.Error())
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("%w: too short", ErrInvalidData)
+	}
+	plain, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(column))
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidData, err.Error())
+	}
+	return string(plain), nil
+}
+
+// NeedsReencryption returns true when the stored value is not encrypted with the active data key.
+// Nothing needs re-encryption when encryption is not enabled.
+func NeedsReencryption(value string) bool {
+	active := current().active
+	if active == "" {
+		return false
+	}
+	return !strings.HasPrefix(value, prefix+active+":")
+}
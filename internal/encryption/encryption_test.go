@@ -0,0 +1,116 @@
+package encryption
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testKey(id string, b byte) string {
+	return id + ":" + base64.StdEncoding.EncodeToString([]byte(strings.Repeat(string(b), keySize)))
+}
+
+func useKeys(t *testing.T, values ...string) {
+	t.Helper()
+	kr, err := newKeyring(context.Background(), values, nil)
+	require.NoError(t, err)
+
+	previous := current()
+	ringMu.Lock()
+	ring = kr
+	ringMu.Unlock()
+	t.Cleanup(func() {
+		ringMu.Lock()
+		ring = previous
+		ringMu.Unlock()
+	})
+}
+
+func TestEncrypt(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		useKeys(t)
+		value, err := Encrypt("pubkeys.body", "ssh-ed25519 AAAA")
+		require.NoError(t, err)
+		assert.Equal(t, "ssh-ed25519 AAAA", value)
+		assert.False(t, NeedsReencryption(value))
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		useKeys(t, testKey("k1", 'a'))
+		value, err := Encrypt("pubkeys.body", "ssh-ed25519 AAAA")
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(value, "enc:v1:k1:"))
+		assert.NotContains(t, value, "AAAA")
+		assert.False(t, NeedsReencryption(value))
+
+		plain, err := Decrypt("pubkeys.body", value)
+		require.NoError(t, err)
+		assert.Equal(t, "ssh-ed25519 AAAA", plain)
+	})
+
+	t.Run("plain text", func(t *testing.T) {
+		useKeys(t, testKey("k1", 'a'))
+		plain, err := Decrypt("pubkeys.body", "ssh-ed25519 AAAA")
+		require.NoError(t, err)
+		assert.Equal(t, "ssh-ed25519 AAAA", plain)
+		assert.True(t, NeedsReencryption(plain))
+	})
+
+	t.Run("different column", func(t *testing.T) {
+		useKeys(t, testKey("k1", 'a'))
+		value, err := Encrypt("pubkeys.body", "ssh-ed25519 AAAA")
+		require.NoError(t, err)
+		_, err = Decrypt("pubkeys.name", value)
+		require.ErrorIs(t, err, ErrInvalidData)
+	})
+
+	t.Run("rotation", func(t *testing.T) {
+		useKeys(t, testKey("k1", 'a'))
+		old, err := Encrypt("pubkeys.body", "ssh-ed25519 AAAA")
+		require.NoError(t, err)
+
+		useKeys(t, testKey("k2", 'b'), testKey("k1", 'a'))
+		assert.True(t, NeedsReencryption(old))
+		plain, err := Decrypt("pubkeys.body", old)
+		require.NoError(t, err)
+		assert.Equal(t, "ssh-ed25519 AAAA", plain)
+
+		value, err := Encrypt("pubkeys.body", plain)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(value, "enc:v1:k2:"))
+
+		useKeys(t, testKey("k2", 'b'))
+		_, err = Decrypt("pubkeys.body", old)
+		require.ErrorIs(t, err, ErrKeyNotFound)
+	})
+}
+
+func TestNewKeyring(t *testing.T) {
+	t.Run("invalid format", func(t *testing.T) {
+		_, err := newKeyring(context.Background(), []string{"abc"}, nil)
+		require.ErrorIs(t, err, ErrInvalidKey)
+	})
+
+	t.Run("short key", func(t *testing.T) {
+		_, err := newKeyring(context.Background(), []string{"k1:" + base64.StdEncoding.EncodeToString([]byte("short"))}, nil)
+		require.ErrorIs(t, err, ErrInvalidKey)
+	})
+
+	t.Run("duplicate id", func(t *testing.T) {
+		_, err := newKeyring(context.Background(), []string{testKey("k1", 'a'), testKey("k1", 'b')}, nil)
+		require.ErrorIs(t, err, ErrInvalidKey)
+	})
+
+	t.Run("unwrap", func(t *testing.T) {
+		unwrap := func(_ context.Context, wrapped []byte) ([]byte, error) {
+			return []byte(strings.Repeat("x", keySize)), nil
+		}
+		kr, err := newKeyring(context.Background(), []string{"k1:" + base64.StdEncoding.EncodeToString([]byte("blob"))}, unwrap)
+		require.NoError(t, err)
+		assert.Equal(t, "k1", kr.active)
+	})
+}
@@ -0,0 +1,31 @@
+package encryption
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+)
+
+// kmsUnwrap decrypts a data key generated by KMS GenerateDataKey (CiphertextBlob) with the AWS
+// service account, the KMS key is identified by the blob.
+func kmsUnwrap(ctx context.Context, wrapped []byte) ([]byte, error) {
+	region := config.Database.Encryption.KMSRegion
+	if region == "" {
+		region = config.AWS.DefaultRegion
+	}
+
+	client := kms.New(kms.Options{
+		Region: region,
+		Credentials: aws.NewCredentialsCache(credentials.NewStaticCredentialsProvider(
+			config.AWS.Key, config.AWS.Secret, config.AWS.Session)),
+	})
+	resp, err := client.Decrypt(ctx, &kms.DecryptInput{CiphertextBlob: wrapped})
+	if err != nil {
+		return nil, fmt.Errorf("kms decrypt: %w", err)
+	}
+	return resp.Plaintext, nil
+}
@@ -9,6 +9,7 @@ type cxtKeyId int
 
 const (
 	accountIdCtxKey cxtKeyId = iota
+	workspacesCtxKey
 )
 
 var MissingAccountInContextError = errors.New("operation requires account_id in context")
@@ -16,6 +16,12 @@ func Identity(ctx context.Context) Principal {
 	return identity.Get(ctx)
 }
 
+// IdentityOrNil returns identity header struct or an empty struct when not set.
+func IdentityOrNil(ctx context.Context) Principal {
+	id, _ := ctx.Value(identity.Key).(Principal)
+	return id
+}
+
 // IdentityHeader returns identity header (base64-encoded JSON)
 func IdentityHeader(ctx context.Context) string {
 	return identity.GetIdentityHeader(ctx)
@@ -0,0 +1,55 @@
+package identity
+
+import (
+	"time"
+
+	"github.com/redhatinsights/platform-go-middlewares/identity"
+)
+
+// ServiceCommonName is the system common name of service identities minted by this application.
+const ServiceCommonName = "provisioning-backend"
+
+// Expired returns true for identities which were minted by the gateway from a short-lived token
+// (jwt-auth) which was authenticated longer than lifetime ago. Certificate or basic auth identities
+// and identities without authentication time are never considered expired.
+func Expired(id Principal, lifetime time.Duration) bool {
+	if lifetime <= 0 || id.Identity.AuthType != "jwt-auth" || id.Identity.Internal.AuthTime == 0 {
+		return false
+	}
+
+	authTime := time.Unix(int64(id.Identity.Internal.AuthTime), 0)
+	return time.Since(authTime) > lifetime
+}
+
+// ServicePrincipal returns a service-level identity of the tenant of the given identity. It does
+// not carry any user information and must be only used for operations not performed on behalf
+// of the user.
+func ServicePrincipal(id Principal) Principal {
+	return Principal{
+		Identity: identity.Identity{
+			AccountNumber: id.Identity.AccountNumber,
+			OrgID:         id.Identity.OrgID,
+			Internal: identity.Internal{
+				OrgID: id.Identity.OrgID,
+			},
+			System: identity.System{
+				CommonName: ServiceCommonName,
+			},
+			Type:     "System",
+			AuthType: "cert-auth",
+		},
+	}
+}
+
+// OnBehalfPrincipal returns a service-level identity of the tenant of the given identity which
+// acts on behalf of the user with the given ID and username, so created resources are owned by
+// the user. It is not authenticated by the gateway, permissions of the user must be checked with
+// RBAC before it is used.
+func OnBehalfPrincipal(id Principal, userID, username string) Principal {
+	principal := ServicePrincipal(id)
+	principal.Identity.User = identity.User{
+		UserID:   userID,
+		Username: username,
+	}
+	return principal
+}
@@ -0,0 +1,30 @@
+package identity
+
+import (
+	"context"
+)
+
+// Workspaces returns workspace IDs the current operation is restricted to, or nil when the
+// operation is not restricted to any workspace.
+func Workspaces(ctx context.Context) []string {
+	value := ctx.Value(workspacesCtxKey)
+	if value == nil {
+		return nil
+	}
+	return value.([]string)
+}
+
+// DefaultWorkspace returns the workspace new resources are associated with, it is the first
+// workspace the operation is restricted to or an empty string when not restricted.
+func DefaultWorkspace(ctx context.Context) string {
+	workspaces := Workspaces(ctx)
+	if len(workspaces) == 0 {
+		return ""
+	}
+	return workspaces[0]
+}
+
+// WithWorkspaces returns context copy with workspace IDs, nil means no restriction.
+func WithWorkspaces(ctx context.Context, workspaces []string) context.Context {
+	return context.WithValue(ctx, workspacesCtxKey, workspaces)
+}
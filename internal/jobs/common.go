@@ -6,9 +6,13 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/RHEnVision/provisioning-backend/internal/cache"
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
 	"github.com/RHEnVision/provisioning-backend/internal/dao"
 	"github.com/RHEnVision/provisioning-backend/internal/metrics"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
 	"github.com/RHEnVision/provisioning-backend/internal/telemetry"
+	"github.com/RHEnVision/provisioning-backend/pkg/worker"
 	"github.com/rs/zerolog"
 )
 
@@ -50,6 +54,7 @@ func finishWithSuccess(ctx context.Context, reservationId int64) {
 		if err != nil {
 			logger.Warn().Err(err).Msg("unable to update job status: finish")
 		}
+		RecordEvent(ctx, reservationId, models.EventFinished, "All steps finished", nil)
 	}
 }
 
@@ -77,6 +82,20 @@ func finishWithError(ctx context.Context, reservationId int64, jobError error) {
 	if err != nil {
 		logger.Warn().Err(err).Msg("unable to update job status: finish")
 	}
+	RecordEvent(ctx, reservationId, models.EventError, jobError.Error(), errorDetails(jobError))
+}
+
+// errorDetails returns details of the error event of a failed job which help to resolve the
+// failure, nil when there are none.
+func errorDetails(jobError error) map[string]string {
+	var subscriptionErr *clients.MarketplaceSubscriptionError
+	if errors.As(jobError, &subscriptionErr) {
+		return map[string]string{
+			"product_code":    subscriptionErr.ProductCode,
+			"marketplace_url": subscriptionErr.URL,
+		}
+	}
+	return nil
 }
 
 // updateStatusBefore is called after every step function within a job. It updates reservation status
@@ -106,6 +125,12 @@ func updateStatusAfter(ctx context.Context, id int64, status string, addSteps in
 	if err != nil {
 		logger.Warn().Err(err).Msg("unable to update step number: update")
 	}
+
+	kind := models.EventStepStarted
+	if addSteps != 0 {
+		kind = models.EventStepFinished
+	}
+	RecordEvent(ctx, id, kind, status, nil)
 }
 
 func nilUnlessTimeout(ctx context.Context) error {
@@ -157,3 +182,34 @@ func waitAndRetry(ctx context.Context, f func() error, intervalsMs ...int) error
 
 	return err
 }
+
+// MarkWaiting updates status of a launch reservation which job is waiting for a free launch slot
+// of the organization.
+func MarkWaiting(ctx context.Context, job *worker.Job) {
+	var reservationId int64
+	switch args := job.Args.(type) {
+	case LaunchInstanceAWSTaskArgs:
+		reservationId = args.ReservationID
+	case LaunchInstanceAzureTaskArgs:
+		reservationId = args.ReservationID
+	case LaunchInstanceGCPTaskArgs:
+		reservationId = args.ReservationID
+	default:
+		return
+	}
+
+	updateStatusBefore(ctx, reservationId, "Waiting for a free launch slot")
+}
+
+// recordCapacityFailure remembers a launch failed on insufficient capacity, capacity probes of
+// following reservations warn about the instance type and zone.
+func recordCapacityFailure(ctx context.Context, launchErr error, provider models.ProviderType, region, zone, instanceType string) {
+	if !errors.Is(launchErr, clients.InsufficientCapacityErr) {
+		return
+	}
+
+	err := cache.RecordInsufficientCapacity(ctx, provider, region, zone, instanceType)
+	if err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Msg("Unable to record insufficient capacity")
+	}
+}
@@ -2,9 +2,13 @@ package jobs
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -52,3 +56,12 @@ func TestWaitAndRetryErrSecond(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 2, calls)
 }
+
+func TestErrorDetails(t *testing.T) {
+	err := fmt.Errorf("cannot run instances: %w", clients.NewMarketplaceSubscriptionError("cpkwgozd4by0cmoinfftz1qqr", errors.New("OptInRequired")))
+	assert.Equal(t, map[string]string{
+		"product_code":    "cpkwgozd4by0cmoinfftz1qqr",
+		"marketplace_url": "https://aws.amazon.com/marketplace/pp?sku=cpkwgozd4by0cmoinfftz1qqr",
+	}, errorDetails(err))
+	assert.Nil(t, errorDetails(clients.InsufficientCapacityErr))
+}
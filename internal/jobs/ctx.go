@@ -16,5 +16,7 @@ func copyContext(ctx context.Context) context.Context {
 	nCtx = logging.WithTraceId(nCtx, logging.TraceId(ctx))
 	nCtx = logging.WithEdgeRequestId(nCtx, logging.EdgeRequestId(ctx))
 	nCtx = identity.WithAccountId(nCtx, identity.AccountId(ctx))
+	nCtx = logging.WithReservationId(nCtx, logging.ReservationId(ctx))
+	nCtx = logging.WithJobId(nCtx, logging.JobId(ctx))
 	return nCtx
 }
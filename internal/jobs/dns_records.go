@@ -0,0 +1,173 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/naming"
+	"github.com/RHEnVision/provisioning-backend/internal/ptr"
+	"github.com/rs/zerolog"
+)
+
+// CreateDNSRecordsStep is the title of the optional step of launch jobs creating DNS records.
+const CreateDNSRecordsStep = "Create DNS record(s)"
+
+// Job logic, when error is returned the job status is updated accordingly
+func DoCreateDNSRecordsAWS(ctx context.Context, args *LaunchInstanceAWSTaskArgs) error {
+	if args.Detail.DNSZone == "" {
+		return nil
+	}
+
+	ec2Client, err := clients.GetEC2Client(ctx, args.ARN, args.Region)
+	if err != nil {
+		return fmt.Errorf("cannot create new ec2 client from config: %w", err)
+	}
+
+	return createDNSRecords(ctx, ec2Client, args.ReservationID, args.Detail.DNSZone, ptr.FromOrEmpty(args.Detail.Name))
+}
+
+// Job logic, when error is returned the job status is updated accordingly
+func DoCreateDNSRecordsAzure(ctx context.Context, args *LaunchInstanceAzureTaskArgs) error {
+	reservation, err := dao.GetReservationDao(ctx).GetAzureById(ctx, args.ReservationID)
+	if err != nil {
+		return fmt.Errorf("cannot get azure reservation by id: %w", err)
+	}
+	if reservation.Detail.DNSZone == "" {
+		return nil
+	}
+
+	azureClient, err := clients.GetAzureClient(ctx, args.Subscription)
+	if err != nil {
+		return fmt.Errorf("cannot create new azure client: %w", err)
+	}
+
+	return createDNSRecords(ctx, azureClient, args.ReservationID, reservation.Detail.DNSZone, reservation.Detail.Name)
+}
+
+// Job logic, when error is returned the job status is updated accordingly
+func DoCreateDNSRecordsGCP(ctx context.Context, args *LaunchInstanceGCPTaskArgs) error {
+	if args.Detail.DNSZone == "" {
+		return nil
+	}
+
+	gcpClient, err := clients.GetGCPClient(ctx, args.ProjectID)
+	if err != nil {
+		return fmt.Errorf("cannot create new GCP client: %w", err)
+	}
+
+	return createDNSRecords(ctx, gcpClient, args.ReservationID, args.Detail.DNSZone, ptr.FromOrEmpty(args.Detail.NamePattern))
+}
+
+// createDNSRecords creates an A record in the zone for every instance of the reservation and
+// stores its name in the instance detail. Records created before an error are deleted again,
+// so a failed step does not leave records behind.
+func createDNSRecords(ctx context.Context, recorder clients.DNSRecorder, reservationID int64, zone, name string) error {
+	logger := zerolog.Ctx(ctx)
+	logger.Debug().Msg("Started create DNS records job")
+
+	// status updates before and after the code logic
+	updateStatusBefore(ctx, reservationID, "Creating DNS record(s)")
+	defer updateStatusAfter(ctx, reservationID, "Created DNS record(s)", 1)
+
+	rDao := dao.GetReservationDao(ctx)
+	instances, err := rDao.ListInstances(ctx, reservationID)
+	if err != nil {
+		return fmt.Errorf("cannot list reservation instances: %w", err)
+	}
+
+	created := make([]*models.DNSRecord, 0, len(instances))
+	for i, instance := range instances {
+		address := instanceAddress(instance)
+		if address == "" {
+			logger.Warn().Str("instance_id", instance.InstanceID).Msg("Instance has no IPv4 address, skipping DNS record")
+			continue
+		}
+
+		label := naming.DNSLabel(config.Reservation.DNSPattern, name, reservationID, i+1)
+		record, err := recorder.CreateDNSRecord(ctx, zone, label, address, config.Reservation.DNSTTL)
+		if err != nil {
+			deleteDNSRecords(ctx, recorder, created)
+			return fmt.Errorf("cannot create DNS record for instance %s: %w", instance.InstanceID, err)
+		}
+		created = append(created, record)
+
+		err = rDao.UpdateInstanceDNSRecord(ctx, reservationID, instance.InstanceID, record)
+		if err != nil {
+			deleteDNSRecords(ctx, recorder, created)
+			return fmt.Errorf("cannot store DNS name of instance %s: %w", instance.InstanceID, err)
+		}
+		logger.Info().Str("instance_id", instance.InstanceID).Msgf("Created DNS record %s", record.Name)
+	}
+
+	return nilUnlessTimeout(ctx)
+}
+
+// instanceAddress returns the public IPv4 address of the instance, or the private address of
+// the primary network interface for instances without a public address.
+func instanceAddress(instance *models.ReservationInstance) string {
+	if instance.Detail.PublicIPv4 != "" {
+		return instance.Detail.PublicIPv4
+	}
+	if len(instance.Detail.NetworkInterfaces) > 0 {
+		return instance.Detail.NetworkInterfaces[0].PrivateIPv4
+	}
+	return ""
+}
+
+func deleteDNSRecords(ctx context.Context, recorder clients.DNSRecorder, records []*models.DNSRecord) {
+	logger := zerolog.Ctx(ctx)
+	for _, record := range records {
+		err := recorder.DeleteDNSRecord(ctx, record)
+		if err != nil {
+			logger.Warn().Err(err).Msgf("Unable to delete DNS record %s", record.Name)
+		}
+	}
+}
+
+// DeleteInstanceDNSRecord deletes the DNS record of a terminated instance, already deleted records
+// are kept. Records which are not found are considered deleted. The deleted record is stored with
+// the instance.
+func DeleteInstanceDNSRecord(ctx context.Context, instance *models.Instance, auth *clients.Authentication) error {
+	if !instance.DNSRecord.Deletable() {
+		return nil
+	}
+
+	var recorder clients.DNSRecorder
+	var err error
+	switch instance.Provider {
+	case models.ProviderTypeAWS:
+		recorder, err = clients.GetEC2Client(ctx, auth, instance.Location)
+	case models.ProviderTypeAzure:
+		recorder, err = clients.GetAzureClient(ctx, auth)
+	case models.ProviderTypeGCP:
+		recorder, err = clients.GetGCPClient(ctx, auth)
+	case models.ProviderTypeNoop, models.ProviderTypeUnknown:
+		return clients.UnknownProviderErr
+	}
+	if err != nil {
+		return fmt.Errorf("cannot create new client: %w", err)
+	}
+
+	record := instance.DNSRecord
+	if record.TTL == 0 {
+		record.TTL = config.Reservation.DNSTTL
+	}
+	err = recorder.DeleteDNSRecord(ctx, &record)
+	recordProviderCall(ctx, instance.ReservationID, "DeleteDNSRecord", map[string]string{"name": record.Name}, err)
+	if err != nil && !errors.Is(err, clients.NotFoundErr) && !errors.Is(err, clients.DNSZoneNotFoundErr) {
+		return fmt.Errorf("cannot delete DNS record: %w", err)
+	}
+
+	record.Deleted = true
+	err = dao.GetReservationDao(ctx).UpdateInstanceDNSRecord(ctx, instance.ReservationID, instance.InstanceID, &record)
+	if err != nil {
+		return fmt.Errorf("cannot store deleted DNS record: %w", err)
+	}
+	instance.DNSRecord = record
+	return nil
+}
@@ -0,0 +1,67 @@
+package jobs_test
+
+import (
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	clientStubs "github.com/RHEnVision/provisioning-backend/internal/clients/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	daoStubs "github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/jobs"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/ptr"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/factories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoCreateDNSRecordsAWS(t *testing.T) {
+	config.Reservation.DNSPattern = "{name}-{index}"
+	ctx := prepareEC2Context(t)
+	pk := factories.NewPubkeyRSA()
+	err := daoStubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to add stubbed key")
+	reservation := prepareAWSReservation(t, ctx, pk)
+	reservation.Detail.Name = ptr.To("web")
+	err = daoStubs.AddAWSReservation(ctx, reservation)
+	require.NoError(t, err, "failed to add stubbed reservation")
+
+	resDao := dao.GetReservationDao(ctx)
+	public := &models.ReservationInstance{
+		ReservationID: reservation.ID,
+		InstanceID:    "i-0a4caa2cf5b097ce1",
+		Detail:        models.ReservationInstanceDetail{PublicIPv4: "203.0.113.10"},
+	}
+	private := &models.ReservationInstance{ReservationID: reservation.ID, InstanceID: "i-0a4caa2cf5b097ce2"}
+	for _, instance := range []*models.ReservationInstance{public, private} {
+		err = resDao.CreateInstance(ctx, instance)
+		require.NoError(t, err, "failed to add stubbed instance")
+	}
+
+	args := func(zone string) *jobs.LaunchInstanceAWSTaskArgs {
+		detail := *reservation.Detail
+		detail.DNSZone = zone
+		return &jobs.LaunchInstanceAWSTaskArgs{
+			ReservationID: reservation.ID,
+			Region:        "us-east-1",
+			Detail:        &detail,
+			ARN:           &clients.Authentication{ProviderType: models.ProviderTypeAWS, Payload: "arn:aws:123123123123"},
+		}
+	}
+
+	t.Run("missing zone", func(t *testing.T) {
+		err := jobs.DoCreateDNSRecordsAWS(ctx, args(clientStubs.MissingDNSZone))
+		require.ErrorIs(t, err, clients.DNSZoneNotFoundErr)
+		assert.Empty(t, public.Detail.DNSName)
+	})
+
+	t.Run("records for instances with an address", func(t *testing.T) {
+		err := jobs.DoCreateDNSRecordsAWS(ctx, args("Z0123456789ABCDEFGHIJ"))
+		require.NoError(t, err)
+		assert.Equal(t, "web-1.example.com", public.Detail.DNSName)
+		assert.Equal(t, "Z0123456789ABCDEFGHIJ", public.DNSRecord.Zone)
+		assert.Equal(t, "203.0.113.10", public.DNSRecord.IPv4)
+		assert.Empty(t, private.Detail.DNSName)
+	})
+}
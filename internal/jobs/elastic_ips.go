@@ -0,0 +1,199 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/rs/zerolog"
+)
+
+// AssociateElasticIPsStep is the title of the optional step of AWS launch jobs associating
+// Elastic IPs to instances.
+const AssociateElasticIPsStep = "Associate Elastic IP(s)"
+
+// Job logic, when error is returned the job status is updated accordingly
+func DoAssociateElasticIPsAWS(ctx context.Context, args *LaunchInstanceAWSTaskArgs) error {
+	if args.Detail.ElasticIP == nil {
+		return nil
+	}
+
+	logger := zerolog.Ctx(ctx)
+	logger.Debug().Msg("Started associate Elastic IPs job")
+
+	// status updates before and after the code logic
+	updateStatusBefore(ctx, args.ReservationID, "Associating Elastic IP(s)")
+	defer updateStatusAfter(ctx, args.ReservationID, "Associated Elastic IP(s)", 1)
+
+	rDao := dao.GetReservationDao(ctx)
+	instances, err := rDao.ListInstances(ctx, args.ReservationID)
+	if err != nil {
+		return fmt.Errorf("cannot list reservation instances: %w", err)
+	}
+
+	ec2Client, err := clients.GetEC2Client(ctx, args.ARN, args.Region)
+	if err != nil {
+		return fmt.Errorf("cannot create new ec2 client from config: %w", err)
+	}
+
+	var pool []*models.ElasticIP
+	if name := args.Detail.ElasticIP.Pool; name != "" {
+		pool, err = ec2Client.ListPoolElasticIPs(ctx, name)
+		recordProviderCall(ctx, args.ReservationID, "ListPoolElasticIPs", map[string]string{"pool": name}, err)
+		if err != nil {
+			return fmt.Errorf("cannot list elastic IP pool: %w", err)
+		}
+		logger.Debug().Msgf("Found %d unassociated addresses in pool %s", len(pool), name)
+	}
+
+	associated := make(map[string]*models.ElasticIP, len(instances))
+	ids := make([]string, len(instances))
+	for i, instance := range instances {
+		ids[i] = instance.InstanceID
+		var eip *models.ElasticIP
+		eip, pool, err = associateElasticIP(ctx, ec2Client, args.ReservationID, instance.InstanceID, pool)
+		if err == nil {
+			associated[instance.InstanceID] = eip
+			err = rDao.UpdateInstanceElasticIP(ctx, args.ReservationID, instance.InstanceID, eip)
+		}
+		if err != nil {
+			releaseElasticIPs(ctx, ec2Client, args.ReservationID, associated)
+			return fmt.Errorf("cannot associate elastic IP to instance %s: %w", instance.InstanceID, err)
+		}
+		logger.Info().Str("instance_id", instance.InstanceID).Bool("pooled", eip.Pooled).Msgf("Associated Elastic IP %s", eip.PublicIP)
+	}
+
+	// public addresses and DNS names of instances change with the association
+	descriptions, err := ec2Client.DescribeInstanceDetails(ctx, ids)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Unable to describe instances, public addresses may be outdated")
+	}
+	for _, description := range descriptions {
+		if eip, ok := associated[description.ID]; ok && description.PublicIPv4 == "" {
+			description.PublicIPv4 = eip.PublicIP
+		}
+		err = rDao.UpdateReservationInstance(ctx, args.ReservationID, description)
+		if err != nil {
+			return fmt.Errorf("cannot update instance description: %w", err)
+		}
+	}
+
+	return nilUnlessTimeout(ctx)
+}
+
+// associateElasticIP associates an address to the instance, pool addresses are tried first and
+// the next one is tried when an address was associated by someone else meanwhile. A new address is
+// allocated when the pool is exhausted and released again when the association fails. The rest of
+// the pool is returned.
+func associateElasticIP(ctx context.Context, ec2Client clients.EC2, reservationId int64, instanceId string, pool []*models.ElasticIP) (*models.ElasticIP, []*models.ElasticIP, error) {
+	for len(pool) > 0 {
+		eip := pool[0]
+		pool = pool[1:]
+
+		associationId, err := ec2Client.AssociateElasticIP(ctx, eip.AllocationID, instanceId)
+		recordProviderCall(ctx, reservationId, "AssociateElasticIP", map[string]string{
+			"allocation_id": eip.AllocationID,
+			"instance_id":   instanceId,
+		}, err)
+		if errors.Is(err, clients.ElasticIPInUseErr) {
+			zerolog.Ctx(ctx).Debug().Msgf("Pool address %s is already associated, trying the next one", eip.AllocationID)
+			continue
+		}
+		if err != nil {
+			return nil, pool, fmt.Errorf("cannot associate pool address: %w", err)
+		}
+		eip.AssociationID = associationId
+		eip.Pooled = true
+		return eip, pool, nil
+	}
+
+	eip, err := ec2Client.AllocateElasticIP(ctx, reservationId)
+	recordProviderCall(ctx, reservationId, "AllocateElasticIP", nil, err)
+	if err != nil {
+		return nil, pool, fmt.Errorf("cannot allocate address: %w", err)
+	}
+
+	eip.AssociationID, err = ec2Client.AssociateElasticIP(ctx, eip.AllocationID, instanceId)
+	recordProviderCall(ctx, reservationId, "AssociateElasticIP", map[string]string{
+		"allocation_id": eip.AllocationID,
+		"instance_id":   instanceId,
+	}, err)
+	if err != nil {
+		releaseErr := ec2Client.ReleaseElasticIP(ctx, eip.AllocationID)
+		recordProviderCall(ctx, reservationId, "ReleaseElasticIP", map[string]string{"allocation_id": eip.AllocationID}, releaseErr)
+		if releaseErr != nil {
+			zerolog.Ctx(ctx).Warn().Err(releaseErr).Msgf("Unable to release Elastic IP %s", eip.AllocationID)
+		}
+		return nil, pool, fmt.Errorf("cannot associate allocated address: %w", err)
+	}
+	return eip, pool, nil
+}
+
+// releaseElasticIPs disassociates addresses of a failed step by instance ID, allocated addresses
+// are released and pool addresses are returned to the pool. Errors are only logged as the step
+// already failed.
+func releaseElasticIPs(ctx context.Context, ec2Client clients.EC2, reservationId int64, associated map[string]*models.ElasticIP) {
+	rDao := dao.GetReservationDao(ctx)
+	for instanceId, eip := range associated {
+		err := releaseElasticIP(ctx, ec2Client, reservationId, eip)
+		if err != nil {
+			zerolog.Ctx(ctx).Warn().Err(err).Str("instance_id", instanceId).Msg("Unable to release Elastic IP")
+			continue
+		}
+		err = rDao.UpdateInstanceElasticIP(ctx, reservationId, instanceId, eip)
+		if err != nil {
+			zerolog.Ctx(ctx).Warn().Err(err).Str("instance_id", instanceId).Msg("Unable to store released Elastic IP")
+		}
+	}
+}
+
+// releaseElasticIP disassociates the address and releases it unless it is pooled, the address is
+// marked as released.
+func releaseElasticIP(ctx context.Context, ec2Client clients.EC2, reservationId int64, eip *models.ElasticIP) error {
+	if eip.AssociationID != "" {
+		err := ec2Client.DisassociateElasticIP(ctx, eip.AssociationID)
+		recordProviderCall(ctx, reservationId, "DisassociateElasticIP", map[string]string{"association_id": eip.AssociationID}, err)
+		// terminated instances have no associations
+		if err != nil && !errors.Is(err, clients.NotFoundErr) {
+			return fmt.Errorf("cannot disassociate elastic IP: %w", err)
+		}
+		eip.AssociationID = ""
+	}
+	if !eip.Pooled {
+		err := ec2Client.ReleaseElasticIP(ctx, eip.AllocationID)
+		recordProviderCall(ctx, reservationId, "ReleaseElasticIP", map[string]string{"allocation_id": eip.AllocationID}, err)
+		if err != nil {
+			return fmt.Errorf("cannot release elastic IP: %w", err)
+		}
+		eip.Released = true
+	}
+	return nil
+}
+
+// ReleaseInstanceElasticIP releases the address allocated for a terminated AWS instance, pooled
+// and already released addresses are kept. The released address is stored with the instance.
+func ReleaseInstanceElasticIP(ctx context.Context, instance *models.Instance, auth *clients.Authentication) error {
+	if !instance.ElasticIP.Releasable() {
+		return nil
+	}
+
+	ec2Client, err := clients.GetEC2Client(ctx, auth, instance.Location)
+	if err != nil {
+		return fmt.Errorf("cannot create new ec2 client from config: %w", err)
+	}
+
+	eip := instance.ElasticIP
+	err = releaseElasticIP(ctx, ec2Client, instance.ReservationID, &eip)
+	if err != nil {
+		return err
+	}
+	err = dao.GetReservationDao(ctx).UpdateInstanceElasticIP(ctx, instance.ReservationID, instance.InstanceID, &eip)
+	if err != nil {
+		return fmt.Errorf("cannot store released elastic IP: %w", err)
+	}
+	instance.ElasticIP = eip
+	return nil
+}
@@ -0,0 +1,109 @@
+package jobs_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	clientStubs "github.com/RHEnVision/provisioning-backend/internal/clients/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	daoStubs "github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/jobs"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/factories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func prepareElasticIPReservation(t *testing.T, ctx context.Context, instanceIds ...string) (*jobs.LaunchInstanceAWSTaskArgs, []*models.ReservationInstance) {
+	t.Helper()
+	pk := factories.NewPubkeyRSA()
+	err := daoStubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to add stubbed key")
+	reservation := prepareAWSReservation(t, ctx, pk)
+	reservation.Detail.ElasticIP = &models.AWSElasticIP{Pool: "web"}
+	err = daoStubs.AddAWSReservation(ctx, reservation)
+	require.NoError(t, err, "failed to add stubbed reservation")
+
+	instances := make([]*models.ReservationInstance, len(instanceIds))
+	for i, id := range instanceIds {
+		instances[i] = &models.ReservationInstance{ReservationID: reservation.ID, InstanceID: id}
+		err = dao.GetReservationDao(ctx).CreateInstance(ctx, instances[i])
+		require.NoError(t, err, "failed to add stubbed instance")
+	}
+
+	return &jobs.LaunchInstanceAWSTaskArgs{
+		ReservationID: reservation.ID,
+		Region:        "us-east-1",
+		Detail:        reservation.Detail,
+		ARN:           &clients.Authentication{ProviderType: models.ProviderTypeAWS, Payload: "arn:aws:123123123123"},
+	}, instances
+}
+
+func TestDoAssociateElasticIPsAWS(t *testing.T) {
+	t.Run("pool addresses first", func(t *testing.T) {
+		ctx := prepareEC2Context(t)
+		args, instances := prepareElasticIPReservation(t, ctx, "i-0a4caa2cf5b097ce1", "i-0a4caa2cf5b097ce2")
+		for _, id := range []string{clientStubs.InUseElasticIPAllocationID, "eipalloc-0pool0000000000001"} {
+			err := clientStubs.AddStubbedEC2ElasticIP(ctx, &models.ElasticIP{AllocationID: id, PublicIP: "198.51.100.1"})
+			require.NoError(t, err, "failed to add stubbed address")
+		}
+
+		err := jobs.DoAssociateElasticIPsAWS(ctx, args)
+		require.NoError(t, err)
+
+		assert.Equal(t, "eipalloc-0pool0000000000001", instances[0].ElasticIP.AllocationID)
+		assert.True(t, instances[0].ElasticIP.Pooled)
+		assert.NotEmpty(t, instances[0].ElasticIP.AssociationID)
+		assert.False(t, instances[1].ElasticIP.Pooled)
+		assert.True(t, instances[1].ElasticIP.Releasable())
+
+		allocated, released, err := clientStubs.StubbedEC2ElasticIPs(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{instances[1].ElasticIP.AllocationID}, allocated)
+		assert.Empty(t, released)
+	})
+
+	t.Run("cleanup on failure", func(t *testing.T) {
+		ctx := prepareEC2Context(t)
+		args, instances := prepareElasticIPReservation(t, ctx, "i-0a4caa2cf5b097ce1", clientStubs.FailingElasticIPInstanceID)
+
+		err := jobs.DoAssociateElasticIPsAWS(ctx, args)
+		require.ErrorIs(t, err, clients.NotFoundErr)
+
+		allocated, released, err := clientStubs.StubbedEC2ElasticIPs(ctx)
+		require.NoError(t, err)
+		assert.Len(t, allocated, 2)
+		assert.ElementsMatch(t, allocated, released)
+		assert.True(t, instances[0].ElasticIP.Released)
+		assert.Empty(t, instances[0].ElasticIP.AssociationID)
+		assert.True(t, instances[1].ElasticIP.Empty())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		ctx := prepareEC2Context(t)
+		args, instances := prepareElasticIPReservation(t, ctx, "i-0a4caa2cf5b097ce1")
+		args.Detail.ElasticIP = nil
+
+		err := jobs.DoAssociateElasticIPsAWS(ctx, args)
+		require.NoError(t, err)
+		assert.True(t, instances[0].ElasticIP.Empty())
+	})
+}
+
+func TestReleaseInstanceElasticIP(t *testing.T) {
+	ctx := prepareEC2Context(t)
+	args, instances := prepareElasticIPReservation(t, ctx, "i-0a4caa2cf5b097ce1")
+	err := jobs.DoAssociateElasticIPsAWS(ctx, args)
+	require.NoError(t, err)
+
+	instance := &models.Instance{ReservationInstance: *instances[0], Provider: models.ProviderTypeAWS, Location: "us-east-1"}
+	err = jobs.ReleaseInstanceElasticIP(ctx, instance, args.ARN)
+	require.NoError(t, err)
+	assert.True(t, instance.ElasticIP.Released)
+	assert.True(t, instances[0].ElasticIP.Released)
+
+	_, released, err := clientStubs.StubbedEC2ElasticIPs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{instance.ElasticIP.AllocationID}, released)
+}
@@ -0,0 +1,119 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/rs/zerolog"
+)
+
+// LifecycleEventType is the type of reservation lifecycle events published to event targets.
+type LifecycleEventType string
+
+const (
+	LifecycleLaunchStarted   LifecycleEventType = "launch-started"
+	LifecycleLaunchSucceeded LifecycleEventType = "launch-succeeded"
+	LifecycleLaunchFailed    LifecycleEventType = "launch-failed"
+)
+
+// lifecycleDetailTypes are EventBridge detail types (and SQS message attributes) of event types
+var lifecycleDetailTypes = map[LifecycleEventType]string{
+	LifecycleLaunchStarted:   "Reservation Launch Started",
+	LifecycleLaunchSucceeded: "Reservation Launch Succeeded",
+	LifecycleLaunchFailed:    "Reservation Launch Failed",
+}
+
+// LifecycleEvent is the detail of events published to the event target of a source.
+type LifecycleEvent struct {
+	Type          LifecycleEventType `json:"event_type"`
+	ReservationID int64              `json:"reservation_id"`
+	SourceID      string             `json:"source_id"`
+	Provider      string             `json:"provider"`
+	Region        string             `json:"region"`
+	Timestamp     time.Time          `json:"timestamp"`
+
+	// Launched instances, only present in succeeded events.
+	Instances []LifecycleInstance `json:"instances,omitempty"`
+
+	// Launch error, only present in failed events.
+	Error string `json:"error,omitempty"`
+}
+
+// LifecycleInstance is an instance of a lifecycle event.
+type LifecycleInstance struct {
+	InstanceID  string `json:"instance_id"`
+	PublicIPv4  string `json:"public_ipv4,omitempty"`
+	PublicDNS   string `json:"public_dns,omitempty"`
+	PrivateIPv4 string `json:"private_ipv4,omitempty"`
+	PrivateDNS  string `json:"private_dns,omitempty"`
+	DNSName     string `json:"dns_name,omitempty"`
+}
+
+// PublishLifecycleEventAWS publishes a lifecycle event of the reservation to the event target from
+// settings of the source, nothing is published when no target is set. The event is sent using the
+// role of the source. Events are informational, errors are logged and never fail the launch.
+func PublishLifecycleEventAWS(ctx context.Context, args *LaunchInstanceAWSTaskArgs, eventType LifecycleEventType, jobErr error) {
+	logger := zerolog.Ctx(ctx)
+	settings, err := dao.GetAccountDao(ctx).GetSourceSettings(ctx, args.SourceID)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Unable to get source settings, lifecycle event not published")
+		return
+	}
+	if settings.EventTarget == "" {
+		return
+	}
+	target, err := clients.ParseEventTarget(settings.EventTarget)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Invalid event target, lifecycle event not published")
+		return
+	}
+
+	event := LifecycleEvent{
+		Type:          eventType,
+		ReservationID: args.ReservationID,
+		SourceID:      args.SourceID,
+		Provider:      models.ProviderTypeAWS.String(),
+		Region:        args.Region,
+		Timestamp:     time.Now().UTC(),
+	}
+	if jobErr != nil {
+		event.Error = jobErr.Error()
+	}
+	if eventType == LifecycleLaunchSucceeded {
+		instances, err := dao.GetReservationDao(ctx).ListInstances(ctx, args.ReservationID)
+		if err != nil {
+			logger.Warn().Err(err).Msg("Unable to list instances for lifecycle event")
+		}
+		for _, instance := range instances {
+			event.Instances = append(event.Instances, LifecycleInstance{
+				InstanceID:  instance.InstanceID,
+				PublicIPv4:  instance.Detail.PublicIPv4,
+				PublicDNS:   instance.Detail.PublicDNS,
+				PrivateIPv4: instance.Detail.PrivateIPv4,
+				PrivateDNS:  instance.Detail.PrivateDNS,
+				DNSName:     instance.Detail.DNSName,
+			})
+		}
+	}
+	detail, err := json.Marshal(event)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Unable to marshal lifecycle event")
+		return
+	}
+
+	ec2Client, err := clients.GetEC2Client(ctx, args.ARN, target.Region)
+	if err == nil {
+		err = ec2Client.PublishEvent(ctx, target, lifecycleDetailTypes[eventType], detail)
+	}
+	recordProviderCall(ctx, args.ReservationID, "PublishEvent", map[string]string{
+		"service":    target.Service,
+		"event_type": string(eventType),
+	}, err)
+	if err != nil {
+		logger.Warn().Err(err).Str("event_type", string(eventType)).Msg("Unable to publish lifecycle event")
+	}
+}
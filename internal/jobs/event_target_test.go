@@ -0,0 +1,83 @@
+package jobs_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	clientStubs "github.com/RHEnVision/provisioning-backend/internal/clients/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	daoStubs "github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/jobs"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/factories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublishLifecycleEventAWS(t *testing.T) {
+	ctx := prepareEC2Context(t)
+	pk := factories.NewPubkeyRSA()
+	err := daoStubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to add stubbed key")
+
+	reservation := prepareAWSReservation(t, ctx, pk)
+	rDao := dao.GetReservationDao(ctx)
+	err = rDao.CreateAWS(ctx, reservation)
+	require.NoError(t, err, "failed to add stubbed reservation")
+	err = rDao.CreateInstance(ctx, &models.ReservationInstance{
+		ReservationID: reservation.ID,
+		InstanceID:    "i-0a4caa2cf5b097ce1",
+		Detail:        models.ReservationInstanceDetail{PublicIPv4: "54.11.88.17"},
+	})
+	require.NoError(t, err, "failed to add stubbed instance")
+
+	args := &jobs.LaunchInstanceAWSTaskArgs{
+		ReservationID: reservation.ID,
+		Region:        reservation.Detail.Region,
+		SourceID:      reservation.SourceID,
+		ARN:           &clients.Authentication{ProviderType: models.ProviderTypeAWS, Payload: "arn:aws:iam::230214684733:role/Test"},
+	}
+
+	t.Run("no target", func(t *testing.T) {
+		jobs.PublishLifecycleEventAWS(ctx, args, jobs.LifecycleLaunchStarted, nil)
+		events, err := clientStubs.StubbedEC2Events(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+
+	err = dao.GetAccountDao(ctx).UpdateSourceSettings(ctx, &models.SourceSettings{
+		SourceID:    reservation.SourceID,
+		EventTarget: "arn:aws:sqs:us-east-1:230214684733:launches",
+	})
+	require.NoError(t, err, "failed to update source settings")
+
+	t.Run("succeeded", func(t *testing.T) {
+		jobs.PublishLifecycleEventAWS(ctx, args, jobs.LifecycleLaunchSucceeded, nil)
+		events, err := clientStubs.StubbedEC2Events(ctx)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, clients.EventTargetSQS, events[0].Target.Service)
+		assert.Equal(t, "Reservation Launch Succeeded", events[0].DetailType)
+
+		var event jobs.LifecycleEvent
+		require.NoError(t, json.Unmarshal(events[0].Detail, &event))
+		assert.Equal(t, jobs.LifecycleLaunchSucceeded, event.Type)
+		assert.Equal(t, reservation.ID, event.ReservationID)
+		require.Len(t, event.Instances, 1)
+		assert.Equal(t, "54.11.88.17", event.Instances[0].PublicIPv4)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		jobs.PublishLifecycleEventAWS(ctx, args, jobs.LifecycleLaunchFailed, errors.New("no capacity"))
+		events, err := clientStubs.StubbedEC2Events(ctx)
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+
+		var event jobs.LifecycleEvent
+		require.NoError(t, json.Unmarshal(events[1].Detail, &event))
+		assert.Equal(t, "no capacity", event.Error)
+		assert.Empty(t, event.Instances)
+	})
+}
@@ -0,0 +1,70 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/logging"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/pkg/worker"
+	"github.com/rs/zerolog"
+)
+
+// RecordEvent stores an event in the reservation timeline. The timeline is only used for debugging
+// and support, therefore errors are logged and never fail the caller. Trace ID of the context is
+// added to details, so logs of the request can be found.
+func RecordEvent(ctx context.Context, reservationId int64, kind models.ReservationEventKind, message string, details map[string]string) {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		// the original context is expired and unusable at this point
+		ctx = copyContext(ctx)
+	}
+	if traceId := logging.TraceId(ctx); traceId != "" {
+		traced := make(map[string]string, len(details)+1)
+		for k, v := range details {
+			traced[k] = v
+		}
+		traced["trace_id"] = traceId
+		details = traced
+	}
+
+	event := &models.ReservationEvent{
+		ReservationID: reservationId,
+		Kind:          kind,
+		Message:       message,
+		Details:       details,
+	}
+	err := dao.GetReservationDao(ctx).CreateEvent(ctx, event)
+	if err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Str("event", string(kind)).Msg("Unable to record reservation event")
+	}
+}
+
+// RecordEnqueued records the job of the reservation was enqueued.
+func RecordEnqueued(ctx context.Context, reservationId int64, job *worker.Job, message string) {
+	RecordEvent(ctx, reservationId, models.EventEnqueued, message, jobDetails(job))
+}
+
+// recordDequeued records the job of the reservation was picked up by a worker.
+func recordDequeued(ctx context.Context, reservationId int64, job *worker.Job) {
+	RecordEvent(ctx, reservationId, models.EventDequeued, "Job picked up by a worker", jobDetails(job))
+}
+
+func jobDetails(job *worker.Job) map[string]string {
+	return map[string]string{
+		"job_id":   job.ID.String(),
+		"job_type": job.Type.String(),
+	}
+}
+
+// recordProviderCall records a call creating resources on a cloud provider, the error is stored
+// in details when the call failed.
+func recordProviderCall(ctx context.Context, reservationId int64, operation string, details map[string]string, err error) {
+	if details == nil {
+		details = map[string]string{}
+	}
+	if err != nil {
+		details["error"] = err.Error()
+	}
+	RecordEvent(ctx, reservationId, models.EventProviderCall, operation, details)
+}
@@ -0,0 +1,225 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/rs/zerolog"
+)
+
+// ProbeInstancesStep is the title of the optional step of launch jobs probing reachability of
+// instances.
+const ProbeInstancesStep = "Probe instance(s)"
+
+// UnreachableStatus is the final status of reservations with instances which did not pass the
+// health probe in time. The launch itself is still successful.
+const UnreachableStatus = "Launched but unreachable"
+
+const (
+	// probeInterval is the delay between probes of instances which are not reachable yet
+	probeInterval = 5 * time.Second
+
+	// probeAttemptTimeout limits a single connection attempt or HTTP request
+	probeAttemptTimeout = 5 * time.Second
+
+	// probeConcurrency limits number of instances probed at once
+	probeConcurrency = 16
+)
+
+var ErrProbeStatus = errors.New("unexpected health probe status")
+
+var ErrNoProbeAddress = errors.New("instance has no public IPv4 address")
+
+// probeClient does not follow redirects, a redirect response means the instance is reachable
+var probeClient = &http.Client{
+	CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// Job logic, when error is returned the job status is updated accordingly
+func DoProbeInstancesAWS(ctx context.Context, args *LaunchInstanceAWSTaskArgs) error {
+	if args.Detail.HealthProbe == nil {
+		return nil
+	}
+	return probeInstances(ctx, args.ReservationID, args.Detail.HealthProbe)
+}
+
+// Job logic, when error is returned the job status is updated accordingly
+func DoProbeInstancesAzure(ctx context.Context, args *LaunchInstanceAzureTaskArgs) error {
+	reservation, err := dao.GetReservationDao(ctx).GetAzureById(ctx, args.ReservationID)
+	if err != nil {
+		return fmt.Errorf("cannot get azure reservation by id: %w", err)
+	}
+	if reservation.Detail.HealthProbe == nil {
+		return nil
+	}
+	return probeInstances(ctx, args.ReservationID, reservation.Detail.HealthProbe)
+}
+
+// Job logic, when error is returned the job status is updated accordingly
+func DoProbeInstancesGCP(ctx context.Context, args *LaunchInstanceGCPTaskArgs) error {
+	if args.Detail.HealthProbe == nil {
+		return nil
+	}
+	return probeInstances(ctx, args.ReservationID, args.Detail.HealthProbe)
+}
+
+// probeInstances probes all instances of the reservation until they are reachable or the probe
+// timeout passes. Unreachable instances do not fail the step, the reservation is marked
+// unreachable instead and an event with the last probe error is recorded for every instance.
+func probeInstances(ctx context.Context, reservationId int64, probe *models.HealthProbe) error {
+	logger := zerolog.Ctx(ctx)
+	logger.Debug().Msg("Started probe instances job")
+
+	// status updates before and after the code logic
+	updateStatusBefore(ctx, reservationId, "Probing instance(s)")
+	status := "Probed instance(s)"
+	defer func() { updateStatusAfter(ctx, reservationId, status, 1) }()
+
+	rDao := dao.GetReservationDao(ctx)
+	instances, err := rDao.ListInstances(ctx, reservationId)
+	if err != nil {
+		return fmt.Errorf("cannot list reservation instances: %w", err)
+	}
+
+	timeout := config.Worker.ProbeTimeout
+	if probe.Timeout > 0 {
+		timeout = time.Duration(probe.Timeout) * time.Second
+	}
+	deadline := time.Now().Add(timeout)
+
+	// only public addresses are probed, private addresses could reach the internal network of the
+	// service instead of the instance
+	pending := make(map[string]string, len(instances))
+	failures := make(map[string]error, len(instances))
+	for _, instance := range instances {
+		if address := instance.Detail.PublicIPv4; address != "" {
+			pending[instance.InstanceID] = address
+		} else {
+			failures[instance.InstanceID] = ErrNoProbeAddress
+		}
+	}
+
+	for len(pending) > 0 {
+		for id, probeErr := range probeAll(ctx, probe, pending) {
+			if probeErr == nil {
+				logger.Debug().Str("instance_id", id).Msgf("Instance is reachable on %s", pending[id])
+				delete(pending, id)
+				delete(failures, id)
+				continue
+			}
+			failures[id] = probeErr
+		}
+		if len(pending) == 0 || !time.Now().Before(deadline) {
+			break
+		}
+
+		logger.Debug().Msgf("Waiting for %d out of %d instances to become reachable", len(pending), len(instances))
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("cannot wait for reachable instances: %w", ctx.Err())
+		case <-time.After(minDuration(probeInterval, time.Until(deadline))):
+		}
+	}
+
+	reachable := len(failures) == 0
+	err = rDao.UpdateReachable(ctx, reservationId, reachable)
+	if err != nil {
+		return fmt.Errorf("cannot store health probe result: %w", err)
+	}
+
+	if reachable {
+		status = "Instance(s) reachable"
+		return nilUnlessTimeout(ctx)
+	}
+	status = UnreachableStatus
+	for id, failure := range failures {
+		logger.Warn().Err(failure).Str("instance_id", id).Msg("Instance did not pass the health probe")
+		RecordEvent(ctx, reservationId, models.EventUnreachable, failure.Error(), map[string]string{"instance_id": id})
+	}
+	return nilUnlessTimeout(ctx)
+}
+
+// probeAll probes addresses of instances in parallel and returns the result of every instance.
+func probeAll(ctx context.Context, probe *models.HealthProbe, addresses map[string]string) map[string]error {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	results := make(map[string]error, len(addresses))
+	limit := make(chan struct{}, probeConcurrency)
+	for id, address := range addresses {
+		wg.Add(1)
+		limit <- struct{}{}
+		go func(id, address string) {
+			defer func() {
+				<-limit
+				wg.Done()
+			}()
+			err := probeAddress(ctx, probe, address)
+
+			mu.Lock()
+			defer mu.Unlock()
+			results[id] = err
+		}(id, address)
+	}
+	wg.Wait()
+	return results
+}
+
+// probeAddress connects to the probe port of the address, or requests the HTTP path on the port
+// and expects a status below 400.
+func probeAddress(ctx context.Context, probe *models.HealthProbe, address string) error {
+	ctx, cancel := context.WithTimeout(ctx, probeAttemptTimeout)
+	defer cancel()
+
+	port := probe.Port
+	if port == 0 {
+		port = 22
+		if probe.HTTPPath != "" {
+			port = 80
+		}
+	}
+	hostPort := net.JoinHostPort(address, strconv.Itoa(port))
+
+	if probe.HTTPPath == "" {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", hostPort)
+		if err != nil {
+			return fmt.Errorf("cannot connect to %s: %w", hostPort, err)
+		}
+		_ = conn.Close()
+		return nil
+	}
+
+	url := "http://" + hostPort + "/" + strings.TrimPrefix(probe.HTTPPath, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("cannot create health probe request: %w", err)
+	}
+	resp, err := probeClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("cannot request %s: %w", url, err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("%w: %s returned %s", ErrProbeStatus, url, resp.Status)
+	}
+	return nil
+}
+
+func minDuration(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+	return b
+}
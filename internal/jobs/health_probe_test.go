@@ -0,0 +1,171 @@
+package jobs_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	daoStubs "github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/jobs"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/factories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func prepareProbeReservation(t *testing.T, ctx context.Context, probe *models.HealthProbe) *jobs.LaunchInstanceAWSTaskArgs {
+	t.Helper()
+	pk := factories.NewPubkeyRSA()
+	err := daoStubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to add stubbed key")
+	reservation := prepareAWSReservation(t, ctx, pk)
+	reservation.Detail.HealthProbe = probe
+	err = daoStubs.AddAWSReservation(ctx, reservation)
+	require.NoError(t, err, "failed to add stubbed reservation")
+
+	err = dao.GetReservationDao(ctx).CreateInstance(ctx, &models.ReservationInstance{
+		ReservationID: reservation.ID,
+		InstanceID:    "i-0a4caa2cf5b097ce1",
+		Detail:        models.ReservationInstanceDetail{PublicIPv4: "127.0.0.1"},
+	})
+	require.NoError(t, err, "failed to add stubbed instance")
+
+	return &jobs.LaunchInstanceAWSTaskArgs{
+		ReservationID: reservation.ID,
+		Region:        "us-east-1",
+		Detail:        reservation.Detail,
+	}
+}
+
+func serverPort(t *testing.T, server *httptest.Server) int {
+	t.Helper()
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	result, err := strconv.Atoi(port)
+	require.NoError(t, err)
+	return result
+}
+
+// finalStatus returns the status of the last finished step from the reservation timeline
+func finalStatus(t *testing.T, ctx context.Context, reservationId int64) string {
+	t.Helper()
+	events, err := dao.GetReservationDao(ctx).ListEvents(ctx, reservationId, 100, 0)
+	require.NoError(t, err)
+	var status string
+	for _, event := range events {
+		if event.Kind == models.EventStepFinished {
+			status = event.Message
+		}
+	}
+	return status
+}
+
+func TestDoProbeInstancesAWS(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/healthz" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	port := serverPort(t, server)
+
+	t.Run("tcp", func(t *testing.T) {
+		ctx := prepareEC2Context(t)
+		args := prepareProbeReservation(t, ctx, &models.HealthProbe{Port: port, Timeout: 1})
+
+		err := jobs.DoProbeInstancesAWS(ctx, args)
+		require.NoError(t, err)
+
+		reservation, err := dao.GetReservationDao(ctx).GetById(ctx, args.ReservationID)
+		require.NoError(t, err)
+		assert.True(t, reservation.Reachable.Valid)
+		assert.True(t, reservation.Reachable.Bool)
+		assert.Equal(t, "Instance(s) reachable", finalStatus(t, ctx, args.ReservationID))
+	})
+
+	t.Run("http", func(t *testing.T) {
+		ctx := prepareEC2Context(t)
+		args := prepareProbeReservation(t, ctx, &models.HealthProbe{Port: port, HTTPPath: "healthz", Timeout: 1})
+
+		err := jobs.DoProbeInstancesAWS(ctx, args)
+		require.NoError(t, err)
+
+		reservation, err := dao.GetReservationDao(ctx).GetById(ctx, args.ReservationID)
+		require.NoError(t, err)
+		assert.True(t, reservation.Reachable.Bool)
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		ctx := prepareEC2Context(t)
+		args := prepareProbeReservation(t, ctx, &models.HealthProbe{Port: port, HTTPPath: "/ready", Timeout: 1})
+
+		err := jobs.DoProbeInstancesAWS(ctx, args)
+		require.NoError(t, err, "unreachable instances must not fail the launch")
+
+		reservation, err := dao.GetReservationDao(ctx).GetById(ctx, args.ReservationID)
+		require.NoError(t, err)
+		assert.True(t, reservation.Reachable.Valid)
+		assert.False(t, reservation.Reachable.Bool)
+		assert.Equal(t, jobs.UnreachableStatus, finalStatus(t, ctx, args.ReservationID))
+
+		events, err := dao.GetReservationDao(ctx).ListEvents(ctx, args.ReservationID, 100, 0)
+		require.NoError(t, err)
+		var unreachable []*models.ReservationEvent
+		for _, event := range events {
+			if event.Kind == models.EventUnreachable {
+				unreachable = append(unreachable, event)
+			}
+		}
+		require.Len(t, unreachable, 1)
+		assert.Equal(t, "i-0a4caa2cf5b097ce1", unreachable[0].Details["instance_id"])
+		assert.Contains(t, unreachable[0].Message, "503")
+	})
+
+	t.Run("private address only", func(t *testing.T) {
+		ctx := prepareEC2Context(t)
+		args := prepareProbeReservation(t, ctx, &models.HealthProbe{Port: port, Timeout: 1})
+		err := dao.GetReservationDao(ctx).CreateInstance(ctx, &models.ReservationInstance{
+			ReservationID: args.ReservationID,
+			InstanceID:    "i-0b5dbb3d06c108df2",
+			Detail: models.ReservationInstanceDetail{
+				NetworkInterfaces: []models.InstanceNetworkInterface{{PrivateIPv4: "127.0.0.1"}},
+			},
+		})
+		require.NoError(t, err, "failed to add stubbed instance")
+
+		err = jobs.DoProbeInstancesAWS(ctx, args)
+		require.NoError(t, err)
+
+		reservation, err := dao.GetReservationDao(ctx).GetById(ctx, args.ReservationID)
+		require.NoError(t, err)
+		assert.False(t, reservation.Reachable.Bool)
+
+		events, err := dao.GetReservationDao(ctx).ListEvents(ctx, args.ReservationID, 100, 0)
+		require.NoError(t, err)
+		var unreachable []*models.ReservationEvent
+		for _, event := range events {
+			if event.Kind == models.EventUnreachable {
+				unreachable = append(unreachable, event)
+			}
+		}
+		require.Len(t, unreachable, 1)
+		assert.Equal(t, "i-0b5dbb3d06c108df2", unreachable[0].Details["instance_id"])
+		assert.Equal(t, jobs.ErrNoProbeAddress.Error(), unreachable[0].Message)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		ctx := prepareEC2Context(t)
+		args := prepareProbeReservation(t, ctx, nil)
+
+		err := jobs.DoProbeInstancesAWS(ctx, args)
+		require.NoError(t, err)
+
+		reservation, err := dao.GetReservationDao(ctx).GetById(ctx, args.ReservationID)
+		require.NoError(t, err)
+		assert.False(t, reservation.Reachable.Valid)
+	})
+}
@@ -0,0 +1,59 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/rs/zerolog"
+)
+
+// Job steps with explicit identity policy.
+const (
+	stepEnsurePubkey        = "EnsurePubkey"
+	stepEnsureResourceGroup = "EnsureResourceGroup"
+	stepLaunchInstances     = "LaunchInstances"
+	stepFetchInstances      = "FetchInstancesDescription"
+	stepAssociateElasticIPs = "AssociateElasticIPs"
+	stepCreateDNSRecords    = "CreateDNSRecords"
+	stepRetrievePassword    = "RetrievePassword"
+	stepProbeInstances      = "ProbeInstances"
+	stepNotification        = "Notification"
+	stepPowerInstance       = "PowerInstance"
+	stepResizeInstance      = "ResizeInstance"
+)
+
+// serviceIdentitySteps is the policy of job steps which may use a service identity when the
+// original identity of the job has likely expired. Steps which create or modify resources on
+// behalf of the user must always use the original identity.
+var serviceIdentitySteps = map[string]bool{
+	stepEnsurePubkey:        false,
+	stepEnsureResourceGroup: false,
+	stepLaunchInstances:     false,
+	stepFetchInstances:      true,
+	stepAssociateElasticIPs: false,
+	stepCreateDNSRecords:    false,
+	stepRetrievePassword:    true,
+	stepProbeInstances:      true,
+	stepNotification:        true,
+	stepPowerInstance:       false,
+	stepResizeInstance:      false,
+}
+
+// stepContext returns context for a job step. When the job identity is expired and the step
+// policy allows it, the identity is replaced with a service identity of the same tenant.
+func stepContext(ctx context.Context, step string) context.Context {
+	id := identity.Identity(ctx)
+	if !identity.Expired(id, config.Worker.IdentityLifetime) {
+		return ctx
+	}
+
+	logger := zerolog.Ctx(ctx)
+	if !serviceIdentitySteps[step] {
+		logger.Warn().Str("step", step).Msg("Job identity has likely expired, step must use the original identity")
+		return ctx
+	}
+
+	logger.Debug().Str("step", step).Msg("Job identity has likely expired, using service identity")
+	return identity.WithIdentity(ctx, identity.ServicePrincipal(id))
+}
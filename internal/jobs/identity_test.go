@@ -0,0 +1,55 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/stretchr/testify/require"
+)
+
+func jwtContext(authTime time.Time) context.Context {
+	id := identity.Principal{}
+	id.Identity.OrgID = "1"
+	id.Identity.Type = "User"
+	id.Identity.AuthType = "jwt-auth"
+	id.Identity.User.Username = "user"
+	id.Identity.Internal.AuthTime = float32(authTime.Unix())
+	return identity.WithIdentity(context.Background(), id)
+}
+
+func TestStepContext(t *testing.T) {
+	defer func(lifetime time.Duration) {
+		config.Worker.IdentityLifetime = lifetime
+	}(config.Worker.IdentityLifetime)
+	config.Worker.IdentityLifetime = 15 * time.Minute
+
+	t.Run("fresh identity", func(t *testing.T) {
+		ctx := stepContext(jwtContext(time.Now()), stepFetchInstances)
+		require.Equal(t, "User", identity.Identity(ctx).Identity.Type)
+	})
+
+	t.Run("expired identity allowed step", func(t *testing.T) {
+		ctx := stepContext(jwtContext(time.Now().Add(-time.Hour)), stepFetchInstances)
+		id := identity.Identity(ctx)
+		require.Equal(t, "System", id.Identity.Type)
+		require.Equal(t, "1", id.Identity.OrgID)
+		require.Empty(t, id.Identity.User.Username)
+	})
+
+	t.Run("expired identity denied step", func(t *testing.T) {
+		ctx := stepContext(jwtContext(time.Now().Add(-time.Hour)), stepLaunchInstances)
+		require.Equal(t, "User", identity.Identity(ctx).Identity.Type)
+	})
+
+	t.Run("certificate identity", func(t *testing.T) {
+		id := identity.Principal{}
+		id.Identity.OrgID = "1"
+		id.Identity.Type = "System"
+		id.Identity.AuthType = "cert-auth"
+		ctx := stepContext(identity.WithIdentity(context.Background(), id), stepFetchInstances)
+		require.Equal(t, "cert-auth", identity.Identity(ctx).Identity.AuthType)
+	})
+}
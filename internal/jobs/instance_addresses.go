@@ -0,0 +1,83 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/rs/zerolog"
+)
+
+// addressPollInterval is the delay between descriptions of instances which are still pending
+const addressPollInterval = 5 * time.Second
+
+// describeFunc returns descriptions of instances, instances which are not found yet can be missing
+type describeFunc func(ids []string) ([]*clients.InstanceDescription, error)
+
+// pollInstanceAddresses describes instances until none of them is pending and stores addresses
+// from every description, so callers get IP addresses and DNS names without looking them up in the
+// cloud account. Cloud providers assign addresses while instances are starting, describe errors
+// are retried because new instances are not immediately visible in the API.
+//
+// Instances still pending after the configured timeout are only logged, their addresses may be
+// incomplete. The last describe error is returned when no instance was described at all.
+func pollInstanceAddresses(ctx context.Context, reservationId int64, instanceIds []string, describe describeFunc) error {
+	logger := zerolog.Ctx(ctx)
+	rDao := dao.GetReservationDao(ctx)
+
+	pending := make(map[string]bool, len(instanceIds))
+	for _, id := range instanceIds {
+		pending[id] = true
+	}
+
+	var described bool
+	deadline := time.Now().Add(config.Worker.AddressTimeout)
+	for {
+		descriptions, err := describe(pendingIds(pending))
+		if err == nil {
+			for _, description := range descriptions {
+				if !pending[description.ID] {
+					continue
+				}
+				err = rDao.UpdateReservationInstance(ctx, reservationId, description)
+				if err != nil {
+					return fmt.Errorf("cannot update instance description: %w", err)
+				}
+				described = true
+				if !description.Pending {
+					delete(pending, description.ID)
+				}
+			}
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			if err != nil && !described {
+				return fmt.Errorf("cannot describe instances: %w", err)
+			}
+			logger.Warn().Err(err).Strs("instance_ids", pendingIds(pending)).Msg("Instances are still pending, addresses may be incomplete")
+			return nil
+		}
+		logger.Debug().Err(err).Msgf("Waiting for addresses of %d out of %d instances", len(pending), len(instanceIds))
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("cannot wait for instance addresses: %w", ctx.Err())
+		case <-time.After(addressPollInterval):
+		}
+	}
+}
+
+func pendingIds(pending map[string]bool) []string {
+	ids := make([]string, 0, len(pending))
+	for id := range pending {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -7,4 +7,10 @@ const (
 	TypeLaunchInstanceAws   worker.JobType = "launch_instances_aws"
 	TypeLaunchInstanceAzure worker.JobType = "launch_instances_azure"
 	TypeLaunchInstanceGcp   worker.JobType = "launch_instances_gcp"
+	TypePowerInstanceAws    worker.JobType = "power_instance_aws"
+	TypePowerInstanceAzure  worker.JobType = "power_instance_azure"
+	TypePowerInstanceGcp    worker.JobType = "power_instance_gcp"
+	TypeResizeInstanceAws   worker.JobType = "resize_instance_aws"
+	TypeResizeInstanceAzure worker.JobType = "resize_instance_azure"
+	TypeResizeInstanceGcp   worker.JobType = "resize_instance_gcp"
 )
@@ -4,12 +4,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/RHEnVision/provisioning-backend/internal/clients"
 	"github.com/RHEnVision/provisioning-backend/internal/clients/http"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
 	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/logging"
 	"github.com/RHEnVision/provisioning-backend/internal/models"
 	"github.com/RHEnVision/provisioning-backend/internal/notifications"
+	"github.com/RHEnVision/provisioning-backend/internal/ptr"
 	"github.com/RHEnVision/provisioning-backend/internal/userdata"
 	"github.com/RHEnVision/provisioning-backend/pkg/worker"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
@@ -51,28 +58,45 @@ func HandleLaunchInstanceAWS(ctx context.Context, job *worker.Job) {
 		return
 	}
 
-	logger := zerolog.Ctx(ctx).With().Int64("reservation_id", args.ReservationID).Logger()
-	ctx = logger.WithContext(ctx)
+	ctx = logging.WithReservationId(ctx, args.ReservationID)
+	recordDequeued(ctx, args.ReservationID, job)
 	nc := notifications.GetNotificationClient(ctx)
+	PublishLifecycleEventAWS(stepContext(ctx, stepNotification), &args, LifecycleLaunchStarted, nil)
 
-	jobErr := DoEnsurePubkeyOnAWS(ctx, &args)
+	jobErr := DoEnsurePubkeyOnAWS(stepContext(ctx, stepEnsurePubkey), &args)
 	if jobErr != nil {
 		finishWithError(ctx, args.ReservationID, jobErr)
-		nc.FailedLaunch(ctx, args.ReservationID, jobErr)
+		nc.FailedLaunch(stepContext(ctx, stepNotification), args.ReservationID, jobErr)
+		PublishLifecycleEventAWS(stepContext(ctx, stepNotification), &args, LifecycleLaunchFailed, jobErr)
 		return
 	}
 
-	jobErr = DoLaunchInstanceAWS(ctx, &args)
+	jobErr = DoLaunchInstanceAWS(stepContext(ctx, stepLaunchInstances), &args)
 	if jobErr != nil {
 		finishWithError(ctx, args.ReservationID, jobErr)
-		nc.FailedLaunch(ctx, args.ReservationID, jobErr)
+		nc.FailedLaunch(stepContext(ctx, stepNotification), args.ReservationID, jobErr)
+		PublishLifecycleEventAWS(stepContext(ctx, stepNotification), &args, LifecycleLaunchFailed, jobErr)
 		return
 	}
-	jobErr = FetchInstancesDescriptionAWS(ctx, &args)
+	jobErr = FetchInstancesDescriptionAWS(stepContext(ctx, stepFetchInstances), &args)
+	if jobErr == nil {
+		jobErr = DoAssociateElasticIPsAWS(stepContext(ctx, stepAssociateElasticIPs), &args)
+	}
+	if jobErr == nil {
+		jobErr = DoRetrievePasswordAWS(stepContext(ctx, stepRetrievePassword), &args)
+	}
+	if jobErr == nil {
+		jobErr = DoCreateDNSRecordsAWS(stepContext(ctx, stepCreateDNSRecords), &args)
+	}
+	if jobErr == nil {
+		jobErr = DoProbeInstancesAWS(stepContext(ctx, stepProbeInstances), &args)
+	}
 	if jobErr != nil {
-		nc.FailedLaunch(ctx, args.ReservationID, jobErr)
+		nc.FailedLaunch(stepContext(ctx, stepNotification), args.ReservationID, jobErr)
+		PublishLifecycleEventAWS(stepContext(ctx, stepNotification), &args, LifecycleLaunchFailed, jobErr)
 	} else {
-		nc.SuccessfulLaunch(ctx, args.ReservationID)
+		nc.SuccessfulLaunch(stepContext(ctx, stepNotification), args.ReservationID)
+		PublishLifecycleEventAWS(stepContext(ctx, stepNotification), &args, LifecycleLaunchSucceeded, nil)
 	}
 
 	finishJob(ctx, args.ReservationID, jobErr)
@@ -83,7 +107,7 @@ func DoEnsurePubkeyOnAWS(ctx context.Context, args *LaunchInstanceAWSTaskArgs) e
 	logger := zerolog.Ctx(ctx)
 	logger.Debug().Msg("Started pubkey upload AWS job")
 
-	logger.Info().Interface("args", args).Msg("Processing pubkey upload AWS job")
+	logger.Info().Interface("args", redactArgs(args)).Msg("Processing pubkey upload AWS job")
 
 	// status updates before and after the code logic
 	updateStatusBefore(ctx, args.ReservationID, "Uploading public key")
@@ -167,7 +191,7 @@ func DoLaunchInstanceAWS(ctx context.Context, args *LaunchInstanceAWSTaskArgs) e
 	logger := zerolog.Ctx(ctx)
 	logger.Debug().Msg("Started launch instance AWS job")
 
-	logger.Info().Interface("args", args).Msg("Processing launch instance AWS job")
+	logger.Info().Interface("args", redactArgs(args)).Msg("Processing launch instance AWS job")
 
 	// status updates before and after the code logic
 	updateStatusBefore(ctx, args.ReservationID, "Launching instance(s)")
@@ -180,17 +204,23 @@ func DoLaunchInstanceAWS(ctx context.Context, args *LaunchInstanceAWSTaskArgs) e
 		return fmt.Errorf("cannot get aws reservation by id: %w", err)
 	}
 
-	// Generate user data
-	userDataInput := userdata.UserData{
-		Type:         models.ProviderTypeAWS,
-		PowerOff:     args.Detail.PowerOff,
-		InsightsTags: true,
-	}
-	userData, err := userdata.GenerateUserData(&userDataInput)
-	if err != nil {
-		return fmt.Errorf("cannot generate user data: %w", err)
+	// Generate user data, Windows images do not run cloud-init and get custom user data as-is
+	var userData []byte
+	if !args.Detail.Windows {
+		userDataInput := userdata.UserData{
+			Type:         models.ProviderTypeAWS,
+			PowerOff:     args.Detail.PowerOff,
+			InsightsTags: true,
+			Custom:       args.Detail.UserData,
+		}
+		userData, err = userdata.GenerateUserData(&userDataInput)
+		if err != nil {
+			return fmt.Errorf("cannot generate user data: %w", err)
+		}
+		logger.Trace().Int("userdata_size", len(userData)).Msg("Generated user data")
+	} else if args.Detail.UserData != "" {
+		userData = []byte(args.Detail.UserData)
 	}
-	logger.Trace().Bool("userdata", true).Msg(string(userData))
 
 	ec2Client, err := clients.GetEC2Client(ctx, args.ARN, args.Region)
 	if err != nil {
@@ -203,25 +233,85 @@ func DoLaunchInstanceAWS(ctx context.Context, args *LaunchInstanceAWSTaskArgs) e
 		AMI:              args.AMI,
 		KeyName:          reservation.Detail.PubkeyName,
 		UserData:         userData,
+		InstanceProfile:  args.Detail.InstanceProfile,
+		EncryptVolumes:   args.Detail.EncryptVolumes,
+		KMSKeyID:         args.Detail.KMSKeyID,
+		RootVolume:       args.Detail.RootVolume,
+		NitroEnclaves:    args.Detail.NitroEnclaves,
+		Hibernation:      args.Detail.Hibernation,
+
+		NetworkInterfaces: args.Detail.NetworkInterfaces,
+		SecurityGroupIDs:  args.Detail.SecurityGroupIDs,
+		Addressing:        args.Detail.Addressing,
+		Tenancy:           args.Detail.Tenancy,
+		HostID:            args.Detail.HostID,
+		Tags:              args.Detail.Tags,
+	}
+	if args.Detail.Spot != nil {
+		req.Spot = true
+		req.SpotMaxPrice = args.Detail.Spot.MaxPrice
+	}
+	if args.Detail.CapacityReservation != nil {
+		req.CapacityReservationID = args.Detail.CapacityReservation.ID
+		req.CapacityReservationGroupARN = args.Detail.CapacityReservation.GroupARN
 	}
 
-	logger.Trace().Msg("Executing RunInstances")
-	instances, awsReservationId, err := ec2Client.RunInstances(ctx, req, args.Detail.Amount, args.Detail.Name, reservation)
-	if err != nil {
-		return fmt.Errorf("cannot run instances: %w", err)
+	fleet := useFleetAWS(args.Detail, args.LaunchTemplateID)
+	logger.Trace().Bool("fleet", fleet).Msg("Launching instances")
+	instances, awsReservationId, allocations, runErr := launchInstancesAWS(ctx, ec2Client, req, args.Detail, reservation, fleet)
+
+	// Spot instances are kept only when all their requests are fulfilled in time
+	var spotRequests map[string]string
+	if req.Spot && runErr == nil {
+		spotRequests, runErr = waitForSpotRequests(ctx, ec2Client, instances)
+		if runErr != nil {
+			cancelSpotInstances(ctx, ec2Client, args.ReservationID, instances, spotRequests)
+			instances, spotRequests = nil, nil
+		}
+	}
+	launchedOnDemand := false
+	if req.Spot && len(instances) == 0 && isSpotFailure(runErr) && args.Detail.Spot.FallbackOnDemand {
+		logger.Warn().Err(runErr).Msg("Spot instances not available, launching on-demand instances")
+		RecordEvent(ctx, args.ReservationID, models.EventSpotFallback, runErr.Error(), nil)
+
+		req.Spot, req.SpotMaxPrice = false, ""
+		req.InstanceType = types.InstanceType(args.Detail.InstanceType)
+		instances, awsReservationId, allocations, runErr = launchInstancesAWS(ctx, ec2Client, req, args.Detail, reservation, fleet)
+		launchedOnDemand = true
 	}
 
 	// For each instance that was created in AWS, add it as a DB record
 	for _, instanceId := range instances {
 		err = resD.CreateInstance(ctx, &models.ReservationInstance{
-			ReservationID: args.ReservationID,
-			InstanceID:    *instanceId,
+			ReservationID:   args.ReservationID,
+			InstanceID:      *instanceId,
+			SpotRequestID:   spotRequests[*instanceId],
+			FleetAllocation: allocations[*instanceId],
 		})
 		if err != nil {
 			return fmt.Errorf("cannot create instance reservation for id %d: %w", instanceId, err)
 		}
 		logger.Info().Str("instance_id", *instanceId).Msgf("Created new instance via AWS reservation %s", *awsReservationId)
 	}
+	if runErr != nil {
+		recordCapacityFailure(ctx, runErr, models.ProviderTypeAWS, args.Region, "", string(req.InstanceType))
+		return fmt.Errorf("cannot run instances: %w", runErr)
+	}
+
+	if len(args.Detail.FallbackInstanceTypes) > 0 || len(args.Detail.SubnetIDs) > 1 || launchedOnDemand {
+		// Save the instance type and subnet which got the capacity, fleet instances have their own pools
+		if len(args.Detail.FallbackInstanceTypes) > 0 && allocations == nil {
+			reservation.Detail.LaunchedInstanceType = string(req.InstanceType)
+		}
+		if len(args.Detail.SubnetIDs) > 1 && allocations == nil {
+			reservation.Detail.LaunchedSubnetID = req.SubnetID
+		}
+		reservation.Detail.LaunchedOnDemand = launchedOnDemand
+		err = resD.UnscopedUpdateAWSDetail(ctx, args.ReservationID, reservation.Detail)
+		if err != nil {
+			return fmt.Errorf("cannot save launched instance type: %w", err)
+		}
+	}
 
 	logger.Info().Str("aws_reservation_id", *awsReservationId).Msg("Adding aws reservation id")
 	// Save the AWS reservation id in aws_reservation_details table
@@ -233,6 +323,243 @@ func DoLaunchInstanceAWS(ctx context.Context, args *LaunchInstanceAWSTaskArgs) e
 	return nilUnlessTimeout(ctx)
 }
 
+// useFleetAWS returns true when instances are launched by EC2 Fleet. Only launches of more instances
+// than the configured threshold without a launch template, network interfaces, addressing options,
+// static private IPs, a dedicated host and a capacity reservation are supported.
+func useFleetAWS(detail *models.AWSDetail, launchTemplateID string) bool {
+	threshold := config.AWS.FleetThreshold
+	return threshold > 0 && detail.Amount > threshold && launchTemplateID == "" &&
+		len(detail.NetworkInterfaces) == 0 && detail.Addressing == nil && len(detail.PrivateIPs) == 0 && detail.HostID == "" &&
+		detail.CapacityReservation == nil
+}
+
+// launchInstancesAWS launches instances by EC2 Fleet or RunInstances. Capacity pools of fleet
+// instances are returned by instance ID, the map is nil for instances launched by RunInstances.
+// Fleet launches fall back to RunInstances when the role is not allowed to create fleets.
+func launchInstancesAWS(ctx context.Context, ec2Client clients.EC2, req *clients.AWSInstanceParams, detail *models.AWSDetail, reservation *models.AWSReservation, fleet bool) ([]*string, *string, map[string]models.FleetAllocation, error) {
+	if fleet {
+		instances, fleetId, allocations, err := createFleetAWS(ctx, ec2Client, req, detail, reservation)
+		if len(instances) > 0 || !errors.Is(err, clients.UnauthorizedErr) {
+			return instances, fleetId, allocations, err
+		}
+		zerolog.Ctx(ctx).Warn().Err(err).Msg("Unable to create EC2 Fleet, launching instances by RunInstances")
+	}
+
+	instances, awsReservationId, err := runInstancesWithFallbackAWS(ctx, ec2Client, req, detail, reservation)
+	return instances, awsReservationId, nil, err
+}
+
+// createFleetAWS launches instances by an instant EC2 Fleet. Pools are the requested and fallback
+// instance types in the requested subnets (or default subnets of all zones), in the same order
+// RunInstances tries them.
+func createFleetAWS(ctx context.Context, ec2Client clients.EC2, req *clients.AWSInstanceParams, detail *models.AWSDetail, reservation *models.AWSReservation) ([]*string, *string, map[string]models.FleetAllocation, error) {
+	pools := fleetPoolsAWS(ctx, ec2Client, detail)
+	fleetInstances, fleetId, err := ec2Client.CreateFleet(ctx, req, pools, detail.Amount, detail.Name, reservation)
+	recordProviderCall(ctx, reservation.ID, "CreateFleet", map[string]string{
+		"pools":  strconv.Itoa(len(pools)),
+		"amount": strconv.Itoa(int(detail.Amount)),
+	}, err)
+
+	instances := make([]*string, len(fleetInstances))
+	allocations := make(map[string]models.FleetAllocation, len(fleetInstances))
+	for i, instance := range fleetInstances {
+		instances[i] = ptr.To(instance.ID)
+		allocations[instance.ID] = instance.Allocation
+	}
+	return instances, fleetId, allocations, err
+}
+
+// fleetPoolsAWS returns capacity pools of a fleet launch. When listing of subnets fails, pools
+// without a subnet are returned and AWS picks the default subnet.
+func fleetPoolsAWS(ctx context.Context, ec2Client clients.EC2, detail *models.AWSDetail) []clients.FleetPool {
+	subnets := detail.SubnetIDs
+	if len(subnets) == 0 {
+		all, err := ec2Client.ListSubnets(ctx)
+		if err != nil {
+			zerolog.Ctx(ctx).Warn().Err(err).Msg("Unable to list subnets, launching fleet in the default subnet")
+		}
+		sort.Slice(all, func(i, j int) bool { return all[i].AvailabilityZone < all[j].AvailabilityZone })
+		for _, subnet := range all {
+			if subnet.Default {
+				subnets = append(subnets, subnet.ID)
+			}
+		}
+	}
+	if len(subnets) == 0 {
+		subnets = []string{""}
+	}
+
+	instanceTypes := append([]string{detail.InstanceType}, detail.FallbackInstanceTypes...)
+	pools := make([]clients.FleetPool, 0, len(instanceTypes)*len(subnets))
+	for _, instanceType := range instanceTypes {
+		for _, subnet := range subnets {
+			pools = append(pools, clients.FleetPool{InstanceType: instanceType, SubnetID: subnet})
+		}
+	}
+	return pools
+}
+
+// runInstancesWithFallbackAWS launches instances of the requested type and tries the alternative
+// instance types in order while nothing was launched due to capacity. The instance type of the last
+// attempt is left in the request.
+func runInstancesWithFallbackAWS(ctx context.Context, ec2Client clients.EC2, req *clients.AWSInstanceParams, detail *models.AWSDetail, reservation *models.AWSReservation) ([]*string, *string, error) {
+	logger := zerolog.Ctx(ctx)
+	instances, awsReservationId, runErr := runInstancesInSubnetsAWS(ctx, ec2Client, req, detail, reservation)
+
+	for _, fallback := range detail.FallbackInstanceTypes {
+		if len(instances) > 0 || !errors.Is(runErr, clients.InsufficientCapacityErr) {
+			break
+		}
+		recordCapacityFailure(ctx, runErr, models.ProviderTypeAWS, detail.Region, "", string(req.InstanceType))
+		logger.Warn().Err(runErr).Msgf("Insufficient capacity of %s, retrying with %s", req.InstanceType, fallback)
+
+		req.InstanceType = types.InstanceType(fallback)
+		instances, awsReservationId, runErr = runInstancesInSubnetsAWS(ctx, ec2Client, req, detail, reservation)
+	}
+	return instances, awsReservationId, runErr
+}
+
+// runInstancesInSubnetsAWS launches instances in the requested subnets in order while nothing was
+// launched due to capacity, the default subnet is used when no subnet was requested. The subnet of
+// the last attempt is left in the request.
+func runInstancesInSubnetsAWS(ctx context.Context, ec2Client clients.EC2, req *clients.AWSInstanceParams, detail *models.AWSDetail, reservation *models.AWSReservation) ([]*string, *string, error) {
+	if len(detail.SubnetIDs) == 0 {
+		return runInstancesAWS(ctx, ec2Client, req, detail, reservation)
+	}
+
+	logger := zerolog.Ctx(ctx)
+	var instances []*string
+	var awsReservationId *string
+	var runErr error
+	for i, subnet := range detail.SubnetIDs {
+		if i > 0 {
+			if len(instances) > 0 || !errors.Is(runErr, clients.InsufficientCapacityErr) {
+				break
+			}
+			logger.Warn().Err(runErr).Msgf("Insufficient capacity of %s in %s, retrying in %s", req.InstanceType, req.SubnetID, subnet)
+		}
+
+		req.SubnetID = subnet
+		instances, awsReservationId, runErr = runInstancesAWS(ctx, ec2Client, req, detail, reservation)
+	}
+	return instances, awsReservationId, runErr
+}
+
+// runInstancesAWS launches all instances at once. Instances with static private IPs are launched
+// one by one, the reservation ID of the first launch is returned together with instances launched
+// before an error.
+func runInstancesAWS(ctx context.Context, ec2Client clients.EC2, req *clients.AWSInstanceParams, detail *models.AWSDetail, reservation *models.AWSReservation) ([]*string, *string, error) {
+	if len(detail.PrivateIPs) == 0 {
+		ids, rid, err := ec2Client.RunInstances(ctx, req, detail.Amount, detail.Name, reservation)
+		recordProviderCall(ctx, reservation.ID, "RunInstances", map[string]string{
+			"instance_type": string(req.InstanceType),
+			"amount":        strconv.Itoa(int(detail.Amount)),
+		}, err)
+		return ids, rid, err
+	}
+
+	var instances []*string
+	var awsReservationId *string
+	for _, ip := range detail.PrivateIPs {
+		params := *req
+		params.NetworkInterfaces = append([]models.NetworkInterface{}, req.NetworkInterfaces...)
+		params.NetworkInterfaces[0].PrivateIPv4 = ip
+
+		ids, rid, err := ec2Client.RunInstances(ctx, &params, 1, detail.Name, reservation)
+		recordProviderCall(ctx, reservation.ID, "RunInstances", map[string]string{
+			"instance_type": string(req.InstanceType),
+			"private_ip":    ip,
+		}, err)
+		if err != nil {
+			return instances, awsReservationId, fmt.Errorf("instance with private IP %s: %w", ip, err)
+		}
+		instances = append(instances, ids...)
+		if awsReservationId == nil {
+			awsReservationId = rid
+		}
+	}
+	return instances, awsReservationId, nil
+}
+
+// ErrSpotFulfillmentTimeout is returned when spot requests are not fulfilled in the configured time
+var ErrSpotFulfillmentTimeout = errors.New("spot requests were not fulfilled in time")
+
+// spotPollInterval is the delay between checks of spot requests
+const spotPollInterval = 5 * time.Second
+
+// isSpotFailure returns true for errors of spot launches which can be solved by on-demand instances.
+func isSpotFailure(err error) bool {
+	return errors.Is(err, clients.SpotUnavailableErr) ||
+		errors.Is(err, clients.InsufficientCapacityErr) ||
+		errors.Is(err, ErrSpotFulfillmentTimeout)
+}
+
+// waitForSpotRequests waits until spot requests of all instances are fulfilled and returns request
+// IDs by instance ID. ErrSpotFulfillmentTimeout is returned when some requests are still open after
+// the configured timeout and SpotUnavailableErr when a request failed. Found request IDs are returned
+// together with errors, so the requests can be canceled.
+func waitForSpotRequests(ctx context.Context, ec2Client clients.EC2, instances []*string) (map[string]string, error) {
+	logger := zerolog.Ctx(ctx)
+	instanceIds := make([]string, len(instances))
+	for i, id := range instances {
+		instanceIds[i] = *id
+	}
+
+	requestIds := make(map[string]string, len(instanceIds))
+	deadline := time.Now().Add(config.AWS.SpotTimeout)
+	for {
+		requests, err := ec2Client.DescribeSpotRequests(ctx, instanceIds)
+		if err != nil {
+			return requestIds, fmt.Errorf("cannot describe spot requests: %w", err)
+		}
+
+		fulfilled := 0
+		for _, request := range requests {
+			requestIds[request.InstanceID] = request.ID
+			if request.Fulfilled() {
+				fulfilled++
+			} else if !request.Pending() {
+				return requestIds, fmt.Errorf("%w: request %s is %s (%s)", clients.SpotUnavailableErr, request.ID, request.State, request.Status)
+			}
+		}
+		if fulfilled == len(instanceIds) {
+			return requestIds, nil
+		}
+
+		if time.Now().After(deadline) {
+			return requestIds, fmt.Errorf("%w: %d out of %d fulfilled", ErrSpotFulfillmentTimeout, fulfilled, len(instanceIds))
+		}
+		logger.Debug().Msgf("Waiting for spot requests, %d out of %d fulfilled", fulfilled, len(instanceIds))
+		select {
+		case <-ctx.Done():
+			return requestIds, fmt.Errorf("cannot wait for spot requests: %w", ctx.Err())
+		case <-time.After(spotPollInterval):
+		}
+	}
+}
+
+// cancelSpotInstances cancels spot requests and terminates instances of a failed spot launch, errors
+// are only logged as the launch already failed.
+func cancelSpotInstances(ctx context.Context, ec2Client clients.EC2, reservationId int64, instances []*string, requests map[string]string) {
+	instanceIds := make([]string, len(instances))
+	for i, id := range instances {
+		instanceIds[i] = *id
+	}
+	requestIds := make([]string, 0, len(requests))
+	for _, id := range requests {
+		requestIds = append(requestIds, id)
+	}
+	sort.Strings(requestIds)
+
+	err := ec2Client.CancelSpotInstances(ctx, requestIds, instanceIds)
+	recordProviderCall(ctx, reservationId, "CancelSpotInstances", map[string]string{
+		"instances": strings.Join(instanceIds, ","),
+	}, err)
+	if err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Strs("instance_ids", instanceIds).Msg("Unable to cancel spot instances")
+	}
+}
+
 func FetchInstancesDescriptionAWS(ctx context.Context, args *LaunchInstanceAWSTaskArgs) error {
 	logger := zerolog.Ctx(ctx)
 	logger.Debug().Msg("Started fetch instances description")
@@ -254,25 +581,9 @@ func FetchInstancesDescriptionAWS(ctx context.Context, args *LaunchInstanceAWSTa
 		return fmt.Errorf("cannot create new ec2 client from config: %w", err)
 	}
 
-	err = waitAndRetry(ctx, func() error {
-		instancesDescriptionList, errRetry := ec2Client.DescribeInstanceDetails(ctx, instancesIDList)
-		if errRetry != nil {
-			return fmt.Errorf("cannot get list instances description: %w", errRetry)
-		}
-
-		if len(instancesDescriptionList) == 0 {
-			return ErrTryAgain
-		}
-
-		for _, instance := range instancesDescriptionList {
-			errRetry := rDao.UpdateReservationInstance(ctx, args.ReservationID, instance)
-			if errRetry != nil {
-				return fmt.Errorf("cannot update instance description: %w", err)
-			}
-		}
-		return nil
-	}, 1000, 500, 500, 1000, 2000)
-
+	err = pollInstanceAddresses(ctx, args.ReservationID, instancesIDList, func(ids []string) ([]*clients.InstanceDescription, error) {
+		return ec2Client.DescribeInstanceDetails(ctx, ids)
+	})
 	if err != nil {
 		return fmt.Errorf("giving up: %w", err)
 	}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/RHEnVision/provisioning-backend/internal/clients"
 	clientStubs "github.com/RHEnVision/provisioning-backend/internal/clients/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
 	"github.com/RHEnVision/provisioning-backend/internal/dao"
 	daoStubs "github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
 	"github.com/RHEnVision/provisioning-backend/internal/jobs"
@@ -152,3 +153,262 @@ func TestHandleEnsurePubkeyOnAWS(t *testing.T) {
 		assert.Equal(t, 1, len(pkrList))
 	})
 }
+
+func TestDoLaunchInstanceAWSFallback(t *testing.T) {
+	ctx := prepareEC2Context(t)
+	pk := factories.NewPubkeyRSA()
+	err := daoStubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to add stubbed key")
+
+	reservation := prepareAWSReservation(t, ctx, pk)
+	reservation.Detail.InstanceType = clientStubs.NoCapacityInstanceType
+	reservation.Detail.FallbackInstanceTypes = []string{"t3.large", "t3.xlarge"}
+	rDao := dao.GetReservationDao(ctx)
+	err = rDao.CreateAWS(ctx, reservation)
+	require.NoError(t, err, "failed to add stubbed reservation")
+
+	args := &jobs.LaunchInstanceAWSTaskArgs{
+		ReservationID: reservation.ID,
+		Region:        reservation.Detail.Region,
+		PubkeyID:      pk.ID,
+		SourceID:      reservation.SourceID,
+		Detail:        reservation.Detail,
+		ARN:           &clients.Authentication{ProviderType: models.ProviderTypeAWS, Payload: "arn:aws:123123123123"},
+	}
+
+	err = jobs.DoLaunchInstanceAWS(ctx, args)
+	require.NoError(t, err, "the launch instance job failed to run")
+
+	resAfter, err := rDao.GetAWSById(ctx, reservation.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "t3.large", resAfter.Detail.LaunchedInstanceType)
+}
+
+func TestDoLaunchInstanceAWSSubnets(t *testing.T) {
+	ctx := prepareEC2Context(t)
+	pk := factories.NewPubkeyRSA()
+	err := daoStubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to add stubbed key")
+
+	reservation := prepareAWSReservation(t, ctx, pk)
+	reservation.Detail.SubnetIDs = []string{clientStubs.NoCapacitySubnetID, "subnet-0f3c5a9b2e8d1c4a6"}
+	reservation.Detail.SecurityGroupIDs = []string{"sg-07a7a4c3f5d6e8b91"}
+	rDao := dao.GetReservationDao(ctx)
+	err = rDao.CreateAWS(ctx, reservation)
+	require.NoError(t, err, "failed to add stubbed reservation")
+
+	args := &jobs.LaunchInstanceAWSTaskArgs{
+		ReservationID: reservation.ID,
+		Region:        reservation.Detail.Region,
+		PubkeyID:      pk.ID,
+		SourceID:      reservation.SourceID,
+		Detail:        reservation.Detail,
+		ARN:           &clients.Authentication{ProviderType: models.ProviderTypeAWS, Payload: "arn:aws:123123123123"},
+	}
+
+	err = jobs.DoLaunchInstanceAWS(ctx, args)
+	require.NoError(t, err, "the launch instance job failed to run")
+
+	resAfter, err := rDao.GetAWSById(ctx, reservation.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "subnet-0f3c5a9b2e8d1c4a6", resAfter.Detail.LaunchedSubnetID)
+}
+
+func TestDoLaunchInstanceAWSMarketplace(t *testing.T) {
+	ctx := prepareEC2Context(t)
+	pk := factories.NewPubkeyRSA()
+	err := daoStubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to add stubbed key")
+
+	reservation := prepareAWSReservation(t, ctx, pk)
+	rDao := dao.GetReservationDao(ctx)
+	err = rDao.CreateAWS(ctx, reservation)
+	require.NoError(t, err, "failed to add stubbed reservation")
+
+	args := &jobs.LaunchInstanceAWSTaskArgs{
+		ReservationID: reservation.ID,
+		Region:        reservation.Detail.Region,
+		PubkeyID:      pk.ID,
+		SourceID:      reservation.SourceID,
+		Detail:        reservation.Detail,
+		AMI:           clientStubs.UnsubscribedMarketplaceAMI,
+		ARN:           &clients.Authentication{ProviderType: models.ProviderTypeAWS, Payload: "arn:aws:123123123123"},
+	}
+
+	err = jobs.DoLaunchInstanceAWS(ctx, args)
+	require.ErrorIs(t, err, clients.MarketplaceSubscriptionErr)
+	assert.Contains(t, err.Error(), "https://aws.amazon.com/marketplace/pp?sku=cpkwgozd4by0cmoinfftz1qqr")
+}
+
+func TestDoLaunchInstanceAWSSpot(t *testing.T) {
+	ctx := prepareEC2Context(t)
+	pk := factories.NewPubkeyRSA()
+	err := daoStubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to add stubbed key")
+	rDao := dao.GetReservationDao(ctx)
+
+	launch := func(t *testing.T, spot *models.AWSSpot) (*models.AWSReservation, error) {
+		t.Helper()
+		reservation := prepareAWSReservation(t, ctx, pk)
+		reservation.Detail.Spot = spot
+		err := rDao.CreateAWS(ctx, reservation)
+		require.NoError(t, err, "failed to add stubbed reservation")
+
+		args := &jobs.LaunchInstanceAWSTaskArgs{
+			ReservationID: reservation.ID,
+			Region:        reservation.Detail.Region,
+			PubkeyID:      pk.ID,
+			SourceID:      reservation.SourceID,
+			Detail:        reservation.Detail,
+			ARN:           &clients.Authentication{ProviderType: models.ProviderTypeAWS, Payload: "arn:aws:123123123123"},
+		}
+		return reservation, jobs.DoLaunchInstanceAWS(ctx, args)
+	}
+
+	t.Run("fulfilled", func(t *testing.T) {
+		reservation, err := launch(t, &models.AWSSpot{MaxPrice: "0.05"})
+		require.NoError(t, err, "the launch instance job failed to run")
+
+		instances, err := rDao.ListInstances(ctx, reservation.ID)
+		require.NoError(t, err)
+		require.Len(t, instances, 1)
+		assert.NotEmpty(t, instances[0].SpotRequestID)
+
+		resAfter, err := rDao.GetAWSById(ctx, reservation.ID)
+		require.NoError(t, err)
+		assert.False(t, resAfter.Detail.LaunchedOnDemand)
+	})
+
+	t.Run("unavailable with fallback", func(t *testing.T) {
+		reservation, err := launch(t, &models.AWSSpot{MaxPrice: clientStubs.SpotUnavailablePrice, FallbackOnDemand: true})
+		require.NoError(t, err, "the launch instance job failed to run")
+
+		instances, err := rDao.ListInstances(ctx, reservation.ID)
+		require.NoError(t, err)
+		require.Len(t, instances, 1)
+		assert.Empty(t, instances[0].SpotRequestID)
+
+		resAfter, err := rDao.GetAWSById(ctx, reservation.ID)
+		require.NoError(t, err)
+		assert.True(t, resAfter.Detail.LaunchedOnDemand)
+	})
+
+	t.Run("unavailable", func(t *testing.T) {
+		_, err := launch(t, &models.AWSSpot{MaxPrice: clientStubs.SpotUnavailablePrice})
+		require.ErrorIs(t, err, clients.SpotUnavailableErr)
+	})
+
+	t.Run("not fulfilled in time", func(t *testing.T) {
+		_, err := launch(t, &models.AWSSpot{MaxPrice: clientStubs.SpotPendingPrice})
+		require.ErrorIs(t, err, jobs.ErrSpotFulfillmentTimeout)
+	})
+
+	t.Run("not fulfilled in time with fallback", func(t *testing.T) {
+		reservation, err := launch(t, &models.AWSSpot{MaxPrice: clientStubs.SpotPendingPrice, FallbackOnDemand: true})
+		require.NoError(t, err, "the launch instance job failed to run")
+
+		instances, err := rDao.ListInstances(ctx, reservation.ID)
+		require.NoError(t, err)
+		require.Len(t, instances, 1)
+		assert.NotEqual(t, clientStubs.SpotPendingInstanceID, instances[0].InstanceID)
+	})
+}
+
+func TestDoLaunchInstanceAWSFleet(t *testing.T) {
+	ctx := prepareEC2Context(t)
+	pk := factories.NewPubkeyRSA()
+	err := daoStubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to add stubbed key")
+	rDao := dao.GetReservationDao(ctx)
+
+	config.AWS.FleetThreshold = 2
+	defer func() { config.AWS.FleetThreshold = 0 }()
+
+	launch := func(t *testing.T, amount int32) *models.AWSReservation {
+		t.Helper()
+		reservation := prepareAWSReservation(t, ctx, pk)
+		reservation.Detail.Amount = amount
+		reservation.Detail.InstanceType = clientStubs.NoCapacityInstanceType
+		reservation.Detail.FallbackInstanceTypes = []string{"t3.large"}
+		err := rDao.CreateAWS(ctx, reservation)
+		require.NoError(t, err, "failed to add stubbed reservation")
+
+		args := &jobs.LaunchInstanceAWSTaskArgs{
+			ReservationID: reservation.ID,
+			Region:        reservation.Detail.Region,
+			PubkeyID:      pk.ID,
+			SourceID:      reservation.SourceID,
+			Detail:        reservation.Detail,
+			ARN:           &clients.Authentication{ProviderType: models.ProviderTypeAWS, Payload: "arn:aws:123123123123"},
+		}
+		err = jobs.DoLaunchInstanceAWS(ctx, args)
+		require.NoError(t, err, "the launch instance job failed to run")
+		return reservation
+	}
+
+	t.Run("over threshold", func(t *testing.T) {
+		reservation := launch(t, 3)
+
+		instances, err := rDao.ListInstances(ctx, reservation.ID)
+		require.NoError(t, err)
+		require.Len(t, instances, 3)
+		for _, instance := range instances {
+			assert.Equal(t, "t3.large", instance.FleetAllocation.InstanceType)
+			assert.Equal(t, "subnet-0f3c5a9b2e8d1c4a6", instance.FleetAllocation.SubnetID)
+			assert.Equal(t, "on-demand", instance.FleetAllocation.Lifecycle)
+		}
+
+		resAfter, err := rDao.GetAWSById(ctx, reservation.ID)
+		require.NoError(t, err)
+		assert.Empty(t, resAfter.Detail.LaunchedInstanceType)
+	})
+
+	t.Run("under threshold", func(t *testing.T) {
+		reservation := launch(t, 2)
+
+		instances, err := rDao.ListInstances(ctx, reservation.ID)
+		require.NoError(t, err)
+		require.Len(t, instances, 1)
+		assert.True(t, instances[0].FleetAllocation.Empty())
+	})
+}
+
+func TestFetchInstancesDescriptionAWS(t *testing.T) {
+	ctx := prepareEC2Context(t)
+	pk := factories.NewPubkeyRSA()
+	err := daoStubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to add stubbed key")
+
+	reservation := prepareAWSReservation(t, ctx, pk)
+	rDao := dao.GetReservationDao(ctx)
+	err = rDao.CreateAWS(ctx, reservation)
+	require.NoError(t, err, "failed to add stubbed reservation")
+	for _, id := range []string{"i-0a4caa2cf5b097ce1", clientStubs.AddressPendingInstanceID} {
+		err = rDao.CreateInstance(ctx, &models.ReservationInstance{ReservationID: reservation.ID, InstanceID: id})
+		require.NoError(t, err, "failed to add stubbed instance")
+	}
+
+	// pending instances are described only once
+	timeout := config.Worker.AddressTimeout
+	config.Worker.AddressTimeout = 0
+	defer func() { config.Worker.AddressTimeout = timeout }()
+
+	args := &jobs.LaunchInstanceAWSTaskArgs{
+		ReservationID: reservation.ID,
+		Region:        reservation.Detail.Region,
+		ARN:           &clients.Authentication{ProviderType: models.ProviderTypeAWS, Payload: "arn:aws:123123123123"},
+	}
+	err = jobs.FetchInstancesDescriptionAWS(ctx, args)
+	require.NoError(t, err, "fetch instances description failed to run")
+
+	instances, err := rDao.ListInstances(ctx, reservation.ID)
+	require.NoError(t, err)
+	require.Len(t, instances, 2)
+	assert.Equal(t, models.ReservationInstanceDetail{
+		PublicDNS:   "ec2-51-83-81-17.compute-1.amazonaws.com",
+		PublicIPv4:  "54.11.88.17",
+		PrivateDNS:  "ip-172-31-5-17.ec2.internal",
+		PrivateIPv4: "172.31.5.17",
+	}, instances[0].Detail)
+	assert.Equal(t, models.ReservationInstanceDetail{PrivateIPv4: "172.31.5.18"}, instances[1].Detail)
+}
@@ -3,9 +3,12 @@ package jobs
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/RHEnVision/provisioning-backend/internal/clients"
 	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/logging"
 	"github.com/RHEnVision/provisioning-backend/internal/models"
 	"github.com/RHEnVision/provisioning-backend/internal/notifications"
 	"github.com/RHEnVision/provisioning-backend/internal/userdata"
@@ -51,25 +54,32 @@ func HandleLaunchInstanceAzure(ctx context.Context, job *worker.Job) {
 		return
 	}
 
-	logger := zerolog.Ctx(ctx).With().Int64("reservation_id", args.ReservationID).Logger()
-	ctx = logger.WithContext(ctx)
+	ctx = logging.WithReservationId(ctx, args.ReservationID)
+	logger := zerolog.Ctx(ctx)
+	recordDequeued(ctx, args.ReservationID, job)
 
 	logger.Info().Msg("Started launch instance Azure job")
 	ctx, span := otel.Tracer(TraceName).Start(ctx, "LaunchInstanceAzureJob")
 	defer span.End()
 	nc := notifications.GetNotificationClient(ctx)
-	jobErr := DoEnsureAzureResourceGroup(ctx, &args)
+	jobErr := DoEnsureAzureResourceGroup(stepContext(ctx, stepEnsureResourceGroup), &args)
 	if jobErr != nil {
 		finishWithError(ctx, args.ReservationID, jobErr)
-		nc.FailedLaunch(ctx, args.ReservationID, jobErr)
+		nc.FailedLaunch(stepContext(ctx, stepNotification), args.ReservationID, jobErr)
 		return
 	}
 
-	jobErr = DoLaunchInstanceAzure(ctx, &args)
+	jobErr = DoLaunchInstanceAzure(stepContext(ctx, stepLaunchInstances), &args)
+	if jobErr == nil {
+		jobErr = DoCreateDNSRecordsAzure(stepContext(ctx, stepCreateDNSRecords), &args)
+	}
+	if jobErr == nil {
+		jobErr = DoProbeInstancesAzure(stepContext(ctx, stepProbeInstances), &args)
+	}
 	if jobErr != nil {
-		nc.FailedLaunch(ctx, args.ReservationID, jobErr)
+		nc.FailedLaunch(stepContext(ctx, stepNotification), args.ReservationID, jobErr)
 	} else {
-		nc.SuccessfulLaunch(ctx, args.ReservationID)
+		nc.SuccessfulLaunch(stepContext(ctx, stepNotification), args.ReservationID)
 	}
 	finishJob(ctx, args.ReservationID, jobErr)
 
@@ -131,30 +141,70 @@ func DoLaunchInstanceAzure(ctx context.Context, args *LaunchInstanceAzureTaskArg
 		span.SetStatus(codes.Error, "cannot instantiate Azure client")
 		return fmt.Errorf("failed to instantiate Azure client: %w", err)
 	}
+
+	// Marketplace images with a purchase plan can be launched only with accepted terms
+	var plan *clients.AzureImagePlan
+	if image, ok := clients.ParseAzureImageURN(args.AzureImageID); ok {
+		described, imageErr := azureClient.DescribeMarketplaceImage(ctx, location, image)
+		if imageErr != nil {
+			span.SetStatus(codes.Error, "cannot describe marketplace image")
+			return fmt.Errorf("cannot describe marketplace image: %w", imageErr)
+		}
+		if described.Plan != nil {
+			if termsErr := azureClient.AcceptMarketplaceTerms(ctx, described.Plan); termsErr != nil {
+				span.SetStatus(codes.Error, "cannot accept marketplace terms")
+				return fmt.Errorf("cannot accept marketplace terms: %w", termsErr)
+			}
+			plan = described.Plan
+		}
+	}
+
 	// Generate user data
 	userDataInput := userdata.UserData{
 		Type:         models.ProviderTypeAzure,
 		PowerOff:     reservation.Detail.PowerOff,
 		InsightsTags: true,
+		Custom:       reservation.Detail.UserData,
 	}
 	userData, err := userdata.GenerateUserData(&userDataInput)
 	if err != nil {
 		return fmt.Errorf("cannot generate user data: %w", err)
 	}
-	logger.Trace().Bool("userdata", true).Msg(string(userData))
+	logger.Trace().Int("userdata_size", len(userData)).Msg("Generated user data")
 
 	vmParams := clients.AzureInstanceParams{
+		ReservationID:     args.ReservationID,
 		Location:          location,
 		ResourceGroupName: resourceGroupName,
 		ImageID:           args.AzureImageID,
+		Plan:              plan,
 		Pubkey:            pubkey,
 		InstanceType:      clients.InstanceTypeName(reservation.Detail.InstanceSize),
 		UserData:          userData,
+
+		Zone:                      reservation.Detail.Zone,
+		ProximityPlacementGroupID: reservation.Detail.ProximityPlacementGroup,
+		SecurityType:              reservation.Detail.SecurityType,
+		SecureBoot:                reservation.Detail.SecureBoot,
+		VTPM:                      reservation.Detail.VTPM,
+		Hibernation:               reservation.Detail.Hibernation,
+		NetworkInterfaces:         reservation.Detail.NetworkInterfaces,
+		PrivateIPs:                reservation.Detail.PrivateIPs,
 	}
 
-	instanceDescriptions, err := azureClient.CreateVMs(ctx, vmParams, reservation.Detail.Amount, vmNamePrefix)
+	namePrefix := vmNamePrefix
+	if reservation.Detail.Name != "" {
+		namePrefix = reservation.Detail.Name
+	}
+	instanceDescriptions, err := azureClient.CreateVMs(ctx, vmParams, reservation.Detail.Amount, namePrefix)
+	recordProviderCall(ctx, args.ReservationID, "CreateVMs", map[string]string{
+		"instance_size": reservation.Detail.InstanceSize,
+		"amount":        strconv.FormatInt(reservation.Detail.Amount, 10),
+	}, err)
 	if err != nil {
 		span.SetStatus(codes.Error, "failed to create instances")
+		region, _, _ := strings.Cut(reservation.Detail.Location, "_")
+		recordCapacityFailure(ctx, err, models.ProviderTypeAzure, region, reservation.Detail.Zone, reservation.Detail.InstanceSize)
 		return fmt.Errorf("cannot create Azure instance: %w", err)
 	}
 
@@ -163,7 +213,9 @@ func DoLaunchInstanceAzure(ctx context.Context, args *LaunchInstanceAzureTaskArg
 			ReservationID: args.ReservationID,
 			InstanceID:    instanceDescription.ID,
 			Detail: models.ReservationInstanceDetail{
-				PublicIPv4: instanceDescription.PublicIPv4,
+				PublicIPv4:        instanceDescription.PublicIPv4,
+				PrivateIPv4:       instanceDescription.PrivateIPv4,
+				NetworkInterfaces: instanceDescription.NetworkInterfaces,
 			},
 		})
 		if err != nil {
@@ -110,3 +110,32 @@ func TestDoLaunchInstanceAzure(t *testing.T) {
 	assert.Equal(t, 2, len(resultInstances))
 	assert.NotEmpty(t, resultInstances[0].Detail.PublicIPv4)
 }
+
+func TestDoLaunchInstanceAzureMarketplacePlan(t *testing.T) {
+	ctx := prepareAzureContext(t)
+
+	pk := factories.NewPubkeyRSA()
+	err := daoStubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to add stubbed key")
+
+	res := prepareAzureReservation(t, ctx, pk)
+
+	rDao := dao.GetReservationDao(ctx)
+	err = rDao.CreateAzure(ctx, res)
+	require.NoError(t, err, "failed to add stubbed reservation")
+
+	args := &jobs.LaunchInstanceAzureTaskArgs{
+		AzureImageID:  "RedHat:" + clientStubs.AzureMarketplaceBYOSOffer + ":rhel-lvm92-gen2:latest",
+		Location:      "useast",
+		PubkeyID:      pk.ID,
+		ReservationID: res.ID,
+		SourceID:      "2",
+		Subscription:  clients.NewAuthentication("subUUID", models.ProviderTypeAzure),
+	}
+
+	err = jobs.DoLaunchInstanceAzure(ctx, args)
+	require.NoError(t, err, "launch instances failed to run")
+
+	assert.True(t, clientStubs.DidAcceptAzureMarketplaceTerms(ctx, "rhel-lvm92-gen2"))
+	assert.Equal(t, 1, clientStubs.CountStubAzureVMs(ctx))
+}
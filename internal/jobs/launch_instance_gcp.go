@@ -3,10 +3,12 @@ package jobs
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/RHEnVision/provisioning-backend/internal/clients"
 	_ "github.com/RHEnVision/provisioning-backend/internal/clients/http/gcp"
 	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/logging"
 	"github.com/RHEnVision/provisioning-backend/internal/models"
 	"github.com/RHEnVision/provisioning-backend/internal/notifications"
 	"github.com/RHEnVision/provisioning-backend/internal/ptr"
@@ -49,22 +51,28 @@ func HandleLaunchInstanceGCP(ctx context.Context, job *worker.Job) {
 		return
 	}
 
-	logger := zerolog.Ctx(ctx).With().Int64("reservation_id", args.ReservationID).Logger()
-	ctx = logger.WithContext(ctx)
+	ctx = logging.WithReservationId(ctx, args.ReservationID)
+	recordDequeued(ctx, args.ReservationID, job)
 	nc := notifications.GetNotificationClient(ctx)
 
-	jobErr := DoLaunchInstanceGCP(ctx, &args)
+	jobErr := DoLaunchInstanceGCP(stepContext(ctx, stepLaunchInstances), &args)
 	if jobErr != nil {
 		finishWithError(ctx, args.ReservationID, jobErr)
-		nc.FailedLaunch(ctx, args.ReservationID, jobErr)
+		nc.FailedLaunch(stepContext(ctx, stepNotification), args.ReservationID, jobErr)
 		return
 	}
 
-	jobErr = FetchInstancesDescriptionGCP(ctx, &args)
+	jobErr = FetchInstancesDescriptionGCP(stepContext(ctx, stepFetchInstances), &args)
+	if jobErr == nil {
+		jobErr = DoCreateDNSRecordsGCP(stepContext(ctx, stepCreateDNSRecords), &args)
+	}
+	if jobErr == nil {
+		jobErr = DoProbeInstancesGCP(stepContext(ctx, stepProbeInstances), &args)
+	}
 	if jobErr != nil {
-		nc.FailedLaunch(ctx, args.ReservationID, jobErr)
+		nc.FailedLaunch(stepContext(ctx, stepNotification), args.ReservationID, jobErr)
 	} else {
-		nc.SuccessfulLaunch(ctx, args.ReservationID)
+		nc.SuccessfulLaunch(stepContext(ctx, stepNotification), args.ReservationID)
 	}
 	finishJob(ctx, args.ReservationID, jobErr)
 }
@@ -73,7 +81,7 @@ func HandleLaunchInstanceGCP(ctx context.Context, job *worker.Job) {
 func DoLaunchInstanceGCP(ctx context.Context, args *LaunchInstanceGCPTaskArgs) error {
 	logger := zerolog.Ctx(ctx)
 	logger.Debug().Msg("Started launch instance GCP job")
-	logger.Info().Interface("args", args).Msg("Processing launch instance GCP job")
+	logger.Info().Interface("args", redactArgs(args)).Msg("Processing launch instance GCP job")
 
 	// status updates before and after the code logic
 	updateStatusBefore(ctx, args.ReservationID, "Launching instance(s)")
@@ -101,7 +109,7 @@ func DoLaunchInstanceGCP(ctx context.Context, args *LaunchInstanceGCPTaskArgs) e
 	if err != nil {
 		return fmt.Errorf("cannot generate user data: %w", err)
 	}
-	logger.Trace().Bool("userdata", true).Msg(string(userData))
+	logger.Trace().Int("userdata_size", len(userData)).Msg("Generated user data")
 	if args.Detail.NamePattern != nil {
 		name = fmt.Sprintf("%s-#####", *args.Detail.NamePattern)
 	}
@@ -112,12 +120,26 @@ func DoLaunchInstanceGCP(ctx context.Context, args *LaunchInstanceGCPTaskArgs) e
 		Zone:             args.Zone,
 		KeyBody:          pk.Body,
 		StartupScript:    string(userData),
+		UserData:         args.Detail.UserData,
 		ReservationID:    args.ReservationID,
 		UUID:             args.Detail.UUID,
 		LaunchTemplateID: args.LaunchTemplateID,
+
+		ServiceAccountEmail: args.Detail.ServiceAccount,
+		Scopes:              args.Detail.Scopes,
+
+		ShieldedSecureBoot:          args.Detail.ShieldedSecureBoot,
+		ShieldedVTPM:                args.Detail.ShieldedVTPM,
+		ShieldedIntegrityMonitoring: args.Detail.ShieldedIntegrityMonitoring,
+
+		Addressing: args.Detail.Addressing,
 	}
 
 	instances, opName, err := gcpClient.InsertInstances(ctx, params, args.Detail.Amount)
+	recordProviderCall(ctx, args.ReservationID, "InsertInstances", map[string]string{
+		"machine_type": args.Detail.MachineType,
+		"amount":       strconv.FormatInt(args.Detail.Amount, 10),
+	}, err)
 	if err != nil {
 		return fmt.Errorf("cannot run instances for gcp client: %w", err)
 	}
@@ -152,8 +174,6 @@ func FetchInstancesDescriptionGCP(ctx context.Context, args *LaunchInstanceGCPTa
 	updateStatusBefore(ctx, args.ReservationID, "Fetching instances description")
 	defer updateStatusAfter(ctx, args.ReservationID, "Fetched instances description", 1)
 
-	rDao := dao.GetReservationDao(ctx)
-
 	gcpClient, err := clients.GetGCPClient(ctx, args.ProjectID)
 	if err != nil {
 		return fmt.Errorf("cannot get gcp client: %w", err)
@@ -163,33 +183,26 @@ func FetchInstancesDescriptionGCP(ctx context.Context, args *LaunchInstanceGCPTa
 		return fmt.Errorf("cannot list instances ids by tag: %w", err)
 	}
 
-	for _, id := range ids {
-		var instanceDesc *clients.InstanceDescription
-		err = waitAndRetry(ctx, func() error {
-			instanceDesc, err = gcpClient.GetInstanceDescriptionByID(ctx, *id, args.Zone)
+	instanceIds := make([]string, len(ids))
+	for i, id := range ids {
+		instanceIds[i] = *id
+	}
 
+	err = pollInstanceAddresses(ctx, args.ReservationID, instanceIds, func(ids []string) ([]*clients.InstanceDescription, error) {
+		var result []*clients.InstanceDescription
+		for _, id := range ids {
+			instanceDesc, err := gcpClient.GetInstanceDescriptionByID(ctx, id, args.Zone)
 			if err != nil {
-				return fmt.Errorf("cannot get instance description : %w", err)
-			}
-
-			if instanceDesc.PublicIPv4 == "" {
-				return ErrTryAgain
+				// try to get the others
+				logger.Warn().Err(err).Str("instance_id", id).Msg("Cannot get instance description")
+				continue
 			}
-
-			return nil
-		}, 1, 500, 500, 1000, 2000, 2000)
-
-		if err != nil {
-			logger.Error().Err(err).Str("instance_id", *id).Msg("Cannot get instance description, skipping")
-
-			// try to get the others
-			continue
-		}
-
-		err = rDao.UpdateReservationInstance(ctx, args.ReservationID, instanceDesc)
-		if err != nil {
-			return fmt.Errorf("cannot update instance description: %w", err)
+			result = append(result, instanceDesc)
 		}
+		return result, nil
+	})
+	if err != nil {
+		return fmt.Errorf("giving up: %w", err)
 	}
 
 	return nil
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/RHEnVision/provisioning-backend/internal/logging"
 	"github.com/RHEnVision/provisioning-backend/internal/notifications"
 	"github.com/RHEnVision/provisioning-backend/pkg/worker"
 	"github.com/rs/zerolog"
@@ -28,15 +29,15 @@ func HandleNoop(ctx context.Context, job *worker.Job) {
 		return
 	}
 
-	logger := zerolog.Ctx(ctx).With().Int64("reservation_id", args.ReservationID).Logger()
-	ctx = logger.WithContext(ctx)
+	ctx = logging.WithReservationId(ctx, args.ReservationID)
+	recordDequeued(ctx, args.ReservationID, job)
 	nc := notifications.GetNotificationClient(ctx)
 
 	jobErr := DoNoop(ctx, &args)
 	if jobErr != nil {
-		nc.FailedLaunch(ctx, args.ReservationID, jobErr)
+		nc.FailedLaunch(stepContext(ctx, stepNotification), args.ReservationID, jobErr)
 	} else {
-		nc.SuccessfulLaunch(ctx, args.ReservationID)
+		nc.SuccessfulLaunch(stepContext(ctx, stepNotification), args.ReservationID)
 	}
 
 	finishJob(ctx, args.ReservationID, jobErr)
@@ -0,0 +1,177 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/logging"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/pkg/worker"
+	"github.com/rs/zerolog"
+)
+
+// PowerAction is the operation of a power instance job.
+type PowerAction string
+
+const (
+	PowerActionStop  PowerAction = "stop"
+	PowerActionStart PowerAction = "start"
+)
+
+var ErrUnknownPowerAction = errors.New("unknown power action")
+
+type PowerInstanceTaskArgs struct {
+	// Associated reservation
+	ReservationID int64
+
+	// Instance to stop or start
+	InstanceID string
+
+	// Stop or start
+	Action PowerAction
+
+	// AWS region of the instance
+	Region string
+
+	// GCP zone of the instance
+	Zone string
+
+	// Authentication fetched from Sources which is linked to a specific source
+	ARN *clients.Authentication
+}
+
+// Unmarshall arguments and handle error
+func HandlePowerInstanceAWS(ctx context.Context, job *worker.Job) {
+	handlePowerInstance(ctx, job, DoPowerInstanceAWS)
+}
+
+// Unmarshall arguments and handle error
+func HandlePowerInstanceAzure(ctx context.Context, job *worker.Job) {
+	handlePowerInstance(ctx, job, DoPowerInstanceAzure)
+}
+
+// Unmarshall arguments and handle error
+func HandlePowerInstanceGCP(ctx context.Context, job *worker.Job) {
+	handlePowerInstance(ctx, job, DoPowerInstanceGCP)
+}
+
+func handlePowerInstance(ctx context.Context, job *worker.Job, fn func(context.Context, *PowerInstanceTaskArgs) error) {
+	args, ok := job.Args.(PowerInstanceTaskArgs)
+	if !ok {
+		err := fmt.Errorf("%w: job %s, reservation: %#v", ErrTypeAssertion, job.ID, job.Args)
+		zerolog.Ctx(ctx).Error().Err(err).Msg("Type assertion error for job")
+		return
+	}
+
+	ctx = logging.WithReservationId(ctx, args.ReservationID)
+	logger := zerolog.Ctx(ctx).With().Str("instance_id", args.InstanceID).Logger()
+	ctx = logger.WithContext(ctx)
+
+	jobErr := fn(stepContext(ctx, stepPowerInstance), &args)
+	finishPowerInstance(ctx, &args, jobErr)
+}
+
+// finishPowerInstance stores the resulting power state of the instance. The state is unknown
+// when the operation failed, the instance may or may not have changed its state.
+func finishPowerInstance(ctx context.Context, args *PowerInstanceTaskArgs, jobErr error) {
+	logger := zerolog.Ctx(ctx)
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		// the original context is expired and unusable at this point
+		ctx = copyContext(ctx)
+	}
+
+	state := models.PowerStateRunning
+	if args.Action == PowerActionStop {
+		state = models.PowerStateStopped
+	}
+	if jobErr != nil {
+		logger.Error().Err(jobErr).Msgf("Unable to %s instance", args.Action)
+		state = models.PowerStateUnknown
+	} else {
+		logger.Info().Msgf("Instance power state changed to %s", state)
+	}
+
+	err := dao.GetReservationDao(ctx).UpdateInstancePowerState(ctx, args.ReservationID, args.InstanceID, state)
+	if err != nil {
+		logger.Warn().Err(err).Msg("unable to update instance power state")
+	}
+}
+
+// Job logic, when error is returned the power state is set to unknown
+func DoPowerInstanceAWS(ctx context.Context, args *PowerInstanceTaskArgs) error {
+	logger := zerolog.Ctx(ctx)
+	logger.Debug().Msgf("Started %s instance AWS job", args.Action)
+
+	ec2Client, err := clients.GetEC2Client(ctx, args.ARN, args.Region)
+	if err != nil {
+		return fmt.Errorf("cannot create new ec2 client from config: %w", err)
+	}
+
+	switch args.Action {
+	case PowerActionStop:
+		err = ec2Client.StopInstances(ctx, []string{args.InstanceID})
+	case PowerActionStart:
+		err = ec2Client.StartInstances(ctx, []string{args.InstanceID})
+	default:
+		err = fmt.Errorf("%w: %s", ErrUnknownPowerAction, args.Action)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot %s instance: %w", args.Action, err)
+	}
+
+	return nilUnlessTimeout(ctx)
+}
+
+// Job logic, when error is returned the power state is set to unknown. Stopped Azure VMs are
+// deallocated, so they are not billed.
+func DoPowerInstanceAzure(ctx context.Context, args *PowerInstanceTaskArgs) error {
+	logger := zerolog.Ctx(ctx)
+	logger.Debug().Msgf("Started %s instance Azure job", args.Action)
+
+	azureClient, err := clients.GetAzureClient(ctx, args.ARN)
+	if err != nil {
+		return fmt.Errorf("cannot create new azure client: %w", err)
+	}
+
+	switch args.Action {
+	case PowerActionStop:
+		err = azureClient.DeallocateVM(ctx, args.InstanceID)
+	case PowerActionStart:
+		err = azureClient.StartVM(ctx, args.InstanceID)
+	default:
+		err = fmt.Errorf("%w: %s", ErrUnknownPowerAction, args.Action)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot %s instance: %w", args.Action, err)
+	}
+
+	return nilUnlessTimeout(ctx)
+}
+
+// Job logic, when error is returned the power state is set to unknown
+func DoPowerInstanceGCP(ctx context.Context, args *PowerInstanceTaskArgs) error {
+	logger := zerolog.Ctx(ctx)
+	logger.Debug().Msgf("Started %s instance GCP job", args.Action)
+
+	gcpClient, err := clients.GetGCPClient(ctx, args.ARN)
+	if err != nil {
+		return fmt.Errorf("cannot create new GCP client: %w", err)
+	}
+
+	switch args.Action {
+	case PowerActionStop:
+		err = gcpClient.StopInstance(ctx, args.InstanceID, args.Zone)
+	case PowerActionStart:
+		err = gcpClient.StartInstance(ctx, args.InstanceID, args.Zone)
+	default:
+		err = fmt.Errorf("%w: %s", ErrUnknownPowerAction, args.Action)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot %s instance: %w", args.Action, err)
+	}
+
+	return nilUnlessTimeout(ctx)
+}
@@ -0,0 +1,58 @@
+package jobs_test
+
+import (
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	daoStubs "github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/jobs"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/factories"
+	"github.com/RHEnVision/provisioning-backend/pkg/worker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlePowerInstanceAWS(t *testing.T) {
+	ctx := prepareEC2Context(t)
+	pk := factories.NewPubkeyRSA()
+	err := daoStubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to add stubbed key")
+	reservation := prepareAWSReservation(t, ctx, pk)
+	err = daoStubs.AddAWSReservation(ctx, reservation)
+	require.NoError(t, err, "failed to add stubbed reservation")
+
+	resDao := dao.GetReservationDao(ctx)
+	instance := &models.ReservationInstance{ReservationID: reservation.ID, InstanceID: "i-0a4caa2cf5b097ce1"}
+	err = resDao.CreateInstance(ctx, instance)
+	require.NoError(t, err, "failed to add stubbed instance")
+
+	powerJob := func(action jobs.PowerAction) *worker.Job {
+		return &worker.Job{
+			Type: jobs.TypePowerInstanceAws,
+			Args: jobs.PowerInstanceTaskArgs{
+				ReservationID: reservation.ID,
+				InstanceID:    instance.InstanceID,
+				Action:        action,
+				Region:        "us-east-1",
+				ARN:           &clients.Authentication{ProviderType: models.ProviderTypeAWS, Payload: "arn:aws:123123123123"},
+			},
+		}
+	}
+
+	t.Run("stop", func(t *testing.T) {
+		jobs.HandlePowerInstanceAWS(ctx, powerJob(jobs.PowerActionStop))
+		assert.Equal(t, models.PowerStateStopped, instance.PowerState)
+	})
+
+	t.Run("start", func(t *testing.T) {
+		jobs.HandlePowerInstanceAWS(ctx, powerJob(jobs.PowerActionStart))
+		assert.Equal(t, models.PowerStateRunning, instance.PowerState)
+	})
+
+	t.Run("unknown action", func(t *testing.T) {
+		jobs.HandlePowerInstanceAWS(ctx, powerJob("reboot"))
+		assert.Equal(t, models.PowerStateUnknown, instance.PowerState)
+	})
+}
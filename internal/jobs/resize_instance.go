@@ -0,0 +1,154 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/logging"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/pkg/worker"
+	"github.com/rs/zerolog"
+)
+
+type ResizeInstanceTaskArgs struct {
+	// Associated reservation
+	ReservationID int64
+
+	// Instance to resize, it must be stopped
+	InstanceID string
+
+	// New instance type (AWS), VM size (Azure) or machine type (GCP)
+	InstanceType string
+
+	// AWS region of the instance
+	Region string
+
+	// GCP zone of the instance
+	Zone string
+
+	// Authentication fetched from Sources which is linked to a specific source
+	ARN *clients.Authentication
+}
+
+// Unmarshall arguments and handle error
+func HandleResizeInstanceAWS(ctx context.Context, job *worker.Job) {
+	handleResizeInstance(ctx, job, DoResizeInstanceAWS)
+}
+
+// Unmarshall arguments and handle error
+func HandleResizeInstanceAzure(ctx context.Context, job *worker.Job) {
+	handleResizeInstance(ctx, job, DoResizeInstanceAzure)
+}
+
+// Unmarshall arguments and handle error
+func HandleResizeInstanceGCP(ctx context.Context, job *worker.Job) {
+	handleResizeInstance(ctx, job, DoResizeInstanceGCP)
+}
+
+func handleResizeInstance(ctx context.Context, job *worker.Job, fn func(context.Context, *ResizeInstanceTaskArgs) error) {
+	args, ok := job.Args.(ResizeInstanceTaskArgs)
+	if !ok {
+		err := fmt.Errorf("%w: job %s, reservation: %#v", ErrTypeAssertion, job.ID, job.Args)
+		zerolog.Ctx(ctx).Error().Err(err).Msg("Type assertion error for job")
+		return
+	}
+
+	ctx = logging.WithReservationId(ctx, args.ReservationID)
+	logger := zerolog.Ctx(ctx).With().Str("instance_id", args.InstanceID).Logger()
+	ctx = logger.WithContext(ctx)
+
+	jobErr := fn(stepContext(ctx, stepResizeInstance), &args)
+	finishResizeInstance(ctx, &args, jobErr)
+}
+
+// finishResizeInstance unlocks the stopped instance and records the change or the failed attempt
+// in the audit log with the original identity of the job.
+func finishResizeInstance(ctx context.Context, args *ResizeInstanceTaskArgs, jobErr error) {
+	logger := zerolog.Ctx(ctx)
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		// the original context is expired and unusable at this point
+		ctx = copyContext(ctx)
+	}
+
+	record := &models.AuditRecord{
+		ReservationID: args.ReservationID,
+		InstanceID:    args.InstanceID,
+		Action:        models.AuditActionInstanceResize,
+		Details:       map[string]string{"instance_type": args.InstanceType},
+	}
+	if jobErr != nil {
+		logger.Error().Err(jobErr).Msgf("Unable to resize instance to %s", args.InstanceType)
+		record.Action = models.AuditActionInstanceResizeFailed
+		record.Details["error"] = jobErr.Error()
+	} else {
+		logger.Info().Msgf("Instance resized to %s", args.InstanceType)
+	}
+
+	// the instance is stopped in both cases, the instance type is unchanged on failure
+	err := dao.GetReservationDao(ctx).UpdateInstancePowerState(ctx, args.ReservationID, args.InstanceID, models.PowerStateStopped, models.PowerStateResizing)
+	if err != nil {
+		logger.Warn().Err(err).Msg("unable to update instance power state")
+	}
+
+	err = dao.GetAuditDao(ctx).Create(ctx, record)
+	if err != nil {
+		logger.Warn().Err(err).Msg("unable to record instance resize in the audit log")
+	}
+}
+
+// Job logic, when error is returned the instance type was not changed
+func DoResizeInstanceAWS(ctx context.Context, args *ResizeInstanceTaskArgs) error {
+	logger := zerolog.Ctx(ctx)
+	logger.Debug().Msg("Started resize instance AWS job")
+
+	ec2Client, err := clients.GetEC2Client(ctx, args.ARN, args.Region)
+	if err != nil {
+		return fmt.Errorf("cannot create new ec2 client from config: %w", err)
+	}
+
+	err = ec2Client.ModifyInstanceType(ctx, args.InstanceID, args.InstanceType)
+	if err != nil {
+		return fmt.Errorf("cannot resize instance: %w", err)
+	}
+
+	return nilUnlessTimeout(ctx)
+}
+
+// Job logic, when error is returned the VM size was not changed
+func DoResizeInstanceAzure(ctx context.Context, args *ResizeInstanceTaskArgs) error {
+	logger := zerolog.Ctx(ctx)
+	logger.Debug().Msg("Started resize instance Azure job")
+
+	azureClient, err := clients.GetAzureClient(ctx, args.ARN)
+	if err != nil {
+		return fmt.Errorf("cannot create new azure client: %w", err)
+	}
+
+	err = azureClient.ResizeVM(ctx, args.InstanceID, args.InstanceType)
+	if err != nil {
+		return fmt.Errorf("cannot resize instance: %w", err)
+	}
+
+	return nilUnlessTimeout(ctx)
+}
+
+// Job logic, when error is returned the machine type was not changed
+func DoResizeInstanceGCP(ctx context.Context, args *ResizeInstanceTaskArgs) error {
+	logger := zerolog.Ctx(ctx)
+	logger.Debug().Msg("Started resize instance GCP job")
+
+	gcpClient, err := clients.GetGCPClient(ctx, args.ARN)
+	if err != nil {
+		return fmt.Errorf("cannot create new GCP client: %w", err)
+	}
+
+	err = gcpClient.SetMachineType(ctx, args.InstanceID, args.Zone, args.InstanceType)
+	if err != nil {
+		return fmt.Errorf("cannot resize instance: %w", err)
+	}
+
+	return nilUnlessTimeout(ctx)
+}
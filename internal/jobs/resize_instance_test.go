@@ -0,0 +1,74 @@
+package jobs_test
+
+import (
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	clientStubs "github.com/RHEnVision/provisioning-backend/internal/clients/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	daoStubs "github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/jobs"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/factories"
+	"github.com/RHEnVision/provisioning-backend/pkg/worker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleResizeInstanceAWS(t *testing.T) {
+	ctx := prepareEC2Context(t)
+	ctx = daoStubs.WithAuditDao(ctx)
+	pk := factories.NewPubkeyRSA()
+	err := daoStubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to add stubbed key")
+	reservation := prepareAWSReservation(t, ctx, pk)
+	err = daoStubs.AddAWSReservation(ctx, reservation)
+	require.NoError(t, err, "failed to add stubbed reservation")
+
+	instance := &models.ReservationInstance{ReservationID: reservation.ID, InstanceID: "i-0a4caa2cf5b097ce1", PowerState: models.PowerStateResizing}
+	err = dao.GetReservationDao(ctx).CreateInstance(ctx, instance)
+	require.NoError(t, err, "failed to add stubbed instance")
+
+	resize := func(instanceType string) {
+		job := &worker.Job{
+			Type: jobs.TypeResizeInstanceAws,
+			Args: jobs.ResizeInstanceTaskArgs{
+				ReservationID: reservation.ID,
+				InstanceID:    instance.InstanceID,
+				InstanceType:  instanceType,
+				Region:        "us-east-1",
+				ARN:           &clients.Authentication{ProviderType: models.ProviderTypeAWS, Payload: "arn:aws:123123123123"},
+			},
+		}
+		jobs.HandleResizeInstanceAWS(ctx, job)
+	}
+
+	t.Run("resized", func(t *testing.T) {
+		resize("t3.large")
+
+		records, err := dao.GetAuditDao(ctx).ListByReservation(ctx, reservation.ID, 100, 0)
+		require.NoError(t, err, "failed to list audit records")
+		require.Len(t, records, 1)
+		assert.Equal(t, models.AuditActionInstanceResize, records[0].Action)
+		assert.Equal(t, "i-0a4caa2cf5b097ce1", records[0].InstanceID)
+		assert.Equal(t, "t3.large", records[0].Details["instance_type"])
+		assert.Equal(t, models.PowerStateStopped, instance.PowerState, "expected the instance to be unlocked")
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		instance.PowerState = models.PowerStateResizing
+		resize(clientStubs.NoCapacityInstanceType)
+
+		records, err := dao.GetAuditDao(ctx).ListByReservation(ctx, reservation.ID, 100, 0)
+		require.NoError(t, err, "failed to list audit records")
+		require.Len(t, records, 2)
+		failed := records[0]
+		if failed.Action == models.AuditActionInstanceResize {
+			failed = records[1]
+		}
+		assert.Equal(t, models.AuditActionInstanceResizeFailed, failed.Action)
+		assert.Equal(t, clientStubs.NoCapacityInstanceType, failed.Details["instance_type"])
+		assert.NotEmpty(t, failed.Details["error"])
+		assert.Equal(t, models.PowerStateStopped, instance.PowerState, "expected the instance to be unlocked")
+	})
+}
@@ -0,0 +1,152 @@
+package jobs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/RHEnVision/provisioning-backend/pkg/worker"
+)
+
+var ErrUnknownJobType = errors.New("unknown job type")
+
+// storedJob is the JSON representation of a job stored in the database, arguments are decoded
+// according to the job type.
+type storedJob struct {
+	Type      worker.JobType     `json:"type"`
+	AccountID int64              `json:"account_id"`
+	Identity  identity.Principal `json:"identity"`
+	Args      json.RawMessage    `json:"args"`
+}
+
+// MarshalJob encodes job type, account, identity and arguments into JSON. The job ID is not
+// stored, every enqueued copy gets a new one.
+func MarshalJob(job *worker.Job) ([]byte, error) {
+	args, err := json.Marshal(job.Args)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal job args: %w", err)
+	}
+
+	data, err := json.Marshal(storedJob{
+		Type:      job.Type,
+		AccountID: job.AccountID,
+		Identity:  job.Identity,
+		Args:      args,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal job: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalJob decodes job stored via MarshalJob. Arguments are decoded into the same type the
+// job handler expects.
+func UnmarshalJob(data []byte) (*worker.Job, error) {
+	var stored storedJob
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal job: %w", err)
+	}
+
+	var args any
+	var err error
+	switch stored.Type {
+	case TypeNoop:
+		args, err = unmarshalArgs[NoopJobArgs](stored.Args)
+	case TypeLaunchInstanceAws:
+		args, err = unmarshalArgs[LaunchInstanceAWSTaskArgs](stored.Args)
+	case TypeLaunchInstanceAzure:
+		args, err = unmarshalArgs[LaunchInstanceAzureTaskArgs](stored.Args)
+	case TypeLaunchInstanceGcp:
+		args, err = unmarshalArgs[LaunchInstanceGCPTaskArgs](stored.Args)
+	case TypePowerInstanceAws, TypePowerInstanceAzure, TypePowerInstanceGcp:
+		args, err = unmarshalArgs[PowerInstanceTaskArgs](stored.Args)
+	case TypeResizeInstanceAws, TypeResizeInstanceAzure, TypeResizeInstanceGcp:
+		args, err = unmarshalArgs[ResizeInstanceTaskArgs](stored.Args)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownJobType, stored.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &worker.Job{
+		Type:      stored.Type,
+		AccountID: stored.AccountID,
+		Identity:  stored.Identity,
+		Args:      args,
+	}, nil
+}
+
+// redactedKeys are JSON keys of stored jobs which values are secrets or personal data (e.g. ARN or
+// service account of the source, user data scripts, name and email of the user), compared
+// case-insensitively.
+var redactedKeys = map[string]struct{}{
+	"payload":       {},
+	"password":      {},
+	"password_data": {},
+	"secret":        {},
+	"token":         {},
+	"user_data":     {},
+	"email":         {},
+	"username":      {},
+	"first_name":    {},
+	"last_name":     {},
+	"user_id":       {},
+	"entitlements":  {},
+}
+
+// RedactedValue replaces values of redacted keys.
+const RedactedValue = "[redacted]"
+
+// RedactJob decodes job stored via MarshalJob into a generic JSON map with secrets and personal data
+// replaced by RedactedValue, so it can be handed over to support.
+func RedactJob(data []byte) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal job: %w", err)
+	}
+	redact(result)
+	return result, nil
+}
+
+// redactArgs returns a generic JSON copy of job arguments with secrets and personal data replaced
+// by RedactedValue, so arguments can be logged. Arguments which cannot be encoded are replaced.
+func redactArgs(args any) any {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return RedactedValue
+	}
+	var result any
+	if err := json.Unmarshal(data, &result); err != nil {
+		return RedactedValue
+	}
+	redact(result)
+	return result
+}
+
+func redact(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if _, ok := redactedKeys[strings.ToLower(key)]; ok && item != nil {
+				v[key] = RedactedValue
+				continue
+			}
+			redact(item)
+		}
+	case []interface{}:
+		for _, item := range v {
+			redact(item)
+		}
+	}
+}
+
+func unmarshalArgs[T any](data json.RawMessage) (T, error) {
+	var args T
+	if err := json.Unmarshal(data, &args); err != nil {
+		return args, fmt.Errorf("unable to unmarshal job args: %w", err)
+	}
+	return args, nil
+}
@@ -0,0 +1,107 @@
+package jobs
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/pkg/worker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalJob(t *testing.T) {
+	principal := identity.Principal{}
+	principal.Identity.OrgID = "000013"
+	job := &worker.Job{
+		Type:      TypeLaunchInstanceAws,
+		AccountID: 1,
+		Identity:  principal,
+		Args: LaunchInstanceAWSTaskArgs{
+			ReservationID: 42,
+			Region:        "us-east-1",
+			Detail:        &models.AWSDetail{InstanceType: "t3.small", Amount: 2},
+			AMI:           "ami-0000000000000000",
+			ARN:           clients.NewAuthentication("arn:aws:iam::000000000000:role/test", models.ProviderTypeAWS),
+		},
+	}
+
+	data, err := MarshalJob(job)
+	require.NoError(t, err)
+
+	decoded, err := UnmarshalJob(data)
+	require.NoError(t, err)
+	assert.Equal(t, job.Type, decoded.Type)
+	assert.Equal(t, job.AccountID, decoded.AccountID)
+	assert.Equal(t, "000013", decoded.Identity.Identity.OrgID)
+	require.IsType(t, LaunchInstanceAWSTaskArgs{}, decoded.Args)
+	assert.Equal(t, job.Args, decoded.Args)
+}
+
+func TestMarshalJobNoop(t *testing.T) {
+	job := &worker.Job{Type: TypeNoop, Args: NoopJobArgs{ReservationID: 1, Sleep: time.Second}}
+
+	data, err := MarshalJob(job)
+	require.NoError(t, err)
+
+	decoded, err := UnmarshalJob(data)
+	require.NoError(t, err)
+	assert.Equal(t, job.Args, decoded.Args)
+}
+
+func TestRedactJob(t *testing.T) {
+	principal := identity.Principal{}
+	principal.Identity.OrgID = "000013"
+	principal.Identity.User.Email = "jdoe@example.com"
+	principal.Identity.User.Username = "jdoe"
+	principal.Identity.User.FirstName = "John"
+	principal.Identity.User.LastName = "Doe"
+	principal.Identity.User.UserID = "5412043"
+	job := &worker.Job{
+		Type:      TypeLaunchInstanceAws,
+		AccountID: 1,
+		Identity:  principal,
+		Args: LaunchInstanceAWSTaskArgs{
+			ReservationID: 42,
+			Region:        "us-east-1",
+			ARN:           clients.NewAuthentication("arn:aws:iam::000000000000:role/test", models.ProviderTypeAWS),
+			Detail:        &models.AWSDetail{UserData: "#!/bin/sh\necho db-password"},
+		},
+	}
+
+	data, err := MarshalJob(job)
+	require.NoError(t, err)
+
+	redacted, err := RedactJob(data)
+	require.NoError(t, err)
+	assert.NotContains(t, fmt.Sprint(redacted), "arn:aws:iam")
+	assert.NotContains(t, fmt.Sprint(redacted), "jdoe@example.com")
+	assert.NotContains(t, fmt.Sprint(redacted), "jdoe")
+	assert.NotContains(t, fmt.Sprint(redacted), "John")
+	assert.NotContains(t, fmt.Sprint(redacted), "Doe")
+	assert.NotContains(t, fmt.Sprint(redacted), "5412043")
+	assert.NotContains(t, fmt.Sprint(redacted), "db-password")
+	assert.Contains(t, fmt.Sprint(redacted), "000013")
+	assert.Equal(t, "us-east-1", redacted["args"].(map[string]interface{})["Region"])
+}
+
+func TestRedactArgs(t *testing.T) {
+	args := LaunchInstanceGCPTaskArgs{
+		ReservationID: 42,
+		Zone:          "us-east1-b",
+		Detail:        &models.GCPDetail{UserData: "#cloud-config\npassword: db-password"},
+	}
+
+	redacted := redactArgs(args)
+	assert.NotContains(t, fmt.Sprint(redacted), "db-password")
+	assert.Contains(t, fmt.Sprint(redacted), "us-east1-b")
+	assert.Equal(t, "#cloud-config\npassword: db-password", args.Detail.UserData)
+}
+
+func TestUnmarshalJobUnknownType(t *testing.T) {
+	_, err := UnmarshalJob([]byte(`{"type":"unknown","args":{}}`))
+	require.ErrorIs(t, err, ErrUnknownJobType)
+}
@@ -0,0 +1,65 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/rs/zerolog"
+)
+
+// RetrievePasswordStep is the title of the step of Windows launch jobs retrieving the
+// Administrator password.
+const RetrievePasswordStep = "Retrieve Administrator password"
+
+// Job logic, when error is returned the job status is updated accordingly
+func DoRetrievePasswordAWS(ctx context.Context, args *LaunchInstanceAWSTaskArgs) error {
+	if !args.Detail.Windows {
+		return nil
+	}
+
+	logger := zerolog.Ctx(ctx)
+	logger.Debug().Msg("Started retrieve password job")
+
+	// status updates before and after the code logic
+	updateStatusBefore(ctx, args.ReservationID, "Retrieving Administrator password")
+	defer updateStatusAfter(ctx, args.ReservationID, "Retrieved Administrator password", 1)
+
+	rDao := dao.GetReservationDao(ctx)
+	instances, err := rDao.ListInstances(ctx, args.ReservationID)
+	if err != nil {
+		return fmt.Errorf("cannot list reservation instances: %w", err)
+	}
+
+	ec2Client, err := clients.GetEC2Client(ctx, args.ARN, args.Region)
+	if err != nil {
+		return fmt.Errorf("cannot create new ec2 client from config: %w", err)
+	}
+
+	for _, instance := range instances {
+		// the password is generated by the instance during its first boot which takes minutes
+		err = waitAndRetry(ctx, func() error {
+			data, errRetry := ec2Client.GetPasswordData(ctx, instance.InstanceID)
+			if errRetry != nil {
+				return fmt.Errorf("cannot get password data: %w", errRetry)
+			}
+			if data == "" {
+				return ErrTryAgain
+			}
+
+			// the password stays encrypted, only the user has the private key to decrypt it
+			errRetry = rDao.UpdateInstancePasswordData(ctx, args.ReservationID, instance.InstanceID, data)
+			if errRetry != nil {
+				return fmt.Errorf("cannot store password data: %w", errRetry)
+			}
+			return nil
+		}, 1000, 15000, 30000, 60000, 60000, 120000, 120000, 240000, 240000)
+		if err != nil {
+			return fmt.Errorf("giving up on password of instance %s: %w", instance.InstanceID, err)
+		}
+		logger.Info().Str("instance_id", instance.InstanceID).Msg("Retrieved Administrator password")
+	}
+
+	return nilUnlessTimeout(ctx)
+}
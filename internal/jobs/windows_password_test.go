@@ -0,0 +1,52 @@
+package jobs_test
+
+import (
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	daoStubs "github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/jobs"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/factories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoRetrievePasswordAWS(t *testing.T) {
+	ctx := prepareEC2Context(t)
+	pk := factories.NewPubkeyRSA()
+	err := daoStubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to add stubbed key")
+	reservation := prepareAWSReservation(t, ctx, pk)
+	err = daoStubs.AddAWSReservation(ctx, reservation)
+	require.NoError(t, err, "failed to add stubbed reservation")
+
+	resDao := dao.GetReservationDao(ctx)
+	instance := &models.ReservationInstance{ReservationID: reservation.ID, InstanceID: "i-0a4caa2cf5b097ce1"}
+	err = resDao.CreateInstance(ctx, instance)
+	require.NoError(t, err, "failed to add stubbed instance")
+
+	args := func(windows bool) *jobs.LaunchInstanceAWSTaskArgs {
+		detail := *reservation.Detail
+		detail.Windows = windows
+		return &jobs.LaunchInstanceAWSTaskArgs{
+			ReservationID: reservation.ID,
+			Region:        "us-east-1",
+			Detail:        &detail,
+			ARN:           &clients.Authentication{ProviderType: models.ProviderTypeAWS, Payload: "arn:aws:123123123123"},
+		}
+	}
+
+	t.Run("skipped for other images", func(t *testing.T) {
+		err := jobs.DoRetrievePasswordAWS(ctx, args(false))
+		require.NoError(t, err)
+		assert.Empty(t, instance.Detail.PasswordData)
+	})
+
+	t.Run("stores encrypted password", func(t *testing.T) {
+		err := jobs.DoRetrievePasswordAWS(ctx, args(true))
+		require.NoError(t, err)
+		assert.Equal(t, "RW5jcnlwdGVkIHBhc3N3b3Jk", instance.Detail.PasswordData)
+	})
+}
@@ -0,0 +1,65 @@
+package kafka
+
+import (
+	"context"
+	"sync"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/rs/zerolog"
+)
+
+// handlerPool runs message handlers in goroutines up to a limit. When the limit is reached, the
+// consumer waits for a free slot before reading the next message, so slow handlers apply
+// backpressure to the reader instead of accumulating messages in memory.
+type handlerPool struct {
+	slots chan struct{}
+	wg    sync.WaitGroup
+}
+
+// newHandlerPool creates a pool of the configured size, at least one handler runs at a time.
+func newHandlerPool() *handlerPool {
+	size := config.Kafka.MaxInFlight
+	if size < 1 {
+		size = 1
+	}
+	return &handlerPool{slots: make(chan struct{}, size)}
+}
+
+// acquire waits for a free slot, it returns false when the context was canceled.
+func (p *handlerPool) acquire(ctx context.Context) bool {
+	select {
+	case p.slots <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// run calls the handler in a new goroutine, a slot must be acquired first.
+func (p *handlerPool) run(ctx context.Context, message *GenericMessage, handler func(ctx context.Context, message *GenericMessage)) {
+	p.wg.Add(1)
+	go func() {
+		defer func() {
+			<-p.slots
+			p.wg.Done()
+		}()
+		handler(ctx, message)
+	}()
+}
+
+// wait blocks until all running handlers finish.
+func (p *handlerPool) wait() {
+	p.wg.Wait()
+}
+
+// tooLarge returns true and logs a warning for messages over the configured size limit, such
+// messages are skipped by consumers.
+func tooLarge(ctx context.Context, message *GenericMessage) bool {
+	size := len(message.Key) + len(message.Value)
+	if config.Kafka.MaxMessageBytes <= 0 || size <= config.Kafka.MaxMessageBytes {
+		return false
+	}
+	zerolog.Ctx(ctx).Warn().Int("size", size).Int("limit", config.Kafka.MaxMessageBytes).
+		Msgf("Skipping message with key %s on topic %s over the size limit", message.Key, message.Topic)
+	return true
+}
@@ -2,7 +2,11 @@ package kafka
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
 )
 
 type Broker interface {
@@ -10,11 +14,39 @@ type Broker interface {
 	Send(ctx context.Context, messages ...*GenericMessage) error
 
 	// Consume messages of a single topic in a loop. Blocking call, use context cancellation to stop.
+	// Handlers may run concurrently (see config.Kafka.MaxInFlight), the call returns after all of
+	// them finish.
 	Consume(ctx context.Context, topic string, since time.Time, handler func(ctx context.Context, message *GenericMessage))
+
+	// ConsumeGroup consumes messages of a single topic as a member of a consumer group. Partitions
+	// are split among members of the group, every message is handled by one of them. Consuming
+	// continues from the offset committed by the group. Blocking call, use context cancellation to stop.
+	ConsumeGroup(ctx context.Context, topic, group string, handler func(ctx context.Context, message *GenericMessage))
 }
 
 var broker Broker = &noopBroker{}
 
+var UnknownTransportErr = errors.New("unknown message bus transport")
+
+// Initialize sets up the message bus transport selected by the configuration (see
+// config.BusTransport) and performs clowder mapping of topics.
+func Initialize(ctx context.Context) error {
+	switch transport := config.BusTransport(); transport {
+	case config.BusTransportKafka:
+		return InitializeKafkaBroker(ctx)
+	case config.BusTransportMemory:
+		broker = NewMemoryBroker(config.Bus.BufferSize)
+	case config.BusTransportNone:
+		broker = &noopBroker{}
+	default:
+		return fmt.Errorf("%w: %s", UnknownTransportErr, transport)
+	}
+
+	InitializeTopicRequests(ctx)
+
+	return nil
+}
+
 //nolint:wrapcheck
 func Send(ctx context.Context, messages ...*GenericMessage) error {
 	return broker.Send(ctx, messages...)
@@ -23,3 +55,7 @@ func Send(ctx context.Context, messages ...*GenericMessage) error {
 func Consume(ctx context.Context, topic string, since time.Time, handler func(ctx context.Context, message *GenericMessage)) {
 	broker.Consume(ctx, topic, since, handler)
 }
+
+func ConsumeGroup(ctx context.Context, topic, group string, handler func(ctx context.Context, message *GenericMessage)) {
+	broker.ConsumeGroup(ctx, topic, group, handler)
+}
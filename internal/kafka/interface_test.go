@@ -2,12 +2,16 @@ package kafka
 
 import (
 	"context"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/RHEnVision/provisioning-backend/internal/config"
 	_ "github.com/RHEnVision/provisioning-backend/internal/testing/initialization"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -64,3 +68,144 @@ func TestMultipleTopics(t *testing.T) {
 	_ = bus.Send(ctx, createMessage("topic2", "key2", "value"))
 	wg.Wait()
 }
+
+func TestConsumeInFlightLimit(t *testing.T) {
+	defer func(limit int) { config.Kafka.MaxInFlight = limit }(config.Kafka.MaxInFlight)
+	config.Kafka.MaxInFlight = 2
+
+	ctx := context.Background()
+	bus := NewStubBroker(16)
+	cct, cancel := context.WithCancel(ctx)
+
+	var running, maxRunning, handled int32
+	release := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		bus.Consume(cct, "topic", time.Now(), func(ctx context.Context, msg *GenericMessage) {
+			current := atomic.AddInt32(&running, 1)
+			for {
+				observed := atomic.LoadInt32(&maxRunning)
+				if current <= observed || atomic.CompareAndSwapInt32(&maxRunning, observed, current) {
+					break
+				}
+			}
+			<-release
+			atomic.AddInt32(&running, -1)
+			atomic.AddInt32(&handled, 1)
+		})
+		close(done)
+	}()
+
+	m := createMessage("topic", "key", "value")
+	_ = bus.Send(ctx, m, m, m, m, m)
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&running) == 2 }, time.Second, time.Millisecond)
+	close(release)
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&handled) == 5 }, time.Second, time.Millisecond)
+
+	cancel()
+	<-done
+	assert.EqualValues(t, 2, atomic.LoadInt32(&maxRunning))
+}
+
+func TestConsumeSkipsLargeMessages(t *testing.T) {
+	defer func(limit int) { config.Kafka.MaxMessageBytes = limit }(config.Kafka.MaxMessageBytes)
+	config.Kafka.MaxMessageBytes = 16
+
+	ctx := context.Background()
+	bus := NewStubBroker(16)
+	cct, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	received := make(chan *GenericMessage, 2)
+	go bus.Consume(cct, "topic", time.Now(), func(ctx context.Context, msg *GenericMessage) {
+		received <- msg
+	})
+
+	_ = bus.Send(ctx, createMessage("topic", "large", strings.Repeat("x", 16)), createMessage("topic", "small", "value"))
+	msg := <-received
+	require.EqualValues(t, "small", msg.Key)
+	assert.Empty(t, received)
+}
+
+func TestMemoryBroker(t *testing.T) {
+	ctx := context.Background()
+	bus := NewMemoryBroker(16)
+
+	// nothing is kept without consumers
+	err := bus.Send(ctx, createMessage("topic", "dropped", "value"))
+	require.NoError(t, err)
+
+	cct, cancel := context.WithCancel(ctx)
+	received := make(chan *GenericMessage, 16)
+	done := make(chan struct{})
+	go func() {
+		bus.Consume(cct, "topic", time.Now(), func(ctx context.Context, msg *GenericMessage) {
+			received <- msg
+		})
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool {
+		_ = bus.Send(ctx, createMessage("topic", "key", "value"))
+		select {
+		case msg := <-received:
+			require.EqualValues(t, "key", msg.Key)
+			return true
+		case <-time.After(10 * time.Millisecond):
+			return false
+		}
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	<-done
+	err = bus.Send(ctx, createMessage("topic", "dropped", "value"))
+	require.NoError(t, err)
+}
+
+func TestMemoryBrokerGroup(t *testing.T) {
+	ctx := context.Background()
+	bus := NewMemoryBroker(16).(*memoryBroker)
+	subscribed := func() int {
+		bus.m.RLock()
+		defer bus.m.RUnlock()
+		members := 0
+		for _, s := range bus.consumers["topic"] {
+			members += s.members
+		}
+		return members
+	}
+
+	cct, cancel := context.WithCancel(ctx)
+	var grouped, all int32
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			bus.ConsumeGroup(cct, "topic", "group", func(ctx context.Context, msg *GenericMessage) {
+				atomic.AddInt32(&grouped, 1)
+			})
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		bus.Consume(cct, "topic", time.Now(), func(ctx context.Context, msg *GenericMessage) {
+			atomic.AddInt32(&all, 1)
+		})
+	}()
+	require.Eventually(t, func() bool { return subscribed() == 3 }, time.Second, time.Millisecond)
+	require.Len(t, bus.consumers["topic"], 2)
+
+	for i := 0; i < 10; i++ {
+		err := bus.Send(ctx, createMessage("topic", "key", "value"))
+		require.NoError(t, err)
+	}
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&grouped) == 10 && atomic.LoadInt32(&all) == 10
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	wg.Wait()
+	require.Equal(t, 0, subscribed())
+}
@@ -156,15 +156,33 @@ func newContextErrLogger(ctx context.Context) func(msg string, a ...interface{})
 	}
 }
 
-// NewReader creates a reader. Use Close() function to close the reader.
+// NewReader creates a reader. Use Close() function to close the reader. The reader prefetches
+// at most as many messages as can be handled concurrently.
 func (b *kafkaBroker) NewReader(ctx context.Context, topic string) *kafka.Reader {
+	return b.newReader(ctx, topic, "")
+}
+
+// NewGroupReader creates a reader of a consumer group. Offsets are committed once per second,
+// a group without committed offsets starts with new messages.
+func (b *kafkaBroker) NewGroupReader(ctx context.Context, topic, group string) *kafka.Reader {
+	return b.newReader(ctx, topic, group)
+}
+
+func (b *kafkaBroker) newReader(ctx context.Context, topic, group string) *kafka.Reader {
+	queueCapacity := config.Kafka.MaxInFlight
+	if queueCapacity < 1 {
+		queueCapacity = 1
+	}
 	return kafka.NewReader(kafka.ReaderConfig{
-		Brokers:     config.Kafka.Brokers,
-		Dialer:      b.dialer,
-		Topic:       topic,
-		StartOffset: kafka.LastOffset,
-		Logger:      kafka.LoggerFunc(newContextLogger(ctx)),
-		ErrorLogger: kafka.LoggerFunc(newContextErrLogger(ctx)),
+		Brokers:        config.Kafka.Brokers,
+		Dialer:         b.dialer,
+		Topic:          topic,
+		GroupID:        group,
+		StartOffset:    kafka.LastOffset,
+		CommitInterval: time.Second,
+		QueueCapacity:  queueCapacity,
+		Logger:         kafka.LoggerFunc(newContextLogger(ctx)),
+		ErrorLogger:    kafka.LoggerFunc(newContextErrLogger(ctx)),
 	})
 }
 
@@ -181,16 +199,33 @@ func (b *kafkaBroker) NewWriter(ctx context.Context) *kafka.Writer {
 
 // Consume reads messages in batches up to 1 MB with up to 10 seconds delay. It blocks, therefore
 // it should be called from a separate goroutine. Use context cancellation to stop the loop.
+// Handlers run concurrently up to the configured limit, reading waits when the limit is reached.
+// Messages over the configured size are skipped. It returns after all handlers finish.
 func (b *kafkaBroker) Consume(ctx context.Context, topic string, since time.Time, handler func(ctx context.Context, message *GenericMessage)) {
-	logger := zerolog.Ctx(ctx)
 	r := b.NewReader(ctx, topic)
 	defer r.Close()
 
 	err := r.SetOffsetAt(ctx, since)
 	if err != nil {
-		logger.Warn().Err(err).Msg("Unable to set initial offset")
+		zerolog.Ctx(ctx).Warn().Err(err).Msg("Unable to set initial offset")
 	}
 
+	b.consume(ctx, r, handler)
+}
+
+// ConsumeGroup works like Consume but reads only partitions assigned to the group member.
+func (b *kafkaBroker) ConsumeGroup(ctx context.Context, topic, group string, handler func(ctx context.Context, message *GenericMessage)) {
+	r := b.NewGroupReader(ctx, topic, group)
+	defer r.Close()
+
+	b.consume(ctx, r, handler)
+}
+
+func (b *kafkaBroker) consume(ctx context.Context, r *kafka.Reader, handler func(ctx context.Context, message *GenericMessage)) {
+	logger := zerolog.Ctx(ctx)
+	pool := newHandlerPool()
+	defer pool.wait()
+
 	for {
 		msg, err := r.ReadMessage(ctx)
 		if err != nil && errors.Is(err, io.EOF) {
@@ -213,22 +248,25 @@ func (b *kafkaBroker) Consume(ctx context.Context, topic string, since time.Time
 				errLogger.Warn().Err(err).Msgf("Could not extract identity from context to Kafka message")
 				newCtx = errLogger.WithContext(ctx)
 			} else {
-				id := identity.Identity(newCtx)
-
 				traceId := trace.SpanFromContext(ctx).SpanContext().TraceID()
 				if !traceId.IsValid() {
 					traceId = random.TraceID()
 				}
 				newCtx = logging.WithTraceId(newCtx, traceId.String())
 
-				newLogger = newLogger.
-					Str("trace_id", traceId.String()).
-					Str("account_number", id.Identity.AccountNumber).
-					Str("org_id", id.Identity.OrgID)
 				newCtx = newLogger.Logger().WithContext(newCtx)
+				newCtx = logging.WithContextLogger(newCtx)
 			}
 
-			handler(newCtx, NewMessageFromKafka(&msg))
+			message := NewMessageFromKafka(&msg)
+			if tooLarge(newCtx, message) {
+				continue
+			}
+			if !pool.acquire(ctx) {
+				logger.Debug().Msg("Kafka receiver has been cancelled")
+				break
+			}
+			pool.run(newCtx, message, handler)
 		}
 	}
 }
@@ -0,0 +1,140 @@
+package kafka
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// In-memory broker delivering messages to consumers running in the same process, meant for local
+// development without Kafka. Unlike Kafka, messages sent to a topic without a running consumer
+// are dropped as well as messages over the buffer size of a slow consumer.
+type memoryBroker struct {
+	consumers  map[string][]*memorySubscription
+	m          sync.RWMutex
+	bufferSize int
+}
+
+// memorySubscription is a channel of one consumer, or of all members of a consumer group.
+type memorySubscription struct {
+	ch      chan *GenericMessage
+	group   string
+	members int
+}
+
+var _ Broker = &memoryBroker{}
+
+func InitializeMemoryBroker(bufferSize int) error {
+	broker = NewMemoryBroker(bufferSize)
+
+	return nil
+}
+
+func NewMemoryBroker(bufferSize int) Broker {
+	return &memoryBroker{
+		consumers:  make(map[string][]*memorySubscription),
+		bufferSize: bufferSize,
+	}
+}
+
+// subscribe creates a subscription, members of the same group share one. Group members read from
+// the same channel, so every message is delivered to one of them.
+func (b *memoryBroker) subscribe(topic, group string) *memorySubscription {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	if group != "" {
+		for _, s := range b.consumers[topic] {
+			if s.group == group {
+				s.members++
+				return s
+			}
+		}
+	}
+
+	s := &memorySubscription{
+		ch:      make(chan *GenericMessage, b.bufferSize),
+		group:   group,
+		members: 1,
+	}
+	b.consumers[topic] = append(b.consumers[topic], s)
+	return s
+}
+
+func (b *memoryBroker) unsubscribe(topic string, sub *memorySubscription) {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	sub.members--
+	if sub.members > 0 {
+		return
+	}
+	consumers := b.consumers[topic]
+	for i, s := range consumers {
+		if s == sub {
+			b.consumers[topic] = append(consumers[:i:i], consumers[i+1:]...)
+			return
+		}
+	}
+}
+
+// Consume delivers messages sent after the call, the since argument is ignored.
+func (b *memoryBroker) Consume(ctx context.Context, topic string, _ time.Time, handler func(ctx context.Context, message *GenericMessage)) {
+	sub := b.subscribe(topic, "")
+	defer b.unsubscribe(topic, sub)
+
+	b.consume(ctx, sub.ch, handler)
+}
+
+// ConsumeGroup delivers messages sent after the call to one member of the group.
+func (b *memoryBroker) ConsumeGroup(ctx context.Context, topic, group string, handler func(ctx context.Context, message *GenericMessage)) {
+	sub := b.subscribe(topic, group)
+	defer b.unsubscribe(topic, sub)
+
+	b.consume(ctx, sub.ch, handler)
+}
+
+func (b *memoryBroker) consume(ctx context.Context, ch chan *GenericMessage, handler func(ctx context.Context, message *GenericMessage)) {
+	pool := newHandlerPool()
+	defer pool.wait()
+
+	for {
+		select {
+		case msg := <-ch:
+			if tooLarge(ctx, msg) {
+				continue
+			}
+			if !pool.acquire(ctx) {
+				return
+			}
+			pool.run(ctx, msg, handler)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (b *memoryBroker) Send(ctx context.Context, messages ...*GenericMessage) error {
+	logger := zerolog.Ctx(ctx)
+	b.m.RLock()
+	defer b.m.RUnlock()
+
+	for _, m := range messages {
+		consumers := b.consumers[m.Topic]
+		if len(consumers) == 0 {
+			logger.Trace().Msgf("Throwing away message for topic %s without consumers", m.Topic)
+			continue
+		}
+		for _, s := range consumers {
+			select {
+			case s.ch <- m:
+			default:
+				logger.Warn().Msgf("Throwing away message for topic %s, consumer buffer is full", m.Topic)
+			}
+		}
+	}
+
+	return nil
+}
@@ -14,12 +14,17 @@ var _ Broker = &noopBroker{}
 
 func (s *noopBroker) Consume(ctx context.Context, topic string, since time.Time, handler func(ctx context.Context, message *GenericMessage)) {
 	logger := zerolog.Ctx(ctx)
-	logger.Warn().Msg("Consume loop not started (Kafka not configured)")
+	logger.Warn().Msg("Consume loop not started (message bus not configured)")
+}
+
+func (s *noopBroker) ConsumeGroup(ctx context.Context, topic, group string, handler func(ctx context.Context, message *GenericMessage)) {
+	logger := zerolog.Ctx(ctx)
+	logger.Warn().Msg("Consume loop not started (message bus not configured)")
 }
 
 func (s *noopBroker) Send(ctx context.Context, messages ...*GenericMessage) error {
 	logger := zerolog.Ctx(ctx)
-	logger.Warn().Msgf("Throwing away %d messages (Kafka not configured)", len(messages))
+	logger.Warn().Msgf("Throwing away %d messages (message bus not configured)", len(messages))
 
 	return nil
 }
@@ -11,11 +11,13 @@ import (
 )
 
 const (
-	application                  = "image-builder"
-	bundle                       = "rhel"
-	notificationMessageVersion   = "v2.0.0"
-	NotificationSuccessEventType = "launch-success"
-	NotificationFailureEventType = "launch-failed"
+	application                   = "image-builder"
+	bundle                        = "rhel"
+	notificationMessageVersion    = "v2.0.0"
+	NotificationSuccessEventType  = "launch-success"
+	NotificationFailureEventType  = "launch-failed"
+	NotificationOrphansEventType  = "orphaned-instances"
+	NotificationScheduleEventType = "scheduled-launch-failed"
 )
 
 type NotificationEvent struct {
@@ -27,6 +29,16 @@ type NotificationContext struct {
 	Provider string `json:"provider"`
 }
 
+type NotificationSourceContext struct {
+	SourceID string `json:"source_id"`
+	Provider string `json:"provider"`
+}
+
+type NotificationTemplateContext struct {
+	TemplateID int64  `json:"template_id"`
+	Provider   string `json:"provider"`
+}
+
 type NotificationError struct {
 	Error string `json:"error"`
 }
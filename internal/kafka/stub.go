@@ -43,17 +43,30 @@ func (s *stubBroker) find(topic string) chan *GenericMessage {
 
 func (s *stubBroker) Consume(ctx context.Context, topic string, since time.Time, handler func(ctx context.Context, message *GenericMessage)) {
 	ch := s.find(topic)
+	pool := newHandlerPool()
+	defer pool.wait()
 
 	for {
 		select {
 		case msg := <-ch:
-			handler(ctx, msg)
+			if tooLarge(ctx, msg) {
+				continue
+			}
+			if !pool.acquire(ctx) {
+				return
+			}
+			pool.run(ctx, msg, handler)
 		case <-ctx.Done():
 			return
 		}
 	}
 }
 
+// ConsumeGroup is the same as Consume, consumers of a topic always share its messages.
+func (s *stubBroker) ConsumeGroup(ctx context.Context, topic, _ string, handler func(ctx context.Context, message *GenericMessage)) {
+	s.Consume(ctx, topic, time.Time{}, handler)
+}
+
 func (s *stubBroker) Send(_ context.Context, messages ...*GenericMessage) error {
 	for _, m := range messages {
 		ch := s.find(m.Topic)
@@ -0,0 +1,65 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+)
+
+const (
+	TenantOrgDeletedEventType = "org-deleted"
+	PurgeCompletedStatus      = "completed"
+	PurgeFailedStatus         = "failed"
+)
+
+// TenantLifecycleMessage is an event of the platform tenant lifecycle.
+type TenantLifecycleMessage struct {
+	EventType string `json:"event_type"`
+	OrgID     string `json:"org_id"`
+}
+
+func NewTenantLifecycleMessage(msg *GenericMessage) (*TenantLifecycleMessage, error) {
+	tlm := TenantLifecycleMessage{}
+	err := json.Unmarshal(msg.Value, &tlm)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal tenant lifecycle message: %w", err)
+	}
+
+	return &tlm, nil
+}
+
+// PurgeReportMessage is the completion report of purging data of a deleted organization.
+type PurgeReportMessage struct {
+	OrgID                string    `json:"org_id"`
+	Status               string    `json:"status"`
+	Error                string    `json:"error,omitempty"`
+	Reservations         int64     `json:"reservations"`
+	Pubkeys              int64     `json:"pubkeys"`
+	ReservationTemplates int64     `json:"reservation_templates"`
+	OrphanedInstances    int64     `json:"orphaned_instances"`
+	AuditRecords         int64     `json:"anonymized_audit_records"`
+	CacheEntries         int64     `json:"cache_entries"`
+	Archives             int64     `json:"archives"`
+	SupportBundles       int64     `json:"support_bundles"`
+	FinishedAt           time.Time `json:"finished_at"`
+}
+
+func (m PurgeReportMessage) GenericMessage(ctx context.Context) (GenericMessage, error) {
+	payload, err := json.Marshal(m)
+	if err != nil {
+		return GenericMessage{}, fmt.Errorf("unable to marshal purge report message: %w", err)
+	}
+
+	return GenericMessage{
+		Topic: PurgeReportTopic,
+		Key:   []byte(m.OrgID),
+		Value: payload,
+		Headers: GenericHeaders(
+			"content-type", "application/json",
+			"x-rh-identity", identity.IdentityHeader(ctx),
+		),
+	}, nil
+}
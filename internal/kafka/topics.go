@@ -11,6 +11,9 @@ var (
 	availabilityStatusRequestTopicReq = "platform.provisioning.internal.availability-check"
 	sendStatusToSourcesTopicReq       = "platform.sources.status"
 	sendNotificationMessage           = "platform.notifications.ingress"
+	sendUsageRecordTopicReq           = "platform.provisioning.usage"
+	tenantLifecycleTopicReq           = "platform.tenants.lifecycle"
+	sendPurgeReportTopicReq           = "platform.provisioning.purge-report"
 )
 
 // topics after clowder mapping
@@ -18,6 +21,9 @@ var (
 	AvailabilityStatusRequestTopic string
 	SourcesStatusTopic             string
 	NotificationTopic              string
+	UsageTopic                     string
+	TenantLifecycleTopic           string
+	PurgeReportTopic               string
 )
 
 // InitializeTopicRequests performs clowder mapping of topics.
@@ -25,4 +31,7 @@ func InitializeTopicRequests(ctx context.Context) {
 	AvailabilityStatusRequestTopic = config.TopicName(ctx, availabilityStatusRequestTopicReq)
 	SourcesStatusTopic = config.TopicName(ctx, sendStatusToSourcesTopicReq)
 	NotificationTopic = config.TopicName(ctx, sendNotificationMessage)
+	UsageTopic = config.TopicName(ctx, sendUsageRecordTopicReq)
+	TenantLifecycleTopic = config.TopicName(ctx, tenantLifecycleTopicReq)
+	PurgeReportTopic = config.TopicName(ctx, sendPurgeReportTopicReq)
 }
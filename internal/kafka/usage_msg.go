@@ -0,0 +1,61 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/google/uuid"
+)
+
+const (
+	UsageStartedEventType    = "instance-started"
+	UsageTerminatedEventType = "instance-terminated"
+)
+
+// UsageMessage is a usage record of an instance for cost attribution. Instance hours are
+// counted from the started record to the terminated record of the same instance.
+type UsageMessage struct {
+	ID            string    `json:"id"`
+	EventType     string    `json:"event_type"`
+	Timestamp     time.Time `json:"timestamp"`
+	OrgID         string    `json:"org_id"`
+	AccountNumber string    `json:"account_number"`
+	ReservationID int64     `json:"reservation_id"`
+	InstanceID    string    `json:"instance_id"`
+	Provider      string    `json:"provider"`
+	SourceID      string    `json:"source_id"`
+	Location      string    `json:"location"`
+	InstanceType  string    `json:"instance_type"`
+}
+
+func (m UsageMessage) GenericMessage(ctx context.Context) (GenericMessage, error) {
+	id := identity.Identity(ctx)
+
+	m.ID = uuid.New().String()
+	m.OrgID = id.Identity.OrgID
+	m.AccountNumber = id.Identity.AccountNumber
+	if m.Timestamp.IsZero() {
+		m.Timestamp = time.Now().UTC()
+	}
+
+	payload, err := json.Marshal(m)
+	if err != nil {
+		return GenericMessage{}, fmt.Errorf("unable to marshal usage message: %w", err)
+	}
+
+	// records of one instance are keyed to the same partition to keep them ordered
+	return GenericMessage{
+		Topic: UsageTopic,
+		Key:   []byte(m.InstanceID),
+		Value: payload,
+		Headers: GenericHeaders(
+			"content-type", "application/json",
+			"rh-message-id", m.ID,
+			"x-rh-identity", identity.IdentityHeader(ctx),
+			"event_type", m.EventType,
+		),
+	}, nil
+}
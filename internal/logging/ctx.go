@@ -10,6 +10,8 @@ const (
 	requestIdCtxKey     commonKeyId = iota
 	edgeRequestIdCtxKey commonKeyId = iota
 	correlationCtxKey   commonKeyId = iota
+	reservationIdCtxKey commonKeyId = iota
+	jobIdCtxKey         commonKeyId = iota
 )
 
 // CorrelationId returns UI correlation id or an empty string when not set.
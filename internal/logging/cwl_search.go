@@ -0,0 +1,57 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+)
+
+var ErrLogSearchDisabled = errors.New("log search is not available, cloudwatch is not enabled")
+
+// SearchLogs returns raw messages from the cloudwatch log group containing any of the terms (e.g.
+// trace or job IDs) logged between start and end. At most limit messages are returned, oldest first.
+// Messages of all streams are searched, therefore logs of both API and worker processes are found.
+// Messages are redacted, because they are handed over to support.
+func SearchLogs(ctx context.Context, terms []string, start, end time.Time, limit int32) ([]string, error) {
+	if !config.Cloudwatch.Enabled {
+		return nil, ErrLogSearchDisabled
+	}
+
+	patterns := make([]string, 0, len(terms))
+	for _, term := range terms {
+		patterns = append(patterns, fmt.Sprintf(`?"%s"`, strings.ReplaceAll(term, `"`, "")))
+	}
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+
+	input := &cloudwatchlogs.FilterLogEventsInput{
+		LogGroupName:  aws.String(config.Cloudwatch.Group),
+		FilterPattern: aws.String(strings.Join(patterns, " ")),
+		StartTime:     aws.Int64(start.UnixMilli()),
+		EndTime:       aws.Int64(end.UnixMilli()),
+	}
+	paginator := cloudwatchlogs.NewFilterLogEventsPaginator(newCloudwatchClient(), input)
+
+	var result []string
+	for paginator.HasMorePages() && int32(len(result)) < limit {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return result, fmt.Errorf("unable to filter log events: %w", err)
+		}
+		for _, event := range page.Events {
+			if int32(len(result)) >= limit {
+				break
+			}
+			// messages logged before redaction was introduced or by other applications
+			result = append(result, Redact(aws.ToString(event.Message)))
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,85 @@
+package logging
+
+import (
+	"context"
+
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/rs/zerolog"
+)
+
+// ReservationId returns reservation id or zero when not set.
+func ReservationId(ctx context.Context) int64 {
+	value := ctx.Value(reservationIdCtxKey)
+	if value == nil {
+		return 0
+	}
+	return value.(int64)
+}
+
+// WithReservationId returns context copy with reservation id value, the context logger stamps it
+// to log entries.
+func WithReservationId(ctx context.Context, id int64) context.Context {
+	return WithContextLogger(context.WithValue(ctx, reservationIdCtxKey, id))
+}
+
+// JobId returns background job id or an empty string when not set.
+func JobId(ctx context.Context) string {
+	value := ctx.Value(jobIdCtxKey)
+	if value == nil {
+		return ""
+	}
+	return value.(string)
+}
+
+// WithJobId returns context copy with background job id value, the context logger stamps it
+// to log entries.
+func WithJobId(ctx context.Context, id string) context.Context {
+	return WithContextLogger(context.WithValue(ctx, jobIdCtxKey, id))
+}
+
+// WithContextLogger returns context copy with the context logger bound to the context. Entries
+// of the logger are stamped with account, org id, reservation id, job id and trace id values
+// stored in the context. It must be called again when any of the values change.
+func WithContextLogger(ctx context.Context) context.Context {
+	logger := zerolog.Ctx(ctx).With().Ctx(ctx).Logger()
+	return logger.WithContext(ctx)
+}
+
+// ContextFields adds fields of account, org id, reservation id, job id and trace id values stored
+// in the context to the logger context. Values which are not set are skipped.
+func ContextFields(ctx context.Context, lctx zerolog.Context) zerolog.Context {
+	return lctx.Fields(contextFields(ctx))
+}
+
+// contextHook stamps entries of loggers bound to a context, see WithContextLogger.
+type contextHook struct{}
+
+func (contextHook) Run(e *zerolog.Event, _ zerolog.Level, _ string) {
+	if ctx := e.GetCtx(); ctx != nil {
+		e.Fields(contextFields(ctx))
+	}
+}
+
+func contextFields(ctx context.Context) []any {
+	var fields []any
+	id := identity.IdentityOrNil(ctx)
+	if id.Identity.AccountNumber != "" {
+		fields = append(fields, "account_number", id.Identity.AccountNumber)
+	}
+	if id.Identity.OrgID != "" {
+		fields = append(fields, "org_id", id.Identity.OrgID)
+	}
+	if accountId := identity.AccountIdOrNil(ctx); accountId != 0 {
+		fields = append(fields, "account_id", accountId)
+	}
+	if reservationId := ReservationId(ctx); reservationId != 0 {
+		fields = append(fields, "reservation_id", reservationId)
+	}
+	if jobId := JobId(ctx); jobId != "" {
+		fields = append(fields, "job_id", jobId)
+	}
+	if traceId := TraceId(ctx); traceId != "" {
+		fields = append(fields, "trace_id", traceId)
+	}
+	return fields
+}
@@ -0,0 +1,68 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func logEntry(t *testing.T, ctx context.Context, buf *bytes.Buffer) map[string]any {
+	t.Helper()
+	buf.Reset()
+	zerolog.Ctx(ctx).Info().Msg("test")
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	return entry
+}
+
+func TestWithContextLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf).Hook(contextHook{})
+	ctx := logger.WithContext(context.Background())
+
+	t.Run("unbound", func(t *testing.T) {
+		entry := logEntry(t, WithTraceId(ctx, "trace"), &buf)
+		assert.NotContains(t, entry, "trace_id")
+	})
+
+	t.Run("bound", func(t *testing.T) {
+		id := identity.Principal{}
+		id.Identity.OrgID = "13"
+		id.Identity.AccountNumber = "42"
+		bctx := identity.WithIdentity(ctx, id)
+		bctx = identity.WithAccountId(bctx, 1)
+		bctx = WithTraceId(bctx, "trace")
+		bctx = WithReservationId(bctx, 7)
+		bctx = WithJobId(bctx, "job")
+
+		entry := logEntry(t, bctx, &buf)
+		assert.Equal(t, "13", entry["org_id"])
+		assert.Equal(t, "42", entry["account_number"])
+		assert.EqualValues(t, 1, entry["account_id"])
+		assert.EqualValues(t, 7, entry["reservation_id"])
+		assert.Equal(t, "job", entry["job_id"])
+		assert.Equal(t, "trace", entry["trace_id"])
+	})
+
+	t.Run("rebound", func(t *testing.T) {
+		bctx := WithReservationId(ctx, 7)
+		bctx = WithReservationId(bctx, 8)
+
+		buf.Reset()
+		zerolog.Ctx(bctx).Info().Msg("test")
+		assert.Equal(t, 1, bytes.Count(buf.Bytes(), []byte(`"reservation_id"`)))
+		assert.Contains(t, buf.String(), `"reservation_id":8`)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		entry := logEntry(t, WithContextLogger(ctx), &buf)
+		assert.Equal(t, map[string]any{"level": "info", "message": "test"}, entry)
+	})
+}
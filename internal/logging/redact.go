@@ -0,0 +1,80 @@
+package logging
+
+import (
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+)
+
+// Redacted replaces sensitive values in logs and error responses.
+const Redacted = "[REDACTED]"
+
+// minSecretLength is the minimum length of configured secrets replaced in text, shorter values
+// (e.g. defaults) would replace common words
+const minSecretLength = 6
+
+// keySeparator matches separators of keys and values, e.g. key=value, key: value or "key":"value"
+const keySeparator = `[\\"']*\s*[:=]\s*[\\"']*`
+
+type redaction struct {
+	re   *regexp.Regexp
+	repl string
+}
+
+// redactions are sensitive values found in log and error messages. Values of keys are also matched
+// in JSON documents, including escaped JSON embedded in JSON strings. Replacements must not contain
+// characters which need escaping in JSON, log lines are redacted after serialization.
+var redactions = []redaction{
+	// Example: X-Rh-Identity: eyJpZGVudGl0eSI6eyJ0eXBlIjoiVXNlciJ9fQ==
+	{regexp.MustCompile(`(?i)(x-rh-identity` + keySeparator + `)[A-Za-z0-9+/_=-]+`), "${1}" + Redacted},
+	// Example: Authorization: Bearer abc.def
+	{regexp.MustCompile(`(?i)(authorization` + keySeparator + `)(bearer |basic )?[^\s\\"',]+`), "${1}${2}" + Redacted},
+	// Example: base64 encoded identity header or JWT token (base64 JSON starts with eyJ)
+	{regexp.MustCompile(`eyJ[A-Za-z0-9+/_-]{16,}(\.[A-Za-z0-9+/_-]*)*=*`), Redacted},
+	// Example: arn:aws:iam::123456789012:role/my-role, partition, service and region are kept and a
+	// trailing colon is left for error messages like "role arn:...: access denied"
+	{regexp.MustCompile(`arn:(aws[a-z-]*):([a-z0-9-]+):([a-z0-9-]*):\d{12}:[^\s\\"',;)\]}]*[^\s\\"',;)\]}:]`), "arn:${1}:${2}:${3}:" + Redacted},
+	// Example: /subscriptions/d8a1b1b4-2d1e-4a70-9c6c-0a8a3b4a3c1f/resourceGroups/rg
+	{regexp.MustCompile(`(?i)(subscriptions/)[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`), "${1}" + Redacted},
+	// Example: "subscription_id":"d8a1b1b4-2d1e-4a70-9c6c-0a8a3b4a3c1f"
+	{regexp.MustCompile(`(?i)(subscription[_ ]?id` + keySeparator + `)[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`), "${1}" + Redacted},
+	// Example: sasl.username=user sasl.password=secret, password=secret
+	{regexp.MustCompile(`(?i)((?:sasl[._ ]?username|password|secret)` + keySeparator + `)[^\s\\"',]+`), "${1}" + Redacted},
+}
+
+// Redact replaces identity headers, ARNs, Azure subscription IDs, SASL and other credentials,
+// and all secrets from the configuration in text.
+func Redact(text string) string {
+	if text == "" {
+		return text
+	}
+	for _, secret := range config.Secrets() {
+		if len(secret) >= minSecretLength {
+			text = strings.ReplaceAll(text, secret, Redacted)
+		}
+	}
+	for _, r := range redactions {
+		text = r.re.ReplaceAllString(text, r.repl)
+	}
+	return text
+}
+
+// redactWriter redacts every write, zerolog writes whole events at once
+type redactWriter struct {
+	w io.Writer
+}
+
+// NewRedactWriter returns a writer which redacts all data before writing it to w.
+func NewRedactWriter(w io.Writer) io.Writer {
+	return &redactWriter{w: w}
+}
+
+func (rw *redactWriter) Write(p []byte) (int, error) {
+	_, err := rw.w.Write([]byte(Redact(string(p))))
+	if err != nil {
+		return 0, err //nolint:wrapcheck
+	}
+	return len(p), nil
+}
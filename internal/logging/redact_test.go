@@ -0,0 +1,97 @@
+package logging
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			"identity header",
+			"header X-Rh-Identity: eyJpZGVudGl0eSI6eyJ0eXBlIjoiVXNlciJ9fQ== received",
+			"header X-Rh-Identity: [REDACTED] received",
+		},
+		{
+			"identity blob",
+			"cannot decode eyJpZGVudGl0eSI6eyJ0eXBlIjoiVXNlciJ9fQ==",
+			"cannot decode [REDACTED]",
+		},
+		{
+			"bearer token",
+			"Authorization: Bearer abc.def",
+			"Authorization: Bearer [REDACTED]",
+		},
+		{
+			"arn",
+			"cannot assume role arn:aws:iam::123456789012:role/my-role: access denied",
+			"cannot assume role arn:aws:iam::[REDACTED]: access denied",
+		},
+		{
+			"govcloud arn",
+			"arn:aws-us-gov:ec2:us-gov-west-1:123456789012:instance/i-1",
+			"arn:aws-us-gov:ec2:us-gov-west-1:[REDACTED]",
+		},
+		{
+			"subscription path",
+			"GET /subscriptions/d8a1b1b4-2d1e-4a70-9c6c-0a8a3b4a3c1f/resourceGroups/rg failed",
+			"GET /subscriptions/[REDACTED]/resourceGroups/rg failed",
+		},
+		{
+			"subscription id",
+			`{"subscription_id":"d8a1b1b4-2d1e-4a70-9c6c-0a8a3b4a3c1f"}`,
+			`{"subscription_id":"[REDACTED]"}`,
+		},
+		{
+			"json password",
+			`{"user":"admin","password":"hunter2"}`,
+			`{"user":"admin","password":"[REDACTED]"}`,
+		},
+		{
+			"escaped json secret",
+			`{"args":"{\"secret\":\"abc\"}"}`,
+			`{"args":"{\"secret\":\"[REDACTED]\"}"}`,
+		},
+		{
+			"sasl",
+			"sasl.username=user sasl.password=pass",
+			"sasl.username=[REDACTED] sasl.password=[REDACTED]",
+		},
+		{
+			"no secrets",
+			"reservation 42 finished",
+			"reservation 42 finished",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, Redact(tc.input))
+		})
+	}
+}
+
+func TestRedactConfigSecret(t *testing.T) {
+	previous := config.Kafka.SASL.Password
+	config.Kafka.SASL.Password = "s3cr3t-kafka"
+	t.Cleanup(func() { config.Kafka.SASL.Password = previous })
+
+	require.Equal(t, "cannot connect with [REDACTED]", Redact("cannot connect with s3cr3t-kafka"))
+}
+
+func TestRedactWriter(t *testing.T) {
+	buf := bytes.NewBufferString("")
+	w := NewRedactWriter(buf)
+	input := `{"message":"assuming arn:aws:iam::123456789012:role/r"}` + "\n"
+	n, err := w.Write([]byte(input))
+	require.NoError(t, err)
+	require.Equal(t, len(input), n)
+	require.Equal(t, `{"message":"assuming arn:aws:iam::[REDACTED]"}`+"\n", buf.String())
+}
@@ -62,6 +62,13 @@ func TestARN(t *testing.T) {
 	require.Equal(t, "?\n", buf.String())
 }
 
+func TestGovCloudARN(t *testing.T) {
+	buf := bytes.NewBufferString("")
+	repl := NewSentryReplacer(buf)
+	_, _ = repl.Write([]byte("arn:aws-us-gov:iam::4328974392798432:role/my-role-123\n"))
+	require.Equal(t, "?\n", buf.String())
+}
+
 func TestARNSplit(t *testing.T) {
 	buf := bytes.NewBufferString("")
 	repl := NewSentryReplacer(buf)
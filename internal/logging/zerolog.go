@@ -34,7 +34,7 @@ func truncateText(str string, length int) string {
 }
 
 func decorate(l zerolog.Logger) zerolog.Logger {
-	logger := l.With().Timestamp().
+	logger := l.Hook(contextHook{}).With().Timestamp().
 		Str("hostname", config.Hostname())
 
 	if version.BuildCommit != "" {
@@ -90,7 +90,7 @@ func cloudwatchWriter() (io.Writer, func(), error) {
 // It is used in unit and database tests.
 func InitializeStdout() {
 	configureZerolog()
-	log.Logger = decorate(log.Output(stdoutWriter(true))).With().Str("binary", config.BinaryName()).Logger()
+	log.Logger = decorate(log.Output(NewRedactWriter(stdoutWriter(true)))).With().Str("binary", config.BinaryName()).Logger()
 }
 
 // InitializeLogger initializes logging to cloudwatch client and enables sentry Error logging.
@@ -129,7 +129,8 @@ func InitializeLogger() (zerolog.Logger, func()) {
 			fn()
 		}
 	}
-	logger := decorate(zerolog.New(io.MultiWriter(writers...)))
+	// all writers get redacted output, call sites do not need to care about secrets
+	logger := decorate(zerolog.New(NewRedactWriter(io.MultiWriter(writers...))))
 	log.Logger = logger
 	zerolog.DefaultContextLogger = &logger
 	return logger, closeFn
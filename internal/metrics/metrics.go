@@ -25,6 +25,15 @@ var TotalInvalidAvailabilityCheckReqs = prometheus.NewCounter(
 	},
 )
 
+var TotalSkippedAvailabilityCheckReqs = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name:        "provisioning_skipped_source_availability_check_request_total",
+		Help:        "availability check requests skipped before checking partitioned by reason (in_progress/interval) and component",
+		ConstLabels: prometheus.Labels{"service": version.PrometheusLabelName, "component": "statuser"},
+	},
+	[]string{"reason"},
+)
+
 var CacheHits = prometheus.NewCounterVec(prometheus.CounterOpts{
 	Name:        "provisioning_cache_hits",
 	Help:        "The total number of cache hits per type with result (hit, miss, err)",
@@ -108,6 +117,34 @@ var Reservations28dCount = prometheus.NewGaugeVec(
 	[]string{"result", "provider"},
 )
 
+var DaoDuration = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name:        "provisioning_dao_duration",
+		Help:        "database access object operation duration (in seconds) by dao and method",
+		ConstLabels: prometheus.Labels{"service": version.PrometheusLabelName},
+		Buckets:     []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+	},
+	[]string{"dao", "method"},
+)
+
+var DaoErrors = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name:        "provisioning_dao_errors_total",
+		Help:        "database access object operation errors by dao and method",
+		ConstLabels: prometheus.Labels{"service": version.PrometheusLabelName},
+	},
+	[]string{"dao", "method"},
+)
+
+var ProviderRetries = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name:        "provisioning_provider_retries_total",
+		Help:        "retries of cloud provider calls partitioned by provider (aws/azure/gcp) and reason (throttled/error)",
+		ConstLabels: prometheus.Labels{"service": version.PrometheusLabelName},
+	},
+	[]string{"provider", "reason"},
+)
+
 func ObserveAvailabilityCheckReqsDuration(provider string, observedFunc func() error) {
 	errString := "false"
 	start := time.Now()
@@ -142,6 +179,10 @@ func IncTotalInvalidAvailabilityCheckReqs() {
 	TotalInvalidAvailabilityCheckReqs.Inc()
 }
 
+func IncTotalSkippedAvailabilityCheckReqs(reason string) {
+	TotalSkippedAvailabilityCheckReqs.WithLabelValues(reason).Inc()
+}
+
 func IncCacheHit(model, result string) {
 	CacheHits.WithLabelValues(model, result).Inc()
 }
@@ -167,6 +208,14 @@ func ObserveDbStatsDuration(observedFunc func()) {
 	observedFunc()
 }
 
+func ObserveDaoDuration(dao, method string, duration time.Duration) {
+	DaoDuration.WithLabelValues(dao, method).Observe(duration.Seconds())
+}
+
+func IncDaoError(dao, method string) {
+	DaoErrors.WithLabelValues(dao, method).Inc()
+}
+
 func SetReservations24hCount(result string, pt models.ProviderType, count int64) {
 	Reservations24hCount.WithLabelValues(result, pt.String()).Set(float64(count))
 }
@@ -174,3 +223,7 @@ func SetReservations24hCount(result string, pt models.ProviderType, count int64)
 func SetReservations28dCount(result string, pt models.ProviderType, count int64) {
 	Reservations28dCount.WithLabelValues(result, pt.String()).Set(float64(count))
 }
+
+func IncProviderRetry(provider, reason string) {
+	ProviderRetries.WithLabelValues(provider, reason).Inc()
+}
@@ -7,8 +7,12 @@ func RegisterStatuserMetrics() {
 		TotalSentAvailabilityCheckReqs,
 		AvailabilityCheckReqsDuration,
 		TotalInvalidAvailabilityCheckReqs,
+		TotalSkippedAvailabilityCheckReqs,
 		RbacAclFetchDuration,
 		CacheHits,
+		ProviderRetries,
+		DaoDuration,
+		DaoErrors,
 	)
 }
 
@@ -19,6 +23,8 @@ func RegisterStatsMetrics() {
 		DbStatsDuration,
 		Reservations24hCount,
 		Reservations28dCount,
+		DaoDuration,
+		DaoErrors,
 	)
 }
 
@@ -26,6 +32,9 @@ func RegisterApiMetrics() {
 	prometheus.MustRegister(
 		RbacAclFetchDuration,
 		CacheHits,
+		ProviderRetries,
+		DaoDuration,
+		DaoErrors,
 	)
 }
 
@@ -35,5 +44,8 @@ func RegisterWorkerMetrics() {
 		ReservationCount,
 		RbacAclFetchDuration,
 		CacheHits,
+		ProviderRetries,
+		DaoDuration,
+		DaoErrors,
 	)
 }
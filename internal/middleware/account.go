@@ -8,6 +8,7 @@ import (
 	"github.com/RHEnVision/provisioning-backend/internal/config"
 	"github.com/RHEnVision/provisioning-backend/internal/dao"
 	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/RHEnVision/provisioning-backend/internal/logging"
 	"github.com/RHEnVision/provisioning-backend/internal/models"
 	"github.com/RHEnVision/provisioning-backend/internal/version"
 	ucontext "github.com/Unleash/unleash-client-go/v3/context"
@@ -19,7 +20,7 @@ func AccountMiddleware(next http.Handler) http.Handler {
 		rhId := identity.Identity(r.Context())
 		orgID := rhId.Identity.OrgID
 		accountNumber := rhId.Identity.AccountNumber
-		logger := log.Ctx(r.Context()).With().Str("account_number", accountNumber).Str("org_id", orgID).Logger()
+		logger := log.Ctx(logging.WithContextLogger(r.Context()))
 
 		cachedAccount := &models.Account{}
 		err := cache.Find(r.Context(), orgID+accountNumber, cachedAccount)
@@ -27,36 +28,33 @@ func AccountMiddleware(next http.Handler) http.Handler {
 			// account not found in cache
 			accDao := dao.GetAccountDao(r.Context())
 
-			cachedAccount, err = accDao.GetOrCreateByIdentity(r.Context(), orgID, accountNumber)
+			cachedAccount, err = cache.Coalesce("account:"+orgID+accountNumber, func() (*models.Account, error) {
+				return accDao.GetOrCreateByIdentity(r.Context(), orgID, accountNumber)
+			})
 			if err != nil {
 				logger.Error().Err(err).Msg("Failed to fetch account")
-				http.Error(w, err.Error(), 500)
+				http.Error(w, logging.Redact(err.Error()), 500)
 				return
 			}
 
 			err = cache.Set(r.Context(), orgID+accountNumber, cachedAccount)
 			if err != nil {
 				logger.Error().Err(err).Msg("Unable to store account to cache")
-				http.Error(w, err.Error(), 500)
+				http.Error(w, logging.Redact(err.Error()), 500)
 				return
 			}
 		} else if err != nil {
 			logger.Error().Err(err).Msg("Cache returned error")
-			http.Error(w, err.Error(), 500)
+			http.Error(w, logging.Redact(err.Error()), 500)
 			return
 		}
 
 		// account found in cache
 		logger.Trace().Int64("account", cachedAccount.ID).Msg("Account cache hit")
 
-		// set contexts - account id
+		// set contexts - account id and logger
 		ctx := identity.WithAccountId(r.Context(), cachedAccount.ID)
-
-		// logger
-		newLogger := logger.With().
-			Int64("account_id", cachedAccount.ID).
-			Logger()
-		ctx = newLogger.WithContext(ctx)
+		ctx = logging.WithContextLogger(ctx)
 
 		// unleash context
 		uctx := ucontext.Context{
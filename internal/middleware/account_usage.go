@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/RHEnVision/provisioning-backend/internal/apiusage"
+)
+
+// AccountUsage counts API calls of accounts for usage reporting. Account must be present in the
+// context, chain AccountMiddleware before this one.
+func AccountUsage(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		apiusage.RecordAPICall(r.Context())
+		next.ServeHTTP(w, r)
+	}
+	return http.HandlerFunc(fn)
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog"
+)
+
+var ErrAdminToken = errors.New("admin token error")
+
+// AdminToken authorizes requests to the admin API with a pre-shared token passed in the
+// "Authorization: Bearer" header. There is no identity or account in the request context. The
+// header is removed from authorized requests, so it does not appear in logs.
+func AdminToken(token string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+			provided := strings.TrimPrefix(header, "Bearer ")
+			if provided == header || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+				errRender := render.Render(w, r, payloads.NewResponseError(r.Context(), http.StatusUnauthorized, "invalid or missing admin token", ErrAdminToken))
+				if errRender != nil {
+					zerolog.Ctx(r.Context()).Warn().Err(errRender).Msg("Cannot render admin middleware error")
+				}
+				return
+			}
+			r.Header.Del("Authorization")
+			next.ServeHTTP(w, r)
+		})
+	}
+}
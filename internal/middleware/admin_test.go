@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminToken(t *testing.T) {
+	handler := AdminToken("secret")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for header, expected := range map[string]int{
+		"Bearer secret": http.StatusOK,
+		"Bearer wrong":  http.StatusUnauthorized,
+		"secret":        http.StatusUnauthorized,
+		"":              http.StatusUnauthorized,
+	} {
+		req, err := http.NewRequestWithContext(context.Background(), "GET", "/admin/queue", nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", header)
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, expected, rr.Code, header)
+	}
+}
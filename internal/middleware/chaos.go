@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/RHEnVision/provisioning-backend/internal/chaos"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog"
+)
+
+// ChaosMiddleware injects latency and errors (503 Service Unavailable) into requests matching the
+// rules. It is intended for resilience testing, see the chaos package.
+func ChaosMiddleware(rules []chaos.Rule) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rule := chaos.Match(rules, r.URL.Path)
+			if rule == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			logger := zerolog.Ctx(r.Context())
+			inject, err := rule.Apply(r.Context())
+			if err != nil {
+				logger.Warn().Err(err).Msg("Chaos latency interrupted")
+				return
+			}
+			if inject {
+				errRender := render.Render(w, r, payloads.NewResponseError(r.Context(), http.StatusServiceUnavailable, "Chaos error injected", chaos.ErrInjected))
+				if errRender != nil {
+					logger.Warn().Err(errRender).Msg("Cannot render chaos middleware error")
+				}
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
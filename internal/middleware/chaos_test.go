@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/chaos"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChaosMiddleware(t *testing.T) {
+	rules, err := chaos.ParseRules([]string{"/failing=:1", "/slow=1ms:0"})
+	require.NoError(t, err)
+
+	handler := ChaosMiddleware(rules)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for path, expected := range map[string]int{
+		"/failing/1": http.StatusServiceUnavailable,
+		"/slow":      http.StatusOK,
+		"/other":     http.StatusOK,
+	} {
+		req, err := http.NewRequestWithContext(context.Background(), "GET", path, nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, expected, rr.Code, path)
+	}
+}
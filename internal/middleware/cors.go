@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"golang.org/x/exp/slices"
+)
+
+// CORS allows browsers to call the API directly from configured origins, e.g. development frontends
+// or third-party consoles without a proxy. Preflight requests are answered without calling the next
+// handler, so it must be chained before identity and permission checks. It does nothing when no origins
+// are configured or when the request origin is not allowed.
+func CORS(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		cfg := config.Application.CORS
+		origin := r.Header.Get("Origin")
+		if origin == "" || !nonBlank(cfg.Origins) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Add("Vary", "Origin")
+		if !allowedOrigin(cfg.Origins, origin) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if slices.Contains(cfg.Origins, "*") && !cfg.Credentials {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+		} else {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+		}
+		if cfg.Credentials {
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			w.Header().Set("Access-Control-Allow-Methods", strings.Join(cfg.Methods, ", "))
+			w.Header().Set("Access-Control-Allow-Headers", strings.Join(cfg.Headers, ", "))
+			w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(cfg.MaxAge.Seconds())))
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		if nonBlank(cfg.ExposedHeaders) {
+			w.Header().Set("Access-Control-Expose-Headers", strings.Join(cfg.ExposedHeaders, ", "))
+		}
+		next.ServeHTTP(w, r)
+	}
+	return http.HandlerFunc(fn)
+}
+
+// allowedOrigin matches the origin against configured origins, which can contain wildcards
+// (e.g. https://*.example.com). A single asterisk allows all origins.
+func allowedOrigin(origins []string, origin string) bool {
+	origin = strings.ToLower(origin)
+	for _, pattern := range origins {
+		pattern = strings.ToLower(pattern)
+		if pattern == "*" || pattern == origin {
+			return true
+		}
+		if ok, _ := path.Match(pattern, origin); ok && pattern != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func nonBlank(values []string) bool {
+	for _, value := range values {
+		if value != "" {
+			return true
+		}
+	}
+	return false
+}
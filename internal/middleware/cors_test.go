@@ -0,0 +1,78 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCORS(t *testing.T) {
+	saved := config.Application.CORS
+	defer func() {
+		config.Application.CORS = saved
+	}()
+	config.Application.CORS.Origins = []string{"http://localhost:1337", "https://*.example.com"}
+	config.Application.CORS.Methods = []string{"GET", "POST"}
+	config.Application.CORS.Headers = []string{"Content-Type", "X-Rh-Identity"}
+	config.Application.CORS.Credentials = true
+	config.Application.CORS.MaxAge = 10 * time.Minute
+
+	handler := middleware.CORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	serve := func(t *testing.T, method, origin string, preflight bool) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), method, "/api/provisioning/v1/pubkeys", nil)
+		require.NoError(t, err)
+		req.Header.Set("Origin", origin)
+		if preflight {
+			req.Header.Set("Access-Control-Request-Method", "POST")
+		}
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("allowed", func(t *testing.T) {
+		rr := serve(t, "GET", "http://localhost:1337", false)
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "http://localhost:1337", rr.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "true", rr.Header().Get("Access-Control-Allow-Credentials"))
+		assert.Equal(t, "Origin", rr.Header().Get("Vary"))
+	})
+
+	t.Run("wildcard", func(t *testing.T) {
+		rr := serve(t, "GET", "https://console.example.com", false)
+		assert.Equal(t, "https://console.example.com", rr.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("not allowed", func(t *testing.T) {
+		rr := serve(t, "GET", "https://example.org", false)
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("preflight", func(t *testing.T) {
+		rr := serve(t, "OPTIONS", "http://localhost:1337", true)
+		require.Equal(t, http.StatusNoContent, rr.Code)
+		assert.Equal(t, "http://localhost:1337", rr.Header().Get("Access-Control-Allow-Origin"))
+		assert.Contains(t, rr.Header().Get("Access-Control-Allow-Methods"), "POST")
+		assert.Contains(t, rr.Header().Get("Access-Control-Allow-Headers"), "X-Rh-Identity")
+		assert.Equal(t, "600", rr.Header().Get("Access-Control-Max-Age"))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		config.Application.CORS.Origins = []string{""}
+		rr := serve(t, "OPTIONS", "http://localhost:1337", true)
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Header().Get("Access-Control-Allow-Origin"))
+	})
+}
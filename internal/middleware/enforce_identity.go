@@ -62,6 +62,7 @@ func EnforceIdentity(next http.Handler) http.Handler {
 			return
 		}
 
+		SetAccountTier(r.Context(), accountTier(jsonData))
 		ctx := context.WithValue(r.Context(), identity.Key, jsonData)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
@@ -19,7 +19,8 @@ var (
 )
 
 // EnforcePermissions enforces permissions via RBAC service. It requires that identity is present
-// in the context, make sure to chain EnforceIdentity middleware before this one.
+// in the context, make sure to chain EnforceIdentity middleware before this one. Workspaces the
+// permission is restricted to are stored in the context and used by DAO for filtering.
 func EnforcePermissions(resource, permission string) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		fn := func(w http.ResponseWriter, r *http.Request) {
@@ -53,6 +54,7 @@ func EnforcePermissions(resource, permission string) func(next http.Handler) htt
 			}
 
 			ctx := rbac.WithAcl(r.Context(), acl)
+			ctx = identity.WithWorkspaces(ctx, acl.Workspaces(resource, permission))
 			next.ServeHTTP(w, r.WithContext(ctx))
 		}
 		return http.HandlerFunc(fn)
@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// allowMethods are HTTP methods reported in the Allow header, in this order.
+var allowMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodOptions,
+}
+
+// HeadAndOptions answers HEAD requests with headers and status of the GET handler without body, and
+// OPTIONS requests with an Allow header listing methods of the matching route. Routes with an explicit
+// HEAD or OPTIONS handler are left untouched. Some API gateways and client generators require both
+// methods. The router must be the one the middleware is used on, methods are resolved by the router
+// itself, so the Allow header lists exactly the methods which would be routed.
+func HeadAndOptions(router chi.Routes) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodHead && r.Method != http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+			path := routePath(r)
+
+			switch r.Method {
+			case http.MethodHead:
+				rctx := chi.RouteContext(r.Context())
+				if rctx != nil && !matches(router, http.MethodHead, path) && matches(router, http.MethodGet, path) {
+					// the router matches the route method, the request method is kept for logging
+					rctx.RouteMethod = http.MethodGet
+					w = &headResponseWriter{ResponseWriter: w}
+				}
+			case http.MethodOptions:
+				if methods := allowedMethods(router, path); len(methods) > 0 && !matches(router, http.MethodOptions, path) {
+					w.Header().Set("Allow", strings.Join(methods, ", "))
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		}
+		return http.HandlerFunc(fn)
+	}
+}
+
+// matches returns true when the router has a handler for the method and path, including mounted
+// sub-routers.
+func matches(router chi.Routes, method, path string) bool {
+	rctx := chi.NewRouteContext()
+	if !router.Match(rctx, method, path) {
+		return false
+	}
+
+	// there is one pattern per router level, chi descends into routers mounted via "/*" patterns
+	routes := router
+	for i, pattern := range rctx.RoutePatterns {
+		if strings.HasSuffix(pattern, "/*") {
+			if routes = subRoutes(routes, pattern); routes == nil {
+				// catch-all handler
+				return true
+			}
+			continue
+		}
+
+		// chi matches a mount point without the trailing wildcard (e.g. "/pubkeys" of "/pubkeys/*")
+		// for any method and does not descend into the mounted router, which only sees "/"
+		if i == len(rctx.RoutePatterns)-1 {
+			if mounted := subRoutes(routes, strings.TrimSuffix(pattern, "/")+"/*"); mounted != nil {
+				return matches(mounted, method, "/")
+			}
+		}
+	}
+	return true
+}
+
+// subRoutes returns the router mounted on the pattern, nil when there is none.
+func subRoutes(router chi.Routes, pattern string) chi.Routes {
+	for _, route := range router.Routes() {
+		if route.Pattern == pattern {
+			return route.SubRoutes
+		}
+	}
+	return nil
+}
+
+// routePath returns the path the router matches, mounted routers only see the remaining path.
+func routePath(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePath != "" {
+		return rctx.RoutePath
+	}
+	if r.URL.RawPath != "" {
+		return r.URL.RawPath
+	}
+	return r.URL.Path
+}
+
+// allowedMethods returns methods of the route in a stable order, HEAD is allowed for GET routes.
+// Nil is returned when no route matches the path.
+func allowedMethods(router chi.Routes, path string) []string {
+	var result []string
+	get := matches(router, http.MethodGet, path)
+	for _, method := range allowMethods {
+		switch method {
+		case http.MethodGet:
+			if get {
+				result = append(result, method)
+			}
+		case http.MethodHead:
+			if get || matches(router, method, path) {
+				result = append(result, method)
+			}
+		case http.MethodOptions:
+			// only reported together with other methods
+		default:
+			if matches(router, method, path) {
+				result = append(result, method)
+			}
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return append(result, http.MethodOptions)
+}
+
+// headResponseWriter discards body written by a GET handler, status and headers are kept.
+type headResponseWriter struct {
+	http.ResponseWriter
+}
+
+func (w *headResponseWriter) Write(data []byte) (int, error) {
+	return len(data), nil
+}
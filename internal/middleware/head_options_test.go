@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeadAndOptions(t *testing.T) {
+	router := chi.NewRouter()
+	apiRouter := chi.NewRouter()
+	apiRouter.Use(HeadAndOptions(apiRouter))
+	apiRouter.Route("/pubkeys", func(r chi.Router) {
+		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Test", "list")
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		})
+		r.Post("/", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+		})
+		r.Delete("/{ID}", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})
+	})
+	ok := func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}
+	apiRouter.Route("/reservations", func(r chi.Router) {
+		r.Route("/{TYPE}", func(r chi.Router) {
+			r.Get("/{ID}", ok)
+			r.Post("/", ok)
+		})
+		r.Get("/{ID}", ok)
+		r.Post("/{ID}/instances/{INSTANCE_ID}:stop", ok)
+		r.Post("/{ID}/instances/{INSTANCE_ID}:start", ok)
+		r.Get("/{ID}/instances/{INSTANCE_ID}/console", ok)
+	})
+	apiRouter.Options("/template", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	router.Mount("/api/provisioning/v1", apiRouter)
+
+	serve := func(t *testing.T, method, path string) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), method, path, nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("head", func(t *testing.T) {
+		rr := serve(t, "HEAD", "/api/provisioning/v1/pubkeys")
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "list", rr.Header().Get("X-Test"))
+		assert.Empty(t, rr.Body.String())
+	})
+
+	t.Run("head without get", func(t *testing.T) {
+		rr := serve(t, "HEAD", "/api/provisioning/v1/pubkeys/1")
+		require.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+
+	t.Run("options", func(t *testing.T) {
+		rr := serve(t, "OPTIONS", "/api/provisioning/v1/pubkeys")
+		require.Equal(t, http.StatusNoContent, rr.Code)
+		assert.Equal(t, "GET, HEAD, POST, OPTIONS", rr.Header().Get("Allow"))
+
+		rr = serve(t, "OPTIONS", "/api/provisioning/v1/pubkeys/1")
+		require.Equal(t, http.StatusNoContent, rr.Code)
+		assert.Equal(t, "DELETE, OPTIONS", rr.Header().Get("Allow"))
+	})
+
+	t.Run("route next to mounted router", func(t *testing.T) {
+		rr := serve(t, "OPTIONS", "/api/provisioning/v1/reservations/123")
+		require.Equal(t, http.StatusNoContent, rr.Code)
+		assert.Equal(t, "GET, HEAD, POST, OPTIONS", rr.Header().Get("Allow"))
+
+		rr = serve(t, "HEAD", "/api/provisioning/v1/reservations/123")
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Body.String())
+
+		rr = serve(t, "OPTIONS", "/api/provisioning/v1/reservations/aws/123")
+		require.Equal(t, http.StatusNoContent, rr.Code)
+		assert.Equal(t, "GET, HEAD, OPTIONS", rr.Header().Get("Allow"))
+	})
+
+	t.Run("parameter with suffix", func(t *testing.T) {
+		for _, action := range []string{"stop", "start"} {
+			rr := serve(t, "OPTIONS", "/api/provisioning/v1/reservations/1/instances/i-1:"+action)
+			require.Equal(t, http.StatusNoContent, rr.Code, action)
+			assert.Equal(t, "POST, OPTIONS", rr.Header().Get("Allow"), action)
+
+			rr = serve(t, "HEAD", "/api/provisioning/v1/reservations/1/instances/i-1:"+action)
+			require.Equal(t, http.StatusMethodNotAllowed, rr.Code, action)
+		}
+
+		rr := serve(t, "HEAD", "/api/provisioning/v1/reservations/1/instances/i-1/console")
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("explicit options", func(t *testing.T) {
+		rr := serve(t, "OPTIONS", "/api/provisioning/v1/template")
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Header().Get("Allow"))
+	})
+
+	t.Run("unknown route", func(t *testing.T) {
+		rr := serve(t, "OPTIONS", "/api/provisioning/v1/unknown")
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
@@ -18,12 +18,11 @@ func LoggerMiddleware(rootLogger *zerolog.Logger) func(next http.Handler) http.H
 		fn := func(w http.ResponseWriter, r *http.Request) {
 			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
 			bytesIn, _ := strconv.Atoi(r.Header.Get("Content-Length"))
-			traceId := logging.TraceId(r.Context())
 			edgeId := logging.EdgeRequestId(r.Context())
 			corrId := logging.CorrelationId(r.Context())
 			lctx := rootLogger.With().
+				Ctx(r.Context()).
 				Timestamp().
-				Str("trace_id", traceId).
 				Str("remote_ip", r.RemoteAddr).
 				Str("url", r.URL.Path).
 				Str("method", r.Method).
@@ -4,6 +4,7 @@ package middleware
 // License: Apache 2.0
 
 import (
+	"context"
 	"net/http"
 	"strconv"
 	"strings"
@@ -12,20 +13,59 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redhatinsights/platform-go-middlewares/identity"
 )
 
-var buckets = []float64{100, 200, 500, 750, 1000, 2000, 5000}
+var (
+	buckets     = []float64{100, 200, 500, 750, 1000, 2000, 5000}
+	sizeBuckets = []float64{100, 1000, 10000, 100000, 1000000, 10000000}
+)
 
 const (
 	metricNameHttpRequestTotal    = "provisioning_http_request_total"
 	metricNameHttpRequestDuration = "provisioning_http_request_duration_ms"
+	metricNameHttpResponseSize    = "provisioning_http_response_size_bytes"
+)
+
+// unmatchedRoute is the path label of requests not matching any route, raw paths are never used
+// as labels to keep cardinality of the metrics bounded.
+const unmatchedRoute = "unmatched"
+
+// Account tiers of the tier label.
+const (
+	TierAnonymous = "anonymous"
+	TierCustomer  = "customer"
+	TierInternal  = "internal"
+	TierSystem    = "system"
 )
 
+var labelNames = []string{"code", "status_code", "method", "path", "tier"}
+
 // Middleware is a handler that exposes prometheus metrics for the number of requests,
-// the latency and the response size, partitioned by status code, method and HTTP path.
+// the latency and the response size, partitioned by status code, method, HTTP path and
+// account tier.
 type Middleware struct {
 	reqs    *prometheus.CounterVec
 	latency *prometheus.HistogramVec
+	size    *prometheus.HistogramVec
+}
+
+// requestLabels are labels of the request metrics set by handlers down the chain. The middleware
+// puts a pointer into the request context, values are read after the request is served.
+type requestLabels struct {
+	tier string
+}
+
+type requestLabelsCtxKeyType int
+
+const requestLabelsCtxKey requestLabelsCtxKeyType = iota
+
+// SetAccountTier sets the tier label of the request metrics, it does nothing when the request is
+// not served by the metrics middleware.
+func SetAccountTier(ctx context.Context, tier string) {
+	if labels, ok := ctx.Value(requestLabelsCtxKey).(*requestLabels); ok {
+		labels.tier = tier
+	}
 }
 
 // NewPatternMiddleware returns a new prometheus Middleware handler that groups requests by the chi routing pattern.
@@ -38,7 +78,7 @@ func NewPatternMiddleware(name string) func(next http.Handler) http.Handler {
 			Help:        "HTTP requests count partitioned by numeric status code, text status code, method and HTTP path (chi route)",
 			ConstLabels: prometheus.Labels{"service": name},
 		},
-		[]string{"code", "status_code", "method", "path"},
+		labelNames,
 	)
 	prometheus.MustRegister(m.reqs)
 
@@ -48,24 +88,56 @@ func NewPatternMiddleware(name string) func(next http.Handler) http.Handler {
 		ConstLabels: prometheus.Labels{"service": name},
 		Buckets:     buckets,
 	},
-		[]string{"code", "status_code", "method", "path"},
+		labelNames,
 	)
 	prometheus.MustRegister(m.latency)
+
+	m.size = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:        metricNameHttpResponseSize,
+		Help:        "Response body size partitioned by numeric status code, text status code, method, HTTP path (chi route) and account tier",
+		ConstLabels: prometheus.Labels{"service": name},
+		Buckets:     sizeBuckets,
+	},
+		labelNames,
+	)
+	prometheus.MustRegister(m.size)
 	return m.patternHandler
 }
 
 func (c Middleware) patternHandler(next http.Handler) http.Handler {
 	fn := func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
+		labels := &requestLabels{tier: TierAnonymous}
 		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
-		next.ServeHTTP(ww, r)
-
-		rctx := chi.RouteContext(r.Context())
-		routePattern := strings.Join(rctx.RoutePatterns, "")
-		routePattern = strings.Replace(routePattern, "/*/", "/", -1)
+		next.ServeHTTP(ww, r.WithContext(context.WithValue(r.Context(), requestLabelsCtxKey, labels)))
 
-		c.reqs.WithLabelValues(strconv.Itoa(ww.Status()), http.StatusText(ww.Status()), r.Method, routePattern).Inc()
-		c.latency.WithLabelValues(strconv.Itoa(ww.Status()), http.StatusText(ww.Status()), r.Method, routePattern).Observe(float64(time.Since(start).Nanoseconds()) / 1000000)
+		values := []string{strconv.Itoa(ww.Status()), http.StatusText(ww.Status()), r.Method, routePattern(r), labels.tier}
+		c.reqs.WithLabelValues(values...).Inc()
+		c.latency.WithLabelValues(values...).Observe(float64(time.Since(start).Nanoseconds()) / 1000000)
+		c.size.WithLabelValues(values...).Observe(float64(ww.BytesWritten()))
 	}
 	return http.HandlerFunc(fn)
 }
+
+// routePattern returns the chi routing pattern of the request, mounted routers are joined.
+func routePattern(r *http.Request) string {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil || len(rctx.RoutePatterns) == 0 {
+		return unmatchedRoute
+	}
+	pattern := strings.Join(rctx.RoutePatterns, "")
+	return strings.Replace(pattern, "/*/", "/", -1)
+}
+
+// accountTier returns the tier label of an identity: Red Hat employees are internal, systems and
+// certificates are system and all other identities are customers.
+func accountTier(id identity.XRHID) string {
+	switch {
+	case id.Identity.User.Internal:
+		return TierInternal
+	case id.Identity.Type == "System" || id.Identity.Type == "X509":
+		return TierSystem
+	default:
+		return TierCustomer
+	}
+}
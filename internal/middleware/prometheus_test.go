@@ -10,6 +10,7 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redhatinsights/platform-go-middlewares/identity"
 )
 
 func Test_PatternLogger(t *testing.T) {
@@ -31,6 +32,11 @@ func Test_PatternLogger(t *testing.T) {
 		fmt.Fprintln(w, "ok")
 	})
 
+	n.Get(`/internal`, func(w http.ResponseWriter, r *http.Request) {
+		SetAccountTier(r.Context(), TierInternal)
+		w.WriteHeader(http.StatusOK)
+	})
+
 	req1, err := http.NewRequestWithContext(ctx, "GET", "http://localhost:3000/ok", nil)
 	if err != nil {
 		t.Error(err)
@@ -47,7 +53,17 @@ func Test_PatternLogger(t *testing.T) {
 	if err != nil {
 		t.Error(err)
 	}
+	req5, err := http.NewRequestWithContext(ctx, "GET", "http://localhost:3000/internal", nil)
+	if err != nil {
+		t.Error(err)
+	}
+	req6, err := http.NewRequestWithContext(ctx, "GET", "http://localhost:3000/unknown/path", nil)
+	if err != nil {
+		t.Error(err)
+	}
 
+	n.ServeHTTP(httptest.NewRecorder(), req5)
+	n.ServeHTTP(httptest.NewRecorder(), req6)
 	n.ServeHTTP(recorder, req1)
 	n.ServeHTTP(recorder, req2)
 	n.ServeHTTP(recorder, req3)
@@ -61,11 +77,17 @@ func Test_PatternLogger(t *testing.T) {
 	if !strings.Contains(body, metricNameHttpRequestDuration) {
 		t.Errorf("body does not contain request duration entry '%s'", metricNameHttpRequestDuration)
 	}
+	if !strings.Contains(body, metricNameHttpResponseSize) {
+		t.Errorf("body does not contain response size entry '%s'", metricNameHttpResponseSize)
+	}
 
-	req1Count := `provisioning_http_request_total{code="200",method="GET",path="/ok",service="patternOnlyTest",status_code="OK"} 1`
+	req1Count := `provisioning_http_request_total{code="200",method="GET",path="/ok",service="patternOnlyTest",status_code="OK",tier="anonymous"} 1`
 	joeBobCount := `provisioning_http_request_total{code="200", status_code="OK",method="GET",path="/users/JoeBob",service="patternOnlyTest"} 1`
 	mistyCount := `provisioning_http_request_total{code="200", status_code="OK",method="GET",path="/users/Misty",service="patternOnlyTest"} 1`
-	firstNamePatternCount := `provisioning_http_request_total{code="200",method="GET",path="/users/{firstName}",service="patternOnlyTest",status_code="OK"} 2`
+	firstNamePatternCount := `provisioning_http_request_total{code="200",method="GET",path="/users/{firstName}",service="patternOnlyTest",status_code="OK",tier="anonymous"} 2`
+	internalCount := `provisioning_http_request_total{code="200",method="GET",path="/internal",service="patternOnlyTest",status_code="OK",tier="internal"} 1`
+	unmatchedCount := `provisioning_http_request_total{code="404",method="GET",path="unmatched",service="patternOnlyTest",status_code="Not Found",tier="anonymous"} 1`
+	req1Size := `provisioning_http_response_size_bytes_sum{code="200",method="GET",path="/ok",service="patternOnlyTest",status_code="OK",tier="anonymous"} 3`
 
 	if !strings.Contains(body, req1Count) {
 		t.Errorf("body does not contain req1 count summary '%s'", req1Count)
@@ -79,4 +101,32 @@ func Test_PatternLogger(t *testing.T) {
 	if !strings.Contains(body, firstNamePatternCount) {
 		t.Errorf("body does not contain first name pattern count summary '%s'", firstNamePatternCount)
 	}
+	if !strings.Contains(body, internalCount) {
+		t.Errorf("body does not contain internal tier count summary '%s'", internalCount)
+	}
+	if !strings.Contains(body, unmatchedCount) {
+		t.Errorf("body does not contain unmatched route count summary '%s'", unmatchedCount)
+	}
+	if !strings.Contains(body, req1Size) {
+		t.Errorf("body does not contain req1 size summary '%s'", req1Size)
+	}
+}
+
+func TestAccountTier(t *testing.T) {
+	var id identity.XRHID
+	id.Identity.Type = "User"
+	if tier := accountTier(id); tier != TierCustomer {
+		t.Errorf("expected %s tier for users, got %s", TierCustomer, tier)
+	}
+
+	id.Identity.User.Internal = true
+	if tier := accountTier(id); tier != TierInternal {
+		t.Errorf("expected %s tier for internal users, got %s", TierInternal, tier)
+	}
+
+	id = identity.XRHID{}
+	id.Identity.Type = "System"
+	if tier := accountTier(id); tier != TierSystem {
+		t.Errorf("expected %s tier for systems, got %s", TierSystem, tier)
+	}
 }
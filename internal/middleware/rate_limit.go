@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/RHEnVision/provisioning-backend/internal/ratelimit"
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog"
+)
+
+var ErrRateLimitExceeded = errors.New("rate limit exceeded")
+
+// RateLimit counts requests per account and responds with 429 when the configured limit is
+// exceeded. X-RateLimit headers are returned with every response. Account must be present
+// in the context, chain AccountMiddleware before this one. When the counter cannot be updated,
+// requests are allowed.
+func RateLimit(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		if !config.Application.RateLimit.Enabled {
+			next.ServeHTTP(w, r)
+			return
+		}
+		logger := zerolog.Ctx(r.Context())
+
+		status, allowed, err := ratelimit.Take(r.Context())
+		if err != nil {
+			logger.Warn().Err(err).Msg("Unable to check rate limit, request allowed")
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		status.SetHeaders(w.Header())
+		if !allowed {
+			w.Header().Set("Retry-After", strconv.FormatInt(status.ResetSeconds(), 10))
+			limitErr := fmt.Errorf("%w: %d requests per %s", ErrRateLimitExceeded, status.Limit, config.Application.RateLimit.Window)
+			errRender := render.Render(w, r, payloads.NewRateLimitError(r.Context(), "rate limit exceeded", limitErr))
+			if errRender != nil {
+				logger.Warn().Err(errRender).Msg("Cannot render rate limit middleware error")
+			}
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	}
+	return http.HandlerFunc(fn)
+}
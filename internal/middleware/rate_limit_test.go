@@ -0,0 +1,59 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/RHEnVision/provisioning-backend/internal/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimit(t *testing.T) {
+	defer func(enabled bool, requests int64, window time.Duration) {
+		config.Application.RateLimit.Enabled = enabled
+		config.Application.RateLimit.Requests = requests
+		config.Application.RateLimit.Window = window
+	}(config.Application.RateLimit.Enabled, config.Application.RateLimit.Requests, config.Application.RateLimit.Window)
+	config.Application.RateLimit.Enabled = true
+	config.Application.RateLimit.Requests = 2
+	config.Application.RateLimit.Window = time.Minute
+
+	handler := middleware.RateLimit(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	ctx := identity.WithAccountId(context.Background(), 10042)
+
+	for i, expected := range []struct {
+		code      int
+		remaining string
+	}{
+		{http.StatusOK, "1"},
+		{http.StatusOK, "0"},
+		{http.StatusTooManyRequests, "0"},
+	} {
+		req, err := http.NewRequestWithContext(ctx, "GET", "/test", nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, expected.code, rr.Code, "Wrong status code of call %d", i+1)
+		assert.Equal(t, "2", rr.Header().Get("X-RateLimit-Limit"))
+		assert.Equal(t, expected.remaining, rr.Header().Get("X-RateLimit-Remaining"))
+		assert.Equal(t, "60", rr.Header().Get("X-RateLimit-Reset"))
+	}
+
+	t.Run("other account", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(identity.WithAccountId(context.Background(), 10043), "GET", "/test", nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+}
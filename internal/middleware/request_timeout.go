@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/rs/zerolog"
+)
+
+var ErrInvalidTimeout = errors.New("invalid timeout")
+
+// RequestTimeout derives a deadline of the request context from the gateway timeout header, so work
+// including outbound calls is abandoned when the gateway has already given up. The configured margin
+// is subtracted, so the error response is sent before the gateway timeout. Requests without the
+// header or with an invalid value have no deadline.
+func RequestTimeout(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		cfg := config.Application.RequestTimeout
+		value := ""
+		if cfg.Header != "" {
+			value = r.Header.Get(cfg.Header)
+		}
+		if value == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		timeout, err := ParseTimeout(value)
+		if err != nil {
+			zerolog.Ctx(r.Context()).Warn().Err(err).Msgf("Ignoring %s header", cfg.Header)
+			next.ServeHTTP(w, r)
+			return
+		}
+		if cfg.Max > 0 && timeout > cfg.Max {
+			timeout = cfg.Max
+		}
+		if timeout > cfg.Margin {
+			timeout -= cfg.Margin
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		zerolog.Ctx(ctx).Trace().Msgf("Request deadline set to %s by %s header", timeout, cfg.Header)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	}
+	return http.HandlerFunc(fn)
+}
+
+// ParseTimeout parses timeout in seconds (e.g. "30" or "2.5") or in time interval syntax (e.g. "30s"),
+// the timeout must be positive.
+func ParseTimeout(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		seconds, floatErr := strconv.ParseFloat(value, 64)
+		if floatErr != nil {
+			return 0, fmt.Errorf("%w: %s", ErrInvalidTimeout, value)
+		}
+		timeout = time.Duration(seconds * float64(time.Second))
+	}
+	if timeout <= 0 {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidTimeout, value)
+	}
+	return timeout, nil
+}
@@ -0,0 +1,83 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTimeout(t *testing.T) {
+	for value, expected := range map[string]time.Duration{
+		"30":    30 * time.Second,
+		"2.5":   2500 * time.Millisecond,
+		"1m30s": 90 * time.Second,
+		" 10s ": 10 * time.Second,
+	} {
+		timeout, err := middleware.ParseTimeout(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, expected, timeout, value)
+	}
+
+	for _, value := range []string{"", "0", "-5", "soon"} {
+		_, err := middleware.ParseTimeout(value)
+		require.ErrorIs(t, err, middleware.ErrInvalidTimeout, value)
+	}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	saved := config.Application.RequestTimeout
+	defer func() {
+		config.Application.RequestTimeout = saved
+	}()
+	config.Application.RequestTimeout.Header = "X-Rh-Timeout"
+	config.Application.RequestTimeout.Margin = time.Second
+	config.Application.RequestTimeout.Max = time.Minute
+
+	serve := func(t *testing.T, value string) (time.Duration, bool) {
+		t.Helper()
+		var left time.Duration
+		var ok bool
+		handler := middleware.RequestTimeout(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var deadline time.Time
+			deadline, ok = r.Context().Deadline()
+			left = time.Until(deadline)
+		}))
+
+		req, err := http.NewRequestWithContext(context.Background(), "GET", "/api/provisioning/v1/sources", nil)
+		require.NoError(t, err)
+		if value != "" {
+			req.Header.Set("X-Rh-Timeout", value)
+		}
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+		return left, ok
+	}
+
+	t.Run("deadline", func(t *testing.T) {
+		left, ok := serve(t, "10")
+		require.True(t, ok, "Deadline must be set")
+		assert.InDelta(t, 9*time.Second, left, float64(100*time.Millisecond))
+	})
+
+	t.Run("capped", func(t *testing.T) {
+		left, ok := serve(t, "1h")
+		require.True(t, ok, "Deadline must be set")
+		assert.InDelta(t, 59*time.Second, left, float64(100*time.Millisecond))
+	})
+
+	t.Run("no header", func(t *testing.T) {
+		_, ok := serve(t, "")
+		require.False(t, ok, "Deadline must not be set")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, ok := serve(t, "soon")
+		require.False(t, ok, "Deadline must not be set")
+	})
+}
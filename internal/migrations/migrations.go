@@ -113,6 +113,9 @@ var (
 func Migrate(ctx context.Context, schema string) error {
 	logger := log.Logger.With().Bool("migration", true).Logger()
 	logger.Debug().Msgf("Started migration")
+	if config.Database.PgBouncer {
+		logger.Warn().Msg("Migrations hold a session advisory lock, they must not run through transaction pooling")
+	}
 	if schema == "" {
 		schema = "public"
 	}
@@ -123,6 +126,17 @@ func Migrate(ctx context.Context, schema string) error {
 	}
 	defer conn.Release()
 
+	// migrations can take longer than the default statement timeout
+	_, err := conn.Exec(ctx, "SET statement_timeout = 0")
+	if err != nil {
+		return fmt.Errorf("error disabling statement timeout: %w", err)
+	}
+	defer func() {
+		if _, resetErr := conn.Exec(ctx, "RESET statement_timeout"); resetErr != nil {
+			logger.Warn().Err(resetErr).Msg("Unable to reset statement timeout")
+		}
+	}()
+
 	mfs := NewEmbeddedFS(&sql.EmbeddedSQLMigrations)
 	table := fmt.Sprintf("%s.schema_version", schema)
 	migrator, err := migrate.NewMigrator(ctx, conn.Conn(), table)
@@ -0,0 +1,18 @@
+--
+-- Notification about reservation status changes. Clients waiting for a reservation to change (long-polling
+-- of the reservation detail) listen on the channel and receive reservation ID as the payload.
+--
+
+CREATE OR REPLACE FUNCTION reservations_notify() RETURNS TRIGGER AS
+$reservations_notify$
+BEGIN
+  PERFORM pg_notify('reservation_status', NEW.id::TEXT);
+  RETURN NEW;
+END;
+$reservations_notify$ LANGUAGE plpgsql;
+
+CREATE TRIGGER reservations_notify_trigger
+  AFTER UPDATE ON reservations
+  FOR EACH ROW
+  WHEN (OLD.status IS DISTINCT FROM NEW.status OR OLD.finished_at IS DISTINCT FROM NEW.finished_at)
+EXECUTE FUNCTION reservations_notify();
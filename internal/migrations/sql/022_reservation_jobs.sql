@@ -0,0 +1,12 @@
+--
+-- Background jobs of reservations. The job (type, identity and arguments) is stored when it is enqueued, so it
+-- can be requeued or replayed later by administrators.
+--
+
+CREATE TABLE reservation_jobs
+(
+  reservation_id BIGINT PRIMARY KEY REFERENCES reservations(id) ON DELETE CASCADE,
+  job_type TEXT NOT NULL CHECK (NOT empty(job_type)),
+  job JSONB NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT current_timestamp
+);
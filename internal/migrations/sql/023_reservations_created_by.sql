@@ -0,0 +1,9 @@
+--
+-- Creator of a reservation taken from the user identity. Reservations created before this migration or
+-- by non-user identities have blank values.
+--
+
+ALTER TABLE reservations ADD COLUMN created_by_user_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE reservations ADD COLUMN created_by TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX reservations_account_id_created_by_user_id_idx ON reservations(account_id, created_by_user_id);
@@ -0,0 +1,8 @@
+--
+-- Workspace of reservations and pubkeys from RBAC resource definitions. Resources created before this
+-- migration or by principals not restricted to workspaces have blank values and are only visible to
+-- principals not restricted to workspaces.
+--
+
+ALTER TABLE reservations ADD COLUMN workspace_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE pubkeys ADD COLUMN workspace_id TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,18 @@
+--
+-- Saved launch definitions. The request column contains the provider specific reservation request which is
+-- used as a base when a reservation is created from the template.
+--
+
+CREATE TABLE reservation_templates
+(
+  id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  account_id BIGINT NOT NULL REFERENCES accounts(id),
+  workspace_id TEXT NOT NULL DEFAULT '',
+  provider INTEGER NOT NULL CHECK (valid_provider(provider)),
+  name TEXT NOT NULL CHECK (NOT empty(name)),
+  request JSONB NOT NULL,
+  created_by TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+
+  UNIQUE(name, account_id)
+);
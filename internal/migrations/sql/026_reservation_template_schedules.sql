@@ -0,0 +1,15 @@
+--
+-- Scheduled launches of reservation templates. One-shot schedules have blank cron expression and next_run_at
+-- is cleared after the launch, recurring schedules have next_run_at computed from the cron expression (UTC).
+-- Scheduled launches are performed by a service identity on behalf of the user who scheduled the template,
+-- only the user ID and username are stored instead of the whole identity header.
+--
+
+ALTER TABLE reservation_templates ADD COLUMN schedule_cron TEXT NOT NULL DEFAULT '';
+ALTER TABLE reservation_templates ADD COLUMN schedule_user_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE reservation_templates ADD COLUMN schedule_username TEXT NOT NULL DEFAULT '';
+ALTER TABLE reservation_templates ADD COLUMN next_run_at TIMESTAMP;
+ALTER TABLE reservation_templates ADD COLUMN last_run_at TIMESTAMP;
+ALTER TABLE reservation_templates ADD COLUMN last_reservation_id BIGINT REFERENCES reservations(id) ON DELETE SET NULL;
+
+CREATE INDEX reservation_templates_next_run_at_idx ON reservation_templates(next_run_at) WHERE next_run_at IS NOT NULL;
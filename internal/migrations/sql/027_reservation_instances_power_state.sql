@@ -0,0 +1,7 @@
+--
+-- Power state of instances changed via stop and start operations. Instances are created running.
+--
+
+ALTER TABLE reservation_instances ADD COLUMN
+  power_state TEXT NOT NULL DEFAULT 'running'
+    CHECK (power_state IN ('running', 'stopping', 'stopped', 'starting', 'unknown'));
@@ -0,0 +1,18 @@
+--
+-- Changes of provisioned resources performed on behalf of users. Records are kept when the reservation
+-- is deleted, therefore there is no foreign key to reservations.
+--
+
+CREATE TABLE audit_log
+(
+  id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  account_id BIGINT NOT NULL REFERENCES accounts(id),
+  reservation_id BIGINT NOT NULL,
+  instance_id TEXT NOT NULL DEFAULT '',
+  action TEXT NOT NULL CHECK (NOT empty(action)),
+  actor TEXT NOT NULL DEFAULT '',
+  details JSONB NOT NULL DEFAULT '{}',
+  created_at TIMESTAMP NOT NULL DEFAULT current_timestamp
+);
+
+CREATE INDEX audit_log_reservation_id ON audit_log(account_id, reservation_id);
@@ -0,0 +1,9 @@
+--
+-- Per-account sequence used in generated reservation names.
+--
+
+CREATE TABLE account_name_sequences
+(
+  account_id BIGINT PRIMARY KEY REFERENCES accounts(id) ON DELETE CASCADE,
+  last_value BIGINT NOT NULL
+);
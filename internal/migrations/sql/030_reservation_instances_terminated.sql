@@ -0,0 +1,8 @@
+--
+-- Instances found terminated in the cloud account by a live state refresh.
+--
+
+ALTER TABLE reservation_instances DROP CONSTRAINT reservation_instances_power_state_check;
+
+ALTER TABLE reservation_instances ADD CONSTRAINT reservation_instances_power_state_check
+  CHECK (power_state IN ('running', 'stopping', 'stopped', 'starting', 'unknown', 'terminated'));
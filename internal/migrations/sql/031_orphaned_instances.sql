@@ -0,0 +1,20 @@
+--
+-- Instances out of sync between cloud accounts and the database found by the orphan detection.
+-- Orphans of a source are replaced on every detection run.
+--
+
+CREATE TABLE orphaned_instances
+(
+  id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  account_id BIGINT NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+  provider INTEGER NOT NULL,
+  source_id TEXT NOT NULL CHECK (NOT empty(source_id)),
+  location TEXT NOT NULL DEFAULT '',
+  instance_id TEXT NOT NULL CHECK (NOT empty(instance_id)),
+  reservation_id BIGINT NOT NULL,
+  kind TEXT NOT NULL CHECK (kind IN ('untracked', 'missing')),
+  detected_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+  UNIQUE (account_id, provider, instance_id)
+);
+
+CREATE INDEX orphaned_instances_source_id ON orphaned_instances(account_id, provider, source_id);
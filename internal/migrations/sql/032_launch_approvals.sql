@@ -0,0 +1,21 @@
+--
+-- Organization settings. Launches of more instances than the approval threshold are held until an
+-- approver approves (or rejects) them, zero disables approvals.
+--
+
+CREATE TABLE account_settings
+(
+  account_id BIGINT PRIMARY KEY REFERENCES accounts(id) ON DELETE CASCADE,
+  approval_threshold BIGINT NOT NULL DEFAULT 0 CHECK (approval_threshold >= 0),
+  updated_at TIMESTAMP NOT NULL DEFAULT current_timestamp
+);
+
+--
+-- Approval state of reservations, blank for reservations which did not require an approval.
+--
+
+ALTER TABLE reservations ADD COLUMN
+  approval TEXT NOT NULL DEFAULT ''
+    CHECK (approval IN ('', 'pending_approval', 'approved', 'rejected'));
+
+ALTER TABLE reservations ADD COLUMN approved_by TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,15 @@
+--
+-- Settings of sources. Launches are only allowed into the allowed regions (all regions when
+-- empty), the default region is used when a launch or a wizard request does not specify one.
+-- Regions are AWS regions, Azure locations and GCP regions without availability zones.
+--
+
+CREATE TABLE source_settings
+(
+  account_id BIGINT NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+  source_id TEXT NOT NULL CHECK (NOT empty(source_id)),
+  default_region TEXT NOT NULL DEFAULT '',
+  allowed_regions TEXT[] NOT NULL DEFAULT '{}',
+  updated_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+  PRIMARY KEY (account_id, source_id)
+);
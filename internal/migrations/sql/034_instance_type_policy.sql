@@ -0,0 +1,9 @@
+--
+-- Instance types policy of organizations. Patterns are shell globs matched against instance type
+-- names, denied patterns take precedence over allowed ones and empty lists do not restrict types.
+-- Zero vCPU limit does not restrict types.
+--
+
+ALTER TABLE account_settings ADD COLUMN allowed_instance_types TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE account_settings ADD COLUMN denied_instance_types TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE account_settings ADD COLUMN max_vcpus INTEGER NOT NULL DEFAULT 0 CHECK (max_vcpus >= 0);
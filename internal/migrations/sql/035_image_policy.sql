@@ -0,0 +1,7 @@
+--
+-- Image policy of organizations. When restricted, only Image Builder composes and images matching
+-- allowed patterns (shell globs matched against image ids) can be launched.
+--
+
+ALTER TABLE account_settings ADD COLUMN composed_images_only BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE account_settings ADD COLUMN allowed_images TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,7 @@
+--
+-- Free-form labels of reservations used to group launches (e.g. by project or event), they are
+-- not propagated to the cloud providers unlike tags.
+--
+
+ALTER TABLE reservations ADD COLUMN labels TEXT[] NOT NULL DEFAULT '{}';
+CREATE INDEX reservations_labels_idx ON reservations USING GIN (labels);
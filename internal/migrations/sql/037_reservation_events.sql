@@ -0,0 +1,16 @@
+--
+-- Timeline of reservations: state transitions and notable events of their jobs (enqueued, dequeued,
+-- step start and finish, provider calls, errors) kept for debugging and support.
+--
+
+CREATE TABLE reservation_events
+(
+  id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  reservation_id BIGINT NOT NULL REFERENCES reservations(id) ON DELETE CASCADE,
+  kind TEXT NOT NULL CHECK (NOT empty(kind)),
+  message TEXT NOT NULL DEFAULT '',
+  details JSONB NOT NULL DEFAULT '{}',
+  created_at TIMESTAMP NOT NULL DEFAULT current_timestamp
+);
+
+CREATE INDEX reservation_events_reservation_id ON reservation_events(reservation_id, id);
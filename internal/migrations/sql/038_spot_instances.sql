@@ -0,0 +1,5 @@
+--
+-- Spot request of AWS instances launched as spot, blank for on-demand instances.
+--
+
+ALTER TABLE reservation_instances ADD COLUMN spot_request_id TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,16 @@
+--
+-- Daily usage of accounts counted by API processes: number of API calls, launched reservations
+-- and requested instances. Used for capacity planning and identifying the heaviest tenants.
+--
+
+CREATE TABLE account_usage
+(
+  account_id BIGINT NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+  day DATE NOT NULL,
+  api_calls BIGINT NOT NULL DEFAULT 0 CHECK (api_calls >= 0),
+  launches BIGINT NOT NULL DEFAULT 0 CHECK (launches >= 0),
+  instances BIGINT NOT NULL DEFAULT 0 CHECK (instances >= 0),
+  PRIMARY KEY (account_id, day)
+);
+
+CREATE INDEX account_usage_day ON account_usage(day);
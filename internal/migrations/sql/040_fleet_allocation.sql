@@ -0,0 +1,6 @@
+--
+-- Capacity pool of AWS instances launched by EC2 Fleet (instance type, subnet, zone and lifecycle),
+-- empty for instances launched by RunInstances.
+--
+
+ALTER TABLE reservation_instances ADD COLUMN fleet_allocation JSONB NOT NULL DEFAULT '{}';
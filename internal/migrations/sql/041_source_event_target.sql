@@ -0,0 +1,6 @@
+--
+-- ARN of an SQS queue or an EventBridge event bus in the AWS account of the source receiving
+-- reservation lifecycle events, blank when events are not published.
+--
+
+ALTER TABLE source_settings ADD COLUMN event_target TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,7 @@
+--
+-- Elastic IP address associated to an AWS instance (allocation and association IDs), empty for
+-- instances without an Elastic IP. Allocated addresses are released when the instance is found
+-- terminated, addresses reused from a pool are kept.
+--
+
+ALTER TABLE reservation_instances ADD COLUMN elastic_ip JSONB NOT NULL DEFAULT '{}';
@@ -0,0 +1,6 @@
+--
+-- Result of the optional health probe of launched instances, false when the launch succeeded
+-- but some instances were not reachable. NULL for reservations which were not probed.
+--
+
+ALTER TABLE reservations ADD COLUMN reachable BOOLEAN;
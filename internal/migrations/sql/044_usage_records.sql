@@ -0,0 +1,13 @@
+--
+-- Usage records sent for an instance. The stats process sends started records of all instances
+-- and terminated records of terminated instances which were not sent yet. Records of existing
+-- instances were already sent by the launch jobs and power state refreshes.
+--
+
+ALTER TABLE reservation_instances ADD COLUMN usage_started BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE reservation_instances ALTER COLUMN usage_started SET DEFAULT FALSE;
+ALTER TABLE reservation_instances ADD COLUMN usage_terminated BOOLEAN NOT NULL DEFAULT FALSE;
+UPDATE reservation_instances SET usage_terminated = TRUE WHERE power_state = 'terminated';
+
+CREATE INDEX reservation_instances_usage_pending ON reservation_instances (reservation_id)
+  WHERE NOT usage_started OR (power_state = 'terminated' AND NOT usage_terminated);
@@ -0,0 +1,18 @@
+--
+-- DNS record created for an instance (zone, name, address and TTL), empty for instances of
+-- reservations without a DNS zone. Records are deleted when the instance is found terminated.
+-- Records created before are taken from the instance detail and the DNS zone of the reservation,
+-- their TTL is not known and the configured TTL is used when they are deleted.
+--
+
+ALTER TABLE reservation_instances ADD COLUMN dns_record JSONB NOT NULL DEFAULT '{}';
+
+UPDATE reservation_instances ri SET dns_record = jsonb_build_object(
+    'zone', COALESCE(aws.detail->>'dns_zone', az.detail->>'dns_zone', gcp.detail->>'dns_zone', ''),
+    'name', ri.detail->>'dns_name',
+    'ipv4', COALESCE(NULLIF(ri.detail->>'public_ipv4', ''), ri.detail->'network_interfaces'->0->>'private_ipv4', ''))
+  FROM reservations r
+    LEFT JOIN aws_reservation_details aws ON aws.reservation_id = r.id
+    LEFT JOIN azure_reservation_details az ON az.reservation_id = r.id
+    LEFT JOIN gcp_reservation_details gcp ON gcp.reservation_id = r.id
+  WHERE r.id = ri.reservation_id AND COALESCE(ri.detail->>'dns_name', '') <> '';
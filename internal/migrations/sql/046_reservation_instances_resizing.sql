@@ -0,0 +1,8 @@
+--
+-- Stopped instances are locked in the resizing state while their instance type is changed.
+--
+
+ALTER TABLE reservation_instances DROP CONSTRAINT reservation_instances_power_state_check;
+
+ALTER TABLE reservation_instances ADD CONSTRAINT reservation_instances_power_state_check
+  CHECK (power_state IN ('running', 'stopping', 'stopped', 'starting', 'unknown', 'terminated', 'resizing'));
@@ -1,6 +1,10 @@
 package models
 
-import "database/sql"
+import (
+	"database/sql"
+	"path"
+	"time"
+)
 
 // Account represents a Red Hat Console account
 type Account struct {
@@ -17,3 +21,105 @@ type Account struct {
 func (a Account) CacheKeyName() string {
 	return "account"
 }
+
+// AccountSettings are settings of an organization.
+type AccountSettings struct {
+	// Associated Account model. Required.
+	AccountID int64 `db:"account_id"`
+
+	// Launches of more instances require an approval, zero disables approvals.
+	ApprovalThreshold int64 `db:"approval_threshold" validate:"gte=0"`
+
+	// Glob patterns of instance types launches are allowed with, all types are allowed when empty.
+	AllowedInstanceTypes []string `db:"allowed_instance_types"`
+
+	// Glob patterns of instance types launches are denied with, takes precedence over allowed types.
+	DeniedInstanceTypes []string `db:"denied_instance_types"`
+
+	// Launches of instance types with more vCPUs are denied, zero disables the limit.
+	MaxVCPUs int32 `db:"max_vcpus" validate:"gte=0"`
+
+	// Only Image Builder composes and allowed images can be launched when set.
+	ComposedImagesOnly bool `db:"composed_images_only"`
+
+	// Glob patterns of image ids which can be launched in addition to Image Builder composes.
+	AllowedImages []string `db:"allowed_images"`
+
+	// Time of the last update.
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// AccountUsage is the usage of an account in one day (UTC).
+type AccountUsage struct {
+	// Associated Account model. Required.
+	AccountID int64 `db:"account_id"`
+
+	// Day of the usage, time is midnight UTC.
+	Day time.Time `db:"day"`
+
+	// Number of API calls.
+	APICalls int64 `db:"api_calls"`
+
+	// Number of launched reservations.
+	Launches int64 `db:"launches"`
+
+	// Number of instances requested by the launches.
+	Instances int64 `db:"instances"`
+}
+
+// AccountUsageTotal is the usage of an account summed over a period.
+type AccountUsageTotal struct {
+	AccountID int64  `db:"account_id"`
+	OrgID     string `db:"org_id"`
+	APICalls  int64  `db:"api_calls"`
+	Launches  int64  `db:"launches"`
+	Instances int64  `db:"instances"`
+}
+
+// AccountPurge is the result of purging data of an account.
+type AccountPurge struct {
+	// Number of deleted reservations including their instances.
+	Reservations int64
+
+	// Number of deleted pubkeys including their resources.
+	Pubkeys int64
+
+	// Number of deleted reservation templates.
+	ReservationTemplates int64
+
+	// Number of deleted orphaned instances.
+	OrphanedInstances int64
+
+	// Number of audit records with the actor and details removed.
+	AuditRecords int64
+
+	// Sources of the deleted reservations, used to purge cached source data.
+	SourceIDs []string
+}
+
+// AllowsInstanceType returns true when launches with the instance type are allowed. Number of
+// vCPUs is zero for unknown types, which are denied when the vCPU limit is set.
+func (s *AccountSettings) AllowsInstanceType(name string, vcpus int32) bool {
+	if s.MaxVCPUs > 0 && (vcpus == 0 || vcpus > s.MaxVCPUs) {
+		return false
+	}
+	if matchesAnyPattern(s.DeniedInstanceTypes, name) {
+		return false
+	}
+	return len(s.AllowedInstanceTypes) == 0 || matchesAnyPattern(s.AllowedInstanceTypes, name)
+}
+
+// AllowsImage returns true when launches from the image are allowed. Composed images are built by
+// Image Builder, other image ids are provider specific (e.g. AMI ids).
+func (s *AccountSettings) AllowsImage(imageID string, composed bool) bool {
+	return !s.ComposedImagesOnly || composed || matchesAnyPattern(s.AllowedImages, imageID)
+}
+
+func matchesAnyPattern(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
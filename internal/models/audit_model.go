@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// AuditAction is the kind of change recorded in the audit log.
+type AuditAction string
+
+const (
+	// AuditActionInstanceResize is recorded when an instance type of an instance was changed.
+	AuditActionInstanceResize AuditAction = "instance_resize"
+	// AuditActionInstanceResizeFailed is recorded when an instance type could not be changed.
+	AuditActionInstanceResizeFailed AuditAction = "instance_resize_failed"
+)
+
+// AuditRecord is a change of provisioned resources performed on behalf of a user.
+type AuditRecord struct {
+	// Required auto-generated PK.
+	ID int64 `db:"id"`
+
+	// Associated Account model. Required.
+	AccountID int64 `db:"account_id"`
+
+	// Associated reservation. Required.
+	ReservationID int64 `db:"reservation_id" validate:"required"`
+
+	// Instance ID on a cloud provider, blank for changes of the whole reservation.
+	InstanceID string `db:"instance_id"`
+
+	// Action performed. Required.
+	Action AuditAction `db:"action" validate:"required"`
+
+	// Username from the identity header, blank for non-user identities.
+	Actor string `db:"actor"`
+
+	// Action specific details (e.g. new instance type).
+	Details map[string]string `db:"details"`
+
+	// Time when the change was recorded.
+	CreatedAt time.Time `db:"created_at"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// OrphanKind tells how an instance is out of sync between a cloud account and the database.
+type OrphanKind string
+
+const (
+	// OrphanKindUntracked is an instance tagged by the service in the cloud account which is not
+	// stored or is stored as terminated.
+	OrphanKindUntracked OrphanKind = "untracked"
+	// OrphanKindMissing is a stored instance which no longer exists in the cloud account.
+	OrphanKindMissing OrphanKind = "missing"
+)
+
+// OrphanedInstance is an instance found by the orphan detection.
+type OrphanedInstance struct {
+	// Required auto-generated PK.
+	ID int64 `db:"id" json:"id"`
+
+	// Associated Account model. Required.
+	AccountID int64 `db:"account_id" json:"account_id"`
+
+	// Provider of the cloud account.
+	Provider ProviderType `db:"provider" json:"provider"`
+
+	// Source ID of the cloud account. Required.
+	SourceID string `db:"source_id" json:"source_id" validate:"required"`
+
+	// AWS region, Azure location or GCP zone of the instance.
+	Location string `db:"location" json:"location"`
+
+	// Instance ID on the cloud provider. Required.
+	InstanceID string `db:"instance_id" json:"instance_id" validate:"required"`
+
+	// Reservation from the tag of untracked instances or of the stored instance.
+	ReservationID int64 `db:"reservation_id" json:"reservation_id"`
+
+	// Kind of the orphan. Required.
+	Kind OrphanKind `db:"kind" json:"kind" validate:"required"`
+
+	// Time when the orphan was detected first.
+	DetectedAt time.Time `db:"detected_at" json:"detected_at"`
+}
@@ -23,6 +23,9 @@ type Pubkey struct {
 	// Associated Account model. Required.
 	AccountID int64 `db:"account_id"`
 
+	// Workspace ID from RBAC, blank when the creator was not restricted to workspaces.
+	WorkspaceID string `db:"workspace_id"`
+
 	// User-facing name. Required.
 	Name string `db:"name" validate:"required"`
 
@@ -21,6 +21,15 @@ type Reservation struct {
 	// Time when reservation was made.
 	CreatedAt time.Time `db:"created_at" json:"created_at"`
 
+	// User ID of the creator from the identity header, blank for non-user identities.
+	CreatedByUserID string `db:"created_by_user_id" json:"created_by_user_id"`
+
+	// Username of the creator from the identity header, blank for non-user identities.
+	CreatedBy string `db:"created_by" json:"created_by"`
+
+	// Workspace ID from RBAC, blank when the creator was not restricted to workspaces.
+	WorkspaceID string `db:"workspace_id" json:"workspace_id"`
+
 	// Total number of job steps for this reservation.
 	Steps int32 `db:"steps" json:"steps"`
 
@@ -44,8 +53,51 @@ type Reservation struct {
 
 	// Flag indicating success, error or unknown state (NULL). See Status for the actual error.
 	Success sql.NullBool `db:"success" json:"success"`
+
+	// Approval state, blank when the launch did not require an approval.
+	Approval ApprovalState `db:"approval" json:"approval"`
+
+	// Username of the approver who approved or rejected the launch.
+	ApprovedBy string `db:"approved_by" json:"approved_by"`
+
+	// Free-form labels grouping reservations, not propagated to the cloud provider.
+	Labels []string `db:"labels" json:"labels"`
+
+	// Result of the health probe, false when the launch succeeded but some instances did not
+	// become reachable. NULL when instances were not probed (yet).
+	Reachable sql.NullBool `db:"reachable" json:"reachable"`
 }
 
+// LabelStats aggregates reservations with the same label.
+type LabelStats struct {
+	// The label.
+	Label string `db:"label"`
+
+	// Number of reservations with the label.
+	Reservations int64 `db:"reservations"`
+
+	// Number of reservations finished successfully.
+	Succeeded int64 `db:"succeeded"`
+
+	// Number of reservations finished with an error.
+	Failed int64 `db:"failed"`
+
+	// Number of instances launched by reservations with the label.
+	Instances int64 `db:"instances"`
+}
+
+// ApprovalState is the state of a launch which requires an approval.
+type ApprovalState string
+
+const (
+	// ApprovalNone is set for launches which did not require an approval.
+	ApprovalNone ApprovalState = ""
+	// ApprovalPending is set until an approver approves or rejects the launch, the job is not enqueued.
+	ApprovalPending  ApprovalState = "pending_approval"
+	ApprovalApproved ApprovalState = "approved"
+	ApprovalRejected ApprovalState = "rejected"
+)
+
 type NoopReservation struct {
 	Reservation
 }
@@ -56,12 +108,22 @@ type AWSDetail struct {
 	// Optional instance name
 	Name *string `json:"name"`
 
-	// Optional launch template id ("lt-987432987342") or empty string
+	// Optional launch template id ("lt-987432987342"), name or empty string
 	LaunchTemplateID string `json:"launch_template_id"`
 
 	// AWS Instance type. Can be blank if LaunchTemplateID is set.
 	InstanceType string `json:"instance_type"`
 
+	// Alternative instance types tried in order when the launch fails on insufficient capacity
+	FallbackInstanceTypes []string `json:"fallback_instance_types,omitempty"`
+
+	// Instance type the instances were launched with, set only when fallback types are defined
+	LaunchedInstanceType string `json:"launched_instance_type,omitempty"`
+
+	// Windows is set for Windows images, instances are accessed over RDP with the Administrator
+	// password instead of SSH.
+	Windows bool `json:"windows,omitempty"`
+
 	// Amount of instances to provision of type: Instance type.
 	Amount int32 `json:"amount"`
 
@@ -70,6 +132,135 @@ type AWSDetail struct {
 
 	// PubkeyName on AWS in given region. Found by the EnsurePubkey job.
 	PubkeyName string `json:"pubkey_name"`
+
+	// Optional IAM instance profile name or ARN attached to the instances
+	InstanceProfile string `json:"instance_profile,omitempty"`
+
+	// Encrypt EBS volumes of the instances
+	EncryptVolumes bool `json:"encrypt_volumes,omitempty"`
+
+	// Optional KMS key used for the volume encryption
+	KMSKeyID string `json:"kms_key_id,omitempty"`
+
+	// Enable Nitro Enclaves on the instances
+	NitroEnclaves bool `json:"nitro_enclaves,omitempty"`
+
+	// Enable hibernation, root volume is always encrypted
+	Hibernation bool `json:"hibernation,omitempty"`
+
+	// Optional root volume options, the root volume of the image is used when nil
+	RootVolume *AWSRootVolume `json:"root_volume,omitempty"`
+
+	// Network interfaces, the first one is the primary interface. Default subnet is used when empty.
+	NetworkInterfaces []NetworkInterface `json:"network_interfaces,omitempty"`
+
+	// Subnets of the primary interface tried in order while there is not enough capacity, only
+	// used without network interfaces. Default subnet is used when empty.
+	SubnetIDs []string `json:"subnet_ids,omitempty"`
+
+	// Security groups of the primary interface, only used without network interfaces
+	SecurityGroupIDs []string `json:"security_group_ids,omitempty"`
+
+	// Subnet the instances were launched in when multiple subnets were requested
+	LaunchedSubnetID string `json:"launched_subnet_id,omitempty"`
+
+	// Tenancy of the instances ("dedicated" or "host"), shared hardware when empty
+	Tenancy string `json:"tenancy,omitempty"`
+
+	// Dedicated host of instances with host tenancy, any host with auto-placement when empty
+	HostID string `json:"host_id,omitempty"`
+
+	// Capacity reservation the instances are launched into, open capacity is used when nil
+	CapacityReservation *AWSCapacityReservation `json:"capacity_reservation,omitempty"`
+
+	// Static private IPv4 addresses of the primary interface, one per instance
+	PrivateIPs []string `json:"private_ips,omitempty"`
+
+	// DNS zone for A records of the instances
+	DNSZone string `json:"dns_zone,omitempty"`
+
+	// Spot options, instances are launched on-demand when nil
+	Spot *AWSSpot `json:"spot,omitempty"`
+
+	// Elastic IP options, instances keep their auto-assigned public IPs when nil
+	ElasticIP *AWSElasticIP `json:"elastic_ip,omitempty"`
+
+	// Public IPv4 and IPv6 addressing of the primary interface, provider defaults when nil
+	Addressing *Addressing `json:"addressing,omitempty"`
+
+	// Reachability probe of launched instances, instances are not probed when nil
+	HealthProbe *HealthProbe `json:"health_probe,omitempty"`
+
+	// Instances were launched on-demand after the spot launch failed
+	LaunchedOnDemand bool `json:"launched_on_demand,omitempty"`
+
+	// Custom tags of the instances and volumes
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// Custom user data merged with the generated cloud-init user data
+	UserData string `json:"user_data,omitempty"`
+}
+
+// AWSSpot are options of instances launched as one-time spot requests.
+type AWSSpot struct {
+	// Maximum hourly price in USD, the on-demand price when blank
+	MaxPrice string `json:"max_price,omitempty"`
+
+	// Launch on-demand instances when spot capacity is not available or the requests are not
+	// fulfilled in time
+	FallbackOnDemand bool `json:"fallback_on_demand,omitempty"`
+}
+
+// AWSCapacityReservation targets an on-demand capacity reservation or a group of them, exactly
+// one of the fields is set.
+type AWSCapacityReservation struct {
+	// ID of the capacity reservation
+	ID string `json:"id,omitempty"`
+
+	// ARN of the resource group of capacity reservations
+	GroupARN string `json:"group_arn,omitempty"`
+}
+
+// AWSElasticIP are options of Elastic IPs associated to launched instances.
+type AWSElasticIP struct {
+	// Pool of Elastic IPs reused before new addresses are allocated, addresses with the pool tag
+	// of this value which are not associated are used. Only new addresses are allocated when blank.
+	Pool string `json:"pool,omitempty"`
+}
+
+// Addressing are public IPv4 and IPv6 options of the primary network interface of AWS and
+// GCP instances.
+type Addressing struct {
+	// Assign a public IPv4 address, the provider default is used when nil
+	PublicIPv4 *bool `json:"public_ipv4,omitempty"`
+
+	// Request an IPv6 address, the subnet must have an IPv6 range
+	IPv6 bool `json:"ipv6,omitempty"`
+}
+
+// HealthProbe checks launched instances are reachable once they are running. The SSH port is
+// probed unless another port or an HTTP path is set.
+type HealthProbe struct {
+	// TCP port, 22 (or 80 with an HTTP path) when zero
+	Port int `json:"port,omitempty"`
+
+	// HTTP path requested on the port, a TCP connection is enough when blank
+	HTTPPath string `json:"http_path,omitempty"`
+
+	// Maximum wait in seconds, the configured default when zero
+	Timeout int `json:"timeout,omitempty"`
+}
+
+// AWSRootVolume overrides the EBS root volume of the image.
+type AWSRootVolume struct {
+	// Size in GiB, the image volume size when zero
+	Size int32 `json:"size,omitempty"`
+
+	// Volume type (gp3 or io2), the image volume type when blank
+	Type string `json:"type,omitempty"`
+
+	// Provisioned IOPS, the default of the volume type when zero
+	IOPS int32 `json:"iops,omitempty"`
 }
 
 type AWSReservation struct {
@@ -112,6 +303,29 @@ type GCPDetail struct {
 
 	// Immediately power off the system after initialization
 	PowerOff bool `json:"poweroff"`
+
+	// Optional service account email attached to the instances
+	ServiceAccount string `json:"service_account,omitempty"`
+
+	// OAuth scopes of the service account
+	Scopes []string `json:"scopes,omitempty"`
+
+	// Shielded VM options, the image must support Shielded VM
+	ShieldedSecureBoot          bool `json:"shielded_secure_boot,omitempty"`
+	ShieldedVTPM                bool `json:"shielded_vtpm,omitempty"`
+	ShieldedIntegrityMonitoring bool `json:"shielded_integrity_monitoring,omitempty"`
+
+	// DNS zone for A records of the instances
+	DNSZone string `json:"dns_zone,omitempty"`
+
+	// Public IPv4 and IPv6 addressing of the network interface, provider defaults when nil
+	Addressing *Addressing `json:"addressing,omitempty"`
+
+	// Reachability probe of launched instances, instances are not probed when nil
+	HealthProbe *HealthProbe `json:"health_probe,omitempty"`
+
+	// Custom user data passed to cloud-init next to the generated startup script
+	UserData string `json:"user_data,omitempty"`
 }
 
 type GCPReservation struct {
@@ -149,8 +363,53 @@ type AzureDetail struct {
 
 	// Immediately power off the system after initialization
 	PowerOff bool `json:"poweroff"`
+
+	// Availability zone ("1", "2" or "3"), blank for non-zonal VMs
+	Zone string `json:"zone,omitempty"`
+
+	// Resource ID of an existing proximity placement group, blank for none
+	ProximityPlacementGroup string `json:"proximity_placement_group,omitempty"`
+
+	// Security type of the VM, blank for standard VMs
+	SecurityType AzureSecurityType `json:"security_type,omitempty"`
+
+	// UEFI settings of trusted launch and confidential VMs
+	SecureBoot bool `json:"secure_boot,omitempty"`
+	VTPM       bool `json:"vtpm,omitempty"`
+
+	// Enable hibernation
+	Hibernation bool `json:"hibernation,omitempty"`
+
+	// Additional network interfaces attached after the primary interface
+	NetworkInterfaces []NetworkInterface `json:"network_interfaces,omitempty"`
+
+	// Static private IPv4 addresses of the primary interface, one per instance
+	PrivateIPs []string `json:"private_ips,omitempty"`
+
+	// DNS zone for A records of the instances
+	DNSZone string `json:"dns_zone,omitempty"`
+
+	// Reachability probe of launched instances, instances are not probed when nil
+	HealthProbe *HealthProbe `json:"health_probe,omitempty"`
+
+	// Custom user data merged with the generated cloud-init user data
+	UserData string `json:"user_data,omitempty"`
 }
 
+// AzureSecurityType is the security type of an Azure VM
+type AzureSecurityType string
+
+const (
+	// Standard VM without additional security features
+	AzureSecurityTypeStandard AzureSecurityType = ""
+
+	// Trusted launch VM with optional secure boot and vTPM
+	AzureSecurityTypeTrustedLaunch AzureSecurityType = "TrustedLaunch"
+
+	// Confidential VM, requires a confidential VM size and image, vTPM is always enabled
+	AzureSecurityTypeConfidentialVM AzureSecurityType = "ConfidentialVM"
+)
+
 type AzureReservation struct {
 	Reservation
 
@@ -167,9 +426,94 @@ type AzureReservation struct {
 	Detail *AzureDetail `db:"detail" json:"detail"`
 }
 
+// ReservationExport is a reservation with provider specific details flattened into common
+// columns, it is used for reporting. Provider columns are blank for noop reservations.
+type ReservationExport struct {
+	Reservation
+
+	// Pubkey ID or zero.
+	PubkeyID int64 `db:"pubkey_id" json:"pubkey_id"`
+
+	// Source ID.
+	SourceID string `db:"source_id" json:"source_id"`
+
+	// The ID of the image from which the instance is created.
+	ImageID string `db:"image_id" json:"image_id"`
+
+	// AWS region, Azure location or GCP zone.
+	Location string `db:"location" json:"location"`
+
+	// AWS instance type, Azure instance size or GCP machine type.
+	InstanceType string `db:"instance_type" json:"instance_type"`
+
+	// Amount of instances.
+	Amount int64 `db:"amount" json:"amount"`
+}
+
 type ReservationInstanceDetail struct {
 	PublicDNS  string `json:"public_dns"`
 	PublicIPv4 string `json:"public_ipv4"`
+
+	// Private DNS name and IPv4 address of the primary network interface.
+	PrivateDNS  string `json:"private_dns,omitempty" yaml:"private_dns,omitempty"`
+	PrivateIPv4 string `json:"private_ipv4,omitempty" yaml:"private_ipv4,omitempty"`
+
+	// Fully qualified name of the A record created in the DNS zone of the reservation.
+	DNSName string `json:"dns_name,omitempty" yaml:"dns_name,omitempty"`
+
+	// Administrator password of Windows instances encrypted with the public key of the reservation
+	// (base64 encoded), it can be decrypted only with the private key of the user.
+	PasswordData string `json:"password_data,omitempty" yaml:"password_data,omitempty"`
+
+	// Network interfaces of the instance, primary interface first. Only present for instances
+	// with multiple network interfaces.
+	NetworkInterfaces []InstanceNetworkInterface `json:"network_interfaces,omitempty" yaml:"network_interfaces,omitempty"`
+}
+
+// NetworkInterface is a network interface requested for instances of a reservation.
+type NetworkInterface struct {
+	// Subnet ID (AWS) or subnet resource ID (Azure)
+	SubnetID string `json:"subnet_id"`
+
+	// Security group IDs (AWS) or a single network security group resource ID (Azure)
+	SecurityGroupIDs []string `json:"security_group_ids,omitempty"`
+
+	// Static private IPv4 address, dynamic address is assigned when blank
+	PrivateIPv4 string `json:"private_ipv4,omitempty"`
+}
+
+// InstanceNetworkInterface is a network interface of a launched instance.
+type InstanceNetworkInterface struct {
+	// Network interface ID (AWS) or resource ID (Azure)
+	ID string `json:"id" yaml:"id"`
+
+	// Subnet of the interface
+	SubnetID string `json:"subnet_id" yaml:"subnet_id"`
+
+	// Primary private IPv4 address of the interface
+	PrivateIPv4 string `json:"private_ipv4" yaml:"private_ipv4"`
+}
+
+// PowerState of an instance, changed by stop and start operations.
+type PowerState string
+
+const (
+	PowerStateRunning  PowerState = "running"
+	PowerStateStopping PowerState = "stopping"
+	PowerStateStopped  PowerState = "stopped"
+	PowerStateStarting PowerState = "starting"
+	// PowerStateUnknown is set when a stop or start operation failed.
+	PowerStateUnknown PowerState = "unknown"
+	// PowerStateTerminated is set when the instance no longer exists in the cloud account.
+	PowerStateTerminated PowerState = "terminated"
+	// PowerStateResizing is set while the instance type of a stopped instance is changed.
+	PowerStateResizing PowerState = "resizing"
+)
+
+// PowerStates are all known power states.
+var PowerStates = []PowerState{
+	PowerStateRunning, PowerStateStopping, PowerStateStopped, PowerStateStarting, PowerStateUnknown, PowerStateTerminated,
+	PowerStateResizing,
 }
 
 type ReservationInstance struct {
@@ -181,4 +525,215 @@ type ReservationInstance struct {
 
 	// Instance's description, ip and dns
 	Detail ReservationInstanceDetail `db:"detail" json:"detail" yaml:"detail"`
+
+	// Power state of the instance.
+	PowerState PowerState `db:"power_state" json:"power_state" yaml:"power_state"`
+
+	// AWS spot request ID of spot instances, blank for on-demand instances.
+	SpotRequestID string `db:"spot_request_id" json:"spot_request_id,omitempty" yaml:"spot_request_id,omitempty"`
+
+	// Capacity pool of AWS instances launched by EC2 Fleet, empty for other instances.
+	FleetAllocation FleetAllocation `db:"fleet_allocation" json:"fleet_allocation" yaml:"fleet_allocation"`
+
+	// Elastic IP associated to an AWS instance, empty for other instances.
+	ElasticIP ElasticIP `db:"elastic_ip" json:"elastic_ip" yaml:"elastic_ip"`
+
+	// DNS record created for the instance, empty for reservations without a DNS zone.
+	DNSRecord DNSRecord `db:"dns_record" json:"dns_record" yaml:"dns_record"`
+}
+
+// FleetAllocation is the capacity pool an EC2 Fleet launched an instance in.
+type FleetAllocation struct {
+	// Instance type of the pool.
+	InstanceType string `json:"instance_type,omitempty" yaml:"instance_type,omitempty"`
+
+	// Subnet of the pool, blank when launched in the default subnet.
+	SubnetID string `json:"subnet_id,omitempty" yaml:"subnet_id,omitempty"`
+
+	// Availability zone of the pool when known.
+	AvailabilityZone string `json:"availability_zone,omitempty" yaml:"availability_zone,omitempty"`
+
+	// Lifecycle of the instance: on-demand or spot.
+	Lifecycle string `json:"lifecycle,omitempty" yaml:"lifecycle,omitempty"`
+}
+
+// Empty returns true for instances not launched by EC2 Fleet.
+func (a FleetAllocation) Empty() bool {
+	return a.InstanceType == ""
+}
+
+// ElasticIP is an Elastic IP address associated to an AWS instance.
+type ElasticIP struct {
+	// Allocation ID of the address ("eipalloc-0123456789abcdef0").
+	AllocationID string `json:"allocation_id,omitempty" yaml:"allocation_id,omitempty"`
+
+	// Association ID of the address and the instance.
+	AssociationID string `json:"association_id,omitempty" yaml:"association_id,omitempty"`
+
+	// The public IPv4 address.
+	PublicIP string `json:"public_ip,omitempty" yaml:"public_ip,omitempty"`
+
+	// Pooled addresses were reused from a pool and are never released by the service.
+	Pooled bool `json:"pooled,omitempty" yaml:"pooled,omitempty"`
+
+	// Released is set once an allocated address was released.
+	Released bool `json:"released,omitempty" yaml:"released,omitempty"`
+}
+
+// Empty returns true for instances without an Elastic IP.
+func (e ElasticIP) Empty() bool {
+	return e.AllocationID == ""
+}
+
+// Releasable returns true for addresses allocated for the instance which were not released yet.
+func (e ElasticIP) Releasable() bool {
+	return !e.Empty() && !e.Pooled && !e.Released
+}
+
+// DNSRecord is an A record of an instance in a DNS zone of the cloud account.
+type DNSRecord struct {
+	// Zone is the AWS hosted zone ID, Azure DNS zone resource ID or GCP managed zone name.
+	Zone string `json:"zone,omitempty" yaml:"zone,omitempty"`
+
+	// Name is the fully qualified domain name without the trailing dot.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+
+	// IPv4 is the address of the record.
+	IPv4 string `json:"ipv4,omitempty" yaml:"ipv4,omitempty"`
+
+	// TTL of the record in seconds.
+	TTL int64 `json:"ttl,omitempty" yaml:"ttl,omitempty"`
+
+	// Deleted is set once the record of a terminated instance was deleted.
+	Deleted bool `json:"deleted,omitempty" yaml:"deleted,omitempty"`
+}
+
+// Empty returns true for instances without a DNS record.
+func (r DNSRecord) Empty() bool {
+	return r.Name == ""
+}
+
+// Deletable returns true for records which were not deleted yet.
+func (r DNSRecord) Deletable() bool {
+	return !r.Empty() && !r.Deleted
+}
+
+// Instance is an instance of a reservation of any provider with details of the reservation.
+type Instance struct {
+	ReservationInstance
+
+	// Provider of the reservation.
+	Provider ProviderType `db:"provider" json:"provider"`
+
+	// Source ID of the reservation.
+	SourceID string `db:"source_id" json:"source_id"`
+
+	// AWS region, Azure location or GCP zone of the reservation.
+	Location string `db:"location" json:"location"`
+
+	// Instance type, VM size or machine type of the reservation.
+	InstanceType string `db:"instance_type" json:"instance_type"`
+
+	// Time of the reservation.
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+
+	// User who created the reservation.
+	CreatedByUserID string `db:"created_by_user_id" json:"created_by_user_id"`
+
+	// UsageStarted is set once the started usage record was sent.
+	UsageStarted bool `db:"usage_started" json:"-"`
+
+	// UsageTerminated is set once the terminated usage record was sent.
+	UsageTerminated bool `db:"usage_terminated" json:"-"`
+}
+
+// UsageInstance is an instance with usage records which were not sent yet.
+type UsageInstance struct {
+	Instance
+
+	// Associated Account model.
+	AccountID int64 `db:"account_id"`
+}
+
+// InstanceSource is a source with instances launched by reservations, AWS sources are listed
+// once per region.
+type InstanceSource struct {
+	// Associated Account model.
+	AccountID int64 `db:"account_id"`
+
+	// Provider of the reservations.
+	Provider ProviderType `db:"provider"`
+
+	// Source ID of the reservations.
+	SourceID string `db:"source_id"`
+
+	// AWS region, blank for other providers.
+	Region string `db:"region"`
+}
+
+// ReservationJob is the background job of a reservation as it was enqueued.
+type ReservationJob struct {
+	// Reservation ID.
+	ReservationID int64 `db:"reservation_id" json:"reservation_id"`
+
+	// Job type (e.g. "launch_instances_aws").
+	JobType string `db:"job_type" json:"job_type"`
+
+	// JSON encoded job including identity and arguments.
+	Job []byte `db:"job" json:"job"`
+
+	// Time when the job was enqueued for the first time.
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+// ReservationEventKind is the kind of event in the reservation timeline.
+type ReservationEventKind string
+
+const (
+	// EventEnqueued is recorded when the job of the reservation is enqueued.
+	EventEnqueued ReservationEventKind = "enqueued"
+	// EventPendingApproval is recorded when the job waits for an approval of the launch.
+	EventPendingApproval ReservationEventKind = "pending_approval"
+	// EventApproved and EventRejected are recorded when an approver decides about the launch.
+	EventApproved ReservationEventKind = "approved"
+	EventRejected ReservationEventKind = "rejected"
+	// EventDequeued is recorded when a worker starts processing the job.
+	EventDequeued ReservationEventKind = "dequeued"
+	// EventStepStarted and EventStepFinished are recorded for every job step, message is the status.
+	EventStepStarted  ReservationEventKind = "step_started"
+	EventStepFinished ReservationEventKind = "step_finished"
+	// EventProviderCall is recorded for calls which create resources on cloud providers.
+	EventProviderCall ReservationEventKind = "provider_call"
+	// EventSpotFallback is recorded when spot instances are not available and on-demand instances
+	// are launched instead, message is the spot error.
+	EventSpotFallback ReservationEventKind = "spot_fallback"
+	// EventUnreachable is recorded for every instance which did not pass the health probe, message
+	// is the last probe error.
+	EventUnreachable ReservationEventKind = "unreachable"
+	// EventError is recorded when the job finishes with an error, message is the error. Details
+	// contain product_code and marketplace_url of unaccepted AWS Marketplace subscriptions.
+	EventError ReservationEventKind = "error"
+	// EventFinished is recorded when all steps of the job finished successfully.
+	EventFinished ReservationEventKind = "finished"
+)
+
+// ReservationEvent is an entry of the reservation timeline.
+type ReservationEvent struct {
+	// Required auto-generated PK.
+	ID int64 `db:"id"`
+
+	// Associated reservation. Required.
+	ReservationID int64 `db:"reservation_id" validate:"required"`
+
+	// Kind of the event. Required.
+	Kind ReservationEventKind `db:"kind" validate:"required"`
+
+	// Human readable description (e.g. status of the step or error message).
+	Message string `db:"message"`
+
+	// Event specific details (e.g. job ID or instance type).
+	Details map[string]string `db:"details"`
+
+	// Time when the event was recorded.
+	CreatedAt time.Time `db:"created_at"`
 }
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// ReservationTemplate is a saved launch definition. The provider specific reservation request
+// is stored as JSON and it is used as a base when a reservation is created from the template.
+type ReservationTemplate struct {
+	// Required auto-generated PK.
+	ID int64 `db:"id"`
+
+	// Associated Account model. Required.
+	AccountID int64 `db:"account_id"`
+
+	// Workspace ID from RBAC, blank when the creator was not restricted to workspaces.
+	WorkspaceID string `db:"workspace_id"`
+
+	// Provider type. Required.
+	Provider ProviderType `db:"provider" validate:"required"`
+
+	// User-facing name, unique within an account. Required.
+	Name string `db:"name" validate:"required"`
+
+	// JSON encoded provider specific reservation request (e.g. AWSReservationRequest). Required.
+	Request []byte `db:"request" validate:"required"`
+
+	// Username of the creator from the identity header, blank for non-user identities.
+	CreatedBy string `db:"created_by"`
+
+	// Time when the template was created.
+	CreatedAt time.Time `db:"created_at"`
+
+	// Cron expression (UTC) of a recurring schedule, blank for one-shot or no schedule.
+	ScheduleCron string `db:"schedule_cron"`
+
+	// User ID of the user who scheduled the template, reservations are created on behalf of
+	// this user by a service identity.
+	ScheduleUserID string `db:"schedule_user_id"`
+
+	// Username of the user who scheduled the template, permissions of this user are checked
+	// before each scheduled launch.
+	ScheduleUsername string `db:"schedule_username"`
+
+	// Time of the next scheduled launch or NULL when the template is not scheduled.
+	NextRunAt sql.NullTime `db:"next_run_at"`
+
+	// Time of the last scheduled launch or NULL.
+	LastRunAt sql.NullTime `db:"last_run_at"`
+
+	// Reservation created by the last scheduled launch or NULL when it failed or was deleted.
+	LastReservationID sql.NullInt64 `db:"last_reservation_id"`
+}
+
+// Scheduled returns true when the template has a pending scheduled launch.
+func (t *ReservationTemplate) Scheduled() bool {
+	return t.NextRunAt.Valid
+}
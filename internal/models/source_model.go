@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"golang.org/x/exp/slices"
+)
+
+// SourceSettings are settings of a source restricting launches into regions and publishing
+// reservation lifecycle events.
+type SourceSettings struct {
+	// Associated Account model. Required.
+	AccountID int64 `db:"account_id"`
+
+	// Source ID. Required.
+	SourceID string `db:"source_id" validate:"required"`
+
+	// Region used when a launch does not specify one, blank for the service default.
+	DefaultRegion string `db:"default_region"`
+
+	// Regions launches are allowed into, all regions are allowed when empty. Regions are AWS
+	// regions, Azure locations and GCP regions without availability zones.
+	AllowedRegions []string `db:"allowed_regions"`
+
+	// ARN of an SQS queue or an EventBridge event bus receiving reservation lifecycle events, blank
+	// when events are not published. AWS sources only.
+	EventTarget string `db:"event_target"`
+
+	// Time of the last update.
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// AllowsRegion returns true when launches into the region are allowed.
+func (s *SourceSettings) AllowsRegion(region string) bool {
+	return len(s.AllowedRegions) == 0 || slices.Contains(s.AllowedRegions, region)
+}
@@ -0,0 +1,84 @@
+// Package naming generates human-friendly resource names from patterns like
+// "{provider}-{region}-{seq}". Braces of unknown placeholders are removed like any other
+// character which is not valid in names.
+package naming
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MaxLength of generated names, GCP names are limited to 63 characters including the
+// instance number suffix.
+const MaxLength = 48
+
+// DefaultPattern is used when no pattern is configured.
+const DefaultPattern = "{provider}-{region}-{seq}"
+
+// SeqPlaceholder is replaced with a zero-padded sequence number.
+const SeqPlaceholder = "{seq}"
+
+var invalidChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Values of placeholders, the sequence is only used when the pattern contains SeqPlaceholder.
+type Values struct {
+	Provider     string
+	Region       string
+	InstanceType string
+	Sequence     int64
+}
+
+// UsesSequence returns true when the pattern contains the sequence placeholder, so the sequence
+// is only incremented when needed.
+func UsesSequence(pattern string) bool {
+	return strings.Contains(pattern, SeqPlaceholder)
+}
+
+// Generate expands the pattern and makes the result valid for all providers: lowercase letters,
+// digits and hyphens, starting with a letter, at most MaxLength characters.
+func Generate(pattern string, values Values) string {
+	name := strings.NewReplacer(
+		"{provider}", values.Provider,
+		"{region}", values.Region,
+		"{type}", values.InstanceType,
+		SeqPlaceholder, fmt.Sprintf("%03d", values.Sequence),
+	).Replace(pattern)
+
+	name = invalidChars.ReplaceAllString(strings.ToLower(name), "-")
+	name = strings.Trim(name, "-")
+	if len(name) > MaxLength {
+		name = strings.TrimRight(name[:MaxLength], "-")
+	}
+	if name == "" {
+		name = "r"
+	} else if name[0] < 'a' || name[0] > 'z' {
+		name = "r-" + name
+	}
+	return name
+}
+
+// DNSLabelMaxLength is the maximum length of a DNS label.
+const DNSLabelMaxLength = 63
+
+// DNSLabel expands a DNS record name pattern with "{name}" (reservation name), "{id}" (reservation
+// ID) and "{index}" (instance number starting at 1) placeholders. The result is a single valid
+// DNS label, dots are replaced like other invalid characters.
+func DNSLabel(pattern, name string, id int64, index int) string {
+	label := strings.NewReplacer(
+		"{name}", name,
+		"{id}", strconv.FormatInt(id, 10),
+		"{index}", strconv.Itoa(index),
+	).Replace(pattern)
+
+	label = invalidChars.ReplaceAllString(strings.ToLower(label), "-")
+	label = strings.Trim(label, "-")
+	if len(label) > DNSLabelMaxLength {
+		label = strings.TrimRight(label[:DNSLabelMaxLength], "-")
+	}
+	if label == "" {
+		label = "i-" + strconv.Itoa(index)
+	}
+	return label
+}
@@ -0,0 +1,64 @@
+package naming_test
+
+import (
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/naming"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	values := naming.Values{Provider: "aws", Region: "us-east-1", InstanceType: "t3.small", Sequence: 3}
+
+	tests := []struct {
+		pattern  string
+		expected string
+	}{
+		{"{provider}-{region}-{seq}", "aws-us-east-1-003"},
+		{"rhel9-{region}-webinar-{seq}", "rhel9-us-east-1-webinar-003"},
+		{"{type}", "t3-small"},
+		{"{seq}", "r-003"},
+		{"My_Lab {region}", "my-lab-us-east-1"},
+		{"{unknown}-{seq}", "unknown-003"},
+		{"", "r"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			assert.Equal(t, tt.expected, naming.Generate(tt.pattern, values))
+		})
+	}
+}
+
+func TestGenerateSequenceOverflow(t *testing.T) {
+	assert.Equal(t, "lab-1234", naming.Generate("lab-{seq}", naming.Values{Sequence: 1234}))
+}
+
+func TestGenerateMaxLength(t *testing.T) {
+	name := naming.Generate("a-very-long-name-of-the-laboratory-environment-for-{region}", naming.Values{Region: "us-east-1"})
+	assert.LessOrEqual(t, len(name), naming.MaxLength)
+	assert.NotEqual(t, '-', name[len(name)-1])
+}
+
+func TestUsesSequence(t *testing.T) {
+	assert.True(t, naming.UsesSequence("{provider}-{seq}"))
+	assert.False(t, naming.UsesSequence("{provider}-{region}"))
+}
+
+func TestDNSLabel(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		expected string
+	}{
+		{"{name}-{index}", "aws-us-east-1-003-2"},
+		{"web{index}.{id}", "web2-42"},
+		{"{unknown}", "unknown"},
+		{"--", "i-2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			assert.Equal(t, tt.expected, naming.DNSLabel(tt.pattern, "aws-us-east-1-003", 42, 2))
+		})
+	}
+}
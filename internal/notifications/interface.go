@@ -2,6 +2,8 @@ package notifications
 
 import (
 	"context"
+
+	"github.com/RHEnVision/provisioning-backend/internal/models"
 )
 
 var GetNotificationClient func(ctx context.Context) NotificationClient = getNoopNotificationClient
@@ -9,4 +11,6 @@ var GetNotificationClient func(ctx context.Context) NotificationClient = getNoop
 type NotificationClient interface {
 	SuccessfulLaunch(ctx context.Context, reservationId int64)
 	FailedLaunch(ctx context.Context, reservationId int64, jobError error)
+	OrphansDetected(ctx context.Context, orphans []*models.OrphanedInstance)
+	FailedScheduledLaunch(ctx context.Context, template *models.ReservationTemplate, launchError error)
 }
@@ -3,6 +3,7 @@ package notifications
 import (
 	"context"
 
+	"github.com/RHEnVision/provisioning-backend/internal/models"
 	"github.com/rs/zerolog"
 )
 
@@ -24,3 +25,13 @@ func (s *noopNotificationClient) FailedLaunch(ctx context.Context, reservationId
 	logger := zerolog.Ctx(ctx)
 	logger.Warn().Msg("FailedLaunch not started (Notifications not configured)")
 }
+
+func (s *noopNotificationClient) OrphansDetected(ctx context.Context, orphans []*models.OrphanedInstance) {
+	logger := zerolog.Ctx(ctx)
+	logger.Warn().Msg("OrphansDetected not started (Notifications not configured)")
+}
+
+func (s *noopNotificationClient) FailedScheduledLaunch(ctx context.Context, template *models.ReservationTemplate, launchError error) {
+	logger := zerolog.Ctx(ctx)
+	logger.Warn().Msg("FailedScheduledLaunch not started (Notifications not configured)")
+}
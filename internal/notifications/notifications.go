@@ -7,6 +7,7 @@ import (
 	"github.com/RHEnVision/provisioning-backend/internal/config"
 	"github.com/RHEnVision/provisioning-backend/internal/dao"
 	"github.com/RHEnVision/provisioning-backend/internal/kafka"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
 	"github.com/rs/zerolog"
 )
 
@@ -94,3 +95,63 @@ func (x *client) FailedLaunch(ctx context.Context, reservationId int64, jobError
 		logger.Error().Err(err).Msg("Unable to send notification message via kafka")
 	}
 }
+
+// OrphansDetected sends one notification per source, all orphans must be of the same source.
+func (x *client) OrphansDetected(ctx context.Context, orphans []*models.OrphanedInstance) {
+	logger := zerolog.Ctx(ctx)
+	logger.Debug().Msg("Triggering an orphaned instances notification")
+	if len(orphans) == 0 {
+		return
+	}
+
+	notificationEvents := make([]kafka.NotificationEvent, len(orphans))
+	for i, orphan := range orphans {
+		marshalOrphan, err := json.Marshal(orphan)
+		if err != nil {
+			logger.Error().Err(err).Msg("Unable to marshal orphaned instance")
+			return
+		}
+		notificationEvents[i] = kafka.NotificationEvent{Payload: marshalOrphan}
+	}
+
+	notificationMsg, err := kafka.NotificationMessage{
+		Context:   kafka.NotificationSourceContext{Provider: orphans[0].Provider.String(), SourceID: orphans[0].SourceID},
+		EventType: kafka.NotificationOrphansEventType, Events: notificationEvents,
+	}.GenericMessage(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg("Unable to create orphaned instances notification message")
+		return
+	}
+	logger.Info().Msg("Sending notification message")
+	err = kafka.Send(ctx, &notificationMsg)
+	if err != nil {
+		logger.Error().Err(err).Msg("Unable to send notification message via kafka")
+	}
+}
+
+// FailedScheduledLaunch is sent when a scheduled launch of a template fails before a reservation
+// is created, e.g. when the user lost permissions or the template request is no longer valid.
+func (x *client) FailedScheduledLaunch(ctx context.Context, template *models.ReservationTemplate, launchError error) {
+	logger := zerolog.Ctx(ctx)
+	logger.Debug().Msg("Triggering a failed scheduled launch notification")
+	marshalError, err := json.Marshal(kafka.NotificationError{Error: launchError.Error()})
+	if err != nil {
+		logger.Error().Err(err).Msg("Unable to marshal error")
+		return
+	}
+
+	notificationEvent := []kafka.NotificationEvent{{Payload: marshalError}}
+	notificationMsg, err := kafka.NotificationMessage{
+		Context:   kafka.NotificationTemplateContext{Provider: template.Provider.String(), TemplateID: template.ID},
+		EventType: kafka.NotificationScheduleEventType, Events: notificationEvent,
+	}.GenericMessage(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg("Unable to create scheduled launch notification message")
+		return
+	}
+	logger.Info().Msg("Sending notification message")
+	err = kafka.Send(ctx, &notificationMsg)
+	if err != nil {
+		logger.Error().Err(err).Msg("Unable to send notification message via kafka")
+	}
+}
@@ -0,0 +1,44 @@
+package payloads
+
+import (
+	"net/http"
+
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/go-chi/render"
+)
+
+type AccountUsageResponse struct {
+	// Day of the usage in UTC (YYYY-MM-DD).
+	Day string `json:"day" yaml:"day"`
+
+	// Number of API calls.
+	APICalls int64 `json:"api_calls" yaml:"api_calls"`
+
+	// Number of launched reservations.
+	Launches int64 `json:"launches" yaml:"launches"`
+
+	// Number of instances requested by the launches.
+	Instances int64 `json:"instances" yaml:"instances"`
+}
+
+type AccountUsageListResponse struct {
+	// Daily usage, newest first. Days without any usage are not listed.
+	Data []*AccountUsageResponse `json:"data" yaml:"data"`
+}
+
+func (s *AccountUsageListResponse) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
+
+func NewAccountUsageListResponse(usage []*models.AccountUsage) render.Renderer {
+	list := make([]*AccountUsageResponse, len(usage))
+	for i, u := range usage {
+		list[i] = &AccountUsageResponse{
+			Day:       u.Day.Format("2006-01-02"),
+			APICalls:  u.APICalls,
+			Launches:  u.Launches,
+			Instances: u.Instances,
+		}
+	}
+	return &AccountUsageListResponse{Data: list}
+}
@@ -0,0 +1,165 @@
+package payloads
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/go-chi/render"
+)
+
+// AdminReservationResponse is a reservation of any account, it is only used by the admin API.
+type AdminReservationResponse struct {
+	GenericReservationResponse
+
+	// Account ID of the reservation.
+	AccountID int64 `json:"account_id" yaml:"account_id"`
+}
+
+type AdminReservationListResponse struct {
+	Data []*AdminReservationResponse `json:"data" yaml:"data"`
+}
+
+type AdminQueueStatsResponse struct {
+	// Number of jobs currently in the queue.
+	EnqueuedJobs uint64 `json:"enqueued_jobs" yaml:"enqueued_jobs"`
+
+	// Number of jobs currently being processed by the API process (in-memory queue only).
+	InFlight int64 `json:"in_flight" yaml:"in_flight"`
+}
+
+type AdminCacheFlushResponse struct {
+	// Number of deleted cache entries.
+	Deleted int64 `json:"deleted" yaml:"deleted"`
+}
+
+// AdminUsageResponse is usage of an account summed over a period.
+type AdminUsageResponse struct {
+	AccountID int64  `json:"account_id" yaml:"account_id"`
+	OrgID     string `json:"org_id" yaml:"org_id"`
+	APICalls  int64  `json:"api_calls" yaml:"api_calls"`
+	Launches  int64  `json:"launches" yaml:"launches"`
+	Instances int64  `json:"instances" yaml:"instances"`
+}
+
+type AdminUsageListResponse struct {
+	Data []*AdminUsageResponse `json:"data" yaml:"data"`
+}
+
+// AdminSupportBundleResponse contains everything known about a reservation, it is attached to support
+// tickets. Secrets and personal data are redacted from the job.
+type AdminSupportBundleResponse struct {
+	// Time when the bundle was generated.
+	GeneratedAt time.Time `json:"generated_at" yaml:"generated_at"`
+
+	// The reservation.
+	Reservation *AdminReservationResponse `json:"reservation" yaml:"reservation"`
+
+	// Stored job of the reservation with type, identity and arguments, missing for reservations
+	// without a stored job.
+	Job map[string]interface{} `json:"job,omitempty" yaml:"job,omitempty"`
+
+	// Reservation timeline ordered by time.
+	Timeline []*ReservationEventResponse `json:"timeline" yaml:"timeline"`
+
+	// Timeline events with errors returned by the cloud provider or job steps.
+	ProviderErrors []*ReservationEventResponse `json:"provider_errors" yaml:"provider_errors"`
+
+	// Trace and job IDs logs were searched for.
+	LogTerms []string `json:"log_terms" yaml:"log_terms"`
+
+	// Log messages with any of the trace or job IDs, oldest first.
+	Logs []string `json:"logs" yaml:"logs"`
+
+	// Reason why logs are missing or incomplete, blank when the search succeeded.
+	LogsError string `json:"logs_error,omitempty" yaml:"logs_error,omitempty"`
+}
+
+func (p *AdminReservationResponse) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
+
+func (p *AdminReservationListResponse) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
+
+func (p *AdminQueueStatsResponse) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
+
+func (p *AdminCacheFlushResponse) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
+
+func (p *AdminUsageListResponse) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
+
+func (p *AdminSupportBundleResponse) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
+
+func NewAdminReservationResponse(reservation *models.Reservation) render.Renderer {
+	return adminReservationResponseMapper(reservation)
+}
+
+func NewAdminReservationListResponse(reservations []*models.Reservation) render.Renderer {
+	list := make([]*AdminReservationResponse, len(reservations))
+	for i, reservation := range reservations {
+		list[i] = adminReservationResponseMapper(reservation)
+	}
+	return &AdminReservationListResponse{Data: list}
+}
+
+func NewAdminQueueStatsResponse(enqueued uint64, inFlight int64) render.Renderer {
+	return &AdminQueueStatsResponse{EnqueuedJobs: enqueued, InFlight: inFlight}
+}
+
+func NewAdminCacheFlushResponse(deleted int64) render.Renderer {
+	return &AdminCacheFlushResponse{Deleted: deleted}
+}
+
+func NewAdminUsageListResponse(usage []*models.AccountUsageTotal) render.Renderer {
+	list := make([]*AdminUsageResponse, len(usage))
+	for i, u := range usage {
+		list[i] = &AdminUsageResponse{
+			AccountID: u.AccountID,
+			OrgID:     u.OrgID,
+			APICalls:  u.APICalls,
+			Launches:  u.Launches,
+			Instances: u.Instances,
+		}
+	}
+	return &AdminUsageListResponse{Data: list}
+}
+
+// NewAdminSupportBundleResponse returns a support bundle, events with an error are listed both in the
+// timeline and in provider errors.
+func NewAdminSupportBundleResponse(reservation *models.Reservation, job map[string]interface{}, events []*models.ReservationEvent, logTerms, logs []string, logsErr error) render.Renderer {
+	bundle := &AdminSupportBundleResponse{
+		GeneratedAt:    time.Now().UTC(),
+		Reservation:    adminReservationResponseMapper(reservation),
+		Job:            job,
+		Timeline:       make([]*ReservationEventResponse, len(events)),
+		ProviderErrors: []*ReservationEventResponse{},
+		LogTerms:       nonNilStrings(logTerms),
+		Logs:           nonNilStrings(logs),
+	}
+	for i, event := range events {
+		bundle.Timeline[i] = NewReservationEventResponse(event)
+		if _, failed := event.Details["error"]; failed || event.Kind == models.EventError {
+			bundle.ProviderErrors = append(bundle.ProviderErrors, bundle.Timeline[i])
+		}
+	}
+	if logsErr != nil {
+		bundle.LogsError = logsErr.Error()
+	}
+	return bundle
+}
+
+func adminReservationResponseMapper(reservation *models.Reservation) *AdminReservationResponse {
+	return &AdminReservationResponse{
+		GenericReservationResponse: *reservationResponseMapper(reservation),
+		AccountID:                  reservation.AccountID,
+	}
+}
@@ -0,0 +1,29 @@
+package payloads
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/artifacts"
+	"github.com/go-chi/render"
+)
+
+// ArtifactLinkResponse is a signed URL of a file in object storage (e.g. a reservation archive).
+type ArtifactLinkResponse struct {
+	// Signed URL, downloads the file without credentials until it expires.
+	URL string `json:"url" yaml:"url"`
+
+	// Time when the URL expires.
+	ExpiresAt time.Time `json:"expires_at" yaml:"expires_at"`
+}
+
+func (s *ArtifactLinkResponse) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
+
+func NewArtifactLinkResponse(link *artifacts.Link) render.Renderer {
+	return &ArtifactLinkResponse{
+		URL:       link.URL,
+		ExpiresAt: link.ExpiresAt,
+	}
+}
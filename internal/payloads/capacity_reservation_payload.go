@@ -0,0 +1,50 @@
+package payloads
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/go-chi/render"
+)
+
+// See clients.CapacityReservation
+type CapacityReservationResponse struct {
+	ID                    string     `json:"id" yaml:"id"`
+	Name                  string     `json:"name" yaml:"name"`
+	InstanceType          string     `json:"instance_type" yaml:"instance_type"`
+	AvailabilityZone      string     `json:"availability_zone" yaml:"availability_zone"`
+	Platform              string     `json:"platform" yaml:"platform"`
+	Tenancy               string     `json:"tenancy" yaml:"tenancy"`
+	InstanceMatchCriteria string     `json:"instance_match_criteria" yaml:"instance_match_criteria"`
+	TotalInstances        int32      `json:"total_instances" yaml:"total_instances"`
+	AvailableInstances    int32      `json:"available_instances" yaml:"available_instances"`
+	EndDate               *time.Time `json:"end_date,omitempty" yaml:"end_date,omitempty"`
+}
+
+type CapacityReservationListResponse struct {
+	Data []*CapacityReservationResponse `json:"data" yaml:"data"`
+}
+
+func (s *CapacityReservationListResponse) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
+
+func NewListCapacityReservationResponse(crl []*clients.CapacityReservation) render.Renderer {
+	list := make([]*CapacityReservationResponse, len(crl))
+	for i, cr := range crl {
+		list[i] = &CapacityReservationResponse{
+			ID:                    cr.ID,
+			Name:                  cr.Name,
+			InstanceType:          cr.InstanceType,
+			AvailabilityZone:      cr.AvailabilityZone,
+			Platform:              cr.Platform,
+			Tenancy:               cr.Tenancy,
+			InstanceMatchCriteria: cr.InstanceMatchCriteria,
+			TotalInstances:        cr.TotalInstances,
+			AvailableInstances:    cr.AvailableInstances,
+			EndDate:               cr.EndDate,
+		}
+	}
+	return &CapacityReservationListResponse{Data: list}
+}
@@ -0,0 +1,35 @@
+package payloads
+
+import (
+	"net/http"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/go-chi/render"
+)
+
+type InstanceConsoleResponse struct {
+	// Instance ID which has been created on a cloud provider.
+	InstanceID string `json:"instance_id" yaml:"instance_id"`
+
+	// Recent serial console output, only the last 64 kB of it.
+	Output string `json:"output" yaml:"output"`
+
+	// Base64 encoded console screenshot (AWS: JPEG, GCP: PNG), empty when not available.
+	Screenshot string `json:"screenshot,omitempty" yaml:"screenshot,omitempty"`
+
+	// Temporary URL of the console screenshot (Azure: BMP), empty when not available.
+	ScreenshotURL string `json:"screenshot_url,omitempty" yaml:"screenshot_url,omitempty"`
+}
+
+func (s *InstanceConsoleResponse) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
+
+func NewInstanceConsoleResponse(instanceID string, console *clients.ConsoleOutput) render.Renderer {
+	return &InstanceConsoleResponse{
+		InstanceID:    instanceID,
+		Output:        console.Output,
+		Screenshot:    console.Screenshot,
+		ScreenshotURL: console.ScreenshotURL,
+	}
+}
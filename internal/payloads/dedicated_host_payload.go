@@ -0,0 +1,47 @@
+package payloads
+
+import (
+	"net/http"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/go-chi/render"
+)
+
+// See clients.DedicatedHost
+type DedicatedHostResponse struct {
+	ID               string `json:"id" yaml:"id"`
+	Name             string `json:"name" yaml:"name"`
+	InstanceFamily   string `json:"instance_family" yaml:"instance_family"`
+	InstanceType     string `json:"instance_type" yaml:"instance_type"`
+	AvailabilityZone string `json:"availability_zone" yaml:"availability_zone"`
+	State            string `json:"state" yaml:"state"`
+	AutoPlacement    bool   `json:"auto_placement" yaml:"auto_placement"`
+	AvailableVCPUs   int32  `json:"available_vcpus" yaml:"available_vcpus"`
+	Instances        int    `json:"instances" yaml:"instances"`
+}
+
+type DedicatedHostListResponse struct {
+	Data []*DedicatedHostResponse `json:"data" yaml:"data"`
+}
+
+func (s *DedicatedHostListResponse) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
+
+func NewListDedicatedHostResponse(hl []*clients.DedicatedHost) render.Renderer {
+	list := make([]*DedicatedHostResponse, len(hl))
+	for i, host := range hl {
+		list[i] = &DedicatedHostResponse{
+			ID:               host.ID,
+			Name:             host.Name,
+			InstanceFamily:   host.InstanceFamily,
+			InstanceType:     host.InstanceType,
+			AvailabilityZone: host.AvailabilityZone,
+			State:            host.State,
+			AutoPlacement:    host.AutoPlacement,
+			AvailableVCPUs:   host.AvailableVCPUs,
+			Instances:        host.Instances,
+		}
+	}
+	return &DedicatedHostListResponse{Data: list}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/RHEnVision/provisioning-backend/internal/clients"
 	httpClients "github.com/RHEnVision/provisioning-backend/internal/clients/http"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
 	"github.com/RHEnVision/provisioning-backend/internal/logging"
 	"github.com/RHEnVision/provisioning-backend/internal/version"
 	"github.com/go-chi/render"
@@ -44,8 +45,11 @@ type ResponseError struct {
 	Environment string `json:"environment,omitempty" yaml:"environment"`
 }
 
+// Render sets the status and redacts secrets from messages, the full error is also logged redacted.
 func (e *ResponseError) Render(_ http.ResponseWriter, r *http.Request) error {
 	render.Status(r, e.HTTPStatusCode)
+	e.Message = logging.Redact(e.Message)
+	e.Error = logging.Redact(e.Error)
 	return nil
 }
 
@@ -91,10 +95,30 @@ func NewMissingRequestParameterError(ctx context.Context, message string) *Respo
 	return NewResponseError(ctx, http.StatusBadRequest, message, nil)
 }
 
+func NewQuotaExceededError(ctx context.Context, quota *clients.Quota, requested int64, err error) *ResponseError {
+	message := fmt.Sprintf("Requested %d vCPUs exceed remaining quota '%s' (limit %d, usage %d)", requested, quota.Name, quota.Limit, quota.Usage)
+	return NewResponseError(ctx, http.StatusUnprocessableEntity, message, err)
+}
+
+func NewCapacityUnavailableError(ctx context.Context, instanceType, location string, zones, types []string, err error) *ResponseError {
+	message := fmt.Sprintf("Instance type %s is likely unavailable in %s", instanceType, location)
+	if len(zones) > 0 {
+		message += fmt.Sprintf(", alternative zones: %s", strings.Join(zones, ", "))
+	}
+	if len(types) > 0 {
+		message += fmt.Sprintf(", alternative types: %s", strings.Join(types, ", "))
+	}
+	return NewResponseError(ctx, http.StatusUnprocessableEntity, message, err)
+}
+
 func PubkeyDuplicateError(ctx context.Context, message string, err error) *ResponseError {
 	return NewResponseError(ctx, http.StatusUnprocessableEntity, message, err)
 }
 
+func ReservationTemplateDuplicateError(ctx context.Context, message string, err error) *ResponseError {
+	return NewResponseError(ctx, http.StatusUnprocessableEntity, message, err)
+}
+
 type userPayload struct {
 	code    int
 	message string
@@ -117,6 +141,13 @@ var errStatus = map[error]*userPayload{
 	httpClients.UploadStatusErr:         {400, "wrong compose status of image builder compose"},
 	httpClients.ImageRequestNotFoundErr: {404, "image builder compose request not found"},
 
+	// ec2 specific errors
+	httpClients.ImageNotFoundErr:           {404, "image not found in AWS account"},
+	httpClients.SubnetNotFoundErr:          {404, "subnet not found in AWS account"},
+	httpClients.LaunchTemplateNotFoundErr:  {404, "launch template not found in AWS account"},
+	httpClients.PartitionNotConfiguredErr:  {400, "AWS partition of the source is not supported"},
+	httpClients.RegionPartitionMismatchErr: {400, "region is not in the AWS partition of the source"},
+
 	// sources specific errors
 	clients.UnknownAuthenticationTypeErr: {500, "unknown authentication type"},
 	clients.UnknownProviderErr:           {500, "unknown provider type"},
@@ -137,6 +168,10 @@ func findUserPayload(err error) *userPayload {
 }
 
 func NewClientError(ctx context.Context, err error) *ResponseError {
+	if clients.IsThrottled(err) {
+		log.Ctx(ctx).Warn().Msgf("Client error: %s", err)
+		return NewResponseError(ctx, http.StatusTooManyRequests, "too many requests; rate limited by a backend service", err)
+	}
 	if payload := findUserPayload(err); payload != nil {
 		logger := log.Ctx(ctx).Warn()
 		if payload.code >= 500 {
@@ -164,12 +199,30 @@ func NewMissingPermissionError(ctx context.Context, resource, permission string,
 	return NewResponseError(ctx, http.StatusForbidden, message, err)
 }
 
+func NewFeatureDisabledError(ctx context.Context, message string, err error) *ResponseError {
+	return NewResponseError(ctx, http.StatusForbidden, message, err)
+}
+
+func NewConflictError(ctx context.Context, message string, err error) *ResponseError {
+	message = fmt.Sprintf("Conflict: %s", message)
+	return NewResponseError(ctx, http.StatusConflict, message, err)
+}
+
+func NewRateLimitError(ctx context.Context, message string, err error) *ResponseError {
+	message = fmt.Sprintf("Too many requests: %s", message)
+	return NewResponseError(ctx, http.StatusTooManyRequests, message, err)
+}
+
 func NewEnqueueTaskError(ctx context.Context, message string, err error) *ResponseError {
 	message = fmt.Sprintf("Task enqueue error: %s", message)
 	return NewResponseError(ctx, http.StatusInternalServerError, message, err)
 }
 
 func NewDAOError(ctx context.Context, message string, err error) *ResponseError {
+	if errors.Is(err, dao.ErrTimeout) {
+		message = fmt.Sprintf("DAO timeout: %s", message)
+		return NewResponseError(ctx, http.StatusServiceUnavailable, message, err)
+	}
 	message = fmt.Sprintf("DAO error: %s", message)
 	return NewResponseError(ctx, http.StatusInternalServerError, message, err)
 }
@@ -191,15 +244,24 @@ func NewStatusError(ctx context.Context, message string, err error) *ResponseErr
 
 func NewAWSError(ctx context.Context, message string, err error) *ResponseError {
 	message = fmt.Sprintf("AWS API error: %s", message)
-	return NewResponseError(ctx, http.StatusInternalServerError, message, err)
+	return NewResponseError(ctx, providerStatus(err), message, err)
 }
 
 func NewAzureError(ctx context.Context, message string, err error) *ResponseError {
 	message = fmt.Sprintf("Azure API error: %s", message)
-	return NewResponseError(ctx, http.StatusInternalServerError, message, err)
+	return NewResponseError(ctx, providerStatus(err), message, err)
 }
 
 func NewGCPError(ctx context.Context, message string, err error) *ResponseError {
 	message = fmt.Sprintf("Google API error: %s", message)
-	return NewResponseError(ctx, http.StatusInternalServerError, message, err)
+	return NewResponseError(ctx, providerStatus(err), message, err)
+}
+
+// providerStatus returns too many requests for errors of operations throttled by a cloud provider
+// and internal server error otherwise.
+func providerStatus(err error) int {
+	if clients.IsThrottled(err) {
+		return http.StatusTooManyRequests
+	}
+	return http.StatusInternalServerError
 }
@@ -1,7 +1,12 @@
 package payloads
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/RHEnVision/provisioning-backend/internal/clients"
@@ -27,6 +32,10 @@ func TestFindUserPayload(t *testing.T) {
 			clients.MissingProvisioningSources,
 			&userPayload{500, "backend service missing provisioning source"},
 		},
+		{
+			clients.NewProviderError(clients.ProviderSources, "ListSourceAuthentications", 404, "", clients.NotFoundErr),
+			&userPayload{404, "not found; returned from a backend service"},
+		},
 	}
 
 	for _, tc := range tests {
@@ -36,3 +45,21 @@ func TestFindUserPayload(t *testing.T) {
 		}
 	}
 }
+
+func TestNewClientErrorThrottled(t *testing.T) {
+	err := clients.NewProviderError(clients.ProviderSources, "ListSourceAuthentications", 429, "", clients.Non2xxResponseErr)
+	if got := NewClientError(context.Background(), err).HTTPStatusCode; got != http.StatusTooManyRequests {
+		t.Fatalf("expected: %v, got: %v", http.StatusTooManyRequests, got)
+	}
+}
+
+func TestResponseErrorRenderRedacts(t *testing.T) {
+	resp := NewResponseError(context.Background(), 500, "cannot assume role arn:aws:iam::123456789012:role/r", errors.New("denied for arn:aws:iam::123456789012:role/r"))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if err := resp.Render(httptest.NewRecorder(), req); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(resp.Message+resp.Error, "123456789012") {
+		t.Fatalf("account ID not redacted: %q %q", resp.Message, resp.Error)
+	}
+}
@@ -0,0 +1,54 @@
+package payloads
+
+import (
+	"net/http"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+)
+
+// See clients.Image
+type ImageResponse struct {
+	// Name of the image
+	Name string `json:"name" yaml:"name"`
+
+	// Image family, can be used instead of image ID to launch the newest image of the family
+	Family string `json:"family,omitempty" yaml:"family,omitempty"`
+
+	// Project owning the image
+	Project string `json:"project" yaml:"project"`
+
+	// Full resource URL, can be used as image ID
+	SelfLink string `json:"self_link" yaml:"self_link"`
+
+	// Image architecture, blank when unknown
+	Architecture string `json:"architecture,omitempty" yaml:"architecture,omitempty"`
+}
+
+type ImageListResponse struct {
+	Data []*ImageResponse `json:"data" yaml:"data"`
+}
+
+func (s *ImageResponse) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
+
+func (s *ImageListResponse) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
+
+func newImageResponse(image *clients.Image) *ImageResponse {
+	return &ImageResponse{
+		Name:         image.Name,
+		Family:       image.Family,
+		Project:      image.Project,
+		SelfLink:     image.SelfLink,
+		Architecture: string(image.Architecture),
+	}
+}
+
+// StreamListImageResponse writes ImageListResponse without building it in memory, see StreamList.
+func StreamListImageResponse(w http.ResponseWriter, r *http.Request, sl []*clients.Image) (int, error) {
+	return StreamList(w, r, sl, func(image *clients.Image) any {
+		return newImageResponse(image)
+	})
+}
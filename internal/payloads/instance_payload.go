@@ -0,0 +1,116 @@
+package payloads
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/go-chi/render"
+)
+
+// ListedInstanceResponse is an instance of any provider with details of its reservation.
+type ListedInstanceResponse struct {
+	InstanceResponse
+
+	// Reservation ID the instance was launched by.
+	ReservationID int64 `json:"reservation_id" yaml:"reservation_id"`
+
+	// Provider of the instance: aws, azure or gcp.
+	Provider string `json:"provider" yaml:"provider"`
+
+	// Source ID of the cloud account.
+	SourceID string `json:"source_id" yaml:"source_id"`
+
+	// AWS region, Azure location or GCP zone of the instance.
+	Location string `json:"location" yaml:"location"`
+
+	// Instance type, VM size or machine type of the instance.
+	InstanceType string `json:"instance_type" yaml:"instance_type"`
+
+	// Time of the reservation.
+	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
+}
+
+type InstanceListResponse struct {
+	Data  []*ListedInstanceResponse `json:"data" yaml:"data"`
+	Meta  *ListMeta                 `json:"meta,omitempty" yaml:"meta"`
+	Links *ListLinks                `json:"links,omitempty" yaml:"links"`
+}
+
+func (s *InstanceListResponse) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
+
+// NewInstancePageResponse returns a list response with pagination metadata and links.
+func NewInstancePageResponse(r *http.Request, instances []*models.Instance, total, limit, offset int64) render.Renderer {
+	list := make([]*ListedInstanceResponse, len(instances))
+	for i, instance := range instances {
+		list[i] = &ListedInstanceResponse{
+			InstanceResponse: *NewInstanceResponse(&instance.ReservationInstance),
+			ReservationID:    instance.ReservationID,
+			Provider:         instance.Provider.String(),
+			SourceID:         instance.SourceID,
+			Location:         instance.Location,
+			InstanceType:     instance.InstanceType,
+			CreatedAt:        instance.CreatedAt,
+		}
+	}
+	response := &InstanceListResponse{Data: list}
+	response.Meta, response.Links = newListPage(r, len(instances), total, limit, offset)
+	return response
+}
+
+// OrphanedInstanceResponse is an instance out of sync between a cloud account and the service.
+type OrphanedInstanceResponse struct {
+	// Instance ID on the cloud provider.
+	InstanceID string `json:"instance_id" yaml:"instance_id"`
+
+	// Kind of the orphan: untracked for instances tagged by the service in the cloud account which
+	// are unknown or terminated in the service, missing for instances which no longer exist in the
+	// cloud account.
+	Kind models.OrphanKind `json:"kind" yaml:"kind"`
+
+	// Reservation ID from the tag of untracked instances or of the missing instance.
+	ReservationID int64 `json:"reservation_id" yaml:"reservation_id"`
+
+	// Provider of the instance: aws, azure or gcp.
+	Provider string `json:"provider" yaml:"provider"`
+
+	// Source ID of the cloud account.
+	SourceID string `json:"source_id" yaml:"source_id"`
+
+	// AWS region, Azure location or GCP zone of the instance.
+	Location string `json:"location" yaml:"location"`
+
+	// Time of the first detection.
+	DetectedAt time.Time `json:"detected_at" yaml:"detected_at"`
+}
+
+type OrphanedInstanceListResponse struct {
+	Data  []*OrphanedInstanceResponse `json:"data" yaml:"data"`
+	Meta  *ListMeta                   `json:"meta,omitempty" yaml:"meta"`
+	Links *ListLinks                  `json:"links,omitempty" yaml:"links"`
+}
+
+func (s *OrphanedInstanceListResponse) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
+
+// NewOrphanedInstancePageResponse returns a list response with pagination metadata and links.
+func NewOrphanedInstancePageResponse(r *http.Request, orphans []*models.OrphanedInstance, total, limit, offset int64) render.Renderer {
+	list := make([]*OrphanedInstanceResponse, len(orphans))
+	for i, orphan := range orphans {
+		list[i] = &OrphanedInstanceResponse{
+			InstanceID:    orphan.InstanceID,
+			Kind:          orphan.Kind,
+			ReservationID: orphan.ReservationID,
+			Provider:      orphan.Provider.String(),
+			SourceID:      orphan.SourceID,
+			Location:      orphan.Location,
+			DetectedAt:    orphan.DetectedAt,
+		}
+	}
+	response := &OrphanedInstanceListResponse{Data: list}
+	response.Meta, response.Links = newListPage(r, len(orphans), total, limit, offset)
+	return response
+}
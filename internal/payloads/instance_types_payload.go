@@ -4,7 +4,6 @@ import (
 	"net/http"
 
 	"github.com/RHEnVision/provisioning-backend/internal/clients"
-	"github.com/go-chi/render"
 )
 
 type InstanceTypeResponse clients.InstanceType
@@ -25,19 +24,23 @@ func (s *InstanceTypeListResponse) Render(_ http.ResponseWriter, _ *http.Request
 	return nil
 }
 
-func NewListInstanceTypeResponse(sl []*clients.InstanceType) render.Renderer {
-	list := make([]*InstanceTypeResponse, len(sl))
-	for i, it := range sl {
-		list[i] = &InstanceTypeResponse{
-			Name:               it.Name,
-			VCPUs:              it.VCPUs,
-			Cores:              it.Cores,
-			MemoryMiB:          it.MemoryMiB,
-			EphemeralStorageGB: it.EphemeralStorageGB,
-			Supported:          it.Supported,
-			Architecture:       it.Architecture,
-			AzureDetail:        it.AzureDetail,
-		}
+func newInstanceTypeResponse(it *clients.InstanceType) *InstanceTypeResponse {
+	return &InstanceTypeResponse{
+		Name:               it.Name,
+		VCPUs:              it.VCPUs,
+		Cores:              it.Cores,
+		MemoryMiB:          it.MemoryMiB,
+		EphemeralStorageGB: it.EphemeralStorageGB,
+		Supported:          it.Supported,
+		Architecture:       it.Architecture,
+		AzureDetail:        it.AzureDetail,
 	}
-	return &InstanceTypeListResponse{Data: list}
+}
+
+// StreamListInstanceTypeResponse writes InstanceTypeListResponse without building it in memory,
+// see StreamList.
+func StreamListInstanceTypeResponse(w http.ResponseWriter, r *http.Request, sl []*clients.InstanceType) (int, error) {
+	return StreamList(w, r, sl, func(it *clients.InstanceType) any {
+		return newInstanceTypeResponse(it)
+	})
 }
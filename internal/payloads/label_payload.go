@@ -0,0 +1,55 @@
+package payloads
+
+import (
+	"net/http"
+
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/go-chi/render"
+)
+
+type LabelsRequest struct {
+	// Labels replacing all labels of the reservation, at most 16 labels are allowed.
+	Labels []string `json:"labels" yaml:"labels"`
+}
+
+type LabelStatsResponse struct {
+	Label string `json:"label" yaml:"label"`
+
+	// Number of reservations with the label.
+	Reservations int64 `json:"reservations" yaml:"reservations"`
+
+	// Number of reservations finished successfully.
+	Succeeded int64 `json:"succeeded" yaml:"succeeded"`
+
+	// Number of reservations finished with an error.
+	Failed int64 `json:"failed" yaml:"failed"`
+
+	// Number of instances launched by reservations with the label.
+	Instances int64 `json:"instances" yaml:"instances"`
+}
+
+type LabelStatsListResponse struct {
+	Data []*LabelStatsResponse `json:"data" yaml:"data"`
+}
+
+func (p *LabelsRequest) Bind(_ *http.Request) error {
+	return nil
+}
+
+func (p *LabelStatsListResponse) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
+
+func NewLabelStatsListResponse(stats []*models.LabelStats) render.Renderer {
+	list := make([]*LabelStatsResponse, len(stats))
+	for i, s := range stats {
+		list[i] = &LabelStatsResponse{
+			Label:        s.Label,
+			Reservations: s.Reservations,
+			Succeeded:    s.Succeeded,
+			Failed:       s.Failed,
+			Instances:    s.Instances,
+		}
+	}
+	return &LabelStatsListResponse{Data: list}
+}
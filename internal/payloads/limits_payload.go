@@ -0,0 +1,38 @@
+package payloads
+
+import (
+	"net/http"
+
+	"github.com/RHEnVision/provisioning-backend/internal/ratelimit"
+	"github.com/go-chi/render"
+)
+
+type LimitsResponse struct {
+	// Rate limiting is enabled, other fields are zero when disabled
+	Enabled bool `json:"enabled" yaml:"enabled"`
+
+	// Maximum number of requests in the window
+	Limit int64 `json:"limit" yaml:"limit"`
+
+	// Number of requests left in the current window
+	Remaining int64 `json:"remaining" yaml:"remaining"`
+
+	// Seconds until the current window resets
+	Reset int64 `json:"reset" yaml:"reset"`
+}
+
+func (s *LimitsResponse) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
+
+func NewLimitsResponse(status *ratelimit.Status) render.Renderer {
+	if status == nil {
+		return &LimitsResponse{}
+	}
+	return &LimitsResponse{
+		Enabled:   true,
+		Limit:     status.Limit,
+		Remaining: status.Remaining,
+		Reset:     status.ResetSeconds(),
+	}
+}
@@ -0,0 +1,48 @@
+package payloads
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ListMeta contains pagination metadata of list responses.
+type ListMeta struct {
+	// Number of items in the response.
+	Count int `json:"count" yaml:"count"`
+
+	// Total number of items, large totals are capped (see dao.MaxCountTotal).
+	Total int64 `json:"total" yaml:"total"`
+}
+
+// ListLinks contains relative links to neighbour pages, missing on the first or the last page.
+type ListLinks struct {
+	Previous string `json:"previous,omitempty" yaml:"previous"`
+	Next     string `json:"next,omitempty" yaml:"next"`
+}
+
+func pageLink(r *http.Request, limit, offset int64) string {
+	u := url.URL{Path: r.URL.Path}
+	query := r.URL.Query()
+	query.Set("limit", strconv.FormatInt(limit, 10))
+	query.Set("offset", strconv.FormatInt(offset, 10))
+	u.RawQuery = query.Encode()
+	return u.RequestURI()
+}
+
+// newListPage returns pagination metadata and links for a list page.
+func newListPage(r *http.Request, count int, total, limit, offset int64) (*ListMeta, *ListLinks) {
+	links := &ListLinks{}
+	if offset > 0 {
+		previous := offset - limit
+		if previous < 0 {
+			previous = 0
+		}
+		links.Previous = pageLink(r, limit, previous)
+	}
+	if offset+limit < total {
+		links.Next = pageLink(r, limit, offset+limit)
+	}
+
+	return &ListMeta{Count: count, Total: total}, links
+}
@@ -0,0 +1,34 @@
+package payloads
+
+import (
+	"net/http"
+
+	"github.com/go-chi/render"
+)
+
+type AzureMarketplaceOfferResponse struct {
+	Publisher string `json:"publisher" yaml:"publisher"`
+	Offer     string `json:"offer" yaml:"offer"`
+	SKU       string `json:"sku" yaml:"sku"`
+
+	// Image URN of the latest version, can be used as image ID of the reservation
+	URN string `json:"urn" yaml:"urn"`
+
+	Description  string `json:"description" yaml:"description"`
+	Architecture string `json:"architecture" yaml:"architecture"`
+
+	// The offer has a purchase plan, its terms are accepted in the subscription on launch
+	Plan bool `json:"plan" yaml:"plan"`
+}
+
+type AzureMarketplaceOfferListResponse struct {
+	Data []AzureMarketplaceOfferResponse `json:"data" yaml:"data"`
+}
+
+func (s *AzureMarketplaceOfferListResponse) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
+
+func NewAzureMarketplaceOfferListResponse(offers []AzureMarketplaceOfferResponse) render.Renderer {
+	return &AzureMarketplaceOfferListResponse{Data: offers}
+}
@@ -0,0 +1,70 @@
+package payloads
+
+import (
+	"net/http"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/go-chi/render"
+)
+
+// See clients.Subnet
+type SubnetResponse struct {
+	ID               string `json:"id" yaml:"id"`
+	Name             string `json:"name" yaml:"name"`
+	VpcID            string `json:"vpc_id" yaml:"vpc_id"`
+	CIDR             string `json:"cidr" yaml:"cidr"`
+	AvailabilityZone string `json:"availability_zone" yaml:"availability_zone"`
+	Default          bool   `json:"default" yaml:"default"`
+}
+
+type SubnetListResponse struct {
+	Data []*SubnetResponse `json:"data" yaml:"data"`
+}
+
+// See clients.SecurityGroup
+type SecurityGroupResponse struct {
+	ID          string `json:"id" yaml:"id"`
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description" yaml:"description"`
+	VpcID       string `json:"vpc_id" yaml:"vpc_id"`
+}
+
+type SecurityGroupListResponse struct {
+	Data []*SecurityGroupResponse `json:"data" yaml:"data"`
+}
+
+func (s *SubnetListResponse) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
+
+func (s *SecurityGroupListResponse) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
+
+func NewListSubnetResponse(sl []*clients.Subnet) render.Renderer {
+	list := make([]*SubnetResponse, len(sl))
+	for i, subnet := range sl {
+		list[i] = &SubnetResponse{
+			ID:               subnet.ID,
+			Name:             subnet.Name,
+			VpcID:            subnet.VpcID,
+			CIDR:             subnet.CIDR,
+			AvailabilityZone: subnet.AvailabilityZone,
+			Default:          subnet.Default,
+		}
+	}
+	return &SubnetListResponse{Data: list}
+}
+
+func NewListSecurityGroupResponse(sl []*clients.SecurityGroup) render.Renderer {
+	list := make([]*SecurityGroupResponse, len(sl))
+	for i, group := range sl {
+		list[i] = &SecurityGroupResponse{
+			ID:          group.ID,
+			Name:        group.Name,
+			Description: group.Description,
+			VpcID:       group.VpcID,
+		}
+	}
+	return &SecurityGroupListResponse{Data: list}
+}
@@ -3,22 +3,27 @@ package payloads
 import (
 	"net/http"
 
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
 	"github.com/go-chi/render"
 )
 
 type PermissionsResponse struct {
 	Valid           bool     `json:"valid"`
 	MissingEntities []string `json:"missing_entities,omitempty"`
+
+	// Quota contains vCPU limit and current usage when it was possible to fetch it
+	Quota *clients.Quota `json:"quota,omitempty"`
 }
 
 func (s *PermissionsResponse) Render(_ http.ResponseWriter, _ *http.Request) error {
 	return nil
 }
 
-func NewPermissionsResponse(sl []string) render.Renderer {
+func NewPermissionsResponse(sl []string, quota *clients.Quota) render.Renderer {
 	response := PermissionsResponse{
 		Valid:           len(sl) == 0,
 		MissingEntities: sl,
+		Quota:           quota,
 	}
 	return &response
 }
@@ -25,7 +25,9 @@ type PubkeyResponse struct {
 	FingerprintLegacy string `json:"fingerprint_legacy,omitempty" yaml:"fingerprint_legacy,omitempty"`
 }
 type PubkeyListResponse struct {
-	Data []*PubkeyResponse `json:"data" yaml:"data"`
+	Data  []*PubkeyResponse `json:"data" yaml:"data"`
+	Meta  *ListMeta         `json:"meta,omitempty" yaml:"meta"`
+	Links *ListLinks        `json:"links,omitempty" yaml:"links"`
 }
 
 func (p *PubkeyRequest) Bind(_ *http.Request) error {
@@ -66,3 +68,10 @@ func NewPubkeyListResponse(pubkeys []*models.Pubkey) render.Renderer {
 	}
 	return &PubkeyListResponse{Data: list}
 }
+
+// NewPubkeyPageResponse returns a list response with pagination metadata and links.
+func NewPubkeyPageResponse(r *http.Request, pubkeys []*models.Pubkey, total, limit, offset int64) render.Renderer {
+	response := NewPubkeyListResponse(pubkeys).(*PubkeyListResponse)
+	response.Meta, response.Links = newListPage(r, len(pubkeys), total, limit, offset)
+	return response
+}
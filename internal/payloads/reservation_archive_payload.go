@@ -0,0 +1,98 @@
+package payloads
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/go-chi/render"
+)
+
+// ReservationArchiveResponse is a reservation stored in object storage before it was deleted
+// by the retention cleanup.
+type ReservationArchiveResponse struct {
+	// Time when the reservation was archived.
+	ArchivedAt time.Time `json:"archived_at" yaml:"archived_at"`
+
+	// Generic reservation fields.
+	Reservation *GenericReservationResponse `json:"reservation" yaml:"reservation"`
+
+	// User ID of the creator, blank for reservations created by non-user identities.
+	CreatedByUserID string `json:"created_by_user_id" yaml:"created_by_user_id"`
+
+	// Workspace ID, blank when the creator was not restricted to workspaces.
+	WorkspaceID string `json:"workspace_id" yaml:"workspace_id"`
+
+	// Provider specific reservation with instances as returned by the reservation detail of the
+	// provider, nil for noop reservations.
+	Detail map[string]interface{} `json:"detail" nullable:"true" yaml:"detail"`
+
+	// Changes of provisioned resources performed on behalf of users.
+	AuditTrail []AuditRecordResponse `json:"audit_trail" yaml:"audit_trail"`
+}
+
+// AuditRecordResponse is a change of a reservation or its instance recorded in the audit log.
+type AuditRecordResponse struct {
+	ID int64 `json:"id" yaml:"id"`
+
+	// Instance ID on a cloud provider, blank for changes of the whole reservation.
+	InstanceID string `json:"instance_id" yaml:"instance_id"`
+
+	// Action performed (e.g. instance_resize).
+	Action string `json:"action" yaml:"action"`
+
+	// Username of the user who performed the change, blank for non-user identities.
+	Actor string `json:"actor" yaml:"actor"`
+
+	// Action specific details.
+	Details map[string]interface{} `json:"details" yaml:"details"`
+
+	// Time when the change was recorded.
+	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
+}
+
+func (p *ReservationArchiveResponse) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
+
+// NewReservationArchiveResponse creates an archive of a reservation, detail is the provider
+// specific reservation response or nil.
+func NewReservationArchiveResponse(reservation *models.Reservation, detail render.Renderer, records []*models.AuditRecord) (*ReservationArchiveResponse, error) {
+	var detailMap map[string]interface{}
+	if detail != nil {
+		buffer, err := json.Marshal(detail)
+		if err != nil {
+			return nil, fmt.Errorf("cannot marshal reservation detail: %w", err)
+		}
+		if err = json.Unmarshal(buffer, &detailMap); err != nil {
+			return nil, fmt.Errorf("cannot unmarshal reservation detail: %w", err)
+		}
+	}
+
+	trail := make([]AuditRecordResponse, len(records))
+	for i, record := range records {
+		details := make(map[string]interface{}, len(record.Details))
+		for key, value := range record.Details {
+			details[key] = value
+		}
+		trail[i] = AuditRecordResponse{
+			ID:         record.ID,
+			InstanceID: record.InstanceID,
+			Action:     string(record.Action),
+			Actor:      record.Actor,
+			Details:    details,
+			CreatedAt:  record.CreatedAt,
+		}
+	}
+
+	return &ReservationArchiveResponse{
+		ArchivedAt:      time.Now(),
+		Reservation:     reservationResponseMapper(reservation),
+		CreatedByUserID: reservation.CreatedByUserID,
+		WorkspaceID:     reservation.WorkspaceID,
+		Detail:          detailMap,
+		AuditTrail:      trail,
+	}, nil
+}
@@ -0,0 +1,206 @@
+package payloads
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/go-chi/render"
+)
+
+// Kinds of changed fields of a reservation diff.
+const (
+	DiffParameter = "parameter"
+	DiffOutcome   = "outcome"
+)
+
+// ReservationDiffResponse lists the differences of launch parameters and outcomes of two
+// reservations.
+type ReservationDiffResponse struct {
+	// ID of the reservation compared.
+	ReservationID int64 `json:"reservation_id" yaml:"reservation_id"`
+
+	// ID of the reservation compared with.
+	OtherReservationID int64 `json:"other_reservation_id" yaml:"other_reservation_id"`
+
+	// Changed fields, outcomes first, ordered by field name. Empty when the reservations were
+	// launched with the same parameters and finished the same way.
+	Changes []ReservationChangeResponse `json:"changes" yaml:"changes"`
+}
+
+// ReservationChangeResponse is a field with different values in the compared reservations.
+type ReservationChangeResponse struct {
+	// Dotted path of the field, e.g. "detail.instance_type" or "detail.tags.env".
+	Field string `json:"field" yaml:"field"`
+
+	// Launch parameter ("parameter") or result of the launch ("outcome").
+	Kind string `json:"kind" yaml:"kind"`
+
+	// Value of the reservation, nil when the field is not set.
+	Value interface{} `json:"value" nullable:"true" yaml:"value"`
+
+	// Value of the other reservation, nil when the field is not set.
+	OtherValue interface{} `json:"other_value" nullable:"true" yaml:"other_value"`
+}
+
+func (p *ReservationDiffResponse) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
+
+// diffOutcomeFields are generic reservation fields describing the result of the launch, the rest
+// of generic fields (IDs, timestamps and the creator) always differ or are not related to launches.
+var diffOutcomeFields = map[string]bool{
+	"provider":    true,
+	"steps":       true,
+	"step_titles": true,
+	"step":        true,
+	"status":      true,
+	"error":       true,
+	"success":     true,
+	"reachable":   true,
+	"approval":    true,
+}
+
+// diffDetailOutcomeFields are fields of provider specific reservations set by the launch job.
+var diffDetailOutcomeFields = map[string]bool{
+	"launched_instance_type": true,
+	"launched_subnet_id":     true,
+	"launched_on_demand":     true,
+	"instance_count":         true,
+	"instance_power_states":  true,
+}
+
+// diffIgnoredDetailFields are fields of provider specific reservations unique for every
+// reservation. Instances are compared by count and power states instead.
+var diffIgnoredDetailFields = map[string]bool{
+	"reservation_id":     true,
+	"aws_reservation_id": true,
+	"instances":          true,
+}
+
+// NewReservationDiffResponse compares two reservations, details are the provider specific
+// reservation responses or nil for noop reservations.
+func NewReservationDiffResponse(reservation, other *models.Reservation, detail, otherDetail render.Renderer) (*ReservationDiffResponse, error) {
+	fields, err := diffFields(reservation, detail)
+	if err != nil {
+		return nil, err
+	}
+	otherFields, err := diffFields(other, otherDetail)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	for name := range otherFields {
+		if _, ok := fields[name]; !ok {
+			names = append(names, name)
+		}
+	}
+
+	changes := make([]ReservationChangeResponse, 0)
+	for _, name := range names {
+		value, otherValue := fields[name], otherFields[name]
+		if reflect.DeepEqual(value.value, otherValue.value) {
+			continue
+		}
+		kind := value.kind
+		if kind == "" {
+			kind = otherValue.kind
+		}
+		changes = append(changes, ReservationChangeResponse{
+			Field:      name,
+			Kind:       kind,
+			Value:      value.value,
+			OtherValue: otherValue.value,
+		})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Kind != changes[j].Kind {
+			return changes[i].Kind == DiffOutcome
+		}
+		return changes[i].Field < changes[j].Field
+	})
+
+	return &ReservationDiffResponse{
+		ReservationID:      reservation.ID,
+		OtherReservationID: other.ID,
+		Changes:            changes,
+	}, nil
+}
+
+type diffField struct {
+	kind  string
+	value interface{}
+}
+
+// diffFields flattens the generic and provider specific fields of a reservation by their dotted
+// path. Objects are flattened (empty objects are the same as missing ones), arrays are compared
+// as a whole.
+func diffFields(reservation *models.Reservation, detail render.Renderer) (map[string]diffField, error) {
+	generic, err := toJSONMap(reservationResponseMapper(reservation))
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]diffField)
+	for key, value := range generic {
+		if diffOutcomeFields[key] {
+			result["reservation."+key] = diffField{kind: DiffOutcome, value: value}
+		}
+	}
+	if detail == nil {
+		return result, nil
+	}
+
+	detailMap, err := toJSONMap(detail)
+	if err != nil {
+		return nil, err
+	}
+	instances, _ := detailMap["instances"].([]interface{})
+	states := make([]interface{}, len(instances))
+	for i, instance := range instances {
+		if instanceMap, ok := instance.(map[string]interface{}); ok {
+			states[i] = instanceMap["power_state"]
+		}
+	}
+	detailMap["instance_count"] = float64(len(instances))
+	detailMap["instance_power_states"] = states
+	for key, value := range detailMap {
+		if diffIgnoredDetailFields[key] {
+			continue
+		}
+		kind := DiffParameter
+		if diffDetailOutcomeFields[key] {
+			kind = DiffOutcome
+		}
+		flattenDiffField(result, "detail."+key, kind, value)
+	}
+	return result, nil
+}
+
+func flattenDiffField(result map[string]diffField, path, kind string, value interface{}) {
+	if object, ok := value.(map[string]interface{}); ok {
+		for key, nested := range object {
+			flattenDiffField(result, path+"."+key, kind, nested)
+		}
+		return
+	}
+	result[path] = diffField{kind: kind, value: value}
+}
+
+func toJSONMap(value interface{}) (map[string]interface{}, error) {
+	buffer, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal reservation: %w", err)
+	}
+	var result map[string]interface{}
+	if err = json.Unmarshal(buffer, &result); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal reservation: %w", err)
+	}
+	return result, nil
+}
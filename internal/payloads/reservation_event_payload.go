@@ -0,0 +1,66 @@
+package payloads
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/go-chi/render"
+)
+
+// ReservationEventResponse is an entry of the reservation timeline.
+type ReservationEventResponse struct {
+	ID int64 `json:"id" yaml:"id"`
+
+	// Kind of the event: enqueued, pending_approval, approved, rejected, dequeued, step_started,
+	// step_finished, provider_call, spot_fallback, unreachable, error or finished.
+	Kind string `json:"kind" yaml:"kind"`
+
+	// Human readable description (e.g. status of the step, provider operation or error message).
+	Message string `json:"message" yaml:"message"`
+
+	// Event specific details (e.g. job ID or instance type).
+	Details map[string]interface{} `json:"details" yaml:"details"`
+
+	// Time when the event was recorded.
+	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
+}
+
+type ReservationEventListResponse struct {
+	Data  []*ReservationEventResponse `json:"data" yaml:"data"`
+	Meta  *ListMeta                   `json:"meta,omitempty" yaml:"meta"`
+	Links *ListLinks                  `json:"links,omitempty" yaml:"links"`
+}
+
+func (p *ReservationEventListResponse) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
+
+func NewReservationEventResponse(event *models.ReservationEvent) *ReservationEventResponse {
+	details := make(map[string]interface{}, len(event.Details))
+	for key, value := range event.Details {
+		details[key] = value
+	}
+	return &ReservationEventResponse{
+		ID:        event.ID,
+		Kind:      string(event.Kind),
+		Message:   event.Message,
+		Details:   details,
+		CreatedAt: event.CreatedAt,
+	}
+}
+
+func NewReservationEventListResponse(events []*models.ReservationEvent) render.Renderer {
+	list := make([]*ReservationEventResponse, len(events))
+	for i, event := range events {
+		list[i] = NewReservationEventResponse(event)
+	}
+	return &ReservationEventListResponse{Data: list}
+}
+
+// NewReservationEventPageResponse returns a list response with pagination metadata and links.
+func NewReservationEventPageResponse(r *http.Request, events []*models.ReservationEvent, total, limit, offset int64) render.Renderer {
+	response := NewReservationEventListResponse(events).(*ReservationEventListResponse)
+	response.Meta, response.Links = newListPage(r, len(events), total, limit, offset)
+	return response
+}
@@ -0,0 +1,106 @@
+package payloads
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+)
+
+// ReservationExportResponse is a single row of reservation history export. Provider specific
+// details are flattened so the export can be used in spreadsheets.
+type ReservationExportResponse struct {
+	ID int64 `json:"id" yaml:"id"`
+
+	// Provider type name (aws, azure, gcp or noop).
+	Provider string `json:"provider" yaml:"provider"`
+
+	// Time when reservation was made.
+	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
+
+	// Time when reservation was finished or nil when it's still processing.
+	FinishedAt *time.Time `json:"finished_at" nullable:"true" yaml:"finished_at"`
+
+	// Flag indicating success, error or unknown state (NULL).
+	Success *bool `json:"success" nullable:"true" yaml:"success"`
+
+	// Textual status of the reservation.
+	Status string `json:"status" yaml:"status"`
+
+	// Error message when reservation was not successful.
+	Error string `json:"error" yaml:"error"`
+
+	// Source ID.
+	SourceID string `json:"source_id" yaml:"source_id"`
+
+	// Pubkey ID or zero for noop reservations.
+	PubkeyID int64 `json:"pubkey_id" yaml:"pubkey_id"`
+
+	// The ID of the image from which the instance is created.
+	ImageID string `json:"image_id" yaml:"image_id"`
+
+	// AWS region, Azure location or GCP zone.
+	Location string `json:"location" yaml:"location"`
+
+	// AWS instance type, Azure instance size or GCP machine type.
+	InstanceType string `json:"instance_type" yaml:"instance_type"`
+
+	// Amount of instances.
+	Amount int64 `json:"amount" yaml:"amount"`
+}
+
+// ReservationExportCSVHeader is the header row of CSV export, it must match CSVRecord.
+var ReservationExportCSVHeader = []string{
+	"id", "provider", "created_at", "finished_at", "success", "status", "error",
+	"source_id", "pubkey_id", "image_id", "location", "instance_type", "amount",
+}
+
+func NewReservationExportResponse(export *models.ReservationExport) *ReservationExportResponse {
+	response := &ReservationExportResponse{
+		ID:           export.ID,
+		Provider:     export.Provider.String(),
+		CreatedAt:    export.CreatedAt,
+		Status:       export.Status,
+		Error:        export.Error,
+		SourceID:     export.SourceID,
+		PubkeyID:     export.PubkeyID,
+		ImageID:      export.ImageID,
+		Location:     export.Location,
+		InstanceType: export.InstanceType,
+		Amount:       export.Amount,
+	}
+	if export.FinishedAt.Valid {
+		response.FinishedAt = &export.FinishedAt.Time
+	}
+	if export.Success.Valid {
+		response.Success = &export.Success.Bool
+	}
+	return response
+}
+
+// CSVRecord returns the row as CSV record, null values are exported as empty strings.
+func (p *ReservationExportResponse) CSVRecord() []string {
+	var finishedAt, success string
+	if p.FinishedAt != nil {
+		finishedAt = p.FinishedAt.Format(time.RFC3339)
+	}
+	if p.Success != nil {
+		success = strconv.FormatBool(*p.Success)
+	}
+
+	return []string{
+		strconv.FormatInt(p.ID, 10),
+		p.Provider,
+		p.CreatedAt.Format(time.RFC3339),
+		finishedAt,
+		success,
+		p.Status,
+		p.Error,
+		p.SourceID,
+		strconv.FormatInt(p.PubkeyID, 10),
+		p.ImageID,
+		p.Location,
+		p.InstanceType,
+		strconv.FormatInt(p.Amount, 10),
+	}
+}
@@ -1,6 +1,8 @@
 package payloads
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"net/http"
 	"time"
 
@@ -20,6 +22,9 @@ type GenericReservationResponse struct {
 	// Time when reservation was made.
 	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
 
+	// Username of the creator, blank for reservations created by non-user identities.
+	CreatedBy string `json:"created_by" yaml:"created_by"`
+
 	// Total number of job steps for this reservation.
 	Steps int32 `json:"steps" yaml:"steps"`
 
@@ -40,6 +45,17 @@ type GenericReservationResponse struct {
 
 	// Flag indicating success, error or unknown state (NULL). See Status for the actual error.
 	Success *bool `json:"success" nullable:"true" yaml:"success"`
+
+	// Result of the health probe: false when the launch succeeded but some instances were not
+	// reachable in time ("launched but unreachable"), null when instances were not probed.
+	Reachable *bool `json:"reachable" nullable:"true" yaml:"reachable"`
+
+	// Approval state of launches above the approval threshold of the organization: pending_approval,
+	// approved or rejected. Blank when no approval was required.
+	Approval string `json:"approval" yaml:"approval"`
+
+	// Free-form labels grouping reservations.
+	Labels []string `json:"labels" yaml:"labels"`
 }
 
 type InstanceResponse struct {
@@ -48,6 +64,58 @@ type InstanceResponse struct {
 
 	// Instance's description, ip and dns
 	Detail models.ReservationInstanceDetail `json:"detail" yaml:"detail"`
+
+	// Power state of the instance: running, stopping, stopped, starting, terminated when it no
+	// longer exists or unknown when the last stop or start operation failed.
+	PowerState models.PowerState `json:"power_state" yaml:"power_state"`
+
+	// Remote desktop connection of Windows instances, only present once the Administrator password
+	// was retrieved.
+	RDP *RDPConnectionResponse `json:"rdp,omitempty" yaml:"rdp,omitempty"`
+
+	// AWS spot request ID, only present for spot instances.
+	SpotRequestID string `json:"spot_request_id,omitempty" yaml:"spot_request_id,omitempty"`
+
+	// Capacity pool of the instance, only present for AWS instances launched by EC2 Fleet.
+	FleetAllocation *models.FleetAllocation `json:"fleet_allocation,omitempty" yaml:"fleet_allocation,omitempty"`
+
+	// Elastic IP of the instance, only present for AWS instances launched with Elastic IPs.
+	ElasticIP *models.ElasticIP `json:"elastic_ip,omitempty" yaml:"elastic_ip,omitempty"`
+}
+
+// RDPConnectionResponse is the remote desktop connection of a Windows instance. The password is
+// encrypted with the public key of the reservation, decrypt the base64 decoded data with the private
+// key (RSA PKCS #1 v1.5), e.g. "base64 -d | openssl pkeyutl -decrypt -inkey id_rsa".
+type RDPConnectionResponse struct {
+	// Host name or IPv4 address of the instance.
+	Host string `json:"host" yaml:"host"`
+
+	// RDP port.
+	Port int `json:"port" yaml:"port"`
+
+	// Name of the user to log in as.
+	Username string `json:"username" yaml:"username"`
+
+	// Base64 encoded password of the user encrypted with the public key.
+	PasswordData string `json:"password_data" yaml:"password_data"`
+}
+
+// NetworkInterfaceRequest is a network interface of instances of a reservation.
+type NetworkInterfaceRequest struct {
+	// Subnet ID ("subnet-0123456789abcdef0") for AWS or subnet resource ID for Azure. Required.
+	SubnetID string `json:"subnet_id" yaml:"subnet_id"`
+
+	// Optional security group IDs for AWS or a single network security group resource ID for Azure.
+	SecurityGroupIDs []string `json:"security_group_ids,omitempty" yaml:"security_group_ids,omitempty"`
+
+	// Optional static private IPv4 address, only allowed when a single instance is launched.
+	PrivateIPv4 string `json:"private_ipv4,omitempty" yaml:"private_ipv4,omitempty"`
+}
+
+// ResizeInstanceRequest changes the instance type of a stopped instance.
+type ResizeInstanceRequest struct {
+	// New instance type (AWS), instance size (Azure) or machine type (GCP). Required.
+	InstanceType string `json:"instance_type" yaml:"instance_type"`
 }
 
 type AWSReservationResponse struct {
@@ -65,13 +133,20 @@ type AWSReservationResponse struct {
 	// AWS Instance type.
 	InstanceType string `json:"instance_type" yaml:"instance_type"`
 
+	// Alternative instance types tried in order on insufficient capacity.
+	FallbackInstanceTypes []string `json:"fallback_instance_types,omitempty" yaml:"fallback_instance_types,omitempty"`
+
+	// Instance type the instances were launched with, only present when fallback types are defined
+	// and the launch finished.
+	LaunchedInstanceType string `json:"launched_instance_type,omitempty" yaml:"launched_instance_type,omitempty"`
+
 	// Amount of instances to provision of type: Instance type.
 	Amount int32 `json:"amount" yaml:"amount"`
 
 	// The ID of the image from which the instance is created.
 	ImageID string `json:"image_id" yaml:"image_id"`
 
-	// Optional launch template ID ("lt-9848392734432") or empty for no template.
+	// Optional launch template ID ("lt-9848392734432") or name, empty for no template.
 	LaunchTemplateID string `json:"launch_template_id" yaml:"launch_template_id"`
 
 	// The ID of the aws reservation which was created, or missing if not created yet.
@@ -83,6 +158,79 @@ type AWSReservationResponse struct {
 	// Immediately power off the system after initialization
 	PowerOff bool `json:"poweroff" yaml:"poweroff"`
 
+	// IAM instance profile name or ARN attached to the instances.
+	InstanceProfile string `json:"instance_profile,omitempty" yaml:"instance_profile,omitempty"`
+
+	// EBS volumes of the instances are encrypted.
+	EncryptVolumes bool `json:"encrypt_volumes,omitempty" yaml:"encrypt_volumes,omitempty"`
+
+	// KMS key used for the volume encryption.
+	KMSKeyID string `json:"kms_key_id,omitempty" yaml:"kms_key_id,omitempty"`
+
+	// Nitro Enclaves are enabled.
+	NitroEnclaves bool `json:"nitro_enclaves,omitempty" yaml:"nitro_enclaves,omitempty"`
+
+	// Hibernation is enabled.
+	Hibernation bool `json:"hibernation,omitempty" yaml:"hibernation,omitempty"`
+
+	// Root volume options, missing when the root volume of the image is used.
+	RootVolume *AWSRootVolumeRequest `json:"root_volume,omitempty" yaml:"root_volume,omitempty" nullable:"true"`
+
+	// Network interfaces, the first one is the primary interface.
+	NetworkInterfaces []NetworkInterfaceRequest `json:"network_interfaces,omitempty" yaml:"network_interfaces,omitempty"`
+
+	// Subnets of the primary interface.
+	SubnetIDs []string `json:"subnet_ids,omitempty" yaml:"subnet_ids,omitempty"`
+
+	// Security groups of the primary interface.
+	SecurityGroupIDs []string `json:"security_group_ids,omitempty" yaml:"security_group_ids,omitempty"`
+
+	// Subnet the instances were launched in, only present when multiple subnets were requested.
+	LaunchedSubnetID string `json:"launched_subnet_id,omitempty" yaml:"launched_subnet_id,omitempty"`
+
+	// Tenancy of the instances ("dedicated" or "host"), missing for shared hardware.
+	Tenancy string `json:"tenancy,omitempty" yaml:"tenancy,omitempty"`
+
+	// Dedicated host of the instances, missing when any host with auto-placement is used.
+	HostID string `json:"host_id,omitempty" yaml:"host_id,omitempty"`
+
+	// Targeted capacity reservation, missing when open capacity reservations are used.
+	CapacityReservation *AWSCapacityReservationRequest `json:"capacity_reservation,omitempty" yaml:"capacity_reservation,omitempty" nullable:"true"`
+
+	// Static private IPv4 addresses of the primary interface.
+	PrivateIPs []string `json:"private_ips,omitempty" yaml:"private_ips,omitempty"`
+
+	// Route53 hosted zone ID with A records of the instances.
+	DNSZone string `json:"dns_zone,omitempty" yaml:"dns_zone,omitempty"`
+
+	// Health probe of the instances, missing when instances were not probed.
+	HealthProbe *HealthProbeRequest `json:"health_probe,omitempty" yaml:"health_probe,omitempty" nullable:"true"`
+
+	// The image is a Windows image, instances are accessed over RDP.
+	Windows bool `json:"windows,omitempty" yaml:"windows,omitempty"`
+
+	// Spot options, missing for on-demand instances.
+	Spot *AWSSpotRequest `json:"spot,omitempty" yaml:"spot,omitempty" nullable:"true"`
+
+	// Elastic IP options, missing when instances keep their auto-assigned public IPs.
+	ElasticIP *AWSElasticIPRequest `json:"elastic_ip,omitempty" yaml:"elastic_ip,omitempty" nullable:"true"`
+
+	// Effective public IPv4 and IPv6 addressing of the primary network interface.
+	Addressing AddressingResponse `json:"addressing" yaml:"addressing"`
+
+	// Instances were launched on-demand because spot instances were not available.
+	LaunchedOnDemand bool `json:"launched_on_demand,omitempty" yaml:"launched_on_demand,omitempty"`
+
+	// Custom tags applied to the instances and volumes.
+	Tags map[string]string `json:"tags,omitempty" yaml:"tags,omitempty"`
+
+	// Hex encoded SHA-256 digest of custom user data passed to the instances, user data is not
+	// returned as it can contain secrets.
+	UserDataSHA256 string `json:"user_data_sha256,omitempty" yaml:"user_data_sha256,omitempty"`
+
+	// Size of custom user data in bytes.
+	UserDataSize int `json:"user_data_size,omitempty" yaml:"user_data_size,omitempty"`
+
 	// Instances array, only present for finished reservations
 	Instances []InstanceResponse `json:"instances,omitempty" yaml:"instances"`
 }
@@ -100,6 +248,36 @@ type AzureReservationResponse struct {
 	// Azure Instance size.
 	InstanceSize string `json:"instance_size" yaml:"instance_size"`
 
+	// Azure availability zone, blank for non-zonal instances.
+	Zone string `json:"zone,omitempty" yaml:"zone,omitempty"`
+
+	// Resource ID of the proximity placement group, blank for none.
+	ProximityPlacementGroup string `json:"proximity_placement_group,omitempty" yaml:"proximity_placement_group,omitempty"`
+
+	// Security type of the instances, blank for standard instances.
+	SecurityType string `json:"security_type,omitempty" yaml:"security_type,omitempty"`
+
+	// Secure boot is enabled.
+	SecureBoot bool `json:"secure_boot,omitempty" yaml:"secure_boot,omitempty"`
+
+	// Virtual TPM is enabled.
+	VTPM bool `json:"vtpm,omitempty" yaml:"vtpm,omitempty"`
+
+	// Hibernation is enabled.
+	Hibernation bool `json:"hibernation,omitempty" yaml:"hibernation,omitempty"`
+
+	// Additional network interfaces.
+	NetworkInterfaces []NetworkInterfaceRequest `json:"network_interfaces,omitempty" yaml:"network_interfaces,omitempty"`
+
+	// Static private IPv4 addresses of the primary interface.
+	PrivateIPs []string `json:"private_ips,omitempty" yaml:"private_ips,omitempty"`
+
+	// Azure DNS zone resource ID with A records of the instances.
+	DNSZone string `json:"dns_zone,omitempty" yaml:"dns_zone,omitempty"`
+
+	// Health probe of the instances, missing when instances were not probed.
+	HealthProbe *HealthProbeRequest `json:"health_probe,omitempty" yaml:"health_probe,omitempty" nullable:"true"`
+
 	// Amount of instances to provision of type: Instance type.
 	Amount int64 `json:"amount" yaml:"amount"`
 
@@ -112,6 +290,13 @@ type AzureReservationResponse struct {
 	// Immediately PowerOff the system after initialization.
 	PowerOff bool `json:"poweroff" yaml:"poweroff"`
 
+	// Hex encoded SHA-256 digest of custom user data passed to the instances, user data is not
+	// returned as it can contain secrets.
+	UserDataSHA256 string `json:"user_data_sha256,omitempty" yaml:"user_data_sha256,omitempty"`
+
+	// Size of custom user data in bytes.
+	UserDataSize int `json:"user_data_size,omitempty" yaml:"user_data_size,omitempty"`
+
 	// Instances IDs, only present for finished reservations.
 	Instances []InstanceResponse `json:"instances,omitempty" yaml:"instances"`
 }
@@ -149,12 +334,47 @@ type GCPReservationResponse struct {
 	// Immediately power off the system after initialization
 	PowerOff bool `json:"poweroff" yaml:"poweroff"`
 
+	// Service account email attached to the instances.
+	ServiceAccount string `json:"service_account,omitempty" yaml:"service_account,omitempty"`
+
+	// OAuth scopes of the service account.
+	Scopes []string `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+
+	// Shielded VM secure boot is enabled.
+	ShieldedSecureBoot bool `json:"shielded_secure_boot,omitempty" yaml:"shielded_secure_boot,omitempty"`
+
+	// Shielded VM virtual TPM is enabled.
+	ShieldedVTPM bool `json:"shielded_vtpm,omitempty" yaml:"shielded_vtpm,omitempty"`
+
+	// Shielded VM integrity monitoring is enabled.
+	ShieldedIntegrityMonitoring bool `json:"shielded_integrity_monitoring,omitempty" yaml:"shielded_integrity_monitoring,omitempty"`
+
+	// Cloud DNS managed zone with A records of the instances.
+	DNSZone string `json:"dns_zone,omitempty" yaml:"dns_zone,omitempty"`
+
+	// Health probe of the instances, missing when instances were not probed.
+	HealthProbe *HealthProbeRequest `json:"health_probe,omitempty" yaml:"health_probe,omitempty" nullable:"true"`
+
+	// Effective public IPv4 and IPv6 addressing of the network interface.
+	Addressing AddressingResponse `json:"addressing" yaml:"addressing"`
+
+	// Hex encoded SHA-256 digest of custom user data passed to the instances, user data is not
+	// returned as it can contain secrets.
+	UserDataSHA256 string `json:"user_data_sha256,omitempty" yaml:"user_data_sha256,omitempty"`
+
+	// Size of custom user data in bytes.
+	UserDataSize int `json:"user_data_size,omitempty" yaml:"user_data_size,omitempty"`
+
 	// Instances IDs, only present for finished reservations.
 	Instances []InstanceResponse `json:"instances,omitempty" yaml:"instances"`
 }
 
 type NoopReservationResponse struct {
+	// ID of the first created reservation.
 	ID int64 `json:"reservation_id" yaml:"reservation_id"`
+
+	// IDs of all created reservations.
+	IDs []int64 `json:"reservation_ids" yaml:"reservation_ids"`
 }
 
 type AWSReservationRequest struct {
@@ -167,23 +387,248 @@ type AWSReservationRequest struct {
 	// AWS region.
 	Region string `json:"region" yaml:"region"`
 
-	// Optional name of the instance(s).
+	// Optional name of the instance(s), generated from the configured pattern when blank
+	// (e.g. "aws-us-east-1-003").
 	Name string `json:"name" yaml:"name"`
 
-	// Optional launch template ID ("lt-9848392734432") or empty for no template.
+	// Optional launch template ID ("lt-9848392734432") or name, empty for no template. The default
+	// version of the template is launched, image, instance type and the public key of the request
+	// override the template. Both image and instance type can be blank when set by the template.
 	LaunchTemplateID string `json:"launch_template_id,omitempty" yaml:"launch_template_id"`
 
 	// AWS Instance type.
 	InstanceType string `json:"instance_type" yaml:"instance_type"`
 
+	// Optional ordered list of alternative instance types (at most 5) of the same architecture,
+	// tried one by one when the launch fails on insufficient capacity of the instance type.
+	FallbackInstanceTypes []string `json:"fallback_instance_types,omitempty" yaml:"fallback_instance_types,omitempty"`
+
 	// Amount of instances to provision of type: Instance type.
 	Amount int32 ` json:"amount" yaml:"amount"`
 
-	// Image Builder UUID of the image that should be launched. AMI's must be prefixed with 'ami-'.
+	// Image Builder UUID of the image that should be launched. AMI's must be prefixed with 'ami-',
+	// including AWS Marketplace and Cloud Access (gold) images. Subscriptions to marketplace
+	// products must be accepted in the account, otherwise the launch fails with the marketplace URL
+	// in the error and in details of the error event.
 	ImageID string `json:"image_id" yaml:"image_id"`
 
 	// Immediately power off the system after initialization
 	PowerOff bool `json:"poweroff" yaml:"poweroff"`
+
+	// Optional IAM instance profile name ("my-profile") or ARN attached to the instances, the role
+	// of the source must be allowed to pass the profile role (iam:PassRole). The permission is
+	// only needed by reservations with an instance profile.
+	InstanceProfile string `json:"instance_profile,omitempty" yaml:"instance_profile,omitempty"`
+
+	// Encrypt EBS volumes of the image, requires an image. The default EBS key of the account
+	// is used unless a KMS key is set.
+	EncryptVolumes bool `json:"encrypt_volumes,omitempty" yaml:"encrypt_volumes,omitempty"`
+
+	// Optional KMS key ID, alias ("alias/my-key") or ARN used for the volume encryption, implies
+	// encryption. The role of the source must be allowed to use the key, keys which the role cannot
+	// describe (kms:DescribeKey) or which are not enabled are rejected.
+	KMSKeyID string `json:"kms_key_id,omitempty" yaml:"kms_key_id,omitempty"`
+
+	// Enable Nitro Enclaves, the instance type must support them.
+	NitroEnclaves bool `json:"nitro_enclaves,omitempty" yaml:"nitro_enclaves,omitempty"`
+
+	// Enable hibernation, the instance type must support it. Implies volume encryption, the root
+	// volume must be large enough to store the instance memory.
+	Hibernation bool `json:"hibernation,omitempty" yaml:"hibernation,omitempty"`
+
+	// Optional root volume options, the root volume of the image is used when not set. Requires
+	// image ID, the size must not be smaller than the image.
+	RootVolume *AWSRootVolumeRequest `json:"root_volume,omitempty" yaml:"root_volume,omitempty" nullable:"true"`
+
+	// Optional network interfaces (at most 8), the first one replaces the primary interface in the
+	// default subnet. Instances get a public IPv4 address only with a single interface.
+	NetworkInterfaces []NetworkInterfaceRequest `json:"network_interfaces,omitempty" yaml:"network_interfaces,omitempty"`
+
+	// Optional subnet IDs ("subnet-0d2b4f8e1a9c3b7e5") of the primary interface, the default subnet
+	// is used when not set. Subnets are tried in order while there is not enough capacity. Cannot be
+	// combined with network interfaces. See /sources/{ID}/subnets for available subnets.
+	SubnetIDs []string `json:"subnet_ids,omitempty" yaml:"subnet_ids,omitempty"`
+
+	// Optional security group IDs ("sg-07a7a4c3f5d6e8b91") of the primary interface, the default
+	// security group is used when not set. Cannot be combined with network interfaces. See
+	// /sources/{ID}/security_groups for available security groups.
+	SecurityGroupIDs []string `json:"security_group_ids,omitempty" yaml:"security_group_ids,omitempty"`
+
+	// Optional tenancy of the instances: "default" for shared hardware (the default), "dedicated"
+	// for single-tenant hardware or "host" for dedicated hosts. The instance type must be supported
+	// by the host, spot instances cannot run on dedicated hosts.
+	Tenancy string `json:"tenancy,omitempty" yaml:"tenancy,omitempty"`
+
+	// Optional dedicated host ID ("h-0a1b2c3d4e5f67890") with host tenancy, instances are placed on
+	// any host with auto-placement when not set. See /sources/{ID}/dedicated_hosts for allocated hosts.
+	HostID string `json:"host_id,omitempty" yaml:"host_id,omitempty"`
+
+	// Optional capacity reservation the instances are launched into, instances use matching open
+	// capacity reservations when not set. The instance type, zone and tenancy must match the
+	// reserved capacity. Cannot be combined with spot instances, dedicated hosts or fallback
+	// instance types. See /sources/{ID}/capacity_reservations for available capacity.
+	CapacityReservation *AWSCapacityReservationRequest `json:"capacity_reservation,omitempty" yaml:"capacity_reservation,omitempty" nullable:"true"`
+
+	// Optional static private IPv4 addresses of the primary interface, one per instance. Addresses
+	// must be in the subnet of the first network interface which is required.
+	PrivateIPs []string `json:"private_ips,omitempty" yaml:"private_ips,omitempty"`
+
+	// Optional Route53 hosted zone ID ("Z0123456789ABCDEFGHIJ"), an A record is created in the zone
+	// for every instance. Record names are built from RESERVATION_DNS_PATTERN.
+	DNSZone string `json:"dns_zone,omitempty" yaml:"dns_zone,omitempty"`
+
+	// Optional health probe, instances are probed after the launch until they are reachable or the
+	// probe times out. Launches with unreachable instances still succeed but are marked unreachable.
+	HealthProbe *HealthProbeRequest `json:"health_probe,omitempty" yaml:"health_probe,omitempty" nullable:"true"`
+
+	// Optional free-form labels (at most 16) grouping reservations, e.g. by project or event. Labels
+	// are not propagated to the cloud provider.
+	Labels []string `json:"labels,omitempty" yaml:"labels,omitempty"`
+
+	// Optional spot options, instances are launched as one-time spot requests terminated on
+	// interruption. Cannot be combined with hibernation.
+	Spot *AWSSpotRequest `json:"spot,omitempty" yaml:"spot,omitempty" nullable:"true"`
+
+	// Optional Elastic IP options, an Elastic IP is associated to every instance after the launch
+	// so instances keep their public IPv4 address when stopped. Cannot be combined with multiple
+	// network interfaces.
+	ElasticIP *AWSElasticIPRequest `json:"elastic_ip,omitempty" yaml:"elastic_ip,omitempty" nullable:"true"`
+
+	// Optional public IPv4 and IPv6 options of the primary network interface. Instances are
+	// launched with a network interface in the requested or default subnet when set.
+	Addressing *AddressingRequest `json:"addressing,omitempty" yaml:"addressing,omitempty" nullable:"true"`
+
+	// Optional custom tags (at most 48) applied to the instances and volumes in addition to the
+	// tags managed by the service ("rh-rid" and "Name"). Keys have up to 128 and values up to 256
+	// characters, keys must not start with "aws:".
+	Tags map[string]string `json:"tags,omitempty" yaml:"tags,omitempty"`
+
+	// Optional user data (at most 12 KiB) passed to the instances, e.g. cloud-config or a shell script.
+	// It is processed by cloud-init after the user data generated by the service, custom cloud-config
+	// cannot override generated keys but items of lists like runcmd are appended. Windows
+	// images get the user data as-is (e.g. a <powershell> script).
+	UserData string `json:"user_data,omitempty" yaml:"user_data,omitempty"`
+}
+
+// AWSRootVolumeRequest are options of the EBS root volume of AWS instances.
+type AWSRootVolumeRequest struct {
+	// Optional size in GiB (1 to 16384), the image volume size when not set.
+	Size int32 `json:"size,omitempty" yaml:"size,omitempty"`
+
+	// Optional volume type ("gp3" or "io2"), the image volume type when blank.
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+
+	// Optional provisioned IOPS, 3000 to 16000 for gp3 and 100 to 64000 for io2 (required), at most
+	// 500 IOPS per GiB.
+	IOPS int32 `json:"iops,omitempty" yaml:"iops,omitempty"`
+}
+
+// AWSSpotRequest are options of AWS spot instances.
+type AWSSpotRequest struct {
+	// Optional maximum hourly price per instance in USD ("0.05"), the on-demand price when blank.
+	MaxPrice string `json:"max_price,omitempty" yaml:"max_price,omitempty"`
+
+	// Launch on-demand instances when spot capacity is not available for the price or spot
+	// requests are not fulfilled in time.
+	FallbackOnDemand bool `json:"fallback_on_demand,omitempty" yaml:"fallback_on_demand,omitempty"`
+}
+
+// AWSCapacityReservationRequest targets an AWS capacity reservation, exactly one of the fields
+// must be set.
+type AWSCapacityReservationRequest struct {
+	// ID of the capacity reservation ("cr-0a1b2c3d4e5f67890").
+	ID string `json:"id,omitempty" yaml:"id,omitempty"`
+
+	// ARN of a resource group of capacity reservations, instances are launched into any
+	// reservation of the group with available capacity.
+	GroupARN string `json:"group_arn,omitempty" yaml:"group_arn,omitempty"`
+}
+
+// AWSElasticIPRequest are options of Elastic IPs associated to AWS instances.
+type AWSElasticIPRequest struct {
+	// Optional pool of Elastic IPs reused before new addresses are allocated, unassociated addresses
+	// tagged "rh-eip-pool" with this value are used first. Pooled addresses are kept when instances
+	// are terminated, allocated addresses are released.
+	Pool string `json:"pool,omitempty" yaml:"pool,omitempty"`
+}
+
+// AddressingRequest are public IPv4 and IPv6 options of the primary network interface.
+type AddressingRequest struct {
+	// Optional public IPv4 address, assigned unless set to false. AWS instances with multiple
+	// network interfaces never get a public IPv4 address.
+	PublicIPv4 *bool `json:"public_ipv4,omitempty" yaml:"public_ipv4,omitempty" nullable:"true"`
+
+	// Request an IPv6 address, the subnet (GCP: the default network subnet of the region) must
+	// have an IPv6 range. GCP instances with a public IPv4 address also get an external IPv6.
+	IPv6 bool `json:"ipv6,omitempty" yaml:"ipv6,omitempty"`
+}
+
+// HealthProbeRequest checks instances are reachable after the launch, the SSH port is probed
+// unless another port or an HTTP path is set. Instances are probed from the service on their public
+// IPv4 address, instances without one are reported unreachable. Firewalls must allow the port.
+type HealthProbeRequest struct {
+	// Optional TCP port (1 to 65535), 22 or 80 when an HTTP path is set.
+	Port int `json:"port,omitempty" yaml:"port,omitempty"`
+
+	// Optional HTTP path ("/healthz") requested on the port, the instance is reachable when the
+	// response status is below 400. A TCP connection is enough when blank.
+	HTTPPath string `json:"http_path,omitempty" yaml:"http_path,omitempty"`
+
+	// Optional maximum wait in seconds for all instances to become reachable, WORKER_PROBE_TIMEOUT
+	// when not set. It must be shorter than the job timeout.
+	Timeout int `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
+// NewHealthProbeResponse returns the health probe of a reservation or nil.
+func NewHealthProbeResponse(probe *models.HealthProbe) *HealthProbeRequest {
+	if probe == nil {
+		return nil
+	}
+	return &HealthProbeRequest{Port: probe.Port, HTTPPath: probe.HTTPPath, Timeout: probe.Timeout}
+}
+
+// Addressing modes of AddressingResponse.
+const (
+	AddressingIPv4             = "ipv4"
+	AddressingPrivateIPv4      = "private-ipv4"
+	AddressingDualStack        = "dual-stack"
+	AddressingPrivateDualStack = "private-dual-stack"
+)
+
+// AddressingResponse is the effective addressing of the primary network interface.
+type AddressingResponse struct {
+	// Addressing mode: ipv4, private-ipv4, dual-stack or private-dual-stack.
+	Mode string `json:"mode" yaml:"mode"`
+
+	// A public IPv4 address is assigned. AWS subnets which do not auto-assign public addresses
+	// can still leave instances without one when no addressing options were requested.
+	PublicIPv4 bool `json:"public_ipv4" yaml:"public_ipv4"`
+
+	// An IPv6 address was requested.
+	IPv6 bool `json:"ipv6" yaml:"ipv6"`
+}
+
+// NewAddressingResponse returns the effective addressing, the public IPv4 default applies when
+// the public address was not requested explicitly.
+func NewAddressingResponse(addressing *models.Addressing, publicDefault bool) AddressingResponse {
+	response := AddressingResponse{PublicIPv4: publicDefault}
+	if addressing != nil {
+		if addressing.PublicIPv4 != nil {
+			response.PublicIPv4 = *addressing.PublicIPv4
+		}
+		response.IPv6 = addressing.IPv6
+	}
+	switch {
+	case response.PublicIPv4 && response.IPv6:
+		response.Mode = AddressingDualStack
+	case response.IPv6:
+		response.Mode = AddressingPrivateDualStack
+	case response.PublicIPv4:
+		response.Mode = AddressingIPv4
+	default:
+		response.Mode = AddressingPrivateIPv4
+	}
+	return response
 }
 
 type AzureReservationRequest struct {
@@ -191,7 +636,8 @@ type AzureReservationRequest struct {
 
 	SourceID string `json:"source_id" yaml:"source_id"`
 
-	// Image Builder UUID of the image that should be launched. This can be directly Azure image ID.
+	// Image Builder UUID of the image that should be launched. This can be directly Azure image ID
+	// or a marketplace image URN in the publisher:offer:sku:version form.
 	ImageID string `json:"image_id" yaml:"image_id"`
 
 	// Azure Location to deploy into.
@@ -200,14 +646,64 @@ type AzureReservationRequest struct {
 	// Azure Instance type.
 	InstanceSize string `json:"instance_size" yaml:"instance_size"`
 
+	// Optional availability zone ("1", "2" or "3"), the instance size must be available in the zone
+	// of the location. When the location has a zone suffix (e.g. "eastus_1") it must be the same zone,
+	// a location without the suffix can be used (e.g. "eastus"). Instances are not zonal when blank.
+	Zone string `json:"zone,omitempty" yaml:"zone,omitempty"`
+
+	// Optional resource ID of an existing proximity placement group to launch the instance(s) into
+	// (e.g. "/subscriptions/ID/resourceGroups/NAME/providers/Microsoft.Compute/proximityPlacementGroups/NAME").
+	ProximityPlacementGroup string `json:"proximity_placement_group,omitempty" yaml:"proximity_placement_group,omitempty"`
+
+	// Optional security type: "TrustedLaunch" or "ConfidentialVM", the image must support it. Confidential
+	// VMs require a confidential VM size (DC or EC series) and always have vTPM enabled. Standard VMs are
+	// launched when blank.
+	SecurityType string `json:"security_type,omitempty" yaml:"security_type,omitempty"`
+
+	// Enable secure boot, requires a security type.
+	SecureBoot bool `json:"secure_boot,omitempty" yaml:"secure_boot,omitempty"`
+
+	// Enable virtual TPM, requires a security type.
+	VTPM bool `json:"vtpm,omitempty" yaml:"vtpm,omitempty"`
+
+	// Enable hibernation, the instance size must support it.
+	Hibernation bool `json:"hibernation,omitempty" yaml:"hibernation,omitempty"`
+
+	// Optional additional network interfaces (at most 7) attached after the primary interface. Azure
+	// requires all interfaces in the same virtual network, the subnets must be in the "redhat-vnet"
+	// network of the resource group.
+	NetworkInterfaces []NetworkInterfaceRequest `json:"network_interfaces,omitempty" yaml:"network_interfaces,omitempty"`
+
+	// Optional static private IPv4 addresses of the primary interface, one per instance. Addresses
+	// must be in the 172.22.0.0/16 subnet of the "redhat-vnet" network.
+	PrivateIPs []string `json:"private_ips,omitempty" yaml:"private_ips,omitempty"`
+
+	// Optional Azure DNS zone resource ID, an A record is created in the zone for every instance.
+	// Record names are built from RESERVATION_DNS_PATTERN.
+	DNSZone string `json:"dns_zone,omitempty" yaml:"dns_zone,omitempty"`
+
+	// Optional health probe, instances are probed after the launch until they are reachable or the
+	// probe times out. Launches with unreachable instances still succeed but are marked unreachable.
+	HealthProbe *HealthProbeRequest `json:"health_probe,omitempty" yaml:"health_probe,omitempty" nullable:"true"`
+
 	// Amount of instances to provision of size: InstanceSize.
 	Amount int64 `json:"amount" yaml:"amount"`
 
-	// Name of the instance(s).
+	// Optional name of the instance(s), generated from the configured pattern when blank
+	// (e.g. "azure-eastus-1-003").
 	Name string `json:"name" yaml:"name"`
 
 	// Immediately power off the system after initialization.
 	PowerOff bool `json:"poweroff" yaml:"poweroff"`
+
+	// Optional free-form labels (at most 16) grouping reservations, e.g. by project or event. Labels
+	// are not propagated to the cloud provider.
+	Labels []string `json:"labels,omitempty" yaml:"labels,omitempty"`
+
+	// Optional user data (at most 12 KiB) passed to the instances, e.g. cloud-config or a shell script.
+	// It is processed by cloud-init after the user data generated by the service, custom cloud-config
+	// cannot override generated keys but items of lists like runcmd are appended.
+	UserData string `json:"user_data,omitempty" yaml:"user_data,omitempty"`
 }
 
 type GCPReservationRequest struct {
@@ -220,7 +716,8 @@ type GCPReservationRequest struct {
 	// Optional launch template id global/instanceTemplates/ID or empty string
 	LaunchTemplateID string `json:"launch_template_id,omitempty" yaml:"launch_template_id"`
 
-	// Optional name pattern of the instance(s).
+	// Optional name pattern of the instance(s), generated from the configured pattern when blank
+	// (e.g. "gcp-us-east4-a-003").
 	NamePattern string `json:"name_pattern" yaml:"name_pattern"`
 
 	// GCP zone.
@@ -232,25 +729,84 @@ type GCPReservationRequest struct {
 	// Amount of instances to provision of type: Instance type.
 	Amount int64 ` json:"amount" yaml:"amount"`
 
-	// Image Builder UUID of the image that should be launched.
+	// Image Builder UUID of the image that should be launched. This can be directly GCP image URL
+	// or an image family in form "family/NAME" (customer project) or "projects/PROJECT/global/images/family/NAME".
 	ImageID string `json:"image_id" yaml:"image_id"`
 
 	// Immediately power off the system after initialization.
 	PowerOff bool `json:"poweroff" yaml:"poweroff"`
+
+	// Optional service account email attached to the instances, "default" attaches the Compute Engine
+	// default service account. No service account is attached when blank, unless the launch template
+	// defines one.
+	ServiceAccount string `json:"service_account,omitempty" yaml:"service_account,omitempty"`
+
+	// Optional OAuth scopes of the service account, full URLs or short names (e.g. "cloud-platform").
+	// The "cloud-platform" scope is used when blank.
+	Scopes []string `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+
+	// Enable Shielded VM secure boot, the image must support Shielded VM.
+	ShieldedSecureBoot bool `json:"shielded_secure_boot,omitempty" yaml:"shielded_secure_boot,omitempty"`
+
+	// Enable Shielded VM virtual TPM.
+	ShieldedVTPM bool `json:"shielded_vtpm,omitempty" yaml:"shielded_vtpm,omitempty"`
+
+	// Enable Shielded VM integrity monitoring, requires virtual TPM.
+	ShieldedIntegrityMonitoring bool `json:"shielded_integrity_monitoring,omitempty" yaml:"shielded_integrity_monitoring,omitempty"`
+
+	// Hibernation is not supported for GCP, requests with it enabled are rejected. Instances
+	// can be suspended without any launch configuration.
+	Hibernation bool `json:"hibernation,omitempty" yaml:"hibernation,omitempty"`
+
+	// Optional Cloud DNS managed zone name, an A record is created in the zone for every instance.
+	// Record names are built from RESERVATION_DNS_PATTERN.
+	DNSZone string `json:"dns_zone,omitempty" yaml:"dns_zone,omitempty"`
+
+	// Optional health probe, instances are probed after the launch until they are reachable or the
+	// probe times out. Launches with unreachable instances still succeed but are marked unreachable.
+	HealthProbe *HealthProbeRequest `json:"health_probe,omitempty" yaml:"health_probe,omitempty" nullable:"true"`
+
+	// Optional public IPv4 and IPv6 options of the network interface in the default network.
+	Addressing *AddressingRequest `json:"addressing,omitempty" yaml:"addressing,omitempty" nullable:"true"`
+
+	// Optional free-form labels (at most 16) grouping reservations, e.g. by project or event. Labels
+	// are not propagated to the cloud provider.
+	Labels []string `json:"labels,omitempty" yaml:"labels,omitempty"`
+
+	// Optional user data (at most 12 KiB) passed to cloud-init in the "user-data" metadata key, e.g.
+	// cloud-config or a shell script. The image must run cloud-init, the startup script generated by
+	// the service is kept.
+	UserData string `json:"user_data,omitempty" yaml:"user_data,omitempty"`
+}
+
+// ReservationStatusRequest is a batch of reservation IDs to return statuses for.
+type ReservationStatusRequest struct {
+	// Reservation IDs, at most 100 IDs can be requested at once.
+	IDs []int64 `json:"ids" yaml:"ids"`
 }
 
 type GenericReservationListResponse struct {
-	Data []*GenericReservationResponse `json:"data" yaml:"data"`
+	Data  []*GenericReservationResponse `json:"data" yaml:"data"`
+	Meta  *ListMeta                     `json:"meta,omitempty" yaml:"meta"`
+	Links *ListLinks                    `json:"links,omitempty" yaml:"links"`
 }
 
 func (p *GenericReservationResponse) Render(_ http.ResponseWriter, _ *http.Request) error {
 	return nil
 }
 
+func (p *ReservationStatusRequest) Bind(_ *http.Request) error {
+	return nil
+}
+
 func (p *AWSReservationRequest) Bind(_ *http.Request) error {
 	return nil
 }
 
+func (p *InstanceResponse) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
+
 func (p *AWSReservationResponse) Render(_ http.ResponseWriter, _ *http.Request) error {
 	return nil
 }
@@ -283,10 +839,62 @@ func (p *GCPReservationRequest) Bind(_ *http.Request) error {
 	return nil
 }
 
+func (p *ResizeInstanceRequest) Bind(_ *http.Request) error {
+	return nil
+}
+
+func NewInstanceResponse(instance *models.ReservationInstance) *InstanceResponse {
+	response := &InstanceResponse{
+		InstanceID:    instance.InstanceID,
+		Detail:        instance.Detail,
+		PowerState:    instance.PowerState,
+		SpotRequestID: instance.SpotRequestID,
+	}
+	if instance.Detail.PasswordData != "" {
+		response.RDP = NewRDPConnectionResponse(&instance.Detail)
+	}
+	if !instance.FleetAllocation.Empty() {
+		allocation := instance.FleetAllocation
+		response.FleetAllocation = &allocation
+	}
+	if !instance.ElasticIP.Empty() {
+		eip := instance.ElasticIP
+		response.ElasticIP = &eip
+	}
+	return response
+}
+
+// NewRDPConnectionResponse prefers the DNS record name over the provider host name and address.
+func NewRDPConnectionResponse(detail *models.ReservationInstanceDetail) *RDPConnectionResponse {
+	host := detail.DNSName
+	if host == "" {
+		host = detail.PublicDNS
+	}
+	if host == "" {
+		host = detail.PublicIPv4
+	}
+	return &RDPConnectionResponse{
+		Host:         host,
+		Port:         3389,
+		Username:     "Administrator",
+		PasswordData: detail.PasswordData,
+	}
+}
+
+// userDataDigest returns the hex encoded SHA-256 digest and the size of custom user data, both
+// are blank for blank user data.
+func userDataDigest(userData string) (string, int) {
+	if userData == "" {
+		return "", 0
+	}
+	sum := sha256.Sum256([]byte(userData))
+	return hex.EncodeToString(sum[:]), len(userData)
+}
+
 func NewAWSReservationResponse(reservation *models.AWSReservation, instances []*models.ReservationInstance) render.Renderer {
 	instancesResponse := make([]InstanceResponse, len(instances))
 	for iter, inst := range instances {
-		instancesResponse[iter] = InstanceResponse{InstanceID: inst.InstanceID, Detail: inst.Detail}
+		instancesResponse[iter] = *NewInstanceResponse(inst)
 	}
 
 	response := AWSReservationResponse{
@@ -301,7 +909,52 @@ func NewAWSReservationResponse(reservation *models.AWSReservation, instances []*
 		PowerOff:         reservation.Detail.PowerOff,
 		Instances:        instancesResponse,
 		LaunchTemplateID: reservation.Detail.LaunchTemplateID,
+		InstanceProfile:  reservation.Detail.InstanceProfile,
+		EncryptVolumes:   reservation.Detail.EncryptVolumes,
+		KMSKeyID:         reservation.Detail.KMSKeyID,
+		NitroEnclaves:    reservation.Detail.NitroEnclaves,
+		Hibernation:      reservation.Detail.Hibernation,
+
+		FallbackInstanceTypes: reservation.Detail.FallbackInstanceTypes,
+		LaunchedInstanceType:  reservation.Detail.LaunchedInstanceType,
+		NetworkInterfaces:     NewNetworkInterfaceResponses(reservation.Detail.NetworkInterfaces),
+		SubnetIDs:             reservation.Detail.SubnetIDs,
+		SecurityGroupIDs:      reservation.Detail.SecurityGroupIDs,
+		LaunchedSubnetID:      reservation.Detail.LaunchedSubnetID,
+		Tenancy:               reservation.Detail.Tenancy,
+		HostID:                reservation.Detail.HostID,
+		PrivateIPs:            reservation.Detail.PrivateIPs,
+		DNSZone:               reservation.Detail.DNSZone,
+		HealthProbe:           NewHealthProbeResponse(reservation.Detail.HealthProbe),
+		Windows:               reservation.Detail.Windows,
+		LaunchedOnDemand:      reservation.Detail.LaunchedOnDemand,
+		Tags:                  reservation.Detail.Tags,
+	}
+	if reservation.Detail.RootVolume != nil {
+		response.RootVolume = &AWSRootVolumeRequest{
+			Size: reservation.Detail.RootVolume.Size,
+			Type: reservation.Detail.RootVolume.Type,
+			IOPS: reservation.Detail.RootVolume.IOPS,
+		}
 	}
+	if reservation.Detail.Spot != nil {
+		response.Spot = &AWSSpotRequest{
+			MaxPrice:         reservation.Detail.Spot.MaxPrice,
+			FallbackOnDemand: reservation.Detail.Spot.FallbackOnDemand,
+		}
+	}
+	if reservation.Detail.CapacityReservation != nil {
+		response.CapacityReservation = &AWSCapacityReservationRequest{
+			ID:       reservation.Detail.CapacityReservation.ID,
+			GroupARN: reservation.Detail.CapacityReservation.GroupARN,
+		}
+	}
+	if reservation.Detail.ElasticIP != nil {
+		response.ElasticIP = &AWSElasticIPRequest{Pool: reservation.Detail.ElasticIP.Pool}
+	}
+	response.UserDataSHA256, response.UserDataSize = userDataDigest(reservation.Detail.UserData)
+	// AWS does not assign public IPv4 addresses to instances with multiple interfaces
+	response.Addressing = NewAddressingResponse(reservation.Detail.Addressing, len(reservation.Detail.NetworkInterfaces) <= 1)
 	if reservation.AWSReservationID != nil {
 		response.AWSReservationID = *reservation.AWSReservationID
 	}
@@ -311,7 +964,7 @@ func NewAWSReservationResponse(reservation *models.AWSReservation, instances []*
 func NewAzureReservationResponse(reservation *models.AzureReservation, instances []*models.ReservationInstance) render.Renderer {
 	instanceIds := make([]InstanceResponse, len(instances))
 	for iter, inst := range instances {
-		instanceIds[iter] = InstanceResponse{InstanceID: inst.InstanceID, Detail: inst.Detail}
+		instanceIds[iter] = *NewInstanceResponse(inst)
 	}
 
 	response := AzureReservationResponse{
@@ -325,17 +978,26 @@ func NewAzureReservationResponse(reservation *models.AzureReservation, instances
 		Name:         reservation.Detail.Name,
 		PowerOff:     reservation.Detail.PowerOff,
 		Instances:    instanceIds,
+
+		Zone:                    reservation.Detail.Zone,
+		ProximityPlacementGroup: reservation.Detail.ProximityPlacementGroup,
+		SecurityType:            string(reservation.Detail.SecurityType),
+		SecureBoot:              reservation.Detail.SecureBoot,
+		VTPM:                    reservation.Detail.VTPM,
+		Hibernation:             reservation.Detail.Hibernation,
+		NetworkInterfaces:       NewNetworkInterfaceResponses(reservation.Detail.NetworkInterfaces),
+		PrivateIPs:              reservation.Detail.PrivateIPs,
+		DNSZone:                 reservation.Detail.DNSZone,
+		HealthProbe:             NewHealthProbeResponse(reservation.Detail.HealthProbe),
 	}
+	response.UserDataSHA256, response.UserDataSize = userDataDigest(reservation.Detail.UserData)
 	return &response
 }
 
 func NewGCPReservationResponse(reservation *models.GCPReservation, instances []*models.ReservationInstance) render.Renderer {
 	instanceIds := make([]InstanceResponse, len(instances))
 	for iter, inst := range instances {
-		instanceIds[iter] = InstanceResponse{
-			InstanceID: inst.InstanceID,
-			Detail:     inst.Detail,
-		}
+		instanceIds[iter] = *NewInstanceResponse(inst)
 	}
 
 	response := GCPReservationResponse{
@@ -351,14 +1013,31 @@ func NewGCPReservationResponse(reservation *models.GCPReservation, instances []*
 		PowerOff:         reservation.Detail.PowerOff,
 		Instances:        instanceIds,
 		LaunchTemplateID: reservation.Detail.LaunchTemplateID,
+		ServiceAccount:   reservation.Detail.ServiceAccount,
+		Scopes:           reservation.Detail.Scopes,
+
+		ShieldedSecureBoot:          reservation.Detail.ShieldedSecureBoot,
+		ShieldedVTPM:                reservation.Detail.ShieldedVTPM,
+		ShieldedIntegrityMonitoring: reservation.Detail.ShieldedIntegrityMonitoring,
+		DNSZone:                     reservation.Detail.DNSZone,
+		HealthProbe:                 NewHealthProbeResponse(reservation.Detail.HealthProbe),
+		Addressing:                  NewAddressingResponse(reservation.Detail.Addressing, true),
 	}
+	response.UserDataSHA256, response.UserDataSize = userDataDigest(reservation.Detail.UserData)
 	return &response
 }
 
-func NewNoopReservationResponse(reservation *models.NoopReservation) render.Renderer {
-	return &NoopReservationResponse{
-		ID: reservation.ID,
+func NewNoopReservationResponse(reservations []*models.NoopReservation) render.Renderer {
+	response := &NoopReservationResponse{
+		IDs: make([]int64, len(reservations)),
 	}
+	for i, reservation := range reservations {
+		response.IDs[i] = reservation.ID
+	}
+	if len(reservations) > 0 {
+		response.ID = reservations[0].ID
+	}
+	return response
 }
 
 func NewReservationListResponse(reservations []*models.Reservation) render.Renderer {
@@ -369,6 +1048,13 @@ func NewReservationListResponse(reservations []*models.Reservation) render.Rende
 	return &GenericReservationListResponse{Data: list}
 }
 
+// NewReservationPageResponse returns a list response with pagination metadata and links.
+func NewReservationPageResponse(r *http.Request, reservations []*models.Reservation, total, limit, offset int64) render.Renderer {
+	response := NewReservationListResponse(reservations).(*GenericReservationListResponse)
+	response.Meta, response.Links = newListPage(r, len(reservations), total, limit, offset)
+	return response
+}
+
 func reservationResponseMapper(reservation *models.Reservation) *GenericReservationResponse {
 	var finishedAt *time.Time
 	if reservation.FinishedAt.Valid {
@@ -378,16 +1064,40 @@ func reservationResponseMapper(reservation *models.Reservation) *GenericReservat
 	if reservation.Success.Valid {
 		success = &reservation.Success.Bool
 	}
+	var reachable *bool
+	if reservation.Reachable.Valid {
+		reachable = &reservation.Reachable.Bool
+	}
 	return &GenericReservationResponse{
 		ID:         reservation.ID,
 		Provider:   int(reservation.Provider),
 		CreatedAt:  reservation.CreatedAt,
+		CreatedBy:  reservation.CreatedBy,
 		FinishedAt: finishedAt,
 		Status:     reservation.Status,
 		Success:    success,
+		Reachable:  reachable,
 		Steps:      reservation.Steps,
 		Step:       reservation.Step,
 		StepTitles: reservation.StepTitles,
 		Error:      reservation.Error,
+		Approval:   string(reservation.Approval),
+		Labels:     nonNilStrings(reservation.Labels),
+	}
+}
+
+// NewNetworkInterfaceResponses returns requested network interfaces of a reservation, nil when empty.
+func NewNetworkInterfaceResponses(nics []models.NetworkInterface) []NetworkInterfaceRequest {
+	if len(nics) == 0 {
+		return nil
+	}
+	result := make([]NetworkInterfaceRequest, len(nics))
+	for i, nic := range nics {
+		result[i] = NetworkInterfaceRequest{
+			SubnetID:         nic.SubnetID,
+			SecurityGroupIDs: nic.SecurityGroupIDs,
+			PrivateIPv4:      nic.PrivateIPv4,
+		}
 	}
+	return result
 }
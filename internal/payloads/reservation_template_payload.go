@@ -0,0 +1,150 @@
+package payloads
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/go-chi/render"
+)
+
+// See models.ReservationTemplate
+type ReservationTemplateRequest struct {
+	// User-facing name, unique within an account.
+	Name string `json:"name" yaml:"name"`
+
+	// Provider type: aws, azure or gcp.
+	Provider string `json:"provider" yaml:"provider"`
+
+	// Provider specific reservation request (e.g. AWSReservationRequest) used as a base for
+	// reservations created from the template.
+	Request map[string]interface{} `json:"request" yaml:"request"`
+}
+
+// See models.ReservationTemplate
+type ReservationTemplateResponse struct {
+	ID int64 `json:"id" yaml:"id"`
+
+	Name string `json:"name" yaml:"name"`
+
+	// Provider type: aws, azure or gcp.
+	Provider string `json:"provider" yaml:"provider"`
+
+	// Provider specific reservation request.
+	Request map[string]interface{} `json:"request" yaml:"request"`
+
+	// Username of the creator, blank for templates created by non-user identities.
+	CreatedBy string `json:"created_by" yaml:"created_by"`
+
+	// Time when the template was created.
+	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
+
+	// Schedule of the template, missing when the template was never scheduled.
+	Schedule *ReservationTemplateScheduleResponse `json:"schedule,omitempty" yaml:"schedule,omitempty"`
+}
+
+// ReservationTemplateScheduleRequest schedules a template, exactly one of the fields must be set.
+type ReservationTemplateScheduleRequest struct {
+	// Time of a one-shot launch, must be in the future.
+	At *time.Time `json:"at,omitempty" yaml:"at,omitempty"`
+
+	// Standard five field cron expression (minute, hour, day of month, month, day of week) in UTC
+	// for recurring launches, e.g. "0 7 * * 1" for every Monday at 7:00 UTC.
+	Cron string `json:"cron,omitempty" yaml:"cron,omitempty"`
+}
+
+type ReservationTemplateScheduleResponse struct {
+	// Cron expression of recurring launches, blank for one-shot launches.
+	Cron string `json:"cron" yaml:"cron"`
+
+	// Time of the next launch or nil when there is no pending launch.
+	NextRunAt *time.Time `json:"next_run_at" nullable:"true" yaml:"next_run_at"`
+
+	// Time of the last launch or nil.
+	LastRunAt *time.Time `json:"last_run_at" nullable:"true" yaml:"last_run_at"`
+
+	// Reservation created by the last launch, missing when it was not created (yet).
+	LastReservationID int64 `json:"last_reservation_id,omitempty" yaml:"last_reservation_id,omitempty"`
+}
+
+type ReservationTemplateListResponse struct {
+	Data  []*ReservationTemplateResponse `json:"data" yaml:"data"`
+	Meta  *ListMeta                      `json:"meta,omitempty" yaml:"meta"`
+	Links *ListLinks                     `json:"links,omitempty" yaml:"links"`
+}
+
+func (p *ReservationTemplateRequest) Bind(_ *http.Request) error {
+	return nil
+}
+
+func (p *ReservationTemplateScheduleRequest) Bind(_ *http.Request) error {
+	return nil
+}
+
+func (p *ReservationTemplateResponse) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
+
+func (p *ReservationTemplateListResponse) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
+
+func (p *ReservationTemplateRequest) NewModel() (*models.ReservationTemplate, error) {
+	request, err := json.Marshal(p.Request)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal template request: %w", err)
+	}
+
+	return &models.ReservationTemplate{
+		Name:     p.Name,
+		Provider: models.ProviderTypeFromString(p.Provider),
+		Request:  request,
+	}, nil
+}
+
+func NewReservationTemplateResponse(template *models.ReservationTemplate) *ReservationTemplateResponse {
+	var request map[string]interface{}
+	if err := json.Unmarshal(template.Request, &request); err != nil {
+		// stored requests are always valid JSON objects
+		request = map[string]interface{}{}
+	}
+
+	response := &ReservationTemplateResponse{
+		ID:        template.ID,
+		Name:      template.Name,
+		Provider:  template.Provider.String(),
+		Request:   request,
+		CreatedBy: template.CreatedBy,
+		CreatedAt: template.CreatedAt,
+	}
+	if template.NextRunAt.Valid || template.LastRunAt.Valid {
+		response.Schedule = &ReservationTemplateScheduleResponse{
+			Cron:              template.ScheduleCron,
+			LastReservationID: template.LastReservationID.Int64,
+		}
+		if template.NextRunAt.Valid {
+			response.Schedule.NextRunAt = &template.NextRunAt.Time
+		}
+		if template.LastRunAt.Valid {
+			response.Schedule.LastRunAt = &template.LastRunAt.Time
+		}
+	}
+	return response
+}
+
+func NewReservationTemplateListResponse(templates []*models.ReservationTemplate) render.Renderer {
+	list := make([]*ReservationTemplateResponse, len(templates))
+	for i, template := range templates {
+		list[i] = NewReservationTemplateResponse(template)
+	}
+	return &ReservationTemplateListResponse{Data: list}
+}
+
+// NewReservationTemplatePageResponse returns a list response with pagination metadata and links.
+func NewReservationTemplatePageResponse(r *http.Request, templates []*models.ReservationTemplate, total, limit, offset int64) render.Renderer {
+	response := NewReservationTemplateListResponse(templates).(*ReservationTemplateListResponse)
+	response.Meta, response.Links = newListPage(r, len(templates), total, limit, offset)
+	return response
+}
@@ -0,0 +1,77 @@
+package payloads
+
+import (
+	"net/http"
+
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/go-chi/render"
+)
+
+type SettingsRequest struct {
+	// Launches of more instances require an approval, zero disables approvals.
+	ApprovalThreshold int64 `json:"approval_threshold" yaml:"approval_threshold"`
+
+	// Glob patterns of instance types launches are allowed with (e.g. "t3.*"), all types are
+	// allowed when empty.
+	AllowedInstanceTypes []string `json:"allowed_instance_types" yaml:"allowed_instance_types"`
+
+	// Glob patterns of instance types launches are denied with (e.g. "*.metal"), takes precedence
+	// over allowed types.
+	DeniedInstanceTypes []string `json:"denied_instance_types" yaml:"denied_instance_types"`
+
+	// Launches of instance types with more vCPUs are denied, zero disables the limit.
+	MaxVCPUs int32 `json:"max_vcpus" yaml:"max_vcpus"`
+
+	// Only Image Builder composes and allowed images can be launched when set.
+	ComposedImagesOnly bool `json:"composed_images_only" yaml:"composed_images_only"`
+
+	// Glob patterns of image ids (e.g. "ami-0c830793775595d4b") which can be launched in addition
+	// to Image Builder composes.
+	AllowedImages []string `json:"allowed_images" yaml:"allowed_images"`
+}
+
+type SettingsResponse struct {
+	// Launches of more instances require an approval, zero disables approvals.
+	ApprovalThreshold int64 `json:"approval_threshold" yaml:"approval_threshold"`
+
+	// Glob patterns of instance types launches are allowed with, all types are allowed when empty.
+	AllowedInstanceTypes []string `json:"allowed_instance_types" yaml:"allowed_instance_types"`
+
+	// Glob patterns of instance types launches are denied with.
+	DeniedInstanceTypes []string `json:"denied_instance_types" yaml:"denied_instance_types"`
+
+	// Launches of instance types with more vCPUs are denied, zero disables the limit.
+	MaxVCPUs int32 `json:"max_vcpus" yaml:"max_vcpus"`
+
+	// Only Image Builder composes and allowed images can be launched when set.
+	ComposedImagesOnly bool `json:"composed_images_only" yaml:"composed_images_only"`
+
+	// Glob patterns of image ids which can be launched in addition to Image Builder composes.
+	AllowedImages []string `json:"allowed_images" yaml:"allowed_images"`
+}
+
+func (p *SettingsRequest) Bind(_ *http.Request) error {
+	return nil
+}
+
+func (s *SettingsResponse) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
+
+func NewSettingsResponse(settings *models.AccountSettings) render.Renderer {
+	return &SettingsResponse{
+		ApprovalThreshold:    settings.ApprovalThreshold,
+		AllowedInstanceTypes: nonNilStrings(settings.AllowedInstanceTypes),
+		DeniedInstanceTypes:  nonNilStrings(settings.DeniedInstanceTypes),
+		MaxVCPUs:             settings.MaxVCPUs,
+		ComposedImagesOnly:   settings.ComposedImagesOnly,
+		AllowedImages:        nonNilStrings(settings.AllowedImages),
+	}
+}
+
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
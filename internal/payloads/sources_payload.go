@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
 	"github.com/go-chi/render"
 )
 
@@ -13,10 +14,23 @@ type SourceResponse struct {
 	Name         string `json:"name,omitempty" yaml:"name"`
 	SourceTypeID string `json:"source_type_id" yaml:"source_type_id"`
 	Uid          string `json:"uid" yaml:"uid"`
+
+	// Provider of the source authentication, omitted when details were not requested or the
+	// authentication could not be fetched.
+	Provider string `json:"provider,omitempty" yaml:"provider"`
+}
+
+// SourceErrorResponse describes why details of a source could not be fetched.
+type SourceErrorResponse struct {
+	SourceID string `json:"source_id" yaml:"source_id"`
+	Error    string `json:"error" yaml:"error"`
 }
 
 type SourceListResponse struct {
 	Data []*SourceResponse `json:"data" yaml:"data"`
+
+	// Sources with details which could not be fetched, they are listed in data without them.
+	Errors []*SourceErrorResponse `json:"errors,omitempty" yaml:"errors"`
 }
 
 func (s *SourceResponse) Render(_ http.ResponseWriter, _ *http.Request) error {
@@ -27,17 +41,25 @@ func (s *SourceListResponse) Render(_ http.ResponseWriter, _ *http.Request) erro
 	return nil
 }
 
-func NewListSourcesResponse(sourceList []*clients.Source) render.Renderer {
-	list := make([]*SourceResponse, len(sourceList))
+// NewListSourcesResponse returns list of sources with authentication details, both slices have
+// the same length. Sources without authentication are listed with the error.
+func NewListSourcesResponse(sourceList []*clients.Source, auths []*clients.Authentication, errs []error) render.Renderer {
+	response := &SourceListResponse{Data: make([]*SourceResponse, len(sourceList))}
 	for i, source := range sourceList {
-		list[i] = &SourceResponse{
+		response.Data[i] = &SourceResponse{
 			ID:           source.ID,
 			Name:         source.Name,
 			SourceTypeID: source.SourceTypeID,
 			Uid:          source.Uid,
 		}
+		if i < len(auths) && auths[i] != nil {
+			response.Data[i].Provider = auths[i].ProviderType.String()
+		}
+		if i < len(errs) && errs[i] != nil {
+			response.Errors = append(response.Errors, &SourceErrorResponse{SourceID: source.ID, Error: errs[i].Error()})
+		}
 	}
-	return &SourceListResponse{Data: list}
+	return response
 }
 
 type SourceUploadInfoResponse struct {
@@ -50,3 +72,53 @@ type SourceUploadInfoResponse struct {
 func (s SourceUploadInfoResponse) Render(_ http.ResponseWriter, _ *http.Request) error {
 	return nil
 }
+
+type SourceSettingsRequest struct {
+	// Region used when a launch does not specify one, blank for the service default. Must be
+	// allowed when allowed regions are set.
+	DefaultRegion string `json:"default_region" yaml:"default_region"`
+
+	// Regions launches are allowed into, all regions are allowed when empty. AWS regions, Azure
+	// locations and GCP regions without availability zones.
+	AllowedRegions []string `json:"allowed_regions" yaml:"allowed_regions"`
+
+	// ARN of an SQS queue or an EventBridge event bus in the AWS account of the source receiving
+	// reservation lifecycle events, blank to stop publishing. AWS sources only, events are sent
+	// using the role of the source.
+	EventTarget string `json:"event_target" yaml:"event_target"`
+}
+
+type SourceSettingsResponse struct {
+	// Source ID.
+	SourceID string `json:"source_id" yaml:"source_id"`
+
+	// Region used when a launch does not specify one, blank for the service default.
+	DefaultRegion string `json:"default_region" yaml:"default_region"`
+
+	// Regions launches are allowed into, all regions are allowed when empty.
+	AllowedRegions []string `json:"allowed_regions" yaml:"allowed_regions"`
+
+	// ARN of an SQS queue or an EventBridge event bus receiving reservation lifecycle events.
+	EventTarget string `json:"event_target" yaml:"event_target"`
+}
+
+func (p *SourceSettingsRequest) Bind(_ *http.Request) error {
+	return nil
+}
+
+func (s *SourceSettingsResponse) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
+
+func NewSourceSettingsResponse(settings *models.SourceSettings) render.Renderer {
+	allowed := settings.AllowedRegions
+	if allowed == nil {
+		allowed = []string{}
+	}
+	return &SourceSettingsResponse{
+		SourceID:       settings.SourceID,
+		DefaultRegion:  settings.DefaultRegion,
+		AllowedRegions: allowed,
+		EventTarget:    settings.EventTarget,
+	}
+}
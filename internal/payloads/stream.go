@@ -0,0 +1,49 @@
+package payloads
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/render"
+)
+
+// streamFlushItems is the number of list items written between flushes of the response.
+const streamFlushItems = 500
+
+// StreamList writes a list response in the same format as list payloads ({"data":[...]}) but
+// converts and encodes items one by one directly into the response writer. Neither the converted
+// slice nor the whole JSON document is kept in memory, the response is flushed periodically and
+// sent with chunked transfer encoding. The status code set by render.Status is respected.
+//
+// The function returns the number of items written. When an error is returned after the first
+// item was written, the response is incomplete and the error can only be logged.
+func StreamList[T any](w http.ResponseWriter, r *http.Request, items []T, convert func(T) any) (int, error) {
+	w.Header().Set("Content-Type", "application/json")
+	if status, ok := r.Context().Value(render.StatusCtxKey).(int); ok {
+		w.WriteHeader(status)
+	}
+	flusher, _ := w.(http.Flusher)
+
+	if _, err := w.Write([]byte(`{"data":[`)); err != nil {
+		return 0, fmt.Errorf("unable to write json: %w", err)
+	}
+	enc := json.NewEncoder(w)
+	for i, item := range items {
+		if i > 0 {
+			if _, err := w.Write([]byte(",")); err != nil {
+				return i, fmt.Errorf("unable to write json: %w", err)
+			}
+		}
+		if err := enc.Encode(convert(item)); err != nil {
+			return i, fmt.Errorf("unable to encode json: %w", err)
+		}
+		if flusher != nil && (i+1)%streamFlushItems == 0 {
+			flusher.Flush()
+		}
+	}
+	if _, err := w.Write([]byte("]}\n")); err != nil {
+		return len(items), fmt.Errorf("unable to write json: %w", err)
+	}
+	return len(items), nil
+}
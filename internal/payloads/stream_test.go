@@ -0,0 +1,57 @@
+package payloads
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/go-chi/render"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamList(t *testing.T) {
+	stream := func(t *testing.T, ctx context.Context, types []*clients.InstanceType) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(ctx, "GET", "/api/provisioning/instance_types", nil)
+		require.NoError(t, err, "failed to create request")
+
+		rr := httptest.NewRecorder()
+		count, err := StreamListInstanceTypeResponse(rr, req, types)
+		require.NoError(t, err)
+		assert.Equal(t, len(types), count)
+		return rr
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		rr := stream(t, context.Background(), nil)
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"data":[]}`, rr.Body.String())
+	})
+
+	t.Run("many items", func(t *testing.T) {
+		types := make([]*clients.InstanceType, streamFlushItems*2+1)
+		for i := range types {
+			types[i] = &clients.InstanceType{Name: "t3.micro", VCPUs: int32(i)}
+		}
+		rr := stream(t, context.Background(), types)
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.True(t, rr.Flushed, "expected the response to be flushed")
+
+		var result InstanceTypeListResponse
+		err := json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		require.Len(t, result.Data, len(types))
+		assert.Equal(t, int32(streamFlushItems*2), result.Data[len(types)-1].VCPUs)
+	})
+
+	t.Run("status", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), render.StatusCtxKey, http.StatusAccepted)
+		rr := stream(t, ctx, []*clients.InstanceType{{Name: "t3.micro"}})
+		require.Equal(t, http.StatusAccepted, rr.Code)
+	})
+}
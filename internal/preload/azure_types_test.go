@@ -23,3 +23,8 @@ func TestAzureValidateRegion(t *testing.T) {
 	require.True(t, AzureInstanceType.ValidateRegion("westeurope_1"))
 	require.False(t, AzureInstanceType.ValidateRegion("centralprague_6"))
 }
+
+func TestAzureValidateZonedRegion(t *testing.T) {
+	require.True(t, AzureInstanceType.ValidateZonedRegion("westeurope", "_"))
+	require.False(t, AzureInstanceType.ValidateZonedRegion("centralprague", "_"))
+}
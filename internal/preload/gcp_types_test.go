@@ -23,3 +23,9 @@ func TestGCPValidateRegion(t *testing.T) {
 	require.True(t, GCPInstanceType.ValidateRegion("europe-west1-b"))
 	require.False(t, GCPInstanceType.ValidateRegion("velky-tynec7-b"))
 }
+
+func TestGCPValidateZonedRegion(t *testing.T) {
+	require.True(t, GCPInstanceType.ValidateZonedRegion("europe-west1", "-"))
+	require.False(t, GCPInstanceType.ValidateZonedRegion("europe", "-"))
+	require.False(t, GCPInstanceType.ValidateZonedRegion("europe-west1-b", "-"))
+}
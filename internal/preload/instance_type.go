@@ -2,6 +2,7 @@ package preload
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/RHEnVision/provisioning-backend/internal/clients"
 	"github.com/RHEnVision/provisioning-backend/internal/middleware"
@@ -87,3 +88,20 @@ func (p *instanceType) ValidateRegion(region string) bool {
 	}
 	return false
 }
+
+// ValidateZonedRegion checks if a region is preloaded for providers which preload availability
+// zones, the zone is separated from the region by the last separator of the name.
+func (p *instanceType) ValidateZonedRegion(region, separator string) bool {
+	dirEntries, err := fsTypes.ReadDir(p.path)
+	if err != nil {
+		panic(fmt.Errorf("unable to read availability dir: %w", err))
+	}
+
+	for _, e := range dirEntries {
+		name := strings.TrimSuffix(e.Name(), ".yaml")
+		if i := strings.LastIndex(name, separator); i > 0 && name[:i] == region {
+			return true
+		}
+	}
+	return false
+}
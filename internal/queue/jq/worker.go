@@ -2,6 +2,7 @@ package jq
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/RHEnVision/provisioning-backend/internal/config"
@@ -12,33 +13,66 @@ import (
 )
 
 var (
-	enqueuer worker.JobEnqueuer
+	enqueuer *worker.LimitedEnqueuer
 	workers  worker.JobWorker
 )
 
-func getEnqueuer(_ context.Context) worker.JobEnqueuer {
-	return enqueuer
+var ErrNoHandler = errors.New("no handler for job type")
+
+// handlers are used for replaying, the queue has its own handlers registered via RegisterJobs
+var handlers = map[worker.JobType]worker.JobHandler{
+	jobs.TypeNoop:                jobs.HandleNoop,
+	jobs.TypeLaunchInstanceAws:   jobs.HandleLaunchInstanceAWS,
+	jobs.TypeLaunchInstanceAzure: jobs.HandleLaunchInstanceAzure,
+	jobs.TypeLaunchInstanceGcp:   jobs.HandleLaunchInstanceGCP,
+	jobs.TypePowerInstanceAws:    jobs.HandlePowerInstanceAWS,
+	jobs.TypePowerInstanceAzure:  jobs.HandlePowerInstanceAzure,
+	jobs.TypePowerInstanceGcp:    jobs.HandlePowerInstanceGCP,
+	jobs.TypeResizeInstanceAws:   jobs.HandleResizeInstanceAWS,
+	jobs.TypeResizeInstanceAzure: jobs.HandleResizeInstanceAzure,
+	jobs.TypeResizeInstanceGcp:   jobs.HandleResizeInstanceGCP,
 }
 
-func init() {
-	queue.GetEnqueuer = getEnqueuer
+func getEnqueuer(_ context.Context) worker.JobEnqueuer {
+	return enqueuer
 }
 
 func RegisterJobs(logger *zerolog.Logger) {
 	logger.Debug().Msg("Registering job queue handlers and interfaces")
 	workers.RegisterHandler(jobs.TypeNoop, jobs.HandleNoop, jobs.NoopJobArgs{})
-	workers.RegisterHandler(jobs.TypeLaunchInstanceAws, jobs.HandleLaunchInstanceAWS, jobs.LaunchInstanceAWSTaskArgs{})
-	workers.RegisterHandler(jobs.TypeLaunchInstanceAzure, jobs.HandleLaunchInstanceAzure, jobs.LaunchInstanceAzureTaskArgs{})
-	workers.RegisterHandler(jobs.TypeLaunchInstanceGcp, jobs.HandleLaunchInstanceGCP, jobs.LaunchInstanceGCPTaskArgs{})
+	workers.RegisterHandler(jobs.TypeLaunchInstanceAws, enqueuer.WrapHandler(jobs.HandleLaunchInstanceAWS), jobs.LaunchInstanceAWSTaskArgs{})
+	workers.RegisterHandler(jobs.TypeLaunchInstanceAzure, enqueuer.WrapHandler(jobs.HandleLaunchInstanceAzure), jobs.LaunchInstanceAzureTaskArgs{})
+	workers.RegisterHandler(jobs.TypeLaunchInstanceGcp, enqueuer.WrapHandler(jobs.HandleLaunchInstanceGCP), jobs.LaunchInstanceGCPTaskArgs{})
+	workers.RegisterHandler(jobs.TypePowerInstanceAws, jobs.HandlePowerInstanceAWS, jobs.PowerInstanceTaskArgs{})
+	workers.RegisterHandler(jobs.TypePowerInstanceAzure, jobs.HandlePowerInstanceAzure, jobs.PowerInstanceTaskArgs{})
+	workers.RegisterHandler(jobs.TypePowerInstanceGcp, jobs.HandlePowerInstanceGCP, jobs.PowerInstanceTaskArgs{})
+	workers.RegisterHandler(jobs.TypeResizeInstanceAws, jobs.HandleResizeInstanceAWS, jobs.ResizeInstanceTaskArgs{})
+	workers.RegisterHandler(jobs.TypeResizeInstanceAzure, jobs.HandleResizeInstanceAzure, jobs.ResizeInstanceTaskArgs{})
+	workers.RegisterHandler(jobs.TypeResizeInstanceGcp, jobs.HandleResizeInstanceGCP, jobs.ResizeInstanceTaskArgs{})
+}
+
+// Replay executes a job synchronously in the calling goroutine. The job bypasses the queue and
+// the organization launch limit, the queue does not need to be initialized.
+func Replay(ctx context.Context, job *worker.Job) error {
+	handler, ok := handlers[job.Type]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNoHandler, job.Type)
+	}
+
+	worker.Execute(ctx, handler, job)
+	return nil
 }
 
 func Initialize(_ context.Context, logger *zerolog.Logger) error {
 	logger.Debug().Msgf("Initializing '%s' job queue", config.Worker.Queue)
 
+	var base worker.JobEnqueuer
+	var store worker.SlotStore
 	switch config.Worker.Queue {
 	case "memory":
 		wk := worker.NewMemoryClient()
-		enqueuer = wk
+		base = wk
+		store = worker.NewMemorySlotStore(config.Worker.Timeout)
 		workers = wk
 	case "redis":
 		wk, err := worker.NewRedisWorker(config.RedisHostAndPort(),
@@ -48,12 +82,21 @@ func Initialize(_ context.Context, logger *zerolog.Logger) error {
 		if err != nil {
 			return fmt.Errorf("cannot initialize redis worker queue: %w", err)
 		}
-		enqueuer = wk
+		base = wk
+		store = wk.NewSlotStore(config.Worker.Timeout)
 		workers = wk
 	default:
 		panic("unknown WORKER_QUEUE setting, expected values: memory, redis, postgres")
 	}
 
+	// launch jobs over the organization limit are kept waiting until a running launch job finishes
+	enqueuer = worker.NewLimitedEnqueuer(base, store, config.Worker.LaunchLimit, jobs.MarkWaiting,
+		jobs.TypeLaunchInstanceAws, jobs.TypeLaunchInstanceAzure, jobs.TypeLaunchInstanceGcp)
+
+	// registered here and not in init, packages importing jq only for Replay or Stats (and their
+	// tests with the stub enqueuer) must not get the uninitialized enqueuer
+	queue.GetEnqueuer = getEnqueuer
+
 	return nil
 }
 
@@ -69,6 +112,11 @@ func StopDequeueLoop(ctx context.Context) {
 	workers.Stop(ctx)
 }
 
+// ResumeWaiting enqueues waiting launch jobs of organizations whose slots expired.
+func ResumeWaiting(ctx context.Context) {
+	enqueuer.ResumeWaiting(ctx)
+}
+
 func Stats(ctx context.Context) worker.Stats {
 	stats, err := workers.Stats(ctx)
 	if err != nil {
@@ -0,0 +1,80 @@
+// Package ratelimit provides per-account fixed window rate limiting of API requests. Counters
+// are stored in the application cache, see cache.Increment.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/cache"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+)
+
+// Status is the rate limit consumption of an account in the current window.
+type Status struct {
+	// Maximum number of requests in the window
+	Limit int64
+
+	// Number of requests left in the window
+	Remaining int64
+
+	// Time until the window resets
+	Reset time.Duration
+}
+
+// SetHeaders writes X-RateLimit headers, reset is in seconds rounded up.
+func (s Status) SetHeaders(h http.Header) {
+	h.Set("X-RateLimit-Limit", strconv.FormatInt(s.Limit, 10))
+	h.Set("X-RateLimit-Remaining", strconv.FormatInt(s.Remaining, 10))
+	h.Set("X-RateLimit-Reset", strconv.FormatInt(s.ResetSeconds(), 10))
+}
+
+// ResetSeconds returns time until the window resets in seconds rounded up.
+func (s Status) ResetSeconds() int64 {
+	return int64((s.Reset + time.Second - 1) / time.Second)
+}
+
+func key(ctx context.Context) string {
+	return "ratelimit:" + strconv.FormatInt(identity.AccountId(ctx), 10)
+}
+
+func newStatus(count int64, reset time.Duration) Status {
+	remaining := config.Application.RateLimit.Requests - count
+	if remaining < 0 {
+		remaining = 0
+	}
+	if reset <= 0 {
+		reset = config.Application.RateLimit.Window
+	}
+
+	return Status{
+		Limit:     config.Application.RateLimit.Requests,
+		Remaining: remaining,
+		Reset:     reset,
+	}
+}
+
+// Take counts a request of the account from the context and returns false when the request
+// is over the limit. Account must be present in the context.
+func Take(ctx context.Context) (Status, bool, error) {
+	count, reset, err := cache.Increment(ctx, key(ctx), config.Application.RateLimit.Window)
+	if err != nil {
+		return Status{}, false, fmt.Errorf("unable to increment rate limit counter: %w", err)
+	}
+
+	return newStatus(count, reset), count <= config.Application.RateLimit.Requests, nil
+}
+
+// Current returns consumption of the account from the context without counting a request.
+func Current(ctx context.Context) (Status, error) {
+	count, reset, err := cache.Counter(ctx, key(ctx))
+	if err != nil {
+		return Status{}, fmt.Errorf("unable to read rate limit counter: %w", err)
+	}
+
+	return newStatus(count, reset), nil
+}
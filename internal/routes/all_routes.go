@@ -6,6 +6,7 @@ import (
 
 	"github.com/RHEnVision/provisioning-backend/api"
 	"github.com/RHEnVision/provisioning-backend/internal/middleware"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
 	"github.com/RHEnVision/provisioning-backend/internal/preload"
 	s "github.com/RHEnVision/provisioning-backend/internal/services"
 	"github.com/go-chi/chi/v5"
@@ -33,6 +34,7 @@ func MountRoot(r *chi.Mux) {
 
 	r.Get("/", s.WelcomeService)
 	r.Get("/ping", s.StatusService)
+	r.Get("/ready", s.ReadyService)
 	r.Route("/docs", func(r chi.Router) {
 		r.Use(redocMiddleware)
 		r.Route("/openapi.json", func(r chi.Router) {
@@ -52,12 +54,24 @@ func MountAPI(r *chi.Mux) {
 	r.Get("/azure_offering_template", s.AzureOfferingTemplate)
 	r.Options("/azure_offering_template", s.AzureOfferingTemplate)
 
+	// Current rate limit consumption, requests are not counted so clients can poll it
+	r.Group(func(r chi.Router) {
+		r.Use(render.SetContentType(render.ContentTypeJSON))
+
+		r.Use(middleware.EnforceIdentity)
+		r.Use(middleware.AccountMiddleware)
+
+		r.Get("/limits", s.GetLimits)
+	})
+
 	// Review permissions in https://github.com/RedHatInsights/rbac-config when editing this group
 	r.Group(func(r chi.Router) {
 		r.Use(render.SetContentType(render.ContentTypeJSON))
 
 		r.Use(middleware.EnforceIdentity)
 		r.Use(middleware.AccountMiddleware)
+		r.Use(middleware.AccountUsage)
+		r.Use(middleware.RateLimit)
 
 		// OpenAPI documented and supported routes
 		r.Route("/sources", func(r chi.Router) {
@@ -75,13 +89,24 @@ func MountAPI(r *chi.Mux) {
 				r.Get("/account_identity", s.GetAWSAccountIdentity)
 
 				r.Get("/launch_templates", s.ListLaunchTemplates)
+				r.Get("/subnets", s.ListSubnets)
+				r.Get("/security_groups", s.ListSecurityGroups)
+				r.Get("/dedicated_hosts", s.ListDedicatedHosts)
+				r.Get("/capacity_reservations", s.ListCapacityReservations)
+				r.Get("/images", s.ListImages)
 				r.Get("/upload_info", s.GetSourceUploadInfo)
+				r.With(middleware.EnforcePermissions("settings", "read")).Get("/settings", s.GetSourceSettings)
+				r.With(middleware.EnforcePermissions("settings", "write")).Put("/settings", s.UpdateSourceSettings)
 				r.Route("/validate_permissions", func(r chi.Router) {
 					r.Get("/", s.ValidatePermissions)
 				})
 			})
 		})
 
+		r.Route("/marketplace_offers", func(r chi.Router) {
+			r.Get("/azure", s.ListAzureMarketplaceOffers)
+		})
+
 		r.Route("/pubkeys", func(r chi.Router) {
 			r.With(middleware.EnforcePermissions("pubkey", "write")).Post("/", s.CreatePubkey)
 			r.With(middleware.EnforcePermissions("pubkey", "read")).Get("/", s.ListPubkeys)
@@ -91,8 +116,24 @@ func MountAPI(r *chi.Mux) {
 			})
 		})
 
+		r.Route("/templates", func(r chi.Router) {
+			r.With(middleware.EnforcePermissions("reservation", "write")).Post("/", s.CreateReservationTemplate)
+			r.With(middleware.EnforcePermissions("reservation", "read")).Get("/", s.ListReservationTemplates)
+			r.Route("/{ID}", func(r chi.Router) {
+				r.With(middleware.EnforcePermissions("reservation", "read")).Get("/", s.GetReservationTemplate)
+				r.With(middleware.EnforcePermissions("reservation", "write")).Delete("/", s.DeleteReservationTemplate)
+				// additional permission checks are in the service functions
+				r.With(middleware.EnforcePermissions("reservation", "write")).Post("/reservations", s.CreateReservationFromTemplate)
+				r.With(middleware.EnforcePermissions("reservation", "write")).Put("/schedule", s.ScheduleReservationTemplate)
+				r.With(middleware.EnforcePermissions("reservation", "write")).Delete("/schedule", s.UnscheduleReservationTemplate)
+			})
+		})
+
 		r.Route("/reservations", func(r chi.Router) {
 			r.With(middleware.EnforcePermissions("reservation", "read")).Get("/", s.ListReservations)
+			r.With(middleware.EnforcePermissions("reservation", "read")).Post("/status", s.ListReservationStatus)
+			r.With(middleware.EnforcePermissions("reservation", "read")).Get("/export", s.ExportReservations)
+			r.With(middleware.EnforcePermissions("reservation", "read")).Get("/labels", s.ListLabelStats)
 			// Different types do have different payloads, therefore TYPE must be part of
 			// URL and not a URL (filter) parameter.
 			r.Route("/{TYPE}", func(r chi.Router) {
@@ -102,6 +143,39 @@ func MountAPI(r *chi.Mux) {
 			})
 			// Generic reservation detail request (no details provided)
 			r.With(middleware.EnforcePermissions("reservation", "read")).Get("/{ID}", s.GetReservationDetail)
+			// Reservations deleted by the retention cleanup (additional permission checks are in the service function)
+			r.With(middleware.EnforcePermissions("reservation", "read")).Get("/{ID}/archive", s.GetReservationArchive)
+			r.With(middleware.EnforcePermissions("reservation", "read")).Get("/{ID}/archive/link", s.GetReservationArchiveLink)
+			// additional permission checks are in the service function
+			r.With(middleware.EnforcePermissions("reservation", "read")).Get("/{ID}/timeline", s.GetReservationTimeline)
+			// additional permission checks are in the service function
+			r.With(middleware.EnforcePermissions("reservation", "read")).Get("/{ID}/export", s.ExportReservation)
+			// additional permission checks are in the service function
+			r.With(middleware.EnforcePermissions("reservation", "read")).Get("/{ID}/diff/{OTHER_ID}", s.DiffReservations)
+			// additional permission checks are in the service function
+			r.With(middleware.EnforcePermissions("reservation", "write")).Put("/{ID}/labels", s.UpdateReservationLabels)
+			// Launches above the approval threshold of the organization (additional permission checks are in the service functions)
+			r.With(middleware.EnforcePermissions("reservation", "approve")).Post("/{ID}/approve", s.ApproveReservation)
+			r.With(middleware.EnforcePermissions("reservation", "approve")).Post("/{ID}/reject", s.RejectReservation)
+			// additional permission checks are in the service functions
+			r.With(middleware.EnforcePermissions("reservation", "write")).Post("/{ID}/instances/{INSTANCE_ID}:stop", s.StopInstance)
+			r.With(middleware.EnforcePermissions("reservation", "write")).Post("/{ID}/instances/{INSTANCE_ID}:start", s.StartInstance)
+			r.With(middleware.EnforcePermissions("reservation", "write")).Post("/{ID}/instances/{INSTANCE_ID}:resize", s.ResizeInstance)
+			r.With(middleware.EnforcePermissions("reservation", "read")).Get("/{ID}/instances/{INSTANCE_ID}/console", s.GetInstanceConsole)
+		})
+
+		r.Route("/account", func(r chi.Router) {
+			r.With(middleware.EnforcePermissions("reservation", "read")).Get("/usage", s.GetAccountUsage)
+		})
+
+		r.Route("/settings", func(r chi.Router) {
+			r.With(middleware.EnforcePermissions("settings", "read")).Get("/", s.GetSettings)
+			r.With(middleware.EnforcePermissions("settings", "write")).Put("/", s.UpdateSettings)
+		})
+
+		r.Route("/instances", func(r chi.Router) {
+			r.With(middleware.EnforcePermissions("reservation", "read")).Get("/", s.ListInstances)
+			r.With(middleware.EnforcePermissions("reservation", "read")).Get("/orphaned", s.ListOrphanedInstances)
 		})
 
 		// Endpoint used by sources background checker (no permissions needed)
@@ -117,10 +191,10 @@ func MountAPI(r *chi.Mux) {
 
 		// Readiness of the service.
 		r.Route("/ready", func(r chi.Router) {
-			// Returns immediately, no database connection is made
+			// Returns immediately, reports degraded database connection
 			r.Get("/", s.ReadyService)
 
-			// Connects to a remote service via HTTP client.
+			// Connects to a remote service via HTTP client or to the database.
 			r.Route("/{SRV}", func(r chi.Router) {
 				r.Get("/", s.ReadyBackendService)
 			})
@@ -132,15 +206,15 @@ func MountAPI(r *chi.Mux) {
 		r.Route("/instance_types", func(r chi.Router) {
 			r.Route("/azure", func(r chi.Router) {
 				r.Use(middleware.ETagMiddleware(preload.AzureInstanceType.ETagValue))
-				r.Get("/", s.ListBuiltinInstanceTypes(preload.AzureInstanceType.InstanceTypesForZone))
+				r.Get("/", s.ListProviderInstanceTypes(models.ProviderTypeAzure))
 			})
 			r.Route("/aws", func(r chi.Router) {
 				r.Use(middleware.ETagMiddleware(preload.EC2InstanceType.ETagValue))
-				r.Get("/", s.ListBuiltinInstanceTypes(preload.EC2InstanceType.InstanceTypesForZone))
+				r.Get("/", s.ListProviderInstanceTypes(models.ProviderTypeAWS))
 			})
 			r.Route("/gcp", func(r chi.Router) {
 				r.Use(middleware.ETagMiddleware(preload.GCPInstanceType.ETagValue))
-				r.Get("/", s.ListBuiltinInstanceTypes(preload.GCPInstanceType.InstanceTypesForZone))
+				r.Get("/", s.ListProviderInstanceTypes(models.ProviderTypeGCP))
 			})
 		})
 
@@ -153,3 +227,27 @@ func MountAPI(r *chi.Mux) {
 		})
 	})
 }
+
+// MountAdmin mounts the admin API, it must be only available on the internal (metrics) port.
+func MountAdmin(r chi.Router, token string) {
+	r.Route("/admin", func(r chi.Router) {
+		r.Use(middleware.CorrelationID)
+		// the token header is removed before it can be logged
+		r.Use(middleware.AdminToken(token))
+		r.Use(middleware.LoggerMiddleware(&log.Logger))
+		r.Use(render.SetContentType(render.ContentTypeJSON))
+
+		r.Route("/reservations", func(r chi.Router) {
+			r.Get("/", s.AdminListReservations)
+			r.Route("/{ID}", func(r chi.Router) {
+				r.Get("/", s.AdminGetReservation)
+				r.Post("/cancel", s.AdminCancelReservation)
+				r.Post("/requeue", s.AdminRequeueReservation)
+				r.Get("/support", s.AdminGetSupportBundle)
+			})
+		})
+		r.Post("/cache/flush", s.AdminFlushCache)
+		r.Get("/queue", s.AdminQueueStats)
+		r.Get("/usage", s.AdminListUsage)
+	})
+}
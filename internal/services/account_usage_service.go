@@ -0,0 +1,49 @@
+package services
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/apiusage"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/go-chi/render"
+)
+
+// MaxAccountUsageDays is the maximum number of days returned by the usage endpoints
+const MaxAccountUsageDays = 90
+
+// usageSince returns the first day of a usage report of the given number of days including today.
+func usageSince(r *http.Request, def uint) (time.Time, error) {
+	days, err := ParseUint(r.URL.Query().Get("days"), def)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if days == 0 {
+		days = 1
+	}
+	if days > MaxAccountUsageDays {
+		days = MaxAccountUsageDays
+	}
+	return apiusage.Day(time.Now()).AddDate(0, 0, 1-int(days)), nil
+}
+
+// GetAccountUsage returns daily API calls and launches of the account. Counts are flushed to the
+// database periodically, the last minutes of usage may be missing.
+func GetAccountUsage(w http.ResponseWriter, r *http.Request) {
+	since, err := usageSince(r, 30)
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse days parameter", err))
+		return
+	}
+
+	usage, err := dao.GetAccountDao(r.Context()).ListUsage(r.Context(), since)
+	if err != nil {
+		renderError(w, r, payloads.NewDAOError(r.Context(), "list account usage", err))
+		return
+	}
+
+	if err := render.Render(w, r, payloads.NewAccountUsageListResponse(usage)); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render account usage", err))
+	}
+}
@@ -0,0 +1,71 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/apiusage"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/RHEnVision/provisioning-backend/internal/services"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAccountUsageHandler(t *testing.T) {
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = identity.WithTenant(t, ctx)
+
+	today := apiusage.Day(time.Now())
+	err := dao.GetAccountDao(ctx).UnscopedAddUsage(ctx, []*models.AccountUsage{
+		{AccountID: 1, Day: today.AddDate(0, 0, -40), APICalls: 5},
+		{AccountID: 1, Day: today.AddDate(0, 0, -1), APICalls: 10, Launches: 1, Instances: 2},
+		{AccountID: 1, Day: today, APICalls: 20},
+	})
+	require.NoError(t, err)
+
+	get := func(t *testing.T, query string) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(ctx, "GET", "/api/provisioning/account/usage"+query, nil)
+		require.NoError(t, err, "failed to create request")
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(services.GetAccountUsage).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("default", func(t *testing.T) {
+		rr := get(t, "")
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.AccountUsageListResponse
+		err := json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		require.Len(t, result.Data, 2)
+		assert.Equal(t, today.Format("2006-01-02"), result.Data[0].Day)
+		assert.Equal(t, int64(20), result.Data[0].APICalls)
+		assert.Equal(t, int64(2), result.Data[1].Instances)
+	})
+
+	t.Run("today", func(t *testing.T) {
+		rr := get(t, "?days=1")
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.AccountUsageListResponse
+		err := json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		require.Len(t, result.Data, 1)
+	})
+
+	t.Run("invalid days", func(t *testing.T) {
+		rr := get(t, "?days=-1")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+}
@@ -0,0 +1,19 @@
+package services
+
+import (
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+)
+
+// addressing validates public IPv4 and IPv6 options of a launch and returns them for the
+// reservation detail. AWS does not assign public IPv4 addresses to instances launched with multiple
+// network interfaces.
+func addressing(request *payloads.AddressingRequest, nics []models.NetworkInterface) (*models.Addressing, error) {
+	if request == nil {
+		return nil, nil
+	}
+	if request.PublicIPv4 != nil && *request.PublicIPv4 && len(nics) > 1 {
+		return nil, PublicIPv4MultipleInterfacesError
+	}
+	return &models.Addressing{PublicIPv4: request.PublicIPv4, IPv6: request.IPv6}, nil
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/RHEnVision/provisioning-backend/internal/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddressing(t *testing.T) {
+	two := []models.NetworkInterface{{SubnetID: "subnet-1"}, {SubnetID: "subnet-2"}}
+
+	t.Run("defaults", func(t *testing.T) {
+		got, err := addressing(nil, two)
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("private dual stack", func(t *testing.T) {
+		got, err := addressing(&payloads.AddressingRequest{PublicIPv4: ptr.To(false), IPv6: true}, two)
+		require.NoError(t, err)
+		assert.Equal(t, &models.Addressing{PublicIPv4: ptr.To(false), IPv6: true}, got)
+		assert.Equal(t, payloads.AddressingPrivateDualStack, payloads.NewAddressingResponse(got, true).Mode)
+	})
+
+	t.Run("public with multiple interfaces", func(t *testing.T) {
+		_, err := addressing(&payloads.AddressingRequest{PublicIPv4: ptr.To(true)}, two)
+		require.ErrorIs(t, err, PublicIPv4MultipleInterfacesError)
+	})
+
+	t.Run("effective mode", func(t *testing.T) {
+		assert.Equal(t, payloads.AddressingIPv4, payloads.NewAddressingResponse(nil, true).Mode)
+		assert.Equal(t, payloads.AddressingPrivateIPv4, payloads.NewAddressingResponse(nil, false).Mode)
+		assert.Equal(t, payloads.AddressingDualStack, payloads.NewAddressingResponse(&models.Addressing{IPv6: true}, true).Mode)
+	})
+}
@@ -0,0 +1,397 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/artifacts"
+	"github.com/RHEnVision/provisioning-backend/internal/cache"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/jobs"
+	"github.com/RHEnVision/provisioning-backend/internal/logging"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/RHEnVision/provisioning-backend/internal/queue"
+	"github.com/RHEnVision/provisioning-backend/internal/queue/jq"
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog"
+)
+
+var (
+	ReservationFinishedError    = errors.New("reservation is already finished")
+	ReservationSucceededError   = errors.New("reservation finished successfully")
+	ReservationJobNotFoundError = errors.New("reservation job was not stored")
+	ReservationNotApprovedError = errors.New("reservation is pending approval or was rejected")
+	ReservationNotFinishedError = errors.New("reservation is still processing")
+)
+
+// AdminCancelMessage is the error of reservations cancelled via the admin API
+const AdminCancelMessage = "cancelled by administrator"
+
+// Maximum number of timeline events and log messages in a support bundle.
+const (
+	supportBundleMaxEvents = 1000
+	supportBundleMaxLogs   = 500
+)
+
+// The admin API is mounted on the metrics port with a pre-shared token, requests have no identity
+// and all DAO calls are unscoped. See cmd/pbctl for the client.
+
+// AdminListReservations returns reservations of all accounts, optionally only pending ones.
+func AdminListReservations(w http.ResponseWriter, r *http.Request) {
+	pending, err := ParseBool(r.URL.Query().Get("pending"))
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse pending parameter", err))
+		return
+	}
+	limit, err := ParseUint(r.URL.Query().Get("limit"), 100)
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse limit parameter", err))
+		return
+	}
+	offset, err := ParseUint(r.URL.Query().Get("offset"), 0)
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse offset parameter", err))
+		return
+	}
+
+	rDao := dao.GetReservationDao(r.Context())
+	reservations, err := rDao.UnscopedList(r.Context(), pending != nil && *pending, int64(limit), int64(offset))
+	if err != nil {
+		renderError(w, r, payloads.NewDAOError(r.Context(), "list reservations", err))
+		return
+	}
+
+	if err := render.Render(w, r, payloads.NewAdminReservationListResponse(reservations)); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render reservations list", err))
+	}
+}
+
+// AdminGetReservation returns a reservation of any account. The wait parameter holds the request
+// until the reservation changes, it is used for tailing reservation progress.
+func AdminGetReservation(w http.ResponseWriter, r *http.Request) {
+	id, err := ParseInt64(r, "ID")
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse ID parameter", err))
+		return
+	}
+	wait, err := ParseDuration(r.URL.Query().Get("wait"))
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse wait parameter", err))
+		return
+	}
+	if wait < 0 {
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "wait parameter", NegativeWaitError))
+		return
+	}
+	if wait > MaxReservationWait {
+		wait = MaxReservationWait
+	}
+
+	rDao := dao.GetReservationDao(r.Context())
+	reservation, err := rDao.UnscopedGetById(r.Context(), id)
+	if err != nil {
+		renderNotFoundOrDAOError(w, r, err, "get reservation")
+		return
+	}
+
+	if wait > 0 && !reservation.FinishedAt.Valid {
+		reservation, err = unscopedWaitForReservationUpdate(r.Context(), reservation, wait)
+		if err != nil {
+			renderNotFoundOrDAOError(w, r, err, "wait for reservation update")
+			return
+		}
+	}
+
+	if err := render.Render(w, r, payloads.NewAdminReservationResponse(reservation)); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render reservation", err))
+	}
+}
+
+// AdminCancelReservation finishes a pending reservation with an error. A job which is already
+// running is not interrupted, but its result is ignored by the user.
+func AdminCancelReservation(w http.ResponseWriter, r *http.Request) {
+	id, err := ParseInt64(r, "ID")
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse ID parameter", err))
+		return
+	}
+	r = r.WithContext(logging.WithReservationId(r.Context(), id))
+
+	rDao := dao.GetReservationDao(r.Context())
+	reservation, err := rDao.UnscopedGetById(r.Context(), id)
+	if err != nil {
+		renderNotFoundOrDAOError(w, r, err, "get reservation")
+		return
+	}
+	if reservation.FinishedAt.Valid {
+		renderError(w, r, payloads.NewConflictError(r.Context(), "cancel reservation", ReservationFinishedError))
+		return
+	}
+
+	err = rDao.FinishWithError(r.Context(), id, AdminCancelMessage)
+	if err != nil {
+		renderError(w, r, payloads.NewDAOError(r.Context(), "cancel reservation", err))
+		return
+	}
+	zerolog.Ctx(r.Context()).Warn().Msg("Reservation cancelled via admin API")
+
+	renderAdminReservation(w, r, id)
+}
+
+// AdminRequeueReservation resets a failed or stuck reservation and enqueues its stored job again.
+// Successful reservations cannot be requeued, that would launch instances again.
+func AdminRequeueReservation(w http.ResponseWriter, r *http.Request) {
+	id, err := ParseInt64(r, "ID")
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse ID parameter", err))
+		return
+	}
+	r = r.WithContext(logging.WithReservationId(r.Context(), id))
+
+	rDao := dao.GetReservationDao(r.Context())
+	reservation, err := rDao.UnscopedGetById(r.Context(), id)
+	if err != nil {
+		renderNotFoundOrDAOError(w, r, err, "get reservation")
+		return
+	}
+	if reservation.Success.Valid && reservation.Success.Bool {
+		renderError(w, r, payloads.NewConflictError(r.Context(), "requeue reservation", ReservationSucceededError))
+		return
+	}
+	// the job may still be queued or running
+	if !reservation.FinishedAt.Valid {
+		renderError(w, r, payloads.NewConflictError(r.Context(), "requeue reservation", ReservationNotFinishedError))
+		return
+	}
+	if reservation.Approval == models.ApprovalPending || reservation.Approval == models.ApprovalRejected {
+		renderError(w, r, payloads.NewConflictError(r.Context(), "requeue reservation", ReservationNotApprovedError))
+		return
+	}
+
+	stored, err := rDao.UnscopedGetJob(r.Context(), id)
+	if errors.Is(err, dao.ErrNoRows) {
+		renderError(w, r, payloads.NewNotFoundError(r.Context(), "reservation job", ReservationJobNotFoundError))
+		return
+	} else if err != nil {
+		renderError(w, r, payloads.NewDAOError(r.Context(), "get reservation job", err))
+		return
+	}
+
+	job, err := jobs.UnmarshalJob(stored.Job)
+	if err != nil {
+		renderError(w, r, payloads.NewEnqueueTaskError(r.Context(), "unable to decode stored job", err))
+		return
+	}
+
+	err = rDao.UnscopedRequeue(r.Context(), id)
+	if errors.Is(err, dao.ErrAffectedMismatch) {
+		// requeued by someone else meanwhile
+		renderError(w, r, payloads.NewConflictError(r.Context(), "requeue reservation", ReservationNotFinishedError))
+		return
+	} else if err != nil {
+		renderError(w, r, payloads.NewDAOError(r.Context(), "requeue reservation", err))
+		return
+	}
+
+	err = queue.GetEnqueuer(r.Context()).Enqueue(r.Context(), job)
+	if err != nil {
+		renderError(w, r, payloads.NewEnqueueTaskError(r.Context(), "job enqueue error", err))
+		return
+	}
+	zerolog.Ctx(logging.WithJobId(r.Context(), job.ID.String())).Warn().Msgf("Reservation job %s requeued via admin API", job.Type)
+	jobs.RecordEnqueued(r.Context(), id, job, "Job requeued by an administrator")
+
+	renderAdminReservation(w, r, id)
+}
+
+// AdminGetSupportBundle returns a downloadable bundle to attach to support tickets: the reservation,
+// its timeline, the stored job with secrets redacted, errors and log messages found by trace and
+// job IDs from the timeline. Logs are only searched when cloudwatch is enabled. With the link
+// parameter, the bundle is stored in object storage and a signed URL is returned instead.
+func AdminGetSupportBundle(w http.ResponseWriter, r *http.Request) {
+	id, err := ParseInt64(r, "ID")
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse ID parameter", err))
+		return
+	}
+	r = r.WithContext(logging.WithReservationId(r.Context(), id))
+	link, err := ParseBool(r.URL.Query().Get("link"))
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse link parameter", err))
+		return
+	}
+	if link != nil && *link && !artifacts.Enabled() {
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "support bundle link", artifacts.ErrDisabled))
+		return
+	}
+
+	rDao := dao.GetReservationDao(r.Context())
+	reservation, err := rDao.UnscopedGetById(r.Context(), id)
+	if err != nil {
+		renderNotFoundOrDAOError(w, r, err, "get reservation")
+		return
+	}
+
+	var job map[string]interface{}
+	stored, err := rDao.UnscopedGetJob(r.Context(), id)
+	if err == nil {
+		job, err = jobs.RedactJob(stored.Job)
+		if err != nil {
+			renderError(w, r, payloads.NewResponseError(r.Context(), http.StatusInternalServerError, "unable to decode stored job", err))
+			return
+		}
+	} else if !errors.Is(err, dao.ErrNoRows) {
+		renderError(w, r, payloads.NewDAOError(r.Context(), "get reservation job", err))
+		return
+	}
+
+	events, err := rDao.UnscopedListEvents(r.Context(), id, supportBundleMaxEvents)
+	if err != nil {
+		renderError(w, r, payloads.NewDAOError(r.Context(), "list reservation events", err))
+		return
+	}
+
+	terms := supportLogTerms(events)
+	end := time.Now()
+	if reservation.FinishedAt.Valid && reservation.FinishedAt.Time.Add(time.Minute).Before(end) {
+		end = reservation.FinishedAt.Time.Add(time.Minute)
+	}
+	logs, logsErr := logging.SearchLogs(r.Context(), terms, reservation.CreatedAt.Add(-time.Minute), end, supportBundleMaxLogs)
+	if logsErr != nil && !errors.Is(logsErr, logging.ErrLogSearchDisabled) {
+		zerolog.Ctx(r.Context()).Warn().Err(logsErr).Msg("Unable to search logs for support bundle")
+	}
+
+	bundle := payloads.NewAdminSupportBundleResponse(reservation, job, events, terms, logs, logsErr)
+	if link != nil && *link {
+		account, err := dao.GetAccountDao(r.Context()).GetById(r.Context(), reservation.AccountID)
+		if err != nil {
+			renderError(w, r, payloads.NewDAOError(r.Context(), "get reservation account", err))
+			return
+		}
+		renderSupportBundleLink(w, r, account.OrgID, id, bundle)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="reservation-%d-support.json"`, id))
+	if err := render.Render(w, r, bundle); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render support bundle", err))
+	}
+}
+
+// renderSupportBundleLink stores the bundle of the reservation and renders its download link.
+func renderSupportBundleLink(w http.ResponseWriter, r *http.Request, orgId string, id int64, bundle render.Renderer) {
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to encode support bundle", err))
+		return
+	}
+
+	key := artifacts.SupportBundleKey(orgId, id, time.Now())
+	link, err := artifacts.Store(r.Context(), key, "application/json", nil, data)
+	if err != nil {
+		renderError(w, r, payloads.NewClientError(r.Context(), err))
+		return
+	}
+
+	if err := render.Render(w, r, payloads.NewArtifactLinkResponse(link)); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render support bundle link", err))
+	}
+}
+
+// supportLogTerms returns unique trace and job IDs of the timeline in order of appearance.
+func supportLogTerms(events []*models.ReservationEvent) []string {
+	var result []string
+	seen := make(map[string]struct{})
+	for _, event := range events {
+		for _, key := range []string{"trace_id", "job_id"} {
+			value := event.Details[key]
+			if _, ok := seen[value]; ok || value == "" {
+				continue
+			}
+			seen[value] = struct{}{}
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// AdminFlushCache deletes all application cache entries.
+func AdminFlushCache(w http.ResponseWriter, r *http.Request) {
+	deleted, err := cache.Flush(r.Context())
+	if err != nil {
+		renderError(w, r, payloads.NewResponseError(r.Context(), http.StatusInternalServerError, "unable to flush cache", err))
+		return
+	}
+
+	if err := render.Render(w, r, payloads.NewAdminCacheFlushResponse(deleted)); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render cache flush", err))
+	}
+}
+
+// AdminQueueStats returns job queue statistics.
+func AdminQueueStats(w http.ResponseWriter, r *http.Request) {
+	stats := jq.Stats(r.Context())
+
+	if err := render.Render(w, r, payloads.NewAdminQueueStatsResponse(stats.EnqueuedJobs, stats.InFlight)); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render queue stats", err))
+	}
+}
+
+// AdminListUsage returns accounts with the most API calls in the last days.
+func AdminListUsage(w http.ResponseWriter, r *http.Request) {
+	since, err := usageSince(r, 7)
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse days parameter", err))
+		return
+	}
+	limit, err := ParseUint(r.URL.Query().Get("limit"), 20)
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse limit parameter", err))
+		return
+	}
+
+	usage, err := dao.GetAccountDao(r.Context()).UnscopedListTopUsage(r.Context(), since, int64(limit))
+	if err != nil {
+		renderError(w, r, payloads.NewDAOError(r.Context(), "list top usage", err))
+		return
+	}
+
+	if err := render.Render(w, r, payloads.NewAdminUsageListResponse(usage)); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render usage list", err))
+	}
+}
+
+func renderAdminReservation(w http.ResponseWriter, r *http.Request, id int64) {
+	reservation, err := dao.GetReservationDao(r.Context()).UnscopedGetById(r.Context(), id)
+	if err != nil {
+		renderNotFoundOrDAOError(w, r, err, "get reservation")
+		return
+	}
+
+	if err := render.Render(w, r, payloads.NewAdminReservationResponse(reservation)); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render reservation", err))
+	}
+}
+
+func unscopedWaitForReservationUpdate(ctx context.Context, reservation *models.Reservation, wait time.Duration) (*models.Reservation, error) {
+	rDao := dao.GetReservationDao(ctx)
+
+	waitCtx, cancel := context.WithTimeout(ctx, waitWithinDeadline(ctx, wait))
+	defer cancel()
+
+	err := rDao.WaitForUpdate(waitCtx, reservation)
+	if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		return nil, fmt.Errorf("unable to wait for reservation: %w", err)
+	}
+
+	reservation, err = rDao.UnscopedGetById(ctx, reservation.ID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get reservation after wait: %w", err)
+	}
+	return reservation, nil
+}
@@ -0,0 +1,191 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	clientStubs "github.com/RHEnVision/provisioning-backend/internal/clients/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/jobs"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/RHEnVision/provisioning-backend/internal/services"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/identity"
+	"github.com/RHEnVision/provisioning-backend/pkg/worker"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// adminContext creates a context without identity with a single AWS reservation
+func adminContext(t *testing.T, finished bool) context.Context {
+	return newAdminContext(t, func(reservation *models.AWSReservation) {
+		if finished {
+			reservation.FinishedAt = sql.NullTime{Time: time.Now(), Valid: true}
+			reservation.Success = sql.NullBool{Bool: true, Valid: true}
+		}
+	})
+}
+
+// failedAdminContext creates a context without identity with a single failed AWS reservation
+func failedAdminContext(t *testing.T) context.Context {
+	return newAdminContext(t, func(reservation *models.AWSReservation) {
+		reservation.FinishedAt = sql.NullTime{Time: time.Now(), Valid: true}
+		reservation.Success = sql.NullBool{Bool: false, Valid: true}
+		reservation.Error = "launch failed"
+	})
+}
+
+func newAdminContext(t *testing.T, modify func(reservation *models.AWSReservation)) context.Context {
+	ctx := stubs.WithReservationDao(context.Background())
+	reservation := &models.AWSReservation{
+		SourceID: "1",
+		ImageID:  "ami-random",
+		Detail:   &models.AWSDetail{Region: "us-east-1", InstanceType: "t1.micro", Amount: 1},
+	}
+	reservation.AccountID = 1
+	reservation.Status = "Created"
+	reservation.Provider = models.ProviderTypeAWS
+	reservation.Steps = 2
+	modify(reservation)
+	err := stubs.AddAWSReservation(ctx, reservation)
+	require.NoError(t, err, "failed to create stub reservation")
+	return ctx
+}
+
+func serveAdmin(t *testing.T, ctx context.Context, handler http.HandlerFunc, method, url string) *httptest.ResponseRecorder {
+	rctx := chi.NewRouteContext()
+	ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+	rctx.URLParams.Add("ID", "1")
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	require.NoError(t, err, "failed to create request")
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestAdminListReservations(t *testing.T) {
+	ctx := adminContext(t, false)
+	rr := serveAdmin(t, ctx, services.AdminListReservations, "GET", "/admin/reservations?pending=true")
+	require.Equal(t, http.StatusOK, rr.Code, "Wrong status code")
+
+	var response payloads.AdminReservationListResponse
+	err := json.NewDecoder(rr.Body).Decode(&response)
+	require.NoError(t, err, "failed to decode response body")
+	require.Len(t, response.Data, 1)
+	assert.Equal(t, int64(1), response.Data[0].AccountID)
+}
+
+func TestAdminCancelReservation(t *testing.T) {
+	t.Run("Pending", func(t *testing.T) {
+		ctx := adminContext(t, false)
+		rr := serveAdmin(t, ctx, services.AdminCancelReservation, "POST", "/admin/reservations/1/cancel")
+		require.Equal(t, http.StatusOK, rr.Code, "Wrong status code")
+	})
+
+	t.Run("Finished", func(t *testing.T) {
+		ctx := adminContext(t, true)
+		rr := serveAdmin(t, ctx, services.AdminCancelReservation, "POST", "/admin/reservations/1/cancel")
+		require.Equal(t, http.StatusConflict, rr.Code, "Wrong status code")
+	})
+}
+
+func TestAdminRequeueReservation(t *testing.T) {
+	t.Run("Succeeded", func(t *testing.T) {
+		ctx := adminContext(t, true)
+		rr := serveAdmin(t, ctx, services.AdminRequeueReservation, "POST", "/admin/reservations/1/requeue")
+		require.Equal(t, http.StatusConflict, rr.Code, "Wrong status code")
+	})
+
+	t.Run("Not finished", func(t *testing.T) {
+		ctx := adminContext(t, false)
+		rr := serveAdmin(t, ctx, services.AdminRequeueReservation, "POST", "/admin/reservations/1/requeue")
+		require.Equal(t, http.StatusConflict, rr.Code, "Wrong status code")
+	})
+
+	t.Run("No stored job", func(t *testing.T) {
+		ctx := failedAdminContext(t)
+		rr := serveAdmin(t, ctx, services.AdminRequeueReservation, "POST", "/admin/reservations/1/requeue")
+		require.Equal(t, http.StatusNotFound, rr.Code, "Wrong status code")
+	})
+}
+
+func TestAdminGetSupportBundle(t *testing.T) {
+	ctx := adminContext(t, false)
+	rDao := dao.GetReservationDao(ctx)
+	data, err := jobs.MarshalJob(&worker.Job{
+		Type:      jobs.TypeLaunchInstanceAws,
+		AccountID: 1,
+		Args: jobs.LaunchInstanceAWSTaskArgs{
+			ReservationID: 1,
+			Region:        "us-east-1",
+			ARN:           clients.NewAuthentication("arn:aws:iam::000000000000:role/test", models.ProviderTypeAWS),
+		},
+	})
+	require.NoError(t, err, "failed to marshal job")
+	err = rDao.CreateJob(ctx, &models.ReservationJob{ReservationID: 1, JobType: jobs.TypeLaunchInstanceAws.String(), Job: data})
+	require.NoError(t, err, "failed to store job")
+	err = rDao.CreateEvent(ctx, &models.ReservationEvent{ReservationID: 1, Kind: models.EventEnqueued, Message: "Job enqueued",
+		Details: map[string]string{"job_id": "a8e3f1d2-5b6c-4e7f-9a0b-1c2d3e4f5a6b", "trace_id": "0af7651916cd43dd8448eb211c80319c"}})
+	require.NoError(t, err, "failed to store event")
+	err = rDao.CreateEvent(ctx, &models.ReservationEvent{ReservationID: 1, Kind: models.EventProviderCall, Message: "RunInstances",
+		Details: map[string]string{"error": "UnauthorizedOperation"}})
+	require.NoError(t, err, "failed to store event")
+
+	rr := serveAdmin(t, ctx, services.AdminGetSupportBundle, "GET", "/admin/reservations/1/support")
+	require.Equal(t, http.StatusOK, rr.Code, "Wrong status code")
+	assert.Contains(t, rr.Header().Get("Content-Disposition"), "reservation-1-support.json")
+	assert.NotContains(t, rr.Body.String(), "arn:aws:iam")
+
+	var response payloads.AdminSupportBundleResponse
+	err = json.NewDecoder(rr.Body).Decode(&response)
+	require.NoError(t, err, "failed to decode response body")
+	assert.Equal(t, int64(1), response.Reservation.ID)
+	assert.Equal(t, string(jobs.TypeLaunchInstanceAws), response.Job["type"])
+	assert.Len(t, response.Timeline, 2)
+	require.Len(t, response.ProviderErrors, 1)
+	assert.Equal(t, "RunInstances", response.ProviderErrors[0].Message)
+	assert.Equal(t, []string{"0af7651916cd43dd8448eb211c80319c", "a8e3f1d2-5b6c-4e7f-9a0b-1c2d3e4f5a6b"}, response.LogTerms)
+	assert.NotEmpty(t, response.LogsError, "Cloudwatch is not enabled in tests")
+}
+
+func TestAdminGetSupportBundleLink(t *testing.T) {
+	ctx := adminContext(t, false)
+	ctx = clientStubs.WithObjectStorageClient(ctx)
+	ctx = stubs.WithAccountDaoOne(ctx)
+
+	t.Run("disabled", func(t *testing.T) {
+		rr := serveAdmin(t, ctx, services.AdminGetSupportBundle, "GET", "/admin/reservations/1/support?link=true")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Wrong status code")
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		config.Archive.Enabled = true
+		config.Archive.LinkExpiration = 15 * time.Minute
+		defer func() { config.Archive.Enabled = false }()
+
+		rr := serveAdmin(t, ctx, services.AdminGetSupportBundle, "GET", "/admin/reservations/1/support?link=true")
+		require.Equal(t, http.StatusOK, rr.Code, "Wrong status code")
+
+		var response payloads.ArtifactLinkResponse
+		err := json.NewDecoder(rr.Body).Decode(&response)
+		require.NoError(t, err, "failed to decode response body")
+		assert.Contains(t, response.URL, "X-Amz-Expires=900")
+		assert.WithinDuration(t, time.Now().Add(15*time.Minute), response.ExpiresAt, time.Minute)
+
+		keys := clientStubs.ObjectKeys(ctx)
+		require.Len(t, keys, 1)
+		assert.Contains(t, response.URL, keys[0])
+		assert.True(t, strings.HasPrefix(keys[0], "support/"+identity.DefaultOrgId+"/reservation-1-"))
+	})
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/google/uuid"
+)
+
+// ArchitectureMismatchError is returned when image architecture does not match architecture
+// of the requested instance type, it wraps ArchitectureMismatch.
+type ArchitectureMismatchError struct {
+	InstanceType      string
+	TypeArchitecture  clients.ArchitectureType
+	ImageArchitecture clients.ArchitectureType
+}
+
+func (e *ArchitectureMismatchError) Error() string {
+	return fmt.Sprintf("%s: instance type %s is %s but image is %s", ArchitectureMismatch, e.InstanceType, e.TypeArchitecture, e.ImageArchitecture)
+}
+
+func (e *ArchitectureMismatchError) Unwrap() error {
+	return ArchitectureMismatch
+}
+
+// checkArchitecture returns ArchitectureMismatchError when architectures do not match. Unknown
+// image architecture (empty string) is not checked, the cloud provider validates it on launch.
+func checkArchitecture(it *clients.InstanceType, imageArch clients.ArchitectureType) error {
+	if imageArch == "" || it.Architecture == imageArch {
+		return nil
+	}
+	return &ArchitectureMismatchError{
+		InstanceType:      it.Name.String(),
+		TypeArchitecture:  it.Architecture,
+		ImageArchitecture: imageArch,
+	}
+}
+
+// composeArchitecture returns architecture of an image builder compose, or empty string when the
+// image is not a compose (direct image name or URL).
+func composeArchitecture(ctx context.Context, imageID string) (clients.ArchitectureType, error) {
+	if _, err := uuid.Parse(imageID); err != nil {
+		return "", nil
+	}
+
+	ibClient, err := clients.GetImageBuilderClient(ctx)
+	if err != nil {
+		return "", fmt.Errorf("unable to get image builder client: %w", err)
+	}
+	return ibClient.GetImageArchitecture(ctx, imageID) //nolint:wrapcheck
+}
+
+// filterArchitecture returns instance types of the architecture, all types when it is empty.
+func filterArchitecture(types []*clients.InstanceType, arch clients.ArchitectureType) []*clients.InstanceType {
+	if arch == "" {
+		return types
+	}
+	result := make([]*clients.InstanceType, 0, len(types))
+	for _, it := range types {
+		if it.Architecture == arch {
+			result = append(result, it)
+		}
+	}
+	return result
+}
+
+// filterImageArchitecture returns images of the architecture, all images when it is empty. Images
+// of unknown architecture are kept, the architecture is not checked on launch for them either.
+func filterImageArchitecture(images []*clients.Image, arch clients.ArchitectureType) []*clients.Image {
+	if arch == "" {
+		return images
+	}
+	result := make([]*clients.Image, 0, len(images))
+	for _, image := range images {
+		if image.Architecture == "" || image.Architecture == arch {
+			result = append(result, image)
+		}
+	}
+	return result
+}
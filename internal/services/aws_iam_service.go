@@ -4,7 +4,6 @@ import (
 	"net/http"
 
 	"github.com/RHEnVision/provisioning-backend/internal/clients"
-	"github.com/RHEnVision/provisioning-backend/internal/config"
 	"github.com/RHEnVision/provisioning-backend/internal/models"
 	"github.com/RHEnVision/provisioning-backend/internal/payloads"
 	"github.com/go-chi/chi/v5"
@@ -18,7 +17,11 @@ func ValidatePermissions(w http.ResponseWriter, r *http.Request) {
 	region := r.URL.Query().Get("region")
 
 	if region == "" {
-		region = config.AWS.DefaultRegion
+		var err error
+		if region, err = sourceDefaultRegion(r.Context(), sourceId); err != nil {
+			renderError(w, r, payloads.NewDAOError(r.Context(), "get source settings", err))
+			return
+		}
 	}
 
 	// Get Sources client
@@ -36,13 +39,14 @@ func ValidatePermissions(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if !authentication.Is(models.ProviderTypeAWS) {
-		if err = render.Render(w, r, payloads.NewPermissionsResponse(nil)); err != nil {
+		if err = render.Render(w, r, payloads.NewPermissionsResponse(nil, nil)); err != nil {
 			renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render missing permissions", err))
 			return
 		}
 		return
 	}
 
+	// blank region is the default region of the partition of the source
 	ec2Client, err := clients.GetEC2Client(r.Context(), authentication, region)
 	if err != nil {
 		renderError(w, r, payloads.NewAWSError(r.Context(), "unable to get AWS EC2 client", err))
@@ -56,7 +60,13 @@ func ValidatePermissions(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := render.Render(w, r, payloads.NewPermissionsResponse(missingPermissions)); err != nil {
+	// vCPU quota is informative only, the check requires extra servicequotas:GetServiceQuota permission
+	quota, err := ec2Client.GetVCPUQuota(r.Context(), "")
+	if err != nil {
+		logger.Warn().Err(err).Msg("Unable to fetch vCPU quota")
+	}
+
+	if err := render.Render(w, r, payloads.NewPermissionsResponse(missingPermissions, quota)); err != nil {
 		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render missing permissions", err))
 		return
 	}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/jobs"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/RHEnVision/provisioning-backend/internal/preload"
+	"github.com/RHEnVision/provisioning-backend/pkg/worker"
+	"github.com/go-chi/render"
+)
+
+type awsProvider struct{}
+
+func init() {
+	RegisterProvider(models.ProviderTypeAWS, awsProvider{})
+}
+
+func (awsProvider) Enabled(_ context.Context) bool {
+	return true
+}
+
+func (awsProvider) ListInstanceTypes(region, zone string, supported *bool) ([]*clients.InstanceType, error) {
+	return preload.EC2InstanceType.InstanceTypesForZone(region, zone, supported)
+}
+
+func (awsProvider) ValidateRequest(ctx context.Context, decode RequestDecoder, _ url.Values) (render.Binder, error) {
+	payload := &payloads.AWSReservationRequest{}
+	if err := decode(payload); err != nil {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, "AWS reservation", err), err)
+	}
+
+	if payload.Hibernation {
+		types := append([]string{payload.InstanceType}, payload.FallbackInstanceTypes...)
+		if err := checkHibernationTypes(models.ProviderTypeAWS, preload.EC2InstanceType.FindInstanceType, types...); err != nil {
+			return nil, withResponse(payloads.NewInvalidRequestError(ctx, err.Error(), err), err)
+		}
+	}
+	return payload, nil
+}
+
+func (awsProvider) Launch(ctx context.Context, request render.Binder) (int64, render.Renderer, error) {
+	payload, ok := request.(*payloads.AWSReservationRequest)
+	if !ok {
+		return 0, nil, fmt.Errorf("%w: %T", UnexpectedRequestTypeError, request)
+	}
+
+	reservation, err := launchAWSReservation(ctx, payload)
+	if err != nil {
+		return 0, nil, err
+	}
+	return reservation.ID, payloads.NewAWSReservationResponse(reservation, make([]*models.ReservationInstance, 0)), nil
+}
+
+func (awsProvider) DescribeReservation(ctx context.Context, reservation *models.Reservation, instances []*models.ReservationInstance) (render.Renderer, error) {
+	detail, err := dao.GetReservationDao(ctx).GetAWSById(ctx, reservation.ID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get AWS reservation: %w", err)
+	}
+	return payloads.NewAWSReservationResponse(detail, instances), nil
+}
+
+func (awsProvider) InstanceLocation(ctx context.Context, id int64) (*ReservationLocation, error) {
+	reservation, err := dao.GetReservationDao(ctx).GetAWSById(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get AWS reservation: %w", err)
+	}
+	return &ReservationLocation{
+		SourceID:     reservation.SourceID,
+		Region:       reservation.Detail.Region,
+		InstanceType: reservation.Detail.InstanceType,
+	}, nil
+}
+
+func (awsProvider) PowerState(ctx context.Context, instance *models.Instance, auth *clients.Authentication) (models.PowerState, error) {
+	ec2Client, err := clients.GetEC2Client(ctx, auth, instance.Location)
+	if err != nil {
+		return "", fmt.Errorf("cannot create new ec2 client from config: %w", err)
+	}
+	return ec2Client.GetPowerState(ctx, instance.InstanceID)
+}
+
+func (awsProvider) PowerJobType() worker.JobType {
+	return jobs.TypePowerInstanceAws
+}
@@ -1,76 +1,221 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"regexp"
 	"strings"
 
 	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	httpClients "github.com/RHEnVision/provisioning-backend/internal/clients/http"
 	_ "github.com/RHEnVision/provisioning-backend/internal/clients/http/image_builder"
 	"github.com/RHEnVision/provisioning-backend/internal/config"
 	"github.com/RHEnVision/provisioning-backend/internal/dao"
 	"github.com/RHEnVision/provisioning-backend/internal/identity"
 	"github.com/RHEnVision/provisioning-backend/internal/jobs"
 	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/naming"
 	"github.com/RHEnVision/provisioning-backend/internal/payloads"
 	"github.com/RHEnVision/provisioning-backend/internal/preload"
-	"github.com/RHEnVision/provisioning-backend/internal/queue"
+	"github.com/RHEnVision/provisioning-backend/internal/ptr"
 	"github.com/RHEnVision/provisioning-backend/pkg/worker"
-	"github.com/go-chi/render"
 	"github.com/rs/zerolog"
+	"golang.org/x/exp/slices"
 )
 
 func CreateAWSReservation(w http.ResponseWriter, r *http.Request) {
-	logger := zerolog.Ctx(r.Context())
+	launchReservation(w, r, awsProvider{})
+}
+
+// launchAWSReservation validates the request, creates the reservation and enqueues its launch job.
+func launchAWSReservation(ctx context.Context, payload *payloads.AWSReservationRequest) (*models.AWSReservation, error) {
+	logger := zerolog.Ctx(ctx)
+
+	var accountId int64 = identity.AccountId(ctx)
+	var id identity.Principal = identity.Identity(ctx)
+
+	if labelsErr := checkLabels(payload.Labels); labelsErr != nil {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, labelsErr.Error(), labelsErr), labelsErr)
+	}
+
+	if userDataErr := checkUserData(payload.UserData); userDataErr != nil {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, userDataErr.Error(), userDataErr), userDataErr)
+	}
 
-	var accountId int64 = identity.AccountId(r.Context())
-	var id identity.Principal = identity.Identity(r.Context())
+	rDao := dao.GetReservationDao(ctx)
+	pkDao := dao.GetPubkeyDao(ctx)
 
-	payload := &payloads.AWSReservationRequest{}
-	if err := render.Bind(r, payload); err != nil {
-		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "AWS reservation", err))
-		return
+	sourceSettings, err := dao.GetAccountDao(ctx).GetSourceSettings(ctx, payload.SourceID)
+	if err != nil {
+		return nil, withResponse(payloads.NewDAOError(ctx, "get source settings", err), err)
 	}
 
-	rDao := dao.GetReservationDao(r.Context())
-	pkDao := dao.GetPubkeyDao(r.Context())
+	// Get Sources client
+	sourcesClient, err := clients.GetSourcesClient(ctx)
+	if err != nil {
+		return nil, withResponse(payloads.NewClientError(ctx, err), err)
+	}
+
+	// Fetch arn from Sources, the region defaults to the partition of the role
+	authentication, err := sourcesClient.GetAuthentication(ctx, payload.SourceID)
+	if err != nil {
+		return nil, withResponse(payloads.NewClientError(ctx, err), err)
+	}
+	if typeErr := authentication.MustBe(models.ProviderTypeAWS); typeErr != nil {
+		return nil, withResponse(payloads.NewClientError(ctx, typeErr), typeErr)
+	}
+	partition := clients.AWSPartition(authentication.Payload)
 
 	// Check for preloaded region
 	if payload.Region == "" {
-		payload.Region = "us-east-1"
+		payload.Region = sourceSettings.DefaultRegion
+	}
+	if payload.Region == "" {
+		payload.Region = clients.AWSDefaultRegion(partition)
+	}
+	if !validAWSRegion(payload.Region) {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, "Unsupported region", UnsupportedRegionError), UnsupportedRegionError)
 	}
-	if !preload.EC2InstanceType.ValidateRegion(payload.Region) {
-		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "Unsupported region", UnsupportedRegionError))
-		return
+	if clients.AWSRegionPartition(payload.Region) != partition {
+		message := fmt.Sprintf("%s: %s", RegionPartitionMismatchError.Error(), partition)
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, message, RegionPartitionMismatchError), RegionPartitionMismatchError)
+	}
+	if regionErr := checkSourceRegion(sourceSettings, payload.Region); regionErr != nil {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, regionErr.Error(), regionErr), regionErr)
 	}
 
 	// Either Launch Template or Instance Type must be set. Both can be set too, in that case, instance type overrides the launch template.
 	if payload.InstanceType == "" && payload.LaunchTemplateID == "" {
-		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "Both instance type and launch template are missing", BothTypeAndTemplateMissingError))
-		return
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, "Both instance type and launch template are missing", BothTypeAndTemplateMissingError), BothTypeAndTemplateMissingError)
+	}
+
+	if payload.LaunchTemplateID != "" && !validLaunchTemplate(payload.LaunchTemplateID) {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, InvalidLaunchTemplateError.Error(), InvalidLaunchTemplateError), InvalidLaunchTemplateError)
 	}
 
-	// Validate architecture match (hardcoded since image builder currently only supports x86_64). This can be only done
-	// when launch template is not set.
+	// Instance type must be known when launch template is not set, architecture is validated against the image later.
 	if payload.LaunchTemplateID == "" {
-		supportedArch := "x86_64"
-		it := preload.EC2InstanceType.FindInstanceType(clients.InstanceTypeName(payload.InstanceType))
-		if it == nil {
-			renderError(w, r, payloads.NewInvalidRequestError(r.Context(), fmt.Sprintf("unknown type: %s", payload.InstanceType), UnknownInstanceTypeNameError))
-			return
+		if it := preload.EC2InstanceType.FindInstanceType(clients.InstanceTypeName(payload.InstanceType)); it == nil {
+			return nil, withResponse(payloads.NewInvalidRequestError(ctx, fmt.Sprintf("unknown type: %s", payload.InstanceType), UnknownInstanceTypeNameError), UnknownInstanceTypeNameError)
 		}
-		if it.Architecture.String() != supportedArch {
-			renderError(w, r, payloads.NewWrongArchitectureUserError(r.Context(), ArchitectureMismatch))
-			return
+	}
+
+	if fallbackErr := checkFallbackInstanceTypes(payload.InstanceType, payload.FallbackInstanceTypes); fallbackErr != nil {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, fallbackErr.Error(), fallbackErr), fallbackErr)
+	}
+
+	// Types of launch templates are resolved once the source is known
+	if payload.InstanceType != "" {
+		types := append([]string{payload.InstanceType}, payload.FallbackInstanceTypes...)
+		if err := CheckInstanceTypeSettings(ctx, preload.EC2InstanceType.FindInstanceType, types...); err != nil {
+			return nil, err
 		}
 	}
 
+	if payload.InstanceProfile != "" && !validInstanceProfile(payload.InstanceProfile) {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, InvalidInstanceProfileError.Error(), InvalidInstanceProfileError), InvalidInstanceProfileError)
+	}
+
+	if spotErr := checkSpot(payload.Spot, payload.Hibernation); spotErr != nil {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, spotErr.Error(), spotErr), spotErr)
+	}
+
+	tenancy, tenancyErr := awsTenancy(payload.Tenancy, payload.HostID, payload.Spot)
+	if tenancyErr != nil {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, tenancyErr.Error(), tenancyErr), tenancyErr)
+	}
+
+	capacityReservation, crErr := awsCapacityReservation(payload.CapacityReservation, tenancy, payload.Spot, payload.FallbackInstanceTypes)
+	if crErr != nil {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, crErr.Error(), crErr), crErr)
+	}
+
+	if tagsErr := checkAWSTags(payload.Tags); tagsErr != nil {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, tagsErr.Error(), tagsErr), tagsErr)
+	}
+
+	// Hibernation stores memory on the root volume which must be encrypted
+	if payload.KMSKeyID != "" || payload.Hibernation {
+		payload.EncryptVolumes = true
+	}
+	if payload.EncryptVolumes && payload.ImageID == "" {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, EncryptionWithoutImageError.Error(), EncryptionWithoutImageError), EncryptionWithoutImageError)
+	}
+
+	volume, volumeErr := rootVolume(payload.RootVolume, payload.ImageID)
+	if volumeErr != nil {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, volumeErr.Error(), volumeErr), volumeErr)
+	}
+
+	nics, nicErr := networkInterfaces(models.ProviderTypeAWS, payload.NetworkInterfaces, int64(payload.Amount))
+	if nicErr != nil {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, nicErr.Error(), nicErr), nicErr)
+	}
+	if subnetErr := checkAWSSubnets(payload.SubnetIDs, payload.SecurityGroupIDs, nics); subnetErr != nil {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, subnetErr.Error(), subnetErr), subnetErr)
+	}
+	if len(payload.PrivateIPs) > 0 && len(nics) == 0 {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, PrivateIPsWithoutSubnetError.Error(), PrivateIPsWithoutSubnetError), PrivateIPsWithoutSubnetError)
+	}
+	if len(payload.PrivateIPs) > 0 && nics[0].PrivateIPv4 != "" {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, PrivateIPsConflictError.Error(), PrivateIPsConflictError), PrivateIPsConflictError)
+	}
+
+	addrs, addrsErr := addressing(payload.Addressing, nics)
+	if addrsErr != nil {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, addrsErr.Error(), addrsErr), addrsErr)
+	}
+
+	if eipErr := checkElasticIP(payload.ElasticIP, nics); eipErr != nil {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, eipErr.Error(), eipErr), eipErr)
+	}
+
+	if payload.DNSZone != "" && !validDNSZone(models.ProviderTypeAWS, payload.DNSZone) {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, InvalidDNSZoneError.Error(), InvalidDNSZoneError), InvalidDNSZoneError)
+	}
+
+	probeDetail, probeErr := healthProbe(payload.HealthProbe, addrs)
+	if probeErr != nil {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, probeErr.Error(), probeErr), probeErr)
+	}
+
 	detail := &models.AWSDetail{
 		Region:           payload.Region,
 		LaunchTemplateID: payload.LaunchTemplateID,
 		InstanceType:     payload.InstanceType,
 		Amount:           payload.Amount,
 		PowerOff:         payload.PowerOff,
+		InstanceProfile:  payload.InstanceProfile,
+		EncryptVolumes:   payload.EncryptVolumes,
+		KMSKeyID:         payload.KMSKeyID,
+		NitroEnclaves:    payload.NitroEnclaves,
+		Hibernation:      payload.Hibernation,
+		RootVolume:       volume,
+
+		FallbackInstanceTypes: payload.FallbackInstanceTypes,
+		NetworkInterfaces:     nics,
+		SubnetIDs:             payload.SubnetIDs,
+		SecurityGroupIDs:      payload.SecurityGroupIDs,
+		Tenancy:               tenancy,
+		HostID:                payload.HostID,
+		CapacityReservation:   capacityReservation,
+		PrivateIPs:            payload.PrivateIPs,
+		DNSZone:               payload.DNSZone,
+		Addressing:            addrs,
+		HealthProbe:           probeDetail,
+		Tags:                  payload.Tags,
+		UserData:              payload.UserData,
+	}
+	if payload.Spot != nil {
+		detail.Spot = &models.AWSSpot{
+			MaxPrice:         strings.TrimSpace(payload.Spot.MaxPrice),
+			FallbackOnDemand: payload.Spot.FallbackOnDemand,
+		}
+	}
+	if payload.ElasticIP != nil {
+		detail.ElasticIP = &models.AWSElasticIP{Pool: strings.TrimSpace(payload.ElasticIP.Pool)}
 	}
 	reservation := &models.AWSReservation{
 		PubkeyID: payload.PubkeyID,
@@ -81,46 +226,169 @@ func CreateAWSReservation(w http.ResponseWriter, r *http.Request) {
 	reservation.AccountID = accountId
 	reservation.Status = "Created"
 	reservation.Provider = models.ProviderTypeAWS
-	reservation.Steps = 3
-	reservation.StepTitles = []string{"Ensure public key", "Launch instance(s)", "Fetch instance(s) description"}
-	newName := config.Application.InstancePrefix + payload.Name
-	reservation.Detail.Name = &newName
+	reservation.Labels = payload.Labels
 
 	// validate pubkey - must be always present because of data integrity (foreign keys)
 	logger.Debug().Msgf("Validating existence of pubkey %d for this account", reservation.PubkeyID)
-	pk, err := pkDao.GetById(r.Context(), reservation.PubkeyID)
+	pk, err := pkDao.GetById(ctx, reservation.PubkeyID)
 	if err != nil {
 		message := fmt.Sprintf("get pubkey with id %d", reservation.PubkeyID)
-		renderNotFoundOrDAOError(w, r, err, message)
-		return
+		return nil, notFoundOrDAOError(ctx, err, message)
 	}
 	logger.Debug().Msgf("Found pubkey %d named '%s'", pk.ID, pk.Name)
 
-	// create reservation in the database
-	err = rDao.CreateAWS(r.Context(), reservation)
+	name, err := reservationName(ctx, payload.Name, naming.Values{
+		Provider:     models.ProviderTypeAWS.String(),
+		Region:       payload.Region,
+		InstanceType: payload.InstanceType,
+	})
 	if err != nil {
-		renderError(w, r, payloads.NewDAOError(r.Context(), "create reservation", err))
-		return
+		return nil, withResponse(payloads.NewDAOError(ctx, "generate reservation name", err), err)
 	}
-	logger.Debug().Msgf("Created a new reservation %d", reservation.ID)
+	newName := config.Application.InstancePrefix + name
+	reservation.Detail.Name = &newName
 
-	// Get Sources client
-	sourcesClient, err := clients.GetSourcesClient(r.Context())
-	if err != nil {
-		renderError(w, r, payloads.NewClientError(r.Context(), err))
-		return
+	// Launch templates define the instance type and image unless they are overridden, the policies
+	// apply to them too
+	instanceType := payload.InstanceType
+	if payload.LaunchTemplateID != "" && (payload.InstanceType == "" || reservation.ImageID == "") {
+		ec2Client, clientErr := clients.GetEC2Client(ctx, authentication, payload.Region)
+		if clientErr != nil {
+			return nil, withResponse(payloads.NewAWSError(ctx, "unable to get AWS EC2 client", clientErr), clientErr)
+		}
+		template, templateErr := ec2Client.GetLaunchTemplateVersion(ctx, payload.LaunchTemplateID)
+		if templateErr != nil {
+			return nil, withResponse(payloads.NewClientError(ctx, templateErr), templateErr)
+		}
+		if payload.InstanceType == "" {
+			if err := CheckInstanceTypeSettings(ctx, preload.EC2InstanceType.FindInstanceType, template.InstanceType); err != nil {
+				return nil, err
+			}
+			instanceType = template.InstanceType
+		}
+		if reservation.ImageID == "" {
+			if err := CheckImageSettings(ctx, template.ImageID, false); err != nil {
+				return nil, err
+			}
+		}
 	}
 
-	// Fetch arn from Sources
-	authentication, err := sourcesClient.GetAuthentication(r.Context(), payload.SourceID)
-	if err != nil {
-		renderError(w, r, payloads.NewClientError(r.Context(), err))
-		return
+	// Check vCPU quota, only possible when instance type is known (launch template can define it too).
+	// The launch job tries the fallback types too, the request is only denied when none of the types
+	// fits into its quota.
+	if instanceType != "" {
+		types := append([]string{instanceType}, payload.FallbackInstanceTypes...)
+		var quotaErr error
+		for _, name := range types {
+			name := name
+			it := preload.EC2InstanceType.FindInstanceType(clients.InstanceTypeName(name))
+			if it == nil {
+				// quota of unknown types cannot be checked
+				quotaErr = nil
+				break
+			}
+			requested := int64(it.VCPUs) * int64(payload.Amount)
+			quotaErr = CheckQuota(ctx, requested, func() (*clients.Quota, error) {
+				ec2Client, clientErr := clients.GetEC2Client(ctx, authentication, payload.Region)
+				if clientErr != nil {
+					return nil, fmt.Errorf("unable to get AWS EC2 client: %w", clientErr)
+				}
+				return ec2Client.GetVCPUQuota(ctx, name)
+			})
+			if quotaErr == nil {
+				break
+			}
+		}
+		if quotaErr != nil {
+			return nil, quotaErr
+		}
+
+		// the launch job tries the fallback types when the instance type has no capacity, the
+		// request is only denied when none of the types is likely available
+		candidates, err := preload.EC2InstanceType.InstanceTypesForZone(payload.Region, "", ptr.To(true))
+		if err != nil {
+			logger.Warn().Err(err).Msgf("Unable to list instance types in %s, no alternatives are suggested", payload.Region)
+		}
+		var capacityErr error
+		for _, name := range types {
+			name := name
+			it := preload.EC2InstanceType.FindInstanceType(clients.InstanceTypeName(name))
+			probe := &capacityProbe{provider: models.ProviderTypeAWS, region: payload.Region, instanceType: it, candidates: candidates}
+			capacityErr = checkCapacity(ctx, probe, func() (*clients.Capacity, error) {
+				ec2Client, clientErr := clients.GetEC2Client(ctx, authentication, payload.Region)
+				if clientErr != nil {
+					return nil, fmt.Errorf("unable to get AWS EC2 client: %w", clientErr)
+				}
+				return ec2Client.ProbeCapacity(ctx, name)
+			})
+			if capacityErr == nil {
+				break
+			}
+		}
+		if capacityErr != nil {
+			return nil, capacityErr
+		}
 	}
 
-	if typeErr := authentication.MustBe(models.ProviderTypeAWS); typeErr != nil {
-		renderError(w, r, payloads.NewClientError(r.Context(), typeErr))
-		return
+	// Check the role can use the KMS key, only keys which cannot be described by the role are rejected
+	// as the policy simulation does not take the key policy into account and needs an extra permission
+	if payload.KMSKeyID != "" {
+		ec2Client, clientErr := clients.GetEC2Client(ctx, authentication, payload.Region)
+		if clientErr != nil {
+			return nil, withResponse(payloads.NewAWSError(ctx, "unable to get AWS EC2 client", clientErr), clientErr)
+		}
+		denied, kmsErr := ec2Client.CheckKMSKeyAccess(ctx, authentication, payload.KMSKeyID)
+		if errors.Is(kmsErr, httpClients.KMSKeyNotFoundErr) {
+			return nil, withResponse(payloads.NewInvalidRequestError(ctx, KMSKeyNotFoundError.Error(), kmsErr), kmsErr)
+		} else if errors.Is(kmsErr, httpClients.KMSKeyAccessDeniedErr) || errors.Is(kmsErr, httpClients.KMSKeyDisabledErr) {
+			message := fmt.Sprintf("%s: %s", KMSKeyAccessDeniedError.Error(), kmsErr.Error())
+			return nil, withResponse(payloads.NewInvalidRequestError(ctx, message, kmsErr), kmsErr)
+		} else if kmsErr != nil {
+			logger.Warn().Err(kmsErr).Msg("Unable to check KMS key access, skipping the check")
+		} else if len(denied) > 0 {
+			logger.Warn().Strs("actions", denied).Msg("KMS key actions are not allowed by the role policy, they must be allowed by the key policy")
+		}
+	}
+
+	// Check the instance profile exists and its role can be passed, best effort as reading profiles
+	// and the policy simulation need extra permissions
+	if payload.InstanceProfile != "" {
+		ec2Client, clientErr := clients.GetEC2Client(ctx, authentication, payload.Region)
+		if clientErr != nil {
+			return nil, withResponse(payloads.NewAWSError(ctx, "unable to get AWS EC2 client", clientErr), clientErr)
+		}
+		denied, profileErr := ec2Client.CheckInstanceProfile(ctx, authentication, payload.InstanceProfile)
+		if errors.Is(profileErr, httpClients.InstanceProfileNotFoundErr) {
+			return nil, withResponse(payloads.NewInvalidRequestError(ctx, InstanceProfileNotFoundError.Error(), profileErr), profileErr)
+		} else if profileErr != nil {
+			logger.Warn().Err(profileErr).Msg("Unable to check instance profile, skipping the check")
+		} else if len(denied) > 0 {
+			message := fmt.Sprintf("%s: %s", InstanceProfileNotPassableError.Error(), strings.Join(denied, ", "))
+			return nil, withResponse(payloads.NewInvalidRequestError(ctx, message, InstanceProfileNotPassableError), InstanceProfileNotPassableError)
+		}
+	}
+
+	// Private IPs must be usable addresses of the primary interface subnet
+	if len(payload.PrivateIPs) > 0 {
+		ec2Client, clientErr := clients.GetEC2Client(ctx, authentication, payload.Region)
+		if clientErr != nil {
+			return nil, withResponse(payloads.NewAWSError(ctx, "unable to get AWS EC2 client", clientErr), clientErr)
+		}
+		cidr, subnetErr := ec2Client.GetSubnetCIDR(ctx, nics[0].SubnetID)
+		if subnetErr != nil {
+			return nil, withResponse(payloads.NewClientError(ctx, subnetErr), subnetErr)
+		}
+		if ipErr := checkPrivateIPs(payload.PrivateIPs, int64(payload.Amount), cidr); ipErr != nil {
+			return nil, withResponse(payloads.NewInvalidRequestError(ctx, ipErr.Error(), ipErr), ipErr)
+		}
+	}
+
+	// Images of launch templates were checked with the template
+	if reservation.ImageID != "" {
+		composed := !strings.HasPrefix(reservation.ImageID, "ami-")
+		if err := CheckImageSettings(ctx, reservation.ImageID, composed); err != nil {
+			return nil, err
+		}
 	}
 
 	var ami string
@@ -130,21 +398,67 @@ func CreateAWSReservation(w http.ResponseWriter, r *http.Request) {
 	} else {
 		// Not prefixed with "ami-" therefore this must be a valid UUID
 		// Get Image builder client
-		IBClient, ibErr := clients.GetImageBuilderClient(r.Context())
+		IBClient, ibErr := clients.GetImageBuilderClient(ctx)
 		logger.Trace().Msg("Creating IB client")
 		if ibErr != nil {
-			renderError(w, r, payloads.NewClientError(r.Context(), ibErr))
-			return
+			return nil, withResponse(payloads.NewClientError(ctx, ibErr), ibErr)
 		}
 
 		// Get AMI
-		ami, ibErr = IBClient.GetAWSAmi(r.Context(), reservation.ImageID)
+		ami, ibErr = IBClient.GetAWSAmi(ctx, reservation.ImageID)
 		if ibErr != nil {
-			renderError(w, r, payloads.NewClientError(r.Context(), ibErr))
-			return
+			return nil, withResponse(payloads.NewClientError(ctx, ibErr), ibErr)
 		}
 	}
 
+	// Validate architecture match of the AMI (both direct and image builder) and the instance type
+	if it := preload.EC2InstanceType.FindInstanceType(clients.InstanceTypeName(payload.InstanceType)); it != nil && ami != "" {
+		ec2Client, clientErr := clients.GetEC2Client(ctx, authentication, payload.Region)
+		if clientErr != nil {
+			return nil, withResponse(payloads.NewAWSError(ctx, "unable to get AWS EC2 client", clientErr), clientErr)
+		}
+		imageArch, archErr := ec2Client.GetImageArchitecture(ctx, ami)
+		if archErr != nil {
+			return nil, withResponse(payloads.NewClientError(ctx, archErr), archErr)
+		}
+		if archErr = checkArchitecture(it, imageArch); archErr != nil {
+			return nil, withResponse(payloads.NewWrongArchitectureUserError(ctx, archErr), archErr)
+		}
+	}
+
+	// Windows instances are accessed with the Administrator password which AWS encrypts with the key pair
+	if ami != "" {
+		ec2Client, clientErr := clients.GetEC2Client(ctx, authentication, payload.Region)
+		if clientErr != nil {
+			return nil, withResponse(payloads.NewAWSError(ctx, "unable to get AWS EC2 client", clientErr), clientErr)
+		}
+		windows, winErr := ec2Client.IsWindowsImage(ctx, ami)
+		if winErr != nil {
+			return nil, withResponse(payloads.NewClientError(ctx, winErr), winErr)
+		}
+		if windows && pk.Type != "ssh-rsa" {
+			return nil, withResponse(payloads.NewInvalidRequestError(ctx, WindowsPubkeyTypeError.Error(), WindowsPubkeyTypeError), WindowsPubkeyTypeError)
+		}
+		reservation.Detail.Windows = windows
+	}
+
+	titles := []string{"Ensure public key", "Launch instance(s)", "Fetch instance(s) description"}
+	if reservation.Detail.ElasticIP != nil {
+		titles = append(titles, jobs.AssociateElasticIPsStep)
+	}
+	if reservation.Detail.Windows {
+		titles = append(titles, jobs.RetrievePasswordStep)
+	}
+	reservation.StepTitles = withProbeStep(withDNSStep(titles, payload.DNSZone), probeDetail)
+	reservation.Steps = int32(len(reservation.StepTitles))
+
+	// create reservation in the database
+	err = rDao.CreateAWS(ctx, reservation)
+	if err != nil {
+		return nil, withResponse(payloads.NewDAOError(ctx, "create reservation", err), err)
+	}
+	logger.Debug().Msgf("Created a new reservation %d", reservation.ID)
+
 	launchJob := worker.Job{
 		Type:      jobs.TypeLaunchInstanceAws,
 		Identity:  id,
@@ -161,15 +475,48 @@ func CreateAWSReservation(w http.ResponseWriter, r *http.Request) {
 		},
 	}
 
-	err = queue.GetEnqueuer(r.Context()).Enqueue(r.Context(), &launchJob)
+	err = enqueueReservationJob(ctx, &reservation.Reservation, int64(reservation.Detail.Amount), &launchJob)
 	if err != nil {
-		renderError(w, r, payloads.NewEnqueueTaskError(r.Context(), "job enqueue error", err))
-		return
+		return nil, withResponse(payloads.NewEnqueueTaskError(ctx, "job enqueue error", err), err)
 	}
 
-	// Return response payload
-	unused := make([]*models.ReservationInstance, 0, 0)
-	if err := render.Render(w, r, payloads.NewAWSReservationResponse(reservation, unused)); err != nil {
-		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render AWS reservation", err))
+	return reservation, nil
+}
+
+var (
+	instanceProfileNameRegexp = regexp.MustCompile(`^[\w+=,.@-]{1,128}$`)
+	instanceProfileARNRegexp  = regexp.MustCompile(`^arn:aws[\w-]*:iam::\d{12}:instance-profile/([\w+=,.@-]+/)*[\w+=,.@-]{1,128}$`)
+)
+
+var (
+	launchTemplateIDRegexp   = regexp.MustCompile(`^lt-[0-9a-f]{8,17}$`)
+	launchTemplateNameRegexp = regexp.MustCompile(`^[\w().\-/]{3,128}$`)
+)
+
+// validLaunchTemplate checks the format of an EC2 launch template ID or name, names must not
+// start with the ID prefix. Existence of the template is checked by AWS.
+// validAWSRegion returns true for regions with preloaded availability. Availability of the GovCloud
+// and China partitions is only preloaded when typesctl has their service accounts, their known
+// regions are valid too.
+func validAWSRegion(region string) bool {
+	if preload.EC2InstanceType.ValidateRegion(region) {
+		return true
+	}
+	return slices.Contains(clients.AWSPartitionRegions, region)
+}
+
+func validLaunchTemplate(template string) bool {
+	if strings.HasPrefix(template, "lt-") {
+		return launchTemplateIDRegexp.MatchString(template)
+	}
+	return launchTemplateNameRegexp.MatchString(template)
+}
+
+// validInstanceProfile checks the format of an IAM instance profile name or ARN, existence
+// of the profile is checked via the assumed role before the reservation is created.
+func validInstanceProfile(profile string) bool {
+	if strings.HasPrefix(profile, "arn:") {
+		return instanceProfileARNRegexp.MatchString(profile)
 	}
+	return instanceProfileNameRegexp.MatchString(profile)
 }
@@ -3,27 +3,41 @@ package services_test
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	Clientstubs "github.com/RHEnVision/provisioning-backend/internal/clients/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
 	"github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
 	"github.com/RHEnVision/provisioning-backend/internal/services"
 	"github.com/RHEnVision/provisioning-backend/internal/testing/factories"
 	"github.com/RHEnVision/provisioning-backend/internal/testing/identity"
 	_ "github.com/RHEnVision/provisioning-backend/internal/testing/initialization"
+	"github.com/RHEnVision/provisioning-backend/internal/userdata"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+func init() {
+	// the region defaults to the partition of the source role
+	config.AWS.DefaultRegion = "us-east-1"
+	config.AWS.GovCloud.DefaultRegion = "us-gov-west-1"
+}
+
 func TestCreateAWSReservationHandler(t *testing.T) {
 	var json_data []byte
 	ctx := stubs.WithAccountDaoOne(context.Background())
 	ctx = identity.WithTenant(t, ctx)
 	ctx = Clientstubs.WithSourcesClient(ctx)
 	ctx = Clientstubs.WithImageBuilderClient(ctx)
+	ctx = Clientstubs.WithEC2Client(ctx)
 	ctx = stubs.WithReservationDao(ctx)
 	ctx = stubs.WithPubkeyDao(ctx)
 	pk := factories.NewPubkeyRSA()
@@ -55,6 +69,11 @@ func TestCreateAWSReservationHandler(t *testing.T) {
 
 		stubCount := stubs.AWSReservationStubCount(ctx)
 		assert.Equal(t, 1, stubCount, "Reservation has not been created through DAO")
+
+		var result payloads.AWSReservationResponse
+		err = json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		assert.Equal(t, "aws-us-east-1-001", result.Name, "Name was not generated")
 	})
 
 	t.Run("failed reservation with invalid region", func(t *testing.T) {
@@ -82,4 +101,1206 @@ func TestCreateAWSReservationHandler(t *testing.T) {
 		assert.Contains(t, rr.Body.String(), "Unsupported region")
 		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
 	})
+
+	t.Run("failed reservation with architecture mismatch", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":     "1",
+			"image_id":      "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":        1,
+			"instance_type": "a1.large",
+			"pubkey_id":     pk.ID,
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		assert.Contains(t, rr.Body.String(), "instance type a1.large is arm64 but image is x86_64")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("successful reservation with instance profile", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":        "1",
+			"image_id":         "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":           1,
+			"instance_type":    "t1.micro",
+			"pubkey_id":        pk.ID,
+			"instance_profile": "arn:aws:iam::123456789012:instance-profile/app/ssm-access",
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.AWSReservationResponse
+		err = json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		assert.Equal(t, "arn:aws:iam::123456789012:instance-profile/app/ssm-access", result.InstanceProfile)
+	})
+
+	t.Run("failed reservation with invalid instance profile", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":        "1",
+			"image_id":         "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":           1,
+			"instance_type":    "t1.micro",
+			"pubkey_id":        pk.ID,
+			"instance_profile": "arn:aws:iam::123456789012:role/ssm-access",
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		assert.Contains(t, rr.Body.String(), "invalid instance profile name or ARN")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("successful reservation with KMS key", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":     "1",
+			"image_id":      "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":        1,
+			"instance_type": "t1.micro",
+			"pubkey_id":     pk.ID,
+			"kms_key_id":    "alias/ebs-key",
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.AWSReservationResponse
+		err = json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		assert.True(t, result.EncryptVolumes, "Encryption is implied by the KMS key")
+		assert.Equal(t, "alias/ebs-key", result.KMSKeyID)
+	})
+
+	t.Run("successful reservation with KMS key allowed by key policy", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":     "1",
+			"image_id":      "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":        1,
+			"instance_type": "t1.micro",
+			"pubkey_id":     pk.ID,
+			"kms_key_id":    "alias/restricted",
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.AWSReservationResponse
+		err = json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		assert.True(t, result.EncryptVolumes, "Encryption is implied by the KMS key")
+		assert.Equal(t, "alias/restricted", result.KMSKeyID)
+	})
+
+	t.Run("failed reservation with denied KMS key", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":     "1",
+			"image_id":      "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":        1,
+			"instance_type": "t1.micro",
+			"pubkey_id":     pk.ID,
+			"kms_key_id":    "alias/denied",
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		assert.Contains(t, rr.Body.String(), "role is not allowed to use the KMS key")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("failed reservation with missing instance profile", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":        "1",
+			"image_id":         "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":           1,
+			"instance_type":    "t1.micro",
+			"pubkey_id":        pk.ID,
+			"instance_profile": "missing-profile",
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		assert.Contains(t, rr.Body.String(), "instance profile not found in AWS account")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("failed reservation with instance profile which cannot be passed", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":        "1",
+			"image_id":         "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":           1,
+			"instance_type":    "t1.micro",
+			"pubkey_id":        pk.ID,
+			"instance_profile": "unpassable-profile",
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		assert.Contains(t, rr.Body.String(), "role is not allowed to pass the instance profile role: iam:PassRole")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("successful reservation with hibernation", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":     "1",
+			"image_id":      "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":        1,
+			"instance_type": "t3.large",
+			"pubkey_id":     pk.ID,
+			"hibernation":   true,
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.AWSReservationResponse
+		err = json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		assert.True(t, result.Hibernation)
+		assert.True(t, result.EncryptVolumes, "Encryption is implied by hibernation")
+	})
+
+	t.Run("failed reservation with hibernation not supported", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":     "1",
+			"image_id":      "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":        1,
+			"instance_type": "a1.large",
+			"pubkey_id":     pk.ID,
+			"hibernation":   true,
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		assert.Contains(t, rr.Body.String(), "instance type does not support hibernation: a1.large")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("failed reservation with hibernation not supported by fallback type", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":               "1",
+			"image_id":                "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":                  1,
+			"instance_type":           "t3.large",
+			"fallback_instance_types": []string{"m5.large", "t3.xlarge", "r5.24xlarge"},
+			"pubkey_id":               pk.ID,
+			"hibernation":             true,
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		assert.Contains(t, rr.Body.String(), "instance type does not support hibernation: r5.24xlarge")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("successful reservation with network interfaces", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":     "1",
+			"image_id":      "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":        1,
+			"instance_type": "t1.micro",
+			"pubkey_id":     pk.ID,
+			"network_interfaces": []map[string]interface{}{
+				{"subnet_id": "subnet-0a1b2c3d", "security_group_ids": []string{"sg-0a1b2c3d"}},
+				{"subnet_id": "subnet-1a2b3c4d", "private_ipv4": "10.0.1.10"},
+			},
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.AWSReservationResponse
+		err = json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		require.Len(t, result.NetworkInterfaces, 2)
+		assert.Equal(t, "subnet-1a2b3c4d", result.NetworkInterfaces[1].SubnetID)
+		assert.Equal(t, "10.0.1.10", result.NetworkInterfaces[1].PrivateIPv4)
+	})
+
+	t.Run("failed reservation with private IP and multiple instances", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":     "1",
+			"image_id":      "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":        2,
+			"instance_type": "t1.micro",
+			"pubkey_id":     pk.ID,
+			"network_interfaces": []map[string]interface{}{
+				{"subnet_id": "subnet-0a1b2c3d", "private_ipv4": "10.0.1.10"},
+			},
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		assert.Contains(t, rr.Body.String(), "private IPv4 address requires amount of 1")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("failed reservation with invalid subnet", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":     "1",
+			"image_id":      "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":        1,
+			"instance_type": "t1.micro",
+			"pubkey_id":     pk.ID,
+			"network_interfaces": []map[string]interface{}{
+				{"security_group_ids": []string{"sg-0a1b2c3d"}},
+			},
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		assert.Contains(t, rr.Body.String(), "invalid subnet ID")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("successful reservation with private IPs", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":     "1",
+			"image_id":      "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":        2,
+			"instance_type": "t1.micro",
+			"pubkey_id":     pk.ID,
+			"network_interfaces": []map[string]interface{}{
+				{"subnet_id": "subnet-0a1b2c3d"},
+			},
+			"private_ips": []string{"10.0.1.10", "10.0.1.11"},
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.AWSReservationResponse
+		err = json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		assert.Equal(t, []string{"10.0.1.10", "10.0.1.11"}, result.PrivateIPs)
+	})
+
+	t.Run("failed reservation with private IP outside of the subnet", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":     "1",
+			"image_id":      "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":        1,
+			"instance_type": "t1.micro",
+			"pubkey_id":     pk.ID,
+			"network_interfaces": []map[string]interface{}{
+				{"subnet_id": "subnet-0a1b2c3d"},
+			},
+			"private_ips": []string{"192.168.1.10"},
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		assert.Contains(t, rr.Body.String(), "private IPv4 address outside of the subnet or reserved")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("successful reservation with DNS zone", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":     "1",
+			"image_id":      "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":        1,
+			"instance_type": "t1.micro",
+			"pubkey_id":     pk.ID,
+			"dns_zone":      "Z0123456789ABCDEFGHIJ",
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.AWSReservationResponse
+		err = json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		assert.Equal(t, "Z0123456789ABCDEFGHIJ", result.DNSZone)
+	})
+
+	t.Run("failed reservation with invalid DNS zone", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":     "1",
+			"image_id":      "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":        1,
+			"instance_type": "t1.micro",
+			"pubkey_id":     pk.ID,
+			"dns_zone":      "example.com",
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		assert.Contains(t, rr.Body.String(), "invalid DNS zone")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("failed reservation without capacity", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":     "1",
+			"image_id":      "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":        1,
+			"instance_type": Clientstubs.NoCapacityInstanceType,
+			"pubkey_id":     pk.ID,
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		assert.Contains(t, rr.Body.String(), "is likely unavailable in us-east-1")
+		assert.Contains(t, rr.Body.String(), "alternative types:")
+		require.Equal(t, http.StatusUnprocessableEntity, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("successful reservation with fallback instance types", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":               "1",
+			"image_id":                "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":                  1,
+			"instance_type":           Clientstubs.NoCapacityInstanceType,
+			"fallback_instance_types": []string{"t3.large"},
+			"pubkey_id":               pk.ID,
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.AWSReservationResponse
+		err = json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		assert.Equal(t, []string{"t3.large"}, result.FallbackInstanceTypes)
+	})
+
+	t.Run("successful reservation in GovCloud", func(t *testing.T) {
+		source, err := Clientstubs.AddSourceWithAuthentication(ctx, models.ProviderTypeAWS, "arn:aws-us-gov:iam::230214684733:role/Test")
+		require.NoError(t, err, "failed to add stubbed source")
+
+		create := func(t *testing.T, region string) *httptest.ResponseRecorder {
+			t.Helper()
+			values := map[string]interface{}{
+				"source_id":     source.ID,
+				"region":        region,
+				"image_id":      "ami-0c830793775595d4b",
+				"amount":        1,
+				"instance_type": "t3.small",
+				"pubkey_id":     pk.ID,
+			}
+			if json_data, err = json.Marshal(values); err != nil {
+				t.Fatalf("unable to marshal values to json: %v", err)
+			}
+
+			req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+			require.NoError(t, err, "failed to create request")
+			req.Header.Add("Content-Type", "application/json")
+
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(services.CreateAWSReservation).ServeHTTP(rr, req)
+			return rr
+		}
+
+		rr := create(t, "")
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+		var result payloads.AWSReservationResponse
+		err = json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		assert.Equal(t, "us-gov-west-1", result.Region)
+
+		rr = create(t, "us-gov-east-1")
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		rr = create(t, "us-east-1")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+		assert.Contains(t, rr.Body.String(), "aws-us-gov")
+	})
+
+	t.Run("reservation with instance type over quota and fallback in quota", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":               "1",
+			"image_id":                "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":                  1,
+			"instance_type":           "c5.12xlarge",
+			"fallback_instance_types": []string{"t3.small"},
+			"pubkey_id":               pk.ID,
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("failed reservation with all instance types over quota", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":               "1",
+			"image_id":                "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":                  1,
+			"instance_type":           "c5.12xlarge",
+			"fallback_instance_types": []string{"c5.9xlarge"},
+			"pubkey_id":               pk.ID,
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		assert.Contains(t, rr.Body.String(), "limit 32, usage 4")
+		require.Equal(t, http.StatusUnprocessableEntity, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("failed reservation with unknown fallback instance type", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":               "1",
+			"image_id":                "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":                  1,
+			"instance_type":           "t3.small",
+			"fallback_instance_types": []string{"t3.nonexisting"},
+			"pubkey_id":               pk.ID,
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		assert.Contains(t, rr.Body.String(), "unknown instance type: t3.nonexisting")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("successful reservation with Windows image", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":     "1",
+			"image_id":      Clientstubs.WindowsAMI,
+			"amount":        1,
+			"instance_type": "t3.large",
+			"pubkey_id":     pk.ID,
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.AWSReservationResponse
+		err = json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		assert.True(t, result.Windows)
+	})
+
+	t.Run("failed reservation with Windows image and ED25519 key", func(t *testing.T) {
+		var err error
+		edPk := factories.NewPubkeyED25519()
+		err = stubs.AddPubkey(ctx, edPk)
+		require.NoError(t, err, "failed to generate pubkey")
+		values := map[string]interface{}{
+			"source_id":     "1",
+			"image_id":      Clientstubs.WindowsAMI,
+			"amount":        1,
+			"instance_type": "t3.large",
+			"pubkey_id":     edPk.ID,
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		assert.Contains(t, rr.Body.String(), "windows images require an RSA public key")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+	t.Run("successful reservation with spot", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":     "1",
+			"image_id":      "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":        1,
+			"instance_type": "t1.micro",
+			"pubkey_id":     pk.ID,
+			"spot":          map[string]interface{}{"max_price": "0.05", "fallback_on_demand": true},
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.AWSReservationResponse
+		err = json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		require.NotNil(t, result.Spot)
+		assert.Equal(t, "0.05", result.Spot.MaxPrice)
+		assert.True(t, result.Spot.FallbackOnDemand)
+	})
+
+	t.Run("failed reservation with invalid spot price", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":     "1",
+			"image_id":      "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":        1,
+			"instance_type": "t1.micro",
+			"pubkey_id":     pk.ID,
+			"spot":          map[string]interface{}{"max_price": "NaN"},
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		assert.Contains(t, rr.Body.String(), "invalid spot price, expected a positive amount in USD: NaN")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+	t.Run("successful reservation with launch template name", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":          "1",
+			"amount":             1,
+			"launch_template_id": "my-template",
+			"pubkey_id":          pk.ID,
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.AWSReservationResponse
+		err = json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		assert.Equal(t, "my-template", result.LaunchTemplateID)
+	})
+
+	t.Run("failed reservation with invalid launch template", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":          "1",
+			"image_id":           "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":             1,
+			"launch_template_id": "lt-invalid",
+			"pubkey_id":          pk.ID,
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		assert.Contains(t, rr.Body.String(), "invalid launch template ID or name")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+	t.Run("successful reservation with tags", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":     "1",
+			"image_id":      "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":        1,
+			"instance_type": "t1.micro",
+			"pubkey_id":     pk.ID,
+			"tags":          map[string]string{"team": "qe", "cost-center": "1234"},
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.AWSReservationResponse
+		err = json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		assert.Equal(t, map[string]string{"team": "qe", "cost-center": "1234"}, result.Tags)
+	})
+
+	t.Run("failed reservation with reserved tag", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":     "1",
+			"image_id":      "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":        1,
+			"instance_type": "t1.micro",
+			"pubkey_id":     pk.ID,
+			"tags":          map[string]string{"aws:team": "qe"},
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		assert.Contains(t, rr.Body.String(), "invalid tag: key 'aws:team' is reserved")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("successful reservation with user data", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":     "1",
+			"image_id":      "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":        1,
+			"instance_type": "t1.micro",
+			"pubkey_id":     pk.ID,
+			"user_data":     "#cloud-config\npackages:\n- vim\n",
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.AWSReservationResponse
+		err = json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		sum := sha256.Sum256([]byte("#cloud-config\npackages:\n- vim\n"))
+		assert.Equal(t, hex.EncodeToString(sum[:]), result.UserDataSHA256)
+		assert.Equal(t, 30, result.UserDataSize)
+		assert.NotContains(t, rr.Body.String(), "vim")
+	})
+
+	t.Run("failed reservation with too large user data", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":     "1",
+			"image_id":      "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":        1,
+			"instance_type": "t1.micro",
+			"pubkey_id":     pk.ID,
+			"user_data":     strings.Repeat("x", userdata.MaxCustomSize+1),
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		assert.Contains(t, rr.Body.String(), "invalid user data")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+	t.Run("successful reservation with root volume", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":     "1",
+			"image_id":      "ami-7846387643232",
+			"amount":        1,
+			"instance_type": "t1.micro",
+			"pubkey_id":     pk.ID,
+			"root_volume":   map[string]interface{}{"size": 50, "type": "gp3", "iops": 6000},
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.AWSReservationResponse
+		err = json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		require.NotNil(t, result.RootVolume)
+		assert.Equal(t, int32(50), result.RootVolume.Size)
+		assert.Equal(t, "gp3", result.RootVolume.Type)
+	})
+
+	t.Run("failed reservation with invalid root volume", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":     "1",
+			"image_id":      "ami-7846387643232",
+			"amount":        1,
+			"instance_type": "t1.micro",
+			"pubkey_id":     pk.ID,
+			"root_volume":   map[string]interface{}{"type": "io2"},
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		assert.Contains(t, rr.Body.String(), "invalid root volume options")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("successful reservation with subnets", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":          "1",
+			"image_id":           "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":             1,
+			"instance_type":      "t1.micro",
+			"pubkey_id":          pk.ID,
+			"subnet_ids":         []string{"subnet-0d2b4f8e1a9c3b7e5", "subnet-0f3c5a9b2e8d1c4a6"},
+			"security_group_ids": []string{"sg-07a7a4c3f5d6e8b91"},
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.AWSReservationResponse
+		err = json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		assert.Equal(t, []string{"subnet-0d2b4f8e1a9c3b7e5", "subnet-0f3c5a9b2e8d1c4a6"}, result.SubnetIDs)
+		assert.Equal(t, []string{"sg-07a7a4c3f5d6e8b91"}, result.SecurityGroupIDs)
+	})
+
+	t.Run("failed reservation with subnets and network interfaces", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":          "1",
+			"image_id":           "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":             1,
+			"instance_type":      "t1.micro",
+			"pubkey_id":          pk.ID,
+			"subnet_ids":         []string{"subnet-0d2b4f8e1a9c3b7e5"},
+			"network_interfaces": []map[string]interface{}{{"subnet_id": "subnet-0f3c5a9b2e8d1c4a6"}},
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		assert.Contains(t, rr.Body.String(), "cannot be combined with network interfaces")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("successful reservation with dedicated host", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":     "1",
+			"image_id":      "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":        1,
+			"instance_type": "t1.micro",
+			"pubkey_id":     pk.ID,
+			"tenancy":       "host",
+			"host_id":       "h-0a1b2c3d4e5f67890",
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.AWSReservationResponse
+		err = json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		assert.Equal(t, "host", result.Tenancy)
+		assert.Equal(t, "h-0a1b2c3d4e5f67890", result.HostID)
+	})
+
+	t.Run("successful reservation with capacity reservation", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":            "1",
+			"image_id":             "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":               1,
+			"instance_type":        "t1.micro",
+			"pubkey_id":            pk.ID,
+			"capacity_reservation": map[string]interface{}{"id": "cr-0a1b2c3d4e5f67890"},
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.AWSReservationResponse
+		err = json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		require.NotNil(t, result.CapacityReservation)
+		assert.Equal(t, "cr-0a1b2c3d4e5f67890", result.CapacityReservation.ID)
+	})
+
+	t.Run("failed reservation with capacity reservation and spot", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":            "1",
+			"image_id":             "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":               1,
+			"instance_type":        "t1.micro",
+			"pubkey_id":            pk.ID,
+			"spot":                 map[string]interface{}{},
+			"capacity_reservation": map[string]interface{}{"id": "cr-0a1b2c3d4e5f67890"},
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		assert.Contains(t, rr.Body.String(), "cannot be combined with spot instances")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("failed reservation with host ID and dedicated tenancy", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":     "1",
+			"image_id":      "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":        1,
+			"instance_type": "t1.micro",
+			"pubkey_id":     pk.ID,
+			"tenancy":       "dedicated",
+			"host_id":       "h-0a1b2c3d4e5f67890",
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAWSReservation)
+		handler.ServeHTTP(rr, req)
+
+		assert.Contains(t, rr.Body.String(), "requires host tenancy")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
 }
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+)
+
+const (
+	// AWS allows 50 tags per resource, two of them are managed by the service
+	maxAWSTags           = 48
+	maxAWSTagKeyLength   = 128
+	maxAWSTagValueLength = 256
+)
+
+// awsTagRegexp are characters allowed in tag keys and values by all AWS services
+var awsTagRegexp = regexp.MustCompile(`^[\p{L}\p{Z}\p{N}_.:/=+\-@]*$`)
+
+// checkAWSTags returns an error when custom tags cannot be applied to EC2 resources, tags managed
+// by the service cannot be overridden.
+func checkAWSTags(tags map[string]string) error {
+	if len(tags) > maxAWSTags {
+		return fmt.Errorf("%w: at most %d allowed", TooManyTagsError, maxAWSTags)
+	}
+	for key, value := range tags {
+		if key == "" || utf8.RuneCountInString(key) > maxAWSTagKeyLength || !awsTagRegexp.MatchString(key) {
+			return fmt.Errorf("%w: key '%s' must have 1 to %d allowed characters", InvalidTagError, key, maxAWSTagKeyLength)
+		}
+		if strings.HasPrefix(strings.ToLower(key), "aws:") || key == clients.ReservationTagKey || key == "Name" {
+			return fmt.Errorf("%w: key '%s' is reserved", InvalidTagError, key)
+		}
+		if utf8.RuneCountInString(value) > maxAWSTagValueLength || !awsTagRegexp.MatchString(value) {
+			return fmt.Errorf("%w: value of '%s' must have at most %d allowed characters", InvalidTagError, key, maxAWSTagValueLength)
+		}
+	}
+	return nil
+}
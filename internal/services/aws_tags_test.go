@@ -0,0 +1,42 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckAWSTags(t *testing.T) {
+	tooMany := make(map[string]string, maxAWSTags+1)
+	for i := 0; i <= maxAWSTags; i++ {
+		tooMany[fmt.Sprintf("key-%d", i)] = "value"
+	}
+
+	tests := []struct {
+		name     string
+		tags     map[string]string
+		expected error
+	}{
+		{"none", nil, nil},
+		{"valid", map[string]string{"team": "qe", "project/app": "web server 1", "owner": "user@example.com", "empty": ""}, nil},
+		{"unicode", map[string]string{"équipe": "qualité"}, nil},
+		{"too many", tooMany, TooManyTagsError},
+		{"empty key", map[string]string{"": "value"}, InvalidTagError},
+		{"long key", map[string]string{strings.Repeat("k", maxAWSTagKeyLength+1): "value"}, InvalidTagError},
+		{"long value", map[string]string{"key": strings.Repeat("v", maxAWSTagValueLength+1)}, InvalidTagError},
+		{"invalid character", map[string]string{"key": "value;"}, InvalidTagError},
+		{"aws prefix", map[string]string{"AWS:team": "qe"}, InvalidTagError},
+		{"reservation tag", map[string]string{"rh-rid": "1"}, InvalidTagError},
+		{"name tag", map[string]string{"Name": "instance"}, InvalidTagError},
+	}
+	for _, tt := range tests {
+		err := checkAWSTags(tt.tags)
+		if tt.expected == nil {
+			assert.NoError(t, err, tt.name)
+		} else {
+			assert.ErrorIs(t, err, tt.expected, tt.name)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/go-chi/render"
+)
+
+// azureMarketplaceOffers are popular RHEL images published by Red Hat in Azure Marketplace,
+// BYOS (bring your own subscription) offers have a purchase plan with terms to accept.
+var azureMarketplaceOffers = []payloads.AzureMarketplaceOfferResponse{
+	{Publisher: "RedHat", Offer: "RHEL", SKU: "9-lvm-gen2", Description: "Red Hat Enterprise Linux 9 (LVM, Gen2), pay as you go", Architecture: string(clients.ArchitectureTypeX86_64)},
+	{Publisher: "RedHat", Offer: "RHEL", SKU: "8-lvm-gen2", Description: "Red Hat Enterprise Linux 8 (LVM, Gen2), pay as you go", Architecture: string(clients.ArchitectureTypeX86_64)},
+	{Publisher: "RedHat", Offer: "rhel-arm64", SKU: "9_2-arm64", Description: "Red Hat Enterprise Linux 9.2 for Arm64, pay as you go", Architecture: string(clients.ArchitectureTypeArm64)},
+	{Publisher: "RedHat", Offer: "RHEL-SAP-HA", SKU: "92sapha-gen2", Description: "Red Hat Enterprise Linux 9.2 for SAP with High Availability (Gen2)", Architecture: string(clients.ArchitectureTypeX86_64)},
+	{Publisher: "RedHat", Offer: "rhel-byos", SKU: "rhel-lvm92-gen2", Description: "Red Hat Enterprise Linux 9.2 (LVM, Gen2), bring your own subscription", Architecture: string(clients.ArchitectureTypeX86_64), Plan: true},
+	{Publisher: "RedHat", Offer: "rhel-byos", SKU: "rhel-lvm88-gen2", Description: "Red Hat Enterprise Linux 8.8 (LVM, Gen2), bring your own subscription", Architecture: string(clients.ArchitectureTypeX86_64), Plan: true},
+}
+
+func init() {
+	for i := range azureMarketplaceOffers {
+		offer := &azureMarketplaceOffers[i]
+		offer.URN = (&clients.AzureMarketplaceImage{Publisher: offer.Publisher, Offer: offer.Offer, SKU: offer.SKU, Version: "latest"}).URN()
+	}
+}
+
+// ListAzureMarketplaceOffers returns popular RHEL marketplace images, URNs can be used as
+// image ID of Azure reservations.
+func ListAzureMarketplaceOffers(w http.ResponseWriter, r *http.Request) {
+	if err := render.Render(w, r, payloads.NewAzureMarketplaceOfferListResponse(azureMarketplaceOffers)); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render marketplace offers list", err))
+		return
+	}
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/jobs"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/RHEnVision/provisioning-backend/internal/preload"
+	"github.com/RHEnVision/provisioning-backend/pkg/worker"
+	"github.com/go-chi/render"
+)
+
+type azureProvider struct{}
+
+func init() {
+	RegisterProvider(models.ProviderTypeAzure, azureProvider{})
+}
+
+func (azureProvider) Enabled(ctx context.Context) bool {
+	return config.FeatureEnabled(ctx, "azure")
+}
+
+func (azureProvider) ListInstanceTypes(region, zone string, supported *bool) ([]*clients.InstanceType, error) {
+	return preload.AzureInstanceType.InstanceTypesForZone(region, zone, supported)
+}
+
+func (azureProvider) ValidateRequest(ctx context.Context, decode RequestDecoder, _ url.Values) (render.Binder, error) {
+	payload := &payloads.AzureReservationRequest{}
+	if err := decode(payload); err != nil {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, "Azure reservation", err), err)
+	}
+
+	if payload.Hibernation {
+		if err := checkHibernationTypes(models.ProviderTypeAzure, preload.AzureInstanceType.FindInstanceType, payload.InstanceSize); err != nil {
+			return nil, withResponse(payloads.NewInvalidRequestError(ctx, err.Error(), err), err)
+		}
+	}
+	return payload, nil
+}
+
+func (azureProvider) Launch(ctx context.Context, request render.Binder) (int64, render.Renderer, error) {
+	payload, ok := request.(*payloads.AzureReservationRequest)
+	if !ok {
+		return 0, nil, fmt.Errorf("%w: %T", UnexpectedRequestTypeError, request)
+	}
+
+	reservation, err := launchAzureReservation(ctx, payload)
+	if err != nil {
+		return 0, nil, err
+	}
+	return reservation.ID, payloads.NewAzureReservationResponse(reservation, make([]*models.ReservationInstance, 0)), nil
+}
+
+func (azureProvider) DescribeReservation(ctx context.Context, reservation *models.Reservation, instances []*models.ReservationInstance) (render.Renderer, error) {
+	detail, err := dao.GetReservationDao(ctx).GetAzureById(ctx, reservation.ID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get Azure reservation: %w", err)
+	}
+	return payloads.NewAzureReservationResponse(detail, instances), nil
+}
+
+// InstanceLocation returns the source only, Azure instance IDs are full resource paths.
+func (azureProvider) InstanceLocation(ctx context.Context, id int64) (*ReservationLocation, error) {
+	reservation, err := dao.GetReservationDao(ctx).GetAzureById(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get Azure reservation: %w", err)
+	}
+	return &ReservationLocation{SourceID: reservation.SourceID, InstanceType: reservation.Detail.InstanceSize}, nil
+}
+
+func (azureProvider) PowerState(ctx context.Context, instance *models.Instance, auth *clients.Authentication) (models.PowerState, error) {
+	azureClient, err := clients.GetAzureClient(ctx, auth)
+	if err != nil {
+		return "", fmt.Errorf("cannot create new azure client: %w", err)
+	}
+	return azureClient.GetPowerState(ctx, instance.InstanceID)
+}
+
+func (azureProvider) PowerJobType() worker.JobType {
+	return jobs.TypePowerInstanceAzure
+}
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -11,80 +13,104 @@ import (
 	"github.com/RHEnVision/provisioning-backend/internal/identity"
 	"github.com/RHEnVision/provisioning-backend/internal/jobs"
 	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/naming"
 	"github.com/RHEnVision/provisioning-backend/internal/payloads"
 	"github.com/RHEnVision/provisioning-backend/internal/preload"
-	"github.com/RHEnVision/provisioning-backend/internal/queue"
+	"github.com/RHEnVision/provisioning-backend/internal/ptr"
 	"github.com/RHEnVision/provisioning-backend/pkg/worker"
-	"github.com/go-chi/render"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 )
 
 func CreateAzureReservation(w http.ResponseWriter, r *http.Request) {
-	logger := zerolog.Ctx(r.Context())
+	launchReservation(w, r, azureProvider{})
+}
+
+// launchAzureReservation validates the request, creates the reservation and enqueues its launch job.
+func launchAzureReservation(ctx context.Context, payload *payloads.AzureReservationRequest) (*models.AzureReservation, error) {
+	logger := zerolog.Ctx(ctx)
+
+	if labelsErr := checkLabels(payload.Labels); labelsErr != nil {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, labelsErr.Error(), labelsErr), labelsErr)
+	}
 
-	payload := &payloads.AzureReservationRequest{}
-	if err := render.Bind(r, payload); err != nil {
-		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "Azure reservation", err))
-		return
+	if userDataErr := checkUserData(payload.UserData); userDataErr != nil {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, userDataErr.Error(), userDataErr), userDataErr)
 	}
 
-	pkDao := dao.GetPubkeyDao(r.Context())
-	rDao := dao.GetReservationDao(r.Context())
+	pkDao := dao.GetPubkeyDao(ctx)
+	rDao := dao.GetReservationDao(ctx)
 
-	// Check for preloaded region
+	sourceSettings, err := dao.GetAccountDao(ctx).GetSourceSettings(ctx, payload.SourceID)
+	if err != nil {
+		return nil, withResponse(payloads.NewDAOError(ctx, "get source settings", err), err)
+	}
+
+	// Check for preloaded region, the default location of the source has no zone
+	if payload.Location == "" && sourceSettings.DefaultRegion != "" {
+		payload.Location = sourceSettings.DefaultRegion
+		if payload.Zone == "" {
+			payload.Location += "_1"
+		}
+	}
 	if payload.Location == "" {
 		payload.Location = "eastus_1"
 	}
+	if payload.Zone != "" && !strings.Contains(payload.Location, "_") {
+		payload.Location = payload.Location + "_" + payload.Zone
+	}
 	if !preload.AzureInstanceType.ValidateRegion(payload.Location) {
-		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "Unsupported location", UnsupportedRegionError))
-		return
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, "Unsupported location", UnsupportedRegionError), UnsupportedRegionError)
+	}
+	region, _, _ := strings.Cut(payload.Location, "_")
+	if regionErr := checkSourceRegion(sourceSettings, region); regionErr != nil {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, regionErr.Error(), regionErr), regionErr)
 	}
 
 	// Validate pubkey
 	logger.Debug().Msgf("Validating existence of pubkey %d for this account", payload.PubkeyID)
-	pk, err := pkDao.GetById(r.Context(), payload.PubkeyID)
+	pk, err := pkDao.GetById(ctx, payload.PubkeyID)
 	if err != nil {
 		message := fmt.Sprintf("get pubkey with id %d", payload.PubkeyID)
-		renderNotFoundOrDAOError(w, r, err, message)
-		return
+		return nil, notFoundOrDAOError(ctx, err, message)
 	}
 	logger.Debug().Msgf("Found pubkey %d named '%s'", pk.ID, pk.Name)
 
 	// Get Sources client
-	sourcesClient, err := clients.GetSourcesClient(r.Context())
+	sourcesClient, err := clients.GetSourcesClient(ctx)
 	if err != nil {
-		renderError(w, r, payloads.NewClientError(r.Context(), err))
-		return
+		return nil, withResponse(payloads.NewClientError(ctx, err), err)
 	}
 
 	// Get IB client
-	ibClient, err := clients.GetImageBuilderClient(r.Context())
+	ibClient, err := clients.GetImageBuilderClient(ctx)
 	if err != nil {
-		renderError(w, r, payloads.NewClientError(r.Context(), err))
-		return
+		return nil, withResponse(payloads.NewClientError(ctx, err), err)
 	}
 
 	// Fetch SubscriptionID from Sources
-	authentication, err := sourcesClient.GetAuthentication(r.Context(), payload.SourceID)
+	authentication, err := sourcesClient.GetAuthentication(ctx, payload.SourceID)
 	if err != nil {
-		renderError(w, r, payloads.NewClientError(r.Context(), err))
-		return
+		return nil, withResponse(payloads.NewClientError(ctx, err), err)
 	}
 
 	if typeErr := authentication.MustBe(models.ProviderTypeAzure); typeErr != nil {
-		renderError(w, r, payloads.NewClientError(r.Context(), typeErr))
-		return
+		return nil, withResponse(payloads.NewClientError(ctx, typeErr), typeErr)
 	}
 
-	var azureImageName string
 	// Azure image IDs are "free form", if it's a UUID we treat it like a compose ID
-	if _, pErr := uuid.Parse(payload.ImageID); pErr == nil {
+	_, pErr := uuid.Parse(payload.ImageID)
+	if err := CheckImageSettings(ctx, payload.ImageID, pErr == nil); err != nil {
+		return nil, err
+	}
+
+	var azureImageName string
+	var marketplaceImage *clients.AzureMarketplaceImage
+	if pErr == nil {
 		// Composer-built image
-		azureImageName, err = ibClient.GetAzureImageID(r.Context(), payload.ImageID)
+		azureImageName, err = ibClient.GetAzureImageID(ctx, payload.ImageID)
 		if err != nil {
-			renderError(w, r, payloads.NewClientError(r.Context(), err))
-			return
+			return nil, withResponse(payloads.NewClientError(ctx, err), err)
 		}
 		azureImageName = fmt.Sprintf("/subscriptions/%s%s", authentication.Payload, azureImageName)
 	} else {
@@ -92,30 +118,134 @@ func CreateAzureReservation(w http.ResponseWriter, r *http.Request) {
 		// Assumes 'redhat-deployed' resource group.
 		if strings.HasPrefix(payload.ImageID, "composer-api") {
 			azureImageName = fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Compute/images/%s", authentication.Payload, "redhat-deployed", payload.ImageID)
+		} else if image, ok := clients.ParseAzureImageURN(payload.ImageID); ok {
+			// Marketplace image URN, the purchase plan terms are accepted by the launch job
+			marketplaceImage = image
+			azureImageName = image.URN()
+		} else if strings.Contains(payload.ImageID, ":") {
+			return nil, withResponse(payloads.NewInvalidRequestError(ctx, InvalidAzureImageURNError.Error(), InvalidAzureImageURNError), InvalidAzureImageURNError)
 		} else {
 			// Anything else is treated like a direct Azure image ID (e.g. from https://imagedirectory.cloud)
 			azureImageName = payload.ImageID
 		}
 	}
 
-	supportedArch := "x86_64"
 	it := preload.AzureInstanceType.FindInstanceType(clients.InstanceTypeName(payload.InstanceSize))
 	if it == nil {
-		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), fmt.Sprintf("unknown instance size: %s", payload.InstanceSize), UnknownInstanceTypeNameError))
-		return
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, fmt.Sprintf("unknown instance size: %s", payload.InstanceSize), UnknownInstanceTypeNameError), UnknownInstanceTypeNameError)
+	}
+	if err := CheckInstanceTypeSettings(ctx, preload.AzureInstanceType.FindInstanceType, payload.InstanceSize); err != nil {
+		return nil, err
+	}
+	imageArch, err := composeArchitecture(ctx, payload.ImageID)
+	if err != nil {
+		return nil, withResponse(payloads.NewClientError(ctx, err), err)
+	}
+	if marketplaceImage != nil {
+		azureClient, clientErr := clients.GetAzureClient(ctx, authentication)
+		if clientErr != nil {
+			return nil, withResponse(payloads.NewAzureError(ctx, "unable to get Azure client", clientErr), clientErr)
+		}
+		location, _, _ := strings.Cut(payload.Location, "_")
+		described, imageErr := azureClient.DescribeMarketplaceImage(ctx, location, marketplaceImage)
+		if errors.Is(imageErr, clients.NotFoundErr) {
+			return nil, withResponse(payloads.NewInvalidRequestError(ctx, imageErr.Error(), UnknownMarketplaceImageError), UnknownMarketplaceImageError)
+		} else if imageErr != nil {
+			return nil, withResponse(payloads.NewAzureError(ctx, "unable to describe Azure marketplace image", imageErr), imageErr)
+		}
+		imageArch = described.Architecture
+	}
+	if err = checkArchitecture(it, imageArch); err != nil {
+		return nil, withResponse(payloads.NewWrongArchitectureUserError(ctx, err), err)
+	}
+
+	if payload.Zone != "" {
+		if zoneErr := checkAzureZone(payload.Location, payload.Zone, it.Name); zoneErr != nil {
+			return nil, withResponse(payloads.NewInvalidRequestError(ctx, zoneErr.Error(), zoneErr), zoneErr)
+		}
+	}
+	if payload.ProximityPlacementGroup != "" && !validProximityPlacementGroupID(payload.ProximityPlacementGroup) {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, InvalidProximityPlacementGroupError.Error(), InvalidProximityPlacementGroupError), InvalidProximityPlacementGroupError)
+	}
+	nics, nicErr := networkInterfaces(models.ProviderTypeAzure, payload.NetworkInterfaces, payload.Amount)
+	if nicErr != nil {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, nicErr.Error(), nicErr), nicErr)
+	}
+	if len(payload.PrivateIPs) > 0 {
+		if ipErr := checkPrivateIPs(payload.PrivateIPs, payload.Amount, clients.AzureSubnetAddressPrefix); ipErr != nil {
+			return nil, withResponse(payloads.NewInvalidRequestError(ctx, ipErr.Error(), ipErr), ipErr)
+		}
 	}
-	if it.Architecture.String() != supportedArch {
-		renderError(w, r, payloads.NewWrongArchitectureUserError(r.Context(), ArchitectureMismatch))
-		return
+	if payload.DNSZone != "" && !validDNSZone(models.ProviderTypeAzure, payload.DNSZone) {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, InvalidDNSZoneError.Error(), InvalidDNSZoneError), InvalidDNSZoneError)
 	}
 
-	name := config.Application.InstancePrefix + payload.Name
+	probeDetail, probeErr := healthProbe(payload.HealthProbe, nil)
+	if probeErr != nil {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, probeErr.Error(), probeErr), probeErr)
+	}
+	securityType := models.AzureSecurityType(payload.SecurityType)
+	if securityErr := checkAzureSecurityType(securityType, payload.SecureBoot, payload.VTPM, it.Name); securityErr != nil {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, securityErr.Error(), securityErr), securityErr)
+	}
+
+	// Check vCPU quota, location can contain availability zone suffix
+	requested := int64(it.VCPUs) * payload.Amount
+	quotaErr := CheckQuota(ctx, requested, func() (*clients.Quota, error) {
+		azureClient, clientErr := clients.GetAzureClient(ctx, authentication)
+		if clientErr != nil {
+			return nil, fmt.Errorf("unable to get Azure client: %w", clientErr)
+		}
+		location, _, _ := strings.Cut(payload.Location, "_")
+		return azureClient.GetVCPUQuota(ctx, location, payload.InstanceSize)
+	})
+	if quotaErr != nil {
+		return nil, quotaErr
+	}
+
+	region, locationZone, _ := strings.Cut(payload.Location, "_")
+	candidates, err := preload.AzureInstanceType.InstanceTypesForZone(region, locationZone, ptr.To(true))
+	if err != nil {
+		logger.Warn().Err(err).Msgf("Unable to list instance types in %s, no alternatives are suggested", payload.Location)
+	}
+	probe := &capacityProbe{provider: models.ProviderTypeAzure, region: region, zone: payload.Zone, instanceType: it, candidates: candidates}
+	capacityErr := checkCapacity(ctx, probe, func() (*clients.Capacity, error) {
+		azureClient, clientErr := clients.GetAzureClient(ctx, authentication)
+		if clientErr != nil {
+			return nil, fmt.Errorf("unable to get Azure client: %w", clientErr)
+		}
+		return azureClient.ProbeCapacity(ctx, region, payload.InstanceSize)
+	})
+	if capacityErr != nil {
+		return nil, capacityErr
+	}
+
+	name, err := reservationName(ctx, payload.Name, naming.Values{
+		Provider:     models.ProviderTypeAzure.String(),
+		Region:       payload.Location,
+		InstanceType: payload.InstanceSize,
+	})
+	if err != nil {
+		return nil, withResponse(payloads.NewDAOError(ctx, "generate reservation name", err), err)
+	}
 	detail := &models.AzureDetail{
 		Location:     payload.Location,
 		InstanceSize: payload.InstanceSize,
 		Amount:       payload.Amount,
 		PowerOff:     payload.PowerOff,
-		Name:         name,
+		Name:         config.Application.InstancePrefix + name,
+
+		Zone:                    payload.Zone,
+		ProximityPlacementGroup: payload.ProximityPlacementGroup,
+		SecurityType:            securityType,
+		SecureBoot:              payload.SecureBoot,
+		VTPM:                    payload.VTPM || securityType == models.AzureSecurityTypeConfidentialVM,
+		Hibernation:             payload.Hibernation,
+		NetworkInterfaces:       nics,
+		PrivateIPs:              payload.PrivateIPs,
+		DNSZone:                 payload.DNSZone,
+		HealthProbe:             probeDetail,
+		UserData:                payload.UserData,
 	}
 	reservation := &models.AzureReservation{
 		PubkeyID: payload.PubkeyID,
@@ -123,21 +253,21 @@ func CreateAzureReservation(w http.ResponseWriter, r *http.Request) {
 		ImageID:  payload.ImageID,
 		Detail:   detail,
 	}
-	reservation.Steps = int32(len(jobs.LaunchInstanceAzureSteps))
-	reservation.StepTitles = jobs.LaunchInstanceAzureSteps
+	reservation.StepTitles = withProbeStep(withDNSStep(jobs.LaunchInstanceAzureSteps, payload.DNSZone), probeDetail)
+	reservation.Labels = payload.Labels
+	reservation.Steps = int32(len(reservation.StepTitles))
 
 	// create reservation in the database
-	err = rDao.CreateAzure(r.Context(), reservation)
+	err = rDao.CreateAzure(ctx, reservation)
 	if err != nil {
-		renderError(w, r, payloads.NewDAOError(r.Context(), "create Azure reservation", err))
-		return
+		return nil, withResponse(payloads.NewDAOError(ctx, "create Azure reservation", err), err)
 	}
 	logger.Debug().Msgf("Created a new reservation %d", reservation.ID)
 
 	launchJob := worker.Job{
 		Type:      jobs.TypeLaunchInstanceAzure,
-		Identity:  identity.Identity(r.Context()),
-		AccountID: identity.AccountId(r.Context()),
+		Identity:  identity.Identity(ctx),
+		AccountID: identity.AccountId(ctx),
 		Args: jobs.LaunchInstanceAzureTaskArgs{
 			ReservationID: reservation.ID,
 			Location:      reservation.Detail.Location,
@@ -148,15 +278,58 @@ func CreateAzureReservation(w http.ResponseWriter, r *http.Request) {
 		},
 	}
 
-	err = queue.GetEnqueuer(r.Context()).Enqueue(r.Context(), &launchJob)
+	err = enqueueReservationJob(ctx, &reservation.Reservation, reservation.Detail.Amount, &launchJob)
 	if err != nil {
-		renderError(w, r, payloads.NewEnqueueTaskError(r.Context(), "job enqueue error", err))
-		return
+		return nil, withResponse(payloads.NewEnqueueTaskError(ctx, "job enqueue error", err), err)
 	}
 
-	// Return response payload
-	unused := make([]*models.ReservationInstance, 0, 0)
-	if err = render.Render(w, r, payloads.NewAzureReservationResponse(reservation, unused)); err != nil {
-		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render Azure reservation", err))
+	return reservation, nil
+}
+
+// checkAzureZone validates the availability zone against the location and the types-per-zone data.
+func checkAzureZone(location, zone string, instanceSize clients.InstanceTypeName) error {
+	region, locationZone, _ := strings.Cut(location, "_")
+	if locationZone != "" && locationZone != zone {
+		return fmt.Errorf("%w: location %s and zone %s", ZoneMismatchError, location, zone)
+	}
+
+	types, err := preload.AzureInstanceType.InstanceTypesForZone(region, zone, nil)
+	if err != nil {
+		return fmt.Errorf("%w: %s zone %s", UnsupportedRegionError, region, zone)
+	}
+	for _, t := range types {
+		if t.Name == instanceSize {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s in %s zone %s", InstanceTypeNotInZoneError, instanceSize, region, zone)
+}
+
+// validProximityPlacementGroupID checks the format of the resource ID, existence is checked
+// by Azure when the instances are created.
+func validProximityPlacementGroupID(id string) bool {
+	lower := strings.ToLower(id)
+	return strings.HasPrefix(lower, "/subscriptions/") &&
+		strings.Contains(lower, "/resourcegroups/") &&
+		strings.Contains(lower, "/providers/microsoft.compute/proximityplacementgroups/")
+}
+
+// checkAzureSecurityType validates the security type and its UEFI settings, confidential VMs
+// are only available in the DC and EC series.
+func checkAzureSecurityType(securityType models.AzureSecurityType, secureBoot, vtpm bool, instanceSize clients.InstanceTypeName) error {
+	switch securityType {
+	case models.AzureSecurityTypeStandard:
+		if secureBoot || vtpm {
+			return SecurityTypeRequiredError
+		}
+	case models.AzureSecurityTypeTrustedLaunch:
+	case models.AzureSecurityTypeConfidentialVM:
+		size := string(instanceSize)
+		if !strings.HasPrefix(size, "Standard_DC") && !strings.HasPrefix(size, "Standard_EC") {
+			return fmt.Errorf("%w: %s", ConfidentialSizeRequiredError, size)
+		}
+	default:
+		return fmt.Errorf("%w: %s", UnknownSecurityTypeError, securityType)
 	}
+	return nil
 }
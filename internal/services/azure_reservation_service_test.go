@@ -27,6 +27,7 @@ func TestCreateAzureReservationHandler(t *testing.T) {
 	ctx = identity.WithTenant(t, ctx)
 	ctx = Clientstubs.WithSourcesClient(ctx)
 	ctx = Clientstubs.WithImageBuilderClient(ctx)
+	ctx = Clientstubs.WithAzureClient(ctx)
 	ctx = stubs.WithReservationDao(ctx)
 	ctx = stubs.WithPubkeyDao(ctx)
 	ctx = stub.WithEnqueuer(ctx)
@@ -68,6 +69,34 @@ func TestCreateAzureReservationHandler(t *testing.T) {
 		assert.Equal(t, "/subscriptions/4b9d213f-712f-4d17-a483-8a10bbe9df3a/resourceGroups/redhat-deployed/providers/Microsoft.Compute/images/composer-api-92ea98f8-7697-472e-80b1-7454fa0e7fa7", jobArgs.AzureImageID, "Expected translated image to real name - one from IB client stub")
 	})
 
+	t.Run("successful reservation with marketplace image", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":     source.ID,
+			"image_id":      "RedHat:" + Clientstubs.AzureMarketplaceOffer + ":9-lvm-gen2:latest",
+			"amount":        1,
+			"instance_size": "Standard_B1s",
+			"pubkey_id":     pk.ID,
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/azure", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAzureReservation)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		enqueued := stub.EnqueuedJobs(ctx)
+		jobArgs := enqueued[len(enqueued)-1].Args.(jobs.LaunchInstanceAzureTaskArgs)
+		assert.Equal(t, "RedHat:RHEL:9-lvm-gen2:latest", jobArgs.AzureImageID)
+	})
+
 	t.Run("failed reservation with invalid location", func(t *testing.T) {
 		var err error
 		values := map[string]interface{}{
@@ -93,4 +122,143 @@ func TestCreateAzureReservationHandler(t *testing.T) {
 		assert.Contains(t, rr.Body.String(), "Unsupported location")
 		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
 	})
+
+	t.Run("successful reservation with zone and proximity placement group", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":                 source.ID,
+			"location":                  "eastus",
+			"zone":                      "2",
+			"proximity_placement_group": "/subscriptions/4b9d213f-712f-4d17-a483-8a10bbe9df3a/resourceGroups/redhat-deployed/providers/Microsoft.Compute/proximityPlacementGroups/ppg-1",
+			"image_id":                  "92ea98f8-7697-472e-80b1-7454fa0e7fa7",
+			"amount":                    1,
+			"instance_size":             "Standard_B1s",
+			"pubkey_id":                 pk.ID,
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/azure", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAzureReservation)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+		assert.Contains(t, rr.Body.String(), `"zone":"2"`)
+		assert.Contains(t, rr.Body.String(), "proximityPlacementGroups/ppg-1")
+	})
+
+	t.Run("successful reservation with trusted launch", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":     source.ID,
+			"image_id":      "92ea98f8-7697-472e-80b1-7454fa0e7fa7",
+			"amount":        1,
+			"instance_size": "Standard_B1s",
+			"pubkey_id":     pk.ID,
+			"security_type": "TrustedLaunch",
+			"secure_boot":   true,
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/azure", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateAzureReservation)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+		assert.Contains(t, rr.Body.String(), `"security_type":"TrustedLaunch"`)
+		assert.Contains(t, rr.Body.String(), `"secure_boot":true`)
+		assert.NotContains(t, rr.Body.String(), `"vtpm"`)
+	})
+
+	invalidOptions := []struct {
+		name    string
+		values  map[string]interface{}
+		message string
+	}{
+		{
+			name:    "invalid marketplace image URN",
+			values:  map[string]interface{}{"image_id": "RedHat:RHEL:9-lvm-gen2", "instance_size": "Standard_B1s"},
+			message: "invalid marketplace image URN",
+		},
+		{
+			name:    "unknown marketplace image",
+			values:  map[string]interface{}{"image_id": "RedHat:Fedora:39:latest", "instance_size": "Standard_B1s"},
+			message: "unknown marketplace image",
+		},
+		{
+			name:    "zone not matching location",
+			values:  map[string]interface{}{"location": "eastus_1", "zone": "2", "instance_size": "Standard_B1s"},
+			message: "zone does not match the location",
+		},
+		{
+			name:    "instance size not in zone",
+			values:  map[string]interface{}{"location": "eastus", "zone": "1", "instance_size": "Basic_A0"},
+			message: "instance type not available in the zone",
+		},
+		{
+			name:    "unknown zone",
+			values:  map[string]interface{}{"location": "eastus", "zone": "9", "instance_size": "Standard_B1s"},
+			message: "unknown region/location/zone",
+		},
+		{
+			name:    "invalid proximity placement group",
+			values:  map[string]interface{}{"proximity_placement_group": "ppg-1", "instance_size": "Standard_B1s"},
+			message: "invalid proximity placement group resource ID",
+		},
+		{
+			name:    "unknown security type",
+			values:  map[string]interface{}{"security_type": "Shielded", "instance_size": "Standard_B1s"},
+			message: "unknown security type",
+		},
+		{
+			name:    "secure boot without security type",
+			values:  map[string]interface{}{"secure_boot": true, "instance_size": "Standard_B1s"},
+			message: "secure boot and vTPM require a security type",
+		},
+		{
+			name:    "confidential VM with standard size",
+			values:  map[string]interface{}{"security_type": "ConfidentialVM", "instance_size": "Standard_B1s"},
+			message: "confidential VM requires a confidential VM size",
+		},
+	}
+	for _, tc := range invalidOptions {
+		tc := tc
+		t.Run("failed reservation with "+tc.name, func(t *testing.T) {
+			var err error
+			values := map[string]interface{}{
+				"source_id": source.ID,
+				"image_id":  "92ea98f8-7697-472e-80b1-7454fa0e7fa7",
+				"amount":    1,
+				"pubkey_id": pk.ID,
+			}
+			for k, v := range tc.values {
+				values[k] = v
+			}
+			if json_data, err = json.Marshal(values); err != nil {
+				t.Fatalf("unable to marshal values to json: %v", err)
+			}
+
+			req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/azure", bytes.NewBuffer(json_data))
+			require.NoError(t, err, "failed to create request")
+			req.Header.Add("Content-Type", "application/json")
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(services.CreateAzureReservation)
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+			assert.Contains(t, rr.Body.String(), tc.message)
+		})
+	}
 }
@@ -7,7 +7,6 @@ import (
 
 	"github.com/RHEnVision/provisioning-backend/internal/clients"
 	"github.com/RHEnVision/provisioning-backend/internal/payloads"
-	"github.com/go-chi/render"
 	"github.com/rs/zerolog"
 )
 
@@ -23,6 +22,12 @@ func ListBuiltinInstanceTypes(typeFunc InstanceTypesForZoneFunc) func(w http.Res
 			return
 		}
 
+		arch, err := ParseArchitecture(r)
+		if err != nil {
+			renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "parameter 'architecture' could not be parsed", err))
+			return
+		}
+
 		if region == "" {
 			renderError(w, r, payloads.NewMissingRequestParameterError(r.Context(), "region parameter is missing"))
 			return
@@ -37,9 +42,7 @@ func ListBuiltinInstanceTypes(typeFunc InstanceTypesForZoneFunc) func(w http.Res
 			return
 		}
 
-		if err := render.Render(w, r, payloads.NewListInstanceTypeResponse(instances)); err != nil {
-			renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render instance types list", err))
-			return
-		}
+		count, err := payloads.StreamListInstanceTypeResponse(w, r, filterArchitecture(instances, arch))
+		logStreamError(r, err, "instance types list", count)
 	}
 }
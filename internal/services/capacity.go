@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/RHEnVision/provisioning-backend/internal/cache"
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/rs/zerolog"
+)
+
+var CapacityUnavailableError = errors.New("requested instance type is likely unavailable")
+
+// maxCapacityAlternatives is the maximum number of alternative instance types suggested
+const maxCapacityAlternatives = 5
+
+// capacityProbe is a capacity check of an instance type in a region, zone is empty when the
+// provider picks the zone.
+type capacityProbe struct {
+	provider     models.ProviderType
+	region       string
+	zone         string
+	instanceType *clients.InstanceType
+
+	// candidates are instance types available in the region suggested as alternatives
+	candidates []*clients.InstanceType
+}
+
+// checkCapacity probes availability of the instance type in the region or zone reported
+// by the provider for the account combined with recent launches failed on insufficient capacity.
+// The check is best effort: when the availability cannot be fetched, the request continues. When
+// the capacity is likely unavailable, alternative zones and instance types are suggested in a
+// warning or the request is denied depending on the configuration. An error carrying the response
+// is returned only when the request was denied.
+func checkCapacity(ctx context.Context, probe *capacityProbe, fetch func() (*clients.Capacity, error)) error {
+	logger := zerolog.Ctx(ctx)
+
+	if config.Reservation.CapacityCheck == config.CapacityCheckOff || probe.instanceType == nil {
+		return nil
+	}
+
+	capacity, err := fetch()
+	if err != nil {
+		logger.Warn().Err(err).Msg("Unable to probe instance type capacity, skipping capacity check")
+		return nil
+	}
+
+	capacity = probe.withoutFailures(ctx, capacity)
+	if capacity.Available(probe.zone) {
+		return nil
+	}
+
+	name := string(probe.instanceType.Name)
+	location := probe.region
+	if probe.zone != "" {
+		location = fmt.Sprintf("%s zone %s", probe.region, probe.zone)
+	}
+	zones := probe.alternativeZones(capacity)
+	types := probe.alternativeTypes(ctx)
+
+	if config.Reservation.CapacityCheck == config.CapacityCheckWarn {
+		logger.Warn().Strs("zones", zones).Strs("types", types).
+			Msgf("Instance type %s is likely unavailable in %s, launching anyway", name, location)
+		return nil
+	}
+
+	capacityErr := fmt.Errorf("%w: %s in %s", CapacityUnavailableError, name, location)
+	return withResponse(payloads.NewCapacityUnavailableError(ctx, name, location, zones, types, capacityErr), capacityErr)
+}
+
+// checkCapacityAndRender performs checkCapacity and renders the error, see checkCapacity.
+func checkCapacityAndRender(w http.ResponseWriter, r *http.Request, probe *capacityProbe, fetch func() (*clients.Capacity, error)) error {
+	err := checkCapacity(r.Context(), probe, fetch)
+	if err != nil {
+		renderResponseError(w, r, err)
+	}
+	return err
+}
+
+// failures returns true when a launch of the instance type failed on insufficient capacity
+// recently, errors of the cache are ignored.
+func (p *capacityProbe) failures(ctx context.Context, zone string, instanceType clients.InstanceTypeName) bool {
+	count, err := cache.InsufficientCapacityFailures(ctx, p.provider, p.region, zone, string(instanceType))
+	if err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Msg("Unable to read insufficient capacity history")
+		return false
+	}
+	return count > 0
+}
+
+// withoutFailures removes the region and zones where launches of the instance type failed
+// on insufficient capacity recently.
+func (p *capacityProbe) withoutFailures(ctx context.Context, capacity *clients.Capacity) *clients.Capacity {
+	result := &clients.Capacity{
+		Regional: capacity.Regional && !p.failures(ctx, "", p.instanceType.Name),
+	}
+	for _, zone := range capacity.Zones {
+		if !p.failures(ctx, zone, p.instanceType.Name) {
+			result.Zones = append(result.Zones, zone)
+		}
+	}
+	return result
+}
+
+func (p *capacityProbe) alternativeZones(capacity *clients.Capacity) []string {
+	zones := make([]string, 0, len(capacity.Zones))
+	for _, zone := range capacity.Zones {
+		if zone != p.zone {
+			zones = append(zones, zone)
+		}
+	}
+	return zones
+}
+
+// alternativeTypes returns candidate types of the same architecture and vCPUs with at least the
+// same memory, smallest first. Types with recent capacity failures are skipped.
+func (p *capacityProbe) alternativeTypes(ctx context.Context) []string {
+	it := p.instanceType
+	matching := make([]*clients.InstanceType, 0)
+	for _, c := range p.candidates {
+		if c.Name == it.Name || c.Architecture != it.Architecture || c.VCPUs != it.VCPUs || c.MemoryMiB < it.MemoryMiB {
+			continue
+		}
+		matching = append(matching, c)
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		if matching[i].MemoryMiB != matching[j].MemoryMiB {
+			return matching[i].MemoryMiB < matching[j].MemoryMiB
+		}
+		return matching[i].Name < matching[j].Name
+	})
+
+	types := make([]string, 0, maxCapacityAlternatives)
+	for _, c := range matching {
+		if len(types) == maxCapacityAlternatives {
+			break
+		}
+		if !p.failures(ctx, p.zone, c.Name) {
+			types = append(types, string(c.Name))
+		}
+	}
+	return types
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+)
+
+// awsCapacityReservation validates the targeted capacity reservation of an AWS launch and returns
+// it for the reservation detail. Reserved capacity is on-demand capacity of a single instance
+// type, so spot instances, dedicated hosts and fallback instance types are not supported.
+func awsCapacityReservation(request *payloads.AWSCapacityReservationRequest, tenancy string, spot *payloads.AWSSpotRequest, fallbackTypes []string) (*models.AWSCapacityReservation, error) {
+	if request == nil {
+		return nil, nil
+	}
+
+	id := strings.TrimSpace(request.ID)
+	arn := strings.TrimSpace(request.GroupARN)
+	if (id == "") == (arn == "") {
+		return nil, InvalidCapacityReservationError
+	}
+	if id != "" && !strings.HasPrefix(id, "cr-") {
+		return nil, fmt.Errorf("%w: %s", InvalidCapacityReservationError, id)
+	}
+	if arn != "" && (!strings.HasPrefix(arn, "arn:") || !strings.Contains(arn, ":resource-groups:")) {
+		return nil, fmt.Errorf("%w: %s", InvalidCapacityReservationError, arn)
+	}
+
+	if spot != nil || tenancy == "host" || len(fallbackTypes) > 0 {
+		return nil, CapacityReservationConflictError
+	}
+	return &models.AWSCapacityReservation{ID: id, GroupARN: arn}, nil
+}
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAWSCapacityReservation(t *testing.T) {
+	const groupARN = "arn:aws:resource-groups:us-east-1:123456789012:group/release-capacity"
+
+	type test struct {
+		name     string
+		request  *payloads.AWSCapacityReservationRequest
+		tenancy  string
+		spot     *payloads.AWSSpotRequest
+		fallback []string
+		want     *models.AWSCapacityReservation
+		err      error
+	}
+
+	tests := []test{
+		{"none", nil, "", nil, nil, nil, nil},
+		{"id", &payloads.AWSCapacityReservationRequest{ID: " cr-0a1b2c3d4e5f67890"}, "", nil, nil, &models.AWSCapacityReservation{ID: "cr-0a1b2c3d4e5f67890"}, nil},
+		{"group", &payloads.AWSCapacityReservationRequest{GroupARN: groupARN}, "dedicated", nil, nil, &models.AWSCapacityReservation{GroupARN: groupARN}, nil},
+		{"empty", &payloads.AWSCapacityReservationRequest{}, "", nil, nil, nil, InvalidCapacityReservationError},
+		{"both", &payloads.AWSCapacityReservationRequest{ID: "cr-0a1b2c3d4e5f67890", GroupARN: groupARN}, "", nil, nil, nil, InvalidCapacityReservationError},
+		{"invalid id", &payloads.AWSCapacityReservationRequest{ID: "h-0a1b2c3d4e5f67890"}, "", nil, nil, nil, InvalidCapacityReservationError},
+		{"invalid group", &payloads.AWSCapacityReservationRequest{GroupARN: "arn:aws:iam::123456789012:role/test"}, "", nil, nil, nil, InvalidCapacityReservationError},
+		{"spot", &payloads.AWSCapacityReservationRequest{ID: "cr-0a1b2c3d4e5f67890"}, "", &payloads.AWSSpotRequest{}, nil, nil, CapacityReservationConflictError},
+		{"host", &payloads.AWSCapacityReservationRequest{ID: "cr-0a1b2c3d4e5f67890"}, "host", nil, nil, nil, CapacityReservationConflictError},
+		{"fallback", &payloads.AWSCapacityReservationRequest{ID: "cr-0a1b2c3d4e5f67890"}, "", nil, []string{"t3a.small"}, nil, CapacityReservationConflictError},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := awsCapacityReservation(tc.request, tc.tenancy, tc.spot, tc.fallback)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/go-chi/render"
+)
+
+// ListCapacityReservations lists active capacity reservations of an AWS source in a region with
+// instances still available, so they can be targeted by reservations.
+func ListCapacityReservations(w http.ResponseWriter, r *http.Request) {
+	ec2Client := sourceEC2Client(w, r)
+	if ec2Client == nil {
+		return
+	}
+
+	reservations, err := ec2Client.ListCapacityReservations(r.Context())
+	if err != nil {
+		renderError(w, r, payloads.NewAWSError(r.Context(), "unable to list AWS EC2 capacity reservations", err))
+		return
+	}
+
+	if err := render.Render(w, r, payloads.NewListCapacityReservationResponse(reservations)); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render capacity reservations list", err))
+		return
+	}
+}
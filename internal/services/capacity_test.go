@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/cache"
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func withCapacityCheck(t *testing.T, mode string) {
+	previous, history := config.Reservation.CapacityCheck, config.Reservation.CapacityHistory
+	config.Reservation.CapacityCheck = mode
+	config.Reservation.CapacityHistory = time.Hour
+	t.Cleanup(func() {
+		config.Reservation.CapacityCheck = previous
+		config.Reservation.CapacityHistory = history
+	})
+}
+
+func fixedCapacity(zones ...string) func() (*clients.Capacity, error) {
+	return func() (*clients.Capacity, error) {
+		return &clients.Capacity{Regional: len(zones) > 0, Zones: zones}, nil
+	}
+}
+
+func testCapacityProbe(region, zone string) *capacityProbe {
+	return &capacityProbe{
+		provider:     models.ProviderTypeAWS,
+		region:       region,
+		zone:         zone,
+		instanceType: &clients.InstanceType{Name: "m5.large", VCPUs: 2, MemoryMiB: 8192, Architecture: clients.ArchitectureTypeX86_64},
+		candidates: []*clients.InstanceType{
+			{Name: "m5.large", VCPUs: 2, MemoryMiB: 8192, Architecture: clients.ArchitectureTypeX86_64},
+			{Name: "r5.large", VCPUs: 2, MemoryMiB: 16384, Architecture: clients.ArchitectureTypeX86_64},
+			{Name: "m5a.large", VCPUs: 2, MemoryMiB: 8192, Architecture: clients.ArchitectureTypeX86_64},
+			{Name: "c5.large", VCPUs: 2, MemoryMiB: 4096, Architecture: clients.ArchitectureTypeX86_64},
+			{Name: "m6g.large", VCPUs: 2, MemoryMiB: 8192, Architecture: clients.ArchitectureTypeArm64},
+		},
+	}
+}
+
+func TestCheckCapacityAndRenderDeny(t *testing.T) {
+	withCapacityCheck(t, config.CapacityCheckDeny)
+	w := httptest.NewRecorder()
+	req, err := http.NewRequestWithContext(context.Background(), "POST", "/", nil)
+	require.NoError(t, err, "failed to create request")
+
+	err = checkCapacityAndRender(w, req, testCapacityProbe("us-east-1", ""), fixedCapacity())
+	require.ErrorIs(t, err, CapacityUnavailableError)
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	require.Contains(t, w.Body.String(), "alternative types: m5a.large, r5.large")
+}
+
+func TestCheckCapacityAndRenderDenyZone(t *testing.T) {
+	withCapacityCheck(t, config.CapacityCheckDeny)
+	w := httptest.NewRecorder()
+	req, err := http.NewRequestWithContext(context.Background(), "POST", "/", nil)
+	require.NoError(t, err, "failed to create request")
+
+	err = checkCapacityAndRender(w, req, testCapacityProbe("eastus", "1"), fixedCapacity("2", "3"))
+	require.ErrorIs(t, err, CapacityUnavailableError)
+	require.Contains(t, w.Body.String(), "alternative zones: 2, 3")
+}
+
+func TestCheckCapacityAndRenderDenyAvailable(t *testing.T) {
+	withCapacityCheck(t, config.CapacityCheckDeny)
+	w := httptest.NewRecorder()
+	req, err := http.NewRequestWithContext(context.Background(), "POST", "/", nil)
+	require.NoError(t, err, "failed to create request")
+
+	err = checkCapacityAndRender(w, req, testCapacityProbe("us-east-1", ""), fixedCapacity("us-east-1a"))
+	require.NoError(t, err)
+}
+
+func TestCheckCapacityAndRenderFailureHistory(t *testing.T) {
+	withCapacityCheck(t, config.CapacityCheckDeny)
+	w := httptest.NewRecorder()
+	req, err := http.NewRequestWithContext(context.Background(), "POST", "/", nil)
+	require.NoError(t, err, "failed to create request")
+
+	err = cache.RecordInsufficientCapacity(req.Context(), models.ProviderTypeAWS, "eu-west-3", "", "m5.large")
+	require.NoError(t, err)
+	err = cache.RecordInsufficientCapacity(req.Context(), models.ProviderTypeAWS, "eu-west-3", "", "m5a.large")
+	require.NoError(t, err)
+
+	err = checkCapacityAndRender(w, req, testCapacityProbe("eu-west-3", ""), fixedCapacity("eu-west-3a"))
+	require.ErrorIs(t, err, CapacityUnavailableError)
+	require.Contains(t, w.Body.String(), "alternative zones: eu-west-3a, alternative types: r5.large")
+}
+
+func TestCheckCapacityAndRenderWarn(t *testing.T) {
+	withCapacityCheck(t, config.CapacityCheckWarn)
+	w := httptest.NewRecorder()
+	req, err := http.NewRequestWithContext(context.Background(), "POST", "/", nil)
+	require.NoError(t, err, "failed to create request")
+
+	err = checkCapacityAndRender(w, req, testCapacityProbe("us-east-1", ""), fixedCapacity())
+	require.NoError(t, err)
+}
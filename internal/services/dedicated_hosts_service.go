@@ -0,0 +1,28 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/go-chi/render"
+)
+
+// ListDedicatedHosts lists dedicated hosts of an AWS source in a region, so they can be picked
+// for reservations with host tenancy.
+func ListDedicatedHosts(w http.ResponseWriter, r *http.Request) {
+	ec2Client := sourceEC2Client(w, r)
+	if ec2Client == nil {
+		return
+	}
+
+	hosts, err := ec2Client.ListDedicatedHosts(r.Context())
+	if err != nil {
+		renderError(w, r, payloads.NewAWSError(r.Context(), "unable to list AWS EC2 dedicated hosts", err))
+		return
+	}
+
+	if err := render.Render(w, r, payloads.NewListDedicatedHostResponse(hosts)); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render dedicated hosts list", err))
+		return
+	}
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"regexp"
+
+	"github.com/RHEnVision/provisioning-backend/internal/jobs"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+)
+
+var (
+	route53ZoneRegexp  = regexp.MustCompile(`^(/hostedzone/)?Z[A-Z0-9]{1,31}$`)
+	azureDNSZoneRegexp = regexp.MustCompile(`(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft\.Network/dnszones/[^/]+$`)
+	gcpDNSZoneRegexp   = regexp.MustCompile(`^[a-z][a-z0-9-]{0,62}$`)
+)
+
+// validDNSZone checks the format of a Route53 hosted zone ID, Azure DNS zone resource ID or
+// Cloud DNS managed zone name, existence of the zone is checked when the records are created.
+func validDNSZone(provider models.ProviderType, zone string) bool {
+	switch provider {
+	case models.ProviderTypeAWS:
+		return route53ZoneRegexp.MatchString(zone)
+	case models.ProviderTypeAzure:
+		return azureDNSZoneRegexp.MatchString(zone)
+	case models.ProviderTypeGCP:
+		return gcpDNSZoneRegexp.MatchString(zone)
+	case models.ProviderTypeNoop, models.ProviderTypeUnknown:
+	}
+	return false
+}
+
+// withDNSStep appends the DNS records step to launch job step titles when a zone is set.
+func withDNSStep(titles []string, zone string) []string {
+	if zone == "" {
+		return titles
+	}
+	result := make([]string, len(titles), len(titles)+1)
+	copy(result, titles)
+	return append(result, jobs.CreateDNSRecordsStep)
+}
@@ -0,0 +1,28 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+)
+
+// checkElasticIP validates Elastic IP options of an AWS launch. Addresses are associated by
+// instance ID which AWS rejects for instances with more than one network interface, the pool is
+// a tag value.
+func checkElasticIP(eip *payloads.AWSElasticIPRequest, nics []models.NetworkInterface) error {
+	if eip == nil {
+		return nil
+	}
+	if len(nics) > 1 {
+		return ElasticIPNetworkInterfacesError
+	}
+
+	pool := strings.TrimSpace(eip.Pool)
+	if utf8.RuneCountInString(pool) > maxAWSTagValueLength || !awsTagRegexp.MatchString(pool) {
+		return fmt.Errorf("%w: must have at most %d allowed characters", InvalidElasticIPPoolError, maxAWSTagValueLength)
+	}
+	return nil
+}
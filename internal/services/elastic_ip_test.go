@@ -0,0 +1,40 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckElasticIP(t *testing.T) {
+	one := []models.NetworkInterface{{SubnetID: "subnet-1"}}
+	two := []models.NetworkInterface{{SubnetID: "subnet-1"}, {SubnetID: "subnet-2"}}
+
+	tests := []struct {
+		name string
+		eip  *payloads.AWSElasticIPRequest
+		nics []models.NetworkInterface
+		err  error
+	}{
+		{"disabled", nil, two, nil},
+		{"allocate", &payloads.AWSElasticIPRequest{}, nil, nil},
+		{"pool", &payloads.AWSElasticIPRequest{Pool: "web servers"}, one, nil},
+		{"multiple interfaces", &payloads.AWSElasticIPRequest{}, two, ElasticIPNetworkInterfacesError},
+		{"long pool", &payloads.AWSElasticIPRequest{Pool: strings.Repeat("p", 257)}, nil, InvalidElasticIPPoolError},
+		{"invalid pool", &payloads.AWSElasticIPRequest{Pool: "web#1"}, nil, InvalidElasticIPPoolError},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkElasticIP(tc.eip, tc.nics)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -30,7 +31,7 @@ func writeBasicError(w http.ResponseWriter, r *http.Request, err error) {
 }
 
 func writeErrorBody(w http.ResponseWriter, _ *http.Request, msg, traceId, err string) {
-	_, _ = w.Write([]byte(fmt.Sprintf(`{"msg": "%s", "trace_id": "%s", "error": "%s"}`, msg, traceId, err)))
+	_, _ = w.Write([]byte(fmt.Sprintf(`{"msg": "%s", "trace_id": "%s", "error": "%s"}`, logging.Redact(msg), traceId, logging.Redact(err))))
 }
 
 func renderError(w http.ResponseWriter, r *http.Request, renderer render.Renderer) {
@@ -40,6 +41,56 @@ func renderError(w http.ResponseWriter, r *http.Request, renderer render.Rendere
 	}
 }
 
+// logStreamError logs errors of streamed list responses. The response status was already written
+// when streaming started, so the error cannot be rendered and the client gets an incomplete body.
+func logStreamError(r *http.Request, err error, resource string, count int) {
+	if err != nil {
+		zerolog.Ctx(r.Context()).Warn().Err(err).Msgf("Streaming of %s failed after %d items", resource, count)
+	}
+}
+
+// responseError is an error of an operation shared by endpoints and background processing (e.g.
+// reservation launches), it carries the error response rendered by endpoints.
+type responseError struct {
+	response *payloads.ResponseError
+	err      error
+}
+
+func (e *responseError) Error() string {
+	if e.err == nil {
+		return e.response.Message
+	}
+	return e.err.Error()
+}
+
+func (e *responseError) Unwrap() error {
+	return e.err
+}
+
+// withResponse returns the error together with the response rendered by endpoints.
+func withResponse(response *payloads.ResponseError, err error) error {
+	return &responseError{response: response, err: err}
+}
+
+// renderResponseError renders the response of an error returned by a shared operation, errors
+// without a response are rendered as internal errors.
+func renderResponseError(w http.ResponseWriter, r *http.Request, err error) {
+	var rErr *responseError
+	if errors.As(err, &rErr) {
+		renderError(w, r, rErr.response)
+		return
+	}
+	renderError(w, r, payloads.NewResponseError(r.Context(), http.StatusInternalServerError, "", err))
+}
+
+// notFoundOrDAOError returns the error with a not found or a DAO error response.
+func notFoundOrDAOError(ctx context.Context, err error, resource string) error {
+	if errors.Is(err, dao.ErrNoRows) {
+		return withResponse(payloads.NewNotFoundError(ctx, resource, err), err)
+	}
+	return withResponse(payloads.NewDAOError(ctx, resource, err), err)
+}
+
 func renderNotFoundOrDAOError(w http.ResponseWriter, r *http.Request, err error, resource string) {
 	if errors.Is(err, dao.ErrNoRows) {
 		renderError(w, r, payloads.NewNotFoundError(r.Context(), resource, err))
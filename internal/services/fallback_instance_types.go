@@ -0,0 +1,46 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/preload"
+)
+
+// maxFallbackInstanceTypes limits the amount of launch attempts of a single job
+const maxFallbackInstanceTypes = 5
+
+// checkFallbackInstanceTypes validates alternative EC2 instance types tried on insufficient
+// capacity, they must be known, unique and of the same architecture as the instance type.
+func checkFallbackInstanceTypes(instanceType string, fallback []string) error {
+	if len(fallback) == 0 {
+		return nil
+	}
+	if instanceType == "" {
+		return FallbackWithoutInstanceTypeError
+	}
+	if len(fallback) > maxFallbackInstanceTypes {
+		return fmt.Errorf("%w: at most %d allowed", TooManyFallbackInstanceTypesError, maxFallbackInstanceTypes)
+	}
+
+	primary := preload.EC2InstanceType.FindInstanceType(clients.InstanceTypeName(instanceType))
+	if primary == nil {
+		return fmt.Errorf("%w: %s", UnknownInstanceTypeNameError, instanceType)
+	}
+	seen := map[string]struct{}{instanceType: {}}
+	for _, name := range fallback {
+		if _, ok := seen[name]; ok {
+			return fmt.Errorf("%w: %s", DuplicateFallbackInstanceTypeError, name)
+		}
+		seen[name] = struct{}{}
+
+		it := preload.EC2InstanceType.FindInstanceType(clients.InstanceTypeName(name))
+		if it == nil {
+			return fmt.Errorf("%w: %s", UnknownInstanceTypeNameError, name)
+		}
+		if it.Architecture != primary.Architecture {
+			return fmt.Errorf("%w: %s is %s", FallbackArchitectureMismatchError, name, it.Architecture)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"regexp"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+)
+
+// gcpImageFamilyRegexp matches "family/NAME" and "projects/PROJECT/global/images/family/NAME"
+// optionally prefixed with the compute API URL.
+var gcpImageFamilyRegexp = regexp.MustCompile(`^(?:https://www\.googleapis\.com/compute/v1/)?(?:projects/([a-z][-a-z0-9]{4,28}[a-z0-9])/global/images/)?family/([a-z](?:[-a-z0-9]{0,61}[a-z0-9])?)$`)
+
+// gcpImageFamily returns the project and family of an image family reference, the project
+// is blank for references to the customer project.
+func gcpImageFamily(imageID string) (string, string, bool) {
+	match := gcpImageFamilyRegexp.FindStringSubmatch(imageID)
+	if match == nil {
+		return "", "", false
+	}
+	return match[1], match[2], true
+}
+
+// gcpImageProjects returns projects to list images from, the public image projects
+// from the configuration followed by the customer project.
+func gcpImageProjects(customerProject string) []string {
+	projects := make([]string, 0, len(config.GCP.ImageProjects)+1)
+	for _, project := range config.GCP.ImageProjects {
+		if project != "" && project != customerProject {
+			projects = append(projects, project)
+		}
+	}
+	return append(projects, customerProject)
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGCPImageFamily(t *testing.T) {
+	tests := []struct {
+		imageID string
+		project string
+		family  string
+		ok      bool
+	}{
+		{"family/rhel-9", "", "rhel-9", true},
+		{"projects/rhel-cloud/global/images/family/rhel-9", "rhel-cloud", "rhel-9", true},
+		{"https://www.googleapis.com/compute/v1/projects/rhel-cloud/global/images/family/rhel-9", "rhel-cloud", "rhel-9", true},
+		{"projects/rhel-cloud/global/images/rhel-9-v20231010", "", "", false},
+		{"family/", "", "", false},
+		{"family/RHEL", "", "", false},
+		{"80967e7f-efef-4eee-85b0-bd4cef4c455d", "", "", false},
+	}
+	for _, tt := range tests {
+		project, family, ok := gcpImageFamily(tt.imageID)
+		assert.Equal(t, tt.ok, ok, tt.imageID)
+		assert.Equal(t, tt.project, project, tt.imageID)
+		assert.Equal(t, tt.family, family, tt.imageID)
+	}
+}
+
+func TestGCPImageProjects(t *testing.T) {
+	saved := config.GCP.ImageProjects
+	defer func() { config.GCP.ImageProjects = saved }()
+
+	config.GCP.ImageProjects = []string{"rhel-cloud", "rhel-sap-cloud"}
+	assert.Equal(t, []string{"rhel-cloud", "rhel-sap-cloud", "customer-project"}, gcpImageProjects("customer-project"))
+	assert.Equal(t, []string{"rhel-sap-cloud", "rhel-cloud"}, gcpImageProjects("rhel-cloud"))
+}
+
+func TestFilterImageArchitecture(t *testing.T) {
+	images := []*clients.Image{
+		{Name: "rhel-9-x86", Architecture: clients.ArchitectureTypeX86_64},
+		{Name: "rhel-9-arm", Architecture: clients.ArchitectureTypeArm64},
+		{Name: "rhel-8"},
+	}
+	assert.Len(t, filterImageArchitecture(images, ""), 3)
+
+	filtered := filterImageArchitecture(images, clients.ArchitectureTypeArm64)
+	if assert.Len(t, filtered, 2) {
+		assert.Equal(t, "rhel-9-arm", filtered[0].Name)
+		assert.Equal(t, "rhel-8", filtered[1].Name, "expected images of unknown architecture to be kept")
+	}
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/jobs"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/RHEnVision/provisioning-backend/internal/preload"
+	"github.com/RHEnVision/provisioning-backend/pkg/worker"
+	"github.com/go-chi/render"
+)
+
+type gcpProvider struct{}
+
+func init() {
+	RegisterProvider(models.ProviderTypeGCP, gcpProvider{})
+}
+
+func (gcpProvider) Enabled(_ context.Context) bool {
+	return true
+}
+
+func (gcpProvider) ListInstanceTypes(region, zone string, supported *bool) ([]*clients.InstanceType, error) {
+	return preload.GCPInstanceType.InstanceTypesForZone(region, zone, supported)
+}
+
+func (gcpProvider) ValidateRequest(ctx context.Context, decode RequestDecoder, _ url.Values) (render.Binder, error) {
+	payload := &payloads.GCPReservationRequest{}
+	if err := decode(payload); err != nil {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, "GCP reservation", err), err)
+	}
+
+	if payload.Hibernation {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, GCPHibernationError.Error(), GCPHibernationError), GCPHibernationError)
+	}
+	return payload, nil
+}
+
+func (gcpProvider) Launch(ctx context.Context, request render.Binder) (int64, render.Renderer, error) {
+	payload, ok := request.(*payloads.GCPReservationRequest)
+	if !ok {
+		return 0, nil, fmt.Errorf("%w: %T", UnexpectedRequestTypeError, request)
+	}
+
+	reservation, err := launchGCPReservation(ctx, payload)
+	if err != nil {
+		return 0, nil, err
+	}
+	return reservation.ID, payloads.NewGCPReservationResponse(reservation, make([]*models.ReservationInstance, 0)), nil
+}
+
+func (gcpProvider) DescribeReservation(ctx context.Context, reservation *models.Reservation, instances []*models.ReservationInstance) (render.Renderer, error) {
+	detail, err := dao.GetReservationDao(ctx).GetGCPById(ctx, reservation.ID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get GCP reservation: %w", err)
+	}
+	return payloads.NewGCPReservationResponse(detail, instances), nil
+}
+
+func (gcpProvider) InstanceLocation(ctx context.Context, id int64) (*ReservationLocation, error) {
+	reservation, err := dao.GetReservationDao(ctx).GetGCPById(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get GCP reservation: %w", err)
+	}
+	return &ReservationLocation{
+		SourceID:     reservation.SourceID,
+		Zone:         reservation.Detail.Zone,
+		InstanceType: reservation.Detail.MachineType,
+	}, nil
+}
+
+func (gcpProvider) PowerState(ctx context.Context, instance *models.Instance, auth *clients.Authentication) (models.PowerState, error) {
+	gcpClient, err := clients.GetGCPClient(ctx, auth)
+	if err != nil {
+		return "", fmt.Errorf("cannot create new GCP client: %w", err)
+	}
+	return gcpClient.GetPowerState(ctx, instance.InstanceID, instance.Location)
+}
+
+func (gcpProvider) PowerJobType() worker.JobType {
+	return jobs.TypePowerInstanceGcp
+}
@@ -1,8 +1,11 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/RHEnVision/provisioning-backend/internal/preload"
 	"github.com/google/uuid"
@@ -13,42 +16,91 @@ import (
 	"github.com/RHEnVision/provisioning-backend/internal/identity"
 	"github.com/RHEnVision/provisioning-backend/internal/jobs"
 	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/naming"
 	"github.com/RHEnVision/provisioning-backend/internal/payloads"
-	"github.com/RHEnVision/provisioning-backend/internal/queue"
 	"github.com/RHEnVision/provisioning-backend/pkg/worker"
-	"github.com/go-chi/render"
 )
 
 func CreateGCPReservation(w http.ResponseWriter, r *http.Request) {
-	logger := zerolog.Ctx(r.Context())
+	launchReservation(w, r, gcpProvider{})
+}
+
+// launchGCPReservation validates the request, creates the reservation and enqueues its launch job.
+func launchGCPReservation(ctx context.Context, payload *payloads.GCPReservationRequest) (*models.GCPReservation, error) {
+	logger := zerolog.Ctx(ctx)
+
+	var accountId int64 = identity.AccountId(ctx)
+	var id identity.Principal = identity.Identity(ctx)
 
-	var accountId int64 = identity.AccountId(r.Context())
-	var id identity.Principal = identity.Identity(r.Context())
+	if labelsErr := checkLabels(payload.Labels); labelsErr != nil {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, labelsErr.Error(), labelsErr), labelsErr)
+	}
 
-	payload := &payloads.GCPReservationRequest{}
-	if err := render.Bind(r, payload); err != nil {
-		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "GCP reservation", err))
-		return
+	if userDataErr := checkUserData(payload.UserData); userDataErr != nil {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, userDataErr.Error(), userDataErr), userDataErr)
 	}
 
-	rDao := dao.GetReservationDao(r.Context())
-	pkDao := dao.GetPubkeyDao(r.Context())
+	rDao := dao.GetReservationDao(ctx)
+	pkDao := dao.GetPubkeyDao(ctx)
 
 	// Check for preloaded region
 	if !preload.GCPInstanceType.ValidateRegion(payload.Zone) {
-		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "Unsupported zone", UnsupportedRegionError))
-		return
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, "Unsupported zone", UnsupportedRegionError), UnsupportedRegionError)
+	}
+
+	sourceSettings, err := dao.GetAccountDao(ctx).GetSourceSettings(ctx, payload.SourceID)
+	if err != nil {
+		return nil, withResponse(payloads.NewDAOError(ctx, "get source settings", err), err)
+	}
+	if regionErr := checkSourceRegion(sourceSettings, gcpRegion(payload.Zone)); regionErr != nil {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, regionErr.Error(), regionErr), regionErr)
+	}
+
+	if err := CheckInstanceTypeSettings(ctx, preload.GCPInstanceType.FindInstanceType, payload.MachineType); err != nil {
+		return nil, err
+	}
+
+	if payload.ShieldedIntegrityMonitoring && !payload.ShieldedVTPM {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, IntegrityMonitoringWithoutVTPMError.Error(), IntegrityMonitoringWithoutVTPMError), IntegrityMonitoringWithoutVTPMError)
+	}
+
+	if payload.DNSZone != "" && !validDNSZone(models.ProviderTypeGCP, payload.DNSZone) {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, InvalidDNSZoneError.Error(), InvalidDNSZoneError), InvalidDNSZoneError)
+	}
+
+	addrs, err := addressing(payload.Addressing, nil)
+	if err != nil {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, err.Error(), err), err)
+	}
+
+	probeDetail, probeErr := healthProbe(payload.HealthProbe, addrs)
+	if probeErr != nil {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, probeErr.Error(), probeErr), probeErr)
+	}
+
+	scopes, err := gcpServiceAccountScopes(payload.ServiceAccount, payload.Scopes)
+	if err != nil {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, err.Error(), err), err)
 	}
 
 	resUUID := uuid.New().String()
 	detail := &models.GCPDetail{
-		NamePattern:      &payload.NamePattern,
 		Zone:             payload.Zone,
 		MachineType:      payload.MachineType,
 		Amount:           payload.Amount,
 		PowerOff:         payload.PowerOff,
 		UUID:             resUUID,
 		LaunchTemplateID: payload.LaunchTemplateID,
+		ServiceAccount:   payload.ServiceAccount,
+		Scopes:           scopes,
+
+		ShieldedSecureBoot:          payload.ShieldedSecureBoot,
+		ShieldedVTPM:                payload.ShieldedVTPM,
+		ShieldedIntegrityMonitoring: payload.ShieldedIntegrityMonitoring,
+		DNSZone:                     payload.DNSZone,
+		Addressing:                  addrs,
+		HealthProbe:                 probeDetail,
+		UserData:                    payload.UserData,
 	}
 	reservation := &models.GCPReservation{
 		PubkeyID: payload.PubkeyID,
@@ -60,64 +112,122 @@ func CreateGCPReservation(w http.ResponseWriter, r *http.Request) {
 	reservation.AccountID = accountId
 	reservation.Status = "Created"
 	reservation.Provider = models.ProviderTypeGCP
-	reservation.Steps = 2
-	reservation.StepTitles = jobs.LaunchInstanceGCPSteps
+	reservation.Labels = payload.Labels
+	reservation.StepTitles = withProbeStep(withDNSStep(jobs.LaunchInstanceGCPSteps, payload.DNSZone), probeDetail)
+	reservation.Steps = int32(len(reservation.StepTitles))
 
 	logger.Debug().Msgf("Validating existence of pubkey %d for this account", reservation.PubkeyID)
-	pk, err := pkDao.GetById(r.Context(), reservation.PubkeyID)
+	pk, err := pkDao.GetById(ctx, reservation.PubkeyID)
 	if err != nil {
 		message := fmt.Sprintf("get pubkey with id %d", reservation.PubkeyID)
-		renderNotFoundOrDAOError(w, r, err, message)
-		return
+		return nil, notFoundOrDAOError(ctx, err, message)
 	}
 	logger.Debug().Msgf("Found pubkey %d named '%s'", pk.ID, pk.Name)
 
+	namePattern, err := reservationName(ctx, payload.NamePattern, naming.Values{
+		Provider:     models.ProviderTypeGCP.String(),
+		Region:       payload.Zone,
+		InstanceType: payload.MachineType,
+	})
+	if err != nil {
+		return nil, withResponse(payloads.NewDAOError(ctx, "generate reservation name", err), err)
+	}
+	reservation.Detail.NamePattern = &namePattern
+
 	// create reservation in the database
-	err = rDao.CreateGCP(r.Context(), reservation)
+	err = rDao.CreateGCP(ctx, reservation)
 	if err != nil {
-		renderError(w, r, payloads.NewDAOError(r.Context(), "create reservation", err))
-		return
+		return nil, withResponse(payloads.NewDAOError(ctx, "create reservation", err), err)
 	}
 	logger.Debug().Msgf("Created a new reservation %d", reservation.ID)
 
 	// Get Sources client
-	sourcesClient, err := clients.GetSourcesClient(r.Context())
+	sourcesClient, err := clients.GetSourcesClient(ctx)
 	if err != nil {
-		renderError(w, r, payloads.NewClientError(r.Context(), err))
-		return
+		return nil, withResponse(payloads.NewClientError(ctx, err), err)
 	}
 
 	// Fetch project id from Sources
-	authentication, err := sourcesClient.GetAuthentication(r.Context(), payload.SourceID)
+	authentication, err := sourcesClient.GetAuthentication(ctx, payload.SourceID)
 	if err != nil {
-		renderError(w, r, payloads.NewClientError(r.Context(), err))
-		return
+		return nil, withResponse(payloads.NewClientError(ctx, err), err)
 	}
 
 	if typeErr := authentication.MustBe(models.ProviderTypeGCP); typeErr != nil {
-		renderError(w, r, payloads.NewClientError(r.Context(), typeErr))
-		return
+		return nil, withResponse(payloads.NewClientError(ctx, typeErr), typeErr)
+	}
+
+	// Check vCPU quota of the zone's region, only possible when machine type is known
+	if it := preload.GCPInstanceType.FindInstanceType(clients.InstanceTypeName(payload.MachineType)); it != nil {
+		requested := int64(it.VCPUs) * payload.Amount
+		quotaErr := CheckQuota(ctx, requested, func() (*clients.Quota, error) {
+			gcpClient, clientErr := clients.GetGCPClient(ctx, authentication)
+			if clientErr != nil {
+				return nil, fmt.Errorf("unable to get GCP client: %w", clientErr)
+			}
+			return gcpClient.GetVCPUQuota(ctx, gcpRegion(payload.Zone), payload.MachineType)
+		})
+		if quotaErr != nil {
+			return nil, quotaErr
+		}
 	}
 
 	// Get Image builder client
-	ibc, ibErr := clients.GetImageBuilderClient(r.Context())
+	ibc, ibErr := clients.GetImageBuilderClient(ctx)
 	logger.Trace().Msg("Creating IB client")
 	if ibErr != nil {
-		renderError(w, r, payloads.NewClientError(r.Context(), ibErr))
-		return
+		return nil, withResponse(payloads.NewClientError(ctx, ibErr), ibErr)
 	}
 
 	// Validate image
+	_, pErr := uuid.Parse(payload.ImageID)
+	if err := CheckImageSettings(ctx, payload.ImageID, pErr == nil); err != nil {
+		return nil, err
+	}
+
 	var name string
-	if _, pErr := uuid.Parse(payload.ImageID); pErr == nil {
+	if pErr == nil {
 		// Composer-built image
-		name, ibErr = ibc.GetGCPImageName(r.Context(), reservation.ImageID)
+		name, ibErr = ibc.GetGCPImageName(ctx, reservation.ImageID)
 		if ibErr != nil {
-			renderError(w, r, payloads.NewClientError(r.Context(), ibErr))
-			return
+			return nil, withResponse(payloads.NewClientError(ctx, ibErr), ibErr)
 		}
 
 		logger.Trace().Msgf("Image Name is %s", name)
+
+		// Validate architecture match, only possible when machine type is known
+		if it := preload.GCPInstanceType.FindInstanceType(clients.InstanceTypeName(payload.MachineType)); it != nil {
+			imageArch, archErr := ibc.GetImageArchitecture(ctx, reservation.ImageID)
+			if archErr != nil {
+				return nil, withResponse(payloads.NewClientError(ctx, archErr), archErr)
+			}
+			if archErr = checkArchitecture(it, imageArch); archErr != nil {
+				return nil, withResponse(payloads.NewWrongArchitectureUserError(ctx, archErr), archErr)
+			}
+		}
+	} else if project, family, ok := gcpImageFamily(payload.ImageID); ok {
+		// Image family is resolved here, so all instances are launched from the same image
+		if project == "" {
+			project = authentication.Payload
+		}
+		gcpClient, clientErr := clients.GetGCPClient(ctx, authentication)
+		if clientErr != nil {
+			return nil, withResponse(payloads.NewGCPError(ctx, "unable to get GCP client", clientErr), clientErr)
+		}
+		image, imageErr := gcpClient.GetImageFromFamily(ctx, project, family)
+		if errors.Is(imageErr, clients.NotFoundErr) {
+			return nil, withResponse(payloads.NewInvalidRequestError(ctx, imageErr.Error(), UnknownImageFamilyError), UnknownImageFamilyError)
+		} else if imageErr != nil {
+			return nil, withResponse(payloads.NewGCPError(ctx, "unable to get GCP image family", imageErr), imageErr)
+		}
+		name = image.SelfLink
+		logger.Trace().Msgf("Image family %s resolved to %s", family, name)
+
+		if it := preload.GCPInstanceType.FindInstanceType(clients.InstanceTypeName(payload.MachineType)); it != nil {
+			if archErr := checkArchitecture(it, image.Architecture); archErr != nil {
+				return nil, withResponse(payloads.NewWrongArchitectureUserError(ctx, archErr), archErr)
+			}
+		}
 	} else {
 		// Treat HTTP(S) URLs like direct image ID (e.g. from https://imagedirectory.cloud)
 		name = payload.ImageID
@@ -138,16 +248,43 @@ func CreateGCPReservation(w http.ResponseWriter, r *http.Request) {
 		},
 	}
 
-	err = queue.GetEnqueuer(r.Context()).Enqueue(r.Context(), &launchJob)
+	err = enqueueReservationJob(ctx, &reservation.Reservation, reservation.Detail.Amount, &launchJob)
 	if err != nil {
-		renderError(w, r, payloads.NewEnqueueTaskError(r.Context(), "job enqueue error", err))
-		return
+		return nil, withResponse(payloads.NewEnqueueTaskError(ctx, "job enqueue error", err), err)
 	}
 
-	unused := make([]*models.ReservationInstance, 0, 0)
-	// Return response payload
-	if err := render.Render(w, r, payloads.NewGCPReservationResponse(reservation, unused)); err != nil {
-		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render reservation", err))
-		return
+	return reservation, nil
+}
+
+// gcpScopePrefix is the URL prefix of Google OAuth scopes
+const gcpScopePrefix = "https://www.googleapis.com/auth/"
+
+// gcpServiceAccountScopes validates the service account and returns the scopes as full URLs,
+// scopes require a service account and default to "cloud-platform".
+func gcpServiceAccountScopes(serviceAccount string, scopes []string) ([]string, error) {
+	if serviceAccount == "" {
+		if len(scopes) > 0 {
+			return nil, ScopesWithoutServiceAccountError
+		}
+		return nil, nil
+	}
+	if serviceAccount != "default" && !strings.Contains(serviceAccount, "@") {
+		return nil, fmt.Errorf("%w: %s", InvalidServiceAccountError, serviceAccount)
+	}
+
+	if len(scopes) == 0 {
+		return []string{gcpScopePrefix + "cloud-platform"}, nil
+	}
+	result := make([]string, len(scopes))
+	for i, scope := range scopes {
+		switch {
+		case scope == "":
+			return nil, fmt.Errorf("%w: empty scope", InvalidServiceAccountError)
+		case strings.HasPrefix(scope, "https://"):
+			result[i] = scope
+		default:
+			result[i] = gcpScopePrefix + scope
+		}
 	}
+	return result, nil
 }
@@ -11,6 +11,7 @@ import (
 	Clientstubs "github.com/RHEnVision/provisioning-backend/internal/clients/stubs"
 	"github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
 	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
 	"github.com/RHEnVision/provisioning-backend/internal/services"
 	"github.com/RHEnVision/provisioning-backend/internal/testing/factories"
 	"github.com/RHEnVision/provisioning-backend/internal/testing/identity"
@@ -85,4 +86,118 @@ func TestCreateGCPReservationHandler(t *testing.T) {
 		assert.Contains(t, rr.Body.String(), "Unsupported zone")
 		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
 	})
+
+	t.Run("successful reservation with service account", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":       source.ID,
+			"image_id":        "80967e7f-efef-4eee-85b0-bd4cef4c455d",
+			"amount":          1,
+			"zone":            "us-central1-a",
+			"machine_type":    "n1-standard-1",
+			"pubkey_id":       pk.ID,
+			"service_account": "launcher@project.iam.gserviceaccount.com",
+			"scopes":          []string{"devstorage.read_only", "https://www.googleapis.com/auth/logging.write"},
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/gcp", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateGCPReservation)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var response payloads.GCPReservationResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&response), "failed to decode response")
+		assert.Equal(t, "launcher@project.iam.gserviceaccount.com", response.ServiceAccount)
+		assert.Equal(t, []string{
+			"https://www.googleapis.com/auth/devstorage.read_only",
+			"https://www.googleapis.com/auth/logging.write",
+		}, response.Scopes)
+	})
+
+	t.Run("failed reservation with scopes without service account", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":    source.ID,
+			"image_id":     "80967e7f-efef-4eee-85b0-bd4cef4c455d",
+			"amount":       1,
+			"zone":         "us-central1-a",
+			"machine_type": "n1-standard-1",
+			"pubkey_id":    pk.ID,
+			"scopes":       []string{"cloud-platform"},
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/gcp", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateGCPReservation)
+		handler.ServeHTTP(rr, req)
+		assert.Contains(t, rr.Body.String(), "scopes require a service account")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("failed reservation with integrity monitoring without vTPM", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":                     source.ID,
+			"image_id":                      "80967e7f-efef-4eee-85b0-bd4cef4c455d",
+			"amount":                        1,
+			"zone":                          "us-central1-a",
+			"machine_type":                  "n1-standard-1",
+			"pubkey_id":                     pk.ID,
+			"shielded_secure_boot":          true,
+			"shielded_integrity_monitoring": true,
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/gcp", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateGCPReservation)
+		handler.ServeHTTP(rr, req)
+		assert.Contains(t, rr.Body.String(), "integrity monitoring requires vTPM")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("failed reservation with hibernation", func(t *testing.T) {
+		var err error
+		values := map[string]interface{}{
+			"source_id":    source.ID,
+			"image_id":     "80967e7f-efef-4eee-85b0-bd4cef4c455d",
+			"amount":       1,
+			"zone":         "us-central1-a",
+			"machine_type": "n1-standard-1",
+			"pubkey_id":    pk.ID,
+			"hibernation":  true,
+		}
+		if json_data, err = json.Marshal(values); err != nil {
+			t.Fatalf("unable to marshal values to json: %v", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/gcp", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateGCPReservation)
+		handler.ServeHTTP(rr, req)
+		assert.Contains(t, rr.Body.String(), "hibernation is not supported for GCP instances")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
 }
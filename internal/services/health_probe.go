@@ -0,0 +1,53 @@
+package services
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/jobs"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+)
+
+// maxHealthProbePathLength limits HTTP paths of health probes
+const maxHealthProbePathLength = 1024
+
+// healthProbe validates health probe options of a launch and returns them for the reservation
+// detail. The probe runs within the launch job, so it must time out before the job does. Only
+// public addresses are probed, so public IPv4 addresses must not be disabled by the addressing.
+func healthProbe(request *payloads.HealthProbeRequest, addrs *models.Addressing) (*models.HealthProbe, error) {
+	if request == nil {
+		return nil, nil
+	}
+	if addrs != nil && addrs.PublicIPv4 != nil && !*addrs.PublicIPv4 {
+		return nil, fmt.Errorf("%w: instances without public IPv4 address cannot be probed", InvalidHealthProbeError)
+	}
+	if request.Port < 0 || request.Port > 65535 {
+		return nil, fmt.Errorf("%w: port must be between 1 and 65535", InvalidHealthProbeError)
+	}
+	if request.Timeout < 0 || (request.Timeout > 0 && time.Duration(request.Timeout)*time.Second >= config.Worker.Timeout) {
+		return nil, fmt.Errorf("%w: timeout must be shorter than %s", InvalidHealthProbeError, config.Worker.Timeout)
+	}
+
+	path := strings.TrimSpace(request.HTTPPath)
+	if path != "" {
+		u, err := url.Parse(path)
+		if err != nil || u.Scheme != "" || u.Host != "" || len(path) > maxHealthProbePathLength || strings.ContainsAny(path, " \t") {
+			return nil, fmt.Errorf("%w: http path must be a path of at most %d characters", InvalidHealthProbeError, maxHealthProbePathLength)
+		}
+	}
+	return &models.HealthProbe{Port: request.Port, HTTPPath: path, Timeout: request.Timeout}, nil
+}
+
+// withProbeStep appends the health probe step to launch job step titles when a probe is set.
+func withProbeStep(titles []string, probe *models.HealthProbe) []string {
+	if probe == nil {
+		return titles
+	}
+	result := make([]string, len(titles), len(titles)+1)
+	copy(result, titles)
+	return append(result, jobs.ProbeInstancesStep)
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/RHEnVision/provisioning-backend/internal/ptr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthProbe(t *testing.T) {
+	timeout := config.Worker.Timeout
+	config.Worker.Timeout = 30 * time.Minute
+	defer func() { config.Worker.Timeout = timeout }()
+
+	tests := []struct {
+		name    string
+		request *payloads.HealthProbeRequest
+		probe   *models.HealthProbe
+		err     error
+	}{
+		{"disabled", nil, nil, nil},
+		{"ssh", &payloads.HealthProbeRequest{}, &models.HealthProbe{}, nil},
+		{"http", &payloads.HealthProbeRequest{Port: 8080, HTTPPath: " /healthz ", Timeout: 600}, &models.HealthProbe{Port: 8080, HTTPPath: "/healthz", Timeout: 600}, nil},
+		{"invalid port", &payloads.HealthProbeRequest{Port: 70000}, nil, InvalidHealthProbeError},
+		{"negative timeout", &payloads.HealthProbeRequest{Timeout: -1}, nil, InvalidHealthProbeError},
+		{"timeout over job timeout", &payloads.HealthProbeRequest{Timeout: 7200}, nil, InvalidHealthProbeError},
+		{"absolute URL", &payloads.HealthProbeRequest{HTTPPath: "http://example.com/healthz"}, nil, InvalidHealthProbeError},
+		{"path with spaces", &payloads.HealthProbeRequest{HTTPPath: "/health check"}, nil, InvalidHealthProbeError},
+		{"long path", &payloads.HealthProbeRequest{HTTPPath: "/" + strings.Repeat("p", 1024)}, nil, InvalidHealthProbeError},
+	}
+
+	t.Run("without public IPv4", func(t *testing.T) {
+		_, err := healthProbe(&payloads.HealthProbeRequest{}, &models.Addressing{PublicIPv4: ptr.To(false)})
+		require.ErrorIs(t, err, InvalidHealthProbeError)
+	})
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			probe, err := healthProbe(tc.request, nil)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.probe, probe)
+		})
+	}
+}
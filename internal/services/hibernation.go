@@ -0,0 +1,67 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+)
+
+// awsHibernationFamilies are EC2 instance families supporting hibernation
+var awsHibernationFamilies = map[string]struct{}{
+	"c3": {}, "c4": {}, "c5": {}, "c5d": {}, "c6i": {}, "c6id": {}, "c7i": {},
+	"i3": {},
+	"m3": {}, "m4": {}, "m5": {}, "m5a": {}, "m5ad": {}, "m5d": {}, "m6i": {}, "m6id": {}, "m7i": {},
+	"r3": {}, "r4": {}, "r5": {}, "r5a": {}, "r5ad": {}, "r5d": {}, "r6i": {}, "r6id": {}, "r7i": {},
+	"t2": {}, "t3": {}, "t3a": {},
+}
+
+// azureHibernationSizes matches B series and D and E series v3 to v5 sizes supporting hibernation
+var azureHibernationSizes = regexp.MustCompile(`^Standard_(B\d+\w*|[DE]\d+\w*_v[345])$`)
+
+// Memory limits of hibernation
+const (
+	awsHibernationMaxMemoryMiB   = 150 * 1024
+	azureHibernationMaxMemoryMiB = 64 * 1024
+)
+
+// supportsHibernation returns true when the instance type can be hibernated, the memory must fit
+// into the root volume which is checked by the cloud provider on launch.
+func supportsHibernation(provider models.ProviderType, it *clients.InstanceType) bool {
+	name := it.Name.String()
+	switch provider {
+	case models.ProviderTypeAWS:
+		family, size, _ := strings.Cut(name, ".")
+		_, ok := awsHibernationFamilies[family]
+		return ok && !strings.HasPrefix(size, "metal") && it.MemoryMiB < awsHibernationMaxMemoryMiB
+	case models.ProviderTypeAzure:
+		return azureHibernationSizes.MatchString(name) && it.MemoryMiB <= azureHibernationMaxMemoryMiB
+	case models.ProviderTypeGCP, models.ProviderTypeNoop, models.ProviderTypeUnknown:
+	}
+	return false
+}
+
+// checkHibernation returns HibernationNotSupportedError when the instance type is unknown
+// or cannot be hibernated.
+func checkHibernation(provider models.ProviderType, it *clients.InstanceType) error {
+	if it == nil {
+		return fmt.Errorf("%w: instance type is required", HibernationNotSupportedError)
+	}
+	if !supportsHibernation(provider, it) {
+		return fmt.Errorf("%w: %s", HibernationNotSupportedError, it.Name)
+	}
+	return nil
+}
+
+// checkHibernationTypes returns HibernationNotSupportedError when any of the instance types
+// cannot be hibernated, fallback types launched on insufficient capacity must be checked too.
+func checkHibernationTypes(provider models.ProviderType, find func(clients.InstanceTypeName) *clients.InstanceType, names ...string) error {
+	for _, name := range names {
+		if err := checkHibernation(provider, find(clients.InstanceTypeName(name))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSupportsHibernation(t *testing.T) {
+	tests := []struct {
+		provider  models.ProviderType
+		name      string
+		memoryMiB int64
+		expected  bool
+	}{
+		{models.ProviderTypeAWS, "t3.large", 8 * 1024, true},
+		{models.ProviderTypeAWS, "m5.metal", 384 * 1024, false},
+		{models.ProviderTypeAWS, "r5.24xlarge", 768 * 1024, false},
+		{models.ProviderTypeAWS, "a1.large", 4 * 1024, false},
+		{models.ProviderTypeAzure, "Standard_D2s_v5", 8 * 1024, true},
+		{models.ProviderTypeAzure, "Standard_B2s", 4 * 1024, true},
+		{models.ProviderTypeAzure, "Standard_E64s_v5", 512 * 1024, false},
+		{models.ProviderTypeAzure, "Basic_A0", 768, false},
+		{models.ProviderTypeGCP, "n1-standard-1", 3840, false},
+		{models.ProviderTypeNoop, "noop", 1024, false},
+	}
+	for _, tt := range tests {
+		it := &clients.InstanceType{Name: clients.InstanceTypeName(tt.name), MemoryMiB: tt.memoryMiB}
+		assert.Equal(t, tt.expected, supportsHibernation(tt.provider, it), "%s %s", tt.provider, tt.name)
+	}
+}
+
+func TestCheckHibernation(t *testing.T) {
+	err := checkHibernation(models.ProviderTypeAWS, nil)
+	require.ErrorIs(t, err, HibernationNotSupportedError)
+
+	err = checkHibernation(models.ProviderTypeAWS, &clients.InstanceType{Name: "a1.large", MemoryMiB: 4096})
+	require.ErrorIs(t, err, HibernationNotSupportedError)
+	require.Contains(t, err.Error(), "a1.large")
+
+	err = checkHibernation(models.ProviderTypeAWS, &clients.InstanceType{Name: "t3.large", MemoryMiB: 8192})
+	require.NoError(t, err)
+}
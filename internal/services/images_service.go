@@ -0,0 +1,66 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/go-chi/chi/v5"
+)
+
+// ListImages returns images which can be launched with the source, only GCP is supported.
+//
+//nolint:exhaustive
+func ListImages(w http.ResponseWriter, r *http.Request) {
+	sourceId := chi.URLParam(r, "ID")
+
+	sourcesClient, err := clients.GetSourcesClient(r.Context())
+	if err != nil {
+		renderError(w, r, payloads.NewClientError(r.Context(), err))
+		return
+	}
+
+	auth, err := sourcesClient.GetAuthentication(r.Context(), sourceId)
+	if err != nil {
+		renderError(w, r, payloads.NewClientError(r.Context(), err))
+		return
+	}
+
+	switch auth.ProviderType {
+	case models.ProviderTypeGCP:
+		listImagesGCP(w, r, auth)
+	case models.ProviderTypeAWS, models.ProviderTypeAzure:
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "image listing is not implemented", ProviderTypeNotImplementedError))
+	default:
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "provider is not supported", UnknownProviderTypeError))
+	}
+}
+
+func listImagesGCP(w http.ResponseWriter, r *http.Request, auth *clients.Authentication) {
+	projects := r.URL.Query()["project"]
+	if len(projects) == 0 {
+		projects = gcpImageProjects(auth.Payload)
+	}
+
+	arch, err := ParseArchitecture(r)
+	if err != nil {
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "parameter 'architecture' could not be parsed", err))
+		return
+	}
+
+	gcpClient, err := clients.GetGCPClient(r.Context(), auth)
+	if err != nil {
+		renderError(w, r, payloads.NewGCPError(r.Context(), "unable to get GCP client", err))
+		return
+	}
+
+	images, err := gcpClient.ListImages(r.Context(), projects)
+	if err != nil {
+		renderError(w, r, payloads.NewGCPError(r.Context(), "unable to list GCP images", err))
+		return
+	}
+
+	count, err := payloads.StreamListImageResponse(w, r, filterImageArchitecture(images, arch))
+	logStreamError(r, err, "images list", count)
+}
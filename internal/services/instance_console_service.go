@@ -0,0 +1,56 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/go-chi/render"
+)
+
+// GetInstanceConsole returns recent serial console output and a screenshot of an instance of
+// a reservation, it helps to find out why an instance is not reachable over SSH after boot.
+func GetInstanceConsole(w http.ResponseWriter, r *http.Request) {
+	target := findInstanceTarget(w, r, "read")
+	if target == nil {
+		return
+	}
+
+	var console *clients.ConsoleOutput
+	var err error
+	instanceId := target.instance.InstanceID
+	switch target.reservation.Provider {
+	case models.ProviderTypeAWS:
+		ec2Client, clientErr := clients.GetEC2Client(r.Context(), target.auth, target.region)
+		if clientErr != nil {
+			renderError(w, r, payloads.NewAWSError(r.Context(), "unable to get AWS EC2 client", clientErr))
+			return
+		}
+		console, err = ec2Client.GetConsoleOutput(r.Context(), instanceId)
+	case models.ProviderTypeAzure:
+		azureClient, clientErr := clients.GetAzureClient(r.Context(), target.auth)
+		if clientErr != nil {
+			renderError(w, r, payloads.NewAzureError(r.Context(), "unable to get Azure client", clientErr))
+			return
+		}
+		console, err = azureClient.GetConsoleOutput(r.Context(), instanceId)
+	case models.ProviderTypeGCP:
+		gcpClient, clientErr := clients.GetGCPClient(r.Context(), target.auth)
+		if clientErr != nil {
+			renderError(w, r, payloads.NewGCPError(r.Context(), "unable to get GCP client", clientErr))
+			return
+		}
+		console, err = gcpClient.GetConsoleOutput(r.Context(), instanceId, target.zone)
+	case models.ProviderTypeNoop, models.ProviderTypeUnknown:
+	}
+	if err != nil {
+		renderError(w, r, payloads.NewClientError(r.Context(), fmt.Errorf("unable to get console output: %w", err)))
+		return
+	}
+
+	if err := render.Render(w, r, payloads.NewInstanceConsoleResponse(instanceId, console)); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render instance console", err))
+	}
+}
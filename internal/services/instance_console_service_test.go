@@ -0,0 +1,82 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/clients/http/rbac"
+	clientStubs "github.com/RHEnVision/provisioning-backend/internal/clients/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/RHEnVision/provisioning-backend/internal/services"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/factories"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/identity"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetInstanceConsoleHandler(t *testing.T) {
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = identity.WithTenant(t, ctx)
+	ctx = clientStubs.WithSourcesClient(ctx)
+	ctx = clientStubs.WithEC2Client(ctx)
+	ctx = stubs.WithReservationDao(ctx)
+	ctx = stubs.WithPubkeyDao(ctx)
+	ctx = rbac.WithAcl(ctx, clients.AllPermissionsRbacAcl)
+
+	pk := factories.NewPubkeyRSA()
+	err := stubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to add stubbed key")
+	reservation := &models.AWSReservation{
+		PubkeyID: pk.ID,
+		SourceID: "1",
+		ImageID:  "ami-0c830793775595d4b",
+		Detail:   &models.AWSDetail{Region: "us-east-1", InstanceType: "t3.small", Amount: 1},
+	}
+	reservation.AccountID = 1
+	reservation.Provider = models.ProviderTypeAWS
+	err = stubs.AddAWSReservation(ctx, reservation)
+	require.NoError(t, err, "failed to add stubbed reservation")
+	instance := &models.ReservationInstance{ReservationID: reservation.ID, InstanceID: "i-0a4caa2cf5b097ce1"}
+	err = dao.GetReservationDao(ctx).CreateInstance(ctx, instance)
+	require.NoError(t, err, "failed to add stubbed instance")
+
+	console := func(t *testing.T, instanceId string) *httptest.ResponseRecorder {
+		t.Helper()
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("ID", strconv.FormatInt(reservation.ID, 10))
+		rctx.URLParams.Add("INSTANCE_ID", instanceId)
+		ctx := context.WithValue(ctx, chi.RouteCtxKey, rctx)
+
+		req, err := http.NewRequestWithContext(ctx, "GET", "/api/provisioning/reservations/1/instances/"+instanceId+"/console", nil)
+		require.NoError(t, err, "failed to create request")
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(services.GetInstanceConsole).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("found", func(t *testing.T) {
+		rr := console(t, instance.InstanceID)
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.InstanceConsoleResponse
+		err := json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		assert.Equal(t, instance.InstanceID, result.InstanceID)
+		assert.Contains(t, result.Output, "i-0a4caa2cf5b097ce1 login:")
+	})
+
+	t.Run("unknown instance", func(t *testing.T) {
+		rr := console(t, "i-0000000000000000")
+		require.Equal(t, http.StatusNotFound, rr.Code, "Handler returned wrong status code")
+	})
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/RHEnVision/provisioning-backend/internal/jobs"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/RHEnVision/provisioning-backend/internal/queue"
+	"github.com/RHEnVision/provisioning-backend/pkg/worker"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog"
+)
+
+var (
+	InstanceNotFoundError  = errors.New("instance not found in the reservation")
+	InvalidPowerStateError = errors.New("operation not allowed in the current power state")
+)
+
+// StopInstance stops a running instance of a reservation without terminating it
+func StopInstance(w http.ResponseWriter, r *http.Request) {
+	changeInstancePower(w, r, jobs.PowerActionStop)
+}
+
+// StartInstance starts a stopped instance of a reservation
+func StartInstance(w http.ResponseWriter, r *http.Request) {
+	changeInstancePower(w, r, jobs.PowerActionStart)
+}
+
+// powerTransition returns the allowed current states and the intermediate state of an action,
+// the unknown state is allowed, so a failed operation can be retried.
+func powerTransition(action jobs.PowerAction) ([]models.PowerState, models.PowerState) {
+	if action == jobs.PowerActionStop {
+		return []models.PowerState{models.PowerStateRunning, models.PowerStateUnknown}, models.PowerStateStopping
+	}
+	return []models.PowerState{models.PowerStateStopped, models.PowerStateUnknown}, models.PowerStateStarting
+}
+
+// instanceTarget is an instance of a reservation with details needed by instance operation jobs.
+type instanceTarget struct {
+	reservation *models.Reservation
+	instance    *models.ReservationInstance
+	region      string
+	zone        string
+	auth        *clients.Authentication
+	provider    Provider
+
+	// instance type of the launch, blank when not known. Resizing keeps the architecture, so it
+	// is used for architecture checks of resized instances too.
+	instanceType string
+}
+
+// findInstanceTarget finds the instance from URL parameters in a reservation the user has the
+// permission for and fetches the authentication of its source. It renders an error and returns
+// nil on failure.
+func findInstanceTarget(w http.ResponseWriter, r *http.Request, permission string) *instanceTarget {
+	id, err := ParseInt64(r, "ID")
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse ID parameter", err))
+		return nil
+	}
+
+	// Azure instance IDs are resource paths and must be URL encoded
+	instanceId, err := url.PathUnescape(chi.URLParam(r, "INSTANCE_ID"))
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse INSTANCE_ID parameter", err))
+		return nil
+	}
+
+	reservation := getVisibleReservation(w, r, id)
+	if reservation == nil {
+		return nil
+	}
+
+	if CheckPermissionAndRender(w, r, permission, "reservation", reservation.Provider.String()) != nil {
+		return nil
+	}
+
+	instances, err := dao.GetReservationDao(r.Context()).ListInstances(r.Context(), id)
+	if err != nil {
+		renderNotFoundOrDAOError(w, r, err, "get reservation instances")
+		return nil
+	}
+	target := &instanceTarget{reservation: reservation}
+	for _, inst := range instances {
+		if inst.InstanceID == instanceId {
+			target.instance = inst
+			break
+		}
+	}
+	if target.instance == nil {
+		renderError(w, r, payloads.NewNotFoundError(r.Context(), instanceId, InstanceNotFoundError))
+		return nil
+	}
+
+	provider, err := LookupProvider(reservation.Provider)
+	if err != nil {
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "provider is not supported", ProviderTypeNotImplementedError))
+		return nil
+	}
+	location, err := provider.InstanceLocation(r.Context(), id)
+	if errors.Is(err, ProviderTypeNotImplementedError) {
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "provider is not supported", err))
+		return nil
+	} else if err != nil {
+		renderNotFoundOrDAOError(w, r, err, fmt.Sprintf("get %s reservation", reservation.Provider))
+		return nil
+	}
+	target.provider = provider
+	target.region, target.zone = location.Region, location.Zone
+	target.instanceType = location.InstanceType
+	// instances launched by EC2 Fleet can run in a pool of a different type
+	if target.instance.FleetAllocation.InstanceType != "" {
+		target.instanceType = target.instance.FleetAllocation.InstanceType
+	}
+
+	sourcesClient, err := clients.GetSourcesClient(r.Context())
+	if err != nil {
+		renderError(w, r, payloads.NewClientError(r.Context(), err))
+		return nil
+	}
+
+	target.auth, err = sourcesClient.GetAuthentication(r.Context(), location.SourceID)
+	if err != nil {
+		renderError(w, r, payloads.NewClientError(r.Context(), err))
+		return nil
+	}
+
+	if typeErr := target.auth.MustBe(reservation.Provider); typeErr != nil {
+		renderError(w, r, payloads.NewClientError(r.Context(), typeErr))
+		return nil
+	}
+
+	return target
+}
+
+func changeInstancePower(w http.ResponseWriter, r *http.Request, action jobs.PowerAction) {
+	logger := zerolog.Ctx(r.Context())
+
+	target := findInstanceTarget(w, r, "write")
+	if target == nil {
+		return
+	}
+	id, instance := target.reservation.ID, target.instance
+
+	args := jobs.PowerInstanceTaskArgs{
+		ReservationID: id,
+		InstanceID:    instance.InstanceID,
+		Action:        action,
+		Region:        target.region,
+		Zone:          target.zone,
+		ARN:           target.auth,
+	}
+
+	// the state is changed first, so concurrent requests for the same instance are rejected
+	rDao := dao.GetReservationDao(r.Context())
+	previous := instance.PowerState
+	from, state := powerTransition(action)
+	err := rDao.UpdateInstancePowerState(r.Context(), id, instance.InstanceID, state, from...)
+	if errors.Is(err, dao.ErrAffectedMismatch) {
+		message := fmt.Sprintf("cannot %s instance in state %s", action, previous)
+		renderError(w, r, payloads.NewConflictError(r.Context(), message, InvalidPowerStateError))
+		return
+	} else if err != nil {
+		renderError(w, r, payloads.NewDAOError(r.Context(), "update instance power state", err))
+		return
+	}
+
+	job := worker.Job{
+		Type:      target.provider.PowerJobType(),
+		Identity:  identity.Identity(r.Context()),
+		AccountID: identity.AccountId(r.Context()),
+		Args:      args,
+	}
+	err = queue.GetEnqueuer(r.Context()).Enqueue(r.Context(), &job)
+	if err != nil {
+		revertErr := rDao.UpdateInstancePowerState(r.Context(), id, instance.InstanceID, previous)
+		if revertErr != nil {
+			logger.Warn().Err(revertErr).Msg("Unable to revert instance power state")
+		}
+		renderError(w, r, payloads.NewEnqueueTaskError(r.Context(), "job enqueue error", err))
+		return
+	}
+
+	instance.PowerState = state
+	render.Status(r, http.StatusAccepted)
+	if err := render.Render(w, r, payloads.NewInstanceResponse(instance)); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render instance", err))
+	}
+}
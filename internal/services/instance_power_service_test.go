@@ -0,0 +1,105 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/clients/http/rbac"
+	clientStubs "github.com/RHEnVision/provisioning-backend/internal/clients/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/jobs"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/RHEnVision/provisioning-backend/internal/queue/stub"
+	"github.com/RHEnVision/provisioning-backend/internal/services"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/factories"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/identity"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStopAndStartInstanceHandler(t *testing.T) {
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = identity.WithTenant(t, ctx)
+	ctx = clientStubs.WithSourcesClient(ctx)
+	ctx = stubs.WithReservationDao(ctx)
+	ctx = stubs.WithPubkeyDao(ctx)
+	ctx = stub.WithEnqueuer(ctx)
+	ctx = rbac.WithAcl(ctx, clients.AllPermissionsRbacAcl)
+
+	pk := factories.NewPubkeyRSA()
+	err := stubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to add stubbed key")
+	reservation := &models.AWSReservation{
+		PubkeyID: pk.ID,
+		SourceID: "1",
+		ImageID:  "ami-0c830793775595d4b",
+		Detail:   &models.AWSDetail{Region: "us-east-1", InstanceType: "t3.small", Amount: 1},
+	}
+	reservation.AccountID = 1
+	reservation.Provider = models.ProviderTypeAWS
+	err = stubs.AddAWSReservation(ctx, reservation)
+	require.NoError(t, err, "failed to add stubbed reservation")
+	instance := &models.ReservationInstance{ReservationID: reservation.ID, InstanceID: "i-0a4caa2cf5b097ce1"}
+	err = dao.GetReservationDao(ctx).CreateInstance(ctx, instance)
+	require.NoError(t, err, "failed to add stubbed instance")
+
+	power := func(t *testing.T, instanceId string, handler http.HandlerFunc) *httptest.ResponseRecorder {
+		t.Helper()
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("ID", strconv.FormatInt(reservation.ID, 10))
+		rctx.URLParams.Add("INSTANCE_ID", instanceId)
+		ctx := context.WithValue(ctx, chi.RouteCtxKey, rctx)
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/1/instances/"+instanceId+":stop", nil)
+		require.NoError(t, err, "failed to create request")
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("stop", func(t *testing.T) {
+		rr := power(t, instance.InstanceID, services.StopInstance)
+		require.Equal(t, http.StatusAccepted, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.InstanceResponse
+		err := json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		assert.Equal(t, models.PowerStateStopping, result.PowerState)
+
+		require.Equal(t, 1, len(stub.EnqueuedJobs(ctx)), "Expected exactly one job to be planned")
+		job := stub.EnqueuedJobs(ctx)[0]
+		assert.Equal(t, jobs.TypePowerInstanceAws, job.Type)
+		args, ok := job.Args.(jobs.PowerInstanceTaskArgs)
+		require.True(t, ok, "Unexpected type of arguments for the planned job")
+		assert.Equal(t, jobs.PowerActionStop, args.Action)
+		assert.Equal(t, "us-east-1", args.Region)
+	})
+
+	t.Run("stop while stopping", func(t *testing.T) {
+		rr := power(t, instance.InstanceID, services.StopInstance)
+		require.Equal(t, http.StatusConflict, rr.Code, "Handler returned wrong status code")
+		assert.Equal(t, 1, len(stub.EnqueuedJobs(ctx)))
+	})
+
+	t.Run("start stopped", func(t *testing.T) {
+		instance.PowerState = models.PowerStateStopped
+		rr := power(t, instance.InstanceID, services.StartInstance)
+		require.Equal(t, http.StatusAccepted, rr.Code, "Handler returned wrong status code")
+		assert.Equal(t, models.PowerStateStarting, instance.PowerState)
+		assert.Equal(t, 2, len(stub.EnqueuedJobs(ctx)))
+	})
+
+	t.Run("unknown instance", func(t *testing.T) {
+		rr := power(t, "i-00000000000000000", services.StopInstance)
+		require.Equal(t, http.StatusNotFound, rr.Code, "Handler returned wrong status code")
+	})
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/RHEnVision/provisioning-backend/internal/jobs"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/RHEnVision/provisioning-backend/internal/preload"
+	"github.com/RHEnVision/provisioning-backend/internal/queue"
+	"github.com/RHEnVision/provisioning-backend/pkg/worker"
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog"
+)
+
+var (
+	MissingInstanceTypeError = errors.New("instance type is required")
+	InstanceNotStoppedError  = errors.New("instance must be stopped to be resized")
+)
+
+var resizeJobTypes = map[models.ProviderType]worker.JobType{
+	models.ProviderTypeAWS:   jobs.TypeResizeInstanceAws,
+	models.ProviderTypeAzure: jobs.TypeResizeInstanceAzure,
+	models.ProviderTypeGCP:   jobs.TypeResizeInstanceGcp,
+}
+
+// ResizeInstance changes the instance type of a stopped instance of a reservation, the change
+// or the failed attempt is recorded in the audit log when the job finishes.
+func ResizeInstance(w http.ResponseWriter, r *http.Request) {
+	payload := &payloads.ResizeInstanceRequest{}
+	if err := render.Bind(r, payload); err != nil {
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "resize instance", err))
+		return
+	}
+	if payload.InstanceType == "" {
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), MissingInstanceTypeError.Error(), MissingInstanceTypeError))
+		return
+	}
+
+	target := findInstanceTarget(w, r, "write")
+	if target == nil {
+		return
+	}
+
+	find := resizeTypeFinder(target.reservation.Provider)
+	it := find(clients.InstanceTypeName(payload.InstanceType))
+	if it == nil {
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), fmt.Sprintf("unknown type: %s", payload.InstanceType), UnknownInstanceTypeNameError))
+		return
+	}
+	known := func(clients.InstanceTypeName) *clients.InstanceType { return it }
+	if CheckInstanceTypePolicyAndRender(w, r, known, payload.InstanceType) != nil {
+		return
+	}
+
+	// the image of the instance does not change, architecture of the launched type is kept
+	if current := find(clients.InstanceTypeName(target.instanceType)); current != nil && current.Architecture != "" {
+		if archErr := checkArchitecture(it, current.Architecture); archErr != nil {
+			renderError(w, r, payloads.NewWrongArchitectureUserError(r.Context(), archErr))
+			return
+		}
+	}
+
+	// the state is changed first, so concurrent power or resize requests are rejected
+	instance := target.instance
+	rDao := dao.GetReservationDao(r.Context())
+	err := rDao.UpdateInstancePowerState(r.Context(), target.reservation.ID, instance.InstanceID, models.PowerStateResizing, models.PowerStateStopped)
+	if errors.Is(err, dao.ErrAffectedMismatch) {
+		message := fmt.Sprintf("cannot resize instance in state %s", instance.PowerState)
+		renderError(w, r, payloads.NewConflictError(r.Context(), message, InstanceNotStoppedError))
+		return
+	} else if err != nil {
+		renderError(w, r, payloads.NewDAOError(r.Context(), "update instance power state", err))
+		return
+	}
+
+	job := worker.Job{
+		Type:      resizeJobTypes[target.reservation.Provider],
+		Identity:  identity.Identity(r.Context()),
+		AccountID: identity.AccountId(r.Context()),
+		Args: jobs.ResizeInstanceTaskArgs{
+			ReservationID: target.reservation.ID,
+			InstanceID:    instance.InstanceID,
+			InstanceType:  payload.InstanceType,
+			Region:        target.region,
+			Zone:          target.zone,
+			ARN:           target.auth,
+		},
+	}
+	err = queue.GetEnqueuer(r.Context()).Enqueue(r.Context(), &job)
+	if err != nil {
+		revertErr := rDao.UpdateInstancePowerState(r.Context(), target.reservation.ID, instance.InstanceID, models.PowerStateStopped)
+		if revertErr != nil {
+			zerolog.Ctx(r.Context()).Warn().Err(revertErr).Msg("Unable to revert instance power state")
+		}
+		renderError(w, r, payloads.NewEnqueueTaskError(r.Context(), "job enqueue error", err))
+		return
+	}
+
+	instance.PowerState = models.PowerStateResizing
+	render.Status(r, http.StatusAccepted)
+	if err := render.Render(w, r, payloads.NewInstanceResponse(instance)); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render instance", err))
+	}
+}
+
+// resizeTypeFinder returns the instance type lookup of a provider.
+func resizeTypeFinder(pType models.ProviderType) func(clients.InstanceTypeName) *clients.InstanceType {
+	switch pType {
+	case models.ProviderTypeAWS:
+		return preload.EC2InstanceType.FindInstanceType
+	case models.ProviderTypeAzure:
+		return preload.AzureInstanceType.FindInstanceType
+	case models.ProviderTypeGCP:
+		return preload.GCPInstanceType.FindInstanceType
+	case models.ProviderTypeNoop, models.ProviderTypeUnknown:
+	}
+	return func(clients.InstanceTypeName) *clients.InstanceType { return nil }
+}
@@ -0,0 +1,115 @@
+package services_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/clients/http/rbac"
+	clientStubs "github.com/RHEnVision/provisioning-backend/internal/clients/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/jobs"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/queue/stub"
+	"github.com/RHEnVision/provisioning-backend/internal/services"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/factories"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/identity"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResizeInstanceHandler(t *testing.T) {
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = identity.WithTenant(t, ctx)
+	ctx = clientStubs.WithSourcesClient(ctx)
+	ctx = stubs.WithReservationDao(ctx)
+	ctx = stubs.WithPubkeyDao(ctx)
+	ctx = stub.WithEnqueuer(ctx)
+	ctx = rbac.WithAcl(ctx, clients.AllPermissionsRbacAcl)
+
+	pk := factories.NewPubkeyRSA()
+	err := stubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to add stubbed key")
+	reservation := &models.AWSReservation{
+		PubkeyID: pk.ID,
+		SourceID: "1",
+		ImageID:  "ami-0c830793775595d4b",
+		Detail:   &models.AWSDetail{Region: "us-east-1", InstanceType: "t3.small", Amount: 1},
+	}
+	reservation.AccountID = 1
+	reservation.Provider = models.ProviderTypeAWS
+	err = stubs.AddAWSReservation(ctx, reservation)
+	require.NoError(t, err, "failed to add stubbed reservation")
+	instance := &models.ReservationInstance{ReservationID: reservation.ID, InstanceID: "i-0a4caa2cf5b097ce1"}
+	err = dao.GetReservationDao(ctx).CreateInstance(ctx, instance)
+	require.NoError(t, err, "failed to add stubbed instance")
+
+	resize := func(t *testing.T, instanceType string) *httptest.ResponseRecorder {
+		t.Helper()
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("ID", strconv.FormatInt(reservation.ID, 10))
+		rctx.URLParams.Add("INSTANCE_ID", instance.InstanceID)
+		ctx := context.WithValue(ctx, chi.RouteCtxKey, rctx)
+
+		var body bytes.Buffer
+		err := json.NewEncoder(&body).Encode(map[string]string{"instance_type": instanceType})
+		require.NoError(t, err, "failed to encode payload")
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/1/instances/"+instance.InstanceID+":resize", &body)
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(services.ResizeInstance).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("running", func(t *testing.T) {
+		rr := resize(t, "t3.large")
+		require.Equal(t, http.StatusConflict, rr.Code, "Handler returned wrong status code")
+		assert.Equal(t, 0, len(stub.EnqueuedJobs(ctx)))
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		instance.PowerState = models.PowerStateStopped
+		rr := resize(t, "x99.unknown")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+		assert.Equal(t, 0, len(stub.EnqueuedJobs(ctx)))
+	})
+
+	t.Run("architecture mismatch", func(t *testing.T) {
+		instance.PowerState = models.PowerStateStopped
+		rr := resize(t, "t4g.large")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+		assert.Contains(t, rr.Body.String(), "architecture")
+		assert.Equal(t, 0, len(stub.EnqueuedJobs(ctx)))
+	})
+
+	t.Run("stopped", func(t *testing.T) {
+		instance.PowerState = models.PowerStateStopped
+		rr := resize(t, "t3.large")
+		require.Equal(t, http.StatusAccepted, rr.Code, "Handler returned wrong status code")
+
+		require.Equal(t, 1, len(stub.EnqueuedJobs(ctx)), "Expected exactly one job to be planned")
+		job := stub.EnqueuedJobs(ctx)[0]
+		assert.Equal(t, jobs.TypeResizeInstanceAws, job.Type)
+		args, ok := job.Args.(jobs.ResizeInstanceTaskArgs)
+		require.True(t, ok, "Unexpected type of arguments for the planned job")
+		assert.Equal(t, "t3.large", args.InstanceType)
+		assert.Equal(t, "us-east-1", args.Region)
+		assert.Equal(t, models.PowerStateResizing, instance.PowerState)
+	})
+
+	t.Run("resizing", func(t *testing.T) {
+		rr := resize(t, "t3.xlarge")
+		require.Equal(t, http.StatusConflict, rr.Code, "Handler returned wrong status code")
+		assert.Equal(t, 1, len(stub.EnqueuedJobs(ctx)), "Expected no additional job to be planned")
+	})
+}
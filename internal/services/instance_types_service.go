@@ -1,19 +1,39 @@
 package services
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/RHEnVision/provisioning-backend/internal/cache"
 	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
 	"github.com/RHEnVision/provisioning-backend/internal/payloads"
 	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/render"
+	"github.com/rs/zerolog"
 )
 
 func ListInstanceTypes(w http.ResponseWriter, r *http.Request) {
 	sourceId := chi.URLParam(r, "ID")
 	region := r.URL.Query().Get("region")
+	if region == "" {
+		var err error
+		if region, err = sourceDefaultRegion(r.Context(), sourceId); err != nil {
+			renderError(w, r, payloads.NewDAOError(r.Context(), "get source settings", err))
+			return
+		}
+	}
 	if region == "" {
 		renderError(w, r, payloads.NewMissingRequestParameterError(r.Context(), "region parameter is missing"))
+		return
+	}
+	arch, err := ParseArchitecture(r)
+	if err != nil {
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "parameter 'architecture' could not be parsed", err))
+		return
 	}
 
 	sourcesClient, err := clients.GetSourcesClient(r.Context())
@@ -28,20 +48,101 @@ func ListInstanceTypes(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ec2Client, err := clients.GetEC2Client(r.Context(), authentication, region)
+	instances, err := listInstanceTypes(r.Context(), sourceId, region, authentication)
 	if err != nil {
-		renderError(w, r, payloads.NewAWSError(r.Context(), "unable to get AWS EC2 client", err))
+		renderError(w, r, payloads.NewAWSError(r.Context(), "unable to list AWS EC2 instances", err))
 		return
 	}
 
-	instances, err := ec2Client.ListInstanceTypes(r.Context())
+	count, err := payloads.StreamListInstanceTypeResponse(w, r, filterArchitecture(instances, arch))
+	logStreamError(r, err, "instance types list", count)
+}
+
+// instanceTypesPrefetchTimeout limits background loads of instance types.
+const instanceTypesPrefetchTimeout = time.Minute
+
+// listInstanceTypes returns AWS instance types of the source and region. They are served from the
+// cache when available, the EC2 client is only created on cache miss. Cache errors are logged and
+// the types are loaded from EC2.
+func listInstanceTypes(ctx context.Context, sourceId, region string, authentication *clients.Authentication) ([]*clients.InstanceType, error) {
+	logger := zerolog.Ctx(ctx)
+	key := fmt.Sprintf("%d:%s:%s", identity.AccountId(ctx), sourceId, region)
+
+	var cached clients.InstanceTypeList
+	err := cache.Find(ctx, key, &cached)
+	if err == nil {
+		return cached, nil
+	} else if !errors.Is(err, cache.ErrNotFound) {
+		logger.Warn().Err(err).Msg("Unable to find instance types in cache")
+	}
+
+	// concurrent requests for the same account, source and region share one paginated listing
+	instances, err := cache.Coalesce("instance_types:"+key, func() ([]*clients.InstanceType, error) {
+		ec2Client, clientErr := clients.GetEC2Client(ctx, authentication, region)
+		if clientErr != nil {
+			return nil, fmt.Errorf("unable to get AWS EC2 client: %w", clientErr)
+		}
+		return ec2Client.ListInstanceTypes(ctx)
+	})
 	if err != nil {
-		renderError(w, r, payloads.NewAWSError(r.Context(), "unable to list AWS EC2 instances", err))
-		return
+		return nil, err
+	}
+
+	err = cache.Set(ctx, key, clients.InstanceTypeList(instances))
+	if err != nil {
+		logger.Warn().Err(err).Msg("Unable to store instance types in cache")
 	}
+	return instances, nil
+}
 
-	if err := render.Render(w, r, payloads.NewListInstanceTypeResponse(instances)); err != nil {
-		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render instance types list", err))
+// prefetchInstanceTypes loads instance types of the source default region into the cache in
+// background, so the next step of the launch wizard does not wait for the paginated listing.
+// It does nothing when the cache is not enabled.
+func prefetchInstanceTypes(ctx context.Context, sourceId string, authentication *clients.Authentication) {
+	if !config.Application.Cache.PrefetchTypes || !cache.Enabled() {
 		return
 	}
+
+	// the request is finished before the prefetch
+	bgCtx, cancel := context.WithTimeout(detachedContext{parent: ctx}, instanceTypesPrefetchTimeout)
+	go func() {
+		defer cancel()
+		logger := zerolog.Ctx(bgCtx)
+
+		region, err := sourceDefaultRegion(bgCtx, sourceId)
+		if err != nil {
+			logger.Warn().Err(err).Msg("Unable to prefetch instance types")
+			return
+		}
+		if region == "" {
+			region = clients.AWSDefaultRegion(clients.AWSPartition(authentication.Payload))
+		}
+
+		if _, err = listInstanceTypes(bgCtx, sourceId, region, authentication); err != nil {
+			logger.Warn().Err(err).Str("region", region).Msg("Unable to prefetch instance types")
+			return
+		}
+		logger.Debug().Str("region", region).Msg("Prefetched instance types")
+	}()
+}
+
+// detachedContext keeps values of the parent context but is never canceled with it.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+func (c detachedContext) Value(key any) any {
+	return c.parent.Value(key)
 }
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog"
+)
+
+var UnknownPowerStateError = errors.New("unknown power state parameter")
+
+// ListInstances returns instances of all reservations of the organization across providers. The
+// power state is the last known one, with refresh=true it is fetched from providers first.
+func ListInstances(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := &dao.InstanceFilter{
+		Region:     query.Get("region"),
+		PowerState: models.PowerState(query.Get("state")),
+	}
+
+	if provider := query.Get("provider"); provider != "" {
+		filter.Provider = models.ProviderTypeFromString(provider)
+		if filter.Provider == models.ProviderTypeUnknown || filter.Provider == models.ProviderTypeNoop {
+			renderError(w, r, payloads.NewInvalidRequestError(r.Context(), UnknownProviderTypeError.Error(), UnknownProviderTypeError))
+			return
+		}
+	}
+
+	if filter.PowerState != "" && !knownPowerState(filter.PowerState) {
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), UnknownPowerStateError.Error(), UnknownPowerStateError))
+		return
+	}
+
+	var refresh bool
+	if value := query.Get("refresh"); value != "" {
+		var err error
+		refresh, err = strconv.ParseBool(value)
+		if err != nil {
+			renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse refresh parameter", err))
+			return
+		}
+	}
+
+	if userScoped(r) {
+		filter.ByCreator = true
+		filter.CreatedByUserID = identity.Identity(r.Context()).Identity.User.UserID
+	}
+
+	limit, offset, err := ParsePage(r)
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse limit or offset parameter", err))
+		return
+	}
+
+	rDao := dao.GetReservationDao(r.Context())
+	instances, err := rDao.ListAllInstances(r.Context(), filter, limit, offset)
+	if err != nil {
+		renderError(w, r, payloads.NewDAOError(r.Context(), "list instances", err))
+		return
+	}
+	total, err := rDao.CountAllInstances(r.Context(), filter)
+	if err != nil {
+		renderError(w, r, payloads.NewDAOError(r.Context(), "count instances", err))
+		return
+	}
+
+	if refresh {
+		refreshPowerStates(r.Context(), instances)
+	}
+
+	if err := render.Render(w, r, payloads.NewInstancePageResponse(r, instances, total, limit, offset)); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render instances list", err))
+	}
+}
+
+func knownPowerState(state models.PowerState) bool {
+	for _, known := range models.PowerStates {
+		if state == known {
+			return true
+		}
+	}
+	return false
+}
+
+// refreshPowerStates fetches the current power state of instances from providers and stores it.
+// It is best effort, instances keep the last known state when the provider cannot be reached.
+func refreshPowerStates(ctx context.Context, instances []*models.Instance) {
+	logger := zerolog.Ctx(ctx)
+	rDao := dao.GetReservationDao(ctx)
+
+	sourcesClient, err := clients.GetSourcesClient(ctx)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Unable to get sources client, power states not refreshed")
+		return
+	}
+
+	// authentication is fetched once per source
+	auths := make(map[string]*clients.Authentication)
+	for _, instance := range instances {
+		// terminated instances never come back
+		if instance.PowerState == models.PowerStateTerminated {
+			continue
+		}
+
+		auth, ok := auths[instance.SourceID]
+		if !ok {
+			auth, err = sourcesClient.GetAuthentication(ctx, instance.SourceID)
+			if err == nil {
+				err = auth.MustBe(instance.Provider)
+			}
+			if err != nil {
+				logger.Warn().Err(err).Str("source_id", instance.SourceID).Msg("Unable to get authentication, power state not refreshed")
+				auth = nil
+			}
+			auths[instance.SourceID] = auth
+		}
+		if auth == nil {
+			continue
+		}
+
+		state, err := fetchPowerState(ctx, instance, auth)
+		if err != nil {
+			logger.Warn().Err(err).Str("instance_id", instance.InstanceID).Msg("Unable to fetch power state")
+			continue
+		}
+		if state == instance.PowerState {
+			continue
+		}
+
+		err = rDao.UpdateInstancePowerState(ctx, instance.ReservationID, instance.InstanceID, state)
+		if err != nil {
+			logger.Warn().Err(err).Str("instance_id", instance.InstanceID).Msg("Unable to store power state")
+			continue
+		}
+		instance.PowerState = state
+	}
+}
+
+func fetchPowerState(ctx context.Context, instance *models.Instance, auth *clients.Authentication) (models.PowerState, error) {
+	provider, err := LookupProvider(instance.Provider)
+	if err != nil {
+		return "", ProviderTypeNotImplementedError
+	}
+	return provider.PowerState(ctx, instance, auth)
+}
+
+// ListOrphanedInstances returns the report of the periodic orphan detection for the organization.
+func ListOrphanedInstances(w http.ResponseWriter, r *http.Request) {
+	limit, offset, err := ParsePage(r)
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse limit or offset parameter", err))
+		return
+	}
+
+	oDao := dao.GetOrphanDao(r.Context())
+	orphans, err := oDao.List(r.Context(), limit, offset)
+	if err != nil {
+		renderError(w, r, payloads.NewDAOError(r.Context(), "list orphaned instances", err))
+		return
+	}
+	total, err := oDao.Count(r.Context())
+	if err != nil {
+		renderError(w, r, payloads.NewDAOError(r.Context(), "count orphaned instances", err))
+		return
+	}
+
+	if err := render.Render(w, r, payloads.NewOrphanedInstancePageResponse(r, orphans, total, limit, offset)); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render orphaned instances list", err))
+	}
+}
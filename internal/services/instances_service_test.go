@@ -0,0 +1,172 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/clients/http/rbac"
+	clientStubs "github.com/RHEnVision/provisioning-backend/internal/clients/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/RHEnVision/provisioning-backend/internal/services"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/factories"
+	tidentity "github.com/RHEnVision/provisioning-backend/internal/testing/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListInstancesHandler(t *testing.T) {
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = tidentity.WithTenant(t, ctx)
+	ctx = clientStubs.WithSourcesClient(ctx)
+	ctx = clientStubs.WithEC2Client(ctx)
+	ctx = stubs.WithReservationDao(ctx)
+	ctx = stubs.WithPubkeyDao(ctx)
+	ctx = rbac.WithAcl(ctx, clients.AllPermissionsRbacAcl)
+
+	pk := factories.NewPubkeyRSA()
+	err := stubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to add stubbed key")
+	reservation := &models.AWSReservation{
+		PubkeyID: pk.ID,
+		SourceID: "1",
+		ImageID:  "ami-0c830793775595d4b",
+		Detail:   &models.AWSDetail{Region: "us-east-1", InstanceType: "t3.small", Amount: 2},
+	}
+	reservation.AccountID = 1
+	reservation.Provider = models.ProviderTypeAWS
+	err = stubs.AddAWSReservation(ctx, reservation)
+	require.NoError(t, err, "failed to add stubbed reservation")
+	for _, id := range []string{"i-0a4caa2cf5b097ce1", clientStubs.TerminatedInstanceID} {
+		instance := &models.ReservationInstance{ReservationID: reservation.ID, InstanceID: id, PowerState: models.PowerStateRunning}
+		err = dao.GetReservationDao(ctx).CreateInstance(ctx, instance)
+		require.NoError(t, err, "failed to add stubbed instance")
+	}
+
+	list := func(t *testing.T, query string) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(ctx, "GET", "/api/provisioning/instances?"+query, nil)
+		require.NoError(t, err, "failed to create request")
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(services.ListInstances).ServeHTTP(rr, req)
+		return rr
+	}
+	decode := func(t *testing.T, rr *httptest.ResponseRecorder) *payloads.InstanceListResponse {
+		t.Helper()
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+		var result payloads.InstanceListResponse
+		err := json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		return &result
+	}
+
+	t.Run("all", func(t *testing.T) {
+		result := decode(t, list(t, ""))
+		require.Len(t, result.Data, 2)
+		assert.Equal(t, int64(2), result.Meta.Total)
+		assert.Equal(t, "aws", result.Data[0].Provider)
+		assert.Equal(t, "us-east-1", result.Data[0].Location)
+		assert.Equal(t, "t3.small", result.Data[0].InstanceType)
+		assert.Equal(t, reservation.ID, result.Data[0].ReservationID)
+	})
+
+	t.Run("filtered", func(t *testing.T) {
+		assert.Len(t, decode(t, list(t, "provider=aws&region=us-east-1")).Data, 2)
+		assert.Empty(t, decode(t, list(t, "provider=azure")).Data)
+		assert.Empty(t, decode(t, list(t, "region=eu-west-1")).Data)
+		assert.Empty(t, decode(t, list(t, "state=stopped")).Data)
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, list(t, "provider=vmware").Code)
+		assert.Equal(t, http.StatusBadRequest, list(t, "state=sleeping").Code)
+	})
+
+	t.Run("refresh", func(t *testing.T) {
+		result := decode(t, list(t, "refresh=true"))
+		require.Len(t, result.Data, 2)
+		assert.Equal(t, models.PowerStateRunning, result.Data[0].PowerState)
+		assert.Equal(t, models.PowerStateTerminated, result.Data[1].PowerState)
+
+		terminated := decode(t, list(t, "state=terminated")).Data
+		require.Len(t, terminated, 1)
+		assert.Equal(t, clientStubs.TerminatedInstanceID, terminated[0].InstanceID)
+	})
+
+	t.Run("user scoped without user id", func(t *testing.T) {
+		defer func(scoped bool) {
+			config.Application.UserScoped = scoped
+		}(config.Application.UserScoped)
+		config.Application.UserScoped = true
+
+		other := &models.AWSReservation{
+			PubkeyID: pk.ID,
+			SourceID: "1",
+			ImageID:  "ami-random",
+			Detail:   &models.AWSDetail{Region: "us-east-1", InstanceType: "t3.small", Amount: 1},
+		}
+		other.AccountID = 1
+		other.CreatedByUserID = "1002"
+		other.Provider = models.ProviderTypeAWS
+		err := stubs.AddAWSReservation(ctx, other)
+		require.NoError(t, err, "failed to add stubbed reservation")
+		instance := &models.ReservationInstance{ReservationID: other.ID, InstanceID: "i-0b5dbb3df6c108df2", PowerState: models.PowerStateRunning}
+		err = dao.GetReservationDao(ctx).CreateInstance(ctx, instance)
+		require.NoError(t, err, "failed to add stubbed instance")
+		require.Len(t, decode(t, list(t, "")).Data, 3)
+
+		id := identity.Identity(ctx)
+		id.Identity.User.UserID = ""
+		scopedCtx := rbac.WithAcl(identity.WithIdentity(ctx, id), clients.NoPermissionsRbacAcl)
+		req, err := http.NewRequestWithContext(scopedCtx, "GET", "/api/provisioning/instances", nil)
+		require.NoError(t, err, "failed to create request")
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(services.ListInstances).ServeHTTP(rr, req)
+
+		result := decode(t, rr)
+		require.Len(t, result.Data, 2)
+		for _, instance := range result.Data {
+			assert.Equal(t, reservation.ID, instance.ReservationID)
+		}
+	})
+}
+
+func TestListOrphanedInstancesHandler(t *testing.T) {
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = tidentity.WithTenant(t, ctx)
+	ctx = stubs.WithOrphanDao(ctx)
+	ctx = rbac.WithAcl(ctx, clients.AllPermissionsRbacAcl)
+
+	_, err := dao.GetOrphanDao(ctx).ReplaceBySource(ctx, models.ProviderTypeAWS, "1", "us-east-1", []*models.OrphanedInstance{
+		{Location: "us-east-1", InstanceID: clientStubs.UntrackedInstanceID, ReservationID: 1, Kind: models.OrphanKindUntracked},
+		{Location: "us-east-1", InstanceID: clientStubs.TerminatedInstanceID, ReservationID: 1, Kind: models.OrphanKindMissing},
+	})
+	require.NoError(t, err, "failed to add stubbed orphans")
+
+	req, err := http.NewRequestWithContext(ctx, "GET", "/api/provisioning/instances/orphaned?limit=1", nil)
+	require.NoError(t, err, "failed to create request")
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(services.ListOrphanedInstances).ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+	var result payloads.OrphanedInstanceListResponse
+	err = json.NewDecoder(rr.Body).Decode(&result)
+	require.NoError(t, err, "failed to decode response body")
+
+	require.Len(t, result.Data, 1)
+	assert.Equal(t, int64(2), result.Meta.Total)
+	assert.Equal(t, clientStubs.UntrackedInstanceID, result.Data[0].InstanceID)
+	assert.Equal(t, models.OrphanKindUntracked, result.Data[0].Kind)
+	assert.Equal(t, "aws", result.Data[0].Provider)
+	assert.Equal(t, "1", result.Data[0].SourceID)
+}
@@ -41,8 +41,16 @@ func ListLaunchTemplates(w http.ResponseWriter, r *http.Request) {
 func ListLaunchTemplateAWS(w http.ResponseWriter, r *http.Request) {
 	sourceId := chi.URLParam(r, "ID")
 	region := r.URL.Query().Get("region")
+	if region == "" {
+		var err error
+		if region, err = sourceDefaultRegion(r.Context(), sourceId); err != nil {
+			renderError(w, r, payloads.NewDAOError(r.Context(), "get source settings", err))
+			return
+		}
+	}
 	if region == "" {
 		renderError(w, r, payloads.NewMissingRequestParameterError(r.Context(), "region parameter is missing"))
+		return
 	}
 
 	sourcesClient, err := clients.GetSourcesClient(r.Context())
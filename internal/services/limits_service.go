@@ -0,0 +1,31 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/RHEnVision/provisioning-backend/internal/ratelimit"
+	"github.com/go-chi/render"
+)
+
+// GetLimits returns current rate limit consumption of the account, the request itself is not counted.
+func GetLimits(w http.ResponseWriter, r *http.Request) {
+	if !config.Application.RateLimit.Enabled {
+		if err := render.Render(w, r, payloads.NewLimitsResponse(nil)); err != nil {
+			renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render limits", err))
+		}
+		return
+	}
+
+	status, err := ratelimit.Current(r.Context())
+	if err != nil {
+		renderError(w, r, payloads.NewResponseError(r.Context(), http.StatusInternalServerError, "unable to read rate limit", err))
+		return
+	}
+
+	status.SetHeaders(w.Header())
+	if err := render.Render(w, r, payloads.NewLimitsResponse(&status)); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render limits", err))
+	}
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+)
+
+// Network interface limits, the Azure limit does not include the primary interface
+const (
+	awsMaxNetworkInterfaces   = 8
+	azureMaxNetworkInterfaces = 7
+)
+
+// networkInterfaces validates requested network interfaces and converts them to the model. Static
+// private addresses cannot be shared, so they are only allowed when a single instance is launched.
+func networkInterfaces(provider models.ProviderType, requested []payloads.NetworkInterfaceRequest, amount int64) ([]models.NetworkInterface, error) {
+	if len(requested) == 0 {
+		return nil, nil
+	}
+
+	limit := awsMaxNetworkInterfaces
+	if provider == models.ProviderTypeAzure {
+		limit = azureMaxNetworkInterfaces
+	}
+	if len(requested) > limit {
+		return nil, fmt.Errorf("%w: at most %d allowed", TooManyNetworkInterfacesError, limit)
+	}
+
+	result := make([]models.NetworkInterface, len(requested))
+	for i, nic := range requested {
+		if err := checkNetworkInterface(provider, nic); err != nil {
+			return nil, fmt.Errorf("%w: interface %d", err, i)
+		}
+		if nic.PrivateIPv4 != "" && amount != 1 {
+			return nil, fmt.Errorf("%w: interface %d", PrivateIPWithAmountError, i)
+		}
+		result[i] = models.NetworkInterface{
+			SubnetID:         nic.SubnetID,
+			SecurityGroupIDs: nic.SecurityGroupIDs,
+			PrivateIPv4:      nic.PrivateIPv4,
+		}
+	}
+	return result, nil
+}
+
+// checkNetworkInterface checks the format of IDs and addresses, existence of the subnet and
+// security groups is checked by the cloud provider on launch.
+func checkNetworkInterface(provider models.ProviderType, nic payloads.NetworkInterfaceRequest) error {
+	if nic.PrivateIPv4 != "" && net.ParseIP(nic.PrivateIPv4).To4() == nil {
+		return InvalidPrivateIPError
+	}
+
+	if provider == models.ProviderTypeAzure {
+		if !strings.Contains(strings.ToLower(nic.SubnetID), "/subnets/") {
+			return InvalidSubnetError
+		}
+		if len(nic.SecurityGroupIDs) > 1 {
+			return InvalidSecurityGroupError
+		}
+		for _, sg := range nic.SecurityGroupIDs {
+			if !strings.Contains(strings.ToLower(sg), "/networksecuritygroups/") {
+				return InvalidSecurityGroupError
+			}
+		}
+		return nil
+	}
+
+	if !strings.HasPrefix(nic.SubnetID, "subnet-") {
+		return InvalidSubnetError
+	}
+	for _, sg := range nic.SecurityGroupIDs {
+		if !strings.HasPrefix(sg, "sg-") {
+			return InvalidSecurityGroupError
+		}
+	}
+	return nil
+}
+
+// checkAWSSubnets validates subnets and security groups of the primary interface, they replace the
+// single network interface and cannot be combined with network interfaces.
+func checkAWSSubnets(subnetIDs, securityGroupIDs []string, nics []models.NetworkInterface) error {
+	if len(subnetIDs) == 0 && len(securityGroupIDs) == 0 {
+		return nil
+	}
+	if len(nics) > 0 {
+		return SubnetsWithNetworkInterfacesError
+	}
+	for _, subnet := range subnetIDs {
+		if !strings.HasPrefix(subnet, "subnet-") {
+			return fmt.Errorf("%w: %s", InvalidSubnetError, subnet)
+		}
+	}
+	for _, sg := range securityGroupIDs {
+		if !strings.HasPrefix(sg, "sg-") {
+			return fmt.Errorf("%w: %s", InvalidSecurityGroupError, sg)
+		}
+	}
+	return nil
+}
+
+// checkPrivateIPs validates static private addresses of the primary interface, one address is
+// required for each instance.
+func checkPrivateIPs(ips []string, amount int64, cidr string) error {
+	if int64(len(ips)) != amount {
+		return fmt.Errorf("%w: %d addresses for %d instances", PrivateIPCountMismatchError, len(ips), amount)
+	}
+
+	_, subnet, err := net.ParseCIDR(cidr)
+	if err != nil || subnet.IP.To4() == nil {
+		return fmt.Errorf("%w: %s", InvalidSubnetError, cidr)
+	}
+
+	seen := make(map[string]struct{}, len(ips))
+	for _, address := range ips {
+		ip := net.ParseIP(address).To4()
+		if ip == nil {
+			return fmt.Errorf("%w: %s", InvalidPrivateIPError, address)
+		}
+		if _, ok := seen[ip.String()]; ok {
+			return fmt.Errorf("%w: %s", DuplicatePrivateIPError, address)
+		}
+		seen[ip.String()] = struct{}{}
+		if !usableSubnetAddress(subnet, ip) {
+			return fmt.Errorf("%w: %s is not usable in %s", PrivateIPOutsideSubnetError, address, cidr)
+		}
+	}
+	return nil
+}
+
+// usableSubnetAddress returns false for addresses outside the subnet and addresses reserved by
+// both AWS and Azure, which are the first four and the last address of a subnet.
+func usableSubnetAddress(subnet *net.IPNet, ip net.IP) bool {
+	if !subnet.Contains(ip) {
+		return false
+	}
+	ones, bits := subnet.Mask.Size()
+	size := uint64(1) << (bits - ones)
+	offset := uint64(binary.BigEndian.Uint32(ip) - binary.BigEndian.Uint32(subnet.IP.To4()))
+	return offset >= 4 && offset < size-1
+}
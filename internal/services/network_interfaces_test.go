@@ -0,0 +1,62 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckPrivateIPs(t *testing.T) {
+	tests := []struct {
+		ips      []string
+		amount   int64
+		cidr     string
+		expected error
+	}{
+		{[]string{"10.0.1.10", "10.0.1.11"}, 2, "10.0.0.0/16", nil},
+		{[]string{"10.0.1.10"}, 2, "10.0.0.0/16", PrivateIPCountMismatchError},
+		{[]string{"10.0.1.10", "10.0.1.10"}, 2, "10.0.0.0/16", DuplicatePrivateIPError},
+		{[]string{"10.0.1"}, 1, "10.0.0.0/16", InvalidPrivateIPError},
+		{[]string{"fd00::10"}, 1, "10.0.0.0/16", InvalidPrivateIPError},
+		{[]string{"10.1.0.10"}, 1, "10.0.0.0/16", PrivateIPOutsideSubnetError},
+		{[]string{"10.0.0.3"}, 1, "10.0.0.0/16", PrivateIPOutsideSubnetError},
+		{[]string{"10.0.255.255"}, 1, "10.0.0.0/16", PrivateIPOutsideSubnetError},
+		{[]string{"172.22.0.4"}, 1, "172.22.0.0/16", nil},
+		{[]string{"10.0.1.10"}, 1, "", InvalidSubnetError},
+	}
+	for _, tt := range tests {
+		err := checkPrivateIPs(tt.ips, tt.amount, tt.cidr)
+		if tt.expected == nil {
+			assert.NoError(t, err, "%v in %s", tt.ips, tt.cidr)
+		} else {
+			assert.ErrorIs(t, err, tt.expected, "%v in %s", tt.ips, tt.cidr)
+		}
+	}
+}
+
+func TestCheckAWSSubnets(t *testing.T) {
+	nics := []models.NetworkInterface{{SubnetID: "subnet-0d2b4f8e1a9c3b7e5"}}
+	tests := []struct {
+		name     string
+		subnets  []string
+		groups   []string
+		nics     []models.NetworkInterface
+		expected error
+	}{
+		{"none", nil, nil, nics, nil},
+		{"subnets", []string{"subnet-0d2b4f8e1a9c3b7e5", "subnet-0f3c5a9b2e8d1c4a6"}, []string{"sg-07a7a4c3f5d6e8b91"}, nil, nil},
+		{"security groups only", nil, []string{"sg-07a7a4c3f5d6e8b91"}, nil, nil},
+		{"invalid subnet", []string{"vpc-0a1b2c3d4e5f67890"}, nil, nil, InvalidSubnetError},
+		{"invalid security group", nil, []string{"default"}, nil, InvalidSecurityGroupError},
+		{"with network interfaces", []string{"subnet-0d2b4f8e1a9c3b7e5"}, nil, nics, SubnetsWithNetworkInterfacesError},
+	}
+	for _, tt := range tests {
+		err := checkAWSSubnets(tt.subnets, tt.groups, tt.nics)
+		if tt.expected == nil {
+			assert.NoError(t, err, tt.name)
+		} else {
+			assert.ErrorIs(t, err, tt.expected, tt.name)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// ListSubnets lists subnets of an AWS source in a region, so they can be picked for reservations.
+func ListSubnets(w http.ResponseWriter, r *http.Request) {
+	ec2Client := sourceEC2Client(w, r)
+	if ec2Client == nil {
+		return
+	}
+
+	subnets, err := ec2Client.ListSubnets(r.Context())
+	if err != nil {
+		renderError(w, r, payloads.NewAWSError(r.Context(), "unable to list AWS EC2 subnets", err))
+		return
+	}
+
+	if err := render.Render(w, r, payloads.NewListSubnetResponse(subnets)); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render subnets list", err))
+		return
+	}
+}
+
+// ListSecurityGroups lists security groups of an AWS source in a region, so they can be picked
+// for reservations.
+func ListSecurityGroups(w http.ResponseWriter, r *http.Request) {
+	ec2Client := sourceEC2Client(w, r)
+	if ec2Client == nil {
+		return
+	}
+
+	groups, err := ec2Client.ListSecurityGroups(r.Context())
+	if err != nil {
+		renderError(w, r, payloads.NewAWSError(r.Context(), "unable to list AWS EC2 security groups", err))
+		return
+	}
+
+	if err := render.Render(w, r, payloads.NewListSecurityGroupResponse(groups)); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render security groups list", err))
+		return
+	}
+}
+
+// sourceEC2Client returns EC2 client of the source and region from URL parameters. Only AWS
+// sources are supported. It renders an error and returns nil on failure.
+func sourceEC2Client(w http.ResponseWriter, r *http.Request) clients.EC2 {
+	sourceId := chi.URLParam(r, "ID")
+
+	sourcesClient, err := clients.GetSourcesClient(r.Context())
+	if err != nil {
+		renderError(w, r, payloads.NewClientError(r.Context(), err))
+		return nil
+	}
+
+	authentication, err := sourcesClient.GetAuthentication(r.Context(), sourceId)
+	if err != nil {
+		renderError(w, r, payloads.NewClientError(r.Context(), err))
+		return nil
+	}
+
+	if authentication.ProviderType != models.ProviderTypeAWS {
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "only AWS sources are supported", ProviderTypeNotImplementedError))
+		return nil
+	}
+
+	region := r.URL.Query().Get("region")
+	if region == "" {
+		if region, err = sourceDefaultRegion(r.Context(), sourceId); err != nil {
+			renderError(w, r, payloads.NewDAOError(r.Context(), "get source settings", err))
+			return nil
+		}
+	}
+	if region == "" {
+		renderError(w, r, payloads.NewMissingRequestParameterError(r.Context(), "region parameter is missing"))
+		return nil
+	}
+
+	ec2Client, err := clients.GetEC2Client(r.Context(), authentication, region)
+	if err != nil {
+		renderError(w, r, payloads.NewAWSError(r.Context(), "unable to get AWS EC2 client", err))
+		return nil
+	}
+	return ec2Client
+}
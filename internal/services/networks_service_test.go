@@ -0,0 +1,90 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	clientStub "github.com/RHEnVision/provisioning-backend/internal/clients/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/RHEnVision/provisioning-backend/internal/services"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/identity"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListNetworksHandler(t *testing.T) {
+	serve := func(t *testing.T, path string, handler http.HandlerFunc) *httptest.ResponseRecorder {
+		t.Helper()
+		ctx := stubs.WithAccountDaoOne(context.Background())
+		ctx = identity.WithTenant(t, ctx)
+		ctx = clientStub.WithSourcesClient(ctx)
+		ctx = clientStub.WithEC2Client(ctx)
+
+		rctx := chi.NewRouteContext()
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+		rctx.URLParams.Add("ID", "1")
+		req, err := http.NewRequestWithContext(ctx, "GET", path, nil)
+		require.NoError(t, err, "failed to create request")
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("subnets", func(t *testing.T) {
+		rr := serve(t, "/api/provisioning/sources/1/subnets?region=us-east-1", services.ListSubnets)
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.SubnetListResponse
+		err := json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+
+		require.Len(t, result.Data, 2)
+		assert.Equal(t, "subnet-0d2b4f8e1a9c3b7e5", result.Data[0].ID)
+		assert.Equal(t, "10.0.1.0/24", result.Data[0].CIDR)
+		assert.True(t, result.Data[1].Default)
+	})
+
+	t.Run("security groups", func(t *testing.T) {
+		rr := serve(t, "/api/provisioning/sources/1/security_groups?region=us-east-1", services.ListSecurityGroups)
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.SecurityGroupListResponse
+		err := json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+
+		require.Len(t, result.Data, 1)
+		assert.Equal(t, "sg-07a7a4c3f5d6e8b91", result.Data[0].ID)
+	})
+
+	t.Run("dedicated hosts", func(t *testing.T) {
+		rr := serve(t, "/api/provisioning/sources/1/dedicated_hosts?region=us-east-1", services.ListDedicatedHosts)
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.DedicatedHostListResponse
+		err := json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+
+		require.Len(t, result.Data, 1)
+		assert.Equal(t, "h-0a1b2c3d4e5f67890", result.Data[0].ID)
+		assert.True(t, result.Data[0].AutoPlacement)
+	})
+
+	t.Run("capacity reservations", func(t *testing.T) {
+		rr := serve(t, "/api/provisioning/sources/1/capacity_reservations?region=us-east-1", services.ListCapacityReservations)
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.CapacityReservationListResponse
+		err := json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+
+		require.Len(t, result.Data, 1)
+		assert.Equal(t, "cr-0a1b2c3d4e5f67890", result.Data[0].ID)
+		assert.Equal(t, int32(4), result.Data[0].AvailableInstances)
+	})
+}
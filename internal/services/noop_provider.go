@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/RHEnVision/provisioning-backend/pkg/worker"
+	"github.com/go-chi/render"
+)
+
+// noopProvider creates reservations processed without any operation, it has no instances.
+type noopProvider struct{}
+
+func init() {
+	RegisterProvider(models.ProviderTypeNoop, noopProvider{})
+}
+
+func (noopProvider) Enabled(_ context.Context) bool {
+	return true
+}
+
+func (noopProvider) ListInstanceTypes(_, _ string, _ *bool) ([]*clients.InstanceType, error) {
+	return nil, nil
+}
+
+func (noopProvider) ValidateRequest(ctx context.Context, _ RequestDecoder, query url.Values) (render.Binder, error) {
+	request, err := validateNoopRequest(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// Launch returns ID of the first reservation, load testing requests create multiple reservations.
+func (noopProvider) Launch(ctx context.Context, request render.Binder) (int64, render.Renderer, error) {
+	payload, ok := request.(*noopReservationRequest)
+	if !ok {
+		return 0, nil, fmt.Errorf("%w: %T", UnexpectedRequestTypeError, request)
+	}
+
+	reservations, err := launchNoopReservations(ctx, payload)
+	if err != nil {
+		return 0, nil, err
+	}
+	return reservations[0].ID, payloads.NewNoopReservationResponse(reservations), nil
+}
+
+func (noopProvider) DescribeReservation(_ context.Context, reservation *models.Reservation, _ []*models.ReservationInstance) (render.Renderer, error) {
+	return payloads.NewReservationResponse(reservation), nil
+}
+
+func (noopProvider) InstanceLocation(_ context.Context, _ int64) (*ReservationLocation, error) {
+	return nil, ProviderTypeNotImplementedError
+}
+
+func (noopProvider) PowerState(_ context.Context, _ *models.Instance, _ *clients.Authentication) (models.PowerState, error) {
+	return "", ProviderTypeNotImplementedError
+}
+
+// PowerJobType returns no type, power actions are rejected by InstanceLocation.
+func (noopProvider) PowerJobType() worker.JobType {
+	return ""
+}
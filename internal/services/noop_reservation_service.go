@@ -1,60 +1,132 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"net/url"
+	"time"
 
+	"github.com/RHEnVision/provisioning-backend/internal/config"
 	"github.com/RHEnVision/provisioning-backend/internal/dao"
 	"github.com/RHEnVision/provisioning-backend/internal/identity"
 	"github.com/RHEnVision/provisioning-backend/internal/jobs"
 	"github.com/RHEnVision/provisioning-backend/internal/models"
 	"github.com/RHEnVision/provisioning-backend/internal/payloads"
-	"github.com/RHEnVision/provisioning-backend/internal/queue"
+	"github.com/RHEnVision/provisioning-backend/internal/random"
 	"github.com/RHEnVision/provisioning-backend/pkg/worker"
-	"github.com/go-chi/render"
 	"github.com/rs/zerolog"
 )
 
+// MaxNoopReservationCount is the maximum amount of noop reservations created in one request
+const MaxNoopReservationCount = 1000
+
+// MaxNoopReservationSleep is the maximum sleep of a single noop job
+const MaxNoopReservationSleep = 10 * time.Minute
+
+var (
+	LoadTestingDisabledError    = errors.New("load testing is not enabled")
+	InvalidNoopCountError       = errors.New("count must be between 1 and 1000")
+	InvalidNoopSleepError       = errors.New("sleep must be between 0 and 10 minutes")
+	InvalidNoopFailureRateError = errors.New("failure rate must be between 0.0 and 1.0")
+)
+
 // CreateNoopReservation is used to create empty reservation that is processed without any operation
 // being made. This is useful when testing the job queue. The endpoint has no payload.
+//
+// For load testing, multiple reservations can be created at once via the count query parameter,
+// jobs can be delayed (sleep) and randomly failed (failure_rate). These parameters are only
+// available when the load testing flag is enabled.
 func CreateNoopReservation(w http.ResponseWriter, r *http.Request) {
-	logger := zerolog.Ctx(r.Context())
-	accountId := identity.AccountId(r.Context())
-	identity := identity.Identity(r.Context())
-	rDao := dao.GetReservationDao(r.Context())
-	reservation := &models.NoopReservation{
-		Reservation: models.Reservation{
-			Provider:   models.ProviderTypeNoop,
-			AccountID:  accountId,
-			Status:     "Created",
-			Steps:      1,
-			StepTitles: []string{"A test step"},
-		},
-	}
-
-	// create reservation in the database
-	err := rDao.CreateNoop(r.Context(), reservation)
+	launchReservation(w, r, noopProvider{})
+}
+
+// noopReservationRequest holds the query parameters of a noop reservation.
+type noopReservationRequest struct {
+	count       uint
+	sleep       time.Duration
+	failureRate float32
+}
+
+func (p *noopReservationRequest) Bind(_ *http.Request) error {
+	return nil
+}
+
+// validateNoopRequest parses and validates query parameters of a noop reservation.
+func validateNoopRequest(ctx context.Context, query url.Values) (*noopReservationRequest, error) {
+	count, err := ParseUint(query.Get("count"), 1)
 	if err != nil {
-		renderError(w, r, payloads.NewDAOError(r.Context(), "create noop reservation", err))
-		return
+		return nil, withResponse(payloads.NewURLParsingError(ctx, "unable to parse count parameter", err), err)
 	}
-	logger.Debug().Msgf("Created a new reservation %d", reservation.ID)
-
-	// create a new job
-	pj := worker.Job{
-		Type:      jobs.TypeNoop,
-		AccountID: accountId,
-		Identity:  identity,
-		Args: jobs.NoopJobArgs{
-			ReservationID: reservation.ID,
-		},
+	sleep, err := ParseDuration(query.Get("sleep"))
+	if err != nil {
+		return nil, withResponse(payloads.NewURLParsingError(ctx, "unable to parse sleep parameter", err), err)
 	}
-	err = queue.GetEnqueuer(r.Context()).Enqueue(r.Context(), &pj)
+	failureRate, err := ParseFloat32(query.Get("failure_rate"))
 	if err != nil {
-		renderError(w, r, payloads.NewEnqueueTaskError(r.Context(), "job enqueue error", err))
-		return
+		return nil, withResponse(payloads.NewURLParsingError(ctx, "unable to parse failure_rate parameter", err), err)
 	}
 
-	if err := render.Render(w, r, payloads.NewNoopReservationResponse(reservation)); err != nil {
-		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render reservation", err))
+	if (count != 1 || sleep != 0 || failureRate != 0) && !config.LoadTestingEnabled(ctx) {
+		return nil, withResponse(payloads.NewFeatureDisabledError(ctx, "load testing parameters are not allowed", LoadTestingDisabledError), LoadTestingDisabledError)
+	}
+	if count < 1 || count > MaxNoopReservationCount {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, "count parameter", InvalidNoopCountError), InvalidNoopCountError)
+	}
+	if sleep < 0 || sleep > MaxNoopReservationSleep {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, "sleep parameter", InvalidNoopSleepError), InvalidNoopSleepError)
+	}
+	if failureRate < 0 || failureRate > 1 {
+		return nil, withResponse(payloads.NewInvalidRequestError(ctx, "failure_rate parameter", InvalidNoopFailureRateError), InvalidNoopFailureRateError)
+	}
+	return &noopReservationRequest{count: count, sleep: sleep, failureRate: failureRate}, nil
+}
+
+// launchNoopReservations creates the noop reservations of the request and enqueues their jobs.
+func launchNoopReservations(ctx context.Context, request *noopReservationRequest) ([]*models.NoopReservation, error) {
+	logger := zerolog.Ctx(ctx)
+	accountId := identity.AccountId(ctx)
+	identity := identity.Identity(ctx)
+	rDao := dao.GetReservationDao(ctx)
+
+	reservations := make([]*models.NoopReservation, 0, request.count)
+	for i := uint(0); i < request.count; i++ {
+		reservation := &models.NoopReservation{
+			Reservation: models.Reservation{
+				Provider:   models.ProviderTypeNoop,
+				AccountID:  accountId,
+				Status:     "Created",
+				Steps:      1,
+				StepTitles: []string{"A test step"},
+			},
+		}
+
+		// create reservation in the database
+		err := rDao.CreateNoop(ctx, reservation)
+		if err != nil {
+			return nil, withResponse(payloads.NewDAOError(ctx, "create noop reservation", err), err)
+		}
+		logger.Debug().Msgf("Created a new reservation %d", reservation.ID)
+
+		// create a new job
+		pj := worker.Job{
+			Type:      jobs.TypeNoop,
+			AccountID: accountId,
+			Identity:  identity,
+			Args: jobs.NoopJobArgs{
+				ReservationID: reservation.ID,
+				Sleep:         request.sleep,
+				Fail:          request.failureRate > 0 && random.Float32() < request.failureRate,
+			},
+		}
+		err = enqueueReservationJob(ctx, &reservation.Reservation, 0, &pj)
+		if err != nil {
+			return nil, withResponse(payloads.NewEnqueueTaskError(ctx, "job enqueue error", err), err)
+		}
+		reservations = append(reservations, reservation)
+	}
+	if request.count > 1 {
+		logger.Info().Msgf("Created %d noop reservations for load testing", request.count)
 	}
+	return reservations, nil
 }
@@ -1,11 +1,39 @@
 package services_test
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/services"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/identity"
 	_ "github.com/RHEnVision/provisioning-backend/internal/testing/initialization"
+	"github.com/stretchr/testify/require"
 )
 
-func TestCreateNoopReservationHandler(t *testing.T) {
-	// TODO full service test
+func TestCreateNoopReservationInvalidParameters(t *testing.T) {
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = identity.WithTenant(t, ctx)
+	ctx = stubs.WithReservationDao(ctx)
+
+	for query, expected := range map[string]int{
+		"count=0":           http.StatusBadRequest,
+		"count=1001":        http.StatusBadRequest,
+		"count=-1":          http.StatusBadRequest,
+		"sleep=1h":          http.StatusBadRequest,
+		"sleep=x":           http.StatusBadRequest,
+		"failure_rate=1.5":  http.StatusBadRequest,
+		"failure_rate=-0.1": http.StatusBadRequest,
+	} {
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/noop?"+query, nil)
+		require.NoError(t, err, "failed to create request")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateNoopReservation)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, expected, rr.Code, "Wrong status code for %s", query)
+	}
 }
@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/ptr"
 	"github.com/go-chi/chi/v5"
 )
 
@@ -39,3 +42,85 @@ func ParseBool(str string) (*bool, error) {
 	}
 	return &b, nil
 }
+
+// ParseDuration converts string into duration (e.g. "30s"). Returns zero when string is empty.
+func ParseDuration(str string) (time.Duration, error) {
+	if str == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(str)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing '%s' to duration: %w", str, err)
+	}
+	return d, nil
+}
+
+// ParseFloat32 converts string into float32. Returns zero when string is empty.
+func ParseFloat32(str string) (float32, error) {
+	if str == "" {
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(str, 32)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing '%s' to float: %w", str, err)
+	}
+	return float32(f), nil
+}
+
+// ParseUint converts string into uint. Returns the default value when string is empty.
+func ParseUint(str string, def uint) (uint, error) {
+	if str == "" {
+		return def, nil
+	}
+	u, err := strconv.ParseUint(str, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing '%s' to uint: %w", str, err)
+	}
+	return uint(u), nil
+}
+
+// DefaultPageLimit is the page size of list endpoints when limit query parameter is not present.
+const DefaultPageLimit = 100
+
+// ParsePage converts limit and offset query parameters, limit is clamped between 1 and
+// DefaultPageLimit like v1 lists which returned the first page regardless of the limit.
+func ParsePage(r *http.Request) (int64, int64, error) {
+	limit, err := ParseUint(r.URL.Query().Get("limit"), DefaultPageLimit)
+	if err != nil {
+		return 0, 0, fmt.Errorf("limit: %w", err)
+	}
+	if limit == 0 {
+		limit = 1
+	} else if limit > DefaultPageLimit {
+		limit = DefaultPageLimit
+	}
+	offset, err := ParseUint(r.URL.Query().Get("offset"), 0)
+	if err != nil {
+		return 0, 0, fmt.Errorf("offset: %w", err)
+	}
+	return int64(limit), int64(offset), nil
+}
+
+// ParseMeta converts the meta query parameter, v1 list responses contain only the data array
+// unless pagination metadata and links are requested.
+func ParseMeta(r *http.Request) (bool, error) {
+	meta, err := ParseBool(r.URL.Query().Get("meta"))
+	if err != nil {
+		return false, fmt.Errorf("meta: %w", err)
+	}
+	return ptr.FromOrEmpty(meta), nil
+}
+
+// ParseArchitecture converts the architecture query parameter, returns empty string when it is
+// not present.
+func ParseArchitecture(r *http.Request) (clients.ArchitectureType, error) {
+	str := r.URL.Query().Get("architecture")
+	if str == "" {
+		return "", nil
+	}
+	arch, err := clients.MapArchitectures(r.Context(), str)
+	if err != nil {
+		return "", fmt.Errorf("error parsing '%s' to architecture: %w", str, err)
+	}
+	return arch, nil
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -23,16 +24,24 @@ func removeEmptyStrings(s []string) []string {
 	return r
 }
 
-// CheckPermissionAndRender can be used to perform an extra permission check that is more detailed than the one
-// performed by the middleware. Do not use this function as the only permission check, permissions should always
-// be enforced via middleware as a bare minimum.
-func CheckPermissionAndRender(w http.ResponseWriter, r *http.Request, permission string, resources ...string) error {
+// CheckPermission can be used to perform an extra permission check that is more detailed than the one
+// performed by the middleware. The returned error carries a forbidden response. Do not use this function
+// as the only permission check, permissions should always be enforced via middleware as a bare minimum.
+func CheckPermission(ctx context.Context, permission string, resources ...string) error {
 	resource := strings.Join(removeEmptyStrings(resources), ".")
-	if !rbac.Acl(r.Context()).IsAllowed(resource, permission) {
+	if !rbac.Acl(ctx).IsAllowed(resource, permission) {
 		permErr := fmt.Errorf("%w: %s on %s", ErrMissingExtraPermission, permission, resource)
-		renderError(w, r, payloads.NewMissingPermissionError(r.Context(), resource, permission, permErr))
-		return permErr
+		return withResponse(payloads.NewMissingPermissionError(ctx, resource, permission, permErr), permErr)
 	}
 
 	return nil
 }
+
+// CheckPermissionAndRender performs CheckPermission and renders the error, see CheckPermission.
+func CheckPermissionAndRender(w http.ResponseWriter, r *http.Request, permission string, resources ...string) error {
+	err := CheckPermission(r.Context(), permission, resources...)
+	if err != nil {
+		renderResponseError(w, r, err)
+	}
+	return err
+}
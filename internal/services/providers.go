@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/RHEnVision/provisioning-backend/pkg/worker"
+	"github.com/go-chi/render"
+)
+
+// RequestDecoder decodes a reservation request into the provider specific payload.
+type RequestDecoder func(payload render.Binder) error
+
+// Provider implements reservation operations of a single provider type. Providers register
+// themselves with RegisterProvider from init functions, generic endpoints look up the provider
+// by the reservation type and dispatch to it, so adding a provider does not change them.
+//
+// The service never terminates reservations, instances are terminated in the cloud account and
+// picked up by the orphan detection, so there is no terminate operation. Jobs are registered by
+// their job type, launch and power jobs of a provider are enqueued by the operations below.
+type Provider interface {
+	// Enabled returns false when reservations of the provider are not available for the request,
+	// e.g. behind a feature flag.
+	Enabled(ctx context.Context) bool
+
+	// ListInstanceTypes returns built-in instance types of a region and zone, all types are
+	// returned when supported is nil.
+	ListInstanceTypes(region, zone string, supported *bool) ([]*clients.InstanceType, error)
+
+	// ValidateRequest decodes and validates a reservation request, URL parameters are passed in
+	// query. The returned request is passed to Launch.
+	ValidateRequest(ctx context.Context, decode RequestDecoder, query url.Values) (render.Binder, error)
+
+	// Launch checks the request against the cloud account, creates the reservation and enqueues
+	// its launch job. Returns ID and response of the created reservation.
+	Launch(ctx context.Context, request render.Binder) (int64, render.Renderer, error)
+
+	// DescribeReservation returns the response of a reservation with provider details.
+	DescribeReservation(ctx context.Context, reservation *models.Reservation, instances []*models.ReservationInstance) (render.Renderer, error)
+
+	// InstanceLocation returns the source, region and zone of instances of a reservation.
+	InstanceLocation(ctx context.Context, id int64) (*ReservationLocation, error)
+
+	// PowerState returns the current power state of an instance.
+	PowerState(ctx context.Context, instance *models.Instance, auth *clients.Authentication) (models.PowerState, error)
+
+	// PowerJobType returns the job type of instance power actions.
+	PowerJobType() worker.JobType
+}
+
+// ReservationLocation describes where instances of a reservation were launched.
+type ReservationLocation struct {
+	SourceID string
+
+	// Region or zone is blank when not used by the provider.
+	Region string
+	Zone   string
+
+	// Instance type of the launch, blank when not known (e.g. AWS launch templates).
+	InstanceType string
+}
+
+var providers = make(map[models.ProviderType]Provider)
+
+// RegisterProvider registers the provider of a type, it is not safe for concurrent use and must
+// be called from init functions.
+func RegisterProvider(pType models.ProviderType, provider Provider) {
+	providers[pType] = provider
+}
+
+// LookupProvider returns the registered provider of a type.
+func LookupProvider(pType models.ProviderType) (Provider, error) {
+	provider, ok := providers[pType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", UnknownProviderTypeError, pType)
+	}
+	return provider, nil
+}
+
+// ListProviderInstanceTypes lists built-in instance types of a registered provider.
+func ListProviderInstanceTypes(pType models.ProviderType) func(w http.ResponseWriter, r *http.Request) {
+	return ListBuiltinInstanceTypes(func(region, zone string, supported *bool) ([]*clients.InstanceType, error) {
+		provider, err := LookupProvider(pType)
+		if err != nil {
+			return nil, err
+		}
+		return provider.ListInstanceTypes(region, zone, supported)
+	})
+}
+
+// launchReservation validates the request of a provider and launches it, the created reservation
+// or an error is rendered.
+func launchReservation(w http.ResponseWriter, r *http.Request, provider Provider) {
+	request, err := provider.ValidateRequest(r.Context(), func(payload render.Binder) error {
+		return render.Bind(r, payload)
+	}, r.URL.Query())
+	if err != nil {
+		renderResponseError(w, r, err)
+		return
+	}
+
+	_, response, err := provider.Launch(r.Context(), request)
+	if err != nil {
+		renderResponseError(w, r, err)
+		return
+	}
+
+	if err := render.Render(w, r, response); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render reservation", err))
+	}
+}
@@ -0,0 +1,36 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupProvider(t *testing.T) {
+	t.Run("registered", func(t *testing.T) {
+		for _, pType := range []models.ProviderType{models.ProviderTypeNoop, models.ProviderTypeAWS, models.ProviderTypeAzure, models.ProviderTypeGCP} {
+			provider, err := LookupProvider(pType)
+			require.NoError(t, err, pType.String())
+			assert.NotNil(t, provider, pType.String())
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		_, err := LookupProvider(models.ProviderTypeUnknown)
+		assert.ErrorIs(t, err, UnknownProviderTypeError)
+	})
+}
+
+func TestProviderLaunchRequestType(t *testing.T) {
+	for _, pType := range []models.ProviderType{models.ProviderTypeNoop, models.ProviderTypeAWS, models.ProviderTypeAzure, models.ProviderTypeGCP} {
+		provider, err := LookupProvider(pType)
+		require.NoError(t, err, pType.String())
+
+		_, _, err = provider.Launch(context.Background(), &payloads.ResizeInstanceRequest{})
+		assert.ErrorIs(t, err, UnexpectedRequestTypeError, pType.String())
+	}
+}
@@ -49,13 +49,38 @@ func CreatePubkey(w http.ResponseWriter, r *http.Request) {
 func ListPubkeys(w http.ResponseWriter, r *http.Request) {
 	pubkeyDao := dao.GetPubkeyDao(r.Context())
 
-	pubkeys, err := pubkeyDao.List(r.Context(), 100, 0)
+	limit, offset, err := ParsePage(r)
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse limit or offset parameter", err))
+		return
+	}
+
+	meta, err := ParseMeta(r)
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse meta parameter", err))
+		return
+	}
+
+	pubkeys, err := pubkeyDao.List(r.Context(), limit, offset)
 	if err != nil {
 		renderError(w, r, payloads.NewDAOError(r.Context(), "list pubkeys", err))
 		return
 	}
 
-	if err := render.Render(w, r, payloads.NewPubkeyListResponse(pubkeys)); err != nil {
+	if !meta {
+		if err := render.Render(w, r, payloads.NewPubkeyListResponse(pubkeys)); err != nil {
+			renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render pubkeys list", err))
+		}
+		return
+	}
+
+	total, err := pubkeyDao.Count(r.Context())
+	if err != nil {
+		renderError(w, r, payloads.NewDAOError(r.Context(), "count pubkeys", err))
+		return
+	}
+
+	if err := render.Render(w, r, payloads.NewPubkeyPageResponse(r, pubkeys, total, limit, offset)); err != nil {
 		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render pubkeys list", err))
 		return
 	}
@@ -7,12 +7,14 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/RHEnVision/provisioning-backend/internal/payloads"
 	"github.com/RHEnVision/provisioning-backend/internal/services"
 	_ "github.com/RHEnVision/provisioning-backend/internal/testing/initialization"
 	"github.com/stretchr/testify/require"
 
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
 	"github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
 	"github.com/RHEnVision/provisioning-backend/internal/models"
 	"github.com/RHEnVision/provisioning-backend/internal/testing/factories"
@@ -51,6 +53,75 @@ func TestListPubkeysHandler(t *testing.T) {
 	assert.Equal(t, 2, len(result.Data), "expected two pubkeys in response json")
 }
 
+func TestListPubkeysHandlerPagination(t *testing.T) {
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = identity.WithTenant(t, ctx)
+	ctx = stubs.WithPubkeyDao(ctx)
+	for i := 0; i < 3; i++ {
+		err := stubs.AddPubkey(ctx, &models.Pubkey{
+			Name: factories.SeqNameWithPrefix("pubkey"),
+			Body: factories.GenerateRSAPubKey(t),
+		})
+		require.NoError(t, err, "failed to add stubbed key")
+	}
+
+	t.Run("meta and links", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(ctx, "GET", "/api/provisioning/pubkeys?meta=true&limit=1&offset=1", nil)
+		require.NoError(t, err, "failed to create request")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.ListPubkeys)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, "Wrong status code")
+
+		var result payloads.PubkeyListResponse
+		err = json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+
+		require.NotNil(t, result.Meta)
+		require.NotNil(t, result.Links)
+		assert.Equal(t, int64(3), result.Meta.Total)
+		assert.Equal(t, len(result.Data), result.Meta.Count)
+		assert.Equal(t, "/api/provisioning/pubkeys?limit=1&meta=true&offset=0", result.Links.Previous)
+		assert.Equal(t, "/api/provisioning/pubkeys?limit=1&meta=true&offset=2", result.Links.Next)
+	})
+
+	t.Run("without meta", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(ctx, "GET", "/api/provisioning/pubkeys", nil)
+		require.NoError(t, err, "failed to create request")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.ListPubkeys)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, "Wrong status code")
+
+		var result map[string]json.RawMessage
+		err = json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		assert.Contains(t, result, "data")
+		assert.NotContains(t, result, "meta")
+		assert.NotContains(t, result, "links")
+	})
+
+	t.Run("clamped limit", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(ctx, "GET", "/api/provisioning/pubkeys?limit=1000", nil)
+		require.NoError(t, err, "failed to create request")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.ListPubkeys)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, "Wrong status code")
+
+		var result payloads.PubkeyListResponse
+		err = json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		assert.Equal(t, 3, len(result.Data))
+	})
+}
+
 func TestCreatePubkeyHandler(t *testing.T) {
 	var err error
 	var json_data []byte
@@ -80,3 +151,48 @@ func TestCreatePubkeyHandler(t *testing.T) {
 	stubCount := stubs.PubkeyStubCount(ctx)
 	assert.Equal(t, 1, stubCount, "Pubkey has not been Created through DAO")
 }
+
+func TestCreatePubkeyHandlerDuplicate(t *testing.T) {
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = identity.WithTenant(t, ctx)
+	ctx = stubs.WithPubkeyDao(ctx)
+	ctx = stubs.WithFaults(ctx)
+	stubs.InjectConstraintViolation(ctx, "PubkeyDao.Create", 1, "pubkeys_account_id_name_key")
+
+	json_data, err := json.Marshal(map[string]interface{}{
+		"name": "duplicate key",
+		"body": factories.NewPubkeyED25519().Body,
+	})
+	require.NoError(t, err, "unable to marshal values to json")
+	req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/pubkeys", bytes.NewBuffer(json_data))
+	require.NoError(t, err, "failed to create request")
+	req.Header.Add("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(services.CreatePubkey)
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, rr.Code, "Handler returned wrong status code")
+	assert.Equal(t, 0, stubs.PubkeyStubCount(ctx), "Pubkey must not be created")
+	assert.Equal(t, 1, stubs.FaultCallCount(ctx, "PubkeyDao.Create"))
+}
+
+func TestListPubkeysHandlerDAOError(t *testing.T) {
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = identity.WithTenant(t, ctx)
+	ctx = stubs.WithPubkeyDao(ctx)
+	ctx = stubs.WithFaults(ctx)
+	stubs.InjectLatency(ctx, "PubkeyDao.List", 10*time.Millisecond)
+	stubs.InjectError(ctx, "PubkeyDao.List", 2, dao.ErrStubGeneric)
+
+	for i, expected := range []int{http.StatusOK, http.StatusInternalServerError, http.StatusOK} {
+		req, err := http.NewRequestWithContext(ctx, "GET", "/api/provisioning/pubkeys", nil)
+		require.NoError(t, err, "failed to create request")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.ListPubkeys)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, expected, rr.Code, "Wrong status code of call %d", i+1)
+	}
+}
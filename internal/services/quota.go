@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/rs/zerolog"
+)
+
+var QuotaExceededError = errors.New("requested vCPUs exceed cloud provider quota")
+
+// CheckQuota compares requested amount of vCPUs with the remaining quota returned by fetch.
+// The check is best effort: when the quota cannot be fetched (e.g. missing permission), the request
+// continues. When the quota would be exceeded, a warning is logged or the request is denied depending
+// on the configuration. An error carrying the response is returned only when the request was denied.
+func CheckQuota(ctx context.Context, requested int64, fetch func() (*clients.Quota, error)) error {
+	logger := zerolog.Ctx(ctx)
+
+	if config.Reservation.QuotaCheck == config.QuotaCheckOff || requested <= 0 {
+		return nil
+	}
+
+	quota, err := fetch()
+	if err != nil {
+		logger.Warn().Err(err).Msg("Unable to fetch vCPU quota, skipping quota check")
+		return nil
+	}
+
+	if quota.Allows(requested) {
+		logger.Debug().Msgf("Requested %d vCPUs fit into quota '%s' (limit %d, usage %d)",
+			requested, quota.Name, quota.Limit, quota.Usage)
+		return nil
+	}
+
+	if config.Reservation.QuotaCheck == config.QuotaCheckWarn {
+		logger.Warn().Msgf("Requested %d vCPUs exceed quota '%s' (limit %d, usage %d), launching anyway",
+			requested, quota.Name, quota.Limit, quota.Usage)
+		return nil
+	}
+
+	quotaErr := fmt.Errorf("%w: requested %d, remaining %d", QuotaExceededError, requested, quota.Remaining())
+	return withResponse(payloads.NewQuotaExceededError(ctx, quota, requested, quotaErr), quotaErr)
+}
+
+// CheckQuotaAndRender performs CheckQuota and renders the error, see CheckQuota.
+func CheckQuotaAndRender(w http.ResponseWriter, r *http.Request, requested int64, fetch func() (*clients.Quota, error)) error {
+	err := CheckQuota(r.Context(), requested, fetch)
+	if err != nil {
+		renderResponseError(w, r, err)
+	}
+	return err
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func withQuotaCheck(t *testing.T, mode string) {
+	previous := config.Reservation.QuotaCheck
+	config.Reservation.QuotaCheck = mode
+	t.Cleanup(func() {
+		config.Reservation.QuotaCheck = previous
+	})
+}
+
+func fixedQuota(limit, usage int64) func() (*clients.Quota, error) {
+	return func() (*clients.Quota, error) {
+		return &clients.Quota{Name: "vCPUs", Limit: limit, Usage: usage}, nil
+	}
+}
+
+func TestCheckQuotaAndRenderDeny(t *testing.T) {
+	withQuotaCheck(t, config.QuotaCheckDeny)
+	w := httptest.NewRecorder()
+	req, err := http.NewRequestWithContext(context.Background(), "POST", "/", nil)
+	require.NoError(t, err, "failed to create request")
+
+	err = CheckQuotaAndRender(w, req, 8, fixedQuota(32, 30))
+	require.ErrorIs(t, err, QuotaExceededError)
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	require.Contains(t, w.Body.String(), "limit 32, usage 30")
+}
+
+func TestCheckQuotaAndRenderDenyWithinQuota(t *testing.T) {
+	withQuotaCheck(t, config.QuotaCheckDeny)
+	w := httptest.NewRecorder()
+	req, err := http.NewRequestWithContext(context.Background(), "POST", "/", nil)
+	require.NoError(t, err, "failed to create request")
+
+	err = CheckQuotaAndRender(w, req, 2, fixedQuota(32, 30))
+	require.NoError(t, err)
+}
+
+func TestCheckQuotaAndRenderWarn(t *testing.T) {
+	withQuotaCheck(t, config.QuotaCheckWarn)
+	w := httptest.NewRecorder()
+	req, err := http.NewRequestWithContext(context.Background(), "POST", "/", nil)
+	require.NoError(t, err, "failed to create request")
+
+	err = CheckQuotaAndRender(w, req, 8, fixedQuota(32, 30))
+	require.NoError(t, err)
+}
+
+func TestCheckQuotaAndRenderFetchError(t *testing.T) {
+	withQuotaCheck(t, config.QuotaCheckDeny)
+	w := httptest.NewRecorder()
+	req, err := http.NewRequestWithContext(context.Background(), "POST", "/", nil)
+	require.NoError(t, err, "failed to create request")
+
+	err = CheckQuotaAndRender(w, req, 8, func() (*clients.Quota, error) {
+		return nil, errors.New("access denied")
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/RHEnVision/provisioning-backend/internal/jobs"
+	"github.com/RHEnVision/provisioning-backend/internal/logging"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/RHEnVision/provisioning-backend/internal/queue"
+	"github.com/RHEnVision/provisioning-backend/pkg/worker"
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog"
+)
+
+var (
+	ApprovalNotPendingError = errors.New("reservation is not pending approval")
+	SelfApprovalError       = errors.New("reservation cannot be approved or rejected by its creator")
+)
+
+// Error message of reservations rejected by an approver.
+const rejectedLaunchError = "Launch was rejected by an approver"
+
+// ApproveReservation approves a launch pending approval and enqueues its job.
+func ApproveReservation(w http.ResponseWriter, r *http.Request) {
+	decideReservation(w, r, models.ApprovalApproved)
+}
+
+// RejectReservation rejects a launch pending approval, the reservation finishes with an error.
+func RejectReservation(w http.ResponseWriter, r *http.Request) {
+	decideReservation(w, r, models.ApprovalRejected)
+}
+
+func decideReservation(w http.ResponseWriter, r *http.Request, decision models.ApprovalState) {
+	id, err := ParseInt64(r, "ID")
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse ID parameter", err))
+		return
+	}
+	r = r.WithContext(logging.WithReservationId(r.Context(), id))
+	logger := zerolog.Ctx(r.Context())
+
+	reservation := getVisibleReservation(w, r, id)
+	if reservation == nil {
+		return
+	}
+
+	if CheckPermissionAndRender(w, r, "approve", "reservation", reservation.Provider.String()) != nil {
+		return
+	}
+
+	message := fmt.Sprintf("cannot change reservation in approval state '%s'", reservation.Approval)
+	if reservation.Approval != models.ApprovalPending {
+		renderError(w, r, payloads.NewConflictError(r.Context(), message, ApprovalNotPendingError))
+		return
+	}
+	user := identity.Identity(r.Context()).Identity.User
+	if reservation.CreatedByUserID != "" && reservation.CreatedByUserID == user.UserID {
+		renderError(w, r, payloads.NewResponseError(r.Context(), http.StatusForbidden, SelfApprovalError.Error(), SelfApprovalError))
+		return
+	}
+
+	// the state is changed first, so concurrent decisions for the same reservation are rejected
+	rDao := dao.GetReservationDao(r.Context())
+	err = rDao.UpdateApproval(r.Context(), id, models.ApprovalPending, decision, user.Username)
+	if errors.Is(err, dao.ErrAffectedMismatch) {
+		renderError(w, r, payloads.NewConflictError(r.Context(), message, ApprovalNotPendingError))
+		return
+	} else if err != nil {
+		renderError(w, r, payloads.NewDAOError(r.Context(), "update reservation approval", err))
+		return
+	}
+	reservation.Approval = decision
+	reservation.ApprovedBy = user.Username
+
+	if decision == models.ApprovalRejected {
+		err = rDao.FinishWithError(r.Context(), id, rejectedLaunchError)
+		if err != nil {
+			renderError(w, r, payloads.NewDAOError(r.Context(), "finish rejected reservation", err))
+			return
+		}
+		reservation.Error = rejectedLaunchError
+		logger.Info().Msg("Launch rejected by an approver")
+		jobs.RecordEvent(r.Context(), id, models.EventRejected, rejectedLaunchError, map[string]string{"approver": user.Username})
+	} else {
+		job, err := enqueueApprovedJob(r, id)
+		if err != nil {
+			revertErr := rDao.UpdateApproval(r.Context(), id, decision, models.ApprovalPending, "")
+			if revertErr != nil {
+				logger.Warn().Err(revertErr).Msg("Unable to revert reservation approval")
+			}
+			renderError(w, r, payloads.NewEnqueueTaskError(r.Context(), "job enqueue error", err))
+			return
+		}
+		logger.Info().Msg("Launch approved by an approver")
+		jobs.RecordEvent(r.Context(), id, models.EventApproved, "Launch approved by an approver", map[string]string{"approver": user.Username})
+		jobs.RecordEnqueued(r.Context(), id, job, "Job enqueued after the approval")
+	}
+
+	if err := render.Render(w, r, payloads.NewReservationResponse(reservation)); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render reservation", err))
+	}
+}
+
+// enqueueApprovedJob enqueues the job stored when the reservation was created.
+func enqueueApprovedJob(r *http.Request, id int64) (*worker.Job, error) {
+	stored, err := dao.GetReservationDao(r.Context()).UnscopedGetJob(r.Context(), id)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get stored job: %w", err)
+	}
+
+	job, err := jobs.UnmarshalJob(stored.Job)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode stored job: %w", err)
+	}
+
+	err = queue.GetEnqueuer(r.Context()).Enqueue(r.Context(), job)
+	if err != nil {
+		return nil, fmt.Errorf("unable to enqueue job: %w", err)
+	}
+	return job, nil
+}
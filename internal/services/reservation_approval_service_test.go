@@ -0,0 +1,227 @@
+package services_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/clients/http/rbac"
+	clientStubs "github.com/RHEnVision/provisioning-backend/internal/clients/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/RHEnVision/provisioning-backend/internal/jobs"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/RHEnVision/provisioning-backend/internal/queue/stub"
+	"github.com/RHEnVision/provisioning-backend/internal/services"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/factories"
+	tidentity "github.com/RHEnVision/provisioning-backend/internal/testing/identity"
+	"github.com/RHEnVision/provisioning-backend/pkg/worker"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAWSReservationAboveApprovalThreshold(t *testing.T) {
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = tidentity.WithTenant(t, ctx)
+	ctx = clientStubs.WithSourcesClient(ctx)
+	ctx = clientStubs.WithImageBuilderClient(ctx)
+	ctx = clientStubs.WithEC2Client(ctx)
+	ctx = stubs.WithReservationDao(ctx)
+	ctx = stubs.WithPubkeyDao(ctx)
+	ctx = stub.WithEnqueuer(ctx)
+	pk := factories.NewPubkeyRSA()
+	err := stubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to generate pubkey")
+	err = dao.GetAccountDao(ctx).UpdateSettings(ctx, &models.AccountSettings{ApprovalThreshold: 2})
+	require.NoError(t, err, "failed to store settings")
+
+	create := func(t *testing.T, amount int) {
+		t.Helper()
+		values := map[string]interface{}{
+			"source_id":     "1",
+			"image_id":      "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":        amount,
+			"instance_type": "t1.micro",
+			"pubkey_id":     pk.ID,
+		}
+		jsonData, err := json.Marshal(values)
+		require.NoError(t, err, "unable to marshal values to json")
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(jsonData))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(services.CreateAWSReservation).ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+	}
+
+	t.Run("within threshold", func(t *testing.T) {
+		create(t, 2)
+		require.Equal(t, 1, len(stub.EnqueuedJobs(ctx)), "Expected exactly one job to be planned")
+
+		reservation, err := dao.GetReservationDao(ctx).GetById(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, models.ApprovalNone, reservation.Approval)
+	})
+
+	t.Run("above threshold", func(t *testing.T) {
+		create(t, 3)
+		require.Equal(t, 1, len(stub.EnqueuedJobs(ctx)), "Expected the job to wait for an approval")
+
+		reservation, err := dao.GetReservationDao(ctx).GetById(ctx, 2)
+		require.NoError(t, err)
+		assert.Equal(t, models.ApprovalPending, reservation.Approval)
+
+		_, err = dao.GetReservationDao(ctx).UnscopedGetJob(ctx, 2)
+		require.NoError(t, err, "Expected the job to be stored")
+	})
+}
+
+func TestApproveAndRejectReservationHandler(t *testing.T) {
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = tidentity.WithTenant(t, ctx)
+	id := identity.Identity(ctx)
+	id.Identity.User.UserID = "1001"
+	id.Identity.User.Username = "approver"
+	ctx = identity.WithIdentity(ctx, id)
+	ctx = stubs.WithReservationDao(ctx)
+	ctx = stubs.WithPubkeyDao(ctx)
+	ctx = stub.WithEnqueuer(ctx)
+	ctx = rbac.WithAcl(ctx, clients.AllPermissionsRbacAcl)
+
+	pk := factories.NewPubkeyRSA()
+	err := stubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to add stubbed key")
+
+	addPending := func(t *testing.T, createdBy string) int64 {
+		t.Helper()
+		reservation := &models.AWSReservation{
+			PubkeyID: pk.ID,
+			SourceID: "1",
+			ImageID:  "ami-0c830793775595d4b",
+			Detail:   &models.AWSDetail{Region: "us-east-1", InstanceType: "t3.small", Amount: 5},
+		}
+		reservation.AccountID = 1
+		reservation.Provider = models.ProviderTypeAWS
+		reservation.CreatedByUserID = createdBy
+		reservation.Approval = models.ApprovalPending
+		err := stubs.AddAWSReservation(ctx, reservation)
+		require.NoError(t, err, "failed to add stubbed reservation")
+
+		data, err := jobs.MarshalJob(&worker.Job{
+			Type:      jobs.TypeLaunchInstanceAws,
+			AccountID: 1,
+			Args:      jobs.LaunchInstanceAWSTaskArgs{ReservationID: reservation.ID},
+		})
+		require.NoError(t, err, "failed to marshal job")
+		err = dao.GetReservationDao(ctx).CreateJob(ctx, &models.ReservationJob{ReservationID: reservation.ID, JobType: jobs.TypeLaunchInstanceAws.String(), Job: data})
+		require.NoError(t, err, "failed to store job")
+		return reservation.ID
+	}
+
+	decide := func(t *testing.T, id int64, handler http.HandlerFunc) *httptest.ResponseRecorder {
+		t.Helper()
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("ID", strconv.FormatInt(id, 10))
+		ctx := context.WithValue(ctx, chi.RouteCtxKey, rctx)
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/"+strconv.FormatInt(id, 10)+"/approve", nil)
+		require.NoError(t, err, "failed to create request")
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("approve", func(t *testing.T) {
+		id := addPending(t, "1002")
+		rr := decide(t, id, services.ApproveReservation)
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.GenericReservationResponse
+		err := json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		assert.Equal(t, string(models.ApprovalApproved), result.Approval)
+
+		require.Equal(t, 1, len(stub.EnqueuedJobs(ctx)), "Expected exactly one job to be planned")
+		assert.Equal(t, jobs.TypeLaunchInstanceAws, stub.EnqueuedJobs(ctx)[0].Type)
+
+		reservation, err := dao.GetReservationDao(ctx).GetById(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, "approver", reservation.ApprovedBy)
+	})
+
+	t.Run("approve twice", func(t *testing.T) {
+		rr := decide(t, 1, services.ApproveReservation)
+		require.Equal(t, http.StatusConflict, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		id := addPending(t, "1002")
+		rr := decide(t, id, services.RejectReservation)
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.GenericReservationResponse
+		err := json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		assert.Equal(t, string(models.ApprovalRejected), result.Approval)
+		assert.NotEmpty(t, result.Error)
+		require.Equal(t, 1, len(stub.EnqueuedJobs(ctx)), "Expected no job to be planned")
+	})
+
+	t.Run("self approval", func(t *testing.T) {
+		id := addPending(t, "1001")
+		rr := decide(t, id, services.ApproveReservation)
+		require.Equal(t, http.StatusForbidden, rr.Code, "Handler returned wrong status code")
+		require.Equal(t, 1, len(stub.EnqueuedJobs(ctx)), "Expected no job to be planned")
+	})
+}
+
+func TestUpdateSettingsHandler(t *testing.T) {
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = tidentity.WithTenant(t, ctx)
+
+	update := func(t *testing.T, threshold int64) *httptest.ResponseRecorder {
+		t.Helper()
+		jsonData, err := json.Marshal(map[string]interface{}{"approval_threshold": threshold})
+		require.NoError(t, err, "unable to marshal values to json")
+
+		req, err := http.NewRequestWithContext(ctx, "PUT", "/api/provisioning/settings", bytes.NewBuffer(jsonData))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(services.UpdateSettings).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("update", func(t *testing.T) {
+		rr := update(t, 10)
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		req, err := http.NewRequestWithContext(ctx, "GET", "/api/provisioning/settings", nil)
+		require.NoError(t, err, "failed to create request")
+		rr = httptest.NewRecorder()
+		http.HandlerFunc(services.GetSettings).ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.SettingsResponse
+		err = json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		assert.Equal(t, int64(10), result.ApprovalThreshold)
+	})
+
+	t.Run("negative threshold", func(t *testing.T) {
+		rr := update(t, -1)
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/RHEnVision/provisioning-backend/internal/archiver"
+	"github.com/RHEnVision/provisioning-backend/internal/artifacts"
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/go-chi/render"
+	"golang.org/x/exp/slices"
+)
+
+var ArchiveDisabledError = errors.New("reservation archival is not enabled")
+
+// GetReservationArchive returns a reservation with details and audit trail stored in object
+// storage before the retention cleanup deleted it.
+func GetReservationArchive(w http.ResponseWriter, r *http.Request) {
+	if !config.Archive.Enabled {
+		renderError(w, r, payloads.NewNotFoundError(r.Context(), "reservation archive", ArchiveDisabledError))
+		return
+	}
+
+	id, err := ParseInt64(r, "ID")
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse ID parameter", err))
+		return
+	}
+
+	message := fmt.Sprintf("archive of reservation %d", id)
+	archive, err := archiver.Get(r.Context(), identity.Identity(r.Context()).Identity.OrgID, id)
+	if errors.Is(err, clients.ObjectNotFoundErr) {
+		renderError(w, r, payloads.NewNotFoundError(r.Context(), message, err))
+		return
+	} else if err != nil {
+		renderError(w, r, payloads.NewClientError(r.Context(), err))
+		return
+	}
+
+	if !archiveAccessible(w, r, message, archive.CreatedByUserID, archive.WorkspaceID, models.ProviderType(archive.Reservation.Provider)) {
+		return
+	}
+
+	if err := render.Render(w, r, archive); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render reservation archive", err))
+	}
+}
+
+// GetReservationArchiveLink returns a signed URL which downloads the archive (gzip-compressed JSON)
+// directly from object storage, the archive is not transferred through the API.
+func GetReservationArchiveLink(w http.ResponseWriter, r *http.Request) {
+	if !config.Archive.Enabled {
+		renderError(w, r, payloads.NewNotFoundError(r.Context(), "reservation archive", ArchiveDisabledError))
+		return
+	}
+
+	id, err := ParseInt64(r, "ID")
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse ID parameter", err))
+		return
+	}
+
+	message := fmt.Sprintf("archive of reservation %d", id)
+	orgId := identity.Identity(r.Context()).Identity.OrgID
+	info, err := archiver.Stat(r.Context(), orgId, id)
+	if errors.Is(err, clients.ObjectNotFoundErr) {
+		renderError(w, r, payloads.NewNotFoundError(r.Context(), message, err))
+		return
+	} else if err != nil {
+		renderError(w, r, payloads.NewClientError(r.Context(), err))
+		return
+	}
+
+	if !archiveAccessible(w, r, message, info.CreatedByUserID, info.WorkspaceID, info.Provider) {
+		return
+	}
+
+	link, err := artifacts.Sign(r.Context(), archiver.Key(orgId, id))
+	if err != nil {
+		renderError(w, r, payloads.NewClientError(r.Context(), err))
+		return
+	}
+
+	if err := render.Render(w, r, payloads.NewArtifactLinkResponse(link)); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render reservation archive link", err))
+	}
+}
+
+// archiveAccessible renders not found when the archive was created by another user or in another
+// workspace and checks read permission of the provider.
+func archiveAccessible(w http.ResponseWriter, r *http.Request, message, createdBy, workspace string, provider models.ProviderType) bool {
+	if userScoped(r) && createdBy != identity.Identity(r.Context()).Identity.User.UserID {
+		renderError(w, r, payloads.NewNotFoundError(r.Context(), message, clients.ObjectNotFoundErr))
+		return false
+	}
+	if workspaces := identity.Workspaces(r.Context()); workspaces != nil && !slices.Contains(workspaces, workspace) {
+		renderError(w, r, payloads.NewNotFoundError(r.Context(), message, clients.ObjectNotFoundErr))
+		return false
+	}
+
+	return CheckPermissionAndRender(w, r, "read", "reservation", provider.String()) == nil
+}
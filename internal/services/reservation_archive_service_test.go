@@ -0,0 +1,107 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/archiver"
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/clients/http/rbac"
+	clientStubs "github.com/RHEnVision/provisioning-backend/internal/clients/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/RHEnVision/provisioning-backend/internal/services"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/factories"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/identity"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetReservationArchiveHandler(t *testing.T) {
+	config.Archive.Enabled = true
+	defer func() { config.Archive.Enabled = false }()
+	config.Reservation.Lifetime = 24 * time.Hour
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = identity.WithTenant(t, ctx)
+	ctx = stubs.WithReservationDao(ctx)
+	ctx = stubs.WithPubkeyDao(ctx)
+	ctx = stubs.WithAuditDao(ctx)
+	ctx = clientStubs.WithObjectStorageClient(ctx)
+	ctx = rbac.WithAcl(ctx, clients.AllPermissionsRbacAcl)
+
+	pk := factories.NewPubkeyRSA()
+	err := stubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to add stubbed key")
+	reservation := &models.AWSReservation{
+		PubkeyID: pk.ID,
+		SourceID: "1",
+		ImageID:  "ami-0c830793775595d4b",
+		Detail:   &models.AWSDetail{Region: "us-east-1", InstanceType: "t3.small", Amount: 1},
+	}
+	reservation.AccountID = 1
+	reservation.Provider = models.ProviderTypeAWS
+	reservation.CreatedAt = time.Now().Add(-48 * time.Hour)
+	err = stubs.AddAWSReservation(ctx, reservation)
+	require.NoError(t, err, "failed to add stubbed reservation")
+	_, err = archiver.ArchiveExpired(ctx)
+	require.NoError(t, err, "failed to archive stubbed reservation")
+
+	serve := func(t *testing.T, handler http.HandlerFunc, id int64, path string) *httptest.ResponseRecorder {
+		t.Helper()
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("ID", strconv.FormatInt(id, 10))
+		ctx := context.WithValue(ctx, chi.RouteCtxKey, rctx)
+
+		req, err := http.NewRequestWithContext(ctx, "GET", "/api/provisioning/reservations/"+strconv.FormatInt(id, 10)+path, nil)
+		require.NoError(t, err, "failed to create request")
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+	get := func(t *testing.T, id int64) *httptest.ResponseRecorder {
+		t.Helper()
+		return serve(t, services.GetReservationArchive, id, "/archive")
+	}
+
+	t.Run("archived", func(t *testing.T) {
+		rr := get(t, reservation.ID)
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.ReservationArchiveResponse
+		err := json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		assert.Equal(t, reservation.ID, result.Reservation.ID)
+		assert.Equal(t, "ami-0c830793775595d4b", result.Detail["image_id"])
+	})
+
+	t.Run("not archived", func(t *testing.T) {
+		rr := get(t, reservation.ID+1)
+		require.Equal(t, http.StatusNotFound, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("link", func(t *testing.T) {
+		config.Archive.LinkExpiration = 5 * time.Minute
+		rr := serve(t, services.GetReservationArchiveLink, reservation.ID, "/archive/link")
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.ArtifactLinkResponse
+		err := json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		assert.Contains(t, result.URL, archiver.Key(identity.DefaultOrgId, reservation.ID))
+		assert.Contains(t, result.URL, "X-Amz-Expires=300")
+	})
+
+	t.Run("link not archived", func(t *testing.T) {
+		rr := serve(t, services.GetReservationArchiveLink, reservation.ID+1, "/archive/link")
+		require.Equal(t, http.StatusNotFound, rr.Code, "Handler returned wrong status code")
+	})
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/go-chi/render"
+)
+
+// DiffReservations compares launch parameters and outcomes of two reservations and returns the
+// changed fields.
+func DiffReservations(w http.ResponseWriter, r *http.Request) {
+	id, err := ParseInt64(r, "ID")
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse ID parameter", err))
+		return
+	}
+	otherId, err := ParseInt64(r, "OTHER_ID")
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse OTHER_ID parameter", err))
+		return
+	}
+
+	reservation, detail, ok := diffReservation(w, r, id)
+	if !ok {
+		return
+	}
+	other, otherDetail, ok := diffReservation(w, r, otherId)
+	if !ok {
+		return
+	}
+
+	response, err := payloads.NewReservationDiffResponse(reservation, other, detail, otherDetail)
+	if err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to compare reservations", err))
+		return
+	}
+	if err := render.Render(w, r, response); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render reservation diff", err))
+	}
+}
+
+// diffReservation loads a reservation with its provider specific response, the detail is nil
+// for noop reservations. Errors are rendered and false is returned.
+func diffReservation(w http.ResponseWriter, r *http.Request, id int64) (*models.Reservation, render.Renderer, bool) {
+	reservation := getVisibleReservation(w, r, id)
+	if reservation == nil {
+		return nil, nil, false
+	}
+
+	if CheckPermissionAndRender(w, r, "read", "reservation", reservation.Provider.String()) != nil {
+		return nil, nil, false
+	}
+
+	rDao := dao.GetReservationDao(r.Context())
+	var instances []*models.ReservationInstance
+	if reservation.Provider != models.ProviderTypeNoop {
+		var err error
+		instances, err = rDao.ListInstances(r.Context(), id)
+		if err != nil {
+			renderError(w, r, payloads.NewDAOError(r.Context(), "list reservation instances", err))
+			return nil, nil, false
+		}
+	}
+
+	switch reservation.Provider {
+	case models.ProviderTypeAWS:
+		detail, err := rDao.GetAWSById(r.Context(), id)
+		if err != nil {
+			renderNotFoundOrDAOError(w, r, err, "get aws reservation")
+			return nil, nil, false
+		}
+		return reservation, payloads.NewAWSReservationResponse(detail, instances), true
+	case models.ProviderTypeAzure:
+		detail, err := rDao.GetAzureById(r.Context(), id)
+		if err != nil {
+			renderNotFoundOrDAOError(w, r, err, "get azure reservation")
+			return nil, nil, false
+		}
+		return reservation, payloads.NewAzureReservationResponse(detail, instances), true
+	case models.ProviderTypeGCP:
+		detail, err := rDao.GetGCPById(r.Context(), id)
+		if err != nil {
+			renderNotFoundOrDAOError(w, r, err, "get gcp reservation")
+			return nil, nil, false
+		}
+		return reservation, payloads.NewGCPReservationResponse(detail, instances), true
+	case models.ProviderTypeNoop, models.ProviderTypeUnknown:
+	}
+	return reservation, nil, true
+}
@@ -0,0 +1,136 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/clients/http/rbac"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/RHEnVision/provisioning-backend/internal/services"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/factories"
+	tidentity "github.com/RHEnVision/provisioning-backend/internal/testing/identity"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffReservations(t *testing.T) {
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = tidentity.WithTenant(t, ctx)
+	ctx = stubs.WithPubkeyDao(ctx)
+	ctx = stubs.WithReservationDao(ctx)
+	ctx = rbac.WithAcl(ctx, clients.AllPermissionsRbacAcl)
+	pk := factories.NewPubkeyRSA()
+	err := stubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to add stubbed key")
+
+	addReservation := func(t *testing.T, detail *models.AWSDetail, success bool, instanceIds ...string) int64 {
+		t.Helper()
+		reservation := &models.AWSReservation{
+			PubkeyID: pk.ID,
+			SourceID: "1",
+			ImageID:  "ami-random",
+			Detail:   detail,
+		}
+		reservation.AccountID = identity.AccountId(ctx)
+		reservation.Status = "Finished"
+		reservation.Provider = models.ProviderTypeAWS
+		reservation.Success = sql.NullBool{Bool: success, Valid: true}
+		err := stubs.AddAWSReservation(ctx, reservation)
+		require.NoError(t, err, "failed to create stub reservation")
+		for _, id := range instanceIds {
+			err = dao.GetReservationDao(ctx).CreateInstance(ctx, &models.ReservationInstance{ReservationID: reservation.ID, InstanceID: id})
+			require.NoError(t, err, "failed to create stub instance")
+		}
+		return reservation.ID
+	}
+
+	diff := func(t *testing.T, id, otherId string) *httptest.ResponseRecorder {
+		t.Helper()
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("ID", id)
+		rctx.URLParams.Add("OTHER_ID", otherId)
+		ctx := context.WithValue(ctx, chi.RouteCtxKey, rctx)
+
+		req, err := http.NewRequestWithContext(ctx, "GET", "/api/provisioning/v1/reservations/"+id+"/diff/"+otherId, nil)
+		require.NoError(t, err, "failed to create request")
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(services.DiffReservations).ServeHTTP(rr, req)
+		return rr
+	}
+
+	id := addReservation(t, &models.AWSDetail{
+		Region:       "us-east-1",
+		InstanceType: "t3.small",
+		Amount:       1,
+		Tags:         map[string]string{"env": "staging", "team": "web"},
+	}, true, "i-0a4caa2cf5b097ce1")
+	otherId := addReservation(t, &models.AWSDetail{
+		Region:                "us-east-1",
+		InstanceType:          "t3.small",
+		FallbackInstanceTypes: []string{"t3a.small"},
+		LaunchedInstanceType:  "t3a.small",
+		Amount:                1,
+		Tags:                  map[string]string{"env": "production", "team": "web"},
+	}, false)
+
+	t.Run("changes", func(t *testing.T) {
+		rr := diff(t, strconv.FormatInt(id, 10), strconv.FormatInt(otherId, 10))
+		require.Equal(t, http.StatusOK, rr.Code, "Wrong status code")
+
+		var result payloads.ReservationDiffResponse
+		err := json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		assert.Equal(t, id, result.ReservationID)
+		assert.Equal(t, otherId, result.OtherReservationID)
+
+		fields := make([]string, len(result.Changes))
+		for i, change := range result.Changes {
+			fields[i] = change.Field
+		}
+		assert.Equal(t, []string{
+			"detail.instance_count",
+			"detail.instance_power_states",
+			"detail.launched_instance_type",
+			"reservation.success",
+			"detail.fallback_instance_types",
+			"detail.tags.env",
+		}, fields)
+
+		tags := result.Changes[5]
+		assert.Equal(t, payloads.DiffParameter, tags.Kind)
+		assert.Equal(t, "staging", tags.Value)
+		assert.Equal(t, "production", tags.OtherValue)
+
+		launched := result.Changes[2]
+		assert.Equal(t, payloads.DiffOutcome, launched.Kind)
+		assert.Nil(t, launched.Value)
+		assert.Equal(t, "t3a.small", launched.OtherValue)
+	})
+
+	t.Run("same reservation", func(t *testing.T) {
+		rr := diff(t, strconv.FormatInt(id, 10), strconv.FormatInt(id, 10))
+		require.Equal(t, http.StatusOK, rr.Code, "Wrong status code")
+
+		var result payloads.ReservationDiffResponse
+		err := json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		assert.Empty(t, result.Changes)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		rr := diff(t, strconv.FormatInt(id, 10), "999999")
+		require.Equal(t, http.StatusNotFound, rr.Code, "Wrong status code")
+	})
+}
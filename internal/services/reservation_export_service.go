@@ -0,0 +1,187 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/RHEnVision/provisioning-backend/internal/terraform"
+	"github.com/rs/zerolog"
+)
+
+var UnknownExportFormatError = errors.New("unknown export format")
+
+// ExportReservations streams reservation history of the account as CSV (default) or JSON array.
+// The label and created_by parameters filter reservations the same way the reservation list does.
+// Rows are written as they are read from the database, therefore errors which happen after the
+// first row was written can only be logged.
+func ExportReservations(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "csv"
+	}
+
+	filter, err := reservationFilter(r)
+	if err != nil {
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "created_by parameter", err))
+		return
+	}
+
+	// number of rows already written
+	count := 0
+	var write func(*payloads.ReservationExportResponse) error
+	var finish func() error
+	switch format {
+	case "csv":
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", `attachment; filename="reservations.csv"`)
+		cw := csv.NewWriter(w)
+		if err := cw.Write(payloads.ReservationExportCSVHeader); err != nil {
+			renderError(w, r, payloads.NewRenderError(r.Context(), "unable to write csv header", err))
+			return
+		}
+		write = func(row *payloads.ReservationExportResponse) error {
+			if err := cw.Write(row.CSVRecord()); err != nil {
+				return fmt.Errorf("unable to write csv: %w", err)
+			}
+			return nil
+		}
+		finish = func() error {
+			cw.Flush()
+			if err := cw.Error(); err != nil {
+				return fmt.Errorf("unable to write csv: %w", err)
+			}
+			return nil
+		}
+	case "json":
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", `attachment; filename="reservations.json"`)
+		enc := json.NewEncoder(w)
+		write = func(row *payloads.ReservationExportResponse) error {
+			separator := ","
+			if count == 0 {
+				separator = "["
+			}
+			if _, err := w.Write([]byte(separator)); err != nil {
+				return fmt.Errorf("unable to write json: %w", err)
+			}
+			if err := enc.Encode(row); err != nil {
+				return fmt.Errorf("unable to encode json: %w", err)
+			}
+			return nil
+		}
+		finish = func() error {
+			closing := "]"
+			if count == 0 {
+				closing = "[]"
+			}
+			if _, err := w.Write([]byte(closing)); err != nil {
+				return fmt.Errorf("unable to write json: %w", err)
+			}
+			return nil
+		}
+	default:
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), fmt.Sprintf("unsupported format: %s", format), UnknownExportFormatError))
+		return
+	}
+
+	logger := zerolog.Ctx(r.Context())
+	rDao := dao.GetReservationDao(r.Context())
+	err = rDao.Export(r.Context(), filter, func(export *models.ReservationExport) error {
+		if writeErr := write(payloads.NewReservationExportResponse(export)); writeErr != nil {
+			return writeErr
+		}
+		count++
+		return nil
+	})
+	if err == nil {
+		err = finish()
+	}
+	if err != nil {
+		if count == 0 {
+			renderError(w, r, payloads.NewDAOError(r.Context(), "export reservations", err))
+			return
+		}
+		logger.Error().Err(err).Msgf("Reservation export failed after %d rows", count)
+		return
+	}
+	logger.Debug().Msgf("Exported %d reservations as %s", count, format)
+}
+
+// ExportReservation renders resources launched by a reservation as Terraform import blocks, so
+// they can be adopted into infrastructure as code. Only the terraform format is supported.
+func ExportReservation(w http.ResponseWriter, r *http.Request) {
+	id, err := ParseInt64(r, "ID")
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse ID parameter", err))
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "terraform"
+	}
+	if format != "terraform" {
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), fmt.Sprintf("unsupported format: %s", format), UnknownExportFormatError))
+		return
+	}
+
+	reservation := getVisibleReservation(w, r, id)
+	if reservation == nil {
+		return
+	}
+
+	if CheckPermissionAndRender(w, r, "read", "reservation", reservation.Provider.String()) != nil {
+		return
+	}
+
+	rDao := dao.GetReservationDao(r.Context())
+	instances, err := rDao.ListInstances(r.Context(), id)
+	if err != nil {
+		renderError(w, r, payloads.NewDAOError(r.Context(), "list reservation instances", err))
+		return
+	}
+
+	var export *terraform.Export
+	switch reservation.Provider {
+	case models.ProviderTypeAWS:
+		detail, detailErr := rDao.GetAWSById(r.Context(), id)
+		if detailErr != nil {
+			renderNotFoundOrDAOError(w, r, detailErr, "get aws reservation")
+			return
+		}
+		export = terraform.NewAWSExport(detail, instances)
+	case models.ProviderTypeAzure:
+		detail, detailErr := rDao.GetAzureById(r.Context(), id)
+		if detailErr != nil {
+			renderNotFoundOrDAOError(w, r, detailErr, "get azure reservation")
+			return
+		}
+		export = terraform.NewAzureExport(detail, instances)
+	case models.ProviderTypeGCP:
+		detail, detailErr := rDao.GetGCPById(r.Context(), id)
+		if detailErr != nil {
+			renderNotFoundOrDAOError(w, r, detailErr, "get gcp reservation")
+			return
+		}
+		export = terraform.NewGCPExport(detail, instances)
+	default:
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "provider is not supported", UnknownProviderTypeError))
+		return
+	}
+
+	buf := &bytes.Buffer{}
+	if err := export.Render(buf); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render terraform export", err))
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="reservation-%d.tf"`, id))
+	_, _ = w.Write(buf.Bytes())
+}
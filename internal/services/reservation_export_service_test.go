@@ -0,0 +1,201 @@
+package services_test
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/clients/http/rbac"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/RHEnVision/provisioning-backend/internal/ptr"
+	"github.com/RHEnVision/provisioning-backend/internal/services"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/factories"
+	tidentity "github.com/RHEnVision/provisioning-backend/internal/testing/identity"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportReservations(t *testing.T) {
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = tidentity.WithTenant(t, ctx)
+	ctx = stubs.WithPubkeyDao(ctx)
+	ctx = stubs.WithReservationDao(ctx)
+	ctx = rbac.WithAcl(ctx, clients.AllPermissionsRbacAcl)
+	pk := factories.NewPubkeyRSA()
+	err := stubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to add stubbed key")
+
+	reservation := &models.AWSReservation{
+		PubkeyID: pk.ID,
+		SourceID: "1",
+		ImageID:  "ami-random",
+		Detail:   &models.AWSDetail{Region: "us-east-1", InstanceType: "t3.small", Amount: 2},
+	}
+	reservation.AccountID = identity.AccountId(ctx)
+	reservation.Status = "Created"
+	reservation.Provider = models.ProviderTypeAWS
+	err = stubs.AddAWSReservation(ctx, reservation)
+	require.NoError(t, err, "failed to create stub reservation")
+
+	export := func(t *testing.T, query string) *httptest.ResponseRecorder {
+		req, err := http.NewRequestWithContext(ctx, "GET", "/api/provisioning/v1/reservations/export"+query, nil)
+		require.NoError(t, err, "failed to create request")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.ExportReservations)
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("CSV", func(t *testing.T) {
+		rr := export(t, "")
+		require.Equal(t, http.StatusOK, rr.Code, "Wrong status code")
+		assert.Equal(t, "text/csv", rr.Header().Get("Content-Type"))
+
+		records, err := csv.NewReader(rr.Body).ReadAll()
+		require.NoError(t, err, "failed to parse csv")
+		require.Len(t, records, 2)
+		assert.Equal(t, payloads.ReservationExportCSVHeader, records[0])
+		assert.Equal(t, []string{"aws", "us-east-1", "t3.small", "2"},
+			[]string{records[1][1], records[1][10], records[1][11], records[1][12]})
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		rr := export(t, "?format=json")
+		require.Equal(t, http.StatusOK, rr.Code, "Wrong status code")
+
+		var response []payloads.ReservationExportResponse
+		err := json.NewDecoder(rr.Body).Decode(&response)
+		require.NoError(t, err, "failed to decode response body")
+		require.Len(t, response, 1)
+		assert.Equal(t, "us-east-1", response[0].Location)
+		assert.Nil(t, response[0].Success)
+	})
+
+	t.Run("Unknown format", func(t *testing.T) {
+		rr := export(t, "?format=xml")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Wrong status code")
+	})
+
+	t.Run("Filtered", func(t *testing.T) {
+		labeled := &models.AWSReservation{
+			PubkeyID: pk.ID,
+			SourceID: "1",
+			ImageID:  "ami-random",
+			Detail:   &models.AWSDetail{Region: "eu-west-1", InstanceType: "t3.small", Amount: 1},
+		}
+		labeled.AccountID = identity.AccountId(ctx)
+		labeled.CreatedByUserID = "1002"
+		labeled.Labels = []string{"hackathon"}
+		labeled.Status = "Created"
+		labeled.Provider = models.ProviderTypeAWS
+		err := stubs.AddAWSReservation(ctx, labeled)
+		require.NoError(t, err, "failed to create stub reservation")
+
+		rr := export(t, "?format=json&label=hackathon")
+		require.Equal(t, http.StatusOK, rr.Code, "Wrong status code")
+		var response []payloads.ReservationExportResponse
+		err = json.NewDecoder(rr.Body).Decode(&response)
+		require.NoError(t, err, "failed to decode response body")
+		require.Len(t, response, 1)
+		assert.Equal(t, "eu-west-1", response[0].Location)
+
+		rr = export(t, "?format=json&created_by=me")
+		require.Equal(t, http.StatusOK, rr.Code, "Wrong status code")
+		response = nil
+		err = json.NewDecoder(rr.Body).Decode(&response)
+		require.NoError(t, err, "failed to decode response body")
+		require.Len(t, response, 1)
+		assert.Equal(t, "us-east-1", response[0].Location)
+
+		rr = export(t, "?created_by=1002")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Wrong status code")
+	})
+	t.Run("User scoped without admin permission", func(t *testing.T) {
+		defer func(scoped bool) {
+			config.Application.UserScoped = scoped
+		}(config.Application.UserScoped)
+		config.Application.UserScoped = true
+
+		id := identity.Identity(ctx)
+		id.Identity.User.UserID = "1002"
+		scopedCtx := rbac.WithAcl(identity.WithIdentity(ctx, id), clients.NoPermissionsRbacAcl)
+		req, err := http.NewRequestWithContext(scopedCtx, "GET", "/api/provisioning/v1/reservations/export?format=json", nil)
+		require.NoError(t, err, "failed to create request")
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(services.ExportReservations).ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code, "Wrong status code")
+
+		var response []payloads.ReservationExportResponse
+		err = json.NewDecoder(rr.Body).Decode(&response)
+		require.NoError(t, err, "failed to decode response body")
+		require.Len(t, response, 1)
+		assert.Equal(t, "eu-west-1", response[0].Location)
+	})
+}
+
+func TestExportReservation(t *testing.T) {
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = tidentity.WithTenant(t, ctx)
+	ctx = stubs.WithPubkeyDao(ctx)
+	ctx = stubs.WithReservationDao(ctx)
+	ctx = rbac.WithAcl(ctx, clients.AllPermissionsRbacAcl)
+	pk := factories.NewPubkeyRSA()
+	err := stubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to add stubbed key")
+
+	reservation := &models.AWSReservation{
+		PubkeyID: pk.ID,
+		SourceID: "1",
+		ImageID:  "ami-random",
+		Detail:   &models.AWSDetail{Region: "us-east-1", InstanceType: "t3.small", Amount: 1, Name: ptr.To("web")},
+	}
+	reservation.AccountID = identity.AccountId(ctx)
+	reservation.Status = "Created"
+	reservation.Provider = models.ProviderTypeAWS
+	err = stubs.AddAWSReservation(ctx, reservation)
+	require.NoError(t, err, "failed to create stub reservation")
+	err = dao.GetReservationDao(ctx).CreateInstance(ctx, &models.ReservationInstance{
+		ReservationID: reservation.ID,
+		InstanceID:    "i-0a4caa2cf5b097ce1",
+	})
+	require.NoError(t, err, "failed to create stub instance")
+
+	export := func(t *testing.T, query string) *httptest.ResponseRecorder {
+		t.Helper()
+		id := strconv.FormatInt(reservation.ID, 10)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("ID", id)
+		ctx := context.WithValue(ctx, chi.RouteCtxKey, rctx)
+
+		req, err := http.NewRequestWithContext(ctx, "GET", "/api/provisioning/v1/reservations/"+id+"/export"+query, nil)
+		require.NoError(t, err, "failed to create request")
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(services.ExportReservation).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("terraform", func(t *testing.T) {
+		rr := export(t, "?format=terraform")
+		require.Equal(t, http.StatusOK, rr.Code, "Wrong status code")
+		assert.Equal(t, "text/plain; charset=utf-8", rr.Header().Get("Content-Type"))
+		assert.Contains(t, rr.Body.String(), "to = aws_instance.web_1\n  id = \"i-0a4caa2cf5b097ce1\"")
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		rr := export(t, "?format=pulumi")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Wrong status code")
+	})
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/go-chi/render"
+)
+
+const (
+	maxReservationLabels = 16
+	maxLabelLength       = 63
+)
+
+var (
+	TooManyLabelsError  = errors.New("too many reservation labels")
+	InvalidLabelError   = errors.New("invalid reservation label")
+	DuplicateLabelError = errors.New("duplicate reservation label")
+)
+
+// checkLabels returns an error when labels cannot be stored on a reservation.
+func checkLabels(labels []string) error {
+	if len(labels) > maxReservationLabels {
+		return fmt.Errorf("%w: at most %d allowed", TooManyLabelsError, maxReservationLabels)
+	}
+	seen := make(map[string]struct{}, len(labels))
+	for _, label := range labels {
+		if label == "" || len(label) > maxLabelLength {
+			return fmt.Errorf("%w: '%s' must have 1 to %d characters", InvalidLabelError, label, maxLabelLength)
+		}
+		if _, ok := seen[label]; ok {
+			return fmt.Errorf("%w: %s", DuplicateLabelError, label)
+		}
+		seen[label] = struct{}{}
+	}
+	return nil
+}
+
+// UpdateReservationLabels replaces labels of a reservation.
+func UpdateReservationLabels(w http.ResponseWriter, r *http.Request) {
+	id, err := ParseInt64(r, "ID")
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse ID parameter", err))
+		return
+	}
+
+	payload := &payloads.LabelsRequest{}
+	if err := render.Bind(r, payload); err != nil {
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "update reservation labels", err))
+		return
+	}
+	if labelsErr := checkLabels(payload.Labels); labelsErr != nil {
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), labelsErr.Error(), labelsErr))
+		return
+	}
+
+	reservation := getVisibleReservation(w, r, id)
+	if reservation == nil {
+		return
+	}
+
+	if CheckPermissionAndRender(w, r, "write", "reservation", reservation.Provider.String()) != nil {
+		return
+	}
+
+	err = dao.GetReservationDao(r.Context()).UpdateLabels(r.Context(), id, payload.Labels)
+	if err != nil {
+		renderError(w, r, payloads.NewDAOError(r.Context(), "update reservation labels", err))
+		return
+	}
+	reservation.Labels = payload.Labels
+
+	if err := render.Render(w, r, payloads.NewReservationResponse(reservation)); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render reservation", err))
+	}
+}
+
+// ListLabelStats returns number of reservations, their results and launched instances per label.
+func ListLabelStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := dao.GetReservationDao(r.Context()).ListLabelStats(r.Context(), scopedFilter(r))
+	if err != nil {
+		renderError(w, r, payloads.NewDAOError(r.Context(), "list label stats", err))
+		return
+	}
+
+	if err := render.Render(w, r, payloads.NewLabelStatsListResponse(stats)); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render label stats", err))
+	}
+}
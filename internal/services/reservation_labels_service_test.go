@@ -0,0 +1,174 @@
+package services_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/clients/http/rbac"
+	clientStubs "github.com/RHEnVision/provisioning-backend/internal/clients/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/RHEnVision/provisioning-backend/internal/services"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/factories"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/identity"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReservationLabels(t *testing.T) {
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = identity.WithTenant(t, ctx)
+	ctx = clientStubs.WithSourcesClient(ctx)
+	ctx = clientStubs.WithImageBuilderClient(ctx)
+	ctx = clientStubs.WithEC2Client(ctx)
+	ctx = stubs.WithReservationDao(ctx)
+	ctx = stubs.WithPubkeyDao(ctx)
+	ctx = rbac.WithAcl(ctx, clients.AllPermissionsRbacAcl)
+	pk := factories.NewPubkeyRSA()
+	err := stubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to generate pubkey")
+
+	create := func(t *testing.T, labels []string) *httptest.ResponseRecorder {
+		t.Helper()
+		values := map[string]interface{}{
+			"source_id":     "1",
+			"image_id":      "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":        1,
+			"instance_type": "t1.micro",
+			"pubkey_id":     pk.ID,
+			"labels":        labels,
+		}
+		jsonData, err := json.Marshal(values)
+		require.NoError(t, err, "unable to marshal values to json")
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(jsonData))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(services.CreateAWSReservation).ServeHTTP(rr, req)
+		return rr
+	}
+
+	list := func(t *testing.T, ctx context.Context, label string) payloads.GenericReservationListResponse {
+		t.Helper()
+		req, err := http.NewRequestWithContext(ctx, "GET", "/api/provisioning/reservations?meta=true&label="+label, nil)
+		require.NoError(t, err, "failed to create request")
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(services.ListReservations).ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.GenericReservationListResponse
+		err = json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		return result
+	}
+
+	t.Run("create with labels", func(t *testing.T) {
+		rr := create(t, []string{"hackathon"})
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+		rr = create(t, nil)
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		result := list(t, ctx, "hackathon")
+		require.Len(t, result.Data, 1)
+		assert.Equal(t, []string{"hackathon"}, result.Data[0].Labels)
+		assert.Equal(t, int64(1), result.Meta.Total)
+	})
+
+	t.Run("invalid labels", func(t *testing.T) {
+		rr := create(t, []string{"hackathon", "hackathon"})
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+		rr = create(t, []string{""})
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("update labels", func(t *testing.T) {
+		jsonData, err := json.Marshal(map[string]interface{}{"labels": []string{"hackathon", "team-a"}})
+		require.NoError(t, err, "unable to marshal values to json")
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("ID", "2")
+		ctx := context.WithValue(ctx, chi.RouteCtxKey, rctx)
+
+		req, err := http.NewRequestWithContext(ctx, "PUT", "/api/provisioning/reservations/2/labels", bytes.NewBuffer(jsonData))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(services.UpdateReservationLabels).ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		result := list(t, ctx, "hackathon")
+		assert.Len(t, result.Data, 2)
+	})
+
+	t.Run("label stats", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(ctx, "GET", "/api/provisioning/reservations/labels", nil)
+		require.NoError(t, err, "failed to create request")
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(services.ListLabelStats).ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.LabelStatsListResponse
+		err = json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		require.Len(t, result.Data, 2)
+		assert.Equal(t, "hackathon", result.Data[0].Label)
+		assert.Equal(t, int64(2), result.Data[0].Reservations)
+		assert.Equal(t, "team-a", result.Data[1].Label)
+		assert.Equal(t, int64(1), result.Data[1].Reservations)
+	})
+
+	t.Run("user scoped without admin permission", func(t *testing.T) {
+		defer func(scoped bool) {
+			config.Application.UserScoped = scoped
+		}(config.Application.UserScoped)
+		config.Application.UserScoped = true
+
+		reservation := &models.AWSReservation{
+			PubkeyID: pk.ID,
+			SourceID: "1",
+			ImageID:  "ami-random",
+			Detail:   &models.AWSDetail{Region: "us-east-1", InstanceType: "t1.micro", Amount: 1},
+		}
+		reservation.AccountID = 1
+		reservation.CreatedByUserID = "1002"
+		reservation.Labels = []string{"hackathon"}
+		reservation.Provider = models.ProviderTypeAWS
+		err := stubs.AddAWSReservation(ctx, reservation)
+		require.NoError(t, err, "failed to create stub reservation")
+
+		result := list(t, ctx, "hackathon")
+		require.Len(t, result.Data, 3)
+
+		scopedCtx := rbac.WithAcl(ctx, clients.NoPermissionsRbacAcl)
+		result = list(t, scopedCtx, "hackathon")
+		require.Len(t, result.Data, 2)
+		for _, reservation := range result.Data {
+			assert.NotEqual(t, int64(3), reservation.ID)
+		}
+
+		req, err := http.NewRequestWithContext(scopedCtx, "GET", "/api/provisioning/reservations/labels", nil)
+		require.NoError(t, err, "failed to create request")
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(services.ListLabelStats).ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var stats payloads.LabelStatsListResponse
+		err = json.NewDecoder(rr.Body).Decode(&stats)
+		require.NoError(t, err, "failed to decode response body")
+		require.Len(t, stats.Data, 2)
+		assert.Equal(t, "hackathon", stats.Data[0].Label)
+		assert.Equal(t, int64(2), stats.Data[0].Reservations)
+	})
+}
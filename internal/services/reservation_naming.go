@@ -0,0 +1,31 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/naming"
+)
+
+// reservationName returns the requested name or generates one from the configured pattern
+// when it is blank. The per-account sequence is only incremented for generated names.
+func reservationName(ctx context.Context, name string, values naming.Values) (string, error) {
+	if name != "" {
+		return name, nil
+	}
+
+	pattern := config.Application.NamePattern
+	if pattern == "" {
+		pattern = naming.DefaultPattern
+	}
+	if naming.UsesSequence(pattern) {
+		seq, err := dao.GetAccountDao(ctx).NextNameSequence(ctx)
+		if err != nil {
+			return "", fmt.Errorf("unable to get name sequence: %w", err)
+		}
+		values.Sequence = seq
+	}
+	return naming.Generate(pattern, values), nil
+}
@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/background"
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/clients/http/rbac"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/cron"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/notifications"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/go-chi/render"
+)
+
+var (
+	InvalidScheduleError  = errors.New("exactly one of 'at' or 'cron' must be provided")
+	ScheduleInPastError   = errors.New("scheduled time must be in the future")
+	ScheduleNeverRunError = errors.New("cron expression never activates")
+	LaunchDisabledError   = errors.New("launches are disabled")
+)
+
+func init() {
+	background.TemplateLauncher = LaunchScheduledTemplate
+}
+
+func ScheduleReservationTemplate(w http.ResponseWriter, r *http.Request) {
+	id, err := ParseInt64(r, "ID")
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse ID parameter", err))
+		return
+	}
+
+	payload := &payloads.ReservationTemplateScheduleRequest{}
+	if err := render.Bind(r, payload); err != nil {
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "schedule reservation template", err))
+		return
+	}
+
+	var nextRunAt time.Time
+	if (payload.At == nil) == (payload.Cron == "") {
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), InvalidScheduleError.Error(), InvalidScheduleError))
+		return
+	} else if payload.At != nil {
+		nextRunAt = payload.At.UTC()
+		if !nextRunAt.After(time.Now()) {
+			renderError(w, r, payloads.NewInvalidRequestError(r.Context(), ScheduleInPastError.Error(), ScheduleInPastError))
+			return
+		}
+	} else {
+		schedule, err := cron.Parse(payload.Cron)
+		if err != nil {
+			renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "schedule reservation template", err))
+			return
+		}
+		nextRunAt = schedule.Next(time.Now().UTC())
+		if nextRunAt.IsZero() {
+			renderError(w, r, payloads.NewInvalidRequestError(r.Context(), ScheduleNeverRunError.Error(), ScheduleNeverRunError))
+			return
+		}
+	}
+
+	templateDao := dao.GetReservationTemplateDao(r.Context())
+
+	template, err := templateDao.GetById(r.Context(), id)
+	if err != nil {
+		message := fmt.Sprintf("get reservation template with id %d", id)
+		renderNotFoundOrDAOError(w, r, err, message)
+		return
+	}
+
+	if CheckPermissionAndRender(w, r, "write", "reservation", template.Provider.String()) != nil {
+		return
+	}
+
+	// launches are performed on behalf of the user who scheduled the template
+	user := identity.Identity(r.Context()).Identity.User
+	err = templateDao.UpdateSchedule(r.Context(), id, payload.Cron, &nextRunAt, user.UserID, user.Username)
+	if err != nil {
+		message := fmt.Sprintf("schedule reservation template with id %d", id)
+		renderNotFoundOrDAOError(w, r, err, message)
+		return
+	}
+
+	template, err = templateDao.GetById(r.Context(), id)
+	if err != nil {
+		message := fmt.Sprintf("get reservation template with id %d", id)
+		renderNotFoundOrDAOError(w, r, err, message)
+		return
+	}
+
+	if err := render.Render(w, r, payloads.NewReservationTemplateResponse(template)); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render reservation template", err))
+	}
+}
+
+func UnscheduleReservationTemplate(w http.ResponseWriter, r *http.Request) {
+	id, err := ParseInt64(r, "ID")
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse ID parameter", err))
+		return
+	}
+
+	templateDao := dao.GetReservationTemplateDao(r.Context())
+
+	template, err := templateDao.GetById(r.Context(), id)
+	if err != nil {
+		message := fmt.Sprintf("get reservation template with id %d", id)
+		renderNotFoundOrDAOError(w, r, err, message)
+		return
+	}
+
+	if CheckPermissionAndRender(w, r, "write", "reservation", template.Provider.String()) != nil {
+		return
+	}
+
+	err = templateDao.UpdateSchedule(r.Context(), id, "", nil, "", "")
+	if err != nil {
+		message := fmt.Sprintf("unschedule reservation template with id %d", id)
+		renderNotFoundOrDAOError(w, r, err, message)
+		return
+	}
+
+	render.NoContent(w, r)
+}
+
+// LaunchScheduledTemplate creates a reservation from a scheduled template. The reservation is
+// created by a service identity on behalf of the user who scheduled the template and permissions
+// of the user are checked again via RBAC, so revoked access also stops scheduled launches. The user
+// is notified when the launch fails before the reservation is created. Returns ID of the new reservation.
+func LaunchScheduledTemplate(ctx context.Context, template *models.ReservationTemplate) (int64, error) {
+	account, err := dao.GetAccountDao(ctx).GetById(ctx, template.AccountID)
+	if err != nil {
+		return 0, fmt.Errorf("unable to get account: %w", err)
+	}
+
+	tenant := identity.Principal{}
+	tenant.Identity.OrgID = account.OrgID
+	tenant.Identity.AccountNumber = account.AccountNumber.String
+	ctx = identity.WithIdentity(ctx, identity.OnBehalfPrincipal(tenant, template.ScheduleUserID, template.ScheduleUsername))
+	ctx = identity.WithAccountId(ctx, account.ID)
+
+	id, err := launchScheduledTemplate(ctx, template)
+	if err != nil {
+		notifications.GetNotificationClient(ctx).FailedScheduledLaunch(ctx, template, err)
+		return 0, err
+	}
+	return id, nil
+}
+
+func launchScheduledTemplate(ctx context.Context, template *models.ReservationTemplate) (int64, error) {
+	if !config.LaunchEnabled(ctx) {
+		return 0, LaunchDisabledError
+	}
+
+	acl, err := clients.GetRbacClient(ctx).GetUserAccess(ctx, template.ScheduleUsername)
+	if err != nil {
+		return 0, fmt.Errorf("unable to get ACL: %w", err)
+	}
+	ctx = rbac.WithAcl(ctx, acl)
+	ctx = identity.WithWorkspaces(ctx, acl.Workspaces("reservation", "write"))
+
+	id, _, err := launchTemplate(ctx, template, nil)
+	return id, err
+}
@@ -0,0 +1,246 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/db"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/go-chi/render"
+)
+
+var (
+	MissingTemplateNameError         = errors.New("template name missing")
+	UnsupportedTemplateProviderError = errors.New("templates are only supported for aws, azure and gcp")
+)
+
+// validateTemplateRequest decodes the JSON request into the provider specific reservation request,
+// unknown fields are rejected so typos are not silently ignored.
+func validateTemplateRequest(ctx context.Context, pType models.ProviderType, request []byte) (Provider, render.Binder, error) {
+	if pType == models.ProviderTypeNoop {
+		return nil, nil, UnsupportedTemplateProviderError
+	}
+	provider, err := LookupProvider(pType)
+	if err != nil {
+		return nil, nil, UnsupportedTemplateProviderError
+	}
+
+	payload, err := provider.ValidateRequest(ctx, func(payload render.Binder) error {
+		decoder := json.NewDecoder(bytes.NewReader(request))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(payload); err != nil {
+			return fmt.Errorf("invalid %s reservation request: %w", pType.String(), err)
+		}
+		return nil
+	}, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return provider, payload, nil
+}
+
+// mergeTemplateRequest returns the template request with top-level fields replaced by overrides.
+func mergeTemplateRequest(request []byte, overrides []byte) ([]byte, error) {
+	merged := make(map[string]interface{})
+	if err := json.Unmarshal(request, &merged); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal template request: %w", err)
+	}
+
+	if len(bytes.TrimSpace(overrides)) > 0 {
+		if err := json.Unmarshal(overrides, &merged); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal overrides: %w", err)
+		}
+	}
+
+	result, err := json.Marshal(merged)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal reservation request: %w", err)
+	}
+	return result, nil
+}
+
+func CreateReservationTemplate(w http.ResponseWriter, r *http.Request) {
+	payload := &payloads.ReservationTemplateRequest{}
+	if err := render.Bind(r, payload); err != nil {
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "create reservation template", err))
+		return
+	}
+
+	if payload.Name == "" {
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), MissingTemplateNameError.Error(), MissingTemplateNameError))
+		return
+	}
+
+	template, err := payload.NewModel()
+	if err != nil {
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "create reservation template", err))
+		return
+	}
+
+	if _, _, err := validateTemplateRequest(r.Context(), template.Provider, template.Request); err != nil {
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "reservation template request", err))
+		return
+	}
+
+	templateDao := dao.GetReservationTemplateDao(r.Context())
+	err = templateDao.Create(r.Context(), template)
+	if err != nil {
+		if db.IsPostgresError(err, db.UniqueConstraintErrorCode) != nil {
+			renderError(w, r, payloads.ReservationTemplateDuplicateError(r.Context(), "reservation template with such name already exists for this account", err))
+		} else {
+			renderError(w, r, payloads.NewDAOError(r.Context(), "create reservation template", err))
+		}
+		return
+	}
+
+	if err := render.Render(w, r, payloads.NewReservationTemplateResponse(template)); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render reservation template", err))
+	}
+}
+
+func ListReservationTemplates(w http.ResponseWriter, r *http.Request) {
+	templateDao := dao.GetReservationTemplateDao(r.Context())
+
+	limit, offset, err := ParsePage(r)
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse limit or offset parameter", err))
+		return
+	}
+
+	templates, err := templateDao.List(r.Context(), limit, offset)
+	if err != nil {
+		renderError(w, r, payloads.NewDAOError(r.Context(), "list reservation templates", err))
+		return
+	}
+
+	total, err := templateDao.Count(r.Context())
+	if err != nil {
+		renderError(w, r, payloads.NewDAOError(r.Context(), "count reservation templates", err))
+		return
+	}
+
+	if err := render.Render(w, r, payloads.NewReservationTemplatePageResponse(r, templates, total, limit, offset)); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render reservation templates list", err))
+		return
+	}
+}
+
+func GetReservationTemplate(w http.ResponseWriter, r *http.Request) {
+	id, err := ParseInt64(r, "ID")
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse ID parameter", err))
+		return
+	}
+
+	templateDao := dao.GetReservationTemplateDao(r.Context())
+
+	template, err := templateDao.GetById(r.Context(), id)
+	if err != nil {
+		message := fmt.Sprintf("get reservation template with id %d", id)
+		renderNotFoundOrDAOError(w, r, err, message)
+		return
+	}
+
+	if err := render.Render(w, r, payloads.NewReservationTemplateResponse(template)); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render reservation template", err))
+	}
+}
+
+func DeleteReservationTemplate(w http.ResponseWriter, r *http.Request) {
+	id, err := ParseInt64(r, "ID")
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse ID parameter", err))
+		return
+	}
+
+	templateDao := dao.GetReservationTemplateDao(r.Context())
+
+	_, err = templateDao.GetById(r.Context(), id)
+	if err != nil {
+		message := fmt.Sprintf("get reservation template with id %d", id)
+		renderNotFoundOrDAOError(w, r, err, message)
+		return
+	}
+
+	err = templateDao.Delete(r.Context(), id)
+	if err != nil {
+		message := fmt.Sprintf("reservation template with id %d", id)
+		renderNotFoundOrDAOError(w, r, err, message)
+		return
+	}
+
+	render.NoContent(w, r)
+}
+
+// CreateReservationFromTemplate creates a reservation from the stored template request. The optional
+// payload is a partial provider specific reservation request, fields present in it override the
+// template (e.g. amount or name). The response is the same as for regular reservations.
+func CreateReservationFromTemplate(w http.ResponseWriter, r *http.Request) {
+	id, err := ParseInt64(r, "ID")
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse ID parameter", err))
+		return
+	}
+
+	templateDao := dao.GetReservationTemplateDao(r.Context())
+
+	template, err := templateDao.GetById(r.Context(), id)
+	if err != nil {
+		message := fmt.Sprintf("get reservation template with id %d", id)
+		renderNotFoundOrDAOError(w, r, err, message)
+		return
+	}
+
+	overrides, err := io.ReadAll(r.Body)
+	if err != nil {
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "unable to read overrides", err))
+		return
+	}
+
+	if !config.LaunchEnabled(r.Context()) {
+		writeUnauthorized(w, r)
+		return
+	}
+
+	_, response, err := launchTemplate(r.Context(), template, overrides)
+	if err != nil {
+		renderResponseError(w, r, err)
+		return
+	}
+
+	if err := render.Render(w, r, response); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render reservation", err))
+	}
+}
+
+// launchTemplate creates a reservation from the template request merged with overrides, permissions
+// of the identity in the context are checked. Returns ID and response of the new reservation.
+func launchTemplate(ctx context.Context, template *models.ReservationTemplate, overrides []byte) (int64, render.Renderer, error) {
+	if err := CheckPermission(ctx, "write", "reservation", template.Provider.String()); err != nil {
+		return 0, nil, err
+	}
+
+	body, err := mergeTemplateRequest(template.Request, overrides)
+	if err != nil {
+		return 0, nil, withResponse(payloads.NewInvalidRequestError(ctx, "reservation template overrides", err), err)
+	}
+
+	provider, payload, err := validateTemplateRequest(ctx, template.Provider, body)
+	if err != nil {
+		return 0, nil, withResponse(payloads.NewInvalidRequestError(ctx, "reservation template overrides", err), err)
+	}
+	if !provider.Enabled(ctx) {
+		message := fmt.Sprintf("%s reservation is not implemented", template.Provider)
+		return 0, nil, withResponse(payloads.NewInvalidRequestError(ctx, message, ProviderTypeNotImplementedError), ProviderTypeNotImplementedError)
+	}
+
+	return provider.Launch(ctx, payload)
+}
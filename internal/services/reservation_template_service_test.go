@@ -0,0 +1,419 @@
+package services_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/clients/http/rbac"
+	clientStubs "github.com/RHEnVision/provisioning-backend/internal/clients/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/RHEnVision/provisioning-backend/internal/services"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/factories"
+	tidentity "github.com/RHEnVision/provisioning-backend/internal/testing/identity"
+	_ "github.com/RHEnVision/provisioning-backend/internal/testing/initialization"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateReservationTemplateHandler(t *testing.T) {
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = tidentity.WithTenant(t, ctx)
+	ctx = stubs.WithReservationTemplateDao(ctx)
+
+	create := func(t *testing.T, values map[string]interface{}) *httptest.ResponseRecorder {
+		t.Helper()
+		json_data, err := json.Marshal(values)
+		require.NoError(t, err, "unable to marshal values to json")
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/templates", bytes.NewBuffer(json_data))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateReservationTemplate)
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("success", func(t *testing.T) {
+		rr := create(t, map[string]interface{}{
+			"name":     "weekly lab",
+			"provider": "aws",
+			"request": map[string]interface{}{
+				"source_id":     "1",
+				"image_id":      "ami-0c830793775595d4b",
+				"amount":        3,
+				"instance_type": "t3.small",
+				"pubkey_id":     1,
+			},
+		})
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.ReservationTemplateResponse
+		err := json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		assert.Equal(t, "weekly lab", result.Name)
+		assert.Equal(t, "aws", result.Provider)
+		assert.Equal(t, "t3.small", result.Request["instance_type"])
+		assert.Equal(t, 1, stubs.ReservationTemplateStubCount(ctx))
+	})
+
+	t.Run("unsupported provider", func(t *testing.T) {
+		rr := create(t, map[string]interface{}{
+			"name":     "noop",
+			"provider": "noop",
+			"request":  map[string]interface{}{},
+		})
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+		assert.Contains(t, rr.Body.String(), "templates are only supported")
+	})
+
+	t.Run("unknown request field", func(t *testing.T) {
+		rr := create(t, map[string]interface{}{
+			"name":     "typo",
+			"provider": "aws",
+			"request": map[string]interface{}{
+				"instance_typ": "t3.small",
+			},
+		})
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+		assert.Contains(t, rr.Body.String(), "instance_typ")
+	})
+
+	t.Run("hibernation not supported", func(t *testing.T) {
+		rr := create(t, map[string]interface{}{
+			"name":     "hibernated lab",
+			"provider": "aws",
+			"request": map[string]interface{}{
+				"instance_type": "a1.large",
+				"hibernation":   true,
+			},
+		})
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+		assert.Contains(t, rr.Body.String(), "does not support hibernation")
+	})
+
+	t.Run("missing name", func(t *testing.T) {
+		rr := create(t, map[string]interface{}{
+			"provider": "aws",
+			"request":  map[string]interface{}{},
+		})
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+}
+
+func TestGetAndDeleteReservationTemplateHandler(t *testing.T) {
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = tidentity.WithTenant(t, ctx)
+	ctx = stubs.WithReservationTemplateDao(ctx)
+	template := &models.ReservationTemplate{
+		Name:     "lab",
+		Provider: models.ProviderTypeGCP,
+		Request:  []byte(`{"zone":"us-east4-b","amount":2}`),
+	}
+	err := stubs.AddReservationTemplate(ctx, template)
+	require.NoError(t, err, "failed to add stubbed template")
+
+	rctx := chi.NewRouteContext()
+	ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+	rctx.URLParams.Add("ID", "1")
+
+	t.Run("get", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(ctx, "GET", "/api/provisioning/templates/1", nil)
+		require.NoError(t, err, "failed to create request")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.GetReservationTemplate)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.ReservationTemplateResponse
+		err = json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		assert.Equal(t, "gcp", result.Provider)
+		assert.Equal(t, "us-east4-b", result.Request["zone"])
+	})
+
+	t.Run("list", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(ctx, "GET", "/api/provisioning/templates", nil)
+		require.NoError(t, err, "failed to create request")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.ListReservationTemplates)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.ReservationTemplateListResponse
+		err = json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		assert.Equal(t, 1, len(result.Data))
+		assert.Equal(t, int64(1), result.Meta.Total)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(ctx, "DELETE", "/api/provisioning/templates/1", nil)
+		require.NoError(t, err, "failed to create request")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.DeleteReservationTemplate)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusNoContent, rr.Code, "Handler returned wrong status code")
+		assert.Equal(t, 0, stubs.ReservationTemplateStubCount(ctx))
+	})
+
+	t.Run("get deleted", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(ctx, "GET", "/api/provisioning/templates/1", nil)
+		require.NoError(t, err, "failed to create request")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.GetReservationTemplate)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusNotFound, rr.Code, "Handler returned wrong status code")
+	})
+}
+
+func TestCreateReservationFromTemplateHandler(t *testing.T) {
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = tidentity.WithTenant(t, ctx)
+	ctx = clientStubs.WithSourcesClient(ctx)
+	ctx = clientStubs.WithImageBuilderClient(ctx)
+	ctx = clientStubs.WithEC2Client(ctx)
+	ctx = stubs.WithReservationDao(ctx)
+	ctx = stubs.WithPubkeyDao(ctx)
+	ctx = stubs.WithReservationTemplateDao(ctx)
+	ctx = rbac.WithAcl(ctx, clients.AllPermissionsRbacAcl)
+	pk := factories.NewPubkeyRSA()
+	err := stubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to generate pubkey")
+
+	request, err := json.Marshal(map[string]interface{}{
+		"source_id":     "1",
+		"image_id":      "2bc640f6-927a-404a-9594-5b2da7e06608",
+		"amount":        1,
+		"instance_type": "t1.micro",
+		"pubkey_id":     pk.ID,
+	})
+	require.NoError(t, err, "unable to marshal template request")
+	err = stubs.AddReservationTemplate(ctx, &models.ReservationTemplate{
+		Name:     "lab",
+		Provider: models.ProviderTypeAWS,
+		Request:  request,
+	})
+	require.NoError(t, err, "failed to add stubbed template")
+
+	rctx := chi.NewRouteContext()
+	ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+	rctx.URLParams.Add("ID", "1")
+
+	t.Run("with overrides", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/templates/1/reservations", bytes.NewBufferString(`{"amount": 3}`))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateReservationFromTemplate)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.AWSReservationResponse
+		err = json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		assert.Equal(t, int32(3), result.Amount)
+		assert.Equal(t, "t1.micro", result.InstanceType)
+		assert.Equal(t, 1, stubs.AWSReservationStubCount(ctx))
+	})
+
+	t.Run("without overrides", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/templates/1/reservations", http.NoBody)
+		require.NoError(t, err, "failed to create request")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateReservationFromTemplate)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+		assert.Equal(t, 2, stubs.AWSReservationStubCount(ctx))
+	})
+
+	t.Run("unknown override", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/templates/1/reservations", bytes.NewBufferString(`{"count": 3}`))
+		require.NoError(t, err, "failed to create request")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateReservationFromTemplate)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+		assert.Equal(t, 2, stubs.AWSReservationStubCount(ctx))
+	})
+
+	t.Run("template not found", func(t *testing.T) {
+		ctx := stubs.WithFaults(ctx)
+		stubs.InjectError(ctx, "ReservationTemplateDao.GetById", 0, dao.ErrNoRows)
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/templates/1/reservations", http.NoBody)
+		require.NoError(t, err, "failed to create request")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.CreateReservationFromTemplate)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusNotFound, rr.Code, "Handler returned wrong status code")
+	})
+}
+
+func TestScheduleReservationTemplateHandler(t *testing.T) {
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = tidentity.WithTenant(t, ctx)
+	ctx = stubs.WithReservationTemplateDao(ctx)
+	ctx = rbac.WithAcl(ctx, clients.AllPermissionsRbacAcl)
+	template := &models.ReservationTemplate{
+		Name:     "lab",
+		Provider: models.ProviderTypeAWS,
+		Request:  []byte(`{"amount":2}`),
+	}
+	err := stubs.AddReservationTemplate(ctx, template)
+	require.NoError(t, err, "failed to add stubbed template")
+
+	rctx := chi.NewRouteContext()
+	ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+	rctx.URLParams.Add("ID", "1")
+
+	schedule := func(t *testing.T, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(ctx, "PUT", "/api/provisioning/templates/1/schedule", bytes.NewBufferString(body))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.ScheduleReservationTemplate)
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("cron", func(t *testing.T) {
+		rr := schedule(t, `{"cron": "30 6 * * 1-5"}`)
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.ReservationTemplateResponse
+		err := json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		require.NotNil(t, result.Schedule)
+		assert.Equal(t, "30 6 * * 1-5", result.Schedule.Cron)
+		require.NotNil(t, result.Schedule.NextRunAt)
+		assert.Equal(t, 6, result.Schedule.NextRunAt.Hour())
+		assert.Equal(t, identity.Identity(ctx).Identity.User.Username, template.ScheduleUsername, "user must be stored")
+	})
+
+	t.Run("one-shot", func(t *testing.T) {
+		at := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+		rr := schedule(t, fmt.Sprintf(`{"at": "%s"}`, at.Format(time.RFC3339)))
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+		assert.Empty(t, template.ScheduleCron)
+		assert.Equal(t, at, template.NextRunAt.Time)
+	})
+
+	t.Run("in the past", func(t *testing.T) {
+		rr := schedule(t, `{"at": "2013-05-13T19:20:25Z"}`)
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("both at and cron", func(t *testing.T) {
+		rr := schedule(t, `{"at": "2113-05-13T19:20:25Z", "cron": "* * * * *"}`)
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("invalid cron", func(t *testing.T) {
+		rr := schedule(t, `{"cron": "61 * * * *"}`)
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("unschedule without permission", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, schedule(t, `{"cron": "30 6 * * 1-5"}`).Code, "Handler returned wrong status code")
+
+		req, err := http.NewRequestWithContext(rbac.WithAcl(ctx, clients.NoPermissionsRbacAcl), "DELETE", "/api/provisioning/templates/1/schedule", nil)
+		require.NoError(t, err, "failed to create request")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.UnscheduleReservationTemplate)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusForbidden, rr.Code, "Handler returned wrong status code")
+		assert.True(t, template.Scheduled())
+	})
+
+	t.Run("unschedule", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(ctx, "DELETE", "/api/provisioning/templates/1/schedule", nil)
+		require.NoError(t, err, "failed to create request")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.UnscheduleReservationTemplate)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusNoContent, rr.Code, "Handler returned wrong status code")
+		assert.False(t, template.Scheduled())
+	})
+}
+
+func TestLaunchScheduledTemplate(t *testing.T) {
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = tidentity.WithTenant(t, ctx)
+	ctx = clientStubs.WithSourcesClient(ctx)
+	ctx = clientStubs.WithImageBuilderClient(ctx)
+	ctx = clientStubs.WithEC2Client(ctx)
+	ctx = stubs.WithReservationDao(ctx)
+	ctx = stubs.WithPubkeyDao(ctx)
+	ctx = stubs.WithReservationTemplateDao(ctx)
+	pk := factories.NewPubkeyRSA()
+	err := stubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to generate pubkey")
+
+	request, err := json.Marshal(map[string]interface{}{
+		"source_id":     "1",
+		"image_id":      "2bc640f6-927a-404a-9594-5b2da7e06608",
+		"amount":        1,
+		"instance_type": "t1.micro",
+		"pubkey_id":     pk.ID,
+	})
+	require.NoError(t, err, "unable to marshal template request")
+	template := &models.ReservationTemplate{
+		Name:             "lab",
+		Provider:         models.ProviderTypeAWS,
+		Request:          request,
+		ScheduleUserID:   identity.Identity(ctx).Identity.User.UserID,
+		ScheduleUsername: identity.Identity(ctx).Identity.User.Username,
+	}
+	err = stubs.AddReservationTemplate(ctx, template)
+	require.NoError(t, err, "failed to add stubbed template")
+
+	id, err := services.LaunchScheduledTemplate(ctx, template)
+	require.NoError(t, err, "scheduled launch failed")
+	assert.NotZero(t, id)
+	assert.Equal(t, 1, stubs.AWSReservationStubCount(ctx))
+
+	reservation, err := dao.GetReservationDao(ctx).GetById(ctx, id)
+	require.NoError(t, err, "reservation not found")
+	assert.Equal(t, template.ScheduleUsername, reservation.CreatedBy, "reservation must be owned by the user")
+
+	template.Request = []byte(`{"source_id": "1", "unknown": true}`)
+	_, err = services.LaunchScheduledTemplate(ctx, template)
+	require.Error(t, err, "invalid template request must fail")
+	assert.Equal(t, 1, stubs.AWSReservationStubCount(ctx))
+}
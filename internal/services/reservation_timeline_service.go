@@ -0,0 +1,49 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/go-chi/render"
+)
+
+// GetReservationTimeline returns state transitions and notable events of a reservation and its
+// launch job ordered by time.
+func GetReservationTimeline(w http.ResponseWriter, r *http.Request) {
+	id, err := ParseInt64(r, "ID")
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse ID parameter", err))
+		return
+	}
+
+	limit, offset, err := ParsePage(r)
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse limit or offset parameter", err))
+		return
+	}
+
+	reservation := getVisibleReservation(w, r, id)
+	if reservation == nil {
+		return
+	}
+
+	if CheckPermissionAndRender(w, r, "read", "reservation", reservation.Provider.String()) != nil {
+		return
+	}
+
+	rDao := dao.GetReservationDao(r.Context())
+	events, err := rDao.ListEvents(r.Context(), id, limit, offset)
+	var total int64
+	if err == nil {
+		total, err = rDao.CountEvents(r.Context(), id)
+	}
+	if err != nil {
+		renderError(w, r, payloads.NewDAOError(r.Context(), "list reservation events", err))
+		return
+	}
+
+	if err := render.Render(w, r, payloads.NewReservationEventPageResponse(r, events, total, limit, offset)); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render reservation timeline", err))
+	}
+}
@@ -0,0 +1,89 @@
+package services_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/clients/http/rbac"
+	clientStubs "github.com/RHEnVision/provisioning-backend/internal/clients/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/RHEnVision/provisioning-backend/internal/queue/stub"
+	"github.com/RHEnVision/provisioning-backend/internal/services"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/factories"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/identity"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetReservationTimelineHandler(t *testing.T) {
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = identity.WithTenant(t, ctx)
+	ctx = clientStubs.WithSourcesClient(ctx)
+	ctx = clientStubs.WithImageBuilderClient(ctx)
+	ctx = clientStubs.WithEC2Client(ctx)
+	ctx = stubs.WithReservationDao(ctx)
+	ctx = stubs.WithPubkeyDao(ctx)
+	ctx = stub.WithEnqueuer(ctx)
+	ctx = rbac.WithAcl(ctx, clients.AllPermissionsRbacAcl)
+	pk := factories.NewPubkeyRSA()
+	err := stubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to generate pubkey")
+
+	values := map[string]interface{}{
+		"source_id":     "1",
+		"image_id":      "2bc640f6-927a-404a-9594-5b2da7e06608",
+		"amount":        1,
+		"instance_type": "t1.micro",
+		"pubkey_id":     pk.ID,
+	}
+	jsonData, err := json.Marshal(values)
+	require.NoError(t, err, "unable to marshal values to json")
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(jsonData))
+	require.NoError(t, err, "failed to create request")
+	req.Header.Add("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(services.CreateAWSReservation).ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+	timeline := func(t *testing.T, id string) *httptest.ResponseRecorder {
+		t.Helper()
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("ID", id)
+		ctx := context.WithValue(ctx, chi.RouteCtxKey, rctx)
+
+		req, err := http.NewRequestWithContext(ctx, "GET", "/api/provisioning/reservations/"+id+"/timeline", nil)
+		require.NoError(t, err, "failed to create request")
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(services.GetReservationTimeline).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("enqueued", func(t *testing.T) {
+		rr := timeline(t, "1")
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.ReservationEventListResponse
+		err := json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		require.Len(t, result.Data, 1)
+		assert.Equal(t, string(models.EventEnqueued), result.Data[0].Kind)
+		assert.Equal(t, stub.EnqueuedJobs(ctx)[0].ID.String(), result.Data[0].Details["job_id"])
+		assert.Equal(t, int64(1), result.Meta.Total)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		rr := timeline(t, "42")
+		require.Equal(t, http.StatusNotFound, rr.Code, "Handler returned wrong status code")
+	})
+}
@@ -1,76 +1,271 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/RHEnVision/provisioning-backend/internal/apiusage"
+	"github.com/RHEnVision/provisioning-backend/internal/clients/http/rbac"
 	"github.com/RHEnVision/provisioning-backend/internal/config"
 	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/RHEnVision/provisioning-backend/internal/jobs"
+	"github.com/RHEnVision/provisioning-backend/internal/logging"
 	"github.com/RHEnVision/provisioning-backend/internal/models"
 	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/RHEnVision/provisioning-backend/internal/queue"
+	"github.com/RHEnVision/provisioning-backend/pkg/worker"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
+	"github.com/rs/zerolog"
 )
 
 var (
-	UnknownProviderTypeError        = errors.New("unknown provider type parameter")
-	ProviderTypeMismatchError       = errors.New("reservation type does not match requested provider type")
-	ProviderTypeNotImplementedError = errors.New("provider type not yet implemented")
-	UnknownInstanceTypeNameError    = errors.New("unknown instance type")
-	ArchitectureMismatch            = errors.New("instance type and image architecture mismatch")
-	BothTypeAndTemplateMissingError = errors.New("instance type or launch template not set")
-	UnsupportedRegionError          = errors.New("unknown region/location/zone")
+	UnknownProviderTypeError            = errors.New("unknown provider type parameter")
+	ProviderTypeMismatchError           = errors.New("reservation type does not match requested provider type")
+	ProviderTypeNotImplementedError     = errors.New("provider type not yet implemented")
+	UnexpectedRequestTypeError          = errors.New("unexpected reservation request type")
+	UnknownInstanceTypeNameError        = errors.New("unknown instance type")
+	ArchitectureMismatch                = errors.New("instance type and image architecture mismatch")
+	BothTypeAndTemplateMissingError     = errors.New("instance type or launch template not set")
+	UnsupportedRegionError              = errors.New("unknown region/location/zone")
+	ZoneMismatchError                   = errors.New("zone does not match the location")
+	InstanceTypeNotInZoneError          = errors.New("instance type not available in the zone")
+	InvalidProximityPlacementGroupError = errors.New("invalid proximity placement group resource ID")
+	InvalidServiceAccountError          = errors.New("invalid service account or scope")
+	ScopesWithoutServiceAccountError    = errors.New("scopes require a service account")
+	InvalidInstanceProfileError         = errors.New("invalid instance profile name or ARN")
+	EncryptionWithoutImageError         = errors.New("volume encryption requires an image")
+	RootVolumeWithoutImageError         = errors.New("root volume options require an image")
+	InvalidRootVolumeError              = errors.New("invalid root volume options")
+	KMSKeyAccessDeniedError             = errors.New("role is not allowed to use the KMS key")
+	KMSKeyNotFoundError                 = errors.New("KMS key not found in AWS account")
+	InstanceProfileNotFoundError        = errors.New("instance profile not found in AWS account")
+	InstanceProfileNotPassableError     = errors.New("role is not allowed to pass the instance profile role")
+	RegionPartitionMismatchError        = errors.New("region is not in the AWS partition of the source")
+	UnknownSecurityTypeError            = errors.New("unknown security type")
+	SecurityTypeRequiredError           = errors.New("secure boot and vTPM require a security type")
+	ConfidentialSizeRequiredError       = errors.New("confidential VM requires a confidential VM size")
+	IntegrityMonitoringWithoutVTPMError = errors.New("integrity monitoring requires vTPM")
+	HibernationNotSupportedError        = errors.New("instance type does not support hibernation")
+	TooManyNetworkInterfacesError       = errors.New("too many network interfaces")
+	InvalidSubnetError                  = errors.New("invalid subnet ID")
+	InvalidSecurityGroupError           = errors.New("invalid security group ID")
+	InvalidPrivateIPError               = errors.New("invalid private IPv4 address")
+	PrivateIPWithAmountError            = errors.New("private IPv4 address requires amount of 1")
+	PrivateIPCountMismatchError         = errors.New("number of private IPs does not match the amount")
+	DuplicatePrivateIPError             = errors.New("duplicate private IPv4 address")
+	PrivateIPOutsideSubnetError         = errors.New("private IPv4 address outside of the subnet or reserved")
+	PrivateIPsWithoutSubnetError        = errors.New("private IPs require a subnet of the first network interface")
+	SubnetsWithNetworkInterfacesError   = errors.New("subnets and security groups cannot be combined with network interfaces")
+	PrivateIPsConflictError             = errors.New("private IPs conflict with the address of the primary interface")
+	InvalidDNSZoneError                 = errors.New("invalid DNS zone")
+	UnknownImageFamilyError             = errors.New("unknown image family")
+	InvalidAzureImageURNError           = errors.New("invalid marketplace image URN, expected publisher:offer:sku:version")
+	UnknownMarketplaceImageError        = errors.New("unknown marketplace image")
+	FallbackWithoutInstanceTypeError    = errors.New("fallback instance types require an instance type")
+	TooManyFallbackInstanceTypesError   = errors.New("too many fallback instance types")
+	DuplicateFallbackInstanceTypeError  = errors.New("duplicate fallback instance type")
+	FallbackArchitectureMismatchError   = errors.New("fallback instance type architecture does not match")
+	NegativeWaitError                   = errors.New("wait duration must not be negative")
+	NoReservationIDsError               = errors.New("no reservation ids")
+	TooManyReservationIDsError          = errors.New("too many reservation ids")
+	UnsupportedCreatedByError           = errors.New("unsupported created_by value")
+	WindowsPubkeyTypeError              = errors.New("windows images require an RSA public key")
+	InvalidSpotPriceError               = errors.New("invalid spot price, expected a positive amount in USD")
+	SpotHibernationError                = errors.New("spot instances cannot be hibernated")
+	GCPHibernationError                 = errors.New("hibernation is not supported for GCP instances")
+	InvalidTenancyError                 = errors.New("invalid tenancy, expected default, dedicated or host")
+	InvalidHostError                    = errors.New("invalid dedicated host ID")
+	HostWithoutHostTenancyError         = errors.New("dedicated host ID requires host tenancy")
+	SpotDedicatedHostError              = errors.New("spot instances cannot run on dedicated hosts")
+	InvalidCapacityReservationError     = errors.New("invalid capacity reservation, expected an ID or a group ARN")
+	CapacityReservationConflictError    = errors.New("capacity reservations cannot be combined with spot instances, dedicated hosts or fallback instance types")
+	InvalidLaunchTemplateError          = errors.New("invalid launch template ID or name")
+	TooManyTagsError                    = errors.New("too many tags")
+	InvalidTagError                     = errors.New("invalid tag")
+	InvalidUserDataError                = errors.New("invalid user data")
+	ElasticIPNetworkInterfacesError     = errors.New("elastic IPs cannot be associated to instances with multiple network interfaces")
+	InvalidElasticIPPoolError           = errors.New("invalid elastic IP pool")
+	PublicIPv4MultipleInterfacesError   = errors.New("public IPv4 addresses cannot be assigned to instances with multiple network interfaces")
+	InvalidHealthProbeError             = errors.New("invalid health probe")
 )
 
+// MaxReservationStatusIDs is the maximum number of reservations in a single batch status request
+const MaxReservationStatusIDs = 100
+
+// MaxReservationWait is the maximum duration a reservation detail request can be held by the wait parameter
+const MaxReservationWait = 60 * time.Second
+
+// userScoped returns true when the request may only see reservations created by the user. Users
+// with the reservation admin permission see reservations of everyone in the organization.
+func userScoped(r *http.Request) bool {
+	return config.Application.UserScoped && !rbac.Acl(r.Context()).IsAllowed("reservation", "admin")
+}
+
+// getVisibleReservation fetches the reservation, reservations created by other users are not found
+// when the request is user scoped. The error is rendered and nil is returned on failure.
+func getVisibleReservation(w http.ResponseWriter, r *http.Request, id int64) *models.Reservation {
+	reservation, err := dao.GetReservationDao(r.Context()).GetById(r.Context(), id)
+	if err == nil && userScoped(r) && reservation.CreatedByUserID != identity.Identity(r.Context()).Identity.User.UserID {
+		err = dao.ErrNoRows
+	}
+	if err != nil {
+		renderNotFoundOrDAOError(w, r, err, "get reservation detail")
+		return nil
+	}
+	return reservation
+}
+
+// scopedFilter returns the filter restricting reservations to the user when the request is user
+// scoped, otherwise the filter matches all reservations.
+func scopedFilter(r *http.Request) *dao.ReservationFilter {
+	filter := &dao.ReservationFilter{}
+	if userScoped(r) {
+		filter.ByCreator = true
+		filter.CreatedByUserID = identity.Identity(r.Context()).Identity.User.UserID
+	}
+	return filter
+}
+
+// reservationFilter returns the filter of the label and created_by query parameters. Reservations
+// are always restricted to the user when the request is user scoped.
+func reservationFilter(r *http.Request) (*dao.ReservationFilter, error) {
+	createdBy := r.URL.Query().Get("created_by")
+	if createdBy != "" && createdBy != "me" {
+		return nil, UnsupportedCreatedByError
+	}
+
+	filter := scopedFilter(r)
+	filter.Label = r.URL.Query().Get("label")
+	if createdBy == "me" {
+		filter.ByCreator = true
+		filter.CreatedByUserID = identity.Identity(r.Context()).Identity.User.UserID
+	}
+	return filter, nil
+}
+
 // CreateReservation dispatches requests to type provider specific handlers
 func CreateReservation(w http.ResponseWriter, r *http.Request) {
+	createReservation(w, r, models.ProviderTypeFromString(chi.URLParam(r, "TYPE")))
+}
+
+func createReservation(w http.ResponseWriter, r *http.Request, pType models.ProviderType) {
 	if !config.LaunchEnabled(r.Context()) {
 		writeUnauthorized(w, r)
+		return
 	}
 
-	pType := models.ProviderTypeFromString(chi.URLParam(r, "TYPE"))
-
 	// Check permission for individual provider type
 	if CheckPermissionAndRender(w, r, "write", "reservation", pType.String()) != nil {
 		return
 	}
 
-	switch pType {
-	case models.ProviderTypeNoop:
-		CreateNoopReservation(w, r)
-	case models.ProviderTypeAWS:
-		CreateAWSReservation(w, r)
-	case models.ProviderTypeAzure:
-		if config.FeatureEnabled(r.Context(), "azure") {
-			CreateAzureReservation(w, r)
-		} else {
-			renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "azure reservation is not implemented", ProviderTypeNotImplementedError))
-		}
-	case models.ProviderTypeGCP:
-		CreateGCPReservation(w, r)
-	case models.ProviderTypeUnknown:
-		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "provider is not supported", UnknownProviderTypeError))
-	default:
-		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "provider is not supported", UnknownProviderTypeError))
+	provider, err := LookupProvider(pType)
+	if err != nil {
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "provider is not supported", err))
+		return
+	}
+	if !provider.Enabled(r.Context()) {
+		message := fmt.Sprintf("%s reservation is not implemented", pType)
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), message, ProviderTypeNotImplementedError))
+		return
 	}
+	launchReservation(w, r, provider)
 }
 
 func ListReservations(w http.ResponseWriter, r *http.Request) {
 	rDao := dao.GetReservationDao(r.Context())
 
-	reservations, err := rDao.List(r.Context(), 100, 0)
+	filter, err := reservationFilter(r)
+	if err != nil {
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "created_by parameter", err))
+		return
+	}
+
+	limit, offset, err := ParsePage(r)
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse limit or offset parameter", err))
+		return
+	}
+
+	meta, err := ParseMeta(r)
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse meta parameter", err))
+		return
+	}
+
+	var reservations []*models.Reservation
+	var total int64
+	filtered := *filter != (dao.ReservationFilter{})
+	if filtered {
+		reservations, err = rDao.ListFiltered(r.Context(), filter, limit, offset)
+	} else {
+		reservations, err = rDao.List(r.Context(), limit, offset)
+	}
 	if err != nil {
 		renderError(w, r, payloads.NewDAOError(r.Context(), "list reservations", err))
 		return
 	}
 
-	if err := render.Render(w, r, payloads.NewReservationListResponse(reservations)); err != nil {
+	if !meta {
+		if err := render.Render(w, r, payloads.NewReservationListResponse(reservations)); err != nil {
+			renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render reservations list", err))
+		}
+		return
+	}
+
+	if filtered {
+		total, err = rDao.CountFiltered(r.Context(), filter)
+	} else {
+		total, err = rDao.Count(r.Context())
+	}
+	if err != nil {
+		renderError(w, r, payloads.NewDAOError(r.Context(), "count reservations", err))
+		return
+	}
+
+	if err := render.Render(w, r, payloads.NewReservationPageResponse(r, reservations, total, limit, offset)); err != nil {
 		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render reservations list", err))
 		return
 	}
 }
 
+// ListReservationStatus returns statuses of multiple reservations at once. Reservations which do not
+// exist or are not visible to a user scoped request are not present in the response.
+func ListReservationStatus(w http.ResponseWriter, r *http.Request) {
+	payload := &payloads.ReservationStatusRequest{}
+	if err := render.Bind(r, payload); err != nil {
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "reservation status", err))
+		return
+	}
+
+	if len(payload.IDs) == 0 {
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "reservation ids are missing", NoReservationIDsError))
+		return
+	}
+	if len(payload.IDs) > MaxReservationStatusIDs {
+		message := fmt.Sprintf("at most %d reservation ids are allowed", MaxReservationStatusIDs)
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), message, TooManyReservationIDsError))
+		return
+	}
+
+	rDao := dao.GetReservationDao(r.Context())
+	reservations, err := rDao.ListByIDs(r.Context(), payload.IDs, scopedFilter(r))
+	if err != nil {
+		renderError(w, r, payloads.NewDAOError(r.Context(), "list reservations by ids", err))
+		return
+	}
+
+	if err := render.Render(w, r, payloads.NewReservationListResponse(reservations)); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render reservations status", err))
+		return
+	}
+}
+
 func GetReservationDetail(w http.ResponseWriter, r *http.Request) {
 	provider := chi.URLParam(r, "TYPE")
 	providerType := models.ProviderTypeFromString(provider)
@@ -85,80 +280,154 @@ func GetReservationDetail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Get generic reservation and find its type
-	rDao := dao.GetReservationDao(r.Context())
-	reservation, err := rDao.GetById(r.Context(), id)
+	wait, err := ParseDuration(r.URL.Query().Get("wait"))
 	if err != nil {
-		renderNotFoundOrDAOError(w, r, err, "get reservation detail")
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse wait parameter", err))
 		return
 	}
+	if wait < 0 {
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "wait parameter", NegativeWaitError))
+		return
+	}
+	if wait > MaxReservationWait {
+		wait = MaxReservationWait
+	}
+
+	// Get generic reservation and find its type
+	reservation := getVisibleReservation(w, r, id)
+	if reservation == nil {
+		return
+	}
+
+	// Long-polling: hold the request until the reservation changes or the wait duration elapses
+	if wait > 0 && !reservation.FinishedAt.Valid {
+		reservation, err = waitForReservationUpdate(r.Context(), reservation, wait)
+		if err != nil {
+			renderNotFoundOrDAOError(w, r, err, "wait for reservation update")
+			return
+		}
+	}
 
 	if providerType != models.ProviderTypeUnknown && reservation.Provider != providerType {
 		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "provider type", ProviderTypeMismatchError))
 		return
 	}
 
-	switch providerType {
 	// Generic reservation request will have provider == "" and thus render this
-	case models.ProviderTypeUnknown, models.ProviderTypeNoop:
+	if providerType == models.ProviderTypeUnknown {
 		if err := render.Render(w, r, payloads.NewReservationResponse(reservation)); err != nil {
 			renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render reservation", err))
 		}
-	case models.ProviderTypeAWS:
-		reservationAws, err := rDao.GetAWSById(r.Context(), id)
-		if err != nil {
-			message := fmt.Sprintf("get AWS reservation with id %d", id)
-			renderNotFoundOrDAOError(w, r, err, message)
-			return
-		}
+		return
+	}
 
-		instances, err := rDao.ListInstances(r.Context(), id)
-		if err != nil {
-			message := fmt.Sprintf("get reservation with id %d", id)
-			renderNotFoundOrDAOError(w, r, err, message)
-			return
-		}
+	typeProvider, err := LookupProvider(providerType)
+	if err != nil {
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "provider is not supported", ProviderTypeNotImplementedError))
+		return
+	}
 
-		if err := render.Render(w, r, payloads.NewAWSReservationResponse(reservationAws, instances)); err != nil {
-			renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render reservation", err))
-		}
-	case models.ProviderTypeAzure:
-		reservationAzure, err := rDao.GetAzureById(r.Context(), id)
-		if err != nil {
-			message := fmt.Sprintf("get Azure reservation with id %d", id)
-			renderNotFoundOrDAOError(w, r, err, message)
-			return
-		}
+	rDao := dao.GetReservationDao(r.Context())
+	instances, err := rDao.ListInstances(r.Context(), id)
+	if err != nil {
+		message := fmt.Sprintf("get reservation instances with id %d", id)
+		renderNotFoundOrDAOError(w, r, err, message)
+		return
+	}
 
-		instances, err := rDao.ListInstances(r.Context(), id)
-		if err != nil {
-			message := fmt.Sprintf("get reservation instances with id %d", id)
-			renderNotFoundOrDAOError(w, r, err, message)
-			return
-		}
+	response, err := typeProvider.DescribeReservation(r.Context(), reservation, instances)
+	if err != nil {
+		message := fmt.Sprintf("get %s reservation with id %d", providerType, id)
+		renderNotFoundOrDAOError(w, r, err, message)
+		return
+	}
 
-		if err := render.Render(w, r, payloads.NewAzureReservationResponse(reservationAzure, instances)); err != nil {
-			renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render reservation", err))
-		}
-	case models.ProviderTypeGCP:
-		reservationGCP, err := rDao.GetGCPById(r.Context(), id)
-		if err != nil {
-			message := fmt.Sprintf("get GCP reservation with id %d", id)
-			renderNotFoundOrDAOError(w, r, err, message)
-			return
+	if err := render.Render(w, r, response); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render reservation", err))
+	}
+}
+
+// waitForReservationUpdate blocks until the reservation changes or the wait duration elapses and
+// returns the most recent state of the reservation.
+func waitForReservationUpdate(ctx context.Context, reservation *models.Reservation, wait time.Duration) (*models.Reservation, error) {
+	logger := zerolog.Ctx(ctx)
+	rDao := dao.GetReservationDao(ctx)
+
+	wait = waitWithinDeadline(ctx, wait)
+	waitCtx, cancel := context.WithTimeout(ctx, wait)
+	defer cancel()
+
+	logger.Debug().Msgf("Waiting up to %s for reservation %d update", wait, reservation.ID)
+	err := rDao.WaitForUpdate(waitCtx, reservation)
+	if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		return nil, fmt.Errorf("unable to wait for reservation: %w", err)
+	}
+
+	reservation, err = rDao.GetById(ctx, reservation.ID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get reservation after wait: %w", err)
+	}
+	return reservation, nil
+}
+
+// Time kept after long-polling to load and render the reservation before the request deadline.
+const waitDeadlineReserve = time.Second
+
+// waitWithinDeadline shortens the wait duration, so long-polling finishes before the request
+// deadline (e.g. gateway timeout) and the reservation can still be returned.
+func waitWithinDeadline(ctx context.Context, wait time.Duration) time.Duration {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return wait
+	}
+	if left := time.Until(deadline) - waitDeadlineReserve; left < wait {
+		if left < 0 {
+			return 0
 		}
+		return left
+	}
+	return wait
+}
+
+// enqueueReservationJob stores the job of the reservation, so it can be requeued later, and
+// enqueues it. Launches of more instances than the approval threshold of the organization are
+// not enqueued, the reservation waits for an approver.
+func enqueueReservationJob(ctx context.Context, reservation *models.Reservation, amount int64, job *worker.Job) error {
+	data, err := jobs.MarshalJob(job)
+	if err != nil {
+		return fmt.Errorf("unable to store job: %w", err)
+	}
+
+	err = dao.GetReservationDao(ctx).CreateJob(ctx, &models.ReservationJob{
+		ReservationID: reservation.ID,
+		JobType:       job.Type.String(),
+		Job:           data,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to store job: %w", err)
+	}
+	apiusage.RecordLaunch(ctx, amount)
 
-		instances, err := rDao.ListInstances(r.Context(), id)
+	settings, err := dao.GetAccountDao(ctx).GetSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to get settings: %w", err)
+	}
+	if settings.ApprovalThreshold > 0 && amount > settings.ApprovalThreshold {
+		err = dao.GetReservationDao(ctx).UpdateApproval(ctx, reservation.ID, models.ApprovalNone, models.ApprovalPending, "")
 		if err != nil {
-			message := fmt.Sprintf("get reservation with id %d", id)
-			renderNotFoundOrDAOError(w, r, err, message)
-			return
+			return fmt.Errorf("unable to request approval: %w", err)
 		}
+		reservation.Approval = models.ApprovalPending
+		zerolog.Ctx(logging.WithReservationId(ctx, reservation.ID)).Info().Msgf("Launch of %d instances waits for an approval", amount)
+		message := fmt.Sprintf("Launch of %d instances exceeds the approval threshold %d", amount, settings.ApprovalThreshold)
+		jobs.RecordEvent(ctx, reservation.ID, models.EventPendingApproval, message, nil)
+		return nil
+	}
 
-		if err := render.Render(w, r, payloads.NewGCPReservationResponse(reservationGCP, instances)); err != nil {
-			renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render reservation", err))
-		}
-	default:
-		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "provider is not supported", ProviderTypeNotImplementedError))
+	err = queue.GetEnqueuer(ctx).Enqueue(ctx, job)
+	if err != nil {
+		return fmt.Errorf("unable to enqueue job: %w", err)
 	}
+	jobs.RecordEnqueued(ctx, reservation.ID, job, "Job enqueued")
+	return nil
 }
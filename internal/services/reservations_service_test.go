@@ -1,6 +1,7 @@
 package services_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"net/http"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/RHEnVision/provisioning-backend/internal/clients"
 	"github.com/RHEnVision/provisioning-backend/internal/clients/http/rbac"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
 	"github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
 	"github.com/RHEnVision/provisioning-backend/internal/identity"
 	"github.com/RHEnVision/provisioning-backend/internal/models"
@@ -74,4 +76,216 @@ func TestGetReservationDetail(t *testing.T) {
 
 		assert.Equal(t, int(models.ProviderTypeAWS), response.Provider, "expected provider to be AWS in parsed json")
 	})
+	t.Run("Generic reservation with wait", func(t *testing.T) {
+		rr := getAWSReservationDetail(t, "?wait=50ms")
+		require.Equal(t, http.StatusOK, rr.Code, "Wrong status code")
+	})
+
+	t.Run("Generic reservation with invalid wait", func(t *testing.T) {
+		rr := getAWSReservationDetail(t, "?wait=never")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Wrong status code")
+	})
+}
+
+// getAWSReservationDetail creates a pending AWS reservation and fetches it via the generic detail handler
+func getAWSReservationDetail(t *testing.T, query string) *httptest.ResponseRecorder {
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = tidentity.WithTenant(t, ctx)
+	ctx = stubs.WithPubkeyDao(ctx)
+	ctx = stubs.WithReservationDao(ctx)
+	ctx = rbac.WithAcl(ctx, clients.AllPermissionsRbacAcl)
+	pk := factories.NewPubkeyRSA()
+	err := stubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to add stubbed key")
+
+	reservation := &models.AWSReservation{
+		PubkeyID: pk.ID,
+		SourceID: "1",
+		ImageID:  "ami-random",
+		Detail:   &models.AWSDetail{Region: "us-east-1", InstanceType: "t1.micro", Amount: 1},
+	}
+	reservation.AccountID = identity.AccountId(ctx)
+	reservation.Status = "Created"
+	reservation.Provider = models.ProviderTypeAWS
+	reservation.Steps = 2
+	err = stubs.AddAWSReservation(ctx, reservation)
+	require.NoError(t, err, "failed to create stub reservation")
+
+	rctx := chi.NewRouteContext()
+	ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+	rctx.URLParams.Add("ID", "1")
+	req, err := http.NewRequestWithContext(ctx, "GET", "/api/provisioning/v1/reservations/1"+query, nil)
+	require.NoError(t, err, "failed to create request")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(services.GetReservationDetail)
+	handler.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestListReservationStatus(t *testing.T) {
+	listStatus := func(t *testing.T, ctx context.Context, ids []int64) *httptest.ResponseRecorder {
+		body, err := json.Marshal(payloads.ReservationStatusRequest{IDs: ids})
+		require.NoError(t, err, "failed to marshal request")
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/v1/reservations/status", bytes.NewBuffer(body))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.ListReservationStatus)
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = tidentity.WithTenant(t, ctx)
+	ctx = stubs.WithPubkeyDao(ctx)
+	ctx = stubs.WithReservationDao(ctx)
+	ctx = rbac.WithAcl(ctx, clients.AllPermissionsRbacAcl)
+	pk := factories.NewPubkeyRSA()
+	err := stubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to add stubbed key")
+
+	for _, status := range []string{"Created", "Finished"} {
+		reservation := &models.AWSReservation{
+			PubkeyID: pk.ID,
+			SourceID: "1",
+			ImageID:  "ami-random",
+			Detail:   &models.AWSDetail{Region: "us-east-1", InstanceType: "t1.micro", Amount: 1},
+		}
+		reservation.AccountID = identity.AccountId(ctx)
+		reservation.Status = status
+		reservation.Provider = models.ProviderTypeAWS
+		err = stubs.AddAWSReservation(ctx, reservation)
+		require.NoError(t, err, "failed to create stub reservation")
+	}
+
+	t.Run("Existing and missing reservations", func(t *testing.T) {
+		rr := listStatus(t, ctx, []int64{1, 2, 42})
+		require.Equal(t, http.StatusOK, rr.Code, "Wrong status code")
+
+		var response payloads.GenericReservationListResponse
+		err := json.NewDecoder(rr.Body).Decode(&response)
+		require.NoError(t, err, "failed to decode response body")
+
+		require.Len(t, response.Data, 2)
+		assert.Equal(t, "Created", response.Data[0].Status)
+		assert.Equal(t, "Finished", response.Data[1].Status)
+	})
+
+	t.Run("No IDs", func(t *testing.T) {
+		rr := listStatus(t, ctx, []int64{})
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Wrong status code")
+	})
+
+	t.Run("Too many IDs", func(t *testing.T) {
+		ids := make([]int64, services.MaxReservationStatusIDs+1)
+		for i := range ids {
+			ids[i] = int64(i + 1)
+		}
+		rr := listStatus(t, ctx, ids)
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Wrong status code")
+	})
+
+	t.Run("User scoped without admin permission", func(t *testing.T) {
+		defer func(scoped bool) {
+			config.Application.UserScoped = scoped
+		}(config.Application.UserScoped)
+		config.Application.UserScoped = true
+
+		reservation := &models.AWSReservation{
+			PubkeyID: pk.ID,
+			SourceID: "1",
+			ImageID:  "ami-random",
+			Detail:   &models.AWSDetail{Region: "us-east-1", InstanceType: "t1.micro", Amount: 1},
+		}
+		reservation.AccountID = identity.AccountId(ctx)
+		reservation.CreatedByUserID = "1001"
+		reservation.Status = "Created"
+		reservation.Provider = models.ProviderTypeAWS
+		err := stubs.AddAWSReservation(ctx, reservation)
+		require.NoError(t, err, "failed to create stub reservation")
+
+		id := identity.Identity(ctx)
+		id.Identity.User.UserID = "1001"
+		scopedCtx := rbac.WithAcl(identity.WithIdentity(ctx, id), clients.NoPermissionsRbacAcl)
+		rr := listStatus(t, scopedCtx, []int64{1, 2, reservation.ID})
+		require.Equal(t, http.StatusOK, rr.Code, "Wrong status code")
+
+		var response payloads.GenericReservationListResponse
+		err = json.NewDecoder(rr.Body).Decode(&response)
+		require.NoError(t, err, "failed to decode response body")
+		require.Len(t, response.Data, 1)
+		assert.Equal(t, reservation.ID, response.Data[0].ID)
+	})
+}
+
+func TestListReservationsCreatedBy(t *testing.T) {
+	listReservations := func(t *testing.T, ctx context.Context, query string) *httptest.ResponseRecorder {
+		req, err := http.NewRequestWithContext(ctx, "GET", "/api/provisioning/v1/reservations"+query, nil)
+		require.NoError(t, err, "failed to create request")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(services.ListReservations)
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = tidentity.WithTenant(t, ctx)
+	id := identity.Identity(ctx)
+	id.Identity.User.UserID = "1001"
+	ctx = identity.WithIdentity(ctx, id)
+	ctx = stubs.WithPubkeyDao(ctx)
+	ctx = stubs.WithReservationDao(ctx)
+	ctx = rbac.WithAcl(ctx, clients.AllPermissionsRbacAcl)
+	pk := factories.NewPubkeyRSA()
+	err := stubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to add stubbed key")
+
+	for _, userId := range []string{"1001", "1002"} {
+		reservation := &models.AWSReservation{
+			PubkeyID: pk.ID,
+			SourceID: "1",
+			ImageID:  "ami-random",
+			Detail:   &models.AWSDetail{Region: "us-east-1", InstanceType: "t1.micro", Amount: 1},
+		}
+		reservation.AccountID = identity.AccountId(ctx)
+		reservation.CreatedByUserID = userId
+		reservation.Status = "Created"
+		reservation.Provider = models.ProviderTypeAWS
+		err = stubs.AddAWSReservation(ctx, reservation)
+		require.NoError(t, err, "failed to create stub reservation")
+	}
+
+	t.Run("Created by me", func(t *testing.T) {
+		rr := listReservations(t, ctx, "?created_by=me")
+		require.Equal(t, http.StatusOK, rr.Code, "Wrong status code")
+
+		var response payloads.GenericReservationListResponse
+		err := json.NewDecoder(rr.Body).Decode(&response)
+		require.NoError(t, err, "failed to decode response body")
+		require.Len(t, response.Data, 1)
+		assert.Equal(t, int64(1), response.Data[0].ID)
+	})
+
+	t.Run("User scoped without admin permission", func(t *testing.T) {
+		defer func(scoped bool) {
+			config.Application.UserScoped = scoped
+		}(config.Application.UserScoped)
+		config.Application.UserScoped = true
+
+		rr := listReservations(t, rbac.WithAcl(ctx, clients.NoPermissionsRbacAcl), "")
+		require.Equal(t, http.StatusOK, rr.Code, "Wrong status code")
+
+		var response payloads.GenericReservationListResponse
+		err := json.NewDecoder(rr.Body).Decode(&response)
+		require.NoError(t, err, "failed to decode response body")
+		require.Len(t, response.Data, 1)
+	})
+
+	t.Run("Unsupported value", func(t *testing.T) {
+		rr := listReservations(t, ctx, "?created_by=1002")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Wrong status code")
+	})
 }
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+)
+
+// EBS limits of supported root volume types
+const (
+	awsMaxVolumeSizeGiB = 16384
+	awsMaxIOPSPerGiB    = 500
+)
+
+// awsVolumeIOPS are provisioned IOPS limits of supported root volume types
+var awsVolumeIOPS = map[string]struct{ min, max int32 }{
+	"gp3": {3000, 16000},
+	"io2": {100, 64000},
+}
+
+// rootVolume validates root volume options and converts them to the model. The image is known when
+// an image ID is requested, the minimum size of the image is checked by the launch job.
+func rootVolume(volume *payloads.AWSRootVolumeRequest, imageID string) (*models.AWSRootVolume, error) {
+	if volume == nil {
+		return nil, nil
+	}
+	if imageID == "" {
+		return nil, RootVolumeWithoutImageError
+	}
+
+	if volume.Size < 0 || volume.Size > awsMaxVolumeSizeGiB {
+		return nil, fmt.Errorf("%w: size must be between 1 and %d GiB", InvalidRootVolumeError, awsMaxVolumeSizeGiB)
+	}
+	limits, ok := awsVolumeIOPS[volume.Type]
+	if volume.Type != "" && !ok {
+		return nil, fmt.Errorf("%w: unsupported type %s", InvalidRootVolumeError, volume.Type)
+	}
+	if volume.IOPS != 0 && !ok {
+		return nil, fmt.Errorf("%w: IOPS require gp3 or io2 type", InvalidRootVolumeError)
+	}
+	if volume.Type == "io2" && volume.IOPS == 0 {
+		return nil, fmt.Errorf("%w: IOPS are required for io2 type", InvalidRootVolumeError)
+	}
+	if volume.IOPS != 0 && (volume.IOPS < limits.min || volume.IOPS > limits.max) {
+		return nil, fmt.Errorf("%w: IOPS of %s must be between %d and %d", InvalidRootVolumeError, volume.Type, limits.min, limits.max)
+	}
+	if volume.Size > 0 && volume.IOPS > volume.Size*awsMaxIOPSPerGiB {
+		return nil, fmt.Errorf("%w: at most %d IOPS per GiB", InvalidRootVolumeError, awsMaxIOPSPerGiB)
+	}
+
+	return &models.AWSRootVolume{
+		Size: volume.Size,
+		Type: volume.Type,
+		IOPS: volume.IOPS,
+	}, nil
+}
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRootVolume(t *testing.T) {
+	tests := []struct {
+		name     string
+		volume   payloads.AWSRootVolumeRequest
+		expected error
+	}{
+		{"size", payloads.AWSRootVolumeRequest{Size: 50}, nil},
+		{"gp3", payloads.AWSRootVolumeRequest{Size: 50, Type: "gp3"}, nil},
+		{"gp3 with IOPS", payloads.AWSRootVolumeRequest{Size: 50, Type: "gp3", IOPS: 6000}, nil},
+		{"io2", payloads.AWSRootVolumeRequest{Type: "io2", IOPS: 100}, nil},
+		{"too large", payloads.AWSRootVolumeRequest{Size: awsMaxVolumeSizeGiB + 1}, InvalidRootVolumeError},
+		{"negative size", payloads.AWSRootVolumeRequest{Size: -1}, InvalidRootVolumeError},
+		{"unsupported type", payloads.AWSRootVolumeRequest{Type: "st1"}, InvalidRootVolumeError},
+		{"IOPS without type", payloads.AWSRootVolumeRequest{IOPS: 3000}, InvalidRootVolumeError},
+		{"io2 without IOPS", payloads.AWSRootVolumeRequest{Type: "io2"}, InvalidRootVolumeError},
+		{"gp3 IOPS too low", payloads.AWSRootVolumeRequest{Type: "gp3", IOPS: 100}, InvalidRootVolumeError},
+		{"io2 IOPS too high", payloads.AWSRootVolumeRequest{Type: "io2", IOPS: 64001}, InvalidRootVolumeError},
+		{"IOPS per GiB", payloads.AWSRootVolumeRequest{Size: 10, Type: "io2", IOPS: 6000}, InvalidRootVolumeError},
+	}
+	for _, tt := range tests {
+		volume := tt.volume
+		result, err := rootVolume(&volume, "ami-0c830793775595d4b")
+		if tt.expected == nil {
+			require.NoError(t, err, tt.name)
+			assert.Equal(t, tt.volume.Size, result.Size, tt.name)
+		} else {
+			assert.ErrorIs(t, err, tt.expected, tt.name)
+		}
+	}
+
+	t.Run("without image", func(t *testing.T) {
+		_, err := rootVolume(&payloads.AWSRootVolumeRequest{Size: 50}, "")
+		assert.ErrorIs(t, err, RootVolumeWithoutImageError)
+	})
+}
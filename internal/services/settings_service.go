@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/go-chi/render"
+	"golang.org/x/exp/slices"
+)
+
+var (
+	NegativeApprovalThresholdError  = errors.New("approval threshold must not be negative")
+	NegativeMaxVCPUsError           = errors.New("maximum vCPUs must not be negative")
+	InvalidInstanceTypePatternError = errors.New("invalid instance type pattern")
+	InstanceTypeNotAllowedError     = errors.New("instance type is not allowed by organization settings")
+	InvalidImagePatternError        = errors.New("invalid image pattern")
+	ImageNotAllowedError            = errors.New("image is not allowed by organization settings")
+)
+
+// GetSettings returns settings of the organization.
+func GetSettings(w http.ResponseWriter, r *http.Request) {
+	settings, err := dao.GetAccountDao(r.Context()).GetSettings(r.Context())
+	if err != nil {
+		renderError(w, r, payloads.NewDAOError(r.Context(), "get settings", err))
+		return
+	}
+
+	if err := render.Render(w, r, payloads.NewSettingsResponse(settings)); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render settings", err))
+	}
+}
+
+// UpdateSettings stores settings of the organization.
+func UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	payload := &payloads.SettingsRequest{}
+	if err := render.Bind(r, payload); err != nil {
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "update settings", err))
+		return
+	}
+	if payload.ApprovalThreshold < 0 {
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), NegativeApprovalThresholdError.Error(), NegativeApprovalThresholdError))
+		return
+	}
+	if payload.MaxVCPUs < 0 {
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), NegativeMaxVCPUsError.Error(), NegativeMaxVCPUsError))
+		return
+	}
+	for _, pattern := range append(slices.Clone(payload.AllowedInstanceTypes), payload.DeniedInstanceTypes...) {
+		if _, err := path.Match(pattern, ""); err != nil || pattern == "" {
+			message := fmt.Sprintf("invalid instance type pattern: '%s'", pattern)
+			renderError(w, r, payloads.NewInvalidRequestError(r.Context(), message, InvalidInstanceTypePatternError))
+			return
+		}
+	}
+	for _, pattern := range payload.AllowedImages {
+		if _, err := path.Match(pattern, ""); err != nil || pattern == "" {
+			message := fmt.Sprintf("invalid image pattern: '%s'", pattern)
+			renderError(w, r, payloads.NewInvalidRequestError(r.Context(), message, InvalidImagePatternError))
+			return
+		}
+	}
+
+	settings := &models.AccountSettings{
+		ApprovalThreshold:    payload.ApprovalThreshold,
+		AllowedInstanceTypes: payload.AllowedInstanceTypes,
+		DeniedInstanceTypes:  payload.DeniedInstanceTypes,
+		MaxVCPUs:             payload.MaxVCPUs,
+		ComposedImagesOnly:   payload.ComposedImagesOnly,
+		AllowedImages:        payload.AllowedImages,
+	}
+	err := dao.GetAccountDao(r.Context()).UpdateSettings(r.Context(), settings)
+	if err != nil {
+		renderError(w, r, payloads.NewDAOError(r.Context(), "update settings", err))
+		return
+	}
+
+	if err := render.Render(w, r, payloads.NewSettingsResponse(settings)); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render settings", err))
+	}
+}
+
+// checkInstanceTypePolicy returns an error when the organization settings do not allow launches
+// with any of the instance types. Number of vCPUs is looked up by the provider specific function.
+func checkInstanceTypePolicy(settings *models.AccountSettings, find func(clients.InstanceTypeName) *clients.InstanceType, names ...string) error {
+	for _, name := range names {
+		var vcpus int32
+		if it := find(clients.InstanceTypeName(name)); it != nil {
+			vcpus = it.VCPUs
+		}
+		if !settings.AllowsInstanceType(name, vcpus) {
+			return fmt.Errorf("%w: %s", InstanceTypeNotAllowedError, name)
+		}
+	}
+	return nil
+}
+
+// CheckInstanceTypeSettings checks the organization settings allow launches with the instance
+// types. The returned error carries a bad request response, the caller must not continue.
+func CheckInstanceTypeSettings(ctx context.Context, find func(clients.InstanceTypeName) *clients.InstanceType, names ...string) error {
+	settings, err := dao.GetAccountDao(ctx).GetSettings(ctx)
+	if err != nil {
+		return withResponse(payloads.NewDAOError(ctx, "get settings", err), fmt.Errorf("unable to get settings: %w", err))
+	}
+
+	if policyErr := checkInstanceTypePolicy(settings, find, names...); policyErr != nil {
+		return withResponse(payloads.NewInvalidRequestError(ctx, policyErr.Error(), policyErr), policyErr)
+	}
+	return nil
+}
+
+// CheckInstanceTypePolicyAndRender checks the organization settings allow launches with the instance
+// types. When not allowed, it renders a bad request and returns an error, the caller must not continue.
+func CheckInstanceTypePolicyAndRender(w http.ResponseWriter, r *http.Request, find func(clients.InstanceTypeName) *clients.InstanceType, names ...string) error {
+	err := CheckInstanceTypeSettings(r.Context(), find, names...)
+	if err != nil {
+		renderResponseError(w, r, err)
+	}
+	return err
+}
+
+// CheckImageSettings checks the organization settings allow launches from the image, composed
+// images are built by Image Builder. The returned error carries a bad request response, the caller
+// must not continue.
+func CheckImageSettings(ctx context.Context, imageID string, composed bool) error {
+	settings, err := dao.GetAccountDao(ctx).GetSettings(ctx)
+	if err != nil {
+		return withResponse(payloads.NewDAOError(ctx, "get settings", err), fmt.Errorf("unable to get settings: %w", err))
+	}
+
+	if !settings.AllowsImage(imageID, composed) {
+		policyErr := fmt.Errorf("%w: %s", ImageNotAllowedError, imageID)
+		return withResponse(payloads.NewInvalidRequestError(ctx, policyErr.Error(), policyErr), policyErr)
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package services_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	clientStubs "github.com/RHEnVision/provisioning-backend/internal/clients/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/RHEnVision/provisioning-backend/internal/services"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/factories"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateSettingsInstanceTypesHandler(t *testing.T) {
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = identity.WithTenant(t, ctx)
+
+	update := func(t *testing.T, values map[string]interface{}) *httptest.ResponseRecorder {
+		t.Helper()
+		jsonData, err := json.Marshal(values)
+		require.NoError(t, err, "unable to marshal values to json")
+
+		req, err := http.NewRequestWithContext(ctx, "PUT", "/api/provisioning/settings", bytes.NewBuffer(jsonData))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(services.UpdateSettings).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("update", func(t *testing.T) {
+		rr := update(t, map[string]interface{}{"denied_instance_types": []string{"*.metal"}, "max_vcpus": 64})
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.SettingsResponse
+		err := json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		assert.Equal(t, []string{}, result.AllowedInstanceTypes)
+		assert.Equal(t, []string{"*.metal"}, result.DeniedInstanceTypes)
+		assert.Equal(t, int32(64), result.MaxVCPUs)
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		rr := update(t, map[string]interface{}{"allowed_instance_types": []string{"t3.[micro"}})
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("negative vcpus", func(t *testing.T) {
+		rr := update(t, map[string]interface{}{"max_vcpus": -1})
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+}
+
+func TestCreateAWSReservationInstanceTypePolicy(t *testing.T) {
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = identity.WithTenant(t, ctx)
+	ctx = clientStubs.WithSourcesClient(ctx)
+	ctx = clientStubs.WithImageBuilderClient(ctx)
+	ctx = clientStubs.WithEC2Client(ctx)
+	ctx = stubs.WithReservationDao(ctx)
+	ctx = stubs.WithPubkeyDao(ctx)
+	pk := factories.NewPubkeyRSA()
+	err := stubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to generate pubkey")
+	err = dao.GetAccountDao(ctx).UpdateSettings(ctx, &models.AccountSettings{
+		AllowedInstanceTypes: []string{"t1.*", "t2.*"},
+		DeniedInstanceTypes:  []string{"t2.micro"},
+	})
+	require.NoError(t, err, "failed to store settings")
+
+	create := func(t *testing.T, instanceType, template string) *httptest.ResponseRecorder {
+		t.Helper()
+		values := map[string]interface{}{
+			"source_id":          "1",
+			"image_id":           "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":             1,
+			"instance_type":      instanceType,
+			"launch_template_id": template,
+			"pubkey_id":          pk.ID,
+		}
+		jsonData, err := json.Marshal(values)
+		require.NoError(t, err, "unable to marshal values to json")
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(jsonData))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(services.CreateAWSReservation).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("allowed", func(t *testing.T) {
+		rr := create(t, "t1.micro", "")
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("denied", func(t *testing.T) {
+		rr := create(t, "t2.micro", "")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("not allowed", func(t *testing.T) {
+		rr := create(t, "m5.large", "")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+		assert.Equal(t, 1, stubs.AWSReservationStubCount(ctx), "Reservation must not be created")
+	})
+
+	t.Run("launch template type not allowed", func(t *testing.T) {
+		rr := create(t, "", "lt-8732678436272377")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+		assert.Contains(t, rr.Body.String(), services.InstanceTypeNotAllowedError.Error())
+		assert.Equal(t, 1, stubs.AWSReservationStubCount(ctx), "Reservation must not be created")
+	})
+
+	t.Run("launch template type overridden", func(t *testing.T) {
+		rr := create(t, "t1.micro", "lt-8732678436272377")
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+	})
+}
+
+func TestCreateAWSReservationImagePolicy(t *testing.T) {
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = identity.WithTenant(t, ctx)
+	ctx = clientStubs.WithSourcesClient(ctx)
+	ctx = clientStubs.WithImageBuilderClient(ctx)
+	ctx = clientStubs.WithEC2Client(ctx)
+	ctx = stubs.WithReservationDao(ctx)
+	ctx = stubs.WithPubkeyDao(ctx)
+	pk := factories.NewPubkeyRSA()
+	err := stubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to generate pubkey")
+	err = dao.GetAccountDao(ctx).UpdateSettings(ctx, &models.AccountSettings{
+		ComposedImagesOnly: true,
+		AllowedImages:      []string{"ami-0c830793775595d4b"},
+	})
+	require.NoError(t, err, "failed to store settings")
+
+	create := func(t *testing.T, imageID, template string) *httptest.ResponseRecorder {
+		t.Helper()
+		values := map[string]interface{}{
+			"source_id":          "1",
+			"image_id":           imageID,
+			"amount":             1,
+			"instance_type":      "t1.micro",
+			"launch_template_id": template,
+			"pubkey_id":          pk.ID,
+		}
+		jsonData, err := json.Marshal(values)
+		require.NoError(t, err, "unable to marshal values to json")
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(jsonData))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(services.CreateAWSReservation).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("composed", func(t *testing.T) {
+		rr := create(t, "2bc640f6-927a-404a-9594-5b2da7e06608", "")
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("allowed AMI", func(t *testing.T) {
+		rr := create(t, "ami-0c830793775595d4b", "")
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("arbitrary AMI", func(t *testing.T) {
+		rr := create(t, "ami-0123456789abcdef0", "")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+		assert.Equal(t, 2, stubs.AWSReservationStubCount(ctx), "Reservation must not be created")
+	})
+
+	t.Run("launch template AMI", func(t *testing.T) {
+		rr := create(t, "", "lt-8732678436272377")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+		assert.Contains(t, rr.Body.String(), services.ImageNotAllowedError.Error())
+		assert.Equal(t, 2, stubs.AWSReservationStubCount(ctx), "Reservation must not be created")
+	})
+
+	t.Run("launch template AMI overridden", func(t *testing.T) {
+		rr := create(t, "2bc640f6-927a-404a-9594-5b2da7e06608", "lt-8732678436272377")
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+	})
+}
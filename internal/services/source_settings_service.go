@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/RHEnVision/provisioning-backend/internal/preload"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"golang.org/x/exp/slices"
+)
+
+var (
+	UnknownPolicyRegionError     = errors.New("unknown region in source settings")
+	DefaultRegionNotAllowedError = errors.New("default region is not in allowed regions")
+	RegionNotAllowedError        = errors.New("region is not allowed by source settings")
+	EventTargetNotSupportedError = errors.New("event targets are only supported for AWS sources")
+	EventTargetAccountError      = errors.New("event target is not in the AWS account of the source")
+)
+
+// GetSourceSettings returns settings of a source, defaults for sources without settings.
+func GetSourceSettings(w http.ResponseWriter, r *http.Request) {
+	sourceId := chi.URLParam(r, "ID")
+
+	settings, err := dao.GetAccountDao(r.Context()).GetSourceSettings(r.Context(), sourceId)
+	if err != nil {
+		renderError(w, r, payloads.NewDAOError(r.Context(), "get source settings", err))
+		return
+	}
+
+	if err := render.Render(w, r, payloads.NewSourceSettingsResponse(settings)); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render source settings", err))
+	}
+}
+
+// UpdateSourceSettings stores settings of a source, regions are validated against regions of
+// the source provider.
+func UpdateSourceSettings(w http.ResponseWriter, r *http.Request) {
+	sourceId := chi.URLParam(r, "ID")
+
+	payload := &payloads.SourceSettingsRequest{}
+	if err := render.Bind(r, payload); err != nil {
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), "update source settings", err))
+		return
+	}
+
+	sourcesClient, err := clients.GetSourcesClient(r.Context())
+	if err != nil {
+		renderError(w, r, payloads.NewClientError(r.Context(), err))
+		return
+	}
+
+	authentication, err := sourcesClient.GetAuthentication(r.Context(), sourceId)
+	if err != nil {
+		renderError(w, r, payloads.NewClientError(r.Context(), err))
+		return
+	}
+
+	for _, region := range append(slices.Clone(payload.AllowedRegions), payload.DefaultRegion) {
+		if region != "" && !knownPolicyRegion(authentication.ProviderType, region) {
+			message := fmt.Sprintf("unknown region: %s", region)
+			renderError(w, r, payloads.NewInvalidRequestError(r.Context(), message, UnknownPolicyRegionError))
+			return
+		}
+	}
+
+	if payload.EventTarget != "" {
+		if err := validateEventTarget(authentication, payload.EventTarget); err != nil {
+			renderError(w, r, payloads.NewInvalidRequestError(r.Context(), err.Error(), err))
+			return
+		}
+	}
+
+	settings := &models.SourceSettings{
+		SourceID:       sourceId,
+		DefaultRegion:  payload.DefaultRegion,
+		AllowedRegions: payload.AllowedRegions,
+		EventTarget:    payload.EventTarget,
+	}
+	if settings.DefaultRegion != "" && !settings.AllowsRegion(settings.DefaultRegion) {
+		renderError(w, r, payloads.NewInvalidRequestError(r.Context(), DefaultRegionNotAllowedError.Error(), DefaultRegionNotAllowedError))
+		return
+	}
+
+	err = dao.GetAccountDao(r.Context()).UpdateSourceSettings(r.Context(), settings)
+	if err != nil {
+		renderError(w, r, payloads.NewDAOError(r.Context(), "update source settings", err))
+		return
+	}
+
+	if err := render.Render(w, r, payloads.NewSourceSettingsResponse(settings)); err != nil {
+		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render source settings", err))
+	}
+}
+
+// knownPolicyRegion checks the region is preloaded, Azure and GCP availability is preloaded per zone.
+func knownPolicyRegion(provider models.ProviderType, region string) bool {
+	switch provider {
+	case models.ProviderTypeAWS:
+		return validAWSRegion(region)
+	case models.ProviderTypeAzure:
+		return preload.AzureInstanceType.ValidateZonedRegion(region, "_")
+	case models.ProviderTypeGCP:
+		return preload.GCPInstanceType.ValidateZonedRegion(region, "-")
+	case models.ProviderTypeNoop, models.ProviderTypeUnknown:
+	}
+	return false
+}
+
+// validateEventTarget checks the event target is a queue or an event bus in a known region of the
+// AWS account of the source, events are published using the role of the source.
+func validateEventTarget(authentication *clients.Authentication, value string) error {
+	if authentication.ProviderType != models.ProviderTypeAWS {
+		return EventTargetNotSupportedError
+	}
+	target, err := clients.ParseEventTarget(value)
+	if err != nil {
+		return fmt.Errorf("event target: %w", err)
+	}
+	if !knownPolicyRegion(models.ProviderTypeAWS, target.Region) {
+		return fmt.Errorf("%w: %s", UnknownPolicyRegionError, target.Region)
+	}
+	if target.Partition != clients.AWSPartition(authentication.Payload) || target.AccountID != clients.AWSAccountID(authentication.Payload) {
+		return EventTargetAccountError
+	}
+	return nil
+}
+
+// checkSourceRegion returns an error when the source settings do not allow launches into the region.
+func checkSourceRegion(settings *models.SourceSettings, region string) error {
+	if !settings.AllowsRegion(region) {
+		return fmt.Errorf("%w: %s", RegionNotAllowedError, region)
+	}
+	return nil
+}
+
+// sourceDefaultRegion returns the default region from source settings, blank when not set.
+func sourceDefaultRegion(ctx context.Context, sourceId string) (string, error) {
+	settings, err := dao.GetAccountDao(ctx).GetSourceSettings(ctx, sourceId)
+	if err != nil {
+		return "", fmt.Errorf("unable to get source settings: %w", err)
+	}
+	return settings.DefaultRegion, nil
+}
+
+// gcpRegion returns the region of a GCP zone.
+func gcpRegion(zone string) string {
+	if i := strings.LastIndex(zone, "-"); i > 0 {
+		return zone[:i]
+	}
+	return zone
+}
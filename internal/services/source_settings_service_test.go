@@ -0,0 +1,149 @@
+package services_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	clientStubs "github.com/RHEnVision/provisioning-backend/internal/clients/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/dao/stubs"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/RHEnVision/provisioning-backend/internal/services"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/factories"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/identity"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateSourceSettingsHandler(t *testing.T) {
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = identity.WithTenant(t, ctx)
+	ctx = clientStubs.WithSourcesClient(ctx)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("ID", "1")
+	ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+
+	update := func(t *testing.T, values map[string]interface{}) *httptest.ResponseRecorder {
+		t.Helper()
+		jsonData, err := json.Marshal(values)
+		require.NoError(t, err, "unable to marshal values to json")
+
+		req, err := http.NewRequestWithContext(ctx, "PUT", "/api/provisioning/sources/1/settings", bytes.NewBuffer(jsonData))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(services.UpdateSourceSettings).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("update", func(t *testing.T) {
+		rr := update(t, map[string]interface{}{"default_region": "eu-west-1", "allowed_regions": []string{"eu-west-1", "eu-central-1"}})
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		req, err := http.NewRequestWithContext(ctx, "GET", "/api/provisioning/sources/1/settings", nil)
+		require.NoError(t, err, "failed to create request")
+		rr = httptest.NewRecorder()
+		http.HandlerFunc(services.GetSourceSettings).ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.SourceSettingsResponse
+		err = json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		assert.Equal(t, "eu-west-1", result.DefaultRegion)
+		assert.Equal(t, []string{"eu-west-1", "eu-central-1"}, result.AllowedRegions)
+	})
+
+	t.Run("unknown region", func(t *testing.T) {
+		rr := update(t, map[string]interface{}{"allowed_regions": []string{"eu-west-42"}})
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("default region not allowed", func(t *testing.T) {
+		rr := update(t, map[string]interface{}{"default_region": "us-east-1", "allowed_regions": []string{"eu-west-1"}})
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("event target", func(t *testing.T) {
+		rr := update(t, map[string]interface{}{"event_target": "arn:aws:events:us-east-1:230214684733:event-bus/provisioning"})
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.SourceSettingsResponse
+		err := json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		assert.Equal(t, "arn:aws:events:us-east-1:230214684733:event-bus/provisioning", result.EventTarget)
+	})
+
+	t.Run("event target in other account", func(t *testing.T) {
+		rr := update(t, map[string]interface{}{"event_target": "arn:aws:sqs:us-east-1:123456789012:launches"})
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+
+	t.Run("invalid event target", func(t *testing.T) {
+		rr := update(t, map[string]interface{}{"event_target": "arn:aws:sns:us-east-1:230214684733:topic"})
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+	})
+}
+
+func TestCreateAWSReservationSourceRegionPolicy(t *testing.T) {
+	ctx := stubs.WithAccountDaoOne(context.Background())
+	ctx = identity.WithTenant(t, ctx)
+	ctx = clientStubs.WithSourcesClient(ctx)
+	ctx = clientStubs.WithImageBuilderClient(ctx)
+	ctx = clientStubs.WithEC2Client(ctx)
+	ctx = stubs.WithReservationDao(ctx)
+	ctx = stubs.WithPubkeyDao(ctx)
+	pk := factories.NewPubkeyRSA()
+	err := stubs.AddPubkey(ctx, pk)
+	require.NoError(t, err, "failed to generate pubkey")
+	err = dao.GetAccountDao(ctx).UpdateSourceSettings(ctx, &models.SourceSettings{
+		SourceID:       "1",
+		DefaultRegion:  "eu-west-1",
+		AllowedRegions: []string{"eu-west-1"},
+	})
+	require.NoError(t, err, "failed to store source settings")
+
+	create := func(t *testing.T, region string) *httptest.ResponseRecorder {
+		t.Helper()
+		values := map[string]interface{}{
+			"source_id":     "1",
+			"image_id":      "2bc640f6-927a-404a-9594-5b2da7e06608",
+			"amount":        1,
+			"instance_type": "t1.micro",
+			"pubkey_id":     pk.ID,
+			"region":        region,
+		}
+		jsonData, err := json.Marshal(values)
+		require.NoError(t, err, "unable to marshal values to json")
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "/api/provisioning/reservations/aws", bytes.NewBuffer(jsonData))
+		require.NoError(t, err, "failed to create request")
+		req.Header.Add("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(services.CreateAWSReservation).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("default region", func(t *testing.T) {
+		rr := create(t, "")
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.AWSReservationResponse
+		err := json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+		assert.Equal(t, "eu-west-1", result.Region)
+	})
+
+	t.Run("region not allowed", func(t *testing.T) {
+		rr := create(t, "us-east-1")
+		require.Equal(t, http.StatusBadRequest, rr.Code, "Handler returned wrong status code")
+		assert.Equal(t, 1, stubs.AWSReservationStubCount(ctx), "Reservation must not be created")
+	})
+}
@@ -8,10 +8,13 @@ import (
 
 	"github.com/RHEnVision/provisioning-backend/internal/cache"
 	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/config"
 	"github.com/RHEnVision/provisioning-backend/internal/models"
 	"github.com/RHEnVision/provisioning-backend/internal/payloads"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
+	"github.com/rs/zerolog"
+	"golang.org/x/sync/errgroup"
 )
 
 func ListSources(w http.ResponseWriter, r *http.Request) {
@@ -22,6 +25,11 @@ func ListSources(w http.ResponseWriter, r *http.Request) {
 		renderError(w, r, payloads.NewClientError(r.Context(), err))
 		return
 	}
+	details, err := ParseBool(r.URL.Query().Get("details"))
+	if err != nil {
+		renderError(w, r, payloads.NewURLParsingError(r.Context(), "unable to parse details parameter", err))
+		return
+	}
 	provider := r.URL.Query().Get("provider")
 	asProviderType := models.ProviderTypeFromString(provider)
 	if asProviderType != models.ProviderTypeUnknown {
@@ -41,12 +49,60 @@ func ListSources(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := render.Render(w, r, payloads.NewListSourcesResponse(sourcesList)); err != nil {
+	var auths []*clients.Authentication
+	var errs []error
+	if details != nil && *details {
+		auths, errs, err = fetchSourceAuthentications(r.Context(), client, sourcesList)
+		if err != nil {
+			renderError(w, r, payloads.NewClientError(r.Context(), err))
+			return
+		}
+	}
+
+	if err := render.Render(w, r, payloads.NewListSourcesResponse(sourcesList, auths, errs)); err != nil {
 		renderError(w, r, payloads.NewRenderError(r.Context(), "unable to render sources list", err))
 		return
 	}
 }
 
+// fetchSourceAuthentications fetches authentications of all sources in parallel, at most the
+// configured amount of calls at once. It makes one call per source, so it is only called when
+// the details are explicitly requested. Failures of individual sources are returned in the errs
+// slice at the position of the source, the error is only returned when the request is cancelled.
+func fetchSourceAuthentications(ctx context.Context, client clients.Sources, sourceList []*clients.Source) ([]*clients.Authentication, []error, error) {
+	logger := zerolog.Ctx(ctx)
+	auths := make([]*clients.Authentication, len(sourceList))
+	errs := make([]error, len(sourceList))
+
+	limit := config.RestEndpoints.Sources.Concurrency
+	if limit < 1 {
+		limit = 1
+	}
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(limit)
+	for i, source := range sourceList {
+		i, source := i, source
+		g.Go(func() error {
+			auth, err := client.GetAuthentication(gCtx, source.ID)
+			if ctxErr := gCtx.Err(); ctxErr != nil {
+				return fmt.Errorf("unable to fetch source details: %w", ctxErr)
+			}
+			if err != nil {
+				logger.Warn().Err(err).Str("source_id", source.ID).Msg("Unable to fetch source authentication")
+				errs[i] = err
+				return nil
+			}
+			auths[i] = auth
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, nil, err //nolint:wrapcheck
+	}
+	return auths, errs, nil
+}
+
 func GetAWSAccountIdentity(w http.ResponseWriter, r *http.Request) {
 	sourceId := chi.URLParam(r, "ID")
 
@@ -101,6 +157,8 @@ func GetSourceUploadInfo(w http.ResponseWriter, r *http.Request) {
 			renderError(w, r, payloads.NewAWSError(r.Context(), "unable to get AWS upload info", err))
 			return
 		}
+		// upload info is requested when the source is selected, instance types are next
+		prefetchInstanceTypes(r.Context(), sourceId, authentication)
 	case models.ProviderTypeAzure:
 		if payload.AzureInfo, err = getAzureAccountDetails(r.Context(), sourceId, authentication); err != nil {
 			renderError(w, r, payloads.NewAzureError(r.Context(), "unable to fetch Azure upload info", err))
@@ -132,7 +190,9 @@ func getAWSAccountDetails(ctx context.Context, sourceId string, authentication *
 			return nil, fmt.Errorf("unable to initialize AWS client: %w", clientErr)
 		}
 
-		result.AccountID, clientErr = ec2Client.GetAccountId(ctx)
+		result.AccountID, clientErr = cache.Coalesce("aws_account:"+sourceId, func() (string, error) {
+			return ec2Client.GetAccountId(ctx)
+		})
 		if clientErr != nil {
 			return nil, fmt.Errorf("unable to get account id: %w", clientErr)
 		}
@@ -158,7 +218,9 @@ func getAzureAccountDetails(ctx context.Context, sourceId string, authentication
 
 	err = cache.Find(ctx, sourceId, &tenantId)
 	if errors.Is(err, cache.ErrNotFound) {
-		tenantId, err = azureClient.TenantId(ctx)
+		tenantId, err = cache.Coalesce("azure_tenant:"+sourceId, func() (clients.AzureTenantId, error) {
+			return azureClient.TenantId(ctx)
+		})
 		if err != nil {
 			return nil, fmt.Errorf("unable to fetch Tenant ID: %w", err)
 		}
@@ -41,6 +41,33 @@ func TestListSourcesHandler(t *testing.T) {
 		require.NoError(t, err, "failed to decode response body")
 
 		assert.Equal(t, 2, len(result.Data), "expected two result in response json")
+		assert.Empty(t, result.Data[0].Provider, "expected no provider without details")
+		assert.Empty(t, result.Errors, "expected no errors without details")
+	})
+
+	t.Run("with details", func(t *testing.T) {
+		ctx := stubs.WithAccountDaoOne(context.Background())
+		ctx = identity.WithTenant(t, ctx)
+		ctx = clientStub.WithSourcesClient(ctx)
+
+		req, err := http.NewRequestWithContext(ctx, "GET", "/api/provisioning/sources?details=true", nil)
+		require.NoError(t, err, "failed to create request")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(ListSources)
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, "Handler returned wrong status code")
+
+		var result payloads.SourceListResponse
+		err = json.NewDecoder(rr.Body).Decode(&result)
+		require.NoError(t, err, "failed to decode response body")
+
+		assert.Equal(t, 2, len(result.Data), "expected two result in response json")
+		assert.Equal(t, "aws", result.Data[0].Provider)
+		assert.Empty(t, result.Data[1].Provider, "expected the source without authentication to have no provider")
+		require.Len(t, result.Errors, 1, "expected the source without authentication to be reported")
+		assert.Equal(t, "2", result.Errors[0].SourceID)
 	})
 
 	t.Run("with provider", func(t *testing.T) {
@@ -111,3 +138,14 @@ func TestGetAzureSourceDetails(t *testing.T) {
 		assert.Equal(t, 3, len(result.AzureInfo.ResourceGroups), "expected three resource groups in response json")
 	})
 }
+
+func TestDetachedContext(t *testing.T) {
+	type key string
+	parent, cancel := context.WithCancel(context.WithValue(context.Background(), key("test"), "value"))
+	ctx := detachedContext{parent: parent}
+	cancel()
+
+	require.NoError(t, ctx.Err(), "detached context must not be canceled with the parent")
+	assert.Nil(t, ctx.Done())
+	assert.Equal(t, "value", ctx.Value(key("test")))
+}
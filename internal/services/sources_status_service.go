@@ -5,7 +5,6 @@ import (
 	stdhttp "net/http"
 
 	"github.com/RHEnVision/provisioning-backend/internal/clients"
-	"github.com/RHEnVision/provisioning-backend/internal/config"
 	"github.com/RHEnVision/provisioning-backend/internal/models"
 	"github.com/RHEnVision/provisioning-backend/internal/payloads"
 	"github.com/go-chi/chi/v5"
@@ -34,7 +33,7 @@ func SourcesStatus(w stdhttp.ResponseWriter, r *stdhttp.Request) {
 	var statusClient clients.ClientStatuser
 	switch auth.Type() {
 	case models.ProviderTypeAWS:
-		statusClient, err = clients.GetEC2Client(r.Context(), auth, config.AWS.DefaultRegion)
+		statusClient, err = clients.GetEC2Client(r.Context(), auth, "")
 		if err != nil {
 			renderError(w, r, payloads.NewAWSError(r.Context(), "unable to get AWS client", err))
 			return
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+)
+
+var spotPriceRegexp = regexp.MustCompile(`^\d{1,6}(\.\d{1,6})?$`)
+
+// checkSpot validates spot options of an AWS launch, the price is a decimal amount in USD like
+// the ones AWS returns in spot price history. Spot instances are terminated when interrupted,
+// hibernation requires persistent requests which are not supported.
+func checkSpot(spot *payloads.AWSSpotRequest, hibernation bool) error {
+	if spot == nil {
+		return nil
+	}
+	if hibernation {
+		return SpotHibernationError
+	}
+
+	price := strings.TrimSpace(spot.MaxPrice)
+	if price == "" {
+		return nil
+	}
+	if value, err := strconv.ParseFloat(price, 64); err != nil || value <= 0 || !spotPriceRegexp.MatchString(price) {
+		return fmt.Errorf("%w: %s", InvalidSpotPriceError, spot.MaxPrice)
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	"github.com/RHEnVision/provisioning-backend/internal/db"
 	"github.com/RHEnVision/provisioning-backend/internal/payloads"
 	"github.com/go-chi/chi/v5"
 )
@@ -14,7 +15,13 @@ func StatusService(w http.ResponseWriter, r *http.Request) {
 	writeOk(w, r)
 }
 
+// ReadyService returns service unavailable when the database connection is degraded, the state
+// is updated by a background health check so no database connection is made.
 func ReadyService(w http.ResponseWriter, r *http.Request) {
+	if !db.Healthy() {
+		writeServiceUnavailable(w, r)
+		return
+	}
 	writeOk(w, r)
 }
 
@@ -23,6 +30,12 @@ var UnknownReadinessServiceErr = errors.New("unknown service for readiness test"
 func ReadyBackendService(w http.ResponseWriter, r *http.Request) {
 	service := chi.URLParam(r, "SRV")
 	switch strings.ToLower(service) {
+	case "db", "database":
+		err := db.Pool.Ping(r.Context())
+		if err != nil {
+			writeServiceUnavailable(w, r)
+			return
+		}
 	case "rbac", "RBAC":
 		client := clients.GetRbacClient(r.Context())
 		err := client.Ready(r.Context())
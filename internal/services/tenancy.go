@@ -0,0 +1,39 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+)
+
+// awsTenancies are supported tenancies of AWS instances, shared hardware is the default
+var awsTenancies = map[string]bool{
+	"":          true,
+	"default":   true,
+	"dedicated": true,
+	"host":      true,
+}
+
+// awsTenancy validates tenancy options of an AWS launch and returns the tenancy stored in the
+// reservation, empty for shared hardware. A host ID requires host tenancy, instances are placed
+// on any host with auto-placement when not set. Spot instances cannot run on dedicated hosts.
+func awsTenancy(tenancy, hostID string, spot *payloads.AWSSpotRequest) (string, error) {
+	if !awsTenancies[tenancy] {
+		return "", fmt.Errorf("%w: %s", InvalidTenancyError, tenancy)
+	}
+	if tenancy == "default" {
+		tenancy = ""
+	}
+
+	if hostID != "" && tenancy != "host" {
+		return "", HostWithoutHostTenancyError
+	}
+	if hostID != "" && !strings.HasPrefix(hostID, "h-") {
+		return "", fmt.Errorf("%w: %s", InvalidHostError, hostID)
+	}
+	if tenancy == "host" && spot != nil {
+		return "", SpotDedicatedHostError
+	}
+	return tenancy, nil
+}
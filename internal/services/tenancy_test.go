@@ -0,0 +1,44 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/payloads"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAWSTenancy(t *testing.T) {
+	type test struct {
+		name    string
+		tenancy string
+		hostID  string
+		spot    *payloads.AWSSpotRequest
+		want    string
+		err     error
+	}
+
+	tests := []test{
+		{"shared", "", "", nil, "", nil},
+		{"default", "default", "", nil, "", nil},
+		{"dedicated", "dedicated", "", &payloads.AWSSpotRequest{}, "dedicated", nil},
+		{"any host", "host", "", nil, "host", nil},
+		{"host", "host", "h-0a1b2c3d4e5f67890", nil, "host", nil},
+		{"unknown", "shared", "", nil, "", InvalidTenancyError},
+		{"host without tenancy", "dedicated", "h-0a1b2c3d4e5f67890", nil, "", HostWithoutHostTenancyError},
+		{"invalid host", "host", "i-0a1b2c3d4e5f67890", nil, "", InvalidHostError},
+		{"spot host", "host", "", &payloads.AWSSpotRequest{}, "", SpotDedicatedHostError},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := awsTenancy(tc.tenancy, tc.hostID, tc.spot)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package services
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/RHEnVision/provisioning-backend/internal/userdata"
+)
+
+// checkUserData returns an error when custom user data cannot be passed to instances, user data
+// must be text as it is merged with the generated user data.
+func checkUserData(data string) error {
+	if len(data) > userdata.MaxCustomSize {
+		return fmt.Errorf("%w: at most %d bytes allowed", InvalidUserDataError, userdata.MaxCustomSize)
+	}
+	if !utf8.ValidString(data) {
+		return fmt.Errorf("%w: must be UTF-8 text", InvalidUserDataError)
+	}
+	return nil
+}
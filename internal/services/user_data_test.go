@@ -0,0 +1,34 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/userdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckUserData(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected error
+	}{
+		{"none", "", nil},
+		{"cloud-config", "#cloud-config\npackages:\n- vim\n", nil},
+		{"script", "#!/bin/sh\necho ahoj světe\n", nil},
+		{"max size", strings.Repeat("x", userdata.MaxCustomSize), nil},
+		{"too large", strings.Repeat("x", userdata.MaxCustomSize+1), InvalidUserDataError},
+		{"binary", "\x1f\x8b\x08\x00", InvalidUserDataError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkUserData(tt.data)
+			if tt.expected == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.expected)
+			}
+		})
+	}
+}
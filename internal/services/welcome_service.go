@@ -19,6 +19,7 @@ const welcomeTmpl = `<!DOCTYPE html>
 		<ul>
 			<li><a href="/docs">OpenAPI documentation</a></li>
 			<li><a href="/ping">Ping service</a> (identity not needed)</li>
+			<li><a href="/ready">Readiness probe</a> (identity not needed)</li>
 			<li><a href="/api/provisioning/{{ .APIVersion }}/openapi.json">OpenAPI JSON</a></li>
 			<li><a href="/api/provisioning/{{ .APIVersion }}/ready">Ready service</a> (identity needed)</li>
 		</ul>
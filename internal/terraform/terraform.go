@@ -0,0 +1,166 @@
+// Package terraform renders resources launched by reservations as Terraform import blocks, so
+// they can be adopted into infrastructure as code.
+package terraform
+
+import (
+	_ "embed"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+)
+
+// Resource is an imported resource.
+type Resource struct {
+	// Terraform resource type, e.g. "aws_instance".
+	Type string
+
+	// Terraform resource name, unique within the type.
+	Name string
+
+	// Import ID of the resource as expected by the Terraform provider.
+	ID string
+
+	// Optional comment rendered above the import block.
+	Comment string
+}
+
+// Export are the resources of a reservation.
+type Export struct {
+	ReservationID int64
+	Name          string
+	Resources     []Resource
+}
+
+//go:embed imports.tmpl
+var importsTemplate string
+
+var importsTmpl = template.Must(template.New("imports").Funcs(template.FuncMap{
+	"quote": strconv.Quote,
+}).Parse(importsTemplate))
+
+// invalidNameRegexp matches characters which are not allowed in Terraform identifiers
+var invalidNameRegexp = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// resourceName returns an identifier from the reservation name and the instance index, names
+// must start with a letter or an underscore.
+func resourceName(name string, index int) string {
+	name = strings.Trim(invalidNameRegexp.ReplaceAllString(strings.ToLower(name), "_"), "_-")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "instance_" + name
+	}
+	return fmt.Sprintf("%s_%d", strings.TrimSuffix(name, "_"), index)
+}
+
+// instanceComment describes the addresses of an instance.
+func instanceComment(instance *models.ReservationInstance) string {
+	comment := "Instance " + instance.InstanceID
+	if instance.Detail.PublicIPv4 != "" {
+		comment += ", public IPv4 " + instance.Detail.PublicIPv4
+	}
+	if instance.Detail.PrivateIPv4 != "" {
+		comment += ", private IPv4 " + instance.Detail.PrivateIPv4
+	}
+	return comment
+}
+
+// NewAWSExport returns instances and the Elastic IPs allocated for them, pooled and released
+// addresses were not created by the reservation and are skipped.
+func NewAWSExport(reservation *models.AWSReservation, instances []*models.ReservationInstance) *Export {
+	name := ""
+	if reservation.Detail != nil && reservation.Detail.Name != nil {
+		name = *reservation.Detail.Name
+	}
+	export := &Export{ReservationID: reservation.ID, Name: name}
+	for i, instance := range instances {
+		id := resourceName(name, i+1)
+		export.Resources = append(export.Resources, Resource{
+			Type:    "aws_instance",
+			Name:    id,
+			ID:      instance.InstanceID,
+			Comment: instanceComment(instance),
+		})
+		if instance.ElasticIP.Releasable() {
+			export.Resources = append(export.Resources, Resource{
+				Type:    "aws_eip",
+				Name:    id,
+				ID:      instance.ElasticIP.AllocationID,
+				Comment: "Elastic IP " + instance.ElasticIP.PublicIP,
+			}, Resource{
+				Type: "aws_eip_association",
+				Name: id,
+				ID:   instance.ElasticIP.AssociationID,
+			})
+		}
+	}
+	return export
+}
+
+// NewAzureExport returns virtual machines with the network interfaces and public IP addresses
+// created for them. Shared networking of the resource group is not exported.
+func NewAzureExport(reservation *models.AzureReservation, instances []*models.ReservationInstance) *Export {
+	name := ""
+	if reservation.Detail != nil {
+		name = reservation.Detail.Name
+	}
+	export := &Export{ReservationID: reservation.ID, Name: name}
+	for i, instance := range instances {
+		id := resourceName(name, i+1)
+		export.Resources = append(export.Resources, Resource{
+			Type:    "azurerm_linux_virtual_machine",
+			Name:    id,
+			ID:      instance.InstanceID,
+			Comment: instanceComment(instance),
+		})
+
+		// ".../providers/Microsoft.Compute/virtualMachines/<name>", names of networking follow the VM
+		prefix, vmName, ok := strings.Cut(instance.InstanceID, "/providers/Microsoft.Compute/virtualMachines/")
+		if !ok || vmName == "" {
+			continue
+		}
+		export.Resources = append(export.Resources, Resource{
+			Type: "azurerm_network_interface",
+			Name: id,
+			ID:   prefix + "/providers/Microsoft.Network/networkInterfaces/" + vmName + "_nic",
+		}, Resource{
+			Type: "azurerm_public_ip",
+			Name: id,
+			ID:   prefix + "/providers/Microsoft.Network/publicIPAddresses/" + vmName + "_ip",
+		})
+	}
+	return export
+}
+
+// NewGCPExport returns instances, they are imported by zone and ID into the project of the
+// provider configuration.
+func NewGCPExport(reservation *models.GCPReservation, instances []*models.ReservationInstance) *Export {
+	name, zone := "", ""
+	if reservation.Detail != nil {
+		zone = reservation.Detail.Zone
+		if reservation.Detail.NamePattern != nil {
+			name = *reservation.Detail.NamePattern
+		}
+	}
+	export := &Export{ReservationID: reservation.ID, Name: name}
+	for i, instance := range instances {
+		export.Resources = append(export.Resources, Resource{
+			Type:    "google_compute_instance",
+			Name:    resourceName(name, i+1),
+			ID:      zone + "/" + instance.InstanceID,
+			Comment: instanceComment(instance),
+		})
+	}
+	return export
+}
+
+// Render writes the import blocks of the export.
+func (e *Export) Render(w io.Writer) error {
+	if err := importsTmpl.Execute(w, e); err != nil {
+		return fmt.Errorf("cannot render terraform imports: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package terraform
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceName(t *testing.T) {
+	assert.Equal(t, "redhat-web_1", resourceName("redhat-web", 1))
+	assert.Equal(t, "my_server_2", resourceName("My Server!", 2))
+	assert.Equal(t, "instance_42vm_1", resourceName("42vm", 1))
+	assert.Equal(t, "instance_3", resourceName("", 3))
+}
+
+func TestAWSExport(t *testing.T) {
+	reservation := &models.AWSReservation{Detail: &models.AWSDetail{Name: ptr.To("web")}}
+	reservation.ID = 7
+	instances := []*models.ReservationInstance{
+		{
+			InstanceID: "i-0a4caa2cf5b097ce1",
+			Detail:     models.ReservationInstanceDetail{PublicIPv4: "203.0.113.1", PrivateIPv4: "10.0.0.1"},
+			ElasticIP:  models.ElasticIP{AllocationID: "eipalloc-1", AssociationID: "eipassoc-1", PublicIP: "203.0.113.1"},
+		},
+		{
+			InstanceID: "i-0a4caa2cf5b097ce2",
+			ElasticIP:  models.ElasticIP{AllocationID: "eipalloc-2", AssociationID: "eipassoc-2", Pooled: true},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	err := NewAWSExport(reservation, instances).Render(buf)
+	require.NoError(t, err)
+	require.Equal(t, `# Resources launched by reservation 7 (web).
+#
+# Import blocks require Terraform 1.5 or newer, generate the configuration of the imported
+# resources with: terraform plan -generate-config-out=generated.tf
+
+# Instance i-0a4caa2cf5b097ce1, public IPv4 203.0.113.1, private IPv4 10.0.0.1
+import {
+  to = aws_instance.web_1
+  id = "i-0a4caa2cf5b097ce1"
+}
+
+# Elastic IP 203.0.113.1
+import {
+  to = aws_eip.web_1
+  id = "eipalloc-1"
+}
+
+import {
+  to = aws_eip_association.web_1
+  id = "eipassoc-1"
+}
+
+# Instance i-0a4caa2cf5b097ce2
+import {
+  to = aws_instance.web_2
+  id = "i-0a4caa2cf5b097ce2"
+}
+`, buf.String())
+}
+
+func TestAzureExport(t *testing.T) {
+	reservation := &models.AzureReservation{Detail: &models.AzureDetail{Name: "vm"}}
+	vmID := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/vm-1"
+	export := NewAzureExport(reservation, []*models.ReservationInstance{{InstanceID: vmID}})
+
+	require.Len(t, export.Resources, 3)
+	assert.Equal(t, Resource{Type: "azurerm_linux_virtual_machine", Name: "vm_1", ID: vmID, Comment: "Instance " + vmID}, export.Resources[0])
+	assert.Equal(t, "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/networkInterfaces/vm-1_nic", export.Resources[1].ID)
+	assert.Equal(t, "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/publicIPAddresses/vm-1_ip", export.Resources[2].ID)
+}
+
+func TestGCPExport(t *testing.T) {
+	reservation := &models.GCPReservation{Detail: &models.GCPDetail{Zone: "us-east4-a"}}
+	export := NewGCPExport(reservation, []*models.ReservationInstance{{InstanceID: "3003942005876582747"}})
+
+	require.Len(t, export.Resources, 1)
+	assert.Equal(t, "google_compute_instance", export.Resources[0].Type)
+	assert.Equal(t, "instance_1", export.Resources[0].Name)
+	assert.Equal(t, "us-east4-a/3003942005876582747", export.Resources[0].ID)
+}
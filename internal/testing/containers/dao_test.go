@@ -0,0 +1,69 @@
+//go:build containers
+// +build containers
+
+package containers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/factories"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPubkeyDao(t *testing.T) {
+	ctx := identity.WithTenant(t, context.Background())
+	pkDao := dao.GetPubkeyDao(ctx)
+	defer reset()
+
+	pk := factories.NewPubkeyRSA()
+	err := pkDao.Create(ctx, pk)
+	require.NoError(t, err)
+
+	loaded, err := pkDao.GetById(ctx, pk.ID)
+	require.NoError(t, err)
+	assert.Equal(t, pk, loaded)
+
+	err = pkDao.Delete(ctx, pk.ID)
+	require.NoError(t, err)
+
+	_, err = pkDao.GetById(ctx, pk.ID)
+	require.ErrorIs(t, err, dao.ErrNoRows)
+}
+
+func TestReservationDao(t *testing.T) {
+	ctx := identity.WithTenant(t, context.Background())
+	reservationDao := dao.GetReservationDao(ctx)
+	defer reset()
+
+	res := &models.AWSReservation{
+		Reservation: models.Reservation{
+			Provider:  models.ProviderTypeAWS,
+			AccountID: 1,
+			Status:    "Created",
+		},
+		PubkeyID: 1,
+		Detail:   &models.AWSDetail{Region: "us-east-1", InstanceType: "t3.small", Amount: 1},
+	}
+	err := reservationDao.CreateAWS(ctx, res)
+	require.NoError(t, err)
+
+	loaded, err := reservationDao.GetAWSById(ctx, res.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "t3.small", loaded.Detail.InstanceType)
+
+	err = reservationDao.UpdateStatus(ctx, res.ID, "Launching", 1)
+	require.NoError(t, err)
+
+	err = reservationDao.FinishWithSuccess(ctx, res.ID)
+	require.NoError(t, err)
+
+	generic, err := reservationDao.GetById(ctx, res.ID)
+	require.NoError(t, err)
+	assert.True(t, generic.Success.Bool)
+	assert.Equal(t, int32(1), generic.Step)
+}
@@ -0,0 +1,41 @@
+//go:build containers
+// +build containers
+
+package containers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RHEnVision/provisioning-backend/internal/clients"
+	_ "github.com/RHEnVision/provisioning-backend/internal/clients/http/ec2"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStackEC2AssumedRole(t *testing.T) {
+	ctx := identity.WithIdentity(t, context.Background())
+	auth := clients.NewAuthentication("arn:aws:iam::000000000000:role/provisioning", models.ProviderTypeAWS)
+
+	// assumes the role via STS first
+	ec2, err := clients.GetEC2Client(ctx, auth, "us-east-1")
+	require.NoError(t, err)
+
+	accountId, err := ec2.GetAccountId(ctx)
+	require.NoError(t, err)
+	assert.NotEmpty(t, accountId)
+
+	pk := &models.Pubkey{
+		Name: "containers-test",
+		Body: "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIEhnn80ZywmjeBFFOGm+cm+5HUwm62qTVnjKlOdYFLHN lzap",
+	}
+	_, err = ec2.ImportPubkey(ctx, pk, "containers")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ec2.DeleteSSHKey(context.Background(), pk.Name) })
+
+	regions, err := ec2.ListAllRegions(ctx)
+	require.NoError(t, err)
+	assert.NotEmpty(t, regions)
+}
@@ -0,0 +1,53 @@
+//go:build containers
+// +build containers
+
+package containers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/kafka"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/identity"
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/require"
+)
+
+func createTopic(t *testing.T, topic string) {
+	conn, err := kafkago.Dial("tcp", config.Kafka.Brokers[0])
+	require.NoError(t, err)
+	defer conn.Close()
+
+	err = conn.CreateTopics(kafkago.TopicConfig{Topic: topic, NumPartitions: 1, ReplicationFactor: 1})
+	require.NoError(t, err)
+}
+
+func TestKafkaSendAndConsume(t *testing.T) {
+	ctx := identity.WithIdentity(t, context.Background())
+	topic := "platform.provisioning.containers-test"
+	createTopic(t, topic)
+
+	received := make(chan *kafka.GenericMessage, 1)
+	cctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go kafka.Consume(cctx, topic, time.Now().Add(-time.Minute), func(_ context.Context, msg *kafka.GenericMessage) {
+		received <- msg
+	})
+
+	err := kafka.Send(ctx, &kafka.GenericMessage{
+		Topic: topic,
+		Key:   []byte("key"),
+		Value: []byte("value"),
+	})
+	require.NoError(t, err)
+
+	select {
+	case msg := <-received:
+		require.EqualValues(t, "key", msg.Key)
+		require.EqualValues(t, "value", msg.Value)
+	case <-time.After(30 * time.Second):
+		t.Fatal("message was not received")
+	}
+}
@@ -0,0 +1,55 @@
+//go:build containers
+// +build containers
+
+// Integration tests against real Postgres, Redis, Kafka and LocalStack started via
+// testcontainers. Unlike the other integration tests, no services need to be running, only
+// Docker or Podman.
+package containers
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	_ "github.com/RHEnVision/provisioning-backend/internal/dao/pgx"
+	"github.com/RHEnVision/provisioning-backend/internal/kafka"
+	_ "github.com/RHEnVision/provisioning-backend/internal/logging/testing"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/integration"
+)
+
+// truncate and seed database tables
+func reset() {
+	integration.DbSeed()
+}
+
+func TestMain(t *testing.M) {
+	ctx := context.Background()
+	ctx = integration.InitConfigEnvironment(ctx, "../../../config/test.env")
+	containers := integration.InitContainersEnvironment(ctx)
+	containers.StartLocalStack(ctx)
+
+	config.Application.Cache.Type = "redis"
+	config.Worker.Queue = "redis"
+	config.Worker.Timeout = 1 * time.Second
+	integration.InitDbEnvironment(ctx)
+	integration.InitRedisEnvironment(ctx)
+	integration.InitJobQueueEnvironment(ctx)
+	if err := kafka.InitializeKafkaBroker(ctx); err != nil {
+		containers.Terminate(ctx)
+		panic(err)
+	}
+
+	integration.DbDrop()
+	integration.DbMigrate()
+	reset()
+	exitVal := t.Run()
+
+	integration.DbDrop()
+	integration.CloseJobQueueEnvironment(ctx)
+	integration.CloseRedisEnvironment(ctx)
+	integration.CloseDbEnvironment(ctx)
+	containers.Terminate(ctx)
+	os.Exit(exitVal)
+}
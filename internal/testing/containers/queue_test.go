@@ -0,0 +1,93 @@
+//go:build containers
+// +build containers
+
+package containers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/dao"
+	"github.com/RHEnVision/provisioning-backend/internal/jobs"
+	"github.com/RHEnVision/provisioning-backend/internal/models"
+	"github.com/RHEnVision/provisioning-backend/internal/queue"
+	"github.com/RHEnVision/provisioning-backend/internal/testing/identity"
+	"github.com/RHEnVision/provisioning-backend/pkg/worker"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisQueueNoop(t *testing.T) {
+	ctx := identity.WithTenant(t, context.Background())
+	reservationDao := dao.GetReservationDao(ctx)
+	defer reset()
+
+	res := &models.NoopReservation{
+		Reservation: models.Reservation{
+			AccountID:  1,
+			Steps:      1,
+			StepTitles: []string{"Test step"},
+			Provider:   models.ProviderTypeNoop,
+			Status:     "Created",
+		},
+	}
+	err := reservationDao.CreateNoop(ctx, res)
+	require.NoError(t, err)
+
+	job := worker.Job{
+		AccountID: 1,
+		Type:      jobs.TypeNoop,
+		Args: jobs.NoopJobArgs{
+			ReservationID: res.ID,
+		},
+	}
+	err = queue.GetEnqueuer(ctx).Enqueue(context.Background(), &job)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		updated, getErr := reservationDao.GetById(ctx, res.ID)
+		return getErr == nil && updated.Success.Valid && updated.Success.Bool
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+func TestRedisSlotStoreResume(t *testing.T) {
+	ctx := context.Background()
+	wk, err := worker.NewRedisWorker(config.RedisHostAndPort(), config.Application.Cache.Redis.User,
+		config.Application.Cache.Redis.Password, config.Application.Cache.Redis.DB, "test-slots", time.Second, 1)
+	require.NoError(t, err)
+	store := wk.NewSlotStore(100 * time.Millisecond)
+
+	newJob := func() *worker.Job {
+		job := &worker.Job{ID: uuid.New(), Type: jobs.TypeNoop, Args: jobs.NoopJobArgs{}}
+		job.Identity.Identity.OrgID = "slots"
+		return job
+	}
+	first, second := newJob(), newJob()
+
+	acquired, err := store.Acquire(ctx, "slots", 1, first)
+	require.NoError(t, err)
+	require.True(t, acquired)
+	acquired, err = store.Acquire(ctx, "slots", 1, second)
+	require.NoError(t, err)
+	require.False(t, acquired)
+
+	resumed, err := store.Resume(ctx, 1)
+	require.NoError(t, err)
+	require.Empty(t, resumed, "slot is still taken")
+
+	// the first job is never released and its counter expires, the waiting job is kept
+	time.Sleep(200 * time.Millisecond)
+	resumed, err = store.Resume(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, resumed, 1)
+	require.Equal(t, second.ID, resumed[0].ID)
+
+	next, err := store.Release(ctx, "slots")
+	require.NoError(t, err)
+	require.Nil(t, next)
+	resumed, err = store.Resume(ctx, 1)
+	require.NoError(t, err)
+	require.Empty(t, resumed)
+}
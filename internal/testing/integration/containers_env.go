@@ -0,0 +1,165 @@
+//go:build containers
+// +build containers
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/docker/go-connections/nat"
+	"github.com/rs/zerolog"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	postgresImage   = "docker.io/library/postgres:15"
+	redisImage      = "docker.io/library/redis:7"
+	kafkaImage      = "docker.io/bitnami/kafka:3.5"
+	localstackImage = "docker.io/localstack/localstack:2.2"
+
+	containerStartupTimeout = 2 * time.Minute
+)
+
+// Containers holds all containers started for the integration tests.
+type Containers struct {
+	containers []testcontainers.Container
+}
+
+// InitContainersEnvironment starts Postgres, Redis and Kafka containers and points the application
+// configuration to them. It must be called after InitConfigEnvironment and before the rest of the
+// environment is initialized. Docker or Podman (with DOCKER_HOST set) is required.
+func InitContainersEnvironment(ctx context.Context) *Containers {
+	logger := zerolog.Ctx(ctx)
+	c := &Containers{}
+
+	pg := c.start(ctx, testcontainers.ContainerRequest{
+		Image:        postgresImage,
+		ExposedPorts: []string{"5432/tcp"},
+		Env: map[string]string{
+			"POSTGRES_DB":       config.Database.Name,
+			"POSTGRES_USER":     config.Database.User,
+			"POSTGRES_PASSWORD": "integration",
+		},
+		// the server restarts once after the initialization
+		WaitingFor: wait.ForLog("database system is ready to accept connections").WithOccurrence(2),
+	})
+	config.Database.Host, config.Database.Port = c.endpoint(ctx, pg, "5432/tcp")
+	config.Database.Password = "integration"
+
+	redis := c.start(ctx, testcontainers.ContainerRequest{
+		Image:        redisImage,
+		ExposedPorts: []string{"6379/tcp"},
+		WaitingFor:   wait.ForListeningPort("6379/tcp"),
+	})
+	host, port := c.endpoint(ctx, redis, "6379/tcp")
+	config.Application.Cache.Redis.Host = host
+	config.Application.Cache.Redis.Port = int(port)
+
+	// Kafka advertises its address to the clients, the host port must be known before the start
+	kafkaPort, err := freePort()
+	if err != nil {
+		c.Terminate(ctx)
+		panic(fmt.Errorf("cannot find free port for kafka: %w", err))
+	}
+	c.start(ctx, testcontainers.ContainerRequest{
+		Image:        kafkaImage,
+		ExposedPorts: []string{fmt.Sprintf("%d:9092/tcp", kafkaPort)},
+		Env: map[string]string{
+			"KAFKA_CFG_NODE_ID":                        "0",
+			"KAFKA_CFG_PROCESS_ROLES":                  "controller,broker",
+			"KAFKA_CFG_LISTENERS":                      "PLAINTEXT://:9092,CONTROLLER://:9093",
+			"KAFKA_CFG_ADVERTISED_LISTENERS":           fmt.Sprintf("PLAINTEXT://localhost:%d", kafkaPort),
+			"KAFKA_CFG_LISTENER_SECURITY_PROTOCOL_MAP": "CONTROLLER:PLAINTEXT,PLAINTEXT:PLAINTEXT",
+			"KAFKA_CFG_CONTROLLER_QUORUM_VOTERS":       "0@localhost:9093",
+			"KAFKA_CFG_CONTROLLER_LISTENER_NAMES":      "CONTROLLER",
+		},
+		WaitingFor: wait.ForLog("Kafka Server started"),
+	})
+	config.Kafka.Brokers = []string{fmt.Sprintf("localhost:%d", kafkaPort)}
+	config.Kafka.Enabled = true
+
+	logger.Info().Msgf("Integration containers started: postgres %s:%d, redis %s, kafka %v",
+		config.Database.Host, config.Database.Port, config.RedisHostAndPort(), config.Kafka.Brokers)
+	return c
+}
+
+// StartLocalStack starts LocalStack with EC2, STS and IAM services and points the AWS client
+// configuration to it. LocalStack accepts any static credentials and role ARNs.
+func (c *Containers) StartLocalStack(ctx context.Context) {
+	localstack := c.start(ctx, testcontainers.ContainerRequest{
+		Image:        localstackImage,
+		ExposedPorts: []string{"4566/tcp"},
+		Env: map[string]string{
+			"SERVICES": "ec2,sts,iam",
+		},
+		WaitingFor: wait.ForHTTP("/_localstack/health").WithPort("4566/tcp"),
+	})
+	host, port := c.endpoint(ctx, localstack, "4566/tcp")
+	config.AWS.Endpoint = fmt.Sprintf("http://%s:%d", host, port)
+	config.AWS.PathStyle = true
+	config.AWS.Key = "test"
+	config.AWS.Secret = "test"
+	config.AWS.Session = ""
+
+	zerolog.Ctx(ctx).Info().Msgf("LocalStack started: %s", config.AWS.Endpoint)
+}
+
+// Terminate stops and removes all containers, errors are ignored.
+func (c *Containers) Terminate(ctx context.Context) {
+	for _, container := range c.containers {
+		_ = container.Terminate(ctx)
+	}
+}
+
+func (c *Containers) start(ctx context.Context, req testcontainers.ContainerRequest) testcontainers.Container {
+	req.WaitingFor = wait.ForAll(req.WaitingFor).WithDeadline(containerStartupTimeout)
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	if err != nil {
+		c.Terminate(ctx)
+		panic(fmt.Errorf("cannot start container %s: %w", req.Image, err))
+	}
+	c.containers = append(c.containers, container)
+	return container
+}
+
+func (c *Containers) endpoint(ctx context.Context, container testcontainers.Container, port nat.Port) (string, uint16) {
+	host, err := container.Host(ctx)
+	if err != nil {
+		c.Terminate(ctx)
+		panic(fmt.Errorf("cannot get container host: %w", err))
+	}
+	mapped, err := container.MappedPort(ctx, port)
+	if err != nil {
+		c.Terminate(ctx)
+		panic(fmt.Errorf("cannot get container port %s: %w", port, err))
+	}
+	p, err := strconv.ParseUint(mapped.Port(), 10, 16)
+	if err != nil {
+		c.Terminate(ctx)
+		panic(fmt.Errorf("cannot parse container port %s: %w", mapped.Port(), err))
+	}
+	return host, uint16(p)
+}
+
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return 0, fmt.Errorf("cannot listen: %w", err)
+	}
+	defer l.Close()
+
+	addr, ok := l.Addr().(*net.TCPAddr)
+	if !ok {
+		return 0, fmt.Errorf("unexpected address type %T", l.Addr())
+	}
+	return addr.Port, nil
+}
@@ -0,0 +1,248 @@
+// Package vcr provides HTTP interaction recording and replay for client contract tests. In the
+// replay mode (default), responses are served from a cassette file and unknown requests fail the
+// test. In the record mode (VCR_MODE=record), requests are sent to the real service and all
+// interactions are written into the cassette when the test finishes.
+//
+// Only the request method, path, query and body together with the response status, content type
+// and body are stored. Request headers are never recorded, credentials cannot leak into cassettes.
+package vcr
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// ModeEnvironmentVariable selects the mode, "record" or "replay" (default).
+const ModeEnvironmentVariable = "VCR_MODE"
+
+// ReplayURL is the base URL clients are configured with in the replay mode.
+const ReplayURL = "http://vcr.replay"
+
+// ErrNoInteraction is returned in the replay mode for requests not found in the cassette
+var ErrNoInteraction = errors.New("no recorded interaction")
+
+type Mode int
+
+const (
+	ModeReplay Mode = iota
+	ModeRecord
+)
+
+// ModeFromEnv returns mode according to VCR_MODE environment variable.
+func ModeFromEnv() Mode {
+	if strings.EqualFold(os.Getenv(ModeEnvironmentVariable), "record") {
+		return ModeRecord
+	}
+	return ModeReplay
+}
+
+type Request struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+	Body   string `json:"body,omitempty"`
+}
+
+type Response struct {
+	StatusCode  int    `json:"status_code"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        string `json:"body"`
+}
+
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Cassette is a set of recorded interactions stored as a JSON file.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Recorder is an HTTP transport which records or replays interactions.
+type Recorder struct {
+	mode      Mode
+	path      string
+	baseURL   *url.URL
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     map[*Interaction]bool
+}
+
+// New creates a recorder for the cassette file. The record URL is the base URL of the real
+// service, it is only used in the record mode. Recorded cassette is saved on test cleanup.
+func New(t testing.TB, path, recordURL string) *Recorder {
+	t.Helper()
+
+	r := &Recorder{
+		mode:      ModeFromEnv(),
+		path:      path,
+		transport: http.DefaultTransport,
+		used:      make(map[*Interaction]bool),
+	}
+
+	base := ReplayURL
+	if r.mode == ModeRecord {
+		if recordURL == "" {
+			t.Skipf("record URL for cassette %s is not configured", path)
+		}
+		base = recordURL
+	} else {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("unable to read cassette %s: %s", path, err)
+		}
+		if err = json.Unmarshal(data, &r.cassette); err != nil {
+			t.Fatalf("unable to parse cassette %s: %s", path, err)
+		}
+	}
+
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		t.Fatalf("unable to parse base URL %s: %s", base, err)
+	}
+	r.baseURL = baseURL
+
+	t.Cleanup(func() {
+		if r.mode != ModeRecord {
+			return
+		}
+		if err := r.save(); err != nil {
+			t.Errorf("unable to save cassette %s: %s", path, err)
+		}
+	})
+	return r
+}
+
+// BaseURL returns the base URL the client must be configured with.
+func (r *Recorder) BaseURL() string {
+	return r.baseURL.String()
+}
+
+// Client returns an HTTP client using the recorder as the transport.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Mode returns the recorder mode.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := r.newRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == ModeRecord {
+		return r.record(req, recorded)
+	}
+	return r.replay(req, recorded)
+}
+
+func (r *Recorder) newRequest(req *http.Request) (Request, error) {
+	recorded := Request{
+		Method: req.Method,
+		Path:   strings.TrimPrefix(req.URL.Path, strings.TrimSuffix(r.baseURL.Path, "/")),
+		Query:  req.URL.Query().Encode(),
+	}
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return Request{}, fmt.Errorf("cannot read request body: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		recorded.Body = string(body)
+	}
+	return recorded, nil
+}
+
+func (r *Recorder) record(req *http.Request, recorded Request) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, fmt.Errorf("recorded request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{
+		Request: recorded,
+		Response: Response{
+			StatusCode:  resp.StatusCode,
+			ContentType: resp.Header.Get("Content-Type"),
+			Body:        string(body),
+		},
+	})
+	return resp, nil
+}
+
+// replay finds the first unused interaction matching the request. When all matching interactions
+// were already used, the last one is replayed again.
+func (r *Recorder) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var found *Interaction
+	for _, interaction := range r.cassette.Interactions {
+		if interaction.Request != recorded {
+			continue
+		}
+		found = interaction
+		if !r.used[interaction] {
+			break
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("%w: %s %s?%s in %s", ErrNoInteraction, recorded.Method, recorded.Path, recorded.Query, r.path)
+	}
+	r.used[found] = true
+
+	header := http.Header{}
+	if found.Response.ContentType != "" {
+		header.Set("Content-Type", found.Response.ContentType)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", found.Response.StatusCode, http.StatusText(found.Response.StatusCode)),
+		StatusCode:    found.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(found.Response.Body)),
+		ContentLength: int64(len(found.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+func (r *Recorder) save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.MarshalIndent(&r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cannot marshal cassette: %w", err)
+	}
+	if err = os.WriteFile(r.path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("cannot write cassette: %w", err)
+	}
+	return nil
+}
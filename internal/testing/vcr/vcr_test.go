@@ -0,0 +1,55 @@
+package vcr
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func get(t *testing.T, client *http.Client, url string) (int, string) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"path":"`+r.URL.Path+`"}`)
+	}))
+	defer ts.Close()
+
+	t.Run("record", func(t *testing.T) {
+		t.Setenv(ModeEnvironmentVariable, "record")
+		rec := New(t, path, ts.URL+"/api/v1")
+		code, body := get(t, rec.Client(), rec.BaseURL()+"/things?b=2&a=1")
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, `{"path":"/api/v1/things"}`, body)
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		rec := New(t, path, "")
+		require.Equal(t, ReplayURL, rec.BaseURL())
+
+		code, body := get(t, rec.Client(), rec.BaseURL()+"/things?a=1&b=2")
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, `{"path":"/api/v1/things"}`, body)
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, rec.BaseURL()+"/other", nil)
+		require.NoError(t, err)
+		_, err = rec.Client().Do(req) //nolint:bodyclose
+		require.ErrorIs(t, err, ErrNoInteraction)
+	})
+}
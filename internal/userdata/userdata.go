@@ -6,6 +6,9 @@ import (
 	"bytes"
 	_ "embed"
 	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
 	"text/template"
 
 	"github.com/RHEnVision/provisioning-backend/internal/models"
@@ -31,6 +34,32 @@ type UserData struct {
 
 	// InsightsTags renders a first-boot script which populates /etc/insights-client/tags.yaml
 	InsightsTags bool
+
+	// Custom user data provided by the user (cloud-config, shell script or any other format
+	// supported by cloud-init). It is merged with the generated cloud-config into a multipart
+	// document. Not used for GCP, the startup script is generated without it.
+	Custom string
+}
+
+// MaxCustomSize is the maximum size of custom user data in bytes. It leaves space for the generated
+// user data within the 16 KiB limit of AWS.
+const MaxCustomSize = 12 * 1024
+
+// customMergeType keeps keys of the generated cloud-config and appends items of lists (e.g. runcmd
+// or write_files) of custom cloud-config.
+const customMergeType = "list(append)+dict(no_replace,recurse_list)+str()"
+
+// customContentTypes are cloud-init part types detected by the first line of custom user data,
+// cloud-init detects the type of text/plain parts itself.
+var customContentTypes = []struct {
+	prefix      string
+	contentType string
+}{
+	{"#cloud-config", "text/cloud-config"},
+	{"#cloud-boothook", "text/cloud-boothook"},
+	{"#include", "text/x-include-url"},
+	{"## template: jinja", "text/jinja2"},
+	{"#!", "text/x-shellscript"},
 }
 
 func (ud UserData) IsAWS() bool {
@@ -85,5 +114,60 @@ func GenerateUserData(userData *UserData) ([]byte, error) {
 		return nil, fmt.Errorf("cannot generate user data: %w", err)
 	}
 
+	if userData.Custom == "" || userData.Type == models.ProviderTypeGCP {
+		return buffer.Bytes(), nil
+	}
+	return mergeCustom(buffer.Bytes(), userData.Custom)
+}
+
+// mergeCustom creates a multipart MIME document with the generated cloud-config first and custom
+// user data second, cloud-init processes both parts.
+func mergeCustom(generated []byte, custom string) ([]byte, error) {
+	var buffer bytes.Buffer
+	mw := multipart.NewWriter(&buffer)
+	fmt.Fprintf(&buffer, "Content-Type: multipart/mixed; boundary=\"%s\"\r\nMIME-Version: 1.0\r\n\r\n", mw.Boundary())
+
+	parts := []struct {
+		header textproto.MIMEHeader
+		body   []byte
+	}{
+		{
+			header: textproto.MIMEHeader{
+				"Content-Type":        {`text/cloud-config; charset="utf-8"`},
+				"Content-Disposition": {`attachment; filename="provisioning.cfg"`},
+			},
+			body: generated,
+		},
+		{
+			header: textproto.MIMEHeader{
+				"Content-Type":        {customContentType(custom) + `; charset="utf-8"`},
+				"Content-Disposition": {`attachment; filename="user-data"`},
+				"Merge-Type":          {customMergeType},
+			},
+			body: []byte(custom),
+		},
+	}
+	for _, part := range parts {
+		w, err := mw.CreatePart(part.header)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create user data part: %w", err)
+		}
+		if _, err = w.Write(part.body); err != nil {
+			return nil, fmt.Errorf("cannot write user data part: %w", err)
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, fmt.Errorf("cannot close user data: %w", err)
+	}
+
 	return buffer.Bytes(), nil
 }
+
+func customContentType(custom string) string {
+	for _, ct := range customContentTypes {
+		if strings.HasPrefix(custom, ct.prefix) {
+			return ct.contentType
+		}
+	}
+	return "text/plain"
+}
@@ -1,7 +1,12 @@
 package userdata
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
 	"regexp"
 	"strings"
 	"testing"
@@ -140,3 +145,57 @@ power_state:
 	assert.NoError(t, validateYAML(userData))
 	assert.Equal(t, expected, strings.Trim(trimRe.ReplaceAllString(string(userData), "\n"), "\n"))
 }
+
+func TestGenerateCustom(t *testing.T) {
+	custom := "#!/bin/sh\necho hello\n"
+	userDataInput := UserData{
+		Type:     models.ProviderTypeAWS,
+		PowerOff: true,
+		Custom:   custom,
+	}
+	userData, err := GenerateUserData(&userDataInput)
+	require.NoError(t, err)
+
+	msg, err := mail.ReadMessage(bytes.NewReader(userData))
+	require.NoError(t, err)
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/mixed", mediaType)
+
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	part, err := mr.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, `text/cloud-config; charset="utf-8"`, part.Header.Get("Content-Type"))
+	generated, err := io.ReadAll(part)
+	require.NoError(t, err)
+	assert.NoError(t, validateYAML(generated))
+	assert.Contains(t, string(generated), "power_state:")
+
+	part, err = mr.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, `text/x-shellscript; charset="utf-8"`, part.Header.Get("Content-Type"))
+	assert.Equal(t, customMergeType, part.Header.Get("Merge-Type"))
+	body, err := io.ReadAll(part)
+	require.NoError(t, err)
+	assert.Equal(t, custom, string(body))
+
+	_, err = mr.NextPart()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestGenerateCustomGCP(t *testing.T) {
+	userDataInput := UserData{
+		Type:   models.ProviderTypeGCP,
+		Custom: "#cloud-config\n",
+	}
+	userData, err := GenerateUserData(&userDataInput)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(userData), "#! /bin/bash"))
+}
+
+func TestCustomContentType(t *testing.T) {
+	assert.Equal(t, "text/cloud-config", customContentType("#cloud-config\npackages: [vim]"))
+	assert.Equal(t, "text/x-shellscript", customContentType("#!/bin/bash\n"))
+	assert.Equal(t, "text/x-include-url", customContentType("#include\nhttps://example.com/config"))
+	assert.Equal(t, "text/plain", customContentType("packages: [vim]"))
+}
@@ -6,7 +6,7 @@ update-clients: ## Update OpenAPI specs from upstream
 	curl -s -o ./config/sources_api.json -z ./config/sources_api.json https://raw.githubusercontent.com/RedHatInsights/sources-api-go/main/public/openapi-3-v3.1.json
 	curl -s -o ./config/rbac_api.json -z ./config/rbac_api.json https://raw.githubusercontent.com/RedHatInsights/insights-rbac/master/docs/source/specs/openapi.json
 
-generate-clients: internal/clients/http/image_builder/client.gen.go internal/clients/http/sources/client.gen.go internal/clients/http/rbac/client.gen.go ## Generate HTTP client stubs
+generate-clients: internal/clients/http/image_builder/client.gen.go internal/clients/http/sources/client.gen.go internal/clients/http/rbac/client.gen.go pkg/client/client.gen.go ## Generate HTTP client stubs
 
 internal/clients/http/sources/client.gen.go: config/sources_config.yml config/sources_api.json
 	$(OAPICODEGEN) -config ./config/sources_config.yml ./config/sources_api.json
@@ -17,6 +17,9 @@ internal/clients/http/image_builder/client.gen.go: config/ib_config.yaml config/
 internal/clients/http/rbac/client.gen.go: config/rbac_config.yml config/rbac_api.json
 	oapi-codegen -config ./config/rbac_config.yml ./config/rbac_api.json
 
+pkg/client/client.gen.go: config/client_config.yml api/openapi.gen.json
+	$(OAPICODEGEN) -config ./config/client_config.yml ./api/openapi.gen.json
+
 .PHONY: validate-clients
 validate-clients: generate-clients ## Compare generated client code with git
-	git diff --exit-code internal/clients/*/client.gen.go
+	git diff --exit-code internal/clients/*/client.gen.go pkg/client/client.gen.go
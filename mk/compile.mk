@@ -4,16 +4,19 @@ SRC_GO := $(shell find . -name \*.go -print)
 SRC_SQL := $(shell find . -name \*.sql -print)
 SRC_YAML := $(shell find . -name \*.yaml -print)
 
-build: pbackend ## Build all binaries
+build: pbackend pbctl ## Build all binaries
 
 all-deps: $(SRC_GO) $(SRC_SQL) $(SRC_YAML)
 
 pbackend: check-go all-deps ## Build backend
 	CGO_ENABLED=0 $(GO) build -o pbackend ./cmd/pbackend
 
+pbctl: check-go all-deps ## Build admin CLI
+	CGO_ENABLED=0 $(GO) build -o pbctl ./cmd/pbctl
+
 .PHONY: strip
 strip: build ## Strip debug information
-	strip pbackend
+	strip pbackend pbctl
 
 .PHONY: run-api
 run-api: check-go ## Run backend API using `go run`
@@ -13,3 +13,11 @@ integration-test: check-go ## Run integration tests (require database)
 	$(GO) test --count=1 -v -tags=integration ./internal/migrations/code
 	$(GO) test --count=1 -v -tags=integration ./internal/cache/tests
 	$(GO) test --count=1 -v -tags=integration ./internal/queue/tests
+
+.PHONY: containers-test
+containers-test: check-go ## Run integration tests against containers (require docker or podman)
+	$(GO) test --count=1 -v -tags=containers ./internal/testing/containers
+
+.PHONY: record-cassettes
+record-cassettes: check-go ## Record client contract test cassettes (require REST_ENDPOINTS_* stage URLs and credentials)
+	VCR_MODE=record $(GO) test --count=1 -v -run Contract ./internal/clients/http/sources ./internal/clients/http/image_builder
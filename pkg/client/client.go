@@ -0,0 +1,82 @@
+// Package client is a Go client for the provisioning API. Most of the code is generated from the
+// OpenAPI spec (client.gen.go), this file contains hand-written helpers on top of it.
+//
+// Example:
+//
+//	c, err := client.NewClientWithResponses("https://console.redhat.com/api/provisioning/v1",
+//		client.WithIdentity(xRhIdentity))
+//	...
+//	reservation, err := client.WaitForReservation(ctx, c, id)
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+var (
+	// ErrReservationFailed is returned when a reservation finished unsuccessfully
+	ErrReservationFailed = errors.New("reservation failed")
+
+	// ErrUnexpectedStatus is returned when the API responded with non-success HTTP status
+	ErrUnexpectedStatus = errors.New("unexpected HTTP status")
+)
+
+// DefaultPollWait is how long the server holds a reservation detail request during polling.
+const DefaultPollWait = 30 * time.Second
+
+// PollInterval is the minimum delay between two reservation detail requests during polling.
+var PollInterval = time.Second
+
+// WithIdentity sets the base64 encoded X-Rh-Identity header on all requests.
+func WithIdentity(identity string) ClientOption {
+	return WithRequestEditorFn(func(_ context.Context, req *http.Request) error {
+		req.Header.Set("X-Rh-Identity", identity)
+		return nil
+	})
+}
+
+// IsFinished returns true when the reservation is no longer processing.
+func (r *V1GenericReservationResponse) IsFinished() bool {
+	return r.FinishedAt != nil
+}
+
+// WaitForReservation polls a reservation until it is finished or the context is done. The server is
+// asked to hold each request via the wait parameter, so polling does not hammer the API. The finished
+// reservation is returned together with ErrReservationFailed when it was not successful.
+func WaitForReservation(ctx context.Context, c ClientWithResponsesInterface, id int64) (*V1GenericReservationResponse, error) {
+	wait := DefaultPollWait.String()
+	params := &GetReservationByIDParams{Wait: &wait}
+
+	for {
+		resp, err := c.GetReservationByIDWithResponse(ctx, id, params)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get reservation %d: %w", id, err)
+		}
+		if resp.JSON200 == nil {
+			return nil, fmt.Errorf("%w: reservation %d returned %s", ErrUnexpectedStatus, id, resp.Status())
+		}
+
+		reservation := resp.JSON200
+		if reservation.IsFinished() {
+			if reservation.Success == nil || !*reservation.Success {
+				var msg string
+				if reservation.Error != nil {
+					msg = *reservation.Error
+				}
+				return reservation, fmt.Errorf("%w: reservation %d: %s", ErrReservationFailed, id, msg)
+			}
+			return reservation, nil
+		}
+
+		// the server can return early or ignore the wait parameter, do not spin
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("wait for reservation %d: %w", id, ctx.Err())
+		case <-time.After(PollInterval):
+		}
+	}
+}
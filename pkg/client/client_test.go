@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *ClientWithResponses {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c, err := NewClientWithResponses(server.URL, WithIdentity("identity"))
+	require.NoError(t, err)
+	return c
+}
+
+func TestWaitForReservation(t *testing.T) {
+	PollInterval = time.Millisecond
+
+	t.Run("success", func(t *testing.T) {
+		var calls int32
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "identity", r.Header.Get("X-Rh-Identity"))
+			assert.Equal(t, "/reservations/42", r.URL.Path)
+			assert.Equal(t, "30s", r.URL.Query().Get("wait"))
+
+			w.Header().Set("Content-Type", "application/json")
+			if atomic.AddInt32(&calls, 1) < 3 {
+				fmt.Fprint(w, `{"id":42,"status":"Launching","finished_at":null,"success":null}`)
+				return
+			}
+			fmt.Fprint(w, `{"id":42,"status":"Finished","finished_at":"2023-01-01T00:00:00Z","success":true}`)
+		})
+
+		reservation, err := WaitForReservation(context.Background(), c, 42)
+		require.NoError(t, err)
+		assert.Equal(t, "Finished", *reservation.Status)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"id":42,"error":"boom","finished_at":"2023-01-01T00:00:00Z","success":false}`)
+		})
+
+		reservation, err := WaitForReservation(context.Background(), c, 42)
+		require.ErrorIs(t, err, ErrReservationFailed)
+		require.NotNil(t, reservation)
+		assert.Contains(t, err.Error(), "boom")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"msg":"not found"}`)
+		})
+
+		_, err := WaitForReservation(context.Background(), c, 42)
+		require.ErrorIs(t, err, ErrUnexpectedStatus)
+	})
+}
@@ -4,8 +4,9 @@ import (
 	"context"
 	"errors"
 
+	"github.com/RHEnVision/provisioning-backend/internal/config"
 	"github.com/RHEnVision/provisioning-backend/internal/identity"
-	"github.com/rs/zerolog"
+	"github.com/RHEnVision/provisioning-backend/internal/logging"
 
 	"github.com/google/uuid"
 )
@@ -73,16 +74,20 @@ type Stats struct {
 }
 
 func contextLogger(ctx context.Context, job *Job) context.Context {
-	accountId := job.AccountID
-	id := job.Identity
-	logger := zerolog.Ctx(ctx).With().
-		Str("job_id", job.ID.String()).
-		Int64("account_id", accountId).
-		Str("account_number", id.Identity.AccountNumber).
-		Str("org_id", id.Identity.OrgID).Logger()
-	newContext := logger.WithContext(ctx)
-	newContext = identity.WithIdentity(newContext, id)
-	newContext = identity.WithAccountId(newContext, accountId)
-
-	return newContext
+	newContext := identity.WithIdentity(ctx, job.Identity)
+	newContext = identity.WithAccountId(newContext, job.AccountID)
+	return logging.WithJobId(newContext, job.ID.String())
+}
+
+// Execute runs a job handler in the calling goroutine with the same context a worker would use.
+// It is used to replay jobs outside of the queue, a random job ID is generated when blank.
+func Execute(ctx context.Context, handler JobHandler, job *Job) {
+	if job.ID == uuid.Nil {
+		job.ID = uuid.New()
+	}
+
+	ctx = contextLogger(ctx, job)
+	cCtx, cFunc := context.WithTimeout(ctx, config.Worker.Timeout)
+	defer cFunc()
+	handler(cCtx, job)
 }
@@ -0,0 +1,264 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// SlotStore keeps track of in-flight jobs of organizations and jobs waiting for a free slot.
+type SlotStore interface {
+	// Acquire takes a slot of the organization for the job. When all slots are taken, the job is
+	// stored until a slot is released and false is returned. Both happen in one atomic step, so
+	// a slot released meanwhile is always inherited by a waiting job.
+	Acquire(ctx context.Context, orgID string, limit int, job *Job) (bool, error)
+
+	// Release returns the oldest waiting job of the organization which inherits the slot, or frees
+	// the slot and returns nil when there are no jobs waiting.
+	Release(ctx context.Context, orgID string) (*Job, error)
+
+	// Resume takes free slots of all organizations for their waiting jobs and returns the jobs.
+	// Slots are only free while jobs are waiting when slots of crashed or hung jobs have expired.
+	// Jobs which were taken are returned even together with an error.
+	Resume(ctx context.Context, limit int) ([]*Job, error)
+}
+
+// LimitedEnqueuer limits the amount of simultaneously running jobs of given types per organization.
+// Jobs over the limit are accepted but kept in a waiting state until a running job of the same
+// organization is released. Handlers of limited job types must be wrapped via WrapHandler.
+type LimitedEnqueuer struct {
+	enqueuer JobEnqueuer
+	store    SlotStore
+	limit    int
+	types    map[JobType]struct{}
+	onWait   func(context.Context, *Job)
+}
+
+var _ JobEnqueuer = &LimitedEnqueuer{}
+
+// NewLimitedEnqueuer wraps an enqueuer, limit of zero or less disables limiting. The onWait callback
+// is called for every job which needs to wait and can be nil.
+func NewLimitedEnqueuer(enqueuer JobEnqueuer, store SlotStore, limit int, onWait func(context.Context, *Job), types ...JobType) *LimitedEnqueuer {
+	typeMap := make(map[JobType]struct{}, len(types))
+	for _, t := range types {
+		typeMap[t] = struct{}{}
+	}
+
+	return &LimitedEnqueuer{
+		enqueuer: enqueuer,
+		store:    store,
+		limit:    limit,
+		types:    typeMap,
+		onWait:   onWait,
+	}
+}
+
+func (e *LimitedEnqueuer) limited(job *Job) bool {
+	if e.limit <= 0 {
+		return false
+	}
+	_, ok := e.types[job.Type]
+	return ok
+}
+
+func (e *LimitedEnqueuer) Enqueue(ctx context.Context, job *Job) error {
+	var err error
+
+	if !e.limited(job) {
+		return e.enqueuer.Enqueue(ctx, job)
+	}
+
+	if job.ID == uuid.Nil {
+		job.ID, err = uuid.NewRandom()
+		if err != nil {
+			return fmt.Errorf("unable to generate UUID: %w", err)
+		}
+	}
+
+	orgID := job.Identity.Identity.OrgID
+	logger := loggerWithJob(ctx, job)
+	acquired, err := e.store.Acquire(ctx, orgID, e.limit, job)
+	if err != nil {
+		return fmt.Errorf("unable to acquire job slot: %w", err)
+	}
+
+	if !acquired {
+		logger.Info().Msgf("Organization %s reached limit of %d running jobs, job is waiting", orgID, e.limit)
+		if e.onWait != nil {
+			e.onWait(ctx, job)
+		}
+		return nil
+	}
+
+	err = e.enqueuer.Enqueue(ctx, job)
+	if err != nil {
+		e.release(ctx, job)
+		return fmt.Errorf("unable to enqueue limited job: %w", err)
+	}
+	return nil
+}
+
+// release frees the slot of the job organization and enqueues the next waiting job. Errors are only
+// logged because the job itself has already finished.
+func (e *LimitedEnqueuer) release(ctx context.Context, job *Job) {
+	logger := loggerWithJob(ctx, job)
+	orgID := job.Identity.Identity.OrgID
+
+	next, err := e.store.Release(ctx, orgID)
+	if err != nil {
+		logger.Error().Err(err).Msgf("Unable to release job slot of organization %s", orgID)
+		return
+	}
+	if next == nil {
+		return
+	}
+
+	logger.Info().Msgf("Enqueuing waiting job %s of organization %s", next.ID, orgID)
+	err = e.enqueuer.Enqueue(ctx, next)
+	if err != nil {
+		logger.Error().Err(err).Msgf("Unable to enqueue waiting job %s", next.ID)
+		// give the slot back, otherwise it would be leaked
+		e.release(ctx, next)
+	}
+}
+
+// ResumeWaiting enqueues waiting jobs of organizations whose slots expired without being released,
+// e.g. when a worker was killed during a job. It must be called periodically.
+func (e *LimitedEnqueuer) ResumeWaiting(ctx context.Context) {
+	if e.limit <= 0 {
+		return
+	}
+	logger := zerolog.Ctx(ctx)
+
+	resumed, err := e.store.Resume(ctx, e.limit)
+	if err != nil {
+		logger.Error().Err(err).Msg("Unable to resume waiting jobs")
+	}
+
+	for _, job := range resumed {
+		orgID := job.Identity.Identity.OrgID
+		logger.Warn().Msgf("Enqueuing waiting job %s of organization %s after its slot expired", job.ID, orgID)
+		err = e.enqueuer.Enqueue(ctx, job)
+		if err != nil {
+			loggerWithJob(ctx, job).Error().Err(err).Msgf("Unable to enqueue waiting job %s", job.ID)
+			e.release(ctx, job)
+		}
+	}
+}
+
+// WrapHandler returns a handler which releases the job slot once the job is finished.
+func (e *LimitedEnqueuer) WrapHandler(handler JobHandler) JobHandler {
+	return func(ctx context.Context, job *Job) {
+		if e.limited(job) {
+			// the handler context can be already expired at this point
+			defer e.release(zerolog.Ctx(ctx).WithContext(context.Background()), job)
+		}
+		handler(ctx, job)
+	}
+}
+
+// MemorySlotStore is a SlotStore for the memory worker, it is only usable within a single process.
+// Like in RedisSlotStore, counters expire after given duration while waiting jobs never expire.
+type MemorySlotStore struct {
+	mu         sync.Mutex
+	expiration time.Duration
+	inFlight   map[string]int
+	expires    map[string]time.Time
+	waiting    map[string][]*Job
+}
+
+var _ SlotStore = &MemorySlotStore{}
+
+// NewMemorySlotStore creates a SlotStore, expiration of zero or less disables counter expiration.
+func NewMemorySlotStore(expiration time.Duration) *MemorySlotStore {
+	return &MemorySlotStore{
+		expiration: expiration,
+		inFlight:   make(map[string]int),
+		expires:    make(map[string]time.Time),
+		waiting:    make(map[string][]*Job),
+	}
+}
+
+// count returns the in-flight counter, expired counters are deleted. Must be called with the lock.
+func (s *MemorySlotStore) count(orgID string) int {
+	if expires, ok := s.expires[orgID]; ok && time.Now().After(expires) {
+		delete(s.inFlight, orgID)
+		delete(s.expires, orgID)
+	}
+	return s.inFlight[orgID]
+}
+
+// setCount sets the in-flight counter and refreshes its expiration. Must be called with the lock.
+func (s *MemorySlotStore) setCount(orgID string, count int) {
+	if count <= 0 {
+		delete(s.inFlight, orgID)
+		delete(s.expires, orgID)
+		return
+	}
+	s.inFlight[orgID] = count
+	if s.expiration > 0 {
+		s.expires[orgID] = time.Now().Add(s.expiration)
+	}
+}
+
+func (s *MemorySlotStore) Acquire(_ context.Context, orgID string, limit int, job *Job) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	count := s.count(orgID)
+	if count >= limit || len(s.waiting[orgID]) > 0 {
+		s.waiting[orgID] = append(s.waiting[orgID], job)
+		return false, nil
+	}
+	s.setCount(orgID, count+1)
+	return true, nil
+}
+
+func (s *MemorySlotStore) Release(_ context.Context, orgID string) (*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	count := s.count(orgID)
+	if len(s.waiting[orgID]) > 0 {
+		// the counter can be expired when the releasing job was hung
+		if count == 0 {
+			count = 1
+		}
+		s.setCount(orgID, count)
+		return s.popWaiting(orgID), nil
+	}
+
+	s.setCount(orgID, count-1)
+	return nil, nil
+}
+
+func (s *MemorySlotStore) Resume(_ context.Context, limit int) ([]*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result []*Job
+	for orgID := range s.waiting {
+		count := s.count(orgID)
+		for count < limit && len(s.waiting[orgID]) > 0 {
+			result = append(result, s.popWaiting(orgID))
+			count++
+			s.setCount(orgID, count)
+		}
+	}
+	return result, nil
+}
+
+// popWaiting removes the oldest waiting job of the organization. Must be called with the lock.
+func (s *MemorySlotStore) popWaiting(orgID string) *Job {
+	jobs := s.waiting[orgID]
+	if len(jobs) == 1 {
+		delete(s.waiting, orgID)
+	} else {
+		s.waiting[orgID] = jobs[1:]
+	}
+	return jobs[0]
+}
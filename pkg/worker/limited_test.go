@@ -0,0 +1,213 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/RHEnVision/provisioning-backend/internal/identity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type collectingEnqueuer struct {
+	enqueued []*Job
+}
+
+func (c *collectingEnqueuer) Enqueue(_ context.Context, job *Job) error {
+	c.enqueued = append(c.enqueued, job)
+	return nil
+}
+
+func newOrgJob(jobType JobType, orgID string) *Job {
+	id := identity.Principal{}
+	id.Identity.OrgID = orgID
+	return &Job{
+		Type:     jobType,
+		Identity: id,
+	}
+}
+
+func TestLimitedEnqueuerWaitsOverLimit(t *testing.T) {
+	ctx := context.Background()
+	base := &collectingEnqueuer{}
+	var waiting []*Job
+	e := NewLimitedEnqueuer(base, NewMemorySlotStore(0), 1, func(_ context.Context, job *Job) {
+		waiting = append(waiting, job)
+	}, "launch")
+
+	first := newOrgJob("launch", "1")
+	second := newOrgJob("launch", "1")
+	other := newOrgJob("launch", "2")
+	require.NoError(t, e.Enqueue(ctx, first))
+	require.NoError(t, e.Enqueue(ctx, second))
+	require.NoError(t, e.Enqueue(ctx, other))
+
+	assert.Equal(t, []*Job{first, other}, base.enqueued)
+	assert.Equal(t, []*Job{second}, waiting)
+
+	// finishing the first job enqueues the waiting one
+	e.WrapHandler(func(_ context.Context, _ *Job) {})(ctx, first)
+	assert.Equal(t, []*Job{first, other, second}, base.enqueued)
+}
+
+func TestLimitedEnqueuerIgnoresOtherTypes(t *testing.T) {
+	ctx := context.Background()
+	base := &collectingEnqueuer{}
+	e := NewLimitedEnqueuer(base, NewMemorySlotStore(0), 1, nil, "launch")
+
+	require.NoError(t, e.Enqueue(ctx, newOrgJob("noop", "1")))
+	require.NoError(t, e.Enqueue(ctx, newOrgJob("noop", "1")))
+	assert.Len(t, base.enqueued, 2)
+}
+
+func TestLimitedEnqueuerUnlimited(t *testing.T) {
+	ctx := context.Background()
+	base := &collectingEnqueuer{}
+	e := NewLimitedEnqueuer(base, NewMemorySlotStore(0), 0, nil, "launch")
+
+	require.NoError(t, e.Enqueue(ctx, newOrgJob("launch", "1")))
+	require.NoError(t, e.Enqueue(ctx, newOrgJob("launch", "1")))
+	assert.Len(t, base.enqueued, 2)
+}
+
+func TestMemorySlotStoreRelease(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemorySlotStore(0)
+
+	first := newOrgJob("launch", "1")
+	second := newOrgJob("launch", "1")
+
+	ok, err := s.Acquire(ctx, "1", 1, first)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = s.Acquire(ctx, "1", 1, second)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// the waiting job inherits the slot
+	next, err := s.Release(ctx, "1")
+	require.NoError(t, err)
+	require.Equal(t, second, next)
+
+	next, err = s.Release(ctx, "1")
+	require.NoError(t, err)
+	require.Nil(t, next)
+
+	ok, err = s.Acquire(ctx, "1", 1, first)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestLimitedEnqueuerResumesAfterExpiration(t *testing.T) {
+	ctx := context.Background()
+	base := &collectingEnqueuer{}
+	store := NewMemorySlotStore(10 * time.Millisecond)
+	e := NewLimitedEnqueuer(base, store, 1, nil, "launch")
+
+	// the first job is never released, e.g. its worker was killed
+	first := newOrgJob("launch", "1")
+	second := newOrgJob("launch", "1")
+	third := newOrgJob("launch", "1")
+	require.NoError(t, e.Enqueue(ctx, first))
+	require.NoError(t, e.Enqueue(ctx, second))
+
+	e.ResumeWaiting(ctx)
+	require.Equal(t, []*Job{first}, base.enqueued, "slot is still taken")
+
+	time.Sleep(20 * time.Millisecond)
+
+	// waiting jobs are not overtaken after the counter expired
+	require.NoError(t, e.Enqueue(ctx, third))
+	require.Equal(t, []*Job{first}, base.enqueued)
+
+	e.ResumeWaiting(ctx)
+	require.Equal(t, []*Job{first, second}, base.enqueued)
+
+	// the resumed job holds the slot and hands it over when finished
+	e.WrapHandler(func(_ context.Context, _ *Job) {})(ctx, second)
+	require.Equal(t, []*Job{first, second, third}, base.enqueued)
+	e.WrapHandler(func(_ context.Context, _ *Job) {})(ctx, third)
+
+	jobs, err := store.Resume(ctx, 1)
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+}
+
+type channelEnqueuer struct {
+	jobs chan *Job
+}
+
+func (c *channelEnqueuer) Enqueue(_ context.Context, job *Job) error {
+	c.jobs <- job
+	return nil
+}
+
+func TestLimitedEnqueuerConcurrent(t *testing.T) {
+	const count = 100
+	ctx := context.Background()
+	base := &channelEnqueuer{jobs: make(chan *Job, count)}
+	e := NewLimitedEnqueuer(base, NewMemorySlotStore(0), 1, nil, "launch")
+
+	var running, maxRunning int32
+	var processed sync.WaitGroup
+	processed.Add(count)
+	handler := e.WrapHandler(func(_ context.Context, _ *Job) {
+		current := atomic.AddInt32(&running, 1)
+		for {
+			seen := atomic.LoadInt32(&maxRunning)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxRunning, seen, current) {
+				break
+			}
+		}
+		atomic.AddInt32(&running, -1)
+		processed.Done()
+	})
+
+	// workers release slots while jobs are still being enqueued
+	for i := 0; i < 4; i++ {
+		go func() {
+			for job := range base.jobs {
+				handler(ctx, job)
+			}
+		}()
+	}
+	for i := 0; i < count; i++ {
+		go func() {
+			assert.NoError(t, e.Enqueue(ctx, newOrgJob("launch", "1")))
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		processed.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("waiting jobs were not processed")
+	}
+	close(base.jobs)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&maxRunning))
+}
+
+func TestExecuteSetsJobContext(t *testing.T) {
+	job := newOrgJob("test", "org")
+	job.AccountID = 13
+
+	var accountId int64
+	var orgId string
+	Execute(context.Background(), func(ctx context.Context, job *Job) {
+		accountId = identity.AccountId(ctx)
+		orgId = identity.Identity(ctx).Identity.OrgID
+	}, job)
+
+	require.NotEqual(t, uuid.Nil, job.ID)
+	assert.Equal(t, int64(13), accountId)
+	assert.Equal(t, "org", orgId)
+}
@@ -9,6 +9,9 @@ import (
 	"github.com/rs/zerolog"
 )
 
+// memoryQueueSize allows handlers to enqueue jobs from the dequeue goroutine
+const memoryQueueSize = 128
+
 type MemoryWorker struct {
 	handlers map[JobType]JobHandler
 	todo     chan *Job
@@ -17,7 +20,7 @@ type MemoryWorker struct {
 func NewMemoryClient() *MemoryWorker {
 	return &MemoryWorker{
 		handlers: make(map[JobType]JobHandler),
-		todo:     make(chan *Job),
+		todo:     make(chan *Job, memoryQueueSize),
 	}
 }
 
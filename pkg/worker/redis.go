@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/RHEnVision/provisioning-backend/internal/config"
+	"github.com/RHEnVision/provisioning-backend/internal/logging"
 	"github.com/RHEnVision/provisioning-backend/internal/metrics"
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
@@ -70,8 +71,7 @@ func (w *RedisWorker) RegisterHandler(jtype JobType, handler JobHandler, args an
 }
 
 func loggerWithJob(ctx context.Context, job *Job) *zerolog.Logger {
-	logger := zerolog.Ctx(ctx).With().
-		Str("job_id", job.ID.String()).
+	logger := zerolog.Ctx(logging.WithJobId(ctx, job.ID.String())).With().
 		Str("job_type", string(job.Type)).
 		Interface("job_args", job.Args).Logger()
 	return &logger
@@ -227,3 +227,159 @@ func (w *RedisWorker) Stats(ctx context.Context) (Stats, error) {
 		InFlight:     atomic.LoadInt64(&w.inFlight),
 	}, nil
 }
+
+// RedisSlotStore is a SlotStore shared by all processes connected to the same Redis. Counters
+// expire after given duration in case a worker is killed before it releases its slot, waiting lists
+// never expire and their jobs are taken by Resume once the counter is gone.
+type RedisSlotStore struct {
+	client     *redis.Client
+	prefix     string
+	expiration time.Duration
+}
+
+var _ SlotStore = &RedisSlotStore{}
+
+// NewSlotStore creates a SlotStore using the worker Redis connection.
+func (w *RedisWorker) NewSlotStore(expiration time.Duration) *RedisSlotStore {
+	return &RedisSlotStore{
+		client:     w.client,
+		prefix:     w.queueName,
+		expiration: expiration,
+	}
+}
+
+func (s *RedisSlotStore) inFlightKey(orgID string) string {
+	return s.prefix + "-inflight-" + orgID
+}
+
+func (s *RedisSlotStore) waitingKey(orgID string) string {
+	return s.prefix + "-waiting-" + orgID
+}
+
+// waitingOrgsKey is a set of organizations with non-empty waiting lists
+func (s *RedisSlotStore) waitingOrgsKey() string {
+	return s.prefix + "-waiting-orgs"
+}
+
+// acquireScript increases the in-flight counter (KEYS[1]) below the limit (ARGV[1]) which expires
+// after ARGV[2] milliseconds, or pushes the job (ARGV[3]) into the waiting list (KEYS[2]) and adds
+// the organization (ARGV[4]) into the set (KEYS[3]). Jobs already waiting are not overtaken.
+var acquireScript = redis.NewScript(`
+local count = tonumber(redis.call('GET', KEYS[1]) or '0')
+if count < tonumber(ARGV[1]) and redis.call('LLEN', KEYS[2]) == 0 then
+	redis.call('SET', KEYS[1], count + 1, 'PX', ARGV[2])
+	return 1
+end
+redis.call('RPUSH', KEYS[2], ARGV[3])
+redis.call('SADD', KEYS[3], ARGV[4])
+return 0
+`)
+
+// releaseScript pops the oldest waiting job (KEYS[2]) which inherits the slot and refreshes the
+// counter (KEYS[1]) expiration (ARGV[1] milliseconds), or decreases the counter and returns nil.
+// The organization (ARGV[2]) is removed from the set (KEYS[3]) once its waiting list is empty.
+var releaseScript = redis.NewScript(`
+local job = redis.call('LPOP', KEYS[2])
+if redis.call('LLEN', KEYS[2]) == 0 then
+	redis.call('SREM', KEYS[3], ARGV[2])
+end
+if job then
+	if redis.call('PEXPIRE', KEYS[1], ARGV[1]) == 0 then
+		redis.call('SET', KEYS[1], 1, 'PX', ARGV[1])
+	end
+	return job
+end
+if redis.call('DECR', KEYS[1]) <= 0 then
+	redis.call('DEL', KEYS[1])
+end
+return false
+`)
+
+// resumeScript pops waiting jobs (KEYS[2]) while the in-flight counter (KEYS[1]) is below the limit
+// (ARGV[1]) and returns them, the counter expires after ARGV[2] milliseconds. The organization
+// (ARGV[3]) is removed from the set (KEYS[3]) once its waiting list is empty.
+var resumeScript = redis.NewScript(`
+local count = tonumber(redis.call('GET', KEYS[1]) or '0')
+local jobs = {}
+while count < tonumber(ARGV[1]) do
+	local job = redis.call('LPOP', KEYS[2])
+	if not job then
+		break
+	end
+	count = count + 1
+	table.insert(jobs, job)
+end
+if #jobs > 0 then
+	redis.call('SET', KEYS[1], count, 'PX', ARGV[2])
+end
+if redis.call('LLEN', KEYS[2]) == 0 then
+	redis.call('SREM', KEYS[3], ARGV[3])
+end
+return jobs
+`)
+
+func (s *RedisSlotStore) Acquire(ctx context.Context, orgID string, limit int, job *Job) (bool, error) {
+	var buffer bytes.Buffer
+	enc := gob.NewEncoder(&buffer)
+	err := enc.Encode(&job)
+	if err != nil {
+		return false, fmt.Errorf("unable to encode args: %w", err)
+	}
+
+	keys := []string{s.inFlightKey(orgID), s.waitingKey(orgID), s.waitingOrgsKey()}
+	acquired, err := acquireScript.Run(ctx, s.client, keys, limit, s.expiration.Milliseconds(), buffer.Bytes(), orgID).Int()
+	if err != nil {
+		return false, fmt.Errorf("unable to acquire job slot in Redis: %w", err)
+	}
+	return acquired == 1, nil
+}
+
+func (s *RedisSlotStore) Release(ctx context.Context, orgID string) (*Job, error) {
+	keys := []string{s.inFlightKey(orgID), s.waitingKey(orgID), s.waitingOrgsKey()}
+	res, err := releaseScript.Run(ctx, s.client, keys, s.expiration.Milliseconds(), orgID).Text()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to release job slot in Redis: %w", err)
+	}
+
+	return decodeWaitingJob(res)
+}
+
+func (s *RedisSlotStore) Resume(ctx context.Context, limit int) ([]*Job, error) {
+	orgIDs, err := s.client.SMembers(ctx, s.waitingOrgsKey()).Result()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list organizations with waiting jobs: %w", err)
+	}
+
+	var result []*Job
+	var decodeErr error
+	for _, orgID := range orgIDs {
+		keys := []string{s.inFlightKey(orgID), s.waitingKey(orgID), s.waitingOrgsKey()}
+		payloads, err := resumeScript.Run(ctx, s.client, keys, limit, s.expiration.Milliseconds(), orgID).StringSlice()
+		if err != nil {
+			return result, fmt.Errorf("unable to resume waiting jobs in Redis: %w", err)
+		}
+
+		// jobs are already popped, keep decoding the rest so they are not lost
+		for _, payload := range payloads {
+			job, err := decodeWaitingJob(payload)
+			if err != nil {
+				decodeErr = err
+				continue
+			}
+			result = append(result, job)
+		}
+	}
+	return result, decodeErr
+}
+
+func decodeWaitingJob(payload string) (*Job, error) {
+	var job Job
+	dec := gob.NewDecoder(strings.NewReader(payload))
+	err := dec.Decode(&job)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal waiting job payload: %w", err)
+	}
+	return &job, nil
+}
@@ -11,6 +11,9 @@ echo "Creating topics..."
 $BASEDIR/kafka/bin/kafka-topics.sh --create --topic platform.provisioning.internal.availability-check --bootstrap-server $KAFKA_HOST &
 $BASEDIR/kafka/bin/kafka-topics.sh --create --topic platform.sources.status --bootstrap-server $KAFKA_HOST &
 $BASEDIR/kafka/bin/kafka-topics.sh --create --topic platform.notifications.ingress --bootstrap-server $KAFKA_HOST &
+$BASEDIR/kafka/bin/kafka-topics.sh --create --topic platform.provisioning.usage --bootstrap-server $KAFKA_HOST &
+$BASEDIR/kafka/bin/kafka-topics.sh --create --topic platform.tenants.lifecycle --bootstrap-server $KAFKA_HOST &
+$BASEDIR/kafka/bin/kafka-topics.sh --create --topic platform.provisioning.purge-report --bootstrap-server $KAFKA_HOST &
 
 echo "Starting Kafka..."
 $BASEDIR/kafka/bin/kafka-server-start.sh $BASEDIR/kafka/config/kraft/server.properties